@@ -0,0 +1,62 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+)
+
+// envReader reads typed settings from the environment. A malformed or out of
+// range value is recorded rather than replaced by its default, so startup can
+// report every bad setting at once instead of running with a value nobody set.
+type envReader struct {
+	errs []error
+}
+
+// positive reads an integer greater than zero
+func (r *envReader) positive(key string, defaultValue int64) int64 {
+	return r.int64(key, defaultValue, 1)
+}
+
+// nonNegative reads an integer of zero or more; zero usually means "off" or
+// "no limit"
+func (r *envReader) nonNegative(key string, defaultValue int64) int64 {
+	return r.int64(key, defaultValue, 0)
+}
+
+func (r *envReader) int64(key string, defaultValue, min int64) int64 {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	result, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		r.errs = append(r.errs, fmt.Errorf("%s: %q is not an integer", key, value))
+		return defaultValue
+	}
+	if result < min {
+		r.errs = append(r.errs, fmt.Errorf("%s: %d is below the minimum of %d", key, result, min))
+		return defaultValue
+	}
+	return result
+}
+
+// bool reads a boolean (true, false, 1, 0, t, f in any case)
+func (r *envReader) bool(key string, defaultValue bool) bool {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	result, err := strconv.ParseBool(value)
+	if err != nil {
+		r.errs = append(r.errs, fmt.Errorf("%s: %q is not a boolean", key, value))
+		return defaultValue
+	}
+	return result
+}
+
+// err returns the invalid settings read so far, nil if there are none
+func (r *envReader) err() error {
+	return errors.Join(r.errs...)
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/awsv4"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/emailverify"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/objectstore"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/secrets"
+)
+
+// newObjectStore selects where compliance documents and generated reports
+// are kept (OBJECT_STORE):
+//
+//	unset           none; scheduled reports, data exports and
+//	                accreditation uploads are disabled
+//	memory          in process, lost on restart (development only)
+//	s3              bucket OBJECT_STORE_BUCKET on S3 or an S3-compatible
+//	                service at OBJECT_STORE_ENDPOINT (OBJECT_STORE_PATH_STYLE
+//	                for MinIO), with AWS_REGION, AWS_ACCESS_KEY_ID,
+//	                AWS_SECRET_ACCESS_KEY and AWS_SESSION_TOKEN. Objects are
+//	                encrypted with OBJECT_STORE_SSE (AES256, aws:kms with
+//	                OBJECT_STORE_KMS_KEY_ID, or none).
+//
+// An S3 bucket gets default encryption and the retention lifecycle applied
+// at startup; failures are logged, as the credentials may only be allowed
+// to read and write objects.
+func newObjectStore(ctx context.Context, logger *zap.Logger) (objectstore.Store, error) {
+	switch kind := os.Getenv("OBJECT_STORE"); kind {
+	case "":
+		return nil, nil
+	case "memory":
+		return objectstore.NewMemory(), nil
+	case "s3":
+		env := &envReader{}
+		store := &objectstore.S3{
+			Client: &awsv4.Client{
+				Service:  "s3",
+				Region:   getEnv("AWS_REGION", os.Getenv("AWS_DEFAULT_REGION")),
+				Endpoint: os.Getenv("OBJECT_STORE_ENDPOINT"),
+				Credentials: awsv4.Credentials{
+					AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+					SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+					SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+				},
+			},
+			Bucket:    os.Getenv("OBJECT_STORE_BUCKET"),
+			PathStyle: env.bool("OBJECT_STORE_PATH_STYLE", false),
+			SSE:       os.Getenv("OBJECT_STORE_SSE"),
+			KMSKeyID:  os.Getenv("OBJECT_STORE_KMS_KEY_ID"),
+		}
+		if err := env.err(); err != nil {
+			return nil, err
+		}
+		if err := store.Validate(); err != nil {
+			return nil, err
+		}
+		if err := store.ApplyEncryption(ctx); err != nil {
+			logger.Warn("bucket default encryption not applied", zap.String("bucket", store.Bucket), zap.Error(err))
+		}
+		if err := store.ApplyLifecycle(ctx, objectstore.DefaultLifecycle); err != nil {
+			logger.Warn("bucket lifecycle not applied", zap.String("bucket", store.Bucket), zap.Error(err))
+		}
+		return store, nil
+	default:
+		return nil, fmt.Errorf("unknown OBJECT_STORE %q (memory or s3)", kind)
+	}
+}
+
+// newMailer sends mail through SMTP_ADDR as SMTP_FROM, authenticating with
+// SMTP_USERNAME and the SMTP_PASSWORD secret (read at startup). It is nil
+// when SMTP_ADDR is unset.
+func newMailer(secretStore *secrets.Store) *emailverify.SMTPMailer {
+	addr := os.Getenv("SMTP_ADDR")
+	if addr == "" {
+		return nil
+	}
+	return &emailverify.SMTPMailer{
+		Addr:     addr,
+		From:     getEnv("SMTP_FROM", "reports@nexus.local"),
+		Username: os.Getenv("SMTP_USERNAME"),
+		Password: secretStore.Get("SMTP_PASSWORD"),
+	}
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/awsv4"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/secrets"
+)
+
+// newSecretsProvider selects where secrets are read from (SECRETS_PROVIDER):
+//
+//	env (default)   environment variables
+//	file            files named after each secret in SECRETS_DIR (default /run/secrets)
+//	vault           the KV v2 secret SECRETS_VAULT_PATH in mount SECRETS_VAULT_MOUNT
+//	                (default "secret"), with VAULT_ADDR and VAULT_TOKEN
+//	ssm             SSM parameters SECRETS_SSM_PREFIX + name, with AWS_REGION,
+//	                AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY, AWS_SESSION_TOKEN
+//	                and AWS_SSM_ENDPOINT
+//
+// Secrets a provider does not hold fall back to the environment.
+func newSecretsProvider() (secrets.Provider, error) {
+	var provider secrets.Provider
+	switch kind := getEnv("SECRETS_PROVIDER", "env"); kind {
+	case "env":
+		return secrets.Env{}, nil
+	case "file":
+		provider = secrets.Files{Dir: getEnv("SECRETS_DIR", "/run/secrets")}
+	case "vault":
+		provider = secrets.Vault{
+			Address: os.Getenv("VAULT_ADDR"),
+			Token:   os.Getenv("VAULT_TOKEN"),
+			Mount:   getEnv("SECRETS_VAULT_MOUNT", "secret"),
+			Path:    os.Getenv("SECRETS_VAULT_PATH"),
+		}
+	case "ssm":
+		provider = secrets.SSM{
+			Client: &awsv4.Client{
+				Service:  "ssm",
+				Region:   getEnv("AWS_REGION", os.Getenv("AWS_DEFAULT_REGION")),
+				Endpoint: os.Getenv("AWS_SSM_ENDPOINT"),
+				Credentials: awsv4.Credentials{
+					AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+					SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+					SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+				},
+			},
+			Prefix: os.Getenv("SECRETS_SSM_PREFIX"),
+		}
+	default:
+		return nil, fmt.Errorf("unknown SECRETS_PROVIDER %q (env, file, vault or ssm)", kind)
+	}
+	return secrets.WithFallback(provider, secrets.Env{}), nil
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/fixtures"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/loadgen"
+)
+
+const seedUsage = `usage: %[1]s seed load [flags]
+       %[1]s seed fixtures [-env name] [-o file]
+
+seed load generates payments, meta-transactions and KYC verifications in the
+database selected by STORAGE_BACKEND / DATABASE_URL / SQLITE_PATH, for
+profiling list endpoints and indexes. Use a scratch database: generated rows
+are not marked and are not cleaned up.
+
+seed fixtures writes a fixture set for the in-memory handlers (KYC
+registrations, NFTs, proposals, token balances) as JSON. The server loads a
+set named by SEED_FIXTURES (dev, demo or test) or a file written here and
+edited; without SEED_FIXTURES it starts empty.
+
+flags:
+`
+
+// runSeed runs the seed subcommand and returns the process exit code
+func runSeed(args []string) int {
+	if len(args) > 0 {
+		switch args[0] {
+		case "load":
+			return runSeedLoad(args)
+		case "fixtures":
+			return runSeedFixtures(args)
+		}
+	}
+	fmt.Fprintf(os.Stderr, seedUsage, os.Args[0])
+	return 2
+}
+
+// runSeedFixtures writes a built-in fixture set as JSON
+func runSeedFixtures(args []string) int {
+	fs := flag.NewFlagSet("seed fixtures", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), seedUsage, os.Args[0])
+		fs.PrintDefaults()
+	}
+	env := fs.String("env", fixtures.Dev, "fixture set: "+strings.Join(fixtures.Environments, ", "))
+	out := fs.String("o", "", "output file (default stdout)")
+	if err := fs.Parse(args[1:]); err != nil {
+		return 2
+	}
+
+	set, err := fixtures.For(*env, time.Now().UTC())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+	data, err := json.MarshalIndent(set, "", "  ")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	data = append(data, '\n')
+
+	if *out == "" {
+		os.Stdout.Write(data)
+		return 0
+	}
+	if err := os.WriteFile(*out, data, 0o644); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	return 0
+}
+
+// runSeedLoad generates load data in the configured database
+func runSeedLoad(args []string) int {
+	fs := flag.NewFlagSet("seed load", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), seedUsage, os.Args[0])
+		fs.PrintDefaults()
+	}
+	var cfg loadgen.Config
+	fs.IntVar(&cfg.Payments, "payments", 0, "payments to generate")
+	fs.IntVar(&cfg.KYC, "kyc", 0, "KYC verifications to generate (each with its KYC payment)")
+	fs.IntVar(&cfg.MetaTxs, "metatx", 0, "meta-transactions to generate")
+	fs.IntVar(&cfg.Payers, "payers", 0, "distinct payer addresses (default one per 20 payments and meta-transactions)")
+	fs.IntVar(&cfg.Workers, "workers", loadgen.DefaultWorkers, "concurrent writers")
+	fs.IntVar(&cfg.BatchSize, "batch", loadgen.DefaultBatchSize, "meta-transactions per insert")
+	fs.Uint64Var(&cfg.Seed, "seed", 0, "random seed for reproducible data (default time-based)")
+	if err := fs.Parse(args[1:]); err != nil {
+		return 2
+	}
+	if cfg.Payments+cfg.KYC+cfg.MetaTxs == 0 {
+		fmt.Fprintln(os.Stderr, "nothing to generate: set -payments, -kyc or -metatx")
+		return 2
+	}
+
+	appCfg, err := loadConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invalid configuration:\n%v\n", err)
+		return 2
+	}
+	logger, _ := initLogger(appCfg.LogLevel)
+	defer logger.Sync()
+
+	if appCfg.StorageBackend == storageMemory {
+		logger.Error("seed load needs a persistent STORAGE_BACKEND (postgres or sqlite)")
+		return 1
+	}
+	repos, err := openRepositories(appCfg, logger)
+	if err != nil {
+		logger.Error("failed to initialize storage", zap.Error(err))
+		return 1
+	}
+	defer repos.close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	generator := loadgen.NewGenerator(repos.payment, repos.pricing, repos.relayer, logger)
+	result, err := generator.Run(ctx, cfg)
+	if result != nil {
+		logger.Info("load data generated",
+			zap.Int64("payments", result.Payments),
+			zap.Int64("kyc", result.KYC),
+			zap.Int64("meta_txs", result.MetaTxs),
+			zap.Uint64("seed", result.Seed),
+			zap.Duration("duration", result.Duration),
+		)
+	}
+	if err != nil {
+		logger.Error("load data generation failed", zap.Error(err))
+		return 1
+	}
+	return 0
+}
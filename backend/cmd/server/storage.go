@@ -0,0 +1,396 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	_ "github.com/lib/pq"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/breaker"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/fieldcrypt"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/storage/encrypted"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/storage/guard"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/storage/memory"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/storage/postgres"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/storage/sqlite"
+)
+
+// Storage backends (STORAGE_BACKEND)
+const (
+	storagePostgres = "postgres"
+	storageMemory   = "memory"
+	storageSQLite   = "sqlite"
+)
+
+// repositories holds the data access implementations selected by STORAGE_BACKEND
+type repositories struct {
+	pricing          repository.PricingRepository
+	payment          repository.PaymentRepository
+	relayer          repository.RelayerRepository
+	contract         repository.ContractRepository
+	governanceConfig repository.GovernanceConfigRepository
+	appConfig        repository.AppConfigRepository
+	chain            repository.ChainRepository
+	requestLog       repository.RequestLogRepository
+	search           repository.SearchRepository
+	kpi              repository.KPIRepository
+	webhookEvent     repository.WebhookEventRepository
+	gasBilling       repository.GasBillingRepository
+	deposit          repository.DepositRepository
+	ledger           repository.LedgerRepository
+	payout           repository.PayoutRepository
+	snapshot         repository.SnapshotRepository
+	governanceAlerts repository.GovernanceNotificationRepository
+	safeTxs          repository.SafeTransactionRepository
+	bridge           repository.BridgeRepository
+	profiles         repository.ProfileRepository
+	activity         repository.ActivityRepository
+	reports          repository.ReportRepository
+	anomalies        repository.AnomalyRepository
+	watchlists       repository.WatchlistRepository
+	fulfillments     repository.FulfillmentRepository
+	entitlements     repository.EntitlementRepository
+	partners         repository.PartnerRepository
+	kycAudit         repository.KYCAuditRepository
+
+	// readRouter routes replica-safe reads when DATABASE_REPLICA_URL is set
+	// (postgres only, nil otherwise)
+	readRouter *postgres.ReadRouter
+
+	// pools reports connection pool utilization (postgres only, nil otherwise)
+	pools *postgres.PoolMonitor
+
+	// dbBreaker trips when the database stops answering (nil for memory)
+	dbBreaker *breaker.Breaker
+
+	// checkSchema reports whether the schema has been applied (postgres
+	// only, nil otherwise: SQLite migrations run before the backend opens)
+	checkSchema func(ctx context.Context) error
+
+	// close releases the backend (no-op for memory)
+	close func()
+}
+
+// openRepositories creates the repositories for the configured storage backend
+func openRepositories(cfg *Config, logger *zap.Logger) (*repositories, error) {
+	var (
+		repos       *repositories
+		err         error
+		isFailure   func(error) bool
+		isRetryable func(error) bool
+	)
+
+	switch cfg.StorageBackend {
+	case storagePostgres, "":
+		repos, err = openPostgresRepositories(cfg, logger)
+		isFailure = postgres.IsUnavailable
+		isRetryable = postgres.IsRetryable
+	case storageMemory:
+		logger.Warn("using in-memory storage: data is lost on restart")
+		return newMemoryRepositories(), nil
+	case storageSQLite:
+		repos, err = openSQLiteRepositories(cfg, logger)
+	default:
+		return nil, fmt.Errorf("unknown STORAGE_BACKEND %q (expected %q, %q or %q)", cfg.StorageBackend, storagePostgres, storageSQLite, storageMemory)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	dbBreaker := breaker.New("database", 0, 0)
+	dbBreaker.OnStateChange(logBreakerStateChange(logger))
+	g := guard.New(time.Duration(cfg.DBQueryTimeout)*time.Second, dbBreaker, isFailure)
+	g.SetRetries(int(cfg.DBMaxRetries), isRetryable)
+	guardRepositories(repos, g)
+
+	return repos, nil
+}
+
+// guardRepositories wraps every repository with per-query deadlines and the
+// database circuit breaker
+func guardRepositories(repos *repositories, g *guard.Guard) {
+	repos.pricing = guard.NewGuardedPricingRepo(repos.pricing, g)
+	repos.payment = guard.NewGuardedPaymentRepo(repos.payment, g)
+	repos.relayer = guard.NewGuardedRelayerRepo(repos.relayer, g)
+	repos.contract = guard.NewGuardedContractRepo(repos.contract, g)
+	repos.governanceConfig = guard.NewGuardedGovernanceConfigRepo(repos.governanceConfig, g)
+	repos.appConfig = guard.NewGuardedAppConfigRepo(repos.appConfig, g)
+	repos.chain = guard.NewGuardedChainRepo(repos.chain, g)
+	repos.requestLog = guard.NewGuardedRequestLogRepo(repos.requestLog, g)
+	repos.search = guard.NewGuardedSearchRepo(repos.search, g)
+	repos.kpi = guard.NewGuardedKPIRepo(repos.kpi, g)
+	repos.webhookEvent = guard.NewGuardedWebhookEventRepo(repos.webhookEvent, g)
+	repos.gasBilling = guard.NewGuardedGasBillingRepo(repos.gasBilling, g)
+	repos.deposit = guard.NewGuardedDepositRepo(repos.deposit, g)
+	repos.ledger = guard.NewGuardedLedgerRepo(repos.ledger, g)
+	repos.payout = guard.NewGuardedPayoutRepo(repos.payout, g)
+	repos.snapshot = guard.NewGuardedSnapshotRepo(repos.snapshot, g)
+	repos.governanceAlerts = guard.NewGuardedGovernanceNotificationRepo(repos.governanceAlerts, g)
+	repos.safeTxs = guard.NewGuardedSafeTransactionRepo(repos.safeTxs, g)
+	repos.bridge = guard.NewGuardedBridgeRepo(repos.bridge, g)
+	repos.profiles = guard.NewGuardedProfileRepo(repos.profiles, g)
+	repos.activity = guard.NewGuardedActivityRepo(repos.activity, g)
+	repos.reports = guard.NewGuardedReportRepo(repos.reports, g)
+	repos.anomalies = guard.NewGuardedAnomalyRepo(repos.anomalies, g)
+	repos.watchlists = guard.NewGuardedWatchlistRepo(repos.watchlists, g)
+	repos.fulfillments = guard.NewGuardedFulfillmentRepo(repos.fulfillments, g)
+	repos.entitlements = guard.NewGuardedEntitlementRepo(repos.entitlements, g)
+	repos.partners = guard.NewGuardedPartnerRepo(repos.partners, g)
+	repos.kycAudit = guard.NewGuardedKYCAuditRepo(repos.kycAudit, g)
+	repos.dbBreaker = g.Breaker()
+}
+
+// encryptRepositories wraps the repositories holding KYC verifications,
+// signing secrets and profile emails so their sensitive fields are encrypted
+// at rest with c
+func encryptRepositories(repos *repositories, c *fieldcrypt.Cipher) *encrypted.EncryptedPaymentRepo {
+	payment := encrypted.NewEncryptedPaymentRepo(repos.payment, c)
+	repos.payment = payment
+	repos.search = encrypted.NewEncryptedSearchRepo(repos.search, c)
+	repos.governanceAlerts = encrypted.NewEncryptedGovernanceNotificationRepo(repos.governanceAlerts, c)
+	repos.watchlists = encrypted.NewEncryptedWatchlistRepo(repos.watchlists, c)
+	repos.profiles = encrypted.NewEncryptedProfileRepo(repos.profiles, c)
+	return payment
+}
+
+// rotateKYCFields re-encrypts KYC verification fields under the current key
+func rotateKYCFields(ctx context.Context, payments *encrypted.EncryptedPaymentRepo, logger *zap.Logger) {
+	n, err := payments.RotateKYCVerifications(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			logger.Error("kyc field re-encryption failed", zap.Int("rotated", n), zap.Error(err))
+		}
+		return
+	}
+	if n > 0 {
+		logger.Info("kyc fields re-encrypted", zap.Int("rotated", n))
+	}
+}
+
+// logBreakerStateChange logs circuit breaker transitions
+func logBreakerStateChange(logger *zap.Logger) func(name string, from, to breaker.State) {
+	return func(name string, from, to breaker.State) {
+		fields := []zap.Field{
+			zap.String("breaker", name),
+			zap.String("from", from.String()),
+			zap.String("to", to.String()),
+		}
+		if to == breaker.StateOpen {
+			logger.Error("circuit breaker opened", fields...)
+			return
+		}
+		logger.Info("circuit breaker state changed", fields...)
+	}
+}
+
+// openPostgresRepositories connects to PostgreSQL (and the read replica, if
+// configured) and creates the repositories
+func openPostgresRepositories(cfg *Config, logger *zap.Logger) (*repositories, error) {
+	pool := poolConfig(cfg)
+	db, err := openPostgres(cfg.DatabaseURL, pool)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify database connection
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("connecting to database: %w", err)
+	}
+	logger.Info("connected to database")
+
+	pricing := postgres.NewPostgresPricingRepo(db)
+	payment := postgres.NewPostgresPaymentRepo(db)
+	relayer := postgres.NewPostgresRelayerRepo(db)
+	contract := postgres.NewPostgresContractRepo(db)
+	governanceConfig := postgres.NewPostgresGovernanceConfigRepo(db)
+	appConfig := postgres.NewPostgresAppConfigRepo(db)
+	requestLog := postgres.NewPostgresRequestLogRepo(db)
+	search := postgres.NewPostgresSearchRepo(db)
+	kpi := postgres.NewPostgresKPIRepo(db)
+
+	repos := &repositories{
+		pricing:          pricing,
+		payment:          payment,
+		relayer:          relayer,
+		contract:         contract,
+		governanceConfig: governanceConfig,
+		appConfig:        appConfig,
+		chain:            postgres.NewPostgresChainRepo(db),
+		requestLog:       requestLog,
+		search:           search,
+		kpi:              kpi,
+		webhookEvent:     postgres.NewPostgresWebhookEventRepo(db),
+		gasBilling:       postgres.NewPostgresGasBillingRepo(db),
+		deposit:          postgres.NewPostgresDepositRepo(db),
+		ledger:           postgres.NewPostgresLedgerRepo(db),
+		payout:           postgres.NewPostgresPayoutRepo(db),
+		snapshot:         postgres.NewPostgresSnapshotRepo(db),
+		governanceAlerts: postgres.NewPostgresGovernanceNotificationRepo(db),
+		safeTxs:          postgres.NewPostgresSafeTransactionRepo(db),
+		bridge:           postgres.NewPostgresBridgeRepo(db),
+		profiles:         postgres.NewPostgresProfileRepo(db),
+		activity:         postgres.NewPostgresActivityRepo(db),
+		reports:          postgres.NewPostgresReportRepo(db),
+		anomalies:        postgres.NewPostgresAnomalyRepo(db),
+		watchlists:       postgres.NewPostgresWatchlistRepo(db),
+		fulfillments:     postgres.NewPostgresFulfillmentRepo(db),
+		entitlements:     postgres.NewPostgresEntitlementRepo(db),
+		partners:         postgres.NewPostgresPartnerRepo(db),
+		kycAudit:         postgres.NewPostgresKYCAuditRepo(db),
+		pools:            postgres.NewPoolMonitor(),
+		checkSchema:      func(ctx context.Context) error { return postgres.CheckSchema(ctx, db) },
+		close:            func() { db.Close() },
+	}
+	repos.pools.Add("primary", db)
+
+	if cfg.ReplicaURL == "" {
+		return repos, nil
+	}
+
+	// An unreachable replica is not fatal: reads stay on the primary until
+	// the router's first successful check
+	replica, err := openPostgres(cfg.ReplicaURL, pool)
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("opening replica connection: %w", err)
+	}
+	router := postgres.NewReadRouter(db, replica, time.Duration(cfg.ReplicaMaxLag)*time.Second, logger)
+	if status := router.Check(ctx); !status.Healthy {
+		logger.Warn("read replica unavailable, reads use primary", zap.String("reason", status.Error))
+	} else {
+		logger.Info("connected to read replica", zap.Duration("lag", status.Lag))
+	}
+
+	// Chain tracking and webhook deduplication read their own writes, so they
+	// stay on the primary
+	pricing.UseReadRouter(router)
+	payment.UseReadRouter(router)
+	relayer.UseReadRouter(router)
+	contract.UseReadRouter(router)
+	governanceConfig.UseReadRouter(router)
+	appConfig.UseReadRouter(router)
+	requestLog.UseReadRouter(router)
+	search.UseReadRouter(router)
+	kpi.UseReadRouter(router)
+
+	repos.readRouter = router
+	repos.pools.Add("replica", replica)
+	repos.close = func() {
+		replica.Close()
+		db.Close()
+	}
+
+	return repos, nil
+}
+
+// openPostgres opens a pooled PostgreSQL handle without connecting
+func openPostgres(url string, pool postgres.PoolConfig) (*sql.DB, error) {
+	db, err := sql.Open("postgres", url)
+	if err != nil {
+		return nil, fmt.Errorf("opening database connection: %w", err)
+	}
+
+	pool.Apply(db)
+
+	return db, nil
+}
+
+// poolConfig builds the PostgreSQL pool settings from DB_* environment
+// variables. The replica uses the same settings as the primary.
+func poolConfig(cfg *Config) postgres.PoolConfig {
+	return postgres.PoolConfig{
+		MaxOpenConns:    int(cfg.DBMaxOpenConns),
+		MaxIdleConns:    int(cfg.DBMaxIdleConns),
+		ConnMaxLifetime: time.Duration(cfg.DBConnMaxLife) * time.Second,
+		ConnMaxIdleTime: time.Duration(cfg.DBConnMaxIdle) * time.Second,
+	}
+}
+
+// openSQLiteRepositories opens the SQLite database file, applies migrations and
+// creates the repositories
+func openSQLiteRepositories(cfg *Config, logger *zap.Logger) (*repositories, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	db, err := sqlite.Open(ctx, cfg.SQLitePath)
+	if err != nil {
+		return nil, err
+	}
+	logger.Info("opened sqlite database", zap.String("path", cfg.SQLitePath))
+
+	return &repositories{
+		pricing:          sqlite.NewSQLitePricingRepo(db),
+		payment:          sqlite.NewSQLitePaymentRepo(db),
+		relayer:          sqlite.NewSQLiteRelayerRepo(db),
+		contract:         sqlite.NewSQLiteContractRepo(db),
+		governanceConfig: sqlite.NewSQLiteGovernanceConfigRepo(db),
+		appConfig:        sqlite.NewSQLiteAppConfigRepo(db),
+		chain:            sqlite.NewSQLiteChainRepo(db),
+		requestLog:       sqlite.NewSQLiteRequestLogRepo(db),
+		search:           sqlite.NewSQLiteSearchRepo(db),
+		kpi:              sqlite.NewSQLiteKPIRepo(db),
+		webhookEvent:     sqlite.NewSQLiteWebhookEventRepo(db),
+		gasBilling:       sqlite.NewSQLiteGasBillingRepo(db),
+		deposit:          sqlite.NewSQLiteDepositRepo(db),
+		ledger:           sqlite.NewSQLiteLedgerRepo(db),
+		payout:           sqlite.NewSQLitePayoutRepo(db),
+		snapshot:         sqlite.NewSQLiteSnapshotRepo(db),
+		governanceAlerts: sqlite.NewSQLiteGovernanceNotificationRepo(db),
+		safeTxs:          sqlite.NewSQLiteSafeTransactionRepo(db),
+		bridge:           sqlite.NewSQLiteBridgeRepo(db),
+		profiles:         sqlite.NewSQLiteProfileRepo(db),
+		activity:         sqlite.NewSQLiteActivityRepo(db),
+		reports:          sqlite.NewSQLiteReportRepo(db),
+		anomalies:        sqlite.NewSQLiteAnomalyRepo(db),
+		watchlists:       sqlite.NewSQLiteWatchlistRepo(db),
+		fulfillments:     sqlite.NewSQLiteFulfillmentRepo(db),
+		entitlements:     sqlite.NewSQLiteEntitlementRepo(db),
+		partners:         sqlite.NewSQLitePartnerRepo(db),
+		kycAudit:         sqlite.NewSQLiteKYCAuditRepo(db),
+		close:            func() { db.Close() },
+	}, nil
+}
+
+// newMemoryRepositories creates in-memory repositories seeded with the demo data
+func newMemoryRepositories() *repositories {
+	store := memory.NewSeededStore()
+
+	return &repositories{
+		pricing:          store.Pricing,
+		payment:          store.Payments,
+		relayer:          store.Relayer,
+		contract:         store.Contracts,
+		governanceConfig: store.GovernanceConfig,
+		appConfig:        store.AppConfig,
+		chain:            store.Chain,
+		requestLog:       store.RequestLogs,
+		search:           store.Search,
+		kpi:              store.KPIs,
+		webhookEvent:     store.WebhookEvents,
+		gasBilling:       store.GasBilling,
+		deposit:          store.Deposits,
+		ledger:           store.Ledger,
+		payout:           store.Payout,
+		snapshot:         store.Snapshot,
+		governanceAlerts: store.GovernanceAlerts,
+		safeTxs:          store.SafeTxs,
+		bridge:           store.Bridge,
+		profiles:         store.Profiles,
+		activity:         store.Activity,
+		reports:          store.Reports,
+		anomalies:        store.Anomalies,
+		watchlists:       store.Watchlists,
+		fulfillments:     store.Fulfillments,
+		entitlements:     store.Entitlements,
+		partners:         store.Partners,
+		kycAudit:         store.KYCAudit,
+		close:            func() {},
+	}
+}
@@ -0,0 +1,186 @@
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/acme/autocert"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/middleware"
+)
+
+// Default Let's Encrypt certificate cache (TLS_AUTOCERT_CACHE_DIR)
+const defaultAutocertCacheDir = "autocert-cache"
+
+// serverTLSConfig returns the TLS settings for the HTTP server, or nil when
+// neither TLS_CERT_FILE nor TLS_AUTOCERT_DOMAINS is set and TLS is
+// terminated in front of the server. With TLS_AUTOCERT_DOMAINS, certificates
+// for those hosts are obtained from Let's Encrypt and renewed automatically;
+// the returned manager answers the HTTP-01 challenge on the redirect
+// listener. With TLS_CLIENT_CA_FILE, client certificates are verified when
+// presented so webhook routes can require them; other routes stay reachable
+// without one.
+func serverTLSConfig(cfg *Config) (*tls.Config, *autocert.Manager, error) {
+	var (
+		tlsConfig *tls.Config
+		manager   *autocert.Manager
+	)
+	switch {
+	case cfg.TLSCertFile != "" && len(cfg.TLSAutocertDomains) > 0:
+		return nil, nil, fmt.Errorf("TLS_CERT_FILE and TLS_AUTOCERT_DOMAINS are mutually exclusive")
+	case cfg.TLSCertFile != "":
+		cert, err := tls.LoadX509KeyPair(cfg.TLSCertFile, cfg.TLSKeyFile)
+		if err != nil {
+			return nil, nil, fmt.Errorf("loading server certificate: %w", err)
+		}
+		tlsConfig = &tls.Config{Certificates: []tls.Certificate{cert}}
+	case len(cfg.TLSAutocertDomains) > 0:
+		manager = &autocert.Manager{
+			Prompt:     autocert.AcceptTOS,
+			HostPolicy: autocert.HostWhitelist(cfg.TLSAutocertDomains...),
+			Cache:      autocert.DirCache(cfg.TLSAutocertCacheDir),
+			Email:      cfg.TLSAutocertEmail,
+		}
+		// Offers h2 and the TLS-ALPN-01 challenge protocol
+		tlsConfig = manager.TLSConfig()
+	default:
+		if cfg.TLSClientCAFile != "" {
+			return nil, nil, fmt.Errorf("TLS_CLIENT_CA_FILE requires TLS_CERT_FILE and TLS_KEY_FILE, or TLS_AUTOCERT_DOMAINS")
+		}
+		return nil, nil, nil
+	}
+	tlsConfig.MinVersion = tls.VersionTLS12
+
+	if cfg.TLSClientCAFile != "" {
+		pem, err := os.ReadFile(cfg.TLSClientCAFile)
+		if err != nil {
+			return nil, nil, fmt.Errorf("reading client CA file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, nil, fmt.Errorf("no certificates found in %s", cfg.TLSClientCAFile)
+		}
+		tlsConfig.ClientCAs = pool
+		tlsConfig.ClientAuth = tls.VerifyClientCertIfGiven
+	}
+
+	return tlsConfig, manager, nil
+}
+
+// serverProtocols returns the protocols the server speaks. HTTP/2 is
+// negotiated over TLS; without TLS, HTTP2_CLEARTEXT enables h2c (prior
+// knowledge) for proxies that forward HTTP/2 to the backend.
+func serverProtocols(cfg *Config, tlsConfig *tls.Config) *http.Protocols {
+	protocols := new(http.Protocols)
+	protocols.SetHTTP1(true)
+	if tlsConfig != nil {
+		protocols.SetHTTP2(true)
+	} else if cfg.HTTP2Cleartext {
+		protocols.SetUnencryptedHTTP2(true)
+	}
+	return protocols
+}
+
+// newRedirectServer returns the plain HTTP listener on TLS_REDIRECT_PORT that
+// redirects to HTTPS and, with autocert, answers Let's Encrypt's HTTP-01
+// challenges. It is nil without TLS or when TLS_REDIRECT_PORT is unset.
+func newRedirectServer(cfg *Config, tlsConfig *tls.Config, manager *autocert.Manager) *http.Server {
+	if tlsConfig == nil || cfg.TLSRedirectPort == "" {
+		return nil
+	}
+	var handler http.Handler = httpsRedirect(cfg.Port)
+	if manager != nil {
+		handler = manager.HTTPHandler(handler)
+	}
+	return &http.Server{
+		Addr:              ":" + cfg.TLSRedirectPort,
+		Handler:           handler,
+		ReadHeaderTimeout: 5 * time.Second,
+		IdleTimeout:       30 * time.Second,
+	}
+}
+
+// httpsRedirect permanently redirects requests to the same URL over HTTPS on
+// httpsPort
+func httpsRedirect(httpsPort string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host := r.Host
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+		if httpsPort != "443" {
+			host = net.JoinHostPort(host, httpsPort)
+		}
+		http.Redirect(w, r, "https://"+host+r.URL.RequestURI(), http.StatusPermanentRedirect)
+	})
+}
+
+// loadWebhookSource reads the allowlist and mTLS settings of one integration
+// from <PREFIX>_WEBHOOK_ALLOWED_IPS, <PREFIX>_WEBHOOK_REQUIRE_CLIENT_CERT and
+// <PREFIX>_WEBHOOK_CLIENT_CERT_NAMES. The allowlist entry "published" expands
+// to the integration's published ranges.
+func loadWebhookSource(env *envReader, integration, prefix string, published []string) middleware.WebhookSourceConfig {
+	src := middleware.WebhookSourceConfig{
+		Integration:       integration,
+		TrustedProxies:    splitList(getEnv("WEBHOOK_TRUSTED_PROXIES", "")),
+		RequireClientCert: env.bool(prefix+"_WEBHOOK_REQUIRE_CLIENT_CERT", false),
+		ClientCertNames:   splitList(getEnv(prefix+"_WEBHOOK_CLIENT_CERT_NAMES", "")),
+	}
+	for _, entry := range splitList(getEnv(prefix+"_WEBHOOK_ALLOWED_IPS", "")) {
+		if entry == middleware.PublishedRanges && published != nil {
+			src.AllowedIPs = append(src.AllowedIPs, published...)
+			continue
+		}
+		src.AllowedIPs = append(src.AllowedIPs, entry)
+	}
+	return src
+}
+
+// webhookSourceMiddleware builds the source check for one integration's
+// webhook route. Misconfiguration stops the server rather than leaving the
+// route open.
+func webhookSourceMiddleware(src middleware.WebhookSourceConfig, tlsConfig *tls.Config, logger *zap.Logger) gin.HandlerFunc {
+	for _, entry := range src.AllowedIPs {
+		if entry == middleware.PublishedRanges {
+			logger.Fatal("no published webhook ranges are built in for this integration; list its IPs explicitly",
+				zap.String("integration", src.Integration))
+		}
+	}
+	if src.RequireClientCert && (tlsConfig == nil || tlsConfig.ClientCAs == nil) {
+		logger.Fatal("webhook client certificates require TLS_CERT_FILE, TLS_KEY_FILE and TLS_CLIENT_CA_FILE",
+			zap.String("integration", src.Integration))
+	}
+
+	handler, err := middleware.WebhookSource(src, logger)
+	if err != nil {
+		logger.Fatal("invalid webhook source configuration", zap.Error(err))
+	}
+
+	if src.Enabled() {
+		logger.Info("webhook source restrictions enabled",
+			zap.String("integration", src.Integration),
+			zap.Int("allowed_networks", len(src.AllowedIPs)),
+			zap.Bool("require_client_cert", src.RequireClientCert),
+		)
+	}
+	return handler
+}
+
+// splitList splits a comma-separated environment value, dropping blanks
+func splitList(value string) []string {
+	var out []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+	return out
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/apiversion"
+)
+
+// v1Policy returns the lifecycle of /api/v1 from API_V1_DEPRECATED_AT,
+// API_V1_SUNSET_AT and API_V1_DEPRECATION_LINK. Until a date is set, v1
+// responses carry no deprecation headers.
+func v1Policy(cfg *Config) (apiversion.Policy, error) {
+	var policy apiversion.Policy
+	var err error
+
+	if policy.DeprecatedAt, err = parsePolicyDate("API_V1_DEPRECATED_AT", cfg.APIV1DeprecatedAt); err != nil {
+		return policy, err
+	}
+	if policy.SunsetAt, err = parsePolicyDate("API_V1_SUNSET_AT", cfg.APIV1SunsetAt); err != nil {
+		return policy, err
+	}
+	if !policy.DeprecatedAt.IsZero() && !policy.SunsetAt.IsZero() && policy.SunsetAt.Before(policy.DeprecatedAt) {
+		return policy, fmt.Errorf("API_V1_SUNSET_AT is before API_V1_DEPRECATED_AT")
+	}
+	policy.Link = cfg.APIV1DeprecationLink
+	return policy, nil
+}
+
+// parsePolicyDate parses an RFC 3339 timestamp or a YYYY-MM-DD date (UTC
+// midnight). An empty value is the zero time.
+func parsePolicyDate(name, value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s: expected RFC 3339 time or YYYY-MM-DD, got %q", name, value)
+	}
+	return t, nil
+}
@@ -0,0 +1,65 @@
+// Package activity records users' payments, KYC reviews, NFT transfers,
+// votes and relayed transactions in the activity_events store that backs
+// their activity feeds.
+package activity
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Listener is told about events as they are recorded (implemented by
+// watchlist.Watcher). It is called synchronously from Record, so it must
+// not block.
+type Listener interface {
+	ActivityRecorded(ctx context.Context, e *repository.ActivityEvent)
+}
+
+// Recorder appends events to the activity store. The feed is a read model:
+// the change an event describes is already stored when it is recorded, so
+// a recording failure is logged rather than returned.
+type Recorder struct {
+	repo   repository.ActivityRepository
+	logger *zap.Logger
+
+	mu        sync.RWMutex
+	listeners []Listener
+}
+
+// NewRecorder creates a recorder writing to repo
+func NewRecorder(repo repository.ActivityRepository, logger *zap.Logger) *Recorder {
+	return &Recorder{repo: repo, logger: logger}
+}
+
+// Subscribe adds a listener told about every later recorded event
+func (r *Recorder) Subscribe(l Listener) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.listeners = append(r.listeners, l)
+}
+
+// Record appends e to its address's feed and tells the listeners
+func (r *Recorder) Record(ctx context.Context, e *repository.ActivityEvent) {
+	e.Address = strings.ToLower(e.Address)
+	if err := r.repo.RecordActivity(ctx, e); err != nil {
+		r.logger.Error("failed to record activity",
+			zap.String("address", e.Address),
+			zap.String("kind", string(e.Kind)),
+			zap.String("subject_id", e.SubjectID),
+			zap.Error(err),
+		)
+		return
+	}
+
+	r.mu.RLock()
+	listeners := r.listeners
+	r.mu.RUnlock()
+	for _, l := range listeners {
+		l.ActivityRecorded(ctx, e)
+	}
+}
@@ -0,0 +1,95 @@
+package activity_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/activity"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/storage/memory"
+)
+
+const user = "0x00000000000000000000000000000000000000aa"
+
+// actions lists the address's feed as kind:action, oldest first
+func actions(t *testing.T, repo repository.ActivityRepository) []string {
+	t.Helper()
+	events, _, err := repo.ListActivity(context.Background(), repository.ActivityFilter{Address: user}, repository.Pagination{PageSize: 100})
+	require.NoError(t, err)
+	out := make([]string, 0, len(events))
+	for i := len(events) - 1; i >= 0; i-- {
+		out = append(out, string(events[i].Kind)+":"+events[i].Action)
+	}
+	return out
+}
+
+func TestRecordingPaymentRepo_RecordsStatusChanges(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	payments := activity.NewRecordingPaymentRepo(store.Payments, activity.NewRecorder(store.Activity, zap.NewNop()))
+
+	p := &repository.Payment{ServiceCode: "kyc_verification", PayerAddress: "0x00000000000000000000000000000000000000AA",
+		PaymentMethod: "stripe", AmountCharged: 15, Currency: "USD", Status: repository.PaymentStatusPending}
+	require.NoError(t, payments.CreatePayment(ctx, p))
+	hash := "0xabc"
+	require.NoError(t, payments.UpdatePaymentStatus(ctx, p.ID, repository.PaymentStatusCompleted, &repository.PaymentStatusUpdate{TxHash: &hash}))
+	// Updates that keep the status are not activity
+	require.NoError(t, payments.UpdatePaymentStatus(ctx, p.ID, repository.PaymentStatusCompleted, &repository.PaymentStatusUpdate{}))
+
+	v := &repository.KYCVerification{UserAddress: user, Status: repository.KYCStatusPending}
+	require.NoError(t, payments.CreateKYCVerification(ctx, v))
+	applicant := "applicant-1"
+	require.NoError(t, payments.UpdateKYCVerification(ctx, v.ID, &repository.KYCVerificationUpdate{SumsubApplicantID: &applicant}))
+	approved := repository.KYCStatusApproved
+	require.NoError(t, payments.UpdateKYCVerification(ctx, v.ID, &repository.KYCVerificationUpdate{Status: &approved}))
+
+	assert.Equal(t, []string{"payment:pending", "payment:completed", "kyc:pending", "kyc:approved"}, actions(t, store.Activity))
+
+	events, _, err := store.Activity.ListActivity(ctx, repository.ActivityFilter{Address: user, Kinds: []repository.ActivityKind{repository.ActivityPayment}}, repository.Pagination{})
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, p.ID, events[0].SubjectID)
+	assert.Equal(t, "0xabc", *events[0].TxHash)
+	assert.Equal(t, "kyc_verification payment of 15 USD via stripe", events[0].Summary)
+}
+
+func TestRecordingRelayerRepo_RecordsStatusChanges(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	relayer := activity.NewRecordingRelayerRepo(store.Relayer, activity.NewRecorder(store.Activity, zap.NewNop()))
+
+	tx := &repository.MetaTransaction{FromAddress: user, ToAddress: "0x00000000000000000000000000000000000000c2",
+		FunctionName: "transfer", Nonce: 1, Deadline: time.Now().Add(time.Hour), Status: repository.MetaTxStatusPending}
+	require.NoError(t, relayer.CreateMetaTx(ctx, tx))
+	hash := "0xdef"
+	require.NoError(t, relayer.UpdateMetaTxStatus(ctx, tx.ID, &repository.MetaTxStatusUpdate{Status: repository.MetaTxStatusSubmitted, TxHash: &hash}))
+	require.NoError(t, relayer.UpdateMetaTxStatus(ctx, tx.ID, &repository.MetaTxStatusUpdate{Status: repository.MetaTxStatusSubmitted}))
+	require.NoError(t, relayer.UpdateMetaTxStatus(ctx, tx.ID, &repository.MetaTxStatusUpdate{Status: repository.MetaTxStatusConfirmed}))
+
+	assert.Equal(t, []string{"meta_tx:pending", "meta_tx:submitted", "meta_tx:confirmed"}, actions(t, store.Activity))
+}
+
+// failingActivity rejects every event
+type failingActivity struct {
+	repository.ActivityRepository
+}
+
+func (failingActivity) RecordActivity(ctx context.Context, e *repository.ActivityEvent) error {
+	return errors.New("database is down")
+}
+
+func TestRecorder_FailureDoesNotFailTheWrite(t *testing.T) {
+	store := memory.NewStore()
+	payments := activity.NewRecordingPaymentRepo(store.Payments, activity.NewRecorder(failingActivity{}, zap.NewNop()))
+
+	p := &repository.Payment{ServiceCode: "kyc_verification", PayerAddress: user, PaymentMethod: "stripe", Currency: "USD", Status: repository.PaymentStatusPending}
+	require.NoError(t, payments.CreatePayment(context.Background(), p))
+	_, err := store.Payments.GetPayment(context.Background(), p.ID)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,134 @@
+package activity
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure RecordingPaymentRepo implements PaymentRepository
+var _ repository.PaymentRepository = (*RecordingPaymentRepo)(nil)
+
+// RecordingPaymentRepo wraps a PaymentRepository and records an event
+// whenever a payment or KYC verification is created or changes status. All
+// other methods go straight to the wrapped repository.
+type RecordingPaymentRepo struct {
+	repository.PaymentRepository
+	recorder *Recorder
+}
+
+// NewRecordingPaymentRepo wraps next, recording through recorder
+func NewRecordingPaymentRepo(next repository.PaymentRepository, recorder *Recorder) *RecordingPaymentRepo {
+	return &RecordingPaymentRepo{PaymentRepository: next, recorder: recorder}
+}
+
+// CreatePayment implements repository.PaymentRepository
+func (r *RecordingPaymentRepo) CreatePayment(ctx context.Context, payment *repository.Payment) error {
+	if err := r.PaymentRepository.CreatePayment(ctx, payment); err != nil {
+		return err
+	}
+	r.recordPayment(ctx, payment, payment.Status, payment.TxHash)
+	return nil
+}
+
+// UpdatePaymentStatus implements repository.PaymentRepository. Updates that
+// leave the status unchanged record nothing.
+func (r *RecordingPaymentRepo) UpdatePaymentStatus(ctx context.Context, id string, status repository.PaymentStatus, details *repository.PaymentStatusUpdate) error {
+	before, err := r.PaymentRepository.GetPayment(ctx, id)
+	if err != nil {
+		// Let the update report the error (or apply it without recording)
+		return r.PaymentRepository.UpdatePaymentStatus(ctx, id, status, details)
+	}
+	if err := r.PaymentRepository.UpdatePaymentStatus(ctx, id, status, details); err != nil {
+		return err
+	}
+	if before.Status == status {
+		return nil
+	}
+
+	txHash := before.TxHash
+	if details != nil && details.TxHash != nil {
+		txHash = details.TxHash
+	}
+	r.recordPayment(ctx, before, status, txHash)
+	return nil
+}
+
+// ExpirePayments implements repository.PaymentRepository
+func (r *RecordingPaymentRepo) ExpirePayments(ctx context.Context, method string, before time.Time, limit int) ([]string, error) {
+	ids, err := r.PaymentRepository.ExpirePayments(ctx, method, before, limit)
+	if err != nil {
+		return ids, err
+	}
+	for _, id := range ids {
+		p, err := r.PaymentRepository.GetPayment(ctx, id)
+		if err != nil {
+			r.recorder.logger.Warn("failed to load expired payment for activity feed", zap.String("payment_id", id), zap.Error(err))
+			continue
+		}
+		r.recordPayment(ctx, p, repository.PaymentStatusExpired, p.TxHash)
+	}
+	return ids, nil
+}
+
+// CreateKYCVerification implements repository.PaymentRepository
+func (r *RecordingPaymentRepo) CreateKYCVerification(ctx context.Context, v *repository.KYCVerification) error {
+	if err := r.PaymentRepository.CreateKYCVerification(ctx, v); err != nil {
+		return err
+	}
+	r.recordKYC(ctx, v, v.Status, v.WhitelistTxHash)
+	return nil
+}
+
+// UpdateKYCVerification implements repository.PaymentRepository. Only
+// status changes are recorded.
+func (r *RecordingPaymentRepo) UpdateKYCVerification(ctx context.Context, id string, update *repository.KYCVerificationUpdate) error {
+	if update == nil || update.Status == nil {
+		return r.PaymentRepository.UpdateKYCVerification(ctx, id, update)
+	}
+	before, err := r.PaymentRepository.GetKYCVerification(ctx, id)
+	if err != nil {
+		return r.PaymentRepository.UpdateKYCVerification(ctx, id, update)
+	}
+	if err := r.PaymentRepository.UpdateKYCVerification(ctx, id, update); err != nil {
+		return err
+	}
+	if before.Status == *update.Status {
+		return nil
+	}
+
+	txHash := before.WhitelistTxHash
+	if update.WhitelistTxHash != nil {
+		txHash = update.WhitelistTxHash
+	}
+	r.recordKYC(ctx, before, *update.Status, txHash)
+	return nil
+}
+
+func (r *RecordingPaymentRepo) recordPayment(ctx context.Context, p *repository.Payment, status repository.PaymentStatus, txHash *string) {
+	r.recorder.Record(ctx, &repository.ActivityEvent{
+		Address:   p.PayerAddress,
+		Kind:      repository.ActivityPayment,
+		Action:    string(status),
+		SubjectID: p.ID,
+		Summary: fmt.Sprintf("%s payment of %s %s via %s",
+			p.ServiceCode, strconv.FormatFloat(p.AmountCharged, 'f', -1, 64), p.Currency, p.PaymentMethod),
+		TxHash: txHash,
+	})
+}
+
+func (r *RecordingPaymentRepo) recordKYC(ctx context.Context, v *repository.KYCVerification, status repository.KYCVerificationStatus, txHash *string) {
+	r.recorder.Record(ctx, &repository.ActivityEvent{
+		Address:   v.UserAddress,
+		Kind:      repository.ActivityKYC,
+		Action:    string(status),
+		SubjectID: v.ID,
+		Summary:   "KYC verification " + string(status),
+		TxHash:    txHash,
+	})
+}
@@ -0,0 +1,76 @@
+package activity
+
+import (
+	"context"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure RecordingRelayerRepo implements RelayerRepository
+var _ repository.RelayerRepository = (*RecordingRelayerRepo)(nil)
+
+// RecordingRelayerRepo wraps a RelayerRepository and records an event for
+// the sender whenever a meta-transaction is created or changes status. All
+// other methods go straight to the wrapped repository.
+type RecordingRelayerRepo struct {
+	repository.RelayerRepository
+	recorder *Recorder
+}
+
+// NewRecordingRelayerRepo wraps next, recording through recorder
+func NewRecordingRelayerRepo(next repository.RelayerRepository, recorder *Recorder) *RecordingRelayerRepo {
+	return &RecordingRelayerRepo{RelayerRepository: next, recorder: recorder}
+}
+
+// CreateMetaTx implements repository.RelayerRepository
+func (r *RecordingRelayerRepo) CreateMetaTx(ctx context.Context, tx *repository.MetaTransaction) error {
+	if err := r.RelayerRepository.CreateMetaTx(ctx, tx); err != nil {
+		return err
+	}
+	r.record(ctx, tx, tx.Status, tx.TxHash)
+	return nil
+}
+
+// CreateMetaTxBatch implements repository.RelayerRepository
+func (r *RecordingRelayerRepo) CreateMetaTxBatch(ctx context.Context, txs []*repository.MetaTransaction) error {
+	if err := r.RelayerRepository.CreateMetaTxBatch(ctx, txs); err != nil {
+		return err
+	}
+	for _, tx := range txs {
+		r.record(ctx, tx, tx.Status, tx.TxHash)
+	}
+	return nil
+}
+
+// UpdateMetaTxStatus implements repository.RelayerRepository. Updates that
+// leave the status unchanged (e.g. a resubmission) record nothing.
+func (r *RecordingRelayerRepo) UpdateMetaTxStatus(ctx context.Context, id string, update *repository.MetaTxStatusUpdate) error {
+	before, err := r.RelayerRepository.GetMetaTx(ctx, id)
+	if err != nil {
+		return r.RelayerRepository.UpdateMetaTxStatus(ctx, id, update)
+	}
+	if err := r.RelayerRepository.UpdateMetaTxStatus(ctx, id, update); err != nil {
+		return err
+	}
+	if before.Status == update.Status {
+		return nil
+	}
+
+	txHash := before.TxHash
+	if update.TxHash != nil {
+		txHash = update.TxHash
+	}
+	r.record(ctx, before, update.Status, txHash)
+	return nil
+}
+
+func (r *RecordingRelayerRepo) record(ctx context.Context, tx *repository.MetaTransaction, status repository.MetaTxStatus, txHash *string) {
+	r.recorder.Record(ctx, &repository.ActivityEvent{
+		Address:   tx.FromAddress,
+		Kind:      repository.ActivityMetaTx,
+		Action:    string(status),
+		SubjectID: tx.ID,
+		Summary:   "Relayed " + tx.FunctionName + " to " + tx.ToAddress,
+		TxHash:    txHash,
+	})
+}
@@ -0,0 +1,385 @@
+// Package anomaly flags unusual payment and relay activity - bursts of
+// failed relays from one sender, rapid-fire payments from one payer, and
+// hourly volume well above its recent baseline - opening a compliance case
+// for each finding and alerting the compliance webhook
+package anomaly
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/callbacks"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// namespace is the app_config namespace holding the detection settings
+const namespace = "anomaly"
+
+// Rules
+const (
+	RuleRelayFailures      = "relay_failures"
+	RuleRapidPayments      = "rapid_payments"
+	RulePaymentVolumeSpike = "payment_volume_spike"
+	RuleRelayVolumeSpike   = "relay_volume_spike"
+)
+
+// rules lists every rule, so the detection counters are always exported
+var rules = []string{RuleRelayFailures, RuleRapidPayments, RulePaymentVolumeSpike, RuleRelayVolumeSpike}
+
+// AlertDetected is the compliance alert type for a new case
+const AlertDetected = "anomaly.detected"
+
+// Detection defaults (overridable via app_config namespace "anomaly")
+const (
+	// DefaultInterval is how often Run looks for anomalies when no interval
+	// is given
+	DefaultInterval = 5 * time.Minute
+
+	defaultRelayFailures       = 10
+	defaultRelayFailureMinutes = 60
+	defaultRapidPayments       = 20
+	defaultRapidPaymentMinutes = 10
+	defaultBaselineHours       = 7 * 24
+	defaultSpikeSigmas         = 4
+	defaultSpikeMinimum        = 50
+)
+
+// Settings are the detection thresholds
+type Settings struct {
+	Enabled bool `json:"enabled"`
+
+	// Failed relays from one sender within the window
+	RelayFailures      int64         `json:"relay_failures"`
+	RelayFailureWindow time.Duration `json:"relay_failure_window"`
+
+	// Payments from one payer within the window
+	RapidPayments      int64         `json:"rapid_payments"`
+	RapidPaymentWindow time.Duration `json:"rapid_payment_window"`
+
+	// The last full hour's volume is a spike when it is more than
+	// SpikeSigmas standard deviations above the mean of the BaselineHours
+	// before it and at least SpikeMinimum
+	BaselineHours int64 `json:"baseline_hours"`
+	SpikeSigmas   int64 `json:"spike_sigmas"`
+	SpikeMinimum  int64 `json:"spike_minimum"`
+}
+
+// Alert is the body POSTed to the compliance webhook for a new case
+type Alert struct {
+	ID         string    `json:"id"`
+	Type       string    `json:"type"`
+	CaseID     string    `json:"case_id"`
+	Rule       string    `json:"rule"`
+	Subject    string    `json:"subject"`
+	Summary    string    `json:"summary"`
+	Observed   float64   `json:"observed"`
+	Threshold  float64   `json:"threshold"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// RunStats summarises one detection pass
+type RunStats struct {
+	StartedAt time.Time `json:"started_at"`
+	Duration  string    `json:"duration"`
+	Skipped   bool      `json:"skipped,omitempty"` // anomaly.enabled is false
+	Findings  int       `json:"findings"`
+	Opened    int       `json:"opened"` // findings without an open case already
+	Errors    []string  `json:"errors,omitempty"`
+}
+
+// Detector periodically checks payment and relay activity for anomalies
+type Detector struct {
+	repo       repository.AnomalyRepository
+	configRepo repository.AppConfigRepository
+	queue      callbacks.Queue
+	logger     *zap.Logger
+	now        func() time.Time
+
+	mu      sync.Mutex
+	opened  map[string]int64 // cases opened per rule since start
+	lastRun time.Time
+}
+
+// NewDetector creates a new detector. Alerts for new cases are queued on q
+// for the compliance alert sender.
+func NewDetector(repo repository.AnomalyRepository, configRepo repository.AppConfigRepository, q callbacks.Queue, logger *zap.Logger) *Detector {
+	return &Detector{
+		repo:       repo,
+		configRepo: configRepo,
+		queue:      q,
+		logger:     logger,
+		now:        time.Now,
+		opened:     make(map[string]int64),
+	}
+}
+
+// Settings loads the detection thresholds. Detection is on unless
+// anomaly.enabled is false; missing or non-positive thresholds use the
+// defaults.
+func (d *Detector) Settings(ctx context.Context) Settings {
+	s := Settings{Enabled: true}
+	if d.configRepo != nil {
+		if enabled, err := d.configRepo.GetBool(ctx, namespace, "enabled", 0); err == nil {
+			s.Enabled = enabled
+		}
+	}
+	s.RelayFailures = d.getConfigNumber(ctx, "relay_failures", defaultRelayFailures)
+	s.RelayFailureWindow = time.Duration(d.getConfigNumber(ctx, "relay_failure_minutes", defaultRelayFailureMinutes)) * time.Minute
+	s.RapidPayments = d.getConfigNumber(ctx, "rapid_payments", defaultRapidPayments)
+	s.RapidPaymentWindow = time.Duration(d.getConfigNumber(ctx, "rapid_payment_minutes", defaultRapidPaymentMinutes)) * time.Minute
+	s.BaselineHours = d.getConfigNumber(ctx, "baseline_hours", defaultBaselineHours)
+	s.SpikeSigmas = d.getConfigNumber(ctx, "spike_sigmas", defaultSpikeSigmas)
+	s.SpikeMinimum = d.getConfigNumber(ctx, "spike_minimum", defaultSpikeMinimum)
+	return s
+}
+
+// Run checks for anomalies every interval until ctx is cancelled
+func (d *Detector) Run(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	d.RunOnce(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			d.RunOnce(ctx)
+		}
+	}
+}
+
+// RunOnce performs a single detection pass, opening a case for each finding
+// whose rule and subject has none open
+func (d *Detector) RunOnce(ctx context.Context) RunStats {
+	start := d.now()
+	stats := RunStats{StartedAt: start}
+	s := d.Settings(ctx)
+	if !s.Enabled {
+		stats.Skipped = true
+		return stats
+	}
+
+	var findings []*repository.ComplianceCase
+	for _, detect := range []struct {
+		rule string
+		fn   func(context.Context, Settings, time.Time) ([]*repository.ComplianceCase, error)
+	}{
+		{RuleRelayFailures, d.relayFailures},
+		{RuleRapidPayments, d.rapidPayments},
+		{"volume_spike", d.volumeSpikes},
+	} {
+		found, err := detect.fn(ctx, s, start)
+		if err != nil {
+			stats.Errors = append(stats.Errors, detect.rule+": "+err.Error())
+			d.logger.Error("anomaly detection failed", zap.String("rule", detect.rule), zap.Error(err))
+			continue
+		}
+		findings = append(findings, found...)
+	}
+
+	stats.Findings = len(findings)
+	for _, c := range findings {
+		opened, err := d.repo.OpenComplianceCase(ctx, c)
+		if err != nil {
+			stats.Errors = append(stats.Errors, c.Rule+": "+err.Error())
+			d.logger.Error("failed to open compliance case", zap.String("rule", c.Rule), zap.String("subject", c.Subject), zap.Error(err))
+			continue
+		}
+		if !opened {
+			continue
+		}
+		stats.Opened++
+		d.mu.Lock()
+		d.opened[c.Rule]++
+		d.mu.Unlock()
+		d.logger.Warn("anomaly detected",
+			zap.String("case_id", c.ID),
+			zap.String("rule", c.Rule),
+			zap.String("subject", c.Subject),
+			zap.String("summary", c.Summary),
+		)
+		d.alert(ctx, c)
+	}
+
+	d.mu.Lock()
+	d.lastRun = start
+	d.mu.Unlock()
+	stats.Duration = time.Since(start).Round(time.Millisecond).String()
+	return stats
+}
+
+// relayFailures flags senders with many failed relays in the window
+func (d *Detector) relayFailures(ctx context.Context, s Settings, now time.Time) ([]*repository.ComplianceCase, error) {
+	counts, err := d.repo.CountFailedRelaysBySender(ctx, now.Add(-s.RelayFailureWindow), s.RelayFailures)
+	if err != nil {
+		return nil, err
+	}
+	var found []*repository.ComplianceCase
+	for _, c := range counts {
+		found = append(found, &repository.ComplianceCase{
+			Rule:    RuleRelayFailures,
+			Subject: c.Subject,
+			Summary: fmt.Sprintf("%d failed relays from %s in the last %s (threshold %d)",
+				c.Count, c.Subject, s.RelayFailureWindow, s.RelayFailures),
+			Observed:  float64(c.Count),
+			Threshold: float64(s.RelayFailures),
+		})
+	}
+	return found, nil
+}
+
+// rapidPayments flags payers with many payments in the window
+func (d *Detector) rapidPayments(ctx context.Context, s Settings, now time.Time) ([]*repository.ComplianceCase, error) {
+	counts, err := d.repo.CountPaymentsByPayer(ctx, now.Add(-s.RapidPaymentWindow), s.RapidPayments)
+	if err != nil {
+		return nil, err
+	}
+	var found []*repository.ComplianceCase
+	for _, c := range counts {
+		found = append(found, &repository.ComplianceCase{
+			Rule:    RuleRapidPayments,
+			Subject: c.Subject,
+			Summary: fmt.Sprintf("%d payments from %s in the last %s (threshold %d)",
+				c.Count, c.Subject, s.RapidPaymentWindow, s.RapidPayments),
+			Observed:  float64(c.Count),
+			Threshold: float64(s.RapidPayments),
+		})
+	}
+	return found, nil
+}
+
+// volumeSpikes compares the last full hour's payments and relays with the
+// hours before it. The subject is the hour, so each spike is one case.
+func (d *Detector) volumeSpikes(ctx context.Context, s Settings, now time.Time) ([]*repository.ComplianceCase, error) {
+	current := now.UTC().Truncate(time.Hour).Add(-time.Hour)
+	baselineStart := current.Add(-time.Duration(s.BaselineHours) * time.Hour)
+	hours, err := d.repo.HourlyActivity(ctx, baselineStart)
+	if err != nil {
+		return nil, err
+	}
+
+	payments := make([]float64, s.BaselineHours)
+	relays := make([]float64, s.BaselineHours)
+	var currentPayments, currentRelays float64
+	for _, h := range hours {
+		switch i := int64(h.Hour.Sub(baselineStart) / time.Hour); {
+		case h.Hour.Equal(current):
+			currentPayments, currentRelays = float64(h.Payments), float64(h.Relays)
+		case i >= 0 && i < s.BaselineHours:
+			payments[i], relays[i] = float64(h.Payments), float64(h.Relays)
+		}
+	}
+
+	var found []*repository.ComplianceCase
+	for _, v := range []struct {
+		rule     string
+		what     string
+		observed float64
+		baseline []float64
+	}{
+		{RulePaymentVolumeSpike, "payments", currentPayments, payments},
+		{RuleRelayVolumeSpike, "relays", currentRelays, relays},
+	} {
+		mean, stddev := meanStddev(v.baseline)
+		threshold := math.Max(mean+float64(s.SpikeSigmas)*stddev, float64(s.SpikeMinimum))
+		if v.observed < float64(s.SpikeMinimum) || v.observed <= mean+float64(s.SpikeSigmas)*stddev {
+			continue
+		}
+		found = append(found, &repository.ComplianceCase{
+			Rule:    v.rule,
+			Subject: current.Format(time.RFC3339),
+			Summary: fmt.Sprintf("%.0f %s in the hour from %s; the %d-hour baseline is %.1f ± %.1f per hour (threshold %.1f)",
+				v.observed, v.what, current.Format("2006-01-02 15:04 UTC"), s.BaselineHours, mean, stddev, threshold),
+			Observed:  v.observed,
+			Threshold: threshold,
+		})
+	}
+	return found, nil
+}
+
+// meanStddev returns the mean and population standard deviation of xs
+func meanStddev(xs []float64) (float64, float64) {
+	if len(xs) == 0 {
+		return 0, 0
+	}
+	var sum float64
+	for _, x := range xs {
+		sum += x
+	}
+	mean := sum / float64(len(xs))
+	var sq float64
+	for _, x := range xs {
+		sq += (x - mean) * (x - mean)
+	}
+	return mean, math.Sqrt(sq / float64(len(xs)))
+}
+
+// alert queues a compliance alert for a new case. The case is already
+// stored, so a queueing failure is logged rather than returned.
+func (d *Detector) alert(ctx context.Context, c *repository.ComplianceCase) {
+	if d.queue == nil {
+		return
+	}
+	a := Alert{
+		ID:         "anomaly:" + c.ID,
+		Type:       AlertDetected,
+		CaseID:     c.ID,
+		Rule:       c.Rule,
+		Subject:    c.Subject,
+		Summary:    c.Summary,
+		Observed:   c.Observed,
+		Threshold:  c.Threshold,
+		OccurredAt: c.OpenedAt.UTC(),
+	}
+	payload, err := json.Marshal(a)
+	if err == nil {
+		_, err = d.queue.Enqueue(ctx, &repository.WebhookEvent{
+			Provider:  repository.WebhookProviderComplianceAlert,
+			EventID:   a.ID,
+			EventType: a.Type,
+			Payload:   payload,
+		})
+	}
+	if err != nil {
+		d.logger.Error("failed to queue compliance alert", zap.String("case_id", c.ID), zap.Error(err))
+	}
+}
+
+// WriteMetrics implements kpi.Collector: cases opened per rule since start
+// (alert on their increase) and when detection last ran
+func (d *Detector) WriteMetrics(w io.Writer) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	fmt.Fprint(w, "# TYPE nexus_anomaly_cases_opened counter\n# HELP nexus_anomaly_cases_opened Compliance cases opened by anomaly detection since the server started.\n")
+	for _, rule := range rules {
+		fmt.Fprintf(w, "nexus_anomaly_cases_opened_total{rule=%q} %d\n", rule, d.opened[rule])
+	}
+	if !d.lastRun.IsZero() {
+		fmt.Fprint(w, "# TYPE nexus_anomaly_last_run_timestamp_seconds gauge\n# HELP nexus_anomaly_last_run_timestamp_seconds When anomaly detection last ran.\n")
+		fmt.Fprintf(w, "nexus_anomaly_last_run_timestamp_seconds %g\n", float64(d.lastRun.UnixMilli())/1000)
+	}
+}
+
+// getConfigNumber reads a positive anomaly config value with a code fallback
+func (d *Detector) getConfigNumber(ctx context.Context, key string, fallback int64) int64 {
+	if d.configRepo == nil {
+		return fallback
+	}
+	v, err := d.configRepo.GetNumber(ctx, namespace, key, 0)
+	if err != nil || v <= 0 {
+		return fallback
+	}
+	return v
+}
@@ -0,0 +1,156 @@
+package anomaly_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/anomaly"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/storage/memory"
+)
+
+// stubActivity returns fixed activity, keeping cases in the memory store
+type stubActivity struct {
+	repository.AnomalyRepository
+	payments []repository.SubjectCount
+	failures []repository.SubjectCount
+	hours    []repository.HourlyCount
+	err      error
+}
+
+func (s *stubActivity) CountPaymentsByPayer(ctx context.Context, since time.Time, min int64) ([]repository.SubjectCount, error) {
+	return s.payments, s.err
+}
+
+func (s *stubActivity) CountFailedRelaysBySender(ctx context.Context, since time.Time, min int64) ([]repository.SubjectCount, error) {
+	return s.failures, s.err
+}
+
+func (s *stubActivity) HourlyActivity(ctx context.Context, since time.Time) ([]repository.HourlyCount, error) {
+	return s.hours, s.err
+}
+
+// stubQueue records the queued alerts
+type stubQueue struct {
+	events []*repository.WebhookEvent
+}
+
+func (q *stubQueue) Enqueue(ctx context.Context, e *repository.WebhookEvent) (bool, error) {
+	q.events = append(q.events, e)
+	return true, nil
+}
+
+func newStub() *stubActivity {
+	store := memory.NewStore()
+	return &stubActivity{AnomalyRepository: store.Anomalies}
+}
+
+func TestDetector_OpensOneCasePerSubject(t *testing.T) {
+	repo := newStub()
+	repo.failures = []repository.SubjectCount{{Subject: "0xsender", Count: 12}}
+	repo.payments = []repository.SubjectCount{{Subject: "0xpayer", Count: 30}}
+	q := &stubQueue{}
+	d := anomaly.NewDetector(repo, nil, q, zap.NewNop())
+
+	stats := d.RunOnce(context.Background())
+	assert.Equal(t, 2, stats.Findings)
+	assert.Equal(t, 2, stats.Opened)
+	assert.Empty(t, stats.Errors)
+	require.Len(t, q.events, 2)
+	assert.Equal(t, repository.WebhookProviderComplianceAlert, q.events[0].Provider)
+	assert.Equal(t, anomaly.AlertDetected, q.events[0].EventType)
+
+	var alert anomaly.Alert
+	require.NoError(t, json.Unmarshal(q.events[0].Payload, &alert))
+	assert.Equal(t, anomaly.RuleRelayFailures, alert.Rule)
+	assert.Equal(t, "0xsender", alert.Subject)
+	assert.Equal(t, float64(12), alert.Observed)
+	assert.Equal(t, float64(10), alert.Threshold)
+	assert.Equal(t, "anomaly:"+alert.CaseID, q.events[0].EventID)
+
+	// The cases stay open, so the next pass alerts nobody
+	stats = d.RunOnce(context.Background())
+	assert.Equal(t, 2, stats.Findings)
+	assert.Equal(t, 0, stats.Opened)
+	assert.Len(t, q.events, 2)
+
+	var buf bytes.Buffer
+	d.WriteMetrics(&buf)
+	assert.Contains(t, buf.String(), `nexus_anomaly_cases_opened_total{rule="relay_failures"} 1`)
+	assert.Contains(t, buf.String(), `nexus_anomaly_cases_opened_total{rule="relay_volume_spike"} 0`)
+	assert.Contains(t, buf.String(), "nexus_anomaly_last_run_timestamp_seconds ")
+}
+
+func TestDetector_VolumeSpike(t *testing.T) {
+	repo := newStub()
+	current := time.Now().UTC().Truncate(time.Hour).Add(-time.Hour)
+	for i := 1; i <= 168; i++ {
+		repo.hours = append(repo.hours, repository.HourlyCount{
+			Hour:     current.Add(-time.Duration(i) * time.Hour),
+			Payments: int64(10 + i%3),
+			Relays:   int64(40 + i%5),
+		})
+	}
+	repo.hours = append(repo.hours, repository.HourlyCount{Hour: current, Payments: 80, Relays: 44})
+	q := &stubQueue{}
+
+	stats := anomaly.NewDetector(repo, nil, q, zap.NewNop()).RunOnce(context.Background())
+	require.Equal(t, 1, stats.Opened, "only payments spiked")
+	require.Len(t, q.events, 1)
+
+	var alert anomaly.Alert
+	require.NoError(t, json.Unmarshal(q.events[0].Payload, &alert))
+	assert.Equal(t, anomaly.RulePaymentVolumeSpike, alert.Rule)
+	assert.Equal(t, current.Format(time.RFC3339), alert.Subject)
+	assert.Equal(t, float64(80), alert.Observed)
+	assert.Equal(t, float64(50), alert.Threshold, "the minimum exceeds mean + 4σ")
+}
+
+func TestDetector_QuietHoursBelowMinimum(t *testing.T) {
+	repo := newStub()
+	current := time.Now().UTC().Truncate(time.Hour).Add(-time.Hour)
+	// No baseline at all: a handful of payments is not a spike
+	repo.hours = []repository.HourlyCount{{Hour: current, Payments: 5, Relays: 3}}
+
+	stats := anomaly.NewDetector(repo, nil, &stubQueue{}, zap.NewNop()).RunOnce(context.Background())
+	assert.Equal(t, 0, stats.Findings)
+}
+
+func TestDetector_ConfigThresholdsAndDisable(t *testing.T) {
+	ctx := context.Background()
+	cfg := memory.NewMemoryAppConfigRepo()
+	n := int64(3)
+	require.NoError(t, cfg.Create(ctx, &repository.AppConfigCreate{
+		Namespace: "anomaly", ConfigKey: "relay_failures", ValueType: "number", ValueNumber: &n,
+	}))
+	d := anomaly.NewDetector(newStub(), cfg, &stubQueue{}, zap.NewNop())
+
+	s := d.Settings(ctx)
+	assert.True(t, s.Enabled)
+	assert.Equal(t, int64(3), s.RelayFailures)
+	assert.Equal(t, int64(20), s.RapidPayments)
+	assert.Equal(t, time.Hour, s.RelayFailureWindow)
+
+	off := false
+	require.NoError(t, cfg.Create(ctx, &repository.AppConfigCreate{
+		Namespace: "anomaly", ConfigKey: "enabled", ValueType: "boolean", ValueBoolean: &off,
+	}))
+	assert.True(t, d.RunOnce(ctx).Skipped)
+}
+
+func TestDetector_ReportsErrors(t *testing.T) {
+	repo := newStub()
+	repo.err = errors.New("connection refused")
+
+	stats := anomaly.NewDetector(repo, nil, &stubQueue{}, zap.NewNop()).RunOnce(context.Background())
+	require.Len(t, stats.Errors, 3)
+	assert.Contains(t, stats.Errors[0], "relay_failures: connection refused")
+}
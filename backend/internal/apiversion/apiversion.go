@@ -0,0 +1,275 @@
+// Package apiversion registers the API's versioned route groups
+// (/api/v1, /api/v2, ...), records the version serving each request for the
+// handlers' serializers, negotiates a version for unversioned /api paths and
+// announces deprecation of old versions via Deprecation, Sunset and Link
+// headers
+package apiversion
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Version is a major API version
+type Version int
+
+const (
+	V1 Version = 1
+	V2 Version = 2
+
+	// DefaultVersion serves unversioned requests that do not ask for a
+	// version: the contract existing clients were written against
+	DefaultVersion = V1
+)
+
+// Header names
+const (
+	// Header selects the version of an unversioned /api request and reports
+	// the version that served every versioned response
+	Header = "API-Version"
+
+	headerDeprecation = "Deprecation"
+	headerSunset      = "Sunset"
+	headerLink        = "Link"
+)
+
+// contextKey holds the request's Version in the gin context
+const contextKey = "api_version"
+
+// String returns the path segment for v, e.g. "v2"
+func (v Version) String() string {
+	return "v" + strconv.Itoa(int(v))
+}
+
+// Prefix returns the route prefix for v, e.g. "/api/v2"
+func (v Version) Prefix() string {
+	return "/api/" + v.String()
+}
+
+// Parse parses "2" or "v2"
+func Parse(s string) (Version, bool) {
+	s = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(s)), "v")
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 1 {
+		return 0, false
+	}
+	return Version(n), true
+}
+
+// FromContext returns the version serving the request (DefaultVersion
+// outside a versioned group)
+func FromContext(c *gin.Context) Version {
+	if v, ok := c.Get(contextKey); ok {
+		if version, ok := v.(Version); ok {
+			return version
+		}
+	}
+	return DefaultVersion
+}
+
+// Policy describes a version's lifecycle
+type Policy struct {
+	// DeprecatedAt is when the version was (or will be) deprecated; zero if
+	// it is not deprecated
+	DeprecatedAt time.Time
+
+	// SunsetAt is when the version stops being served; zero if not scheduled
+	SunsetAt time.Time
+
+	// Link points to the deprecation notice or migration guide
+	Link string
+}
+
+// Deprecated reports whether the policy announces deprecation
+func (p Policy) Deprecated() bool {
+	return !p.DeprecatedAt.IsZero() || !p.SunsetAt.IsZero()
+}
+
+// Registry creates the versioned route groups on a router
+type Registry struct {
+	engine *gin.Engine
+
+	mu       sync.RWMutex
+	policies map[Version]Policy
+
+	// successors maps "METHOD route" to the newest later version serving
+	// the same route, built from the engine's routes on first use
+	successorsOnce sync.Once
+	successors     map[string]Version
+}
+
+// NewRegistry creates a registry for the engine's versioned routes
+func NewRegistry(engine *gin.Engine) *Registry {
+	return &Registry{
+		engine:   engine,
+		policies: make(map[Version]Policy),
+	}
+}
+
+// Group creates the route group for v. Handlers in the group see v via
+// FromContext, responses carry the API-Version header and, once the policy
+// announces deprecation, Deprecation/Sunset headers and a successor-version
+// Link when a later version serves the same route.
+func (r *Registry) Group(v Version, policy Policy, middleware ...gin.HandlerFunc) *gin.RouterGroup {
+	r.mu.Lock()
+	r.policies[v] = policy
+	r.mu.Unlock()
+
+	handlers := append([]gin.HandlerFunc{r.versionHandler(v, policy)}, middleware...)
+	return r.engine.Group(v.Prefix(), handlers...)
+}
+
+// Versions returns the registered versions, oldest first
+func (r *Registry) Versions() []Version {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	versions := make([]Version, 0, len(r.policies))
+	for v := range r.policies {
+		versions = append(versions, v)
+	}
+	slices.Sort(versions)
+	return versions
+}
+
+// supports reports whether v has a registered group
+func (r *Registry) supports(v Version) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	_, ok := r.policies[v]
+	return ok
+}
+
+// versionHandler tags the request with v and sets the lifecycle headers
+func (r *Registry) versionHandler(v Version, policy Policy) gin.HandlerFunc {
+	var deprecation, sunset string
+	if !policy.DeprecatedAt.IsZero() {
+		// RFC 9745: structured-field date
+		deprecation = "@" + strconv.FormatInt(policy.DeprecatedAt.Unix(), 10)
+	}
+	if !policy.SunsetAt.IsZero() {
+		// RFC 8594: HTTP-date
+		sunset = policy.SunsetAt.UTC().Format(http.TimeFormat)
+	}
+
+	return func(c *gin.Context) {
+		c.Set(contextKey, v)
+		header := c.Writer.Header()
+		header.Set(Header, v.String())
+
+		if !policy.Deprecated() {
+			c.Next()
+			return
+		}
+		if deprecation != "" {
+			header.Set(headerDeprecation, deprecation)
+		}
+		if sunset != "" {
+			header.Set(headerSunset, sunset)
+		}
+		if policy.Link != "" {
+			header.Add(headerLink, fmt.Sprintf(`<%s>; rel="deprecation"`, policy.Link))
+		}
+		if next, ok := r.successor(c.Request.Method, c.FullPath(), v); ok {
+			path := next.Prefix() + strings.TrimPrefix(c.Request.URL.Path, v.Prefix())
+			header.Add(headerLink, fmt.Sprintf(`<%s>; rel="successor-version"`, path))
+		}
+		c.Next()
+	}
+}
+
+// successor returns the newest version after v that serves the same route
+func (r *Registry) successor(method, route string, v Version) (Version, bool) {
+	r.successorsOnce.Do(r.buildSuccessors)
+
+	rest, ok := strings.CutPrefix(route, v.Prefix())
+	if !ok {
+		return 0, false
+	}
+	next, ok := r.successors[method+" "+rest]
+	return next, ok && next > v
+}
+
+// buildSuccessors indexes the engine's routes by their path within a
+// version, keeping the newest version for each
+func (r *Registry) buildSuccessors() {
+	r.successors = make(map[string]Version)
+	for _, route := range r.engine.Routes() {
+		for _, v := range r.Versions() {
+			if rest, ok := strings.CutPrefix(route.Path, v.Prefix()); ok && (rest == "" || rest[0] == '/') {
+				key := route.Method + " " + rest
+				if v > r.successors[key] {
+					r.successors[key] = v
+				}
+			}
+		}
+	}
+}
+
+// acceptVersion matches version-specific media types such as
+// application/vnd.nexus.v2+json
+var acceptVersion = regexp.MustCompile(`application/vnd\.nexus\.v(\d+)\+json`)
+
+// versionSegment matches a version path segment ("v1", "v2")
+var versionSegment = regexp.MustCompile(`^v\d+$`)
+
+// Negotiate serves unversioned /api/... requests from a versioned group:
+// the version named by the API-Version header or an
+// application/vnd.nexus.vN+json Accept type, else DefaultVersion. Requests for
+// an unregistered version get 400. Versioned paths pass through unchanged.
+func (r *Registry) Negotiate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		rest, ok := strings.CutPrefix(req.URL.Path, "/api/")
+		if !ok {
+			next.ServeHTTP(w, req)
+			return
+		}
+		if segment, _, _ := strings.Cut(rest, "/"); versionSegment.MatchString(segment) {
+			next.ServeHTTP(w, req)
+			return
+		}
+
+		v, ok := requestedVersion(req)
+		if !ok || !r.supports(v) {
+			writeUnsupported(w, r.Versions())
+			return
+		}
+
+		// The response depends on the negotiation headers, not just the URL
+		w.Header().Add("Vary", Header+", Accept")
+		req.URL.Path = v.Prefix() + "/" + rest
+		req.URL.RawPath = ""
+		next.ServeHTTP(w, req)
+	})
+}
+
+// requestedVersion reads the version a request asks for. ok is false if it
+// names a version that cannot be parsed.
+func requestedVersion(req *http.Request) (Version, bool) {
+	if h := req.Header.Get(Header); h != "" {
+		return Parse(h)
+	}
+	if m := acceptVersion.FindStringSubmatch(req.Header.Get("Accept")); m != nil {
+		return Parse(m[1])
+	}
+	return DefaultVersion, true
+}
+
+// writeUnsupported answers a request for a version that is not served
+func writeUnsupported(w http.ResponseWriter, versions []Version) {
+	names := make([]string, len(versions))
+	for i, v := range versions {
+		names[i] = v.String()
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusBadRequest)
+	fmt.Fprintf(w, `{"success":false,"error":"Unsupported API version (supported: %s)"}`, strings.Join(names, ", "))
+}
@@ -0,0 +1,134 @@
+package apiversion_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/apiversion"
+)
+
+// setupRouter serves GET /payments/:id on v1 and v2 and GET /kyc/status on
+// v1 only, echoing the version each handler sees
+func setupRouter(v1 apiversion.Policy) (*gin.Engine, *apiversion.Registry) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	versions := apiversion.NewRegistry(router)
+
+	echo := func(c *gin.Context) {
+		c.String(http.StatusOK, apiversion.FromContext(c).String())
+	}
+	api := versions.Group(apiversion.V1, v1)
+	api.GET("/payments/:id", echo)
+	api.GET("/kyc/status", echo)
+	versions.Group(apiversion.V2, apiversion.Policy{}).GET("/payments/:id", echo)
+	return router, versions
+}
+
+func TestParse(t *testing.T) {
+	for _, s := range []string{"2", "v2", " V2 "} {
+		v, ok := apiversion.Parse(s)
+		assert.True(t, ok, s)
+		assert.Equal(t, apiversion.V2, v, s)
+	}
+	for _, s := range []string{"", "v", "two", "0", "-1"} {
+		_, ok := apiversion.Parse(s)
+		assert.False(t, ok, s)
+	}
+	assert.Equal(t, "/api/v2", apiversion.V2.Prefix())
+}
+
+func TestGroup_DeprecationHeaders(t *testing.T) {
+	deprecatedAt := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+	sunsetAt := time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)
+	router, _ := setupRouter(apiversion.Policy{
+		DeprecatedAt: deprecatedAt,
+		SunsetAt:     sunsetAt,
+		Link:         "https://docs.example.com/api/v2-migration",
+	})
+
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/api/v1/payments/pay_1", nil))
+	require.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, "v1", resp.Body.String())
+	assert.Equal(t, "v1", resp.Header().Get(apiversion.Header))
+	assert.Equal(t, "@1782864000", resp.Header().Get("Deprecation"))
+	assert.Equal(t, "Fri, 01 Jan 2027 00:00:00 GMT", resp.Header().Get("Sunset"))
+	assert.Equal(t, []string{
+		`<https://docs.example.com/api/v2-migration>; rel="deprecation"`,
+		`</api/v2/payments/pay_1>; rel="successor-version"`,
+	}, resp.Header().Values("Link"))
+
+	// No v2 counterpart: deprecated without a successor link
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/api/v1/kyc/status", nil))
+	assert.Equal(t, "@1782864000", resp.Header().Get("Deprecation"))
+	assert.Equal(t, []string{`<https://docs.example.com/api/v2-migration>; rel="deprecation"`}, resp.Header().Values("Link"))
+
+	// The current version carries only its version
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/api/v2/payments/pay_1", nil))
+	assert.Equal(t, "v2", resp.Body.String())
+	assert.Equal(t, "v2", resp.Header().Get(apiversion.Header))
+	assert.Empty(t, resp.Header().Get("Deprecation"))
+	assert.Empty(t, resp.Header().Get("Sunset"))
+	assert.Empty(t, resp.Header().Values("Link"))
+}
+
+func TestGroup_NoHeadersWithoutPolicy(t *testing.T) {
+	router, _ := setupRouter(apiversion.Policy{})
+
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/api/v1/payments/pay_1", nil))
+	assert.Equal(t, "v1", resp.Header().Get(apiversion.Header))
+	assert.Empty(t, resp.Header().Get("Deprecation"))
+	assert.Empty(t, resp.Header().Values("Link"))
+}
+
+func TestNegotiate(t *testing.T) {
+	router, versions := setupRouter(apiversion.Policy{})
+	handler := versions.Negotiate(router)
+
+	tests := []struct {
+		name    string
+		path    string
+		headers map[string]string
+		status  int
+		body    string
+	}{
+		{name: "default version", path: "/api/payments/pay_1", status: http.StatusOK, body: "v1"},
+		{name: "version header", path: "/api/payments/pay_1", headers: map[string]string{"API-Version": "2"}, status: http.StatusOK, body: "v2"},
+		{name: "accept media type", path: "/api/payments/pay_1", headers: map[string]string{"Accept": "application/vnd.nexus.v2+json"}, status: http.StatusOK, body: "v2"},
+		{name: "header wins over accept", path: "/api/payments/pay_1", headers: map[string]string{"API-Version": "v1", "Accept": "application/vnd.nexus.v2+json"}, status: http.StatusOK, body: "v1"},
+		{name: "versioned path is not rewritten", path: "/api/v1/payments/pay_1", headers: map[string]string{"API-Version": "2"}, status: http.StatusOK, body: "v1"},
+		{name: "unsupported version", path: "/api/payments/pay_1", headers: map[string]string{"API-Version": "9"}, status: http.StatusBadRequest,
+			body: `{"success":false,"error":"Unsupported API version (supported: v1, v2)"}`},
+		{name: "invalid version", path: "/api/payments/pay_1", headers: map[string]string{"API-Version": "latest"}, status: http.StatusBadRequest,
+			body: `{"success":false,"error":"Unsupported API version (supported: v1, v2)"}`},
+		{name: "route missing from negotiated version", path: "/api/kyc/status", headers: map[string]string{"API-Version": "2"}, status: http.StatusNotFound, body: "404 page not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+			resp := httptest.NewRecorder()
+			handler.ServeHTTP(resp, req)
+
+			assert.Equal(t, tt.status, resp.Code)
+			assert.Equal(t, tt.body, resp.Body.String())
+		})
+	}
+
+	// Negotiated responses vary on the negotiation headers
+	resp := httptest.NewRecorder()
+	handler.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/api/payments/pay_1", nil))
+	assert.Equal(t, "API-Version, Accept", resp.Header().Get("Vary"))
+}
@@ -0,0 +1,115 @@
+package auth
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// messageHeader ends the first line of an EIP-4361 message
+const messageHeader = " wants you to sign in with your Ethereum account:"
+
+// Message is a parsed EIP-4361 sign-in message
+type Message struct {
+	Domain         string
+	Address        string
+	Statement      string
+	URI            string
+	Version        string
+	ChainID        int64
+	Nonce          string
+	IssuedAt       time.Time
+	ExpirationTime *time.Time
+	NotBefore      *time.Time
+	RequestID      string
+	Resources      []string
+}
+
+// ParseMessage parses an EIP-4361 message. URI, Version (1), Chain ID, Nonce
+// and Issued At are required.
+func ParseMessage(text string) (*Message, error) {
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	if len(lines) < 2 || !strings.HasSuffix(lines[0], messageHeader) {
+		return nil, fmt.Errorf("%w: missing header", ErrInvalidMessage)
+	}
+
+	m := &Message{
+		Domain:  strings.TrimSuffix(lines[0], messageHeader),
+		Address: lines[1],
+	}
+	if m.Domain == "" || strings.ContainsAny(m.Domain, " \t") {
+		return nil, fmt.Errorf("%w: invalid domain", ErrInvalidMessage)
+	}
+	if !common.IsHexAddress(m.Address) || !strings.HasPrefix(m.Address, "0x") {
+		return nil, fmt.Errorf("%w: invalid address", ErrInvalidMessage)
+	}
+
+	var statement []string
+	inResources := false
+	for _, line := range lines[2:] {
+		key, value, ok := strings.Cut(line, ": ")
+		if inResources {
+			if resource, ok := strings.CutPrefix(line, "- "); ok {
+				m.Resources = append(m.Resources, resource)
+				continue
+			}
+			inResources = false
+		}
+
+		var err error
+		switch {
+		case line == "Resources:":
+			inResources = true
+		case ok && key == "URI":
+			m.URI = value
+		case ok && key == "Version":
+			m.Version = value
+		case ok && key == "Chain ID":
+			m.ChainID, err = strconv.ParseInt(value, 10, 64)
+		case ok && key == "Nonce":
+			m.Nonce = value
+		case ok && key == "Issued At":
+			m.IssuedAt, err = time.Parse(time.RFC3339, value)
+		case ok && key == "Expiration Time":
+			m.ExpirationTime, err = parseTimePtr(value)
+		case ok && key == "Not Before":
+			m.NotBefore, err = parseTimePtr(value)
+		case ok && key == "Request ID":
+			m.RequestID = value
+		case m.URI == "":
+			// The statement sits between the address and the fields
+			statement = append(statement, line)
+		default:
+			return nil, fmt.Errorf("%w: unexpected line %q", ErrInvalidMessage, line)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid %s", ErrInvalidMessage, key)
+		}
+	}
+	m.Statement = strings.TrimSpace(strings.Join(statement, "\n"))
+
+	switch {
+	case m.URI == "":
+		return nil, fmt.Errorf("%w: missing URI", ErrInvalidMessage)
+	case m.Version != "1":
+		return nil, fmt.Errorf("%w: unsupported version", ErrInvalidMessage)
+	case m.ChainID <= 0:
+		return nil, fmt.Errorf("%w: missing chain ID", ErrInvalidMessage)
+	case len(m.Nonce) < 8:
+		return nil, fmt.Errorf("%w: missing nonce", ErrInvalidMessage)
+	case m.IssuedAt.IsZero():
+		return nil, fmt.Errorf("%w: missing issued-at time", ErrInvalidMessage)
+	}
+	return m, nil
+}
+
+func parseTimePtr(value string) (*time.Time, error) {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
@@ -0,0 +1,296 @@
+// Package auth signs users in with their wallet (Sign-In with Ethereum,
+// EIP-4361) and issues the session tokens that authenticate self-service
+// endpoints. Nonces and sessions are HMAC-signed with SESSION_SIGNING_KEY, so
+// any instance sharing the key accepts them; without a key a random one is
+// generated per process and sessions end on restart. The settings are read
+// from app_config namespace "auth".
+package auth
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// namespace is the app_config namespace holding the sign-in settings
+const namespace = "auth"
+
+// Defaults for settings missing from app_config
+const (
+	defaultNonceTTL   = 10 * time.Minute
+	defaultSessionTTL = 24 * time.Hour
+)
+
+// maxUsedNonces bounds the set of nonces already signed in with
+const maxUsedNonces = 100000
+
+// Sign-in errors
+var (
+	ErrInvalidMessage   = errors.New("invalid sign-in message")
+	ErrInvalidSignature = errors.New("signature does not match the message address")
+	ErrInvalidNonce     = errors.New("nonce is invalid, expired or already used")
+	ErrDomainMismatch   = errors.New("sign-in message is for another domain")
+	ErrMessageExpired   = errors.New("sign-in message is expired or not yet valid")
+	ErrInvalidSession   = errors.New("invalid or expired session")
+	// ErrDomainNotConfigured refuses sign-in until auth.siwe_domain is set:
+	// checking messages against the request's Host would let another site
+	// replay a signature it collected for itself
+	ErrDomainNotConfigured = errors.New("sign-in is not configured: auth.siwe_domain is unset")
+)
+
+// Settings are the sign-in settings in effect
+type Settings struct {
+	// Domain the messages must be for; sign-in is refused while it is empty
+	Domain     string        `json:"domain"`
+	NonceTTL   time.Duration `json:"nonce_ttl"`
+	SessionTTL time.Duration `json:"session_ttl"`
+}
+
+// Session is a signed-in address and its bearer token
+type Session struct {
+	Token     string    `json:"token"`
+	Address   string    `json:"address"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// Service issues nonces, verifies signed sign-in messages and authenticates
+// session tokens
+type Service struct {
+	configRepo  repository.AppConfigRepository
+	signingKey  func() string
+	fallbackKey []byte
+	logger      *zap.Logger
+	now         func() time.Time
+
+	mu   sync.Mutex
+	used map[string]time.Time // nonce -> its expiry
+}
+
+// NewService creates a sign-in service. signingKey is read on every call, so
+// a rotated key applies without a restart (and ends existing sessions).
+func NewService(configRepo repository.AppConfigRepository, signingKey func() string, logger *zap.Logger) *Service {
+	fallback := make([]byte, 32)
+	if _, err := rand.Read(fallback); err != nil {
+		panic(fmt.Sprintf("auth: generating session key: %v", err))
+	}
+	return &Service{
+		configRepo:  configRepo,
+		signingKey:  signingKey,
+		fallbackKey: fallback,
+		logger:      logger,
+		now:         time.Now,
+		used:        make(map[string]time.Time),
+	}
+}
+
+// Settings loads the sign-in settings, falling back to defaults for missing
+// or unreadable ones
+func (s *Service) Settings(ctx context.Context) Settings {
+	settings := Settings{NonceTTL: defaultNonceTTL, SessionTTL: defaultSessionTTL}
+	if domain, err := s.configRepo.GetString(ctx, namespace, "siwe_domain", 0); err == nil {
+		settings.Domain = strings.TrimSpace(domain)
+	}
+	if seconds, err := s.configRepo.GetNumber(ctx, namespace, "nonce_seconds", 0); err == nil && seconds > 0 {
+		settings.NonceTTL = time.Duration(seconds) * time.Second
+	}
+	if seconds, err := s.configRepo.GetNumber(ctx, namespace, "session_seconds", 0); err == nil && seconds > 0 {
+		settings.SessionTTL = time.Duration(seconds) * time.Second
+	}
+	return settings
+}
+
+// key returns the HMAC key in effect
+func (s *Service) key() []byte {
+	if key := s.signingKey(); key != "" {
+		return []byte(key)
+	}
+	return s.fallbackKey
+}
+
+// mac signs a purpose-prefixed payload, so a nonce can't pass as a session
+func (s *Service) mac(purpose string, payload []byte) []byte {
+	h := hmac.New(sha256.New, s.key())
+	h.Write([]byte(purpose))
+	h.Write(payload)
+	return h.Sum(nil)
+}
+
+// Nonce returns a fresh nonce for a sign-in message. It carries its own
+// expiry and signature, so nothing is stored until it is used.
+func (s *Service) Nonce(ctx context.Context) (string, time.Time, error) {
+	expires := s.now().Add(s.Settings(ctx).NonceTTL).UTC().Truncate(time.Second)
+
+	payload := make([]byte, 24)
+	if _, err := rand.Read(payload[:16]); err != nil {
+		return "", time.Time{}, fmt.Errorf("generating nonce: %w", err)
+	}
+	binary.BigEndian.PutUint64(payload[16:], uint64(expires.Unix()))
+	// Hex keeps the nonce alphanumeric, as EIP-4361 requires
+	return hex.EncodeToString(append(payload, s.mac("nonce:", payload)[:16]...)), expires, nil
+}
+
+// checkNonce verifies a nonce's signature and expiry and returns the expiry
+func (s *Service) checkNonce(nonce string) (time.Time, error) {
+	raw, err := hex.DecodeString(nonce)
+	if err != nil || len(raw) != 40 {
+		return time.Time{}, ErrInvalidNonce
+	}
+	payload, sig := raw[:24], raw[24:]
+	if !hmac.Equal(sig, s.mac("nonce:", payload)[:16]) {
+		return time.Time{}, ErrInvalidNonce
+	}
+	expires := time.Unix(int64(binary.BigEndian.Uint64(payload[16:])), 0)
+	if !s.now().Before(expires) {
+		return time.Time{}, ErrInvalidNonce
+	}
+	return expires, nil
+}
+
+// useNonce marks a nonce used, failing if it already was. The set only
+// holds unexpired nonces; expired ones fail checkNonce anyway.
+func (s *Service) useNonce(nonce string, expires time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.used[nonce]; ok {
+		return ErrInvalidNonce
+	}
+	if len(s.used) >= maxUsedNonces {
+		now := s.now()
+		for n, exp := range s.used {
+			if !now.Before(exp) {
+				delete(s.used, n)
+			}
+		}
+		if len(s.used) >= maxUsedNonces {
+			return ErrInvalidNonce
+		}
+	}
+	s.used[nonce] = expires
+	return nil
+}
+
+// SignIn verifies a signed sign-in message for auth.siwe_domain and starts a
+// session for its address. Only EOA signatures are accepted; contract
+// wallets (EIP-1271) are not.
+func (s *Service) SignIn(ctx context.Context, message, signature string) (*Session, error) {
+	settings := s.Settings(ctx)
+	if settings.Domain == "" {
+		return nil, ErrDomainNotConfigured
+	}
+	m, err := ParseMessage(message)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(m.Domain, settings.Domain) {
+		return nil, ErrDomainMismatch
+	}
+
+	now := s.now()
+	if (m.ExpirationTime != nil && !now.Before(*m.ExpirationTime)) || (m.NotBefore != nil && now.Before(*m.NotBefore)) {
+		return nil, ErrMessageExpired
+	}
+
+	nonceExpires, err := s.checkNonce(m.Nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	signer, err := RecoverSigner(message, signature)
+	if err != nil {
+		return nil, err
+	}
+	if signer != common.HexToAddress(m.Address) {
+		return nil, ErrInvalidSignature
+	}
+
+	if err := s.useNonce(m.Nonce, nonceExpires); err != nil {
+		return nil, err
+	}
+
+	address := strings.ToLower(m.Address)
+	expires := now.Add(settings.SessionTTL).UTC().Truncate(time.Second)
+	if m.ExpirationTime != nil && m.ExpirationTime.Before(expires) {
+		expires = m.ExpirationTime.UTC().Truncate(time.Second)
+	}
+	payload := address + "." + strconv.FormatInt(expires.Unix(), 10)
+
+	s.logger.Info("wallet signed in", zap.String("address", address), zap.Int64("chain_id", m.ChainID))
+	return &Session{
+		Token:     payload + "." + hex.EncodeToString(s.mac("session:", []byte(payload))),
+		Address:   address,
+		ExpiresAt: expires,
+	}, nil
+}
+
+// Authenticate returns the address a session token was issued to
+func (s *Service) Authenticate(token string) (string, error) {
+	i := strings.LastIndexByte(token, '.')
+	if i < 0 {
+		return "", ErrInvalidSession
+	}
+	payload, sig := token[:i], token[i+1:]
+
+	mac, err := hex.DecodeString(sig)
+	if err != nil || !hmac.Equal(mac, s.mac("session:", []byte(payload))) {
+		return "", ErrInvalidSession
+	}
+
+	address, expiry, ok := strings.Cut(payload, ".")
+	if !ok {
+		return "", ErrInvalidSession
+	}
+	unix, err := strconv.ParseInt(expiry, 10, 64)
+	if err != nil || !s.now().Before(time.Unix(unix, 0)) {
+		return "", ErrInvalidSession
+	}
+	return address, nil
+}
+
+// SignLink signs the payload of a link that stands in for a session, such
+// as a data export download. Rotating the signing key invalidates links
+// along with sessions.
+func (s *Service) SignLink(payload string) string {
+	return hex.EncodeToString(s.mac("link:", []byte(payload)))
+}
+
+// VerifyLink reports whether signature was issued by SignLink for payload
+func (s *Service) VerifyLink(payload, signature string) bool {
+	mac, err := hex.DecodeString(signature)
+	return err == nil && hmac.Equal(mac, s.mac("link:", []byte(payload)))
+}
+
+// RecoverSigner returns the address that personal_sign-ed message, or
+// ErrInvalidSignature
+func RecoverSigner(message, signature string) (common.Address, error) {
+	sig, err := hex.DecodeString(strings.TrimPrefix(signature, "0x"))
+	if err != nil || len(sig) != crypto.SignatureLength {
+		return common.Address{}, ErrInvalidSignature
+	}
+	// Wallets produce v as 27/28; recovery wants 0/1
+	if sig[crypto.RecoveryIDOffset] >= 27 {
+		sig[crypto.RecoveryIDOffset] -= 27
+	}
+
+	pub, err := crypto.SigToPub(accounts.TextHash([]byte(message)), sig)
+	if err != nil {
+		return common.Address{}, ErrInvalidSignature
+	}
+	return crypto.PubkeyToAddress(*pub), nil
+}
@@ -0,0 +1,221 @@
+package auth_test
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/auth"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/storage/memory"
+)
+
+const domain = "app.nexus.example"
+
+// newService creates a service for domain
+func newService(key string) *auth.Service {
+	configs := memory.NewStore().AppConfig
+	siweDomain := domain
+	err := configs.Create(context.Background(), &repository.AppConfigCreate{
+		Namespace: "auth", ConfigKey: "siwe_domain", ValueType: "string", ValueString: &siweDomain,
+	})
+	if err != nil {
+		panic(err)
+	}
+	return auth.NewService(configs, func() string { return key }, zap.NewNop())
+}
+
+// message builds an EIP-4361 message for address on domain
+func message(address, nonce string, extra ...string) string {
+	return messageFor(domain, address, nonce, extra...)
+}
+
+// messageFor builds an EIP-4361 message for address on site
+func messageFor(site, address, nonce string, extra ...string) string {
+	lines := []string{
+		site + " wants you to sign in with your Ethereum account:",
+		address,
+		"",
+		"Sign in to Nexus.",
+		"",
+		"URI: https://" + site,
+		"Version: 1",
+		"Chain ID: 1",
+		"Nonce: " + nonce,
+		"Issued At: " + time.Now().UTC().Format(time.RFC3339),
+	}
+	return strings.Join(append(lines, extra...), "\n")
+}
+
+// wallet signs messages like personal_sign
+type wallet struct {
+	address string
+	sign    func(string) string
+}
+
+func newWallet(t *testing.T) wallet {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	return wallet{
+		address: crypto.PubkeyToAddress(key.PublicKey).Hex(),
+		sign: func(msg string) string {
+			sig, err := crypto.Sign(accounts.TextHash([]byte(msg)), key)
+			require.NoError(t, err)
+			sig[crypto.RecoveryIDOffset] += 27
+			return "0x" + hex.EncodeToString(sig)
+		},
+	}
+}
+
+func TestSignIn(t *testing.T) {
+	ctx := context.Background()
+	svc := newService("test-key")
+	w := newWallet(t)
+
+	nonce, expires, err := svc.Nonce(ctx)
+	require.NoError(t, err)
+	assert.True(t, expires.After(time.Now()))
+
+	msg := message(w.address, nonce)
+	session, err := svc.SignIn(ctx, msg, w.sign(msg))
+	require.NoError(t, err)
+	assert.Equal(t, strings.ToLower(w.address), session.Address)
+	assert.WithinDuration(t, time.Now().Add(24*time.Hour), session.ExpiresAt, time.Minute)
+
+	address, err := svc.Authenticate(session.Token)
+	require.NoError(t, err)
+	assert.Equal(t, session.Address, address)
+
+	// A nonce signs in once
+	_, err = svc.SignIn(ctx, msg, w.sign(msg))
+	assert.ErrorIs(t, err, auth.ErrInvalidNonce)
+}
+
+func TestSignIn_Rejects(t *testing.T) {
+	ctx := context.Background()
+	svc := newService("test-key")
+	w, other := newWallet(t), newWallet(t)
+	nonce := func() string {
+		n, _, err := svc.Nonce(ctx)
+		require.NoError(t, err)
+		return n
+	}
+
+	msg := message(w.address, nonce())
+	_, err := svc.SignIn(ctx, msg, other.sign(msg))
+	assert.ErrorIs(t, err, auth.ErrInvalidSignature, "signed by another wallet")
+
+	// A message another site had signed, replayed with its Host header
+	msg = messageFor("evil.example", w.address, nonce())
+	_, err = svc.SignIn(ctx, msg, w.sign(msg))
+	assert.ErrorIs(t, err, auth.ErrDomainMismatch)
+
+	msg = message(w.address, "abcdef0123456789")
+	_, err = svc.SignIn(ctx, msg, w.sign(msg))
+	assert.ErrorIs(t, err, auth.ErrInvalidNonce, "not issued by the service")
+
+	foreign, _, err := newService("other-key").Nonce(ctx)
+	require.NoError(t, err)
+	msg = message(w.address, foreign)
+	_, err = svc.SignIn(ctx, msg, w.sign(msg))
+	assert.ErrorIs(t, err, auth.ErrInvalidNonce, "issued under another key")
+
+	msg = message(w.address, nonce(), "Expiration Time: "+time.Now().Add(-time.Minute).UTC().Format(time.RFC3339))
+	_, err = svc.SignIn(ctx, msg, w.sign(msg))
+	assert.ErrorIs(t, err, auth.ErrMessageExpired)
+
+	_, err = svc.SignIn(ctx, "hello", w.sign("hello"))
+	assert.ErrorIs(t, err, auth.ErrInvalidMessage)
+}
+
+func TestSignIn_RequiresConfiguredDomain(t *testing.T) {
+	ctx := context.Background()
+	svc := auth.NewService(memory.NewStore().AppConfig, func() string { return "test-key" }, zap.NewNop())
+	w := newWallet(t)
+
+	nonce, _, err := svc.Nonce(ctx)
+	require.NoError(t, err)
+	msg := message(w.address, nonce)
+	_, err = svc.SignIn(ctx, msg, w.sign(msg))
+	assert.ErrorIs(t, err, auth.ErrDomainNotConfigured)
+}
+
+func TestSignIn_SessionEndsWithMessage(t *testing.T) {
+	ctx := context.Background()
+	svc := newService("test-key")
+	w := newWallet(t)
+
+	nonce, _, err := svc.Nonce(ctx)
+	require.NoError(t, err)
+	expiry := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	msg := message(w.address, nonce, "Expiration Time: "+expiry.Format(time.RFC3339))
+	session, err := svc.SignIn(ctx, msg, w.sign(msg))
+	require.NoError(t, err)
+	assert.Equal(t, expiry, session.ExpiresAt)
+}
+
+func TestAuthenticate_RejectsForgedTokens(t *testing.T) {
+	ctx := context.Background()
+	svc := newService("test-key")
+	w := newWallet(t)
+
+	nonce, _, err := svc.Nonce(ctx)
+	require.NoError(t, err)
+	msg := message(w.address, nonce)
+	session, err := svc.SignIn(ctx, msg, w.sign(msg))
+	require.NoError(t, err)
+
+	forged := strings.Replace(session.Token, session.Address, strings.ToLower(newWallet(t).address), 1)
+	for _, token := range []string{"", "garbage", forged, session.Token + "00"} {
+		_, err := svc.Authenticate(token)
+		assert.ErrorIs(t, err, auth.ErrInvalidSession, token)
+	}
+
+	// Rotating the key ends sessions
+	_, err = newService("rotated-key").Authenticate(session.Token)
+	assert.ErrorIs(t, err, auth.ErrInvalidSession)
+}
+
+func TestSignLink(t *testing.T) {
+	svc := newService("test-key")
+	signature := svc.SignLink("export:0xaa:1700000000")
+	assert.True(t, svc.VerifyLink("export:0xaa:1700000000", signature))
+	assert.False(t, svc.VerifyLink("export:0xbb:1700000000", signature))
+	assert.False(t, svc.VerifyLink("export:0xaa:1700000000", "zz"))
+	assert.False(t, newService("rotated-key").VerifyLink("export:0xaa:1700000000", signature))
+}
+
+func TestParseMessage(t *testing.T) {
+	msg := message("0x1111111111111111111111111111111111111111", "abcdef0123456789",
+		"Request ID: req-1",
+		"Resources:",
+		"- https://example.com/terms",
+		"- ipfs://bafy",
+	)
+	m, err := auth.ParseMessage(msg)
+	require.NoError(t, err)
+	assert.Equal(t, domain, m.Domain)
+	assert.Equal(t, "Sign in to Nexus.", m.Statement)
+	assert.Equal(t, int64(1), m.ChainID)
+	assert.Equal(t, "req-1", m.RequestID)
+	assert.Equal(t, []string{"https://example.com/terms", "ipfs://bafy"}, m.Resources)
+
+	for _, bad := range []string{
+		strings.Replace(msg, "Version: 1", "Version: 2", 1),
+		strings.Replace(msg, "Chain ID: 1", "Chain ID: one", 1),
+		strings.Replace(msg, "0x1111111111111111111111111111111111111111", "0x123", 1),
+		fmt.Sprintf("%s\nUnknown: field", msg),
+	} {
+		_, err := auth.ParseMessage(bad)
+		assert.ErrorIs(t, err, auth.ErrInvalidMessage)
+	}
+}
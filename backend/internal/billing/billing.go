@@ -0,0 +1,295 @@
+// Package billing implements sponsored-gas billing. When enabled (app_config
+// namespace "gas_billing"), the gas the relayer pays for each mined
+// meta-transaction is converted to NEXUS at the price oracle's rate and
+// charged to the signer, who settles the balance with a NEXUS transfer to the
+// treasury or by invoice.
+package billing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// namespace is the app_config namespace holding the billing settings
+const namespace = "gas_billing"
+
+// nexusTokenDBName is the contract registry name of the NEXUS token
+const nexusTokenDBName = "nexusToken"
+
+// Billing errors
+var (
+	ErrNoRate                = errors.New("no NEXUS/ETH rate configured")
+	ErrDebtLimit             = errors.New("outstanding gas debt exceeds the limit")
+	ErrSettlementUnavailable = errors.New("on-chain settlement is not configured")
+	ErrNothingDue            = errors.New("no gas debt due")
+)
+
+// wei is 10^18, the base units of one ETH (and one NEXUS)
+var wei = big.NewInt(1e18)
+
+// PriceOracle converts ETH to NEXUS
+type PriceOracle interface {
+	// NexusPerETH returns how many NEXUS base units one ETH buys, or
+	// ErrNoRate
+	NexusPerETH(ctx context.Context) (*big.Int, error)
+}
+
+// ConfigOracle is a fixed-rate oracle: the rate is kept in app_config
+// (gas_billing.nexus_per_eth) and maintained by admins
+type ConfigOracle struct {
+	configRepo repository.AppConfigRepository
+	chainID    int64
+}
+
+// NewConfigOracle creates a fixed-rate oracle
+func NewConfigOracle(configRepo repository.AppConfigRepository, chainID int64) *ConfigOracle {
+	return &ConfigOracle{configRepo: configRepo, chainID: chainID}
+}
+
+// NexusPerETH implements PriceOracle
+func (o *ConfigOracle) NexusPerETH(ctx context.Context) (*big.Int, error) {
+	rate, err := o.configRepo.GetWei(ctx, namespace, "nexus_per_eth", o.chainID)
+	if errors.Is(err, repository.ErrAppConfigNotFound) {
+		return nil, ErrNoRate
+	}
+	if err != nil {
+		return nil, fmt.Errorf("loading NEXUS/ETH rate: %w", err)
+	}
+	if rate == nil || rate.Sign() <= 0 {
+		return nil, ErrNoRate
+	}
+	return rate, nil
+}
+
+// FallbackOracle takes the rate from the first of its oracles that has one,
+// e.g. the Chainlink feeds and then the fixed app_config rate. With no rate
+// anywhere it returns the last oracle's error.
+type FallbackOracle []PriceOracle
+
+// NexusPerETH implements PriceOracle
+func (o FallbackOracle) NexusPerETH(ctx context.Context) (*big.Int, error) {
+	err := ErrNoRate
+	for _, oracle := range o {
+		var rate *big.Int
+		if rate, err = oracle.NexusPerETH(ctx); err == nil {
+			return rate, nil
+		}
+	}
+	return nil, err
+}
+
+// Settings are the billing settings in effect
+type Settings struct {
+	Enabled         bool     `json:"enabled"`
+	MarkupPercent   int64    `json:"markup_percent"`
+	MaxOutstanding  *big.Int `json:"max_outstanding"` // 0 = no limit
+	TreasuryAddress string   `json:"treasury_address,omitempty"`
+}
+
+// Biller charges relayed gas to users and records their settlements
+type Biller struct {
+	repo       repository.GasBillingRepository
+	relayer    repository.RelayerRepository
+	contracts  repository.ContractRepository
+	configRepo repository.AppConfigRepository
+	oracle     PriceOracle
+	logger     *zap.Logger
+	chainID    int64
+}
+
+// NewBiller creates a new biller
+func NewBiller(repo repository.GasBillingRepository, relayer repository.RelayerRepository, contracts repository.ContractRepository, configRepo repository.AppConfigRepository, oracle PriceOracle, logger *zap.Logger, chainID int64) *Biller {
+	return &Biller{
+		repo:       repo,
+		relayer:    relayer,
+		contracts:  contracts,
+		configRepo: configRepo,
+		oracle:     oracle,
+		logger:     logger,
+		chainID:    chainID,
+	}
+}
+
+// Settings loads the billing settings. Missing or unreadable settings leave
+// billing disabled.
+func (b *Biller) Settings(ctx context.Context) Settings {
+	s := Settings{MaxOutstanding: new(big.Int)}
+	if enabled, err := b.configRepo.GetBool(ctx, namespace, "enabled", b.chainID); err == nil {
+		s.Enabled = enabled
+	}
+	if markup, err := b.configRepo.GetNumber(ctx, namespace, "markup_percent", b.chainID); err == nil && markup > 0 {
+		s.MarkupPercent = markup
+	}
+	if limit, err := b.configRepo.GetWei(ctx, namespace, "max_outstanding", b.chainID); err == nil && limit != nil && limit.Sign() > 0 {
+		s.MaxOutstanding = limit
+	}
+	if treasury, err := b.configRepo.GetString(ctx, namespace, "treasury_address", b.chainID); err == nil {
+		s.TreasuryAddress = strings.ToLower(treasury)
+	}
+	return s
+}
+
+// ConvertToNexus converts a wei cost to NEXUS base units at nexusPerETH
+// plus markupPercent, rounded up
+func ConvertToNexus(costWei, nexusPerETH *big.Int, markupPercent int64) *big.Int {
+	amount := new(big.Int).Mul(costWei, nexusPerETH)
+	amount.Mul(amount, big.NewInt(100+markupPercent))
+	divisor := new(big.Int).Mul(wei, big.NewInt(100))
+
+	quotient, remainder := new(big.Int).QuoRem(amount, divisor, new(big.Int))
+	if remainder.Sign() > 0 {
+		quotient.Add(quotient, big.NewInt(1))
+	}
+	return quotient
+}
+
+// ChargeMetaTx charges a mined meta-transaction's gas to its signer. It is
+// called on every confirmation, so a meta-transaction confirmed again after
+// a reorg is not charged twice. Nothing is charged while billing is disabled
+// or the oracle has no rate.
+func (b *Biller) ChargeMetaTx(ctx context.Context, metaTxID string, receipt *types.Receipt) error {
+	settings := b.Settings(ctx)
+	if !settings.Enabled || receipt.EffectiveGasPrice == nil {
+		return nil
+	}
+
+	rate, err := b.oracle.NexusPerETH(ctx)
+	if errors.Is(err, ErrNoRate) {
+		b.logger.Warn("gas billing enabled without a NEXUS/ETH rate, not charging", zap.String("meta_tx_id", metaTxID))
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	tx, err := b.relayer.GetMetaTx(ctx, metaTxID)
+	if errors.Is(err, repository.ErrMetaTxNotFound) {
+		b.logger.Warn("cannot charge gas for unknown meta-tx", zap.String("meta_tx_id", metaTxID))
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("loading meta-tx %s for billing: %w", metaTxID, err)
+	}
+
+	cost := new(big.Int).Mul(receipt.EffectiveGasPrice, new(big.Int).SetUint64(receipt.GasUsed))
+	charge := &repository.GasCharge{
+		MetaTxID:      metaTxID,
+		UserAddress:   strings.ToLower(tx.FromAddress),
+		GasUsed:       receipt.GasUsed,
+		GasPrice:      receipt.EffectiveGasPrice.String(),
+		CostWei:       cost.String(),
+		NexusPerETH:   rate.String(),
+		MarkupPercent: settings.MarkupPercent,
+		AmountNexus:   ConvertToNexus(cost, rate, settings.MarkupPercent).String(),
+	}
+
+	created, err := b.repo.CreateGasCharge(ctx, charge)
+	if err != nil {
+		return fmt.Errorf("charging meta-tx %s: %w", metaTxID, err)
+	}
+	if created {
+		b.logger.Info("gas charged",
+			zap.String("meta_tx_id", metaTxID),
+			zap.String("user", charge.UserAddress),
+			zap.String("amount_nexus", charge.AmountNexus),
+		)
+	}
+	return nil
+}
+
+// CheckRelay returns ErrDebtLimit if the user owes more than the configured
+// limit
+func (b *Biller) CheckRelay(ctx context.Context, userAddress string) error {
+	settings := b.Settings(ctx)
+	if !settings.Enabled || settings.MaxOutstanding.Sign() == 0 {
+		return nil
+	}
+
+	debt, err := b.repo.GetGasDebt(ctx, strings.ToLower(userAddress))
+	if err != nil {
+		return err
+	}
+	if debt.OutstandingAmount().Cmp(settings.MaxOutstanding) > 0 {
+		return ErrDebtLimit
+	}
+	return nil
+}
+
+// NewOnchainSettlement records a NEXUS transfer to the treasury as a pending
+// settlement. It settles once the transfer is confirmed and verified.
+func (b *Biller) NewOnchainSettlement(ctx context.Context, userAddress, txHash string, amount *big.Int) (*repository.GasSettlement, error) {
+	treasury := b.Settings(ctx).TreasuryAddress
+	if treasury == "" {
+		return nil, ErrSettlementUnavailable
+	}
+	token, err := b.contracts.GetByChainAndDBName(ctx, b.chainID, nexusTokenDBName)
+	if errors.Is(err, repository.ErrContractAddressNotFound) {
+		return nil, ErrSettlementUnavailable
+	}
+	if err != nil {
+		return nil, fmt.Errorf("resolving NEXUS token: %w", err)
+	}
+
+	tokenAddress := strings.ToLower(token.Address)
+	txHash = strings.ToLower(txHash)
+	s := &repository.GasSettlement{
+		UserAddress:     strings.ToLower(userAddress),
+		Method:          repository.GasSettlementOnchain,
+		AmountNexus:     amount.String(),
+		Status:          repository.GasSettlementPending,
+		TxHash:          &txHash,
+		TokenAddress:    &tokenAddress,
+		TreasuryAddress: &treasury,
+	}
+	if err := b.repo.CreateGasSettlement(ctx, s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Invoice bills a user off-chain. A nil amount invoices what is due: the
+// outstanding debt not already covered by open settlements.
+func (b *Biller) Invoice(ctx context.Context, userAddress string, amount *big.Int, invoiceRef, createdBy string) (*repository.GasSettlement, error) {
+	userAddress = strings.ToLower(userAddress)
+	if amount == nil {
+		debt, err := b.repo.GetGasDebt(ctx, userAddress)
+		if err != nil {
+			return nil, err
+		}
+		pending, ok := new(big.Int).SetString(debt.Pending, 10)
+		if !ok {
+			pending = new(big.Int)
+		}
+		amount = new(big.Int).Sub(debt.OutstandingAmount(), pending)
+	}
+	if amount.Sign() <= 0 {
+		return nil, ErrNothingDue
+	}
+
+	s := &repository.GasSettlement{
+		UserAddress: userAddress,
+		Method:      repository.GasSettlementInvoice,
+		AmountNexus: amount.String(),
+		Status:      repository.GasSettlementInvoiced,
+		InvoiceRef:  &invoiceRef,
+		CreatedBy:   &createdBy,
+	}
+	if err := b.repo.CreateGasSettlement(ctx, s); err != nil {
+		return nil, err
+	}
+
+	b.logger.Info("gas debt invoiced",
+		zap.String("user", userAddress),
+		zap.String("amount_nexus", s.AmountNexus),
+		zap.String("invoice_ref", invoiceRef),
+	)
+	return s, nil
+}
@@ -0,0 +1,178 @@
+package billing_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/billing"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/storage/memory"
+)
+
+const chainID = 31337
+
+func setConfig(t *testing.T, store *memory.Store, cfg *repository.AppConfigCreate) {
+	t.Helper()
+	cfg.Namespace = "gas_billing"
+	cfg.UpdatedBy = "test"
+	require.NoError(t, store.AppConfig.Create(context.Background(), cfg))
+}
+
+func enableBilling(t *testing.T, store *memory.Store, nexusPerETH string, markup, maxOutstanding int64) {
+	t.Helper()
+	enabled := true
+	rate, _ := new(big.Int).SetString(nexusPerETH, 10)
+	setConfig(t, store, &repository.AppConfigCreate{ConfigKey: "enabled", ValueType: "boolean", ValueBoolean: &enabled})
+	setConfig(t, store, &repository.AppConfigCreate{ConfigKey: "nexus_per_eth", ValueType: "wei", ValueWei: rate})
+	setConfig(t, store, &repository.AppConfigCreate{ConfigKey: "markup_percent", ValueType: "number", ValueNumber: &markup})
+	setConfig(t, store, &repository.AppConfigCreate{ConfigKey: "max_outstanding", ValueType: "wei", ValueWei: big.NewInt(maxOutstanding)})
+}
+
+func newBiller(store *memory.Store) *billing.Biller {
+	return billing.NewBiller(store.GasBilling, store.Relayer, store.Contracts, store.AppConfig,
+		billing.NewConfigOracle(store.AppConfig, chainID), zap.NewNop(), chainID)
+}
+
+func relayed(t *testing.T, store *memory.Store, from string) *repository.MetaTransaction {
+	t.Helper()
+	tx := &repository.MetaTransaction{FromAddress: from, ToAddress: "0xdef", FunctionName: "transfer", Calldata: "0x", Value: "0",
+		GasLimit: 200000, Deadline: time.Now().Add(time.Hour), Signature: "0xsig", Status: repository.MetaTxStatusSubmitted}
+	require.NoError(t, store.Relayer.CreateMetaTx(context.Background(), tx))
+	return tx
+}
+
+func TestConvertToNexus(t *testing.T) {
+	oneETH := big.NewInt(1e18)
+	rate := big.NewInt(2000)
+
+	// 1 ETH at 2000 base units per ETH plus 10% markup
+	assert.Equal(t, "2200", billing.ConvertToNexus(oneETH, rate, 10).String())
+	// Fractions of a base unit round up
+	assert.Equal(t, "1", billing.ConvertToNexus(big.NewInt(1), rate, 0).String())
+	assert.Equal(t, "0", billing.ConvertToNexus(big.NewInt(0), rate, 0).String())
+}
+
+// fixedOracle returns a fixed rate or error
+type fixedOracle struct {
+	rate *big.Int
+	err  error
+}
+
+func (o fixedOracle) NexusPerETH(ctx context.Context) (*big.Int, error) {
+	return o.rate, o.err
+}
+
+func TestFallbackOracle(t *testing.T) {
+	ctx := context.Background()
+	feedDown := fixedOracle{err: errors.New("feed down")}
+
+	rate, err := billing.FallbackOracle{feedDown, fixedOracle{rate: big.NewInt(2000)}}.NexusPerETH(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "2000", rate.String())
+
+	// The last oracle's error is returned, so a missing fixed rate still
+	// skips the charge
+	_, err = billing.FallbackOracle{feedDown, fixedOracle{err: billing.ErrNoRate}}.NexusPerETH(ctx)
+	assert.ErrorIs(t, err, billing.ErrNoRate)
+	_, err = billing.FallbackOracle{}.NexusPerETH(ctx)
+	assert.ErrorIs(t, err, billing.ErrNoRate)
+}
+
+func TestBiller_ChargeMetaTx(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	biller := newBiller(store)
+	tx := relayed(t, store, "0xabc")
+	receipt := &types.Receipt{GasUsed: 100000, EffectiveGasPrice: big.NewInt(2e9)}
+
+	// Disabled by default: nothing is charged
+	require.NoError(t, biller.ChargeMetaTx(ctx, tx.ID, receipt))
+	debt, err := store.GasBilling.GetGasDebt(ctx, "0xabc")
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), debt.ChargeCount)
+
+	// 0.0002 ETH at 5000 NEXUS/ETH plus 20%
+	enableBilling(t, store, "5000000000000000000000", 20, 0)
+	require.NoError(t, biller.ChargeMetaTx(ctx, tx.ID, receipt))
+	// Confirmed again after a reorg: charged once
+	require.NoError(t, biller.ChargeMetaTx(ctx, tx.ID, receipt))
+
+	charges, total, err := store.GasBilling.ListGasCharges(ctx, "0xabc", repository.Pagination{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	require.Len(t, charges, 1)
+	assert.Equal(t, "200000000000000", charges[0].CostWei)
+	assert.Equal(t, "1200000000000000000", charges[0].AmountNexus)
+	assert.Equal(t, int64(20), charges[0].MarkupPercent)
+
+	// Unknown meta-transactions are skipped rather than retried
+	assert.NoError(t, biller.ChargeMetaTx(ctx, "missing", receipt))
+}
+
+func TestBiller_CheckRelayAndInvoice(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	biller := newBiller(store)
+	enableBilling(t, store, "1000000000000000000000", 0, 1e17)
+
+	require.NoError(t, biller.CheckRelay(ctx, "0xABC"))
+	_, err := biller.Invoice(ctx, "0xabc", nil, "INV-0", "admin")
+	assert.ErrorIs(t, err, billing.ErrNothingDue)
+
+	// 0.0002 ETH at 1000 NEXUS/ETH = 0.2 NEXUS, over the 0.1 NEXUS limit
+	tx := relayed(t, store, "0xabc")
+	require.NoError(t, biller.ChargeMetaTx(ctx, tx.ID, &types.Receipt{GasUsed: 100000, EffectiveGasPrice: big.NewInt(2e9)}))
+	assert.ErrorIs(t, biller.CheckRelay(ctx, "0xABC"), billing.ErrDebtLimit)
+
+	// Invoicing does not lower the debt until the invoice is settled
+	invoice, err := biller.Invoice(ctx, "0xabc", nil, "INV-1", "admin")
+	require.NoError(t, err)
+	assert.Equal(t, "200000000000000000", invoice.AmountNexus)
+	assert.Equal(t, repository.GasSettlementInvoiced, invoice.Status)
+	_, err = biller.Invoice(ctx, "0xabc", nil, "INV-2", "admin")
+	assert.ErrorIs(t, err, billing.ErrNothingDue)
+	assert.ErrorIs(t, biller.CheckRelay(ctx, "0xabc"), billing.ErrDebtLimit)
+
+	require.NoError(t, store.GasBilling.UpdateGasSettlementStatus(ctx, invoice.ID, &repository.GasSettlementUpdate{Status: repository.GasSettlementSettled}))
+	assert.NoError(t, biller.CheckRelay(ctx, "0xabc"))
+}
+
+func TestBiller_NewOnchainSettlement(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewSeededStore()
+	biller := newBiller(store)
+	txHash := "0x" + strings.Repeat("ab", 32)
+	amount := big.NewInt(1000)
+
+	_, err := biller.NewOnchainSettlement(ctx, "0xabc", txHash, amount)
+	assert.ErrorIs(t, err, billing.ErrSettlementUnavailable, "no treasury configured")
+
+	treasury := "0xTreasury"
+	setConfig(t, store, &repository.AppConfigCreate{ConfigKey: "treasury_address", ValueType: "address", ValueString: &treasury})
+	_, err = biller.NewOnchainSettlement(ctx, "0xabc", txHash, amount)
+	assert.ErrorIs(t, err, billing.ErrSettlementUnavailable, "no NEXUS token deployed")
+
+	mapping, err := store.Contracts.GetMappingByDBName(ctx, "nexusToken")
+	require.NoError(t, err)
+	_, err = store.Contracts.Upsert(ctx, &repository.ContractAddressUpsert{ChainID: chainID, ContractMappingID: mapping.ID, Address: "0xToken"})
+	require.NoError(t, err)
+
+	s, err := biller.NewOnchainSettlement(ctx, "0xABC", txHash, amount)
+	require.NoError(t, err)
+	assert.Equal(t, "0xabc", s.UserAddress)
+	assert.Equal(t, repository.GasSettlementPending, s.Status)
+	assert.Equal(t, "0xtreasury", *s.TreasuryAddress)
+	assert.Equal(t, "0xtoken", *s.TokenAddress)
+
+	_, err = biller.NewOnchainSettlement(ctx, "0xabc", txHash, amount)
+	assert.ErrorIs(t, err, repository.ErrGasSettlementExists)
+}
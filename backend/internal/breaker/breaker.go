@@ -0,0 +1,266 @@
+// Package breaker implements a circuit breaker for calls to dependencies
+// (database, Stripe, Sumsub, RPC) so a failing dependency is shed quickly
+// instead of tying up request goroutines until it times out
+package breaker
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Default breaker settings
+const (
+	DefaultFailureThreshold = 5
+	DefaultCooldown         = 30 * time.Second
+
+	// halfOpenRetryAfter is suggested to callers rejected while a trial call
+	// is in flight
+	halfOpenRetryAfter = time.Second
+)
+
+// ErrOpen is matched (errors.Is) by every *OpenError
+var ErrOpen = errors.New("circuit breaker open")
+
+// OpenError is returned without calling the dependency while the breaker is open
+type OpenError struct {
+	Name       string
+	RetryAfter time.Duration
+}
+
+func (e *OpenError) Error() string {
+	return fmt.Sprintf("%s unavailable: circuit breaker open, retry after %s", e.Name, e.RetryAfter.Round(time.Second))
+}
+
+// Is makes errors.Is(err, ErrOpen) true for any OpenError
+func (e *OpenError) Is(target error) bool {
+	return target == ErrOpen
+}
+
+// RetryAfter returns how long the caller should wait if err came from an open
+// breaker, and false otherwise
+func RetryAfter(err error) (time.Duration, bool) {
+	var openErr *OpenError
+	if errors.As(err, &openErr) {
+		return openErr.RetryAfter, true
+	}
+	return 0, false
+}
+
+// State is the breaker state
+type State int
+
+const (
+	// StateClosed passes calls through and counts consecutive failures
+	StateClosed State = iota
+	// StateOpen rejects calls until the cooldown elapses
+	StateOpen
+	// StateHalfOpen lets a single trial call through; its result closes or
+	// re-opens the breaker
+	StateHalfOpen
+)
+
+func (s State) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateOpen:
+		return "open"
+	case StateHalfOpen:
+		return "half_open"
+	default:
+		return "unknown"
+	}
+}
+
+// Stats is a point-in-time snapshot of a breaker
+type Stats struct {
+	Name                string     `json:"name"`
+	State               string     `json:"state"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	Rejected            uint64     `json:"rejected"`
+	LastError           string     `json:"last_error,omitempty"`
+	OpenedAt            *time.Time `json:"opened_at,omitempty"`
+}
+
+// Breaker trips open after FailureThreshold consecutive failures and stays
+// open for Cooldown before allowing a trial call
+type Breaker struct {
+	name      string
+	threshold int
+	cooldown  time.Duration
+
+	mu            sync.Mutex
+	state         State
+	failures      int
+	openedAt      time.Time
+	trialInFlight bool
+	rejected      uint64
+	lastError     string
+	onStateChange func(name string, from, to State)
+}
+
+// New creates a closed breaker. Non-positive settings use the defaults.
+func New(name string, threshold int, cooldown time.Duration) *Breaker {
+	if threshold <= 0 {
+		threshold = DefaultFailureThreshold
+	}
+	if cooldown <= 0 {
+		cooldown = DefaultCooldown
+	}
+	return &Breaker{
+		name:      name,
+		threshold: threshold,
+		cooldown:  cooldown,
+	}
+}
+
+// OnStateChange registers a callback invoked (outside the lock) on every
+// transition, e.g. for logging
+func (b *Breaker) OnStateChange(fn func(name string, from, to State)) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.onStateChange = fn
+}
+
+// Name returns the dependency name
+func (b *Breaker) Name() string {
+	return b.name
+}
+
+// State returns the current state, moving open to half-open once the
+// cooldown has elapsed
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == StateOpen && time.Now().Sub(b.openedAt) >= b.cooldown {
+		return StateHalfOpen
+	}
+	return b.state
+}
+
+// OpenFor returns the remaining cooldown while the breaker is open
+func (b *Breaker) OpenFor() (time.Duration, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state != StateOpen {
+		return 0, false
+	}
+	remaining := b.cooldown - time.Since(b.openedAt)
+	return remaining, remaining > 0
+}
+
+// Stats returns a snapshot for health reporting
+func (b *Breaker) Stats() Stats {
+	state := b.State()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	st := Stats{
+		Name:                b.name,
+		State:               state.String(),
+		ConsecutiveFailures: b.failures,
+		Rejected:            b.rejected,
+		LastError:           b.lastError,
+	}
+	if state != StateClosed {
+		openedAt := b.openedAt
+		st.OpenedAt = &openedAt
+	}
+	return st
+}
+
+// Allow reserves a call. It returns an *OpenError if the call must be shed;
+// otherwise the caller must report the outcome with Done.
+func (b *Breaker) Allow() error {
+	b.mu.Lock()
+	var from State
+	changed := false
+
+	switch b.state {
+	case StateOpen:
+		remaining := b.cooldown - time.Now().Sub(b.openedAt)
+		if remaining > 0 {
+			b.rejected++
+			b.mu.Unlock()
+			return &OpenError{Name: b.name, RetryAfter: remaining}
+		}
+		from, changed = b.state, true
+		b.state = StateHalfOpen
+		b.trialInFlight = true
+	case StateHalfOpen:
+		if b.trialInFlight {
+			b.rejected++
+			b.mu.Unlock()
+			return &OpenError{Name: b.name, RetryAfter: halfOpenRetryAfter}
+		}
+		b.trialInFlight = true
+	}
+
+	notify := b.onStateChange
+	b.mu.Unlock()
+
+	if changed && notify != nil {
+		notify(b.name, from, StateHalfOpen)
+	}
+	return nil
+}
+
+// Done records the outcome of a call reserved with Allow. failed should be
+// true only for errors that indicate the dependency is unhealthy (timeouts,
+// connection errors), not for "not found" or validation errors.
+func (b *Breaker) Done(failed bool, err error) {
+	b.mu.Lock()
+	from := b.state
+	b.trialInFlight = false
+
+	switch {
+	case b.state == StateOpen:
+		// Tripped by a concurrent call; keep the original cooldown
+	case !failed:
+		b.failures = 0
+		b.state = StateClosed
+	default:
+		b.failures++
+		if err != nil {
+			b.lastError = err.Error()
+		}
+		if b.state == StateHalfOpen || b.failures >= b.threshold {
+			b.state = StateOpen
+			b.openedAt = time.Now()
+		}
+	}
+
+	to := b.state
+	notify := b.onStateChange
+	b.mu.Unlock()
+
+	if from != to && notify != nil {
+		notify(b.name, from, to)
+	}
+}
+
+// Release gives back a call reserved with Allow without recording an outcome
+// (e.g. the caller's context was cancelled)
+func (b *Breaker) Release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.trialInFlight = false
+}
+
+// Do runs fn through the breaker. isFailure classifies fn's error; nil treats
+// every error as a failure.
+func (b *Breaker) Do(fn func() error, isFailure func(error) bool) error {
+	if err := b.Allow(); err != nil {
+		return err
+	}
+
+	err := fn()
+	failed := err != nil
+	if failed && isFailure != nil {
+		failed = isFailure(err)
+	}
+	b.Done(failed, err)
+	return err
+}
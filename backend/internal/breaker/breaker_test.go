@@ -0,0 +1,98 @@
+package breaker_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/breaker"
+)
+
+var errDown = errors.New("connection refused")
+
+func fail() error    { return errDown }
+func succeed() error { return nil }
+
+func TestBreaker_OpensAfterConsecutiveFailures(t *testing.T) {
+	b := breaker.New("database", 3, time.Minute)
+
+	for i := 0; i < 2; i++ {
+		assert.ErrorIs(t, b.Do(fail, nil), errDown)
+	}
+	// A success resets the count
+	require.NoError(t, b.Do(succeed, nil))
+	for i := 0; i < 2; i++ {
+		assert.ErrorIs(t, b.Do(fail, nil), errDown)
+	}
+	assert.Equal(t, breaker.StateClosed, b.State())
+
+	assert.ErrorIs(t, b.Do(fail, nil), errDown)
+	assert.Equal(t, breaker.StateOpen, b.State())
+
+	called := false
+	err := b.Do(func() error { called = true; return nil }, nil)
+	assert.False(t, called, "open breaker must not call the dependency")
+	assert.ErrorIs(t, err, breaker.ErrOpen)
+
+	retryAfter, ok := breaker.RetryAfter(err)
+	require.True(t, ok)
+	assert.InDelta(t, time.Minute, retryAfter, float64(time.Second))
+
+	stats := b.Stats()
+	assert.Equal(t, "open", stats.State)
+	assert.Equal(t, uint64(1), stats.Rejected)
+	assert.Equal(t, errDown.Error(), stats.LastError)
+	assert.NotNil(t, stats.OpenedAt)
+}
+
+func TestBreaker_IgnoresNonFailures(t *testing.T) {
+	b := breaker.New("database", 1, time.Minute)
+	errNotFound := errors.New("not found")
+
+	err := b.Do(func() error { return errNotFound }, func(err error) bool { return err != errNotFound })
+	assert.ErrorIs(t, err, errNotFound)
+	assert.Equal(t, breaker.StateClosed, b.State())
+}
+
+func TestBreaker_HalfOpenTrial(t *testing.T) {
+	cooldown := 20 * time.Millisecond
+	b := breaker.New("stripe", 1, cooldown)
+	assert.ErrorIs(t, b.Do(fail, nil), errDown)
+
+	time.Sleep(cooldown)
+	assert.Equal(t, breaker.StateHalfOpen, b.State())
+
+	// Only one trial at a time
+	require.NoError(t, b.Allow())
+	assert.ErrorIs(t, b.Allow(), breaker.ErrOpen)
+
+	// A failed trial re-opens for a full cooldown
+	b.Done(true, errDown)
+	assert.Equal(t, breaker.StateOpen, b.State())
+	assert.ErrorIs(t, b.Do(succeed, nil), breaker.ErrOpen)
+
+	// A successful trial closes it
+	time.Sleep(cooldown)
+	require.NoError(t, b.Do(succeed, nil))
+	assert.Equal(t, breaker.StateClosed, b.State())
+	assert.Nil(t, b.Stats().OpenedAt)
+}
+
+func TestBreaker_StateChangeCallback(t *testing.T) {
+	cooldown := 10 * time.Millisecond
+	b := breaker.New("sumsub", 1, cooldown)
+
+	var transitions []string
+	b.OnStateChange(func(name string, from, to breaker.State) {
+		transitions = append(transitions, from.String()+"->"+to.String())
+	})
+
+	_ = b.Do(fail, nil)
+	time.Sleep(cooldown)
+	_ = b.Do(succeed, nil)
+
+	assert.Equal(t, []string{"closed->open", "open->half_open", "half_open->closed"}, transitions)
+}
@@ -0,0 +1,189 @@
+// Package bridge tracks NEXUS transfers across NexusBridge. Users record an
+// intent when they start a transfer; a background watcher scans the bridge
+// on every active network with a nexusBridge deployment for lock and burn
+// events (the source leg) and for mint, unlock and timelock events (the
+// destination leg), at confirmation depth (chain confirmation_depth), and
+// pairs them by the source chain's outbound nonce.
+package bridge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// bridgeDBName is the contract registry name of NexusBridge
+const bridgeDBName = "nexusBridge"
+
+// DefaultInterval is how often Run scans the bridges when no interval is given
+const DefaultInterval = 30 * time.Second
+
+const (
+	// defaultConfirmationDepth is used when chain confirmation_depth is unset
+	defaultConfirmationDepth = 12
+
+	// maxLogRange caps the block range of a single eth_getLogs query
+	maxLogRange = 2000
+
+	// intentLookupPageSize is how many of a sender's transfers are checked
+	// for one already seen under an intent's tx hash
+	intentLookupPageSize = 100
+)
+
+// Bridge errors
+var (
+	ErrInvalidAddress   = errors.New("invalid address")
+	ErrInvalidAmount    = errors.New("amount must be a positive integer in base units")
+	ErrInvalidTxHash    = errors.New("invalid transaction hash")
+	ErrSameChain        = errors.New("source and destination chains must differ")
+	ErrUnsupportedChain = errors.New("NexusBridge is not deployed on this chain")
+)
+
+// Client is the chain access the watcher needs (implemented by
+// chain.FailoverClient)
+type Client interface {
+	BlockNumber(ctx context.Context) (uint64, error)
+	FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error)
+}
+
+// ClientFunc returns the client for a chain (chain.ClientManager.Client)
+type ClientFunc func(ctx context.Context, chainID int64) (Client, error)
+
+// Intent is a transfer a user has started (or is about to) on the source
+// chain. Amount is in base units.
+type Intent struct {
+	Sender        string
+	Recipient     string
+	Amount        string
+	SourceChainID int64
+	DestChainID   int64
+	TxHash        string // optional: the lock or burn transaction
+}
+
+// Service records bridge intents and watches the bridges for their legs
+type Service struct {
+	repo       repository.BridgeRepository
+	contracts  repository.ContractRepository
+	configRepo repository.AppConfigRepository
+	clients    ClientFunc
+	logger     *zap.Logger
+}
+
+// NewService creates a new bridge service
+func NewService(repo repository.BridgeRepository, contracts repository.ContractRepository, configRepo repository.AppConfigRepository, clients ClientFunc, logger *zap.Logger) *Service {
+	return &Service{
+		repo:       repo,
+		contracts:  contracts,
+		configRepo: configRepo,
+		clients:    clients,
+		logger:     logger,
+	}
+}
+
+// RecordIntent records a transfer ahead of its source event, so it shows
+// as pending until the watcher sees it. An intent naming the tx hash of a
+// transfer already seen returns that transfer.
+func (s *Service) RecordIntent(ctx context.Context, in Intent) (*repository.BridgeTransfer, error) {
+	if !common.IsHexAddress(in.Sender) || !common.IsHexAddress(in.Recipient) {
+		return nil, ErrInvalidAddress
+	}
+	amount, ok := new(big.Int).SetString(in.Amount, 10)
+	if !ok || amount.Sign() <= 0 {
+		return nil, ErrInvalidAmount
+	}
+	var txHash *string
+	if in.TxHash != "" {
+		if b, err := hexutil.Decode(in.TxHash); err != nil || len(b) != common.HashLength {
+			return nil, ErrInvalidTxHash
+		}
+		h := strings.ToLower(in.TxHash)
+		txHash = &h
+	}
+	if in.SourceChainID == in.DestChainID {
+		return nil, ErrSameChain
+	}
+	for _, chainID := range []int64{in.SourceChainID, in.DestChainID} {
+		if _, err := s.bridgeContract(ctx, chainID); err != nil {
+			return nil, err
+		}
+	}
+
+	sender := strings.ToLower(in.Sender)
+	if txHash != nil {
+		if t, err := s.seen(ctx, sender, *txHash); err != nil || t != nil {
+			return t, err
+		}
+	}
+
+	t := &repository.BridgeTransfer{
+		Sender:        sender,
+		Recipient:     strings.ToLower(in.Recipient),
+		Amount:        amount.String(),
+		SourceChainID: in.SourceChainID,
+		DestChainID:   in.DestChainID,
+		SourceStatus:  repository.BridgeLegPending,
+		SourceTxHash:  txHash,
+		DestStatus:    repository.BridgeLegPending,
+	}
+	if err := s.repo.CreateBridgeTransfer(ctx, t); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("bridge intent recorded",
+		zap.String("transfer_id", t.ID),
+		zap.String("sender", t.Sender),
+		zap.Int64("source_chain_id", t.SourceChainID),
+		zap.Int64("dest_chain_id", t.DestChainID),
+	)
+	return t, nil
+}
+
+// seen returns sender's transfer whose source leg was txHash, if the
+// watcher has already seen it
+func (s *Service) seen(ctx context.Context, sender, txHash string) (*repository.BridgeTransfer, error) {
+	transfers, _, err := s.repo.ListBridgeTransfers(ctx, sender, repository.Pagination{Page: 1, PageSize: intentLookupPageSize})
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range transfers {
+		if t.Nonce != nil && t.SourceTxHash != nil && *t.SourceTxHash == txHash {
+			return t, nil
+		}
+	}
+	return nil, nil
+}
+
+// bridgeContract returns the NexusBridge deployment on chainID
+func (s *Service) bridgeContract(ctx context.Context, chainID int64) (*repository.ContractAddress, error) {
+	contract, err := s.contracts.GetByChainAndDBName(ctx, chainID, bridgeDBName)
+	if errors.Is(err, repository.ErrContractAddressNotFound) {
+		return nil, fmt.Errorf("chain %d: %w", chainID, ErrUnsupportedChain)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("loading bridge contract for chain %d: %w", chainID, err)
+	}
+	return contract, nil
+}
+
+// confirmationDepth loads chain.confirmation_depth for chainID (minimum 1)
+func (s *Service) confirmationDepth(ctx context.Context, chainID int64) uint64 {
+	depth := int64(defaultConfirmationDepth)
+	if val, err := s.configRepo.GetNumber(ctx, "chain", "confirmation_depth", chainID); err == nil {
+		depth = val
+	}
+	if depth < 1 {
+		depth = 1
+	}
+	return uint64(depth)
+}
@@ -0,0 +1,257 @@
+package bridge_test
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/bridge"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/storage/memory"
+)
+
+const (
+	sourceChain = 31337
+	destChain   = 11155111
+
+	sourceBridge = "0x00000000000000000000000000000000000000b1"
+	destBridge   = "0x00000000000000000000000000000000000000b2"
+
+	sender    = "0x1111111111111111111111111111111111111111"
+	recipient = "0x2222222222222222222222222222222222222222"
+)
+
+var (
+	lockedTopic   = crypto.Keccak256Hash([]byte("TokensLocked(bytes32,address,address,uint256,uint256,uint256)"))
+	mintedTopic   = crypto.Keccak256Hash([]byte("TokensMinted(bytes32,address,uint256,uint256,uint256)"))
+	queuedTopic   = crypto.Keccak256Hash([]byte("LargeTransferQueued(bytes32,address,uint256,uint256)"))
+	executedTopic = crypto.Keccak256Hash([]byte("LargeTransferExecuted(bytes32,address,uint256)"))
+)
+
+// fakeChain serves one chain's bridge logs up to its head
+type fakeChain struct {
+	mu      sync.Mutex
+	head    uint64
+	logs    []types.Log
+	queries int
+}
+
+func (f *fakeChain) BlockNumber(ctx context.Context) (uint64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.head, nil
+}
+
+func (f *fakeChain) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.queries++
+
+	var out []types.Log
+	for _, l := range f.logs {
+		if l.BlockNumber < q.FromBlock.Uint64() || l.BlockNumber > q.ToBlock.Uint64() || l.Address != q.Addresses[0] {
+			continue
+		}
+		out = append(out, l)
+	}
+	return out, nil
+}
+
+func (f *fakeChain) emit(l types.Log) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.logs = append(f.logs, l)
+}
+
+// words ABI-encodes uint256 values
+func words(values ...*big.Int) []byte {
+	var out []byte
+	for _, v := range values {
+		out = append(out, common.LeftPadBytes(v.Bytes(), 32)...)
+	}
+	return out
+}
+
+func addressTopic(addr string) common.Hash {
+	return common.BytesToHash(common.HexToAddress(addr).Bytes())
+}
+
+func locked(block, nonce uint64, amount *big.Int) types.Log {
+	return types.Log{
+		Address:     common.HexToAddress(sourceBridge),
+		Topics:      []common.Hash{lockedTopic, crypto.Keccak256Hash(big.NewInt(int64(nonce)).Bytes()), addressTopic(sender), addressTopic(recipient)},
+		Data:        words(amount, big.NewInt(destChain), new(big.Int).SetUint64(nonce)),
+		BlockNumber: block,
+		TxHash:      crypto.Keccak256Hash([]byte("lock"), big.NewInt(int64(nonce)).Bytes()),
+	}
+}
+
+func minted(block, nonce uint64, amount *big.Int) types.Log {
+	id := bridge.DestTransferID(common.HexToAddress(recipient), amount, sourceChain, destChain, nonce)
+	return types.Log{
+		Address:     common.HexToAddress(destBridge),
+		Topics:      []common.Hash{mintedTopic, id, addressTopic(recipient)},
+		Data:        words(amount, big.NewInt(sourceChain), new(big.Int).SetUint64(nonce)),
+		BlockNumber: block,
+		TxHash:      crypto.Keccak256Hash([]byte("mint"), big.NewInt(int64(nonce)).Bytes()),
+	}
+}
+
+func newService(t *testing.T) (*bridge.Service, *memory.Store, map[int64]*fakeChain) {
+	t.Helper()
+	ctx := context.Background()
+	store := memory.NewSeededStore()
+
+	mapping, err := store.Contracts.GetMappingByDBName(ctx, "nexusBridge")
+	require.NoError(t, err)
+	deployed := int64(10)
+	for chainID, addr := range map[int64]string{sourceChain: sourceBridge, destChain: destBridge} {
+		_, err := store.Contracts.Upsert(ctx, &repository.ContractAddressUpsert{
+			ChainID: chainID, ContractMappingID: mapping.ID, Address: addr, DeploymentBlock: &deployed,
+		})
+		require.NoError(t, err)
+	}
+
+	chains := map[int64]*fakeChain{sourceChain: {head: 100}, destChain: {head: 100}}
+	clients := func(ctx context.Context, chainID int64) (bridge.Client, error) {
+		return chains[chainID], nil
+	}
+	return bridge.NewService(store.Bridge, store.Contracts, store.AppConfig, clients, zap.NewNop()), store, chains
+}
+
+func TestDestTransferID_MatchesABIEncoding(t *testing.T) {
+	addressType, _ := abi.NewType("address", "", nil)
+	uintType, _ := abi.NewType("uint256", "", nil)
+	args := abi.Arguments{{Type: addressType}, {Type: uintType}, {Type: uintType}, {Type: uintType}, {Type: uintType}}
+
+	amount, _ := new(big.Int).SetString("5000000000000000000", 10)
+	packed, err := args.Pack(common.HexToAddress(recipient), amount, big.NewInt(sourceChain), big.NewInt(destChain), big.NewInt(7))
+	require.NoError(t, err)
+
+	assert.Equal(t, crypto.Keccak256Hash(packed), bridge.DestTransferID(common.HexToAddress(recipient), amount, sourceChain, destChain, 7))
+}
+
+func TestRecordIntent_Validates(t *testing.T) {
+	svc, _, _ := newService(t)
+	ctx := context.Background()
+	valid := bridge.Intent{Sender: sender, Recipient: recipient, Amount: "1000", SourceChainID: sourceChain, DestChainID: destChain}
+
+	for name, tc := range map[string]struct {
+		edit func(*bridge.Intent)
+		err  error
+	}{
+		"bad sender":        {func(in *bridge.Intent) { in.Sender = "0x123" }, bridge.ErrInvalidAddress},
+		"zero amount":       {func(in *bridge.Intent) { in.Amount = "0" }, bridge.ErrInvalidAmount},
+		"decimal amount":    {func(in *bridge.Intent) { in.Amount = "1.5" }, bridge.ErrInvalidAmount},
+		"bad tx hash":       {func(in *bridge.Intent) { in.TxHash = "0xabc" }, bridge.ErrInvalidTxHash},
+		"same chain":        {func(in *bridge.Intent) { in.DestChainID = sourceChain }, bridge.ErrSameChain},
+		"unsupported chain": {func(in *bridge.Intent) { in.DestChainID = 1 }, bridge.ErrUnsupportedChain},
+	} {
+		t.Run(name, func(t *testing.T) {
+			in := valid
+			tc.edit(&in)
+			_, err := svc.RecordIntent(ctx, in)
+			assert.ErrorIs(t, err, tc.err)
+		})
+	}
+}
+
+func TestWatcher_TracksBothLegs(t *testing.T) {
+	svc, store, chains := newService(t)
+	ctx := context.Background()
+	amount := big.NewInt(1000)
+
+	intent, err := svc.RecordIntent(ctx, bridge.Intent{
+		Sender: sender, Recipient: recipient, Amount: "1000", SourceChainID: sourceChain, DestChainID: destChain,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, repository.BridgeLegPending, intent.SourceStatus)
+
+	// Not at confirmation depth (12) yet
+	chains[sourceChain].emit(locked(95, 0, amount))
+	svc.RunOnce(ctx)
+	got, err := store.Bridge.GetBridgeTransfer(ctx, intent.ID)
+	require.NoError(t, err)
+	assert.Equal(t, repository.BridgeLegPending, got.SourceStatus)
+
+	chains[sourceChain].head = 110
+	svc.RunOnce(ctx)
+	got, err = store.Bridge.GetBridgeTransfer(ctx, intent.ID)
+	require.NoError(t, err)
+	assert.Equal(t, repository.BridgeLockMint, got.Direction)
+	assert.Equal(t, repository.BridgeLegConfirmed, got.SourceStatus)
+	assert.NotNil(t, got.SourceConfirmedAt)
+	require.NotNil(t, got.Nonce)
+	assert.Equal(t, uint64(0), *got.Nonce)
+	assert.Equal(t, repository.BridgeLegPending, got.DestStatus)
+
+	chains[destChain].emit(minted(96, 0, amount))
+	chains[destChain].head = 110
+	svc.RunOnce(ctx)
+	got, err = store.Bridge.GetBridgeTransfer(ctx, intent.ID)
+	require.NoError(t, err)
+	assert.Equal(t, repository.BridgeLegCompleted, got.DestStatus)
+	assert.NotNil(t, got.DestCompletedAt)
+	require.NotNil(t, got.DestTxHash)
+	assert.Equal(t, minted(96, 0, amount).TxHash.Hex(), *got.DestTxHash)
+
+	// Scanned blocks are not queried again
+	queries := chains[sourceChain].queries
+	svc.RunOnce(ctx)
+	assert.Equal(t, queries, chains[sourceChain].queries)
+
+	list, total, err := store.Bridge.ListBridgeTransfers(ctx, recipient, repository.Pagination{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Equal(t, intent.ID, list[0].ID)
+}
+
+func TestWatcher_LargeTransferTimelock(t *testing.T) {
+	svc, store, chains := newService(t)
+	ctx := context.Background()
+	amount, _ := new(big.Int).SetString("200000000000000000000000", 10)
+
+	// Seen on the destination first, then the source
+	id := bridge.DestTransferID(common.HexToAddress(recipient), amount, sourceChain, destChain, 3)
+	chains[destChain].emit(types.Log{
+		Address:     common.HexToAddress(destBridge),
+		Topics:      []common.Hash{queuedTopic, id, addressTopic(recipient)},
+		Data:        words(amount, big.NewInt(1_800_000_000)),
+		BlockNumber: 40,
+		TxHash:      crypto.Keccak256Hash([]byte("queue")),
+	})
+	chains[sourceChain].emit(locked(30, 3, amount))
+	svc.RunOnce(ctx)
+
+	transfer, err := store.Bridge.GetBridgeTransferByNonce(ctx, sourceChain, 3)
+	require.NoError(t, err)
+	assert.Equal(t, repository.BridgeLegConfirmed, transfer.SourceStatus)
+	assert.Equal(t, repository.BridgeLegQueued, transfer.DestStatus)
+	require.NotNil(t, transfer.DestUnlockAt)
+	assert.Equal(t, int64(1_800_000_000), transfer.DestUnlockAt.Unix())
+
+	chains[destChain].emit(types.Log{
+		Address:     common.HexToAddress(destBridge),
+		Topics:      []common.Hash{executedTopic, id, addressTopic(recipient)},
+		Data:        words(amount),
+		BlockNumber: 95,
+		TxHash:      crypto.Keccak256Hash([]byte("execute")),
+	})
+	chains[destChain].head = 110
+	svc.RunOnce(ctx)
+
+	transfer, err = store.Bridge.GetBridgeTransfer(ctx, transfer.ID)
+	require.NoError(t, err)
+	assert.Equal(t, repository.BridgeLegCompleted, transfer.DestStatus)
+	assert.Equal(t, sender, transfer.Sender)
+}
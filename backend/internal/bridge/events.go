@@ -0,0 +1,115 @@
+package bridge
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// bridgeABIJSON is the part of the NexusBridge ABI the watcher reads
+const bridgeABIJSON = `[
+	{"type":"event","name":"TokensLocked","inputs":[
+		{"name":"transferId","type":"bytes32","indexed":true},
+		{"name":"sender","type":"address","indexed":true},
+		{"name":"recipient","type":"address","indexed":true},
+		{"name":"amount","type":"uint256","indexed":false},
+		{"name":"destChain","type":"uint256","indexed":false},
+		{"name":"nonce","type":"uint256","indexed":false}]},
+	{"type":"event","name":"TokensBurned","inputs":[
+		{"name":"transferId","type":"bytes32","indexed":true},
+		{"name":"sender","type":"address","indexed":true},
+		{"name":"recipient","type":"address","indexed":true},
+		{"name":"amount","type":"uint256","indexed":false},
+		{"name":"destChain","type":"uint256","indexed":false},
+		{"name":"nonce","type":"uint256","indexed":false}]},
+	{"type":"event","name":"TokensMinted","inputs":[
+		{"name":"transferId","type":"bytes32","indexed":true},
+		{"name":"recipient","type":"address","indexed":true},
+		{"name":"amount","type":"uint256","indexed":false},
+		{"name":"sourceChain","type":"uint256","indexed":false},
+		{"name":"nonce","type":"uint256","indexed":false}]},
+	{"type":"event","name":"TokensUnlocked","inputs":[
+		{"name":"transferId","type":"bytes32","indexed":true},
+		{"name":"recipient","type":"address","indexed":true},
+		{"name":"amount","type":"uint256","indexed":false},
+		{"name":"sourceChain","type":"uint256","indexed":false},
+		{"name":"nonce","type":"uint256","indexed":false}]},
+	{"type":"event","name":"LargeTransferQueued","inputs":[
+		{"name":"transferId","type":"bytes32","indexed":true},
+		{"name":"recipient","type":"address","indexed":true},
+		{"name":"amount","type":"uint256","indexed":false},
+		{"name":"unlockTime","type":"uint256","indexed":false}]},
+	{"type":"event","name":"LargeTransferExecuted","inputs":[
+		{"name":"transferId","type":"bytes32","indexed":true},
+		{"name":"recipient","type":"address","indexed":true},
+		{"name":"amount","type":"uint256","indexed":false}]},
+	{"type":"event","name":"LargeTransferCancelled","inputs":[
+		{"name":"transferId","type":"bytes32","indexed":true}]}
+]`
+
+var bridgeABI = mustParseABI(bridgeABIJSON)
+
+func mustParseABI(s string) abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(s))
+	if err != nil {
+		panic(fmt.Sprintf("bridge: invalid NexusBridge ABI: %v", err))
+	}
+	return parsed
+}
+
+// Event topics
+var (
+	tokensLockedTopic   = bridgeABI.Events["TokensLocked"].ID
+	tokensBurnedTopic   = bridgeABI.Events["TokensBurned"].ID
+	tokensMintedTopic   = bridgeABI.Events["TokensMinted"].ID
+	tokensUnlockedTopic = bridgeABI.Events["TokensUnlocked"].ID
+	queuedTopic         = bridgeABI.Events["LargeTransferQueued"].ID
+	executedTopic       = bridgeABI.Events["LargeTransferExecuted"].ID
+	cancelledTopic      = bridgeABI.Events["LargeTransferCancelled"].ID
+)
+
+// watchedTopics are the events the watcher filters for
+var watchedTopics = []common.Hash{
+	tokensLockedTopic, tokensBurnedTopic, tokensMintedTopic, tokensUnlockedTopic,
+	queuedTopic, executedTopic, cancelledTopic,
+}
+
+// DestTransferID is the transfer ID the destination bridge computes when
+// minting or unlocking: keccak256(abi.encode(recipient, amount, sourceChain,
+// destChain, nonce)). The timelock events carry only this ID.
+func DestTransferID(recipient common.Address, amount *big.Int, sourceChainID, destChainID int64, nonce uint64) common.Hash {
+	words := [][]byte{
+		common.LeftPadBytes(recipient.Bytes(), 32),
+		common.LeftPadBytes(amount.Bytes(), 32),
+		common.LeftPadBytes(big.NewInt(sourceChainID).Bytes(), 32),
+		common.LeftPadBytes(big.NewInt(destChainID).Bytes(), 32),
+		common.LeftPadBytes(new(big.Int).SetUint64(nonce).Bytes(), 32),
+	}
+	return crypto.Keccak256Hash(words...)
+}
+
+// unpackUints decodes an event's non-indexed uint256 fields
+func unpackUints(event string, data []byte) ([]*big.Int, error) {
+	values, err := bridgeABI.Unpack(event, data)
+	if err != nil {
+		return nil, fmt.Errorf("decoding %s: %w", event, err)
+	}
+	out := make([]*big.Int, len(values))
+	for i, v := range values {
+		n, ok := v.(*big.Int)
+		if !ok {
+			return nil, fmt.Errorf("decoding %s: field %d is %T", event, i, v)
+		}
+		out[i] = n
+	}
+	return out, nil
+}
+
+// topicAddress decodes an indexed address
+func topicAddress(topic common.Hash) string {
+	return strings.ToLower(common.BytesToAddress(topic.Bytes()).Hex())
+}
@@ -0,0 +1,304 @@
+package bridge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Run scans the bridges every interval until ctx is done
+func (s *Service) Run(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		s.RunOnce(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce scans the bridge on every active network it is deployed on
+func (s *Service) RunOnce(ctx context.Context) {
+	networks, err := s.contracts.GetActiveNetworks(ctx)
+	if err != nil {
+		s.logger.Warn("bridge scan failed", zap.Error(err))
+		return
+	}
+
+	for _, n := range networks {
+		contract, err := s.bridgeContract(ctx, n.ChainID)
+		if errors.Is(err, ErrUnsupportedChain) {
+			continue
+		}
+		if err == nil {
+			err = s.scan(ctx, n.ChainID, contract)
+		}
+		if err != nil {
+			s.logger.Warn("bridge scan failed", zap.Int64("chain_id", n.ChainID), zap.Error(err))
+		}
+	}
+}
+
+// scan applies the bridge events of chainID from its cursor up to
+// confirmation depth. The cursor starts at the bridge's deployment block, or
+// at the current confirmed block if that is not recorded. It moves after
+// each range so a failed range is scanned again; applying an event twice
+// changes nothing.
+func (s *Service) scan(ctx context.Context, chainID int64, contract *repository.ContractAddress) error {
+	client, err := s.clients(ctx, chainID)
+	if err != nil {
+		return err
+	}
+	head, err := client.BlockNumber(ctx)
+	if err != nil {
+		return fmt.Errorf("getting block number: %w", err)
+	}
+	depth := s.confirmationDepth(ctx, chainID)
+	if head+1 < depth {
+		return nil
+	}
+	confirmed := head + 1 - depth
+
+	from, err := s.repo.GetBridgeCursor(ctx, chainID)
+	if errors.Is(err, repository.ErrBridgeCursorNotFound) {
+		from = confirmed
+		if contract.DeploymentBlock != nil && *contract.DeploymentBlock >= 0 {
+			from = uint64(*contract.DeploymentBlock)
+		}
+	} else if err != nil {
+		return err
+	}
+
+	address := common.HexToAddress(contract.Address)
+	for from <= confirmed {
+		to := from + maxLogRange - 1
+		if to > confirmed {
+			to = confirmed
+		}
+		logs, err := client.FilterLogs(ctx, ethereum.FilterQuery{
+			FromBlock: new(big.Int).SetUint64(from),
+			ToBlock:   new(big.Int).SetUint64(to),
+			Addresses: []common.Address{address},
+			Topics:    [][]common.Hash{watchedTopics},
+		})
+		if err != nil {
+			return fmt.Errorf("filtering bridge logs %d-%d: %w", from, to, err)
+		}
+		for _, l := range logs {
+			if l.Removed || len(l.Topics) == 0 {
+				continue
+			}
+			if err := s.apply(ctx, chainID, l); err != nil {
+				return fmt.Errorf("applying bridge log %s:%d: %w", l.TxHash.Hex(), l.Index, err)
+			}
+		}
+		if err := s.repo.SetBridgeCursor(ctx, chainID, to+1); err != nil {
+			return err
+		}
+		from = to + 1
+	}
+	return nil
+}
+
+// apply records one bridge event seen on chainID
+func (s *Service) apply(ctx context.Context, chainID int64, l types.Log) error {
+	switch l.Topics[0] {
+	case tokensLockedTopic:
+		return s.onSent(ctx, chainID, l, "TokensLocked", repository.BridgeLockMint)
+	case tokensBurnedTopic:
+		return s.onSent(ctx, chainID, l, "TokensBurned", repository.BridgeBurnRelease)
+	case tokensMintedTopic:
+		return s.onReceived(ctx, chainID, l, "TokensMinted", repository.BridgeLockMint)
+	case tokensUnlockedTopic:
+		return s.onReceived(ctx, chainID, l, "TokensUnlocked", repository.BridgeBurnRelease)
+	case queuedTopic, executedTopic, cancelledTopic:
+		return s.onTimelock(ctx, chainID, l)
+	}
+	return nil
+}
+
+// onSent records a lock or burn, the source leg of a transfer. It completes
+// the transfer's row if the destination leg was seen first, or else the
+// oldest intent it fits, or else starts a new row.
+func (s *Service) onSent(ctx context.Context, chainID int64, l types.Log, event string, direction repository.BridgeDirection) error {
+	if len(l.Topics) != 4 {
+		return fmt.Errorf("%s: expected 4 topics, got %d", event, len(l.Topics))
+	}
+	values, err := unpackUints(event, l.Data)
+	if err != nil {
+		return err
+	}
+	amount, destChain, nonce := values[0], values[1], values[2]
+	if !destChain.IsInt64() || !nonce.IsUint64() {
+		s.logger.Warn("bridge event out of range", zap.String("event", event), zap.String("tx_hash", l.TxHash.Hex()))
+		return nil
+	}
+
+	sender := topicAddress(l.Topics[2])
+	recipient := topicAddress(l.Topics[3])
+	n := nonce.Uint64()
+	sourceTransferID := l.Topics[1].Hex()
+	destTransferID := DestTransferID(common.BytesToAddress(l.Topics[3].Bytes()), amount, chainID, destChain.Int64(), n).Hex()
+	txHash := l.TxHash.Hex()
+	block := l.BlockNumber
+	confirmed := repository.BridgeLegConfirmed
+
+	t, err := s.repo.GetBridgeTransferByNonce(ctx, chainID, n)
+	if errors.Is(err, repository.ErrBridgeTransferNotFound) {
+		t, err = s.repo.MatchBridgeIntent(ctx, repository.BridgeIntentMatch{
+			Sender:        sender,
+			Recipient:     recipient,
+			Amount:        amount.String(),
+			SourceChainID: chainID,
+			DestChainID:   destChain.Int64(),
+			TxHash:        txHash,
+		})
+	}
+	switch {
+	case err == nil:
+		return s.repo.UpdateBridgeTransfer(ctx, t.ID, &repository.BridgeTransferUpdate{
+			Direction:        &direction,
+			Sender:           &sender,
+			Nonce:            &n,
+			SourceStatus:     &confirmed,
+			SourceTransferID: &sourceTransferID,
+			SourceTxHash:     &txHash,
+			SourceBlock:      &block,
+			DestTransferID:   &destTransferID,
+		})
+	case !errors.Is(err, repository.ErrBridgeTransferNotFound):
+		return err
+	}
+
+	return s.repo.CreateBridgeTransfer(ctx, &repository.BridgeTransfer{
+		Direction:        direction,
+		Sender:           sender,
+		Recipient:        recipient,
+		Amount:           amount.String(),
+		SourceChainID:    chainID,
+		DestChainID:      destChain.Int64(),
+		Nonce:            &n,
+		SourceStatus:     confirmed,
+		SourceTransferID: &sourceTransferID,
+		SourceTxHash:     &txHash,
+		SourceBlock:      &block,
+		DestStatus:       repository.BridgeLegPending,
+		DestTransferID:   &destTransferID,
+	})
+}
+
+// onReceived records a mint or unlock, which completes the destination leg
+func (s *Service) onReceived(ctx context.Context, chainID int64, l types.Log, event string, direction repository.BridgeDirection) error {
+	if len(l.Topics) != 3 {
+		return fmt.Errorf("%s: expected 3 topics, got %d", event, len(l.Topics))
+	}
+	values, err := unpackUints(event, l.Data)
+	if err != nil {
+		return err
+	}
+	amount, sourceChain, nonce := values[0], values[1], values[2]
+	if !sourceChain.IsInt64() || !nonce.IsUint64() {
+		s.logger.Warn("bridge event out of range", zap.String("event", event), zap.String("tx_hash", l.TxHash.Hex()))
+		return nil
+	}
+
+	n := nonce.Uint64()
+	destTransferID := l.Topics[1].Hex()
+	txHash := l.TxHash.Hex()
+	block := l.BlockNumber
+	completed := repository.BridgeLegCompleted
+
+	t, err := s.repo.GetBridgeTransferByNonce(ctx, sourceChain.Int64(), n)
+	switch {
+	case err == nil:
+		return s.repo.UpdateBridgeTransfer(ctx, t.ID, &repository.BridgeTransferUpdate{
+			Direction:      &direction,
+			DestStatus:     &completed,
+			DestTransferID: &destTransferID,
+			DestTxHash:     &txHash,
+			DestBlock:      &block,
+		})
+	case !errors.Is(err, repository.ErrBridgeTransferNotFound):
+		return err
+	}
+
+	return s.repo.CreateBridgeTransfer(ctx, &repository.BridgeTransfer{
+		Direction:      direction,
+		Recipient:      topicAddress(l.Topics[2]),
+		Amount:         amount.String(),
+		SourceChainID:  sourceChain.Int64(),
+		DestChainID:    chainID,
+		Nonce:          &n,
+		SourceStatus:   repository.BridgeLegPending,
+		DestStatus:     completed,
+		DestTransferID: &destTransferID,
+		DestTxHash:     &txHash,
+		DestBlock:      &block,
+	})
+}
+
+// onTimelock records a large transfer being queued behind the destination
+// bridge's timelock, then executed or cancelled. These events carry only the
+// destination transfer ID, so a transfer whose source leg has not been seen
+// cannot be matched and the event is skipped.
+func (s *Service) onTimelock(ctx context.Context, chainID int64, l types.Log) error {
+	if len(l.Topics) < 2 {
+		return fmt.Errorf("timelock event: expected at least 2 topics, got %d", len(l.Topics))
+	}
+	destTransferID := l.Topics[1].Hex()
+	t, err := s.repo.GetBridgeTransferByDestID(ctx, destTransferID)
+	if errors.Is(err, repository.ErrBridgeTransferNotFound) {
+		s.logger.Warn("bridge timelock event for unknown transfer",
+			zap.Int64("chain_id", chainID),
+			zap.String("dest_transfer_id", destTransferID),
+			zap.String("tx_hash", l.TxHash.Hex()),
+		)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	txHash := l.TxHash.Hex()
+	block := l.BlockNumber
+	update := &repository.BridgeTransferUpdate{DestTxHash: &txHash, DestBlock: &block}
+	var status repository.BridgeLegStatus
+	switch l.Topics[0] {
+	case queuedTopic:
+		values, err := unpackUints("LargeTransferQueued", l.Data)
+		if err != nil {
+			return err
+		}
+		if t.DestStatus != repository.BridgeLegPending {
+			return nil // already executed or cancelled
+		}
+		status = repository.BridgeLegQueued
+		if values[1].IsInt64() {
+			unlockAt := time.Unix(values[1].Int64(), 0).UTC()
+			update.DestUnlockAt = &unlockAt
+		}
+	case executedTopic:
+		status = repository.BridgeLegCompleted
+	case cancelledTopic:
+		status = repository.BridgeLegCancelled
+	}
+	update.DestStatus = &status
+	return s.repo.UpdateBridgeTransfer(ctx, t.ID, update)
+}
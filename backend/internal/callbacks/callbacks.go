@@ -0,0 +1,135 @@
+// Package callbacks notifies dapps of payment status changes. Payment
+// requests may name a callback URL and a signing secret; every status change
+// the payment lifecycle makes (Stripe webhooks, crypto confirmations, reorg
+// rollbacks) is queued as a webhook event and POSTed to the URL in the
+// background, signed with the secret. Deliveries get the webhook processor's
+// retries, backoff and dead-lettering.
+//
+// Receivers should check the signature, reject stale timestamps, and treat
+// notifications as at-least-once: the "id" field is stable across retries.
+package callbacks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Delivery headers
+const (
+	// SignatureHeader carries "t=<unix seconds>,v1=<hex HMAC-SHA256>" over
+	// "<unix seconds>.<body>", keyed with the payment's callback secret
+	SignatureHeader = "X-Nexus-Signature"
+
+	// EventIDHeader repeats the notification ID for deduplication
+	EventIDHeader = "X-Nexus-Event-Id"
+)
+
+// EventType is the type of every notification
+const EventType = "payment.status_changed"
+
+// Callback limits
+const (
+	MinSecretLength = 16
+	MaxSecretLength = 256
+	MaxURLLength    = 2048
+)
+
+var (
+	// ErrInvalidURL is returned by ValidateURL
+	ErrInvalidURL = errors.New("invalid callback url")
+
+	// ErrInvalidSecret is returned by ValidateSecret
+	ErrInvalidSecret = errors.New("invalid callback secret")
+)
+
+// Notification is the JSON body POSTed to a payment's callback URL
+type Notification struct {
+	ID              string                   `json:"id"`
+	Type            string                   `json:"type"`
+	PaymentID       string                   `json:"payment_id"`
+	Status          repository.PaymentStatus `json:"status"`
+	PreviousStatus  repository.PaymentStatus `json:"previous_status,omitempty"`
+	ServiceCode     string                   `json:"service_code"`
+	PayerAddress    string                   `json:"payer_address"`
+	PaymentMethod   string                   `json:"payment_method"`
+	AmountCharged   float64                  `json:"amount_charged"`
+	Currency        string                   `json:"currency"`
+	TxHash          *string                  `json:"tx_hash,omitempty"`
+	StripeSessionID *string                  `json:"stripe_session_id,omitempty"`
+	ErrorMessage    *string                  `json:"error_message,omitempty"`
+	OccurredAt      time.Time                `json:"occurred_at"`
+}
+
+// newNotification describes p entering its current status from previous
+// (empty for a new payment). The ID is unique per transition, so a payment
+// that returns to a status (e.g. completed again after a reorg) notifies
+// again.
+func newNotification(p *repository.Payment, previous repository.PaymentStatus) *Notification {
+	return &Notification{
+		ID:              fmt.Sprintf("%s:%s:%d", p.ID, p.Status, p.UpdatedAt.UnixNano()),
+		Type:            EventType,
+		PaymentID:       p.ID,
+		Status:          p.Status,
+		PreviousStatus:  previous,
+		ServiceCode:     p.ServiceCode,
+		PayerAddress:    p.PayerAddress,
+		PaymentMethod:   p.PaymentMethod,
+		AmountCharged:   p.AmountCharged,
+		Currency:        p.Currency,
+		TxHash:          p.TxHash,
+		StripeSessionID: p.StripeSessionID,
+		ErrorMessage:    p.ErrorMessage,
+		OccurredAt:      p.UpdatedAt,
+	}
+}
+
+// ValidateURL checks a callback URL: absolute https, or http when
+// allowInsecure is set (local development)
+func ValidateURL(raw string, allowInsecure bool) error {
+	if len(raw) > MaxURLLength {
+		return fmt.Errorf("%w: longer than %d characters", ErrInvalidURL, MaxURLLength)
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidURL, err)
+	}
+	switch {
+	case u.Scheme == "https":
+	case u.Scheme == "http" && allowInsecure:
+	default:
+		return fmt.Errorf("%w: must be an https URL", ErrInvalidURL)
+	}
+	if u.Host == "" || u.Hostname() == "" {
+		return fmt.Errorf("%w: missing host", ErrInvalidURL)
+	}
+	if u.User != nil {
+		return fmt.Errorf("%w: must not contain credentials", ErrInvalidURL)
+	}
+	return nil
+}
+
+// ValidateSecret checks a callback signing secret's length
+func ValidateSecret(secret string) error {
+	if len(secret) < MinSecretLength || len(secret) > MaxSecretLength {
+		return fmt.Errorf("%w: must be %d to %d characters", ErrInvalidSecret, MinSecretLength, MaxSecretLength)
+	}
+	return nil
+}
+
+// Sign returns the SignatureHeader value for body sent at t
+func Sign(secret string, t time.Time, body []byte) string {
+	ts := strconv.FormatInt(t.Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(ts))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "t=" + ts + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
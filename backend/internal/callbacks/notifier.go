@@ -0,0 +1,184 @@
+package callbacks
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Queue stores webhook events for background delivery (implemented by
+// webhooks.Processor)
+type Queue interface {
+	Enqueue(ctx context.Context, e *repository.WebhookEvent) (bool, error)
+}
+
+// Ensure NotifyingPaymentRepo implements PaymentRepository
+var _ repository.PaymentRepository = (*NotifyingPaymentRepo)(nil)
+
+// NotifyingPaymentRepo wraps a PaymentRepository and queues a notification
+// whenever a payment with a callback URL is created or changes status.
+// Wrapping the repository covers every path that moves a payment through its
+// lifecycle without each one having to remember to notify.
+type NotifyingPaymentRepo struct {
+	next   repository.PaymentRepository
+	queue  Queue
+	logger *zap.Logger
+}
+
+// NewNotifyingPaymentRepo wraps next, queuing notifications on q
+func NewNotifyingPaymentRepo(next repository.PaymentRepository, q Queue, logger *zap.Logger) *NotifyingPaymentRepo {
+	return &NotifyingPaymentRepo{next: next, queue: q, logger: logger}
+}
+
+// CreatePayment implements repository.PaymentRepository. The initial status
+// (pending, or processing for crypto payments) is notified too.
+func (r *NotifyingPaymentRepo) CreatePayment(ctx context.Context, payment *repository.Payment) error {
+	if err := r.next.CreatePayment(ctx, payment); err != nil {
+		return err
+	}
+	if payment.CallbackURL != nil {
+		r.notify(ctx, payment, "")
+	}
+	return nil
+}
+
+// GetPayment implements repository.PaymentRepository
+func (r *NotifyingPaymentRepo) GetPayment(ctx context.Context, id string) (*repository.Payment, error) {
+	return r.next.GetPayment(ctx, id)
+}
+
+// GetPaymentByStripeSession implements repository.PaymentRepository
+func (r *NotifyingPaymentRepo) GetPaymentByStripeSession(ctx context.Context, sessionID string) (*repository.Payment, error) {
+	return r.next.GetPaymentByStripeSession(ctx, sessionID)
+}
+
+// GetPaymentByStripePaymentID implements repository.PaymentRepository
+func (r *NotifyingPaymentRepo) GetPaymentByStripePaymentID(ctx context.Context, paymentIntentID string) (*repository.Payment, error) {
+	return r.next.GetPaymentByStripePaymentID(ctx, paymentIntentID)
+}
+
+// UpdatePaymentStatus implements repository.PaymentRepository. Updates that
+// leave the status unchanged (e.g. a redelivered Stripe event) are not
+// notified.
+func (r *NotifyingPaymentRepo) UpdatePaymentStatus(ctx context.Context, id string, status repository.PaymentStatus, details *repository.PaymentStatusUpdate) error {
+	before, err := r.next.GetPayment(ctx, id)
+	if err != nil {
+		// Let the update report the error (or apply it without notifying)
+		return r.next.UpdatePaymentStatus(ctx, id, status, details)
+	}
+	if err := r.next.UpdatePaymentStatus(ctx, id, status, details); err != nil {
+		return err
+	}
+	if before.CallbackURL == nil || before.Status == status {
+		return nil
+	}
+
+	after, err := r.next.GetPayment(ctx, id)
+	if err != nil {
+		r.logger.Error("failed to load payment for callback",
+			zap.String("payment_id", id),
+			zap.String("status", string(status)),
+			zap.Error(err),
+		)
+		return nil
+	}
+	r.notify(ctx, after, before.Status)
+	return nil
+}
+
+// notify queues the notification for p. The status change is already
+// stored, so a queueing failure is logged rather than returned.
+func (r *NotifyingPaymentRepo) notify(ctx context.Context, p *repository.Payment, previous repository.PaymentStatus) {
+	n := newNotification(p, previous)
+	payload, err := json.Marshal(n)
+	if err == nil {
+		_, err = r.queue.Enqueue(ctx, &repository.WebhookEvent{
+			Provider:  repository.WebhookProviderPaymentCallback,
+			EventID:   n.ID,
+			EventType: EventType,
+			Payload:   payload,
+		})
+	}
+	if err != nil {
+		r.logger.Error("failed to queue payment callback",
+			zap.String("payment_id", p.ID),
+			zap.String("status", string(p.Status)),
+			zap.Error(err),
+		)
+	}
+}
+
+// ListPayments implements repository.PaymentRepository
+func (r *NotifyingPaymentRepo) ListPayments(ctx context.Context, filter repository.PaymentFilter, page repository.Pagination) ([]*repository.Payment, int64, error) {
+	return r.next.ListPayments(ctx, filter, page)
+}
+
+// ArchivePayments implements repository.PaymentRepository
+func (r *NotifyingPaymentRepo) ArchivePayments(ctx context.Context, before time.Time, limit int) (int64, error) {
+	return r.next.ArchivePayments(ctx, before, limit)
+}
+
+// GetArchivedPayment implements repository.PaymentRepository
+func (r *NotifyingPaymentRepo) GetArchivedPayment(ctx context.Context, id string) (*repository.Payment, error) {
+	return r.next.GetArchivedPayment(ctx, id)
+}
+
+// ListArchivedPayments implements repository.PaymentRepository
+func (r *NotifyingPaymentRepo) ListArchivedPayments(ctx context.Context, filter repository.PaymentFilter, page repository.Pagination) ([]*repository.Payment, int64, error) {
+	return r.next.ListArchivedPayments(ctx, filter, page)
+}
+
+// ExpirePayments implements repository.PaymentRepository. Each expired
+// payment with a callback URL is notified.
+func (r *NotifyingPaymentRepo) ExpirePayments(ctx context.Context, method string, before time.Time, limit int) ([]string, error) {
+	ids, err := r.next.ExpirePayments(ctx, method, before, limit)
+	for _, id := range ids {
+		p, getErr := r.next.GetPayment(ctx, id)
+		if getErr != nil {
+			r.logger.Error("failed to load payment for callback",
+				zap.String("payment_id", id),
+				zap.String("status", string(repository.PaymentStatusExpired)),
+				zap.Error(getErr),
+			)
+			continue
+		}
+		if p.CallbackURL != nil {
+			r.notify(ctx, p, repository.PaymentStatusPending)
+		}
+	}
+	return ids, err
+}
+
+// CreateKYCVerification implements repository.PaymentRepository
+func (r *NotifyingPaymentRepo) CreateKYCVerification(ctx context.Context, v *repository.KYCVerification) error {
+	return r.next.CreateKYCVerification(ctx, v)
+}
+
+// GetKYCVerification implements repository.PaymentRepository
+func (r *NotifyingPaymentRepo) GetKYCVerification(ctx context.Context, id string) (*repository.KYCVerification, error) {
+	return r.next.GetKYCVerification(ctx, id)
+}
+
+// GetKYCVerificationByAddress implements repository.PaymentRepository
+func (r *NotifyingPaymentRepo) GetKYCVerificationByAddress(ctx context.Context, address string) (*repository.KYCVerification, error) {
+	return r.next.GetKYCVerificationByAddress(ctx, address)
+}
+
+// GetKYCVerificationByApplicant implements repository.PaymentRepository
+func (r *NotifyingPaymentRepo) GetKYCVerificationByApplicant(ctx context.Context, applicantID string) (*repository.KYCVerification, error) {
+	return r.next.GetKYCVerificationByApplicant(ctx, applicantID)
+}
+
+// UpdateKYCVerification implements repository.PaymentRepository
+func (r *NotifyingPaymentRepo) UpdateKYCVerification(ctx context.Context, id string, update *repository.KYCVerificationUpdate) error {
+	return r.next.UpdateKYCVerification(ctx, id, update)
+}
+
+// ListKYCVerifications implements repository.PaymentRepository
+func (r *NotifyingPaymentRepo) ListKYCVerifications(ctx context.Context, filter repository.KYCVerificationFilter, page repository.Pagination) ([]*repository.KYCVerification, int64, error) {
+	return r.next.ListKYCVerifications(ctx, filter, page)
+}
@@ -0,0 +1,128 @@
+package callbacks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// deliveryTimeout bounds one POST to a callback URL
+const deliveryTimeout = 10 * time.Second
+
+// ErrPrivateAddress is returned when a callback URL resolves to a loopback,
+// private or link-local address and insecure callbacks are not allowed
+var ErrPrivateAddress = errors.New("callback url resolves to a non-public address")
+
+// Sender delivers queued payment notifications (registered with the webhook
+// processor for WebhookProviderPaymentCallback)
+type Sender struct {
+	payments repository.PaymentRepository
+	client   *http.Client
+	logger   *zap.Logger
+	now      func() time.Time
+}
+
+// NewSender creates a sender that reads callback URLs and secrets from
+// payments. Unless allowInsecure is set (local development), callbacks to
+// loopback, private and link-local addresses are refused so a dapp cannot aim
+// the server at internal services.
+func NewSender(payments repository.PaymentRepository, allowInsecure bool, logger *zap.Logger) *Sender {
+	return &Sender{
+		payments: payments,
+		client:   NewClient(allowInsecure),
+		logger:   logger,
+		now:      time.Now,
+	}
+}
+
+// NewClient returns the HTTP client callbacks are delivered with. Unless
+// allowInsecure is set it refuses to connect to non-public addresses, and
+// it treats a redirect as a failed delivery.
+func NewClient(allowInsecure bool) *http.Client {
+	dialer := &net.Dialer{Timeout: 5 * time.Second}
+	if !allowInsecure {
+		dialer.Control = publicOnly
+	}
+	return &http.Client{
+		Timeout:   deliveryTimeout,
+		Transport: &http.Transport{DialContext: dialer.DialContext, TLSHandshakeTimeout: 5 * time.Second},
+		// A redirect is treated as a failed delivery
+		CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+	}
+}
+
+// ProcessWebhookEvent POSTs a queued notification to the payment's callback
+// URL (called by the background webhook processor). A non-2xx response is
+// retried with backoff until the event is dead-lettered.
+func (s *Sender) ProcessWebhookEvent(ctx context.Context, e *repository.WebhookEvent) error {
+	var n Notification
+	if err := json.Unmarshal(e.Payload, &n); err != nil {
+		return fmt.Errorf("parsing stored payment callback: %w", err)
+	}
+
+	p, err := s.payments.GetPayment(ctx, n.PaymentID)
+	if err != nil {
+		if errors.Is(err, repository.ErrPaymentNotFound) {
+			s.logger.Warn("dropping callback for unknown payment", zap.String("payment_id", n.PaymentID))
+			return nil
+		}
+		return fmt.Errorf("getting payment %s: %w", n.PaymentID, err)
+	}
+	if p.CallbackURL == nil || p.CallbackSecret == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deliveryTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, *p.CallbackURL, bytes.NewReader(e.Payload))
+	if err != nil {
+		return fmt.Errorf("building callback request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "nexus-protocol-callbacks/1")
+	req.Header.Set(EventIDHeader, n.ID)
+	req.Header.Set(SignatureHeader, Sign(*p.CallbackSecret, s.now(), e.Payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("posting payment callback: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("payment callback returned %d", resp.StatusCode)
+	}
+	s.logger.Info("payment callback delivered",
+		zap.String("payment_id", n.PaymentID),
+		zap.String("status", string(n.Status)),
+	)
+	return nil
+}
+
+// publicOnly refuses connections to non-public addresses. It runs after DNS
+// resolution, so a public hostname resolving to an internal address is
+// refused too.
+func publicOnly(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsMulticast() {
+		return fmt.Errorf("%w: %s", ErrPrivateAddress, host)
+	}
+	return nil
+}
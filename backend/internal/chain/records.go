@@ -0,0 +1,570 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure record handlers implement RecordHandler
+var (
+	_ RecordHandler = (*PaymentRecordHandler)(nil)
+	_ RecordHandler = (*MetaTxRecordHandler)(nil)
+	_ RecordHandler = (*GasSettlementRecordHandler)(nil)
+	_ RecordHandler = (*PayoutRecordHandler)(nil)
+	_ RecordHandler = (*SafeTxRecordHandler)(nil)
+)
+
+// ============================================================================
+// Crypto Payments
+// ============================================================================
+
+// PaymentRecordHandler applies confirmation outcomes to crypto payments
+type PaymentRecordHandler struct {
+	repo   repository.PaymentRepository
+	logger *zap.Logger
+}
+
+// NewPaymentRecordHandler creates a new payment record handler
+func NewPaymentRecordHandler(repo repository.PaymentRepository, logger *zap.Logger) *PaymentRecordHandler {
+	return &PaymentRecordHandler{repo: repo, logger: logger}
+}
+
+// OnConfirmed marks the payment completed
+func (h *PaymentRecordHandler) OnConfirmed(ctx context.Context, tx *repository.TrackedTx, receipt *types.Receipt) error {
+	if err := h.repo.UpdatePaymentStatus(ctx, tx.RecordID, repository.PaymentStatusCompleted, nil); err != nil {
+		return fmt.Errorf("completing payment %s: %w", tx.RecordID, err)
+	}
+
+	h.logger.Info("crypto payment confirmed",
+		zap.String("payment_id", tx.RecordID),
+		zap.String("tx_hash", tx.TxHash),
+		zap.Uint64("block", receipt.BlockNumber.Uint64()),
+	)
+	return nil
+}
+
+// OnFailed marks the payment failed
+func (h *PaymentRecordHandler) OnFailed(ctx context.Context, tx *repository.TrackedTx, receipt *types.Receipt) error {
+	errMsg := "payment transaction reverted"
+	if err := h.repo.UpdatePaymentStatus(ctx, tx.RecordID, repository.PaymentStatusFailed, &repository.PaymentStatusUpdate{
+		ErrorMessage: &errMsg,
+	}); err != nil {
+		return fmt.Errorf("failing payment %s: %w", tx.RecordID, err)
+	}
+
+	h.logger.Warn("crypto payment reverted",
+		zap.String("payment_id", tx.RecordID),
+		zap.String("tx_hash", tx.TxHash),
+	)
+	return nil
+}
+
+// OnReorged returns the payment to pending until it is re-verified
+func (h *PaymentRecordHandler) OnReorged(ctx context.Context, tx *repository.TrackedTx) error {
+	if err := h.repo.UpdatePaymentStatus(ctx, tx.RecordID, repository.PaymentStatusPending, nil); err != nil {
+		return fmt.Errorf("resetting payment %s: %w", tx.RecordID, err)
+	}
+	return nil
+}
+
+// ============================================================================
+// Meta-Transactions
+// ============================================================================
+
+// MetaTxRecordHandler applies confirmation outcomes to relayed meta-transactions
+type MetaTxRecordHandler struct {
+	repo    repository.RelayerRepository
+	charger GasCharger
+	logger  *zap.Logger
+}
+
+// GasCharger bills the gas of a mined meta-transaction to its signer
+type GasCharger interface {
+	ChargeMetaTx(ctx context.Context, metaTxID string, receipt *types.Receipt) error
+}
+
+// NewMetaTxRecordHandler creates a new meta-transaction record handler
+func NewMetaTxRecordHandler(repo repository.RelayerRepository, logger *zap.Logger) *MetaTxRecordHandler {
+	return &MetaTxRecordHandler{repo: repo, logger: logger}
+}
+
+// SetGasCharger enables sponsored-gas billing. Reverted meta-transactions
+// are charged too: the relayer paid for their gas all the same.
+func (h *MetaTxRecordHandler) SetGasCharger(charger GasCharger) {
+	h.charger = charger
+}
+
+// OnConfirmed marks the meta-transaction confirmed and records gas costs
+func (h *MetaTxRecordHandler) OnConfirmed(ctx context.Context, tx *repository.TrackedTx, receipt *types.Receipt) error {
+	update := gasUpdate(repository.MetaTxStatusConfirmed, receipt)
+	if err := h.repo.UpdateMetaTxStatus(ctx, tx.RecordID, update); err != nil {
+		return fmt.Errorf("confirming meta-tx %s: %w", tx.RecordID, err)
+	}
+	return h.charge(ctx, tx, receipt)
+}
+
+// OnFailed marks the meta-transaction failed and records gas costs
+func (h *MetaTxRecordHandler) OnFailed(ctx context.Context, tx *repository.TrackedTx, receipt *types.Receipt) error {
+	update := gasUpdate(repository.MetaTxStatusFailed, receipt)
+	errMsg := "forwarded call reverted"
+	update.ErrorMessage = &errMsg
+	if err := h.repo.UpdateMetaTxStatus(ctx, tx.RecordID, update); err != nil {
+		return fmt.Errorf("failing meta-tx %s: %w", tx.RecordID, err)
+	}
+	return h.charge(ctx, tx, receipt)
+}
+
+// OnReorged returns the meta-transaction to submitted; the signed tx is
+// usually re-included by the network
+func (h *MetaTxRecordHandler) OnReorged(ctx context.Context, tx *repository.TrackedTx) error {
+	if err := h.repo.UpdateMetaTxStatus(ctx, tx.RecordID, &repository.MetaTxStatusUpdate{
+		Status: repository.MetaTxStatusSubmitted,
+	}); err != nil {
+		return fmt.Errorf("resetting meta-tx %s: %w", tx.RecordID, err)
+	}
+	return nil
+}
+
+// charge bills the meta-transaction's gas if billing is enabled
+func (h *MetaTxRecordHandler) charge(ctx context.Context, tx *repository.TrackedTx, receipt *types.Receipt) error {
+	if h.charger == nil {
+		return nil
+	}
+	return h.charger.ChargeMetaTx(ctx, tx.RecordID, receipt)
+}
+
+// gasUpdate builds a status update carrying gas used, gas price and relay cost
+func gasUpdate(status repository.MetaTxStatus, receipt *types.Receipt) *repository.MetaTxStatusUpdate {
+	gasUsed := receipt.GasUsed
+	update := &repository.MetaTxStatusUpdate{
+		Status:  status,
+		GasUsed: &gasUsed,
+	}
+
+	if receipt.EffectiveGasPrice != nil {
+		gasPrice := receipt.EffectiveGasPrice.String()
+		cost := new(big.Int).Mul(receipt.EffectiveGasPrice, new(big.Int).SetUint64(gasUsed))
+		costETH := new(big.Float).Quo(new(big.Float).SetInt(cost), big.NewFloat(1e18)).Text('f', 18)
+		update.GasPrice = &gasPrice
+		update.RelayCostETH = &costETH
+	}
+
+	return update
+}
+
+// ============================================================================
+// Gas Settlements
+// ============================================================================
+
+// transferTopic is the ERC-20 Transfer(address,address,uint256) event topic
+var transferTopic = crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))
+
+// GasSettlementRecordHandler applies confirmation outcomes to on-chain gas
+// debt settlements
+type GasSettlementRecordHandler struct {
+	repo   repository.GasBillingRepository
+	logger *zap.Logger
+}
+
+// NewGasSettlementRecordHandler creates a new gas settlement record handler
+func NewGasSettlementRecordHandler(repo repository.GasBillingRepository, logger *zap.Logger) *GasSettlementRecordHandler {
+	return &GasSettlementRecordHandler{repo: repo, logger: logger}
+}
+
+// OnConfirmed settles the settlement if the transaction transferred at least
+// the settled amount of NEXUS from the user to the treasury, and fails it
+// otherwise
+func (h *GasSettlementRecordHandler) OnConfirmed(ctx context.Context, tx *repository.TrackedTx, receipt *types.Receipt) error {
+	s, err := h.repo.GetGasSettlement(ctx, tx.RecordID)
+	if err != nil {
+		return fmt.Errorf("loading gas settlement %s: %w", tx.RecordID, err)
+	}
+
+	update := &repository.GasSettlementUpdate{Status: repository.GasSettlementSettled}
+	if reason := verifySettlementTransfer(s, receipt); reason != "" {
+		update.Status = repository.GasSettlementFailed
+		update.ErrorMessage = &reason
+	}
+	if err := h.update(ctx, tx.RecordID, update); err != nil {
+		return err
+	}
+
+	if update.Status == repository.GasSettlementSettled {
+		h.logger.Info("gas debt settled on-chain",
+			zap.String("settlement_id", s.ID),
+			zap.String("user", s.UserAddress),
+			zap.String("amount_nexus", s.AmountNexus),
+			zap.String("tx_hash", tx.TxHash),
+		)
+	} else {
+		h.logger.Warn("gas settlement transfer rejected",
+			zap.String("settlement_id", s.ID),
+			zap.String("tx_hash", tx.TxHash),
+			zap.String("reason", *update.ErrorMessage),
+		)
+	}
+	return nil
+}
+
+// OnFailed marks the settlement failed
+func (h *GasSettlementRecordHandler) OnFailed(ctx context.Context, tx *repository.TrackedTx, receipt *types.Receipt) error {
+	errMsg := "settlement transfer reverted"
+	return h.update(ctx, tx.RecordID, &repository.GasSettlementUpdate{
+		Status:       repository.GasSettlementFailed,
+		ErrorMessage: &errMsg,
+	})
+}
+
+// OnReorged returns the settlement to pending until it is re-verified
+func (h *GasSettlementRecordHandler) OnReorged(ctx context.Context, tx *repository.TrackedTx) error {
+	return h.update(ctx, tx.RecordID, &repository.GasSettlementUpdate{
+		Status: repository.GasSettlementPending,
+	})
+}
+
+// update applies a status change. A settlement an admin already closed
+// (voided) is left as it is.
+func (h *GasSettlementRecordHandler) update(ctx context.Context, id string, update *repository.GasSettlementUpdate) error {
+	err := h.repo.UpdateGasSettlementStatus(ctx, id, update)
+	if errors.Is(err, repository.ErrGasSettlementClosed) {
+		h.logger.Info("gas settlement already closed, ignoring confirmation outcome",
+			zap.String("settlement_id", id),
+			zap.String("status", string(update.Status)),
+		)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("updating gas settlement %s: %w", id, err)
+	}
+	return nil
+}
+
+// verifySettlementTransfer checks the receipt for NEXUS transfers from the
+// user to the treasury covering the settlement amount and returns why it
+// falls short, or "" if it does not
+func verifySettlementTransfer(s *repository.GasSettlement, receipt *types.Receipt) string {
+	if s.TokenAddress == nil || s.TreasuryAddress == nil {
+		return "settlement has no token or treasury to verify against"
+	}
+	want, ok := new(big.Int).SetString(s.AmountNexus, 10)
+	if !ok {
+		return "invalid settlement amount"
+	}
+
+	token := common.HexToAddress(*s.TokenAddress)
+	from := common.HexToAddress(s.UserAddress)
+	to := common.HexToAddress(*s.TreasuryAddress)
+
+	paid := new(big.Int)
+	for _, log := range receipt.Logs {
+		if log.Address != token || len(log.Topics) != 3 || log.Topics[0] != transferTopic {
+			continue
+		}
+		if common.BytesToAddress(log.Topics[1].Bytes()) != from || common.BytesToAddress(log.Topics[2].Bytes()) != to {
+			continue
+		}
+		paid.Add(paid, new(big.Int).SetBytes(log.Data))
+	}
+
+	switch {
+	case paid.Sign() == 0:
+		return fmt.Sprintf("no NEXUS transfer from %s to treasury %s", strings.ToLower(from.Hex()), strings.ToLower(to.Hex()))
+	case paid.Cmp(want) < 0:
+		return fmt.Sprintf("transferred %s NEXUS base units, settlement is for %s", paid, want)
+	}
+	return ""
+}
+
+// ============================================================================
+// Payouts
+// ============================================================================
+
+// PayoutRecordHandler reconciles sent payouts against their receipts
+type PayoutRecordHandler struct {
+	repo   repository.PayoutRepository
+	logger *zap.Logger
+}
+
+// NewPayoutRecordHandler creates a new payout record handler
+func NewPayoutRecordHandler(repo repository.PayoutRepository, logger *zap.Logger) *PayoutRecordHandler {
+	return &PayoutRecordHandler{repo: repo, logger: logger}
+}
+
+// OnConfirmed confirms the payout if the receipt shows it paid the recipient
+// in full, and fails it otherwise. Block, gas used and fee are recorded
+// either way.
+func (h *PayoutRecordHandler) OnConfirmed(ctx context.Context, tx *repository.TrackedTx, receipt *types.Receipt) error {
+	p, err := h.repo.GetPayout(ctx, tx.RecordID)
+	if err != nil {
+		return fmt.Errorf("loading payout %s: %w", tx.RecordID, err)
+	}
+
+	update := payoutReceiptUpdate(repository.PayoutConfirmed, receipt)
+	if reason := verifyPayoutTransfer(p, receipt); reason != "" {
+		update.Status = repository.PayoutFailed
+		update.ErrorMessage = &reason
+	}
+	if err := h.update(ctx, tx.RecordID, update); err != nil {
+		return err
+	}
+
+	if update.Status == repository.PayoutConfirmed {
+		h.logger.Info("payout confirmed",
+			zap.String("payout_id", p.ID),
+			zap.String("kind", string(p.Kind)),
+			zap.String("recipient", p.Recipient),
+			zap.String("amount", p.Amount),
+			zap.String("currency", p.Currency),
+			zap.String("tx_hash", tx.TxHash),
+		)
+	} else {
+		h.logger.Error("payout receipt does not match",
+			zap.String("payout_id", p.ID),
+			zap.String("tx_hash", tx.TxHash),
+			zap.String("reason", *update.ErrorMessage),
+		)
+	}
+	return nil
+}
+
+// OnFailed marks the payout failed
+func (h *PayoutRecordHandler) OnFailed(ctx context.Context, tx *repository.TrackedTx, receipt *types.Receipt) error {
+	update := payoutReceiptUpdate(repository.PayoutFailed, receipt)
+	errMsg := "payout transfer reverted"
+	update.ErrorMessage = &errMsg
+	if err := h.update(ctx, tx.RecordID, update); err != nil {
+		return err
+	}
+
+	h.logger.Error("payout transfer reverted",
+		zap.String("payout_id", tx.RecordID),
+		zap.String("tx_hash", tx.TxHash),
+	)
+	return nil
+}
+
+// OnReorged returns the payout to submitted; the signed tx is usually
+// re-included by the network
+func (h *PayoutRecordHandler) OnReorged(ctx context.Context, tx *repository.TrackedTx) error {
+	return h.update(ctx, tx.RecordID, &repository.PayoutUpdate{
+		Status: repository.PayoutSubmitted,
+	})
+}
+
+// update applies a status change, ignoring outcomes the payout's status no
+// longer allows
+func (h *PayoutRecordHandler) update(ctx context.Context, id string, update *repository.PayoutUpdate) error {
+	err := h.repo.UpdatePayoutStatus(ctx, id, update)
+	if errors.Is(err, repository.ErrPayoutClosed) {
+		h.logger.Info("payout cannot take confirmation outcome, ignoring",
+			zap.String("payout_id", id),
+			zap.String("status", string(update.Status)),
+		)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("updating payout %s: %w", id, err)
+	}
+	return nil
+}
+
+// payoutReceiptUpdate builds a status update carrying the receipt's block,
+// gas used and fee
+func payoutReceiptUpdate(status repository.PayoutStatus, receipt *types.Receipt) *repository.PayoutUpdate {
+	gasUsed := receipt.GasUsed
+	update := &repository.PayoutUpdate{
+		Status:  status,
+		GasUsed: &gasUsed,
+	}
+	if receipt.BlockNumber != nil {
+		block := receipt.BlockNumber.Uint64()
+		update.BlockNumber = &block
+	}
+	if receipt.EffectiveGasPrice != nil {
+		fee := new(big.Int).Mul(receipt.EffectiveGasPrice, new(big.Int).SetUint64(gasUsed)).String()
+		update.FeeWei = &fee
+	}
+	return update
+}
+
+// verifyPayoutTransfer checks that a NEXUS payout's receipt transfers the
+// amount from the sending key to the recipient and returns why it falls
+// short, or "" if it does not. An ETH payout's value is part of the signed
+// transaction, so a successful receipt is enough.
+func verifyPayoutTransfer(p *repository.Payout, receipt *types.Receipt) string {
+	if p.TokenAddress == nil {
+		return ""
+	}
+	if p.SenderAddress == nil {
+		return "payout has no sender to verify against"
+	}
+	want, ok := new(big.Int).SetString(p.Amount, 10)
+	if !ok {
+		return "invalid payout amount"
+	}
+
+	token := common.HexToAddress(*p.TokenAddress)
+	from := common.HexToAddress(*p.SenderAddress)
+	to := common.HexToAddress(p.Recipient)
+
+	paid := new(big.Int)
+	for _, log := range receipt.Logs {
+		if log.Address != token || len(log.Topics) != 3 || log.Topics[0] != transferTopic {
+			continue
+		}
+		if common.BytesToAddress(log.Topics[1].Bytes()) != from || common.BytesToAddress(log.Topics[2].Bytes()) != to {
+			continue
+		}
+		paid.Add(paid, new(big.Int).SetBytes(log.Data))
+	}
+
+	switch {
+	case paid.Sign() == 0:
+		return fmt.Sprintf("no NEXUS transfer from %s to %s", strings.ToLower(from.Hex()), strings.ToLower(to.Hex()))
+	case paid.Cmp(want) != 0:
+		return fmt.Sprintf("transferred %s NEXUS base units, payout is for %s", paid, want)
+	}
+	return ""
+}
+
+// ============================================================================
+// Safe Transactions
+// ============================================================================
+
+// Safe (v1.3+) execution events; both carry the safeTxHash as the first
+// data word
+var (
+	safeExecutionSuccessTopic = crypto.Keccak256Hash([]byte("ExecutionSuccess(bytes32,uint256)"))
+	safeExecutionFailureTopic = crypto.Keccak256Hash([]byte("ExecutionFailure(bytes32,uint256)"))
+)
+
+// SafeTxRecordHandler reconciles executed Safe transactions against their
+// receipts
+type SafeTxRecordHandler struct {
+	repo   repository.SafeTransactionRepository
+	logger *zap.Logger
+}
+
+// NewSafeTxRecordHandler creates a new Safe transaction record handler
+func NewSafeTxRecordHandler(repo repository.SafeTransactionRepository, logger *zap.Logger) *SafeTxRecordHandler {
+	return &SafeTxRecordHandler{repo: repo, logger: logger}
+}
+
+// OnConfirmed marks the Safe transaction executed if its Safe emitted
+// ExecutionSuccess for it, and failed otherwise: execTransaction does not
+// revert when the inner call fails with safeTxGas or gasPrice set.
+func (h *SafeTxRecordHandler) OnConfirmed(ctx context.Context, tx *repository.TrackedTx, receipt *types.Receipt) error {
+	safeTx, err := h.repo.GetSafeTransaction(ctx, tx.RecordID)
+	if err != nil {
+		return fmt.Errorf("loading safe transaction %s: %w", tx.RecordID, err)
+	}
+
+	update := safeTxReceiptUpdate(repository.SafeTxExecuted, receipt)
+	if reason := verifySafeExecution(safeTx, receipt); reason != "" {
+		update.Status = repository.SafeTxFailed
+		update.ErrorMessage = &reason
+	}
+	if err := h.update(ctx, tx.RecordID, update); err != nil {
+		return err
+	}
+
+	if update.Status == repository.SafeTxExecuted {
+		h.logger.Info("Safe transaction executed",
+			zap.String("safe_tx_id", safeTx.ID),
+			zap.String("safe", safeTx.SafeAddress),
+			zap.Uint64("nonce", safeTx.Nonce),
+			zap.String("tx_hash", tx.TxHash),
+		)
+	} else {
+		h.logger.Error("Safe transaction did not execute",
+			zap.String("safe_tx_id", safeTx.ID),
+			zap.String("tx_hash", tx.TxHash),
+			zap.String("reason", *update.ErrorMessage),
+		)
+	}
+	return nil
+}
+
+// OnFailed marks the Safe transaction failed
+func (h *SafeTxRecordHandler) OnFailed(ctx context.Context, tx *repository.TrackedTx, receipt *types.Receipt) error {
+	update := safeTxReceiptUpdate(repository.SafeTxFailed, receipt)
+	errMsg := "execTransaction reverted"
+	update.ErrorMessage = &errMsg
+	if err := h.update(ctx, tx.RecordID, update); err != nil {
+		return err
+	}
+
+	h.logger.Error("Safe execTransaction reverted",
+		zap.String("safe_tx_id", tx.RecordID),
+		zap.String("tx_hash", tx.TxHash),
+	)
+	return nil
+}
+
+// OnReorged returns the Safe transaction to submitted; the signed tx is
+// usually re-included by the network
+func (h *SafeTxRecordHandler) OnReorged(ctx context.Context, tx *repository.TrackedTx) error {
+	return h.update(ctx, tx.RecordID, &repository.SafeTransactionUpdate{
+		Status: repository.SafeTxSubmitted,
+	})
+}
+
+// update applies a status change, ignoring outcomes the Safe transaction's
+// status no longer allows
+func (h *SafeTxRecordHandler) update(ctx context.Context, id string, update *repository.SafeTransactionUpdate) error {
+	err := h.repo.UpdateSafeTransactionStatus(ctx, id, update)
+	if errors.Is(err, repository.ErrSafeTransactionClosed) {
+		h.logger.Info("Safe transaction cannot take confirmation outcome, ignoring",
+			zap.String("safe_tx_id", id),
+			zap.String("status", string(update.Status)),
+		)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("updating safe transaction %s: %w", id, err)
+	}
+	return nil
+}
+
+// safeTxReceiptUpdate builds a status update carrying the receipt's block
+func safeTxReceiptUpdate(status repository.SafeTransactionStatus, receipt *types.Receipt) *repository.SafeTransactionUpdate {
+	update := &repository.SafeTransactionUpdate{Status: status}
+	if receipt.BlockNumber != nil {
+		block := receipt.BlockNumber.Uint64()
+		update.BlockNumber = &block
+	}
+	return update
+}
+
+// verifySafeExecution checks that the receipt has the Safe's
+// ExecutionSuccess event for the transaction and returns why not, or "" if
+// it does
+func verifySafeExecution(safeTx *repository.SafeTransaction, receipt *types.Receipt) string {
+	safe := common.HexToAddress(safeTx.SafeAddress)
+	hash := common.HexToHash(safeTx.SafeTxHash)
+	for _, log := range receipt.Logs {
+		if log.Address != safe || len(log.Topics) == 0 || len(log.Data) < 32 {
+			continue
+		}
+		if common.BytesToHash(log.Data[:32]) != hash {
+			continue
+		}
+		switch log.Topics[0] {
+		case safeExecutionSuccessTopic:
+			return ""
+		case safeExecutionFailureTopic:
+			return "the Safe's call failed (ExecutionFailure)"
+		}
+	}
+	return "no ExecutionSuccess event for " + strings.ToLower(hash.Hex())
+}
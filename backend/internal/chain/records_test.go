@@ -0,0 +1,185 @@
+package chain_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/chain"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/storage/memory"
+)
+
+var (
+	nexusToken = common.HexToAddress("0x5FbDB2315678afecb367f032d93F642f64180aa3")
+	treasury   = common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	payer      = common.HexToAddress("0x3C44CdDdB6a900fa2b585dd299e03d12FA4293BC")
+)
+
+// transferLog builds an ERC-20 Transfer event
+func transferLog(token, from, to common.Address, value int64) *types.Log {
+	return &types.Log{
+		Address: token,
+		Topics: []common.Hash{
+			crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)")),
+			common.BytesToHash(from.Bytes()),
+			common.BytesToHash(to.Bytes()),
+		},
+		Data: common.LeftPadBytes(big.NewInt(value).Bytes(), 32),
+	}
+}
+
+func TestGasSettlementRecordHandler_VerifiesTransfer(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryGasBillingRepo()
+	handler := chain.NewGasSettlementRecordHandler(repo, zap.NewNop())
+
+	settle := func(txHash string, logs ...*types.Log) *repository.GasSettlement {
+		token, to := nexusToken.Hex(), treasury.Hex()
+		s := &repository.GasSettlement{UserAddress: payer.Hex(), Method: repository.GasSettlementOnchain, AmountNexus: "1000",
+			Status: repository.GasSettlementPending, TxHash: &txHash, TokenAddress: &token, TreasuryAddress: &to}
+		require.NoError(t, repo.CreateGasSettlement(ctx, s))
+
+		tracked := &repository.TrackedTx{RecordID: s.ID, TxHash: txHash}
+		require.NoError(t, handler.OnConfirmed(ctx, tracked, &types.Receipt{Status: types.ReceiptStatusSuccessful, Logs: logs}))
+		got, err := repo.GetGasSettlement(ctx, s.ID)
+		require.NoError(t, err)
+		return got
+	}
+
+	// Split across two transfers
+	paid := settle("0x01", transferLog(nexusToken, payer, treasury, 600), transferLog(nexusToken, payer, treasury, 400))
+	assert.Equal(t, repository.GasSettlementSettled, paid.Status)
+
+	short := settle("0x02", transferLog(nexusToken, payer, treasury, 999))
+	assert.Equal(t, repository.GasSettlementFailed, short.Status)
+	require.NotNil(t, short.ErrorMessage)
+	assert.Contains(t, *short.ErrorMessage, "transferred 999")
+
+	wrongToken := settle("0x03", transferLog(common.HexToAddress("0x01"), payer, treasury, 1000))
+	assert.Equal(t, repository.GasSettlementFailed, wrongToken.Status)
+
+	wrongRecipient := settle("0x04", transferLog(nexusToken, payer, payer, 1000))
+	assert.Equal(t, repository.GasSettlementFailed, wrongRecipient.Status)
+
+	// A reorg reopens the settlement; once voided it stays closed
+	tracked := &repository.TrackedTx{RecordID: paid.ID, TxHash: "0x01"}
+	require.NoError(t, handler.OnReorged(ctx, tracked))
+	require.NoError(t, repo.UpdateGasSettlementStatus(ctx, paid.ID, &repository.GasSettlementUpdate{Status: repository.GasSettlementVoid}))
+	require.NoError(t, handler.OnFailed(ctx, tracked, &types.Receipt{}))
+	got, err := repo.GetGasSettlement(ctx, paid.ID)
+	require.NoError(t, err)
+	assert.Equal(t, repository.GasSettlementVoid, got.Status)
+}
+
+func TestPayoutRecordHandler_Reconciles(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryPayoutRepo()
+	handler := chain.NewPayoutRecordHandler(repo, zap.NewNop())
+
+	send := func(txHash string, token *string) *repository.Payout {
+		p := &repository.Payout{Kind: repository.PayoutGrant, Recipient: payer.Hex(), Currency: "NEXUS", TokenAddress: token,
+			Amount: "1000", Status: repository.PayoutApproved, CreatedBy: "alice"}
+		if token == nil {
+			p.Currency = "ETH"
+		}
+		require.NoError(t, repo.CreatePayout(ctx, p))
+		sender := treasury.Hex()
+		require.NoError(t, repo.UpdatePayoutStatus(ctx, p.ID, &repository.PayoutUpdate{Status: repository.PayoutSending}))
+		require.NoError(t, repo.UpdatePayoutStatus(ctx, p.ID, &repository.PayoutUpdate{Status: repository.PayoutSubmitted, SenderAddress: &sender, TxHash: &txHash}))
+		got, err := repo.GetPayout(ctx, p.ID)
+		require.NoError(t, err)
+		return got
+	}
+	confirm := func(p *repository.Payout, logs ...*types.Log) *repository.Payout {
+		receipt := &types.Receipt{Status: types.ReceiptStatusSuccessful, Logs: logs, GasUsed: 50000,
+			EffectiveGasPrice: big.NewInt(2e9), BlockNumber: big.NewInt(42)}
+		require.NoError(t, handler.OnConfirmed(ctx, &repository.TrackedTx{RecordID: p.ID, TxHash: *p.TxHash}, receipt))
+		got, err := repo.GetPayout(ctx, p.ID)
+		require.NoError(t, err)
+		return got
+	}
+
+	token := nexusToken.Hex()
+	paid := confirm(send("0x01", &token), transferLog(nexusToken, treasury, payer, 1000))
+	assert.Equal(t, repository.PayoutConfirmed, paid.Status)
+	require.NotNil(t, paid.FeeWei)
+	assert.Equal(t, "100000000000000", *paid.FeeWei)
+	assert.Equal(t, uint64(42), *paid.BlockNumber)
+	assert.NotNil(t, paid.ConfirmedAt)
+
+	short := confirm(send("0x02", &token), transferLog(nexusToken, treasury, payer, 999))
+	assert.Equal(t, repository.PayoutFailed, short.Status)
+	require.NotNil(t, short.ErrorMessage)
+	assert.Contains(t, *short.ErrorMessage, "transferred 999")
+
+	eth := confirm(send("0x03", nil))
+	assert.Equal(t, repository.PayoutConfirmed, eth.Status)
+
+	// A reorg returns the payout to submitted and drops its block
+	require.NoError(t, handler.OnReorged(ctx, &repository.TrackedTx{RecordID: paid.ID, TxHash: "0x01"}))
+	got, err := repo.GetPayout(ctx, paid.ID)
+	require.NoError(t, err)
+	assert.Equal(t, repository.PayoutSubmitted, got.Status)
+	assert.Nil(t, got.BlockNumber)
+	assert.Nil(t, got.ConfirmedAt)
+	assert.Equal(t, "0x01", *got.TxHash)
+}
+
+func TestSafeTxRecordHandler_Reconciles(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemorySafeTransactionRepo()
+	handler := chain.NewSafeTxRecordHandler(repo, zap.NewNop())
+	safe := treasury
+
+	send := func(safeTxHash, txHash string) *repository.SafeTransaction {
+		tx := &repository.SafeTransaction{ChainID: 1, SafeAddress: safe.Hex(), To: payer.Hex(), Value: "0", Data: "0x",
+			SafeTxGas: "0", BaseGas: "0", GasPrice: "0", SafeTxHash: safeTxHash, Threshold: 1, Status: repository.SafeTxReady}
+		require.NoError(t, repo.CreateSafeTransaction(ctx, tx))
+		require.NoError(t, repo.UpdateSafeTransactionStatus(ctx, tx.ID, &repository.SafeTransactionUpdate{Status: repository.SafeTxSending}))
+		require.NoError(t, repo.UpdateSafeTransactionStatus(ctx, tx.ID, &repository.SafeTransactionUpdate{Status: repository.SafeTxSubmitted, TxHash: &txHash}))
+		return tx
+	}
+	confirm := func(tx *repository.SafeTransaction, logs ...*types.Log) *repository.SafeTransaction {
+		receipt := &types.Receipt{Status: types.ReceiptStatusSuccessful, Logs: logs, BlockNumber: big.NewInt(42)}
+		require.NoError(t, handler.OnConfirmed(ctx, &repository.TrackedTx{RecordID: tx.ID, TxHash: "0x01"}, receipt))
+		got, err := repo.GetSafeTransaction(ctx, tx.ID)
+		require.NoError(t, err)
+		return got
+	}
+	execution := func(event string, safeTxHash common.Hash) *types.Log {
+		return &types.Log{
+			Address: safe,
+			Topics:  []common.Hash{crypto.Keccak256Hash([]byte(event + "(bytes32,uint256)"))},
+			Data:    append(safeTxHash.Bytes(), make([]byte, 32)...),
+		}
+	}
+
+	hashA, hashB, hashC := common.HexToHash("0xa1"), common.HexToHash("0xb2"), common.HexToHash("0xc3")
+	executed := confirm(send(hashA.Hex(), "0x01"), execution("ExecutionSuccess", hashA))
+	assert.Equal(t, repository.SafeTxExecuted, executed.Status)
+	require.NotNil(t, executed.BlockNumber)
+	assert.Equal(t, uint64(42), *executed.BlockNumber)
+
+	// execTransaction succeeds but the Safe's call failed
+	innerFailed := confirm(send(hashB.Hex(), "0x02"), execution("ExecutionFailure", hashB))
+	assert.Equal(t, repository.SafeTxFailed, innerFailed.Status)
+	require.NotNil(t, innerFailed.ErrorMessage)
+	assert.Contains(t, *innerFailed.ErrorMessage, "ExecutionFailure")
+
+	otherTx := confirm(send(hashC.Hex(), "0x03"), execution("ExecutionSuccess", hashA))
+	assert.Equal(t, repository.SafeTxFailed, otherTx.Status, "the event must be for this safeTxHash")
+
+	// A reorg returns it to submitted
+	require.NoError(t, handler.OnReorged(ctx, &repository.TrackedTx{RecordID: executed.ID, TxHash: "0x01"}))
+	got, err := repo.GetSafeTransaction(ctx, executed.ID)
+	require.NoError(t, err)
+	assert.Equal(t, repository.SafeTxSubmitted, got.Status)
+}
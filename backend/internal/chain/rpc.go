@@ -0,0 +1,738 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/ethclient/gethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/breaker"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/timing"
+)
+
+// RPC health settings
+const (
+	// failureThreshold consecutive failures put an endpoint in cooldown
+	failureThreshold = 3
+	rpcCooldown      = 30 * time.Second
+
+	healthCheckInterval = 30 * time.Second
+	healthCheckTimeout  = 5 * time.Second
+
+	// maxBlockLag is how far an endpoint's head may trail the best endpoint
+	// before it is deprioritized
+	maxBlockLag = 5
+
+	// latencyWeight is the EWMA weight given to the newest latency sample
+	latencyWeight = 0.2
+
+	// A chain's breaker opens after this many calls in a row fail on every
+	// endpoint, and sheds calls for rpcBreakerCooldown
+	rpcBreakerThreshold = 3
+	rpcBreakerCooldown  = 15 * time.Second
+)
+
+// ErrNoRPCEndpoints is returned when a chain has no configured RPC endpoints
+var ErrNoRPCEndpoints = errors.New("no rpc endpoints configured")
+
+// Ensure FailoverClient can back abigen bindings and the confirmation tracker
+var (
+	_ bind.ContractBackend = (*FailoverClient)(nil)
+	_ bind.DeployBackend   = (*FailoverClient)(nil)
+	_ Client               = (*FailoverClient)(nil)
+)
+
+// NetworkSource provides per-chain RPC endpoints (network_config)
+type NetworkSource interface {
+	GetNetworkByChainID(ctx context.Context, chainID int64) (*repository.NetworkConfig, error)
+}
+
+// EndpointStats is a point-in-time health snapshot of an RPC endpoint
+type EndpointStats struct {
+	ChainID             int64      `json:"chain_id"`
+	URL                 string     `json:"url"`
+	Healthy             bool       `json:"healthy"`
+	Lagging             bool       `json:"lagging"`
+	Requests            uint64     `json:"requests"`
+	Failures            uint64     `json:"failures"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	LatencyMs           float64    `json:"latency_ms"`
+	HeadBlock           uint64     `json:"head_block"`
+	LastError           string     `json:"last_error,omitempty"`
+	LastErrorAt         *time.Time `json:"last_error_at,omitempty"`
+	CooldownUntil       *time.Time `json:"cooldown_until,omitempty"`
+	Circuit             string     `json:"circuit"` // chain-wide breaker state
+}
+
+// ClientManager owns the RPC endpoints for every chain and hands out
+// failover clients shared by the relayer, verifier and indexers
+type ClientManager struct {
+	networks NetworkSource
+	logger   *zap.Logger
+
+	mu      sync.Mutex
+	static  map[int64][]string
+	ws      map[int64]string
+	clients map[int64]*FailoverClient
+}
+
+// NewClientManager creates a new RPC client manager. Endpoints are loaded from
+// network_config (rpc_url followed by rpc_fallback_urls) on first use.
+func NewClientManager(networks NetworkSource, logger *zap.Logger) *ClientManager {
+	return &ClientManager{
+		networks: networks,
+		logger:   logger,
+		static:   make(map[int64][]string),
+		ws:       make(map[int64]string),
+		clients:  make(map[int64]*FailoverClient),
+	}
+}
+
+// AddEndpoints registers endpoints for a chain ahead of those in
+// network_config (e.g. the RPC_URL environment variable)
+func (m *ClientManager) AddEndpoints(chainID int64, urls ...string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, u := range urls {
+		if u = strings.TrimSpace(u); u != "" {
+			m.static[chainID] = append(m.static[chainID], u)
+		}
+	}
+	delete(m.clients, chainID) // Rebuild on next use
+}
+
+// SetWSEndpoint sets the WebSocket endpoint for a chain, overriding
+// network_config.ws_url (e.g. the WS_RPC_URL environment variable)
+func (m *ClientManager) SetWSEndpoint(chainID int64, url string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if url = strings.TrimSpace(url); url != "" {
+		m.ws[chainID] = url
+		delete(m.clients, chainID) // Rebuild on next use
+	}
+}
+
+// Client returns the failover client for a chain
+func (m *ClientManager) Client(ctx context.Context, chainID int64) (*FailoverClient, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if c, ok := m.clients[chainID]; ok {
+		return c, nil
+	}
+
+	urls := append([]string{}, m.static[chainID]...)
+	wsURL := m.ws[chainID]
+	if m.networks != nil {
+		network, err := m.networks.GetNetworkByChainID(ctx, chainID)
+		switch {
+		case err == nil:
+			if network.RPCUrl != nil {
+				urls = append(urls, *network.RPCUrl)
+			}
+			urls = append(urls, network.RPCFallbackUrls...)
+			if wsURL == "" && network.WSUrl != nil {
+				wsURL = *network.WSUrl
+			}
+		case !errors.Is(err, repository.ErrNetworkNotFound):
+			return nil, fmt.Errorf("loading rpc endpoints for chain %d: %w", chainID, err)
+		}
+	}
+
+	c := newFailoverClient(chainID, dedupeURLs(urls), m.logger)
+	c.wsURL = wsURL
+	if len(c.endpoints) == 0 {
+		return nil, fmt.Errorf("chain %d: %w", chainID, ErrNoRPCEndpoints)
+	}
+
+	m.clients[chainID] = c
+	return c, nil
+}
+
+// Run probes every loaded endpoint until the context is cancelled
+func (m *ClientManager) Run(ctx context.Context) {
+	ticker := time.NewTicker(healthCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for _, c := range m.loadedClients() {
+				c.probe(ctx)
+			}
+		}
+	}
+}
+
+// EndpointStats returns health snapshots for all loaded endpoints
+func (m *ClientManager) EndpointStats() []EndpointStats {
+	var stats []EndpointStats
+	for _, c := range m.loadedClients() {
+		stats = append(stats, c.Stats()...)
+	}
+	sort.SliceStable(stats, func(i, j int) bool { return stats[i].ChainID < stats[j].ChainID })
+	return stats
+}
+
+// Close closes all dialed endpoints
+func (m *ClientManager) Close() {
+	for _, c := range m.loadedClients() {
+		c.Close()
+	}
+}
+
+func (m *ClientManager) loadedClients() []*FailoverClient {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	clients := make([]*FailoverClient, 0, len(m.clients))
+	for _, c := range m.clients {
+		clients = append(clients, c)
+	}
+	return clients
+}
+
+// ============================================================================
+// Endpoint Health
+// ============================================================================
+
+// endpoint is a single RPC URL with its health state
+type endpoint struct {
+	url string
+
+	mu                  sync.Mutex
+	client              *ethclient.Client
+	requests            uint64
+	failures            uint64
+	consecutiveFailures int
+	latency             time.Duration
+	headBlock           uint64
+	lagging             bool
+	lastError           string
+	lastErrorAt         time.Time
+	cooldownUntil       time.Time
+}
+
+// dial returns the endpoint's client, connecting on first use
+func (e *endpoint) dial(ctx context.Context) (*ethclient.Client, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.client != nil {
+		return e.client, nil
+	}
+
+	client, err := ethclient.DialContext(ctx, e.url)
+	if err != nil {
+		return nil, fmt.Errorf("dialing rpc endpoint: %w", err)
+	}
+	e.client = client
+	return client, nil
+}
+
+func (e *endpoint) recordSuccess(latency time.Duration) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.requests++
+	e.consecutiveFailures = 0
+	e.cooldownUntil = time.Time{}
+	if e.latency == 0 {
+		e.latency = latency
+	} else {
+		e.latency = time.Duration(latencyWeight*float64(latency) + (1-latencyWeight)*float64(e.latency))
+	}
+}
+
+func (e *endpoint) recordFailure(err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.requests++
+	e.failures++
+	e.consecutiveFailures++
+	e.lastError = err.Error()
+	e.lastErrorAt = time.Now()
+	if e.consecutiveFailures >= failureThreshold {
+		e.cooldownUntil = time.Now().Add(rpcCooldown)
+	}
+}
+
+// score ranks endpoints; lower is better. Endpoints in cooldown or lagging
+// behind the best head are still used as a last resort.
+func (e *endpoint) score(now time.Time) float64 {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	s := float64(e.latency) / float64(time.Millisecond)
+	s += float64(e.consecutiveFailures) * 1_000
+	if e.lagging {
+		s += 100_000
+	}
+	if now.Before(e.cooldownUntil) {
+		s += 1_000_000
+	}
+	return s
+}
+
+func (e *endpoint) stats(chainID int64) EndpointStats {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	st := EndpointStats{
+		ChainID:             chainID,
+		URL:                 redactURL(e.url),
+		Healthy:             !time.Now().Before(e.cooldownUntil) && e.consecutiveFailures < failureThreshold,
+		Lagging:             e.lagging,
+		Requests:            e.requests,
+		Failures:            e.failures,
+		ConsecutiveFailures: e.consecutiveFailures,
+		LatencyMs:           float64(e.latency) / float64(time.Millisecond),
+		HeadBlock:           e.headBlock,
+		LastError:           e.lastError,
+	}
+	if !e.lastErrorAt.IsZero() {
+		t := e.lastErrorAt
+		st.LastErrorAt = &t
+	}
+	if time.Now().Before(e.cooldownUntil) {
+		t := e.cooldownUntil
+		st.CooldownUntil = &t
+	}
+	return st
+}
+
+// ============================================================================
+// Failover Client
+// ============================================================================
+
+// FailoverClient is an Ethereum client for one chain that routes each call to
+// the healthiest endpoint and fails over to the next on transport errors
+type FailoverClient struct {
+	chainID   int64
+	endpoints []*endpoint
+	wsURL     string
+	breaker   *breaker.Breaker
+	logger    *zap.Logger
+}
+
+func newFailoverClient(chainID int64, urls []string, logger *zap.Logger) *FailoverClient {
+	c := &FailoverClient{
+		chainID: chainID,
+		breaker: breaker.New(fmt.Sprintf("rpc chain %d", chainID), rpcBreakerThreshold, rpcBreakerCooldown),
+		logger:  logger,
+	}
+	for _, u := range urls {
+		c.endpoints = append(c.endpoints, &endpoint{url: u})
+	}
+	c.breaker.OnStateChange(func(name string, from, to breaker.State) {
+		logger.Warn("rpc circuit breaker state changed",
+			zap.Int64("chain_id", chainID),
+			zap.String("from", from.String()),
+			zap.String("to", to.String()),
+		)
+	})
+	return c
+}
+
+// WSURL returns the chain's WebSocket endpoint ("" if subscriptions are not configured)
+func (c *FailoverClient) WSURL() string {
+	return c.wsURL
+}
+
+// Stats returns health snapshots for this chain's endpoints
+func (c *FailoverClient) Stats() []EndpointStats {
+	circuit := c.breaker.State().String()
+	stats := make([]EndpointStats, 0, len(c.endpoints))
+	for _, e := range c.endpoints {
+		st := e.stats(c.chainID)
+		st.Circuit = circuit
+		stats = append(stats, st)
+	}
+	return stats
+}
+
+// Close closes all dialed endpoints
+func (c *FailoverClient) Close() {
+	for _, e := range c.endpoints {
+		e.mu.Lock()
+		if e.client != nil {
+			e.client.Close()
+			e.client = nil
+		}
+		e.mu.Unlock()
+	}
+}
+
+// ordered returns endpoints best first
+func (c *FailoverClient) ordered() []*endpoint {
+	now := time.Now()
+	eps := append([]*endpoint{}, c.endpoints...)
+	scores := make(map[*endpoint]float64, len(eps))
+	for _, e := range eps {
+		scores[e] = e.score(now)
+	}
+	sort.SliceStable(eps, func(i, j int) bool { return scores[eps[i]] < scores[eps[j]] })
+	return eps
+}
+
+// do runs fn through the chain's circuit breaker, failing fast with a
+// breaker.OpenError while every endpoint has recently been failing. The
+// call, failovers included, is recorded as timing.RPC.
+func (c *FailoverClient) do(ctx context.Context, op string, fn func(*ethclient.Client) error) error {
+	defer timing.Track(ctx, timing.RPC)()
+	if err := c.breaker.Allow(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	exhausted, err := c.failover(ctx, op, fn)
+	if ctx.Err() != nil {
+		c.breaker.Release()
+	} else {
+		c.breaker.Done(exhausted, err)
+	}
+	return err
+}
+
+// failover runs fn against endpoints in health order until one succeeds or
+// returns an error that another endpoint would not fix (reverts, not found,
+// etc.). exhausted reports that every endpoint failed.
+func (c *FailoverClient) failover(ctx context.Context, op string, fn func(*ethclient.Client) error) (exhausted bool, err error) {
+	var lastErr error
+
+	for _, e := range c.ordered() {
+		client, err := e.dial(ctx)
+		if err != nil {
+			e.recordFailure(err)
+			lastErr = err
+			continue
+		}
+
+		start := time.Now()
+		err = fn(client)
+		if err == nil || !isFailoverError(ctx, err) {
+			e.recordSuccess(time.Since(start))
+			return false, err
+		}
+
+		e.recordFailure(err)
+		lastErr = err
+		if ctx.Err() != nil {
+			return false, err
+		}
+
+		c.logger.Warn("rpc endpoint failed, trying next",
+			zap.Int64("chain_id", c.chainID),
+			zap.String("endpoint", redactURL(e.url)),
+			zap.String("op", op),
+			zap.Error(err),
+		)
+	}
+
+	return true, fmt.Errorf("%s: all rpc endpoints failed for chain %d: %w", op, c.chainID, lastErr)
+}
+
+// probe refreshes head block and lag state of every endpoint
+func (c *FailoverClient) probe(ctx context.Context) {
+	heads := make(map[*endpoint]uint64, len(c.endpoints))
+	var best uint64
+
+	for _, e := range c.endpoints {
+		probeCtx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+		client, err := e.dial(probeCtx)
+		if err == nil {
+			start := time.Now()
+			var head uint64
+			head, err = client.BlockNumber(probeCtx)
+			if err == nil {
+				e.recordSuccess(time.Since(start))
+				heads[e] = head
+				if head > best {
+					best = head
+				}
+			}
+		}
+		cancel()
+
+		if err != nil && ctx.Err() == nil {
+			e.recordFailure(err)
+		}
+	}
+
+	for _, e := range c.endpoints {
+		head, ok := heads[e]
+		e.mu.Lock()
+		if ok {
+			e.headBlock = head
+			e.lagging = best-head > maxBlockLag
+		}
+		e.mu.Unlock()
+	}
+}
+
+// ChainID retrieves the chain ID reported by the node
+func (c *FailoverClient) ChainID(ctx context.Context) (*big.Int, error) {
+	var out *big.Int
+	err := c.do(ctx, "eth_chainId", func(cl *ethclient.Client) (err error) {
+		out, err = cl.ChainID(ctx)
+		return err
+	})
+	return out, err
+}
+
+// BlockNumber returns the most recent block number
+func (c *FailoverClient) BlockNumber(ctx context.Context) (uint64, error) {
+	var out uint64
+	err := c.do(ctx, "eth_blockNumber", func(cl *ethclient.Client) (err error) {
+		out, err = cl.BlockNumber(ctx)
+		return err
+	})
+	return out, err
+}
+
+// HeaderByNumber returns a block header (latest if number is nil)
+func (c *FailoverClient) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	var out *types.Header
+	err := c.do(ctx, "eth_getBlockByNumber", func(cl *ethclient.Client) (err error) {
+		out, err = cl.HeaderByNumber(ctx, number)
+		return err
+	})
+	return out, err
+}
+
+// TransactionReceipt returns the receipt of a mined transaction
+func (c *FailoverClient) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	var out *types.Receipt
+	err := c.do(ctx, "eth_getTransactionReceipt", func(cl *ethclient.Client) (err error) {
+		out, err = cl.TransactionReceipt(ctx, txHash)
+		return err
+	})
+	return out, err
+}
+
+// BalanceAt returns the wei balance of an account
+func (c *FailoverClient) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	var out *big.Int
+	err := c.do(ctx, "eth_getBalance", func(cl *ethclient.Client) (err error) {
+		out, err = cl.BalanceAt(ctx, account, blockNumber)
+		return err
+	})
+	return out, err
+}
+
+// CodeAt returns the contract code of an account
+func (c *FailoverClient) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	var out []byte
+	err := c.do(ctx, "eth_getCode", func(cl *ethclient.Client) (err error) {
+		out, err = cl.CodeAt(ctx, account, blockNumber)
+		return err
+	})
+	return out, err
+}
+
+// CallContract executes a message call without creating a transaction
+func (c *FailoverClient) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	var out []byte
+	err := c.do(ctx, "eth_call", func(cl *ethclient.Client) (err error) {
+		out, err = cl.CallContract(ctx, call, blockNumber)
+		return err
+	})
+	return out, err
+}
+
+// PendingCodeAt returns the contract code of an account in the pending state
+func (c *FailoverClient) PendingCodeAt(ctx context.Context, account common.Address) ([]byte, error) {
+	var out []byte
+	err := c.do(ctx, "eth_getCode", func(cl *ethclient.Client) (err error) {
+		out, err = cl.PendingCodeAt(ctx, account)
+		return err
+	})
+	return out, err
+}
+
+// PendingNonceAt returns the account nonce in the pending state
+func (c *FailoverClient) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	var out uint64
+	err := c.do(ctx, "eth_getTransactionCount", func(cl *ethclient.Client) (err error) {
+		out, err = cl.PendingNonceAt(ctx, account)
+		return err
+	})
+	return out, err
+}
+
+// SuggestGasPrice retrieves the currently suggested gas price
+func (c *FailoverClient) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	var out *big.Int
+	err := c.do(ctx, "eth_gasPrice", func(cl *ethclient.Client) (err error) {
+		out, err = cl.SuggestGasPrice(ctx)
+		return err
+	})
+	return out, err
+}
+
+// SuggestGasTipCap retrieves the currently suggested priority fee
+func (c *FailoverClient) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	var out *big.Int
+	err := c.do(ctx, "eth_maxPriorityFeePerGas", func(cl *ethclient.Client) (err error) {
+		out, err = cl.SuggestGasTipCap(ctx)
+		return err
+	})
+	return out, err
+}
+
+// EstimateGas estimates the gas needed to execute a call
+func (c *FailoverClient) EstimateGas(ctx context.Context, call ethereum.CallMsg) (uint64, error) {
+	var out uint64
+	err := c.do(ctx, "eth_estimateGas", func(cl *ethclient.Client) (err error) {
+		out, err = cl.EstimateGas(ctx, call)
+		return err
+	})
+	return out, err
+}
+
+// CallContractWithOverrides executes a message call against the latest state
+// with account overrides applied (balances, code, storage)
+func (c *FailoverClient) CallContractWithOverrides(ctx context.Context, call ethereum.CallMsg, overrides map[common.Address]ethereum.OverrideAccount) ([]byte, error) {
+	var out []byte
+	err := c.do(ctx, "eth_call", func(cl *ethclient.Client) (err error) {
+		out, err = gethclient.New(cl.Client()).CallContract(ctx, call, nil, &overrides)
+		return err
+	})
+	return out, err
+}
+
+// EstimateGasWithOverrides estimates the gas needed to execute a call with
+// account overrides applied. Nodes without override support for
+// eth_estimateGas return a JSON-RPC error.
+func (c *FailoverClient) EstimateGasWithOverrides(ctx context.Context, call ethereum.CallMsg, overrides map[common.Address]ethereum.OverrideAccount) (uint64, error) {
+	arg := map[string]interface{}{
+		"from": call.From,
+		"to":   call.To,
+	}
+	if len(call.Data) > 0 {
+		arg["input"] = hexutil.Bytes(call.Data)
+	}
+	if call.Value != nil {
+		arg["value"] = (*hexutil.Big)(call.Value)
+	}
+	if call.Gas != 0 {
+		arg["gas"] = hexutil.Uint64(call.Gas)
+	}
+
+	var out hexutil.Uint64
+	err := c.do(ctx, "eth_estimateGas", func(cl *ethclient.Client) error {
+		return cl.Client().CallContext(ctx, &out, "eth_estimateGas", arg, "latest", overrides)
+	})
+	return uint64(out), err
+}
+
+// SendTransaction broadcasts a signed transaction. Re-sending to another
+// endpoint after a transport failure is safe; "already known" counts as sent.
+func (c *FailoverClient) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	return c.do(ctx, "eth_sendRawTransaction", func(cl *ethclient.Client) error {
+		err := cl.SendTransaction(ctx, tx)
+		if err != nil && strings.Contains(strings.ToLower(err.Error()), "already known") {
+			return nil
+		}
+		return err
+	})
+}
+
+// FilterLogs executes a log filter query
+func (c *FailoverClient) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	var out []types.Log
+	err := c.do(ctx, "eth_getLogs", func(cl *ethclient.Client) (err error) {
+		out, err = cl.FilterLogs(ctx, q)
+		return err
+	})
+	return out, err
+}
+
+// SubscribeFilterLogs subscribes to log events on the first endpoint that
+// supports subscriptions. The subscription does not fail over once established.
+func (c *FailoverClient) SubscribeFilterLogs(ctx context.Context, q ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	var out ethereum.Subscription
+	err := c.do(ctx, "eth_subscribe", func(cl *ethclient.Client) (err error) {
+		out, err = cl.SubscribeFilterLogs(ctx, q, ch)
+		return err
+	})
+	return out, err
+}
+
+// ============================================================================
+// Helpers
+// ============================================================================
+
+// isFailoverError reports whether another endpoint might succeed where this
+// one failed. JSON-RPC errors (reverts, invalid params) and not-found results
+// are answers from a working node and are returned as-is.
+func isFailoverError(ctx context.Context, err error) bool {
+	if ctx.Err() != nil || errors.Is(err, ethereum.NotFound) {
+		return false
+	}
+
+	var httpErr rpc.HTTPError
+	if errors.As(err, &httpErr) {
+		return true
+	}
+
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) {
+		// -32005: request/rate limit exceeded (provider specific)
+		return rpcErr.ErrorCode() == -32005
+	}
+
+	// Transport errors (connection refused, timeouts, EOF)
+	return true
+}
+
+// dedupeURLs removes blank and duplicate URLs preserving order
+func dedupeURLs(urls []string) []string {
+	seen := make(map[string]bool, len(urls))
+	var out []string
+	for _, u := range urls {
+		u = strings.TrimSpace(u)
+		if u == "" || seen[u] {
+			continue
+		}
+		seen[u] = true
+		out = append(out, u)
+	}
+	return out
+}
+
+// redactURL strips credentials and API key paths from an endpoint URL for
+// logs and stats (e.g. https://eth-mainnet.g.alchemy.com/v2/<key>)
+func redactURL(raw string) string {
+	scheme := ""
+	rest := raw
+	if i := strings.Index(raw, "://"); i >= 0 {
+		scheme, rest = raw[:i+3], raw[i+3:]
+	}
+	if i := strings.LastIndex(rest, "@"); i >= 0 {
+		rest = rest[i+1:]
+	}
+	if i := strings.IndexAny(rest, "/?"); i >= 0 && len(rest) > i+1 {
+		rest = rest[:i] + "/..."
+	}
+	return scheme + rest
+}
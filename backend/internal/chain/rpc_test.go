@@ -0,0 +1,125 @@
+package chain_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/chain"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// fakeNetworks serves network_config rows
+type fakeNetworks map[int64]*repository.NetworkConfig
+
+func (n fakeNetworks) GetNetworkByChainID(ctx context.Context, chainID int64) (*repository.NetworkConfig, error) {
+	nc, ok := n[chainID]
+	if !ok {
+		return nil, repository.ErrNetworkNotFound
+	}
+	return nc, nil
+}
+
+// newRPCServer answers every JSON-RPC request with result, or with a JSON-RPC
+// error when rpcErr is set
+func newRPCServer(t *testing.T, result string, rpcErr string, hits *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(hits, 1)
+		var req struct {
+			ID json.RawMessage `json:"id"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+
+		resp := map[string]interface{}{"jsonrpc": "2.0", "id": req.ID}
+		if rpcErr != "" {
+			resp["error"] = map[string]interface{}{"code": 3, "message": rpcErr}
+		} else {
+			resp["result"] = result
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}))
+}
+
+func newFailingServer(hits *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(hits, 1)
+		http.Error(w, "bad gateway", http.StatusBadGateway)
+	}))
+}
+
+func TestClientManager_FailsOverOnTransportError(t *testing.T) {
+	var badHits, goodHits int32
+	bad := newFailingServer(&badHits)
+	defer bad.Close()
+	good := newRPCServer(t, "0x7a69", "", &goodHits)
+	defer good.Close()
+
+	primary := bad.URL
+	manager := chain.NewClientManager(fakeNetworks{
+		31337: {ChainID: 31337, RPCUrl: &primary, RPCFallbackUrls: []string{good.URL}},
+	}, zap.NewNop())
+	defer manager.Close()
+
+	client, err := manager.Client(context.Background(), 31337)
+	require.NoError(t, err)
+
+	chainID, err := client.ChainID(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(31337), chainID.Int64())
+	assert.Equal(t, int32(1), badHits)
+
+	// After repeated failures the bad endpoint goes into cooldown and the
+	// healthy one is tried first
+	for i := 0; i < 3; i++ {
+		_, err := client.ChainID(context.Background())
+		require.NoError(t, err)
+	}
+	assert.LessOrEqual(t, badHits, int32(2))
+
+	stats := manager.EndpointStats()
+	require.Len(t, stats, 2)
+	for _, st := range stats {
+		if st.URL == good.URL {
+			assert.True(t, st.Healthy)
+			assert.Zero(t, st.Failures)
+		} else {
+			assert.NotZero(t, st.Failures)
+			assert.NotEmpty(t, st.LastError)
+		}
+	}
+}
+
+func TestClientManager_DoesNotFailOverOnRPCError(t *testing.T) {
+	var firstHits, secondHits int32
+	first := newRPCServer(t, "", "execution reverted", &firstHits)
+	defer first.Close()
+	second := newRPCServer(t, "0x7a69", "", &secondHits)
+	defer second.Close()
+
+	manager := chain.NewClientManager(nil, zap.NewNop())
+	manager.AddEndpoints(31337, first.URL, second.URL)
+	defer manager.Close()
+
+	client, err := manager.Client(context.Background(), 31337)
+	require.NoError(t, err)
+
+	_, err = client.ChainID(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "execution reverted")
+	assert.Equal(t, int32(0), secondHits)
+}
+
+func TestClientManager_NoEndpoints(t *testing.T) {
+	manager := chain.NewClientManager(fakeNetworks{}, zap.NewNop())
+
+	_, err := manager.Client(context.Background(), 1)
+	assert.ErrorIs(t, err, chain.ErrNoRPCEndpoints)
+}
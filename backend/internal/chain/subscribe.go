@@ -0,0 +1,391 @@
+package chain
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"go.uber.org/zap"
+)
+
+// Subscription settings
+const (
+	// Resubscribe backoff while the WebSocket endpoint is unavailable. HTTP
+	// polling covers the gap until the next attempt.
+	minResubscribeBackoff = 2 * time.Second
+	maxResubscribeBackoff = 2 * time.Minute
+
+	// stableSubscription resets the backoff once a subscription lived this long
+	stableSubscription = time.Minute
+
+	// maxLogRange caps the block range of a single eth_getLogs backfill query
+	maxLogRange = 2000
+)
+
+// HeadPoller is the HTTP fallback used while no subscription is active
+type HeadPoller interface {
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+}
+
+// LogPoller is the HTTP fallback (and gap backfill) for log subscriptions
+type LogPoller interface {
+	BlockNumber(ctx context.Context) (uint64, error)
+	FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error)
+}
+
+// wsDialer connects to a WebSocket endpoint (ethclient.DialContext by default)
+type wsDialer func(ctx context.Context, url string) (*ethclient.Client, error)
+
+// ============================================================================
+// New Heads
+// ============================================================================
+
+// HeadSubscriber streams new block headers using eth_subscribe("newHeads")
+// on the network's ws_url, resubscribing on disconnect and polling over HTTP
+// while the subscription is down (or when no ws_url is configured).
+type HeadSubscriber struct {
+	chainID      int64
+	wsURL        string
+	poller       HeadPoller
+	pollInterval time.Duration
+	dial         wsDialer
+	logger       *zap.Logger
+
+	lastHash common.Hash
+}
+
+// NewHeadSubscriber creates a new head subscriber. An empty wsURL means HTTP
+// polling only.
+func NewHeadSubscriber(chainID int64, wsURL string, poller HeadPoller, pollInterval time.Duration, logger *zap.Logger) *HeadSubscriber {
+	if pollInterval <= 0 {
+		pollInterval = defaultPollInterval
+	}
+	return &HeadSubscriber{
+		chainID:      chainID,
+		wsURL:        wsURL,
+		poller:       poller,
+		pollInterval: pollInterval,
+		dial:         ethclient.DialContext,
+		logger:       logger,
+	}
+}
+
+// Run streams headers to out until the context is cancelled. Consecutive
+// duplicates are dropped; a new hash at the same height (reorg) is delivered.
+func (s *HeadSubscriber) Run(ctx context.Context, out chan<- *types.Header) {
+	if s.wsURL == "" {
+		s.poll(ctx, out, time.Time{})
+		return
+	}
+
+	backoff := minResubscribeBackoff
+	for ctx.Err() == nil {
+		started := time.Now()
+		err := s.stream(ctx, out)
+		if ctx.Err() != nil {
+			return
+		}
+		if time.Since(started) > stableSubscription {
+			backoff = minResubscribeBackoff
+		}
+
+		s.logger.Warn("newHeads subscription lost, falling back to polling",
+			zap.Int64("chain_id", s.chainID),
+			zap.String("endpoint", redactURL(s.wsURL)),
+			zap.Duration("retry_in", backoff),
+			zap.Error(err),
+		)
+
+		s.poll(ctx, out, time.Now().Add(backoff))
+		backoff = nextBackoff(backoff)
+	}
+}
+
+// stream runs a single WebSocket subscription until it fails
+func (s *HeadSubscriber) stream(ctx context.Context, out chan<- *types.Header) error {
+	client, err := s.dial(ctx, s.wsURL)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	headers := make(chan *types.Header, 16)
+	sub, err := client.SubscribeNewHead(ctx, headers)
+	if err != nil {
+		return err
+	}
+	defer sub.Unsubscribe()
+
+	s.logger.Info("subscribed to newHeads",
+		zap.Int64("chain_id", s.chainID),
+		zap.String("endpoint", redactURL(s.wsURL)),
+	)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case err := <-sub.Err():
+			return err
+		case h := <-headers:
+			if !s.emit(ctx, out, h) {
+				return ctx.Err()
+			}
+		}
+	}
+}
+
+// poll fetches the latest header every pollInterval until the deadline (zero
+// deadline = until the context is cancelled)
+func (s *HeadSubscriber) poll(ctx context.Context, out chan<- *types.Header, until time.Time) {
+	ticker := time.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		h, err := s.poller.HeaderByNumber(ctx, nil)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			s.logger.Debug("head poll failed", zap.Int64("chain_id", s.chainID), zap.Error(err))
+		} else if !s.emit(ctx, out, h) {
+			return
+		}
+
+		if !until.IsZero() && !time.Now().Add(s.pollInterval).Before(until) {
+			// Sleep out the remainder of the backoff, then resubscribe
+			select {
+			case <-ctx.Done():
+			case <-time.After(time.Until(until)):
+			}
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// emit delivers a header unless it repeats the previous one; returns false if
+// the context was cancelled
+func (s *HeadSubscriber) emit(ctx context.Context, out chan<- *types.Header, h *types.Header) bool {
+	if h == nil || h.Hash() == s.lastHash {
+		return true
+	}
+	select {
+	case out <- h:
+		s.lastHash = h.Hash()
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// ============================================================================
+// Logs
+// ============================================================================
+
+// LogSubscriber streams logs matching a filter using eth_subscribe("logs"),
+// backfilling missed blocks with eth_getLogs after every reconnect and
+// polling over HTTP while the subscription is down.
+type LogSubscriber struct {
+	chainID      int64
+	wsURL        string
+	poller       LogPoller
+	query        ethereum.FilterQuery
+	pollInterval time.Duration
+	dial         wsDialer
+	logger       *zap.Logger
+
+	// next is the first block not yet delivered
+	next uint64
+}
+
+// NewLogSubscriber creates a new log subscriber starting at fromBlock. An
+// empty wsURL means HTTP polling only. FromBlock/ToBlock in the query are
+// managed by the subscriber.
+func NewLogSubscriber(chainID int64, wsURL string, poller LogPoller, query ethereum.FilterQuery, fromBlock uint64, pollInterval time.Duration, logger *zap.Logger) *LogSubscriber {
+	if pollInterval <= 0 {
+		pollInterval = defaultPollInterval
+	}
+	query.FromBlock, query.ToBlock = nil, nil
+	return &LogSubscriber{
+		chainID:      chainID,
+		wsURL:        wsURL,
+		poller:       poller,
+		query:        query,
+		pollInterval: pollInterval,
+		dial:         ethclient.DialContext,
+		logger:       logger,
+		next:         fromBlock,
+	}
+}
+
+// NextBlock returns the first block whose logs have not been delivered yet,
+// for checkpointing by indexers
+func (s *LogSubscriber) NextBlock() uint64 {
+	return s.next
+}
+
+// Run streams logs to out until the context is cancelled. Logs removed by a
+// reorg are delivered again with Removed set (subscription mode only).
+func (s *LogSubscriber) Run(ctx context.Context, out chan<- types.Log) {
+	if s.wsURL == "" {
+		s.poll(ctx, out, time.Time{})
+		return
+	}
+
+	backoff := minResubscribeBackoff
+	for ctx.Err() == nil {
+		started := time.Now()
+		err := s.stream(ctx, out)
+		if ctx.Err() != nil {
+			return
+		}
+		if time.Since(started) > stableSubscription {
+			backoff = minResubscribeBackoff
+		}
+
+		s.logger.Warn("logs subscription lost, falling back to polling",
+			zap.Int64("chain_id", s.chainID),
+			zap.String("endpoint", redactURL(s.wsURL)),
+			zap.Duration("retry_in", backoff),
+			zap.Error(err),
+		)
+
+		s.poll(ctx, out, time.Now().Add(backoff))
+		backoff = nextBackoff(backoff)
+	}
+}
+
+// stream subscribes, backfills the gap since the last delivered block and
+// forwards live logs until the subscription fails
+func (s *LogSubscriber) stream(ctx context.Context, out chan<- types.Log) error {
+	client, err := s.dial(ctx, s.wsURL)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	// Subscribe first so nothing is missed between backfill and live logs
+	logs := make(chan types.Log, 128)
+	sub, err := client.SubscribeFilterLogs(ctx, s.query, logs)
+	if err != nil {
+		return err
+	}
+	defer sub.Unsubscribe()
+
+	if err := s.backfill(ctx, out); err != nil {
+		return err
+	}
+
+	s.logger.Info("subscribed to logs",
+		zap.Int64("chain_id", s.chainID),
+		zap.String("endpoint", redactURL(s.wsURL)),
+		zap.Uint64("from_block", s.next),
+	)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case err := <-sub.Err():
+			return err
+		case l := <-logs:
+			// Skip logs already delivered by the backfill (unless reorged out)
+			if !l.Removed && l.BlockNumber < s.next {
+				continue
+			}
+			select {
+			case out <- l:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+			if !l.Removed && l.BlockNumber+1 > s.next {
+				s.next = l.BlockNumber + 1
+			}
+		}
+	}
+}
+
+// poll backfills every pollInterval until the deadline (zero deadline = until
+// the context is cancelled)
+func (s *LogSubscriber) poll(ctx context.Context, out chan<- types.Log, until time.Time) {
+	ticker := time.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.backfill(ctx, out); err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			s.logger.Debug("log poll failed", zap.Int64("chain_id", s.chainID), zap.Error(err))
+		}
+
+		if !until.IsZero() && !time.Now().Add(s.pollInterval).Before(until) {
+			select {
+			case <-ctx.Done():
+			case <-time.After(time.Until(until)):
+			}
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// backfill delivers logs from the next undelivered block up to the head in
+// bounded eth_getLogs ranges
+func (s *LogSubscriber) backfill(ctx context.Context, out chan<- types.Log) error {
+	head, err := s.poller.BlockNumber(ctx)
+	if err != nil {
+		return err
+	}
+
+	for s.next <= head {
+		to := s.next + maxLogRange - 1
+		if to > head {
+			to = head
+		}
+
+		q := s.query
+		q.FromBlock = new(big.Int).SetUint64(s.next)
+		q.ToBlock = new(big.Int).SetUint64(to)
+
+		logs, err := s.poller.FilterLogs(ctx, q)
+		if err != nil {
+			return err
+		}
+
+		for _, l := range logs {
+			select {
+			case out <- l:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		s.next = to + 1
+	}
+
+	return nil
+}
+
+// nextBackoff doubles the resubscribe backoff up to the maximum
+func nextBackoff(d time.Duration) time.Duration {
+	d *= 2
+	if d > maxResubscribeBackoff {
+		d = maxResubscribeBackoff
+	}
+	return d
+}
@@ -0,0 +1,149 @@
+package chain_test
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/chain"
+)
+
+// syncClient wraps fakeClient for concurrent use by a subscriber goroutine
+type syncClient struct {
+	mu sync.Mutex
+	*fakeClient
+}
+
+func (c *syncClient) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.fakeClient.HeaderByNumber(ctx, number)
+}
+
+func (c *syncClient) extend(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.fakeClient.extend(n, 0)
+}
+
+func receiveHeader(t *testing.T, ch <-chan *types.Header) *types.Header {
+	t.Helper()
+	select {
+	case h := <-ch:
+		return h
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for header")
+		return nil
+	}
+}
+
+func TestHeadSubscriber_PollingDeliversNewHeadsOnce(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client := &syncClient{fakeClient: newFakeClient(5, 0)}
+	sub := chain.NewHeadSubscriber(31337, "", client, 10*time.Millisecond, zap.NewNop())
+
+	heads := make(chan *types.Header)
+	go sub.Run(ctx, heads)
+
+	assert.Equal(t, uint64(4), receiveHeader(t, heads).Number.Uint64())
+
+	// No new block: nothing is re-delivered
+	select {
+	case h := <-heads:
+		t.Fatalf("unexpected duplicate head %d", h.Number.Uint64())
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	client.extend(1)
+	assert.Equal(t, uint64(5), receiveHeader(t, heads).Number.Uint64())
+}
+
+func TestHeadSubscriber_FallsBackToPollingWhenWSUnavailable(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client := &syncClient{fakeClient: newFakeClient(3, 0)}
+	sub := chain.NewHeadSubscriber(31337, "ws://127.0.0.1:1", client, 10*time.Millisecond, zap.NewNop())
+
+	heads := make(chan *types.Header)
+	go sub.Run(ctx, heads)
+
+	assert.Equal(t, uint64(2), receiveHeader(t, heads).Number.Uint64())
+}
+
+// fakeLogPoller serves logs per block
+type fakeLogPoller struct {
+	mu      sync.Mutex
+	head    uint64
+	logs    map[uint64][]types.Log
+	queries [][2]uint64
+}
+
+func (p *fakeLogPoller) BlockNumber(ctx context.Context) (uint64, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.head, nil
+}
+
+func (p *fakeLogPoller) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	from, to := q.FromBlock.Uint64(), q.ToBlock.Uint64()
+	p.queries = append(p.queries, [2]uint64{from, to})
+
+	var out []types.Log
+	for n := from; n <= to; n++ {
+		out = append(out, p.logs[n]...)
+	}
+	return out, nil
+}
+
+func TestLogSubscriber_PollingBackfillsInBoundedRanges(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	addr := common.HexToAddress("0x1")
+	poller := &fakeLogPoller{
+		head: 4500,
+		logs: map[uint64][]types.Log{
+			100:  {{Address: addr, BlockNumber: 100}},
+			2500: {{Address: addr, BlockNumber: 2500}},
+			4500: {{Address: addr, BlockNumber: 4500}},
+		},
+	}
+
+	sub := chain.NewLogSubscriber(31337, "", poller, ethereum.FilterQuery{Addresses: []common.Address{addr}}, 0, 10*time.Millisecond, zap.NewNop())
+
+	out := make(chan types.Log)
+	go sub.Run(ctx, out)
+
+	var blocks []uint64
+	for i := 0; i < 3; i++ {
+		select {
+		case l := <-out:
+			blocks = append(blocks, l.BlockNumber)
+		case <-time.After(2 * time.Second):
+			t.Fatal("timed out waiting for logs")
+		}
+	}
+	assert.Equal(t, []uint64{100, 2500, 4500}, blocks)
+
+	poller.mu.Lock()
+	defer poller.mu.Unlock()
+	require.GreaterOrEqual(t, len(poller.queries), 3)
+	assert.Equal(t, [2]uint64{0, 1999}, poller.queries[0])
+	assert.Equal(t, [2]uint64{2000, 3999}, poller.queries[1])
+	assert.Equal(t, [2]uint64{4000, 4500}, poller.queries[2])
+}
@@ -0,0 +1,460 @@
+// Package chain tracks on-chain state backing application records, including
+// confirmation depth and reorg detection.
+package chain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Default tracker settings (overridable via app_config namespace "chain")
+const (
+	defaultConfirmationDepth = 12
+	defaultPollInterval      = 15 * time.Second
+	defaultReorgWindow       = 128
+
+	// maxBlocksPerPoll caps how many new block hashes are recorded per poll so
+	// a tracker that falls behind catches up gradually
+	maxBlocksPerPoll = 64
+
+	// trackedTxBatchSize caps how many unfinalized txs are checked per poll
+	trackedTxBatchSize = 100
+)
+
+// Client is the subset of the Ethereum client used by the tracker
+type Client interface {
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error)
+}
+
+// RecordHandler applies confirmation outcomes to the application record
+// behind a tracked transaction. Implementations must be idempotent: a record
+// can be confirmed, reorged out and confirmed again.
+type RecordHandler interface {
+	// OnConfirmed is called once the tx reached confirmation depth and succeeded
+	OnConfirmed(ctx context.Context, tx *repository.TrackedTx, receipt *types.Receipt) error
+	// OnFailed is called once the tx reached confirmation depth but reverted
+	OnFailed(ctx context.Context, tx *repository.TrackedTx, receipt *types.Receipt) error
+	// OnReorged is called when the block containing the tx left the canonical chain
+	OnReorged(ctx context.Context, tx *repository.TrackedTx) error
+}
+
+// ConfirmationTracker follows the chain head, detects reorgs by comparing
+// recorded block hashes against the canonical chain, and finalizes tracked
+// transactions once they reach the configured confirmation depth.
+type ConfirmationTracker struct {
+	client     Client
+	repo       repository.ChainRepository
+	configRepo repository.AppConfigRepository
+	chainID    int64
+	logger     *zap.Logger
+
+	mu       sync.RWMutex
+	handlers map[string]RecordHandler
+
+	// Optional new-head stream; each head triggers a poll
+	headSub *HeadSubscriber
+}
+
+// NewConfirmationTracker creates a new confirmation tracker for a chain
+func NewConfirmationTracker(
+	client Client,
+	repo repository.ChainRepository,
+	configRepo repository.AppConfigRepository,
+	chainID int64,
+	logger *zap.Logger,
+) *ConfirmationTracker {
+	return &ConfirmationTracker{
+		client:     client,
+		repo:       repo,
+		configRepo: configRepo,
+		chainID:    chainID,
+		logger:     logger,
+		handlers:   make(map[string]RecordHandler),
+	}
+}
+
+// RegisterHandler registers the handler for a tracked record type
+func (t *ConfirmationTracker) RegisterHandler(recordType string, h RecordHandler) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.handlers[recordType] = h
+}
+
+// UseHeadSubscriber makes Run poll on every new head from the subscriber in
+// addition to the fixed interval
+func (t *ConfirmationTracker) UseHeadSubscriber(s *HeadSubscriber) {
+	t.headSub = s
+}
+
+// Track starts tracking a transaction for an application record
+func (t *ConfirmationTracker) Track(ctx context.Context, recordType, recordID, txHash string) error {
+	tx := &repository.TrackedTx{
+		ChainID:    t.chainID,
+		TxHash:     txHash,
+		RecordType: recordType,
+		RecordID:   recordID,
+	}
+	return t.repo.TrackTx(ctx, tx)
+}
+
+// Run polls the chain until the context is cancelled
+func (t *ConfirmationTracker) Run(ctx context.Context) {
+	interval := t.pollInterval(ctx)
+	t.logger.Info("confirmation tracker started",
+		zap.Int64("chain_id", t.chainID),
+		zap.Duration("interval", interval),
+	)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var heads chan *types.Header
+	if t.headSub != nil {
+		heads = make(chan *types.Header, 1)
+		go t.headSub.Run(ctx, heads)
+	}
+
+	for {
+		if err := t.Poll(ctx); err != nil && ctx.Err() == nil {
+			t.logger.Warn("confirmation tracker poll failed", zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			t.logger.Info("confirmation tracker stopped", zap.Int64("chain_id", t.chainID))
+			return
+		case <-ticker.C:
+		case <-heads:
+			ticker.Reset(interval)
+		}
+	}
+}
+
+// Poll runs a single tracker iteration: reorg check, block recording and
+// confirmation updates
+func (t *ConfirmationTracker) Poll(ctx context.Context) error {
+	head, err := t.client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("getting chain head: %w", err)
+	}
+	headNum := head.Number.Uint64()
+	window := t.reorgWindow(ctx)
+
+	if err := t.checkReorg(ctx, window); err != nil {
+		return err
+	}
+
+	if err := t.recordBlocks(ctx, headNum, window); err != nil {
+		return err
+	}
+
+	return t.updateTrackedTxs(ctx, headNum)
+}
+
+// checkReorg compares the latest recorded block with the canonical chain and,
+// on mismatch, rolls back everything above the common ancestor
+func (t *ConfirmationTracker) checkReorg(ctx context.Context, window uint64) error {
+	latest, err := t.repo.GetLatestBlock(ctx, t.chainID)
+	if err != nil {
+		if errors.Is(err, repository.ErrBlockNotFound) {
+			return nil
+		}
+		return fmt.Errorf("getting latest recorded block: %w", err)
+	}
+
+	ancestor, reorged, err := t.findCommonAncestor(ctx, latest, window)
+	if err != nil || !reorged {
+		return err
+	}
+
+	t.logger.Warn("chain reorg detected",
+		zap.Int64("chain_id", t.chainID),
+		zap.Uint64("recorded_head", latest.Number),
+		zap.Uint64("common_ancestor", ancestor),
+		zap.Uint64("depth", latest.Number-ancestor),
+	)
+
+	if _, err := t.repo.DeleteBlocksAbove(ctx, t.chainID, ancestor); err != nil {
+		return err
+	}
+
+	rolledBack, err := t.repo.RollbackTxsAbove(ctx, t.chainID, ancestor)
+	if err != nil {
+		return err
+	}
+
+	for _, tx := range rolledBack {
+		t.notifyReorged(ctx, tx)
+	}
+
+	return nil
+}
+
+// findCommonAncestor walks back from the latest recorded block until the
+// recorded hash matches the canonical chain. If no match is found within the
+// recorded window, the block below the oldest checked height is used.
+func (t *ConfirmationTracker) findCommonAncestor(ctx context.Context, latest *repository.BlockRecord, window uint64) (uint64, bool, error) {
+	number := latest.Number
+	stored := latest
+
+	for depth := uint64(0); depth <= window; depth++ {
+		canonical, err := t.client.HeaderByNumber(ctx, new(big.Int).SetUint64(number))
+		switch {
+		case errors.Is(err, ethereum.NotFound):
+			// Node's chain is now shorter than our record; keep walking back
+		case err != nil:
+			return 0, false, fmt.Errorf("getting header %d: %w", number, err)
+		case canonical.Hash().Hex() == stored.Hash:
+			return number, depth > 0, nil
+		}
+
+		if number == 0 {
+			return 0, true, nil
+		}
+		number--
+
+		stored, err = t.repo.GetBlock(ctx, t.chainID, number)
+		if err != nil {
+			if errors.Is(err, repository.ErrBlockNotFound) {
+				return number, true, nil
+			}
+			return 0, false, err
+		}
+	}
+
+	return number, true, nil
+}
+
+// recordBlocks records canonical block hashes from the last recorded block up
+// to the chain head and prunes hashes that fell outside the reorg window
+func (t *ConfirmationTracker) recordBlocks(ctx context.Context, headNum, window uint64) error {
+	from := headNum
+	var parentHash string
+
+	latest, err := t.repo.GetLatestBlock(ctx, t.chainID)
+	switch {
+	case err == nil:
+		from = latest.Number + 1
+		parentHash = latest.Hash
+	case !errors.Is(err, repository.ErrBlockNotFound):
+		return fmt.Errorf("getting latest recorded block: %w", err)
+	}
+
+	if from+maxBlocksPerPoll <= headNum {
+		from = headNum - maxBlocksPerPoll + 1
+		parentHash = ""
+	}
+
+	for n := from; n <= headNum; n++ {
+		header, err := t.client.HeaderByNumber(ctx, new(big.Int).SetUint64(n))
+		if err != nil {
+			return fmt.Errorf("getting header %d: %w", n, err)
+		}
+
+		// A parent mismatch means a reorg happened mid-poll; the next poll's
+		// reorg check will roll it back
+		if parentHash != "" && header.ParentHash.Hex() != parentHash {
+			t.logger.Debug("parent hash mismatch while recording blocks",
+				zap.Uint64("block", n),
+			)
+			break
+		}
+
+		block := &repository.BlockRecord{
+			ChainID:    t.chainID,
+			Number:     n,
+			Hash:       header.Hash().Hex(),
+			ParentHash: header.ParentHash.Hex(),
+		}
+		if err := t.repo.SaveBlock(ctx, block); err != nil {
+			return err
+		}
+		parentHash = block.Hash
+	}
+
+	if headNum > window {
+		if _, err := t.repo.PruneBlocksBelow(ctx, t.chainID, headNum-window); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// updateTrackedTxs refreshes inclusion and confirmation state of unfinalized
+// transactions and finalizes those that reached confirmation depth
+func (t *ConfirmationTracker) updateTrackedTxs(ctx context.Context, headNum uint64) error {
+	txs, err := t.repo.ListUnfinalizedTxs(ctx, t.chainID, trackedTxBatchSize)
+	if err != nil {
+		return err
+	}
+	if len(txs) == 0 {
+		return nil
+	}
+
+	depth := t.confirmationDepth(ctx)
+
+	for _, tx := range txs {
+		receipt, err := t.client.TransactionReceipt(ctx, common.HexToHash(tx.TxHash))
+		if err != nil {
+			if errors.Is(err, ethereum.NotFound) {
+				if tx.BlockNumber != nil {
+					// Previously included but no longer on chain (dropped by a reorg)
+					t.resetTx(ctx, tx)
+				}
+				continue
+			}
+			t.logger.Warn("failed to get receipt", zap.String("tx_hash", tx.TxHash), zap.Error(err))
+			continue
+		}
+
+		blockNum := receipt.BlockNumber.Uint64()
+		blockHash := receipt.BlockHash.Hex()
+		confirmations := 0
+		if headNum >= blockNum {
+			confirmations = int(headNum-blockNum) + 1
+		}
+
+		if tx.BlockHash != nil && *tx.BlockHash != blockHash {
+			t.logger.Info("tracked tx re-included in a different block",
+				zap.String("tx_hash", tx.TxHash),
+				zap.Uint64("block", blockNum),
+			)
+		}
+
+		update := &repository.TrackedTxUpdate{
+			Status:        repository.TrackedTxStatusIncluded,
+			BlockNumber:   &blockNum,
+			BlockHash:     &blockHash,
+			Confirmations: &confirmations,
+		}
+
+		if confirmations >= depth {
+			final, err := t.finalize(ctx, tx, receipt)
+			if err != nil {
+				t.logger.Error("failed to finalize tracked record",
+					zap.String("record_type", tx.RecordType),
+					zap.String("record_id", tx.RecordID),
+					zap.Error(err),
+				)
+			} else {
+				update.Status = final
+			}
+		}
+
+		if err := t.repo.UpdateTrackedTx(ctx, tx.ID, update); err != nil {
+			t.logger.Error("failed to update tracked tx", zap.String("id", tx.ID), zap.Error(err))
+		}
+	}
+
+	return nil
+}
+
+// finalize applies the receipt outcome to the record and returns the final status
+func (t *ConfirmationTracker) finalize(ctx context.Context, tx *repository.TrackedTx, receipt *types.Receipt) (repository.TrackedTxStatus, error) {
+	h := t.handler(tx.RecordType)
+
+	if receipt.Status == types.ReceiptStatusSuccessful {
+		if h != nil {
+			if err := h.OnConfirmed(ctx, tx, receipt); err != nil {
+				return "", err
+			}
+		}
+		return repository.TrackedTxStatusConfirmed, nil
+	}
+
+	if h != nil {
+		if err := h.OnFailed(ctx, tx, receipt); err != nil {
+			return "", err
+		}
+	}
+	return repository.TrackedTxStatusFailed, nil
+}
+
+// resetTx returns a dropped transaction to pending and notifies its handler
+func (t *ConfirmationTracker) resetTx(ctx context.Context, tx *repository.TrackedTx) {
+	err := t.repo.UpdateTrackedTx(ctx, tx.ID, &repository.TrackedTxUpdate{
+		Status:     repository.TrackedTxStatusPending,
+		ClearBlock: true,
+	})
+	if err != nil {
+		t.logger.Error("failed to reset tracked tx", zap.String("id", tx.ID), zap.Error(err))
+		return
+	}
+	t.notifyReorged(ctx, tx)
+}
+
+// notifyReorged tells the record handler that its tx left the canonical chain
+func (t *ConfirmationTracker) notifyReorged(ctx context.Context, tx *repository.TrackedTx) {
+	t.logger.Warn("tracked tx reorged out",
+		zap.String("tx_hash", tx.TxHash),
+		zap.String("record_type", tx.RecordType),
+		zap.String("record_id", tx.RecordID),
+	)
+
+	h := t.handler(tx.RecordType)
+	if h == nil {
+		return
+	}
+	if err := h.OnReorged(ctx, tx); err != nil {
+		t.logger.Error("failed to roll back reorged record",
+			zap.String("record_type", tx.RecordType),
+			zap.String("record_id", tx.RecordID),
+			zap.Error(err),
+		)
+	}
+}
+
+func (t *ConfirmationTracker) handler(recordType string) RecordHandler {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.handlers[recordType]
+}
+
+// confirmationDepth loads chain.confirmation_depth (minimum 1)
+func (t *ConfirmationTracker) confirmationDepth(ctx context.Context) int {
+	depth := int64(defaultConfirmationDepth)
+	if t.configRepo != nil {
+		if val, err := t.configRepo.GetNumber(ctx, "chain", "confirmation_depth", t.chainID); err == nil {
+			depth = val
+		}
+	}
+	if depth < 1 {
+		depth = 1
+	}
+	return int(depth)
+}
+
+// reorgWindow loads chain.reorg_window_blocks
+func (t *ConfirmationTracker) reorgWindow(ctx context.Context) uint64 {
+	window := int64(defaultReorgWindow)
+	if t.configRepo != nil {
+		if val, err := t.configRepo.GetNumber(ctx, "chain", "reorg_window_blocks", t.chainID); err == nil && val > 0 {
+			window = val
+		}
+	}
+	return uint64(window)
+}
+
+// pollInterval loads chain.poll_interval_seconds
+func (t *ConfirmationTracker) pollInterval(ctx context.Context) time.Duration {
+	interval := defaultPollInterval
+	if t.configRepo != nil {
+		if val, err := t.configRepo.GetNumber(ctx, "chain", "poll_interval_seconds", t.chainID); err == nil && val > 0 {
+			interval = time.Duration(val) * time.Second
+		}
+	}
+	return interval
+}
@@ -0,0 +1,315 @@
+package chain_test
+
+import (
+	"context"
+	"math/big"
+	"sort"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/chain"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// fakeClient serves a mutable canonical chain
+type fakeClient struct {
+	headers  []*types.Header
+	receipts map[common.Hash]*types.Receipt
+}
+
+func newFakeClient(length int, fork byte) *fakeClient {
+	c := &fakeClient{receipts: make(map[common.Hash]*types.Receipt)}
+	c.extend(length, fork)
+	return c
+}
+
+// extend appends blocks; fork varies block contents so hashes differ per branch
+func (c *fakeClient) extend(n int, fork byte) {
+	for i := 0; i < n; i++ {
+		h := &types.Header{
+			Number:     big.NewInt(int64(len(c.headers))),
+			Difficulty: big.NewInt(0),
+			Extra:      []byte{fork},
+		}
+		if len(c.headers) > 0 {
+			h.ParentHash = c.headers[len(c.headers)-1].Hash()
+		}
+		c.headers = append(c.headers, h)
+	}
+}
+
+// reorg drops blocks above keep and builds a new branch to the given length
+func (c *fakeClient) reorg(keep, length int, fork byte) {
+	c.headers = c.headers[:keep+1]
+	c.extend(length-len(c.headers), fork)
+}
+
+func (c *fakeClient) include(txHash string, number uint64, status uint64) {
+	c.receipts[common.HexToHash(txHash)] = &types.Receipt{
+		Status:      status,
+		BlockNumber: new(big.Int).SetUint64(number),
+		BlockHash:   c.headers[number].Hash(),
+		GasUsed:     21000,
+	}
+}
+
+func (c *fakeClient) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	if number == nil {
+		return c.headers[len(c.headers)-1], nil
+	}
+	if number.Uint64() >= uint64(len(c.headers)) {
+		return nil, ethereum.NotFound
+	}
+	return c.headers[number.Uint64()], nil
+}
+
+func (c *fakeClient) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	r, ok := c.receipts[txHash]
+	if !ok {
+		return nil, ethereum.NotFound
+	}
+	return r, nil
+}
+
+// fakeChainRepo is an in-memory ChainRepository
+type fakeChainRepo struct {
+	blocks map[uint64]*repository.BlockRecord
+	txs    map[string]*repository.TrackedTx
+}
+
+func newFakeChainRepo() *fakeChainRepo {
+	return &fakeChainRepo{
+		blocks: make(map[uint64]*repository.BlockRecord),
+		txs:    make(map[string]*repository.TrackedTx),
+	}
+}
+
+func (r *fakeChainRepo) SaveBlock(ctx context.Context, b *repository.BlockRecord) error {
+	r.blocks[b.Number] = b
+	return nil
+}
+
+func (r *fakeChainRepo) GetBlock(ctx context.Context, chainID int64, number uint64) (*repository.BlockRecord, error) {
+	b, ok := r.blocks[number]
+	if !ok {
+		return nil, repository.ErrBlockNotFound
+	}
+	return b, nil
+}
+
+func (r *fakeChainRepo) GetLatestBlock(ctx context.Context, chainID int64) (*repository.BlockRecord, error) {
+	var latest *repository.BlockRecord
+	for _, b := range r.blocks {
+		if latest == nil || b.Number > latest.Number {
+			latest = b
+		}
+	}
+	if latest == nil {
+		return nil, repository.ErrBlockNotFound
+	}
+	return latest, nil
+}
+
+func (r *fakeChainRepo) DeleteBlocksAbove(ctx context.Context, chainID int64, number uint64) (int64, error) {
+	var n int64
+	for k := range r.blocks {
+		if k > number {
+			delete(r.blocks, k)
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (r *fakeChainRepo) PruneBlocksBelow(ctx context.Context, chainID int64, number uint64) (int64, error) {
+	var n int64
+	for k := range r.blocks {
+		if k < number {
+			delete(r.blocks, k)
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (r *fakeChainRepo) TrackTx(ctx context.Context, tx *repository.TrackedTx) error {
+	tx.ID = tx.RecordID
+	tx.Status = repository.TrackedTxStatusPending
+	r.txs[tx.ID] = tx
+	return nil
+}
+
+func (r *fakeChainRepo) ListUnfinalizedTxs(ctx context.Context, chainID int64, limit int) ([]*repository.TrackedTx, error) {
+	var result []*repository.TrackedTx
+	for _, tx := range r.txs {
+		if tx.Status == repository.TrackedTxStatusPending || tx.Status == repository.TrackedTxStatusIncluded {
+			cp := *tx
+			result = append(result, &cp)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result, nil
+}
+
+func (r *fakeChainRepo) UpdateTrackedTx(ctx context.Context, id string, u *repository.TrackedTxUpdate) error {
+	tx, ok := r.txs[id]
+	if !ok {
+		return repository.ErrTrackedTxNotFound
+	}
+	tx.Status = u.Status
+	if u.ClearBlock {
+		tx.BlockNumber, tx.BlockHash, tx.Confirmations = nil, nil, 0
+		return nil
+	}
+	if u.BlockNumber != nil {
+		tx.BlockNumber = u.BlockNumber
+	}
+	if u.BlockHash != nil {
+		tx.BlockHash = u.BlockHash
+	}
+	if u.Confirmations != nil {
+		tx.Confirmations = *u.Confirmations
+	}
+	return nil
+}
+
+func (r *fakeChainRepo) RollbackTxsAbove(ctx context.Context, chainID int64, number uint64) ([]*repository.TrackedTx, error) {
+	var result []*repository.TrackedTx
+	for _, tx := range r.txs {
+		if tx.BlockNumber != nil && *tx.BlockNumber > number {
+			tx.Status = repository.TrackedTxStatusPending
+			tx.BlockNumber, tx.BlockHash, tx.Confirmations = nil, nil, 0
+			tx.ReorgCount++
+			cp := *tx
+			result = append(result, &cp)
+		}
+	}
+	return result, nil
+}
+
+// recordingHandler records outcomes per record ID
+type recordingHandler struct {
+	events []string
+}
+
+func (h *recordingHandler) OnConfirmed(ctx context.Context, tx *repository.TrackedTx, receipt *types.Receipt) error {
+	h.events = append(h.events, "confirmed:"+tx.RecordID)
+	return nil
+}
+
+func (h *recordingHandler) OnFailed(ctx context.Context, tx *repository.TrackedTx, receipt *types.Receipt) error {
+	h.events = append(h.events, "failed:"+tx.RecordID)
+	return nil
+}
+
+func (h *recordingHandler) OnReorged(ctx context.Context, tx *repository.TrackedTx) error {
+	h.events = append(h.events, "reorged:"+tx.RecordID)
+	return nil
+}
+
+const testTxHash = "0x1111111111111111111111111111111111111111111111111111111111111111"
+
+// Tests
+
+func newTestTracker(client *fakeClient, repo *fakeChainRepo) (*chain.ConfirmationTracker, *recordingHandler) {
+	tracker := chain.NewConfirmationTracker(client, repo, nil, 31337, zap.NewNop())
+	h := &recordingHandler{}
+	tracker.RegisterHandler(repository.TrackedRecordPayment, h)
+	return tracker, h
+}
+
+func TestConfirmationTracker_ConfirmsAfterDepth(t *testing.T) {
+	ctx := context.Background()
+	client := newFakeClient(20, 0)
+	repo := newFakeChainRepo()
+	tracker, h := newTestTracker(client, repo)
+
+	require.NoError(t, tracker.Track(ctx, repository.TrackedRecordPayment, "pay-1", testTxHash))
+	client.include(testTxHash, 15, types.ReceiptStatusSuccessful)
+
+	// Head 19, included at 15: 5 confirmations < default depth 12
+	require.NoError(t, tracker.Poll(ctx))
+	assert.Equal(t, repository.TrackedTxStatusIncluded, repo.txs["pay-1"].Status)
+	assert.Equal(t, 5, repo.txs["pay-1"].Confirmations)
+	assert.Empty(t, h.events)
+
+	client.extend(7, 0)
+	require.NoError(t, tracker.Poll(ctx))
+	assert.Equal(t, repository.TrackedTxStatusConfirmed, repo.txs["pay-1"].Status)
+	assert.Equal(t, []string{"confirmed:pay-1"}, h.events)
+}
+
+func TestConfirmationTracker_RevertedTxFails(t *testing.T) {
+	ctx := context.Background()
+	client := newFakeClient(30, 0)
+	repo := newFakeChainRepo()
+	tracker, h := newTestTracker(client, repo)
+
+	require.NoError(t, tracker.Track(ctx, repository.TrackedRecordPayment, "pay-1", testTxHash))
+	client.include(testTxHash, 10, types.ReceiptStatusFailed)
+
+	require.NoError(t, tracker.Poll(ctx))
+	assert.Equal(t, repository.TrackedTxStatusFailed, repo.txs["pay-1"].Status)
+	assert.Equal(t, []string{"failed:pay-1"}, h.events)
+}
+
+func TestConfirmationTracker_ReorgRollsBackConfirmedTx(t *testing.T) {
+	ctx := context.Background()
+	client := newFakeClient(30, 0)
+	repo := newFakeChainRepo()
+	tracker, h := newTestTracker(client, repo)
+
+	require.NoError(t, tracker.Poll(ctx))
+
+	require.NoError(t, tracker.Track(ctx, repository.TrackedRecordPayment, "pay-1", testTxHash))
+	client.include(testTxHash, 29, types.ReceiptStatusSuccessful)
+	client.extend(12, 0)
+	require.NoError(t, tracker.Poll(ctx))
+	require.Equal(t, repository.TrackedTxStatusConfirmed, repo.txs["pay-1"].Status)
+
+	// Replace everything above block 25 with a longer fork that drops the tx
+	client.reorg(25, 45, 1)
+	delete(client.receipts, common.HexToHash(testTxHash))
+
+	require.NoError(t, tracker.Poll(ctx))
+	assert.Equal(t, repository.TrackedTxStatusPending, repo.txs["pay-1"].Status)
+	assert.Nil(t, repo.txs["pay-1"].BlockNumber)
+	assert.Equal(t, 1, repo.txs["pay-1"].ReorgCount)
+	assert.Equal(t, []string{"confirmed:pay-1", "reorged:pay-1"}, h.events)
+
+	// Recorded blocks now follow the new fork
+	latest, err := repo.GetLatestBlock(ctx, 31337)
+	require.NoError(t, err)
+	assert.Equal(t, client.headers[44].Hash().Hex(), latest.Hash)
+
+	// Re-included on the new fork and confirmed again after depth
+	client.include(testTxHash, 40, types.ReceiptStatusSuccessful)
+	client.extend(10, 1)
+	require.NoError(t, tracker.Poll(ctx))
+	assert.Equal(t, repository.TrackedTxStatusConfirmed, repo.txs["pay-1"].Status)
+	assert.Equal(t, []string{"confirmed:pay-1", "reorged:pay-1", "confirmed:pay-1"}, h.events)
+}
+
+func TestConfirmationTracker_DroppedTxResetsToPending(t *testing.T) {
+	ctx := context.Background()
+	client := newFakeClient(20, 0)
+	repo := newFakeChainRepo()
+	tracker, h := newTestTracker(client, repo)
+
+	require.NoError(t, tracker.Track(ctx, repository.TrackedRecordPayment, "pay-1", testTxHash))
+	client.include(testTxHash, 18, types.ReceiptStatusSuccessful)
+	require.NoError(t, tracker.Poll(ctx))
+	require.Equal(t, repository.TrackedTxStatusIncluded, repo.txs["pay-1"].Status)
+
+	// Receipt disappears without a detected block hash change
+	delete(client.receipts, common.HexToHash(testTxHash))
+	require.NoError(t, tracker.Poll(ctx))
+	assert.Equal(t, repository.TrackedTxStatusPending, repo.txs["pay-1"].Status)
+	assert.Equal(t, []string{"reorged:pay-1"}, h.events)
+}
@@ -0,0 +1,240 @@
+// Package contractnotify announces changes of a contract's primary address.
+// Wrapping the contract repository, it compares every Upsert with the
+// address it replaces; when the address changes, in-process listeners (the
+// relayer's forwarder binding, the vote indexer's governor subscription) are
+// told so they switch without a restart, and a contract.address_changed
+// event is queued as a webhook event for the dependent services listening
+// on app_config contracts.change_webhook_url.
+package contractnotify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/callbacks"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// EventType is the type of every change event
+const EventType = "contract.address_changed"
+
+// Change is a contract's primary address moving, and the body POSTed to
+// the change webhook
+type Change struct {
+	ID               string    `json:"id"` // stable across retries, so redeliveries can be deduplicated
+	Type             string    `json:"type"`
+	ChainID          int64     `json:"chain_id"`
+	ContractID       string    `json:"contract_id"`
+	DBName           string    `json:"db_name"`
+	SolidityName     string    `json:"solidity_name"`
+	OldAddress       string    `json:"old_address,omitempty"` // empty for a first deployment
+	NewAddress       string    `json:"new_address"`
+	ABIVersion       string    `json:"abi_version"`
+	DeploymentTxHash *string   `json:"deployment_tx_hash,omitempty"`
+	DeploymentBlock  *int64    `json:"deployment_block,omitempty"`
+	OccurredAt       time.Time `json:"occurred_at"`
+}
+
+// Listener is told about primary address changes as they are stored
+// (implemented by handlers.RelayerHandler and govindex.Indexer). It is
+// called synchronously from the Upsert, so it must not block.
+type Listener interface {
+	ContractAddressChanged(chainID int64, dbName, address string)
+}
+
+// Ensure NotifyingContractRepo implements ContractRepository
+var _ repository.ContractRepository = (*NotifyingContractRepo)(nil)
+
+// NotifyingContractRepo wraps a ContractRepository and announces every
+// change of a primary contract address, whichever path upserted it (the
+// contracts API, deployment registration or a deploy script)
+type NotifyingContractRepo struct {
+	next   repository.ContractRepository
+	queue  callbacks.Queue
+	logger *zap.Logger
+
+	mu        sync.RWMutex
+	listeners []Listener
+}
+
+// NewNotifyingContractRepo wraps next, queueing change events on q
+func NewNotifyingContractRepo(next repository.ContractRepository, q callbacks.Queue, logger *zap.Logger) *NotifyingContractRepo {
+	return &NotifyingContractRepo{next: next, queue: q, logger: logger}
+}
+
+// Subscribe adds a listener told about every later address change
+func (r *NotifyingContractRepo) Subscribe(l Listener) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.listeners = append(r.listeners, l)
+}
+
+// Upsert implements repository.ContractRepository. An upsert keeping the
+// primary address (e.g. updated notes) is not announced.
+func (r *NotifyingContractRepo) Upsert(ctx context.Context, contract *repository.ContractAddressUpsert) (*repository.ContractAddress, error) {
+	before, err := r.primary(ctx, contract.ChainID, contract.ContractMappingID)
+	if err != nil {
+		// Let the upsert report the error (or apply it without announcing)
+		return r.next.Upsert(ctx, contract)
+	}
+	after, err := r.next.Upsert(ctx, contract)
+	if err != nil {
+		return nil, err
+	}
+
+	var old string
+	if before != nil {
+		if strings.EqualFold(before.Address, after.Address) {
+			return after, nil
+		}
+		old = before.Address
+	}
+	r.announce(ctx, newChange(after, old))
+	return after, nil
+}
+
+// primary returns the chain's primary address of a mapping, nil if none is
+// registered
+func (r *NotifyingContractRepo) primary(ctx context.Context, chainID int64, mappingID string) (*repository.ContractAddress, error) {
+	contracts, err := r.next.GetByChainID(ctx, chainID)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range contracts {
+		if c.ContractMappingID == mappingID && c.IsPrimary {
+			return c, nil
+		}
+	}
+	return nil, nil
+}
+
+// newChange describes c's primary address replacing old. The ID is unique
+// per change, so a contract switching back to an earlier address announces
+// again.
+func newChange(c *repository.ContractAddress, old string) *Change {
+	return &Change{
+		ID:               fmt.Sprintf("%s:%s:%d", c.ID, strings.ToLower(c.Address), c.UpdatedAt.UnixNano()),
+		Type:             EventType,
+		ChainID:          c.ChainID,
+		ContractID:       c.ID,
+		DBName:           c.DBName,
+		SolidityName:     c.SolidityName,
+		OldAddress:       old,
+		NewAddress:       c.Address,
+		ABIVersion:       c.ABIVersion,
+		DeploymentTxHash: c.DeploymentTxHash,
+		DeploymentBlock:  c.DeploymentBlock,
+		OccurredAt:       c.UpdatedAt,
+	}
+}
+
+// announce tells the listeners and queues the change event. The address is
+// already stored, so a queueing failure is logged rather than returned.
+func (r *NotifyingContractRepo) announce(ctx context.Context, c *Change) {
+	r.logger.Info("contract address changed",
+		zap.Int64("chain_id", c.ChainID),
+		zap.String("contract", c.DBName),
+		zap.String("old_address", c.OldAddress),
+		zap.String("new_address", c.NewAddress),
+	)
+
+	r.mu.RLock()
+	listeners := r.listeners
+	r.mu.RUnlock()
+	for _, l := range listeners {
+		l.ContractAddressChanged(c.ChainID, c.DBName, c.NewAddress)
+	}
+
+	payload, err := json.Marshal(c)
+	if err == nil {
+		_, err = r.queue.Enqueue(ctx, &repository.WebhookEvent{
+			Provider:  repository.WebhookProviderContractChange,
+			EventID:   c.ID,
+			EventType: EventType,
+			Payload:   payload,
+		})
+	}
+	if err != nil {
+		r.logger.Error("failed to queue contract change event",
+			zap.String("contract_id", c.ContractID),
+			zap.String("new_address", c.NewAddress),
+			zap.Error(err),
+		)
+	}
+}
+
+// GetNetworkByChainID implements repository.ContractRepository
+func (r *NotifyingContractRepo) GetNetworkByChainID(ctx context.Context, chainID int64) (*repository.NetworkConfig, error) {
+	return r.next.GetNetworkByChainID(ctx, chainID)
+}
+
+// GetNetworkByName implements repository.ContractRepository
+func (r *NotifyingContractRepo) GetNetworkByName(ctx context.Context, name string) (*repository.NetworkConfig, error) {
+	return r.next.GetNetworkByName(ctx, name)
+}
+
+// GetActiveNetworks implements repository.ContractRepository
+func (r *NotifyingContractRepo) GetActiveNetworks(ctx context.Context) ([]*repository.NetworkConfig, error) {
+	return r.next.GetActiveNetworks(ctx)
+}
+
+// GetAllMappings implements repository.ContractRepository
+func (r *NotifyingContractRepo) GetAllMappings(ctx context.Context) ([]*repository.ContractMapping, error) {
+	return r.next.GetAllMappings(ctx)
+}
+
+// GetMappingBySolidityName implements repository.ContractRepository
+func (r *NotifyingContractRepo) GetMappingBySolidityName(ctx context.Context, name string) (*repository.ContractMapping, error) {
+	return r.next.GetMappingBySolidityName(ctx, name)
+}
+
+// GetMappingByDBName implements repository.ContractRepository
+func (r *NotifyingContractRepo) GetMappingByDBName(ctx context.Context, dbName string) (*repository.ContractMapping, error) {
+	return r.next.GetMappingByDBName(ctx, dbName)
+}
+
+// GetByChainID implements repository.ContractRepository
+func (r *NotifyingContractRepo) GetByChainID(ctx context.Context, chainID int64) ([]*repository.ContractAddress, error) {
+	return r.next.GetByChainID(ctx, chainID)
+}
+
+// GetByChainAndDBName implements repository.ContractRepository
+func (r *NotifyingContractRepo) GetByChainAndDBName(ctx context.Context, chainID int64, dbName string) (*repository.ContractAddress, error) {
+	return r.next.GetByChainAndDBName(ctx, chainID, dbName)
+}
+
+// GetByID implements repository.ContractRepository
+func (r *NotifyingContractRepo) GetByID(ctx context.Context, id string) (*repository.ContractAddress, error) {
+	return r.next.GetByID(ctx, id)
+}
+
+// GetHistory implements repository.ContractRepository
+func (r *NotifyingContractRepo) GetHistory(ctx context.Context, contractID string, limit int) ([]*repository.ContractAddressHistory, error) {
+	return r.next.GetHistory(ctx, contractID, limit)
+}
+
+// GetDeploymentConfig implements repository.ContractRepository
+func (r *NotifyingContractRepo) GetDeploymentConfig(ctx context.Context, chainID int64) (*repository.DeploymentConfig, error) {
+	return r.next.GetDeploymentConfig(ctx, chainID)
+}
+
+// UpsertABI implements repository.ContractRepository
+func (r *NotifyingContractRepo) UpsertABI(ctx context.Context, artifact *repository.ABIArtifactUpsert) (*repository.ABIArtifact, error) {
+	return r.next.UpsertABI(ctx, artifact)
+}
+
+// GetABI implements repository.ContractRepository
+func (r *NotifyingContractRepo) GetABI(ctx context.Context, dbName, version string) (*repository.ABIArtifact, error) {
+	return r.next.GetABI(ctx, dbName, version)
+}
+
+// ListABIVersions implements repository.ContractRepository
+func (r *NotifyingContractRepo) ListABIVersions(ctx context.Context, dbName string) ([]*repository.ABIArtifact, error) {
+	return r.next.ListABIVersions(ctx, dbName)
+}
@@ -0,0 +1,143 @@
+package contractnotify_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/callbacks"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/contractnotify"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/storage/memory"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/webhooks"
+)
+
+const (
+	chainID = 31337
+	secret  = "contract-change-secret"
+
+	firstAddress  = "0x5FbDB2315678afecb367f032d93F642f64180aa3"
+	secondAddress = "0xe7f1725E7734CE288F8367e1Bb143E90bb3F0512"
+)
+
+type staticSecrets map[string]string
+
+func (s staticSecrets) Get(name string) string { return s[name] }
+
+// receiver records the change events POSTed to it
+type receiver struct {
+	mu      sync.Mutex
+	changes []contractnotify.Change
+	bodies  [][]byte
+	sigs    []string
+}
+
+func (r *receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+	var c contractnotify.Change
+	_ = json.Unmarshal(body, &c)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.changes = append(r.changes, c)
+	r.bodies = append(r.bodies, body)
+	r.sigs = append(r.sigs, req.Header.Get(callbacks.SignatureHeader))
+}
+
+// listener records the address changes it is told about
+type listener struct {
+	changes []string
+}
+
+func (l *listener) ContractAddressChanged(chainID int64, dbName, address string) {
+	l.changes = append(l.changes, strconv.FormatInt(chainID, 10)+"/"+dbName+"="+address)
+}
+
+func TestNotifyingContractRepo_AnnouncesAddressChanges(t *testing.T) {
+	ctx := context.Background()
+	rcv := &receiver{}
+	srv := httptest.NewServer(rcv)
+	t.Cleanup(srv.Close)
+
+	config := memory.NewMemoryAppConfigRepo()
+	url := srv.URL + "/contracts"
+	require.NoError(t, config.Create(ctx, &repository.AppConfigCreate{
+		Namespace:   "contracts",
+		ConfigKey:   "change_webhook_url",
+		ValueType:   "string",
+		ValueString: &url,
+	}))
+	processor := webhooks.NewProcessor(memory.NewMemoryWebhookEventRepo(), nil, zap.NewNop())
+	processor.RegisterHandler(repository.WebhookProviderContractChange, contractnotify.NewSender(config, staticSecrets{"CONTRACT_WEBHOOK_SECRET": secret}, zap.NewNop()))
+
+	contracts := memory.NewMemoryContractRepo()
+	contracts.AddNetwork(&repository.NetworkConfig{ChainID: chainID, NetworkName: "localhost", IsActive: true})
+	mappingID := contracts.AddMapping(&repository.ContractMapping{SolidityName: "NexusForwarder", DBName: "nexusForwarder"})
+	repo := contractnotify.NewNotifyingContractRepo(contracts, processor, zap.NewNop())
+	l := &listener{}
+	repo.Subscribe(l)
+
+	upsert := func(address, notes string) {
+		t.Helper()
+		_, err := repo.Upsert(ctx, &repository.ContractAddressUpsert{ChainID: chainID, ContractMappingID: mappingID, Address: address, Notes: &notes})
+		require.NoError(t, err)
+	}
+	upsert(firstAddress, "initial")
+	upsert(strings.ToLower(firstAddress), "same address, new notes")
+	upsert(secondAddress, "redeployed")
+
+	// Changing the notes alone announces nothing
+	assert.Equal(t, []string{
+		"31337/nexusForwarder=" + firstAddress,
+		"31337/nexusForwarder=" + secondAddress,
+	}, l.changes)
+
+	assert.Equal(t, 2, processor.RunOnce(ctx))
+	require.Len(t, rcv.changes, 2)
+	first, second := rcv.changes[0], rcv.changes[1]
+	if first.NewAddress != firstAddress {
+		first, second = second, first
+	}
+	assert.Equal(t, contractnotify.EventType, second.Type)
+	assert.Equal(t, "nexusForwarder", second.DBName)
+	assert.Equal(t, "NexusForwarder", second.SolidityName)
+	assert.Empty(t, first.OldAddress)
+	assert.Equal(t, strings.ToLower(firstAddress), second.OldAddress)
+	assert.Equal(t, secondAddress, second.NewAddress)
+	assert.NotEqual(t, first.ID, second.ID)
+
+	// Each body is signed with the change webhook secret
+	for i, body := range rcv.bodies {
+		ts, err := strconv.ParseInt(strings.TrimPrefix(strings.SplitN(rcv.sigs[i], ",", 2)[0], "t="), 10, 64)
+		require.NoError(t, err)
+		assert.Equal(t, callbacks.Sign(secret, time.Unix(ts, 0), body), rcv.sigs[i])
+	}
+}
+
+func TestSender_NoWebhookConfigured(t *testing.T) {
+	ctx := context.Background()
+	processor := webhooks.NewProcessor(memory.NewMemoryWebhookEventRepo(), nil, zap.NewNop())
+	processor.RegisterHandler(repository.WebhookProviderContractChange, contractnotify.NewSender(memory.NewMemoryAppConfigRepo(), staticSecrets{}, zap.NewNop()))
+
+	contracts := memory.NewMemoryContractRepo()
+	contracts.AddNetwork(&repository.NetworkConfig{ChainID: chainID, NetworkName: "localhost", IsActive: true})
+	mappingID := contracts.AddMapping(&repository.ContractMapping{SolidityName: "NexusGovernor", DBName: "nexusGovernor"})
+	repo := contractnotify.NewNotifyingContractRepo(contracts, processor, zap.NewNop())
+
+	_, err := repo.Upsert(ctx, &repository.ContractAddressUpsert{ChainID: chainID, ContractMappingID: mappingID, Address: firstAddress})
+	require.NoError(t, err)
+	// The event is processed (and dropped) rather than retried
+	assert.Equal(t, 1, processor.RunOnce(ctx))
+	assert.Equal(t, 0, processor.RunOnce(ctx))
+}
@@ -0,0 +1,103 @@
+package contractnotify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/callbacks"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// deliveryTimeout bounds one POST to the change webhook
+const deliveryTimeout = 10 * time.Second
+
+// SecretSource returns the current value of a named secret (implemented by
+// secrets.Store)
+type SecretSource interface {
+	Get(name string) string
+}
+
+// Sender delivers queued change events (registered with the webhook
+// processor for WebhookProviderContractChange). Events go to app_config
+// contracts.change_webhook_url, signed like payment callbacks with the
+// CONTRACT_WEBHOOK_SECRET secret.
+type Sender struct {
+	configRepo repository.AppConfigRepository
+	secrets    SecretSource
+	client     *http.Client
+	logger     *zap.Logger
+	now        func() time.Time
+}
+
+// NewSender creates a change event sender. The URL is set by operators, so
+// unlike dapp callbacks it may point at an internal service.
+func NewSender(configRepo repository.AppConfigRepository, secrets SecretSource, logger *zap.Logger) *Sender {
+	return &Sender{
+		configRepo: configRepo,
+		secrets:    secrets,
+		client: &http.Client{
+			Timeout: deliveryTimeout,
+			// A redirect is treated as a failed delivery
+			CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+		},
+		logger: logger,
+		now:    time.Now,
+	}
+}
+
+// ProcessWebhookEvent POSTs a queued change event to the change webhook
+// (called by the background webhook processor). Without a configured URL
+// the event is dropped: in-process listeners have already switched. A
+// non-2xx response is retried with backoff until the event is dead-lettered.
+func (s *Sender) ProcessWebhookEvent(ctx context.Context, e *repository.WebhookEvent) error {
+	var c Change
+	if err := json.Unmarshal(e.Payload, &c); err != nil {
+		return fmt.Errorf("parsing stored contract change: %w", err)
+	}
+
+	url, _ := s.configRepo.GetString(ctx, "contracts", "change_webhook_url", c.ChainID)
+	if url == "" {
+		s.logger.Debug("contract change not delivered: contracts.change_webhook_url is not set",
+			zap.String("event_id", c.ID),
+			zap.String("contract", c.DBName),
+		)
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deliveryTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(e.Payload))
+	if err != nil {
+		return fmt.Errorf("building contract change request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "nexus-protocol-contracts/1")
+	req.Header.Set(callbacks.EventIDHeader, c.ID)
+	if secret := s.secrets.Get("CONTRACT_WEBHOOK_SECRET"); secret != "" {
+		req.Header.Set(callbacks.SignatureHeader, callbacks.Sign(secret, s.now(), e.Payload))
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("posting contract change: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("contract change webhook returned %d", resp.StatusCode)
+	}
+	s.logger.Info("contract change delivered",
+		zap.String("event_id", c.ID),
+		zap.String("contract", c.DBName),
+		zap.String("new_address", c.NewAddress),
+	)
+	return nil
+}
@@ -0,0 +1,7 @@
+// Package contracts contains Go bindings for the Nexus Protocol smart contracts.
+//
+// The bindings are generated with abigen from the Foundry build artifacts and
+// must not be edited by hand. Regenerate them with `make bindings` after
+// changing a contract's external interface; the ABI used for each binding is
+// kept alongside it under abi/.
+package contracts
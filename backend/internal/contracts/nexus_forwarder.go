@@ -0,0 +1,2660 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package contracts
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// NexusForwarderExecutionResult is an auto generated low-level Go binding around an user-defined struct.
+type NexusForwarderExecutionResult struct {
+	Success    bool
+	ReturnData []byte
+}
+
+// NexusForwarderForwardRequest is an auto generated low-level Go binding around an user-defined struct.
+type NexusForwarderForwardRequest struct {
+	From     common.Address
+	To       common.Address
+	Value    *big.Int
+	Gas      *big.Int
+	Nonce    *big.Int
+	Deadline *big.Int
+	Data     []byte
+}
+
+// NexusForwarderMetaData contains all meta data concerning the NexusForwarder contract.
+var NexusForwarderMetaData = &bind.MetaData{
+	ABI: "[{\"type\":\"constructor\",\"inputs\":[{\"internalType\":\"address\",\"name\":\"admin\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"relayer\",\"type\":\"address\"}],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"ADMIN_ROLE\",\"inputs\":[],\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"DEFAULT_ADMIN_ROLE\",\"inputs\":[],\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"RELAYER_ROLE\",\"inputs\":[],\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"allowedTargets\",\"inputs\":[{\"internalType\":\"address\",\"name\":\"target\",\"type\":\"address\"}],\"outputs\":[{\"internalType\":\"bool\",\"name\":\"allowed\",\"type\":\"bool\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"domainSeparator\",\"inputs\":[],\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"eip712Domain\",\"inputs\":[],\"outputs\":[{\"internalType\":\"bytes1\",\"name\":\"fields\",\"type\":\"bytes1\"},{\"internalType\":\"string\",\"name\":\"name\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"version\",\"type\":\"string\"},{\"internalType\":\"uint256\",\"name\":\"chainId\",\"type\":\"uint256\"},{\"internalType\":\"address\",\"name\":\"verifyingContract\",\"type\":\"address\"},{\"internalType\":\"bytes32\",\"name\":\"salt\",\"type\":\"bytes32\"},{\"internalType\":\"uint256[]\",\"name\":\"extensions\",\"type\":\"uint256[]\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"enforceTargetWhitelist\",\"inputs\":[],\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"execute\",\"inputs\":[{\"components\":[{\"internalType\":\"address\",\"name\":\"from\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"to\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"value\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"gas\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"nonce\",\"type\":\"uint256\"},{\"internalType\":\"uint48\",\"name\":\"deadline\",\"type\":\"uint48\"},{\"internalType\":\"bytes\",\"name\":\"data\",\"type\":\"bytes\"}],\"internalType\":\"structNexusForwarder.ForwardRequest\",\"name\":\"request\",\"type\":\"tuple\"},{\"internalType\":\"bytes\",\"name\":\"signature\",\"type\":\"bytes\"}],\"outputs\":[{\"internalType\":\"bool\",\"name\":\"success\",\"type\":\"bool\"},{\"internalType\":\"bytes\",\"name\":\"returnData\",\"type\":\"bytes\"}],\"stateMutability\":\"payable\"},{\"type\":\"function\",\"name\":\"executeBatch\",\"inputs\":[{\"components\":[{\"internalType\":\"address\",\"name\":\"from\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"to\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"value\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"gas\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"nonce\",\"type\":\"uint256\"},{\"internalType\":\"uint48\",\"name\":\"deadline\",\"type\":\"uint48\"},{\"internalType\":\"bytes\",\"name\":\"data\",\"type\":\"bytes\"}],\"internalType\":\"structNexusForwarder.ForwardRequest[]\",\"name\":\"requests\",\"type\":\"tuple[]\"},{\"internalType\":\"bytes[]\",\"name\":\"signatures\",\"type\":\"bytes[]\"}],\"outputs\":[{\"components\":[{\"internalType\":\"bool\",\"name\":\"success\",\"type\":\"bool\"},{\"internalType\":\"bytes\",\"name\":\"returnData\",\"type\":\"bytes\"}],\"internalType\":\"structNexusForwarder.ExecutionResult[]\",\"name\":\"results\",\"type\":\"tuple[]\"}],\"stateMutability\":\"payable\"},{\"type\":\"function\",\"name\":\"getNonce\",\"inputs\":[{\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\"}],\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"getRequestHash\",\"inputs\":[{\"components\":[{\"internalType\":\"address\",\"name\":\"from\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"to\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"value\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"gas\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"nonce\",\"type\":\"uint256\"},{\"internalType\":\"uint48\",\"name\":\"deadline\",\"type\":\"uint48\"},{\"internalType\":\"bytes\",\"name\":\"data\",\"type\":\"bytes\"}],\"internalType\":\"structNexusForwarder.ForwardRequest\",\"name\":\"request\",\"type\":\"tuple\"}],\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"getRoleAdmin\",\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"role\",\"type\":\"bytes32\"}],\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"grantRole\",\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"role\",\"type\":\"bytes32\"},{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"}],\"outputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"hasRole\",\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"role\",\"type\":\"bytes32\"},{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"}],\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"isTargetAllowed\",\"inputs\":[{\"internalType\":\"address\",\"name\":\"target\",\"type\":\"address\"}],\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"nonces\",\"inputs\":[{\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\"}],\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"pause\",\"inputs\":[],\"outputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"paused\",\"inputs\":[],\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"renounceRole\",\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"role\",\"type\":\"bytes32\"},{\"internalType\":\"address\",\"name\":\"callerConfirmation\",\"type\":\"address\"}],\"outputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"revokeRole\",\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"role\",\"type\":\"bytes32\"},{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"}],\"outputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"setEnforceWhitelist\",\"inputs\":[{\"internalType\":\"bool\",\"name\":\"enforced\",\"type\":\"bool\"}],\"outputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"setTargetAllowed\",\"inputs\":[{\"internalType\":\"address\",\"name\":\"target\",\"type\":\"address\"},{\"internalType\":\"bool\",\"name\":\"allowed\",\"type\":\"bool\"}],\"outputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"setTargetsAllowed\",\"inputs\":[{\"internalType\":\"address[]\",\"name\":\"targets\",\"type\":\"address[]\"},{\"internalType\":\"bool\",\"name\":\"allowed\",\"type\":\"bool\"}],\"outputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"supportsInterface\",\"inputs\":[{\"internalType\":\"bytes4\",\"name\":\"interfaceId\",\"type\":\"bytes4\"}],\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"totalExecutions\",\"inputs\":[],\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"totalGasSponsored\",\"inputs\":[],\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"unpause\",\"inputs\":[],\"outputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"verify\",\"inputs\":[{\"components\":[{\"internalType\":\"address\",\"name\":\"from\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"to\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"value\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"gas\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"nonce\",\"type\":\"uint256\"},{\"internalType\":\"uint48\",\"name\":\"deadline\",\"type\":\"uint48\"},{\"internalType\":\"bytes\",\"name\":\"data\",\"type\":\"bytes\"}],\"internalType\":\"structNexusForwarder.ForwardRequest\",\"name\":\"request\",\"type\":\"tuple\"},{\"internalType\":\"bytes\",\"name\":\"signature\",\"type\":\"bytes\"}],\"outputs\":[{\"internalType\":\"bool\",\"name\":\"valid\",\"type\":\"bool\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"withdrawTips\",\"inputs\":[{\"internalType\":\"addresspayable\",\"name\":\"to\",\"type\":\"address\"}],\"outputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"receive\",\"stateMutability\":\"payable\"},{\"type\":\"event\",\"name\":\"BatchExecuted\",\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"batchId\",\"type\":\"uint256\",\"indexed\":true},{\"internalType\":\"uint256\",\"name\":\"successCount\",\"type\":\"uint256\",\"indexed\":false},{\"internalType\":\"uint256\",\"name\":\"failureCount\",\"type\":\"uint256\",\"indexed\":false}],\"anonymous\":false},{\"type\":\"event\",\"name\":\"EIP712DomainChanged\",\"inputs\":[],\"anonymous\":false},{\"type\":\"event\",\"name\":\"Paused\",\"inputs\":[{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\",\"indexed\":false}],\"anonymous\":false},{\"type\":\"event\",\"name\":\"RelayerTipped\",\"inputs\":[{\"internalType\":\"address\",\"name\":\"relayer\",\"type\":\"address\",\"indexed\":true},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\",\"indexed\":false}],\"anonymous\":false},{\"type\":\"event\",\"name\":\"RequestExecuted\",\"inputs\":[{\"internalType\":\"address\",\"name\":\"from\",\"type\":\"address\",\"indexed\":true},{\"internalType\":\"address\",\"name\":\"to\",\"type\":\"address\",\"indexed\":true},{\"internalType\":\"uint256\",\"name\":\"nonce\",\"type\":\"uint256\",\"indexed\":false},{\"internalType\":\"bool\",\"name\":\"success\",\"type\":\"bool\",\"indexed\":false},{\"internalType\":\"bytes\",\"name\":\"returnData\",\"type\":\"bytes\",\"indexed\":false}],\"anonymous\":false},{\"type\":\"event\",\"name\":\"RoleAdminChanged\",\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"role\",\"type\":\"bytes32\",\"indexed\":true},{\"internalType\":\"bytes32\",\"name\":\"previousAdminRole\",\"type\":\"bytes32\",\"indexed\":true},{\"internalType\":\"bytes32\",\"name\":\"newAdminRole\",\"type\":\"bytes32\",\"indexed\":true}],\"anonymous\":false},{\"type\":\"event\",\"name\":\"RoleGranted\",\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"role\",\"type\":\"bytes32\",\"indexed\":true},{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\",\"indexed\":true},{\"internalType\":\"address\",\"name\":\"sender\",\"type\":\"address\",\"indexed\":true}],\"anonymous\":false},{\"type\":\"event\",\"name\":\"RoleRevoked\",\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"role\",\"type\":\"bytes32\",\"indexed\":true},{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\",\"indexed\":true},{\"internalType\":\"address\",\"name\":\"sender\",\"type\":\"address\",\"indexed\":true}],\"anonymous\":false},{\"type\":\"event\",\"name\":\"TargetWhitelistUpdated\",\"inputs\":[{\"internalType\":\"address\",\"name\":\"target\",\"type\":\"address\",\"indexed\":true},{\"internalType\":\"bool\",\"name\":\"allowed\",\"type\":\"bool\",\"indexed\":false}],\"anonymous\":false},{\"type\":\"event\",\"name\":\"Unpaused\",\"inputs\":[{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\",\"indexed\":false}],\"anonymous\":false},{\"type\":\"event\",\"name\":\"WhitelistEnforcementUpdated\",\"inputs\":[{\"internalType\":\"bool\",\"name\":\"enforced\",\"type\":\"bool\",\"indexed\":false}],\"anonymous\":false},{\"type\":\"error\",\"name\":\"AccessControlBadConfirmation\",\"inputs\":[]},{\"type\":\"error\",\"name\":\"AccessControlUnauthorizedAccount\",\"inputs\":[{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"},{\"internalType\":\"bytes32\",\"name\":\"neededRole\",\"type\":\"bytes32\"}]},{\"type\":\"error\",\"name\":\"ArrayLengthMismatch\",\"inputs\":[]},{\"type\":\"error\",\"name\":\"CallFailed\",\"inputs\":[{\"internalType\":\"bytes\",\"name\":\"returnData\",\"type\":\"bytes\"}]},{\"type\":\"error\",\"name\":\"ECDSAInvalidSignature\",\"inputs\":[]},{\"type\":\"error\",\"name\":\"ECDSAInvalidSignatureLength\",\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"length\",\"type\":\"uint256\"}]},{\"type\":\"error\",\"name\":\"ECDSAInvalidSignatureS\",\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"s\",\"type\":\"bytes32\"}]},{\"type\":\"error\",\"name\":\"EmptyBatch\",\"inputs\":[]},{\"type\":\"error\",\"name\":\"EnforcedPause\",\"inputs\":[]},{\"type\":\"error\",\"name\":\"ExpectedPause\",\"inputs\":[]},{\"type\":\"error\",\"name\":\"InsufficientGas\",\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"required\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"provided\",\"type\":\"uint256\"}]},{\"type\":\"error\",\"name\":\"InvalidAccountNonce\",\"inputs\":[{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"currentNonce\",\"type\":\"uint256\"}]},{\"type\":\"error\",\"name\":\"InvalidNonce\",\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"expected\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"provided\",\"type\":\"uint256\"}]},{\"type\":\"error\",\"name\":\"InvalidShortString\",\"inputs\":[]},{\"type\":\"error\",\"name\":\"InvalidSignature\",\"inputs\":[]},{\"type\":\"error\",\"name\":\"ReentrancyGuardReentrantCall\",\"inputs\":[]},{\"type\":\"error\",\"name\":\"RequestExpired\",\"inputs\":[{\"internalType\":\"uint48\",\"name\":\"deadline\",\"type\":\"uint48\"},{\"internalType\":\"uint256\",\"name\":\"currentTime\",\"type\":\"uint256\"}]},{\"type\":\"error\",\"name\":\"SignerMismatch\",\"inputs\":[{\"internalType\":\"address\",\"name\":\"signer\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"from\",\"type\":\"address\"}]},{\"type\":\"error\",\"name\":\"StringTooLong\",\"inputs\":[{\"internalType\":\"string\",\"name\":\"str\",\"type\":\"string\"}]},{\"type\":\"error\",\"name\":\"TargetNotAllowed\",\"inputs\":[{\"internalType\":\"address\",\"name\":\"target\",\"type\":\"address\"}]},{\"type\":\"error\",\"name\":\"ValueTransferFailed\",\"inputs\":[]}]",
+}
+
+// NexusForwarderABI is the input ABI used to generate the binding from.
+// Deprecated: Use NexusForwarderMetaData.ABI instead.
+var NexusForwarderABI = NexusForwarderMetaData.ABI
+
+// NexusForwarder is an auto generated Go binding around an Ethereum contract.
+type NexusForwarder struct {
+	NexusForwarderCaller     // Read-only binding to the contract
+	NexusForwarderTransactor // Write-only binding to the contract
+	NexusForwarderFilterer   // Log filterer for contract events
+}
+
+// NexusForwarderCaller is an auto generated read-only Go binding around an Ethereum contract.
+type NexusForwarderCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// NexusForwarderTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type NexusForwarderTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// NexusForwarderFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type NexusForwarderFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// NexusForwarderSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type NexusForwarderSession struct {
+	Contract     *NexusForwarder   // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// NexusForwarderCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type NexusForwarderCallerSession struct {
+	Contract *NexusForwarderCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts         // Call options to use throughout this session
+}
+
+// NexusForwarderTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type NexusForwarderTransactorSession struct {
+	Contract     *NexusForwarderTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts         // Transaction auth options to use throughout this session
+}
+
+// NexusForwarderRaw is an auto generated low-level Go binding around an Ethereum contract.
+type NexusForwarderRaw struct {
+	Contract *NexusForwarder // Generic contract binding to access the raw methods on
+}
+
+// NexusForwarderCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type NexusForwarderCallerRaw struct {
+	Contract *NexusForwarderCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// NexusForwarderTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type NexusForwarderTransactorRaw struct {
+	Contract *NexusForwarderTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewNexusForwarder creates a new instance of NexusForwarder, bound to a specific deployed contract.
+func NewNexusForwarder(address common.Address, backend bind.ContractBackend) (*NexusForwarder, error) {
+	contract, err := bindNexusForwarder(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &NexusForwarder{NexusForwarderCaller: NexusForwarderCaller{contract: contract}, NexusForwarderTransactor: NexusForwarderTransactor{contract: contract}, NexusForwarderFilterer: NexusForwarderFilterer{contract: contract}}, nil
+}
+
+// NewNexusForwarderCaller creates a new read-only instance of NexusForwarder, bound to a specific deployed contract.
+func NewNexusForwarderCaller(address common.Address, caller bind.ContractCaller) (*NexusForwarderCaller, error) {
+	contract, err := bindNexusForwarder(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &NexusForwarderCaller{contract: contract}, nil
+}
+
+// NewNexusForwarderTransactor creates a new write-only instance of NexusForwarder, bound to a specific deployed contract.
+func NewNexusForwarderTransactor(address common.Address, transactor bind.ContractTransactor) (*NexusForwarderTransactor, error) {
+	contract, err := bindNexusForwarder(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &NexusForwarderTransactor{contract: contract}, nil
+}
+
+// NewNexusForwarderFilterer creates a new log filterer instance of NexusForwarder, bound to a specific deployed contract.
+func NewNexusForwarderFilterer(address common.Address, filterer bind.ContractFilterer) (*NexusForwarderFilterer, error) {
+	contract, err := bindNexusForwarder(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &NexusForwarderFilterer{contract: contract}, nil
+}
+
+// bindNexusForwarder binds a generic wrapper to an already deployed contract.
+func bindNexusForwarder(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := NexusForwarderMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_NexusForwarder *NexusForwarderRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _NexusForwarder.Contract.NexusForwarderCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_NexusForwarder *NexusForwarderRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _NexusForwarder.Contract.NexusForwarderTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_NexusForwarder *NexusForwarderRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _NexusForwarder.Contract.NexusForwarderTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_NexusForwarder *NexusForwarderCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _NexusForwarder.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_NexusForwarder *NexusForwarderTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _NexusForwarder.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_NexusForwarder *NexusForwarderTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _NexusForwarder.Contract.contract.Transact(opts, method, params...)
+}
+
+// ADMINROLE is a free data retrieval call binding the contract method 0x75b238fc.
+//
+// Solidity: function ADMIN_ROLE() view returns(bytes32)
+func (_NexusForwarder *NexusForwarderCaller) ADMINROLE(opts *bind.CallOpts) ([32]byte, error) {
+	var out []interface{}
+	err := _NexusForwarder.contract.Call(opts, &out, "ADMIN_ROLE")
+
+	if err != nil {
+		return *new([32]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([32]byte)).(*[32]byte)
+
+	return out0, err
+
+}
+
+// ADMINROLE is a free data retrieval call binding the contract method 0x75b238fc.
+//
+// Solidity: function ADMIN_ROLE() view returns(bytes32)
+func (_NexusForwarder *NexusForwarderSession) ADMINROLE() ([32]byte, error) {
+	return _NexusForwarder.Contract.ADMINROLE(&_NexusForwarder.CallOpts)
+}
+
+// ADMINROLE is a free data retrieval call binding the contract method 0x75b238fc.
+//
+// Solidity: function ADMIN_ROLE() view returns(bytes32)
+func (_NexusForwarder *NexusForwarderCallerSession) ADMINROLE() ([32]byte, error) {
+	return _NexusForwarder.Contract.ADMINROLE(&_NexusForwarder.CallOpts)
+}
+
+// DEFAULTADMINROLE is a free data retrieval call binding the contract method 0xa217fddf.
+//
+// Solidity: function DEFAULT_ADMIN_ROLE() view returns(bytes32)
+func (_NexusForwarder *NexusForwarderCaller) DEFAULTADMINROLE(opts *bind.CallOpts) ([32]byte, error) {
+	var out []interface{}
+	err := _NexusForwarder.contract.Call(opts, &out, "DEFAULT_ADMIN_ROLE")
+
+	if err != nil {
+		return *new([32]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([32]byte)).(*[32]byte)
+
+	return out0, err
+
+}
+
+// DEFAULTADMINROLE is a free data retrieval call binding the contract method 0xa217fddf.
+//
+// Solidity: function DEFAULT_ADMIN_ROLE() view returns(bytes32)
+func (_NexusForwarder *NexusForwarderSession) DEFAULTADMINROLE() ([32]byte, error) {
+	return _NexusForwarder.Contract.DEFAULTADMINROLE(&_NexusForwarder.CallOpts)
+}
+
+// DEFAULTADMINROLE is a free data retrieval call binding the contract method 0xa217fddf.
+//
+// Solidity: function DEFAULT_ADMIN_ROLE() view returns(bytes32)
+func (_NexusForwarder *NexusForwarderCallerSession) DEFAULTADMINROLE() ([32]byte, error) {
+	return _NexusForwarder.Contract.DEFAULTADMINROLE(&_NexusForwarder.CallOpts)
+}
+
+// RELAYERROLE is a free data retrieval call binding the contract method 0x926d7d7f.
+//
+// Solidity: function RELAYER_ROLE() view returns(bytes32)
+func (_NexusForwarder *NexusForwarderCaller) RELAYERROLE(opts *bind.CallOpts) ([32]byte, error) {
+	var out []interface{}
+	err := _NexusForwarder.contract.Call(opts, &out, "RELAYER_ROLE")
+
+	if err != nil {
+		return *new([32]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([32]byte)).(*[32]byte)
+
+	return out0, err
+
+}
+
+// RELAYERROLE is a free data retrieval call binding the contract method 0x926d7d7f.
+//
+// Solidity: function RELAYER_ROLE() view returns(bytes32)
+func (_NexusForwarder *NexusForwarderSession) RELAYERROLE() ([32]byte, error) {
+	return _NexusForwarder.Contract.RELAYERROLE(&_NexusForwarder.CallOpts)
+}
+
+// RELAYERROLE is a free data retrieval call binding the contract method 0x926d7d7f.
+//
+// Solidity: function RELAYER_ROLE() view returns(bytes32)
+func (_NexusForwarder *NexusForwarderCallerSession) RELAYERROLE() ([32]byte, error) {
+	return _NexusForwarder.Contract.RELAYERROLE(&_NexusForwarder.CallOpts)
+}
+
+// AllowedTargets is a free data retrieval call binding the contract method 0xb8fe8d5f.
+//
+// Solidity: function allowedTargets(address target) view returns(bool allowed)
+func (_NexusForwarder *NexusForwarderCaller) AllowedTargets(opts *bind.CallOpts, target common.Address) (bool, error) {
+	var out []interface{}
+	err := _NexusForwarder.contract.Call(opts, &out, "allowedTargets", target)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// AllowedTargets is a free data retrieval call binding the contract method 0xb8fe8d5f.
+//
+// Solidity: function allowedTargets(address target) view returns(bool allowed)
+func (_NexusForwarder *NexusForwarderSession) AllowedTargets(target common.Address) (bool, error) {
+	return _NexusForwarder.Contract.AllowedTargets(&_NexusForwarder.CallOpts, target)
+}
+
+// AllowedTargets is a free data retrieval call binding the contract method 0xb8fe8d5f.
+//
+// Solidity: function allowedTargets(address target) view returns(bool allowed)
+func (_NexusForwarder *NexusForwarderCallerSession) AllowedTargets(target common.Address) (bool, error) {
+	return _NexusForwarder.Contract.AllowedTargets(&_NexusForwarder.CallOpts, target)
+}
+
+// DomainSeparator is a free data retrieval call binding the contract method 0xf698da25.
+//
+// Solidity: function domainSeparator() view returns(bytes32)
+func (_NexusForwarder *NexusForwarderCaller) DomainSeparator(opts *bind.CallOpts) ([32]byte, error) {
+	var out []interface{}
+	err := _NexusForwarder.contract.Call(opts, &out, "domainSeparator")
+
+	if err != nil {
+		return *new([32]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([32]byte)).(*[32]byte)
+
+	return out0, err
+
+}
+
+// DomainSeparator is a free data retrieval call binding the contract method 0xf698da25.
+//
+// Solidity: function domainSeparator() view returns(bytes32)
+func (_NexusForwarder *NexusForwarderSession) DomainSeparator() ([32]byte, error) {
+	return _NexusForwarder.Contract.DomainSeparator(&_NexusForwarder.CallOpts)
+}
+
+// DomainSeparator is a free data retrieval call binding the contract method 0xf698da25.
+//
+// Solidity: function domainSeparator() view returns(bytes32)
+func (_NexusForwarder *NexusForwarderCallerSession) DomainSeparator() ([32]byte, error) {
+	return _NexusForwarder.Contract.DomainSeparator(&_NexusForwarder.CallOpts)
+}
+
+// Eip712Domain is a free data retrieval call binding the contract method 0x84b0196e.
+//
+// Solidity: function eip712Domain() view returns(bytes1 fields, string name, string version, uint256 chainId, address verifyingContract, bytes32 salt, uint256[] extensions)
+func (_NexusForwarder *NexusForwarderCaller) Eip712Domain(opts *bind.CallOpts) (struct {
+	Fields            [1]byte
+	Name              string
+	Version           string
+	ChainId           *big.Int
+	VerifyingContract common.Address
+	Salt              [32]byte
+	Extensions        []*big.Int
+}, error) {
+	var out []interface{}
+	err := _NexusForwarder.contract.Call(opts, &out, "eip712Domain")
+
+	outstruct := new(struct {
+		Fields            [1]byte
+		Name              string
+		Version           string
+		ChainId           *big.Int
+		VerifyingContract common.Address
+		Salt              [32]byte
+		Extensions        []*big.Int
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.Fields = *abi.ConvertType(out[0], new([1]byte)).(*[1]byte)
+	outstruct.Name = *abi.ConvertType(out[1], new(string)).(*string)
+	outstruct.Version = *abi.ConvertType(out[2], new(string)).(*string)
+	outstruct.ChainId = *abi.ConvertType(out[3], new(*big.Int)).(**big.Int)
+	outstruct.VerifyingContract = *abi.ConvertType(out[4], new(common.Address)).(*common.Address)
+	outstruct.Salt = *abi.ConvertType(out[5], new([32]byte)).(*[32]byte)
+	outstruct.Extensions = *abi.ConvertType(out[6], new([]*big.Int)).(*[]*big.Int)
+
+	return *outstruct, err
+
+}
+
+// Eip712Domain is a free data retrieval call binding the contract method 0x84b0196e.
+//
+// Solidity: function eip712Domain() view returns(bytes1 fields, string name, string version, uint256 chainId, address verifyingContract, bytes32 salt, uint256[] extensions)
+func (_NexusForwarder *NexusForwarderSession) Eip712Domain() (struct {
+	Fields            [1]byte
+	Name              string
+	Version           string
+	ChainId           *big.Int
+	VerifyingContract common.Address
+	Salt              [32]byte
+	Extensions        []*big.Int
+}, error) {
+	return _NexusForwarder.Contract.Eip712Domain(&_NexusForwarder.CallOpts)
+}
+
+// Eip712Domain is a free data retrieval call binding the contract method 0x84b0196e.
+//
+// Solidity: function eip712Domain() view returns(bytes1 fields, string name, string version, uint256 chainId, address verifyingContract, bytes32 salt, uint256[] extensions)
+func (_NexusForwarder *NexusForwarderCallerSession) Eip712Domain() (struct {
+	Fields            [1]byte
+	Name              string
+	Version           string
+	ChainId           *big.Int
+	VerifyingContract common.Address
+	Salt              [32]byte
+	Extensions        []*big.Int
+}, error) {
+	return _NexusForwarder.Contract.Eip712Domain(&_NexusForwarder.CallOpts)
+}
+
+// EnforceTargetWhitelist is a free data retrieval call binding the contract method 0x2abf912c.
+//
+// Solidity: function enforceTargetWhitelist() view returns(bool)
+func (_NexusForwarder *NexusForwarderCaller) EnforceTargetWhitelist(opts *bind.CallOpts) (bool, error) {
+	var out []interface{}
+	err := _NexusForwarder.contract.Call(opts, &out, "enforceTargetWhitelist")
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// EnforceTargetWhitelist is a free data retrieval call binding the contract method 0x2abf912c.
+//
+// Solidity: function enforceTargetWhitelist() view returns(bool)
+func (_NexusForwarder *NexusForwarderSession) EnforceTargetWhitelist() (bool, error) {
+	return _NexusForwarder.Contract.EnforceTargetWhitelist(&_NexusForwarder.CallOpts)
+}
+
+// EnforceTargetWhitelist is a free data retrieval call binding the contract method 0x2abf912c.
+//
+// Solidity: function enforceTargetWhitelist() view returns(bool)
+func (_NexusForwarder *NexusForwarderCallerSession) EnforceTargetWhitelist() (bool, error) {
+	return _NexusForwarder.Contract.EnforceTargetWhitelist(&_NexusForwarder.CallOpts)
+}
+
+// GetNonce is a free data retrieval call binding the contract method 0x2d0335ab.
+//
+// Solidity: function getNonce(address owner) view returns(uint256)
+func (_NexusForwarder *NexusForwarderCaller) GetNonce(opts *bind.CallOpts, owner common.Address) (*big.Int, error) {
+	var out []interface{}
+	err := _NexusForwarder.contract.Call(opts, &out, "getNonce", owner)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// GetNonce is a free data retrieval call binding the contract method 0x2d0335ab.
+//
+// Solidity: function getNonce(address owner) view returns(uint256)
+func (_NexusForwarder *NexusForwarderSession) GetNonce(owner common.Address) (*big.Int, error) {
+	return _NexusForwarder.Contract.GetNonce(&_NexusForwarder.CallOpts, owner)
+}
+
+// GetNonce is a free data retrieval call binding the contract method 0x2d0335ab.
+//
+// Solidity: function getNonce(address owner) view returns(uint256)
+func (_NexusForwarder *NexusForwarderCallerSession) GetNonce(owner common.Address) (*big.Int, error) {
+	return _NexusForwarder.Contract.GetNonce(&_NexusForwarder.CallOpts, owner)
+}
+
+// GetRequestHash is a free data retrieval call binding the contract method 0xaeffde54.
+//
+// Solidity: function getRequestHash((address,address,uint256,uint256,uint256,uint48,bytes) request) view returns(bytes32)
+func (_NexusForwarder *NexusForwarderCaller) GetRequestHash(opts *bind.CallOpts, request NexusForwarderForwardRequest) ([32]byte, error) {
+	var out []interface{}
+	err := _NexusForwarder.contract.Call(opts, &out, "getRequestHash", request)
+
+	if err != nil {
+		return *new([32]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([32]byte)).(*[32]byte)
+
+	return out0, err
+
+}
+
+// GetRequestHash is a free data retrieval call binding the contract method 0xaeffde54.
+//
+// Solidity: function getRequestHash((address,address,uint256,uint256,uint256,uint48,bytes) request) view returns(bytes32)
+func (_NexusForwarder *NexusForwarderSession) GetRequestHash(request NexusForwarderForwardRequest) ([32]byte, error) {
+	return _NexusForwarder.Contract.GetRequestHash(&_NexusForwarder.CallOpts, request)
+}
+
+// GetRequestHash is a free data retrieval call binding the contract method 0xaeffde54.
+//
+// Solidity: function getRequestHash((address,address,uint256,uint256,uint256,uint48,bytes) request) view returns(bytes32)
+func (_NexusForwarder *NexusForwarderCallerSession) GetRequestHash(request NexusForwarderForwardRequest) ([32]byte, error) {
+	return _NexusForwarder.Contract.GetRequestHash(&_NexusForwarder.CallOpts, request)
+}
+
+// GetRoleAdmin is a free data retrieval call binding the contract method 0x248a9ca3.
+//
+// Solidity: function getRoleAdmin(bytes32 role) view returns(bytes32)
+func (_NexusForwarder *NexusForwarderCaller) GetRoleAdmin(opts *bind.CallOpts, role [32]byte) ([32]byte, error) {
+	var out []interface{}
+	err := _NexusForwarder.contract.Call(opts, &out, "getRoleAdmin", role)
+
+	if err != nil {
+		return *new([32]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([32]byte)).(*[32]byte)
+
+	return out0, err
+
+}
+
+// GetRoleAdmin is a free data retrieval call binding the contract method 0x248a9ca3.
+//
+// Solidity: function getRoleAdmin(bytes32 role) view returns(bytes32)
+func (_NexusForwarder *NexusForwarderSession) GetRoleAdmin(role [32]byte) ([32]byte, error) {
+	return _NexusForwarder.Contract.GetRoleAdmin(&_NexusForwarder.CallOpts, role)
+}
+
+// GetRoleAdmin is a free data retrieval call binding the contract method 0x248a9ca3.
+//
+// Solidity: function getRoleAdmin(bytes32 role) view returns(bytes32)
+func (_NexusForwarder *NexusForwarderCallerSession) GetRoleAdmin(role [32]byte) ([32]byte, error) {
+	return _NexusForwarder.Contract.GetRoleAdmin(&_NexusForwarder.CallOpts, role)
+}
+
+// HasRole is a free data retrieval call binding the contract method 0x91d14854.
+//
+// Solidity: function hasRole(bytes32 role, address account) view returns(bool)
+func (_NexusForwarder *NexusForwarderCaller) HasRole(opts *bind.CallOpts, role [32]byte, account common.Address) (bool, error) {
+	var out []interface{}
+	err := _NexusForwarder.contract.Call(opts, &out, "hasRole", role, account)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// HasRole is a free data retrieval call binding the contract method 0x91d14854.
+//
+// Solidity: function hasRole(bytes32 role, address account) view returns(bool)
+func (_NexusForwarder *NexusForwarderSession) HasRole(role [32]byte, account common.Address) (bool, error) {
+	return _NexusForwarder.Contract.HasRole(&_NexusForwarder.CallOpts, role, account)
+}
+
+// HasRole is a free data retrieval call binding the contract method 0x91d14854.
+//
+// Solidity: function hasRole(bytes32 role, address account) view returns(bool)
+func (_NexusForwarder *NexusForwarderCallerSession) HasRole(role [32]byte, account common.Address) (bool, error) {
+	return _NexusForwarder.Contract.HasRole(&_NexusForwarder.CallOpts, role, account)
+}
+
+// IsTargetAllowed is a free data retrieval call binding the contract method 0x5309fa47.
+//
+// Solidity: function isTargetAllowed(address target) view returns(bool)
+func (_NexusForwarder *NexusForwarderCaller) IsTargetAllowed(opts *bind.CallOpts, target common.Address) (bool, error) {
+	var out []interface{}
+	err := _NexusForwarder.contract.Call(opts, &out, "isTargetAllowed", target)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// IsTargetAllowed is a free data retrieval call binding the contract method 0x5309fa47.
+//
+// Solidity: function isTargetAllowed(address target) view returns(bool)
+func (_NexusForwarder *NexusForwarderSession) IsTargetAllowed(target common.Address) (bool, error) {
+	return _NexusForwarder.Contract.IsTargetAllowed(&_NexusForwarder.CallOpts, target)
+}
+
+// IsTargetAllowed is a free data retrieval call binding the contract method 0x5309fa47.
+//
+// Solidity: function isTargetAllowed(address target) view returns(bool)
+func (_NexusForwarder *NexusForwarderCallerSession) IsTargetAllowed(target common.Address) (bool, error) {
+	return _NexusForwarder.Contract.IsTargetAllowed(&_NexusForwarder.CallOpts, target)
+}
+
+// Nonces is a free data retrieval call binding the contract method 0x7ecebe00.
+//
+// Solidity: function nonces(address owner) view returns(uint256)
+func (_NexusForwarder *NexusForwarderCaller) Nonces(opts *bind.CallOpts, owner common.Address) (*big.Int, error) {
+	var out []interface{}
+	err := _NexusForwarder.contract.Call(opts, &out, "nonces", owner)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// Nonces is a free data retrieval call binding the contract method 0x7ecebe00.
+//
+// Solidity: function nonces(address owner) view returns(uint256)
+func (_NexusForwarder *NexusForwarderSession) Nonces(owner common.Address) (*big.Int, error) {
+	return _NexusForwarder.Contract.Nonces(&_NexusForwarder.CallOpts, owner)
+}
+
+// Nonces is a free data retrieval call binding the contract method 0x7ecebe00.
+//
+// Solidity: function nonces(address owner) view returns(uint256)
+func (_NexusForwarder *NexusForwarderCallerSession) Nonces(owner common.Address) (*big.Int, error) {
+	return _NexusForwarder.Contract.Nonces(&_NexusForwarder.CallOpts, owner)
+}
+
+// Paused is a free data retrieval call binding the contract method 0x5c975abb.
+//
+// Solidity: function paused() view returns(bool)
+func (_NexusForwarder *NexusForwarderCaller) Paused(opts *bind.CallOpts) (bool, error) {
+	var out []interface{}
+	err := _NexusForwarder.contract.Call(opts, &out, "paused")
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// Paused is a free data retrieval call binding the contract method 0x5c975abb.
+//
+// Solidity: function paused() view returns(bool)
+func (_NexusForwarder *NexusForwarderSession) Paused() (bool, error) {
+	return _NexusForwarder.Contract.Paused(&_NexusForwarder.CallOpts)
+}
+
+// Paused is a free data retrieval call binding the contract method 0x5c975abb.
+//
+// Solidity: function paused() view returns(bool)
+func (_NexusForwarder *NexusForwarderCallerSession) Paused() (bool, error) {
+	return _NexusForwarder.Contract.Paused(&_NexusForwarder.CallOpts)
+}
+
+// SupportsInterface is a free data retrieval call binding the contract method 0x01ffc9a7.
+//
+// Solidity: function supportsInterface(bytes4 interfaceId) view returns(bool)
+func (_NexusForwarder *NexusForwarderCaller) SupportsInterface(opts *bind.CallOpts, interfaceId [4]byte) (bool, error) {
+	var out []interface{}
+	err := _NexusForwarder.contract.Call(opts, &out, "supportsInterface", interfaceId)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// SupportsInterface is a free data retrieval call binding the contract method 0x01ffc9a7.
+//
+// Solidity: function supportsInterface(bytes4 interfaceId) view returns(bool)
+func (_NexusForwarder *NexusForwarderSession) SupportsInterface(interfaceId [4]byte) (bool, error) {
+	return _NexusForwarder.Contract.SupportsInterface(&_NexusForwarder.CallOpts, interfaceId)
+}
+
+// SupportsInterface is a free data retrieval call binding the contract method 0x01ffc9a7.
+//
+// Solidity: function supportsInterface(bytes4 interfaceId) view returns(bool)
+func (_NexusForwarder *NexusForwarderCallerSession) SupportsInterface(interfaceId [4]byte) (bool, error) {
+	return _NexusForwarder.Contract.SupportsInterface(&_NexusForwarder.CallOpts, interfaceId)
+}
+
+// TotalExecutions is a free data retrieval call binding the contract method 0x642f7d5e.
+//
+// Solidity: function totalExecutions() view returns(uint256)
+func (_NexusForwarder *NexusForwarderCaller) TotalExecutions(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _NexusForwarder.contract.Call(opts, &out, "totalExecutions")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// TotalExecutions is a free data retrieval call binding the contract method 0x642f7d5e.
+//
+// Solidity: function totalExecutions() view returns(uint256)
+func (_NexusForwarder *NexusForwarderSession) TotalExecutions() (*big.Int, error) {
+	return _NexusForwarder.Contract.TotalExecutions(&_NexusForwarder.CallOpts)
+}
+
+// TotalExecutions is a free data retrieval call binding the contract method 0x642f7d5e.
+//
+// Solidity: function totalExecutions() view returns(uint256)
+func (_NexusForwarder *NexusForwarderCallerSession) TotalExecutions() (*big.Int, error) {
+	return _NexusForwarder.Contract.TotalExecutions(&_NexusForwarder.CallOpts)
+}
+
+// TotalGasSponsored is a free data retrieval call binding the contract method 0xe0f6dfca.
+//
+// Solidity: function totalGasSponsored() view returns(uint256)
+func (_NexusForwarder *NexusForwarderCaller) TotalGasSponsored(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _NexusForwarder.contract.Call(opts, &out, "totalGasSponsored")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// TotalGasSponsored is a free data retrieval call binding the contract method 0xe0f6dfca.
+//
+// Solidity: function totalGasSponsored() view returns(uint256)
+func (_NexusForwarder *NexusForwarderSession) TotalGasSponsored() (*big.Int, error) {
+	return _NexusForwarder.Contract.TotalGasSponsored(&_NexusForwarder.CallOpts)
+}
+
+// TotalGasSponsored is a free data retrieval call binding the contract method 0xe0f6dfca.
+//
+// Solidity: function totalGasSponsored() view returns(uint256)
+func (_NexusForwarder *NexusForwarderCallerSession) TotalGasSponsored() (*big.Int, error) {
+	return _NexusForwarder.Contract.TotalGasSponsored(&_NexusForwarder.CallOpts)
+}
+
+// Verify is a free data retrieval call binding the contract method 0x2cc902fc.
+//
+// Solidity: function verify((address,address,uint256,uint256,uint256,uint48,bytes) request, bytes signature) view returns(bool valid)
+func (_NexusForwarder *NexusForwarderCaller) Verify(opts *bind.CallOpts, request NexusForwarderForwardRequest, signature []byte) (bool, error) {
+	var out []interface{}
+	err := _NexusForwarder.contract.Call(opts, &out, "verify", request, signature)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// Verify is a free data retrieval call binding the contract method 0x2cc902fc.
+//
+// Solidity: function verify((address,address,uint256,uint256,uint256,uint48,bytes) request, bytes signature) view returns(bool valid)
+func (_NexusForwarder *NexusForwarderSession) Verify(request NexusForwarderForwardRequest, signature []byte) (bool, error) {
+	return _NexusForwarder.Contract.Verify(&_NexusForwarder.CallOpts, request, signature)
+}
+
+// Verify is a free data retrieval call binding the contract method 0x2cc902fc.
+//
+// Solidity: function verify((address,address,uint256,uint256,uint256,uint48,bytes) request, bytes signature) view returns(bool valid)
+func (_NexusForwarder *NexusForwarderCallerSession) Verify(request NexusForwarderForwardRequest, signature []byte) (bool, error) {
+	return _NexusForwarder.Contract.Verify(&_NexusForwarder.CallOpts, request, signature)
+}
+
+// Execute is a paid mutator transaction binding the contract method 0x86736e6a.
+//
+// Solidity: function execute((address,address,uint256,uint256,uint256,uint48,bytes) request, bytes signature) payable returns(bool success, bytes returnData)
+func (_NexusForwarder *NexusForwarderTransactor) Execute(opts *bind.TransactOpts, request NexusForwarderForwardRequest, signature []byte) (*types.Transaction, error) {
+	return _NexusForwarder.contract.Transact(opts, "execute", request, signature)
+}
+
+// Execute is a paid mutator transaction binding the contract method 0x86736e6a.
+//
+// Solidity: function execute((address,address,uint256,uint256,uint256,uint48,bytes) request, bytes signature) payable returns(bool success, bytes returnData)
+func (_NexusForwarder *NexusForwarderSession) Execute(request NexusForwarderForwardRequest, signature []byte) (*types.Transaction, error) {
+	return _NexusForwarder.Contract.Execute(&_NexusForwarder.TransactOpts, request, signature)
+}
+
+// Execute is a paid mutator transaction binding the contract method 0x86736e6a.
+//
+// Solidity: function execute((address,address,uint256,uint256,uint256,uint48,bytes) request, bytes signature) payable returns(bool success, bytes returnData)
+func (_NexusForwarder *NexusForwarderTransactorSession) Execute(request NexusForwarderForwardRequest, signature []byte) (*types.Transaction, error) {
+	return _NexusForwarder.Contract.Execute(&_NexusForwarder.TransactOpts, request, signature)
+}
+
+// ExecuteBatch is a paid mutator transaction binding the contract method 0xa66944d5.
+//
+// Solidity: function executeBatch((address,address,uint256,uint256,uint256,uint48,bytes)[] requests, bytes[] signatures) payable returns((bool,bytes)[] results)
+func (_NexusForwarder *NexusForwarderTransactor) ExecuteBatch(opts *bind.TransactOpts, requests []NexusForwarderForwardRequest, signatures [][]byte) (*types.Transaction, error) {
+	return _NexusForwarder.contract.Transact(opts, "executeBatch", requests, signatures)
+}
+
+// ExecuteBatch is a paid mutator transaction binding the contract method 0xa66944d5.
+//
+// Solidity: function executeBatch((address,address,uint256,uint256,uint256,uint48,bytes)[] requests, bytes[] signatures) payable returns((bool,bytes)[] results)
+func (_NexusForwarder *NexusForwarderSession) ExecuteBatch(requests []NexusForwarderForwardRequest, signatures [][]byte) (*types.Transaction, error) {
+	return _NexusForwarder.Contract.ExecuteBatch(&_NexusForwarder.TransactOpts, requests, signatures)
+}
+
+// ExecuteBatch is a paid mutator transaction binding the contract method 0xa66944d5.
+//
+// Solidity: function executeBatch((address,address,uint256,uint256,uint256,uint48,bytes)[] requests, bytes[] signatures) payable returns((bool,bytes)[] results)
+func (_NexusForwarder *NexusForwarderTransactorSession) ExecuteBatch(requests []NexusForwarderForwardRequest, signatures [][]byte) (*types.Transaction, error) {
+	return _NexusForwarder.Contract.ExecuteBatch(&_NexusForwarder.TransactOpts, requests, signatures)
+}
+
+// GrantRole is a paid mutator transaction binding the contract method 0x2f2ff15d.
+//
+// Solidity: function grantRole(bytes32 role, address account) returns()
+func (_NexusForwarder *NexusForwarderTransactor) GrantRole(opts *bind.TransactOpts, role [32]byte, account common.Address) (*types.Transaction, error) {
+	return _NexusForwarder.contract.Transact(opts, "grantRole", role, account)
+}
+
+// GrantRole is a paid mutator transaction binding the contract method 0x2f2ff15d.
+//
+// Solidity: function grantRole(bytes32 role, address account) returns()
+func (_NexusForwarder *NexusForwarderSession) GrantRole(role [32]byte, account common.Address) (*types.Transaction, error) {
+	return _NexusForwarder.Contract.GrantRole(&_NexusForwarder.TransactOpts, role, account)
+}
+
+// GrantRole is a paid mutator transaction binding the contract method 0x2f2ff15d.
+//
+// Solidity: function grantRole(bytes32 role, address account) returns()
+func (_NexusForwarder *NexusForwarderTransactorSession) GrantRole(role [32]byte, account common.Address) (*types.Transaction, error) {
+	return _NexusForwarder.Contract.GrantRole(&_NexusForwarder.TransactOpts, role, account)
+}
+
+// Pause is a paid mutator transaction binding the contract method 0x8456cb59.
+//
+// Solidity: function pause() returns()
+func (_NexusForwarder *NexusForwarderTransactor) Pause(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _NexusForwarder.contract.Transact(opts, "pause")
+}
+
+// Pause is a paid mutator transaction binding the contract method 0x8456cb59.
+//
+// Solidity: function pause() returns()
+func (_NexusForwarder *NexusForwarderSession) Pause() (*types.Transaction, error) {
+	return _NexusForwarder.Contract.Pause(&_NexusForwarder.TransactOpts)
+}
+
+// Pause is a paid mutator transaction binding the contract method 0x8456cb59.
+//
+// Solidity: function pause() returns()
+func (_NexusForwarder *NexusForwarderTransactorSession) Pause() (*types.Transaction, error) {
+	return _NexusForwarder.Contract.Pause(&_NexusForwarder.TransactOpts)
+}
+
+// RenounceRole is a paid mutator transaction binding the contract method 0x36568abe.
+//
+// Solidity: function renounceRole(bytes32 role, address callerConfirmation) returns()
+func (_NexusForwarder *NexusForwarderTransactor) RenounceRole(opts *bind.TransactOpts, role [32]byte, callerConfirmation common.Address) (*types.Transaction, error) {
+	return _NexusForwarder.contract.Transact(opts, "renounceRole", role, callerConfirmation)
+}
+
+// RenounceRole is a paid mutator transaction binding the contract method 0x36568abe.
+//
+// Solidity: function renounceRole(bytes32 role, address callerConfirmation) returns()
+func (_NexusForwarder *NexusForwarderSession) RenounceRole(role [32]byte, callerConfirmation common.Address) (*types.Transaction, error) {
+	return _NexusForwarder.Contract.RenounceRole(&_NexusForwarder.TransactOpts, role, callerConfirmation)
+}
+
+// RenounceRole is a paid mutator transaction binding the contract method 0x36568abe.
+//
+// Solidity: function renounceRole(bytes32 role, address callerConfirmation) returns()
+func (_NexusForwarder *NexusForwarderTransactorSession) RenounceRole(role [32]byte, callerConfirmation common.Address) (*types.Transaction, error) {
+	return _NexusForwarder.Contract.RenounceRole(&_NexusForwarder.TransactOpts, role, callerConfirmation)
+}
+
+// RevokeRole is a paid mutator transaction binding the contract method 0xd547741f.
+//
+// Solidity: function revokeRole(bytes32 role, address account) returns()
+func (_NexusForwarder *NexusForwarderTransactor) RevokeRole(opts *bind.TransactOpts, role [32]byte, account common.Address) (*types.Transaction, error) {
+	return _NexusForwarder.contract.Transact(opts, "revokeRole", role, account)
+}
+
+// RevokeRole is a paid mutator transaction binding the contract method 0xd547741f.
+//
+// Solidity: function revokeRole(bytes32 role, address account) returns()
+func (_NexusForwarder *NexusForwarderSession) RevokeRole(role [32]byte, account common.Address) (*types.Transaction, error) {
+	return _NexusForwarder.Contract.RevokeRole(&_NexusForwarder.TransactOpts, role, account)
+}
+
+// RevokeRole is a paid mutator transaction binding the contract method 0xd547741f.
+//
+// Solidity: function revokeRole(bytes32 role, address account) returns()
+func (_NexusForwarder *NexusForwarderTransactorSession) RevokeRole(role [32]byte, account common.Address) (*types.Transaction, error) {
+	return _NexusForwarder.Contract.RevokeRole(&_NexusForwarder.TransactOpts, role, account)
+}
+
+// SetEnforceWhitelist is a paid mutator transaction binding the contract method 0xc8127efc.
+//
+// Solidity: function setEnforceWhitelist(bool enforced) returns()
+func (_NexusForwarder *NexusForwarderTransactor) SetEnforceWhitelist(opts *bind.TransactOpts, enforced bool) (*types.Transaction, error) {
+	return _NexusForwarder.contract.Transact(opts, "setEnforceWhitelist", enforced)
+}
+
+// SetEnforceWhitelist is a paid mutator transaction binding the contract method 0xc8127efc.
+//
+// Solidity: function setEnforceWhitelist(bool enforced) returns()
+func (_NexusForwarder *NexusForwarderSession) SetEnforceWhitelist(enforced bool) (*types.Transaction, error) {
+	return _NexusForwarder.Contract.SetEnforceWhitelist(&_NexusForwarder.TransactOpts, enforced)
+}
+
+// SetEnforceWhitelist is a paid mutator transaction binding the contract method 0xc8127efc.
+//
+// Solidity: function setEnforceWhitelist(bool enforced) returns()
+func (_NexusForwarder *NexusForwarderTransactorSession) SetEnforceWhitelist(enforced bool) (*types.Transaction, error) {
+	return _NexusForwarder.Contract.SetEnforceWhitelist(&_NexusForwarder.TransactOpts, enforced)
+}
+
+// SetTargetAllowed is a paid mutator transaction binding the contract method 0x3a3d56fa.
+//
+// Solidity: function setTargetAllowed(address target, bool allowed) returns()
+func (_NexusForwarder *NexusForwarderTransactor) SetTargetAllowed(opts *bind.TransactOpts, target common.Address, allowed bool) (*types.Transaction, error) {
+	return _NexusForwarder.contract.Transact(opts, "setTargetAllowed", target, allowed)
+}
+
+// SetTargetAllowed is a paid mutator transaction binding the contract method 0x3a3d56fa.
+//
+// Solidity: function setTargetAllowed(address target, bool allowed) returns()
+func (_NexusForwarder *NexusForwarderSession) SetTargetAllowed(target common.Address, allowed bool) (*types.Transaction, error) {
+	return _NexusForwarder.Contract.SetTargetAllowed(&_NexusForwarder.TransactOpts, target, allowed)
+}
+
+// SetTargetAllowed is a paid mutator transaction binding the contract method 0x3a3d56fa.
+//
+// Solidity: function setTargetAllowed(address target, bool allowed) returns()
+func (_NexusForwarder *NexusForwarderTransactorSession) SetTargetAllowed(target common.Address, allowed bool) (*types.Transaction, error) {
+	return _NexusForwarder.Contract.SetTargetAllowed(&_NexusForwarder.TransactOpts, target, allowed)
+}
+
+// SetTargetsAllowed is a paid mutator transaction binding the contract method 0x5f67eebf.
+//
+// Solidity: function setTargetsAllowed(address[] targets, bool allowed) returns()
+func (_NexusForwarder *NexusForwarderTransactor) SetTargetsAllowed(opts *bind.TransactOpts, targets []common.Address, allowed bool) (*types.Transaction, error) {
+	return _NexusForwarder.contract.Transact(opts, "setTargetsAllowed", targets, allowed)
+}
+
+// SetTargetsAllowed is a paid mutator transaction binding the contract method 0x5f67eebf.
+//
+// Solidity: function setTargetsAllowed(address[] targets, bool allowed) returns()
+func (_NexusForwarder *NexusForwarderSession) SetTargetsAllowed(targets []common.Address, allowed bool) (*types.Transaction, error) {
+	return _NexusForwarder.Contract.SetTargetsAllowed(&_NexusForwarder.TransactOpts, targets, allowed)
+}
+
+// SetTargetsAllowed is a paid mutator transaction binding the contract method 0x5f67eebf.
+//
+// Solidity: function setTargetsAllowed(address[] targets, bool allowed) returns()
+func (_NexusForwarder *NexusForwarderTransactorSession) SetTargetsAllowed(targets []common.Address, allowed bool) (*types.Transaction, error) {
+	return _NexusForwarder.Contract.SetTargetsAllowed(&_NexusForwarder.TransactOpts, targets, allowed)
+}
+
+// Unpause is a paid mutator transaction binding the contract method 0x3f4ba83a.
+//
+// Solidity: function unpause() returns()
+func (_NexusForwarder *NexusForwarderTransactor) Unpause(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _NexusForwarder.contract.Transact(opts, "unpause")
+}
+
+// Unpause is a paid mutator transaction binding the contract method 0x3f4ba83a.
+//
+// Solidity: function unpause() returns()
+func (_NexusForwarder *NexusForwarderSession) Unpause() (*types.Transaction, error) {
+	return _NexusForwarder.Contract.Unpause(&_NexusForwarder.TransactOpts)
+}
+
+// Unpause is a paid mutator transaction binding the contract method 0x3f4ba83a.
+//
+// Solidity: function unpause() returns()
+func (_NexusForwarder *NexusForwarderTransactorSession) Unpause() (*types.Transaction, error) {
+	return _NexusForwarder.Contract.Unpause(&_NexusForwarder.TransactOpts)
+}
+
+// WithdrawTips is a paid mutator transaction binding the contract method 0xee697850.
+//
+// Solidity: function withdrawTips(address to) returns()
+func (_NexusForwarder *NexusForwarderTransactor) WithdrawTips(opts *bind.TransactOpts, to common.Address) (*types.Transaction, error) {
+	return _NexusForwarder.contract.Transact(opts, "withdrawTips", to)
+}
+
+// WithdrawTips is a paid mutator transaction binding the contract method 0xee697850.
+//
+// Solidity: function withdrawTips(address to) returns()
+func (_NexusForwarder *NexusForwarderSession) WithdrawTips(to common.Address) (*types.Transaction, error) {
+	return _NexusForwarder.Contract.WithdrawTips(&_NexusForwarder.TransactOpts, to)
+}
+
+// WithdrawTips is a paid mutator transaction binding the contract method 0xee697850.
+//
+// Solidity: function withdrawTips(address to) returns()
+func (_NexusForwarder *NexusForwarderTransactorSession) WithdrawTips(to common.Address) (*types.Transaction, error) {
+	return _NexusForwarder.Contract.WithdrawTips(&_NexusForwarder.TransactOpts, to)
+}
+
+// Receive is a paid mutator transaction binding the contract receive function.
+//
+// Solidity: receive() payable returns()
+func (_NexusForwarder *NexusForwarderTransactor) Receive(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _NexusForwarder.contract.RawTransact(opts, nil) // calldata is disallowed for receive function
+}
+
+// Receive is a paid mutator transaction binding the contract receive function.
+//
+// Solidity: receive() payable returns()
+func (_NexusForwarder *NexusForwarderSession) Receive() (*types.Transaction, error) {
+	return _NexusForwarder.Contract.Receive(&_NexusForwarder.TransactOpts)
+}
+
+// Receive is a paid mutator transaction binding the contract receive function.
+//
+// Solidity: receive() payable returns()
+func (_NexusForwarder *NexusForwarderTransactorSession) Receive() (*types.Transaction, error) {
+	return _NexusForwarder.Contract.Receive(&_NexusForwarder.TransactOpts)
+}
+
+// NexusForwarderBatchExecutedIterator is returned from FilterBatchExecuted and is used to iterate over the raw logs and unpacked data for BatchExecuted events raised by the NexusForwarder contract.
+type NexusForwarderBatchExecutedIterator struct {
+	Event *NexusForwarderBatchExecuted // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *NexusForwarderBatchExecutedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(NexusForwarderBatchExecuted)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(NexusForwarderBatchExecuted)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *NexusForwarderBatchExecutedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *NexusForwarderBatchExecutedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// NexusForwarderBatchExecuted represents a BatchExecuted event raised by the NexusForwarder contract.
+type NexusForwarderBatchExecuted struct {
+	BatchId      *big.Int
+	SuccessCount *big.Int
+	FailureCount *big.Int
+	Raw          types.Log // Blockchain specific contextual infos
+}
+
+// FilterBatchExecuted is a free log retrieval operation binding the contract event 0x2403003b183ff811dedab647749b7525f94e39ff05e4a7b4f02743c21b6c1622.
+//
+// Solidity: event BatchExecuted(uint256 indexed batchId, uint256 successCount, uint256 failureCount)
+func (_NexusForwarder *NexusForwarderFilterer) FilterBatchExecuted(opts *bind.FilterOpts, batchId []*big.Int) (*NexusForwarderBatchExecutedIterator, error) {
+
+	var batchIdRule []interface{}
+	for _, batchIdItem := range batchId {
+		batchIdRule = append(batchIdRule, batchIdItem)
+	}
+
+	logs, sub, err := _NexusForwarder.contract.FilterLogs(opts, "BatchExecuted", batchIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return &NexusForwarderBatchExecutedIterator{contract: _NexusForwarder.contract, event: "BatchExecuted", logs: logs, sub: sub}, nil
+}
+
+// WatchBatchExecuted is a free log subscription operation binding the contract event 0x2403003b183ff811dedab647749b7525f94e39ff05e4a7b4f02743c21b6c1622.
+//
+// Solidity: event BatchExecuted(uint256 indexed batchId, uint256 successCount, uint256 failureCount)
+func (_NexusForwarder *NexusForwarderFilterer) WatchBatchExecuted(opts *bind.WatchOpts, sink chan<- *NexusForwarderBatchExecuted, batchId []*big.Int) (event.Subscription, error) {
+
+	var batchIdRule []interface{}
+	for _, batchIdItem := range batchId {
+		batchIdRule = append(batchIdRule, batchIdItem)
+	}
+
+	logs, sub, err := _NexusForwarder.contract.WatchLogs(opts, "BatchExecuted", batchIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(NexusForwarderBatchExecuted)
+				if err := _NexusForwarder.contract.UnpackLog(event, "BatchExecuted", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseBatchExecuted is a log parse operation binding the contract event 0x2403003b183ff811dedab647749b7525f94e39ff05e4a7b4f02743c21b6c1622.
+//
+// Solidity: event BatchExecuted(uint256 indexed batchId, uint256 successCount, uint256 failureCount)
+func (_NexusForwarder *NexusForwarderFilterer) ParseBatchExecuted(log types.Log) (*NexusForwarderBatchExecuted, error) {
+	event := new(NexusForwarderBatchExecuted)
+	if err := _NexusForwarder.contract.UnpackLog(event, "BatchExecuted", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// NexusForwarderEIP712DomainChangedIterator is returned from FilterEIP712DomainChanged and is used to iterate over the raw logs and unpacked data for EIP712DomainChanged events raised by the NexusForwarder contract.
+type NexusForwarderEIP712DomainChangedIterator struct {
+	Event *NexusForwarderEIP712DomainChanged // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *NexusForwarderEIP712DomainChangedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(NexusForwarderEIP712DomainChanged)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(NexusForwarderEIP712DomainChanged)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *NexusForwarderEIP712DomainChangedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *NexusForwarderEIP712DomainChangedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// NexusForwarderEIP712DomainChanged represents a EIP712DomainChanged event raised by the NexusForwarder contract.
+type NexusForwarderEIP712DomainChanged struct {
+	Raw types.Log // Blockchain specific contextual infos
+}
+
+// FilterEIP712DomainChanged is a free log retrieval operation binding the contract event 0x0a6387c9ea3628b88a633bb4f3b151770f70085117a15f9bf3787cda53f13d31.
+//
+// Solidity: event EIP712DomainChanged()
+func (_NexusForwarder *NexusForwarderFilterer) FilterEIP712DomainChanged(opts *bind.FilterOpts) (*NexusForwarderEIP712DomainChangedIterator, error) {
+
+	logs, sub, err := _NexusForwarder.contract.FilterLogs(opts, "EIP712DomainChanged")
+	if err != nil {
+		return nil, err
+	}
+	return &NexusForwarderEIP712DomainChangedIterator{contract: _NexusForwarder.contract, event: "EIP712DomainChanged", logs: logs, sub: sub}, nil
+}
+
+// WatchEIP712DomainChanged is a free log subscription operation binding the contract event 0x0a6387c9ea3628b88a633bb4f3b151770f70085117a15f9bf3787cda53f13d31.
+//
+// Solidity: event EIP712DomainChanged()
+func (_NexusForwarder *NexusForwarderFilterer) WatchEIP712DomainChanged(opts *bind.WatchOpts, sink chan<- *NexusForwarderEIP712DomainChanged) (event.Subscription, error) {
+
+	logs, sub, err := _NexusForwarder.contract.WatchLogs(opts, "EIP712DomainChanged")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(NexusForwarderEIP712DomainChanged)
+				if err := _NexusForwarder.contract.UnpackLog(event, "EIP712DomainChanged", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseEIP712DomainChanged is a log parse operation binding the contract event 0x0a6387c9ea3628b88a633bb4f3b151770f70085117a15f9bf3787cda53f13d31.
+//
+// Solidity: event EIP712DomainChanged()
+func (_NexusForwarder *NexusForwarderFilterer) ParseEIP712DomainChanged(log types.Log) (*NexusForwarderEIP712DomainChanged, error) {
+	event := new(NexusForwarderEIP712DomainChanged)
+	if err := _NexusForwarder.contract.UnpackLog(event, "EIP712DomainChanged", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// NexusForwarderPausedIterator is returned from FilterPaused and is used to iterate over the raw logs and unpacked data for Paused events raised by the NexusForwarder contract.
+type NexusForwarderPausedIterator struct {
+	Event *NexusForwarderPaused // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *NexusForwarderPausedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(NexusForwarderPaused)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(NexusForwarderPaused)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *NexusForwarderPausedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *NexusForwarderPausedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// NexusForwarderPaused represents a Paused event raised by the NexusForwarder contract.
+type NexusForwarderPaused struct {
+	Account common.Address
+	Raw     types.Log // Blockchain specific contextual infos
+}
+
+// FilterPaused is a free log retrieval operation binding the contract event 0x62e78cea01bee320cd4e420270b5ea74000d11b0c9f74754ebdbfc544b05a258.
+//
+// Solidity: event Paused(address account)
+func (_NexusForwarder *NexusForwarderFilterer) FilterPaused(opts *bind.FilterOpts) (*NexusForwarderPausedIterator, error) {
+
+	logs, sub, err := _NexusForwarder.contract.FilterLogs(opts, "Paused")
+	if err != nil {
+		return nil, err
+	}
+	return &NexusForwarderPausedIterator{contract: _NexusForwarder.contract, event: "Paused", logs: logs, sub: sub}, nil
+}
+
+// WatchPaused is a free log subscription operation binding the contract event 0x62e78cea01bee320cd4e420270b5ea74000d11b0c9f74754ebdbfc544b05a258.
+//
+// Solidity: event Paused(address account)
+func (_NexusForwarder *NexusForwarderFilterer) WatchPaused(opts *bind.WatchOpts, sink chan<- *NexusForwarderPaused) (event.Subscription, error) {
+
+	logs, sub, err := _NexusForwarder.contract.WatchLogs(opts, "Paused")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(NexusForwarderPaused)
+				if err := _NexusForwarder.contract.UnpackLog(event, "Paused", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParsePaused is a log parse operation binding the contract event 0x62e78cea01bee320cd4e420270b5ea74000d11b0c9f74754ebdbfc544b05a258.
+//
+// Solidity: event Paused(address account)
+func (_NexusForwarder *NexusForwarderFilterer) ParsePaused(log types.Log) (*NexusForwarderPaused, error) {
+	event := new(NexusForwarderPaused)
+	if err := _NexusForwarder.contract.UnpackLog(event, "Paused", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// NexusForwarderRelayerTippedIterator is returned from FilterRelayerTipped and is used to iterate over the raw logs and unpacked data for RelayerTipped events raised by the NexusForwarder contract.
+type NexusForwarderRelayerTippedIterator struct {
+	Event *NexusForwarderRelayerTipped // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *NexusForwarderRelayerTippedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(NexusForwarderRelayerTipped)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(NexusForwarderRelayerTipped)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *NexusForwarderRelayerTippedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *NexusForwarderRelayerTippedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// NexusForwarderRelayerTipped represents a RelayerTipped event raised by the NexusForwarder contract.
+type NexusForwarderRelayerTipped struct {
+	Relayer common.Address
+	Amount  *big.Int
+	Raw     types.Log // Blockchain specific contextual infos
+}
+
+// FilterRelayerTipped is a free log retrieval operation binding the contract event 0xe9a3840fcaf9421987ff9f4b4c011526f952dcb5cd53dffb754ad7adacfa6141.
+//
+// Solidity: event RelayerTipped(address indexed relayer, uint256 amount)
+func (_NexusForwarder *NexusForwarderFilterer) FilterRelayerTipped(opts *bind.FilterOpts, relayer []common.Address) (*NexusForwarderRelayerTippedIterator, error) {
+
+	var relayerRule []interface{}
+	for _, relayerItem := range relayer {
+		relayerRule = append(relayerRule, relayerItem)
+	}
+
+	logs, sub, err := _NexusForwarder.contract.FilterLogs(opts, "RelayerTipped", relayerRule)
+	if err != nil {
+		return nil, err
+	}
+	return &NexusForwarderRelayerTippedIterator{contract: _NexusForwarder.contract, event: "RelayerTipped", logs: logs, sub: sub}, nil
+}
+
+// WatchRelayerTipped is a free log subscription operation binding the contract event 0xe9a3840fcaf9421987ff9f4b4c011526f952dcb5cd53dffb754ad7adacfa6141.
+//
+// Solidity: event RelayerTipped(address indexed relayer, uint256 amount)
+func (_NexusForwarder *NexusForwarderFilterer) WatchRelayerTipped(opts *bind.WatchOpts, sink chan<- *NexusForwarderRelayerTipped, relayer []common.Address) (event.Subscription, error) {
+
+	var relayerRule []interface{}
+	for _, relayerItem := range relayer {
+		relayerRule = append(relayerRule, relayerItem)
+	}
+
+	logs, sub, err := _NexusForwarder.contract.WatchLogs(opts, "RelayerTipped", relayerRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(NexusForwarderRelayerTipped)
+				if err := _NexusForwarder.contract.UnpackLog(event, "RelayerTipped", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseRelayerTipped is a log parse operation binding the contract event 0xe9a3840fcaf9421987ff9f4b4c011526f952dcb5cd53dffb754ad7adacfa6141.
+//
+// Solidity: event RelayerTipped(address indexed relayer, uint256 amount)
+func (_NexusForwarder *NexusForwarderFilterer) ParseRelayerTipped(log types.Log) (*NexusForwarderRelayerTipped, error) {
+	event := new(NexusForwarderRelayerTipped)
+	if err := _NexusForwarder.contract.UnpackLog(event, "RelayerTipped", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// NexusForwarderRequestExecutedIterator is returned from FilterRequestExecuted and is used to iterate over the raw logs and unpacked data for RequestExecuted events raised by the NexusForwarder contract.
+type NexusForwarderRequestExecutedIterator struct {
+	Event *NexusForwarderRequestExecuted // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *NexusForwarderRequestExecutedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(NexusForwarderRequestExecuted)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(NexusForwarderRequestExecuted)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *NexusForwarderRequestExecutedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *NexusForwarderRequestExecutedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// NexusForwarderRequestExecuted represents a RequestExecuted event raised by the NexusForwarder contract.
+type NexusForwarderRequestExecuted struct {
+	From       common.Address
+	To         common.Address
+	Nonce      *big.Int
+	Success    bool
+	ReturnData []byte
+	Raw        types.Log // Blockchain specific contextual infos
+}
+
+// FilterRequestExecuted is a free log retrieval operation binding the contract event 0x45567ff6526831d3b6985cbca2fb8f15d8d4bf8ad43e616b664515525459a032.
+//
+// Solidity: event RequestExecuted(address indexed from, address indexed to, uint256 nonce, bool success, bytes returnData)
+func (_NexusForwarder *NexusForwarderFilterer) FilterRequestExecuted(opts *bind.FilterOpts, from []common.Address, to []common.Address) (*NexusForwarderRequestExecutedIterator, error) {
+
+	var fromRule []interface{}
+	for _, fromItem := range from {
+		fromRule = append(fromRule, fromItem)
+	}
+	var toRule []interface{}
+	for _, toItem := range to {
+		toRule = append(toRule, toItem)
+	}
+
+	logs, sub, err := _NexusForwarder.contract.FilterLogs(opts, "RequestExecuted", fromRule, toRule)
+	if err != nil {
+		return nil, err
+	}
+	return &NexusForwarderRequestExecutedIterator{contract: _NexusForwarder.contract, event: "RequestExecuted", logs: logs, sub: sub}, nil
+}
+
+// WatchRequestExecuted is a free log subscription operation binding the contract event 0x45567ff6526831d3b6985cbca2fb8f15d8d4bf8ad43e616b664515525459a032.
+//
+// Solidity: event RequestExecuted(address indexed from, address indexed to, uint256 nonce, bool success, bytes returnData)
+func (_NexusForwarder *NexusForwarderFilterer) WatchRequestExecuted(opts *bind.WatchOpts, sink chan<- *NexusForwarderRequestExecuted, from []common.Address, to []common.Address) (event.Subscription, error) {
+
+	var fromRule []interface{}
+	for _, fromItem := range from {
+		fromRule = append(fromRule, fromItem)
+	}
+	var toRule []interface{}
+	for _, toItem := range to {
+		toRule = append(toRule, toItem)
+	}
+
+	logs, sub, err := _NexusForwarder.contract.WatchLogs(opts, "RequestExecuted", fromRule, toRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(NexusForwarderRequestExecuted)
+				if err := _NexusForwarder.contract.UnpackLog(event, "RequestExecuted", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseRequestExecuted is a log parse operation binding the contract event 0x45567ff6526831d3b6985cbca2fb8f15d8d4bf8ad43e616b664515525459a032.
+//
+// Solidity: event RequestExecuted(address indexed from, address indexed to, uint256 nonce, bool success, bytes returnData)
+func (_NexusForwarder *NexusForwarderFilterer) ParseRequestExecuted(log types.Log) (*NexusForwarderRequestExecuted, error) {
+	event := new(NexusForwarderRequestExecuted)
+	if err := _NexusForwarder.contract.UnpackLog(event, "RequestExecuted", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// NexusForwarderRoleAdminChangedIterator is returned from FilterRoleAdminChanged and is used to iterate over the raw logs and unpacked data for RoleAdminChanged events raised by the NexusForwarder contract.
+type NexusForwarderRoleAdminChangedIterator struct {
+	Event *NexusForwarderRoleAdminChanged // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *NexusForwarderRoleAdminChangedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(NexusForwarderRoleAdminChanged)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(NexusForwarderRoleAdminChanged)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *NexusForwarderRoleAdminChangedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *NexusForwarderRoleAdminChangedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// NexusForwarderRoleAdminChanged represents a RoleAdminChanged event raised by the NexusForwarder contract.
+type NexusForwarderRoleAdminChanged struct {
+	Role              [32]byte
+	PreviousAdminRole [32]byte
+	NewAdminRole      [32]byte
+	Raw               types.Log // Blockchain specific contextual infos
+}
+
+// FilterRoleAdminChanged is a free log retrieval operation binding the contract event 0xbd79b86ffe0ab8e8776151514217cd7cacd52c909f66475c3af44e129f0b00ff.
+//
+// Solidity: event RoleAdminChanged(bytes32 indexed role, bytes32 indexed previousAdminRole, bytes32 indexed newAdminRole)
+func (_NexusForwarder *NexusForwarderFilterer) FilterRoleAdminChanged(opts *bind.FilterOpts, role [][32]byte, previousAdminRole [][32]byte, newAdminRole [][32]byte) (*NexusForwarderRoleAdminChangedIterator, error) {
+
+	var roleRule []interface{}
+	for _, roleItem := range role {
+		roleRule = append(roleRule, roleItem)
+	}
+	var previousAdminRoleRule []interface{}
+	for _, previousAdminRoleItem := range previousAdminRole {
+		previousAdminRoleRule = append(previousAdminRoleRule, previousAdminRoleItem)
+	}
+	var newAdminRoleRule []interface{}
+	for _, newAdminRoleItem := range newAdminRole {
+		newAdminRoleRule = append(newAdminRoleRule, newAdminRoleItem)
+	}
+
+	logs, sub, err := _NexusForwarder.contract.FilterLogs(opts, "RoleAdminChanged", roleRule, previousAdminRoleRule, newAdminRoleRule)
+	if err != nil {
+		return nil, err
+	}
+	return &NexusForwarderRoleAdminChangedIterator{contract: _NexusForwarder.contract, event: "RoleAdminChanged", logs: logs, sub: sub}, nil
+}
+
+// WatchRoleAdminChanged is a free log subscription operation binding the contract event 0xbd79b86ffe0ab8e8776151514217cd7cacd52c909f66475c3af44e129f0b00ff.
+//
+// Solidity: event RoleAdminChanged(bytes32 indexed role, bytes32 indexed previousAdminRole, bytes32 indexed newAdminRole)
+func (_NexusForwarder *NexusForwarderFilterer) WatchRoleAdminChanged(opts *bind.WatchOpts, sink chan<- *NexusForwarderRoleAdminChanged, role [][32]byte, previousAdminRole [][32]byte, newAdminRole [][32]byte) (event.Subscription, error) {
+
+	var roleRule []interface{}
+	for _, roleItem := range role {
+		roleRule = append(roleRule, roleItem)
+	}
+	var previousAdminRoleRule []interface{}
+	for _, previousAdminRoleItem := range previousAdminRole {
+		previousAdminRoleRule = append(previousAdminRoleRule, previousAdminRoleItem)
+	}
+	var newAdminRoleRule []interface{}
+	for _, newAdminRoleItem := range newAdminRole {
+		newAdminRoleRule = append(newAdminRoleRule, newAdminRoleItem)
+	}
+
+	logs, sub, err := _NexusForwarder.contract.WatchLogs(opts, "RoleAdminChanged", roleRule, previousAdminRoleRule, newAdminRoleRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(NexusForwarderRoleAdminChanged)
+				if err := _NexusForwarder.contract.UnpackLog(event, "RoleAdminChanged", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseRoleAdminChanged is a log parse operation binding the contract event 0xbd79b86ffe0ab8e8776151514217cd7cacd52c909f66475c3af44e129f0b00ff.
+//
+// Solidity: event RoleAdminChanged(bytes32 indexed role, bytes32 indexed previousAdminRole, bytes32 indexed newAdminRole)
+func (_NexusForwarder *NexusForwarderFilterer) ParseRoleAdminChanged(log types.Log) (*NexusForwarderRoleAdminChanged, error) {
+	event := new(NexusForwarderRoleAdminChanged)
+	if err := _NexusForwarder.contract.UnpackLog(event, "RoleAdminChanged", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// NexusForwarderRoleGrantedIterator is returned from FilterRoleGranted and is used to iterate over the raw logs and unpacked data for RoleGranted events raised by the NexusForwarder contract.
+type NexusForwarderRoleGrantedIterator struct {
+	Event *NexusForwarderRoleGranted // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *NexusForwarderRoleGrantedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(NexusForwarderRoleGranted)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(NexusForwarderRoleGranted)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *NexusForwarderRoleGrantedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *NexusForwarderRoleGrantedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// NexusForwarderRoleGranted represents a RoleGranted event raised by the NexusForwarder contract.
+type NexusForwarderRoleGranted struct {
+	Role    [32]byte
+	Account common.Address
+	Sender  common.Address
+	Raw     types.Log // Blockchain specific contextual infos
+}
+
+// FilterRoleGranted is a free log retrieval operation binding the contract event 0x2f8788117e7eff1d82e926ec794901d17c78024a50270940304540a733656f0d.
+//
+// Solidity: event RoleGranted(bytes32 indexed role, address indexed account, address indexed sender)
+func (_NexusForwarder *NexusForwarderFilterer) FilterRoleGranted(opts *bind.FilterOpts, role [][32]byte, account []common.Address, sender []common.Address) (*NexusForwarderRoleGrantedIterator, error) {
+
+	var roleRule []interface{}
+	for _, roleItem := range role {
+		roleRule = append(roleRule, roleItem)
+	}
+	var accountRule []interface{}
+	for _, accountItem := range account {
+		accountRule = append(accountRule, accountItem)
+	}
+	var senderRule []interface{}
+	for _, senderItem := range sender {
+		senderRule = append(senderRule, senderItem)
+	}
+
+	logs, sub, err := _NexusForwarder.contract.FilterLogs(opts, "RoleGranted", roleRule, accountRule, senderRule)
+	if err != nil {
+		return nil, err
+	}
+	return &NexusForwarderRoleGrantedIterator{contract: _NexusForwarder.contract, event: "RoleGranted", logs: logs, sub: sub}, nil
+}
+
+// WatchRoleGranted is a free log subscription operation binding the contract event 0x2f8788117e7eff1d82e926ec794901d17c78024a50270940304540a733656f0d.
+//
+// Solidity: event RoleGranted(bytes32 indexed role, address indexed account, address indexed sender)
+func (_NexusForwarder *NexusForwarderFilterer) WatchRoleGranted(opts *bind.WatchOpts, sink chan<- *NexusForwarderRoleGranted, role [][32]byte, account []common.Address, sender []common.Address) (event.Subscription, error) {
+
+	var roleRule []interface{}
+	for _, roleItem := range role {
+		roleRule = append(roleRule, roleItem)
+	}
+	var accountRule []interface{}
+	for _, accountItem := range account {
+		accountRule = append(accountRule, accountItem)
+	}
+	var senderRule []interface{}
+	for _, senderItem := range sender {
+		senderRule = append(senderRule, senderItem)
+	}
+
+	logs, sub, err := _NexusForwarder.contract.WatchLogs(opts, "RoleGranted", roleRule, accountRule, senderRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(NexusForwarderRoleGranted)
+				if err := _NexusForwarder.contract.UnpackLog(event, "RoleGranted", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseRoleGranted is a log parse operation binding the contract event 0x2f8788117e7eff1d82e926ec794901d17c78024a50270940304540a733656f0d.
+//
+// Solidity: event RoleGranted(bytes32 indexed role, address indexed account, address indexed sender)
+func (_NexusForwarder *NexusForwarderFilterer) ParseRoleGranted(log types.Log) (*NexusForwarderRoleGranted, error) {
+	event := new(NexusForwarderRoleGranted)
+	if err := _NexusForwarder.contract.UnpackLog(event, "RoleGranted", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// NexusForwarderRoleRevokedIterator is returned from FilterRoleRevoked and is used to iterate over the raw logs and unpacked data for RoleRevoked events raised by the NexusForwarder contract.
+type NexusForwarderRoleRevokedIterator struct {
+	Event *NexusForwarderRoleRevoked // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *NexusForwarderRoleRevokedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(NexusForwarderRoleRevoked)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(NexusForwarderRoleRevoked)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *NexusForwarderRoleRevokedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *NexusForwarderRoleRevokedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// NexusForwarderRoleRevoked represents a RoleRevoked event raised by the NexusForwarder contract.
+type NexusForwarderRoleRevoked struct {
+	Role    [32]byte
+	Account common.Address
+	Sender  common.Address
+	Raw     types.Log // Blockchain specific contextual infos
+}
+
+// FilterRoleRevoked is a free log retrieval operation binding the contract event 0xf6391f5c32d9c69d2a47ea670b442974b53935d1edc7fd64eb21e047a839171b.
+//
+// Solidity: event RoleRevoked(bytes32 indexed role, address indexed account, address indexed sender)
+func (_NexusForwarder *NexusForwarderFilterer) FilterRoleRevoked(opts *bind.FilterOpts, role [][32]byte, account []common.Address, sender []common.Address) (*NexusForwarderRoleRevokedIterator, error) {
+
+	var roleRule []interface{}
+	for _, roleItem := range role {
+		roleRule = append(roleRule, roleItem)
+	}
+	var accountRule []interface{}
+	for _, accountItem := range account {
+		accountRule = append(accountRule, accountItem)
+	}
+	var senderRule []interface{}
+	for _, senderItem := range sender {
+		senderRule = append(senderRule, senderItem)
+	}
+
+	logs, sub, err := _NexusForwarder.contract.FilterLogs(opts, "RoleRevoked", roleRule, accountRule, senderRule)
+	if err != nil {
+		return nil, err
+	}
+	return &NexusForwarderRoleRevokedIterator{contract: _NexusForwarder.contract, event: "RoleRevoked", logs: logs, sub: sub}, nil
+}
+
+// WatchRoleRevoked is a free log subscription operation binding the contract event 0xf6391f5c32d9c69d2a47ea670b442974b53935d1edc7fd64eb21e047a839171b.
+//
+// Solidity: event RoleRevoked(bytes32 indexed role, address indexed account, address indexed sender)
+func (_NexusForwarder *NexusForwarderFilterer) WatchRoleRevoked(opts *bind.WatchOpts, sink chan<- *NexusForwarderRoleRevoked, role [][32]byte, account []common.Address, sender []common.Address) (event.Subscription, error) {
+
+	var roleRule []interface{}
+	for _, roleItem := range role {
+		roleRule = append(roleRule, roleItem)
+	}
+	var accountRule []interface{}
+	for _, accountItem := range account {
+		accountRule = append(accountRule, accountItem)
+	}
+	var senderRule []interface{}
+	for _, senderItem := range sender {
+		senderRule = append(senderRule, senderItem)
+	}
+
+	logs, sub, err := _NexusForwarder.contract.WatchLogs(opts, "RoleRevoked", roleRule, accountRule, senderRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(NexusForwarderRoleRevoked)
+				if err := _NexusForwarder.contract.UnpackLog(event, "RoleRevoked", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseRoleRevoked is a log parse operation binding the contract event 0xf6391f5c32d9c69d2a47ea670b442974b53935d1edc7fd64eb21e047a839171b.
+//
+// Solidity: event RoleRevoked(bytes32 indexed role, address indexed account, address indexed sender)
+func (_NexusForwarder *NexusForwarderFilterer) ParseRoleRevoked(log types.Log) (*NexusForwarderRoleRevoked, error) {
+	event := new(NexusForwarderRoleRevoked)
+	if err := _NexusForwarder.contract.UnpackLog(event, "RoleRevoked", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// NexusForwarderTargetWhitelistUpdatedIterator is returned from FilterTargetWhitelistUpdated and is used to iterate over the raw logs and unpacked data for TargetWhitelistUpdated events raised by the NexusForwarder contract.
+type NexusForwarderTargetWhitelistUpdatedIterator struct {
+	Event *NexusForwarderTargetWhitelistUpdated // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *NexusForwarderTargetWhitelistUpdatedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(NexusForwarderTargetWhitelistUpdated)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(NexusForwarderTargetWhitelistUpdated)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *NexusForwarderTargetWhitelistUpdatedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *NexusForwarderTargetWhitelistUpdatedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// NexusForwarderTargetWhitelistUpdated represents a TargetWhitelistUpdated event raised by the NexusForwarder contract.
+type NexusForwarderTargetWhitelistUpdated struct {
+	Target  common.Address
+	Allowed bool
+	Raw     types.Log // Blockchain specific contextual infos
+}
+
+// FilterTargetWhitelistUpdated is a free log retrieval operation binding the contract event 0x52eec1a0372bcc0f149dd096fc04f013047c878bd949d11adecf9b39bc0c71dc.
+//
+// Solidity: event TargetWhitelistUpdated(address indexed target, bool allowed)
+func (_NexusForwarder *NexusForwarderFilterer) FilterTargetWhitelistUpdated(opts *bind.FilterOpts, target []common.Address) (*NexusForwarderTargetWhitelistUpdatedIterator, error) {
+
+	var targetRule []interface{}
+	for _, targetItem := range target {
+		targetRule = append(targetRule, targetItem)
+	}
+
+	logs, sub, err := _NexusForwarder.contract.FilterLogs(opts, "TargetWhitelistUpdated", targetRule)
+	if err != nil {
+		return nil, err
+	}
+	return &NexusForwarderTargetWhitelistUpdatedIterator{contract: _NexusForwarder.contract, event: "TargetWhitelistUpdated", logs: logs, sub: sub}, nil
+}
+
+// WatchTargetWhitelistUpdated is a free log subscription operation binding the contract event 0x52eec1a0372bcc0f149dd096fc04f013047c878bd949d11adecf9b39bc0c71dc.
+//
+// Solidity: event TargetWhitelistUpdated(address indexed target, bool allowed)
+func (_NexusForwarder *NexusForwarderFilterer) WatchTargetWhitelistUpdated(opts *bind.WatchOpts, sink chan<- *NexusForwarderTargetWhitelistUpdated, target []common.Address) (event.Subscription, error) {
+
+	var targetRule []interface{}
+	for _, targetItem := range target {
+		targetRule = append(targetRule, targetItem)
+	}
+
+	logs, sub, err := _NexusForwarder.contract.WatchLogs(opts, "TargetWhitelistUpdated", targetRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(NexusForwarderTargetWhitelistUpdated)
+				if err := _NexusForwarder.contract.UnpackLog(event, "TargetWhitelistUpdated", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseTargetWhitelistUpdated is a log parse operation binding the contract event 0x52eec1a0372bcc0f149dd096fc04f013047c878bd949d11adecf9b39bc0c71dc.
+//
+// Solidity: event TargetWhitelistUpdated(address indexed target, bool allowed)
+func (_NexusForwarder *NexusForwarderFilterer) ParseTargetWhitelistUpdated(log types.Log) (*NexusForwarderTargetWhitelistUpdated, error) {
+	event := new(NexusForwarderTargetWhitelistUpdated)
+	if err := _NexusForwarder.contract.UnpackLog(event, "TargetWhitelistUpdated", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// NexusForwarderUnpausedIterator is returned from FilterUnpaused and is used to iterate over the raw logs and unpacked data for Unpaused events raised by the NexusForwarder contract.
+type NexusForwarderUnpausedIterator struct {
+	Event *NexusForwarderUnpaused // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *NexusForwarderUnpausedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(NexusForwarderUnpaused)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(NexusForwarderUnpaused)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *NexusForwarderUnpausedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *NexusForwarderUnpausedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// NexusForwarderUnpaused represents a Unpaused event raised by the NexusForwarder contract.
+type NexusForwarderUnpaused struct {
+	Account common.Address
+	Raw     types.Log // Blockchain specific contextual infos
+}
+
+// FilterUnpaused is a free log retrieval operation binding the contract event 0x5db9ee0a495bf2e6ff9c91a7834c1ba4fdd244a5e8aa4e537bd38aeae4b073aa.
+//
+// Solidity: event Unpaused(address account)
+func (_NexusForwarder *NexusForwarderFilterer) FilterUnpaused(opts *bind.FilterOpts) (*NexusForwarderUnpausedIterator, error) {
+
+	logs, sub, err := _NexusForwarder.contract.FilterLogs(opts, "Unpaused")
+	if err != nil {
+		return nil, err
+	}
+	return &NexusForwarderUnpausedIterator{contract: _NexusForwarder.contract, event: "Unpaused", logs: logs, sub: sub}, nil
+}
+
+// WatchUnpaused is a free log subscription operation binding the contract event 0x5db9ee0a495bf2e6ff9c91a7834c1ba4fdd244a5e8aa4e537bd38aeae4b073aa.
+//
+// Solidity: event Unpaused(address account)
+func (_NexusForwarder *NexusForwarderFilterer) WatchUnpaused(opts *bind.WatchOpts, sink chan<- *NexusForwarderUnpaused) (event.Subscription, error) {
+
+	logs, sub, err := _NexusForwarder.contract.WatchLogs(opts, "Unpaused")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(NexusForwarderUnpaused)
+				if err := _NexusForwarder.contract.UnpackLog(event, "Unpaused", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseUnpaused is a log parse operation binding the contract event 0x5db9ee0a495bf2e6ff9c91a7834c1ba4fdd244a5e8aa4e537bd38aeae4b073aa.
+//
+// Solidity: event Unpaused(address account)
+func (_NexusForwarder *NexusForwarderFilterer) ParseUnpaused(log types.Log) (*NexusForwarderUnpaused, error) {
+	event := new(NexusForwarderUnpaused)
+	if err := _NexusForwarder.contract.UnpackLog(event, "Unpaused", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// NexusForwarderWhitelistEnforcementUpdatedIterator is returned from FilterWhitelistEnforcementUpdated and is used to iterate over the raw logs and unpacked data for WhitelistEnforcementUpdated events raised by the NexusForwarder contract.
+type NexusForwarderWhitelistEnforcementUpdatedIterator struct {
+	Event *NexusForwarderWhitelistEnforcementUpdated // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *NexusForwarderWhitelistEnforcementUpdatedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(NexusForwarderWhitelistEnforcementUpdated)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(NexusForwarderWhitelistEnforcementUpdated)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *NexusForwarderWhitelistEnforcementUpdatedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *NexusForwarderWhitelistEnforcementUpdatedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// NexusForwarderWhitelistEnforcementUpdated represents a WhitelistEnforcementUpdated event raised by the NexusForwarder contract.
+type NexusForwarderWhitelistEnforcementUpdated struct {
+	Enforced bool
+	Raw      types.Log // Blockchain specific contextual infos
+}
+
+// FilterWhitelistEnforcementUpdated is a free log retrieval operation binding the contract event 0xf53fed92c8c7fa28287c094817d86453491a705cb668c75b35525c0532360d2c.
+//
+// Solidity: event WhitelistEnforcementUpdated(bool enforced)
+func (_NexusForwarder *NexusForwarderFilterer) FilterWhitelistEnforcementUpdated(opts *bind.FilterOpts) (*NexusForwarderWhitelistEnforcementUpdatedIterator, error) {
+
+	logs, sub, err := _NexusForwarder.contract.FilterLogs(opts, "WhitelistEnforcementUpdated")
+	if err != nil {
+		return nil, err
+	}
+	return &NexusForwarderWhitelistEnforcementUpdatedIterator{contract: _NexusForwarder.contract, event: "WhitelistEnforcementUpdated", logs: logs, sub: sub}, nil
+}
+
+// WatchWhitelistEnforcementUpdated is a free log subscription operation binding the contract event 0xf53fed92c8c7fa28287c094817d86453491a705cb668c75b35525c0532360d2c.
+//
+// Solidity: event WhitelistEnforcementUpdated(bool enforced)
+func (_NexusForwarder *NexusForwarderFilterer) WatchWhitelistEnforcementUpdated(opts *bind.WatchOpts, sink chan<- *NexusForwarderWhitelistEnforcementUpdated) (event.Subscription, error) {
+
+	logs, sub, err := _NexusForwarder.contract.WatchLogs(opts, "WhitelistEnforcementUpdated")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(NexusForwarderWhitelistEnforcementUpdated)
+				if err := _NexusForwarder.contract.UnpackLog(event, "WhitelistEnforcementUpdated", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseWhitelistEnforcementUpdated is a log parse operation binding the contract event 0xf53fed92c8c7fa28287c094817d86453491a705cb668c75b35525c0532360d2c.
+//
+// Solidity: event WhitelistEnforcementUpdated(bool enforced)
+func (_NexusForwarder *NexusForwarderFilterer) ParseWhitelistEnforcementUpdated(log types.Log) (*NexusForwarderWhitelistEnforcementUpdated, error) {
+	event := new(NexusForwarderWhitelistEnforcementUpdated)
+	if err := _NexusForwarder.contract.UnpackLog(event, "WhitelistEnforcementUpdated", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
@@ -0,0 +1,451 @@
+// Package dataexport compiles everything the backend holds about an address
+// - payments, KYC status, NFT transfers, votes and meta-transactions - into
+// a downloadable JSON document or ZIP archive for GDPR access requests.
+// Exports are generated in the background and written to object storage
+// under exports/users/<address>/, where the exports lifecycle rule deletes
+// them after 30 days. A status manifest next to the export tracks its
+// progress, so any instance can answer for it.
+package dataexport
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/objectstore"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+const (
+	// ReuseWindow is how long a finished export is served before a new
+	// request generates a fresh one
+	ReuseWindow = 24 * time.Hour
+
+	// pendingTimeout is how long an export may stay pending before it is
+	// presumed lost (its instance stopped) and a request starts over
+	pendingTimeout = 15 * time.Minute
+
+	// generateTimeout bounds one background generation
+	generateTimeout = 10 * time.Minute
+
+	// pageSize bounds one page read from a repository
+	pageSize = 100
+)
+
+// ErrNotFound is returned when an address has no export
+var ErrNotFound = errors.New("no data export found")
+
+// Format is the shape of the downloadable export
+type Format string
+
+const (
+	FormatJSON Format = "json" // one JSON document
+	FormatZIP  Format = "zip"  // one JSON file per section
+)
+
+// Valid reports whether f is a known format
+func (f Format) Valid() bool {
+	return f == FormatJSON || f == FormatZIP
+}
+
+// Status is the progress of an export
+type Status string
+
+const (
+	StatusPending Status = "pending"
+	StatusReady   Status = "ready"
+	StatusFailed  Status = "failed"
+)
+
+// Export is the status manifest of an address's export
+type Export struct {
+	ID          string         `json:"id"`
+	Address     string         `json:"address"`
+	Format      Format         `json:"format"`
+	Status      Status         `json:"status"`
+	Error       string         `json:"error,omitempty"`
+	Records     map[string]int `json:"records,omitempty"` // per section
+	Size        int64          `json:"size,omitempty"`
+	ObjectKey   string         `json:"-"`
+	RequestedAt time.Time      `json:"requested_at"`
+	CompletedAt *time.Time     `json:"completed_at,omitempty"`
+}
+
+// manifest is the stored form of an Export, keeping its object key
+type manifest struct {
+	Export
+	ObjectKey string `json:"object_key"`
+}
+
+// Filename is the download's file name
+func (x *Export) Filename() string {
+	return "nexus-data-export-" + x.Address + "." + string(x.Format)
+}
+
+// ContentType is the download's media type
+func (x *Export) ContentType() string {
+	if x.Format == FormatZIP {
+		return "application/zip"
+	}
+	return "application/json"
+}
+
+// KYCRecord is a KYC verification as exported: its status and timeline,
+// without the provider's applicant, inspection and reviewer details
+type KYCRecord struct {
+	ID              string                           `json:"id"`
+	Status          repository.KYCVerificationStatus `json:"status"`
+	ReviewStatus    *string                          `json:"review_status,omitempty"`
+	WhitelistTxHash *string                          `json:"whitelist_tx_hash,omitempty"`
+	CreatedAt       time.Time                        `json:"created_at"`
+	SubmittedAt     *time.Time                       `json:"submitted_at,omitempty"`
+	VerifiedAt      *time.Time                       `json:"verified_at,omitempty"`
+	RejectedAt      *time.Time                       `json:"rejected_at,omitempty"`
+}
+
+// Document is the exported data of one address
+type Document struct {
+	Address          string                        `json:"address"`
+	GeneratedAt      time.Time                     `json:"generated_at"`
+	Payments         []*repository.Payment         `json:"payments"`
+	KYC              []KYCRecord                   `json:"kyc"`
+	NFTs             []*repository.ActivityEvent   `json:"nfts"` // mints and transfers
+	Votes            []*repository.ActivityEvent   `json:"votes"`
+	MetaTransactions []*repository.MetaTransaction `json:"meta_transactions"`
+}
+
+// sections lists the document's sections as ZIP entries
+func (d *Document) sections() []struct {
+	name string
+	data any
+	n    int
+} {
+	return []struct {
+		name string
+		data any
+		n    int
+	}{
+		{"payments", d.Payments, len(d.Payments)},
+		{"kyc", d.KYC, len(d.KYC)},
+		{"nfts", d.NFTs, len(d.NFTs)},
+		{"votes", d.Votes, len(d.Votes)},
+		{"meta_transactions", d.MetaTransactions, len(d.MetaTransactions)},
+	}
+}
+
+// Exporter generates and tracks exports
+type Exporter struct {
+	payments repository.PaymentRepository
+	relayer  repository.RelayerRepository
+	activity repository.ActivityRepository
+	store    objectstore.Store
+	logger   *zap.Logger
+	now      func() time.Time
+
+	wg sync.WaitGroup
+}
+
+// NewExporter creates an exporter writing exports to store
+func NewExporter(payments repository.PaymentRepository, relayer repository.RelayerRepository, activity repository.ActivityRepository, store objectstore.Store, logger *zap.Logger) *Exporter {
+	return &Exporter{
+		payments: payments,
+		relayer:  relayer,
+		activity: activity,
+		store:    store,
+		logger:   logger,
+		now:      time.Now,
+	}
+}
+
+// Request returns the address's export in format, starting one in the
+// background unless one is pending or finished within ReuseWindow. It
+// reports whether an export was started.
+func (e *Exporter) Request(ctx context.Context, address string, format Format) (*Export, bool, error) {
+	address = strings.ToLower(address)
+	now := e.now().UTC()
+
+	current, err := e.Get(ctx, address)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return nil, false, err
+	}
+	if current != nil && current.Format == format {
+		switch {
+		case current.Status == StatusPending && now.Sub(current.RequestedAt) < pendingTimeout,
+			current.Status == StatusReady && now.Sub(current.RequestedAt) < ReuseWindow:
+			return current, false, nil
+		}
+	}
+
+	x := &Export{
+		ID:          newID(),
+		Address:     address,
+		Format:      format,
+		Status:      StatusPending,
+		RequestedAt: now,
+	}
+	x.ObjectKey = fmt.Sprintf("%susers/%s/%s.%s", objectstore.PrefixExports, address, x.ID, format)
+	if err := e.save(ctx, x); err != nil {
+		return nil, false, err
+	}
+
+	// The generation updates its own copy; x is returned to the caller
+	job := *x
+	e.wg.Add(1)
+	go func() {
+		defer e.wg.Done()
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), generateTimeout)
+		defer cancel()
+		if err := e.Generate(ctx, &job); err != nil {
+			e.logger.Error("data export failed", zap.String("address", address), zap.String("export_id", x.ID), zap.Error(err))
+		}
+	}()
+
+	e.logger.Info("data export requested", zap.String("address", address), zap.String("export_id", x.ID), zap.String("format", string(format)))
+	return x, true, nil
+}
+
+// Wait blocks until the exports started so far have finished
+func (e *Exporter) Wait() {
+	e.wg.Wait()
+}
+
+// Get returns the address's latest export
+func (e *Exporter) Get(ctx context.Context, address string) (*Export, error) {
+	body, err := e.store.Get(ctx, manifestKey(strings.ToLower(address)))
+	if err != nil {
+		if errors.Is(err, objectstore.ErrNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("reading export manifest: %w", err)
+	}
+	defer body.Close()
+
+	var m manifest
+	if err := json.NewDecoder(body).Decode(&m); err != nil {
+		return nil, fmt.Errorf("parsing export manifest: %w", err)
+	}
+	m.Export.ObjectKey = m.ObjectKey
+	return &m.Export, nil
+}
+
+// Open returns a ready export's contents
+func (e *Exporter) Open(ctx context.Context, x *Export) (io.ReadCloser, error) {
+	if x.Status != StatusReady {
+		return nil, ErrNotFound
+	}
+	body, err := e.store.Get(ctx, x.ObjectKey)
+	if errors.Is(err, objectstore.ErrNotFound) {
+		return nil, ErrNotFound
+	}
+	return body, err
+}
+
+// Generate compiles and stores x, recording the outcome in x and its
+// manifest (called in the background by Request, on an Export nothing else
+// reads)
+func (e *Exporter) Generate(ctx context.Context, x *Export) error {
+	err := e.generate(ctx, x)
+	completed := e.now().UTC()
+	x.CompletedAt = &completed
+	if err != nil {
+		x.Status = StatusFailed
+		x.Error = "export could not be generated; request it again later"
+	} else {
+		x.Status = StatusReady
+	}
+	if saveErr := e.save(ctx, x); saveErr != nil && err == nil {
+		err = saveErr
+	}
+	return err
+}
+
+func (e *Exporter) generate(ctx context.Context, x *Export) error {
+	doc, err := e.Compile(ctx, x.Address)
+	if err != nil {
+		return err
+	}
+
+	var body []byte
+	switch x.Format {
+	case FormatZIP:
+		body, err = zipDocument(doc)
+	default:
+		body, err = json.MarshalIndent(doc, "", "  ")
+	}
+	if err != nil {
+		return fmt.Errorf("encoding export: %w", err)
+	}
+
+	if err := e.store.Put(ctx, x.ObjectKey, bytes.NewReader(body), int64(len(body)), x.ContentType()); err != nil {
+		return fmt.Errorf("storing export: %w", err)
+	}
+	x.Size = int64(len(body))
+	x.Records = make(map[string]int)
+	for _, s := range doc.sections() {
+		x.Records[s.name] = s.n
+	}
+	return nil
+}
+
+// Compile reads the address's records from every store
+func (e *Exporter) Compile(ctx context.Context, address string) (*Document, error) {
+	address = strings.ToLower(address)
+	doc := &Document{
+		Address:          address,
+		GeneratedAt:      e.now().UTC(),
+		Payments:         []*repository.Payment{},
+		KYC:              []KYCRecord{},
+		MetaTransactions: []*repository.MetaTransaction{},
+	}
+
+	paymentFilter := repository.PaymentFilter{PayerAddress: address, IncludeExpired: true}
+	for _, list := range []func(context.Context, repository.PaymentFilter, repository.Pagination) ([]*repository.Payment, int64, error){
+		e.payments.ListPayments, e.payments.ListArchivedPayments,
+	} {
+		err := eachPage(func(page repository.Pagination) (int, int64, error) {
+			payments, total, err := list(ctx, paymentFilter, page)
+			doc.Payments = append(doc.Payments, payments...)
+			return len(payments), total, err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("listing payments: %w", err)
+		}
+	}
+
+	err := eachPage(func(page repository.Pagination) (int, int64, error) {
+		verifications, total, err := e.payments.ListKYCVerifications(ctx, repository.KYCVerificationFilter{UserAddress: address}, page)
+		for _, v := range verifications {
+			doc.KYC = append(doc.KYC, KYCRecord{
+				ID:              v.ID,
+				Status:          v.Status,
+				ReviewStatus:    v.SumsubReviewStatus,
+				WhitelistTxHash: v.WhitelistTxHash,
+				CreatedAt:       v.CreatedAt,
+				SubmittedAt:     v.SubmittedAt,
+				VerifiedAt:      v.VerifiedAt,
+				RejectedAt:      v.RejectedAt,
+			})
+		}
+		return len(verifications), total, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing KYC verifications: %w", err)
+	}
+
+	if doc.NFTs, err = e.listActivity(ctx, address, repository.ActivityNFTTransfer); err != nil {
+		return nil, fmt.Errorf("listing NFT transfers: %w", err)
+	}
+	if doc.Votes, err = e.listActivity(ctx, address, repository.ActivityVote); err != nil {
+		return nil, fmt.Errorf("listing votes: %w", err)
+	}
+
+	metaTxFilter := repository.MetaTxFilter{FromAddress: address}
+	for _, list := range []func(context.Context, repository.MetaTxFilter, repository.Pagination) ([]*repository.MetaTransaction, int64, error){
+		e.relayer.ListMetaTx, e.relayer.ListArchivedMetaTx,
+	} {
+		err := eachPage(func(page repository.Pagination) (int, int64, error) {
+			txs, total, err := list(ctx, metaTxFilter, page)
+			doc.MetaTransactions = append(doc.MetaTransactions, txs...)
+			return len(txs), total, err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("listing meta-transactions: %w", err)
+		}
+	}
+
+	return doc, nil
+}
+
+// listActivity lists the address's activity of one kind
+func (e *Exporter) listActivity(ctx context.Context, address string, kind repository.ActivityKind) ([]*repository.ActivityEvent, error) {
+	out := []*repository.ActivityEvent{}
+	filter := repository.ActivityFilter{Address: address, Kinds: []repository.ActivityKind{kind}}
+	err := eachPage(func(page repository.Pagination) (int, int64, error) {
+		events, total, err := e.activity.ListActivity(ctx, filter, page)
+		out = append(out, events...)
+		return len(events), total, err
+	})
+	return out, err
+}
+
+// eachPage calls list for successive pages until the total is read
+func eachPage(list func(repository.Pagination) (int, int64, error)) error {
+	for page := 1; ; page++ {
+		n, total, err := list(repository.Pagination{Page: page, PageSize: pageSize})
+		if err != nil {
+			return err
+		}
+		if int64(page*pageSize) >= total || n == 0 {
+			return nil
+		}
+	}
+}
+
+// save writes x's manifest
+func (e *Exporter) save(ctx context.Context, x *Export) error {
+	body, err := json.Marshal(manifest{Export: *x, ObjectKey: x.ObjectKey})
+	if err != nil {
+		return fmt.Errorf("encoding export manifest: %w", err)
+	}
+	if err := e.store.Put(ctx, manifestKey(x.Address), bytes.NewReader(body), int64(len(body)), "application/json"); err != nil {
+		return fmt.Errorf("storing export manifest: %w", err)
+	}
+	return nil
+}
+
+// zipDocument writes one JSON file per section, plus a summary
+func zipDocument(doc *Document) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+
+	write := func(name string, data any) error {
+		w, err := zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: doc.GeneratedAt})
+		if err != nil {
+			return err
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(data)
+	}
+
+	records := make(map[string]int)
+	for _, s := range doc.sections() {
+		if err := write(s.name+".json", s.data); err != nil {
+			return nil, err
+		}
+		records[s.name] = s.n
+	}
+	summary := map[string]any{"address": doc.Address, "generated_at": doc.GeneratedAt, "records": records}
+	if err := write("summary.json", summary); err != nil {
+		return nil, err
+	}
+
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func manifestKey(address string) string {
+	return objectstore.PrefixExports + "users/" + address + "/status.json"
+}
+
+// newID generates a random export ID
+func newID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(fmt.Sprintf("generating export id: %v", err))
+	}
+	return hex.EncodeToString(b)
+}
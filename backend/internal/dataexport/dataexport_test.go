@@ -0,0 +1,123 @@
+package dataexport_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/dataexport"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/objectstore"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/storage/memory"
+)
+
+const (
+	user  = "0x00000000000000000000000000000000000000aa"
+	other = "0x00000000000000000000000000000000000000bb"
+)
+
+func seed(t *testing.T, store *memory.Store) {
+	t.Helper()
+	ctx := context.Background()
+
+	for _, payer := range []string{user, other} {
+		require.NoError(t, store.Payments.CreatePayment(ctx, &repository.Payment{ServiceCode: "kyc_verification", PayerAddress: payer,
+			PaymentMethod: "stripe", Currency: "USD", Status: repository.PaymentStatusCompleted}))
+	}
+
+	applicant := "applicant-123"
+	require.NoError(t, store.Payments.CreateKYCVerification(ctx, &repository.KYCVerification{UserAddress: user,
+		SumsubApplicantID: &applicant, SumsubReviewResult: map[string]any{"moderationComment": "internal"}, Status: repository.KYCStatusApproved}))
+
+	require.NoError(t, store.Relayer.CreateMetaTx(ctx, &repository.MetaTransaction{FromAddress: user, ToAddress: other,
+		FunctionName: "transfer", Nonce: 1, Deadline: time.Now().Add(time.Hour), Status: repository.MetaTxStatusPending}))
+
+	for _, e := range []*repository.ActivityEvent{
+		{Address: user, Kind: repository.ActivityVote, Action: "for", SubjectID: "p1"},
+		{Address: user, Kind: repository.ActivityNFTTransfer, Action: "minted", SubjectID: "7"},
+		{Address: other, Kind: repository.ActivityVote, Action: "against", SubjectID: "p1"},
+	} {
+		require.NoError(t, store.Activity.RecordActivity(ctx, e))
+	}
+}
+
+func TestExporter_CompilesAddressData(t *testing.T) {
+	store := memory.NewStore()
+	seed(t, store)
+	exporter := dataexport.NewExporter(store.Payments, store.Relayer, store.Activity, objectstore.NewMemory(), zap.NewNop())
+
+	doc, err := exporter.Compile(context.Background(), "0x00000000000000000000000000000000000000AA")
+	require.NoError(t, err)
+	assert.Equal(t, user, doc.Address)
+	require.Len(t, doc.Payments, 1)
+	assert.Equal(t, user, doc.Payments[0].PayerAddress)
+	require.Len(t, doc.KYC, 1)
+	assert.Equal(t, repository.KYCStatusApproved, doc.KYC[0].Status)
+	assert.Len(t, doc.NFTs, 1)
+	assert.Len(t, doc.Votes, 1)
+	assert.Len(t, doc.MetaTransactions, 1)
+
+	// Internal review details stay out of the export
+	body, err := json.Marshal(doc)
+	require.NoError(t, err)
+	assert.NotContains(t, string(body), "applicant-123")
+	assert.NotContains(t, string(body), "moderationComment")
+}
+
+func TestExporter_RequestGeneratesInBackground(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	seed(t, store)
+	exporter := dataexport.NewExporter(store.Payments, store.Relayer, store.Activity, objectstore.NewMemory(), zap.NewNop())
+
+	_, err := exporter.Get(ctx, user)
+	assert.ErrorIs(t, err, dataexport.ErrNotFound)
+
+	x, started, err := exporter.Request(ctx, user, dataexport.FormatZIP)
+	require.NoError(t, err)
+	assert.True(t, started)
+	assert.Equal(t, dataexport.StatusPending, x.Status)
+	exporter.Wait()
+	assert.Equal(t, dataexport.StatusPending, x.Status, "the returned export is not shared with the generation")
+
+	x, err = exporter.Get(ctx, user)
+	require.NoError(t, err)
+	require.Equal(t, dataexport.StatusReady, x.Status)
+	assert.Equal(t, 1, x.Records["payments"])
+	assert.NotNil(t, x.CompletedAt)
+
+	body, err := exporter.Open(ctx, x)
+	require.NoError(t, err)
+	data, err := io.ReadAll(body)
+	require.NoError(t, err)
+	body.Close()
+	assert.Equal(t, x.Size, int64(len(data)))
+
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+	var names []string
+	for _, f := range zr.File {
+		names = append(names, f.Name)
+	}
+	assert.ElementsMatch(t, []string{"payments.json", "kyc.json", "nfts.json", "votes.json", "meta_transactions.json", "summary.json"}, names)
+
+	// A finished export is reused, a different format starts a new one
+	again, started, err := exporter.Request(ctx, user, dataexport.FormatZIP)
+	require.NoError(t, err)
+	assert.False(t, started)
+	assert.Equal(t, x.ID, again.ID)
+
+	again, started, err = exporter.Request(ctx, user, dataexport.FormatJSON)
+	require.NoError(t, err)
+	assert.True(t, started)
+	assert.NotEqual(t, x.ID, again.ID)
+	exporter.Wait()
+}
@@ -0,0 +1,274 @@
+// Package deposits implements crypto payments by transfer to a unique deposit
+// address. Each payment intent gets its own address, derived from the deposit
+// HD wallet (DEPOSIT_WALLET_SEED), so a transfer is matched to its payment by
+// where it was sent rather than by a tx hash the payer reports. A background
+// watcher confirms payments once the address balance reaches the expected
+// amount at confirmation depth, and a sweeper moves the funds to the treasury
+// (app_config namespace "deposits").
+package deposits
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// namespace is the app_config namespace holding the deposit settings
+const namespace = "deposits"
+
+// nexusTokenDBName is the contract registry name of the NEXUS token
+const nexusTokenDBName = "nexusToken"
+
+// DefaultInterval is how often Run checks deposit addresses when no interval
+// is given
+const DefaultInterval = 15 * time.Second
+
+// Defaults for settings missing from app_config
+const (
+	defaultExpiry            = time.Hour
+	defaultTolerancePercent  = 1
+	defaultSweepRetry        = 10 * time.Minute
+	defaultConfirmationDepth = 12
+)
+
+const (
+	// listPageSize is the page size intents are loaded with
+	listPageSize = 100
+
+	// ethTransferGas is the gas of a plain ETH transfer
+	ethTransferGas = 21000
+
+	// Headroom over the token transfer gas estimate, and over the sweep fee
+	// when topping up a deposit address for gas, in percent
+	gasEstimateHeadroomPercent = 120
+	gasTopUpHeadroomPercent    = 150
+)
+
+// Deposit errors
+var (
+	ErrUnsupportedCurrency = errors.New("unsupported deposit currency")
+	ErrTokenUnavailable    = errors.New("NEXUS token is not deployed on this chain")
+	ErrInvalidAmount       = errors.New("deposit amount must be positive")
+)
+
+// Client is the chain access the watcher and sweeper need (implemented by
+// chain.FailoverClient)
+type Client interface {
+	BlockNumber(ctx context.Context) (uint64, error)
+	BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error)
+	CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error)
+	TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error)
+	PendingNonceAt(ctx context.Context, account common.Address) (uint64, error)
+	SuggestGasPrice(ctx context.Context) (*big.Int, error)
+	EstimateGas(ctx context.Context, call ethereum.CallMsg) (uint64, error)
+	SendTransaction(ctx context.Context, tx *types.Transaction) error
+}
+
+// Settings are the deposit settings in effect
+type Settings struct {
+	Expiry           time.Duration `json:"expiry"`
+	TolerancePercent int64         `json:"tolerance_percent"`
+	SweepRetry       time.Duration `json:"sweep_retry"`
+	TreasuryAddress  string        `json:"treasury_address,omitempty"` // empty = no sweeping
+}
+
+// Service opens deposit intents and watches and sweeps their addresses
+type Service struct {
+	repo       repository.DepositRepository
+	payments   repository.PaymentRepository
+	contracts  repository.ContractRepository
+	configRepo repository.AppConfigRepository
+	wallet     *Wallet
+	client     Client
+	logger     *zap.Logger
+	chainID    int64
+	now        func() time.Time
+}
+
+// NewService creates a new deposit service
+func NewService(repo repository.DepositRepository, payments repository.PaymentRepository, contracts repository.ContractRepository, configRepo repository.AppConfigRepository, wallet *Wallet, client Client, logger *zap.Logger, chainID int64) *Service {
+	return &Service{
+		repo:       repo,
+		payments:   payments,
+		contracts:  contracts,
+		configRepo: configRepo,
+		wallet:     wallet,
+		client:     client,
+		logger:     logger,
+		chainID:    chainID,
+		now:        time.Now,
+	}
+}
+
+// ChainID is the chain deposits must be sent on
+func (s *Service) ChainID() int64 {
+	return s.chainID
+}
+
+// GasAddress is the account funding the gas of NEXUS sweeps
+func (s *Service) GasAddress() string {
+	return strings.ToLower(s.wallet.GasAddress().Hex())
+}
+
+// Settings loads the deposit settings, falling back to defaults for missing
+// or unreadable ones
+func (s *Service) Settings(ctx context.Context) Settings {
+	settings := Settings{
+		Expiry:           defaultExpiry,
+		TolerancePercent: defaultTolerancePercent,
+		SweepRetry:       defaultSweepRetry,
+	}
+	if minutes, err := s.configRepo.GetNumber(ctx, namespace, "expiry_minutes", s.chainID); err == nil && minutes > 0 {
+		settings.Expiry = time.Duration(minutes) * time.Minute
+	}
+	if tolerance, err := s.configRepo.GetNumber(ctx, namespace, "tolerance_percent", s.chainID); err == nil && tolerance >= 0 && tolerance < 100 {
+		settings.TolerancePercent = tolerance
+	}
+	if minutes, err := s.configRepo.GetNumber(ctx, namespace, "sweep_retry_minutes", s.chainID); err == nil && minutes > 0 {
+		settings.SweepRetry = time.Duration(minutes) * time.Minute
+	}
+	if treasury, err := s.configRepo.GetString(ctx, namespace, "treasury_address", s.chainID); err == nil && common.IsHexAddress(treasury) {
+		settings.TreasuryAddress = strings.ToLower(treasury)
+	}
+	return settings
+}
+
+// Open records p as a pending payment of amount base units and assigns it a
+// fresh deposit address. p.PaymentMethod selects the currency (eth or nexus).
+func (s *Service) Open(ctx context.Context, p *repository.Payment, amount *big.Int) (*repository.DepositIntent, error) {
+	if amount == nil || amount.Sign() <= 0 {
+		return nil, ErrInvalidAmount
+	}
+
+	var currency string
+	var tokenAddress *string
+	switch p.PaymentMethod {
+	case "eth":
+		currency = "ETH"
+	case "nexus":
+		currency = "NEXUS"
+		token, err := s.contracts.GetByChainAndDBName(ctx, s.chainID, nexusTokenDBName)
+		if errors.Is(err, repository.ErrContractAddressNotFound) {
+			return nil, ErrTokenUnavailable
+		}
+		if err != nil {
+			return nil, fmt.Errorf("resolving NEXUS token: %w", err)
+		}
+		addr := strings.ToLower(token.Address)
+		tokenAddress = &addr
+	default:
+		return nil, ErrUnsupportedCurrency
+	}
+
+	index, err := s.repo.NextDepositIndex(ctx)
+	if err != nil {
+		return nil, err
+	}
+	address, err := s.wallet.DepositAddress(index)
+	if err != nil {
+		return nil, fmt.Errorf("deriving deposit address %d: %w", index, err)
+	}
+
+	p.Currency = currency
+	p.Status = repository.PaymentStatusPending
+	if err := s.payments.CreatePayment(ctx, p); err != nil {
+		return nil, fmt.Errorf("creating payment: %w", err)
+	}
+
+	d := &repository.DepositIntent{
+		PaymentID:       p.ID,
+		PayerAddress:    p.PayerAddress,
+		Currency:        currency,
+		TokenAddress:    tokenAddress,
+		DepositAddress:  strings.ToLower(address.Hex()),
+		DerivationIndex: index,
+		ExpectedAmount:  amount.String(),
+		Status:          repository.DepositAwaiting,
+		ExpiresAt:       s.now().Add(s.Settings(ctx).Expiry).UTC(),
+	}
+	if err := s.repo.CreateDepositIntent(ctx, d); err != nil {
+		msg := "deposit address could not be assigned"
+		if uerr := s.payments.UpdatePaymentStatus(ctx, p.ID, repository.PaymentStatusFailed, &repository.PaymentStatusUpdate{ErrorMessage: &msg}); uerr != nil {
+			s.logger.Error("failed to fail payment without deposit address", zap.String("payment_id", p.ID), zap.Error(uerr))
+		}
+		return nil, fmt.Errorf("creating deposit intent: %w", err)
+	}
+
+	s.logger.Info("deposit intent opened",
+		zap.String("intent_id", d.ID),
+		zap.String("payment_id", p.ID),
+		zap.String("deposit_address", d.DepositAddress),
+		zap.String("expected", d.ExpectedAmount),
+		zap.String("currency", currency),
+	)
+	return d, nil
+}
+
+// Run watches and sweeps deposit addresses every interval until ctx is done
+func (s *Service) Run(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		s.RunOnce(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce checks every watched intent, then sweeps the paid ones
+func (s *Service) RunOnce(ctx context.Context) {
+	if err := s.Watch(ctx); err != nil {
+		s.logger.Warn("deposit watch failed", zap.Error(err))
+	}
+	if err := s.Sweep(ctx); err != nil {
+		s.logger.Warn("deposit sweep failed", zap.Error(err))
+	}
+}
+
+// listByStatus loads every intent in status
+func (s *Service) listByStatus(ctx context.Context, status repository.DepositStatus) ([]*repository.DepositIntent, error) {
+	var all []*repository.DepositIntent
+	for page := 1; ; page++ {
+		intents, total, err := s.repo.ListDepositIntents(ctx, repository.DepositIntentFilter{Status: status},
+			repository.Pagination{Page: page, PageSize: listPageSize})
+		if err != nil {
+			return nil, fmt.Errorf("listing %s deposit intents: %w", status, err)
+		}
+		all = append(all, intents...)
+		if len(intents) < listPageSize || int64(len(all)) >= total {
+			return all, nil
+		}
+	}
+}
+
+// ToBaseUnits converts a decimal amount of ETH or NEXUS to base units (18
+// decimals), truncating beyond the 18th decimal. The amount is converted
+// from its shortest decimal form so 0.29 becomes 290000000000000000 rather
+// than the float's binary approximation.
+func ToBaseUnits(amount float64) *big.Int {
+	r, ok := new(big.Rat).SetString(strconv.FormatFloat(amount, 'f', -1, 64))
+	if !ok {
+		return new(big.Int)
+	}
+	r.Mul(r, new(big.Rat).SetInt(big.NewInt(1e18)))
+	return new(big.Int).Quo(r.Num(), r.Denom())
+}
@@ -0,0 +1,358 @@
+package deposits_test
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/deposits"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/storage/memory"
+)
+
+const (
+	chainID = 31337
+
+	// anvilMnemonic is the default mnemonic of anvil and hardhat
+	anvilMnemonic = "test test test test test test test test test test test junk"
+
+	treasury = "0x00000000000000000000000000000000000000aa"
+	token    = "0x00000000000000000000000000000000000000bb"
+)
+
+// fakeChain serves balances for the watcher and records sent transactions.
+// Balances set with confirm are visible at every block; those set with
+// arrive only at the head.
+type fakeChain struct {
+	mu        sync.Mutex
+	head      uint64
+	confirmed map[common.Address]*big.Int
+	latest    map[common.Address]*big.Int
+	tokens    map[common.Address]*big.Int
+	sent      []*types.Transaction
+	mined     map[common.Hash]bool
+}
+
+func newFakeChain() *fakeChain {
+	return &fakeChain{
+		head:      100,
+		confirmed: map[common.Address]*big.Int{},
+		latest:    map[common.Address]*big.Int{},
+		tokens:    map[common.Address]*big.Int{},
+		mined:     map[common.Hash]bool{},
+	}
+}
+
+func (f *fakeChain) arrive(addr string, amount int64) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.latest[common.HexToAddress(addr)] = big.NewInt(amount)
+}
+
+func (f *fakeChain) confirm(addr string, amount int64) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.latest[common.HexToAddress(addr)] = big.NewInt(amount)
+	f.confirmed[common.HexToAddress(addr)] = big.NewInt(amount)
+}
+
+func (f *fakeChain) BlockNumber(ctx context.Context) (uint64, error) {
+	return f.head, nil
+}
+
+func (f *fakeChain) BalanceAt(ctx context.Context, account common.Address, block *big.Int) (*big.Int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	balances := f.latest
+	if block != nil && block.Uint64() < f.head {
+		balances = f.confirmed
+	}
+	if b, ok := balances[account]; ok {
+		return new(big.Int).Set(b), nil
+	}
+	return new(big.Int), nil
+}
+
+func (f *fakeChain) CallContract(ctx context.Context, call ethereum.CallMsg, block *big.Int) ([]byte, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	account := common.BytesToAddress(call.Data[4:36])
+	b, ok := f.tokens[account]
+	if !ok {
+		b = new(big.Int)
+	}
+	return common.LeftPadBytes(b.Bytes(), 32), nil
+}
+
+func (f *fakeChain) TransactionReceipt(ctx context.Context, hash common.Hash) (*types.Receipt, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.mined[hash] {
+		return &types.Receipt{Status: types.ReceiptStatusSuccessful}, nil
+	}
+	return nil, ethereum.NotFound
+}
+
+func (f *fakeChain) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	return 0, nil
+}
+
+func (f *fakeChain) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	return big.NewInt(1), nil
+}
+
+func (f *fakeChain) EstimateGas(ctx context.Context, call ethereum.CallMsg) (uint64, error) {
+	return 50000, nil
+}
+
+func (f *fakeChain) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.sent = append(f.sent, tx)
+	return nil
+}
+
+// mine applies the last sent transaction: ETH moves to its recipient and a
+// token transfer empties the sender's token balance
+func (f *fakeChain) mine(t *testing.T) *types.Transaction {
+	t.Helper()
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	require.NotEmpty(t, f.sent)
+	tx := f.sent[len(f.sent)-1]
+	from, err := types.Sender(types.LatestSignerForChainID(big.NewInt(chainID)), tx)
+	require.NoError(t, err)
+
+	if len(tx.Data()) == 0 {
+		cost := new(big.Int).Add(tx.Value(), new(big.Int).Mul(tx.GasPrice(), new(big.Int).SetUint64(tx.Gas())))
+		if b, ok := f.latest[from]; ok {
+			f.latest[from] = new(big.Int).Sub(b, cost)
+		}
+		to := *tx.To()
+		prev, ok := f.latest[to]
+		if !ok {
+			prev = new(big.Int)
+		}
+		f.latest[to] = new(big.Int).Add(prev, tx.Value())
+	} else {
+		f.tokens[from] = new(big.Int)
+	}
+	f.confirmed = f.latest
+	f.mined[tx.Hash()] = true
+	return tx
+}
+
+func setConfig(t *testing.T, store *memory.Store, key, valueType string, number *int64, str *string) {
+	t.Helper()
+	require.NoError(t, store.AppConfig.Create(context.Background(), &repository.AppConfigCreate{
+		Namespace: "deposits", ConfigKey: key, ValueType: valueType, ValueNumber: number, ValueString: str, UpdatedBy: "test",
+	}))
+}
+
+func newService(t *testing.T) (*deposits.Service, *memory.Store, *fakeChain) {
+	t.Helper()
+	wallet, err := deposits.NewWallet(anvilMnemonic)
+	require.NoError(t, err)
+	store := memory.NewSeededStore()
+	client := newFakeChain()
+	return deposits.NewService(store.Deposits, store.Payments, store.Contracts, store.AppConfig, wallet, client, zap.NewNop(), chainID), store, client
+}
+
+func open(t *testing.T, svc *deposits.Service, method string, amount int64) *repository.DepositIntent {
+	t.Helper()
+	d, err := svc.Open(context.Background(), &repository.Payment{
+		ServiceCode:   "kyc_verification",
+		PayerAddress:  "0x1111111111111111111111111111111111111111",
+		PaymentMethod: method,
+		AmountCharged: 1,
+	}, big.NewInt(amount))
+	require.NoError(t, err)
+	return d
+}
+
+func intentAndPayment(t *testing.T, store *memory.Store, id string) (*repository.DepositIntent, *repository.Payment) {
+	t.Helper()
+	d, err := store.Deposits.GetDepositIntent(context.Background(), id)
+	require.NoError(t, err)
+	p, err := store.Payments.GetPayment(context.Background(), d.PaymentID)
+	require.NoError(t, err)
+	return d, p
+}
+
+func TestService_OpenAssignsUniqueAddresses(t *testing.T) {
+	svc, store, _ := newService(t)
+
+	first := open(t, svc, "eth", 1000)
+	second := open(t, svc, "eth", 1000)
+	assert.NotEqual(t, first.DepositAddress, second.DepositAddress)
+	assert.Equal(t, int64(0), first.DerivationIndex)
+	assert.Equal(t, int64(1), second.DerivationIndex)
+	assert.Equal(t, repository.DepositAwaiting, first.Status)
+	assert.Equal(t, "1000", first.ExpectedAmount)
+	assert.Nil(t, first.TokenAddress)
+
+	_, p := intentAndPayment(t, store, first.ID)
+	assert.Equal(t, repository.PaymentStatusPending, p.Status)
+	assert.Equal(t, "ETH", p.Currency)
+
+	_, err := svc.Open(context.Background(), &repository.Payment{PaymentMethod: "nexus"}, big.NewInt(1))
+	assert.ErrorIs(t, err, deposits.ErrTokenUnavailable)
+	_, err = svc.Open(context.Background(), &repository.Payment{PaymentMethod: "eth"}, big.NewInt(0))
+	assert.ErrorIs(t, err, deposits.ErrInvalidAmount)
+}
+
+func TestService_WatchConfirmsAndSweepsETH(t *testing.T) {
+	ctx := context.Background()
+	svc, store, client := newService(t)
+	d := open(t, svc, "eth", 1000000)
+
+	// Nothing received yet
+	require.NoError(t, svc.Watch(ctx))
+	got, _ := intentAndPayment(t, store, d.ID)
+	assert.Equal(t, repository.DepositAwaiting, got.Status)
+
+	// Seen at the head, within the 1% tolerance: confirming
+	client.arrive(d.DepositAddress, 990000)
+	require.NoError(t, svc.Watch(ctx))
+	got, p := intentAndPayment(t, store, d.ID)
+	assert.Equal(t, repository.DepositConfirming, got.Status)
+	assert.Equal(t, repository.PaymentStatusProcessing, p.Status)
+	assert.NotNil(t, got.DetectedAt)
+
+	// Reorged out: awaiting again
+	client.arrive(d.DepositAddress, 0)
+	require.NoError(t, svc.Watch(ctx))
+	got, p = intentAndPayment(t, store, d.ID)
+	assert.Equal(t, repository.DepositAwaiting, got.Status)
+	assert.Equal(t, repository.PaymentStatusPending, p.Status)
+
+	// At confirmation depth: paid
+	client.confirm(d.DepositAddress, 1000000)
+	require.NoError(t, svc.Watch(ctx))
+	got, p = intentAndPayment(t, store, d.ID)
+	assert.Equal(t, repository.DepositPaid, got.Status)
+	assert.Equal(t, "1000000", got.ReceivedAmount)
+	assert.Equal(t, repository.PaymentStatusCompleted, p.Status)
+
+	// No treasury: nothing is swept
+	require.NoError(t, svc.Sweep(ctx))
+	assert.Empty(t, client.sent)
+
+	addr := treasury
+	setConfig(t, store, "treasury_address", "address", nil, &addr)
+	require.NoError(t, svc.Sweep(ctx))
+	require.Len(t, client.sent, 1)
+	tx := client.sent[0]
+	assert.Equal(t, common.HexToAddress(treasury), *tx.To())
+	assert.Equal(t, int64(1000000-21000), tx.Value().Int64())
+
+	// Unmined sweep within the retry window: not resent
+	require.NoError(t, svc.Sweep(ctx))
+	assert.Len(t, client.sent, 1)
+
+	client.mine(t)
+	require.NoError(t, svc.Sweep(ctx))
+	got, _ = intentAndPayment(t, store, d.ID)
+	assert.Equal(t, repository.DepositSwept, got.Status)
+	assert.NotNil(t, got.SweptAt)
+	assert.Equal(t, strings.ToLower(tx.Hash().Hex()), strings.ToLower(*got.SweepTxHash))
+}
+
+func TestService_SweepsTokensWithGasTopUp(t *testing.T) {
+	ctx := context.Background()
+	svc, store, client := newService(t)
+
+	mapping, err := store.Contracts.GetMappingByDBName(ctx, "nexusToken")
+	require.NoError(t, err)
+	_, err = store.Contracts.Upsert(ctx, &repository.ContractAddressUpsert{ChainID: chainID, ContractMappingID: mapping.ID, Address: token})
+	require.NoError(t, err)
+	addr := treasury
+	setConfig(t, store, "treasury_address", "address", nil, &addr)
+
+	d := open(t, svc, "nexus", 5000)
+	require.NotNil(t, d.TokenAddress)
+	assert.Equal(t, token, *d.TokenAddress)
+
+	deposit := common.HexToAddress(d.DepositAddress)
+	client.tokens[deposit] = big.NewInt(5000)
+	svc.RunOnce(ctx)
+	got, _ := intentAndPayment(t, store, d.ID)
+	assert.Equal(t, repository.DepositPaid, got.Status)
+
+	// The deposit address holds no ETH: it is topped up from the gas account
+	require.Len(t, client.sent, 1)
+	topUp := client.mine(t)
+	assert.Equal(t, deposit, *topUp.To())
+	assert.Equal(t, svc.GasAddress(), strings.ToLower(sender(t, topUp).Hex()))
+
+	// Then the tokens go to the treasury
+	require.NoError(t, svc.Sweep(ctx))
+	require.Len(t, client.sent, 2)
+	transfer := client.mine(t)
+	assert.Equal(t, common.HexToAddress(token), *transfer.To())
+	assert.Equal(t, deposit, sender(t, transfer))
+	assert.Equal(t, common.HexToAddress(treasury), common.BytesToAddress(transfer.Data()[4:36]))
+	assert.Equal(t, int64(5000), new(big.Int).SetBytes(transfer.Data()[36:68]).Int64())
+
+	require.NoError(t, svc.Sweep(ctx))
+	got, _ = intentAndPayment(t, store, d.ID)
+	assert.Equal(t, repository.DepositSwept, got.Status)
+}
+
+func TestService_WatchExpires(t *testing.T) {
+	ctx := context.Background()
+	svc, store, client := newService(t)
+	wallet, err := deposits.NewWallet(anvilMnemonic)
+	require.NoError(t, err)
+
+	expire := func(index int64) *repository.DepositIntent {
+		p := &repository.Payment{ServiceCode: "kyc_verification", PaymentMethod: "eth", Currency: "ETH", Status: repository.PaymentStatusPending}
+		require.NoError(t, store.Payments.CreatePayment(ctx, p))
+		address, err := wallet.DepositAddress(index)
+		require.NoError(t, err)
+		d := &repository.DepositIntent{PaymentID: p.ID, Currency: "ETH", DepositAddress: strings.ToLower(address.Hex()),
+			DerivationIndex: index, ExpectedAmount: "1000", Status: repository.DepositAwaiting, ExpiresAt: time.Now().Add(-time.Minute)}
+		require.NoError(t, store.Deposits.CreateDepositIntent(ctx, d))
+		return d
+	}
+
+	empty := expire(10)
+	short := expire(11)
+	client.confirm(short.DepositAddress, 500)
+	require.NoError(t, svc.Watch(ctx))
+
+	got, p := intentAndPayment(t, store, empty.ID)
+	assert.Equal(t, repository.DepositExpired, got.Status)
+	assert.Equal(t, repository.PaymentStatusExpired, p.Status)
+
+	got, p = intentAndPayment(t, store, short.ID)
+	assert.Equal(t, repository.DepositUnderpaid, got.Status)
+	assert.Equal(t, "500", got.ReceivedAmount)
+	assert.Equal(t, repository.PaymentStatusFailed, p.Status)
+	require.NotNil(t, p.ErrorMessage)
+	assert.Contains(t, *p.ErrorMessage, "underpaid")
+}
+
+func TestToBaseUnits(t *testing.T) {
+	assert.Equal(t, "290000000000000000", deposits.ToBaseUnits(0.29).String())
+	assert.Equal(t, "1000000000000000000000", deposits.ToBaseUnits(1000).String())
+	assert.Equal(t, "1", deposits.ToBaseUnits(0.000000000000000001).String())
+	assert.Equal(t, "0", deposits.ToBaseUnits(0).String())
+}
+
+func sender(t *testing.T, tx *types.Transaction) common.Address {
+	t.Helper()
+	from, err := types.Sender(types.LatestSignerForChainID(big.NewInt(chainID)), tx)
+	require.NoError(t, err)
+	return from
+}
@@ -0,0 +1,186 @@
+package deposits
+
+import (
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+// hardened marks a hardened BIP-32 child index
+const hardened uint32 = 0x80000000
+
+// Derivation paths. Deposit addresses use account 1 of the BIP-44 Ethereum
+// coin type so they never collide with account 0, whose first address pays
+// the gas of token sweeps.
+var (
+	depositAccountPath = []uint32{44 | hardened, 60 | hardened, 1 | hardened, 0}
+	gasAccountPath     = []uint32{44 | hardened, 60 | hardened, 0 | hardened, 0, 0}
+)
+
+// ErrInvalidSeed is returned for a wallet seed that is neither a hex seed
+// nor a BIP-39 mnemonic
+var ErrInvalidSeed = errors.New("invalid deposit wallet seed")
+
+// curveN is the order of secp256k1
+var curveN = crypto.S256().Params().N
+
+// Wallet is the BIP-32 HD wallet deposit addresses are derived from. It holds
+// the account key, so deriving an address needs no further access to the
+// seed.
+type Wallet struct {
+	deposits *extendedKey
+	gas      *ecdsa.PrivateKey
+}
+
+// NewWallet creates a wallet from a hex seed (16 to 64 bytes) or a BIP-39
+// mnemonic of 12 to 24 words. The mnemonic checksum is not verified, so a
+// typo yields a different (but valid) wallet.
+func NewWallet(seed string) (*Wallet, error) {
+	raw, err := seedBytes(seed)
+	if err != nil {
+		return nil, err
+	}
+
+	master, err := masterKey(raw)
+	if err != nil {
+		return nil, err
+	}
+	deposits, err := master.derive(depositAccountPath)
+	if err != nil {
+		return nil, err
+	}
+	gasKey, err := master.derive(gasAccountPath)
+	if err != nil {
+		return nil, err
+	}
+	gas, err := crypto.ToECDSA(gasKey.key)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSeed, err)
+	}
+
+	return &Wallet{deposits: deposits, gas: gas}, nil
+}
+
+// DepositKey derives the key of the deposit address at index
+func (w *Wallet) DepositKey(index int64) (*ecdsa.PrivateKey, error) {
+	if index < 0 || index >= int64(hardened) {
+		return nil, fmt.Errorf("deposit index %d out of range", index)
+	}
+	child, err := w.deposits.child(uint32(index))
+	if err != nil {
+		return nil, err
+	}
+	return crypto.ToECDSA(child.key)
+}
+
+// DepositAddress derives the deposit address at index
+func (w *Wallet) DepositAddress(index int64) (common.Address, error) {
+	key, err := w.DepositKey(index)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return crypto.PubkeyToAddress(key.PublicKey), nil
+}
+
+// GasKey is the key of the account funding the gas of token sweeps
+func (w *Wallet) GasKey() *ecdsa.PrivateKey {
+	return w.gas
+}
+
+// GasAddress is the account funding the gas of token sweeps. It must hold
+// ETH for NEXUS deposits to be swept.
+func (w *Wallet) GasAddress() common.Address {
+	return crypto.PubkeyToAddress(w.gas.PublicKey)
+}
+
+// seedBytes decodes a hex seed or stretches a mnemonic into its BIP-39 seed
+// (no passphrase)
+func seedBytes(seed string) ([]byte, error) {
+	seed = strings.TrimSpace(seed)
+	words := strings.Fields(seed)
+	if len(words) == 1 {
+		raw, err := hex.DecodeString(strings.TrimPrefix(seed, "0x"))
+		if err != nil || len(raw) < 16 || len(raw) > 64 {
+			return nil, fmt.Errorf("%w: expected a 16-64 byte hex seed or a mnemonic", ErrInvalidSeed)
+		}
+		return raw, nil
+	}
+	if len(words) < 12 || len(words) > 24 || len(words)%3 != 0 {
+		return nil, fmt.Errorf("%w: a mnemonic has 12, 15, 18, 21 or 24 words", ErrInvalidSeed)
+	}
+	mnemonic := strings.ToLower(strings.Join(words, " "))
+	return pbkdf2.Key([]byte(mnemonic), []byte("mnemonic"), 2048, 64, sha512.New), nil
+}
+
+// extendedKey is a BIP-32 extended private key
+type extendedKey struct {
+	key       []byte // 32 bytes
+	chainCode []byte // 32 bytes
+}
+
+// masterKey derives the BIP-32 master key of seed
+func masterKey(seed []byte) (*extendedKey, error) {
+	mac := hmac.New(sha512.New, []byte("Bitcoin seed"))
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+
+	k := new(big.Int).SetBytes(sum[:32])
+	if k.Sign() == 0 || k.Cmp(curveN) >= 0 {
+		return nil, fmt.Errorf("%w: unusable master key", ErrInvalidSeed)
+	}
+	return &extendedKey{key: sum[:32], chainCode: sum[32:]}, nil
+}
+
+// derive follows path from k
+func (k *extendedKey) derive(path []uint32) (*extendedKey, error) {
+	var err error
+	for _, i := range path {
+		if k, err = k.child(i); err != nil {
+			return nil, err
+		}
+	}
+	return k, nil
+}
+
+// child derives child i of k (CKDpriv)
+func (k *extendedKey) child(i uint32) (*extendedKey, error) {
+	var data []byte
+	if i >= hardened {
+		data = append([]byte{0}, k.key...)
+	} else {
+		priv, err := crypto.ToECDSA(k.key)
+		if err != nil {
+			return nil, err
+		}
+		data = crypto.CompressPubkey(&priv.PublicKey)
+	}
+	data = binary.BigEndian.AppendUint32(data, i)
+
+	mac := hmac.New(sha512.New, k.chainCode)
+	mac.Write(data)
+	sum := mac.Sum(nil)
+
+	// The spec skips to the next index for these (probability < 2^-127);
+	// surfacing them keeps an index's address stable
+	il := new(big.Int).SetBytes(sum[:32])
+	if il.Cmp(curveN) >= 0 {
+		return nil, fmt.Errorf("deriving child %d: invalid key", i)
+	}
+	childKey := il.Add(il, new(big.Int).SetBytes(k.key))
+	childKey.Mod(childKey, curveN)
+	if childKey.Sign() == 0 {
+		return nil, fmt.Errorf("deriving child %d: invalid key", i)
+	}
+
+	return &extendedKey{key: childKey.FillBytes(make([]byte, 32)), chainCode: sum[32:]}, nil
+}
@@ -0,0 +1,53 @@
+package deposits_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/deposits"
+)
+
+func TestNewWallet_Mnemonic(t *testing.T) {
+	wallet, err := deposits.NewWallet(anvilMnemonic)
+	require.NoError(t, err)
+
+	// m/44'/60'/0'/0/0 of the anvil mnemonic is its first funded account
+	assert.Equal(t, "0xf39fd6e51aad88f6f4ce6ab8827279cfffb92266", strings.ToLower(wallet.GasAddress().Hex()))
+
+	// Whitespace and case do not change the wallet
+	same, err := deposits.NewWallet("  TEST test test test test test test test test test test   junk\n")
+	require.NoError(t, err)
+	assert.Equal(t, wallet.GasAddress(), same.GasAddress())
+
+	seen := map[string]bool{wallet.GasAddress().Hex(): true}
+	for i := int64(0); i < 5; i++ {
+		addr, err := wallet.DepositAddress(i)
+		require.NoError(t, err)
+		assert.False(t, seen[addr.Hex()], "address %d reused", i)
+		seen[addr.Hex()] = true
+
+		key, err := wallet.DepositKey(i)
+		require.NoError(t, err)
+		assert.Equal(t, addr, crypto.PubkeyToAddress(key.PublicKey))
+	}
+
+	_, err = wallet.DepositAddress(-1)
+	assert.Error(t, err)
+}
+
+func TestNewWallet_HexSeed(t *testing.T) {
+	a, err := deposits.NewWallet("000102030405060708090a0b0c0d0e0f")
+	require.NoError(t, err)
+	b, err := deposits.NewWallet("0x000102030405060708090a0b0c0d0e0f")
+	require.NoError(t, err)
+	assert.Equal(t, a.GasAddress(), b.GasAddress())
+
+	for _, seed := range []string{"", "0x1234", "not-hex-at-all-0000000000000000", "one two three"} {
+		_, err := deposits.NewWallet(seed)
+		assert.ErrorIs(t, err, deposits.ErrInvalidSeed, seed)
+	}
+}
@@ -0,0 +1,173 @@
+package deposits
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Sweep moves the funds of paid and underpaid deposits to the treasury
+// (deposits.treasury_address; nothing is swept while it is unset). Sweeping
+// is driven by the address's balance, so it is safe to repeat: while the last
+// sweep transaction is unmined and younger than sweep_retry_minutes the
+// address is left alone, otherwise whatever it still holds is sent. An
+// address is swept once nothing worth sending is left. Underpaid deposits are
+// swept too; refunding them is left to operators.
+func (s *Service) Sweep(ctx context.Context) error {
+	settings := s.Settings(ctx)
+	if settings.TreasuryAddress == "" {
+		return nil
+	}
+
+	for _, status := range []repository.DepositStatus{repository.DepositPaid, repository.DepositUnderpaid} {
+		intents, err := s.listByStatus(ctx, status)
+		if err != nil {
+			return err
+		}
+		for _, d := range intents {
+			if s.sweepPending(ctx, d, settings) {
+				continue
+			}
+			if err := s.sweepIntent(ctx, d, common.HexToAddress(settings.TreasuryAddress)); err != nil {
+				s.logger.Warn("failed to sweep deposit address",
+					zap.String("intent_id", d.ID),
+					zap.String("deposit_address", d.DepositAddress),
+					zap.Error(err),
+				)
+			}
+		}
+	}
+	return nil
+}
+
+// sweepPending reports whether the intent's last sweep transaction may still
+// be mined
+func (s *Service) sweepPending(ctx context.Context, d *repository.DepositIntent, settings Settings) bool {
+	if d.SweepTxHash == nil || d.SweepAttemptedAt == nil || s.now().Sub(*d.SweepAttemptedAt) >= settings.SweepRetry {
+		return false
+	}
+	_, err := s.client.TransactionReceipt(ctx, common.HexToHash(*d.SweepTxHash))
+	return errors.Is(err, ethereum.NotFound)
+}
+
+// sweepIntent sends the next sweep transaction of an intent, or marks it
+// swept when nothing is left
+func (s *Service) sweepIntent(ctx context.Context, d *repository.DepositIntent, treasury common.Address) error {
+	key, err := s.wallet.DepositKey(d.DerivationIndex)
+	if err != nil {
+		return err
+	}
+	address := crypto.PubkeyToAddress(key.PublicKey)
+	if address != common.HexToAddress(d.DepositAddress) {
+		return fmt.Errorf("derived address %s does not match %s", address.Hex(), d.DepositAddress)
+	}
+
+	gasPrice, err := s.client.SuggestGasPrice(ctx)
+	if err != nil {
+		return fmt.Errorf("getting gas price: %w", err)
+	}
+	ethBalance, err := s.client.BalanceAt(ctx, address, nil)
+	if err != nil {
+		return fmt.Errorf("getting ETH balance: %w", err)
+	}
+
+	var tx *types.Transaction
+	if d.TokenAddress == nil {
+		tx, err = s.sweepETH(ctx, key, ethBalance, gasPrice, treasury)
+	} else {
+		tx, err = s.sweepToken(ctx, key, common.HexToAddress(*d.TokenAddress), ethBalance, gasPrice, treasury)
+	}
+	if err != nil {
+		return err
+	}
+
+	if tx == nil {
+		if err := s.repo.UpdateDepositIntent(ctx, d.ID, &repository.DepositIntentUpdate{Status: repository.DepositSwept}); err != nil {
+			return fmt.Errorf("updating deposit intent: %w", err)
+		}
+		s.logger.Info("deposit address swept", zap.String("intent_id", d.ID), zap.String("deposit_address", d.DepositAddress))
+		return nil
+	}
+
+	hash := tx.Hash().Hex()
+	if err := s.repo.UpdateDepositIntent(ctx, d.ID, &repository.DepositIntentUpdate{Status: d.Status, SweepTxHash: &hash}); err != nil {
+		return fmt.Errorf("recording sweep transaction %s: %w", hash, err)
+	}
+	s.logger.Info("deposit sweep sent",
+		zap.String("intent_id", d.ID),
+		zap.String("deposit_address", d.DepositAddress),
+		zap.String("tx_hash", hash),
+	)
+	return nil
+}
+
+// sweepETH sends the balance minus the transfer fee to the treasury. It
+// returns nil when the balance does not cover the fee.
+func (s *Service) sweepETH(ctx context.Context, key *ecdsa.PrivateKey, balance, gasPrice *big.Int, treasury common.Address) (*types.Transaction, error) {
+	fee := new(big.Int).Mul(gasPrice, big.NewInt(ethTransferGas))
+	if balance.Cmp(fee) <= 0 {
+		return nil, nil
+	}
+	return s.send(ctx, key, treasury, new(big.Int).Sub(balance, fee), ethTransferGas, gasPrice, nil)
+}
+
+// sweepToken transfers the token balance to the treasury. A deposit address
+// without the ETH to pay for that is first topped up from the gas account;
+// the transfer follows on a later sweep. It returns nil once no tokens are
+// left.
+func (s *Service) sweepToken(ctx context.Context, key *ecdsa.PrivateKey, token common.Address, ethBalance, gasPrice *big.Int, treasury common.Address) (*types.Transaction, error) {
+	address := crypto.PubkeyToAddress(key.PublicKey)
+	balance, err := s.tokenBalance(ctx, token, address, nil)
+	if err != nil {
+		return nil, err
+	}
+	if balance.Sign() == 0 {
+		return nil, nil
+	}
+
+	data := append(append([]byte{}, transferSelector...), common.LeftPadBytes(treasury.Bytes(), 32)...)
+	data = append(data, common.LeftPadBytes(balance.Bytes(), 32)...)
+	gas, err := s.client.EstimateGas(ctx, ethereum.CallMsg{From: address, To: &token, Data: data})
+	if err != nil {
+		return nil, fmt.Errorf("estimating token transfer gas: %w", err)
+	}
+	gas = gas * gasEstimateHeadroomPercent / 100
+
+	fee := new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(gas))
+	if ethBalance.Cmp(fee) < 0 {
+		topUp := new(big.Int).Mul(fee, big.NewInt(gasTopUpHeadroomPercent))
+		topUp.Quo(topUp, big.NewInt(100))
+		topUp.Sub(topUp, ethBalance)
+		return s.send(ctx, s.wallet.GasKey(), address, topUp, ethTransferGas, gasPrice, nil)
+	}
+	return s.send(ctx, key, token, new(big.Int), gas, gasPrice, data)
+}
+
+// send signs and sends a legacy transaction from key
+func (s *Service) send(ctx context.Context, key *ecdsa.PrivateKey, to common.Address, value *big.Int, gas uint64, gasPrice *big.Int, data []byte) (*types.Transaction, error) {
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	nonce, err := s.client.PendingNonceAt(ctx, from)
+	if err != nil {
+		return nil, fmt.Errorf("getting nonce of %s: %w", from.Hex(), err)
+	}
+
+	tx, err := types.SignTx(types.NewTransaction(nonce, to, value, gas, gasPrice, data),
+		types.LatestSignerForChainID(big.NewInt(s.chainID)), key)
+	if err != nil {
+		return nil, fmt.Errorf("signing sweep transaction: %w", err)
+	}
+	if err := s.client.SendTransaction(ctx, tx); err != nil {
+		return nil, fmt.Errorf("sending transaction from %s: %w", from.Hex(), err)
+	}
+	return tx, nil
+}
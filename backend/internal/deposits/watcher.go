@@ -0,0 +1,173 @@
+package deposits
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// ERC-20 selectors
+var (
+	balanceOfSelector = []byte{0x70, 0xa0, 0x82, 0x31} // balanceOf(address)
+	transferSelector  = []byte{0xa9, 0x05, 0x9c, 0xbb} // transfer(address,uint256)
+)
+
+// Watch checks the balance of every awaiting or confirming deposit address.
+// Balances are read at the head and at confirmation depth (chain
+// confirmation_depth): enough at the head moves an intent to confirming,
+// enough at depth pays it. A confirming intent whose transfer is reorged out
+// goes back to awaiting. Awaiting intents past their expiry expire, or fail as
+// underpaid when the address holds less than expected.
+func (s *Service) Watch(ctx context.Context) error {
+	var watched []*repository.DepositIntent
+	for _, status := range []repository.DepositStatus{repository.DepositAwaiting, repository.DepositConfirming} {
+		intents, err := s.listByStatus(ctx, status)
+		if err != nil {
+			return err
+		}
+		watched = append(watched, intents...)
+	}
+	if len(watched) == 0 {
+		return nil
+	}
+
+	head, err := s.client.BlockNumber(ctx)
+	if err != nil {
+		return fmt.Errorf("getting block number: %w", err)
+	}
+	depth := uint64(s.confirmationDepth(ctx))
+	confirmedBlock := new(big.Int)
+	if head+1 > depth {
+		confirmedBlock.SetUint64(head + 1 - depth)
+	}
+	tolerance := s.Settings(ctx).TolerancePercent
+
+	for _, d := range watched {
+		if err := s.watchIntent(ctx, d, confirmedBlock, tolerance); err != nil {
+			s.logger.Warn("failed to check deposit address",
+				zap.String("intent_id", d.ID),
+				zap.String("deposit_address", d.DepositAddress),
+				zap.Error(err),
+			)
+		}
+	}
+	return nil
+}
+
+// watchIntent moves one intent on according to its balances
+func (s *Service) watchIntent(ctx context.Context, d *repository.DepositIntent, confirmedBlock *big.Int, tolerance int64) error {
+	expected, ok := new(big.Int).SetString(d.ExpectedAmount, 10)
+	if !ok {
+		return fmt.Errorf("invalid expected amount %q", d.ExpectedAmount)
+	}
+	threshold := new(big.Int).Mul(expected, big.NewInt(100-tolerance))
+	threshold.Quo(threshold, big.NewInt(100))
+
+	latest, err := s.balance(ctx, d, nil)
+	if err != nil {
+		return err
+	}
+	confirmed, err := s.balance(ctx, d, confirmedBlock)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case confirmed.Cmp(threshold) >= 0:
+		return s.transition(ctx, d, repository.DepositPaid, confirmed, repository.PaymentStatusCompleted, nil)
+
+	case latest.Cmp(threshold) >= 0:
+		if d.Status == repository.DepositAwaiting {
+			return s.transition(ctx, d, repository.DepositConfirming, latest, repository.PaymentStatusProcessing, nil)
+		}
+
+	case d.Status == repository.DepositConfirming:
+		s.logger.Warn("deposit no longer found at head, awaiting it again",
+			zap.String("intent_id", d.ID),
+			zap.String("deposit_address", d.DepositAddress),
+		)
+		return s.transition(ctx, d, repository.DepositAwaiting, latest, repository.PaymentStatusPending, nil)
+
+	case s.now().After(d.ExpiresAt):
+		if latest.Sign() == 0 {
+			return s.transition(ctx, d, repository.DepositExpired, latest, repository.PaymentStatusExpired, nil)
+		}
+		msg := fmt.Sprintf("underpaid: received %s of %s %s base units", latest, d.ExpectedAmount, d.Currency)
+		return s.transition(ctx, d, repository.DepositUnderpaid, latest, repository.PaymentStatusFailed, &msg)
+	}
+
+	if latest.String() != d.ReceivedAmount {
+		received := latest.String()
+		return s.repo.UpdateDepositIntent(ctx, d.ID, &repository.DepositIntentUpdate{Status: d.Status, ReceivedAmount: &received})
+	}
+	return nil
+}
+
+// transition moves an intent and its payment to their next statuses
+func (s *Service) transition(ctx context.Context, d *repository.DepositIntent, status repository.DepositStatus, received *big.Int, paymentStatus repository.PaymentStatus, errMsg *string) error {
+	amount := received.String()
+	if err := s.repo.UpdateDepositIntent(ctx, d.ID, &repository.DepositIntentUpdate{Status: status, ReceivedAmount: &amount}); err != nil {
+		return fmt.Errorf("updating deposit intent: %w", err)
+	}
+	var details *repository.PaymentStatusUpdate
+	if errMsg != nil {
+		details = &repository.PaymentStatusUpdate{ErrorMessage: errMsg}
+	}
+	if err := s.payments.UpdatePaymentStatus(ctx, d.PaymentID, paymentStatus, details); err != nil {
+		return fmt.Errorf("updating payment %s: %w", d.PaymentID, err)
+	}
+
+	s.logger.Info("deposit intent updated",
+		zap.String("intent_id", d.ID),
+		zap.String("payment_id", d.PaymentID),
+		zap.String("from", string(d.Status)),
+		zap.String("to", string(status)),
+		zap.String("received", amount),
+	)
+	return nil
+}
+
+// balance reads the deposit address's balance of the intent's currency at
+// block (nil = latest)
+func (s *Service) balance(ctx context.Context, d *repository.DepositIntent, block *big.Int) (*big.Int, error) {
+	address := common.HexToAddress(d.DepositAddress)
+	if d.TokenAddress == nil {
+		balance, err := s.client.BalanceAt(ctx, address, block)
+		if err != nil {
+			return nil, fmt.Errorf("getting ETH balance: %w", err)
+		}
+		return balance, nil
+	}
+	return s.tokenBalance(ctx, common.HexToAddress(*d.TokenAddress), address, block)
+}
+
+// tokenBalance calls token.balanceOf(account) at block (nil = latest)
+func (s *Service) tokenBalance(ctx context.Context, token, account common.Address, block *big.Int) (*big.Int, error) {
+	data := append(append([]byte{}, balanceOfSelector...), common.LeftPadBytes(account.Bytes(), 32)...)
+	out, err := s.client.CallContract(ctx, ethereum.CallMsg{To: &token, Data: data}, block)
+	if err != nil {
+		return nil, fmt.Errorf("getting token balance: %w", err)
+	}
+	if len(out) != 32 {
+		return nil, fmt.Errorf("getting token balance: unexpected %d byte result", len(out))
+	}
+	return new(big.Int).SetBytes(out), nil
+}
+
+// confirmationDepth loads chain.confirmation_depth (minimum 1)
+func (s *Service) confirmationDepth(ctx context.Context) int64 {
+	depth := int64(defaultConfirmationDepth)
+	if val, err := s.configRepo.GetNumber(ctx, "chain", "confirmation_depth", s.chainID); err == nil {
+		depth = val
+	}
+	if depth < 1 {
+		depth = 1
+	}
+	return depth
+}
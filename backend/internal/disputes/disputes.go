@@ -0,0 +1,235 @@
+// Package disputes applies Stripe disputes (chargebacks) to the payments they
+// are raised against. A disputed payment is marked disputed, the KYC
+// verification it paid for is suspended and compliance is alerted; once the
+// dispute closes the payment settles as completed (won) or refunded (lost).
+package disputes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/callbacks"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Compliance alert types
+const (
+	AlertOpened = "payment.dispute_opened"
+	AlertClosed = "payment.dispute_closed"
+)
+
+// verificationPageSize bounds one page of the payer's verifications
+const verificationPageSize = 100
+
+// Dispute is a dispute as reported by Stripe
+type Dispute struct {
+	ID       string
+	Status   string // needs_response, under_review, won, lost, warning_closed, ...
+	Reason   string
+	Amount   int64 // in the currency's smallest unit
+	Currency string
+}
+
+// Alert is the body POSTed to the compliance webhook
+type Alert struct {
+	ID            string                   `json:"id"` // stable per dispute and status, so redeliveries can be deduplicated
+	Type          string                   `json:"type"`
+	DisputeID     string                   `json:"dispute_id"`
+	DisputeStatus string                   `json:"dispute_status"`
+	DisputeReason string                   `json:"dispute_reason,omitempty"`
+	Amount        int64                    `json:"amount"`
+	Currency      string                   `json:"currency"`
+	PaymentID     string                   `json:"payment_id"`
+	PaymentStatus repository.PaymentStatus `json:"payment_status"`
+	PayerAddress  string                   `json:"payer_address"`
+	ServiceCode   string                   `json:"service_code"`
+
+	// KYC verifications paid for by the payment that this change suspended
+	// or reinstated
+	SuspendedVerifications  []string `json:"suspended_verifications,omitempty"`
+	ReinstatedVerifications []string `json:"reinstated_verifications,omitempty"`
+
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// Service applies disputes to payments and the verifications they paid for
+type Service struct {
+	payments repository.PaymentRepository
+	queue    callbacks.Queue
+	logger   *zap.Logger
+	now      func() time.Time
+}
+
+// NewService creates a dispute service. Pass the notifying payment repository
+// so dapps are told about disputed payments; compliance alerts are queued on
+// q for the Sender.
+func NewService(payments repository.PaymentRepository, q callbacks.Queue, logger *zap.Logger) *Service {
+	return &Service{
+		payments: payments,
+		queue:    q,
+		logger:   logger,
+		now:      time.Now,
+	}
+}
+
+// Open marks p disputed, suspends the KYC verifications it paid for and
+// alerts compliance. Redelivered events re-apply the same state.
+func (s *Service) Open(ctx context.Context, p *repository.Payment, d Dispute) error {
+	if err := s.payments.UpdatePaymentStatus(ctx, p.ID, repository.PaymentStatusDisputed, disputeUpdate(d)); err != nil {
+		return fmt.Errorf("marking payment %s disputed: %w", p.ID, err)
+	}
+
+	suspended, err := s.updateVerifications(ctx, p, func(v *repository.KYCVerification) (repository.KYCVerificationStatus, bool) {
+		switch v.Status {
+		case repository.KYCStatusRejected, repository.KYCStatusExpired, repository.KYCStatusSuspended:
+			return "", false
+		}
+		return repository.KYCStatusSuspended, true
+	})
+	if err != nil {
+		return err
+	}
+
+	s.logger.Warn("payment disputed",
+		zap.String("payment_id", p.ID),
+		zap.String("dispute_id", d.ID),
+		zap.String("reason", d.Reason),
+		zap.Strings("suspended_verifications", suspended),
+	)
+	s.alert(ctx, &Alert{
+		Type:                   AlertOpened,
+		PaymentStatus:          repository.PaymentStatusDisputed,
+		SuspendedVerifications: suspended,
+	}, p, d)
+	return nil
+}
+
+// Close settles p once its dispute closes: won (or an inquiry closed without
+// a chargeback) completes it again and reinstates its verifications, lost
+// refunds it and leaves them suspended. Compliance is alerted either way.
+func (s *Service) Close(ctx context.Context, p *repository.Payment, d Dispute) error {
+	status := repository.PaymentStatusDisputed
+	switch d.Status {
+	case "won", "warning_closed":
+		status = repository.PaymentStatusCompleted
+	case "lost":
+		status = repository.PaymentStatusRefunded
+	}
+	if err := s.payments.UpdatePaymentStatus(ctx, p.ID, status, disputeUpdate(d)); err != nil {
+		return fmt.Errorf("settling disputed payment %s: %w", p.ID, err)
+	}
+
+	var reinstated []string
+	if status == repository.PaymentStatusCompleted {
+		var err error
+		reinstated, err = s.updateVerifications(ctx, p, func(v *repository.KYCVerification) (repository.KYCVerificationStatus, bool) {
+			if v.Status != repository.KYCStatusSuspended {
+				return "", false
+			}
+			return reinstatedStatus(v), true
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	s.logger.Info("payment dispute closed",
+		zap.String("payment_id", p.ID),
+		zap.String("dispute_id", d.ID),
+		zap.String("dispute_status", d.Status),
+		zap.String("payment_status", string(status)),
+	)
+	s.alert(ctx, &Alert{
+		Type:                    AlertClosed,
+		PaymentStatus:           status,
+		ReinstatedVerifications: reinstated,
+	}, p, d)
+	return nil
+}
+
+// updateVerifications moves the payer's verifications paid for by p to the
+// status next returns for them, returning the IDs it changed
+func (s *Service) updateVerifications(ctx context.Context, p *repository.Payment, next func(*repository.KYCVerification) (repository.KYCVerificationStatus, bool)) ([]string, error) {
+	var changed []string
+	filter := repository.KYCVerificationFilter{UserAddress: p.PayerAddress}
+	for page := 1; ; page++ {
+		verifications, total, err := s.payments.ListKYCVerifications(ctx, filter, repository.Pagination{Page: page, PageSize: verificationPageSize})
+		if err != nil {
+			return changed, fmt.Errorf("listing verifications of %s: %w", p.PayerAddress, err)
+		}
+		for _, v := range verifications {
+			if v.PaymentID == nil || *v.PaymentID != p.ID {
+				continue
+			}
+			status, ok := next(v)
+			if !ok {
+				continue
+			}
+			if err := s.payments.UpdateKYCVerification(ctx, v.ID, &repository.KYCVerificationUpdate{Status: &status}); err != nil {
+				return changed, fmt.Errorf("updating verification %s: %w", v.ID, err)
+			}
+			changed = append(changed, v.ID)
+		}
+		if int64(page*verificationPageSize) >= total || len(verifications) == 0 {
+			return changed, nil
+		}
+	}
+}
+
+// reinstatedStatus is where a suspended verification resumes. The status it
+// had is not kept, so it is read from the timestamps; a Sumsub review that
+// finished during the suspension applies when its webhook is replayed.
+func reinstatedStatus(v *repository.KYCVerification) repository.KYCVerificationStatus {
+	switch {
+	case v.VerifiedAt != nil:
+		return repository.KYCStatusApproved
+	case v.SubmittedAt != nil:
+		return repository.KYCStatusSubmitted
+	default:
+		return repository.KYCStatusPending
+	}
+}
+
+// alert queues a compliance alert. The payment change is already stored, so
+// a queueing failure is logged rather than returned.
+func (s *Service) alert(ctx context.Context, a *Alert, p *repository.Payment, d Dispute) {
+	a.ID = "dispute:" + d.ID + ":" + d.Status
+	a.DisputeID = d.ID
+	a.DisputeStatus = d.Status
+	a.DisputeReason = d.Reason
+	a.Amount = d.Amount
+	a.Currency = d.Currency
+	a.PaymentID = p.ID
+	a.PayerAddress = p.PayerAddress
+	a.ServiceCode = p.ServiceCode
+	a.OccurredAt = s.now().UTC()
+
+	payload, err := json.Marshal(a)
+	if err == nil {
+		_, err = s.queue.Enqueue(ctx, &repository.WebhookEvent{
+			Provider:  repository.WebhookProviderComplianceAlert,
+			EventID:   a.ID,
+			EventType: a.Type,
+			Payload:   payload,
+		})
+	}
+	if err != nil {
+		s.logger.Error("failed to queue compliance alert",
+			zap.String("payment_id", p.ID),
+			zap.String("dispute_id", d.ID),
+			zap.Error(err),
+		)
+	}
+}
+
+func disputeUpdate(d Dispute) *repository.PaymentStatusUpdate {
+	update := &repository.PaymentStatusUpdate{DisputeID: &d.ID, DisputeStatus: &d.Status}
+	if d.Reason != "" {
+		update.DisputeReason = &d.Reason
+	}
+	return update
+}
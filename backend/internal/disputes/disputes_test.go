@@ -0,0 +1,197 @@
+package disputes_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/callbacks"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/disputes"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/storage/memory"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/webhooks"
+)
+
+const payer = "0x1111111111111111111111111111111111111111"
+
+type staticSecrets map[string]string
+
+func (s staticSecrets) Get(name string) string { return s[name] }
+
+// receiver records the alerts POSTed to it
+type receiver struct {
+	mu     sync.Mutex
+	alerts []disputes.Alert
+	sigs   []string
+}
+
+func (r *receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+	var a disputes.Alert
+	_ = json.Unmarshal(body, &a)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.alerts = append(r.alerts, a)
+	r.sigs = append(r.sigs, req.Header.Get(callbacks.SignatureHeader))
+	w.WriteHeader(http.StatusOK)
+}
+
+type fixture struct {
+	payments  *memory.MemoryPaymentRepo
+	processor *webhooks.Processor
+	config    *memory.MemoryAppConfigRepo
+	service   *disputes.Service
+	receiver  *receiver
+	url       string
+}
+
+func setup(t *testing.T) *fixture {
+	t.Helper()
+	rcv := &receiver{}
+	srv := httptest.NewServer(rcv)
+	t.Cleanup(srv.Close)
+
+	payments := memory.NewMemoryPaymentRepo()
+	config := memory.NewMemoryAppConfigRepo()
+	processor := webhooks.NewProcessor(memory.NewMemoryWebhookEventRepo(), nil, zap.NewNop())
+	processor.RegisterHandler(repository.WebhookProviderComplianceAlert,
+		disputes.NewSender(config, staticSecrets{"COMPLIANCE_WEBHOOK_SECRET": "compliance-secret"}, zap.NewNop()))
+	return &fixture{
+		payments:  payments,
+		processor: processor,
+		config:    config,
+		service:   disputes.NewService(payments, processor, zap.NewNop()),
+		receiver:  rcv,
+		url:       srv.URL,
+	}
+}
+
+func (f *fixture) setURL(t *testing.T, url string) {
+	t.Helper()
+	require.NoError(t, f.config.Create(context.Background(), &repository.AppConfigCreate{
+		Namespace:   "disputes",
+		ConfigKey:   "compliance_webhook_url",
+		ValueType:   "string",
+		ValueString: &url,
+	}))
+}
+
+// paidVerification creates a completed Stripe payment and the approved KYC
+// verification it paid for
+func (f *fixture) paidVerification(t *testing.T, intentID string) (*repository.Payment, *repository.KYCVerification) {
+	t.Helper()
+	ctx := context.Background()
+	p := &repository.Payment{
+		ServiceCode:     "kyc_verification",
+		PayerAddress:    payer,
+		PaymentMethod:   "stripe",
+		AmountCharged:   15,
+		Currency:        "USD",
+		Status:          repository.PaymentStatusCompleted,
+		StripePaymentID: &intentID,
+	}
+	require.NoError(t, f.payments.CreatePayment(ctx, p))
+
+	v := &repository.KYCVerification{PaymentID: &p.ID, UserAddress: payer, Status: repository.KYCStatusSubmitted}
+	require.NoError(t, f.payments.CreateKYCVerification(ctx, v))
+	approved := repository.KYCStatusApproved
+	require.NoError(t, f.payments.UpdateKYCVerification(ctx, v.ID, &repository.KYCVerificationUpdate{Status: &approved}))
+	return p, v
+}
+
+func (f *fixture) verificationStatus(t *testing.T, id string) repository.KYCVerificationStatus {
+	t.Helper()
+	v, err := f.payments.GetKYCVerification(context.Background(), id)
+	require.NoError(t, err)
+	return v.Status
+}
+
+func TestService_OpenAndWin(t *testing.T) {
+	f := setup(t)
+	f.setURL(t, f.url)
+	ctx := context.Background()
+	p, v := f.paidVerification(t, "pi_won")
+	other, otherV := f.paidVerification(t, "pi_other")
+
+	d := disputes.Dispute{ID: "dp_1", Status: "needs_response", Reason: "fraudulent", Amount: 1543, Currency: "usd"}
+	require.NoError(t, f.service.Open(ctx, p, d))
+
+	got, err := f.payments.GetPayment(ctx, p.ID)
+	require.NoError(t, err)
+	assert.Equal(t, repository.PaymentStatusDisputed, got.Status)
+	require.NotNil(t, got.DisputeID)
+	assert.Equal(t, "dp_1", *got.DisputeID)
+	assert.Equal(t, "needs_response", *got.DisputeStatus)
+	assert.Equal(t, "fraudulent", *got.DisputeReason)
+	require.NotNil(t, got.DisputedAt)
+	disputedAt := *got.DisputedAt
+
+	// Only the verification the disputed payment paid for is suspended
+	assert.Equal(t, repository.KYCStatusSuspended, f.verificationStatus(t, v.ID))
+	assert.Equal(t, repository.KYCStatusApproved, f.verificationStatus(t, otherV.ID))
+	unchanged, err := f.payments.GetPayment(ctx, other.ID)
+	require.NoError(t, err)
+	assert.Equal(t, repository.PaymentStatusCompleted, unchanged.Status)
+
+	// A redelivered event queues no second alert
+	require.NoError(t, f.service.Open(ctx, p, d))
+
+	d.Status = "won"
+	require.NoError(t, f.service.Close(ctx, p, d))
+
+	got, err = f.payments.GetPayment(ctx, p.ID)
+	require.NoError(t, err)
+	assert.Equal(t, repository.PaymentStatusCompleted, got.Status)
+	assert.Equal(t, "won", *got.DisputeStatus)
+	assert.Equal(t, disputedAt, *got.DisputedAt, "disputed_at keeps the first dispute time")
+	assert.Equal(t, repository.KYCStatusApproved, f.verificationStatus(t, v.ID))
+
+	assert.Equal(t, 2, f.processor.RunOnce(ctx))
+	require.Len(t, f.receiver.alerts, 2)
+	opened, closed := f.receiver.alerts[0], f.receiver.alerts[1]
+	if opened.Type != disputes.AlertOpened {
+		opened, closed = closed, opened
+	}
+	assert.Equal(t, disputes.AlertOpened, opened.Type)
+	assert.Equal(t, "dispute:dp_1:needs_response", opened.ID)
+	assert.Equal(t, p.ID, opened.PaymentID)
+	assert.Equal(t, int64(1543), opened.Amount)
+	assert.Equal(t, []string{v.ID}, opened.SuspendedVerifications)
+	assert.Equal(t, disputes.AlertClosed, closed.Type)
+	assert.Equal(t, repository.PaymentStatusCompleted, closed.PaymentStatus)
+	assert.Equal(t, []string{v.ID}, closed.ReinstatedVerifications)
+	for _, sig := range f.receiver.sigs {
+		assert.True(t, strings.HasPrefix(sig, "t="), sig)
+	}
+}
+
+func TestService_Lost(t *testing.T) {
+	f := setup(t)
+	ctx := context.Background()
+	p, v := f.paidVerification(t, "pi_lost")
+
+	d := disputes.Dispute{ID: "dp_2", Status: "needs_response", Reason: "product_not_received"}
+	require.NoError(t, f.service.Open(ctx, p, d))
+	d.Status = "lost"
+	require.NoError(t, f.service.Close(ctx, p, d))
+
+	got, err := f.payments.GetPayment(ctx, p.ID)
+	require.NoError(t, err)
+	assert.Equal(t, repository.PaymentStatusRefunded, got.Status)
+	assert.Equal(t, "lost", *got.DisputeStatus)
+	assert.Equal(t, repository.KYCStatusSuspended, f.verificationStatus(t, v.ID), "a lost dispute keeps the verification suspended")
+
+	// Without a compliance URL the alerts are logged and dropped, not retried
+	assert.Equal(t, 2, f.processor.RunOnce(ctx))
+	assert.Empty(t, f.receiver.alerts)
+	assert.Equal(t, 0, f.processor.RunOnce(ctx))
+}
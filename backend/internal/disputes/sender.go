@@ -0,0 +1,103 @@
+package disputes
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/callbacks"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// deliveryTimeout bounds one POST to the compliance webhook
+const deliveryTimeout = 10 * time.Second
+
+// SecretSource returns the current value of a named secret (implemented by
+// secrets.Store)
+type SecretSource interface {
+	Get(name string) string
+}
+
+// Sender delivers queued compliance alerts (registered with the webhook
+// processor for WebhookProviderComplianceAlert). Alerts go to app_config
+// disputes.compliance_webhook_url, signed like payment callbacks with the
+// COMPLIANCE_WEBHOOK_SECRET secret.
+type Sender struct {
+	configRepo repository.AppConfigRepository
+	secrets    SecretSource
+	client     *http.Client
+	logger     *zap.Logger
+	now        func() time.Time
+}
+
+// NewSender creates a compliance alert sender. The URL is set by operators,
+// so unlike dapp callbacks it may point at an internal service.
+func NewSender(configRepo repository.AppConfigRepository, secrets SecretSource, logger *zap.Logger) *Sender {
+	return &Sender{
+		configRepo: configRepo,
+		secrets:    secrets,
+		client: &http.Client{
+			Timeout: deliveryTimeout,
+			// A redirect is treated as a failed delivery
+			CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+		},
+		logger: logger,
+		now:    time.Now,
+	}
+}
+
+// ProcessWebhookEvent POSTs a queued alert to the compliance webhook (called
+// by the background webhook processor). Without a configured URL the alert
+// is only logged. A non-2xx response is retried with backoff until the event
+// is dead-lettered.
+func (s *Sender) ProcessWebhookEvent(ctx context.Context, e *repository.WebhookEvent) error {
+	var a Alert
+	if err := json.Unmarshal(e.Payload, &a); err != nil {
+		return fmt.Errorf("parsing stored compliance alert: %w", err)
+	}
+
+	url, _ := s.configRepo.GetString(ctx, "disputes", "compliance_webhook_url", 0)
+	if url == "" {
+		s.logger.Warn("compliance alert not delivered: disputes.compliance_webhook_url is not set",
+			zap.String("alert_id", a.ID),
+			zap.String("type", a.Type),
+			zap.String("payment_id", a.PaymentID),
+		)
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deliveryTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(e.Payload))
+	if err != nil {
+		return fmt.Errorf("building compliance alert request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "nexus-protocol-compliance/1")
+	req.Header.Set(callbacks.EventIDHeader, a.ID)
+	if secret := s.secrets.Get("COMPLIANCE_WEBHOOK_SECRET"); secret != "" {
+		req.Header.Set(callbacks.SignatureHeader, callbacks.Sign(secret, s.now(), e.Payload))
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("posting compliance alert: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("compliance webhook returned %d", resp.StatusCode)
+	}
+	s.logger.Info("compliance alert delivered",
+		zap.String("alert_id", a.ID),
+		zap.String("payment_id", a.PaymentID),
+	)
+	return nil
+}
@@ -0,0 +1,138 @@
+//go:build e2e
+
+package e2e_test
+
+import (
+	"crypto/rand"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/handlers"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// mappingID looks up a contract mapping ID by its db_name
+func (s *server) mappingID(dbName string) string {
+	s.t.Helper()
+
+	var resp struct {
+		Data struct {
+			Mappings []repository.ContractMapping `json:"mappings"`
+		} `json:"data"`
+		Error string `json:"error"`
+	}
+	require.Equal(s.t, http.StatusOK, s.request(http.MethodGet, "/api/v1/contracts/mappings", nil, &resp), resp.Error)
+	for _, m := range resp.Data.Mappings {
+		if m.DBName == dbName {
+			return m.ID
+		}
+	}
+	s.t.Fatalf("contract mapping %s not seeded", dbName)
+	return ""
+}
+
+// register upserts the address of a mapping on the suite's chain
+func (s *server) register(mappingID string, address common.Address) *repository.ContractAddress {
+	s.t.Helper()
+
+	var resp struct {
+		Data struct {
+			Contract *repository.ContractAddress `json:"contract"`
+		} `json:"data"`
+		Error string `json:"error"`
+	}
+	notes := "e2e"
+	status := s.request(http.MethodPost, "/api/v1/contracts", handlers.UpsertContractRequest{
+		ChainID:           chainID,
+		ContractMappingID: mappingID,
+		Address:           address.Hex(),
+		Notes:             &notes,
+	}, &resp)
+	require.Equal(s.t, http.StatusOK, status, resp.Error)
+	require.NotNil(s.t, resp.Data.Contract)
+	return resp.Data.Contract
+}
+
+func randomAddress(t *testing.T) common.Address {
+	t.Helper()
+	var addr common.Address
+	_, err := rand.Read(addr[:])
+	require.NoError(t, err)
+	return addr
+}
+
+func TestContractRegistration_RegistersAndRecordsHistory(t *testing.T) {
+	s := newServer(t)
+
+	// The deployed forwarder when available, so the registry matches the chain
+	first := env.forwarder
+	if first == (common.Address{}) {
+		first = randomAddress(t)
+	}
+	second := randomAddress(t)
+
+	id := s.mappingID("nexusForwarder")
+	registered := s.register(id, first)
+	assert.Equal(t, "nexusForwarder", registered.DBName)
+	assert.True(t, strings.EqualFold(first.Hex(), registered.Address))
+
+	var got struct {
+		Data  *repository.ContractAddress `json:"data"`
+		Error string                      `json:"error"`
+	}
+	require.Equal(t, http.StatusOK, s.request(http.MethodGet, "/api/v1/contracts/31337/nexusForwarder", nil, &got), got.Error)
+	assert.True(t, strings.EqualFold(first.Hex(), got.Data.Address))
+
+	// Redeployment: same record, new address, change kept in history
+	moved := s.register(id, second)
+	assert.Equal(t, registered.ID, moved.ID)
+	assert.True(t, strings.EqualFold(second.Hex(), moved.Address))
+
+	var history struct {
+		Data struct {
+			History []repository.ContractAddressHistory `json:"history"`
+		} `json:"data"`
+		Error string `json:"error"`
+	}
+	require.Equal(t, http.StatusOK, s.request(http.MethodGet, "/api/v1/contracts/history/"+moved.ID, nil, &history), history.Error)
+
+	found := false
+	for _, h := range history.Data.History {
+		if h.OldAddress != nil && strings.EqualFold(*h.OldAddress, first.Hex()) && strings.EqualFold(h.NewAddress, second.Hex()) {
+			found = true
+		}
+	}
+	assert.True(t, found, "history should record %s -> %s", first.Hex(), second.Hex())
+
+	// Leave the forwarder registered for anything reading the registry later
+	if env.forwarder != (common.Address{}) {
+		s.register(id, env.forwarder)
+	}
+}
+
+func TestContractRegistration_RejectsUnknownNetworkAndMapping(t *testing.T) {
+	s := newServer(t)
+	id := s.mappingID("nexusForwarder")
+
+	var resp struct {
+		Error string `json:"error"`
+	}
+	status := s.request(http.MethodPost, "/api/v1/contracts", handlers.UpsertContractRequest{
+		ChainID:           999999,
+		ContractMappingID: id,
+		Address:           randomAddress(t).Hex(),
+	}, &resp)
+	assert.Equal(t, http.StatusNotFound, status, resp.Error)
+
+	status = s.request(http.MethodPost, "/api/v1/contracts", handlers.UpsertContractRequest{
+		ChainID:           chainID,
+		ContractMappingID: "00000000-0000-4000-8000-000000000000",
+		Address:           randomAddress(t).Hex(),
+	}, &resp)
+	assert.Equal(t, http.StatusNotFound, status, resp.Error)
+}
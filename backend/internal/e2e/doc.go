@@ -0,0 +1,19 @@
+// Package e2e holds end-to-end tests that run the API against PostgreSQL and
+// a local EVM node (anvil) with the NexusForwarder deployed. The tests are
+// behind the "e2e" build tag:
+//
+//	go test -tags e2e ./internal/e2e/...
+//
+// By default the suite starts throwaway postgres and anvil containers with
+// the docker CLI and removes them afterwards. To reuse running services
+// (e.g. CI service containers), set:
+//
+//	E2E_DATABASE_URL      empty PostgreSQL database; init-db.sql is applied
+//	                      if the schema is missing
+//	E2E_RPC_URL           anvil JSON-RPC endpoint (chain ID 31337, default
+//	                      mnemonic accounts)
+//	E2E_FORWARDER_ADDRESS already deployed forwarder; otherwise it is
+//	                      deployed with forge if forge is installed
+//
+// Relay tests are skipped when the forwarder cannot be deployed.
+package e2e
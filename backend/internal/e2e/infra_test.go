@@ -0,0 +1,261 @@
+//go:build e2e
+
+package e2e_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	_ "github.com/lib/pq"
+)
+
+const (
+	chainID = 31337
+
+	postgresImage = "postgres:16-alpine"
+	anvilImage    = "ghcr.io/foundry-rs/foundry:latest"
+
+	startupTimeout = 90 * time.Second
+)
+
+// Default anvil accounts. Account 0 deploys the forwarder and relays, account
+// 1 pays and account 2 signs meta-transactions.
+var (
+	deployerKey = mustKey("ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80")
+	payerKey    = mustKey("59c6995e998f97a5a0044966f0945389dc9e86dae88c7a8412f4603b6b78690d")
+	signerKey   = mustKey("5de4111afa1a4b94908f83103eb1f1706367c2e68ca870fc3fb9a804cdab365a")
+)
+
+// errNotConfigured means the suite has no way to reach its infrastructure
+var errNotConfigured = errors.New("no docker CLI and no E2E_DATABASE_URL/E2E_RPC_URL")
+
+// infra is the infrastructure shared by all tests in the suite
+type infra struct {
+	db        *sql.DB
+	rpcURL    string
+	eth       *ethclient.Client
+	forwarder common.Address // zero when it could not be deployed
+
+	// forwarderSkip explains why relay tests are skipped
+	forwarderSkip string
+
+	cleanup []func()
+}
+
+// setupInfra connects to or starts postgres and anvil, applies the schema
+// and deploys the forwarder
+func setupInfra(ctx context.Context) (*infra, error) {
+	env := &infra{}
+
+	dbURL := os.Getenv("E2E_DATABASE_URL")
+	rpcURL := os.Getenv("E2E_RPC_URL")
+	if (dbURL == "" || rpcURL == "") && !hasCommand("docker") {
+		return env, errNotConfigured
+	}
+
+	if dbURL == "" {
+		hostPort, stop, err := startContainer(ctx, "5432/tcp",
+			"-e", "POSTGRES_DB=nexus", "-e", "POSTGRES_USER=nexus", "-e", "POSTGRES_PASSWORD=nexus",
+			postgresImage)
+		env.cleanup = append(env.cleanup, stop)
+		if err != nil {
+			return env, fmt.Errorf("starting postgres: %w", err)
+		}
+		dbURL = "postgres://nexus:nexus@" + hostPort + "/nexus?sslmode=disable"
+	}
+
+	if rpcURL == "" {
+		hostPort, stop, err := startContainer(ctx, "8545/tcp",
+			"--entrypoint", "anvil", anvilImage, "--host", "0.0.0.0", "--chain-id", fmt.Sprint(chainID))
+		env.cleanup = append(env.cleanup, stop)
+		if err != nil {
+			return env, fmt.Errorf("starting anvil: %w", err)
+		}
+		rpcURL = "http://" + hostPort
+	}
+	env.rpcURL = rpcURL
+
+	db, err := sql.Open("postgres", dbURL)
+	if err != nil {
+		return env, fmt.Errorf("opening database: %w", err)
+	}
+	env.db = db
+	env.cleanup = append(env.cleanup, func() { db.Close() })
+
+	if err := waitFor(ctx, func(ctx context.Context) error { return db.PingContext(ctx) }); err != nil {
+		return env, fmt.Errorf("waiting for postgres: %w", err)
+	}
+	if err := applySchema(ctx, db); err != nil {
+		return env, err
+	}
+
+	eth, err := ethclient.DialContext(ctx, rpcURL)
+	if err != nil {
+		return env, fmt.Errorf("dialing anvil: %w", err)
+	}
+	env.eth = eth
+	env.cleanup = append(env.cleanup, eth.Close)
+
+	if err := waitFor(ctx, func(ctx context.Context) error {
+		id, err := eth.ChainID(ctx)
+		if err == nil && id.Int64() != chainID {
+			return fmt.Errorf("node serves chain %s, want %d", id, chainID)
+		}
+		return err
+	}); err != nil {
+		return env, fmt.Errorf("waiting for anvil: %w", err)
+	}
+
+	env.forwarder, env.forwarderSkip = deployForwarder(ctx, rpcURL)
+	return env, nil
+}
+
+// teardown releases everything setupInfra created, newest first
+func (env *infra) teardown() {
+	for i := len(env.cleanup) - 1; i >= 0; i-- {
+		env.cleanup[i]()
+	}
+}
+
+// startContainer runs a detached container publishing port on a random
+// loopback port and returns "127.0.0.1:<port>" and a function removing it
+func startContainer(ctx context.Context, port string, args ...string) (string, func(), error) {
+	runArgs := append([]string{"run", "-d", "--rm", "-p", "127.0.0.1::" + strings.TrimSuffix(port, "/tcp")}, args...)
+	id, err := docker(ctx, runArgs...)
+	if err != nil {
+		return "", func() {}, err
+	}
+	stop := func() { _, _ = docker(context.Background(), "rm", "-f", id) }
+
+	mapped, err := docker(ctx, "port", id, port)
+	if err != nil {
+		return "", stop, err
+	}
+	// "docker port" prints one line per address family
+	return strings.SplitN(mapped, "\n", 2)[0], stop, nil
+}
+
+// docker runs the docker CLI and returns its trimmed output
+func docker(ctx context.Context, args ...string) (string, error) {
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "docker", args...)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("docker %s: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// applySchema loads init-db.sql unless the schema already exists
+func applySchema(ctx context.Context, db *sql.DB) error {
+	var exists bool
+	if err := db.QueryRowContext(ctx, `SELECT to_regclass('public.payments') IS NOT NULL`).Scan(&exists); err != nil {
+		return fmt.Errorf("checking schema: %w", err)
+	}
+	if exists {
+		return nil
+	}
+
+	schema, err := os.ReadFile(filepath.Join(repoRoot(), "infrastructure", "docker", "init-db.sql"))
+	if err != nil {
+		return fmt.Errorf("reading schema: %w", err)
+	}
+	if _, err := db.ExecContext(ctx, string(schema)); err != nil {
+		return fmt.Errorf("applying schema: %w", err)
+	}
+	return nil
+}
+
+// deployForwarder returns the forwarder to relay through: E2E_FORWARDER_ADDRESS
+// or a fresh deployment with forge. On failure it returns the reason relay
+// tests are skipped.
+func deployForwarder(ctx context.Context, rpcURL string) (common.Address, string) {
+	if addr := os.Getenv("E2E_FORWARDER_ADDRESS"); addr != "" {
+		return common.HexToAddress(addr), ""
+	}
+	if !hasCommand("forge") {
+		return common.Address{}, "forge is not installed and E2E_FORWARDER_ADDRESS is unset"
+	}
+
+	deployer := crypto.PubkeyToAddress(deployerKey.PublicKey).Hex()
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "forge", "create", "src/metatx/NexusForwarder.sol:NexusForwarder",
+		"--rpc-url", rpcURL,
+		"--private-key", "0x"+keyHex(deployerKey),
+		"--broadcast", "--json",
+		"--constructor-args", deployer, deployer, // admin, relayer
+	)
+	cmd.Dir = filepath.Join(repoRoot(), "contracts")
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return common.Address{}, fmt.Sprintf("forge create failed: %v: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	// The JSON result is the last line; forge may log compilation first
+	lines := strings.Split(strings.TrimSpace(string(out)), "\n")
+	var result struct {
+		DeployedTo string `json:"deployedTo"`
+	}
+	if err := json.Unmarshal([]byte(lines[len(lines)-1]), &result); err != nil || !common.IsHexAddress(result.DeployedTo) {
+		return common.Address{}, fmt.Sprintf("unexpected forge create output: %s", out)
+	}
+	return common.HexToAddress(result.DeployedTo), ""
+}
+
+// waitFor retries check until it succeeds or startupTimeout elapses
+func waitFor(ctx context.Context, check func(ctx context.Context) error) error {
+	ctx, cancel := context.WithTimeout(ctx, startupTimeout)
+	defer cancel()
+
+	for {
+		attemptCtx, attemptCancel := context.WithTimeout(ctx, 5*time.Second)
+		err := check(attemptCtx)
+		attemptCancel()
+		if err == nil {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(500 * time.Millisecond):
+		}
+	}
+}
+
+// repoRoot returns the repository root (tests run in backend/internal/e2e)
+func repoRoot() string {
+	return filepath.Join("..", "..", "..")
+}
+
+func hasCommand(name string) bool {
+	_, err := exec.LookPath(name)
+	return err == nil
+}
+
+func mustKey(hexKey string) *ecdsa.PrivateKey {
+	key, err := crypto.HexToECDSA(hexKey)
+	if err != nil {
+		panic(err)
+	}
+	return key
+}
+
+func keyHex(key *ecdsa.PrivateKey) string {
+	return fmt.Sprintf("%x", crypto.FromECDSA(key))
+}
@@ -0,0 +1,179 @@
+//go:build e2e
+
+package e2e_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/chain"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/handlers"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/storage/postgres"
+)
+
+var env *infra
+
+func TestMain(m *testing.M) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	var err error
+	env, err = setupInfra(ctx)
+	cancel()
+
+	if errors.Is(err, errNotConfigured) {
+		fmt.Println("skipping e2e tests:", err)
+		os.Exit(0)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "e2e setup failed:", err)
+		env.teardown()
+		os.Exit(1)
+	}
+
+	code := m.Run()
+	env.teardown()
+	os.Exit(code)
+}
+
+// server is the API wired like cmd/server on the shared postgres and anvil
+type server struct {
+	t       *testing.T
+	http    *httptest.Server
+	tracker *chain.ConfirmationTracker
+
+	payments repository.PaymentRepository
+	relayer  repository.RelayerRepository
+}
+
+// newServer starts the API. The confirmation tracker is not run in the
+// background; tests call poll once the chain has advanced.
+func newServer(t *testing.T) *server {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	logger := zap.NewNop()
+
+	paymentRepo := postgres.NewPostgresPaymentRepo(env.db)
+	pricingRepo := postgres.NewPostgresPricingRepo(env.db)
+	relayerRepo := postgres.NewPostgresRelayerRepo(env.db)
+	contractRepo := postgres.NewPostgresContractRepo(env.db)
+	appConfigRepo := postgres.NewPostgresAppConfigRepo(env.db)
+	chainRepo := postgres.NewPostgresChainRepo(env.db)
+
+	// network_config points at localhost:8545; use only the suite's node
+	rpcManager := chain.NewClientManager(nil, logger)
+	rpcManager.AddEndpoints(chainID, env.rpcURL)
+	t.Cleanup(rpcManager.Close)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	client, err := rpcManager.Client(ctx, chainID)
+	require.NoError(t, err)
+
+	tracker := chain.NewConfirmationTracker(client, chainRepo, appConfigRepo, chainID, logger)
+	tracker.RegisterHandler(repository.TrackedRecordPayment, chain.NewPaymentRecordHandler(paymentRepo, logger))
+	tracker.RegisterHandler(repository.TrackedRecordMetaTx, chain.NewMetaTxRecordHandler(relayerRepo, logger))
+
+	paymentHandler := handlers.NewPaymentHandler(paymentRepo, pricingRepo, logger)
+	paymentHandler.SetTxTracker(tracker)
+	contractHandler := handlers.NewContractHandler(contractRepo, appConfigRepo, rpcManager, logger)
+
+	router := gin.New()
+	api := router.Group("/api/v1")
+
+	payments := api.Group("/payments")
+	payments.POST("/crypto", paymentHandler.ProcessCryptoPayment)
+	payments.GET("/:paymentId", paymentHandler.GetPayment)
+
+	contracts := api.Group("/contracts")
+	contracts.GET("/mappings", contractHandler.ListMappings)
+	contracts.GET("/:chainId/:name", contractHandler.GetContract)
+	contracts.POST("", contractHandler.UpsertContract)
+	contracts.GET("/history/:id", contractHandler.GetContractHistory)
+
+	if env.forwarder != (common.Address{}) {
+		t.Setenv("RELAYER_PRIVATE_KEY", keyHex(deployerKey))
+		t.Setenv("FORWARDER_ADDRESS", env.forwarder.Hex())
+		relayerHandler, err := handlers.NewRelayerHandler(relayerRepo, appConfigRepo, rpcManager, logger, chainID, nil)
+		require.NoError(t, err)
+		relayerHandler.SetTxTracker(tracker)
+
+		relay := api.Group("/relay")
+		relay.POST("", relayerHandler.Relay)
+		relay.GET("/status/:id", relayerHandler.GetStatus)
+		relay.GET("/nonce/:address", relayerHandler.GetNonce)
+	}
+
+	srv := httptest.NewServer(router)
+	t.Cleanup(srv.Close)
+
+	return &server{
+		t:        t,
+		http:     srv,
+		tracker:  tracker,
+		payments: paymentRepo,
+		relayer:  relayerRepo,
+	}
+}
+
+// requireForwarder skips relay tests when no forwarder is deployed
+func requireForwarder(t *testing.T) {
+	t.Helper()
+	if env.forwarder == (common.Address{}) {
+		t.Skip("relay tests need the forwarder: " + env.forwarderSkip)
+	}
+}
+
+// request sends a JSON request and decodes the JSON response into out
+func (s *server) request(method, path string, body, out interface{}) int {
+	s.t.Helper()
+
+	var reader io.Reader
+	if body != nil {
+		raw, err := json.Marshal(body)
+		require.NoError(s.t, err)
+		reader = bytes.NewReader(raw)
+	}
+	req, err := http.NewRequest(method, s.http.URL+path, reader)
+	require.NoError(s.t, err)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.http.Client().Do(req)
+	require.NoError(s.t, err)
+	defer resp.Body.Close()
+
+	raw, err := io.ReadAll(resp.Body)
+	require.NoError(s.t, err)
+	if out != nil {
+		require.NoError(s.t, json.Unmarshal(raw, out), string(raw))
+	}
+	return resp.StatusCode
+}
+
+// poll runs one confirmation tracker pass
+func (s *server) poll() {
+	s.t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	require.NoError(s.t, s.tracker.Poll(ctx))
+}
+
+// mine asks anvil for n more blocks
+func mine(t *testing.T, n int) {
+	t.Helper()
+	require.NoError(t, env.eth.Client().Call(nil, "anvil_mine", fmt.Sprintf("0x%x", n)))
+}
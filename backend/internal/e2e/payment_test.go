@@ -0,0 +1,153 @@
+//go:build e2e
+
+package e2e_test
+
+import (
+	"context"
+	"crypto/rand"
+	"math/big"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/handlers"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// sendETH transfers wei from the payer to the deployer and waits for it to be mined
+func sendETH(t *testing.T, wei *big.Int) *types.Transaction {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	from := crypto.PubkeyToAddress(payerKey.PublicKey)
+	to := crypto.PubkeyToAddress(deployerKey.PublicKey)
+
+	nonce, err := env.eth.PendingNonceAt(ctx, from)
+	require.NoError(t, err)
+	gasPrice, err := env.eth.SuggestGasPrice(ctx)
+	require.NoError(t, err)
+
+	tx, err := types.SignTx(types.NewTx(&types.LegacyTx{
+		Nonce:    nonce,
+		To:       &to,
+		Value:    wei,
+		Gas:      21000,
+		GasPrice: gasPrice,
+	}), types.LatestSignerForChainID(big.NewInt(chainID)), payerKey)
+	require.NoError(t, err)
+	require.NoError(t, env.eth.SendTransaction(ctx, tx))
+
+	receipt, err := bind.WaitMined(ctx, env.eth, tx)
+	require.NoError(t, err)
+	require.Equal(t, types.ReceiptStatusSuccessful, receipt.Status)
+	return tx
+}
+
+// randomTxHash returns a hash no transaction has, unique across runs on a
+// reused database
+func randomTxHash(t *testing.T) string {
+	t.Helper()
+	b := make([]byte, 32)
+	_, err := rand.Read(b)
+	require.NoError(t, err)
+	return hexutil.Encode(b)
+}
+
+// getPayment reads a payment through the API
+func (s *server) getPayment(id string) *repository.Payment {
+	s.t.Helper()
+
+	var resp struct {
+		Success bool                `json:"success"`
+		Data    *repository.Payment `json:"data"`
+		Error   string              `json:"error"`
+	}
+	require.Equal(s.t, http.StatusOK, s.request(http.MethodGet, "/api/v1/payments/"+id, nil, &resp), resp.Error)
+	return resp.Data
+}
+
+func TestCryptoPayment_CompletesAfterConfirmation(t *testing.T) {
+	s := newServer(t)
+
+	// kyc_verification costs 0.005 ETH
+	tx := sendETH(t, big.NewInt(5e15))
+	payer := crypto.PubkeyToAddress(payerKey.PublicKey).Hex()
+
+	var resp struct {
+		Success bool `json:"success"`
+		Data    struct {
+			PaymentID string `json:"payment_id"`
+			Status    string `json:"status"`
+		} `json:"data"`
+		Error string `json:"error"`
+	}
+	status := s.request(http.MethodPost, "/api/v1/payments/crypto", handlers.CryptoPaymentRequest{
+		ServiceCode:   "kyc_verification",
+		PayerAddress:  payer,
+		PaymentMethod: "eth",
+		TxHash:        tx.Hash().Hex(),
+		Amount:        0.005,
+	}, &resp)
+	require.Equal(t, http.StatusOK, status, resp.Error)
+	assert.Equal(t, string(repository.PaymentStatusProcessing), resp.Data.Status)
+
+	payment := s.getPayment(resp.Data.PaymentID)
+	assert.Equal(t, repository.PaymentStatusProcessing, payment.Status)
+	assert.Equal(t, strings.ToLower(payer), payment.PayerAddress)
+	assert.Equal(t, "ETH", payment.Currency)
+
+	// Anvil's confirmation depth is 1 (app_config chain/confirmation_depth)
+	s.poll()
+	assert.Equal(t, repository.PaymentStatusCompleted, s.getPayment(resp.Data.PaymentID).Status)
+}
+
+func TestCryptoPayment_UnminedTxStaysProcessing(t *testing.T) {
+	s := newServer(t)
+
+	var resp struct {
+		Success bool `json:"success"`
+		Data    struct {
+			PaymentID string `json:"payment_id"`
+		} `json:"data"`
+		Error string `json:"error"`
+	}
+	status := s.request(http.MethodPost, "/api/v1/payments/crypto", handlers.CryptoPaymentRequest{
+		ServiceCode:   "kyc_verification",
+		PayerAddress:  crypto.PubkeyToAddress(payerKey.PublicKey).Hex(),
+		PaymentMethod: "eth",
+		TxHash:        randomTxHash(t),
+		Amount:        0.005,
+	}, &resp)
+	require.Equal(t, http.StatusOK, status, resp.Error)
+
+	mine(t, 3)
+	s.poll()
+	assert.Equal(t, repository.PaymentStatusProcessing, s.getPayment(resp.Data.PaymentID).Status)
+}
+
+func TestCryptoPayment_RejectsUnderpayment(t *testing.T) {
+	s := newServer(t)
+
+	var resp struct {
+		Success bool   `json:"success"`
+		Error   string `json:"error"`
+	}
+	status := s.request(http.MethodPost, "/api/v1/payments/crypto", handlers.CryptoPaymentRequest{
+		ServiceCode:   "kyc_verification",
+		PayerAddress:  crypto.PubkeyToAddress(payerKey.PublicKey).Hex(),
+		PaymentMethod: "eth",
+		TxHash:        randomTxHash(t),
+		Amount:        0.001,
+	}, &resp)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Contains(t, resp.Error, "Insufficient payment")
+}
@@ -0,0 +1,196 @@
+//go:build e2e
+
+package e2e_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/contracts"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/handlers"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// signForwardRequest signs req with the signer key the way a wallet does
+// (eth_signTypedData_v4), independently of the relayer's own hashing
+func signForwardRequest(t *testing.T, req handlers.RelayRequest) string {
+	t.Helper()
+
+	typed := apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": {
+				{Name: "name", Type: "string"},
+				{Name: "version", Type: "string"},
+				{Name: "chainId", Type: "uint256"},
+				{Name: "verifyingContract", Type: "address"},
+			},
+			"ForwardRequest": {
+				{Name: "from", Type: "address"},
+				{Name: "to", Type: "address"},
+				{Name: "value", Type: "uint256"},
+				{Name: "gas", Type: "uint256"},
+				{Name: "nonce", Type: "uint256"},
+				{Name: "deadline", Type: "uint48"},
+				{Name: "data", Type: "bytes"},
+			},
+		},
+		PrimaryType: "ForwardRequest",
+		Domain: apitypes.TypedDataDomain{
+			Name:              "NexusForwarder",
+			Version:           "1",
+			ChainId:           math.NewHexOrDecimal256(chainID),
+			VerifyingContract: env.forwarder.Hex(),
+		},
+		Message: apitypes.TypedDataMessage{
+			"from":     req.From,
+			"to":       req.To,
+			"value":    req.Value,
+			"gas":      fmt.Sprint(req.Gas),
+			"nonce":    fmt.Sprint(req.Nonce),
+			"deadline": fmt.Sprint(req.Deadline),
+			"data":     req.Data,
+		},
+	}
+
+	digest, _, err := apitypes.TypedDataAndHash(typed)
+	require.NoError(t, err)
+	sig, err := crypto.Sign(digest, signerKey)
+	require.NoError(t, err)
+	sig[64] += 27
+	return hexutil.Encode(sig)
+}
+
+// getNonce reads the signer's next forwarder nonce through the API
+func (s *server) getNonce(address string) uint64 {
+	s.t.Helper()
+
+	var resp struct {
+		Data struct {
+			Nonce  uint64 `json:"nonce"`
+			Source string `json:"source"`
+		} `json:"data"`
+		Error string `json:"error"`
+	}
+	require.Equal(s.t, http.StatusOK, s.request(http.MethodGet, "/api/v1/relay/nonce/"+address, nil, &resp), resp.Error)
+	require.Equal(s.t, "chain", resp.Data.Source)
+	return resp.Data.Nonce
+}
+
+// getMetaTx reads a meta-transaction through the API
+func (s *server) getMetaTx(id string) *repository.MetaTransaction {
+	s.t.Helper()
+
+	var resp struct {
+		Data  *repository.MetaTransaction `json:"data"`
+		Error string                      `json:"error"`
+	}
+	require.Equal(s.t, http.StatusOK, s.request(http.MethodGet, "/api/v1/relay/status/"+id, nil, &resp), resp.Error)
+	return resp.Data
+}
+
+// relayRequest builds an unsigned meta-transaction from the signer to the
+// payer account with the signer's next nonce
+func (s *server) relayRequest() handlers.RelayRequest {
+	from := crypto.PubkeyToAddress(signerKey.PublicKey).Hex()
+	return handlers.RelayRequest{
+		From:         from,
+		To:           crypto.PubkeyToAddress(payerKey.PublicKey).Hex(),
+		Value:        "0",
+		Gas:          100000,
+		Nonce:        s.getNonce(from),
+		Deadline:     uint64(time.Now().Add(time.Hour).Unix()),
+		Data:         "0x",
+		FunctionName: "e2e",
+	}
+}
+
+func TestRelay_ExecutesThroughForwarder(t *testing.T) {
+	requireForwarder(t)
+	s := newServer(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	req := s.relayRequest()
+	req.Signature = signForwardRequest(t, req)
+
+	var resp struct {
+		Success bool `json:"success"`
+		Data    struct {
+			ID     string `json:"id"`
+			TxHash string `json:"tx_hash"`
+		} `json:"data"`
+		Error string `json:"error"`
+	}
+	require.Equal(t, http.StatusOK, s.request(http.MethodPost, "/api/v1/relay", req, &resp), resp.Error)
+	require.True(t, resp.Success)
+
+	tx, _, err := env.eth.TransactionByHash(ctx, common.HexToHash(resp.Data.TxHash))
+	require.NoError(t, err)
+	receipt, err := bind.WaitMined(ctx, env.eth, tx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), receipt.Status)
+	assert.Equal(t, env.forwarder, *tx.To())
+
+	// The forwarder consumed the nonce
+	forwarder, err := contracts.NewNexusForwarder(env.forwarder, env.eth)
+	require.NoError(t, err)
+	nonce, err := forwarder.GetNonce(&bind.CallOpts{Context: ctx}, common.HexToAddress(req.From))
+	require.NoError(t, err)
+	assert.Equal(t, req.Nonce+1, nonce.Uint64())
+	assert.Equal(t, req.Nonce+1, s.getNonce(req.From))
+
+	assert.Equal(t, repository.MetaTxStatusSubmitted, s.getMetaTx(resp.Data.ID).Status)
+
+	s.poll()
+	metaTx := s.getMetaTx(resp.Data.ID)
+	assert.Equal(t, repository.MetaTxStatusConfirmed, metaTx.Status)
+	require.NotNil(t, metaTx.GasUsed)
+	assert.Equal(t, receipt.GasUsed, *metaTx.GasUsed)
+}
+
+func TestRelay_RejectsBadSignatureAndReplay(t *testing.T) {
+	requireForwarder(t)
+	s := newServer(t)
+
+	// Signed for a different nonce than submitted
+	req := s.relayRequest()
+	req.Signature = signForwardRequest(t, req)
+	tampered := req
+	tampered.Nonce++
+
+	var resp struct {
+		Success bool `json:"success"`
+		Data    struct {
+			TxHash string `json:"tx_hash"`
+		} `json:"data"`
+		Error string `json:"error"`
+	}
+	assert.Equal(t, http.StatusBadRequest, s.request(http.MethodPost, "/api/v1/relay", tampered, &resp))
+	assert.Contains(t, resp.Error, "Invalid signature")
+
+	// The genuine request goes through once; the forwarder rejects a replay
+	require.Equal(t, http.StatusOK, s.request(http.MethodPost, "/api/v1/relay", req, &resp), resp.Error)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	tx, _, err := env.eth.TransactionByHash(ctx, common.HexToHash(resp.Data.TxHash))
+	require.NoError(t, err)
+	_, err = bind.WaitMined(ctx, env.eth, tx)
+	require.NoError(t, err)
+
+	resp.Error = ""
+	assert.Equal(t, http.StatusBadRequest, s.request(http.MethodPost, "/api/v1/relay", req, &resp))
+	assert.Contains(t, resp.Error, "rejected by forwarder")
+}
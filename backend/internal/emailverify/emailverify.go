@@ -0,0 +1,277 @@
+// Package emailverify confirms that a wallet's owner controls an email
+// address, the check behind KYCLevelBasic. Each challenge is mailed as both a
+// signed verification link and a six-digit code; either confirms it. Links
+// and codes are HMAC-signed with the signing key (SESSION_SIGNING_KEY), so
+// they are only checked against the challenge they were issued for. The
+// address being verified is held encrypted with the field cipher from the
+// moment it is captured.
+package emailverify
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/mail"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/fieldcrypt"
+)
+
+// EmailField is the field name verified emails are encrypted under
+const EmailField = "kyc_registrations.email"
+
+const (
+	// challengeTTL is how long a link or code stays valid
+	challengeTTL = 15 * time.Minute
+	// resendInterval is the minimum time between two challenges for an address
+	resendInterval = time.Minute
+	// maxAttempts wrong codes end a challenge
+	maxAttempts = 5
+	// maxEmailLength matches the profile email limit
+	maxEmailLength = 254
+	// maxPending bounds the challenges held; expired ones are pruned past it
+	maxPending = 100000
+)
+
+// Verification errors
+var (
+	ErrInvalidEmail     = errors.New("invalid email address")
+	ErrTooSoon          = errors.New("a verification email was sent recently")
+	ErrDelivery         = errors.New("verification email could not be sent")
+	ErrNoChallenge      = errors.New("no email verification is pending")
+	ErrInvalidCode      = errors.New("invalid verification code")
+	ErrTooManyAttempts  = errors.New("too many wrong codes, request a new email")
+	ErrInvalidLink      = errors.New("invalid or expired verification link")
+	ErrChallengeExpired = errors.New("email verification expired, request a new email")
+)
+
+// Mailer sends one plain-text email
+type Mailer interface {
+	SendMail(ctx context.Context, to, subject, body string) error
+}
+
+// Confirmation is a confirmed email. Email is encrypted under EmailField.
+type Confirmation struct {
+	Address    string
+	Email      string
+	VerifiedAt time.Time
+}
+
+// challenge is a pending verification
+type challenge struct {
+	nonce    string
+	email    string // encrypted
+	codeMAC  []byte
+	sentAt   time.Time
+	expires  time.Time
+	attempts int
+}
+
+// Service issues and confirms email challenges
+type Service struct {
+	mailer      Mailer
+	cipher      *fieldcrypt.Cipher
+	signingKey  func() string
+	fallbackKey []byte
+	logger      *zap.Logger
+	now         func() time.Time
+
+	mu      sync.Mutex
+	pending map[string]*challenge // address -> its latest challenge
+}
+
+// NewService creates an email verification service. signingKey is read on
+// every call; without one a random per-process key is used.
+func NewService(mailer Mailer, cipher *fieldcrypt.Cipher, signingKey func() string, logger *zap.Logger) *Service {
+	fallback := make([]byte, 32)
+	if _, err := rand.Read(fallback); err != nil {
+		panic(fmt.Sprintf("emailverify: generating signing key: %v", err))
+	}
+	return &Service{
+		mailer:      mailer,
+		cipher:      cipher,
+		signingKey:  signingKey,
+		fallbackKey: fallback,
+		logger:      logger,
+		now:         time.Now,
+		pending:     make(map[string]*challenge),
+	}
+}
+
+// NormalizeEmail validates a bare address (no display name) and lowercases
+// its domain
+func NormalizeEmail(email string) (string, error) {
+	email = strings.TrimSpace(email)
+	parsed, err := mail.ParseAddress(email)
+	if err != nil || parsed.Address != email || len(email) > maxEmailLength {
+		return "", ErrInvalidEmail
+	}
+	at := strings.LastIndex(email, "@")
+	return email[:at] + strings.ToLower(email[at:]), nil
+}
+
+// mac signs a purpose-prefixed payload with the key in effect
+func (s *Service) mac(purpose string, parts ...string) []byte {
+	key := s.fallbackKey
+	if k := s.signingKey(); k != "" {
+		key = []byte(k)
+	}
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(purpose))
+	for _, p := range parts {
+		h.Write([]byte{0})
+		h.Write([]byte(p))
+	}
+	return h.Sum(nil)
+}
+
+// Start captures email for address and mails it a verification link and
+// code, replacing any earlier challenge. linkURL is the page the link opens;
+// the token is added as its "token" query parameter.
+func (s *Service) Start(ctx context.Context, address, email, linkURL string) (time.Time, error) {
+	address = strings.ToLower(address)
+	email, err := NormalizeEmail(email)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	now := s.now()
+	s.mu.Lock()
+	if prev, ok := s.pending[address]; ok && now.Sub(prev.sentAt) < resendInterval {
+		s.mu.Unlock()
+		return time.Time{}, ErrTooSoon
+	}
+	s.mu.Unlock()
+
+	encrypted, err := s.cipher.Encrypt(EmailField, email)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("encrypting email: %w", err)
+	}
+	nonceBytes := make([]byte, 16)
+	if _, err := rand.Read(nonceBytes); err != nil {
+		return time.Time{}, fmt.Errorf("generating nonce: %w", err)
+	}
+	n, err := rand.Int(rand.Reader, big.NewInt(1000000))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("generating code: %w", err)
+	}
+	code := fmt.Sprintf("%06d", n.Int64())
+
+	ch := &challenge{
+		nonce:   hex.EncodeToString(nonceBytes),
+		email:   encrypted,
+		sentAt:  now,
+		expires: now.Add(challengeTTL).UTC().Truncate(time.Second),
+	}
+	ch.codeMAC = s.mac("code", address, ch.nonce, code)
+
+	link, err := s.link(linkURL, address, ch)
+	if err != nil {
+		return time.Time{}, err
+	}
+	body := fmt.Sprintf("Confirm this email address for wallet %s by opening\n\n%s\n\n"+
+		"or entering the code %s. Both expire at %s.\n\n"+
+		"If you did not ask for this, ignore this email.\n",
+		address, link, code, ch.expires.Format(time.RFC1123))
+	if err := s.mailer.SendMail(ctx, email, "Confirm your email address", body); err != nil {
+		s.logger.Error("failed to send verification email", zap.String("address", address), zap.Error(err))
+		return time.Time{}, ErrDelivery
+	}
+
+	s.mu.Lock()
+	if len(s.pending) >= maxPending {
+		for a, p := range s.pending {
+			if !now.Before(p.expires) {
+				delete(s.pending, a)
+			}
+		}
+	}
+	s.pending[address] = ch
+	s.mu.Unlock()
+	return ch.expires, nil
+}
+
+// link builds the verification link for ch. The token is
+// address.expiry.nonce.mac, so it names its challenge.
+func (s *Service) link(linkURL, address string, ch *challenge) (string, error) {
+	u, err := url.Parse(linkURL)
+	if err != nil {
+		return "", fmt.Errorf("parsing verification link URL: %w", err)
+	}
+	expiry := make([]byte, 8)
+	binary.BigEndian.PutUint64(expiry, uint64(ch.expires.Unix()))
+	token := strings.Join([]string{address, hex.EncodeToString(expiry), ch.nonce,
+		hex.EncodeToString(s.mac("link", address, hex.EncodeToString(expiry), ch.nonce))}, ".")
+	q := u.Query()
+	q.Set("token", token)
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
+
+// ConfirmCode confirms address's pending challenge with the mailed code
+func (s *Service) ConfirmCode(address, code string) (*Confirmation, error) {
+	address = strings.ToLower(address)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ch, ok := s.pending[address]
+	if !ok {
+		return nil, ErrNoChallenge
+	}
+	if !s.now().Before(ch.expires) {
+		delete(s.pending, address)
+		return nil, ErrChallengeExpired
+	}
+	if !hmac.Equal(ch.codeMAC, s.mac("code", address, ch.nonce, strings.TrimSpace(code))) {
+		ch.attempts++
+		if ch.attempts >= maxAttempts {
+			delete(s.pending, address)
+			return nil, ErrTooManyAttempts
+		}
+		return nil, ErrInvalidCode
+	}
+	return s.confirm(address, ch), nil
+}
+
+// ConfirmLink confirms the challenge a verification link's token was issued
+// for. A link from an earlier, replaced challenge is rejected.
+func (s *Service) ConfirmLink(token string) (*Confirmation, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 4 {
+		return nil, ErrInvalidLink
+	}
+	address, expiryHex, nonce, macHex := parts[0], parts[1], parts[2], parts[3]
+	sig, err := hex.DecodeString(macHex)
+	if err != nil || !hmac.Equal(sig, s.mac("link", address, expiryHex, nonce)) {
+		return nil, ErrInvalidLink
+	}
+	expiry, err := hex.DecodeString(expiryHex)
+	if err != nil || len(expiry) != 8 || !s.now().Before(time.Unix(int64(binary.BigEndian.Uint64(expiry)), 0)) {
+		return nil, ErrInvalidLink
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ch, ok := s.pending[address]
+	if !ok || ch.nonce != nonce {
+		return nil, ErrInvalidLink
+	}
+	return s.confirm(address, ch), nil
+}
+
+// confirm ends ch; s.mu must be held
+func (s *Service) confirm(address string, ch *challenge) *Confirmation {
+	delete(s.pending, address)
+	return &Confirmation{Address: address, Email: ch.email, VerifiedAt: s.now().UTC()}
+}
@@ -0,0 +1,141 @@
+package emailverify_test
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/emailverify"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/fieldcrypt"
+)
+
+const (
+	address = "0x00000000000000000000000000000000000000aa"
+	linkURL = "https://app.nexus.example/verify-email"
+	// testKeys is one base64 AES-256 key
+	testKeys = "k1:MDEyMzQ1Njc4OTAxMjM0NTY3ODkwMTIzNDU2Nzg5MDE="
+)
+
+// outbox records sent mail
+type outbox struct {
+	to, body []string
+	err      error
+}
+
+func (o *outbox) SendMail(ctx context.Context, to, subject, body string) error {
+	if o.err != nil {
+		return o.err
+	}
+	o.to = append(o.to, to)
+	o.body = append(o.body, body)
+	return nil
+}
+
+// code and token extract the last email's code and link token
+func (o *outbox) code(t *testing.T) string {
+	m := regexp.MustCompile(`code (\d{6})`).FindStringSubmatch(o.body[len(o.body)-1])
+	require.NotNil(t, m)
+	return m[1]
+}
+
+func (o *outbox) token(t *testing.T) string {
+	m := regexp.MustCompile(regexp.QuoteMeta(linkURL) + `\S+`).FindString(o.body[len(o.body)-1])
+	u, err := url.Parse(m)
+	require.NoError(t, err)
+	return u.Query().Get("token")
+}
+
+func newService(t *testing.T, key string) (*emailverify.Service, *outbox, *fieldcrypt.Cipher) {
+	cipher := fieldcrypt.NewCipher()
+	require.NoError(t, cipher.SetKeys(testKeys))
+	mail := &outbox{}
+	return emailverify.NewService(mail, cipher, func() string { return key }, zap.NewNop()), mail, cipher
+}
+
+func TestConfirmCode(t *testing.T) {
+	svc, mail, cipher := newService(t, "test-key")
+
+	_, err := svc.Start(context.Background(), strings.ToUpper(address), " Alice@Example.COM ", linkURL)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Alice@example.com"}, mail.to)
+
+	_, err = svc.ConfirmCode(address, "not-it")
+	assert.ErrorIs(t, err, emailverify.ErrInvalidCode)
+
+	conf, err := svc.ConfirmCode(address, mail.code(t))
+	require.NoError(t, err)
+	assert.Equal(t, address, conf.Address)
+	assert.NotContains(t, conf.Email, "example.com", "the email is stored encrypted")
+	email, err := cipher.Decrypt(emailverify.EmailField, conf.Email)
+	require.NoError(t, err)
+	assert.Equal(t, "Alice@example.com", email)
+
+	// A challenge confirms once
+	_, err = svc.ConfirmCode(address, mail.code(t))
+	assert.ErrorIs(t, err, emailverify.ErrNoChallenge)
+}
+
+func TestConfirmCode_AttemptsAreLimited(t *testing.T) {
+	svc, mail, _ := newService(t, "test-key")
+	_, err := svc.Start(context.Background(), address, "alice@example.com", linkURL)
+	require.NoError(t, err)
+
+	for i := 0; i < 4; i++ {
+		_, err = svc.ConfirmCode(address, "000000x")
+		assert.ErrorIs(t, err, emailverify.ErrInvalidCode)
+	}
+	_, err = svc.ConfirmCode(address, "000000x")
+	assert.ErrorIs(t, err, emailverify.ErrTooManyAttempts)
+	_, err = svc.ConfirmCode(address, mail.code(t))
+	assert.ErrorIs(t, err, emailverify.ErrNoChallenge)
+}
+
+func TestConfirmLink(t *testing.T) {
+	svc, mail, _ := newService(t, "test-key")
+	_, err := svc.Start(context.Background(), address, "alice@example.com", linkURL)
+	require.NoError(t, err)
+	token := mail.token(t)
+
+	for _, forged := range []string{"", "garbage", strings.Replace(token, address, "0x00000000000000000000000000000000000000bb", 1), token + "00"} {
+		_, err := svc.ConfirmLink(forged)
+		assert.ErrorIs(t, err, emailverify.ErrInvalidLink, forged)
+	}
+	other, _, _ := newService(t, "other-key")
+	_, err = other.ConfirmLink(token)
+	assert.ErrorIs(t, err, emailverify.ErrInvalidLink, "signed under another key")
+
+	conf, err := svc.ConfirmLink(token)
+	require.NoError(t, err)
+	assert.Equal(t, address, conf.Address)
+
+	_, err = svc.ConfirmLink(token)
+	assert.ErrorIs(t, err, emailverify.ErrInvalidLink, "links confirm once")
+}
+
+func TestStart_Rejects(t *testing.T) {
+	ctx := context.Background()
+	svc, mail, _ := newService(t, "test-key")
+
+	for _, bad := range []string{"alice", "Alice <alice@example.com>", strings.Repeat("a", 250) + "@example.com"} {
+		_, err := svc.Start(ctx, address, bad, linkURL)
+		assert.ErrorIs(t, err, emailverify.ErrInvalidEmail, bad)
+	}
+
+	_, err := svc.Start(ctx, address, "alice@example.com", linkURL)
+	require.NoError(t, err)
+	_, err = svc.Start(ctx, address, "alice@example.com", linkURL)
+	assert.ErrorIs(t, err, emailverify.ErrTooSoon)
+
+	mail.err = errors.New("connection refused")
+	_, err = svc.Start(ctx, "0x00000000000000000000000000000000000000bb", "bob@example.com", linkURL)
+	assert.ErrorIs(t, err, emailverify.ErrDelivery)
+	_, err = svc.ConfirmCode("0x00000000000000000000000000000000000000bb", "123456")
+	assert.ErrorIs(t, err, emailverify.ErrNoChallenge, "an unsent challenge is not kept")
+}
@@ -0,0 +1,63 @@
+package emailverify
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+// Ensure SMTPMailer implements Mailer
+var _ Mailer = (*SMTPMailer)(nil)
+
+// SMTPMailer sends mail through an SMTP relay (SMTP_ADDR, host:port) as From,
+// upgrading to TLS when the server offers STARTTLS. Username and Password are
+// optional PLAIN credentials, which net/smtp only sends over TLS or to
+// localhost.
+type SMTPMailer struct {
+	Addr     string
+	From     string
+	Username string
+	Password string
+}
+
+// SendMail implements Mailer
+func (m *SMTPMailer) SendMail(ctx context.Context, to, subject, body string) error {
+	if strings.ContainsAny(to, "\r\n") || strings.ContainsAny(subject, "\r\n") {
+		return fmt.Errorf("header contains a line break")
+	}
+	host, _, err := net.SplitHostPort(m.Addr)
+	if err != nil {
+		return fmt.Errorf("parsing SMTP address: %w", err)
+	}
+
+	var auth smtp.Auth
+	if m.Username != "" {
+		auth = smtp.PlainAuth("", m.Username, m.Password, host)
+	}
+	msg := strings.Join([]string{
+		"From: " + m.From,
+		"To: " + to,
+		"Subject: " + subject,
+		"Date: " + time.Now().Format(time.RFC1123Z),
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=utf-8",
+		"",
+		strings.ReplaceAll(body, "\n", "\r\n"),
+	}, "\r\n")
+
+	// net/smtp takes no context; run it so a cancelled request stops waiting
+	done := make(chan error, 1)
+	go func() { done <- smtp.SendMail(m.Addr, auth, m.From, []string{to}, []byte(msg)) }()
+	select {
+	case err := <-done:
+		if err != nil {
+			return fmt.Errorf("sending mail: %w", err)
+		}
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
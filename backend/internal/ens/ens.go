@@ -0,0 +1,348 @@
+// Package ens resolves ENS names to addresses and addresses to their primary
+// (reverse record) names. Lookups go through the ENS registry at
+// ens.registry_address on ens.chain_id (app_config namespace "ens"; mainnet
+// by default) and are cached for ens.cache_seconds, misses included, so
+// responses can carry names without an RPC call per address.
+package ens
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// namespace is the app_config namespace holding the ENS settings
+const namespace = "ens"
+
+// Defaults for settings missing from app_config
+const (
+	defaultChainID  = 1
+	defaultRegistry = "0x00000000000C2E074eC69A0dFb2997BA6C7d2e1e"
+	defaultCacheTTL = 15 * time.Minute
+)
+
+const (
+	// maxLookups caps how many addresses one LookupNames call resolves
+	maxLookups = 50
+
+	// maxCached bounds each cache; expired entries are dropped when it fills
+	maxCached = 10000
+)
+
+// ENS errors
+var (
+	ErrInvalidName    = errors.New("invalid ENS name")
+	ErrInvalidAddress = errors.New("invalid address")
+	ErrNotFound       = errors.New("name not found")
+	ErrUnavailable    = errors.New("ENS resolution unavailable")
+)
+
+// ensABIJSON is the part of the ENS registry and public resolver the service
+// calls
+const ensABIJSON = `[
+	{"type":"function","name":"resolver","stateMutability":"view","inputs":[{"name":"node","type":"bytes32"}],"outputs":[{"name":"","type":"address"}]},
+	{"type":"function","name":"addr","stateMutability":"view","inputs":[{"name":"node","type":"bytes32"}],"outputs":[{"name":"","type":"address"}]},
+	{"type":"function","name":"name","stateMutability":"view","inputs":[{"name":"node","type":"bytes32"}],"outputs":[{"name":"","type":"string"}]}
+]`
+
+var ensABI = mustParseABI(ensABIJSON)
+
+func mustParseABI(s string) abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(s))
+	if err != nil {
+		panic(fmt.Sprintf("ens: invalid ABI: %v", err))
+	}
+	return parsed
+}
+
+// Client is the chain access the service needs (implemented by
+// chain.FailoverClient)
+type Client interface {
+	CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error)
+}
+
+// ClientFunc returns the client for a chain (chain.ClientManager.Client)
+type ClientFunc func(ctx context.Context, chainID int64) (Client, error)
+
+// Settings are the ENS settings in effect
+type Settings struct {
+	ChainID  int64         `json:"chain_id"`
+	Registry string        `json:"registry"`
+	CacheTTL time.Duration `json:"cache_ttl"`
+}
+
+// entry is a cached lookup: a name or address, or ErrNotFound
+type entry struct {
+	value   string
+	err     error
+	expires time.Time
+}
+
+// Service resolves and caches ENS lookups
+type Service struct {
+	configRepo repository.AppConfigRepository
+	clients    ClientFunc
+	logger     *zap.Logger
+	now        func() time.Time
+
+	mu        sync.Mutex
+	addresses map[string]entry // name -> address
+	names     map[string]entry // address -> primary name
+}
+
+// NewService creates a new ENS resolver
+func NewService(configRepo repository.AppConfigRepository, clients ClientFunc, logger *zap.Logger) *Service {
+	return &Service{
+		configRepo: configRepo,
+		clients:    clients,
+		logger:     logger,
+		now:        time.Now,
+		addresses:  make(map[string]entry),
+		names:      make(map[string]entry),
+	}
+}
+
+// Settings loads the ENS settings, falling back to defaults for missing or
+// unreadable ones
+func (s *Service) Settings(ctx context.Context) Settings {
+	settings := Settings{ChainID: defaultChainID, Registry: defaultRegistry, CacheTTL: defaultCacheTTL}
+	if chainID, err := s.configRepo.GetNumber(ctx, namespace, "chain_id", 0); err == nil && chainID > 0 {
+		settings.ChainID = chainID
+	}
+	if registry, err := s.configRepo.GetString(ctx, namespace, "registry_address", 0); err == nil && common.IsHexAddress(registry) {
+		settings.Registry = registry
+	}
+	if seconds, err := s.configRepo.GetNumber(ctx, namespace, "cache_seconds", 0); err == nil && seconds >= 0 {
+		settings.CacheTTL = time.Duration(seconds) * time.Second
+	}
+	return settings
+}
+
+// Normalize lowercases and validates a name. Full ENSIP-15 normalization is
+// not applied: names with characters outside what it would accept
+// unchanged are rejected rather than mapped.
+func Normalize(name string) (string, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" || len(name) > 255 {
+		return "", ErrInvalidName
+	}
+	for _, label := range strings.Split(name, ".") {
+		if label == "" {
+			return "", ErrInvalidName
+		}
+		for _, r := range label {
+			if r <= ' ' || strings.ContainsRune("/\\?#%@:\"'<>", r) {
+				return "", ErrInvalidName
+			}
+		}
+	}
+	return name, nil
+}
+
+// Namehash returns the ENS node of a normalized name
+func Namehash(name string) common.Hash {
+	var node common.Hash
+	if name == "" {
+		return node
+	}
+	labels := strings.Split(name, ".")
+	for i := len(labels) - 1; i >= 0; i-- {
+		label := crypto.Keccak256Hash([]byte(labels[i]))
+		node = crypto.Keccak256Hash(node.Bytes(), label.Bytes())
+	}
+	return node
+}
+
+// reverseNode is the node of an address's reverse record
+func reverseNode(address common.Address) common.Hash {
+	return Namehash(strings.ToLower(strings.TrimPrefix(address.Hex(), "0x")) + ".addr.reverse")
+}
+
+// Resolve returns the address a name resolves to
+func (s *Service) Resolve(ctx context.Context, name string) (string, error) {
+	name, err := Normalize(name)
+	if err != nil {
+		return "", err
+	}
+	return s.cached(ctx, s.addresses, name, func(ctx context.Context, settings Settings, client Client) (string, error) {
+		return s.resolve(ctx, settings, client, name)
+	})
+}
+
+// Reverse returns the primary name of an address. The name is only returned
+// if it resolves back to the address.
+func (s *Service) Reverse(ctx context.Context, address string) (string, error) {
+	if !common.IsHexAddress(address) {
+		return "", ErrInvalidAddress
+	}
+	address = strings.ToLower(address)
+	return s.cached(ctx, s.names, address, func(ctx context.Context, settings Settings, client Client) (string, error) {
+		return s.reverse(ctx, settings, client, common.HexToAddress(address))
+	})
+}
+
+// LookupNames returns the primary names of those addresses that have one.
+// Lookups that fail are left out. Only the first maxLookups distinct
+// addresses are resolved.
+func (s *Service) LookupNames(ctx context.Context, addresses []string) map[string]string {
+	names := make(map[string]string)
+	seen := make(map[string]bool)
+	for _, address := range addresses {
+		address = strings.ToLower(address)
+		if seen[address] || !common.IsHexAddress(address) {
+			continue
+		}
+		if len(seen) == maxLookups {
+			break
+		}
+		seen[address] = true
+
+		name, err := s.Reverse(ctx, address)
+		if errors.Is(err, ErrUnavailable) {
+			// Don't wait on every address while ENS is unreachable
+			s.logger.Debug("ENS lookups skipped", zap.Error(err))
+			break
+		}
+		if err == nil {
+			names[address] = name
+		}
+	}
+	return names
+}
+
+// cached serves key from cache, or looks it up and caches the answer.
+// Unavailability is not cached.
+func (s *Service) cached(ctx context.Context, cache map[string]entry, key string, lookup func(context.Context, Settings, Client) (string, error)) (string, error) {
+	now := s.now()
+	s.mu.Lock()
+	e, ok := cache[key]
+	s.mu.Unlock()
+	if ok && now.Before(e.expires) {
+		return e.value, e.err
+	}
+
+	settings := s.Settings(ctx)
+	client, err := s.clients(ctx, settings.ChainID)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrUnavailable, err)
+	}
+	value, err := lookup(ctx, settings, client)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return "", err
+	}
+
+	s.mu.Lock()
+	if len(cache) >= maxCached {
+		for k, e := range cache {
+			if !now.Before(e.expires) {
+				delete(cache, k)
+			}
+		}
+	}
+	if len(cache) < maxCached {
+		cache[key] = entry{value: value, err: err, expires: now.Add(settings.CacheTTL)}
+	}
+	s.mu.Unlock()
+	return value, err
+}
+
+// resolve looks up a name's address record
+func (s *Service) resolve(ctx context.Context, settings Settings, client Client, name string) (string, error) {
+	node := Namehash(name)
+	resolver, err := s.resolver(ctx, settings, client, node)
+	if err != nil {
+		return "", err
+	}
+	out, err := call(ctx, client, resolver, "addr", node)
+	if err != nil {
+		return "", err
+	}
+	address, _ := out[0].(common.Address)
+	if address == (common.Address{}) {
+		return "", ErrNotFound
+	}
+	return strings.ToLower(address.Hex()), nil
+}
+
+// reverse looks up an address's reverse record and verifies it resolves
+// back to the address
+func (s *Service) reverse(ctx context.Context, settings Settings, client Client, address common.Address) (string, error) {
+	node := reverseNode(address)
+	resolver, err := s.resolver(ctx, settings, client, node)
+	if err != nil {
+		return "", err
+	}
+	out, err := call(ctx, client, resolver, "name", node)
+	if err != nil {
+		return "", err
+	}
+	name, _ := out[0].(string)
+	if name, err = Normalize(name); err != nil {
+		return "", ErrNotFound
+	}
+
+	forward, err := s.resolve(ctx, settings, client, name)
+	if err != nil {
+		return "", err
+	}
+	if forward != strings.ToLower(address.Hex()) {
+		return "", ErrNotFound
+	}
+	return name, nil
+}
+
+// resolver returns the resolver the registry has set for node
+func (s *Service) resolver(ctx context.Context, settings Settings, client Client, node common.Hash) (common.Address, error) {
+	out, err := call(ctx, client, common.HexToAddress(settings.Registry), "resolver", node)
+	if err != nil {
+		return common.Address{}, err
+	}
+	resolver, _ := out[0].(common.Address)
+	if resolver == (common.Address{}) {
+		return common.Address{}, ErrNotFound
+	}
+	return resolver, nil
+}
+
+// call calls a read-only registry or resolver method. A resolver that reverts
+// or returns nothing has no record.
+func call(ctx context.Context, client Client, address common.Address, method string, node common.Hash) ([]interface{}, error) {
+	data, err := ensABI.Pack(method, node)
+	if err != nil {
+		return nil, err
+	}
+	result, err := client.CallContract(ctx, ethereum.CallMsg{To: &address, Data: data}, nil)
+	if err != nil {
+		if isRevert(err) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("%w: %s: %v", ErrUnavailable, method, err)
+	}
+	if len(result) == 0 {
+		return nil, ErrNotFound
+	}
+	out, err := ensABI.Unpack(method, result)
+	if err != nil || len(out) == 0 {
+		return nil, ErrNotFound
+	}
+	return out, nil
+}
+
+// isRevert reports whether a call failed because the contract reverted
+func isRevert(err error) bool {
+	var rpcErr rpc.Error
+	return errors.As(err, &rpcErr) && strings.Contains(rpcErr.Error(), "revert")
+}
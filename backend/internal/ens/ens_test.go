@@ -0,0 +1,160 @@
+package ens_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/ens"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/storage/memory"
+)
+
+const (
+	registry = "0x00000000000C2E074eC69A0dFb2997BA6C7d2e1e"
+	resolver = "0x00000000000000000000000000000000000000ee"
+
+	alice   = "0x1111111111111111111111111111111111111111"
+	mallory = "0x3333333333333333333333333333333333333333"
+)
+
+var (
+	resolverSelector = crypto.Keccak256([]byte("resolver(bytes32)"))[:4]
+	addrSelector     = crypto.Keccak256([]byte("addr(bytes32)"))[:4]
+	nameSelector     = crypto.Keccak256([]byte("name(bytes32)"))[:4]
+)
+
+// fakeENS is a registry with one resolver holding address and name records
+type fakeENS struct {
+	mu    sync.Mutex
+	addrs map[common.Hash]common.Address
+	names map[common.Hash]string
+	calls int
+	err   error
+}
+
+func (f *fakeENS) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls++
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	node := common.BytesToHash(call.Data[4:36])
+	_, hasAddr := f.addrs[node]
+	_, hasName := f.names[node]
+	switch {
+	case *call.To == common.HexToAddress(registry) && bytes.Equal(call.Data[:4], resolverSelector):
+		if hasAddr || hasName {
+			return common.LeftPadBytes(common.HexToAddress(resolver).Bytes(), 32), nil
+		}
+		return make([]byte, 32), nil
+	case *call.To == common.HexToAddress(resolver) && bytes.Equal(call.Data[:4], addrSelector):
+		return common.LeftPadBytes(f.addrs[node].Bytes(), 32), nil
+	case *call.To == common.HexToAddress(resolver) && bytes.Equal(call.Data[:4], nameSelector):
+		stringType, _ := abi.NewType("string", "", nil)
+		return abi.Arguments{{Type: stringType}}.Pack(f.names[node])
+	}
+	return nil, errors.New("unexpected call")
+}
+
+func reverseNode(address string) common.Hash {
+	return ens.Namehash(strings.TrimPrefix(strings.ToLower(address), "0x") + ".addr.reverse")
+}
+
+func newService(t *testing.T) (*ens.Service, *fakeENS) {
+	t.Helper()
+	store := memory.NewSeededStore()
+	fake := &fakeENS{
+		addrs: map[common.Hash]common.Address{
+			ens.Namehash("alice.eth"): common.HexToAddress(alice),
+		},
+		names: map[common.Hash]string{
+			reverseNode(alice): "Alice.eth",
+			// Claims a name that resolves elsewhere
+			reverseNode(mallory): "alice.eth",
+		},
+	}
+	clients := func(ctx context.Context, chainID int64) (ens.Client, error) {
+		require.Equal(t, int64(1), chainID)
+		return fake, nil
+	}
+	return ens.NewService(store.AppConfig, clients, zap.NewNop()), fake
+}
+
+func TestNamehash(t *testing.T) {
+	assert.Equal(t, common.Hash{}, ens.Namehash(""))
+	assert.Equal(t, "0x93cdeb708b7545dc668eb9280176169d1c33cfd8ed6f04690a0bcc88a93fc4ae", ens.Namehash("eth").Hex())
+	assert.Equal(t, "0xde9b09fd7c5f901e23a3f19fecc54828e9c848539801e86591bd9801b019f84f", ens.Namehash("foo.eth").Hex())
+}
+
+func TestNormalize(t *testing.T) {
+	name, err := ens.Normalize(" Alice.ETH ")
+	require.NoError(t, err)
+	assert.Equal(t, "alice.eth", name)
+
+	for _, bad := range []string{"", "alice..eth", ".eth", "al ice.eth", "alice.eth/x"} {
+		_, err := ens.Normalize(bad)
+		assert.ErrorIs(t, err, ens.ErrInvalidName, bad)
+	}
+}
+
+func TestResolve_CachesAnswersAndMisses(t *testing.T) {
+	svc, fake := newService(t)
+	ctx := context.Background()
+
+	address, err := svc.Resolve(ctx, "ALICE.eth")
+	require.NoError(t, err)
+	assert.Equal(t, alice, address)
+
+	_, err = svc.Resolve(ctx, "nobody.eth")
+	assert.ErrorIs(t, err, ens.ErrNotFound)
+
+	calls := fake.calls
+	_, err = svc.Resolve(ctx, "alice.eth")
+	require.NoError(t, err)
+	_, err = svc.Resolve(ctx, "nobody.eth")
+	assert.ErrorIs(t, err, ens.ErrNotFound)
+	assert.Equal(t, calls, fake.calls)
+}
+
+func TestReverse_VerifiesForwardRecord(t *testing.T) {
+	svc, _ := newService(t)
+	ctx := context.Background()
+
+	name, err := svc.Reverse(ctx, strings.ToUpper(alice[2:]))
+	require.NoError(t, err)
+	assert.Equal(t, "alice.eth", name)
+
+	_, err = svc.Reverse(ctx, mallory)
+	assert.ErrorIs(t, err, ens.ErrNotFound)
+
+	_, err = svc.Reverse(ctx, "0x123")
+	assert.ErrorIs(t, err, ens.ErrInvalidAddress)
+}
+
+func TestLookupNames(t *testing.T) {
+	svc, fake := newService(t)
+	ctx := context.Background()
+
+	names := svc.LookupNames(ctx, []string{alice, mallory, alice, "not an address"})
+	assert.Equal(t, map[string]string{alice: "alice.eth"}, names)
+
+	// The rest are skipped once ENS is unreachable
+	svc, fake = newService(t)
+	fake.err = errors.New("connection refused")
+	assert.Empty(t, svc.LookupNames(ctx, []string{alice, mallory}))
+	assert.Equal(t, 1, fake.calls)
+}
@@ -0,0 +1,109 @@
+// Package errreport sends recovered panics to an error tracker. Reporter is
+// the extension point; Sentry is the bundled implementation.
+package errreport
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/getsentry/sentry-go"
+)
+
+// Panic describes a panic recovered while serving a request. Request data is
+// already scrubbed of credentials and signatures.
+type Panic struct {
+	Value     interface{}
+	Stack     []byte
+	RequestID string
+	Route     string // route pattern, e.g. /api/v1/relay/status/:id
+	Method    string
+	URL       string
+	Query     string
+	Headers   map[string]string
+}
+
+// Message renders the panic value
+func (p *Panic) Message() string {
+	if err, ok := p.Value.(error); ok {
+		return err.Error()
+	}
+	return fmt.Sprint(p.Value)
+}
+
+// Reporter sends panics to an error tracker. ReportPanic is called from the
+// recovering goroutine while the panicking frames are still on the stack; it
+// must not block on the network.
+type Reporter interface {
+	ReportPanic(ctx context.Context, p *Panic)
+}
+
+// Nop discards reports
+type Nop struct{}
+
+// ReportPanic implements Reporter
+func (Nop) ReportPanic(context.Context, *Panic) {}
+
+// SentryConfig configures the Sentry reporter
+type SentryConfig struct {
+	DSN         string
+	Environment string
+	Release     string
+
+	// Transport overrides the HTTP transport (nil sends to the DSN)
+	Transport sentry.Transport
+}
+
+// Sentry reports panics to Sentry. Events are queued and sent in the
+// background; call Flush before exiting.
+type Sentry struct {
+	hub *sentry.Hub
+}
+
+// NewSentry creates a Sentry reporter
+func NewSentry(cfg SentryConfig) (*Sentry, error) {
+	client, err := sentry.NewClient(sentry.ClientOptions{
+		Dsn:         cfg.DSN,
+		Environment: cfg.Environment,
+		Release:     cfg.Release,
+		Transport:   cfg.Transport,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating sentry client: %w", err)
+	}
+	return &Sentry{hub: sentry.NewHub(client, sentry.NewScope())}, nil
+}
+
+// ReportPanic implements Reporter
+func (s *Sentry) ReportPanic(ctx context.Context, p *Panic) {
+	handled := false
+	event := sentry.NewEvent()
+	event.Level = sentry.LevelFatal
+	event.Message = p.Message()
+	event.Transaction = p.Method + " " + p.Route
+	event.Exception = []sentry.Exception{{
+		Type:  fmt.Sprintf("panic: %T", p.Value),
+		Value: p.Message(),
+		// Built here, before the panicking frames unwind
+		Stacktrace: sentry.NewStacktrace(),
+		Mechanism:  &sentry.Mechanism{Type: "gin.recovery", Handled: &handled},
+	}}
+	event.Tags = map[string]string{
+		"request_id": p.RequestID,
+		"route":      p.Route,
+		"method":     p.Method,
+	}
+	event.Request = &sentry.Request{
+		URL:         p.URL,
+		Method:      p.Method,
+		QueryString: p.Query,
+		Headers:     p.Headers,
+	}
+
+	s.hub.CaptureEvent(event)
+}
+
+// Flush waits up to timeout for queued events to be sent
+func (s *Sentry) Flush(timeout time.Duration) bool {
+	return s.hub.Flush(timeout)
+}
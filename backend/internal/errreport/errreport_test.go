@@ -0,0 +1,78 @@
+package errreport_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/errreport"
+)
+
+// captureTransport keeps events instead of sending them
+type captureTransport struct {
+	mu     sync.Mutex
+	events []*sentry.Event
+}
+
+func (t *captureTransport) Configure(sentry.ClientOptions) {}
+func (t *captureTransport) Flush(time.Duration) bool       { return true }
+func (t *captureTransport) SendEvent(event *sentry.Event) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.events = append(t.events, event)
+}
+
+func TestSentry_ReportPanic(t *testing.T) {
+	transport := &captureTransport{}
+	reporter, err := errreport.NewSentry(errreport.SentryConfig{
+		DSN:         "https://public@sentry.example.com/1",
+		Environment: "test",
+		Release:     "v1.2.3",
+		Transport:   transport,
+	})
+	require.NoError(t, err)
+
+	reporter.ReportPanic(context.Background(), &errreport.Panic{
+		Value:     errors.New("nil map write"),
+		RequestID: "req-1",
+		Route:     "/api/v1/relay/status/:id",
+		Method:    "GET",
+		URL:       "https://api.example.com/api/v1/relay/status/42",
+		Query:     "fields=id",
+		Headers:   map[string]string{"Authorization": "[REDACTED]"},
+	})
+	require.True(t, reporter.Flush(time.Second))
+
+	require.Len(t, transport.events, 1)
+	event := transport.events[0]
+	assert.Equal(t, sentry.LevelFatal, event.Level)
+	assert.Equal(t, "test", event.Environment)
+	assert.Equal(t, "v1.2.3", event.Release)
+	assert.Equal(t, "GET /api/v1/relay/status/:id", event.Transaction)
+	assert.Equal(t, map[string]string{
+		"request_id": "req-1",
+		"route":      "/api/v1/relay/status/:id",
+		"method":     "GET",
+	}, event.Tags)
+	assert.Equal(t, "fields=id", event.Request.QueryString)
+	assert.Equal(t, "[REDACTED]", event.Request.Headers["Authorization"])
+
+	require.Len(t, event.Exception, 1)
+	exception := event.Exception[0]
+	assert.Equal(t, "nil map write", exception.Value)
+	require.NotNil(t, exception.Mechanism.Handled)
+	assert.False(t, *exception.Mechanism.Handled)
+	require.NotNil(t, exception.Stacktrace)
+	assert.NotEmpty(t, exception.Stacktrace.Frames)
+}
+
+func TestNewSentry_InvalidDSN(t *testing.T) {
+	_, err := errreport.NewSentry(errreport.SentryConfig{DSN: "not a dsn"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,159 @@
+// Package expiry lapses pending payments that were never paid - abandoned
+// Stripe checkouts, crypto payments whose transfer never arrived - once they
+// pass the configured window, so they stop showing up as open
+package expiry
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Expiry defaults (overridable via app_config namespace "payment_expiry")
+const (
+	// Stripe checkout sessions themselves expire after 24 hours
+	defaultStripeMinutes = 24 * 60
+	defaultCryptoMinutes = 24 * 60
+	defaultBatchSize     = 500
+
+	// DefaultInterval is how often Run expires payments when no interval is
+	// given
+	DefaultInterval = 5 * time.Minute
+
+	// maxBatchesPerRun bounds one pass per payment method
+	maxBatchesPerRun = 100
+)
+
+// windows maps each config key to the payment methods it applies to
+var windows = []struct {
+	key      string
+	fallback int64
+	methods  []string
+}{
+	{"stripe_minutes", defaultStripeMinutes, []string{"stripe"}},
+	{"crypto_minutes", defaultCryptoMinutes, []string{"eth", "nexus"}},
+}
+
+// RunStats summarises one expiry pass
+type RunStats struct {
+	StartedAt time.Time        `json:"started_at"`
+	Duration  string           `json:"duration"`
+	Expired   map[string]int64 `json:"expired"` // by payment method
+	Errors    []string         `json:"errors,omitempty"`
+}
+
+// Expirer periodically moves stale pending payments to expired
+type Expirer struct {
+	payments   repository.PaymentRepository
+	configRepo repository.AppConfigRepository
+	logger     *zap.Logger
+}
+
+// NewExpirer creates a new expirer. Pass the notifying payment repository so
+// dapps are told about payments that expire.
+func NewExpirer(payments repository.PaymentRepository, configRepo repository.AppConfigRepository, logger *zap.Logger) *Expirer {
+	return &Expirer{
+		payments:   payments,
+		configRepo: configRepo,
+		logger:     logger,
+	}
+}
+
+// Run expires payments every interval until ctx is cancelled
+func (e *Expirer) Run(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	e.RunOnce(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			e.RunOnce(ctx)
+		}
+	}
+}
+
+// RunOnce performs a single expiry pass
+func (e *Expirer) RunOnce(ctx context.Context) RunStats {
+	start := time.Now()
+	stats := RunStats{StartedAt: start, Expired: map[string]int64{}}
+	batchSize := int(e.getConfigNumber(ctx, "batch_size", defaultBatchSize))
+	if batchSize <= 0 {
+		batchSize = defaultBatchSize
+	}
+
+	var total int64
+	for _, w := range windows {
+		minutes := e.getConfigNumber(ctx, w.key, w.fallback)
+		if minutes <= 0 {
+			continue
+		}
+		cutoff := start.Add(-time.Duration(minutes) * time.Minute)
+		for _, method := range w.methods {
+			expired, err := e.expire(ctx, method, cutoff, batchSize)
+			stats.Expired[method] = expired
+			total += expired
+			if err != nil {
+				stats.Errors = append(stats.Errors, method+": "+err.Error())
+				e.logger.Error("failed to expire payments",
+					zap.String("payment_method", method),
+					zap.Int64("expired", expired),
+					zap.Error(err),
+				)
+			}
+		}
+	}
+
+	stats.Duration = time.Since(start).Round(time.Millisecond).String()
+	if total > 0 {
+		e.logger.Info("expired unpaid payments",
+			zap.Any("by_method", stats.Expired),
+			zap.String("duration", stats.Duration),
+		)
+	}
+	return stats
+}
+
+// expire expires the method's payments created before cutoff in batches
+// until less than a full batch is left, ctx ends or the per-run batch limit
+// is reached
+func (e *Expirer) expire(ctx context.Context, method string, cutoff time.Time, batchSize int) (int64, error) {
+	var total int64
+	for i := 0; i < maxBatchesPerRun; i++ {
+		if ctx.Err() != nil {
+			return total, nil
+		}
+		ids, err := e.payments.ExpirePayments(ctx, method, cutoff, batchSize)
+		total += int64(len(ids))
+		if err != nil {
+			if ctx.Err() != nil {
+				return total, nil
+			}
+			return total, err
+		}
+		if len(ids) < batchSize {
+			break
+		}
+	}
+	return total, nil
+}
+
+// getConfigNumber reads a payment_expiry config value with a code fallback
+func (e *Expirer) getConfigNumber(ctx context.Context, key string, fallback int64) int64 {
+	if e.configRepo == nil {
+		return fallback
+	}
+	v, err := e.configRepo.GetNumber(ctx, "payment_expiry", key, 0)
+	if err != nil {
+		return fallback
+	}
+	return v
+}
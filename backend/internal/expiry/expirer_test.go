@@ -0,0 +1,90 @@
+package expiry_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/expiry"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/storage/memory"
+)
+
+// stubPayments expires from a fixed backlog per method and records each
+// call's cutoff
+type stubPayments struct {
+	repository.PaymentRepository
+	backlog map[string]int
+	calls   map[string][]time.Time
+	err     error
+}
+
+func (s *stubPayments) ExpirePayments(ctx context.Context, method string, before time.Time, limit int) ([]string, error) {
+	if s.calls == nil {
+		s.calls = map[string][]time.Time{}
+	}
+	s.calls[method] = append(s.calls[method], before)
+	if s.err != nil {
+		return nil, s.err
+	}
+	n := min(s.backlog[method], limit)
+	s.backlog[method] -= n
+	ids := make([]string, n)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("%s-%d", method, i)
+	}
+	return ids, nil
+}
+
+func setNumber(t *testing.T, cfg *memory.MemoryAppConfigRepo, key string, v int64) {
+	t.Helper()
+	require.NoError(t, cfg.Create(context.Background(), &repository.AppConfigCreate{
+		Namespace:   "payment_expiry",
+		ConfigKey:   key,
+		ValueType:   "number",
+		ValueNumber: &v,
+	}))
+}
+
+func TestExpirer_ExpiresEachMethodInBatches(t *testing.T) {
+	cfg := memory.NewMemoryAppConfigRepo()
+	setNumber(t, cfg, "batch_size", 10)
+	setNumber(t, cfg, "stripe_minutes", 30)
+
+	payments := &stubPayments{backlog: map[string]int{"stripe": 25, "eth": 3}}
+	stats := expiry.NewExpirer(payments, cfg, zap.NewNop()).RunOnce(context.Background())
+
+	assert.Equal(t, map[string]int64{"stripe": 25, "eth": 3, "nexus": 0}, stats.Expired)
+	assert.Len(t, payments.calls["stripe"], 3, "two full batches then a partial one")
+	assert.Len(t, payments.calls["eth"], 1)
+	assert.Empty(t, stats.Errors)
+
+	assert.WithinDuration(t, time.Now().Add(-30*time.Minute), payments.calls["stripe"][0], time.Minute)
+	assert.WithinDuration(t, time.Now().Add(-24*time.Hour), payments.calls["eth"][0], time.Minute, "crypto uses the default window")
+}
+
+func TestExpirer_ZeroMinutesDisables(t *testing.T) {
+	cfg := memory.NewMemoryAppConfigRepo()
+	setNumber(t, cfg, "crypto_minutes", 0)
+
+	payments := &stubPayments{backlog: map[string]int{"eth": 5}}
+	stats := expiry.NewExpirer(payments, cfg, zap.NewNop()).RunOnce(context.Background())
+
+	assert.Len(t, payments.calls["stripe"], 1)
+	assert.NotContains(t, payments.calls, "eth")
+	assert.NotContains(t, stats.Expired, "eth")
+}
+
+func TestExpirer_ReportsErrors(t *testing.T) {
+	payments := &stubPayments{err: errors.New("connection refused")}
+	stats := expiry.NewExpirer(payments, nil, zap.NewNop()).RunOnce(context.Background())
+
+	require.Len(t, stats.Errors, 3)
+	assert.Contains(t, stats.Errors[0], "stripe: connection refused")
+}
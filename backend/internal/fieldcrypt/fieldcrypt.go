@@ -0,0 +1,246 @@
+// Package fieldcrypt encrypts individual database fields with AES-256-GCM.
+//
+// An encrypted value is stored as "enc:v1:<key id>:<base64 nonce||ciphertext>",
+// so rows written under an older key stay readable after a rotation as long
+// as that key is still configured, and values written before encryption was
+// enabled (no prefix) are returned as they are. Each value is bound to its
+// field name, so a ciphertext copied into another column does not decrypt.
+//
+// Fields that are looked up by value use deterministic encryption (the nonce
+// is derived from the plaintext), which reveals only whether two values are
+// equal.
+package fieldcrypt
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// prefix marks an encrypted value
+const prefix = "enc:v1:"
+
+// KeySize is the length of an encryption key in bytes (AES-256)
+const KeySize = 32
+
+// ErrUnknownKey is returned when a value was encrypted under a key that is not
+// configured
+var ErrUnknownKey = errors.New("field encrypted with an unknown key")
+
+// ErrMalformed is returned for a value with the encryption prefix that cannot
+// be parsed or authenticated
+var ErrMalformed = errors.New("malformed encrypted field")
+
+var keyIDPattern = regexp.MustCompile(`^[A-Za-z0-9._-]{1,32}$`)
+
+// key is one AES-GCM key and the key its deterministic nonces are derived with
+type key struct {
+	id       string
+	aead     cipher.AEAD
+	nonceKey []byte
+}
+
+// Cipher encrypts and decrypts fields. Keys are replaced at runtime with
+// SetKeys; until keys are set, values are stored unencrypted.
+type Cipher struct {
+	mu      sync.RWMutex
+	current *key
+	keys    map[string]*key
+}
+
+// NewCipher creates a cipher with no keys
+func NewCipher() *Cipher {
+	return &Cipher{}
+}
+
+// SetKeys replaces the keys from a comma-separated list of <id>:<base64 key>
+// pairs. The first key encrypts; the others only decrypt, for values written
+// before a rotation. An empty spec removes all keys.
+func (c *Cipher) SetKeys(spec string) error {
+	var (
+		current *key
+		keys    = make(map[string]*key)
+	)
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		id, encoded, ok := strings.Cut(entry, ":")
+		if !ok || !keyIDPattern.MatchString(id) {
+			return fmt.Errorf("invalid field encryption key %q: expected <id>:<base64 key>", id)
+		}
+		if _, dup := keys[id]; dup {
+			return fmt.Errorf("duplicate field encryption key id %q", id)
+		}
+		secret, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil || len(secret) != KeySize {
+			return fmt.Errorf("field encryption key %q must be %d bytes, base64-encoded", id, KeySize)
+		}
+		k, err := newKey(id, secret)
+		if err != nil {
+			return err
+		}
+		keys[id] = k
+		if current == nil {
+			current = k
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.current = current
+	c.keys = keys
+	return nil
+}
+
+func newKey(id string, secret []byte) (*key, error) {
+	block, err := aes.NewCipher(secret)
+	if err != nil {
+		return nil, fmt.Errorf("field encryption key %q: %w", id, err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("field encryption key %q: %w", id, err)
+	}
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte("fieldcrypt deterministic nonce"))
+	return &key{id: id, aead: aead, nonceKey: mac.Sum(nil)}, nil
+}
+
+// Enabled reports whether an encryption key is configured
+func (c *Cipher) Enabled() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.current != nil
+}
+
+// CurrentKeyID returns the ID of the key new values are encrypted with ("" if
+// none)
+func (c *Cipher) CurrentKeyID() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.current == nil {
+		return ""
+	}
+	return c.current.id
+}
+
+// Encrypt encrypts plaintext for field with a random nonce. Without a key the
+// plaintext is returned unchanged.
+func (c *Cipher) Encrypt(field, plaintext string) (string, error) {
+	c.mu.RLock()
+	k := c.current
+	c.mu.RUnlock()
+	if k == nil {
+		return plaintext, nil
+	}
+
+	nonce := make([]byte, k.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("generating nonce: %w", err)
+	}
+	return k.seal(field, plaintext, nonce), nil
+}
+
+// EncryptDeterministic encrypts plaintext for field so that equal plaintexts
+// give equal ciphertexts under the same key, for fields that are queried by
+// value. Without a key the plaintext is returned unchanged.
+func (c *Cipher) EncryptDeterministic(field, plaintext string) string {
+	c.mu.RLock()
+	k := c.current
+	c.mu.RUnlock()
+	if k == nil {
+		return plaintext
+	}
+	return k.seal(field, plaintext, k.deterministicNonce(field, plaintext))
+}
+
+// Candidates returns every form a deterministically encrypted value may be
+// stored in: encrypted under each configured key (current first), then the
+// plaintext for rows written before encryption was enabled
+func (c *Cipher) Candidates(field, plaintext string) []string {
+	c.mu.RLock()
+	current, keys := c.current, c.keys
+	c.mu.RUnlock()
+
+	candidates := make([]string, 0, len(keys)+1)
+	if current != nil {
+		candidates = append(candidates, current.seal(field, plaintext, current.deterministicNonce(field, plaintext)))
+	}
+	for _, k := range keys {
+		if k != current {
+			candidates = append(candidates, k.seal(field, plaintext, k.deterministicNonce(field, plaintext)))
+		}
+	}
+	return append(candidates, plaintext)
+}
+
+// Decrypt decrypts a value of field. Values without the encryption prefix
+// are returned unchanged.
+func (c *Cipher) Decrypt(field, value string) (string, error) {
+	if !IsEncrypted(value) {
+		return value, nil
+	}
+	id, encoded, ok := strings.Cut(strings.TrimPrefix(value, prefix), ":")
+	if !ok {
+		return "", ErrMalformed
+	}
+
+	c.mu.RLock()
+	k := c.keys[id]
+	c.mu.RUnlock()
+	if k == nil {
+		return "", fmt.Errorf("%w %q", ErrUnknownKey, id)
+	}
+
+	sealed, err := base64.RawStdEncoding.DecodeString(encoded)
+	if err != nil || len(sealed) < k.aead.NonceSize() {
+		return "", ErrMalformed
+	}
+	nonce, ciphertext := sealed[:k.aead.NonceSize()], sealed[k.aead.NonceSize():]
+	plaintext, err := k.aead.Open(nil, nonce, ciphertext, []byte(field))
+	if err != nil {
+		return "", ErrMalformed
+	}
+	return string(plaintext), nil
+}
+
+// NeedsRotation reports whether value should be rewritten: it is plaintext or
+// encrypted under a key other than the current one. Without a key nothing
+// needs rewriting.
+func (c *Cipher) NeedsRotation(value string) bool {
+	c.mu.RLock()
+	current := c.current
+	c.mu.RUnlock()
+	if current == nil {
+		return false
+	}
+	return !strings.HasPrefix(value, prefix+current.id+":")
+}
+
+// IsEncrypted reports whether value carries the encryption prefix
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, prefix)
+}
+
+func (k *key) seal(field, plaintext string, nonce []byte) string {
+	sealed := k.aead.Seal(nonce, nonce, []byte(plaintext), []byte(field))
+	return prefix + k.id + ":" + base64.RawStdEncoding.EncodeToString(sealed)
+}
+
+func (k *key) deterministicNonce(field, plaintext string) []byte {
+	mac := hmac.New(sha256.New, k.nonceKey)
+	mac.Write([]byte(field))
+	mac.Write([]byte{0})
+	mac.Write([]byte(plaintext))
+	return mac.Sum(nil)[:k.aead.NonceSize()]
+}
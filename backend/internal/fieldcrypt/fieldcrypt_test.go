@@ -0,0 +1,121 @@
+package fieldcrypt_test
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/fieldcrypt"
+)
+
+func testKey(b byte) string {
+	return base64.StdEncoding.EncodeToString([]byte(strings.Repeat(string(b), fieldcrypt.KeySize)))
+}
+
+func newCipher(t *testing.T, spec string) *fieldcrypt.Cipher {
+	c := fieldcrypt.NewCipher()
+	require.NoError(t, c.SetKeys(spec))
+	return c
+}
+
+func TestCipher_RoundTrip(t *testing.T) {
+	c := newCipher(t, "k1:"+testKey('a'))
+	assert.True(t, c.Enabled())
+	assert.Equal(t, "k1", c.CurrentKeyID())
+
+	a, err := c.Encrypt("field", "secret")
+	require.NoError(t, err)
+	b, err := c.Encrypt("field", "secret")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(a, "enc:v1:k1:"))
+	assert.NotEqual(t, a, b, "random nonces")
+
+	plain, err := c.Decrypt("field", a)
+	require.NoError(t, err)
+	assert.Equal(t, "secret", plain)
+
+	// A ciphertext is bound to its field
+	_, err = c.Decrypt("other", a)
+	assert.ErrorIs(t, err, fieldcrypt.ErrMalformed)
+}
+
+func TestCipher_Deterministic(t *testing.T) {
+	c := newCipher(t, "k1:"+testKey('a'))
+
+	a := c.EncryptDeterministic("field", "applicant")
+	assert.Equal(t, a, c.EncryptDeterministic("field", "applicant"))
+	assert.NotEqual(t, a, c.EncryptDeterministic("field", "other"))
+	assert.NotEqual(t, a, c.EncryptDeterministic("other", "applicant"))
+
+	plain, err := c.Decrypt("field", a)
+	require.NoError(t, err)
+	assert.Equal(t, "applicant", plain)
+}
+
+func TestCipher_Rotation(t *testing.T) {
+	old := newCipher(t, "k1:"+testKey('a'))
+	stored, err := old.Encrypt("field", "secret")
+	require.NoError(t, err)
+	storedID := old.EncryptDeterministic("field", "applicant")
+
+	c := newCipher(t, "k2:"+testKey('b')+", k1:"+testKey('a'))
+	assert.Equal(t, "k2", c.CurrentKeyID())
+
+	// Values under the old key still decrypt
+	plain, err := c.Decrypt("field", stored)
+	require.NoError(t, err)
+	assert.Equal(t, "secret", plain)
+	assert.True(t, c.NeedsRotation(stored))
+	assert.True(t, c.NeedsRotation("legacy plaintext"))
+
+	fresh, err := c.Encrypt("field", "secret")
+	require.NoError(t, err)
+	assert.False(t, c.NeedsRotation(fresh))
+
+	// Lookups try the current key, the old key, then plaintext
+	candidates := c.Candidates("field", "applicant")
+	require.Len(t, candidates, 3)
+	assert.Equal(t, c.EncryptDeterministic("field", "applicant"), candidates[0])
+	assert.Equal(t, storedID, candidates[1])
+	assert.Equal(t, "applicant", candidates[2])
+
+	// Once the old key is dropped its values no longer decrypt
+	require.NoError(t, c.SetKeys("k2:"+testKey('b')))
+	_, err = c.Decrypt("field", stored)
+	assert.ErrorIs(t, err, fieldcrypt.ErrUnknownKey)
+}
+
+func TestCipher_NoKeys(t *testing.T) {
+	c := fieldcrypt.NewCipher()
+	assert.False(t, c.Enabled())
+
+	s, err := c.Encrypt("field", "secret")
+	require.NoError(t, err)
+	assert.Equal(t, "secret", s)
+	assert.Equal(t, "secret", c.EncryptDeterministic("field", "secret"))
+	assert.Equal(t, []string{"secret"}, c.Candidates("field", "secret"))
+	assert.False(t, c.NeedsRotation("secret"))
+
+	plain, err := c.Decrypt("field", "secret")
+	require.NoError(t, err)
+	assert.Equal(t, "secret", plain)
+}
+
+func TestCipher_SetKeysInvalid(t *testing.T) {
+	c := newCipher(t, "k1:"+testKey('a'))
+
+	for _, spec := range []string{
+		"k1",
+		"bad id:" + testKey('a'),
+		"k1:" + base64.StdEncoding.EncodeToString([]byte("short")),
+		"k1:not-base64!",
+		"k1:" + testKey('a') + ",k1:" + testKey('b'),
+	} {
+		assert.Error(t, c.SetKeys(spec), spec)
+	}
+	// A rejected spec leaves the keys in place
+	assert.Equal(t, "k1", c.CurrentKeyID())
+}
@@ -0,0 +1,236 @@
+// Package fixtures holds the seed data for the in-memory handlers (KYC
+// registrations, NFTs, governance proposals, token balances) for each
+// environment. Production starts empty; dev, demo and test deployments pick
+// a set with SEED_FIXTURES, or a JSON file exported by "seed fixtures".
+package fixtures
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/handlers"
+)
+
+// Environments with a built-in fixture set
+const (
+	Dev  = "dev"  // demo data plus the first local anvil account as an officer
+	Demo = "demo" // the data shown in public demos
+	Test = "test" // a small, fixed set for integration tests
+)
+
+// Environments lists the built-in fixture sets
+var Environments = []string{Dev, Demo, Test}
+
+// Well-known demo addresses
+const (
+	treasury      = "0x0000000000000000000000000000000000000001"
+	stakingPool   = "0x0000000000000000000000000000000000000002"
+	approvedUser  = "0x0000000000000000000000000000000000000003"
+	pendingUser   = "0x0000000000000000000000000000000000000004"
+	anvilAccount0 = "0xf39fd6e51aad88f6f4ce6ab8827279cfffb92266"
+)
+
+// For returns the fixture set for env, with timestamps relative to now
+func For(env string, now time.Time) (*handlers.Fixtures, error) {
+	switch env {
+	case Demo:
+		return demo(now), nil
+	case Dev:
+		f := demo(now)
+		f.KYC.ComplianceOfficers = append(f.KYC.ComplianceOfficers, anvilAccount0)
+		f.KYC.Whitelist = append(f.KYC.Whitelist, anvilAccount0)
+		f.TokenBalances[anvilAccount0] = "1000000000000000000000000" // 1M
+		return f, nil
+	case Test:
+		return test(now), nil
+	default:
+		return nil, fmt.Errorf("unknown fixture set %q (expected %s)", env, strings.Join(Environments, ", "))
+	}
+}
+
+// Load resolves a SEED_FIXTURES value: empty for no fixtures, the name of a
+// built-in set, or the path of a JSON fixture file
+func Load(spec string, now time.Time) (*handlers.Fixtures, error) {
+	if spec == "" {
+		return nil, nil
+	}
+	for _, env := range Environments {
+		if spec == env {
+			return For(env, now)
+		}
+	}
+
+	data, err := os.ReadFile(spec)
+	if err != nil {
+		return nil, fmt.Errorf("reading fixtures: %w", err)
+	}
+	var f handlers.Fixtures
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("parsing fixtures %s: %w", spec, err)
+	}
+	if err := f.Validate(); err != nil {
+		return nil, fmt.Errorf("fixtures %s: %w", spec, err)
+	}
+	return &f, nil
+}
+
+// demo is the data the handlers used to seed themselves with
+func demo(now time.Time) *handlers.Fixtures {
+	verifiedAt := now
+	expiry := now.Add(365 * 24 * time.Hour)
+
+	rarities := []string{"Common", "Uncommon", "Rare", "Epic", "Legendary"}
+	elements := []string{"Fire", "Water", "Earth", "Air", "Lightning"}
+	nfts := make([]*handlers.NFTToken, 0, len(rarities))
+	for i := 1; i <= len(rarities); i++ {
+		nfts = append(nfts, guardian(i, rarities[i-1], elements[i-1], now))
+	}
+
+	return &handlers.Fixtures{
+		KYC: handlers.KYCFixtures{
+			ComplianceOfficers: []string{treasury, stakingPool},
+			Registrations: []*handlers.KYCRegistration{
+				{
+					Address:            approvedUser,
+					Status:             handlers.KYCStatusApproved,
+					Level:              handlers.KYCLevelAdvanced,
+					Jurisdiction:       "US",
+					VerifiedAt:         &verifiedAt,
+					ExpiresAt:          &expiry,
+					DocumentHash:       "0x" + strings.Repeat("a", 64),
+					RiskScore:          15,
+					AccreditedInvestor: true,
+					CreatedAt:          now.Add(-30 * 24 * time.Hour),
+					UpdatedAt:          now,
+					ReviewedBy:         treasury,
+				},
+				{
+					Address:      pendingUser,
+					Status:       handlers.KYCStatusPending,
+					Level:        handlers.KYCLevelNone,
+					Jurisdiction: "GB",
+					DocumentHash: "0x" + strings.Repeat("b", 64),
+					CreatedAt:    now.Add(-2 * 24 * time.Hour),
+					UpdatedAt:    now.Add(-2 * 24 * time.Hour),
+				},
+			},
+		},
+		NFTs: nfts,
+		Proposals: []*handlers.Proposal{
+			{
+				Proposer:     treasury,
+				Title:        "Increase Staking Rewards from 10% to 12% APY",
+				Description:  "This proposal aims to increase staking rewards to incentivize more participation in network security. The increase from 10% to 12% APY will be funded from the treasury reserve allocation.",
+				Targets:      []string{"0xStakingContract"},
+				Values:       []string{"0"},
+				Calldatas:    []string{"0x...setRewardRate(1200)"},
+				StartTime:    now.Add(-1 * time.Hour),
+				EndTime:      now.Add(6 * 24 * time.Hour),
+				State:        handlers.ProposalStateActive,
+				ForVotes:     "5000000000000000000000000",
+				AgainstVotes: "1000000000000000000000000",
+				AbstainVotes: "500000000000000000000000",
+				CreatedAt:    now.Add(-2 * time.Hour),
+			},
+			{
+				Proposer:     stakingPool,
+				Title:        "Allocate 1M NXS for Developer Grants",
+				Description:  "Allocate 1,000,000 NXS tokens from the treasury to fund developer grants and ecosystem growth initiatives.",
+				Targets:      []string{"0xTreasuryContract"},
+				Values:       []string{"0"},
+				Calldatas:    []string{"0x...transfer(grants, 1000000)"},
+				StartTime:    now.Add(-9 * 24 * time.Hour),
+				EndTime:      now.Add(-2 * 24 * time.Hour),
+				State:        handlers.ProposalStateSucceeded,
+				ForVotes:     "10000000000000000000000000",
+				AgainstVotes: "2000000000000000000000000",
+				AbstainVotes: "1000000000000000000000000",
+				CreatedAt:    now.Add(-10 * 24 * time.Hour),
+			},
+		},
+		TokenBalances: map[string]string{
+			treasury:     "80000000000000000000000000", // 80M
+			stakingPool:  "15000000000000000000000000", // 15M
+			approvedUser: "5000000000000000000000000",  // 5M
+		},
+	}
+}
+
+// test is one record of each kind, for tests that need known data without
+// depending on the demo content
+func test(now time.Time) *handlers.Fixtures {
+	verifiedAt := now
+	expiry := now.Add(365 * 24 * time.Hour)
+
+	return &handlers.Fixtures{
+		KYC: handlers.KYCFixtures{
+			ComplianceOfficers: []string{treasury},
+			Registrations: []*handlers.KYCRegistration{
+				{
+					Address:      approvedUser,
+					Status:       handlers.KYCStatusApproved,
+					Level:        handlers.KYCLevelStandard,
+					Jurisdiction: "GB",
+					VerifiedAt:   &verifiedAt,
+					ExpiresAt:    &expiry,
+					CreatedAt:    now.Add(-24 * time.Hour),
+					UpdatedAt:    now,
+					ReviewedBy:   treasury,
+				},
+				{
+					Address:      pendingUser,
+					Status:       handlers.KYCStatusPending,
+					Jurisdiction: "DE",
+					CreatedAt:    now.Add(-time.Hour),
+					UpdatedAt:    now.Add(-time.Hour),
+				},
+			},
+		},
+		NFTs: []*handlers.NFTToken{guardian(1, "Common", "Fire", now)},
+		Proposals: []*handlers.Proposal{
+			{
+				ID:           "0x" + strings.Repeat("01", 32),
+				Proposer:     treasury,
+				Title:        "Test proposal",
+				Description:  "An active proposal for tests.",
+				Targets:      []string{"0xStakingContract"},
+				Values:       []string{"0"},
+				Calldatas:    []string{"0x"},
+				StartTime:    now.Add(-time.Minute),
+				EndTime:      now.Add(24 * time.Hour),
+				State:        handlers.ProposalStateActive,
+				ForVotes:     "0",
+				AgainstVotes: "0",
+				AbstainVotes: "0",
+				CreatedAt:    now.Add(-time.Hour),
+			},
+		},
+		TokenBalances: map[string]string{
+			treasury:     "1000000000000000000000000", // 1M
+			approvedUser: "1000000000000000000000",    // 1k
+		},
+	}
+}
+
+// guardian is the nth demo NFT, owned by the approved demo user. The
+// Legendary one is soulbound.
+func guardian(n int, rarity, element string, now time.Time) *handlers.NFTToken {
+	return &handlers.NFTToken{
+		TokenID:     fmt.Sprintf("%d", n),
+		Owner:       approvedUser,
+		Name:        fmt.Sprintf("Nexus Guardian #%d", n),
+		Description: "A powerful guardian from the Nexus realm, sworn to protect the protocol.",
+		Image:       fmt.Sprintf("https://api.nexusprotocol.io/images/%d.png", n),
+		Attributes: []handlers.NFTAttribute{
+			{TraitType: "Rarity", Value: rarity},
+			{TraitType: "Element", Value: element},
+			{TraitType: "Power Level", Value: n * 20, DisplayType: "number"},
+			{TraitType: "Generation", Value: 1, DisplayType: "number"},
+		},
+		Soulbound: rarity == "Legendary",
+		MintedAt:  now.Add(-time.Duration(n) * 24 * time.Hour),
+	}
+}
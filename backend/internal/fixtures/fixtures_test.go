@@ -0,0 +1,91 @@
+package fixtures_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/fixtures"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/handlers"
+)
+
+func TestFor_SetsAreValid(t *testing.T) {
+	for _, env := range fixtures.Environments {
+		t.Run(env, func(t *testing.T) {
+			f, err := fixtures.For(env, time.Now())
+			require.NoError(t, err)
+			assert.NoError(t, f.Validate())
+			assert.NotEmpty(t, f.KYC.Registrations)
+			assert.NotEmpty(t, f.Proposals)
+		})
+	}
+
+	_, err := fixtures.For("production", time.Now())
+	assert.Error(t, err)
+}
+
+func TestLoad(t *testing.T) {
+	f, err := fixtures.Load("", time.Now())
+	require.NoError(t, err)
+	assert.Nil(t, f, "no fixtures by default")
+
+	// A file exported from a built-in set loads back
+	demo, err := fixtures.For(fixtures.Demo, time.Now())
+	require.NoError(t, err)
+	data, err := json.Marshal(demo)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "fixtures.json")
+	require.NoError(t, os.WriteFile(path, data, 0o644))
+
+	f, err = fixtures.Load(path, time.Now())
+	require.NoError(t, err)
+	assert.Len(t, f.NFTs, len(demo.NFTs))
+	assert.Equal(t, demo.TokenBalances, f.TokenBalances)
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"kyc":{"compliance_officers":["not-an-address"]}}`), 0o644))
+	_, err = fixtures.Load(path, time.Now())
+	assert.Error(t, err)
+}
+
+func TestSeed_HandlersStartEmpty(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	kyc := handlers.NewKYCHandler(zap.NewNop())
+	governance := handlers.NewGovernanceHandler(zap.NewNop(), nil, 31337)
+	router := gin.New()
+	router.GET("/kyc/status/:address", kyc.GetKYCStatus)
+	router.GET("/governance/proposals", governance.ListProposals)
+
+	get := func(path string) (int, map[string]interface{}) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		var body map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		return w.Code, body
+	}
+
+	const approved = "/kyc/status/0x0000000000000000000000000000000000000003"
+	code, _ := get(approved)
+	assert.Equal(t, http.StatusNotFound, code)
+	_, body := get("/governance/proposals")
+	assert.Equal(t, float64(0), body["total"])
+
+	f, err := fixtures.For(fixtures.Test, time.Now())
+	require.NoError(t, err)
+	kyc.Seed(f.KYC)
+	governance.Seed(f.Proposals)
+
+	code, body = get(approved)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "approved", body["registration"].(map[string]interface{})["status"])
+	_, body = get("/governance/proposals")
+	assert.Equal(t, float64(len(f.Proposals)), body["total"])
+}
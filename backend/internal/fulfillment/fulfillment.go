@@ -0,0 +1,147 @@
+// Package fulfillment delivers what completed payments paid for. Each
+// service code is registered with a Fulfiller; when a payment completes,
+// FulfillingPaymentRepo queues it on the webhook queue and the Dispatcher
+// runs the service's fulfiller, retrying with backoff until it succeeds.
+// What was delivered is recorded once per payment.
+package fulfillment
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// EventType is the webhook event type of queued fulfillments
+const EventType = "payment.completed"
+
+// Fulfiller delivers one service
+type Fulfiller interface {
+	// Fulfill delivers p's service and describes what was delivered; the
+	// dispatcher fills in the payment, service and address. A failed
+	// fulfillment runs again, so Fulfill must be safe to repeat.
+	Fulfill(ctx context.Context, p *repository.Payment) (*repository.Fulfillment, error)
+}
+
+// Job is the payload of a queued fulfillment
+type Job struct {
+	PaymentID   string `json:"payment_id"`
+	ServiceCode string `json:"service_code"`
+}
+
+// Dispatcher runs the fulfiller registered for a completed payment's
+// service (registered with the webhook processor for
+// WebhookProviderFulfillment)
+type Dispatcher struct {
+	payments repository.PaymentRepository
+	repo     repository.FulfillmentRepository
+	logger   *zap.Logger
+
+	mu         sync.RWMutex
+	fulfillers map[string]Fulfiller // service code -> fulfiller
+}
+
+// NewDispatcher creates a dispatcher with no services registered
+func NewDispatcher(payments repository.PaymentRepository, repo repository.FulfillmentRepository, logger *zap.Logger) *Dispatcher {
+	return &Dispatcher{
+		payments:   payments,
+		repo:       repo,
+		logger:     logger,
+		fulfillers: make(map[string]Fulfiller),
+	}
+}
+
+// Register makes f fulfill payments for serviceCode, replacing any
+// fulfiller registered before
+func (d *Dispatcher) Register(serviceCode string, f Fulfiller) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.fulfillers[serviceCode] = f
+}
+
+// Services lists the registered service codes, sorted
+func (d *Dispatcher) Services() []string {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	codes := make([]string, 0, len(d.fulfillers))
+	for code := range d.fulfillers {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+// fulfiller returns serviceCode's fulfiller, if registered
+func (d *Dispatcher) fulfiller(serviceCode string) (Fulfiller, bool) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	f, ok := d.fulfillers[serviceCode]
+	return f, ok
+}
+
+// ProcessWebhookEvent fulfills a queued payment (called by the background
+// webhook processor). Payments already fulfilled, no longer completed (e.g.
+// refunded meanwhile) or for services without a fulfiller are skipped; a
+// failed fulfillment is retried with backoff until the event is
+// dead-lettered.
+func (d *Dispatcher) ProcessWebhookEvent(ctx context.Context, e *repository.WebhookEvent) error {
+	var job Job
+	if err := json.Unmarshal(e.Payload, &job); err != nil {
+		return fmt.Errorf("parsing stored fulfillment: %w", err)
+	}
+
+	if _, err := d.repo.GetFulfillmentByPayment(ctx, job.PaymentID); err == nil {
+		return nil
+	} else if !errors.Is(err, repository.ErrFulfillmentNotFound) {
+		return fmt.Errorf("getting fulfillment of payment %s: %w", job.PaymentID, err)
+	}
+
+	p, err := d.payments.GetPayment(ctx, job.PaymentID)
+	if err != nil {
+		if errors.Is(err, repository.ErrPaymentNotFound) {
+			d.logger.Warn("dropping fulfillment of a missing payment", zap.String("payment_id", job.PaymentID))
+			return nil
+		}
+		return fmt.Errorf("getting payment %s: %w", job.PaymentID, err)
+	}
+	if p.Status != repository.PaymentStatusCompleted {
+		d.logger.Info("skipping fulfillment of a payment no longer completed",
+			zap.String("payment_id", p.ID),
+			zap.String("status", string(p.Status)),
+		)
+		return nil
+	}
+	f, ok := d.fulfiller(p.ServiceCode)
+	if !ok {
+		d.logger.Warn("no fulfiller for service", zap.String("payment_id", p.ID), zap.String("service_code", p.ServiceCode))
+		return nil
+	}
+
+	out, err := f.Fulfill(ctx, p)
+	if err != nil {
+		return fmt.Errorf("fulfilling %s payment %s: %w", p.ServiceCode, p.ID, err)
+	}
+	out.PaymentID = p.ID
+	out.ServiceCode = p.ServiceCode
+	out.Address = strings.ToLower(p.PayerAddress)
+	if out.Quantity == 0 {
+		out.Quantity = 1
+	}
+	if _, err := d.repo.RecordFulfillment(ctx, out); err != nil {
+		return err
+	}
+
+	d.logger.Info("payment fulfilled",
+		zap.String("payment_id", p.ID),
+		zap.String("service_code", p.ServiceCode),
+		zap.String("kind", string(out.Kind)),
+	)
+	return nil
+}
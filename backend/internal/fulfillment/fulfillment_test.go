@@ -0,0 +1,162 @@
+package fulfillment_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/fulfillment"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/storage/memory"
+)
+
+const payer = "0x00000000000000000000000000000000000000aa"
+
+// fakeQueue keeps enqueued events in order, once per event ID
+type fakeQueue struct {
+	events []*repository.WebhookEvent
+}
+
+func (q *fakeQueue) Enqueue(_ context.Context, e *repository.WebhookEvent) (bool, error) {
+	for _, queued := range q.events {
+		if queued.EventID == e.EventID {
+			return false, nil
+		}
+	}
+	q.events = append(q.events, e)
+	return true, nil
+}
+
+// drain runs every queued event through d
+func (q *fakeQueue) drain(t *testing.T, d *fulfillment.Dispatcher) {
+	t.Helper()
+	for _, e := range q.events {
+		assert.Equal(t, repository.WebhookProviderFulfillment, e.Provider)
+		require.NoError(t, d.ProcessWebhookEvent(context.Background(), e))
+	}
+	q.events = nil
+}
+
+func newPayments(t *testing.T) (*memory.Store, *fulfillment.Dispatcher, *fakeQueue, repository.PaymentRepository) {
+	t.Helper()
+	store := memory.NewStore()
+	d := fulfillment.NewDispatcher(store.Payments, store.Fulfillments, zap.NewNop())
+	fulfillment.RegisterDefaults(d, store.Payments, store.Fulfillments, store.Entitlements)
+	q := &fakeQueue{}
+	return store, d, q, fulfillment.NewFulfillingPaymentRepo(store.Payments, d, q, zap.NewNop())
+}
+
+func pay(t *testing.T, payments repository.PaymentRepository, serviceCode string) *repository.Payment {
+	t.Helper()
+	ctx := context.Background()
+	p := &repository.Payment{ServiceCode: serviceCode, PayerAddress: payer, PaymentMethod: "stripe",
+		Currency: "USD", Status: repository.PaymentStatusPending}
+	require.NoError(t, payments.CreatePayment(ctx, p))
+	require.NoError(t, payments.UpdatePaymentStatus(ctx, p.ID, repository.PaymentStatusCompleted, nil))
+	return p
+}
+
+func TestDispatcher_FulfillsCompletedPayments(t *testing.T) {
+	ctx := context.Background()
+	store, d, q, payments := newPayments(t)
+	assert.Equal(t, []string{"kyc_aml_recheck", "kyc_enhanced", "kyc_verification", "kyc_verification_10", "nft_mint", "premium_monthly"}, d.Services())
+
+	kyc := pay(t, payments, "kyc_verification")
+	mint := pay(t, payments, "nft_mint")
+	pay(t, payments, "meta_tx_relay") // per-use fee, nothing to fulfill
+	require.Len(t, q.events, 2)
+
+	// A redelivered event fulfills once
+	q.events = append(q.events, q.events[0])
+	q.drain(t, d)
+
+	f, err := store.Fulfillments.GetFulfillmentByPayment(ctx, kyc.ID)
+	require.NoError(t, err)
+	assert.Equal(t, repository.FulfillmentKYC, f.Kind)
+	assert.Equal(t, payer, f.Address)
+	v, err := store.Payments.GetKYCVerificationByAddress(ctx, payer)
+	require.NoError(t, err)
+	assert.Equal(t, repository.KYCStatusPending, v.Status)
+	assert.Equal(t, v.ID, *f.Reference)
+	assert.Equal(t, kyc.ID, *v.PaymentID)
+
+	f, err = store.Fulfillments.GetFulfillmentByPayment(ctx, mint.ID)
+	require.NoError(t, err)
+	assert.Equal(t, repository.FulfillmentNFTMintCredit, f.Kind)
+	assert.Equal(t, 1, f.Quantity)
+
+	// A KYC check paid for while one is under way reuses it
+	again := pay(t, payments, "kyc_enhanced")
+	q.drain(t, d)
+	f, err = store.Fulfillments.GetFulfillmentByPayment(ctx, again.ID)
+	require.NoError(t, err)
+	assert.Equal(t, v.ID, *f.Reference)
+	_, total, err := store.Payments.ListKYCVerifications(ctx, repository.KYCVerificationFilter{UserAddress: payer}, repository.Pagination{Page: 1, PageSize: 10})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+}
+
+func TestDispatcher_SubscriptionsExtend(t *testing.T) {
+	ctx := context.Background()
+	store, d, q, payments := newPayments(t)
+
+	first := pay(t, payments, "premium_monthly")
+	q.drain(t, d)
+	second := pay(t, payments, "premium_monthly")
+	third := pay(t, payments, "premium_monthly")
+	q.drain(t, d)
+
+	a, err := store.Fulfillments.GetFulfillmentByPayment(ctx, first.ID)
+	require.NoError(t, err)
+	b, err := store.Fulfillments.GetFulfillmentByPayment(ctx, second.ID)
+	require.NoError(t, err)
+	c, err := store.Fulfillments.GetFulfillmentByPayment(ctx, third.ID)
+	require.NoError(t, err)
+	assert.Equal(t, repository.FulfillmentAPISubscription, a.Kind)
+	assert.True(t, b.ValidFrom.Equal(*a.ValidUntil))
+	assert.True(t, c.ValidFrom.Equal(*b.ValidUntil))
+
+	active, total, err := store.Fulfillments.ListFulfillments(ctx, repository.FulfillmentFilter{Address: payer, ActiveAt: a.ValidFrom}, repository.Pagination{Page: 1, PageSize: 10})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Equal(t, first.ID, active[0].PaymentID)
+}
+
+func TestDispatcher_CreditPacksGrantOnce(t *testing.T) {
+	ctx := context.Background()
+	store, d, q, payments := newPayments(t)
+
+	p := pay(t, payments, "kyc_verification_10")
+	q.events = append(q.events, q.events[0])
+	q.drain(t, d)
+
+	f, err := store.Fulfillments.GetFulfillmentByPayment(ctx, p.ID)
+	require.NoError(t, err)
+	assert.Equal(t, repository.FulfillmentCredits, f.Kind)
+	assert.Equal(t, "kyc_verification", *f.Reference)
+	e, err := store.Entitlements.GetEntitlement(ctx, payer, "kyc_verification")
+	require.NoError(t, err)
+	assert.Equal(t, 10, e.Balance)
+
+	// A grant that outlived a failed fulfillment record is not repeated
+	_, err = fulfillment.NewCredits(store.Entitlements, "kyc_verification", 10).Fulfill(ctx, p)
+	require.NoError(t, err)
+	e, err = store.Entitlements.GetEntitlement(ctx, payer, "kyc_verification")
+	require.NoError(t, err)
+	assert.Equal(t, 10, e.Granted)
+}
+
+func TestDispatcher_SkipsPaymentsNoLongerCompleted(t *testing.T) {
+	ctx := context.Background()
+	store, d, q, payments := newPayments(t)
+
+	p := pay(t, payments, "nft_mint")
+	require.NoError(t, payments.UpdatePaymentStatus(ctx, p.ID, repository.PaymentStatusRefunded, nil))
+	q.drain(t, d)
+
+	_, err := store.Fulfillments.GetFulfillmentByPayment(ctx, p.ID)
+	assert.ErrorIs(t, err, repository.ErrFulfillmentNotFound)
+}
@@ -0,0 +1,83 @@
+package fulfillment
+
+import (
+	"context"
+	"encoding/json"
+
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/callbacks"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure FulfillingPaymentRepo implements PaymentRepository
+var _ repository.PaymentRepository = (*FulfillingPaymentRepo)(nil)
+
+// FulfillingPaymentRepo wraps a PaymentRepository and queues a payment for
+// fulfillment when it is created completed or its status changes to
+// completed, whichever path completed it. All other methods go straight to
+// the wrapped repository.
+type FulfillingPaymentRepo struct {
+	repository.PaymentRepository
+	dispatcher *Dispatcher
+	queue      callbacks.Queue
+	logger     *zap.Logger
+}
+
+// NewFulfillingPaymentRepo wraps next, queuing completed payments of the
+// services registered with d on q
+func NewFulfillingPaymentRepo(next repository.PaymentRepository, d *Dispatcher, q callbacks.Queue, logger *zap.Logger) *FulfillingPaymentRepo {
+	return &FulfillingPaymentRepo{PaymentRepository: next, dispatcher: d, queue: q, logger: logger}
+}
+
+// CreatePayment implements repository.PaymentRepository
+func (r *FulfillingPaymentRepo) CreatePayment(ctx context.Context, payment *repository.Payment) error {
+	if err := r.PaymentRepository.CreatePayment(ctx, payment); err != nil {
+		return err
+	}
+	if payment.Status == repository.PaymentStatusCompleted {
+		r.enqueue(ctx, payment)
+	}
+	return nil
+}
+
+// UpdatePaymentStatus implements repository.PaymentRepository. Updates that
+// leave the status unchanged queue nothing.
+func (r *FulfillingPaymentRepo) UpdatePaymentStatus(ctx context.Context, id string, status repository.PaymentStatus, details *repository.PaymentStatusUpdate) error {
+	before, err := r.PaymentRepository.GetPayment(ctx, id)
+	if err != nil {
+		// Let the update report the error (or apply it without fulfilling)
+		return r.PaymentRepository.UpdatePaymentStatus(ctx, id, status, details)
+	}
+	if err := r.PaymentRepository.UpdatePaymentStatus(ctx, id, status, details); err != nil {
+		return err
+	}
+	if before.Status != status && status == repository.PaymentStatusCompleted {
+		r.enqueue(ctx, before)
+	}
+	return nil
+}
+
+// enqueue queues p's fulfillment. The completion is already stored, so a
+// queueing failure is logged rather than returned.
+func (r *FulfillingPaymentRepo) enqueue(ctx context.Context, p *repository.Payment) {
+	if _, ok := r.dispatcher.fulfiller(p.ServiceCode); !ok {
+		return
+	}
+	payload, err := json.Marshal(Job{PaymentID: p.ID, ServiceCode: p.ServiceCode})
+	if err == nil {
+		_, err = r.queue.Enqueue(ctx, &repository.WebhookEvent{
+			Provider:  repository.WebhookProviderFulfillment,
+			EventID:   p.ID,
+			EventType: EventType,
+			Payload:   payload,
+		})
+	}
+	if err != nil {
+		r.logger.Error("failed to queue payment fulfillment",
+			zap.String("payment_id", p.ID),
+			zap.String("service_code", p.ServiceCode),
+			zap.Error(err),
+		)
+	}
+}
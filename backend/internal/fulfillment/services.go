@@ -0,0 +1,150 @@
+package fulfillment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// subscriptionPeriod is how long one premium_monthly payment lasts
+const subscriptionPeriod = 30 * 24 * time.Hour
+
+// RegisterDefaults registers the fulfillers of the priced services that
+// deliver something once paid: KYC checks, NFT mint credits, the premium
+// API subscription and prepaid credit packs. Per-use fees (meta_tx_relay,
+// governance_proposal) are settled where they are charged.
+func RegisterDefaults(d *Dispatcher, payments repository.PaymentRepository, fulfillments repository.FulfillmentRepository, entitlements repository.EntitlementRepository) {
+	kyc := NewKYCFulfiller(payments)
+	d.Register("kyc_verification", kyc)
+	d.Register("kyc_aml_recheck", kyc)
+	d.Register("kyc_enhanced", kyc)
+	d.Register("nft_mint", NFTMintCredits{PerPayment: 1})
+	d.Register("premium_monthly", NewSubscription(fulfillments, subscriptionPeriod))
+	d.Register("kyc_verification_10", NewCredits(entitlements, "kyc_verification", 10))
+}
+
+// KYCFulfiller opens a KYC verification for the payer, which the Sumsub
+// flow (POST /api/v1/kyc/applicant) then carries forward
+type KYCFulfiller struct {
+	payments repository.PaymentRepository
+}
+
+// NewKYCFulfiller creates a KYC fulfiller
+func NewKYCFulfiller(payments repository.PaymentRepository) *KYCFulfiller {
+	return &KYCFulfiller{payments: payments}
+}
+
+// Fulfill implements Fulfiller. A verification awaiting payment moves to
+// pending; one already under way is reused; otherwise a new one is opened
+// for the payment.
+func (k *KYCFulfiller) Fulfill(ctx context.Context, p *repository.Payment) (*repository.Fulfillment, error) {
+	address := strings.ToLower(p.PayerAddress)
+	v, err := k.payments.GetKYCVerificationByAddress(ctx, address)
+	if err != nil && !errors.Is(err, repository.ErrKYCNotFound) {
+		return nil, fmt.Errorf("getting KYC verification of %s: %w", address, err)
+	}
+
+	switch {
+	case v != nil && v.PaymentID != nil && *v.PaymentID == p.ID:
+		// Opened by an earlier attempt
+	case v != nil && v.Status == repository.KYCStatusPaymentRequired:
+		status := repository.KYCStatusPending
+		if err := k.payments.UpdateKYCVerification(ctx, v.ID, &repository.KYCVerificationUpdate{Status: &status}); err != nil {
+			return nil, fmt.Errorf("updating KYC verification %s: %w", v.ID, err)
+		}
+	case v != nil && kycUnderWay(v.Status):
+	default:
+		v = &repository.KYCVerification{PaymentID: &p.ID, UserAddress: address, Status: repository.KYCStatusPending}
+		if err := k.payments.CreateKYCVerification(ctx, v); err != nil {
+			return nil, fmt.Errorf("creating KYC verification for %s: %w", address, err)
+		}
+	}
+
+	return &repository.Fulfillment{Kind: repository.FulfillmentKYC, Quantity: 1, Reference: &v.ID}, nil
+}
+
+// kycUnderWay reports whether a verification in status is still to be decided
+func kycUnderWay(status repository.KYCVerificationStatus) bool {
+	switch status {
+	case repository.KYCStatusPending, repository.KYCStatusSubmitted, repository.KYCStatusInReview:
+		return true
+	}
+	return false
+}
+
+// NFTMintCredits grants mints; the fulfillment record is the credit
+type NFTMintCredits struct {
+	PerPayment int
+}
+
+// Fulfill implements Fulfiller
+func (n NFTMintCredits) Fulfill(ctx context.Context, p *repository.Payment) (*repository.Fulfillment, error) {
+	return &repository.Fulfillment{Kind: repository.FulfillmentNFTMintCredit, Quantity: n.PerPayment}, nil
+}
+
+// Credits grants prepaid credits for a service, spent later through
+// EntitlementRepository.ConsumeCredits
+type Credits struct {
+	entitlements repository.EntitlementRepository
+	serviceCode  string
+	perPayment   int
+}
+
+// NewCredits creates a fulfiller granting perPayment credits for serviceCode
+func NewCredits(entitlements repository.EntitlementRepository, serviceCode string, perPayment int) *Credits {
+	return &Credits{entitlements: entitlements, serviceCode: serviceCode, perPayment: perPayment}
+}
+
+// Fulfill implements Fulfiller. The grant is keyed by payment, so a retry
+// after the fulfillment failed to record does not credit twice.
+func (c *Credits) Fulfill(ctx context.Context, p *repository.Payment) (*repository.Fulfillment, error) {
+	address, serviceCode := strings.ToLower(p.PayerAddress), c.serviceCode
+	if _, err := c.entitlements.GrantCredits(ctx, &repository.EntitlementGrant{
+		PaymentID:   p.ID,
+		Address:     address,
+		ServiceCode: serviceCode,
+		Quantity:    c.perPayment,
+	}); err != nil {
+		return nil, fmt.Errorf("granting %s credits to %s: %w", serviceCode, address, err)
+	}
+
+	return &repository.Fulfillment{Kind: repository.FulfillmentCredits, Quantity: c.perPayment, Reference: &serviceCode}, nil
+}
+
+// Subscription grants API access for a period, extending the payer's
+// current subscription to the service if one is active
+type Subscription struct {
+	fulfillments repository.FulfillmentRepository
+	period       time.Duration
+	now          func() time.Time
+}
+
+// NewSubscription creates a fulfiller granting period per payment
+func NewSubscription(fulfillments repository.FulfillmentRepository, period time.Duration) *Subscription {
+	return &Subscription{fulfillments: fulfillments, period: period, now: time.Now}
+}
+
+// Fulfill implements Fulfiller
+func (s *Subscription) Fulfill(ctx context.Context, p *repository.Payment) (*repository.Fulfillment, error) {
+	// Renewals paid ahead start when the latest period ends
+	from := s.now().UTC()
+	recent, _, err := s.fulfillments.ListFulfillments(ctx, repository.FulfillmentFilter{
+		Address:     strings.ToLower(p.PayerAddress),
+		ServiceCode: p.ServiceCode,
+	}, repository.Pagination{Page: 1, PageSize: 100})
+	if err != nil {
+		return nil, fmt.Errorf("listing subscriptions: %w", err)
+	}
+	for _, f := range recent {
+		if f.ValidUntil != nil && f.ValidUntil.After(from) {
+			from = *f.ValidUntil
+		}
+	}
+
+	until := from.Add(s.period)
+	return &repository.Fulfillment{Kind: repository.FulfillmentAPISubscription, Quantity: 1, ValidFrom: &from, ValidUntil: &until}, nil
+}
@@ -0,0 +1,135 @@
+// Package geoip resolves client IPs to countries with a MaxMind DB and holds
+// the geo-blocking policy: the restricted jurisdictions, stored in app_config
+// (namespace "geo") so every instance follows them, and cached in memory so
+// the per-request check never touches the database
+package geoip
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// namespace is the app_config namespace holding the policy
+const namespace = "geo"
+
+// Defaults
+const (
+	// DefaultRefreshInterval is how often Run reloads the policy when no
+	// interval is given
+	DefaultRefreshInterval = 30 * time.Second
+
+	// DefaultBlockedCountries are the comprehensively sanctioned
+	// jurisdictions blocked until geo.blocked_countries says otherwise
+	DefaultBlockedCountries = "CU,IR,KP,SY"
+)
+
+// PolicyState is the current geo-blocking policy
+type PolicyState struct {
+	Enabled      bool     `json:"enabled"`
+	Countries    []string `json:"blocked_countries"`
+	BlockUnknown bool     `json:"block_unknown"` // also block IPs without a country
+
+	blocked map[string]bool
+}
+
+// Policy decides which countries are blocked. Blocking is off until
+// geo.block_enabled is set.
+type Policy struct {
+	configRepo repository.AppConfigRepository
+	logger     *zap.Logger
+	state      atomic.Pointer[PolicyState]
+}
+
+// NewPolicy creates a geo-blocking policy, initially disabled until the first
+// Refresh
+func NewPolicy(configRepo repository.AppConfigRepository, logger *zap.Logger) *Policy {
+	p := &Policy{configRepo: configRepo, logger: logger}
+	p.state.Store(newPolicyState(false, DefaultBlockedCountries, false))
+	return p
+}
+
+// State returns the current policy
+func (p *Policy) State() PolicyState {
+	return *p.state.Load()
+}
+
+// Blocked reports whether requests from country ("" when unknown) are
+// rejected
+func (p *Policy) Blocked(country string) bool {
+	s := p.state.Load()
+	if !s.Enabled {
+		return false
+	}
+	if country == "" {
+		return s.BlockUnknown
+	}
+	return s.blocked[strings.ToUpper(country)]
+}
+
+// Refresh loads the stored policy. On a read error the current policy is
+// kept.
+func (p *Policy) Refresh(ctx context.Context) error {
+	enabled, err := p.configRepo.GetBool(ctx, namespace, "block_enabled", 0)
+	if err != nil && !errors.Is(err, repository.ErrAppConfigNotFound) {
+		return fmt.Errorf("loading geo-blocking policy: %w", err)
+	}
+	countries := DefaultBlockedCountries
+	if v, err := p.configRepo.GetString(ctx, namespace, "blocked_countries", 0); err == nil && v != "" {
+		countries = v
+	}
+	blockUnknown, _ := p.configRepo.GetBool(ctx, namespace, "block_unknown", 0)
+
+	state := newPolicyState(enabled, countries, blockUnknown)
+	if previous := p.State(); previous.Enabled != state.Enabled ||
+		strings.Join(previous.Countries, ",") != strings.Join(state.Countries, ",") {
+		p.logger.Info("geo-blocking policy changed",
+			zap.Bool("enabled", state.Enabled),
+			zap.Strings("blocked_countries", state.Countries),
+		)
+	}
+	p.state.Store(state)
+	return nil
+}
+
+// Run refreshes the policy every interval until ctx is cancelled
+func (p *Policy) Run(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultRefreshInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := p.Refresh(ctx); err != nil {
+			p.logger.Warn("failed to refresh geo-blocking policy", zap.Error(err))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// newPolicyState parses a comma-separated country list
+func newPolicyState(enabled bool, countries string, blockUnknown bool) *PolicyState {
+	s := &PolicyState{Enabled: enabled, BlockUnknown: blockUnknown, blocked: make(map[string]bool)}
+	for _, c := range strings.Split(countries, ",") {
+		c = strings.ToUpper(strings.TrimSpace(c))
+		if len(c) == 2 && !s.blocked[c] {
+			s.blocked[c] = true
+			s.Countries = append(s.Countries, c)
+		}
+	}
+	sort.Strings(s.Countries)
+	return s
+}
@@ -0,0 +1,46 @@
+package geoip_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/geoip"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/storage/memory"
+)
+
+func TestPolicy_Refresh(t *testing.T) {
+	ctx := context.Background()
+	cfg := memory.NewMemoryAppConfigRepo()
+	p := geoip.NewPolicy(cfg, zap.NewNop())
+
+	require.NoError(t, p.Refresh(ctx))
+	assert.False(t, p.Blocked("IR"), "blocking is off until enabled")
+
+	on := true
+	require.NoError(t, cfg.Create(ctx, &repository.AppConfigCreate{
+		Namespace: "geo", ConfigKey: "block_enabled", ValueType: "boolean", ValueBoolean: &on,
+	}))
+	require.NoError(t, p.Refresh(ctx))
+	assert.True(t, p.Blocked("IR"), "default list")
+	assert.True(t, p.Blocked("kp"))
+	assert.False(t, p.Blocked("US"))
+	assert.False(t, p.Blocked(""), "unknown countries pass by default")
+
+	countries := " ru, IR,by,xyz"
+	require.NoError(t, cfg.Create(ctx, &repository.AppConfigCreate{
+		Namespace: "geo", ConfigKey: "blocked_countries", ValueType: "string", ValueString: &countries,
+	}))
+	require.NoError(t, cfg.Create(ctx, &repository.AppConfigCreate{
+		Namespace: "geo", ConfigKey: "block_unknown", ValueType: "boolean", ValueBoolean: &on,
+	}))
+	require.NoError(t, p.Refresh(ctx))
+	assert.Equal(t, []string{"BY", "IR", "RU"}, p.State().Countries)
+	assert.True(t, p.Blocked("RU"))
+	assert.False(t, p.Blocked("KP"), "the stored list replaces the default")
+	assert.True(t, p.Blocked(""))
+}
@@ -0,0 +1,377 @@
+package geoip
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"net/netip"
+	"os"
+)
+
+// metadataMarker precedes the metadata map at the end of a MaxMind DB
+var metadataMarker = []byte("\xAB\xCD\xEFMaxMind.com")
+
+// dataSectionSeparator is the gap between the search tree and the data
+const dataSectionSeparator = 16
+
+// ErrInvalidDatabase is returned for files that are not MaxMind DBs
+var ErrInvalidDatabase = errors.New("invalid MaxMind DB")
+
+// Metadata describes a MaxMind DB
+type Metadata struct {
+	DatabaseType string
+	BuildEpoch   uint64
+	IPVersion    uint64
+	NodeCount    uint64
+	RecordSize   uint64
+}
+
+// Reader looks up countries in a MaxMind DB (GeoLite2-Country,
+// GeoIP2-Country, GeoIP2-City or any database with a country.iso_code) held
+// in memory. It is safe for concurrent use.
+type Reader struct {
+	buf      []byte
+	tree     []byte
+	data     []byte
+	metadata Metadata
+	ipv4Root uint64 // node reached after the 96 zero bits of ::a.b.c.d
+}
+
+// Open reads the MaxMind DB at path
+func Open(path string) (*Reader, error) {
+	buf, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading GeoIP database: %w", err)
+	}
+	return FromBytes(buf)
+}
+
+// FromBytes parses a MaxMind DB held in buf, which must not be modified
+// afterwards
+func FromBytes(buf []byte) (*Reader, error) {
+	i := bytes.LastIndex(buf, metadataMarker)
+	if i < 0 {
+		return nil, fmt.Errorf("%w: no metadata", ErrInvalidDatabase)
+	}
+	meta := decoder{buf: buf[i+len(metadataMarker):]}
+	v, _, err := meta.decode(0, 0)
+	if err != nil {
+		return nil, fmt.Errorf("%w: metadata: %v", ErrInvalidDatabase, err)
+	}
+	m, ok := v.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("%w: metadata is not a map", ErrInvalidDatabase)
+	}
+
+	r := &Reader{buf: buf}
+	r.metadata.DatabaseType, _ = m["database_type"].(string)
+	r.metadata.BuildEpoch, _ = m["build_epoch"].(uint64)
+	r.metadata.IPVersion, _ = m["ip_version"].(uint64)
+	r.metadata.NodeCount, _ = m["node_count"].(uint64)
+	r.metadata.RecordSize, _ = m["record_size"].(uint64)
+	switch r.metadata.RecordSize {
+	case 24, 28, 32:
+	default:
+		return nil, fmt.Errorf("%w: unsupported record size %d", ErrInvalidDatabase, r.metadata.RecordSize)
+	}
+	if r.metadata.IPVersion != 4 && r.metadata.IPVersion != 6 {
+		return nil, fmt.Errorf("%w: unsupported IP version %d", ErrInvalidDatabase, r.metadata.IPVersion)
+	}
+
+	treeSize := r.metadata.NodeCount * r.metadata.RecordSize / 4
+	if treeSize+dataSectionSeparator > uint64(i) {
+		return nil, fmt.Errorf("%w: search tree exceeds the file", ErrInvalidDatabase)
+	}
+	r.tree = buf[:treeSize]
+	r.data = buf[treeSize+dataSectionSeparator : i]
+
+	if r.metadata.IPVersion == 6 {
+		node := uint64(0)
+		for i := 0; i < 96 && node < r.metadata.NodeCount; i++ {
+			node = r.record(node, 0)
+		}
+		r.ipv4Root = node
+	}
+	return r, nil
+}
+
+// Metadata returns the database's metadata
+func (r *Reader) Metadata() Metadata {
+	return r.metadata
+}
+
+// Country returns the ISO 3166-1 alpha-2 code ip is registered in, or ""
+// when the address is invalid or not in the database
+func (r *Reader) Country(ip string) string {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return ""
+	}
+	v, err := r.lookup(addr.Unmap())
+	if err != nil || v == nil {
+		return ""
+	}
+	m, _ := v.(map[string]any)
+	for _, key := range []string{"country", "registered_country"} {
+		if c, ok := m[key].(map[string]any); ok {
+			if code, ok := c["iso_code"].(string); ok && code != "" {
+				return code
+			}
+		}
+	}
+	return ""
+}
+
+// lookup walks the search tree for addr, returning the decoded record or nil
+func (r *Reader) lookup(addr netip.Addr) (any, error) {
+	var bits []byte
+	node := uint64(0)
+	switch {
+	case addr.Is4():
+		b := addr.As4()
+		bits = b[:]
+		if r.metadata.IPVersion == 6 {
+			node = r.ipv4Root
+		}
+	case r.metadata.IPVersion == 4:
+		return nil, nil
+	default:
+		b := addr.As16()
+		bits = b[:]
+	}
+
+	for i := 0; i < len(bits)*8 && node < r.metadata.NodeCount; i++ {
+		bit := (bits[i/8] >> (7 - uint(i%8))) & 1
+		node = r.record(node, bit)
+	}
+	if node == r.metadata.NodeCount {
+		return nil, nil
+	}
+	if node < r.metadata.NodeCount {
+		return nil, fmt.Errorf("%w: search tree too deep", ErrInvalidDatabase)
+	}
+	offset := node - r.metadata.NodeCount - dataSectionSeparator
+	d := decoder{buf: r.data}
+	v, _, err := d.decode(offset, 0)
+	return v, err
+}
+
+// record returns the left (bit 0) or right (bit 1) record of node
+func (r *Reader) record(node uint64, bit byte) uint64 {
+	switch r.metadata.RecordSize {
+	case 24:
+		b := r.tree[node*6+uint64(bit)*3:]
+		return uint64(b[0])<<16 | uint64(b[1])<<8 | uint64(b[2])
+	case 28:
+		b := r.tree[node*7:]
+		if bit == 0 {
+			return uint64(b[3]&0xF0)<<20 | uint64(b[0])<<16 | uint64(b[1])<<8 | uint64(b[2])
+		}
+		return uint64(b[3]&0x0F)<<24 | uint64(b[4])<<16 | uint64(b[5])<<8 | uint64(b[6])
+	default:
+		b := r.tree[node*8+uint64(bit)*4:]
+		return uint64(binary.BigEndian.Uint32(b))
+	}
+}
+
+// MaxMind DB data types
+const (
+	typeExtended = iota
+	typePointer
+	typeString
+	typeDouble
+	typeBytes
+	typeUint16
+	typeUint32
+	typeMap
+	typeInt32
+	typeUint64
+	typeUint128
+	typeArray
+	typeContainer
+	typeEndMarker
+	typeBool
+	typeFloat
+)
+
+// maxDepth bounds nested maps and arrays in a malformed database
+const maxDepth = 32
+
+// decoder reads values from a MaxMind DB data section
+type decoder struct {
+	buf []byte
+}
+
+// decode reads the value at offset, returning it and the offset after it.
+// Maps decode to map[string]any, arrays to []any and unsigned integers to
+// uint64 (uint128 values to []byte).
+func (d *decoder) decode(offset uint64, depth int) (any, uint64, error) {
+	if depth > maxDepth {
+		return nil, 0, errors.New("data nested too deeply")
+	}
+	typ, size, offset, err := d.control(offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if typ == typePointer {
+		ptr, next, err := d.pointer(size, offset)
+		if err != nil {
+			return nil, 0, err
+		}
+		v, _, err := d.decode(ptr, depth+1)
+		return v, next, err
+	}
+
+	switch typ {
+	case typeMap:
+		m := make(map[string]any, size)
+		for i := uint64(0); i < size; i++ {
+			k, next, err := d.decode(offset, depth+1)
+			if err != nil {
+				return nil, 0, err
+			}
+			key, ok := k.(string)
+			if !ok {
+				return nil, 0, errors.New("map key is not a string")
+			}
+			v, next, err := d.decode(next, depth+1)
+			if err != nil {
+				return nil, 0, err
+			}
+			m[key] = v
+			offset = next
+		}
+		return m, offset, nil
+	case typeArray:
+		a := make([]any, 0, min(size, 1024))
+		for i := uint64(0); i < size; i++ {
+			v, next, err := d.decode(offset, depth+1)
+			if err != nil {
+				return nil, 0, err
+			}
+			a = append(a, v)
+			offset = next
+		}
+		return a, offset, nil
+	case typeBool:
+		return size != 0, offset, nil
+	}
+
+	end := offset + size
+	if end > uint64(len(d.buf)) || end < offset {
+		return nil, 0, errors.New("value exceeds the data section")
+	}
+	b := d.buf[offset:end]
+	switch typ {
+	case typeString:
+		return string(b), end, nil
+	case typeBytes, typeUint128:
+		return append([]byte(nil), b...), end, nil
+	case typeDouble:
+		if size != 8 {
+			return nil, 0, errors.New("invalid double size")
+		}
+		return math.Float64frombits(binary.BigEndian.Uint64(b)), end, nil
+	case typeFloat:
+		if size != 4 {
+			return nil, 0, errors.New("invalid float size")
+		}
+		return float64(math.Float32frombits(binary.BigEndian.Uint32(b))), end, nil
+	case typeUint16, typeUint32, typeUint64:
+		if size > 8 {
+			return nil, 0, errors.New("invalid integer size")
+		}
+		var n uint64
+		for _, c := range b {
+			n = n<<8 | uint64(c)
+		}
+		return n, end, nil
+	case typeInt32:
+		if size > 4 {
+			return nil, 0, errors.New("invalid integer size")
+		}
+		var n uint32
+		for _, c := range b {
+			n = n<<8 | uint32(c)
+		}
+		return int64(int32(n)), end, nil
+	default:
+		return nil, 0, fmt.Errorf("unsupported data type %d", typ)
+	}
+}
+
+// control reads a field's control byte(s), returning its type, its size (for
+// pointers, the control byte's low five bits) and the offset of its payload
+func (d *decoder) control(offset uint64) (int, uint64, uint64, error) {
+	next := func() (uint64, error) {
+		if offset >= uint64(len(d.buf)) {
+			return 0, errors.New("unexpected end of data")
+		}
+		b := d.buf[offset]
+		offset++
+		return uint64(b), nil
+	}
+
+	ctrl, err := next()
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	typ := int(ctrl >> 5)
+	if typ == typeExtended {
+		ext, err := next()
+		if err != nil {
+			return 0, 0, 0, err
+		}
+		typ = int(ext) + 7
+	}
+	size := ctrl & 0x1F
+	if typ == typePointer {
+		return typ, size, offset, nil
+	}
+
+	if size >= 29 {
+		n := int(size - 28)
+		var extra uint64
+		for i := 0; i < n; i++ {
+			b, err := next()
+			if err != nil {
+				return 0, 0, 0, err
+			}
+			extra = extra<<8 | b
+		}
+		switch size {
+		case 29:
+			size = 29 + extra
+		case 30:
+			size = 285 + extra
+		default:
+			size = 65821 + extra
+		}
+	}
+	return typ, size, offset, nil
+}
+
+// pointer resolves a pointer's data section offset from its control bits
+func (d *decoder) pointer(ctrl, offset uint64) (uint64, uint64, error) {
+	n := (ctrl >> 3 & 0x3) + 1
+	if offset+n > uint64(len(d.buf)) {
+		return 0, 0, errors.New("unexpected end of data")
+	}
+	var ptr uint64
+	if n < 4 {
+		ptr = ctrl & 0x7
+	}
+	for _, c := range d.buf[offset : offset+n] {
+		ptr = ptr<<8 | uint64(c)
+	}
+	switch n {
+	case 2:
+		ptr += 2048
+	case 3:
+		ptr += 526336
+	}
+	return ptr, offset + n, nil
+}
@@ -0,0 +1,175 @@
+package geoip_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/geoip"
+)
+
+// writeDB builds a MaxMind DB with 24-bit records mapping each prefix to
+// {"country": {"iso_code": code}}
+func writeDB(t *testing.T, ipVersion int, prefixes map[string]string) []byte {
+	t.Helper()
+
+	type node struct {
+		child [2]*node
+		data  int // offset into the data section, -1 for none
+	}
+	root := &node{data: -1}
+	var data []byte
+	for prefix, code := range prefixes {
+		p := netip.MustParsePrefix(prefix)
+		if p.Addr().Is6() && ipVersion == 4 {
+			continue
+		}
+		var bits []byte
+		n := p.Bits()
+		if p.Addr().Is4() {
+			b := p.Addr().As4()
+			bits = b[:]
+			if ipVersion == 6 {
+				bits = append(make([]byte, 12), bits...)
+				n += 96
+			}
+		} else {
+			b := p.Addr().As16()
+			bits = b[:]
+		}
+
+		cur := root
+		for i := 0; i < n; i++ {
+			bit := (bits[i/8] >> (7 - uint(i%8))) & 1
+			if cur.child[bit] == nil {
+				cur.child[bit] = &node{data: -1}
+			}
+			cur = cur.child[bit]
+		}
+		cur.data = len(data)
+		data = append(data, encode(map[string]any{"country": map[string]any{"iso_code": code}})...)
+	}
+
+	// Number the internal nodes breadth first
+	var nodes []*node
+	index := map[*node]int{}
+	for queue := []*node{root}; len(queue) > 0; queue = queue[1:] {
+		n := queue[0]
+		if n.data >= 0 {
+			continue
+		}
+		index[n] = len(nodes)
+		nodes = append(nodes, n)
+		for _, c := range n.child {
+			if c != nil {
+				queue = append(queue, c)
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	count := len(nodes)
+	for _, n := range nodes {
+		for _, c := range n.child {
+			v := count
+			switch {
+			case c == nil:
+			case c.data >= 0:
+				v = count + 16 + c.data
+			default:
+				v = index[c]
+			}
+			buf.Write([]byte{byte(v >> 16), byte(v >> 8), byte(v)})
+		}
+	}
+	buf.Write(make([]byte, 16))
+	buf.Write(data)
+	buf.WriteString("\xAB\xCD\xEFMaxMind.com")
+	buf.Write(encode(map[string]any{
+		"node_count":    uint32(count),
+		"record_size":   uint16(24),
+		"ip_version":    uint16(ipVersion),
+		"database_type": "Test-Country",
+		"build_epoch":   uint64(1700000000),
+	}))
+	return buf.Bytes()
+}
+
+// encode writes v in the MaxMind DB data format
+func encode(v any) []byte {
+	ctrl := func(typ, size int) []byte {
+		if typ <= 7 {
+			return []byte{byte(typ<<5 | size)}
+		}
+		return []byte{byte(size), byte(typ - 7)}
+	}
+	uintField := func(typ int, n uint64, width int) []byte {
+		b := make([]byte, 8)
+		binary.BigEndian.PutUint64(b, n)
+		return append(ctrl(typ, width), b[8-width:]...)
+	}
+	switch v := v.(type) {
+	case string:
+		return append(ctrl(2, len(v)), v...)
+	case uint16:
+		return uintField(5, uint64(v), 2)
+	case uint32:
+		return uintField(6, uint64(v), 4)
+	case uint64:
+		return uintField(9, v, 8)
+	case map[string]any:
+		out := ctrl(7, len(v))
+		for k, val := range v {
+			out = append(out, encode(k)...)
+			out = append(out, encode(val)...)
+		}
+		return out
+	}
+	panic("unsupported type")
+}
+
+func TestReader_Country(t *testing.T) {
+	for _, version := range []int{4, 6} {
+		db := writeDB(t, version, map[string]string{
+			"81.2.69.0/24":    "GB",
+			"5.160.0.0/14":    "IR",
+			"2a02:d0::/29":    "DE",
+			"175.45.176.0/22": "KP",
+		})
+		r, err := geoip.FromBytes(db)
+		require.NoError(t, err)
+		assert.Equal(t, "Test-Country", r.Metadata().DatabaseType)
+
+		assert.Equal(t, "GB", r.Country("81.2.69.142"), "IPv%d database", version)
+		assert.Equal(t, "IR", r.Country("5.161.1.1"))
+		assert.Equal(t, "KP", r.Country("::ffff:175.45.177.1"), "IPv4-mapped addresses use the IPv4 network")
+		assert.Equal(t, "", r.Country("8.8.8.8"))
+		assert.Equal(t, "", r.Country("not an ip"))
+		if version == 6 {
+			assert.Equal(t, "DE", r.Country("2a02:d0::1"))
+		} else {
+			assert.Equal(t, "", r.Country("2a02:d0::1"), "an IPv4 database has no IPv6 networks")
+		}
+	}
+}
+
+func TestOpen(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "country.mmdb")
+	require.NoError(t, os.WriteFile(path, writeDB(t, 6, map[string]string{"81.2.69.0/24": "GB"}), 0o600))
+
+	r, err := geoip.Open(path)
+	require.NoError(t, err)
+	assert.Equal(t, "GB", r.Country("81.2.69.1"))
+
+	_, err = geoip.FromBytes([]byte("not a database"))
+	assert.ErrorIs(t, err, geoip.ErrInvalidDatabase)
+	_, err = geoip.Open(filepath.Join(dir, "missing.mmdb"))
+	assert.Error(t, err)
+}
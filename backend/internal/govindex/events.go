@@ -0,0 +1,139 @@
+package govindex
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// governorABIJSON is the part of the NexusGovernor ABI the indexer reads.
+// VoteCastWithDetails repeats VoteCast and is not indexed.
+const governorABIJSON = `[
+	{"type":"event","name":"VoteCast","inputs":[
+		{"name":"voter","type":"address","indexed":true},
+		{"name":"proposalId","type":"uint256","indexed":false},
+		{"name":"support","type":"uint8","indexed":false},
+		{"name":"weight","type":"uint256","indexed":false},
+		{"name":"reason","type":"string","indexed":false}]},
+	{"type":"event","name":"VoteCastWithParams","inputs":[
+		{"name":"voter","type":"address","indexed":true},
+		{"name":"proposalId","type":"uint256","indexed":false},
+		{"name":"support","type":"uint8","indexed":false},
+		{"name":"weight","type":"uint256","indexed":false},
+		{"name":"reason","type":"string","indexed":false},
+		{"name":"params","type":"bytes","indexed":false}]}
+]`
+
+var governorABI = mustParseABI(governorABIJSON)
+
+func mustParseABI(s string) abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(s))
+	if err != nil {
+		panic(fmt.Sprintf("govindex: invalid NexusGovernor ABI: %v", err))
+	}
+	return parsed
+}
+
+// Event topics
+var (
+	voteCastTopic           = governorABI.Events["VoteCast"].ID
+	voteCastWithParamsTopic = governorABI.Events["VoteCastWithParams"].ID
+)
+
+// watchedTopics are the events the indexer subscribes to
+var watchedTopics = []common.Hash{voteCastTopic, voteCastWithParamsTopic}
+
+// proposalArgs is the encoding the governor hashes proposal IDs from
+var proposalArgs = func() abi.Arguments {
+	names := []string{"address[]", "uint256[]", "bytes[]", "bytes32"}
+	args := make(abi.Arguments, len(names))
+	for i, t := range names {
+		typ, err := abi.NewType(t, "", nil)
+		if err != nil {
+			panic(fmt.Sprintf("govindex: invalid type %s: %v", t, err))
+		}
+		args[i] = abi.Argument{Type: typ}
+	}
+	return args
+}()
+
+// HashProposal returns the governor's ID for a proposal:
+// keccak256(abi.encode(targets, values, calldatas, keccak256(description))).
+// Values are base 10 wei and calldatas 0x-prefixed hex.
+func HashProposal(targets, values, calldatas []string, description string) (*big.Int, error) {
+	if len(targets) != len(values) || len(values) != len(calldatas) {
+		return nil, fmt.Errorf("targets, values and calldatas differ in length")
+	}
+	addrs := make([]common.Address, len(targets))
+	for i, t := range targets {
+		if !common.IsHexAddress(t) {
+			return nil, fmt.Errorf("invalid target %q", t)
+		}
+		addrs[i] = common.HexToAddress(t)
+	}
+	amounts := make([]*big.Int, len(values))
+	for i, v := range values {
+		n, ok := new(big.Int).SetString(v, 10)
+		if !ok || n.Sign() < 0 {
+			return nil, fmt.Errorf("invalid value %q", v)
+		}
+		amounts[i] = n
+	}
+	data := make([][]byte, len(calldatas))
+	for i, c := range calldatas {
+		b, err := hexutil.Decode(c)
+		if err != nil {
+			return nil, fmt.Errorf("invalid calldata %q: %w", c, err)
+		}
+		data[i] = b
+	}
+
+	encoded, err := proposalArgs.Pack(addrs, amounts, data, crypto.Keccak256Hash([]byte(description)))
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(crypto.Keccak256(encoded)), nil
+}
+
+// decodeVote decodes a VoteCast or VoteCastWithParams log
+func decodeVote(l types.Log) (proposalID *big.Int, vote *ChainVote, err error) {
+	var event string
+	switch l.Topics[0] {
+	case voteCastTopic:
+		event = "VoteCast"
+	case voteCastWithParamsTopic:
+		event = "VoteCastWithParams"
+	default:
+		return nil, nil, fmt.Errorf("unexpected event topic %s", l.Topics[0].Hex())
+	}
+	if len(l.Topics) != 2 {
+		return nil, nil, fmt.Errorf("%s: expected 2 topics, got %d", event, len(l.Topics))
+	}
+	values, err := governorABI.Unpack(event, l.Data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("decoding %s: %w", event, err)
+	}
+	proposalID, ok1 := values[0].(*big.Int)
+	support, ok2 := values[1].(uint8)
+	weight, ok3 := values[2].(*big.Int)
+	reason, ok4 := values[3].(string)
+	if !ok1 || !ok2 || !ok3 || !ok4 {
+		return nil, nil, fmt.Errorf("decoding %s: unexpected field types", event)
+	}
+
+	return proposalID, &ChainVote{
+		Voter:       strings.ToLower(common.BytesToAddress(l.Topics[1].Bytes()).Hex()),
+		Support:     support,
+		Weight:      weight.String(),
+		Reason:      reason,
+		TxHash:      l.TxHash.Hex(),
+		BlockNumber: l.BlockNumber,
+		LogIndex:    l.Index,
+	}, nil
+}
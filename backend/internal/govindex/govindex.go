@@ -0,0 +1,379 @@
+// Package govindex tallies governance votes from the governor's VoteCast
+// events rather than from the votes submitted through the API. An indexer
+// streams the events of the chain's nexusGovernor deployment (over
+// eth_subscribe when the network has a ws_url, eth_getLogs polling
+// otherwise), tallies them per on-chain proposal ID, and reconciles each API
+// proposal's recorded votes against them. Proposals with on-chain votes get
+// their totals replaced by the chain tally; votes that differ between the
+// two are flagged as discrepancies. TokenVotes reads the voting power votes
+// are weighted by, as of a proposal's snapshot block.
+package govindex
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/chain"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// governorDBName is the contract registry name of NexusGovernor
+const governorDBName = "nexusGovernor"
+
+// DefaultInterval is how often Run polls for logs without a subscription,
+// and reconciles API votes cast since the last event, when no interval is
+// given
+const DefaultInterval = 15 * time.Second
+
+// Vote support values, as in GovernorCountingSimple
+const (
+	SupportAgainst uint8 = 0
+	SupportFor     uint8 = 1
+	SupportAbstain uint8 = 2
+)
+
+// Discrepancy kinds
+const (
+	// DiscrepancyMissingOnChain is an API vote with no VoteCast event
+	DiscrepancyMissingOnChain = "missing_on_chain"
+	// DiscrepancyMissingInAPI is a VoteCast event with no API vote
+	DiscrepancyMissingInAPI = "missing_in_api"
+	// DiscrepancySupport is a vote cast with a different support on chain
+	DiscrepancySupport = "support_mismatch"
+	// DiscrepancyWeight is a vote counted with a different weight on chain
+	DiscrepancyWeight = "weight_mismatch"
+)
+
+// Client is the chain access the indexer needs (implemented by
+// chain.FailoverClient)
+type Client interface {
+	chain.LogPoller
+	WSURL() string
+}
+
+// Vote is a vote recorded through the API
+type Vote struct {
+	Voter   string `json:"voter"`
+	Support uint8  `json:"support"`
+	Weight  string `json:"weight"` // wei, base 10
+}
+
+// Proposal is an API proposal with the votes recorded for it. OnchainID is
+// the governor's proposal ID, nil when the proposal's actions don't hash to
+// one.
+type Proposal struct {
+	ID        string
+	OnchainID *big.Int
+	Votes     []Vote
+}
+
+// Governance is the API side of the governance proposals (implemented by
+// handlers.GovernanceHandler)
+type Governance interface {
+	// IndexedProposals returns every proposal with its recorded votes
+	IndexedProposals() []Proposal
+	// SetChainTally replaces a proposal's vote totals with the chain tally
+	SetChainTally(id string, forVotes, againstVotes, abstainVotes *big.Int)
+}
+
+// ChainVote is a vote seen in a VoteCast event
+type ChainVote struct {
+	Voter       string `json:"voter"`
+	Support     uint8  `json:"support"`
+	Weight      string `json:"weight"`
+	Reason      string `json:"reason,omitempty"`
+	TxHash      string `json:"tx_hash"`
+	BlockNumber uint64 `json:"block_number"`
+	LogIndex    uint   `json:"log_index"`
+}
+
+// Discrepancy is a voter whose API and on-chain votes disagree
+type Discrepancy struct {
+	Voter string     `json:"voter"`
+	Kind  string     `json:"kind"`
+	API   *Vote      `json:"api,omitempty"`
+	Chain *ChainVote `json:"chain,omitempty"`
+}
+
+// Tally is a proposal's on-chain tally and its reconciliation with the API
+type Tally struct {
+	ProposalID    string        `json:"proposal_id"`
+	OnchainID     string        `json:"onchain_id,omitempty"`
+	ForVotes      string        `json:"for_votes"`
+	AgainstVotes  string        `json:"against_votes"`
+	AbstainVotes  string        `json:"abstain_votes"`
+	Voters        int           `json:"voters"`
+	Discrepancies []Discrepancy `json:"discrepancies"`
+	ReconciledAt  time.Time     `json:"reconciled_at"`
+}
+
+// Indexer tallies the governor's vote events
+type Indexer struct {
+	client     Client
+	contracts  repository.ContractRepository
+	governance Governance
+	chainID    int64
+	logger     *zap.Logger
+
+	mu      sync.RWMutex
+	votes   map[string]map[string]*ChainVote // on-chain proposal ID -> voter -> vote
+	tallies map[string]*Tally                // API proposal ID -> tally
+	flagged map[string]int                   // API proposal ID -> discrepancies last logged
+
+	moved chan struct{} // signalled when the governor's address changes
+}
+
+// NewIndexer creates a vote indexer for the governor on chainID
+func NewIndexer(client Client, contracts repository.ContractRepository, governance Governance, chainID int64, logger *zap.Logger) *Indexer {
+	return &Indexer{
+		client:     client,
+		contracts:  contracts,
+		governance: governance,
+		chainID:    chainID,
+		logger:     logger,
+		votes:      make(map[string]map[string]*ChainVote),
+		tallies:    make(map[string]*Tally),
+		flagged:    make(map[string]int),
+		moved:      make(chan struct{}, 1),
+	}
+}
+
+// Run indexes vote events from the governor's deployment block until ctx is
+// done, reconciling after each batch of events and every interval. It waits
+// for the governor to be registered on the chain, and starts over from the
+// new deployment when the governor's address changes.
+func (x *Indexer) Run(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		governor := x.waitForGovernor(ctx, ticker)
+		if governor == nil {
+			return
+		}
+		if !x.index(ctx, governor, interval, ticker) {
+			return
+		}
+
+		// The old governor's votes no longer count
+		x.mu.Lock()
+		x.votes = make(map[string]map[string]*ChainVote)
+		x.mu.Unlock()
+	}
+}
+
+// ContractAddressChanged implements contractnotify.Listener: Run switches to
+// a moved governor
+func (x *Indexer) ContractAddressChanged(chainID int64, dbName, _ string) {
+	if chainID != x.chainID || dbName != governorDBName {
+		return
+	}
+	select {
+	case x.moved <- struct{}{}:
+	default:
+	}
+}
+
+// waitForGovernor returns the chain's governor once it is registered, nil
+// when ctx is done first
+func (x *Indexer) waitForGovernor(ctx context.Context, ticker *time.Ticker) *repository.ContractAddress {
+	for {
+		contract, err := x.contracts.GetByChainAndDBName(ctx, x.chainID, governorDBName)
+		switch {
+		case err == nil:
+			return contract
+		case errors.Is(err, repository.ErrContractAddressNotFound):
+			x.logger.Debug("vote indexing waiting for governor deployment", zap.Int64("chain_id", x.chainID))
+		default:
+			x.logger.Warn("loading governor contract failed", zap.Int64("chain_id", x.chainID), zap.Error(err))
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// index streams and reconciles the governor's vote events until ctx is done
+// (false) or the governor moves (true)
+func (x *Indexer) index(ctx context.Context, governor *repository.ContractAddress, interval time.Duration, ticker *time.Ticker) bool {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var from uint64
+	if governor.DeploymentBlock != nil && *governor.DeploymentBlock >= 0 {
+		from = uint64(*governor.DeploymentBlock)
+	}
+	subscriber := chain.NewLogSubscriber(x.chainID, x.client.WSURL(), x.client, ethereum.FilterQuery{
+		Addresses: []common.Address{common.HexToAddress(governor.Address)},
+		Topics:    [][]common.Hash{watchedTopics},
+	}, from, interval, x.logger)
+	logs := make(chan types.Log, 128)
+	go subscriber.Run(ctx, logs)
+
+	x.logger.Info("indexing governor votes",
+		zap.Int64("chain_id", x.chainID),
+		zap.String("governor", governor.Address),
+		zap.Uint64("from_block", from),
+	)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-x.moved:
+			x.logger.Info("governor moved, restarting vote indexing", zap.String("old_governor", governor.Address))
+			return true
+		case l := <-logs:
+			x.apply(l)
+			// Reconcile once the batch delivered so far is applied
+			for drained := false; !drained; {
+				select {
+				case l := <-logs:
+					x.apply(l)
+				default:
+					drained = true
+				}
+			}
+		case <-ticker.C:
+		}
+		x.Reconcile(time.Now().UTC())
+	}
+}
+
+// apply records a vote event, or forgets it when a reorg removed it
+func (x *Indexer) apply(l types.Log) {
+	if len(l.Topics) == 0 {
+		return
+	}
+	proposalID, vote, err := decodeVote(l)
+	if err != nil {
+		x.logger.Warn("skipping undecodable vote event", zap.String("tx_hash", l.TxHash.Hex()), zap.Uint("log_index", l.Index), zap.Error(err))
+		return
+	}
+	key := proposalID.String()
+
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	if l.Removed {
+		if seen, ok := x.votes[key][vote.Voter]; ok && seen.TxHash == vote.TxHash {
+			delete(x.votes[key], vote.Voter)
+		}
+		return
+	}
+	if x.votes[key] == nil {
+		x.votes[key] = make(map[string]*ChainVote)
+	}
+	x.votes[key][vote.Voter] = vote
+}
+
+// Reconcile tallies the on-chain votes of every API proposal, replaces the
+// totals of proposals voted on chain and flags API votes that disagree
+func (x *Indexer) Reconcile(now time.Time) {
+	proposals := x.governance.IndexedProposals()
+
+	x.mu.Lock()
+	tallies := make(map[string]*Tally, len(proposals))
+	var chainTallies []*Tally
+	for _, p := range proposals {
+		t := x.tally(p, now)
+		tallies[p.ID] = t
+		if t.Voters > 0 {
+			chainTallies = append(chainTallies, t)
+		}
+
+		if n := len(t.Discrepancies); n != x.flagged[p.ID] {
+			if n > 0 {
+				x.logger.Warn("governance votes disagree with chain",
+					zap.String("proposal_id", p.ID),
+					zap.String("onchain_id", t.OnchainID),
+					zap.Int("discrepancies", n),
+				)
+			}
+			x.flagged[p.ID] = n
+		}
+	}
+	x.tallies = tallies
+	x.mu.Unlock()
+
+	for _, t := range chainTallies {
+		x.governance.SetChainTally(t.ProposalID, parseWei(t.ForVotes), parseWei(t.AgainstVotes), parseWei(t.AbstainVotes))
+	}
+}
+
+// tally reconciles one proposal. x.mu must be held.
+func (x *Indexer) tally(p Proposal, now time.Time) *Tally {
+	t := &Tally{ProposalID: p.ID, ForVotes: "0", AgainstVotes: "0", AbstainVotes: "0", Discrepancies: []Discrepancy{}, ReconciledAt: now}
+	if p.OnchainID == nil {
+		return t
+	}
+	t.OnchainID = p.OnchainID.String()
+	onchain := x.votes[t.OnchainID]
+
+	totals := [3]*big.Int{new(big.Int), new(big.Int), new(big.Int)}
+	for _, v := range onchain {
+		if int(v.Support) < len(totals) {
+			totals[v.Support].Add(totals[v.Support], parseWei(v.Weight))
+		}
+	}
+	t.AgainstVotes = totals[SupportAgainst].String()
+	t.ForVotes = totals[SupportFor].String()
+	t.AbstainVotes = totals[SupportAbstain].String()
+	t.Voters = len(onchain)
+
+	recorded := make(map[string]bool, len(p.Votes))
+	for i := range p.Votes {
+		api := p.Votes[i]
+		recorded[api.Voter] = true
+		seen, ok := onchain[api.Voter]
+		switch {
+		case !ok:
+			t.Discrepancies = append(t.Discrepancies, Discrepancy{Voter: api.Voter, Kind: DiscrepancyMissingOnChain, API: &api})
+		case seen.Support != api.Support:
+			t.Discrepancies = append(t.Discrepancies, Discrepancy{Voter: api.Voter, Kind: DiscrepancySupport, API: &api, Chain: seen})
+		case parseWei(seen.Weight).Cmp(parseWei(api.Weight)) != 0:
+			t.Discrepancies = append(t.Discrepancies, Discrepancy{Voter: api.Voter, Kind: DiscrepancyWeight, API: &api, Chain: seen})
+		}
+	}
+	for voter, seen := range onchain {
+		if !recorded[voter] {
+			t.Discrepancies = append(t.Discrepancies, Discrepancy{Voter: voter, Kind: DiscrepancyMissingInAPI, Chain: seen})
+		}
+	}
+	sort.Slice(t.Discrepancies, func(i, j int) bool {
+		return t.Discrepancies[i].Voter < t.Discrepancies[j].Voter
+	})
+	return t
+}
+
+// Tally returns a proposal's tally as of the last reconciliation
+func (x *Indexer) Tally(proposalID string) (*Tally, bool) {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+
+	t, ok := x.tallies[proposalID]
+	return t, ok
+}
+
+// parseWei parses a base 10 amount, treating invalid input as zero
+func parseWei(s string) *big.Int {
+	n, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		return new(big.Int)
+	}
+	return n
+}
@@ -0,0 +1,281 @@
+package govindex_test
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/govindex"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/storage/memory"
+)
+
+const (
+	chainID  = 31337
+	governor = "0x00000000000000000000000000000000000000a1"
+
+	alice = "0x1111111111111111111111111111111111111111"
+	bob   = "0x2222222222222222222222222222222222222222"
+	carol = "0x3333333333333333333333333333333333333333"
+)
+
+var voteCastTopic = crypto.Keccak256Hash([]byte("VoteCast(address,uint256,uint8,uint256,string)"))
+
+// fakeChain serves the governor's logs up to its head
+type fakeChain struct {
+	mu   sync.Mutex
+	head uint64
+	logs []types.Log
+}
+
+func (f *fakeChain) BlockNumber(context.Context) (uint64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.head, nil
+}
+
+func (f *fakeChain) FilterLogs(_ context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var out []types.Log
+	for _, l := range f.logs {
+		if l.BlockNumber >= q.FromBlock.Uint64() && l.BlockNumber <= q.ToBlock.Uint64() && l.Address == q.Addresses[0] {
+			out = append(out, l)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeChain) WSURL() string { return "" }
+
+// vote appends a VoteCast log in a new block
+func (f *fakeChain) vote(t *testing.T, voter string, proposalID *big.Int, support uint8, weight int64) {
+	t.Helper()
+	args := abi.Arguments{{Type: mustType(t, "uint256")}, {Type: mustType(t, "uint8")}, {Type: mustType(t, "uint256")}, {Type: mustType(t, "string")}}
+	data, err := args.Pack(proposalID, support, big.NewInt(weight), "")
+	require.NoError(t, err)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.head++
+	f.logs = append(f.logs, types.Log{
+		Address:     common.HexToAddress(governor),
+		Topics:      []common.Hash{voteCastTopic, common.BytesToHash(common.HexToAddress(voter).Bytes())},
+		Data:        data,
+		BlockNumber: f.head,
+		TxHash:      crypto.Keccak256Hash([]byte(voter), proposalID.Bytes()),
+	})
+}
+
+func mustType(t *testing.T, name string) abi.Type {
+	typ, err := abi.NewType(name, "", nil)
+	require.NoError(t, err)
+	return typ
+}
+
+// fakeGovernance holds API proposals and the tallies pushed to them
+type fakeGovernance struct {
+	mu        sync.Mutex
+	proposals []govindex.Proposal
+	tallies   map[string][3]string
+}
+
+func (g *fakeGovernance) IndexedProposals() []govindex.Proposal {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return append([]govindex.Proposal(nil), g.proposals...)
+}
+
+func (g *fakeGovernance) SetChainTally(id string, forVotes, againstVotes, abstainVotes *big.Int) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.tallies[id] = [3]string{forVotes.String(), againstVotes.String(), abstainVotes.String()}
+}
+
+func (g *fakeGovernance) tally(id string) ([3]string, bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	t, ok := g.tallies[id]
+	return t, ok
+}
+
+func TestIndexer_TalliesAndReconciles(t *testing.T) {
+	ctx := context.Background()
+	contracts := memory.NewMemoryContractRepo()
+	contracts.AddNetwork(&repository.NetworkConfig{ChainID: chainID, NetworkName: "localhost", IsActive: true})
+	mappingID := contracts.AddMapping(&repository.ContractMapping{SolidityName: "NexusGovernor", DBName: "nexusGovernor"})
+	_, err := contracts.Upsert(ctx, &repository.ContractAddressUpsert{ChainID: chainID, ContractMappingID: mappingID, Address: governor})
+	require.NoError(t, err)
+
+	onchainID, err := govindex.HashProposal([]string{alice}, []string{"0"}, []string{"0x"}, "Fund the grants program")
+	require.NoError(t, err)
+	governance := &fakeGovernance{
+		proposals: []govindex.Proposal{
+			{ID: "p1", OnchainID: onchainID, Votes: []govindex.Vote{
+				{Voter: alice, Support: govindex.SupportFor, Weight: "100"},
+				{Voter: bob, Support: govindex.SupportFor, Weight: "50"},
+				{Voter: carol, Support: govindex.SupportAbstain, Weight: "10"},
+			}},
+			{ID: "offchain"},
+		},
+		tallies: make(map[string][3]string),
+	}
+
+	chain := &fakeChain{}
+	chain.vote(t, alice, onchainID, govindex.SupportFor, 100)
+	chain.vote(t, bob, onchainID, govindex.SupportAgainst, 50)
+	chain.vote(t, "0x4444444444444444444444444444444444444444", onchainID, govindex.SupportFor, 7)
+	chain.vote(t, alice, big.NewInt(12345), govindex.SupportFor, 1) // another proposal
+
+	indexer := govindex.NewIndexer(chain, contracts, governance, chainID, zap.NewNop())
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go indexer.Run(runCtx, 10*time.Millisecond)
+
+	require.Eventually(t, func() bool {
+		tally, ok := indexer.Tally("p1")
+		return ok && tally.Voters == 3
+	}, 5*time.Second, 10*time.Millisecond)
+
+	tally, _ := indexer.Tally("p1")
+	assert.Equal(t, onchainID.String(), tally.OnchainID)
+	assert.Equal(t, "107", tally.ForVotes)
+	assert.Equal(t, "50", tally.AgainstVotes)
+	assert.Equal(t, "0", tally.AbstainVotes)
+
+	kinds := make(map[string]string)
+	for _, d := range tally.Discrepancies {
+		kinds[d.Voter] = d.Kind
+	}
+	assert.Equal(t, map[string]string{
+		bob:   govindex.DiscrepancySupport,
+		carol: govindex.DiscrepancyMissingOnChain,
+		"0x4444444444444444444444444444444444444444": govindex.DiscrepancyMissingInAPI,
+	}, kinds)
+
+	// The chain tally replaces the API totals of proposals voted on chain
+	totals, ok := governance.tally("p1")
+	require.True(t, ok)
+	assert.Equal(t, [3]string{"107", "50", "0"}, totals)
+	_, ok = governance.tally("offchain")
+	assert.False(t, ok)
+	offchain, ok := indexer.Tally("offchain")
+	require.True(t, ok)
+	assert.Empty(t, offchain.OnchainID)
+	assert.Empty(t, offchain.Discrepancies)
+
+	// New events are picked up as they arrive
+	chain.vote(t, carol, onchainID, govindex.SupportAbstain, 10)
+	require.Eventually(t, func() bool {
+		tally, _ := indexer.Tally("p1")
+		return tally.Voters == 4 && len(tally.Discrepancies) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestIndexer_FollowsMovedGovernor(t *testing.T) {
+	ctx := context.Background()
+	contracts := memory.NewMemoryContractRepo()
+	contracts.AddNetwork(&repository.NetworkConfig{ChainID: chainID, NetworkName: "localhost", IsActive: true})
+	mappingID := contracts.AddMapping(&repository.ContractMapping{SolidityName: "NexusGovernor", DBName: "nexusGovernor"})
+	_, err := contracts.Upsert(ctx, &repository.ContractAddressUpsert{ChainID: chainID, ContractMappingID: mappingID, Address: governor})
+	require.NoError(t, err)
+
+	onchainID := big.NewInt(7)
+	governance := &fakeGovernance{
+		proposals: []govindex.Proposal{{ID: "p1", OnchainID: onchainID}},
+		tallies:   make(map[string][3]string),
+	}
+	chain := &fakeChain{}
+	chain.vote(t, alice, onchainID, govindex.SupportFor, 100)
+
+	indexer := govindex.NewIndexer(chain, contracts, governance, chainID, zap.NewNop())
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go indexer.Run(runCtx, 10*time.Millisecond)
+	require.Eventually(t, func() bool {
+		tally, ok := indexer.Tally("p1")
+		return ok && tally.Voters == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// The new governor's votes replace the old one's
+	moved := "0x00000000000000000000000000000000000000a2"
+	_, err = contracts.Upsert(ctx, &repository.ContractAddressUpsert{ChainID: chainID, ContractMappingID: mappingID, Address: moved})
+	require.NoError(t, err)
+	chain.vote(t, bob, onchainID, govindex.SupportAgainst, 5)
+	chain.mu.Lock()
+	chain.logs[len(chain.logs)-1].Address = common.HexToAddress(moved)
+	chain.mu.Unlock()
+	indexer.ContractAddressChanged(chainID, "nexusGovernor", moved)
+
+	require.Eventually(t, func() bool {
+		tally, _ := indexer.Tally("p1")
+		return tally.Voters == 1 && tally.AgainstVotes == "5"
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestHashProposal(t *testing.T) {
+	id, err := govindex.HashProposal([]string{alice}, []string{"0"}, []string{"0x"}, "a")
+	require.NoError(t, err)
+	again, err := govindex.HashProposal([]string{strings.ToUpper(alice[2:])}, []string{"0"}, []string{"0x"}, "a")
+	require.NoError(t, err)
+	assert.Equal(t, id, again)
+	other, err := govindex.HashProposal([]string{alice}, []string{"0"}, []string{"0x"}, "b")
+	require.NoError(t, err)
+	assert.NotEqual(t, id, other)
+
+	for _, tc := range [][3][]string{
+		{{"0x0"}, {"0"}, {"0x"}},
+		{{alice}, {"-1"}, {"0x"}},
+		{{alice}, {"0"}, {"zz"}},
+		{{alice}, {"0", "1"}, {"0x"}},
+	} {
+		_, err := govindex.HashProposal(tc[0], tc[1], tc[2], "a")
+		assert.Error(t, err, tc)
+	}
+}
+
+// fakeCaller answers view calls with one uint256 and records the block
+type fakeCaller struct {
+	block *big.Int
+	to    common.Address
+}
+
+func (f *fakeCaller) CallContract(_ context.Context, call ethereum.CallMsg, block *big.Int) ([]byte, error) {
+	f.block, f.to = block, *call.To
+	return common.LeftPadBytes(big.NewInt(42).Bytes(), 32), nil
+}
+
+func TestTokenVotes_AtBlock(t *testing.T) {
+	ctx := context.Background()
+	contracts := memory.NewMemoryContractRepo()
+	contracts.AddNetwork(&repository.NetworkConfig{ChainID: chainID, NetworkName: "localhost", IsActive: true})
+	caller := &fakeCaller{}
+	votes := govindex.NewTokenVotes(caller, contracts, chainID)
+
+	_, err := votes.VotingPower(ctx, alice, big.NewInt(9))
+	assert.ErrorIs(t, err, govindex.ErrTokenNotDeployed)
+
+	token := "0x00000000000000000000000000000000000000c1"
+	mappingID := contracts.AddMapping(&repository.ContractMapping{SolidityName: "NexusToken", DBName: "nexusToken"})
+	_, err = contracts.Upsert(ctx, &repository.ContractAddressUpsert{ChainID: chainID, ContractMappingID: mappingID, Address: token})
+	require.NoError(t, err)
+
+	power, err := votes.VotingPower(ctx, alice, big.NewInt(9))
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(42), power)
+	assert.Equal(t, big.NewInt(9), caller.block)
+	assert.Equal(t, common.HexToAddress(token), caller.to)
+}
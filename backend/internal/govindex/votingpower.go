@@ -0,0 +1,74 @@
+package govindex
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// tokenDBName is the contract registry name of NexusToken
+const tokenDBName = "nexusToken"
+
+// ErrTokenNotDeployed is returned when NexusToken is not registered on the chain
+var ErrTokenNotDeployed = errors.New("nexusToken is not registered on this chain")
+
+// tokenABI is the part of NexusToken voting power is read from
+var tokenABI = mustParseABI(`[
+	{"type":"function","name":"getVotes","stateMutability":"view","inputs":[{"name":"account","type":"address"}],"outputs":[{"name":"","type":"uint256"}]}
+]`)
+
+// Caller performs view calls (implemented by chain.FailoverClient)
+type Caller interface {
+	CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error)
+}
+
+// TokenVotes reads NexusToken voting power as of a block (the voting power
+// source of handlers.GovernanceHandler). Blocks older than the node's state
+// history need an archive node.
+type TokenVotes struct {
+	client    Caller
+	contracts repository.ContractRepository
+	chainID   int64
+}
+
+// NewTokenVotes creates a voting power reader for the token on chainID
+func NewTokenVotes(client Caller, contracts repository.ContractRepository, chainID int64) *TokenVotes {
+	return &TokenVotes{client: client, contracts: contracts, chainID: chainID}
+}
+
+// VotingPower returns the votes delegated to address at the end of block,
+// or at the latest block when block is nil
+func (v *TokenVotes) VotingPower(ctx context.Context, address string, block *big.Int) (*big.Int, error) {
+	contract, err := v.contracts.GetByChainAndDBName(ctx, v.chainID, tokenDBName)
+	if errors.Is(err, repository.ErrContractAddressNotFound) {
+		return nil, ErrTokenNotDeployed
+	}
+	if err != nil {
+		return nil, fmt.Errorf("loading %s: %w", tokenDBName, err)
+	}
+
+	data, err := tokenABI.Pack("getVotes", common.HexToAddress(address))
+	if err != nil {
+		return nil, err
+	}
+	to := common.HexToAddress(contract.Address)
+	result, err := v.client.CallContract(ctx, ethereum.CallMsg{To: &to, Data: data}, block)
+	if err != nil {
+		return nil, fmt.Errorf("getVotes: %w", err)
+	}
+	out, err := tokenABI.Unpack("getVotes", result)
+	if err != nil || len(out) != 1 {
+		return nil, fmt.Errorf("decoding getVotes: %v", err)
+	}
+	votes, ok := out[0].(*big.Int)
+	if !ok {
+		return nil, fmt.Errorf("decoding getVotes: got %T", out[0])
+	}
+	return votes, nil
+}
@@ -0,0 +1,336 @@
+// Package govnotify notifies token holders of governance proposal lifecycle
+// events: a proposal opening for votes, its votes nearing quorum, and its
+// queueing in the timelock. Holders subscribe an address with a webhook URL,
+// a signing secret, the events they want and a digest option; alerts are
+// queued as webhook events and POSTed in the background, signed like payment
+// callbacks. Subscribers without a digest are alerted as each event is
+// recorded, the others once a day or week with the events since their last
+// digest. Nothing is recorded until app_config governance_notifications.enabled
+// is set.
+package govnotify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/callbacks"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// namespace is the app_config namespace holding the notification settings
+const namespace = "governance_notifications"
+
+// DefaultInterval is how often Run checks proposals when no interval is
+// given
+const DefaultInterval = time.Minute
+
+// defaultNearQuorumPercent applies when
+// governance_notifications.near_quorum_percent is unset
+const defaultNearQuorumPercent = 80
+
+// subscriptionPageSize bounds one page of subscriptions
+const subscriptionPageSize = 100
+
+// maxDigestEvents bounds the events listed in one digest; the rest go in
+// the next one
+const maxDigestEvents = 200
+
+// AlertTypeDigest is the type of digest alerts. Single-event alerts are
+// typed "governance." followed by the event type.
+const AlertTypeDigest = "governance.digest"
+
+// Proposal is the part of a governance proposal its lifecycle events are
+// read from
+type Proposal struct {
+	ID      string
+	Title   string
+	State   string   // handlers.ProposalState values
+	Votes   *big.Int // votes counted toward quorum
+	Quorum  *big.Int
+	EndTime time.Time
+	Eta     *time.Time // timelock execution time, once queued
+}
+
+// Proposals lists the current governance proposals with their state brought
+// up to date (implemented by handlers.GovernanceHandler)
+type Proposals interface {
+	LifecycleProposals() []Proposal
+}
+
+// Alert is the body POSTed to a subscriber's webhook URL
+type Alert struct {
+	ID          string                        `json:"id"` // stable across retries, so redeliveries can be deduplicated
+	Type        string                        `json:"type"`
+	Address     string                        `json:"address"`
+	DisplayName string                        `json:"display_name,omitempty"` // from the address's profile
+	Events      []*repository.GovernanceEvent `json:"events"`
+	OccurredAt  time.Time                     `json:"occurred_at"`
+}
+
+// Profiles looks up the profile of an alert's address (implemented by
+// repository.ProfileRepository)
+type Profiles interface {
+	GetProfile(ctx context.Context, address string) (*repository.Profile, error)
+}
+
+// Watcher records proposal lifecycle events and queues subscribers' alerts
+type Watcher struct {
+	repo       repository.GovernanceNotificationRepository
+	configRepo repository.AppConfigRepository
+	queue      callbacks.Queue
+	proposals  Proposals
+	profiles   Profiles
+	logger     *zap.Logger
+	now        func() time.Time
+}
+
+// NewWatcher creates a watcher queueing alerts on q for the Sender
+func NewWatcher(repo repository.GovernanceNotificationRepository, configRepo repository.AppConfigRepository, q callbacks.Queue, logger *zap.Logger) *Watcher {
+	return &Watcher{
+		repo:       repo,
+		configRepo: configRepo,
+		queue:      q,
+		logger:     logger,
+		now:        time.Now,
+	}
+}
+
+// SetProposals sets the proposals whose lifecycle is watched
+func (w *Watcher) SetProposals(p Proposals) {
+	w.proposals = p
+}
+
+// SetProfiles sets where alerts' display names are looked up
+func (w *Watcher) SetProfiles(p Profiles) {
+	w.profiles = p
+}
+
+// Run checks proposals and sends due digests every interval until ctx is
+// done
+func (w *Watcher) Run(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := w.RunOnce(ctx); err != nil {
+			w.logger.Warn("governance notification check failed", zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce records new lifecycle events, alerts the subscribers without a
+// digest of them and sends the digests that are due, if
+// governance_notifications.enabled is set
+func (w *Watcher) RunOnce(ctx context.Context) error {
+	if enabled, err := w.configRepo.GetBool(ctx, namespace, "enabled", 0); err != nil || !enabled {
+		return nil
+	}
+
+	events, err := w.Record(ctx)
+	if err != nil {
+		return err
+	}
+	for _, e := range events {
+		if err := w.alertSubscribers(ctx, e); err != nil {
+			return err
+		}
+	}
+	return w.SendDigests(ctx)
+}
+
+// Record records the lifecycle events the proposals have reached and
+// returns those not recorded before
+func (w *Watcher) Record(ctx context.Context) ([]*repository.GovernanceEvent, error) {
+	if w.proposals == nil {
+		return nil, nil
+	}
+	percent, err := w.configRepo.GetNumber(ctx, namespace, "near_quorum_percent", 0)
+	if err != nil || percent <= 0 {
+		percent = defaultNearQuorumPercent
+	}
+
+	var recorded []*repository.GovernanceEvent
+	for _, p := range w.proposals.LifecycleProposals() {
+		for _, e := range lifecycleEvents(p, percent) {
+			ok, err := w.repo.RecordGovernanceEvent(ctx, e)
+			if err != nil {
+				return recorded, err
+			}
+			if ok {
+				w.logger.Info("governance event recorded",
+					zap.String("proposal_id", e.ProposalID),
+					zap.String("event_type", string(e.EventType)),
+				)
+				recorded = append(recorded, e)
+			}
+		}
+	}
+	return recorded, nil
+}
+
+// lifecycleEvents returns the events p has reached. Events already recorded
+// are skipped by the repository.
+func lifecycleEvents(p Proposal, nearQuorumPercent int64) []*repository.GovernanceEvent {
+	var events []*repository.GovernanceEvent
+	add := func(t repository.GovernanceEventType, detail string) {
+		events = append(events, &repository.GovernanceEvent{ProposalID: p.ID, EventType: t, Title: p.Title, Detail: detail})
+	}
+
+	switch p.State {
+	case "active":
+		add(repository.GovernanceEventProposalOpened, "Voting ends "+p.EndTime.UTC().Format(time.RFC3339))
+		if p.Votes != nil && p.Quorum != nil && p.Quorum.Sign() > 0 {
+			// votes*100 >= quorum*percent, avoiding a division
+			votes := new(big.Int).Mul(p.Votes, big.NewInt(100))
+			threshold := new(big.Int).Mul(p.Quorum, big.NewInt(nearQuorumPercent))
+			if votes.Cmp(threshold) >= 0 {
+				reached := new(big.Int).Quo(votes, p.Quorum)
+				add(repository.GovernanceEventNearQuorum, reached.String()+"% of quorum reached")
+			}
+		}
+	case "queued":
+		detail := "Queued in the timelock"
+		if p.Eta != nil {
+			detail = "Executable from " + p.Eta.UTC().Format(time.RFC3339)
+		}
+		add(repository.GovernanceEventProposalQueued, detail)
+	}
+	return events
+}
+
+// alertSubscribers queues an alert of e for every subscriber who wants it
+// as it happens
+func (w *Watcher) alertSubscribers(ctx context.Context, e *repository.GovernanceEvent) error {
+	filter := repository.GovernanceSubscriptionFilter{Event: e.EventType, Digest: repository.GovernanceDigestNone}
+	return w.eachSubscription(ctx, filter, func(s *repository.GovernanceSubscription) error {
+		w.enqueue(ctx, &Alert{
+			ID:         e.ID + ":" + s.Address,
+			Type:       "governance." + string(e.EventType),
+			Address:    s.Address,
+			Events:     []*repository.GovernanceEvent{e},
+			OccurredAt: e.OccurredAt,
+		})
+		return nil
+	})
+}
+
+// SendDigests queues the daily and weekly digests that are due. A digest
+// lists the subscribed events recorded since the previous one (or since the
+// subscription was created); none is sent when there are none, but the
+// schedule moves on either way.
+func (w *Watcher) SendDigests(ctx context.Context) error {
+	for _, digest := range []repository.GovernanceDigest{repository.GovernanceDigestDaily, repository.GovernanceDigestWeekly} {
+		filter := repository.GovernanceSubscriptionFilter{Digest: digest}
+		err := w.eachSubscription(ctx, filter, func(s *repository.GovernanceSubscription) error {
+			return w.sendDigest(ctx, s)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sendDigest queues s's digest if it is due
+func (w *Watcher) sendDigest(ctx context.Context, s *repository.GovernanceSubscription) error {
+	now := w.now()
+	since := s.CreatedAt
+	if s.LastDigestAt != nil {
+		since = *s.LastDigestAt
+	}
+	if now.Before(since.Add(s.Digest.Period())) {
+		return nil
+	}
+
+	events, err := w.repo.ListGovernanceEvents(ctx, since, maxDigestEvents)
+	if err != nil {
+		return fmt.Errorf("listing governance events since %s: %w", since.Format(time.RFC3339), err)
+	}
+	sentAt := now
+	if len(events) == maxDigestEvents {
+		// Pick up after the last listed event next time
+		sentAt = events[len(events)-1].OccurredAt
+	}
+
+	var wanted []*repository.GovernanceEvent
+	for _, e := range events {
+		if s.Wants(e.EventType) {
+			wanted = append(wanted, e)
+		}
+	}
+	if len(wanted) > 0 {
+		w.enqueue(ctx, &Alert{
+			ID:         "digest:" + s.Address + ":" + strconv.FormatInt(sentAt.UnixNano(), 10),
+			Type:       AlertTypeDigest,
+			Address:    s.Address,
+			Events:     wanted,
+			OccurredAt: now.UTC(),
+		})
+	}
+
+	if err := w.repo.MarkGovernanceDigestSent(ctx, s.Address, sentAt); err != nil {
+		return fmt.Errorf("marking digest sent for %s: %w", s.Address, err)
+	}
+	return nil
+}
+
+// eachSubscription calls fn for every subscription matching filter
+func (w *Watcher) eachSubscription(ctx context.Context, filter repository.GovernanceSubscriptionFilter, fn func(*repository.GovernanceSubscription) error) error {
+	for page := 1; ; page++ {
+		subscriptions, total, err := w.repo.ListGovernanceSubscriptions(ctx, filter, repository.Pagination{Page: page, PageSize: subscriptionPageSize})
+		if err != nil {
+			return fmt.Errorf("listing governance subscriptions: %w", err)
+		}
+		for _, s := range subscriptions {
+			if err := fn(s); err != nil {
+				return err
+			}
+		}
+		if int64(page*subscriptionPageSize) >= total || len(subscriptions) == 0 {
+			return nil
+		}
+	}
+}
+
+// enqueue queues an alert. The event is already recorded, so a queueing
+// failure is logged rather than returned, and the alert goes out without a
+// display name if the profile can't be read.
+func (w *Watcher) enqueue(ctx context.Context, a *Alert) {
+	if w.profiles != nil {
+		if p, err := w.profiles.GetProfile(ctx, a.Address); err == nil && p.DisplayName != nil {
+			a.DisplayName = *p.DisplayName
+		}
+	}
+
+	payload, err := json.Marshal(a)
+	if err == nil {
+		_, err = w.queue.Enqueue(ctx, &repository.WebhookEvent{
+			Provider:  repository.WebhookProviderGovernanceAlert,
+			EventID:   a.ID,
+			EventType: a.Type,
+			Payload:   payload,
+		})
+	}
+	if err != nil {
+		w.logger.Error("failed to queue governance alert",
+			zap.String("alert_id", a.ID),
+			zap.String("address", a.Address),
+			zap.Error(err),
+		)
+	}
+}
@@ -0,0 +1,208 @@
+package govnotify_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/callbacks"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/govnotify"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/storage/memory"
+)
+
+// fakeQueue records enqueued alerts by event ID
+type fakeQueue struct {
+	events map[string]*repository.WebhookEvent
+}
+
+func (q *fakeQueue) Enqueue(_ context.Context, e *repository.WebhookEvent) (bool, error) {
+	if _, ok := q.events[e.EventID]; ok {
+		return false, nil
+	}
+	q.events[e.EventID] = e
+	return true, nil
+}
+
+// alerts decodes the queued alerts for address
+func (q *fakeQueue) alerts(t *testing.T, address string) []govnotify.Alert {
+	t.Helper()
+	var out []govnotify.Alert
+	for _, e := range q.events {
+		var a govnotify.Alert
+		require.NoError(t, json.Unmarshal(e.Payload, &a))
+		if a.Address == address {
+			out = append(out, a)
+		}
+	}
+	return out
+}
+
+// proposals is a fixed Proposals source
+type proposals []govnotify.Proposal
+
+func (p proposals) LifecycleProposals() []govnotify.Proposal { return p }
+
+func tokens(n int64) *big.Int {
+	return new(big.Int).Mul(big.NewInt(n), big.NewInt(1e18))
+}
+
+func newWatcher(t *testing.T) (*govnotify.Watcher, *memory.Store, *fakeQueue) {
+	t.Helper()
+	store := memory.NewStore()
+	enabled := true
+	require.NoError(t, store.AppConfig.Create(context.Background(), &repository.AppConfigCreate{
+		Namespace: "governance_notifications", ConfigKey: "enabled", ValueType: "boolean", ValueBoolean: &enabled, UpdatedBy: "test",
+	}))
+	queue := &fakeQueue{events: make(map[string]*repository.WebhookEvent)}
+	return govnotify.NewWatcher(store.GovernanceAlerts, store.AppConfig, queue, zap.NewNop()), store, queue
+}
+
+func subscribe(t *testing.T, store *memory.Store, address string, digest repository.GovernanceDigest, events ...repository.GovernanceEventType) {
+	t.Helper()
+	s := &repository.GovernanceSubscription{Address: address, WebhookURL: "https://example.com/" + address, Secret: "0123456789abcdef", Digest: digest}
+	for _, e := range events {
+		s.Events = append(s.Events, string(e))
+	}
+	require.NoError(t, store.GovernanceAlerts.UpsertGovernanceSubscription(context.Background(), s))
+}
+
+func TestRunOnce_AlertsSubscribers(t *testing.T) {
+	ctx := context.Background()
+	watcher, store, queue := newWatcher(t)
+	subscribe(t, store, "0xopen", repository.GovernanceDigestNone, repository.GovernanceEventProposalOpened)
+	subscribe(t, store, "0xquorum", repository.GovernanceDigestNone, repository.GovernanceEventNearQuorum, repository.GovernanceEventProposalQueued)
+
+	eta := time.Now().Add(48 * time.Hour)
+	source := proposals{
+		{ID: "p1", Title: "Fund grants", State: "active", Votes: tokens(3_000_000), Quorum: tokens(4_000_000), EndTime: time.Now().Add(time.Hour)},
+		{ID: "p2", Title: "Raise fees", State: "active", Votes: tokens(1_000_000), Quorum: tokens(4_000_000), EndTime: time.Now().Add(time.Hour)},
+		{ID: "p3", Title: "Upgrade", State: "queued", Votes: tokens(5_000_000), Quorum: tokens(4_000_000), Eta: &eta},
+	}
+	watcher.SetProposals(source)
+	require.NoError(t, watcher.RunOnce(ctx))
+
+	open := queue.alerts(t, "0xopen")
+	require.Len(t, open, 2, "both active proposals opened")
+	for _, a := range open {
+		assert.Equal(t, "governance.proposal_opened", a.Type)
+		require.Len(t, a.Events, 1)
+	}
+
+	quorum := queue.alerts(t, "0xquorum")
+	require.Len(t, quorum, 1, "p1 is at 75% of quorum, short of the 80% default")
+	assert.Equal(t, "governance.proposal_queued", quorum[0].Type)
+	assert.Equal(t, "p3", quorum[0].Events[0].ProposalID)
+
+	// Crossing the threshold alerts once
+	source[0].Votes = tokens(3_500_000)
+	require.NoError(t, watcher.RunOnce(ctx))
+	require.NoError(t, watcher.RunOnce(ctx))
+	quorum = queue.alerts(t, "0xquorum")
+	require.Len(t, quorum, 2)
+	assert.Len(t, queue.alerts(t, "0xopen"), 2, "events are recorded once")
+}
+
+func TestRunOnce_SendsDueDigests(t *testing.T) {
+	ctx := context.Background()
+	watcher, store, queue := newWatcher(t)
+	subscribe(t, store, "0xdaily", repository.GovernanceDigestDaily, repository.GovernanceEventProposalOpened)
+	subscribe(t, store, "0xweekly", repository.GovernanceDigestWeekly, repository.GovernanceEventProposalOpened)
+	subscribe(t, store, "0xnew", repository.GovernanceDigestDaily, repository.GovernanceEventProposalOpened)
+	require.NoError(t, store.GovernanceAlerts.MarkGovernanceDigestSent(ctx, "0xdaily", time.Now().Add(-25*time.Hour)))
+	require.NoError(t, store.GovernanceAlerts.MarkGovernanceDigestSent(ctx, "0xweekly", time.Now().Add(-48*time.Hour)))
+
+	watcher.SetProposals(proposals{
+		{ID: "p1", Title: "Fund grants", State: "active", EndTime: time.Now().Add(time.Hour)},
+		{ID: "p2", Title: "Raise fees", State: "active", EndTime: time.Now().Add(time.Hour)},
+	})
+	require.NoError(t, watcher.RunOnce(ctx))
+
+	daily := queue.alerts(t, "0xdaily")
+	require.Len(t, daily, 1, "a digest is one alert")
+	assert.Equal(t, govnotify.AlertTypeDigest, daily[0].Type)
+	assert.Len(t, daily[0].Events, 2)
+	assert.Empty(t, queue.alerts(t, "0xweekly"), "the weekly digest is not due")
+	assert.Empty(t, queue.alerts(t, "0xnew"), "a new subscription's first digest is a day away")
+
+	s, err := store.GovernanceAlerts.GetGovernanceSubscription(ctx, "0xdaily")
+	require.NoError(t, err)
+	require.NotNil(t, s.LastDigestAt)
+	assert.WithinDuration(t, time.Now(), *s.LastDigestAt, time.Minute)
+
+	require.NoError(t, watcher.RunOnce(ctx))
+	assert.Len(t, queue.alerts(t, "0xdaily"), 1, "the next digest waits a day")
+}
+
+func TestRunOnce_Disabled(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	queue := &fakeQueue{events: make(map[string]*repository.WebhookEvent)}
+	watcher := govnotify.NewWatcher(store.GovernanceAlerts, store.AppConfig, queue, zap.NewNop())
+	watcher.SetProposals(proposals{{ID: "p1", State: "active"}})
+
+	require.NoError(t, watcher.RunOnce(ctx))
+	events, err := store.GovernanceAlerts.ListGovernanceEvents(ctx, time.Time{}, 10)
+	require.NoError(t, err)
+	assert.Empty(t, events, "nothing is recorded until governance_notifications.enabled is set")
+}
+
+func TestSender_SignsAndDropsDeleted(t *testing.T) {
+	ctx := context.Background()
+	var received *http.Request
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		body, _ = io.ReadAll(r.Body)
+	}))
+	t.Cleanup(server.Close)
+
+	store := memory.NewStore()
+	require.NoError(t, store.GovernanceAlerts.UpsertGovernanceSubscription(ctx, &repository.GovernanceSubscription{
+		Address: "0xaa", WebhookURL: server.URL, Secret: "0123456789abcdef", Events: []string{"proposal_opened"}, Digest: repository.GovernanceDigestNone,
+	}))
+	sender := govnotify.NewSender(store.GovernanceAlerts, true, zap.NewNop())
+
+	payload := []byte(`{"id":"e1:0xaa","type":"governance.proposal_opened","address":"0xaa","events":[]}`)
+	event := &repository.WebhookEvent{Provider: repository.WebhookProviderGovernanceAlert, EventID: "e1:0xaa", Payload: payload}
+	require.NoError(t, sender.ProcessWebhookEvent(ctx, event))
+	require.NotNil(t, received)
+	assert.Equal(t, payload, body)
+	assert.Equal(t, "e1:0xaa", received.Header.Get(callbacks.EventIDHeader))
+	assert.True(t, strings.HasPrefix(received.Header.Get(callbacks.SignatureHeader), "t="))
+
+	received = nil
+	require.NoError(t, store.GovernanceAlerts.DeleteGovernanceSubscription(ctx, "0xaa"))
+	require.NoError(t, sender.ProcessWebhookEvent(ctx, event))
+	assert.Nil(t, received, "alerts for a deleted subscription are dropped")
+}
+
+func TestRunOnce_AddsDisplayName(t *testing.T) {
+	ctx := context.Background()
+	watcher, store, queue := newWatcher(t)
+	watcher.SetProfiles(store.Profiles)
+	subscribe(t, store, "0xnamed", repository.GovernanceDigestNone, repository.GovernanceEventProposalOpened)
+	subscribe(t, store, "0xanon", repository.GovernanceDigestNone, repository.GovernanceEventProposalOpened)
+	name := "Alice"
+	require.NoError(t, store.Profiles.UpsertProfile(ctx, &repository.Profile{Address: "0xnamed", DisplayName: &name, PreferredCurrency: "USD"}))
+
+	watcher.SetProposals(proposals{{ID: "p1", Title: "Fund grants", State: "active", EndTime: time.Now().Add(time.Hour)}})
+	require.NoError(t, watcher.RunOnce(ctx))
+
+	named := queue.alerts(t, "0xnamed")
+	require.Len(t, named, 1)
+	assert.Equal(t, "Alice", named[0].DisplayName)
+	anon := queue.alerts(t, "0xanon")
+	require.Len(t, anon, 1)
+	assert.Empty(t, anon[0].DisplayName)
+}
@@ -0,0 +1,93 @@
+package govnotify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/callbacks"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// deliveryTimeout bounds one POST to a subscriber's webhook
+const deliveryTimeout = 10 * time.Second
+
+// Sender delivers queued governance alerts (registered with the webhook
+// processor for WebhookProviderGovernanceAlert). Each alert goes to the
+// subscriber's current webhook URL, signed with their secret.
+type Sender struct {
+	repo   repository.GovernanceNotificationRepository
+	client *http.Client
+	logger *zap.Logger
+	now    func() time.Time
+}
+
+// NewSender creates a governance alert sender. Subscribers choose their own
+// URLs, so like payment callbacks non-public addresses are refused unless
+// allowInsecure is set (local development).
+func NewSender(repo repository.GovernanceNotificationRepository, allowInsecure bool, logger *zap.Logger) *Sender {
+	return &Sender{
+		repo:   repo,
+		client: callbacks.NewClient(allowInsecure),
+		logger: logger,
+		now:    time.Now,
+	}
+}
+
+// ProcessWebhookEvent POSTs a queued alert to the subscriber's webhook
+// (called by the background webhook processor). Alerts for a deleted
+// subscription are dropped. A non-2xx response is retried with backoff until
+// the event is dead-lettered.
+func (s *Sender) ProcessWebhookEvent(ctx context.Context, e *repository.WebhookEvent) error {
+	var a Alert
+	if err := json.Unmarshal(e.Payload, &a); err != nil {
+		return fmt.Errorf("parsing stored governance alert: %w", err)
+	}
+
+	sub, err := s.repo.GetGovernanceSubscription(ctx, a.Address)
+	if err != nil {
+		if errors.Is(err, repository.ErrGovernanceSubscriptionNotFound) {
+			s.logger.Info("dropping governance alert for a deleted subscription",
+				zap.String("alert_id", a.ID),
+				zap.String("address", a.Address),
+			)
+			return nil
+		}
+		return fmt.Errorf("getting governance subscription %s: %w", a.Address, err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deliveryTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.WebhookURL, bytes.NewReader(e.Payload))
+	if err != nil {
+		return fmt.Errorf("building governance alert request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "nexus-protocol-governance/1")
+	req.Header.Set(callbacks.EventIDHeader, a.ID)
+	req.Header.Set(callbacks.SignatureHeader, callbacks.Sign(sub.Secret, s.now(), e.Payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("posting governance alert: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("governance webhook returned %d", resp.StatusCode)
+	}
+	s.logger.Info("governance alert delivered",
+		zap.String("alert_id", a.ID),
+		zap.String("address", a.Address),
+		zap.Int("events", len(a.Events)),
+	)
+	return nil
+}
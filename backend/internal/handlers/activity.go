@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// ActivityHandler serves users' activity feeds
+type ActivityHandler struct {
+	repo   repository.ActivityRepository
+	logger *zap.Logger
+}
+
+// NewActivityHandler creates a new activity handler with injected dependencies
+func NewActivityHandler(repo repository.ActivityRepository, logger *zap.Logger) *ActivityHandler {
+	return &ActivityHandler{repo: repo, logger: logger}
+}
+
+// ActivityResponse wraps activity API responses
+type ActivityResponse struct {
+	Success bool        `json:"success"`
+	Data    interface{} `json:"data,omitempty"`
+	Error   string      `json:"error,omitempty"`
+}
+
+// ListActivity handles GET /api/v1/users/:address/activity
+// @Summary Get a user's activity feed
+// @Description Lists the address's payments, KYC events, NFT transfers, votes and relayed transactions as one timeline, newest first
+// @Tags users
+// @Produce json
+// @Param address path string true "Wallet address"
+// @Param kind query string false "Comma-separated kinds: payment, kyc, nft_transfer, vote, meta_tx"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(20)
+// @Success 200 {object} ActivityResponse
+// @Failure 400 {object} ActivityResponse
+// @Router /api/v1/users/{address}/activity [get]
+func (h *ActivityHandler) ListActivity(c *gin.Context) {
+	address := c.Param("address")
+	if !isValidAddress(address) {
+		c.JSON(http.StatusBadRequest, ActivityResponse{Success: false, Error: "Invalid address"})
+		return
+	}
+
+	filter := repository.ActivityFilter{Address: strings.ToLower(address)}
+	if kinds := c.Query("kind"); kinds != "" {
+		for _, k := range strings.Split(kinds, ",") {
+			kind := repository.ActivityKind(strings.TrimSpace(k))
+			if !kind.Valid() {
+				c.JSON(http.StatusBadRequest, ActivityResponse{Success: false, Error: "Invalid kind: " + string(kind)})
+				return
+			}
+			filter.Kinds = append(filter.Kinds, kind)
+		}
+	}
+
+	page, pageSize, ok := queryPage(c, 20)
+	if !ok {
+		return
+	}
+
+	events, total, err := h.repo.ListActivity(c.Request.Context(), filter, repository.Pagination{
+		Page:     page,
+		PageSize: pageSize,
+	})
+	if err != nil {
+		h.logger.Error("failed to list activity", zap.String("address", filter.Address), zap.Error(err))
+		c.JSON(http.StatusInternalServerError, ActivityResponse{Success: false, Error: "Internal server error"})
+		return
+	}
+	if events == nil {
+		events = []*repository.ActivityEvent{}
+	}
+
+	c.JSON(http.StatusOK, ActivityResponse{
+		Success: true,
+		Data: gin.H{
+			"events":    events,
+			"total":     total,
+			"page":      page,
+			"page_size": pageSize,
+		},
+	})
+}
@@ -143,11 +143,9 @@ func (h *AppConfigHandler) ListAll(c *gin.Context) {
 // @Router /api/v1/config/{namespace} [get]
 func (h *AppConfigHandler) ListByNamespace(c *gin.Context) {
 	namespace := c.Param("namespace")
-	chainID := int64(0)
-	if chainStr := c.Query("chain_id"); chainStr != "" {
-		if id, err := strconv.ParseInt(chainStr, 10, 64); err == nil {
-			chainID = id
-		}
+	chainID, ok := appConfigChainID(c)
+	if !ok {
+		return
 	}
 
 	configs, err := h.repo.ListByNamespace(c.Request.Context(), namespace, chainID)
@@ -190,11 +188,9 @@ func (h *AppConfigHandler) ListByNamespace(c *gin.Context) {
 func (h *AppConfigHandler) GetConfig(c *gin.Context) {
 	namespace := c.Param("namespace")
 	key := c.Param("key")
-	chainID := int64(0)
-	if chainStr := c.Query("chain_id"); chainStr != "" {
-		if id, err := strconv.ParseInt(chainStr, 10, 64); err == nil {
-			chainID = id
-		}
+	chainID, ok := appConfigChainID(c)
+	if !ok {
+		return
 	}
 
 	// Use fallback to try chain-specific first, then global
@@ -236,11 +232,9 @@ func (h *AppConfigHandler) GetConfig(c *gin.Context) {
 func (h *AppConfigHandler) UpdateConfig(c *gin.Context) {
 	namespace := c.Param("namespace")
 	key := c.Param("key")
-	chainID := int64(0)
-	if chainStr := c.Query("chain_id"); chainStr != "" {
-		if id, err := strconv.ParseInt(chainStr, 10, 64); err == nil {
-			chainID = id
-		}
+	chainID, ok := appConfigChainID(c)
+	if !ok {
+		return
 	}
 
 	var req AppConfigUpdateRequest
@@ -441,11 +435,9 @@ func (h *AppConfigHandler) CreateConfig(c *gin.Context) {
 func (h *AppConfigHandler) DeleteConfig(c *gin.Context) {
 	namespace := c.Param("namespace")
 	key := c.Param("key")
-	chainID := int64(0)
-	if chainStr := c.Query("chain_id"); chainStr != "" {
-		if id, err := strconv.ParseInt(chainStr, 10, 64); err == nil {
-			chainID = id
-		}
+	chainID, ok := appConfigChainID(c)
+	if !ok {
+		return
 	}
 	deletedBy := c.Query("deleted_by")
 	if deletedBy == "" {
@@ -496,15 +488,21 @@ func (h *AppConfigHandler) DeleteConfig(c *gin.Context) {
 func (h *AppConfigHandler) GetConfigHistory(c *gin.Context) {
 	namespace := c.Param("namespace")
 	key := c.Param("key")
-	chainID := int64(0)
-	if chainStr := c.Query("chain_id"); chainStr != "" {
-		if id, err := strconv.ParseInt(chainStr, 10, 64); err == nil {
-			chainID = id
-		}
+	chainID, ok := appConfigChainID(c)
+	if !ok {
+		return
 	}
 	limit := 20
 	if limitStr := c.Query("limit"); limitStr != "" {
-		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 {
+		l, err := strconv.Atoi(limitStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, AppConfigResponse{
+				Success: false,
+				Message: "Invalid limit: must be an integer",
+			})
+			return
+		}
+		if l > 0 {
 			limit = l
 		}
 	}
@@ -529,3 +527,21 @@ func (h *AppConfigHandler) GetConfigHistory(c *gin.Context) {
 		"total":   len(history),
 	})
 }
+
+// appConfigChainID reads the optional chain_id query parameter (0, the
+// global scope, when absent). When ok is false a 400 has been written.
+func appConfigChainID(c *gin.Context) (int64, bool) {
+	chainStr := c.Query("chain_id")
+	if chainStr == "" {
+		return 0, true
+	}
+	id, err := strconv.ParseInt(chainStr, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, AppConfigResponse{
+			Success: false,
+			Message: "Invalid chain_id: must be an integer",
+		})
+		return 0, false
+	}
+	return id, true
+}
@@ -0,0 +1,282 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/retention"
+)
+
+// ArchiveHandler handles the admin query endpoints for archived payments and
+// meta-transactions
+type ArchiveHandler struct {
+	payments repository.PaymentRepository
+	relayer  repository.RelayerRepository
+	archiver ArchiveStatusProvider
+	logger   *zap.Logger
+}
+
+// ArchiveStatusProvider reports the last archive pass (implemented by
+// retention.Archiver)
+type ArchiveStatusProvider interface {
+	LastRun() *retention.RunStats
+}
+
+// NewArchiveHandler creates a new archive handler with injected dependencies
+func NewArchiveHandler(payments repository.PaymentRepository, relayer repository.RelayerRepository, archiver ArchiveStatusProvider, logger *zap.Logger) *ArchiveHandler {
+	return &ArchiveHandler{
+		payments: payments,
+		relayer:  relayer,
+		archiver: archiver,
+		logger:   logger,
+	}
+}
+
+// ArchiveResponse wraps archive API responses
+type ArchiveResponse struct {
+	Success bool        `json:"success"`
+	Data    interface{} `json:"data,omitempty"`
+	Error   string      `json:"error,omitempty"`
+}
+
+// ArchiveListResponse wraps a page of archived records
+type ArchiveListResponse struct {
+	Success  bool        `json:"success"`
+	Records  interface{} `json:"records"`
+	Total    int64       `json:"total"`
+	Page     int         `json:"page"`
+	PageSize int         `json:"page_size"`
+}
+
+// ListArchivedPayments handles GET /api/v1/admin/archive/payments
+// @Summary Search archived payments
+// @Description Returns settled payments moved out of the live table by the retention archiver, newest first. Expired payments are left out unless include_expired is set or status=expired is asked for.
+// @Tags admin
+// @Produce json
+// @Param payer_address query string false "Payer address"
+// @Param service_code query string false "Service code"
+// @Param payment_method query string false "Payment method"
+// @Param status query string false "Payment status"
+// @Param include_expired query bool false "Include expired payments" default(false)
+// @Param disputed query bool false "Only payments that have been disputed" default(false)
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(50)
+// @Param fields query string false "Comma-separated record fields to return (id is always included)"
+// @Success 200 {object} ArchiveListResponse
+// @Failure 401 {object} ArchiveResponse
+// @Router /api/v1/admin/archive/payments [get]
+func (h *ArchiveHandler) ListArchivedPayments(c *gin.Context) {
+	page, pageSize, ok := queryPage(c, 50)
+	if !ok {
+		return
+	}
+
+	includeExpired, ok := queryBool(c, "include_expired", false)
+	if !ok {
+		return
+	}
+	disputed, ok := queryBool(c, "disputed", false)
+	if !ok {
+		return
+	}
+	filter := repository.PaymentFilter{
+		PayerAddress:   strings.ToLower(c.Query("payer_address")),
+		ServiceCode:    c.Query("service_code"),
+		PaymentMethod:  c.Query("payment_method"),
+		Status:         repository.PaymentStatus(c.Query("status")),
+		IncludeExpired: includeExpired,
+		Disputed:       disputed,
+	}
+
+	payments, total, err := h.payments.ListArchivedPayments(c.Request.Context(), filter, repository.Pagination{
+		Page:     page,
+		PageSize: pageSize,
+	})
+	if err != nil {
+		h.logger.Error("failed to list archived payments", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, ArchiveResponse{
+			Success: false,
+			Error:   "Internal server error",
+		})
+		return
+	}
+
+	if payments == nil {
+		payments = []*repository.Payment{}
+	}
+
+	c.JSON(http.StatusOK, ArchiveListResponse{
+		Success:  true,
+		Records:  payments,
+		Total:    total,
+		Page:     page,
+		PageSize: pageSize,
+	})
+}
+
+// GetArchivedPayment handles GET /api/v1/admin/archive/payments/:id
+// @Summary Get an archived payment
+// @Description Returns a single archived payment
+// @Tags admin
+// @Produce json
+// @Param id path string true "Payment ID"
+// @Param fields query string false "Comma-separated record fields to return (id is always included)"
+// @Success 200 {object} ArchiveResponse
+// @Failure 400 {object} ArchiveResponse
+// @Failure 404 {object} ArchiveResponse
+// @Router /api/v1/admin/archive/payments/{id} [get]
+func (h *ArchiveHandler) GetArchivedPayment(c *gin.Context) {
+	id := c.Param("id")
+	if !uuidPattern.MatchString(id) {
+		c.JSON(http.StatusBadRequest, ArchiveResponse{
+			Success: false,
+			Error:   "Invalid payment ID",
+		})
+		return
+	}
+
+	payment, err := h.payments.GetArchivedPayment(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, repository.ErrPaymentNotFound) {
+			c.JSON(http.StatusNotFound, ArchiveResponse{
+				Success: false,
+				Error:   "Archived payment not found",
+			})
+			return
+		}
+		h.logger.Error("failed to get archived payment", zap.String("id", id), zap.Error(err))
+		c.JSON(http.StatusInternalServerError, ArchiveResponse{
+			Success: false,
+			Error:   "Internal server error",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, ArchiveResponse{
+		Success: true,
+		Data:    payment,
+	})
+}
+
+// ListArchivedMetaTxs handles GET /api/v1/admin/archive/meta-transactions
+// @Summary Search archived meta-transactions
+// @Description Returns finished meta-transactions moved out of the live table by the retention archiver, newest first
+// @Tags admin
+// @Produce json
+// @Param from_address query string false "Signer address"
+// @Param to_address query string false "Target contract"
+// @Param function_name query string false "Function name"
+// @Param status query string false "Meta-transaction status"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(50)
+// @Param fields query string false "Comma-separated record fields to return (id is always included)"
+// @Success 200 {object} ArchiveListResponse
+// @Failure 401 {object} ArchiveResponse
+// @Router /api/v1/admin/archive/meta-transactions [get]
+func (h *ArchiveHandler) ListArchivedMetaTxs(c *gin.Context) {
+	page, pageSize, ok := queryPage(c, 50)
+	if !ok {
+		return
+	}
+
+	filter := repository.MetaTxFilter{
+		FromAddress:  strings.ToLower(c.Query("from_address")),
+		ToAddress:    strings.ToLower(c.Query("to_address")),
+		FunctionName: c.Query("function_name"),
+		Status:       repository.MetaTxStatus(c.Query("status")),
+	}
+
+	txs, total, err := h.relayer.ListArchivedMetaTx(c.Request.Context(), filter, repository.Pagination{
+		Page:     page,
+		PageSize: pageSize,
+	})
+	if err != nil {
+		h.logger.Error("failed to list archived meta-transactions", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, ArchiveResponse{
+			Success: false,
+			Error:   "Internal server error",
+		})
+		return
+	}
+
+	if txs == nil {
+		txs = []*repository.MetaTransaction{}
+	}
+
+	c.JSON(http.StatusOK, ArchiveListResponse{
+		Success:  true,
+		Records:  txs,
+		Total:    total,
+		Page:     page,
+		PageSize: pageSize,
+	})
+}
+
+// GetArchivedMetaTx handles GET /api/v1/admin/archive/meta-transactions/:id
+// @Summary Get an archived meta-transaction
+// @Description Returns a single archived meta-transaction
+// @Tags admin
+// @Produce json
+// @Param id path string true "Meta-transaction ID"
+// @Param fields query string false "Comma-separated record fields to return (id is always included)"
+// @Success 200 {object} ArchiveResponse
+// @Failure 400 {object} ArchiveResponse
+// @Failure 404 {object} ArchiveResponse
+// @Router /api/v1/admin/archive/meta-transactions/{id} [get]
+func (h *ArchiveHandler) GetArchivedMetaTx(c *gin.Context) {
+	id := c.Param("id")
+	if !uuidPattern.MatchString(id) {
+		c.JSON(http.StatusBadRequest, ArchiveResponse{
+			Success: false,
+			Error:   "Invalid meta-transaction ID",
+		})
+		return
+	}
+
+	tx, err := h.relayer.GetArchivedMetaTx(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, repository.ErrMetaTxNotFound) {
+			c.JSON(http.StatusNotFound, ArchiveResponse{
+				Success: false,
+				Error:   "Archived meta-transaction not found",
+			})
+			return
+		}
+		h.logger.Error("failed to get archived meta-transaction", zap.String("id", id), zap.Error(err))
+		c.JSON(http.StatusInternalServerError, ArchiveResponse{
+			Success: false,
+			Error:   "Internal server error",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, ArchiveResponse{
+		Success: true,
+		Data:    tx,
+	})
+}
+
+// GetArchiveStatus handles GET /api/v1/admin/archive/status
+// @Summary Archiver status
+// @Description Returns the result of the most recent retention archive pass
+// @Tags admin
+// @Produce json
+// @Success 200 {object} ArchiveResponse
+// @Failure 401 {object} ArchiveResponse
+// @Router /api/v1/admin/archive/status [get]
+func (h *ArchiveHandler) GetArchiveStatus(c *gin.Context) {
+	var last *retention.RunStats
+	if h.archiver != nil {
+		last = h.archiver.LastRun()
+	}
+
+	c.JSON(http.StatusOK, ArchiveResponse{
+		Success: true,
+		Data:    gin.H{"enabled": h.archiver != nil, "last_run": last},
+	})
+}
@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/auth"
+)
+
+// AuthHandler signs wallets in with Sign-In with Ethereum
+type AuthHandler struct {
+	service *auth.Service
+	logger  *zap.Logger
+}
+
+// NewAuthHandler creates a new sign-in handler with injected dependencies
+func NewAuthHandler(service *auth.Service, logger *zap.Logger) *AuthHandler {
+	return &AuthHandler{service: service, logger: logger}
+}
+
+// AuthResponse wraps sign-in API responses
+type AuthResponse struct {
+	Success bool        `json:"success"`
+	Data    interface{} `json:"data,omitempty"`
+	Error   string      `json:"error,omitempty"`
+}
+
+// NonceResponse is a nonce to put in a sign-in message
+type NonceResponse struct {
+	Nonce     string    `json:"nonce"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// SignInRequest is the body of POST /api/v1/auth/siwe/verify
+type SignInRequest struct {
+	Message   string `json:"message" binding:"required"`   // EIP-4361 message
+	Signature string `json:"signature" binding:"required"` // personal_sign of message, hex
+}
+
+// Nonce handles POST /api/v1/auth/siwe/nonce
+// @Summary Get a sign-in nonce
+// @Description Returns a single-use nonce for an EIP-4361 (Sign-In with Ethereum) message. It expires after auth.nonce_seconds.
+// @Tags auth
+// @Produce json
+// @Success 200 {object} AuthResponse{data=NonceResponse}
+// @Router /api/v1/auth/siwe/nonce [post]
+func (h *AuthHandler) Nonce(c *gin.Context) {
+	nonce, expires, err := h.service.Nonce(c.Request.Context())
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, AuthResponse{
+		Success: true,
+		Data:    NonceResponse{Nonce: nonce, ExpiresAt: expires},
+	})
+}
+
+// SignIn handles POST /api/v1/auth/siwe/verify
+// @Summary Sign in with Ethereum
+// @Description Verifies a signed EIP-4361 message and returns a session token for its address, sent as "Authorization: Bearer <token>" to self-service endpoints. The message domain must be auth.siwe_domain, without which sign-in answers 503, and the nonce one from /auth/siwe/nonce. Contract wallet (EIP-1271) signatures are not supported.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body SignInRequest true "Signed message"
+// @Success 200 {object} AuthResponse{data=auth.Session}
+// @Failure 400 {object} AuthResponse
+// @Failure 401 {object} AuthResponse
+// @Failure 503 {object} AuthResponse
+// @Router /api/v1/auth/siwe/verify [post]
+func (h *AuthHandler) SignIn(c *gin.Context) {
+	var req SignInRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, AuthResponse{
+			Success: false,
+			Error:   "Invalid request: " + err.Error(),
+		})
+		return
+	}
+
+	session, err := h.service.SignIn(c.Request.Context(), req.Message, req.Signature)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, AuthResponse{Success: true, Data: session})
+}
+
+// respondError maps sign-in errors to responses
+func (h *AuthHandler) respondError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, auth.ErrInvalidMessage):
+		c.JSON(http.StatusBadRequest, AuthResponse{Success: false, Error: err.Error()})
+	case errors.Is(err, auth.ErrInvalidSignature), errors.Is(err, auth.ErrInvalidNonce),
+		errors.Is(err, auth.ErrDomainMismatch), errors.Is(err, auth.ErrMessageExpired):
+		c.JSON(http.StatusUnauthorized, AuthResponse{Success: false, Error: err.Error()})
+	case errors.Is(err, auth.ErrDomainNotConfigured):
+		h.logger.Warn("sign-in refused: set app_config auth.siwe_domain to the site's domain")
+		c.JSON(http.StatusServiceUnavailable, AuthResponse{Success: false, Error: err.Error()})
+	default:
+		h.logger.Error("sign-in request failed", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, AuthResponse{
+			Success: false,
+			Error:   "Internal server error",
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/breaker"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/outbound"
+)
+
+// providerTimeout bounds each call to an external provider (Stripe, Sumsub)
+const providerTimeout = 10 * time.Second
+
+// callProvider runs fn through b (if set). isFailure decides which errors
+// count against the provider; nil counts every error.
+func callProvider(b *breaker.Breaker, fn func() error, isFailure func(error) bool) error {
+	if b == nil {
+		return fn()
+	}
+	return b.Do(fn, isFailure)
+}
+
+// respondUnavailable writes 503 with Retry-After if err came from an open
+// circuit breaker or the provider's client-side rate limit and reports
+// whether it did
+func respondUnavailable(c *gin.Context, err error) bool {
+	retryAfter, ok := breaker.RetryAfter(err)
+	if !ok {
+		if !errors.Is(err, outbound.ErrRateLimited) {
+			return false
+		}
+		retryAfter = time.Second
+	}
+
+	seconds := int(math.Ceil(retryAfter.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+	c.Header("Retry-After", strconv.Itoa(seconds))
+	c.JSON(http.StatusServiceUnavailable, gin.H{
+		"success": false,
+		"error":   "Service temporarily unavailable",
+	})
+	return true
+}
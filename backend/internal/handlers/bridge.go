@@ -0,0 +1,233 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/bridge"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// BridgeHandler handles recording and tracking NEXUS bridge transfers
+type BridgeHandler struct {
+	service *bridge.Service
+	repo    repository.BridgeRepository
+	logger  *zap.Logger
+}
+
+// NewBridgeHandler creates a new bridge handler with injected dependencies
+func NewBridgeHandler(service *bridge.Service, repo repository.BridgeRepository, logger *zap.Logger) *BridgeHandler {
+	return &BridgeHandler{service: service, repo: repo, logger: logger}
+}
+
+// BridgeResponse wraps bridge API responses
+type BridgeResponse struct {
+	Success bool        `json:"success"`
+	Data    interface{} `json:"data,omitempty"`
+	Error   string      `json:"error,omitempty"`
+}
+
+// RecordBridgeTransferRequest is the body of POST /api/v1/bridge/transfers
+type RecordBridgeTransferRequest struct {
+	Sender        string `json:"sender" binding:"required"`
+	Recipient     string `json:"recipient" binding:"required"`
+	Amount        string `json:"amount" binding:"required"`
+	SourceChainID int64  `json:"source_chain_id" binding:"required"`
+	DestChainID   int64  `json:"dest_chain_id" binding:"required"`
+	TxHash        string `json:"tx_hash"`
+}
+
+// BridgeTransferView is a bridge transfer with each leg's progress
+type BridgeTransferView struct {
+	ID          string                     `json:"id"`
+	Direction   repository.BridgeDirection `json:"direction,omitempty"`
+	Sender      string                     `json:"sender,omitempty"`
+	Recipient   string                     `json:"recipient"`
+	Amount      string                     `json:"amount"`
+	Nonce       *uint64                    `json:"nonce,omitempty"`
+	Status      string                     `json:"status"` // pending, in_transit, queued, completed or cancelled
+	Source      BridgeLegView              `json:"source"`
+	Destination BridgeLegView              `json:"destination"`
+	CreatedAt   time.Time                  `json:"created_at"`
+	UpdatedAt   time.Time                  `json:"updated_at"`
+}
+
+// BridgeLegView is one side of a bridge transfer
+type BridgeLegView struct {
+	ChainID     int64                      `json:"chain_id"`
+	Status      repository.BridgeLegStatus `json:"status"`
+	TransferID  *string                    `json:"transfer_id,omitempty"`
+	TxHash      *string                    `json:"tx_hash,omitempty"`
+	BlockNumber *uint64                    `json:"block_number,omitempty"`
+	UnlockAt    *time.Time                 `json:"unlock_at,omitempty"`
+	CompletedAt *time.Time                 `json:"completed_at,omitempty"` // source confirmed, or destination minted or unlocked
+}
+
+// newBridgeTransferView splits t into its legs
+func newBridgeTransferView(t *repository.BridgeTransfer) *BridgeTransferView {
+	status := "pending"
+	switch {
+	case t.DestStatus == repository.BridgeLegCompleted:
+		status = "completed"
+	case t.DestStatus == repository.BridgeLegCancelled:
+		status = "cancelled"
+	case t.DestStatus == repository.BridgeLegQueued:
+		status = "queued"
+	case t.SourceStatus == repository.BridgeLegConfirmed:
+		status = "in_transit"
+	}
+
+	return &BridgeTransferView{
+		ID:        t.ID,
+		Direction: t.Direction,
+		Sender:    t.Sender,
+		Recipient: t.Recipient,
+		Amount:    t.Amount,
+		Nonce:     t.Nonce,
+		Status:    status,
+		Source: BridgeLegView{
+			ChainID:     t.SourceChainID,
+			Status:      t.SourceStatus,
+			TransferID:  t.SourceTransferID,
+			TxHash:      t.SourceTxHash,
+			BlockNumber: t.SourceBlock,
+			CompletedAt: t.SourceConfirmedAt,
+		},
+		Destination: BridgeLegView{
+			ChainID:     t.DestChainID,
+			Status:      t.DestStatus,
+			TransferID:  t.DestTransferID,
+			TxHash:      t.DestTxHash,
+			BlockNumber: t.DestBlock,
+			UnlockAt:    t.DestUnlockAt,
+			CompletedAt: t.DestCompletedAt,
+		},
+		CreatedAt: t.CreatedAt,
+		UpdatedAt: t.UpdatedAt,
+	}
+}
+
+// RecordBridgeTransfer handles POST /api/v1/bridge/transfers
+// @Summary Record a bridge transfer
+// @Description Records a NEXUS transfer the sender has started on the source chain so it can be tracked before the bridge event is confirmed. Amount is in base units (18 decimals); tx_hash, if given, is the lock or burn transaction.
+// @Tags bridge
+// @Accept json
+// @Produce json
+// @Param request body RecordBridgeTransferRequest true "Bridge transfer"
+// @Success 201 {object} BridgeResponse{data=BridgeTransferView}
+// @Failure 400 {object} BridgeResponse
+// @Router /api/v1/bridge/transfers [post]
+func (h *BridgeHandler) RecordBridgeTransfer(c *gin.Context) {
+	var req RecordBridgeTransferRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, BridgeResponse{
+			Success: false,
+			Error:   "Invalid request: " + err.Error(),
+		})
+		return
+	}
+
+	t, err := h.service.RecordIntent(c.Request.Context(), bridge.Intent{
+		Sender:        req.Sender,
+		Recipient:     req.Recipient,
+		Amount:        req.Amount,
+		SourceChainID: req.SourceChainID,
+		DestChainID:   req.DestChainID,
+		TxHash:        req.TxHash,
+	})
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, BridgeResponse{
+		Success: true,
+		Data:    newBridgeTransferView(t),
+	})
+}
+
+// ListBridgeTransfers handles GET /api/v1/bridge/transfers/:address
+// @Summary List bridge transfers
+// @Description Returns the bridge transfers an address sent or received, newest first, with the status of the source leg (lock or burn) and destination leg (mint or unlock) of each
+// @Tags bridge
+// @Produce json
+// @Param address path string true "Sender or recipient address"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(20)
+// @Success 200 {object} BridgeResponse
+// @Failure 400 {object} BridgeResponse
+// @Router /api/v1/bridge/transfers/{address} [get]
+func (h *BridgeHandler) ListBridgeTransfers(c *gin.Context) {
+	address := c.Param("address")
+	if !isValidAddress(address) {
+		c.JSON(http.StatusBadRequest, BridgeResponse{
+			Success: false,
+			Error:   "Invalid address format",
+		})
+		return
+	}
+
+	page, pageSize, ok := queryPage(c, 20)
+	if !ok {
+		return
+	}
+
+	list, total, err := h.repo.ListBridgeTransfers(c.Request.Context(), strings.ToLower(address), repository.Pagination{
+		Page:     page,
+		PageSize: pageSize,
+	})
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	transfers := make([]*BridgeTransferView, 0, len(list))
+	for _, t := range list {
+		transfers = append(transfers, newBridgeTransferView(t))
+	}
+
+	c.JSON(http.StatusOK, BridgeResponse{
+		Success: true,
+		Data: gin.H{
+			"transfers": transfers,
+			"total":     total,
+			"page":      page,
+			"page_size": pageSize,
+		},
+	})
+}
+
+// respondError maps bridge service and repository errors to responses
+func (h *BridgeHandler) respondError(c *gin.Context, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, bridge.ErrInvalidAddress),
+		errors.Is(err, bridge.ErrInvalidAmount),
+		errors.Is(err, bridge.ErrInvalidTxHash),
+		errors.Is(err, bridge.ErrSameChain),
+		errors.Is(err, bridge.ErrUnsupportedChain):
+		status = http.StatusBadRequest
+	case errors.Is(err, repository.ErrBridgeTransferNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, repository.ErrBridgeTransferExists):
+		status = http.StatusConflict
+	}
+
+	if status == http.StatusInternalServerError {
+		h.logger.Error("bridge request failed", zap.Error(err))
+		c.JSON(status, BridgeResponse{
+			Success: false,
+			Error:   "Internal server error",
+		})
+		return
+	}
+	c.JSON(status, BridgeResponse{
+		Success: false,
+		Error:   err.Error(),
+	})
+}
@@ -0,0 +1,201 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// ComplianceCaseHandler reviews the compliance cases opened by anomaly
+// detection
+type ComplianceCaseHandler struct {
+	repo   repository.AnomalyRepository
+	logger *zap.Logger
+}
+
+// NewComplianceCaseHandler creates a new compliance case handler with injected dependencies
+func NewComplianceCaseHandler(repo repository.AnomalyRepository, logger *zap.Logger) *ComplianceCaseHandler {
+	return &ComplianceCaseHandler{repo: repo, logger: logger}
+}
+
+// ComplianceCaseResponse wraps compliance case API responses
+type ComplianceCaseResponse struct {
+	Success bool        `json:"success"`
+	Data    interface{} `json:"data,omitempty"`
+	Error   string      `json:"error,omitempty"`
+}
+
+// CloseComplianceCaseRequest is the body of POST /api/v1/admin/compliance/cases/:id/close
+type CloseComplianceCaseRequest struct {
+	ClosedBy   string `json:"closed_by" binding:"required"`
+	Resolution string `json:"resolution" binding:"required"`
+}
+
+// ListComplianceCases handles GET /api/v1/admin/compliance/cases
+// @Summary List compliance cases
+// @Description Returns the cases opened by anomaly detection, newest first
+// @Tags admin
+// @Produce json
+// @Param rule query string false "Filter by rule (relay_failures, rapid_payments, payment_volume_spike, relay_volume_spike)"
+// @Param subject query string false "Filter by subject (address or hour)"
+// @Param status query string false "Filter by status (open, closed)"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(20)
+// @Success 200 {object} ComplianceCaseResponse
+// @Failure 400 {object} ComplianceCaseResponse
+// @Router /api/v1/admin/compliance/cases [get]
+func (h *ComplianceCaseHandler) ListComplianceCases(c *gin.Context) {
+	filter := repository.ComplianceCaseFilter{
+		Rule:    c.Query("rule"),
+		Subject: c.Query("subject"),
+	}
+	if status := c.Query("status"); status != "" {
+		s := repository.ComplianceCaseStatus(status)
+		if s != repository.ComplianceCaseOpen && s != repository.ComplianceCaseClosed {
+			c.JSON(http.StatusBadRequest, ComplianceCaseResponse{
+				Success: false,
+				Error:   "status must be open or closed",
+			})
+			return
+		}
+		filter.Status = s
+	}
+	page, pageSize, ok := queryPage(c, 20)
+	if !ok {
+		return
+	}
+
+	list, total, err := h.repo.ListComplianceCases(c.Request.Context(), filter, repository.Pagination{
+		Page:     page,
+		PageSize: pageSize,
+	})
+	if err != nil {
+		h.respondError(c, "", err)
+		return
+	}
+	if list == nil {
+		list = []*repository.ComplianceCase{}
+	}
+
+	c.JSON(http.StatusOK, ComplianceCaseResponse{
+		Success: true,
+		Data: gin.H{
+			"cases":     list,
+			"total":     total,
+			"page":      page,
+			"page_size": pageSize,
+		},
+	})
+}
+
+// GetComplianceCase handles GET /api/v1/admin/compliance/cases/:id
+// @Summary Get compliance case
+// @Tags admin
+// @Produce json
+// @Param id path string true "Case ID"
+// @Success 200 {object} ComplianceCaseResponse{data=repository.ComplianceCase}
+// @Failure 400 {object} ComplianceCaseResponse
+// @Failure 404 {object} ComplianceCaseResponse
+// @Router /api/v1/admin/compliance/cases/{id} [get]
+func (h *ComplianceCaseHandler) GetComplianceCase(c *gin.Context) {
+	id, ok := h.caseID(c)
+	if !ok {
+		return
+	}
+
+	cc, err := h.repo.GetComplianceCase(c.Request.Context(), id)
+	if err != nil {
+		h.respondError(c, id, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, ComplianceCaseResponse{
+		Success: true,
+		Data:    cc,
+	})
+}
+
+// CloseComplianceCase handles POST /api/v1/admin/compliance/cases/:id/close
+// @Summary Close compliance case
+// @Description Closes an open case with the reviewer's resolution. The same rule and subject can open a new case afterwards.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "Case ID"
+// @Param request body CloseComplianceCaseRequest true "Resolution"
+// @Success 200 {object} ComplianceCaseResponse{data=repository.ComplianceCase}
+// @Failure 400 {object} ComplianceCaseResponse
+// @Failure 404 {object} ComplianceCaseResponse
+// @Failure 409 {object} ComplianceCaseResponse
+// @Router /api/v1/admin/compliance/cases/{id}/close [post]
+func (h *ComplianceCaseHandler) CloseComplianceCase(c *gin.Context) {
+	id, ok := h.caseID(c)
+	if !ok {
+		return
+	}
+	var req CloseComplianceCaseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ComplianceCaseResponse{
+			Success: false,
+			Error:   "Invalid request: " + err.Error(),
+		})
+		return
+	}
+
+	cc, err := h.repo.CloseComplianceCase(c.Request.Context(), id, req.ClosedBy, req.Resolution)
+	if err != nil {
+		h.respondError(c, id, err)
+		return
+	}
+
+	h.logger.Info("compliance case closed",
+		zap.String("case_id", id),
+		zap.String("rule", cc.Rule),
+		zap.String("closed_by", req.ClosedBy),
+	)
+	c.JSON(http.StatusOK, ComplianceCaseResponse{
+		Success: true,
+		Data:    cc,
+	})
+}
+
+// caseID reads the :id parameter, answering 400 when it is not a UUID
+func (h *ComplianceCaseHandler) caseID(c *gin.Context) (string, bool) {
+	id := c.Param("id")
+	if !uuidPattern.MatchString(id) {
+		c.JSON(http.StatusBadRequest, ComplianceCaseResponse{
+			Success: false,
+			Error:   "Invalid ID",
+		})
+		return "", false
+	}
+	return id, true
+}
+
+// respondError maps compliance case repository errors to responses
+func (h *ComplianceCaseHandler) respondError(c *gin.Context, id string, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, repository.ErrComplianceCaseNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, repository.ErrComplianceCaseClosed):
+		status = http.StatusConflict
+	}
+
+	if status == http.StatusInternalServerError {
+		h.logger.Error("compliance case request failed", zap.String("id", id), zap.Error(err))
+		c.JSON(status, ComplianceCaseResponse{
+			Success: false,
+			Error:   "Internal server error",
+		})
+		return
+	}
+	c.JSON(status, ComplianceCaseResponse{
+		Success: false,
+		Error:   err.Error(),
+	})
+}
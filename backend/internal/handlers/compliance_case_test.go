@@ -0,0 +1,80 @@
+package handlers_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/handlers"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/storage/memory"
+)
+
+func TestComplianceCaseHandler_ListAndClose(t *testing.T) {
+	store := memory.NewStore()
+	h := handlers.NewComplianceCaseHandler(store.Anomalies, zap.NewNop())
+
+	cc := &repository.ComplianceCase{
+		Rule:      "relay_failures",
+		Subject:   "0xsender",
+		Summary:   "12 failed relays",
+		Observed:  12,
+		Threshold: 10,
+	}
+	opened, err := store.Anomalies.OpenComplianceCase(context.Background(), cc)
+	require.NoError(t, err)
+	require.True(t, opened)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/api/v1/admin/compliance/cases", h.ListComplianceCases)
+	router.GET("/api/v1/admin/compliance/cases/:id", h.GetComplianceCase)
+	router.POST("/api/v1/admin/compliance/cases/:id/close", h.CloseComplianceCase)
+
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(method, path, bytes.NewBufferString(body)))
+		return w
+	}
+
+	w := do(http.MethodGet, "/api/v1/admin/compliance/cases?status=open", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"total":1`)
+	assert.Contains(t, w.Body.String(), cc.ID)
+
+	w = do(http.MethodGet, "/api/v1/admin/compliance/cases?status=pending", "")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = do(http.MethodPost, "/api/v1/admin/compliance/cases/"+cc.ID+"/close", `{"closed_by":"officer"}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code, "a resolution is required")
+
+	w = do(http.MethodPost, "/api/v1/admin/compliance/cases/"+cc.ID+"/close",
+		`{"closed_by":"officer","resolution":"gas price spike, not abuse"}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var closed struct {
+		Data repository.ComplianceCase `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &closed))
+	assert.Equal(t, repository.ComplianceCaseClosed, closed.Data.Status)
+	require.NotNil(t, closed.Data.ClosedAt)
+
+	w = do(http.MethodPost, "/api/v1/admin/compliance/cases/"+cc.ID+"/close",
+		`{"closed_by":"officer","resolution":"again"}`)
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	w = do(http.MethodGet, "/api/v1/admin/compliance/cases?status=open", "")
+	assert.Contains(t, w.Body.String(), `"total":0`)
+
+	w = do(http.MethodGet, "/api/v1/admin/compliance/cases/00000000-0000-0000-0000-000000000000", "")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	w = do(http.MethodGet, "/api/v1/admin/compliance/cases/nope", "")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Records updated by admins (pricing, payment methods, KYC registrations)
+// carry a version incremented by every update. Reads return it in the body
+// and as the ETag; an update naming the version it was based on, with
+// If-Match or expected_version, fails with 409 if another update came first.
+
+// errInvalidIfMatch is returned for an If-Match header that is not a
+// record version
+var errInvalidIfMatch = errors.New("If-Match must be a single record version, e.g. \"3\"")
+
+// errVersionMismatch is returned when If-Match and expected_version disagree
+var errVersionMismatch = errors.New("If-Match and expected_version name different versions")
+
+// versionETag returns the ETag of a record at version
+func versionETag(version int64) string {
+	return `"` + strconv.FormatInt(version, 10) + `"`
+}
+
+// setVersionETag sets the ETag of a single record response, which the ETag
+// middleware then uses instead of a content hash
+func setVersionETag(c *gin.Context, version int64) {
+	c.Header("ETag", versionETag(version))
+}
+
+// expectedVersion returns the version an update must apply to: from the
+// If-Match header or expected_version in the body (fromBody, may be nil).
+// It returns nil if the request names none, or If-Match is "*".
+func expectedVersion(c *gin.Context, fromBody *int64) (*int64, error) {
+	ifMatch := strings.TrimSpace(c.GetHeader("If-Match"))
+	if ifMatch == "" || ifMatch == "*" {
+		return fromBody, nil
+	}
+
+	// If-Match uses the strong comparison, so weak tags never match
+	unquoted, ok := strings.CutPrefix(ifMatch, `"`)
+	if ok {
+		unquoted, ok = strings.CutSuffix(unquoted, `"`)
+	}
+	version, err := strconv.ParseInt(unquoted, 10, 64)
+	if !ok || err != nil || version < 1 {
+		return nil, errInvalidIfMatch
+	}
+	if fromBody != nil && *fromBody != version {
+		return nil, errVersionMismatch
+	}
+	return &version, nil
+}
@@ -0,0 +1,119 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/handlers"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/storage/memory"
+)
+
+const operator = "0x1234567890123456789012345678901234567890"
+
+// send serves a JSON request, with If-Match if ifMatch is set
+func send(router *gin.Engine, method, path, ifMatch string, body interface{}) *httptest.ResponseRecorder {
+	var buf bytes.Buffer
+	if body != nil {
+		json.NewEncoder(&buf).Encode(body)
+	}
+	req := httptest.NewRequest(method, path, &buf)
+	req.Header.Set("Content-Type", "application/json")
+	if ifMatch != "" {
+		req.Header.Set("If-Match", ifMatch)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestPricingHandler_OptimisticConcurrency(t *testing.T) {
+	repo := memory.NewMemoryPricingRepo()
+	repo.AddPricing(&repository.Pricing{ServiceCode: "kyc_verification", PriceUSD: 15, IsActive: true})
+	repo.AddPaymentMethod(&repository.PaymentMethod{MethodCode: "stripe", IsActive: true})
+	router := setupPricingTestRouter(handlers.NewPricingHandler(repo, zap.NewNop()))
+
+	w := send(router, http.MethodGet, "/api/v1/pricing/kyc_verification", "", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `"1"`, w.Header().Get("ETag"))
+
+	// Two admins edit version 1; the second one conflicts
+	w = send(router, http.MethodPut, "/api/v1/pricing/kyc_verification", `"1"`, gin.H{"price_usd": 20, "operator": operator})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, `"2"`, w.Header().Get("ETag"))
+
+	w = send(router, http.MethodPut, "/api/v1/pricing/kyc_verification", "", gin.H{"price_usd": 25, "operator": operator, "expected_version": 1})
+	require.Equal(t, http.StatusConflict, w.Code)
+	var resp struct {
+		Data repository.Pricing `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, int64(2), resp.Data.Version, "the conflict returns the current record")
+	assert.Equal(t, 20.0, resp.Data.PriceUSD)
+
+	// Without a version the update applies as before
+	w = send(router, http.MethodPut, "/api/v1/pricing/kyc_verification", "", gin.H{"price_usd": 25, "operator": operator})
+	require.Equal(t, http.StatusOK, w.Code)
+	pricing, err := repo.GetPricing(t.Context(), "kyc_verification")
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), pricing.Version)
+
+	for _, ifMatch := range []string{`W/"3"`, "3", `"abc"`} {
+		w = send(router, http.MethodPut, "/api/v1/pricing/kyc_verification", ifMatch, gin.H{"price_usd": 30, "operator": operator})
+		assert.Equal(t, http.StatusBadRequest, w.Code, ifMatch)
+	}
+	w = send(router, http.MethodPut, "/api/v1/pricing/kyc_verification", `"3"`, gin.H{"price_usd": 30, "operator": operator, "expected_version": 2})
+	assert.Equal(t, http.StatusBadRequest, w.Code, "If-Match and expected_version disagree")
+
+	// Payment methods
+	w = send(router, http.MethodPut, "/api/v1/payment-methods/stripe", `"1"`, gin.H{"fee_percent": 3, "operator": operator})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	w = send(router, http.MethodPut, "/api/v1/payment-methods/stripe", `"1"`, gin.H{"fee_percent": 4, "operator": operator})
+	assert.Equal(t, http.StatusConflict, w.Code)
+	w = send(router, http.MethodGet, "/api/v1/payment-methods/stripe", "", nil)
+	assert.Equal(t, `"2"`, w.Header().Get("ETag"))
+	w = send(router, http.MethodPut, "/api/v1/payment-methods/paypal", `"1"`, gin.H{"fee_percent": 4, "operator": operator})
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestKYCHandler_OptimisticConcurrency(t *testing.T) {
+	const (
+		officer = "0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+		user    = "0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"
+	)
+	h := handlers.NewKYCHandler(zap.NewNop())
+	h.Seed(handlers.KYCFixtures{
+		ComplianceOfficers: []string{officer},
+		Registrations:      []*handlers.KYCRegistration{{Address: user, Status: handlers.KYCStatusPending, Jurisdiction: "US"}},
+	})
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/kyc/status/:address", h.GetKYCStatus)
+	router.POST("/kyc/update", h.UpdateKYC)
+
+	w := send(router, http.MethodGet, "/kyc/status/"+user, "", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	etag := w.Header().Get("ETag")
+	assert.Equal(t, `"1"`, etag)
+
+	approve := gin.H{"address": user, "status": "approved", "level": 2, "reviewer": officer}
+	w = send(router, http.MethodPost, "/kyc/update", etag, approve)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	// A second officer reviewing the same version is refused
+	reject := gin.H{"address": user, "status": "rejected", "rejection_reason": "blurry", "reviewer": officer}
+	w = send(router, http.MethodPost, "/kyc/update", etag, reject)
+	require.Equal(t, http.StatusConflict, w.Code)
+	var resp handlers.KYCResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, handlers.KYCStatusApproved, resp.Registration.Status)
+	assert.Equal(t, int64(2), resp.Registration.Version)
+}
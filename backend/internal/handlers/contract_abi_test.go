@@ -0,0 +1,136 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/handlers"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/middleware"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/storage/memory"
+)
+
+const (
+	tokenABIv1 = `[{"type":"function","name":"name","inputs":[],"outputs":[{"type":"string"}],"stateMutability":"view"}]`
+	tokenABIv2 = `[{"type":"function","name":"symbol","inputs":[],"outputs":[{"type":"string"}],"stateMutability":"view"}]`
+)
+
+func abiRouter(t *testing.T) *gin.Engine {
+	t.Helper()
+	repo := memory.NewMemoryContractRepo()
+	repo.AddMapping(&repository.ContractMapping{SolidityName: "NexusToken", DBName: "nexusToken"})
+	contracts := handlers.NewContractHandler(repo, nil, nil, zap.NewNop())
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/contracts/abis/:name", contracts.ListABIVersions)
+	router.GET("/contracts/abis/:name/:version", contracts.GetABI)
+	router.POST("/contracts/abis", middleware.DeployAuthFunc(func() string { return deployToken }), contracts.UploadABI)
+	return router
+}
+
+type abiResponse struct {
+	Success bool   `json:"success"`
+	Error   string `json:"error"`
+	Data    struct {
+		Artifact repository.ABIArtifact   `json:"artifact"`
+		Versions []repository.ABIArtifact `json:"versions"`
+	} `json:"data"`
+}
+
+func uploadABI(t *testing.T, router *gin.Engine, token, name, version, abi string) (int, abiResponse) {
+	t.Helper()
+	body, err := json.Marshal(map[string]interface{}{"contract_name": name, "version": version, "abi": json.RawMessage(abi)})
+	require.NoError(t, err)
+	req := httptest.NewRequest(http.MethodPost, "/contracts/abis", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	var resp abiResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp), w.Body.String())
+	return w.Code, resp
+}
+
+func getABI(t *testing.T, router *gin.Engine, path string) (int, repository.ABIArtifact) {
+	t.Helper()
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+	var resp struct {
+		Data repository.ABIArtifact `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp), w.Body.String())
+	return w.Code, resp.Data
+}
+
+func TestContractHandler_UploadABI(t *testing.T) {
+	router := abiRouter(t)
+
+	// Uploads need the deploy token
+	code, _ := uploadABI(t, router, "", "nexusToken", "1.0.0", tokenABIv1)
+	assert.Equal(t, http.StatusUnauthorized, code)
+
+	code, resp := uploadABI(t, router, deployToken, "nexusToken", "1.0.0", tokenABIv1)
+	require.Equal(t, http.StatusOK, code, resp.Error)
+	first := resp.Data.Artifact
+	assert.Equal(t, "nexusToken", first.DBName)
+	assert.NotEmpty(t, first.ABIHash)
+
+	// The same ABI (formatted differently) is accepted again unchanged...
+	code, resp = uploadABI(t, router, deployToken, "nexusToken", "1.0.0", "[\n  "+tokenABIv1[1:])
+	require.Equal(t, http.StatusOK, code, resp.Error)
+	assert.Equal(t, first.ID, resp.Data.Artifact.ID)
+
+	// ...but a published version cannot be rewritten
+	code, resp = uploadABI(t, router, deployToken, "nexusToken", "1.0.0", tokenABIv2)
+	assert.Equal(t, http.StatusConflict, code)
+	assert.Contains(t, resp.Error, "already published")
+	code, got := getABI(t, router, "/contracts/abis/nexusToken/1.0.0")
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, first.ABIHash, got.ABIHash)
+	assert.JSONEq(t, tokenABIv1, string(got.ABI))
+
+	// latest follows new versions
+	time.Sleep(time.Millisecond)
+	code, resp = uploadABI(t, router, deployToken, "nexusToken", "2.0.0", tokenABIv2)
+	require.Equal(t, http.StatusOK, code, resp.Error)
+	code, got = getABI(t, router, "/contracts/abis/nexusToken/latest")
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "2.0.0", got.Version)
+	assert.JSONEq(t, tokenABIv2, string(got.ABI))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/contracts/abis/nexusToken", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Len(t, resp.Data.Versions, 2)
+	assert.Equal(t, "2.0.0", resp.Data.Versions[0].Version)
+	assert.Empty(t, resp.Data.Versions[0].ABI, "listings omit the ABI")
+
+	code, _ = getABI(t, router, "/contracts/abis/nexusToken/3.0.0")
+	assert.Equal(t, http.StatusNotFound, code)
+}
+
+func TestContractHandler_UploadABIValidation(t *testing.T) {
+	router := abiRouter(t)
+
+	code, _ := uploadABI(t, router, deployToken, "nexusToken", repository.ABIVersionLatest, tokenABIv1)
+	assert.Equal(t, http.StatusBadRequest, code, "latest is reserved")
+	code, _ = uploadABI(t, router, deployToken, "nexusToken", "1.0.0", `{"type":"function"}`)
+	assert.Equal(t, http.StatusBadRequest, code)
+	code, _ = uploadABI(t, router, deployToken, "nexusToken", "1.0.0", `[{"type":"nope"}]`)
+	assert.Equal(t, http.StatusBadRequest, code)
+	code, _ = uploadABI(t, router, deployToken, "nexusVault", "1.0.0", tokenABIv1)
+	assert.Equal(t, http.StatusNotFound, code)
+}
@@ -0,0 +1,444 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/chain"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// defaultCallAllowlist is used when contracts.call_allowlist is not configured in app_config.
+// Only view/pure methods listed here can be invoked through the call proxy.
+var defaultCallAllowlist = map[string][]string{
+	"nexusToken":     {"name", "symbol", "decimals", "totalSupply", "balanceOf", "allowance", "getVotes", "delegates"},
+	"nexusStaking":   {"getVotingPower", "getPendingUnbonding", "getWithdrawableUnbonding", "canInitiateUnbonding", "getEpochInfo"},
+	"nexusNFT":       {"name", "symbol", "totalSupply", "balanceOf", "ownerOf", "tokenURI", "remainingSupply", "getMintInfo"},
+	"nexusKYC":       {"isCompliant", "getKYCLevel", "isWhitelisted", "isBlacklisted", "isKYCExpired"},
+	"nexusGovernor":  {"state", "proposalThreshold", "quorum", "votingDelay", "votingPeriod", "canPropose"},
+	"nexusForwarder": {"getNonce", "isTargetAllowed", "paused"},
+}
+
+// ContractCallRequest represents a read-only contract call
+type ContractCallRequest struct {
+	Method string            `json:"method" binding:"required"`
+	Args   []json.RawMessage `json:"args"`
+}
+
+// CallContract handles POST /api/v1/contracts/:chainId/:name/call
+// @Summary Call a view method on a deployed contract
+// @Description Performs eth_call against the contract registered for the chain using its stored ABI.
+// @Description Only allowlisted view/pure methods can be called.
+// @Tags contracts
+// @Accept json
+// @Produce json
+// @Param chainId path int true "Chain ID"
+// @Param name path string true "Contract DB name (e.g., nexusToken)"
+// @Param request body ContractCallRequest true "Method and arguments"
+// @Success 200 {object} ContractResponse
+// @Failure 400 {object} ContractResponse
+// @Failure 403 {object} ContractResponse
+// @Failure 404 {object} ContractResponse
+// @Failure 502 {object} ContractResponse
+// @Router /api/v1/contracts/{chainId}/{name}/call [post]
+func (h *ContractHandler) CallContract(c *gin.Context) {
+	chainIDStr := c.Param("chainId")
+	chainID, err := strconv.ParseInt(chainIDStr, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ContractResponse{
+			Success: false,
+			Error:   "Invalid chain ID format",
+		})
+		return
+	}
+
+	name := c.Param("name")
+
+	var req ContractCallRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ContractResponse{
+			Success: false,
+			Error:   "Invalid request: " + err.Error(),
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	if !h.isCallAllowed(ctx, chainID, name, req.Method) {
+		c.JSON(http.StatusForbidden, ContractResponse{
+			Success: false,
+			Error:   "Method not allowed: " + name + "." + req.Method,
+		})
+		return
+	}
+
+	contract, err := h.repo.GetByChainAndDBName(ctx, chainID, name)
+	if err != nil {
+		if errors.Is(err, repository.ErrContractAddressNotFound) {
+			c.JSON(http.StatusNotFound, ContractResponse{
+				Success: false,
+				Error:   "Contract not found: " + name + " on chain " + chainIDStr,
+			})
+			return
+		}
+		h.logger.Error("failed to get contract", zap.Int64("chainId", chainID), zap.String("name", name), zap.Error(err))
+		c.JSON(http.StatusInternalServerError, ContractResponse{
+			Success: false,
+			Error:   "Internal server error",
+		})
+		return
+	}
+
+	artifact, err := h.repo.GetABI(ctx, name, contract.ABIVersion)
+	if err != nil {
+		if errors.Is(err, repository.ErrABIArtifactNotFound) {
+			c.JSON(http.StatusNotFound, ContractResponse{
+				Success: false,
+				Error:   "ABI not found: " + name + "@" + contract.ABIVersion,
+			})
+			return
+		}
+		h.logger.Error("failed to get abi", zap.String("name", name), zap.Error(err))
+		c.JSON(http.StatusInternalServerError, ContractResponse{
+			Success: false,
+			Error:   "Internal server error",
+		})
+		return
+	}
+
+	parsed, err := abi.JSON(strings.NewReader(string(artifact.ABI)))
+	if err != nil {
+		h.logger.Error("stored abi is invalid", zap.String("name", name), zap.String("version", artifact.Version), zap.Error(err))
+		c.JSON(http.StatusInternalServerError, ContractResponse{
+			Success: false,
+			Error:   "Internal server error",
+		})
+		return
+	}
+
+	method, ok := parsed.Methods[req.Method]
+	if !ok {
+		c.JSON(http.StatusBadRequest, ContractResponse{
+			Success: false,
+			Error:   "Method not found in ABI: " + req.Method,
+		})
+		return
+	}
+	if !method.IsConstant() {
+		c.JSON(http.StatusForbidden, ContractResponse{
+			Success: false,
+			Error:   "Only view or pure methods can be called",
+		})
+		return
+	}
+
+	args, err := decodeCallArgs(method.Inputs, req.Args)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ContractResponse{
+			Success: false,
+			Error:   "Invalid arguments: " + err.Error(),
+		})
+		return
+	}
+
+	calldata, err := parsed.Pack(req.Method, args...)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ContractResponse{
+			Success: false,
+			Error:   "Invalid arguments: " + err.Error(),
+		})
+		return
+	}
+
+	client, err := h.rpcClient(ctx, chainID)
+	if err != nil {
+		h.logger.Warn("rpc unavailable for contract call", zap.Int64("chainId", chainID), zap.Error(err))
+		c.JSON(http.StatusServiceUnavailable, ContractResponse{
+			Success: false,
+			Error:   "RPC not available for chain " + chainIDStr,
+		})
+		return
+	}
+
+	to := common.HexToAddress(contract.Address)
+	output, err := client.CallContract(ctx, ethereum.CallMsg{To: &to, Data: calldata}, nil)
+	if err != nil {
+		if respondUnavailable(c, err) {
+			return
+		}
+		h.logger.Warn("eth_call failed",
+			zap.Int64("chainId", chainID),
+			zap.String("name", name),
+			zap.String("method", req.Method),
+			zap.Error(err),
+		)
+		c.JSON(http.StatusBadGateway, ContractResponse{
+			Success: false,
+			Error:   "Contract call failed: " + err.Error(),
+		})
+		return
+	}
+
+	values, err := method.Outputs.Unpack(output)
+	if err != nil {
+		c.JSON(http.StatusBadGateway, ContractResponse{
+			Success: false,
+			Error:   "Failed to decode result: " + err.Error(),
+		})
+		return
+	}
+
+	result := make(map[string]interface{}, len(values))
+	for i, v := range values {
+		key := method.Outputs[i].Name
+		if key == "" {
+			key = strconv.Itoa(i)
+		}
+		result[key] = formatCallValue(v)
+	}
+
+	c.JSON(http.StatusOK, ContractResponse{
+		Success: true,
+		Data: gin.H{
+			"chain_id": chainID,
+			"contract": name,
+			"address":  contract.Address,
+			"method":   req.Method,
+			"result":   result,
+		},
+	})
+}
+
+// isCallAllowed checks the method against the contracts.call_allowlist config
+// (JSON object of contract db_name -> method names), falling back to defaults
+func (h *ContractHandler) isCallAllowed(ctx context.Context, chainID int64, name, method string) bool {
+	allowlist := defaultCallAllowlist
+	if h.configRepo != nil {
+		var configured map[string][]string
+		if err := h.configRepo.GetJSON(ctx, "contracts", "call_allowlist", chainID, &configured); err == nil && configured != nil {
+			allowlist = configured
+		}
+	}
+
+	for _, m := range allowlist[name] {
+		if m == method {
+			return true
+		}
+	}
+	return false
+}
+
+// rpcClient returns the shared failover client for the chain
+func (h *ContractHandler) rpcClient(ctx context.Context, chainID int64) (*chain.FailoverClient, error) {
+	if h.rpc == nil {
+		return nil, chain.ErrNoRPCEndpoints
+	}
+	return h.rpc.Client(ctx, chainID)
+}
+
+// decodeCallArgs converts JSON arguments into the Go types expected by the ABI packer
+func decodeCallArgs(inputs abi.Arguments, raw []json.RawMessage) ([]interface{}, error) {
+	if len(raw) != len(inputs) {
+		return nil, fmt.Errorf("expected %d arguments, got %d", len(inputs), len(raw))
+	}
+
+	args := make([]interface{}, len(inputs))
+	for i, input := range inputs {
+		// Numbers stay as json.Number so uint256 values don't round through float64
+		dec := json.NewDecoder(bytes.NewReader(raw[i]))
+		dec.UseNumber()
+		var v interface{}
+		if err := dec.Decode(&v); err != nil {
+			return nil, fmt.Errorf("argument %d: %w", i, err)
+		}
+		converted, err := convertCallArg(input.Type, v)
+		if err != nil {
+			return nil, fmt.Errorf("argument %d (%s): %w", i, input.Type.String(), err)
+		}
+		args[i] = converted
+	}
+	return args, nil
+}
+
+// convertCallArg converts a decoded JSON value into the Go value for an ABI type
+func convertCallArg(t abi.Type, v interface{}) (interface{}, error) {
+	switch t.T {
+	case abi.AddressTy:
+		s, ok := v.(string)
+		if !ok || !common.IsHexAddress(s) {
+			return nil, fmt.Errorf("expected hex address")
+		}
+		return common.HexToAddress(s), nil
+
+	case abi.BoolTy:
+		b, ok := v.(bool)
+		if !ok {
+			return nil, fmt.Errorf("expected boolean")
+		}
+		return b, nil
+
+	case abi.StringTy:
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected string")
+		}
+		return s, nil
+
+	case abi.IntTy, abi.UintTy:
+		n, err := toBigInt(v)
+		if err != nil {
+			return nil, err
+		}
+		// The packer wraps big values modulo 2^size, so check the range here
+		if !fitsIntType(t, n) {
+			return nil, fmt.Errorf("value out of range")
+		}
+		goType := t.GetType()
+		if goType == reflect.TypeOf(&big.Int{}) {
+			return n, nil
+		}
+		// Sized integers (<= 64 bits) must be passed as their exact Go type
+		if t.T == abi.UintTy {
+			return reflect.ValueOf(n.Uint64()).Convert(goType).Interface(), nil
+		}
+		return reflect.ValueOf(n.Int64()).Convert(goType).Interface(), nil
+
+	case abi.BytesTy:
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected hex string")
+		}
+		return hexutil.Decode(s)
+
+	case abi.FixedBytesTy:
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected hex string")
+		}
+		b, err := hexutil.Decode(s)
+		if err != nil {
+			return nil, err
+		}
+		if len(b) != t.Size {
+			return nil, fmt.Errorf("expected %d bytes, got %d", t.Size, len(b))
+		}
+		arr := reflect.New(t.GetType()).Elem()
+		reflect.Copy(arr, reflect.ValueOf(b))
+		return arr.Interface(), nil
+
+	case abi.SliceTy, abi.ArrayTy:
+		items, ok := v.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("expected array")
+		}
+		if t.T == abi.ArrayTy && len(items) != t.Size {
+			return nil, fmt.Errorf("expected %d elements, got %d", t.Size, len(items))
+		}
+		var out reflect.Value
+		if t.T == abi.ArrayTy {
+			out = reflect.New(t.GetType()).Elem()
+		} else {
+			out = reflect.MakeSlice(t.GetType(), len(items), len(items))
+		}
+		for i, item := range items {
+			elem, err := convertCallArg(*t.Elem, item)
+			if err != nil {
+				return nil, fmt.Errorf("element %d: %w", i, err)
+			}
+			out.Index(i).Set(reflect.ValueOf(elem))
+		}
+		return out.Interface(), nil
+	}
+
+	return nil, fmt.Errorf("unsupported argument type")
+}
+
+// toBigInt parses a JSON number or decimal/hex string into a big.Int
+func toBigInt(v interface{}) (*big.Int, error) {
+	switch val := v.(type) {
+	case json.Number:
+		n, ok := new(big.Int).SetString(val.String(), 10)
+		if !ok {
+			return nil, fmt.Errorf("expected integer, got %s", val)
+		}
+		return n, nil
+	case string:
+		n, ok := new(big.Int).SetString(val, 0)
+		if !ok {
+			return nil, fmt.Errorf("invalid integer %q", val)
+		}
+		return n, nil
+	}
+	return nil, fmt.Errorf("expected integer or numeric string")
+}
+
+// fitsIntType reports whether n is representable as the ABI's intN/uintN type
+func fitsIntType(t abi.Type, n *big.Int) bool {
+	if t.T == abi.UintTy {
+		return n.Sign() >= 0 && n.BitLen() <= t.Size
+	}
+	limit := new(big.Int).Lsh(big.NewInt(1), uint(t.Size-1))
+	if n.Sign() < 0 {
+		return n.CmpAbs(limit) <= 0
+	}
+	return n.Cmp(limit) < 0
+}
+
+// formatCallValue converts decoded ABI values into JSON-friendly representations
+func formatCallValue(v interface{}) interface{} {
+	switch val := v.(type) {
+	case *big.Int:
+		return val.String()
+	case common.Address:
+		return val.Hex()
+	case []byte:
+		return hexutil.Encode(val)
+	case bool, string:
+		return val
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(rv.Uint(), 10)
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10)
+	case reflect.Array:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			b := make([]byte, rv.Len())
+			reflect.Copy(reflect.ValueOf(b), rv)
+			return hexutil.Encode(b)
+		}
+		fallthrough
+	case reflect.Slice:
+		out := make([]interface{}, rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			out[i] = formatCallValue(rv.Index(i).Interface())
+		}
+		return out
+	case reflect.Struct:
+		out := make(map[string]interface{}, rv.NumField())
+		for i := 0; i < rv.NumField(); i++ {
+			out[rv.Type().Field(i).Name] = formatCallValue(rv.Field(i).Interface())
+		}
+		return out
+	}
+
+	return v
+}
@@ -0,0 +1,204 @@
+package handlers_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/chain"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/handlers"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/storage/memory"
+)
+
+const callNFTABI = `[
+	{"type":"function","name":"ownerOf","stateMutability":"view","inputs":[{"name":"tokenId","type":"uint256"}],"outputs":[{"type":"address"}]},
+	{"type":"function","name":"balanceOf","stateMutability":"view","inputs":[{"name":"owner","type":"address"}],"outputs":[{"name":"balance","type":"uint256"}]},
+	{"type":"function","name":"getMintInfo","stateMutability":"view","inputs":[],"outputs":[
+		{"name":"price","type":"uint256"},{"name":"active","type":"bool"},{"name":"root","type":"bytes32"},{"name":"phases","type":"uint64[]"}]},
+	{"type":"function","name":"transferFrom","stateMutability":"nonpayable","inputs":[
+		{"name":"from","type":"address"},{"name":"to","type":"address"},{"name":"tokenId","type":"uint256"}],"outputs":[]}
+]`
+
+var (
+	callContract = common.HexToAddress("0x00000000000000000000000000000000000000c1")
+	callOwner    = common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+)
+
+// callNode answers eth_call for callNFTABI and records the calldata it received
+type callNode struct {
+	t      *testing.T
+	abi    abi.ABI
+	inputs [][]byte
+}
+
+func (n *callNode) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		ID     json.RawMessage   `json:"id"`
+		Method string            `json:"method"`
+		Params []json.RawMessage `json:"params"`
+	}
+	require.NoError(n.t, json.NewDecoder(r.Body).Decode(&req))
+	resp := map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": "0x"}
+
+	var call struct {
+		To    common.Address `json:"to"`
+		Data  hexutil.Bytes  `json:"data"`
+		Input hexutil.Bytes  `json:"input"`
+	}
+	if len(req.Params) > 0 {
+		json.Unmarshal(req.Params[0], &call)
+	}
+	input := append(call.Data, call.Input...)
+
+	pack := func(method string, values ...interface{}) {
+		out, err := n.abi.Methods[method].Outputs.Pack(values...)
+		require.NoError(n.t, err)
+		resp["result"] = hexutil.Encode(out)
+	}
+	switch {
+	case req.Method == "eth_chainId":
+		resp["result"] = "0x7a69"
+	case req.Method != "eth_call" || call.To != callContract:
+	case bytes.HasPrefix(input, n.abi.Methods["ownerOf"].ID):
+		n.inputs = append(n.inputs, input)
+		pack("ownerOf", callOwner)
+	case bytes.HasPrefix(input, n.abi.Methods["balanceOf"].ID):
+		n.inputs = append(n.inputs, input)
+		pack("balanceOf", math.MaxBig256)
+	case bytes.HasPrefix(input, n.abi.Methods["getMintInfo"].ID):
+		pack("getMintInfo", big.NewInt(5e16), true, [32]byte{0xab}, []uint64{1, 18446744073709551615})
+	}
+	json.NewEncoder(w).Encode(resp)
+}
+
+func callRouter(t *testing.T, config repository.AppConfigRepository) (*gin.Engine, *callNode) {
+	t.Helper()
+	ctx := context.Background()
+	parsed, err := abi.JSON(strings.NewReader(callNFTABI))
+	require.NoError(t, err)
+	node := &callNode{t: t, abi: parsed}
+	server := httptest.NewServer(node)
+	t.Cleanup(server.Close)
+
+	manager := chain.NewClientManager(nil, zap.NewNop())
+	manager.AddEndpoints(31337, server.URL)
+	t.Cleanup(manager.Close)
+
+	repo := memory.NewMemoryContractRepo()
+	repo.AddNetwork(&repository.NetworkConfig{ChainID: 31337, NetworkName: "localhost", IsActive: true})
+	mappingID := repo.AddMapping(&repository.ContractMapping{SolidityName: "NexusNFT", DBName: "nexusNFT"})
+	_, err = repo.Upsert(ctx, &repository.ContractAddressUpsert{ChainID: 31337, ContractMappingID: mappingID, Address: callContract.Hex()})
+	require.NoError(t, err)
+	_, err = repo.UpsertABI(ctx, &repository.ABIArtifactUpsert{DBName: "nexusNFT", Version: "1.0.0", ABI: json.RawMessage(callNFTABI), ABIHash: "0xnft"})
+	require.NoError(t, err)
+
+	contracts := handlers.NewContractHandler(repo, config, manager, zap.NewNop())
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/contracts/:chainId/:name/call", contracts.CallContract)
+	return router, node
+}
+
+func callMethod(t *testing.T, router *gin.Engine, body string) (int, handlers.ContractResponse, map[string]interface{}) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/contracts/31337/nexusNFT/call", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	var resp handlers.ContractResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp), w.Body.String())
+	result := map[string]interface{}{}
+	if data, ok := resp.Data.(map[string]interface{}); ok {
+		result, _ = data["result"].(map[string]interface{})
+	}
+	return w.Code, resp, result
+}
+
+func TestContractHandler_CallContractAllowlist(t *testing.T) {
+	config := memory.NewMemoryAppConfigRepo()
+	router, node := callRouter(t, config)
+
+	code, resp, _ := callMethod(t, router, `{"method":"transferFrom","args":[]}`)
+	assert.Equal(t, http.StatusForbidden, code)
+	assert.Contains(t, resp.Error, "Method not allowed")
+
+	// A configured allowlist replaces the defaults, and still only lets view methods through
+	allowlist := `{"nexusNFT":["getMintInfo","transferFrom"]}`
+	require.NoError(t, config.Create(context.Background(), &repository.AppConfigCreate{
+		Namespace: "contracts", ConfigKey: "call_allowlist", ValueType: "json", ValueString: &allowlist}))
+
+	code, resp, _ = callMethod(t, router, `{"method":"ownerOf","args":[1]}`)
+	assert.Equal(t, http.StatusForbidden, code)
+	assert.Contains(t, resp.Error, "Method not allowed")
+
+	code, resp, _ = callMethod(t, router, `{"method":"transferFrom","args":[]}`)
+	assert.Equal(t, http.StatusForbidden, code)
+	assert.Contains(t, resp.Error, "view or pure")
+	assert.Empty(t, node.inputs, "rejected calls never reach the node")
+}
+
+func TestContractHandler_CallContractDecodesArguments(t *testing.T) {
+	router, node := callRouter(t, nil)
+	method := node.abi.Methods["ownerOf"]
+
+	// 2^256-1 as a bare JSON number must not round through float64
+	maxUint := math.MaxBig256.String()
+	for _, arg := range []string{maxUint, `"` + maxUint + `"`, `"0x` + math.MaxBig256.Text(16) + `"`} {
+		node.inputs = nil
+		code, resp, result := callMethod(t, router, `{"method":"ownerOf","args":[`+arg+`]}`)
+		require.Equal(t, http.StatusOK, code, resp.Error)
+		assert.Equal(t, callOwner.Hex(), result["0"])
+
+		require.Len(t, node.inputs, 1)
+		args, err := method.Inputs.Unpack(node.inputs[0][4:])
+		require.NoError(t, err)
+		assert.Equal(t, 0, math.MaxBig256.Cmp(args[0].(*big.Int)), "argument %s", arg)
+	}
+
+	for _, body := range []string{
+		`{"method":"ownerOf","args":[1.5]}`,
+		`{"method":"ownerOf","args":[1e3]}`,
+		`{"method":"ownerOf","args":[-1]}`,
+		`{"method":"ownerOf","args":["` + new(big.Int).Lsh(big.NewInt(1), 256).String() + `"]}`,
+		`{"method":"ownerOf","args":[]}`,
+		`{"method":"balanceOf","args":["0xnope"]}`,
+	} {
+		code, resp, _ := callMethod(t, router, body)
+		assert.Equal(t, http.StatusBadRequest, code, body)
+		assert.Contains(t, resp.Error, "Invalid arguments", body)
+	}
+}
+
+func TestContractHandler_CallContractEncodesOutputs(t *testing.T) {
+	router, node := callRouter(t, nil)
+
+	code, resp, result := callMethod(t, router, `{"method":"balanceOf","args":["`+callOwner.Hex()+`"]}`)
+	require.Equal(t, http.StatusOK, code, resp.Error)
+	assert.Equal(t, math.MaxBig256.String(), result["balance"], "named outputs keep their names; uint256 is a decimal string")
+	args, err := node.abi.Methods["balanceOf"].Inputs.Unpack(node.inputs[0][4:])
+	require.NoError(t, err)
+	assert.Equal(t, callOwner, args[0])
+
+	code, resp, result = callMethod(t, router, `{"method":"getMintInfo","args":[]}`)
+	require.Equal(t, http.StatusOK, code, resp.Error)
+	assert.Equal(t, "50000000000000000", result["price"])
+	assert.Equal(t, true, result["active"])
+	assert.Equal(t, "0xab"+strings.Repeat("00", 31), result["root"])
+	assert.Equal(t, []interface{}{"1", "18446744073709551615"}, result["phases"])
+}
@@ -0,0 +1,346 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Deployment artifact formats
+const (
+	DeploymentFormatFoundry = "foundry" // forge script broadcast/<script>/<chain>/run-latest.json
+	DeploymentFormatHardhat = "hardhat" // hardhat-deploy export (hardhat export --export)
+)
+
+// foundryBroadcast is the part of a forge script broadcast artifact read
+type foundryBroadcast struct {
+	Transactions []struct {
+		Hash            string `json:"hash"`
+		TransactionType string `json:"transactionType"`
+		ContractName    string `json:"contractName"`
+		ContractAddress string `json:"contractAddress"`
+		Transaction     struct {
+			From string `json:"from"`
+		} `json:"transaction"`
+	} `json:"transactions"`
+	Receipts []struct {
+		TransactionHash string `json:"transactionHash"`
+		BlockNumber     string `json:"blockNumber"`
+		Status          string `json:"status"`
+	} `json:"receipts"`
+	Chain  int64  `json:"chain"`
+	Commit string `json:"commit"`
+}
+
+// hardhatExport is the part of a hardhat-deploy export read
+type hardhatExport struct {
+	ChainID   string `json:"chainId"`
+	Contracts map[string]struct {
+		Address         string `json:"address"`
+		TransactionHash string `json:"transactionHash"`
+		Receipt         *struct {
+			From        string `json:"from"`
+			BlockNumber int64  `json:"blockNumber"`
+		} `json:"receipt"`
+	} `json:"contracts"`
+}
+
+// deployedContract is one contract deployment read from an artifact
+type deployedContract struct {
+	SolidityName string
+	Address      string
+	TxHash       string
+	Block        *int64
+	Deployer     string
+}
+
+// SkippedDeployment is an artifact entry that was not registered
+type SkippedDeployment struct {
+	ContractName string `json:"contract_name"`
+	Address      string `json:"address,omitempty"`
+	Reason       string `json:"reason"`
+}
+
+// RegisterDeployments handles POST /api/v1/contracts/deployments
+// @Summary Register contracts from a deployment artifact
+// @Description For deployment pipelines (bearer CONTRACT_DEPLOY_TOKEN). Accepts a Foundry broadcast (run-latest.json) or a hardhat-deploy export as the body, resolves each deployed contract's mapping by Solidity name and registers its address with history. Contracts without a mapping are skipped and listed.
+// @Tags contracts
+// @Accept json
+// @Produce json
+// @Param chain_id query int false "Chain ID, when the artifact does not name one"
+// @Param abi_version query string false "ABI version of the deployed contracts"
+// @Success 200 {object} ContractResponse
+// @Failure 400 {object} ContractResponse
+// @Failure 401 {object} ContractResponse
+// @Failure 404 {object} ContractResponse
+// @Router /api/v1/contracts/deployments [post]
+func (h *ContractHandler) RegisterDeployments(c *gin.Context) {
+	body, err := c.GetRawData()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ContractResponse{
+			Success: false,
+			Error:   "Failed to read request body",
+		})
+		return
+	}
+
+	format, chainID, deployments, notes, err := parseDeploymentArtifact(body)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ContractResponse{
+			Success: false,
+			Error:   "Invalid deployment artifact: " + err.Error(),
+		})
+		return
+	}
+	if chainIDStr := c.Query("chain_id"); chainIDStr != "" {
+		override, err := strconv.ParseInt(chainIDStr, 10, 64)
+		if err != nil || (chainID != 0 && chainID != override) {
+			c.JSON(http.StatusBadRequest, ContractResponse{
+				Success: false,
+				Error:   "chain_id does not match the artifact",
+			})
+			return
+		}
+		chainID = override
+	}
+	if chainID == 0 {
+		c.JSON(http.StatusBadRequest, ContractResponse{
+			Success: false,
+			Error:   "The artifact names no chain; pass chain_id",
+		})
+		return
+	}
+	var abiVersion *string
+	if v := c.Query("abi_version"); v != "" {
+		abiVersion = &v
+	}
+
+	ctx := c.Request.Context()
+	if _, err := h.repo.GetNetworkByChainID(ctx, chainID); err != nil {
+		if errors.Is(err, repository.ErrNetworkNotFound) {
+			c.JSON(http.StatusNotFound, ContractResponse{
+				Success: false,
+				Error:   "Network not found for chain ID",
+			})
+			return
+		}
+		h.logger.Error("failed to get network", zap.Int64("chainId", chainID), zap.Error(err))
+		c.JSON(http.StatusInternalServerError, ContractResponse{
+			Success: false,
+			Error:   "Internal server error",
+		})
+		return
+	}
+
+	// Resolve every mapping before registering anything
+	skipped := []SkippedDeployment{}
+	upserts := make([]*repository.ContractAddressUpsert, 0, len(deployments))
+	for _, d := range deployments {
+		if !isValidAddress(d.Address) {
+			skipped = append(skipped, SkippedDeployment{ContractName: d.SolidityName, Address: d.Address, Reason: "invalid address"})
+			continue
+		}
+		mapping, err := h.repo.GetMappingBySolidityName(ctx, d.SolidityName)
+		if err != nil {
+			if errors.Is(err, repository.ErrContractMappingNotFound) {
+				skipped = append(skipped, SkippedDeployment{ContractName: d.SolidityName, Address: d.Address, Reason: "no contract mapping"})
+				continue
+			}
+			h.logger.Error("failed to get contract mapping", zap.String("solidityName", d.SolidityName), zap.Error(err))
+			c.JSON(http.StatusInternalServerError, ContractResponse{
+				Success: false,
+				Error:   "Internal server error",
+			})
+			return
+		}
+
+		upsert := &repository.ContractAddressUpsert{
+			ChainID:           chainID,
+			ContractMappingID: mapping.ID,
+			Address:           d.Address,
+			DeploymentBlock:   d.Block,
+			ABIVersion:        abiVersion,
+			Notes:             &notes,
+		}
+		if d.TxHash != "" {
+			txHash := d.TxHash
+			upsert.DeploymentTxHash = &txHash
+		}
+		if isValidAddress(d.Deployer) {
+			deployer := d.Deployer
+			upsert.DeployedBy = &deployer
+		}
+		upserts = append(upserts, upsert)
+	}
+
+	registered := make([]*repository.ContractAddress, 0, len(upserts))
+	for _, upsert := range upserts {
+		contract, err := h.repo.Upsert(ctx, upsert)
+		if err != nil {
+			h.logger.Error("failed to register deployed contract",
+				zap.Int64("chainId", chainID),
+				zap.String("mappingId", upsert.ContractMappingID),
+				zap.String("address", upsert.Address),
+				zap.Int("registered", len(registered)),
+				zap.Error(err),
+			)
+			c.JSON(http.StatusInternalServerError, ContractResponse{
+				Success: false,
+				Data: gin.H{
+					"registered": registered,
+				},
+				Error: "Failed to register contract",
+			})
+			return
+		}
+		registered = append(registered, contract)
+	}
+
+	h.logger.Info("deployment registered",
+		zap.Int64("chainId", chainID),
+		zap.String("format", format),
+		zap.Int("registered", len(registered)),
+		zap.Int("skipped", len(skipped)),
+	)
+
+	c.JSON(http.StatusOK, ContractResponse{
+		Success: true,
+		Data: gin.H{
+			"format":     format,
+			"chain_id":   chainID,
+			"registered": registered,
+			"skipped":    skipped,
+		},
+		Message: fmt.Sprintf("Registered %d contracts", len(registered)),
+	})
+}
+
+// parseDeploymentArtifact reads the contracts deployed by a Foundry broadcast
+// or a hardhat-deploy export. chainID is 0 when the artifact names none.
+func parseDeploymentArtifact(body []byte) (format string, chainID int64, deployments []deployedContract, notes string, err error) {
+	var probe map[string]json.RawMessage
+	if err := json.Unmarshal(body, &probe); err != nil {
+		return "", 0, nil, "", errors.New("body is not a JSON object")
+	}
+
+	switch {
+	case probe["transactions"] != nil:
+		deployments, chainID, notes, err = parseFoundryBroadcast(body)
+		return DeploymentFormatFoundry, chainID, deployments, notes, err
+	case probe["contracts"] != nil:
+		deployments, chainID, notes, err = parseHardhatExport(body)
+		return DeploymentFormatHardhat, chainID, deployments, notes, err
+	}
+	return "", 0, nil, "", errors.New("expected a Foundry broadcast (transactions) or a hardhat-deploy export (contracts)")
+}
+
+// parseFoundryBroadcast reads the contracts created by successful
+// transactions. Later deployments of a contract replace earlier ones.
+func parseFoundryBroadcast(body []byte) ([]deployedContract, int64, string, error) {
+	var artifact foundryBroadcast
+	if err := json.Unmarshal(body, &artifact); err != nil {
+		return nil, 0, "", err
+	}
+
+	type receipt struct {
+		block  *int64
+		failed bool
+	}
+	receipts := make(map[string]receipt, len(artifact.Receipts))
+	for _, r := range artifact.Receipts {
+		var rec receipt
+		if n, err := hexutil.DecodeUint64(r.BlockNumber); err == nil {
+			block := int64(n)
+			rec.block = &block
+		}
+		rec.failed = r.Status == "0x0"
+		receipts[strings.ToLower(r.TransactionHash)] = rec
+	}
+
+	var deployments []deployedContract
+	index := make(map[string]int)
+	for _, tx := range artifact.Transactions {
+		if tx.TransactionType != "CREATE" && tx.TransactionType != "CREATE2" {
+			continue
+		}
+		if tx.ContractName == "" {
+			continue
+		}
+		rec, mined := receipts[strings.ToLower(tx.Hash)]
+		if rec.failed {
+			continue
+		}
+		d := deployedContract{
+			SolidityName: tx.ContractName,
+			Address:      tx.ContractAddress,
+			TxHash:       strings.ToLower(tx.Hash),
+			Deployer:     tx.Transaction.From,
+		}
+		if mined {
+			d.Block = rec.block
+		}
+		if i, ok := index[d.SolidityName]; ok {
+			deployments[i] = d
+			continue
+		}
+		index[d.SolidityName] = len(deployments)
+		deployments = append(deployments, d)
+	}
+
+	notes := "Registered from Foundry broadcast"
+	if artifact.Commit != "" {
+		notes += " (commit " + artifact.Commit + ")"
+	}
+	return deployments, artifact.Chain, notes, nil
+}
+
+// parseHardhatExport reads the contracts of a hardhat-deploy export, in
+// name order
+func parseHardhatExport(body []byte) ([]deployedContract, int64, string, error) {
+	var artifact hardhatExport
+	if err := json.Unmarshal(body, &artifact); err != nil {
+		return nil, 0, "", err
+	}
+
+	var chainID int64
+	if artifact.ChainID != "" {
+		id, err := strconv.ParseInt(artifact.ChainID, 10, 64)
+		if err != nil {
+			return nil, 0, "", fmt.Errorf("invalid chainId %q", artifact.ChainID)
+		}
+		chainID = id
+	}
+
+	names := make([]string, 0, len(artifact.Contracts))
+	for name := range artifact.Contracts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	deployments := make([]deployedContract, 0, len(names))
+	for _, name := range names {
+		entry := artifact.Contracts[name]
+		d := deployedContract{
+			SolidityName: name,
+			Address:      entry.Address,
+			TxHash:       strings.ToLower(entry.TransactionHash),
+		}
+		if entry.Receipt != nil {
+			block := entry.Receipt.BlockNumber
+			d.Block = &block
+			d.Deployer = entry.Receipt.From
+		}
+		deployments = append(deployments, d)
+	}
+	return deployments, chainID, "Registered from hardhat-deploy export", nil
+}
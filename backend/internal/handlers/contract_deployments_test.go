@@ -0,0 +1,166 @@
+package handlers_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/handlers"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/middleware"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/storage/memory"
+)
+
+const (
+	deployToken = "deploy-secret"
+	deployer    = "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"
+	tokenAddr   = "0x5FbDB2315678afecb367f032d93F642f64180aa3"
+	stakingAddr = "0xe7f1725E7734CE288F8367e1Bb143E90bb3F0512"
+)
+
+// foundryRun is a trimmed forge script broadcast: two deployments, one
+// unmapped contract, a call and a reverted deployment
+const foundryRun = `{
+  "transactions": [
+    {"hash": "0xAA01", "transactionType": "CREATE", "contractName": "NexusToken", "contractAddress": "0x5FbDB2315678afecb367f032d93F642f64180aa3", "transaction": {"from": "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"}},
+    {"hash": "0xaa02", "transactionType": "CREATE2", "contractName": "NexusStaking", "contractAddress": "0xe7f1725E7734CE288F8367e1Bb143E90bb3F0512", "transaction": {"from": "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"}},
+    {"hash": "0xaa03", "transactionType": "CREATE", "contractName": "Multicall3", "contractAddress": "0x9fE46736679d2D9a65F0992F2272dE9f3c7fa6e0", "transaction": {"from": "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"}},
+    {"hash": "0xaa04", "transactionType": "CALL", "contractName": "NexusToken", "contractAddress": "0x5FbDB2315678afecb367f032d93F642f64180aa3", "transaction": {"from": "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"}},
+    {"hash": "0xaa05", "transactionType": "CREATE", "contractName": "NexusNFT", "contractAddress": "0xCf7Ed3AccA5a467e9e704C703E8D87F634fB0Fc9", "transaction": {"from": "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"}}
+  ],
+  "receipts": [
+    {"transactionHash": "0xaa01", "blockNumber": "0x1", "status": "0x1"},
+    {"transactionHash": "0xaa02", "blockNumber": "0x2", "status": "0x1"},
+    {"transactionHash": "0xaa03", "blockNumber": "0x3", "status": "0x1"},
+    {"transactionHash": "0xaa05", "blockNumber": "0x4", "status": "0x0"}
+  ],
+  "chain": 31337,
+  "commit": "abc1234"
+}`
+
+const hardhatDeployExport = `{
+  "name": "localhost",
+  "chainId": "31337",
+  "contracts": {
+    "NexusToken": {"address": "0x5FbDB2315678afecb367f032d93F642f64180aa3", "transactionHash": "0xbb01", "receipt": {"from": "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266", "blockNumber": 7}},
+    "NexusStaking": {"address": "not-an-address"}
+  }
+}`
+
+func deploymentRouter(t *testing.T) (*gin.Engine, *memory.MemoryContractRepo) {
+	t.Helper()
+	repo := memory.NewMemoryContractRepo()
+	repo.AddNetwork(&repository.NetworkConfig{ChainID: 31337, NetworkName: "localhost", IsActive: true})
+	for _, name := range []string{"NexusToken", "NexusStaking", "NexusNFT"} {
+		repo.AddMapping(&repository.ContractMapping{SolidityName: name, DBName: "n" + name[1:]})
+	}
+	contracts := handlers.NewContractHandler(repo, nil, nil, zap.NewNop())
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/contracts/deployments", middleware.DeployAuthFunc(func() string { return deployToken }), contracts.RegisterDeployments)
+	return router, repo
+}
+
+func postDeployment(router *gin.Engine, query, token, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/contracts/deployments"+query, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+type deploymentResponse struct {
+	Success bool `json:"success"`
+	Data    struct {
+		Format     string                       `json:"format"`
+		ChainID    int64                        `json:"chain_id"`
+		Registered []repository.ContractAddress `json:"registered"`
+		Skipped    []handlers.SkippedDeployment `json:"skipped"`
+	} `json:"data"`
+}
+
+func TestContractHandler_RegisterFoundryBroadcast(t *testing.T) {
+	ctx := context.Background()
+	router, repo := deploymentRouter(t)
+
+	assert.Equal(t, http.StatusUnauthorized, postDeployment(router, "", "", foundryRun).Code)
+	assert.Equal(t, http.StatusUnauthorized, postDeployment(router, "", "wrong", foundryRun).Code)
+
+	w := postDeployment(router, "?abi_version=1.2.0", deployToken, foundryRun)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var resp deploymentResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, handlers.DeploymentFormatFoundry, resp.Data.Format)
+	assert.Equal(t, int64(31337), resp.Data.ChainID)
+	require.Len(t, resp.Data.Registered, 2)
+	assert.Equal(t, []handlers.SkippedDeployment{
+		{ContractName: "Multicall3", Address: "0x9fE46736679d2D9a65F0992F2272dE9f3c7fa6e0", Reason: "no contract mapping"},
+	}, resp.Data.Skipped)
+
+	token, err := repo.GetByChainAndDBName(ctx, 31337, "nexusToken")
+	require.NoError(t, err)
+	assert.Equal(t, tokenAddr, token.Address)
+	require.NotNil(t, token.DeploymentTxHash)
+	assert.Equal(t, "0xaa01", *token.DeploymentTxHash)
+	require.NotNil(t, token.DeploymentBlock)
+	assert.Equal(t, int64(1), *token.DeploymentBlock)
+	require.NotNil(t, token.DeployedBy)
+	assert.Equal(t, deployer, *token.DeployedBy)
+	assert.Equal(t, "1.2.0", token.ABIVersion)
+
+	// The reverted deployment is not registered
+	_, err = repo.GetByChainAndDBName(ctx, 31337, "nexusNFT")
+	assert.ErrorIs(t, err, repository.ErrContractAddressNotFound)
+
+	// A redeployment is recorded in the contract's history
+	redeploy := `{"transactions": [{"hash": "0xcc01", "transactionType": "CREATE", "contractName": "NexusToken", "contractAddress": "` + stakingAddr + `", "transaction": {"from": "` + deployer + `"}}], "receipts": [], "chain": 31337}`
+	require.Equal(t, http.StatusOK, postDeployment(router, "", deployToken, redeploy).Code)
+	history, err := repo.GetHistory(ctx, token.ID, 10)
+	require.NoError(t, err)
+	require.NotEmpty(t, history)
+	assert.Equal(t, stakingAddr, history[0].NewAddress)
+}
+
+func TestContractHandler_RegisterHardhatExport(t *testing.T) {
+	router, _ := deploymentRouter(t)
+
+	w := postDeployment(router, "", deployToken, hardhatDeployExport)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var resp deploymentResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, handlers.DeploymentFormatHardhat, resp.Data.Format)
+	require.Len(t, resp.Data.Registered, 1)
+	assert.Equal(t, tokenAddr, resp.Data.Registered[0].Address)
+	require.NotNil(t, resp.Data.Registered[0].DeploymentBlock)
+	assert.Equal(t, int64(7), *resp.Data.Registered[0].DeploymentBlock)
+	assert.Equal(t, []handlers.SkippedDeployment{
+		{ContractName: "NexusStaking", Address: "not-an-address", Reason: "invalid address"},
+	}, resp.Data.Skipped)
+
+	// The chain must be known and agree with the artifact
+	assert.Equal(t, http.StatusBadRequest, postDeployment(router, "?chain_id=1", deployToken, hardhatDeployExport).Code)
+	assert.Equal(t, http.StatusBadRequest, postDeployment(router, "", deployToken, `{"contracts": {}}`).Code)
+	assert.Equal(t, http.StatusNotFound, postDeployment(router, "?chain_id=1", deployToken, `{"contracts": {}}`).Code)
+	assert.Equal(t, http.StatusBadRequest, postDeployment(router, "", deployToken, `{"abi": []}`).Code)
+}
+
+func TestDeployAuthFunc_Unconfigured(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/contracts/deployments", middleware.DeployAuthFunc(func() string { return "" }), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	assert.Equal(t, http.StatusServiceUnavailable, postDeployment(router, "", "anything", "{}").Code)
+}
@@ -1,27 +1,39 @@
 package handlers
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
 	"net/http"
 	"strconv"
+	"strings"
 
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/chain"
 	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
 )
 
 // ContractHandler handles contract address related API endpoints
 type ContractHandler struct {
-	repo   repository.ContractRepository
-	logger *zap.Logger
+	repo       repository.ContractRepository
+	configRepo repository.AppConfigRepository
+	logger     *zap.Logger
+
+	// Shared RPC clients for read-only contract calls
+	rpc *chain.ClientManager
 }
 
 // NewContractHandler creates a new contract handler with injected dependencies
-func NewContractHandler(repo repository.ContractRepository, logger *zap.Logger) *ContractHandler {
+func NewContractHandler(repo repository.ContractRepository, configRepo repository.AppConfigRepository, rpc *chain.ClientManager, logger *zap.Logger) *ContractHandler {
 	return &ContractHandler{
-		repo:   repo,
-		logger: logger,
+		repo:       repo,
+		configRepo: configRepo,
+		logger:     logger,
+		rpc:        rpc,
 	}
 }
 
@@ -43,6 +55,17 @@ type UpsertContractRequest struct {
 	ABIVersion        *string `json:"abi_version,omitempty"`
 	DeployedBy        *string `json:"deployed_by,omitempty"`
 	Notes             *string `json:"notes,omitempty"`
+	EIP712Name        *string `json:"eip712_name,omitempty"`    // EIP-712 domain name, for contracts that verify typed signatures
+	EIP712Version     *string `json:"eip712_version,omitempty"` // EIP-712 domain version
+}
+
+// UploadABIRequest represents a request to store a contract ABI version
+type UploadABIRequest struct {
+	ContractName    string          `json:"contract_name" binding:"required"` // Contract DB name (e.g., nexusToken)
+	Version         string          `json:"version" binding:"required"`
+	ABI             json.RawMessage `json:"abi" binding:"required"`
+	CompilerVersion *string         `json:"compiler_version,omitempty"`
+	UploadedBy      *string         `json:"uploaded_by,omitempty"`
 }
 
 // ============================================================================
@@ -207,6 +230,7 @@ func (h *ContractHandler) GetDeploymentConfig(c *gin.Context) {
 // @Tags contracts
 // @Produce json
 // @Param chainId path int true "Chain ID"
+// @Param fields query string false "Comma-separated record fields to return (id is always included)"
 // @Success 200 {object} ContractResponse
 // @Router /api/v1/contracts/{chainId} [get]
 func (h *ContractHandler) ListContracts(c *gin.Context) {
@@ -344,6 +368,8 @@ func (h *ContractHandler) UpsertContract(c *gin.Context) {
 		ABIVersion:        req.ABIVersion,
 		DeployedBy:        req.DeployedBy,
 		Notes:             req.Notes,
+		EIP712Name:        req.EIP712Name,
+		EIP712Version:     req.EIP712Version,
 	}
 
 	contract, err := h.repo.Upsert(c.Request.Context(), upsert)
@@ -414,9 +440,9 @@ func (h *ContractHandler) GetContractHistory(c *gin.Context) {
 		return
 	}
 
-	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
-	if limit < 1 || limit > 100 {
-		limit = 20
+	limit, ok := queryLimit(c, "limit", 20, 100)
+	if !ok {
+		return
 	}
 
 	// Verify contract exists
@@ -458,3 +484,237 @@ func (h *ContractHandler) GetContractHistory(c *gin.Context) {
 		},
 	})
 }
+
+// ============================================================================
+// ABI Artifact Endpoints
+// ============================================================================
+
+// UploadABI handles POST /api/v1/contracts/abis
+// @Summary Upload a contract ABI
+// @Description Publishes the ABI for a contract and version (bearer CONTRACT_DEPLOY_TOKEN). A published version cannot change: re-uploading the same ABI returns it, a different ABI is refused with 409.
+// @Tags contracts
+// @Accept json
+// @Produce json
+// @Param request body UploadABIRequest true "ABI upload request"
+// @Success 200 {object} ContractResponse
+// @Failure 400 {object} ContractResponse
+// @Failure 401 {object} ContractResponse
+// @Failure 404 {object} ContractResponse
+// @Failure 409 {object} ContractResponse
+// @Router /api/v1/contracts/abis [post]
+func (h *ContractHandler) UploadABI(c *gin.Context) {
+	var req UploadABIRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ContractResponse{
+			Success: false,
+			Error:   "Invalid request: " + err.Error(),
+		})
+		return
+	}
+
+	if req.Version == repository.ABIVersionLatest {
+		c.JSON(http.StatusBadRequest, ContractResponse{
+			Success: false,
+			Error:   "Version '" + repository.ABIVersionLatest + "' is reserved",
+		})
+		return
+	}
+
+	compact, err := normalizeABI(req.ABI)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ContractResponse{
+			Success: false,
+			Error:   "Invalid ABI: " + err.Error(),
+		})
+		return
+	}
+
+	artifact, err := h.repo.UpsertABI(c.Request.Context(), &repository.ABIArtifactUpsert{
+		DBName:          req.ContractName,
+		Version:         req.Version,
+		ABI:             compact,
+		ABIHash:         crypto.Keccak256Hash(compact).Hex(),
+		CompilerVersion: req.CompilerVersion,
+		UploadedBy:      req.UploadedBy,
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrContractMappingNotFound) {
+			c.JSON(http.StatusNotFound, ContractResponse{
+				Success: false,
+				Error:   "Contract mapping not found: " + req.ContractName,
+			})
+			return
+		}
+		if errors.Is(err, repository.ErrABIVersionConflict) {
+			c.JSON(http.StatusConflict, ContractResponse{
+				Success: false,
+				Error:   "Version " + req.Version + " of " + req.ContractName + " is already published with a different ABI; upload it as a new version",
+			})
+			return
+		}
+		h.logger.Error("failed to store abi",
+			zap.String("name", req.ContractName),
+			zap.String("version", req.Version),
+			zap.Error(err),
+		)
+		c.JSON(http.StatusInternalServerError, ContractResponse{
+			Success: false,
+			Error:   "Failed to store ABI",
+		})
+		return
+	}
+
+	h.logger.Info("abi stored",
+		zap.String("name", artifact.DBName),
+		zap.String("version", artifact.Version),
+		zap.String("hash", artifact.ABIHash),
+	)
+
+	c.JSON(http.StatusOK, ContractResponse{
+		Success: true,
+		Data: gin.H{
+			"artifact": artifact,
+		},
+		Message: "ABI stored successfully",
+	})
+}
+
+// ListABIVersions handles GET /api/v1/contracts/abis/:name
+// @Summary List ABI versions for a contract
+// @Description Returns the stored ABI versions (without ABI bodies) for a contract, newest first
+// @Tags contracts
+// @Produce json
+// @Param name path string true "Contract DB name (e.g., nexusToken)"
+// @Success 200 {object} ContractResponse
+// @Router /api/v1/contracts/abis/{name} [get]
+func (h *ContractHandler) ListABIVersions(c *gin.Context) {
+	name := c.Param("name")
+
+	versions, err := h.repo.ListABIVersions(c.Request.Context(), name)
+	if err != nil {
+		h.logger.Error("failed to list abi versions", zap.String("name", name), zap.Error(err))
+		c.JSON(http.StatusInternalServerError, ContractResponse{
+			Success: false,
+			Error:   "Internal server error",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, ContractResponse{
+		Success: true,
+		Data: gin.H{
+			"contract_name": name,
+			"versions":      versions,
+			"total":         len(versions),
+		},
+	})
+}
+
+// GetABI handles GET /api/v1/contracts/abis/:name/:version
+// @Summary Get a contract ABI
+// @Description Returns the ABI for a contract and version. Use "latest" for the most recent upload.
+// @Tags contracts
+// @Produce json
+// @Param name path string true "Contract DB name (e.g., nexusToken)"
+// @Param version path string true "ABI version or 'latest'"
+// @Success 200 {object} ContractResponse
+// @Failure 404 {object} ContractResponse
+// @Router /api/v1/contracts/abis/{name}/{version} [get]
+func (h *ContractHandler) GetABI(c *gin.Context) {
+	h.respondWithABI(c, c.Param("name"), c.Param("version"))
+}
+
+// GetDeployedABI handles GET /api/v1/contracts/:chainId/:name/abi
+// @Summary Get the ABI of a deployed contract
+// @Description Resolves the ABI matching the abi_version of the contract deployed on a chain
+// @Tags contracts
+// @Produce json
+// @Param chainId path int true "Chain ID"
+// @Param name path string true "Contract DB name (e.g., nexusToken)"
+// @Success 200 {object} ContractResponse
+// @Failure 404 {object} ContractResponse
+// @Router /api/v1/contracts/{chainId}/{name}/abi [get]
+func (h *ContractHandler) GetDeployedABI(c *gin.Context) {
+	chainIDStr := c.Param("chainId")
+	chainID, err := strconv.ParseInt(chainIDStr, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ContractResponse{
+			Success: false,
+			Error:   "Invalid chain ID format",
+		})
+		return
+	}
+
+	name := c.Param("name")
+
+	contract, err := h.repo.GetByChainAndDBName(c.Request.Context(), chainID, name)
+	if err != nil {
+		if errors.Is(err, repository.ErrContractAddressNotFound) {
+			c.JSON(http.StatusNotFound, ContractResponse{
+				Success: false,
+				Error:   "Contract not found: " + name + " on chain " + chainIDStr,
+			})
+			return
+		}
+		h.logger.Error("failed to get contract",
+			zap.Int64("chainId", chainID),
+			zap.String("name", name),
+			zap.Error(err),
+		)
+		c.JSON(http.StatusInternalServerError, ContractResponse{
+			Success: false,
+			Error:   "Internal server error",
+		})
+		return
+	}
+
+	h.respondWithABI(c, name, contract.ABIVersion)
+}
+
+// respondWithABI writes the ABI artifact for name@version to the response
+func (h *ContractHandler) respondWithABI(c *gin.Context, name, version string) {
+	artifact, err := h.repo.GetABI(c.Request.Context(), name, version)
+	if err != nil {
+		if errors.Is(err, repository.ErrABIArtifactNotFound) {
+			c.JSON(http.StatusNotFound, ContractResponse{
+				Success: false,
+				Error:   "ABI not found: " + name + "@" + version,
+			})
+			return
+		}
+		h.logger.Error("failed to get abi",
+			zap.String("name", name),
+			zap.String("version", version),
+			zap.Error(err),
+		)
+		c.JSON(http.StatusInternalServerError, ContractResponse{
+			Success: false,
+			Error:   "Internal server error",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, ContractResponse{
+		Success: true,
+		Data:    artifact,
+	})
+}
+
+// normalizeABI validates that raw is a parseable contract ABI and returns it compacted
+func normalizeABI(raw json.RawMessage) (json.RawMessage, error) {
+	trimmed := bytes.TrimSpace(raw)
+	if len(trimmed) == 0 || trimmed[0] != '[' {
+		return nil, repository.ErrInvalidABI
+	}
+
+	if _, err := abi.JSON(strings.NewReader(string(trimmed))); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, trimmed); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
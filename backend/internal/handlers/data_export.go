@@ -0,0 +1,196 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/dataexport"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/middleware"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/objectstore"
+)
+
+// exportDownloadTTL is how long a data export download link works
+const exportDownloadTTL = time.Hour
+
+// ExportPresigner issues direct download links for stored exports
+// (implemented by the objectstore backends)
+type ExportPresigner interface {
+	PresignGet(ctx context.Context, key string, ttl time.Duration) (string, error)
+}
+
+// LinkSigner signs download links served by the backend itself, for object
+// stores that cannot presign (implemented by auth.Service)
+type LinkSigner interface {
+	SignLink(payload string) string
+	VerifyLink(payload, signature string) bool
+}
+
+// DataExportHandler lets users download everything held about their
+// address (GDPR right of access)
+type DataExportHandler struct {
+	exporter  *dataexport.Exporter
+	presigner ExportPresigner
+	links     LinkSigner
+	logger    *zap.Logger
+}
+
+// NewDataExportHandler creates a new data export handler with injected dependencies
+func NewDataExportHandler(exporter *dataexport.Exporter, presigner ExportPresigner, links LinkSigner, logger *zap.Logger) *DataExportHandler {
+	return &DataExportHandler{exporter: exporter, presigner: presigner, links: links, logger: logger}
+}
+
+// DataExportResponse wraps data export API responses
+type DataExportResponse struct {
+	Success bool        `json:"success"`
+	Data    interface{} `json:"data,omitempty"`
+	Error   string      `json:"error,omitempty"`
+}
+
+// DataExportStatus is an export's progress, with a download link once
+// it is ready
+type DataExportStatus struct {
+	*dataexport.Export
+	DownloadURL string     `json:"download_url,omitempty"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"` // of the download link
+}
+
+// RequestExport handles GET /api/v1/users/:address/export
+// @Summary Export your data
+// @Description Compiles the signed-in wallet's payments, KYC status, NFTs, votes and meta-transactions. The export is generated in the background: poll until it is ready, then follow download_url. A finished export is reused for 24 hours.
+// @Tags users
+// @Produce json
+// @Param address path string true "Wallet address (must be the signed-in one)"
+// @Param format query string false "zip (default) or json"
+// @Success 200 {object} DataExportResponse{data=DataExportStatus}
+// @Success 202 {object} DataExportResponse{data=DataExportStatus}
+// @Failure 400 {object} DataExportResponse
+// @Failure 401 {object} DataExportResponse
+// @Failure 403 {object} DataExportResponse
+// @Router /api/v1/users/{address}/export [get]
+func (h *DataExportHandler) RequestExport(c *gin.Context) {
+	address := strings.ToLower(c.Param("address"))
+	if !isValidAddress(address) {
+		c.JSON(http.StatusBadRequest, DataExportResponse{Success: false, Error: "Invalid address"})
+		return
+	}
+	if address != middleware.SessionAddress(c) {
+		c.JSON(http.StatusForbidden, DataExportResponse{Success: false, Error: "You can only export your own data"})
+		return
+	}
+	format := dataexport.Format(strings.ToLower(c.DefaultQuery("format", string(dataexport.FormatZIP))))
+	if !format.Valid() {
+		c.JSON(http.StatusBadRequest, DataExportResponse{Success: false, Error: "format must be zip or json"})
+		return
+	}
+
+	x, _, err := h.exporter.Request(c.Request.Context(), address, format)
+	if err != nil {
+		h.logger.Error("failed to request data export", zap.String("address", address), zap.Error(err))
+		c.JSON(http.StatusInternalServerError, DataExportResponse{Success: false, Error: "Failed to request data export"})
+		return
+	}
+
+	status := DataExportStatus{Export: x}
+	switch x.Status {
+	case dataexport.StatusPending:
+		c.JSON(http.StatusAccepted, DataExportResponse{Success: true, Data: status})
+		return
+	case dataexport.StatusReady:
+		link, expires, err := h.downloadLink(c, x)
+		if err != nil {
+			h.logger.Error("failed to issue data export link", zap.String("address", address), zap.Error(err))
+			c.JSON(http.StatusServiceUnavailable, DataExportResponse{Success: false, Error: "Export download is unavailable"})
+			return
+		}
+		status.DownloadURL, status.ExpiresAt = link, &expires
+	}
+	c.JSON(http.StatusOK, DataExportResponse{Success: true, Data: status})
+}
+
+// DownloadExport handles GET /api/v1/users/:address/export/download
+// @Summary Download your data export
+// @Description Streams a ready export. The link is signed and issued by the export endpoint; no session is needed.
+// @Tags users
+// @Produce application/zip
+// @Param address path string true "Wallet address"
+// @Param id query string true "Export ID"
+// @Param expires query int true "Link expiry (unix seconds)"
+// @Param signature query string true "Link signature"
+// @Success 200 {file} file
+// @Failure 403 {object} DataExportResponse
+// @Failure 404 {object} DataExportResponse
+// @Router /api/v1/users/{address}/export/download [get]
+func (h *DataExportHandler) DownloadExport(c *gin.Context) {
+	address := strings.ToLower(c.Param("address"))
+	id := c.Query("id")
+	expires, err := strconv.ParseInt(c.Query("expires"), 10, 64)
+	if err != nil || !h.links.VerifyLink(exportLinkPayload(address, id, expires), c.Query("signature")) {
+		c.JSON(http.StatusForbidden, DataExportResponse{Success: false, Error: "Invalid download link"})
+		return
+	}
+	if time.Now().Unix() > expires {
+		c.JSON(http.StatusForbidden, DataExportResponse{Success: false, Error: "Download link has expired"})
+		return
+	}
+
+	x, err := h.exporter.Get(c.Request.Context(), address)
+	if err == nil && x.ID != id {
+		err = dataexport.ErrNotFound // replaced by a newer export
+	}
+	var body io.ReadCloser
+	if err == nil {
+		body, err = h.exporter.Open(c.Request.Context(), x)
+	}
+	if err != nil {
+		if errors.Is(err, dataexport.ErrNotFound) {
+			c.JSON(http.StatusNotFound, DataExportResponse{Success: false, Error: "Export not found"})
+			return
+		}
+		h.logger.Error("failed to open data export", zap.String("address", address), zap.String("export_id", id), zap.Error(err))
+		c.JSON(http.StatusInternalServerError, DataExportResponse{Success: false, Error: "Failed to read data export"})
+		return
+	}
+	defer body.Close()
+
+	c.Header("Content-Type", x.ContentType())
+	c.Header("Content-Disposition", `attachment; filename="`+x.Filename()+`"`)
+	c.Status(http.StatusOK)
+	if _, err := io.Copy(c.Writer, body); err != nil {
+		h.logger.Warn("data export download interrupted", zap.String("export_id", id), zap.Error(err))
+	}
+}
+
+// downloadLink presigns x, falling back to a signed link to DownloadExport
+func (h *DataExportHandler) downloadLink(c *gin.Context, x *dataexport.Export) (string, time.Time, error) {
+	expires := time.Now().UTC().Add(exportDownloadTTL).Truncate(time.Second)
+
+	link, err := h.presigner.PresignGet(c.Request.Context(), x.ObjectKey, exportDownloadTTL)
+	if err == nil {
+		return link, expires, nil
+	}
+	if !errors.Is(err, objectstore.ErrPresignUnsupported) {
+		return "", time.Time{}, err
+	}
+
+	query := url.Values{
+		"id":        {x.ID},
+		"expires":   {strconv.FormatInt(expires.Unix(), 10)},
+		"signature": {h.links.SignLink(exportLinkPayload(x.Address, x.ID, expires.Unix()))},
+	}
+	return "/api/v1/users/" + x.Address + "/export/download?" + query.Encode(), expires, nil
+}
+
+// exportLinkPayload is what a backend download link's signature covers
+func exportLinkPayload(address, id string, expires int64) string {
+	return fmt.Sprintf("export:%s:%s:%d", address, id, expires)
+}
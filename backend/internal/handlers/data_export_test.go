@@ -0,0 +1,88 @@
+package handlers_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/auth"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/dataexport"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/handlers"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/middleware"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/objectstore"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/storage/memory"
+)
+
+func TestDataExportHandler_OwnDataWithSignedLink(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	store := memory.NewStore()
+	const address = "0x00000000000000000000000000000000000000aa"
+	require.NoError(t, store.Payments.CreatePayment(context.Background(), &repository.Payment{ServiceCode: "kyc_verification",
+		PayerAddress: address, PaymentMethod: "stripe", Currency: "USD", Status: repository.PaymentStatusCompleted}))
+
+	// The memory object store cannot presign, so links point at the backend
+	objects := objectstore.NewMemory()
+	exporter := dataexport.NewExporter(store.Payments, store.Relayer, store.Activity, objects, zap.NewNop())
+	links := auth.NewService(store.AppConfig, func() string { return "test-signing-key" }, zap.NewNop())
+	handler := handlers.NewDataExportHandler(exporter, objects, links, zap.NewNop())
+
+	router := gin.New()
+	router.GET("/api/v1/users/:address/export", middleware.RequireSession(sessions{"alice-token": address}), handler.RequestExport)
+	router.GET("/api/v1/users/:address/export/download", handler.DownloadExport)
+
+	do := func(path, token string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	assert.Equal(t, http.StatusUnauthorized, do("/api/v1/users/"+address+"/export", "").Code)
+	assert.Equal(t, http.StatusForbidden, do("/api/v1/users/0x00000000000000000000000000000000000000bb/export", "alice-token").Code)
+	assert.Equal(t, http.StatusBadRequest, do("/api/v1/users/"+address+"/export?format=csv", "alice-token").Code)
+
+	w := do("/api/v1/users/0x00000000000000000000000000000000000000AA/export", "alice-token")
+	require.Equal(t, http.StatusAccepted, w.Code, w.Body.String())
+	exporter.Wait()
+
+	w = do("/api/v1/users/"+address+"/export", "alice-token")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var resp struct {
+		Data struct {
+			Status      string `json:"status"`
+			DownloadURL string `json:"download_url"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "ready", resp.Data.Status)
+	require.NotEmpty(t, resp.Data.DownloadURL)
+
+	w = do(resp.Data.DownloadURL, "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, "application/zip", w.Header().Get("Content-Type"))
+	zr, err := zip.NewReader(bytes.NewReader(w.Body.Bytes()), int64(w.Body.Len()))
+	require.NoError(t, err)
+	assert.Len(t, zr.File, 6)
+
+	// A tampered link is refused
+	link, err := url.Parse(resp.Data.DownloadURL)
+	require.NoError(t, err)
+	query := link.Query()
+	query.Set("expires", "99999999999")
+	link.RawQuery = query.Encode()
+	assert.Equal(t, http.StatusForbidden, do(link.String(), "").Code)
+}
@@ -0,0 +1,289 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/deposits"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// DepositHandler handles crypto payments by transfer to a unique deposit
+// address
+type DepositHandler struct {
+	repo              repository.DepositRepository
+	pricingRepo       repository.PricingRepository
+	service           *deposits.Service
+	logger            *zap.Logger
+	insecureCallbacks bool
+}
+
+// NewDepositHandler creates a new deposit handler with injected dependencies.
+// Without a service (no DEPOSIT_WALLET_SEED or no RPC) new deposits are
+// refused with 503; existing intents can still be read.
+func NewDepositHandler(repo repository.DepositRepository, pricingRepo repository.PricingRepository, service *deposits.Service, logger *zap.Logger) *DepositHandler {
+	return &DepositHandler{
+		repo:        repo,
+		pricingRepo: pricingRepo,
+		service:     service,
+		logger:      logger,
+	}
+}
+
+// SetInsecureCallbacks accepts http callback URLs (for local development)
+func (h *DepositHandler) SetInsecureCallbacks(allow bool) {
+	h.insecureCallbacks = allow
+}
+
+// DepositResponse wraps deposit API responses
+type DepositResponse struct {
+	Success bool        `json:"success"`
+	Data    interface{} `json:"data,omitempty"`
+	Error   string      `json:"error,omitempty"`
+}
+
+// CreateDepositRequest opens a deposit-address payment
+type CreateDepositRequest struct {
+	ServiceCode   string `json:"service_code" binding:"required"`
+	PayerAddress  string `json:"payer_address" binding:"required"`
+	PaymentMethod string `json:"payment_method" binding:"required"` // nexus or eth
+
+	// Optional, as for CreateCheckoutRequest
+	CallbackURL    string `json:"callback_url,omitempty"`
+	CallbackSecret string `json:"callback_secret,omitempty"`
+}
+
+// DepositInstructions is an intent with the chain its transfer must be sent on
+type DepositInstructions struct {
+	*repository.DepositIntent
+	ChainID int64 `json:"chain_id"`
+}
+
+// CreateDeposit handles POST /api/v1/payments/deposits
+// @Summary Open a deposit-address crypto payment
+// @Description Creates a pending payment and a unique address to pay it to. Send expected_amount base units of ETH, or of the NEXUS token at token_address, to deposit_address before expires_at; the payment completes once the transfer reaches confirmation depth.
+// @Tags payments
+// @Accept json
+// @Produce json
+// @Param request body CreateDepositRequest true "Deposit request"
+// @Success 201 {object} DepositResponse{data=DepositInstructions}
+// @Failure 400 {object} DepositResponse
+// @Failure 503 {object} DepositResponse
+// @Router /api/v1/payments/deposits [post]
+func (h *DepositHandler) CreateDeposit(c *gin.Context) {
+	if h.service == nil {
+		c.JSON(http.StatusServiceUnavailable, DepositResponse{
+			Success: false,
+			Error:   "Deposit payments are not configured",
+		})
+		return
+	}
+
+	var req CreateDepositRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, DepositResponse{
+			Success: false,
+			Error:   "Invalid request: " + err.Error(),
+		})
+		return
+	}
+
+	if !isValidAddress(req.PayerAddress) {
+		c.JSON(http.StatusBadRequest, DepositResponse{
+			Success: false,
+			Error:   "Invalid payer address format",
+		})
+		return
+	}
+
+	if req.PaymentMethod != "eth" && req.PaymentMethod != "nexus" {
+		c.JSON(http.StatusBadRequest, DepositResponse{
+			Success: false,
+			Error:   "Invalid payment method. Must be 'eth' or 'nexus'",
+		})
+		return
+	}
+
+	callbackURL, callbackSecret, err := validateCallback(req.CallbackURL, req.CallbackSecret, h.insecureCallbacks)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, DepositResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	pricing, err := h.pricingRepo.GetPricing(ctx, req.ServiceCode)
+	if err != nil {
+		if errors.Is(err, repository.ErrPricingNotFound) {
+			c.JSON(http.StatusBadRequest, DepositResponse{
+				Success: false,
+				Error:   "Service not found",
+			})
+			return
+		}
+		h.logger.Error("failed to get pricing", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, DepositResponse{
+			Success: false,
+			Error:   "Internal server error",
+		})
+		return
+	}
+
+	price := pricing.PriceETH
+	if req.PaymentMethod == "nexus" {
+		price = pricing.PriceNEXUS
+	}
+	if price == nil || *price <= 0 {
+		c.JSON(http.StatusBadRequest, DepositResponse{
+			Success: false,
+			Error:   strings.ToUpper(req.PaymentMethod) + " payment not available for this service",
+		})
+		return
+	}
+
+	amountUSD := pricing.PriceUSD
+	payment := &repository.Payment{
+		ServiceCode:   req.ServiceCode,
+		PricingID:     &pricing.ID,
+		PayerAddress:  strings.ToLower(req.PayerAddress),
+		PaymentMethod: req.PaymentMethod,
+		AmountCharged: *price,
+		AmountUSD:     &amountUSD,
+
+		CallbackURL:    callbackURL,
+		CallbackSecret: callbackSecret,
+		Country:        clientCountry(c),
+	}
+
+	intent, err := h.service.Open(ctx, payment, deposits.ToBaseUnits(*price))
+	if err != nil {
+		if errors.Is(err, deposits.ErrTokenUnavailable) {
+			c.JSON(http.StatusServiceUnavailable, DepositResponse{
+				Success: false,
+				Error:   "NEXUS payment not available on this network",
+			})
+			return
+		}
+		h.logger.Error("failed to open deposit intent", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, DepositResponse{
+			Success: false,
+			Error:   "Failed to create deposit address",
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, DepositResponse{
+		Success: true,
+		Data:    DepositInstructions{DepositIntent: intent, ChainID: h.service.ChainID()},
+	})
+}
+
+// GetDeposit handles GET /api/v1/payments/deposits/:id
+// @Summary Get deposit intent
+// @Description Returns a deposit intent: where to pay, how much was received and its status (awaiting, confirming, paid, underpaid, swept or expired)
+// @Tags payments
+// @Produce json
+// @Param id path string true "Deposit intent ID"
+// @Success 200 {object} DepositResponse{data=repository.DepositIntent}
+// @Failure 404 {object} DepositResponse
+// @Router /api/v1/payments/deposits/{id} [get]
+func (h *DepositHandler) GetDeposit(c *gin.Context) {
+	intent, err := h.repo.GetDepositIntent(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		if errors.Is(err, repository.ErrDepositIntentNotFound) {
+			c.JSON(http.StatusNotFound, DepositResponse{
+				Success: false,
+				Error:   "Deposit intent not found",
+			})
+			return
+		}
+		h.logger.Error("failed to get deposit intent", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, DepositResponse{
+			Success: false,
+			Error:   "Internal server error",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, DepositResponse{
+		Success: true,
+		Data:    intent,
+	})
+}
+
+// ListDeposits handles GET /api/v1/admin/payments/deposits
+// @Summary List deposit intents
+// @Description Returns deposit intents, newest first, with the gas account that funds NEXUS sweeps. Expired intents are left out unless include_expired is set or status=expired is asked for.
+// @Tags admin
+// @Produce json
+// @Param status query string false "awaiting, confirming, paid, underpaid, swept or expired"
+// @Param payer_address query string false "Payer address"
+// @Param include_expired query bool false "Include expired intents" default(false)
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(20)
+// @Success 200 {object} DepositResponse
+// @Failure 400 {object} DepositResponse
+// @Failure 401 {object} DepositResponse
+// @Router /api/v1/admin/payments/deposits [get]
+func (h *DepositHandler) ListDeposits(c *gin.Context) {
+	includeExpired, ok := queryBool(c, "include_expired", false)
+	if !ok {
+		return
+	}
+	filter := repository.DepositIntentFilter{
+		PayerAddress:   strings.ToLower(c.Query("payer_address")),
+		Status:         repository.DepositStatus(c.Query("status")),
+		IncludeExpired: includeExpired,
+	}
+	switch filter.Status {
+	case "", repository.DepositAwaiting, repository.DepositConfirming, repository.DepositPaid,
+		repository.DepositUnderpaid, repository.DepositSwept, repository.DepositExpired:
+	default:
+		c.JSON(http.StatusBadRequest, DepositResponse{
+			Success: false,
+			Error:   "Invalid status",
+		})
+		return
+	}
+	page, pageSize, ok := queryPage(c, 20)
+	if !ok {
+		return
+	}
+
+	intents, total, err := h.repo.ListDepositIntents(c.Request.Context(), filter, repository.Pagination{
+		Page:     page,
+		PageSize: pageSize,
+	})
+	if err != nil {
+		h.logger.Error("failed to list deposit intents", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, DepositResponse{
+			Success: false,
+			Error:   "Internal server error",
+		})
+		return
+	}
+	if intents == nil {
+		intents = []*repository.DepositIntent{}
+	}
+
+	data := gin.H{
+		"deposits":  intents,
+		"total":     total,
+		"page":      page,
+		"page_size": pageSize,
+	}
+	if h.service != nil {
+		data["gas_address"] = h.service.GasAddress()
+	}
+	c.JSON(http.StatusOK, DepositResponse{
+		Success: true,
+		Data:    data,
+	})
+}
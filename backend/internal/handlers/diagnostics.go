@@ -0,0 +1,187 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/pprof"
+	"runtime"
+	rpprof "runtime/pprof"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// DiagnosticsHandler exposes profiling, goroutine dumps and the log level to
+// operators debugging a running instance
+type DiagnosticsHandler struct {
+	level  zap.AtomicLevel
+	logger *zap.Logger
+}
+
+// NewDiagnosticsHandler creates a new diagnostics handler. level must be the
+// level the server's logger was built with.
+func NewDiagnosticsHandler(level zap.AtomicLevel, logger *zap.Logger) *DiagnosticsHandler {
+	return &DiagnosticsHandler{
+		level:  level,
+		logger: logger,
+	}
+}
+
+// DiagnosticsResponse wraps diagnostics API responses
+type DiagnosticsResponse struct {
+	Success bool        `json:"success"`
+	Data    interface{} `json:"data,omitempty"`
+	Error   string      `json:"error,omitempty"`
+}
+
+// LogLevel is the current log level
+type LogLevel struct {
+	Level string `json:"level"`
+}
+
+// SetLogLevelRequest changes the log level
+type SetLogLevelRequest struct {
+	Level string `json:"level" binding:"required"`
+}
+
+// RuntimeStats summarizes the Go runtime
+type RuntimeStats struct {
+	GoVersion     string `json:"go_version"`
+	NumGoroutines int    `json:"num_goroutines"`
+	NumCPU        int    `json:"num_cpu"`
+	GOMAXPROCS    int    `json:"gomaxprocs"`
+	HeapAlloc     uint64 `json:"heap_alloc_bytes"`
+	HeapInuse     uint64 `json:"heap_inuse_bytes"`
+	HeapObjects   uint64 `json:"heap_objects"`
+	NumGC         uint32 `json:"num_gc"`
+	LastGCPause   string `json:"last_gc_pause"`
+}
+
+// RegisterPprof mounts the net/http/pprof handlers under group
+// (index, cmdline, profile, symbol, trace and the named profiles)
+func (h *DiagnosticsHandler) RegisterPprof(group *gin.RouterGroup) {
+	group.GET("/", pprofHandler(pprof.Index))
+	group.GET("/cmdline", pprofHandler(pprof.Cmdline))
+	group.GET("/profile", pprofHandler(pprof.Profile))
+	group.GET("/symbol", pprofHandler(pprof.Symbol))
+	group.POST("/symbol", pprofHandler(pprof.Symbol))
+	group.GET("/trace", pprofHandler(pprof.Trace))
+	group.GET("/:profile", func(c *gin.Context) {
+		pprofHandler(pprof.Handler(c.Param("profile")).ServeHTTP)(c)
+	})
+}
+
+// pprofHandler adapts a pprof handler. CPU profiles and traces run longer
+// than the server's write timeout, which pprof refuses, so the timeout is
+// hidden from it and the write deadline lifted.
+func pprofHandler(fn http.HandlerFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := context.WithValue(c.Request.Context(), http.ServerContextKey, &http.Server{})
+		_ = http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{})
+		fn(c.Writer, c.Request.WithContext(ctx))
+	}
+}
+
+// Goroutines handles GET /api/v1/admin/debug/goroutines
+// @Summary Goroutine dump
+// @Description Returns the stack of every goroutine as plain text
+// @Tags admin
+// @Produce plain
+// @Success 200 {string} string "Goroutine stacks"
+// @Failure 401 {object} DiagnosticsResponse
+// @Router /api/v1/admin/debug/goroutines [get]
+func (h *DiagnosticsHandler) Goroutines(c *gin.Context) {
+	c.Header("Content-Type", "text/plain; charset=utf-8")
+	c.Status(http.StatusOK)
+	if err := rpprof.Lookup("goroutine").WriteTo(c.Writer, 2); err != nil {
+		h.logger.Error("failed to write goroutine dump", zap.Error(err))
+	}
+}
+
+// Runtime handles GET /api/v1/admin/debug/runtime
+// @Summary Runtime statistics
+// @Description Returns goroutine, heap and GC statistics of this instance
+// @Tags admin
+// @Produce json
+// @Success 200 {object} DiagnosticsResponse{data=RuntimeStats}
+// @Failure 401 {object} DiagnosticsResponse
+// @Router /api/v1/admin/debug/runtime [get]
+func (h *DiagnosticsHandler) Runtime(c *gin.Context) {
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+
+	c.JSON(http.StatusOK, DiagnosticsResponse{
+		Success: true,
+		Data: RuntimeStats{
+			GoVersion:     runtime.Version(),
+			NumGoroutines: runtime.NumGoroutine(),
+			NumCPU:        runtime.NumCPU(),
+			GOMAXPROCS:    runtime.GOMAXPROCS(0),
+			HeapAlloc:     m.HeapAlloc,
+			HeapInuse:     m.HeapInuse,
+			HeapObjects:   m.HeapObjects,
+			NumGC:         m.NumGC,
+			LastGCPause:   time.Duration(m.PauseNs[(m.NumGC+255)%256]).String(),
+		},
+	})
+}
+
+// GetLogLevel handles GET /api/v1/admin/debug/log-level
+// @Summary Get log level
+// @Description Returns the current log level of this instance
+// @Tags admin
+// @Produce json
+// @Success 200 {object} DiagnosticsResponse{data=LogLevel}
+// @Failure 401 {object} DiagnosticsResponse
+// @Router /api/v1/admin/debug/log-level [get]
+func (h *DiagnosticsHandler) GetLogLevel(c *gin.Context) {
+	c.JSON(http.StatusOK, DiagnosticsResponse{
+		Success: true,
+		Data:    LogLevel{Level: h.level.Level().String()},
+	})
+}
+
+// SetLogLevel handles PUT /api/v1/admin/debug/log-level
+// @Summary Set log level
+// @Description Changes the log level of this instance only (debug, info, warn or error), until it restarts or the shared settings at /api/v1/admin/logging change
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param request body SetLogLevelRequest true "New log level"
+// @Success 200 {object} DiagnosticsResponse{data=LogLevel}
+// @Failure 400 {object} DiagnosticsResponse
+// @Failure 401 {object} DiagnosticsResponse
+// @Router /api/v1/admin/debug/log-level [put]
+func (h *DiagnosticsHandler) SetLogLevel(c *gin.Context) {
+	var req SetLogLevelRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, DiagnosticsResponse{
+			Success: false,
+			Error:   "Invalid request: " + err.Error(),
+		})
+		return
+	}
+
+	level, err := zapcore.ParseLevel(req.Level)
+	if err != nil || level < zapcore.DebugLevel || level > zapcore.ErrorLevel {
+		c.JSON(http.StatusBadRequest, DiagnosticsResponse{
+			Success: false,
+			Error:   "level must be debug, info, warn or error",
+		})
+		return
+	}
+
+	previous := h.level.Level()
+	h.level.SetLevel(level)
+	h.logger.Warn("log level changed",
+		zap.String("from", previous.String()),
+		zap.String("to", level.String()),
+	)
+
+	c.JSON(http.StatusOK, DiagnosticsResponse{
+		Success: true,
+		Data:    LogLevel{Level: level.String()},
+	})
+}
@@ -0,0 +1,82 @@
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/handlers"
+)
+
+func setupDiagnosticsRouter(level zap.AtomicLevel) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	h := handlers.NewDiagnosticsHandler(level, zap.NewNop())
+	debug := router.Group("/admin/debug")
+	h.RegisterPprof(debug.Group("/pprof"))
+	debug.GET("/goroutines", h.Goroutines)
+	debug.GET("/runtime", h.Runtime)
+	debug.GET("/log-level", h.GetLogLevel)
+	debug.PUT("/log-level", h.SetLogLevel)
+	return router
+}
+
+func TestDiagnosticsHandler_LogLevel(t *testing.T) {
+	level := zap.NewAtomicLevelAt(zapcore.InfoLevel)
+	router := setupDiagnosticsRouter(level)
+
+	do := func(method, body string) *httptest.ResponseRecorder {
+		resp := httptest.NewRecorder()
+		req := httptest.NewRequest(method, "/admin/debug/log-level", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(resp, req)
+		return resp
+	}
+
+	resp := do(http.MethodGet, "")
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"level":"info"`)
+
+	resp = do(http.MethodPut, `{"level":"debug"}`)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, zapcore.DebugLevel, level.Level())
+
+	assert.Equal(t, http.StatusBadRequest, do(http.MethodPut, `{"level":"fatal"}`).Code)
+	assert.Equal(t, http.StatusBadRequest, do(http.MethodPut, `{"level":"verbose"}`).Code)
+	assert.Equal(t, http.StatusBadRequest, do(http.MethodPut, `{}`).Code)
+	assert.Equal(t, zapcore.DebugLevel, level.Level())
+}
+
+func TestDiagnosticsHandler_Dumps(t *testing.T) {
+	router := setupDiagnosticsRouter(zap.NewAtomicLevel())
+
+	get := func(path string) *httptest.ResponseRecorder {
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, path, nil))
+		return resp
+	}
+
+	resp := get("/admin/debug/goroutines")
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), "goroutine ")
+
+	resp = get("/admin/debug/runtime")
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"num_goroutines"`)
+
+	resp = get("/admin/debug/pprof/")
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), "heap")
+
+	resp = get("/admin/debug/pprof/heap?debug=1")
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), "heap profile")
+
+	assert.Equal(t, http.StatusNotFound, get("/admin/debug/pprof/nonexistent").Code)
+}
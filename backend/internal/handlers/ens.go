@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/ens"
+)
+
+// ENSHandler resolves ENS names and reverse records
+type ENSHandler struct {
+	service *ens.Service
+	logger  *zap.Logger
+}
+
+// NewENSHandler creates a new ENS handler with injected dependencies
+func NewENSHandler(service *ens.Service, logger *zap.Logger) *ENSHandler {
+	return &ENSHandler{service: service, logger: logger}
+}
+
+// ENSResponse wraps ENS API responses
+type ENSResponse struct {
+	Success bool        `json:"success"`
+	Data    interface{} `json:"data,omitempty"`
+	Error   string      `json:"error,omitempty"`
+}
+
+// Resolution is a name and the address it resolves to
+type Resolution struct {
+	Name    string `json:"name"`
+	Address string `json:"address"`
+}
+
+// Resolve handles GET /api/v1/resolve/:name
+// @Summary Resolve an ENS name
+// @Description Resolves an ENS name to its address. Given an address, returns its primary name (reverse record), provided the name resolves back to the address.
+// @Tags ens
+// @Produce json
+// @Param name path string true "ENS name (e.g. vitalik.eth) or address"
+// @Success 200 {object} ENSResponse
+// @Failure 400 {object} ENSResponse
+// @Failure 404 {object} ENSResponse
+// @Failure 503 {object} ENSResponse
+// @Router /api/v1/resolve/{name} [get]
+func (h *ENSHandler) Resolve(c *gin.Context) {
+	ctx := c.Request.Context()
+	param := strings.TrimSpace(c.Param("name"))
+
+	if isValidAddress(param) {
+		name, err := h.service.Reverse(ctx, param)
+		if err != nil {
+			h.respondError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, ENSResponse{
+			Success: true,
+			Data:    Resolution{Name: name, Address: strings.ToLower(param)},
+		})
+		return
+	}
+
+	address, err := h.service.Resolve(ctx, param)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+	name, _ := ens.Normalize(param)
+	c.JSON(http.StatusOK, ENSResponse{
+		Success: true,
+		Data:    Resolution{Name: name, Address: address},
+	})
+}
+
+// respondError maps ENS errors to responses. Resolution failures are
+// reported without the RPC error behind them.
+func (h *ENSHandler) respondError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, ens.ErrInvalidName), errors.Is(err, ens.ErrInvalidAddress):
+		c.JSON(http.StatusBadRequest, ENSResponse{Success: false, Error: err.Error()})
+	case errors.Is(err, ens.ErrNotFound):
+		c.JSON(http.StatusNotFound, ENSResponse{Success: false, Error: err.Error()})
+	case errors.Is(err, ens.ErrUnavailable):
+		h.logger.Warn("ENS resolution unavailable", zap.String("name", c.Param("name")), zap.Error(err))
+		c.JSON(http.StatusServiceUnavailable, ENSResponse{Success: false, Error: ens.ErrUnavailable.Error()})
+	default:
+		h.logger.Error("ENS request failed", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, ENSResponse{
+			Success: false,
+			Error:   "Internal server error",
+		})
+	}
+}
@@ -0,0 +1,170 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/middleware"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// EntitlementHandler serves users' prepaid service credits
+type EntitlementHandler struct {
+	repo   repository.EntitlementRepository
+	logger *zap.Logger
+}
+
+// NewEntitlementHandler creates a new entitlement handler with injected dependencies
+func NewEntitlementHandler(repo repository.EntitlementRepository, logger *zap.Logger) *EntitlementHandler {
+	return &EntitlementHandler{repo: repo, logger: logger}
+}
+
+// EntitlementResponse wraps entitlement API responses
+type EntitlementResponse struct {
+	Success bool        `json:"success"`
+	Data    interface{} `json:"data,omitempty"`
+	Error   string      `json:"error,omitempty"`
+}
+
+// EntitlementBalance is an address's credits for a service
+type EntitlementBalance struct {
+	ServiceCode string `json:"service_code"`
+	Granted     int    `json:"granted"`
+	Consumed    int    `json:"consumed"`
+	Balance     int    `json:"balance"`
+}
+
+// ConsumeCreditsRequest is the body of a consumption; Reference names what
+// the credits paid for and is only logged
+type ConsumeCreditsRequest struct {
+	Quantity  int    `json:"quantity" binding:"omitempty,min=1,max=1000"`
+	Reference string `json:"reference" binding:"max=100"`
+}
+
+func newEntitlementBalance(e *repository.Entitlement) EntitlementBalance {
+	return EntitlementBalance{ServiceCode: e.ServiceCode, Granted: e.Granted, Consumed: e.Consumed(), Balance: e.Balance}
+}
+
+// ListEntitlements handles GET /api/v1/users/:address/entitlements
+// @Summary List a user's credit balances
+// @Description Lists the address's prepaid credits per service, bought through credit packs such as kyc_verification_10
+// @Tags users
+// @Produce json
+// @Param address path string true "Wallet address"
+// @Success 200 {object} EntitlementResponse{data=[]EntitlementBalance}
+// @Failure 400 {object} EntitlementResponse
+// @Router /api/v1/users/{address}/entitlements [get]
+func (h *EntitlementHandler) ListEntitlements(c *gin.Context) {
+	address := strings.ToLower(c.Param("address"))
+	if !isValidAddress(address) {
+		c.JSON(http.StatusBadRequest, EntitlementResponse{Success: false, Error: "Invalid address"})
+		return
+	}
+
+	entitlements, err := h.repo.ListEntitlements(c.Request.Context(), address)
+	if err != nil {
+		h.logger.Error("failed to list entitlements", zap.String("address", address), zap.Error(err))
+		c.JSON(http.StatusInternalServerError, EntitlementResponse{Success: false, Error: "Internal server error"})
+		return
+	}
+
+	balances := make([]EntitlementBalance, 0, len(entitlements))
+	for _, e := range entitlements {
+		balances = append(balances, newEntitlementBalance(e))
+	}
+	c.JSON(http.StatusOK, EntitlementResponse{Success: true, Data: balances})
+}
+
+// GetEntitlement handles GET /api/v1/users/:address/entitlements/:service_code
+// @Summary Get a user's credit balance for a service
+// @Description Returns the address's prepaid credits for the service; zero when it never bought any
+// @Tags users
+// @Produce json
+// @Param address path string true "Wallet address"
+// @Param service_code path string true "Service credited, e.g. kyc_verification"
+// @Success 200 {object} EntitlementResponse{data=EntitlementBalance}
+// @Failure 400 {object} EntitlementResponse
+// @Router /api/v1/users/{address}/entitlements/{service_code} [get]
+func (h *EntitlementHandler) GetEntitlement(c *gin.Context) {
+	address := strings.ToLower(c.Param("address"))
+	if !isValidAddress(address) {
+		c.JSON(http.StatusBadRequest, EntitlementResponse{Success: false, Error: "Invalid address"})
+		return
+	}
+	serviceCode := c.Param("service_code")
+
+	e, err := h.repo.GetEntitlement(c.Request.Context(), address, serviceCode)
+	if errors.Is(err, repository.ErrEntitlementNotFound) {
+		e, err = &repository.Entitlement{Address: address, ServiceCode: serviceCode}, nil
+	}
+	if err != nil {
+		h.logger.Error("failed to get entitlement", zap.String("address", address), zap.String("service_code", serviceCode), zap.Error(err))
+		c.JSON(http.StatusInternalServerError, EntitlementResponse{Success: false, Error: "Internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, EntitlementResponse{Success: true, Data: newEntitlementBalance(e)})
+}
+
+// ConsumeCredits handles POST /api/v1/users/:address/entitlements/:service_code/consume
+// @Summary Spend prepaid credits
+// @Description Takes quantity (default 1) credits from the signed-in address's balance for the service. The balance is checked and decremented in one step, so concurrent spends cannot overdraw it.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param address path string true "Wallet address (must be the signed-in one)"
+// @Param service_code path string true "Service credited, e.g. kyc_verification"
+// @Param request body ConsumeCreditsRequest false "Credits to spend"
+// @Success 200 {object} EntitlementResponse{data=EntitlementBalance}
+// @Failure 400 {object} EntitlementResponse
+// @Failure 401 {object} EntitlementResponse
+// @Failure 402 {object} EntitlementResponse
+// @Failure 403 {object} EntitlementResponse
+// @Router /api/v1/users/{address}/entitlements/{service_code}/consume [post]
+func (h *EntitlementHandler) ConsumeCredits(c *gin.Context) {
+	address := strings.ToLower(c.Param("address"))
+	if !isValidAddress(address) {
+		c.JSON(http.StatusBadRequest, EntitlementResponse{Success: false, Error: "Invalid address"})
+		return
+	}
+	if address != middleware.SessionAddress(c) {
+		c.JSON(http.StatusForbidden, EntitlementResponse{Success: false, Error: "You can only spend your own credits"})
+		return
+	}
+
+	var req ConsumeCreditsRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, EntitlementResponse{Success: false, Error: "Invalid request: " + err.Error()})
+			return
+		}
+	}
+	if req.Quantity == 0 {
+		req.Quantity = 1
+	}
+	serviceCode := c.Param("service_code")
+
+	e, err := h.repo.ConsumeCredits(c.Request.Context(), address, serviceCode, req.Quantity)
+	if errors.Is(err, repository.ErrInsufficientCredits) {
+		c.JSON(http.StatusPaymentRequired, EntitlementResponse{Success: false, Error: "Insufficient credits for " + serviceCode})
+		return
+	}
+	if err != nil {
+		h.logger.Error("failed to consume credits", zap.String("address", address), zap.String("service_code", serviceCode), zap.Error(err))
+		c.JSON(http.StatusInternalServerError, EntitlementResponse{Success: false, Error: "Internal server error"})
+		return
+	}
+
+	h.logger.Info("credits consumed",
+		zap.String("address", address),
+		zap.String("service_code", serviceCode),
+		zap.Int("quantity", req.Quantity),
+		zap.Int("balance", e.Balance),
+		zap.String("reference", req.Reference),
+	)
+	c.JSON(http.StatusOK, EntitlementResponse{Success: true, Data: newEntitlementBalance(e)})
+}
@@ -0,0 +1,64 @@
+package handlers_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/handlers"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/middleware"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/storage/memory"
+)
+
+func TestEntitlementHandler_BalanceAndConsume(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	store := memory.NewStore()
+	const address = "0x00000000000000000000000000000000000000aa"
+	_, err := store.Entitlements.GrantCredits(context.Background(), &repository.EntitlementGrant{PaymentID: "p1", Address: address,
+		ServiceCode: "kyc_verification", Quantity: 2})
+	require.NoError(t, err)
+
+	handler := handlers.NewEntitlementHandler(store.Entitlements, zap.NewNop())
+	router := gin.New()
+	router.GET("/api/v1/users/:address/entitlements", handler.ListEntitlements)
+	router.GET("/api/v1/users/:address/entitlements/:service_code", handler.GetEntitlement)
+	router.POST("/api/v1/users/:address/entitlements/:service_code/consume", middleware.RequireSession(sessions{"alice-token": address}), handler.ConsumeCredits)
+
+	do := func(method, path, token, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		router.ServeHTTP(w, req)
+		return w
+	}
+	consume := "/api/v1/users/" + address + "/entitlements/kyc_verification/consume"
+
+	assert.Equal(t, http.StatusUnauthorized, do(http.MethodPost, consume, "", "").Code)
+	assert.Equal(t, http.StatusForbidden, do(http.MethodPost, "/api/v1/users/0x00000000000000000000000000000000000000bb/entitlements/kyc_verification/consume", "alice-token", "").Code)
+	assert.Equal(t, http.StatusBadRequest, do(http.MethodPost, consume, "alice-token", `{"quantity":-1}`).Code)
+
+	w := do(http.MethodPost, consume, "alice-token", "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Contains(t, w.Body.String(), `"balance":1`)
+	assert.Equal(t, http.StatusPaymentRequired, do(http.MethodPost, consume, "alice-token", `{"quantity":2}`).Code)
+
+	w = do(http.MethodGet, "/api/v1/users/"+address+"/entitlements/kyc_verification", "", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"granted":2,"consumed":1,"balance":1`)
+	w = do(http.MethodGet, "/api/v1/users/"+address+"/entitlements/nft_mint", "", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"balance":0`)
+	w = do(http.MethodGet, "/api/v1/users/"+address+"/entitlements", "", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"service_code":"kyc_verification"`)
+}
@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/apiversion"
+)
+
+// Handlers shared between API versions build their result once and write it
+// with respondRecord/respondList/respondError, which pick the envelope of the
+// version serving the request:
+//
+//	v1: {"success": true, "data": ...} / {"success": false, "error": "..."}
+//	    Lists are {"data": {"<key>": [...], "total", "page", "page_size", ...}}.
+//	v2: {"data": ...} / {"error": {"code": "not_found", "message": "..."}}
+//	    Lists are {"data": [...], "meta": {"total", "page", "page_size", ...}}.
+//	    Success is carried by the status code alone.
+
+// PageInfo describes one page of a list response
+type PageInfo struct {
+	Total    int64 `json:"total"`
+	Page     int   `json:"page"`
+	PageSize int   `json:"page_size"`
+}
+
+// V2Response is the v2 response envelope
+type V2Response struct {
+	Data  interface{} `json:"data,omitempty"`
+	Meta  gin.H       `json:"meta,omitempty"`
+	Error *V2Error    `json:"error,omitempty"`
+}
+
+// V2Error is a v2 error: a stable machine-readable code and a message
+type V2Error struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// v1Response is the v1 envelope, as the per-handler response types encode it
+type v1Response struct {
+	Success bool        `json:"success"`
+	Data    interface{} `json:"data,omitempty"`
+	Error   string      `json:"error,omitempty"`
+}
+
+// respondRecord writes a single record with 200
+func respondRecord(c *gin.Context, record interface{}) {
+	if apiversion.FromContext(c) >= apiversion.V2 {
+		c.JSON(http.StatusOK, V2Response{Data: record})
+		return
+	}
+	c.JSON(http.StatusOK, v1Response{Success: true, Data: record})
+}
+
+// respondList writes one page of records with 200. v1 nests the records
+// under listKey next to the pagination fields; extra fields (may be nil)
+// join the pagination fields in either version.
+func respondList(c *gin.Context, listKey string, records interface{}, page PageInfo, extra gin.H) {
+	fields := gin.H{
+		"total":     page.Total,
+		"page":      page.Page,
+		"page_size": page.PageSize,
+	}
+	for k, v := range extra {
+		fields[k] = v
+	}
+
+	if apiversion.FromContext(c) >= apiversion.V2 {
+		c.JSON(http.StatusOK, V2Response{Data: records, Meta: fields})
+		return
+	}
+	fields[listKey] = records
+	c.JSON(http.StatusOK, v1Response{Success: true, Data: fields})
+}
+
+// respondError writes an error with the given status
+func respondError(c *gin.Context, status int, message string) {
+	if apiversion.FromContext(c) >= apiversion.V2 {
+		c.JSON(status, V2Response{Error: &V2Error{Code: errorCode(status), Message: message}})
+		return
+	}
+	c.JSON(status, v1Response{Success: false, Error: message})
+}
+
+// errorCode derives a v2 error code from the status, e.g. "not_found"
+func errorCode(status int) string {
+	switch status {
+	case http.StatusInternalServerError:
+		return "internal_error"
+	case http.StatusServiceUnavailable:
+		return "unavailable"
+	}
+	text := http.StatusText(status)
+	if text == "" {
+		return "error"
+	}
+	return strings.ReplaceAll(strings.ToLower(text), " ", "_")
+}
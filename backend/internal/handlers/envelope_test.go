@@ -0,0 +1,66 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/apiversion"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/handlers"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+func setupVersionedPaymentRouter(handler *handlers.PaymentHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	versions := apiversion.NewRegistry(router)
+	for _, v := range []apiversion.Version{apiversion.V1, apiversion.V2} {
+		versions.Group(v, apiversion.Policy{}).GET("/payments/:paymentId", handler.GetPayment)
+	}
+	return router
+}
+
+func TestEnvelope_GetPaymentByVersion(t *testing.T) {
+	mockPayRepo := new(MockPaymentRepository)
+	mockPayRepo.On("GetPayment", mock.Anything, "pay-001").Return(createTestPayment(), nil)
+	mockPayRepo.On("GetPayment", mock.Anything, "pay-unknown").Return(nil, repository.ErrPaymentNotFound)
+	handler := handlers.NewPaymentHandler(mockPayRepo, new(MockPricingRepository), zap.NewNop())
+	router := setupVersionedPaymentRouter(handler)
+
+	get := func(path string) (int, map[string]interface{}) {
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, path, nil))
+		var body map[string]interface{}
+		require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &body))
+		return resp.Code, body
+	}
+
+	// v1 keeps its envelope
+	code, body := get("/api/v1/payments/pay-001")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, true, body["success"])
+	assert.Equal(t, "pay-001", body["data"].(map[string]interface{})["id"])
+
+	code, body = get("/api/v1/payments/pay-unknown")
+	assert.Equal(t, http.StatusNotFound, code)
+	assert.Equal(t, false, body["success"])
+	assert.Equal(t, "Payment not found", body["error"])
+
+	// v2 drops success and structures errors
+	code, body = get("/api/v2/payments/pay-001")
+	assert.Equal(t, http.StatusOK, code)
+	assert.NotContains(t, body, "success")
+	assert.Equal(t, "pay-001", body["data"].(map[string]interface{})["id"])
+
+	code, body = get("/api/v2/payments/pay-unknown")
+	assert.Equal(t, http.StatusNotFound, code)
+	assert.NotContains(t, body, "success")
+	assert.Equal(t, map[string]interface{}{"code": "not_found", "message": "Payment not found"}, body["error"])
+}
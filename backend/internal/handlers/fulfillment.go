@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// FulfillmentHandler serves what users' completed payments delivered
+type FulfillmentHandler struct {
+	repo   repository.FulfillmentRepository
+	logger *zap.Logger
+}
+
+// NewFulfillmentHandler creates a new fulfillment handler with injected dependencies
+func NewFulfillmentHandler(repo repository.FulfillmentRepository, logger *zap.Logger) *FulfillmentHandler {
+	return &FulfillmentHandler{repo: repo, logger: logger}
+}
+
+// FulfillmentResponse wraps fulfillment API responses
+type FulfillmentResponse struct {
+	Success bool        `json:"success"`
+	Data    interface{} `json:"data,omitempty"`
+	Error   string      `json:"error,omitempty"`
+}
+
+// ListFulfillments handles GET /api/v1/users/:address/fulfillments
+// @Summary List a user's fulfilled services
+// @Description Lists what the address's completed payments delivered (KYC verifications, NFT mint credits, API subscriptions), newest first
+// @Tags users
+// @Produce json
+// @Param address path string true "Wallet address"
+// @Param service_code query string false "Filter by service code"
+// @Param kind query string false "kyc, nft_mint_credit or api_subscription"
+// @Param active query bool false "Only those in force now (subscriptions within their period)"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(20)
+// @Success 200 {object} FulfillmentResponse
+// @Failure 400 {object} FulfillmentResponse
+// @Router /api/v1/users/{address}/fulfillments [get]
+func (h *FulfillmentHandler) ListFulfillments(c *gin.Context) {
+	address := c.Param("address")
+	if !isValidAddress(address) {
+		c.JSON(http.StatusBadRequest, FulfillmentResponse{Success: false, Error: "Invalid address"})
+		return
+	}
+
+	filter := repository.FulfillmentFilter{
+		Address:     strings.ToLower(address),
+		ServiceCode: c.Query("service_code"),
+		Kind:        repository.FulfillmentKind(c.Query("kind")),
+	}
+	switch filter.Kind {
+	case "", repository.FulfillmentKYC, repository.FulfillmentNFTMintCredit, repository.FulfillmentAPISubscription:
+	default:
+		c.JSON(http.StatusBadRequest, FulfillmentResponse{Success: false, Error: "Invalid kind: " + string(filter.Kind)})
+		return
+	}
+	if c.Query("active") == "true" {
+		now := time.Now().UTC()
+		filter.ActiveAt = &now
+	}
+
+	page, pageSize, ok := queryPage(c, 20)
+	if !ok {
+		return
+	}
+	fulfillments, total, err := h.repo.ListFulfillments(c.Request.Context(), filter, repository.Pagination{
+		Page:     page,
+		PageSize: pageSize,
+	})
+	if err != nil {
+		h.logger.Error("failed to list fulfillments", zap.String("address", filter.Address), zap.Error(err))
+		c.JSON(http.StatusInternalServerError, FulfillmentResponse{Success: false, Error: "Internal server error"})
+		return
+	}
+	if fulfillments == nil {
+		fulfillments = []*repository.Fulfillment{}
+	}
+
+	c.JSON(http.StatusOK, FulfillmentResponse{
+		Success: true,
+		Data: gin.H{
+			"fulfillments": fulfillments,
+			"total":        total,
+			"page":         page,
+			"page_size":    pageSize,
+		},
+	})
+}
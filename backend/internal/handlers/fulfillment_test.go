@@ -0,0 +1,55 @@
+package handlers_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/handlers"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/storage/memory"
+)
+
+func TestFulfillmentHandler_ListsActiveFulfillments(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctx := context.Background()
+	store := memory.NewStore()
+	const address = "0x00000000000000000000000000000000000000aa"
+
+	lapsed := time.Now().Add(-time.Hour)
+	started := lapsed.AddDate(0, -1, 0)
+	for _, f := range []*repository.Fulfillment{
+		{PaymentID: "p1", ServiceCode: "premium_monthly", Address: address, Kind: repository.FulfillmentAPISubscription, Quantity: 1, ValidFrom: &started, ValidUntil: &lapsed},
+		{PaymentID: "p2", ServiceCode: "nft_mint", Address: address, Kind: repository.FulfillmentNFTMintCredit, Quantity: 1},
+	} {
+		_, err := store.Fulfillments.RecordFulfillment(ctx, f)
+		require.NoError(t, err)
+	}
+
+	router := gin.New()
+	router.GET("/api/v1/users/:address/fulfillments", handlers.NewFulfillmentHandler(store.Fulfillments, zap.NewNop()).ListFulfillments)
+	get := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w
+	}
+
+	w := get("/api/v1/users/" + address + "/fulfillments")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"total":2`)
+
+	w = get("/api/v1/users/" + address + "/fulfillments?active=true")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"total":1`)
+	assert.Contains(t, w.Body.String(), `"kind":"nft_mint_credit"`)
+
+	assert.Equal(t, http.StatusBadRequest, get("/api/v1/users/"+address+"/fulfillments?kind=refund").Code)
+	assert.Equal(t, http.StatusBadRequest, get("/api/v1/users/0xnope/fulfillments").Code)
+}
@@ -0,0 +1,500 @@
+package handlers
+
+import (
+	"errors"
+	"math/big"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/billing"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// GasBillingHandler handles sponsored-gas billing endpoints: users' gas debt
+// and its settlement
+type GasBillingHandler struct {
+	repo      repository.GasBillingRepository
+	biller    *billing.Biller
+	logger    *zap.Logger
+	txTracker TxTracker
+}
+
+// NewGasBillingHandler creates a new gas billing handler with injected dependencies
+func NewGasBillingHandler(repo repository.GasBillingRepository, biller *billing.Biller, logger *zap.Logger) *GasBillingHandler {
+	return &GasBillingHandler{
+		repo:   repo,
+		biller: biller,
+		logger: logger,
+	}
+}
+
+// SetTxTracker enables confirmation tracking for on-chain settlements.
+// Without a tracker they stay pending until an admin settles them.
+func (h *GasBillingHandler) SetTxTracker(tracker TxTracker) {
+	h.txTracker = tracker
+}
+
+// GasBillingResponse wraps gas billing API responses
+type GasBillingResponse struct {
+	Success bool        `json:"success"`
+	Data    interface{} `json:"data,omitempty"`
+	Error   string      `json:"error,omitempty"`
+}
+
+// GasBillingSummary is a user's gas debt with the billing settings in effect
+type GasBillingSummary struct {
+	*repository.GasDebt
+	Enabled         bool   `json:"enabled"`
+	TreasuryAddress string `json:"treasury_address,omitempty"`
+}
+
+// CreateGasSettlementRequest reports a NEXUS transfer to the treasury
+type CreateGasSettlementRequest struct {
+	TxHash string `json:"tx_hash" binding:"required"`
+	Amount string `json:"amount" binding:"required"` // NEXUS base units
+}
+
+// CreateGasInvoiceRequest bills a user's gas debt off-chain
+type CreateGasInvoiceRequest struct {
+	Amount     string `json:"amount,omitempty"` // NEXUS base units; defaults to the debt not yet covered by open settlements
+	InvoiceRef string `json:"invoice_ref" binding:"required"`
+	CreatedBy  string `json:"created_by" binding:"required"`
+}
+
+// UpdateGasSettlementRequest closes a settlement
+type UpdateGasSettlementRequest struct {
+	Status repository.GasSettlementStatus `json:"status" binding:"required"` // settled or void
+}
+
+// GetGasDebt handles GET /api/v1/billing/gas/:address
+// @Summary Get gas debt
+// @Description Returns the NEXUS a user owes for relayed gas: charged, settled, pending settlement and outstanding
+// @Tags billing
+// @Produce json
+// @Param address path string true "User address"
+// @Success 200 {object} GasBillingResponse{data=GasBillingSummary}
+// @Failure 400 {object} GasBillingResponse
+// @Router /api/v1/billing/gas/{address} [get]
+func (h *GasBillingHandler) GetGasDebt(c *gin.Context) {
+	address, ok := h.address(c)
+	if !ok {
+		return
+	}
+
+	debt, err := h.repo.GetGasDebt(c.Request.Context(), address)
+	if err != nil {
+		h.logger.Error("failed to get gas debt", zap.String("address", address), zap.Error(err))
+		c.JSON(http.StatusInternalServerError, GasBillingResponse{
+			Success: false,
+			Error:   "Internal server error",
+		})
+		return
+	}
+
+	settings := h.biller.Settings(c.Request.Context())
+	c.JSON(http.StatusOK, GasBillingResponse{
+		Success: true,
+		Data: GasBillingSummary{
+			GasDebt:         debt,
+			Enabled:         settings.Enabled,
+			TreasuryAddress: settings.TreasuryAddress,
+		},
+	})
+}
+
+// ListGasCharges handles GET /api/v1/billing/gas/:address/charges
+// @Summary List gas charges
+// @Description Returns the NEXUS charged to a user per relayed meta-transaction, newest first, with the gas cost and rate applied
+// @Tags billing
+// @Produce json
+// @Param address path string true "User address"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(20)
+// @Success 200 {object} GasBillingResponse
+// @Failure 400 {object} GasBillingResponse
+// @Router /api/v1/billing/gas/{address}/charges [get]
+func (h *GasBillingHandler) ListGasCharges(c *gin.Context) {
+	address, ok := h.address(c)
+	if !ok {
+		return
+	}
+	page, ok := gasBillingPage(c, 20)
+	if !ok {
+		return
+	}
+
+	charges, total, err := h.repo.ListGasCharges(c.Request.Context(), address, page)
+	if err != nil {
+		h.logger.Error("failed to list gas charges", zap.String("address", address), zap.Error(err))
+		c.JSON(http.StatusInternalServerError, GasBillingResponse{
+			Success: false,
+			Error:   "Internal server error",
+		})
+		return
+	}
+	if charges == nil {
+		charges = []*repository.GasCharge{}
+	}
+
+	c.JSON(http.StatusOK, GasBillingResponse{
+		Success: true,
+		Data: gin.H{
+			"charges":   charges,
+			"total":     total,
+			"page":      page.Page,
+			"page_size": page.PageSize,
+		},
+	})
+}
+
+// ListGasSettlements handles GET /api/v1/billing/gas/:address/settlements
+// @Summary List gas settlements
+// @Description Returns a user's on-chain and invoiced settlements, newest first
+// @Tags billing
+// @Produce json
+// @Param address path string true "User address"
+// @Param status query string false "pending, invoiced, settled, failed or void"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(20)
+// @Success 200 {object} GasBillingResponse
+// @Failure 400 {object} GasBillingResponse
+// @Router /api/v1/billing/gas/{address}/settlements [get]
+func (h *GasBillingHandler) ListGasSettlements(c *gin.Context) {
+	address, ok := h.address(c)
+	if !ok {
+		return
+	}
+
+	filter := repository.GasSettlementFilter{
+		UserAddress: address,
+		Status:      repository.GasSettlementStatus(c.Query("status")),
+	}
+	switch filter.Status {
+	case "", repository.GasSettlementPending, repository.GasSettlementInvoiced,
+		repository.GasSettlementSettled, repository.GasSettlementFailed, repository.GasSettlementVoid:
+	default:
+		c.JSON(http.StatusBadRequest, GasBillingResponse{
+			Success: false,
+			Error:   "Invalid status",
+		})
+		return
+	}
+	page, ok := gasBillingPage(c, 20)
+	if !ok {
+		return
+	}
+
+	settlements, total, err := h.repo.ListGasSettlements(c.Request.Context(), filter, page)
+	if err != nil {
+		h.logger.Error("failed to list gas settlements", zap.String("address", address), zap.Error(err))
+		c.JSON(http.StatusInternalServerError, GasBillingResponse{
+			Success: false,
+			Error:   "Internal server error",
+		})
+		return
+	}
+	if settlements == nil {
+		settlements = []*repository.GasSettlement{}
+	}
+
+	c.JSON(http.StatusOK, GasBillingResponse{
+		Success: true,
+		Data: gin.H{
+			"settlements": settlements,
+			"total":       total,
+			"page":        page.Page,
+			"page_size":   page.PageSize,
+		},
+	})
+}
+
+// CreateGasSettlement handles POST /api/v1/billing/gas/:address/settlements
+// @Summary Settle gas debt on-chain
+// @Description Records a NEXUS transfer from the user to the treasury. The settlement stays pending until the transaction confirms and its Transfer events are verified to cover the amount.
+// @Tags billing
+// @Accept json
+// @Produce json
+// @Param address path string true "User address"
+// @Param request body CreateGasSettlementRequest true "Settlement transfer"
+// @Success 201 {object} GasBillingResponse{data=repository.GasSettlement}
+// @Failure 400 {object} GasBillingResponse
+// @Failure 409 {object} GasBillingResponse
+// @Failure 503 {object} GasBillingResponse
+// @Router /api/v1/billing/gas/{address}/settlements [post]
+func (h *GasBillingHandler) CreateGasSettlement(c *gin.Context) {
+	address, ok := h.address(c)
+	if !ok {
+		return
+	}
+
+	var req CreateGasSettlementRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, GasBillingResponse{
+			Success: false,
+			Error:   "Invalid request: " + err.Error(),
+		})
+		return
+	}
+	if !isValidTxHash(req.TxHash) {
+		c.JSON(http.StatusBadRequest, GasBillingResponse{
+			Success: false,
+			Error:   "Invalid transaction hash format",
+		})
+		return
+	}
+	amount, ok := parseNexusAmount(req.Amount)
+	if !ok {
+		c.JSON(http.StatusBadRequest, GasBillingResponse{
+			Success: false,
+			Error:   "Invalid amount, expected a positive integer of NEXUS base units",
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	settlement, err := h.biller.NewOnchainSettlement(ctx, address, req.TxHash, amount)
+	switch {
+	case errors.Is(err, repository.ErrGasSettlementExists):
+		c.JSON(http.StatusConflict, GasBillingResponse{
+			Success: false,
+			Error:   "Transaction already submitted as a settlement",
+		})
+		return
+	case errors.Is(err, billing.ErrSettlementUnavailable):
+		c.JSON(http.StatusServiceUnavailable, GasBillingResponse{
+			Success: false,
+			Error:   "On-chain settlement is not configured",
+		})
+		return
+	case err != nil:
+		h.logger.Error("failed to create gas settlement", zap.String("address", address), zap.Error(err))
+		c.JSON(http.StatusInternalServerError, GasBillingResponse{
+			Success: false,
+			Error:   "Internal server error",
+		})
+		return
+	}
+
+	if h.txTracker != nil {
+		if err := h.txTracker.Track(ctx, repository.TrackedRecordGasSettlement, settlement.ID, *settlement.TxHash); err != nil {
+			h.logger.Error("failed to track gas settlement", zap.String("id", settlement.ID), zap.Error(err))
+		}
+	}
+
+	c.JSON(http.StatusCreated, GasBillingResponse{
+		Success: true,
+		Data:    settlement,
+	})
+}
+
+// ListGasDebts handles GET /api/v1/admin/billing/gas/debts
+// @Summary List gas debts
+// @Description Returns every user with outstanding gas debt, largest first
+// @Tags admin
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(50)
+// @Success 200 {object} GasBillingResponse
+// @Failure 401 {object} GasBillingResponse
+// @Router /api/v1/admin/billing/gas/debts [get]
+func (h *GasBillingHandler) ListGasDebts(c *gin.Context) {
+	page, ok := gasBillingPage(c, 50)
+	if !ok {
+		return
+	}
+
+	debts, total, err := h.repo.ListGasDebts(c.Request.Context(), page)
+	if err != nil {
+		h.logger.Error("failed to list gas debts", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, GasBillingResponse{
+			Success: false,
+			Error:   "Internal server error",
+		})
+		return
+	}
+	if debts == nil {
+		debts = []*repository.GasDebt{}
+	}
+
+	c.JSON(http.StatusOK, GasBillingResponse{
+		Success: true,
+		Data: gin.H{
+			"debts":     debts,
+			"total":     total,
+			"page":      page.Page,
+			"page_size": page.PageSize,
+		},
+	})
+}
+
+// CreateGasInvoice handles POST /api/v1/admin/billing/gas/:address/invoices
+// @Summary Invoice gas debt
+// @Description Bills a user's gas debt off-chain. Without an amount the invoice covers the outstanding debt not already pending settlement.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param address path string true "User address"
+// @Param request body CreateGasInvoiceRequest true "Invoice"
+// @Success 201 {object} GasBillingResponse{data=repository.GasSettlement}
+// @Failure 400 {object} GasBillingResponse
+// @Failure 401 {object} GasBillingResponse
+// @Failure 409 {object} GasBillingResponse
+// @Router /api/v1/admin/billing/gas/{address}/invoices [post]
+func (h *GasBillingHandler) CreateGasInvoice(c *gin.Context) {
+	address, ok := h.address(c)
+	if !ok {
+		return
+	}
+
+	var req CreateGasInvoiceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, GasBillingResponse{
+			Success: false,
+			Error:   "Invalid request: " + err.Error(),
+		})
+		return
+	}
+
+	var amount *big.Int
+	if req.Amount != "" {
+		if amount, ok = parseNexusAmount(req.Amount); !ok {
+			c.JSON(http.StatusBadRequest, GasBillingResponse{
+				Success: false,
+				Error:   "Invalid amount, expected a positive integer of NEXUS base units",
+			})
+			return
+		}
+	}
+
+	settlement, err := h.biller.Invoice(c.Request.Context(), address, amount, req.InvoiceRef, req.CreatedBy)
+	if errors.Is(err, billing.ErrNothingDue) {
+		c.JSON(http.StatusConflict, GasBillingResponse{
+			Success: false,
+			Error:   "No gas debt due",
+		})
+		return
+	}
+	if err != nil {
+		h.logger.Error("failed to invoice gas debt", zap.String("address", address), zap.Error(err))
+		c.JSON(http.StatusInternalServerError, GasBillingResponse{
+			Success: false,
+			Error:   "Internal server error",
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, GasBillingResponse{
+		Success: true,
+		Data:    settlement,
+	})
+}
+
+// UpdateGasSettlement handles PUT /api/v1/admin/billing/gas/settlements/:id
+// @Summary Close a gas settlement
+// @Description Marks an open settlement settled (e.g. a paid invoice) or void
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "Settlement ID"
+// @Param request body UpdateGasSettlementRequest true "New status"
+// @Success 200 {object} GasBillingResponse{data=repository.GasSettlement}
+// @Failure 400 {object} GasBillingResponse
+// @Failure 401 {object} GasBillingResponse
+// @Failure 404 {object} GasBillingResponse
+// @Failure 409 {object} GasBillingResponse
+// @Router /api/v1/admin/billing/gas/settlements/{id} [put]
+func (h *GasBillingHandler) UpdateGasSettlement(c *gin.Context) {
+	id := c.Param("id")
+
+	var req UpdateGasSettlementRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, GasBillingResponse{
+			Success: false,
+			Error:   "Invalid request: " + err.Error(),
+		})
+		return
+	}
+	if req.Status != repository.GasSettlementSettled && req.Status != repository.GasSettlementVoid {
+		c.JSON(http.StatusBadRequest, GasBillingResponse{
+			Success: false,
+			Error:   "Invalid status, expected settled or void",
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	err := h.repo.UpdateGasSettlementStatus(ctx, id, &repository.GasSettlementUpdate{Status: req.Status})
+	switch {
+	case errors.Is(err, repository.ErrGasSettlementNotFound):
+		c.JSON(http.StatusNotFound, GasBillingResponse{
+			Success: false,
+			Error:   "Settlement not found",
+		})
+		return
+	case errors.Is(err, repository.ErrGasSettlementClosed):
+		c.JSON(http.StatusConflict, GasBillingResponse{
+			Success: false,
+			Error:   "Settlement is not open",
+		})
+		return
+	case err != nil:
+		h.logger.Error("failed to update gas settlement", zap.String("id", id), zap.Error(err))
+		c.JSON(http.StatusInternalServerError, GasBillingResponse{
+			Success: false,
+			Error:   "Internal server error",
+		})
+		return
+	}
+
+	settlement, err := h.repo.GetGasSettlement(ctx, id)
+	if err != nil {
+		h.logger.Error("failed to reload gas settlement", zap.String("id", id), zap.Error(err))
+		c.JSON(http.StatusInternalServerError, GasBillingResponse{
+			Success: false,
+			Error:   "Internal server error",
+		})
+		return
+	}
+
+	h.logger.Info("gas settlement closed",
+		zap.String("id", id),
+		zap.String("status", string(req.Status)),
+	)
+
+	c.JSON(http.StatusOK, GasBillingResponse{
+		Success: true,
+		Data:    settlement,
+	})
+}
+
+// address validates and normalizes the :address path parameter
+func (h *GasBillingHandler) address(c *gin.Context) (string, bool) {
+	address := c.Param("address")
+	if !isValidAddress(address) {
+		c.JSON(http.StatusBadRequest, GasBillingResponse{
+			Success: false,
+			Error:   "Invalid address format",
+		})
+		return "", false
+	}
+	return strings.ToLower(address), true
+}
+
+// gasBillingPage parses page and page_size, capping the page size at 100.
+// When ok is false a 400 has been written.
+func gasBillingPage(c *gin.Context, defaultSize int) (repository.Pagination, bool) {
+	page, pageSize, ok := queryPage(c, defaultSize)
+	return repository.Pagination{Page: page, PageSize: pageSize}, ok
+}
+
+// parseNexusAmount parses a positive decimal amount of NEXUS base units
+func parseNexusAmount(s string) (*big.Int, bool) {
+	amount, ok := new(big.Int).SetString(s, 10)
+	if !ok || amount.Sign() <= 0 {
+		return nil, false
+	}
+	return amount, true
+}
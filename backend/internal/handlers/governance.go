@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
+	"errors"
 	"math/big"
 	"net/http"
 	"sort"
@@ -12,6 +13,8 @@ import (
 	"sync"
 	"time"
 
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/activity"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/govnotify"
 	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
@@ -31,6 +34,18 @@ type GovernanceHandler struct {
 	quorumPercent     uint64        // Quorum percentage (e.g., 4 = 4%)
 	proposalThreshold *big.Int      // Minimum tokens to create proposal
 	timelockDelay     time.Duration // Timelock execution delay
+	// Proposal deposits (see governance_deposit.go)
+	payments    repository.PaymentRepository
+	pricing     repository.PricingRepository
+	votingPower VotingPowerSource
+	deposits    map[string]string // deposit paymentID -> proposalID
+	activity    *activity.Recorder
+	// Execution receipts (see governance_execution.go)
+	contracts repository.ContractRepository
+	executor  ProposalExecutor
+	executing map[string]bool // proposalID -> execution in flight
+	tallies   TallyIndex        // on-chain tallies (see governance_tally.go)
+	blocks    BlockSource       // snapshot blocks (see governance_snapshot.go)
 }
 
 // ProposalState represents the state of a proposal
@@ -45,6 +60,9 @@ const (
 	ProposalStateQueued    ProposalState = "queued"
 	ProposalStateExpired   ProposalState = "expired"
 	ProposalStateExecuted  ProposalState = "executed"
+	// ProposalStateAwaitingDeposit proposals are hidden until the proposer
+	// pays their deposit
+	ProposalStateAwaitingDeposit ProposalState = "awaiting_deposit"
 )
 
 // VoteType represents the type of vote
@@ -56,6 +74,19 @@ const (
 	VoteAbstain VoteType = 2
 )
 
+// String returns "against", "for" or "abstain"
+func (v VoteType) String() string {
+	switch v {
+	case VoteAgainst:
+		return "against"
+	case VoteFor:
+		return "for"
+	case VoteAbstain:
+		return "abstain"
+	}
+	return strconv.Itoa(int(v))
+}
+
 // Proposal represents a governance proposal
 type Proposal struct {
 	ID           string        `json:"id"`
@@ -76,6 +107,16 @@ type Proposal struct {
 	CanceledAt   *time.Time    `json:"canceled_at,omitempty"`
 	QueuedAt     *time.Time    `json:"queued_at,omitempty"`
 	Eta          *time.Time    `json:"eta,omitempty"` // Timelock execution time
+	// DepositPaymentID is the payment of the proposer's deposit, if needed
+	DepositPaymentID *string `json:"deposit_payment_id,omitempty"`
+	// Execution is the receipt of the execute transaction
+	Execution *ProposalExecution `json:"execution,omitempty"`
+	// TallySource is "chain" once the totals come from VoteCast events
+	TallySource string `json:"tally_source,omitempty"`
+	// SnapshotBlock is the block voting power is read at, recorded with its
+	// state root at creation
+	SnapshotBlock     *uint64 `json:"snapshot_block,omitempty"`
+	SnapshotStateRoot string  `json:"snapshot_state_root,omitempty"`
 }
 
 // Vote represents a vote on a proposal
@@ -96,6 +137,9 @@ type CreateProposalRequest struct {
 	Targets     []string `json:"targets" binding:"required"`
 	Values      []string `json:"values" binding:"required"`
 	Calldatas   []string `json:"calldatas" binding:"required"`
+	// DepositPaymentID is a completed governance_proposal payment by the
+	// proposer, for proposers below the proposal threshold
+	DepositPaymentID string `json:"deposit_payment_id,omitempty"`
 }
 
 // CreateProposalResponse represents a proposal creation response
@@ -112,7 +156,7 @@ type CastVoteRequest struct {
 	ProposalID string   `json:"proposal_id" binding:"required"`
 	Support    VoteType `json:"support" binding:"required"`
 	Reason     string   `json:"reason,omitempty"`
-	Weight     string   `json:"weight,omitempty"` // For demo, can be specified; in prod would be from snapshot
+	Weight     string   `json:"weight,omitempty"` // For demo, can be specified; ignored when voting power is read at the snapshot block
 }
 
 // CastVoteResponse represents a vote casting response
@@ -168,6 +212,8 @@ func NewGovernanceHandler(logger *zap.Logger, configRepo repository.GovernanceCo
 		chainID:           chainID,
 		proposals:         make(map[string]*Proposal),
 		votes:             make(map[string]map[string]*Vote),
+		deposits:          make(map[string]string),
+		executing:         make(map[string]bool),
 		votingDelay:       1 * time.Minute,       // 1 minute delay (demo-friendly)
 		votingPeriod:      10 * time.Minute,      // 10 minutes voting period (demo-friendly)
 		quorumPercent:     4,                     // 4% quorum
@@ -178,12 +224,14 @@ func NewGovernanceHandler(logger *zap.Logger, configRepo repository.GovernanceCo
 	// Load configuration from database
 	h.loadConfigFromDatabase()
 
-	// Seed demo proposals
-	h.seedDemoProposals()
-
 	return h
 }
 
+// SetActivity records votes in the voters' activity feeds
+func (h *GovernanceHandler) SetActivity(recorder *activity.Recorder) {
+	h.activity = recorder
+}
+
 // loadConfigFromDatabase loads governance parameters from the database
 func (h *GovernanceHandler) loadConfigFromDatabase() {
 	if h.configRepo == nil {
@@ -257,51 +305,6 @@ func contextWithTimeout() (context.Context, context.CancelFunc) {
 	return context.WithTimeout(context.Background(), 5*time.Second)
 }
 
-// seedDemoProposals initializes demo proposals for testing
-func (h *GovernanceHandler) seedDemoProposals() {
-	now := time.Now()
-
-	// Active proposal
-	activeProposal := &Proposal{
-		ID:           h.generateProposalID("0x0000000000000000000000000000000000000001", "Increase Staking Rewards", now.Add(-2*time.Hour)),
-		Proposer:     "0x0000000000000000000000000000000000000001",
-		Title:        "Increase Staking Rewards from 10% to 12% APY",
-		Description:  "This proposal aims to increase staking rewards to incentivize more participation in network security. The increase from 10% to 12% APY will be funded from the treasury reserve allocation.",
-		Targets:      []string{"0xStakingContract"},
-		Values:       []string{"0"},
-		Calldatas:    []string{"0x...setRewardRate(1200)"},
-		StartTime:    now.Add(-1 * time.Hour),
-		EndTime:      now.Add(6 * 24 * time.Hour),
-		State:        ProposalStateActive,
-		ForVotes:     "5000000000000000000000000",
-		AgainstVotes: "1000000000000000000000000",
-		AbstainVotes: "500000000000000000000000",
-		CreatedAt:    now.Add(-2 * time.Hour),
-	}
-	h.proposals[activeProposal.ID] = activeProposal
-	h.votes[activeProposal.ID] = make(map[string]*Vote)
-
-	// Succeeded proposal
-	succeededProposal := &Proposal{
-		ID:           h.generateProposalID("0x0000000000000000000000000000000000000002", "Treasury Allocation", now.Add(-10*24*time.Hour)),
-		Proposer:     "0x0000000000000000000000000000000000000002",
-		Title:        "Allocate 1M NXS for Developer Grants",
-		Description:  "Allocate 1,000,000 NXS tokens from the treasury to fund developer grants and ecosystem growth initiatives.",
-		Targets:      []string{"0xTreasuryContract"},
-		Values:       []string{"0"},
-		Calldatas:    []string{"0x...transfer(grants, 1000000)"},
-		StartTime:    now.Add(-9 * 24 * time.Hour),
-		EndTime:      now.Add(-2 * 24 * time.Hour),
-		State:        ProposalStateSucceeded,
-		ForVotes:     "10000000000000000000000000",
-		AgainstVotes: "2000000000000000000000000",
-		AbstainVotes: "1000000000000000000000000",
-		CreatedAt:    now.Add(-10 * 24 * time.Hour),
-	}
-	h.proposals[succeededProposal.ID] = succeededProposal
-	h.votes[succeededProposal.ID] = make(map[string]*Vote)
-}
-
 // generateProposalID generates a unique proposal ID
 func (h *GovernanceHandler) generateProposalID(proposer, title string, timestamp time.Time) string {
 	data := proposer + title + timestamp.String()
@@ -358,14 +361,53 @@ func (h *GovernanceHandler) CreateProposal(c *gin.Context) {
 	}
 
 	// In production, would verify:
-	// 1. Proposer has sufficient voting power (proposal threshold)
-	// 2. No duplicate proposals
-	// 3. Valid target addresses
+	// 1. No duplicate proposals
+	// 2. Valid target addresses
 	// For demo, we accept the proposal
 
 	now := time.Now()
 	proposer := strings.ToLower(req.Proposer)
 
+	// Voting power for the proposal is read as of the current block
+	snapshotBlock, snapshotRoot, err := h.latestSnapshot(c.Request.Context())
+	if err != nil {
+		h.logger.Error("failed to read proposal snapshot block", zap.Error(err))
+		c.JSON(http.StatusServiceUnavailable, CreateProposalResponse{
+			Success: false,
+			Message: "Failed to read the snapshot block",
+		})
+		return
+	}
+	var snapshot *big.Int
+	if snapshotBlock != nil {
+		snapshot = new(big.Int).SetUint64(*snapshotBlock)
+	}
+
+	// Proposers below the proposal threshold pay a deposit first
+	needsDeposit, err := h.depositRequired(c.Request.Context(), proposer, snapshot)
+	if err != nil {
+		h.logger.Error("failed to check proposal deposit requirement", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, CreateProposalResponse{
+			Success: false,
+			Message: "Failed to check proposal deposit requirement",
+		})
+		return
+	}
+	if needsDeposit && req.DepositPaymentID != "" {
+		if err := h.checkDeposit(c.Request.Context(), proposer, req.DepositPaymentID); err != nil {
+			status := depositStatus(err)
+			if status == http.StatusInternalServerError {
+				h.logger.Error("failed to check proposal deposit", zap.Error(err))
+				err = errors.New("failed to check deposit payment")
+			}
+			c.JSON(status, CreateProposalResponse{
+				Success: false,
+				Message: err.Error(),
+			})
+			return
+		}
+	}
+
 	proposal := &Proposal{
 		ID:           h.generateProposalID(proposer, req.Title, now),
 		Proposer:     proposer,
@@ -381,9 +423,28 @@ func (h *GovernanceHandler) CreateProposal(c *gin.Context) {
 		AgainstVotes: "0",
 		AbstainVotes: "0",
 		CreatedAt:    now,
+
+		SnapshotBlock:     snapshotBlock,
+		SnapshotStateRoot: snapshotRoot,
 	}
 
+	message := "Proposal created successfully. Voting will begin in " + h.votingDelay.String()
+
 	h.mu.Lock()
+	if needsDeposit {
+		if req.DepositPaymentID == "" {
+			proposal.State = ProposalStateAwaitingDeposit
+			message = "Proposal created. It stays hidden until the proposer pays the " + ProposalDepositServiceCode +
+				" deposit and attaches it with POST /api/v1/governance/proposals/" + proposal.ID + "/deposit"
+		} else if err := h.useDeposit(proposal, req.DepositPaymentID, now); err != nil {
+			h.mu.Unlock()
+			c.JSON(depositStatus(err), CreateProposalResponse{
+				Success: false,
+				Message: err.Error(),
+			})
+			return
+		}
+	}
 	h.proposals[proposal.ID] = proposal
 	h.votes[proposal.ID] = make(map[string]*Vote)
 	h.mu.Unlock()
@@ -392,13 +453,14 @@ func (h *GovernanceHandler) CreateProposal(c *gin.Context) {
 		zap.String("proposal_id", proposal.ID),
 		zap.String("proposer", proposer),
 		zap.String("title", req.Title),
+		zap.String("state", string(proposal.State)),
 	)
 
 	c.JSON(http.StatusOK, CreateProposalResponse{
 		Success:    true,
 		ProposalID: proposal.ID,
 		Proposal:   proposal,
-		Message:    "Proposal created successfully. Voting will begin in " + h.votingDelay.String(),
+		Message:    message,
 	})
 }
 
@@ -451,20 +513,18 @@ func (h *GovernanceHandler) GetProposal(c *gin.Context) {
 // @Success 200 {object} ProposalsListResponse
 // @Router /api/v1/governance/proposals [get]
 func (h *GovernanceHandler) ListProposals(c *gin.Context) {
-	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
-	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "10"))
-	stateFilter := c.Query("state")
-
-	if page < 1 {
-		page = 1
-	}
-	if pageSize < 1 || pageSize > 100 {
-		pageSize = 10
+	page, pageSize, ok := queryPage(c, 10)
+	if !ok {
+		return
 	}
+	stateFilter := c.Query("state")
 
 	h.mu.RLock()
 	var allProposals []*Proposal
 	for _, proposal := range h.proposals {
+		if proposal.hidden() {
+			continue
+		}
 		h.updateProposalState(proposal)
 		if stateFilter == "" || string(proposal.State) == stateFilter {
 			allProposals = append(allProposals, proposal)
@@ -546,6 +606,42 @@ func (h *GovernanceHandler) CastVote(c *gin.Context) {
 		return
 	}
 
+	// Weight the vote by the voter's power at the proposal's snapshot block
+	var snapshotPower *big.Int
+	if h.votingPower != nil {
+		h.mu.RLock()
+		proposal, exists := h.proposals[req.ProposalID]
+		var block *big.Int
+		if exists {
+			block = proposal.snapshotBlock()
+		}
+		h.mu.RUnlock()
+
+		if exists {
+			power, err := h.votingPower.VotingPower(c.Request.Context(), req.Voter, block)
+			if err != nil {
+				h.logger.Error("failed to read voting power",
+					zap.String("proposal_id", req.ProposalID),
+					zap.String("voter", req.Voter),
+					zap.Error(err),
+				)
+				c.JSON(http.StatusServiceUnavailable, CastVoteResponse{
+					Success: false,
+					Message: "Failed to read voting power at the snapshot block",
+				})
+				return
+			}
+			if power.Sign() <= 0 {
+				c.JSON(http.StatusBadRequest, CastVoteResponse{
+					Success: false,
+					Message: "Voter has no voting power at the snapshot block",
+				})
+				return
+			}
+			snapshotPower = power
+		}
+	}
+
 	h.mu.Lock()
 	defer h.mu.Unlock()
 
@@ -582,9 +678,12 @@ func (h *GovernanceHandler) CastVote(c *gin.Context) {
 		return
 	}
 
-	// Determine vote weight (in production, would be from snapshot)
+	// Determine vote weight: the snapshot voting power, or for demo the
+	// requested weight
 	weight := req.Weight
-	if weight == "" {
+	if snapshotPower != nil {
+		weight = snapshotPower.String()
+	} else if weight == "" {
 		weight = "1000000000000000000000" // Default 1000 tokens for demo
 	}
 
@@ -609,15 +708,17 @@ func (h *GovernanceHandler) CastVote(c *gin.Context) {
 	}
 	h.votes[req.ProposalID][voter] = vote
 
-	// Update vote totals
-	switch req.Support {
-	case VoteFor:
+	// Update vote totals, unless they are tallied from chain events
+	switch {
+	case proposal.TallySource == TallySourceChain:
+		// The vote counts once its VoteCast event is indexed
+	case req.Support == VoteFor:
 		forVotes, _ := new(big.Int).SetString(proposal.ForVotes, 10)
 		proposal.ForVotes = new(big.Int).Add(forVotes, weightInt).String()
-	case VoteAgainst:
+	case req.Support == VoteAgainst:
 		againstVotes, _ := new(big.Int).SetString(proposal.AgainstVotes, 10)
 		proposal.AgainstVotes = new(big.Int).Add(againstVotes, weightInt).String()
-	case VoteAbstain:
+	case req.Support == VoteAbstain:
 		abstainVotes, _ := new(big.Int).SetString(proposal.AbstainVotes, 10)
 		proposal.AbstainVotes = new(big.Int).Add(abstainVotes, weightInt).String()
 	}
@@ -629,6 +730,16 @@ func (h *GovernanceHandler) CastVote(c *gin.Context) {
 		zap.String("weight", weight),
 	)
 
+	if h.activity != nil {
+		h.activity.Record(c.Request.Context(), &repository.ActivityEvent{
+			Address:   voter,
+			Kind:      repository.ActivityVote,
+			Action:    req.Support.String(),
+			SubjectID: req.ProposalID,
+			Summary:   "Voted " + req.Support.String() + " on " + proposal.Title,
+		})
+	}
+
 	txID := generateMockTxID()
 
 	c.JSON(http.StatusOK, CastVoteResponse{
@@ -683,12 +794,15 @@ func (h *GovernanceHandler) GetVotes(c *gin.Context) {
 
 // GetVotingPower handles GET /api/v1/governance/voting-power/:address
 // @Summary Get voting power for an address
-// @Description Returns the voting power for an address at the current block
+// @Description Returns the voting power for an address at the current block, or at a proposal's snapshot block
 // @Tags governance
 // @Produce json
 // @Param address path string true "Ethereum address"
+// @Param proposal_id query string false "Read the voting power at this proposal's snapshot block"
 // @Success 200 {object} map[string]interface{}
 // @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 503 {object} map[string]interface{}
 // @Router /api/v1/governance/voting-power/{address} [get]
 func (h *GovernanceHandler) GetVotingPower(c *gin.Context) {
 	address := c.Param("address")
@@ -701,18 +815,50 @@ func (h *GovernanceHandler) GetVotingPower(c *gin.Context) {
 		return
 	}
 
-	// In production, would query voting power from snapshot
-	// For demo, return mock voting power
+	var block *big.Int
+	if proposalID := c.Query("proposal_id"); proposalID != "" {
+		h.mu.RLock()
+		proposal, exists := h.proposals[proposalID]
+		if exists {
+			block = proposal.snapshotBlock()
+		}
+		h.mu.RUnlock()
+		if !exists {
+			c.JSON(http.StatusNotFound, gin.H{
+				"success": false,
+				"message": "Proposal not found",
+			})
+			return
+		}
+	}
+
+	// Without a voting power source, return mock voting power for demo
 	address = strings.ToLower(address)
 	votingPower := "1000000000000000000000" // 1000 tokens for demo
+	if h.votingPower != nil {
+		power, err := h.votingPower.VotingPower(c.Request.Context(), address, block)
+		if err != nil {
+			h.logger.Error("failed to read voting power", zap.String("address", address), zap.Error(err))
+			c.JSON(http.StatusServiceUnavailable, gin.H{
+				"success": false,
+				"message": "Failed to read voting power",
+			})
+			return
+		}
+		votingPower = power.String()
+	}
 
-	c.JSON(http.StatusOK, gin.H{
+	resp := gin.H{
 		"success":      true,
 		"address":      address,
 		"voting_power": votingPower,
 		"delegated_to": address, // Self-delegated by default
 		"timestamp":    time.Now().UTC().Format(time.RFC3339),
-	})
+	}
+	if block != nil {
+		resp["block"] = block.Uint64()
+	}
+	c.JSON(http.StatusOK, resp)
 }
 
 // GetGovernanceParams handles GET /api/v1/governance/params
@@ -788,22 +934,23 @@ func (h *GovernanceHandler) QueueProposal(c *gin.Context) {
 
 // ExecuteProposal handles POST /api/v1/governance/proposals/:id/execute
 // @Summary Execute a queued proposal
-// @Description Executes a queued proposal after timelock delay has passed
+// @Description Executes a queued proposal after timelock delay has passed and records the receipt: the transaction hash and each action decoded with its target's stored ABI
 // @Tags governance
 // @Produce json
 // @Param id path string true "Proposal ID"
 // @Success 200 {object} ProposalResponse
 // @Failure 400 {object} ProposalResponse
 // @Failure 404 {object} ProposalResponse
+// @Failure 409 {object} ProposalResponse
+// @Failure 502 {object} ProposalResponse
 // @Router /api/v1/governance/proposals/{id}/execute [post]
 func (h *GovernanceHandler) ExecuteProposal(c *gin.Context) {
 	proposalID := c.Param("id")
 
 	h.mu.Lock()
-	defer h.mu.Unlock()
-
 	proposal, exists := h.proposals[proposalID]
 	if !exists {
+		h.mu.Unlock()
 		c.JSON(http.StatusNotFound, ProposalResponse{
 			Success: false,
 			Message: "Proposal not found",
@@ -812,6 +959,7 @@ func (h *GovernanceHandler) ExecuteProposal(c *gin.Context) {
 	}
 
 	if proposal.State != ProposalStateQueued {
+		h.mu.Unlock()
 		c.JSON(http.StatusBadRequest, ProposalResponse{
 			Success: false,
 			Message: "Only queued proposals can be executed. Current state: " + string(proposal.State),
@@ -822,6 +970,7 @@ func (h *GovernanceHandler) ExecuteProposal(c *gin.Context) {
 	// Check timelock has passed
 	now := time.Now()
 	if proposal.Eta != nil && now.Before(*proposal.Eta) {
+		h.mu.Unlock()
 		c.JSON(http.StatusBadRequest, ProposalResponse{
 			Success: false,
 			Message: "Timelock delay has not passed. Wait until: " + proposal.Eta.Format(time.RFC3339),
@@ -829,12 +978,45 @@ func (h *GovernanceHandler) ExecuteProposal(c *gin.Context) {
 		return
 	}
 
-	// In production, would execute the proposal actions on-chain
+	if h.executing[proposalID] {
+		h.mu.Unlock()
+		c.JSON(http.StatusConflict, ProposalResponse{
+			Success: false,
+			Message: "Proposal execution is already in progress",
+		})
+		return
+	}
+	h.executing[proposalID] = true
+	snapshot := *proposal
+	h.mu.Unlock()
+
+	// The transaction and ABI lookups run without holding the lock
+	execution, err := h.execute(c.Request.Context(), snapshot, now)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.executing, proposalID)
+
+	if err != nil {
+		h.logger.Error("proposal execution failed",
+			zap.String("proposal_id", proposalID),
+			zap.Error(err),
+		)
+		c.JSON(http.StatusBadGateway, ProposalResponse{
+			Success: false,
+			Message: "Proposal execution failed: " + err.Error(),
+		})
+		return
+	}
+
 	proposal.State = ProposalStateExecuted
 	proposal.ExecutedAt = &now
+	proposal.Execution = execution
 
 	h.logger.Info("proposal executed",
 		zap.String("proposal_id", proposalID),
+		zap.String("tx_hash", execution.TxHash),
+		zap.Bool("simulated", execution.Simulated),
 	)
 
 	c.JSON(http.StatusOK, ProposalResponse{
@@ -932,8 +1114,10 @@ func (h *GovernanceHandler) CancelProposal(c *gin.Context) {
 func (h *GovernanceHandler) updateProposalState(proposal *Proposal) {
 	now := time.Now()
 
-	// Skip if already in terminal state
+	// Skip if already in terminal state or not yet open
 	switch proposal.State {
+	case ProposalStateAwaitingDeposit:
+		return
 	case ProposalStateCanceled, ProposalStateDefeated, ProposalStateExecuted, ProposalStateExpired:
 		return
 	case ProposalStateQueued:
@@ -959,15 +1143,8 @@ func (h *GovernanceHandler) updateProposalState(proposal *Proposal) {
 		// Calculate quorum (simplified - in production would check against total supply snapshot)
 		forVotes, _ := new(big.Int).SetString(proposal.ForVotes, 10)
 		againstVotes, _ := new(big.Int).SetString(proposal.AgainstVotes, 10)
-		abstainVotes, _ := new(big.Int).SetString(proposal.AbstainVotes, 10)
 
-		totalVotes := new(big.Int).Add(forVotes, againstVotes)
-		totalVotes.Add(totalVotes, abstainVotes)
-
-		// Simplified quorum check (4% of 100M tokens)
-		quorum, _ := new(big.Int).SetString("4000000000000000000000000", 10) // 4M tokens
-
-		if totalVotes.Cmp(quorum) < 0 {
+		if quorumVotes(proposal).Cmp(proposalQuorum()) < 0 {
 			proposal.State = ProposalStateDefeated
 		} else if forVotes.Cmp(againstVotes) > 0 {
 			proposal.State = ProposalStateSucceeded
@@ -977,6 +1154,118 @@ func (h *GovernanceHandler) updateProposalState(proposal *Proposal) {
 	}
 }
 
+// hidden reports whether a proposal is left out of listings because its
+// deposit is unpaid
+func (p *Proposal) hidden() bool {
+	return p.State == ProposalStateAwaitingDeposit
+}
+
+// quorumVotes returns the votes counted toward a proposal's quorum: for,
+// against and abstain
+func quorumVotes(proposal *Proposal) *big.Int {
+	total := new(big.Int)
+	for _, votes := range []string{proposal.ForVotes, proposal.AgainstVotes, proposal.AbstainVotes} {
+		if v, ok := new(big.Int).SetString(votes, 10); ok {
+			total.Add(total, v)
+		}
+	}
+	return total
+}
+
+// proposalQuorum returns the votes a proposal needs to pass. Simplified:
+// 4% of 100M tokens rather than of the total supply snapshot.
+func proposalQuorum() *big.Int {
+	quorum, _ := new(big.Int).SetString("4000000000000000000000000", 10) // 4M tokens
+	return quorum
+}
+
+// votingSupply returns the token supply participation is measured against.
+// Simplified like the quorum: 100M tokens.
+func votingSupply() *big.Int {
+	supply, _ := new(big.Int).SetString("100000000000000000000000000", 10) // 100M tokens
+	return supply
+}
+
+// ProposalPassed reports whether a proposal exists and has passed its vote
+// (succeeded, queued or executed). Grant payouts are gated on it.
+func (h *GovernanceHandler) ProposalPassed(_ context.Context, id string) (bool, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	proposal, exists := h.proposals[id]
+	if !exists {
+		return false, nil
+	}
+	h.updateProposalState(proposal)
+
+	switch proposal.State {
+	case ProposalStateSucceeded, ProposalStateQueued, ProposalStateExecuted:
+		return true, nil
+	}
+	return false, nil
+}
+
+// ProposalDescriptions returns every visible proposal's description by proposal ID.
+// Snapshot proposals linked from a description are linked to its proposal.
+func (h *GovernanceHandler) ProposalDescriptions() map[string]string {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	descriptions := make(map[string]string, len(h.proposals))
+	for id, proposal := range h.proposals {
+		if !proposal.hidden() {
+			descriptions[id] = proposal.Description
+		}
+	}
+	return descriptions
+}
+
+// LifecycleProposals returns every proposal with its state brought up to
+// date, for lifecycle notifications
+func (h *GovernanceHandler) LifecycleProposals() []govnotify.Proposal {
+	proposals := h.proposalCopies()
+	out := make([]govnotify.Proposal, 0, len(proposals))
+	for i := range proposals {
+		p := &proposals[i]
+		out = append(out, govnotify.Proposal{
+			ID:      p.ID,
+			Title:   p.Title,
+			State:   string(p.State),
+			Votes:   quorumVotes(p),
+			Quorum:  proposalQuorum(),
+			EndTime: p.EndTime,
+			Eta:     p.Eta,
+		})
+	}
+	return out
+}
+
+// proposalCopies returns a copy of every visible proposal with its state brought up
+// to date
+func (h *GovernanceHandler) proposalCopies() []Proposal {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	proposals := make([]Proposal, 0, len(h.proposals))
+	for _, proposal := range h.proposals {
+		if proposal.hidden() {
+			continue
+		}
+		h.updateProposalState(proposal)
+		proposals = append(proposals, *proposal)
+	}
+	return proposals
+}
+
+// hasProposal reports whether a visible on-chain proposal exists
+func (h *GovernanceHandler) hasProposal(id string) bool {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	proposal, exists := h.proposals[id]
+	return exists && !proposal.hidden()
+}
+
 // DelegateRequest represents a delegation request
 type DelegateRequest struct {
 	From string `json:"from" binding:"required"`
@@ -1281,9 +1570,9 @@ func (h *GovernanceHandler) GetGovernanceConfigHistory(c *gin.Context) {
 	}
 
 	configKey := c.Param("key")
-	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
-	if limit < 1 || limit > 100 {
-		limit = 10
+	limit, ok := queryLimit(c, "limit", 10, 100)
+	if !ok {
+		return
 	}
 
 	ctx, cancel := contextWithTimeout()
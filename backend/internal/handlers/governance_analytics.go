@@ -0,0 +1,346 @@
+package handlers
+
+import (
+	"math/big"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Turnout trend intervals
+const (
+	TurnoutIntervalWeek  = "week"
+	TurnoutIntervalMonth = "month"
+)
+
+// weightBucketCount is the number of vote weight histogram buckets: powers
+// of ten of whole tokens from under 1 to 1M and more
+const weightBucketCount = 8
+
+// GovernanceAnalyticsResponse wraps the voting analytics
+type GovernanceAnalyticsResponse struct {
+	Success   bool                 `json:"success"`
+	Analytics *GovernanceAnalytics `json:"analytics,omitempty"`
+	Message   string               `json:"message,omitempty"`
+}
+
+// GovernanceAnalytics summarizes voting for the DAO dashboard. Vote weights
+// are in wei; rates are percentages.
+type GovernanceAnalytics struct {
+	Proposals    []ProposalParticipation `json:"proposals"` // newest first
+	Turnout      []TurnoutPeriod         `json:"turnout"`   // oldest first
+	TopVoters    []TopVoter              `json:"top_voters"`
+	Distribution VoteDistribution        `json:"distribution"`
+	TotalVotes   int                     `json:"total_votes"`
+	UniqueVoters int                     `json:"unique_voters"`
+	VotingSupply string                  `json:"voting_supply"` // participation rates are relative to this
+	GeneratedAt  time.Time               `json:"generated_at"`
+}
+
+// ProposalParticipation is one proposal's turnout and result split
+type ProposalParticipation struct {
+	ProposalID        string        `json:"proposal_id"`
+	Title             string        `json:"title"`
+	State             ProposalState `json:"state"`
+	StartTime         time.Time     `json:"start_time"`
+	Voters            int           `json:"voters"`
+	VotesCast         string        `json:"votes_cast"`
+	ParticipationRate float64       `json:"participation_rate"` // votes cast of the voting supply
+	QuorumProgress    float64       `json:"quorum_progress"`    // votes cast of the quorum
+	ForPercent        float64       `json:"for_percent"`
+	AgainstPercent    float64       `json:"against_percent"`
+	AbstainPercent    float64       `json:"abstain_percent"`
+}
+
+// TurnoutPeriod is the turnout of the proposals that started in one week or
+// month
+type TurnoutPeriod struct {
+	PeriodStart              time.Time `json:"period_start"`
+	Proposals                int       `json:"proposals"`
+	Votes                    int       `json:"votes"`
+	UniqueVoters             int       `json:"unique_voters"`
+	VotesCast                string    `json:"votes_cast"`
+	AverageParticipationRate float64   `json:"average_participation_rate"`
+}
+
+// TopVoter is a voter ranked by the total weight they voted with
+type TopVoter struct {
+	Address      string `json:"address"`
+	Votes        int    `json:"votes"`
+	Weight       string `json:"weight"`
+	ForVotes     int    `json:"for_votes"`
+	AgainstVotes int    `json:"against_votes"`
+	AbstainVotes int    `json:"abstain_votes"`
+}
+
+// VoteDistribution buckets the votes by support and by weight
+type VoteDistribution struct {
+	Support []SupportBucket `json:"support"`
+	Weight  []WeightBucket  `json:"weight"`
+}
+
+// SupportBucket counts the votes cast with one support value
+type SupportBucket struct {
+	Support VoteType `json:"support"`
+	Label   string   `json:"label"`
+	Votes   int      `json:"votes"`
+	Weight  string   `json:"weight"`
+}
+
+// WeightBucket counts the votes whose weight, in whole tokens, is at least
+// MinTokens and below MaxTokens (unbounded when MaxTokens is omitted)
+type WeightBucket struct {
+	MinTokens int64  `json:"min_tokens"`
+	MaxTokens *int64 `json:"max_tokens,omitempty"`
+	Votes     int    `json:"votes"`
+}
+
+// GetAnalytics handles GET /api/v1/governance/analytics
+// @Summary Get voting analytics
+// @Description Returns participation per proposal, turnout trends, top voters and vote distribution histograms computed from the recorded votes. since and until (RFC3339) filter proposals by start time.
+// @Tags governance
+// @Produce json
+// @Param since query string false "Proposals starting at or after (RFC3339)"
+// @Param until query string false "Proposals starting before (RFC3339)"
+// @Param interval query string false "Turnout trend interval: week or month" default(month)
+// @Param top query int false "Number of top voters (max 100)" default(10)
+// @Success 200 {object} GovernanceAnalyticsResponse
+// @Failure 400 {object} GovernanceAnalyticsResponse
+// @Router /api/v1/governance/analytics [get]
+func (h *GovernanceHandler) GetAnalytics(c *gin.Context) {
+	since, err := parseOptionalTime(c.Query("since"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, GovernanceAnalyticsResponse{Success: false, Message: "Invalid since: must be RFC3339"})
+		return
+	}
+	until, err := parseOptionalTime(c.Query("until"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, GovernanceAnalyticsResponse{Success: false, Message: "Invalid until: must be RFC3339"})
+		return
+	}
+	interval := c.DefaultQuery("interval", TurnoutIntervalMonth)
+	if interval != TurnoutIntervalWeek && interval != TurnoutIntervalMonth {
+		c.JSON(http.StatusBadRequest, GovernanceAnalyticsResponse{Success: false, Message: "Invalid interval: must be week or month"})
+		return
+	}
+	top, ok := queryLimit(c, "top", 10, 100)
+	if !ok {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	var proposals []*Proposal
+	for _, proposal := range h.proposals {
+		if proposal.hidden() {
+			continue
+		}
+		if since != nil && proposal.StartTime.Before(*since) {
+			continue
+		}
+		if until != nil && !proposal.StartTime.Before(*until) {
+			continue
+		}
+		h.updateProposalState(proposal)
+		proposals = append(proposals, proposal)
+	}
+	sort.Slice(proposals, func(i, j int) bool {
+		return proposals[i].StartTime.After(proposals[j].StartTime)
+	})
+
+	c.JSON(http.StatusOK, GovernanceAnalyticsResponse{
+		Success:   true,
+		Analytics: h.analytics(proposals, interval, top),
+	})
+}
+
+// analytics computes the analytics of proposals (newest first) and their
+// votes. The caller holds h.mu.
+func (h *GovernanceHandler) analytics(proposals []*Proposal, interval string, top int) *GovernanceAnalytics {
+	supply := votingSupply()
+	quorum := proposalQuorum()
+
+	supportBuckets := []SupportBucket{
+		{Support: VoteFor, Label: "for"},
+		{Support: VoteAgainst, Label: "against"},
+		{Support: VoteAbstain, Label: "abstain"},
+	}
+	supportWeights := []*big.Int{new(big.Int), new(big.Int), new(big.Int)}
+	weightBuckets := newWeightBuckets()
+
+	voters := make(map[string]*TopVoter)
+	voterWeights := make(map[string]*big.Int)
+	periods := make(map[time.Time]*turnoutAccumulator)
+
+	out := &GovernanceAnalytics{
+		Proposals:    make([]ProposalParticipation, 0, len(proposals)),
+		VotingSupply: supply.String(),
+		GeneratedAt:  time.Now().UTC(),
+	}
+
+	for _, proposal := range proposals {
+		votes := h.votes[proposal.ID]
+		cast := quorumVotes(proposal)
+		forVotes, _ := new(big.Int).SetString(proposal.ForVotes, 10)
+		againstVotes, _ := new(big.Int).SetString(proposal.AgainstVotes, 10)
+		abstainVotes, _ := new(big.Int).SetString(proposal.AbstainVotes, 10)
+
+		participation := percentOf(cast, supply)
+		out.Proposals = append(out.Proposals, ProposalParticipation{
+			ProposalID:        proposal.ID,
+			Title:             proposal.Title,
+			State:             proposal.State,
+			StartTime:         proposal.StartTime,
+			Voters:            len(votes),
+			VotesCast:         cast.String(),
+			ParticipationRate: participation,
+			QuorumProgress:    percentOf(cast, quorum),
+			ForPercent:        percentOf(forVotes, cast),
+			AgainstPercent:    percentOf(againstVotes, cast),
+			AbstainPercent:    percentOf(abstainVotes, cast),
+		})
+
+		start := periodStart(proposal.StartTime, interval)
+		period, ok := periods[start]
+		if !ok {
+			period = &turnoutAccumulator{voters: make(map[string]bool), cast: new(big.Int)}
+			periods[start] = period
+		}
+		period.proposals++
+		period.participation += participation
+		period.cast.Add(period.cast, cast)
+
+		for voter, vote := range votes {
+			weight, ok := new(big.Int).SetString(vote.Weight, 10)
+			if !ok {
+				continue
+			}
+			out.TotalVotes++
+			period.votes++
+			period.voters[voter] = true
+
+			if int(vote.Support) < len(supportBuckets) {
+				supportBuckets[vote.Support].Votes++
+				supportWeights[vote.Support].Add(supportWeights[vote.Support], weight)
+			}
+			weightBuckets[weightBucket(weight)].Votes++
+
+			v, ok := voters[voter]
+			if !ok {
+				v = &TopVoter{Address: voter}
+				voters[voter] = v
+				voterWeights[voter] = new(big.Int)
+			}
+			v.Votes++
+			voterWeights[voter].Add(voterWeights[voter], weight)
+			switch vote.Support {
+			case VoteFor:
+				v.ForVotes++
+			case VoteAgainst:
+				v.AgainstVotes++
+			case VoteAbstain:
+				v.AbstainVotes++
+			}
+		}
+	}
+
+	for i := range supportBuckets {
+		supportBuckets[i].Weight = supportWeights[i].String()
+	}
+	out.Distribution = VoteDistribution{Support: supportBuckets, Weight: weightBuckets}
+	out.UniqueVoters = len(voters)
+
+	out.TopVoters = make([]TopVoter, 0, len(voters))
+	for address, v := range voters {
+		v.Weight = voterWeights[address].String()
+		out.TopVoters = append(out.TopVoters, *v)
+	}
+	sort.Slice(out.TopVoters, func(i, j int) bool {
+		a, b := voterWeights[out.TopVoters[i].Address], voterWeights[out.TopVoters[j].Address]
+		if cmp := a.Cmp(b); cmp != 0 {
+			return cmp > 0
+		}
+		return out.TopVoters[i].Address < out.TopVoters[j].Address
+	})
+	if len(out.TopVoters) > top {
+		out.TopVoters = out.TopVoters[:top]
+	}
+
+	out.Turnout = make([]TurnoutPeriod, 0, len(periods))
+	for start, period := range periods {
+		out.Turnout = append(out.Turnout, TurnoutPeriod{
+			PeriodStart:              start,
+			Proposals:                period.proposals,
+			Votes:                    period.votes,
+			UniqueVoters:             len(period.voters),
+			VotesCast:                period.cast.String(),
+			AverageParticipationRate: period.participation / float64(period.proposals),
+		})
+	}
+	sort.Slice(out.Turnout, func(i, j int) bool {
+		return out.Turnout[i].PeriodStart.Before(out.Turnout[j].PeriodStart)
+	})
+
+	return out
+}
+
+// turnoutAccumulator collects one turnout period
+type turnoutAccumulator struct {
+	proposals     int
+	votes         int
+	voters        map[string]bool
+	cast          *big.Int
+	participation float64 // sum of the proposals' participation rates
+}
+
+// periodStart returns the start (UTC) of the week (from Monday) or month t
+// falls in
+func periodStart(t time.Time, interval string) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	if interval == TurnoutIntervalWeek {
+		offset := (int(day.Weekday()) + 6) % 7 // days since Monday
+		return day.AddDate(0, 0, -offset)
+	}
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// newWeightBuckets returns the empty vote weight histogram
+func newWeightBuckets() []WeightBucket {
+	buckets := make([]WeightBucket, weightBucketCount)
+	lower, upper := int64(0), int64(1)
+	for i := range buckets {
+		buckets[i].MinTokens = lower
+		if i < weightBucketCount-1 {
+			max := upper
+			buckets[i].MaxTokens = &max
+		}
+		lower, upper = upper, upper*10
+	}
+	return buckets
+}
+
+// weightBucket returns the histogram bucket of a weight in wei
+func weightBucket(weight *big.Int) int {
+	tokens := new(big.Int).Quo(weight, big.NewInt(1e18))
+	if tokens.Sign() == 0 {
+		return 0
+	}
+	bucket := len(tokens.String()) // 1-9 tokens -> 1, 10-99 -> 2, ...
+	if bucket >= weightBucketCount {
+		bucket = weightBucketCount - 1
+	}
+	return bucket
+}
+
+// percentOf returns part as a percentage of whole (0 when whole is zero)
+func percentOf(part, whole *big.Int) float64 {
+	if part == nil || whole == nil || whole.Sign() == 0 {
+		return 0
+	}
+	pct, _ := new(big.Rat).SetFrac(new(big.Int).Mul(part, big.NewInt(100)), whole).Float64()
+	return pct
+}
@@ -0,0 +1,100 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/handlers"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/storage/memory"
+)
+
+func TestGovernanceHandler_Analytics(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	store := memory.NewStore()
+	governance := handlers.NewGovernanceHandler(zap.NewNop(), store.GovernanceConfig, 31337)
+
+	now := time.Now().UTC()
+	governance.Seed([]*handlers.Proposal{
+		{ID: "p1", Title: "Fund grants", State: handlers.ProposalStateActive, ForVotes: "0", AgainstVotes: "0", AbstainVotes: "0",
+			StartTime: now.Add(-40 * 24 * time.Hour), EndTime: now.Add(time.Hour), CreatedAt: now.Add(-41 * 24 * time.Hour)},
+		{ID: "p2", Title: "Raise fees", State: handlers.ProposalStateActive, ForVotes: "0", AgainstVotes: "0", AbstainVotes: "0",
+			StartTime: now.Add(-time.Hour), EndTime: now.Add(time.Hour), CreatedAt: now.Add(-2 * time.Hour)},
+	})
+
+	router := gin.New()
+	router.POST("/api/v1/governance/vote", governance.CastVote)
+	router.GET("/api/v1/governance/analytics", governance.GetAnalytics)
+
+	vote := func(voter int, proposal string, support int, tokens string) {
+		body := fmt.Sprintf(`{"voter":"0x%040x","proposal_id":%q,"support":%d,"weight":"%s000000000000000000"}`, voter, proposal, support, tokens)
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/governance/vote", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	}
+	vote(1, "p1", 1, "3000000") // 3M tokens
+	vote(2, "p1", 2, "1000000")
+	vote(1, "p2", 1, "500")
+	vote(3, "p2", 1, "5")
+
+	analytics := func(query string) *handlers.GovernanceAnalytics {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/governance/analytics"+query, nil))
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var resp handlers.GovernanceAnalyticsResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		require.NotNil(t, resp.Analytics)
+		return resp.Analytics
+	}
+
+	a := analytics("?top=2")
+	assert.Equal(t, 4, a.TotalVotes)
+	assert.Equal(t, 3, a.UniqueVoters)
+
+	require.Len(t, a.Proposals, 2)
+	assert.Equal(t, "p2", a.Proposals[0].ProposalID, "newest first")
+	p1 := a.Proposals[1]
+	assert.Equal(t, 2, p1.Voters)
+	assert.InDelta(t, 4.0, p1.ParticipationRate, 1e-9, "4M of 100M tokens")
+	assert.InDelta(t, 100.0, p1.QuorumProgress, 1e-9)
+	assert.InDelta(t, 75.0, p1.ForPercent, 1e-9)
+	assert.InDelta(t, 25.0, p1.AbstainPercent, 1e-9)
+
+	require.Len(t, a.TopVoters, 2)
+	assert.Equal(t, fmt.Sprintf("0x%040x", 1), a.TopVoters[0].Address)
+	assert.Equal(t, 2, a.TopVoters[0].Votes)
+	assert.Equal(t, "3000500000000000000000000", a.TopVoters[0].Weight)
+
+	require.Len(t, a.Turnout, 2, "the proposals started in different months")
+	assert.True(t, a.Turnout[0].PeriodStart.Before(a.Turnout[1].PeriodStart))
+
+	require.Len(t, a.Distribution.Support, 3)
+	assert.Equal(t, 3, a.Distribution.Support[1].Votes, "for")
+	assert.Equal(t, 1, a.Distribution.Support[2].Votes, "abstain")
+	counts := map[int64]int{}
+	for _, b := range a.Distribution.Weight {
+		counts[b.MinTokens] = b.Votes
+	}
+	assert.Equal(t, map[int64]int{0: 0, 1: 1, 10: 0, 100: 1, 1000: 0, 10000: 0, 100000: 0, 1000000: 2}, counts)
+
+	recent := analytics("?since=" + now.Add(-24*time.Hour).Format(time.RFC3339) + "&interval=week")
+	require.Len(t, recent.Proposals, 1)
+	assert.Equal(t, 2, recent.TotalVotes)
+	require.Len(t, recent.Turnout, 1)
+	assert.Equal(t, time.Monday, recent.Turnout[0].PeriodStart.Weekday())
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/governance/analytics?interval=day", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
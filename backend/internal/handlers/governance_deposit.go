@@ -0,0 +1,222 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// ProposalDepositServiceCode is the pricing service code proposal deposits
+// are paid for through the payments module
+const ProposalDepositServiceCode = "governance_proposal"
+
+// Proposal deposit errors
+var (
+	errDepositNotFound   = errors.New("deposit payment not found")
+	errDepositNotDeposit = errors.New("deposit payment is not for service " + ProposalDepositServiceCode)
+	errDepositPayer      = errors.New("deposit payment was made by a different address than the proposer")
+	errDepositIncomplete = errors.New("deposit payment has not completed")
+	errDepositUsed       = errors.New("deposit payment already backs another proposal")
+)
+
+// VotingPowerSource reads an address's voting power in wei as of a block,
+// or the latest block when block is nil (implemented by govindex.TokenVotes)
+type VotingPowerSource interface {
+	VotingPower(ctx context.Context, address string, block *big.Int) (*big.Int, error)
+}
+
+// SetProposalDeposits requires proposers below the proposal threshold to pay
+// a refundable deposit (service ProposalDepositServiceCode) before their
+// proposal becomes visible. Deposits are required while that service is
+// priced and active.
+func (h *GovernanceHandler) SetProposalDeposits(payments repository.PaymentRepository, pricing repository.PricingRepository) {
+	h.payments = payments
+	h.pricing = pricing
+}
+
+// SetVotingPower weights votes by the voters' voting power at the proposal's
+// snapshot block, and lets proposers at or above the proposal threshold
+// create visible proposals without a deposit
+func (h *GovernanceHandler) SetVotingPower(source VotingPowerSource) {
+	h.votingPower = source
+}
+
+// depositRequired reports whether proposer must pay a deposit to propose,
+// judged by the proposer's voting power at block
+func (h *GovernanceHandler) depositRequired(ctx context.Context, proposer string, block *big.Int) (bool, error) {
+	if h.payments == nil || h.pricing == nil {
+		return false, nil
+	}
+	pricing, err := h.pricing.GetPricing(ctx, ProposalDepositServiceCode)
+	if err != nil {
+		if errors.Is(err, repository.ErrPricingNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	if !pricing.IsActive {
+		return false, nil
+	}
+
+	if h.votingPower != nil {
+		power, err := h.votingPower.VotingPower(ctx, proposer, block)
+		if err != nil {
+			// Without the voting power the proposer can still deposit
+			h.logger.Warn("failed to read proposer voting power", zap.String("proposer", proposer), zap.Error(err))
+		} else if power.Cmp(h.proposalThreshold) >= 0 {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// checkDeposit verifies paymentID is a completed deposit paid by proposer
+// that backs no other proposal. The caller marks it used under h.mu.
+func (h *GovernanceHandler) checkDeposit(ctx context.Context, proposer, paymentID string) error {
+	p, err := h.payments.GetPayment(ctx, paymentID)
+	if err != nil {
+		if errors.Is(err, repository.ErrPaymentNotFound) {
+			return errDepositNotFound
+		}
+		return err
+	}
+	switch {
+	case p.ServiceCode != ProposalDepositServiceCode:
+		return errDepositNotDeposit
+	case strings.ToLower(p.PayerAddress) != proposer:
+		return errDepositPayer
+	case p.Status != repository.PaymentStatusCompleted:
+		return errDepositIncomplete
+	}
+	return nil
+}
+
+// useDeposit records that paymentID backs proposal and opens the proposal
+// for voting after the voting delay. The caller holds h.mu.
+func (h *GovernanceHandler) useDeposit(proposal *Proposal, paymentID string, now time.Time) error {
+	if owner, used := h.deposits[paymentID]; used && owner != proposal.ID {
+		return errDepositUsed
+	}
+	h.deposits[paymentID] = proposal.ID
+	proposal.DepositPaymentID = &paymentID
+	proposal.State = ProposalStatePending
+	proposal.StartTime = now.Add(h.votingDelay)
+	proposal.EndTime = now.Add(h.votingDelay + h.votingPeriod)
+	return nil
+}
+
+// depositStatus maps a deposit check error to its HTTP status
+func depositStatus(err error) int {
+	switch {
+	case errors.Is(err, errDepositNotFound), errors.Is(err, errDepositNotDeposit),
+		errors.Is(err, errDepositPayer), errors.Is(err, errDepositIncomplete):
+		return http.StatusBadRequest
+	case errors.Is(err, errDepositUsed):
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
+
+// PayProposalDepositRequest is the body of POST
+// /api/v1/governance/proposals/:id/deposit
+type PayProposalDepositRequest struct {
+	PaymentID string `json:"payment_id" binding:"required"`
+}
+
+// PayProposalDeposit handles POST /api/v1/governance/proposals/:id/deposit
+// @Summary Back a proposal with its deposit
+// @Description Attaches a completed governance_proposal payment by the proposer to a proposal awaiting its deposit. The proposal becomes visible and voting starts after the voting delay.
+// @Tags governance
+// @Accept json
+// @Produce json
+// @Param id path string true "Proposal ID"
+// @Param request body PayProposalDepositRequest true "Deposit payment"
+// @Success 200 {object} ProposalResponse
+// @Failure 400 {object} ProposalResponse
+// @Failure 404 {object} ProposalResponse
+// @Failure 409 {object} ProposalResponse
+// @Router /api/v1/governance/proposals/{id}/deposit [post]
+func (h *GovernanceHandler) PayProposalDeposit(c *gin.Context) {
+	proposalID := c.Param("id")
+
+	var req PayProposalDepositRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ProposalResponse{
+			Success: false,
+			Message: "Invalid request: " + err.Error(),
+		})
+		return
+	}
+
+	h.mu.RLock()
+	proposal, exists := h.proposals[proposalID]
+	var proposer string
+	if exists {
+		proposer = proposal.Proposer
+	}
+	h.mu.RUnlock()
+	if !exists {
+		c.JSON(http.StatusNotFound, ProposalResponse{
+			Success: false,
+			Message: "Proposal not found",
+		})
+		return
+	}
+	if h.payments == nil {
+		c.JSON(http.StatusConflict, ProposalResponse{
+			Success: false,
+			Message: "Proposal deposits are not enabled",
+		})
+		return
+	}
+
+	if err := h.checkDeposit(c.Request.Context(), proposer, req.PaymentID); err != nil {
+		status := depositStatus(err)
+		if status == http.StatusInternalServerError {
+			h.logger.Error("failed to check proposal deposit", zap.String("proposal_id", proposalID), zap.Error(err))
+			err = errors.New("failed to check deposit payment")
+		}
+		c.JSON(status, ProposalResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if proposal.State != ProposalStateAwaitingDeposit {
+		c.JSON(http.StatusConflict, ProposalResponse{
+			Success: false,
+			Message: "Proposal is not awaiting a deposit. Current state: " + string(proposal.State),
+		})
+		return
+	}
+	if err := h.useDeposit(proposal, req.PaymentID, time.Now()); err != nil {
+		c.JSON(depositStatus(err), ProposalResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	h.logger.Info("proposal deposit paid",
+		zap.String("proposal_id", proposalID),
+		zap.String("payment_id", req.PaymentID),
+	)
+
+	c.JSON(http.StatusOK, ProposalResponse{
+		Success:  true,
+		Proposal: proposal,
+		Message:  "Deposit accepted. Voting will begin in " + h.votingDelay.String(),
+	})
+}
@@ -0,0 +1,114 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/handlers"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/storage/memory"
+)
+
+// fixedVotingPower gives every address the same voting power
+type fixedVotingPower int64
+
+func (p fixedVotingPower) VotingPower(context.Context, string, *big.Int) (*big.Int, error) {
+	return new(big.Int).Mul(big.NewInt(int64(p)), big.NewInt(1e18)), nil
+}
+
+func TestGovernanceHandler_ProposalDeposits(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctx := context.Background()
+	store := memory.NewStore()
+	store.Pricing.AddPricing(&repository.Pricing{ServiceCode: handlers.ProposalDepositServiceCode, IsActive: true})
+	governance := handlers.NewGovernanceHandler(zap.NewNop(), store.GovernanceConfig, 31337)
+	governance.SetProposalDeposits(store.Payments, store.Pricing)
+
+	router := gin.New()
+	router.POST("/api/v1/governance/proposals", governance.CreateProposal)
+	router.GET("/api/v1/governance/proposals", governance.ListProposals)
+	router.GET("/api/v1/governance/proposals/:id", governance.GetProposal)
+	router.POST("/api/v1/governance/proposals/:id/deposit", governance.PayProposalDeposit)
+
+	post := func(path, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+		return w
+	}
+	proposer := fmt.Sprintf("0x%040x", 0xabcdef)
+	create := func(title, depositID string) *httptest.ResponseRecorder {
+		return post("/api/v1/governance/proposals", fmt.Sprintf(
+			`{"proposer":%q,"title":%q,"description":"d","targets":["0x0"],"values":["0"],"calldatas":["0x"],"deposit_payment_id":%q}`,
+			proposer, title, depositID))
+	}
+	pay := func(payer string, status repository.PaymentStatus) string {
+		p := &repository.Payment{ServiceCode: handlers.ProposalDepositServiceCode, PayerAddress: payer, PaymentMethod: "crypto", Status: status}
+		require.NoError(t, store.Payments.CreatePayment(ctx, p))
+		return p.ID
+	}
+	listed := func() int {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/governance/proposals", nil))
+		var resp handlers.ProposalsListResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		return resp.Total
+	}
+
+	// Without a deposit the proposal waits, hidden
+	w := create("Fund grants", "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var created handlers.CreateProposalResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	assert.Equal(t, handlers.ProposalStateAwaitingDeposit, created.Proposal.State)
+	assert.Zero(t, listed())
+
+	depositPath := "/api/v1/governance/proposals/" + created.ProposalID + "/deposit"
+	pending := pay(proposer, repository.PaymentStatusPending)
+	assert.Equal(t, http.StatusBadRequest, post(depositPath, fmt.Sprintf(`{"payment_id":%q}`, pending)).Code, "uncompleted deposit")
+	other := pay(fmt.Sprintf("0x%040x", 2), repository.PaymentStatusCompleted)
+	assert.Equal(t, http.StatusBadRequest, post(depositPath, fmt.Sprintf(`{"payment_id":%q}`, other)).Code, "someone else's deposit")
+
+	deposit := pay("0x"+strings.ToUpper(proposer[2:]), repository.PaymentStatusCompleted) // payer case doesn't matter
+	w = post(depositPath, fmt.Sprintf(`{"payment_id":%q}`, deposit))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var paid handlers.ProposalResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &paid))
+	assert.Equal(t, handlers.ProposalStatePending, paid.Proposal.State)
+	require.NotNil(t, paid.Proposal.DepositPaymentID)
+	assert.Equal(t, deposit, *paid.Proposal.DepositPaymentID)
+	assert.Equal(t, 1, listed())
+
+	// A deposit backs one proposal
+	assert.Equal(t, http.StatusConflict, create("Raise fees", deposit).Code)
+	assert.Equal(t, http.StatusConflict, post(depositPath, fmt.Sprintf(`{"payment_id":%q}`, pay(proposer, repository.PaymentStatusCompleted))).Code,
+		"the proposal is no longer awaiting a deposit")
+
+	// Paying up front opens the proposal straight away
+	w = create("Raise fees", pay(proposer, repository.PaymentStatusCompleted))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	assert.Equal(t, handlers.ProposalStatePending, created.Proposal.State)
+	assert.Equal(t, 2, listed())
+
+	// Proposers at the threshold need no deposit
+	governance.SetVotingPower(fixedVotingPower(100))
+	w = create("Upgrade", "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var free handlers.CreateProposalResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &free))
+	assert.Equal(t, handlers.ProposalStatePending, free.Proposal.State)
+	assert.Nil(t, free.Proposal.DepositPaymentID)
+}
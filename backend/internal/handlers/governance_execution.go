@@ -0,0 +1,196 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// ActionStatusExecuted marks an action carried out by a mined execute call.
+// The governor executes actions in one transaction, so they all succeed or
+// the call reverts and the proposal stays queued.
+const ActionStatusExecuted = "executed"
+
+// ProposalExecutor submits a proposal's actions on chain through the
+// governor's execute(targets, values, calldatas, descriptionHash)
+type ProposalExecutor interface {
+	Execute(ctx context.Context, targets, values, calldatas []string, descriptionHash common.Hash) (common.Hash, error)
+}
+
+// ProposalExecution is the receipt of an executed proposal
+type ProposalExecution struct {
+	TxHash string `json:"tx_hash"`
+	// Simulated is set when no executor is configured and TxHash is a mock
+	Simulated  bool             `json:"simulated"`
+	ExecutedAt time.Time        `json:"executed_at"`
+	Actions    []ExecutedAction `json:"actions"`
+}
+
+// ExecutedAction is the outcome of one proposal action, decoded against the
+// stored ABI of its target contract when the target is known
+type ExecutedAction struct {
+	Index      int         `json:"index"`
+	Target     string      `json:"target"`
+	Contract   string      `json:"contract,omitempty"` // db name of a registered target
+	ABIVersion string      `json:"abi_version,omitempty"`
+	Function   string      `json:"function,omitempty"` // e.g. transfer(address,uint256)
+	Args       []ActionArg `json:"args,omitempty"`
+	Value      string      `json:"value"`
+	Calldata   string      `json:"calldata"`
+	Status     string      `json:"status"`
+	// DecodeError explains why the calldata is shown raw
+	DecodeError string `json:"decode_error,omitempty"`
+}
+
+// ActionArg is a decoded function argument
+type ActionArg struct {
+	Name  string      `json:"name"`
+	Type  string      `json:"type"`
+	Value interface{} `json:"value"`
+}
+
+// SetContractRepo decodes executed actions with the stored ABIs of the
+// target contracts on the handler's chain
+func (h *GovernanceHandler) SetContractRepo(repo repository.ContractRepository) {
+	h.contracts = repo
+}
+
+// SetExecutor executes proposals on chain. Without one, execution is
+// simulated and receipts carry a mock transaction hash.
+func (h *GovernanceHandler) SetExecutor(executor ProposalExecutor) {
+	h.executor = executor
+}
+
+// execute runs the proposal's actions and returns the receipt
+func (h *GovernanceHandler) execute(ctx context.Context, p Proposal, now time.Time) (*ProposalExecution, error) {
+	execution := &ProposalExecution{ExecutedAt: now}
+	if h.executor != nil {
+		descriptionHash := crypto.Keccak256Hash([]byte(p.Description))
+		txHash, err := h.executor.Execute(ctx, p.Targets, p.Values, p.Calldatas, descriptionHash)
+		if err != nil {
+			return nil, err
+		}
+		execution.TxHash = txHash.Hex()
+	} else {
+		execution.TxHash = generateMockTxID()
+		execution.Simulated = true
+	}
+	execution.Actions = h.decodeActions(ctx, p.Targets, p.Values, p.Calldatas)
+	return execution, nil
+}
+
+// decodeActions resolves each action's target to a registered contract and
+// decodes its calldata with that contract's ABI. Actions that cannot be
+// decoded keep their raw calldata.
+func (h *GovernanceHandler) decodeActions(ctx context.Context, targets, values, calldatas []string) []ExecutedAction {
+	actions := make([]ExecutedAction, len(targets))
+	for i, target := range targets {
+		actions[i] = ExecutedAction{Index: i, Target: target, Value: "0", Status: ActionStatusExecuted}
+		if i < len(values) {
+			actions[i].Value = values[i]
+		}
+		if i < len(calldatas) {
+			actions[i].Calldata = calldatas[i]
+		}
+	}
+	if h.contracts == nil {
+		return actions
+	}
+
+	deployed, err := h.contracts.GetByChainID(ctx, h.chainID)
+	if err != nil {
+		h.logger.Warn("failed to load contracts for proposal actions", zap.Int64("chain_id", h.chainID), zap.Error(err))
+		for i := range actions {
+			actions[i].DecodeError = "contract lookup failed"
+		}
+		return actions
+	}
+	byAddress := make(map[string]*repository.ContractAddress, len(deployed))
+	for _, contract := range deployed {
+		byAddress[strings.ToLower(contract.Address)] = contract
+	}
+
+	abis := make(map[string]*abi.ABI)
+	for i := range actions {
+		action := &actions[i]
+		contract, ok := byAddress[strings.ToLower(action.Target)]
+		if !ok {
+			action.DecodeError = "unknown target contract"
+			continue
+		}
+		action.Contract = contract.DBName
+		action.ABIVersion = contract.ABIVersion
+
+		if action.Calldata == "" || action.Calldata == "0x" {
+			continue // plain value transfer
+		}
+		data, err := hexutil.Decode(action.Calldata)
+		if err != nil {
+			action.DecodeError = "invalid calldata"
+			continue
+		}
+		if len(data) < 4 {
+			action.DecodeError = "calldata has no function selector"
+			continue
+		}
+
+		key := contract.DBName + "@" + contract.ABIVersion
+		parsed, ok := abis[key]
+		if !ok {
+			parsed, err = h.loadABI(ctx, contract.DBName, contract.ABIVersion)
+			if err != nil {
+				action.DecodeError = err.Error()
+				continue
+			}
+			abis[key] = parsed
+		}
+
+		method, err := parsed.MethodById(data[:4])
+		if err != nil {
+			action.DecodeError = "function selector " + hexutil.Encode(data[:4]) + " not in ABI " + key
+			continue
+		}
+		action.Function = method.Sig
+		unpacked, err := method.Inputs.Unpack(data[4:])
+		if err != nil {
+			action.DecodeError = "arguments do not match " + method.Sig
+			continue
+		}
+		action.Args = make([]ActionArg, len(unpacked))
+		for j, v := range unpacked {
+			action.Args[j] = ActionArg{
+				Name:  method.Inputs[j].Name,
+				Type:  method.Inputs[j].Type.String(),
+				Value: formatCallValue(v),
+			}
+		}
+	}
+	return actions
+}
+
+// loadABI parses a stored ABI artifact
+func (h *GovernanceHandler) loadABI(ctx context.Context, dbName, version string) (*abi.ABI, error) {
+	artifact, err := h.contracts.GetABI(ctx, dbName, version)
+	if err != nil {
+		if errors.Is(err, repository.ErrABIArtifactNotFound) {
+			return nil, errors.New("ABI not found: " + dbName + "@" + version)
+		}
+		h.logger.Warn("failed to get abi", zap.String("name", dbName), zap.String("version", version), zap.Error(err))
+		return nil, errors.New("ABI lookup failed")
+	}
+	parsed, err := abi.JSON(strings.NewReader(string(artifact.ABI)))
+	if err != nil {
+		h.logger.Warn("stored abi is invalid", zap.String("name", dbName), zap.String("version", version), zap.Error(err))
+		return nil, errors.New("stored ABI is invalid: " + dbName + "@" + version)
+	}
+	return &parsed, nil
+}
@@ -0,0 +1,146 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/handlers"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/storage/memory"
+)
+
+const tokenABI = `[{"type":"function","name":"transfer","stateMutability":"nonpayable",
+	"inputs":[{"name":"to","type":"address"},{"name":"amount","type":"uint256"}],"outputs":[{"name":"","type":"bool"}]}]`
+
+// fakeExecutor records execute calls and fails while err is set
+type fakeExecutor struct {
+	calls int
+	hash  common.Hash
+	err   error
+}
+
+func (e *fakeExecutor) Execute(_ context.Context, _, _, _ []string, _ common.Hash) (common.Hash, error) {
+	e.calls++
+	return e.hash, e.err
+}
+
+func TestGovernanceHandler_ExecutionReceipt(t *testing.T) {
+	ctx := context.Background()
+	token := "0x" + strings.Repeat("ab", 20)
+	unknown := "0x" + strings.Repeat("cd", 20)
+
+	contracts := memory.NewMemoryContractRepo()
+	contracts.AddNetwork(&repository.NetworkConfig{ChainID: 31337, NetworkName: "localhost", IsActive: true})
+	mappingID := contracts.AddMapping(&repository.ContractMapping{SolidityName: "NexusToken", DBName: "nexusToken"})
+	version := "1.0.0"
+	_, err := contracts.Upsert(ctx, &repository.ContractAddressUpsert{ChainID: 31337, ContractMappingID: mappingID, Address: token, ABIVersion: &version})
+	require.NoError(t, err)
+	_, err = contracts.UpsertABI(ctx, &repository.ABIArtifactUpsert{DBName: "nexusToken", Version: version, ABI: json.RawMessage(tokenABI), ABIHash: "0xhash"})
+	require.NoError(t, err)
+
+	parsed, err := abi.JSON(strings.NewReader(tokenABI))
+	require.NoError(t, err)
+	to := common.HexToAddress("0x" + strings.Repeat("12", 20))
+	calldata, err := parsed.Pack("transfer", to, big.NewInt(5e18))
+	require.NoError(t, err)
+
+	governance := handlers.NewGovernanceHandler(zap.NewNop(), nil, 31337)
+	governance.SetContractRepo(contracts)
+	eta := time.Now().Add(-time.Minute)
+	governance.Seed([]*handlers.Proposal{{
+		ID:        "p1",
+		Proposer:  token,
+		Title:     "Grant",
+		Targets:   []string{token, unknown, token},
+		Values:    []string{"0", "0", "1000"},
+		Calldatas: []string{hexutil.Encode(calldata), "0xa9059cbb", "0x"},
+		State:     handlers.ProposalStateQueued,
+		Eta:       &eta,
+	}})
+	executor := &fakeExecutor{hash: common.HexToHash("0xfeed"), err: errors.New("execution reverted")}
+	governance.SetExecutor(executor)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/governance/proposals/:id", governance.GetProposal)
+	router.POST("/governance/proposals/:id/execute", governance.ExecuteProposal)
+
+	// A reverted execute leaves the proposal queued without a receipt
+	w := send(router, http.MethodPost, "/governance/proposals/p1/execute", "", nil)
+	assert.Equal(t, http.StatusBadGateway, w.Code)
+	w = send(router, http.MethodGet, "/governance/proposals/p1", "", nil)
+	var resp handlers.ProposalResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, handlers.ProposalStateQueued, resp.Proposal.State)
+	assert.Nil(t, resp.Proposal.Execution)
+
+	executor.err = nil
+	w = send(router, http.MethodPost, "/governance/proposals/p1/execute", "", nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, 2, executor.calls)
+
+	w = send(router, http.MethodGet, "/governance/proposals/p1", "", nil)
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, handlers.ProposalStateExecuted, resp.Proposal.State)
+	execution := resp.Proposal.Execution
+	require.NotNil(t, execution)
+	assert.Equal(t, common.HexToHash("0xfeed").Hex(), execution.TxHash)
+	assert.False(t, execution.Simulated)
+	require.Len(t, execution.Actions, 3)
+
+	transfer := execution.Actions[0]
+	assert.Equal(t, "nexusToken", transfer.Contract)
+	assert.Equal(t, "transfer(address,uint256)", transfer.Function)
+	assert.Equal(t, handlers.ActionStatusExecuted, transfer.Status)
+	assert.Empty(t, transfer.DecodeError)
+	require.Len(t, transfer.Args, 2)
+	assert.Equal(t, handlers.ActionArg{Name: "to", Type: "address", Value: to.Hex()}, transfer.Args[0])
+	assert.Equal(t, handlers.ActionArg{Name: "amount", Type: "uint256", Value: "5000000000000000000"}, transfer.Args[1])
+
+	// Unknown targets keep their raw calldata; value transfers have none
+	assert.Equal(t, "unknown target contract", execution.Actions[1].DecodeError)
+	assert.Equal(t, "0xa9059cbb", execution.Actions[1].Calldata)
+	assert.Empty(t, execution.Actions[2].Function)
+	assert.Empty(t, execution.Actions[2].DecodeError)
+	assert.Equal(t, "1000", execution.Actions[2].Value)
+
+	w = send(router, http.MethodPost, "/governance/proposals/p1/execute", "", nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestGovernanceHandler_SimulatedExecution(t *testing.T) {
+	governance := handlers.NewGovernanceHandler(zap.NewNop(), nil, 31337)
+	eta := time.Now().Add(-time.Minute)
+	governance.Seed([]*handlers.Proposal{{
+		ID: "p1", Targets: []string{"0x0"}, Values: []string{"0"}, Calldatas: []string{"0x12345678"},
+		State: handlers.ProposalStateQueued, Eta: &eta,
+	}})
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/governance/proposals/:id/execute", governance.ExecuteProposal)
+
+	w := send(router, http.MethodPost, "/governance/proposals/p1/execute", "", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp handlers.ProposalResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.NotNil(t, resp.Proposal.Execution)
+	assert.True(t, resp.Proposal.Execution.Simulated)
+	assert.NotEmpty(t, resp.Proposal.Execution.TxHash)
+	require.Len(t, resp.Proposal.Execution.Actions, 1)
+	assert.Equal(t, "0x12345678", resp.Proposal.Execution.Actions[0].Calldata)
+}
@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// BlockSource reads block headers (implemented by chain.FailoverClient)
+type BlockSource interface {
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+}
+
+// SetSnapshotBlocks records the latest block and its state root as each
+// proposal's snapshot when it is created. Voting power for the proposal is
+// then read as of that block.
+func (h *GovernanceHandler) SetSnapshotBlocks(source BlockSource) {
+	h.blocks = source
+}
+
+// latestSnapshot returns the latest block and its state root for a new
+// proposal, or nothing without a block source
+func (h *GovernanceHandler) latestSnapshot(ctx context.Context) (*uint64, string, error) {
+	if h.blocks == nil {
+		return nil, "", nil
+	}
+	header, err := h.blocks.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	block := header.Number.Uint64()
+	return &block, header.Root.Hex(), nil
+}
+
+// snapshotBlock returns the block a proposal's voting power is read at, nil
+// for the latest block
+func (p *Proposal) snapshotBlock() *big.Int {
+	if p.SnapshotBlock == nil {
+		return nil
+	}
+	return new(big.Int).SetUint64(*p.SnapshotBlock)
+}
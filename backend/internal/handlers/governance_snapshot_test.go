@@ -0,0 +1,101 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/handlers"
+)
+
+// headAt serves a fixed latest header, or fails while err is set
+type headAt struct {
+	header *types.Header
+	err    error
+}
+
+func (h *headAt) HeaderByNumber(context.Context, *big.Int) (*types.Header, error) {
+	return h.header, h.err
+}
+
+// powerByBlock gives voters block*1e18 votes and records the blocks read
+type powerByBlock struct {
+	mu     sync.Mutex
+	blocks []*big.Int
+}
+
+func (p *powerByBlock) VotingPower(_ context.Context, _ string, block *big.Int) (*big.Int, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.blocks = append(p.blocks, block)
+	if block == nil {
+		return big.NewInt(1), nil
+	}
+	return new(big.Int).Mul(block, big.NewInt(1e18)), nil
+}
+
+func TestGovernanceHandler_SnapshotBlock(t *testing.T) {
+	root := common.HexToHash("0x5157")
+	blocks := &headAt{header: &types.Header{Number: big.NewInt(123), Root: root}}
+	power := &powerByBlock{}
+	governance := handlers.NewGovernanceHandler(zap.NewNop(), nil, 31337)
+	governance.SetSnapshotBlocks(blocks)
+	governance.SetVotingPower(power)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/governance/proposals", governance.CreateProposal)
+	router.GET("/governance/proposals/:id", governance.GetProposal)
+	router.POST("/governance/vote", governance.CastVote)
+	router.GET("/governance/voting-power/:address", governance.GetVotingPower)
+
+	proposer := "0x" + strings.Repeat("aa", 20)
+	create := gin.H{"proposer": proposer, "title": "t", "description": "d", "targets": []string{proposer}, "values": []string{"0"}, "calldatas": []string{"0x"}}
+	w := send(router, http.MethodPost, "/governance/proposals", "", create)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var created handlers.CreateProposalResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	require.NotNil(t, created.Proposal.SnapshotBlock)
+	assert.Equal(t, uint64(123), *created.Proposal.SnapshotBlock)
+	assert.Equal(t, root.Hex(), created.Proposal.SnapshotStateRoot)
+
+	// Votes are weighted by the power at the snapshot, not the request
+	block := uint64(50)
+	governance.Seed([]*handlers.Proposal{{
+		ID: "p1", State: handlers.ProposalStateActive, StartTime: time.Now().Add(-time.Hour), EndTime: time.Now().Add(time.Hour),
+		ForVotes: "0", AgainstVotes: "0", AbstainVotes: "0", SnapshotBlock: &block,
+	}})
+	voter := "0x" + strings.Repeat("bb", 20)
+	w = send(router, http.MethodPost, "/governance/vote", "", gin.H{"proposal_id": "p1", "voter": voter, "support": 1, "weight": "1"})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var voted handlers.CastVoteResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &voted))
+	assert.Equal(t, "50000000000000000000", voted.Vote.Weight)
+	assert.Equal(t, []*big.Int{big.NewInt(50)}, power.blocks)
+
+	w = send(router, http.MethodGet, "/governance/voting-power/"+voter+"?proposal_id=p1", "", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "50000000000000000000", resp["voting_power"])
+	assert.Equal(t, float64(50), resp["block"])
+	assert.Equal(t, http.StatusNotFound, send(router, http.MethodGet, "/governance/voting-power/"+voter+"?proposal_id=nope", "", nil).Code)
+
+	// No proposal is created without its snapshot
+	blocks.err = errors.New("rpc down")
+	create["title"] = "t2"
+	assert.Equal(t, http.StatusServiceUnavailable, send(router, http.MethodPost, "/governance/proposals", "", create).Code)
+}
@@ -0,0 +1,275 @@
+package handlers
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/callbacks"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// SubscriptionSecretHeader carries a governance subscription's or address
+// watchlist's current secret, required to read, change or delete it
+const SubscriptionSecretHeader = "X-Nexus-Subscription-Secret"
+
+// GovernanceSubscriptionHandler handles token holders' governance
+// notification subscriptions
+type GovernanceSubscriptionHandler struct {
+	repo              repository.GovernanceNotificationRepository
+	logger            *zap.Logger
+	insecureCallbacks bool
+}
+
+// NewGovernanceSubscriptionHandler creates a new governance subscription handler with injected dependencies
+func NewGovernanceSubscriptionHandler(repo repository.GovernanceNotificationRepository, logger *zap.Logger) *GovernanceSubscriptionHandler {
+	return &GovernanceSubscriptionHandler{repo: repo, logger: logger}
+}
+
+// SetInsecureCallbacks accepts http webhook URLs (for local development)
+func (h *GovernanceSubscriptionHandler) SetInsecureCallbacks(allow bool) {
+	h.insecureCallbacks = allow
+}
+
+// GovernanceSubscriptionResponse wraps governance subscription API responses
+type GovernanceSubscriptionResponse struct {
+	Success bool        `json:"success"`
+	Data    interface{} `json:"data,omitempty"`
+	Error   string      `json:"error,omitempty"`
+}
+
+// UpsertGovernanceSubscriptionRequest is the body of PUT
+// /api/v1/governance/subscriptions/:address
+type UpsertGovernanceSubscriptionRequest struct {
+	WebhookURL string   `json:"webhook_url" binding:"required"`
+	Secret     string   `json:"secret" binding:"required"`
+	Events     []string `json:"events" binding:"required"`
+	Digest     string   `json:"digest"` // none (default), daily or weekly
+}
+
+// UpsertSubscription handles PUT /api/v1/governance/subscriptions/:address
+// @Summary Subscribe to governance notifications
+// @Description Creates or replaces an address's notification preferences. Alerts are POSTed to webhook_url signed with secret like payment callbacks. Replacing a subscription needs its current secret in X-Nexus-Subscription-Secret.
+// @Tags governance
+// @Accept json
+// @Produce json
+// @Param address path string true "Token holder address"
+// @Param request body UpsertGovernanceSubscriptionRequest true "Subscription"
+// @Success 200 {object} GovernanceSubscriptionResponse{data=repository.GovernanceSubscription}
+// @Failure 400 {object} GovernanceSubscriptionResponse
+// @Failure 403 {object} GovernanceSubscriptionResponse
+// @Router /api/v1/governance/subscriptions/{address} [put]
+func (h *GovernanceSubscriptionHandler) UpsertSubscription(c *gin.Context) {
+	address, ok := h.address(c)
+	if !ok {
+		return
+	}
+
+	var req UpsertGovernanceSubscriptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, GovernanceSubscriptionResponse{
+			Success: false,
+			Error:   "Invalid request: " + err.Error(),
+		})
+		return
+	}
+	sub, err := h.subscription(address, req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, GovernanceSubscriptionResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	if _, _, ok := h.authorize(c, address); !ok {
+		return
+	}
+
+	if err := h.repo.UpsertGovernanceSubscription(c.Request.Context(), sub); err != nil {
+		h.logger.Error("failed to save governance subscription", zap.String("address", address), zap.Error(err))
+		c.JSON(http.StatusInternalServerError, GovernanceSubscriptionResponse{
+			Success: false,
+			Error:   "Failed to save subscription",
+		})
+		return
+	}
+
+	h.logger.Info("governance subscription saved",
+		zap.String("address", address),
+		zap.Strings("events", sub.Events),
+		zap.String("digest", string(sub.Digest)),
+	)
+	c.JSON(http.StatusOK, GovernanceSubscriptionResponse{
+		Success: true,
+		Data:    sub,
+	})
+}
+
+// GetSubscription handles GET /api/v1/governance/subscriptions/:address
+// @Summary Get governance notification preferences
+// @Description Returns an address's subscription. Needs its secret in X-Nexus-Subscription-Secret.
+// @Tags governance
+// @Produce json
+// @Param address path string true "Token holder address"
+// @Success 200 {object} GovernanceSubscriptionResponse{data=repository.GovernanceSubscription}
+// @Failure 403 {object} GovernanceSubscriptionResponse
+// @Failure 404 {object} GovernanceSubscriptionResponse
+// @Router /api/v1/governance/subscriptions/{address} [get]
+func (h *GovernanceSubscriptionHandler) GetSubscription(c *gin.Context) {
+	address, ok := h.address(c)
+	if !ok {
+		return
+	}
+	sub, exists, ok := h.authorize(c, address)
+	if !ok {
+		return
+	}
+	if !exists {
+		h.notFound(c)
+		return
+	}
+
+	c.JSON(http.StatusOK, GovernanceSubscriptionResponse{
+		Success: true,
+		Data:    sub,
+	})
+}
+
+// DeleteSubscription handles DELETE /api/v1/governance/subscriptions/:address
+// @Summary Unsubscribe from governance notifications
+// @Description Deletes an address's subscription; alerts still queued for it are dropped. Needs its secret in X-Nexus-Subscription-Secret.
+// @Tags governance
+// @Produce json
+// @Param address path string true "Token holder address"
+// @Success 200 {object} GovernanceSubscriptionResponse
+// @Failure 403 {object} GovernanceSubscriptionResponse
+// @Failure 404 {object} GovernanceSubscriptionResponse
+// @Router /api/v1/governance/subscriptions/{address} [delete]
+func (h *GovernanceSubscriptionHandler) DeleteSubscription(c *gin.Context) {
+	address, ok := h.address(c)
+	if !ok {
+		return
+	}
+	_, exists, ok := h.authorize(c, address)
+	if !ok {
+		return
+	}
+	if !exists {
+		h.notFound(c)
+		return
+	}
+
+	if err := h.repo.DeleteGovernanceSubscription(c.Request.Context(), address); err != nil {
+		if errors.Is(err, repository.ErrGovernanceSubscriptionNotFound) {
+			h.notFound(c)
+			return
+		}
+		h.logger.Error("failed to delete governance subscription", zap.String("address", address), zap.Error(err))
+		c.JSON(http.StatusInternalServerError, GovernanceSubscriptionResponse{
+			Success: false,
+			Error:   "Failed to delete subscription",
+		})
+		return
+	}
+
+	h.logger.Info("governance subscription deleted", zap.String("address", address))
+	c.JSON(http.StatusOK, GovernanceSubscriptionResponse{
+		Success: true,
+		Data:    gin.H{"address": address},
+	})
+}
+
+// subscription validates req into the subscription of address
+func (h *GovernanceSubscriptionHandler) subscription(address string, req UpsertGovernanceSubscriptionRequest) (*repository.GovernanceSubscription, error) {
+	if err := callbacks.ValidateURL(req.WebhookURL, h.insecureCallbacks); err != nil {
+		return nil, err
+	}
+	if err := callbacks.ValidateSecret(req.Secret); err != nil {
+		return nil, err
+	}
+
+	digest := repository.GovernanceDigestNone
+	if req.Digest != "" {
+		digest = repository.GovernanceDigest(strings.ToLower(req.Digest))
+	}
+	if !digest.Valid() {
+		return nil, errors.New("digest must be none, daily or weekly")
+	}
+
+	var events []string
+	seen := make(map[repository.GovernanceEventType]bool)
+	for _, e := range req.Events {
+		t := repository.GovernanceEventType(strings.ToLower(e))
+		if !t.Valid() {
+			return nil, errors.New("events must be proposal_opened, near_quorum or proposal_queued")
+		}
+		if !seen[t] {
+			seen[t] = true
+			events = append(events, string(t))
+		}
+	}
+	if len(events) == 0 {
+		return nil, errors.New("at least one event is required")
+	}
+
+	return &repository.GovernanceSubscription{
+		Address:    address,
+		WebhookURL: req.WebhookURL,
+		Secret:     req.Secret,
+		Events:     events,
+		Digest:     digest,
+	}, nil
+}
+
+// authorize loads the subscription of address, if any, and checks the
+// request carries its secret. It reports whether the subscription exists
+// and whether the request may proceed, having responded if not.
+func (h *GovernanceSubscriptionHandler) authorize(c *gin.Context, address string) (*repository.GovernanceSubscription, bool, bool) {
+	sub, err := h.repo.GetGovernanceSubscription(c.Request.Context(), address)
+	if err != nil {
+		if errors.Is(err, repository.ErrGovernanceSubscriptionNotFound) {
+			return nil, false, true
+		}
+		h.logger.Error("failed to get governance subscription", zap.String("address", address), zap.Error(err))
+		c.JSON(http.StatusInternalServerError, GovernanceSubscriptionResponse{
+			Success: false,
+			Error:   "Failed to get subscription",
+		})
+		return nil, false, false
+	}
+
+	provided := c.GetHeader(SubscriptionSecretHeader)
+	if subtle.ConstantTimeCompare([]byte(provided), []byte(sub.Secret)) != 1 {
+		c.JSON(http.StatusForbidden, GovernanceSubscriptionResponse{
+			Success: false,
+			Error:   "Missing or invalid " + SubscriptionSecretHeader + " header",
+		})
+		return nil, true, false
+	}
+	return sub, true, true
+}
+
+// address validates and normalizes the :address path parameter
+func (h *GovernanceSubscriptionHandler) address(c *gin.Context) (string, bool) {
+	address := c.Param("address")
+	if !isValidAddress(address) {
+		c.JSON(http.StatusBadRequest, GovernanceSubscriptionResponse{
+			Success: false,
+			Error:   "Invalid address format",
+		})
+		return "", false
+	}
+	return strings.ToLower(address), true
+}
+
+func (h *GovernanceSubscriptionHandler) notFound(c *gin.Context) {
+	c.JSON(http.StatusNotFound, GovernanceSubscriptionResponse{
+		Success: false,
+		Error:   "Subscription not found",
+	})
+}
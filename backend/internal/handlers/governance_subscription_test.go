@@ -0,0 +1,71 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/handlers"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/storage/memory"
+)
+
+func TestGovernanceSubscriptionHandler_SecretGuardsChanges(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	store := memory.NewStore()
+	handler := handlers.NewGovernanceSubscriptionHandler(store.GovernanceAlerts, zap.NewNop())
+
+	router := gin.New()
+	router.PUT("/api/v1/governance/subscriptions/:address", handler.UpsertSubscription)
+	router.GET("/api/v1/governance/subscriptions/:address", handler.GetSubscription)
+	router.DELETE("/api/v1/governance/subscriptions/:address", handler.DeleteSubscription)
+
+	const address = "0x00000000000000000000000000000000000000AA"
+	do := func(method, secret, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(method, "/api/v1/governance/subscriptions/"+address, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if secret != "" {
+			req.Header.Set(handlers.SubscriptionSecretHeader, secret)
+		}
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	assert.Equal(t, http.StatusBadRequest, do(http.MethodPut, "", `{"webhook_url":"http://example.com","secret":"0123456789abcdef","events":["proposal_opened"]}`).Code)
+	assert.Equal(t, http.StatusBadRequest, do(http.MethodPut, "", `{"webhook_url":"https://example.com","secret":"0123456789abcdef","events":["proposal_passed"]}`).Code)
+	assert.Equal(t, http.StatusBadRequest, do(http.MethodPut, "", `{"webhook_url":"https://example.com","secret":"0123456789abcdef","events":["near_quorum"],"digest":"hourly"}`).Code)
+
+	w := do(http.MethodPut, "", `{"webhook_url":"https://example.com","secret":"0123456789abcdef","events":["proposal_opened","near_quorum","near_quorum"],"digest":"weekly"}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.NotContains(t, w.Body.String(), "0123456789abcdef")
+
+	// Replacing, reading or deleting needs the current secret
+	newer := `{"webhook_url":"https://attacker.example","secret":"fedcba9876543210","events":["proposal_opened"]}`
+	assert.Equal(t, http.StatusForbidden, do(http.MethodPut, "", newer).Code)
+	assert.Equal(t, http.StatusForbidden, do(http.MethodGet, "wrong-secret-0000", "").Code)
+	assert.Equal(t, http.StatusForbidden, do(http.MethodDelete, "", "").Code)
+
+	w = do(http.MethodGet, "0123456789abcdef", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp struct {
+		Data struct {
+			Address string   `json:"address"`
+			Events  []string `json:"events"`
+			Digest  string   `json:"digest"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, strings.ToLower(address), resp.Data.Address)
+	assert.Equal(t, []string{"proposal_opened", "near_quorum"}, resp.Data.Events)
+	assert.Equal(t, "weekly", resp.Data.Digest)
+
+	assert.Equal(t, http.StatusOK, do(http.MethodDelete, "0123456789abcdef", "").Code)
+	assert.Equal(t, http.StatusNotFound, do(http.MethodGet, "0123456789abcdef", "").Code)
+}
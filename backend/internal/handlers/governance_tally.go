@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"math/big"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/govindex"
+)
+
+// TallySourceChain marks proposals whose vote totals come from the
+// governor's VoteCast events rather than from API-submitted votes
+const TallySourceChain = "chain"
+
+// TallyIndex reads on-chain tallies (implemented by govindex.Indexer)
+type TallyIndex interface {
+	Tally(proposalID string) (*govindex.Tally, bool)
+}
+
+// ProposalTallyResponse represents a proposal's on-chain tally
+type ProposalTallyResponse struct {
+	Success bool            `json:"success"`
+	Tally   *govindex.Tally `json:"tally,omitempty"`
+	Message string          `json:"message,omitempty"`
+}
+
+// SetTallyIndex serves on-chain tallies and their reconciliation with the
+// API-recorded votes
+func (h *GovernanceHandler) SetTallyIndex(index TallyIndex) {
+	h.tallies = index
+}
+
+// IndexedProposals returns every proposal with its governor proposal ID and
+// the votes recorded through the API, for reconciliation with the chain
+func (h *GovernanceHandler) IndexedProposals() []govindex.Proposal {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	out := make([]govindex.Proposal, 0, len(h.proposals))
+	for id, p := range h.proposals {
+		indexed := govindex.Proposal{ID: id}
+		if onchainID, err := govindex.HashProposal(p.Targets, p.Values, p.Calldatas, p.Description); err == nil {
+			indexed.OnchainID = onchainID
+		}
+		for _, v := range h.votes[id] {
+			indexed.Votes = append(indexed.Votes, govindex.Vote{Voter: v.Voter, Support: uint8(v.Support), Weight: v.Weight})
+		}
+		out = append(out, indexed)
+	}
+	return out
+}
+
+// SetChainTally replaces a proposal's vote totals with its on-chain tally.
+// Votes cast through the API afterwards are recorded but no longer counted.
+func (h *GovernanceHandler) SetChainTally(id string, forVotes, againstVotes, abstainVotes *big.Int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	proposal, exists := h.proposals[id]
+	if !exists {
+		return
+	}
+	proposal.ForVotes = forVotes.String()
+	proposal.AgainstVotes = againstVotes.String()
+	proposal.AbstainVotes = abstainVotes.String()
+	proposal.TallySource = TallySourceChain
+}
+
+// GetProposalTally handles GET /api/v1/governance/proposals/:id/tally
+// @Summary Get a proposal's on-chain tally
+// @Description Returns the tally of the governor's VoteCast events for a proposal and the API-recorded votes that disagree with them
+// @Tags governance
+// @Produce json
+// @Param id path string true "Proposal ID"
+// @Success 200 {object} ProposalTallyResponse
+// @Failure 404 {object} ProposalTallyResponse
+// @Failure 503 {object} ProposalTallyResponse
+// @Router /api/v1/governance/proposals/{id}/tally [get]
+func (h *GovernanceHandler) GetProposalTally(c *gin.Context) {
+	if h.tallies == nil {
+		c.JSON(http.StatusServiceUnavailable, ProposalTallyResponse{
+			Success: false,
+			Message: "Vote indexing is not enabled",
+		})
+		return
+	}
+
+	proposalID := c.Param("id")
+	if !h.hasProposal(proposalID) {
+		c.JSON(http.StatusNotFound, ProposalTallyResponse{
+			Success: false,
+			Message: "Proposal not found",
+		})
+		return
+	}
+
+	tally, ok := h.tallies.Tally(proposalID)
+	if !ok {
+		c.JSON(http.StatusNotFound, ProposalTallyResponse{
+			Success: false,
+			Message: "Proposal has not been reconciled with the chain yet",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, ProposalTallyResponse{
+		Success: true,
+		Tally:   tally,
+	})
+}
@@ -0,0 +1,84 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/govindex"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/handlers"
+)
+
+// stubTallies serves fixed tallies
+type stubTallies map[string]*govindex.Tally
+
+func (s stubTallies) Tally(id string) (*govindex.Tally, bool) {
+	t, ok := s[id]
+	return t, ok
+}
+
+func TestGovernanceHandler_ChainTally(t *testing.T) {
+	target := "0x" + strings.Repeat("ab", 20)
+	governance := handlers.NewGovernanceHandler(zap.NewNop(), nil, 31337)
+	governance.Seed([]*handlers.Proposal{{
+		ID: "p1", Description: "d", Targets: []string{target}, Values: []string{"0"}, Calldatas: []string{"0x"},
+		State: handlers.ProposalStateActive, StartTime: time.Now().Add(-time.Hour), EndTime: time.Now().Add(time.Hour),
+		ForVotes: "0", AgainstVotes: "0", AbstainVotes: "0",
+	}})
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/governance/proposals/:id", governance.GetProposal)
+	router.GET("/governance/proposals/:id/tally", governance.GetProposalTally)
+	router.POST("/governance/vote", governance.CastVote)
+
+	proposal := func() *handlers.Proposal {
+		t.Helper()
+		w := send(router, http.MethodGet, "/governance/proposals/p1", "", nil)
+		var resp handlers.ProposalResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		return resp.Proposal
+	}
+	vote := func(voter string) {
+		t.Helper()
+		w := send(router, http.MethodPost, "/governance/vote", "", gin.H{"proposal_id": "p1", "voter": voter, "support": 1, "weight": "5"})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	}
+
+	assert.Equal(t, http.StatusServiceUnavailable, send(router, http.MethodGet, "/governance/proposals/p1/tally", "", nil).Code)
+
+	// API votes are counted until the chain tally takes over
+	vote("0x" + strings.Repeat("11", 20))
+	assert.Equal(t, "5", proposal().ForVotes)
+
+	indexed := governance.IndexedProposals()
+	require.Len(t, indexed, 1)
+	onchainID, err := govindex.HashProposal([]string{target}, []string{"0"}, []string{"0x"}, "d")
+	require.NoError(t, err)
+	assert.Equal(t, onchainID, indexed[0].OnchainID)
+	require.Len(t, indexed[0].Votes, 1)
+	assert.Equal(t, govindex.Vote{Voter: "0x" + strings.Repeat("11", 20), Support: govindex.SupportFor, Weight: "5"}, indexed[0].Votes[0])
+
+	governance.SetChainTally("p1", big.NewInt(7), big.NewInt(3), big.NewInt(0))
+	vote("0x" + strings.Repeat("22", 20))
+	p := proposal()
+	assert.Equal(t, handlers.TallySourceChain, p.TallySource)
+	assert.Equal(t, "7", p.ForVotes)
+	assert.Equal(t, "3", p.AgainstVotes)
+
+	governance.SetTallyIndex(stubTallies{"p1": {ProposalID: "p1", ForVotes: "7", Voters: 2}})
+	w := send(router, http.MethodGet, "/governance/proposals/p1/tally", "", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp handlers.ProposalTallyResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, 2, resp.Tally.Voters)
+	assert.Equal(t, http.StatusNotFound, send(router, http.MethodGet, "/governance/proposals/p2/tally", "", nil).Code)
+}
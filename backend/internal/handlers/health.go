@@ -1,12 +1,24 @@
 package handlers
 
 import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
 	"net/http"
 	"runtime"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/breaker"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/chain"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/kpi"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/middleware"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/storage/postgres"
 )
 
 // HealthHandler handles health check endpoints
@@ -16,6 +28,57 @@ type HealthHandler struct {
 	version   string
 	commit    string
 	buildDate string
+	rpcStats  RPCStatsProvider
+	replica   ReplicaStatusProvider
+	pools     PoolStatsProvider
+	kpis      BusinessKPIProvider
+	dbBreaker *breaker.Breaker
+	providers []*breaker.Breaker
+
+	// Startup warm-up checks, run by /startup and /ready until all pass once
+	startupMu     sync.Mutex
+	startupChecks []startupCheck
+	started       atomic.Bool
+
+	// draining is set at shutdown so /ready fails before connections close
+	draining atomic.Bool
+}
+
+// StartupCheck reports an error until a dependency has warmed up (schema
+// applied, RPC reachable, caches primed)
+type StartupCheck func(ctx context.Context) error
+
+// startupCheckTimeout bounds each warm-up check so probes answer promptly
+const startupCheckTimeout = 2 * time.Second
+
+// startupCheck is a named warm-up check and whether it has passed
+type startupCheck struct {
+	name   string
+	check  StartupCheck
+	passed bool
+}
+
+// RPCStatsProvider reports RPC endpoint health (implemented by chain.ClientManager)
+type RPCStatsProvider interface {
+	EndpointStats() []chain.EndpointStats
+}
+
+// ReplicaStatusProvider reports read replica lag and routing (implemented by
+// postgres.ReadRouter)
+type ReplicaStatusProvider interface {
+	ReplicaStatus() postgres.ReplicaStatus
+}
+
+// PoolStatsProvider reports database connection pool utilization
+// (implemented by postgres.PoolMonitor)
+type PoolStatsProvider interface {
+	PoolStats() []postgres.PoolStats
+}
+
+// BusinessKPIProvider renders business KPIs as OpenMetrics (implemented by
+// kpi.Exporter)
+type BusinessKPIProvider interface {
+	WriteOpenMetrics(ctx context.Context, w io.Writer) error
 }
 
 // HealthResponse represents the health check response
@@ -36,6 +99,13 @@ type Check struct {
 	Latency string `json:"latency,omitempty"`
 }
 
+// StartupResponse represents the startup probe response
+type StartupResponse struct {
+	Started   bool             `json:"started"`
+	Timestamp string           `json:"timestamp"`
+	Checks    map[string]Check `json:"checks,omitempty"`
+}
+
 // ReadinessResponse represents the readiness check response
 type ReadinessResponse struct {
 	Ready     bool              `json:"ready"`
@@ -52,6 +122,8 @@ type MetricsResponse struct {
 	MemAlloc      uint64 `json:"memory_alloc_mb"`
 	MemSys        uint64 `json:"memory_sys_mb"`
 	NumGC         uint32 `json:"num_gc"`
+
+	DatabasePools []postgres.PoolStats `json:"database_pools,omitempty"`
 }
 
 // NewHealthHandler creates a new health handler
@@ -65,9 +137,55 @@ func NewHealthHandler(logger *zap.Logger, version, commit, buildDate string) *He
 	}
 }
 
+// SetRPCStats enables RPC endpoint health reporting
+func (h *HealthHandler) SetRPCStats(provider RPCStatsProvider) {
+	h.rpcStats = provider
+}
+
+// SetReplicaStatus enables read replica health reporting
+func (h *HealthHandler) SetReplicaStatus(provider ReplicaStatusProvider) {
+	h.replica = provider
+}
+
+// SetPoolStats enables connection pool utilization in /metrics
+func (h *HealthHandler) SetPoolStats(provider PoolStatsProvider) {
+	h.pools = provider
+}
+
+// SetBusinessKPIs enables GET /metrics/business
+func (h *HealthHandler) SetBusinessKPIs(provider BusinessKPIProvider) {
+	h.kpis = provider
+}
+
+// SetDatabaseBreaker makes the database check report the repository circuit breaker
+func (h *HealthHandler) SetDatabaseBreaker(b *breaker.Breaker) {
+	h.dbBreaker = b
+}
+
+// AddProviderBreakers reports external provider breakers (Stripe, Sumsub) in
+// the detailed health check. Providers are optional and never fail the check.
+func (h *HealthHandler) AddProviderBreakers(breakers ...*breaker.Breaker) {
+	h.providers = append(h.providers, breakers...)
+}
+
+// AddStartupCheck registers a warm-up check. /startup and /ready fail until
+// every check has passed once; after that they are not run again.
+func (h *HealthHandler) AddStartupCheck(name string, check StartupCheck) {
+	h.startupMu.Lock()
+	defer h.startupMu.Unlock()
+	h.startupChecks = append(h.startupChecks, startupCheck{name: name, check: check})
+	h.started.Store(false)
+}
+
+// StartDraining makes /ready fail from now on, so load balancers stop sending
+// traffic before the server closes its connections
+func (h *HealthHandler) StartDraining() {
+	h.draining.Store(true)
+}
+
 // Health handles GET /health
 // @Summary Health check
-// @Description Returns the health status of the API
+// @Description Returns the health status of the API. With HEALTH_API_TOKEN set, the version, commit, build date and uptime are only included for requests bearing the token.
 // @Tags health
 // @Produce json
 // @Success 200 {object} HealthResponse
@@ -76,10 +194,12 @@ func (h *HealthHandler) Health(c *gin.Context) {
 	response := HealthResponse{
 		Status:    "healthy",
 		Timestamp: time.Now().UTC().Format(time.RFC3339),
-		Version:   h.version,
-		Commit:    h.commit,
-		BuildDate: h.buildDate,
-		Uptime:    time.Since(h.startTime).Round(time.Second).String(),
+	}
+	if middleware.HealthVerbose(c) {
+		response.Version = h.version
+		response.Commit = h.commit
+		response.BuildDate = h.buildDate
+		response.Uptime = time.Since(h.startTime).Round(time.Second).String()
 	}
 
 	c.JSON(http.StatusOK, response)
@@ -92,6 +212,7 @@ func (h *HealthHandler) Health(c *gin.Context) {
 // @Produce json
 // @Success 200 {object} HealthResponse
 // @Failure 503 {object} HealthResponse
+// @Failure 401 {object} map[string]interface{} "HEALTH_API_TOKEN is set and the request does not bear it"
 // @Router /health/detailed [get]
 func (h *HealthHandler) HealthDetailed(c *gin.Context) {
 	checks := make(map[string]Check)
@@ -104,6 +225,17 @@ func (h *HealthHandler) HealthDetailed(c *gin.Context) {
 		allHealthy = false
 	}
 
+	// Check read replica (if configured). Reads fall back to the primary, so a
+	// lagging or unreachable replica degrades the check without failing it.
+	if h.replica != nil {
+		checks["database_replica"] = h.checkReplica()
+	}
+
+	// External providers (optional, don't fail health check)
+	for _, b := range h.providers {
+		checks[b.Name()] = breakerCheck(b)
+	}
+
 	// Check cache
 	cacheCheck := h.checkCache()
 	checks["cache"] = cacheCheck
@@ -136,9 +268,32 @@ func (h *HealthHandler) HealthDetailed(c *gin.Context) {
 	c.JSON(httpStatus, response)
 }
 
+// Startup handles GET /startup
+// @Summary Startup check
+// @Description Returns whether the API has finished warming up (for Kubernetes startup probes). Warm-up checks are retried on every call until they all pass once.
+// @Tags health
+// @Produce json
+// @Success 200 {object} StartupResponse
+// @Failure 503 {object} StartupResponse
+// @Router /startup [get]
+func (h *HealthHandler) Startup(c *gin.Context) {
+	started, checks := h.runStartupChecks(c.Request.Context())
+
+	httpStatus := http.StatusOK
+	if !started {
+		httpStatus = http.StatusServiceUnavailable
+	}
+
+	c.JSON(httpStatus, StartupResponse{
+		Started:   started,
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+		Checks:    checks,
+	})
+}
+
 // Ready handles GET /ready
 // @Summary Readiness check
-// @Description Returns whether the API is ready to serve traffic
+// @Description Returns whether the API is ready to serve traffic: warm-up has finished, the database is reachable and the server is not shutting down
 // @Tags health
 // @Produce json
 // @Success 200 {object} ReadinessResponse
@@ -148,6 +303,22 @@ func (h *HealthHandler) Ready(c *gin.Context) {
 	checks := make(map[string]Check)
 	ready := true
 
+	if h.draining.Load() {
+		checks["shutdown"] = Check{
+			Status:  "unhealthy",
+			Message: "Shutting down, draining connections",
+		}
+		ready = false
+	}
+
+	started, startupChecks := h.runStartupChecks(c.Request.Context())
+	for name, check := range startupChecks {
+		checks[name] = check
+	}
+	if !started {
+		ready = false
+	}
+
 	// For readiness, we check if we can serve requests
 	dbCheck := h.checkDatabase()
 	checks["database"] = dbCheck
@@ -169,6 +340,53 @@ func (h *HealthHandler) Ready(c *gin.Context) {
 	c.JSON(httpStatus, response)
 }
 
+// runStartupChecks runs the warm-up checks that have not passed yet and
+// reports whether all have. Once they have, no checks are run or reported.
+func (h *HealthHandler) runStartupChecks(ctx context.Context) (bool, map[string]Check) {
+	if h.started.Load() {
+		return true, nil
+	}
+
+	h.startupMu.Lock()
+	defer h.startupMu.Unlock()
+
+	checks := make(map[string]Check, len(h.startupChecks))
+	started := true
+	for i := range h.startupChecks {
+		sc := &h.startupChecks[i]
+		if sc.passed {
+			checks[sc.name] = Check{Status: "healthy", Message: "Warmed up"}
+			continue
+		}
+
+		start := time.Now()
+		checkCtx, cancel := context.WithTimeout(ctx, startupCheckTimeout)
+		err := sc.check(checkCtx)
+		cancel()
+		if err != nil {
+			checks[sc.name] = Check{
+				Status:  "unhealthy",
+				Message: "Warming up: " + err.Error(),
+				Latency: time.Since(start).String(),
+			}
+			started = false
+			continue
+		}
+
+		sc.passed = true
+		checks[sc.name] = Check{
+			Status:  "healthy",
+			Message: "Warmed up",
+			Latency: time.Since(start).String(),
+		}
+	}
+
+	if started {
+		h.started.Store(true)
+	}
+	return started, checks
+}
+
 // Live handles GET /live
 // @Summary Liveness check
 // @Description Returns whether the API is alive (for Kubernetes liveness probes)
@@ -190,6 +408,7 @@ func (h *HealthHandler) Live(c *gin.Context) {
 // @Tags health
 // @Produce json
 // @Success 200 {object} MetricsResponse
+// @Failure 401 {object} map[string]interface{} "HEALTH_API_TOKEN is set and the request does not bear it"
 // @Router /metrics [get]
 func (h *HealthHandler) Metrics(c *gin.Context) {
 	var m runtime.MemStats
@@ -204,16 +423,45 @@ func (h *HealthHandler) Metrics(c *gin.Context) {
 		MemSys:        m.Sys / 1024 / 1024,
 		NumGC:         m.NumGC,
 	}
+	if h.pools != nil {
+		response.DatabasePools = h.pools.PoolStats()
+	}
 
 	c.JSON(http.StatusOK, response)
 }
 
+// BusinessMetrics handles GET /metrics/business
+// @Summary Business KPIs
+// @Description Returns payment, KYC, relay and NFT KPIs in the OpenMetrics text format for Prometheus scraping
+// @Tags health
+// @Produce plain
+// @Success 200 {string} string
+// @Failure 503 {string} string
+// @Failure 401 {object} map[string]interface{} "HEALTH_API_TOKEN is set and the request does not bear it"
+// @Router /metrics/business [get]
+func (h *HealthHandler) BusinessMetrics(c *gin.Context) {
+	if h.kpis == nil {
+		c.String(http.StatusNotFound, "business metrics not configured\n")
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := h.kpis.WriteOpenMetrics(c.Request.Context(), &buf); err != nil {
+		h.logger.Error("failed to export business metrics", zap.Error(err))
+		c.String(http.StatusServiceUnavailable, "business metrics unavailable\n")
+		return
+	}
+
+	c.Data(http.StatusOK, kpi.ContentType, buf.Bytes())
+}
+
 // Version handles GET /version
 // @Summary Version information
 // @Description Returns the API version and build information
 // @Tags health
 // @Produce json
 // @Success 200 {object} map[string]string
+// @Failure 401 {object} map[string]interface{} "HEALTH_API_TOKEN is set and the request does not bear it"
 // @Router /version [get]
 func (h *HealthHandler) Version(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{
@@ -228,6 +476,10 @@ func (h *HealthHandler) Version(c *gin.Context) {
 
 // checkDatabase checks database connectivity
 func (h *HealthHandler) checkDatabase() Check {
+	if h.dbBreaker != nil {
+		return breakerCheck(h.dbBreaker)
+	}
+
 	start := time.Now()
 
 	// In production, this would ping the actual database
@@ -241,6 +493,46 @@ func (h *HealthHandler) checkDatabase() Check {
 	}
 }
 
+// breakerCheck reports a dependency by its circuit breaker state
+func breakerCheck(b *breaker.Breaker) Check {
+	stats := b.Stats()
+
+	switch stats.State {
+	case breaker.StateOpen.String():
+		return Check{
+			Status:  "unhealthy",
+			Message: "Circuit breaker open: " + stats.LastError,
+		}
+	case breaker.StateHalfOpen.String():
+		return Check{
+			Status:  "degraded",
+			Message: "Circuit breaker half-open, probing recovery",
+		}
+	default:
+		return Check{
+			Status:  "healthy",
+			Message: "Circuit breaker closed",
+		}
+	}
+}
+
+// checkReplica reports read replica reachability and replication lag
+func (h *HealthHandler) checkReplica() Check {
+	status := h.replica.ReplicaStatus()
+
+	if status.Routing == postgres.RouteReplica {
+		return Check{
+			Status:  "healthy",
+			Message: fmt.Sprintf("Serving reads, replication lag %s (max %s)", status.Lag.Round(time.Millisecond), status.MaxLag),
+		}
+	}
+
+	return Check{
+		Status:  "degraded",
+		Message: fmt.Sprintf("Reads routed to primary: %s", status.Error),
+	}
+}
+
 // checkCache checks cache connectivity
 func (h *HealthHandler) checkCache() Check {
 	start := time.Now()
@@ -260,17 +552,67 @@ func (h *HealthHandler) checkCache() Check {
 func (h *HealthHandler) checkBlockchain() Check {
 	start := time.Now()
 
-	// In production, this would check RPC connection
-	// For demo, return optional/unconfigured status
-	latency := time.Since(start)
+	if h.rpcStats == nil {
+		return Check{
+			Status:  "healthy",
+			Message: "Blockchain RPC not configured (optional)",
+			Latency: time.Since(start).String(),
+		}
+	}
+
+	stats := h.rpcStats.EndpointStats()
+	healthy := 0
+	var bestLatency float64
+	for _, st := range stats {
+		if st.Healthy {
+			healthy++
+			if bestLatency == 0 || st.LatencyMs < bestLatency {
+				bestLatency = st.LatencyMs
+			}
+		}
+	}
+
+	status := "healthy"
+	switch {
+	case len(stats) == 0:
+		return Check{
+			Status:  "healthy",
+			Message: "No RPC endpoints in use yet",
+			Latency: time.Since(start).String(),
+		}
+	case healthy == 0:
+		status = "unhealthy"
+	case healthy < len(stats):
+		status = "degraded"
+	}
 
 	return Check{
-		Status:  "healthy",
-		Message: "Blockchain RPC not configured (optional)",
-		Latency: latency.String(),
+		Status:  status,
+		Message: fmt.Sprintf("%d/%d RPC endpoints healthy", healthy, len(stats)),
+		Latency: time.Duration(bestLatency * float64(time.Millisecond)).String(),
 	}
 }
 
+// RPCHealth handles GET /health/rpc
+// @Summary RPC endpoint health
+// @Description Returns health, failover state and latency metrics for every RPC endpoint in use
+// @Tags health
+// @Produce json
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{} "HEALTH_API_TOKEN is set and the request does not bear it"
+// @Router /health/rpc [get]
+func (h *HealthHandler) RPCHealth(c *gin.Context) {
+	stats := []chain.EndpointStats{}
+	if h.rpcStats != nil {
+		stats = append(stats, h.rpcStats.EndpointStats()...)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"timestamp": time.Now().UTC().Format(time.RFC3339),
+		"endpoints": stats,
+	})
+}
+
 // Ping handles GET /ping
 // @Summary Ping
 // @Description Simple ping endpoint for load balancer health checks
@@ -1,7 +1,10 @@
 package handlers_test
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -13,6 +16,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/colemanwhaylon/nexus-protocol/backend/internal/handlers"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/storage/postgres"
 )
 
 // Helper functions for health tests
@@ -24,7 +28,9 @@ func setupHealthTestRouter(handler *handlers.HealthHandler) *gin.Engine {
 	router.GET("/health/detailed", handler.HealthDetailed)
 	router.GET("/ready", handler.Ready)
 	router.GET("/live", handler.Live)
+	router.GET("/startup", handler.Startup)
 	router.GET("/metrics", handler.Metrics)
+	router.GET("/metrics/business", handler.BusinessMetrics)
 	router.GET("/version", handler.Version)
 	router.GET("/ping", handler.Ping)
 
@@ -141,6 +147,65 @@ func TestHealthHandler_HealthDetailed(t *testing.T) {
 	}
 }
 
+// fakeReplica reports a fixed replica status
+type fakeReplica struct {
+	status postgres.ReplicaStatus
+}
+
+func (f *fakeReplica) ReplicaStatus() postgres.ReplicaStatus {
+	return f.status
+}
+
+func TestHealthHandler_HealthDetailed_Replica(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        postgres.ReplicaStatus
+		expectedCheck string
+	}{
+		{
+			name:          "replica within lag serves reads",
+			status:        postgres.ReplicaStatus{Healthy: true, Lag: 200 * time.Millisecond, MaxLag: 10 * time.Second, Routing: postgres.RouteReplica},
+			expectedCheck: "healthy",
+		},
+		{
+			name:          "lagging replica falls back to primary",
+			status:        postgres.ReplicaStatus{Healthy: true, Lag: time.Minute, MaxLag: 10 * time.Second, Routing: postgres.RoutePrimary, Error: "replication lag 1m0s exceeds 10s"},
+			expectedCheck: "degraded",
+		},
+		{
+			name:          "unreachable replica falls back to primary",
+			status:        postgres.ReplicaStatus{Routing: postgres.RoutePrimary, Error: "connection refused"},
+			expectedCheck: "degraded",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := createTestHealthHandler()
+			handler.SetReplicaStatus(&fakeReplica{status: tt.status})
+			router := setupHealthTestRouter(handler)
+
+			req, _ := http.NewRequest("GET", "/health/detailed", nil)
+			resp := httptest.NewRecorder()
+			router.ServeHTTP(resp, req)
+
+			// Reads still work on the primary, so the overall status is unaffected
+			assert.Equal(t, http.StatusOK, resp.Code)
+
+			var body handlers.HealthResponse
+			require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &body))
+			assert.Equal(t, "healthy", body.Status)
+
+			check, ok := body.Checks["database_replica"]
+			require.True(t, ok, "replica check should be present")
+			assert.Equal(t, tt.expectedCheck, check.Status)
+			if tt.status.Error != "" {
+				assert.Contains(t, check.Message, tt.status.Error)
+			}
+		})
+	}
+}
+
 // Tests for Ready endpoint
 func TestHealthHandler_Ready(t *testing.T) {
 	tests := []struct {
@@ -186,6 +251,53 @@ func TestHealthHandler_Ready(t *testing.T) {
 	}
 }
 
+func TestHealthHandler_StartupAndDraining(t *testing.T) {
+	handler := createTestHealthHandler()
+	router := setupHealthTestRouter(handler)
+
+	rpcCalls := 0
+	rpcErr := errors.New("dial tcp: connection refused")
+	handler.AddStartupCheck("schema", func(ctx context.Context) error { return nil })
+	handler.AddStartupCheck("rpc", func(ctx context.Context) error {
+		rpcCalls++
+		return rpcErr
+	})
+
+	get := func(path string) (int, map[string]interface{}) {
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, path, nil))
+		var body map[string]interface{}
+		require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &body))
+		return resp.Code, body
+	}
+
+	code, body := get("/startup")
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.False(t, body["started"].(bool))
+	checks := body["checks"].(map[string]interface{})
+	assert.Equal(t, "healthy", checks["schema"].(map[string]interface{})["status"])
+	assert.Contains(t, checks["rpc"].(map[string]interface{})["message"], "connection refused")
+
+	code, body = get("/ready")
+	assert.Equal(t, http.StatusServiceUnavailable, code, "not ready until warmed up")
+	assert.Contains(t, body["checks"], "rpc")
+
+	rpcErr = nil
+	code, _ = get("/ready")
+	assert.Equal(t, http.StatusOK, code)
+	code, body = get("/startup")
+	assert.Equal(t, http.StatusOK, code)
+	assert.True(t, body["started"].(bool))
+	assert.Equal(t, 3, rpcCalls, "passed checks are not run again")
+
+	handler.StartDraining()
+	code, body = get("/ready")
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Contains(t, body["checks"], "shutdown")
+	code, _ = get("/live")
+	assert.Equal(t, http.StatusOK, code, "liveness is unaffected by draining")
+}
+
 // Tests for Live endpoint
 func TestHealthHandler_Live(t *testing.T) {
 	tests := []struct {
@@ -278,6 +390,80 @@ func TestHealthHandler_Metrics(t *testing.T) {
 	}
 }
 
+// fakePools reports fixed pool statistics
+type fakePools struct {
+	stats []postgres.PoolStats
+}
+
+func (f *fakePools) PoolStats() []postgres.PoolStats {
+	return f.stats
+}
+
+func TestHealthHandler_Metrics_DatabasePools(t *testing.T) {
+	handler := createTestHealthHandler()
+	handler.SetPoolStats(&fakePools{stats: []postgres.PoolStats{
+		{Name: "primary", MaxOpen: 25, Open: 10, InUse: 5, Idle: 5, Utilization: 0.2, WaitCount: 3},
+	}})
+	router := setupHealthTestRouter(handler)
+
+	req, _ := http.NewRequest("GET", "/metrics", nil)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	var body handlers.MetricsResponse
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &body))
+	require.Len(t, body.DatabasePools, 1)
+	assert.Equal(t, "primary", body.DatabasePools[0].Name)
+	assert.Equal(t, 5, body.DatabasePools[0].InUse)
+	assert.InDelta(t, 0.2, body.DatabasePools[0].Utilization, 0.001)
+	assert.Equal(t, int64(3), body.DatabasePools[0].WaitCount)
+}
+
+// fakeKPIs writes a fixed OpenMetrics body or fails
+type fakeKPIs struct {
+	err error
+}
+
+func (f *fakeKPIs) WriteOpenMetrics(ctx context.Context, w io.Writer) error {
+	if f.err != nil {
+		return f.err
+	}
+	_, err := io.WriteString(w, "# TYPE nexus_payments gauge\nnexus_payments{status=\"completed\"} 3\n# EOF\n")
+	return err
+}
+
+func TestHealthHandler_BusinessMetrics(t *testing.T) {
+	t.Run("not configured", func(t *testing.T) {
+		router := setupHealthTestRouter(createTestHealthHandler())
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, httptest.NewRequest("GET", "/metrics/business", nil))
+		assert.Equal(t, http.StatusNotFound, resp.Code)
+	})
+
+	t.Run("exports openmetrics", func(t *testing.T) {
+		handler := createTestHealthHandler()
+		handler.SetBusinessKPIs(&fakeKPIs{})
+		router := setupHealthTestRouter(handler)
+
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, httptest.NewRequest("GET", "/metrics/business", nil))
+		require.Equal(t, http.StatusOK, resp.Code)
+		assert.Contains(t, resp.Header().Get("Content-Type"), "application/openmetrics-text")
+		assert.Contains(t, resp.Body.String(), `nexus_payments{status="completed"} 3`)
+	})
+
+	t.Run("database unavailable", func(t *testing.T) {
+		handler := createTestHealthHandler()
+		handler.SetBusinessKPIs(&fakeKPIs{err: errors.New("connection refused")})
+		router := setupHealthTestRouter(handler)
+
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, httptest.NewRequest("GET", "/metrics/business", nil))
+		assert.Equal(t, http.StatusServiceUnavailable, resp.Code)
+	})
+}
+
 // Tests for Version endpoint
 func TestHealthHandler_Version(t *testing.T) {
 	tests := []struct {
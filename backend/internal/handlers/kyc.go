@@ -12,6 +12,10 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/emailverify"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/middleware"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
 )
 
 // KYCHandler handles KYC-related API endpoints
@@ -22,8 +26,28 @@ type KYCHandler struct {
 	whitelist      map[string]bool
 	blacklist      map[string]bool
 	complianceOfficers map[string]bool
+	// Deprecated: in-memory audit log, kept only until SetAuditStore is called
 	auditLog       []*AuditLogEntry
+	auditStore     repository.KYCAuditRepository // persistent audit log (see kyc_audit.go)
 	jurisdictions  map[string]*JurisdictionConfig
+	// Email verification for KYCLevelBasic (see kyc_email.go)
+	emailVerifier *emailverify.Service
+	emailLinkURL  string
+	// Accreditation attestations (see kyc_accreditation.go)
+	documents             DocumentStore
+	attestations          map[string]*AccreditationAttestation   // ID -> attestation
+	attestationsByAddress map[string][]*AccreditationAttestation // address -> its attestations, oldest first
+	// geo resolves audit entries' IPs to countries (nil = not recorded)
+	geo middleware.CountryResolver
+	// Consent to KYC data processing (see kyc_consent.go)
+	consentVersion string                      // privacy policy version registrations must accept ("" = none)
+	consents       map[string][]*ConsentRecord // address -> its consents, oldest first
+	// Review queue (see kyc_queue.go)
+	queueSLA      map[KYCLevel]time.Duration
+	assignments   map[string]*KYCAssignment // address -> officer reviewing its pending registration
+	verifications KYCVerificationSource
+	// Two-person approval (see kyc_four_eyes.go)
+	fourEyesRiskScore uint8
 }
 
 // KYCStatus represents the KYC verification status
@@ -35,6 +59,8 @@ const (
 	KYCStatusRejected  KYCStatus = "rejected"
 	KYCStatusExpired   KYCStatus = "expired"
 	KYCStatusSuspended KYCStatus = "suspended"
+	// Approved by one officer, awaiting a second (see kyc_four_eyes.go)
+	KYCStatusPendingSecondApproval KYCStatus = "pending_second_approval"
 )
 
 // KYCLevel represents the verification level
@@ -59,10 +85,19 @@ type KYCRegistration struct {
 	SuspensionReason  string    `json:"suspension_reason,omitempty"`
 	DocumentHash      string    `json:"document_hash,omitempty"` // Hash of submitted documents
 	RiskScore         uint8     `json:"risk_score"` // 0-100, higher = more risk
-	AccreditedInvestor bool     `json:"accredited_investor"`
+	AccreditedInvestor bool     `json:"accredited_investor"` // set by an approved attestation
+	AccreditationExpiresAt *time.Time `json:"accreditation_expires_at,omitempty"`
 	CreatedAt         time.Time `json:"created_at"`
 	UpdatedAt         time.Time `json:"updated_at"`
+	Version           int64     `json:"version"` // incremented by every change, see concurrency.go
 	ReviewedBy        string    `json:"reviewed_by,omitempty"`
+	// Email is the verified email, encrypted under emailverify.EmailField
+	Email           string     `json:"-"`
+	EmailVerifiedAt *time.Time `json:"email_verified_at,omitempty"`
+	// First of two approvals, for registrations that need both (see kyc_four_eyes.go)
+	FirstApprovedBy string     `json:"first_approved_by,omitempty"`
+	FirstApprovedAt *time.Time `json:"first_approved_at,omitempty"`
+	ApprovalLevel   KYCLevel   `json:"approval_level,omitempty"` // granted by the second approval
 }
 
 // JurisdictionConfig represents jurisdiction-specific settings
@@ -74,6 +109,7 @@ type JurisdictionConfig struct {
 	MaxTransactionUSD uint64   `json:"max_transaction_usd"`
 	RequiresAccredited bool    `json:"requires_accredited"`
 	Restricted        bool     `json:"restricted"` // OFAC or similar restrictions
+	HighRisk          bool     `json:"high_risk"`  // approvals need two officers (see kyc_four_eyes.go)
 }
 
 // AuditLogEntry represents a compliance audit log entry
@@ -85,6 +121,7 @@ type AuditLogEntry struct {
 	Subject       string    `json:"subject"`
 	Details       string    `json:"details"`
 	IPAddress     string    `json:"ip_address,omitempty"`
+	Country       string    `json:"country,omitempty"` // of IPAddress, when GeoIP is configured
 	PreviousState string    `json:"previous_state,omitempty"`
 	NewState      string    `json:"new_state,omitempty"`
 }
@@ -94,7 +131,8 @@ type RegisterKYCRequest struct {
 	Address           string `json:"address" binding:"required"`
 	Jurisdiction      string `json:"jurisdiction" binding:"required"`
 	DocumentHash      string `json:"document_hash,omitempty"`
-	AccreditedInvestor bool  `json:"accredited_investor"`
+	// Consent to the current privacy policy, unless the address accepted it before
+	Consent *KYCConsent `json:"consent,omitempty"`
 }
 
 // UpdateKYCRequest represents a KYC update request
@@ -105,6 +143,11 @@ type UpdateKYCRequest struct {
 	RejectionReason  string    `json:"rejection_reason,omitempty"`
 	SuspensionReason string    `json:"suspension_reason,omitempty"`
 	Reviewer         string    `json:"reviewer" binding:"required"`
+	// RiskScore can only raise the registration's score, so an officer
+	// cannot lower it to approve a high-risk registration alone
+	RiskScore        *uint8    `json:"risk_score,omitempty" binding:"omitempty,max=100"`
+	// ExpectedVersion is the version the update is based on (or If-Match)
+	ExpectedVersion *int64 `json:"expected_version,omitempty"`
 }
 
 // WhitelistRequest represents a whitelist/blacklist update request
@@ -135,8 +178,11 @@ type AuditLogResponse struct {
 	Success bool             `json:"success"`
 	Entries []*AuditLogEntry `json:"entries"`
 	Total   int              `json:"total"`
-	Page    int              `json:"page"`
+	Page    int              `json:"page,omitempty"` // in-memory log only
 	PageSize int             `json:"page_size"`
+	// NextCursor fetches the next (older) page from the audit store
+	NextCursor string `json:"next_cursor,omitempty"`
+	Message    string `json:"message,omitempty"`
 }
 
 // ComplianceCheckResponse represents a compliance check result
@@ -152,6 +198,8 @@ type ComplianceCheckResponse struct {
 	CanTransact     bool     `json:"can_transact"`
 	MaxTransaction  string   `json:"max_transaction,omitempty"`
 	Restrictions    []string `json:"restrictions,omitempty"`
+	AccreditedInvestor bool                  `json:"accredited_investor"`
+	Accreditation      *AccreditationSummary `json:"accreditation,omitempty"`
 	Message         string   `json:"message,omitempty"`
 }
 
@@ -165,21 +213,28 @@ func NewKYCHandler(logger *zap.Logger) *KYCHandler {
 		complianceOfficers: make(map[string]bool),
 		auditLog:           make([]*AuditLogEntry, 0),
 		jurisdictions:      make(map[string]*JurisdictionConfig),
+		attestations:          make(map[string]*AccreditationAttestation),
+		attestationsByAddress: make(map[string][]*AccreditationAttestation),
+		consents:              make(map[string][]*ConsentRecord),
+		queueSLA:              make(map[KYCLevel]time.Duration, len(DefaultKYCQueueSLA)),
+		assignments:           make(map[string]*KYCAssignment),
+		fourEyesRiskScore:     DefaultFourEyesRiskScore,
+	}
+	for level, target := range DefaultKYCQueueSLA {
+		h.queueSLA[level] = target
 	}
 
 	// Initialize jurisdictions
 	h.initializeJurisdictions()
 
-	// Add demo compliance officers
-	h.complianceOfficers["0x0000000000000000000000000000000000000001"] = true
-	h.complianceOfficers["0x0000000000000000000000000000000000000002"] = true
-
-	// Seed demo KYC data
-	h.seedDemoKYC()
-
 	return h
 }
 
+// SetGeoIP records the country of each audit entry's IP
+func (h *KYCHandler) SetGeoIP(resolver middleware.CountryResolver) {
+	h.geo = resolver
+}
+
 // initializeJurisdictions sets up jurisdiction configurations
 func (h *KYCHandler) initializeJurisdictions() {
 	// Major jurisdictions - simplified for demo
@@ -211,47 +266,6 @@ func (h *KYCHandler) initializeJurisdictions() {
 	}
 }
 
-// seedDemoKYC initializes demo KYC registrations
-func (h *KYCHandler) seedDemoKYC() {
-	now := time.Now()
-	expiry := now.Add(365 * 24 * time.Hour) // 1 year expiry
-
-	// Approved user
-	approvedUser := &KYCRegistration{
-		Address:           "0x0000000000000000000000000000000000000003",
-		Status:            KYCStatusApproved,
-		Level:             KYCLevelAdvanced,
-		Jurisdiction:      "US",
-		VerifiedAt:        &now,
-		ExpiresAt:         &expiry,
-		DocumentHash:      "0x" + strings.Repeat("a", 64),
-		RiskScore:         15,
-		AccreditedInvestor: true,
-		CreatedAt:         now.Add(-30 * 24 * time.Hour),
-		UpdatedAt:         now,
-		ReviewedBy:        "0x0000000000000000000000000000000000000001",
-	}
-	h.registrations[approvedUser.Address] = approvedUser
-	h.whitelist[approvedUser.Address] = true
-
-	// Pending user
-	pendingUser := &KYCRegistration{
-		Address:           "0x0000000000000000000000000000000000000004",
-		Status:            KYCStatusPending,
-		Level:             KYCLevelNone,
-		Jurisdiction:      "GB",
-		DocumentHash:      "0x" + strings.Repeat("b", 64),
-		RiskScore:         0,
-		AccreditedInvestor: false,
-		CreatedAt:         now.Add(-2 * 24 * time.Hour),
-		UpdatedAt:         now.Add(-2 * 24 * time.Hour),
-	}
-	h.registrations[pendingUser.Address] = pendingUser
-
-	// Log seed actions
-	h.addAuditLog("SEED_DATA", "system", "system", "Demo KYC data initialized", "", "", "")
-}
-
 // generateAuditID generates a unique audit log ID
 func (h *KYCHandler) generateAuditID() string {
 	data := time.Now().String() + strconv.Itoa(len(h.auditLog))
@@ -272,6 +286,13 @@ func (h *KYCHandler) addAuditLog(action, actor, subject, details, ip, prevState,
 		PreviousState: prevState,
 		NewState:      newState,
 	}
+	if h.geo != nil && ip != "" {
+		entry.Country = h.geo.Country(ip)
+	}
+	if h.auditStore != nil {
+		h.appendAudit(entry)
+		return
+	}
 	h.auditLog = append(h.auditLog, entry)
 }
 
@@ -326,12 +347,21 @@ func (h *KYCHandler) Register(c *gin.Context) {
 
 	address := strings.ToLower(req.Address)
 
+	consent, status, message := h.verifyConsent(c, address, req.Consent)
+	if status != 0 {
+		c.JSON(status, KYCResponse{
+			Success: false,
+			Message: message,
+		})
+		return
+	}
+
 	h.mu.Lock()
 	defer h.mu.Unlock()
 
 	// Check if already registered
 	if existing, exists := h.registrations[address]; exists {
-		if existing.Status == KYCStatusPending || existing.Status == KYCStatusApproved {
+		if existing.Status == KYCStatusPending || existing.Status == KYCStatusPendingSecondApproval || existing.Status == KYCStatusApproved {
 			c.JSON(http.StatusBadRequest, KYCResponse{
 				Success: false,
 				Message: "Address already has a KYC registration",
@@ -349,7 +379,21 @@ func (h *KYCHandler) Register(c *gin.Context) {
 		return
 	}
 
+	// Check consent to the current privacy policy
+	if h.consentVersion != "" && consent == nil && !h.hasConsented(address) {
+		c.JSON(http.StatusForbidden, KYCResponse{
+			Success: false,
+			Message: "Consent to privacy policy version " + h.consentVersion + " is required",
+		})
+		return
+	}
+
 	now := time.Now()
+	version := int64(1)
+	if existing, exists := h.registrations[address]; exists {
+		// A rejected or expired registration is replaced; its version carries on
+		version = existing.Version + 1
+	}
 	registration := &KYCRegistration{
 		Address:           address,
 		Status:            KYCStatusPending,
@@ -357,12 +401,16 @@ func (h *KYCHandler) Register(c *gin.Context) {
 		Jurisdiction:      req.Jurisdiction,
 		DocumentHash:      req.DocumentHash,
 		RiskScore:         0,
-		AccreditedInvestor: req.AccreditedInvestor,
 		CreatedAt:         now,
 		UpdatedAt:         now,
+		Version:           version,
 	}
 
+	if consent != nil {
+		h.recordConsent(consent)
+	}
 	h.registrations[address] = registration
+	delete(h.assignments, address) // a replaced registration queues afresh
 	h.addAuditLog("KYC_REGISTER", address, address, "New KYC registration submitted", c.ClientIP(), "", string(KYCStatusPending))
 
 	h.logger.Info("KYC registration submitted",
@@ -422,24 +470,27 @@ func (h *KYCHandler) GetKYCStatus(c *gin.Context) {
 		zap.String("status", string(registration.Status)),
 	)
 
+	setVersionETag(c, registration.Version)
 	c.JSON(http.StatusOK, KYCResponse{
 		Success:      true,
 		Registration: registration,
 	})
 }
 
-// UpdateKYC handles POST /api/v1/kyc/update
+// UpdateKYC handles POST /api/v1/admin/kyc/update
 // @Summary Update KYC status
-// @Description Updates KYC status (compliance officer only)
+// @Description Updates KYC status (compliance officer only). With If-Match (the ETag of the status endpoint) or expected_version the update only applies to that version; otherwise 409 returns the current registration.
 // @Tags kyc
 // @Accept json
 // @Produce json
+// @Param If-Match header string false "Version the update is based on, e.g. \"3\""
 // @Param request body UpdateKYCRequest true "KYC update request"
 // @Success 200 {object} KYCResponse
 // @Failure 400 {object} KYCResponse
 // @Failure 403 {object} KYCResponse
 // @Failure 404 {object} KYCResponse
-// @Router /api/v1/kyc/update [post]
+// @Failure 409 {object} KYCResponse
+// @Router /api/v1/admin/kyc/update [post]
 func (h *KYCHandler) UpdateKYC(c *gin.Context) {
 	var req UpdateKYCRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -460,6 +511,15 @@ func (h *KYCHandler) UpdateKYC(c *gin.Context) {
 		return
 	}
 
+	version, err := expectedVersion(c, req.ExpectedVersion)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, KYCResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
 	address := strings.ToLower(req.Address)
 	reviewer := strings.ToLower(req.Reviewer)
 
@@ -483,6 +543,17 @@ func (h *KYCHandler) UpdateKYC(c *gin.Context) {
 		})
 		return
 	}
+	if version != nil && *version != registration.Version {
+		c.JSON(http.StatusConflict, KYCResponse{
+			Success:      false,
+			Registration: registration,
+			Message:      "KYC registration was changed by another update; review the current version and retry",
+		})
+		return
+	}
+	if h.fourEyes(c, registration, reviewer, &req) {
+		return
+	}
 
 	prevStatus := registration.Status
 	now := time.Now()
@@ -490,19 +561,22 @@ func (h *KYCHandler) UpdateKYC(c *gin.Context) {
 	// Update registration
 	registration.Status = req.Status
 	registration.UpdatedAt = now
+	registration.Version++
 	registration.ReviewedBy = reviewer
 
 	if req.Level > 0 {
 		registration.Level = req.Level
 	}
+	if req.RiskScore != nil && *req.RiskScore > registration.RiskScore {
+		registration.RiskScore = *req.RiskScore
+	}
+	if req.Status != KYCStatusPending {
+		delete(h.assignments, address) // reviewed, it leaves the queue
+	}
 
 	switch req.Status {
 	case KYCStatusApproved:
-		registration.VerifiedAt = &now
-		expiry := now.Add(365 * 24 * time.Hour) // 1 year validity
-		registration.ExpiresAt = &expiry
-		// Auto-whitelist on approval
-		h.whitelist[address] = true
+		h.grantApproval(registration, now)
 	case KYCStatusRejected:
 		registration.RejectionReason = req.RejectionReason
 	case KYCStatusSuspended:
@@ -522,6 +596,7 @@ func (h *KYCHandler) UpdateKYC(c *gin.Context) {
 		zap.String("new_status", string(req.Status)),
 	)
 
+	setVersionETag(c, registration.Version)
 	c.JSON(http.StatusOK, KYCResponse{
 		Success:      true,
 		Registration: registration,
@@ -529,6 +604,15 @@ func (h *KYCHandler) UpdateKYC(c *gin.Context) {
 	})
 }
 
+// grantApproval verifies an approved registration for a year and whitelists it
+func (h *KYCHandler) grantApproval(registration *KYCRegistration, now time.Time) {
+	registration.VerifiedAt = &now
+	expiry := now.Add(365 * 24 * time.Hour) // 1 year validity
+	registration.ExpiresAt = &expiry
+	// Auto-whitelist on approval
+	h.whitelist[registration.Address] = true
+}
+
 // AddToWhitelist handles POST /api/v1/kyc/whitelist
 // @Summary Add to whitelist
 // @Description Adds an address to the whitelist
@@ -701,6 +785,7 @@ func (h *KYCHandler) AddToBlacklist(c *gin.Context) {
 		reg.Status = KYCStatusSuspended
 		reg.SuspensionReason = "Blacklisted: " + req.Reason
 		reg.UpdatedAt = time.Now()
+		reg.Version++
 	}
 
 	h.addAuditLog("BLACKLIST_ADD", operator, address, "Added to blacklist: "+req.Reason, c.ClientIP(), "", "")
@@ -821,6 +906,8 @@ func (h *KYCHandler) CheckCompliance(c *gin.Context) {
 		response.KYCStatus = registration.Status
 		response.KYCLevel = registration.Level
 		response.Jurisdiction = registration.Jurisdiction
+		response.AccreditedInvestor = isAccredited(registration, time.Now())
+		response.Accreditation = h.accreditationSummary(address, time.Now())
 
 		// Check expiration
 		if registration.ExpiresAt != nil && time.Now().After(*registration.ExpiresAt) {
@@ -833,7 +920,7 @@ func (h *KYCHandler) CheckCompliance(c *gin.Context) {
 			if !j.Allowed {
 				response.Restrictions = append(response.Restrictions, "Jurisdiction not allowed")
 			}
-			if j.RequiresAccredited && !registration.AccreditedInvestor {
+			if j.RequiresAccredited && !response.AccreditedInvestor {
 				response.Restrictions = append(response.Restrictions, "Accredited investor status required")
 			}
 			if j.MaxTransactionUSD > 0 {
@@ -939,14 +1026,9 @@ func (h *KYCHandler) IsBlacklisted(c *gin.Context) {
 // @Success 200 {object} KYCListResponse
 // @Router /api/v1/kyc/pending [get]
 func (h *KYCHandler) ListPending(c *gin.Context) {
-	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
-	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
-
-	if page < 1 {
-		page = 1
-	}
-	if pageSize < 1 || pageSize > 100 {
-		pageSize = 20
+	page, pageSize, ok := queryPage(c, 20)
+	if !ok {
+		return
 	}
 
 	h.mu.RLock()
@@ -997,23 +1079,26 @@ func (h *KYCHandler) ListPending(c *gin.Context) {
 // @Description Returns compliance audit log entries
 // @Tags kyc
 // @Produce json
-// @Param page query int false "Page number (default: 1)"
+// @Param page query int false "Page number (default: 1; in-memory log only)"
 // @Param page_size query int false "Page size (default: 50, max: 100)"
 // @Param subject query string false "Filter by subject address"
+// @Param cursor query string false "next_cursor of the previous page (audit store only)"
 // @Success 200 {object} AuditLogResponse
+// @Failure 400 {object} AuditLogResponse
 // @Router /api/v1/kyc/audit-log [get]
 func (h *KYCHandler) GetAuditLog(c *gin.Context) {
-	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
-	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "50"))
+	page, pageSize, ok := queryPage(c, 50)
+	if !ok {
+		return
+	}
 	subjectFilter := strings.ToLower(c.Query("subject"))
 
-	if page < 1 {
-		page = 1
-	}
-	if pageSize < 1 || pageSize > 100 {
-		pageSize = 50
+	if h.auditStore != nil {
+		h.listAuditLog(c, subjectFilter, page, pageSize)
+		return
 	}
 
+	// Deprecated: filters and sorts the whole in-memory log per request
 	h.mu.RLock()
 	var entries []*AuditLogEntry
 	for _, entry := range h.auditLog {
@@ -1084,7 +1169,7 @@ func (h *KYCHandler) GetJurisdictions(c *gin.Context) {
 	})
 }
 
-// AddComplianceOfficer handles POST /api/v1/kyc/compliance-officer
+// AddComplianceOfficer handles POST /api/v1/admin/kyc/compliance-officer
 // @Summary Add compliance officer
 // @Description Adds a new compliance officer (admin only)
 // @Tags kyc
@@ -1093,7 +1178,7 @@ func (h *KYCHandler) GetJurisdictions(c *gin.Context) {
 // @Param request body map[string]string true "Add officer request"
 // @Success 200 {object} map[string]interface{}
 // @Failure 400 {object} map[string]interface{}
-// @Router /api/v1/kyc/compliance-officer [post]
+// @Router /api/v1/admin/kyc/compliance-officer [post]
 func (h *KYCHandler) AddComplianceOfficer(c *gin.Context) {
 	var req struct {
 		Address string `json:"address" binding:"required"`
@@ -1146,7 +1231,7 @@ func (h *KYCHandler) AddComplianceOfficer(c *gin.Context) {
 	})
 }
 
-// RemoveComplianceOfficer handles DELETE /api/v1/kyc/compliance-officer/:address
+// RemoveComplianceOfficer handles DELETE /api/v1/admin/kyc/compliance-officer/:address
 // @Summary Remove compliance officer
 // @Description Removes a compliance officer (admin only)
 // @Tags kyc
@@ -1156,7 +1241,7 @@ func (h *KYCHandler) AddComplianceOfficer(c *gin.Context) {
 // @Success 200 {object} map[string]interface{}
 // @Failure 400 {object} map[string]interface{}
 // @Failure 403 {object} map[string]interface{}
-// @Router /api/v1/kyc/compliance-officer/{address} [delete]
+// @Router /api/v1/admin/kyc/compliance-officer/{address} [delete]
 func (h *KYCHandler) RemoveComplianceOfficer(c *gin.Context) {
 	address := c.Param("address")
 	admin := c.Query("admin")
@@ -0,0 +1,518 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+const (
+	// maxAttestationSize bounds an uploaded accreditation document
+	maxAttestationSize = 10 << 20
+	// defaultAccreditationValidity applies when the reviewer sets no expiry;
+	// verification letters are customarily relied on for 90 days
+	defaultAccreditationValidity = 90 * 24 * time.Hour
+	// attestationDownloadTTL is how long a reviewer's document link works
+	attestationDownloadTTL = 5 * time.Minute
+)
+
+// DocumentStore holds uploaded compliance documents (implemented by the
+// objectstore backends)
+type DocumentStore interface {
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+	PresignGet(ctx context.Context, key string, ttl time.Duration) (string, error)
+}
+
+// AttestationDocumentResponse is a short-lived download link for an
+// attestation's document
+type AttestationDocumentResponse struct {
+	Success   bool       `json:"success"`
+	URL       string     `json:"url,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	Message   string     `json:"message,omitempty"`
+}
+
+// AttestationStatus is the review state of an accreditation attestation
+type AttestationStatus string
+
+const (
+	AttestationPending  AttestationStatus = "pending"
+	AttestationApproved AttestationStatus = "approved"
+	AttestationRejected AttestationStatus = "rejected"
+	AttestationExpired  AttestationStatus = "expired" // approved, past ExpiresAt
+)
+
+// attestationDocumentTypes are the accepted evidence of accredited status
+var attestationDocumentTypes = map[string]bool{
+	"accountant_letter":         true, // CPA verification letter
+	"attorney_letter":           true,
+	"broker_dealer_letter":      true,
+	"investment_adviser_letter": true,
+	"financial_statement":       true,
+}
+
+// attestationContentTypes are the accepted upload formats
+var attestationContentTypes = map[string]bool{
+	"application/pdf": true,
+	"image/png":       true,
+	"image/jpeg":      true,
+}
+
+// AccreditationAttestation is an uploaded document attesting that an
+// address's owner is an accredited investor. The file is kept in the document
+// store under ObjectKey; only its hash is held here.
+type AccreditationAttestation struct {
+	ID              string            `json:"id"`
+	Address         string            `json:"address"`
+	DocumentType    string            `json:"document_type"`
+	DocumentHash    string            `json:"document_hash"` // SHA-256 of the file, hex
+	ObjectKey       string            `json:"object_key"`
+	FileName        string            `json:"file_name"`
+	ContentType     string            `json:"content_type"`
+	Size            int64             `json:"size"`
+	Status          AttestationStatus `json:"status"`
+	SubmittedAt     time.Time         `json:"submitted_at"`
+	ReviewedBy      string            `json:"reviewed_by,omitempty"`
+	ReviewedAt      *time.Time        `json:"reviewed_at,omitempty"`
+	RejectionReason string            `json:"rejection_reason,omitempty"`
+	ExpiresAt       *time.Time        `json:"expires_at,omitempty"`
+}
+
+// ReviewAttestationRequest is a compliance officer's decision on an attestation
+type ReviewAttestationRequest struct {
+	Officer   string     `json:"officer" binding:"required"`
+	Approve   bool       `json:"approve"`
+	Reason    string     `json:"reason,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"` // approvals only, default 90 days
+}
+
+// AttestationResponse wraps one attestation
+type AttestationResponse struct {
+	Success     bool                      `json:"success"`
+	Attestation *AccreditationAttestation `json:"attestation,omitempty"`
+	Message     string                    `json:"message,omitempty"`
+}
+
+// AttestationListResponse wraps a list of attestations
+type AttestationListResponse struct {
+	Success      bool                        `json:"success"`
+	Attestations []*AccreditationAttestation `json:"attestations"`
+	Total        int                         `json:"total"`
+	Page         int                         `json:"page,omitempty"`
+	PageSize     int                         `json:"page_size,omitempty"`
+	Message      string                      `json:"message,omitempty"`
+}
+
+// AccreditationSummary is the accreditation part of a compliance check
+type AccreditationSummary struct {
+	Status    AttestationStatus `json:"status"`
+	ExpiresAt *time.Time        `json:"expires_at,omitempty"`
+}
+
+// SetDocumentStore enables accreditation uploads
+func (h *KYCHandler) SetDocumentStore(store DocumentStore) {
+	h.documents = store
+}
+
+// status reports a's status at now
+func (a *AccreditationAttestation) status(now time.Time) AttestationStatus {
+	if a.Status == AttestationApproved && a.ExpiresAt != nil && !now.Before(*a.ExpiresAt) {
+		return AttestationExpired
+	}
+	return a.Status
+}
+
+// view copies a with its status as of now
+func (a *AccreditationAttestation) view(now time.Time) *AccreditationAttestation {
+	cp := *a
+	cp.Status = a.status(now)
+	return &cp
+}
+
+// isAccredited reports whether registration holds an unexpired accreditation.
+// It is granted by approving an attestation (or by seed fixtures), never by
+// the registrant.
+func isAccredited(registration *KYCRegistration, now time.Time) bool {
+	return registration.AccreditedInvestor &&
+		(registration.AccreditationExpiresAt == nil || now.Before(*registration.AccreditationExpiresAt))
+}
+
+// accreditationSummary describes address's latest attestation; h.mu must be
+// held. Nil if none was uploaded.
+func (h *KYCHandler) accreditationSummary(address string, now time.Time) *AccreditationSummary {
+	list := h.attestationsByAddress[address]
+	if len(list) == 0 {
+		return nil
+	}
+	// The approval in force outranks newer uploads still under review
+	for i := len(list) - 1; i >= 0; i-- {
+		if a := list[i]; a.status(now) == AttestationApproved {
+			return &AccreditationSummary{Status: AttestationApproved, ExpiresAt: a.ExpiresAt}
+		}
+	}
+	latest := list[len(list)-1]
+	return &AccreditationSummary{Status: latest.status(now), ExpiresAt: latest.ExpiresAt}
+}
+
+// UploadAttestation handles POST /api/v1/kyc/accreditation
+// @Summary Upload an accreditation attestation
+// @Description Uploads a document or letter attesting accredited investor status (PDF, PNG or JPEG, up to 10 MB) for compliance officer review. The file is kept in object storage and its SHA-256 hash recorded.
+// @Tags kyc
+// @Accept multipart/form-data
+// @Produce json
+// @Param address formData string true "Registered address"
+// @Param document_type formData string true "accountant_letter, attorney_letter, broker_dealer_letter, investment_adviser_letter or financial_statement"
+// @Param file formData file true "Document"
+// @Success 200 {object} AttestationResponse
+// @Failure 400 {object} AttestationResponse
+// @Failure 404 {object} AttestationResponse
+// @Failure 413 {object} AttestationResponse
+// @Failure 503 {object} AttestationResponse
+// @Router /api/v1/kyc/accreditation [post]
+func (h *KYCHandler) UploadAttestation(c *gin.Context) {
+	if h.documents == nil {
+		c.JSON(http.StatusServiceUnavailable, AttestationResponse{Success: false, Message: "Document storage is not configured"})
+		return
+	}
+
+	address := c.PostForm("address")
+	if !isValidAddress(address) {
+		c.JSON(http.StatusBadRequest, AttestationResponse{Success: false, Message: "Invalid address format"})
+		return
+	}
+	address = strings.ToLower(address)
+	docType := c.PostForm("document_type")
+	if !attestationDocumentTypes[docType] {
+		c.JSON(http.StatusBadRequest, AttestationResponse{Success: false, Message: "Invalid document_type"})
+		return
+	}
+
+	header, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, AttestationResponse{Success: false, Message: "A file is required"})
+		return
+	}
+	if header.Size > maxAttestationSize {
+		c.JSON(http.StatusRequestEntityTooLarge, AttestationResponse{Success: false, Message: "File exceeds 10 MB"})
+		return
+	}
+	file, err := header.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, AttestationResponse{Success: false, Message: "Could not read the file"})
+		return
+	}
+	defer file.Close()
+	content, err := io.ReadAll(io.LimitReader(file, maxAttestationSize+1))
+	if err != nil || len(content) > maxAttestationSize {
+		c.JSON(http.StatusBadRequest, AttestationResponse{Success: false, Message: "Could not read the file"})
+		return
+	}
+	// The declared type is not trusted; the content decides
+	contentType := http.DetectContentType(content)
+	if !attestationContentTypes[contentType] {
+		c.JSON(http.StatusBadRequest, AttestationResponse{Success: false, Message: "File must be a PDF, PNG or JPEG"})
+		return
+	}
+
+	h.mu.RLock()
+	_, registered := h.registrations[address]
+	blacklisted := h.blacklist[address]
+	h.mu.RUnlock()
+	if !registered {
+		c.JSON(http.StatusNotFound, AttestationResponse{Success: false, Message: "No KYC registration found for this address"})
+		return
+	}
+	if blacklisted {
+		c.JSON(http.StatusForbidden, AttestationResponse{Success: false, Message: "Address is blacklisted"})
+		return
+	}
+
+	idBytes := make([]byte, 16)
+	if _, err := rand.Read(idBytes); err != nil {
+		h.logger.Error("failed to generate attestation ID", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, AttestationResponse{Success: false, Message: "Internal server error"})
+		return
+	}
+	sum := sha256.Sum256(content)
+	a := &AccreditationAttestation{
+		ID:           "att-" + hex.EncodeToString(idBytes),
+		Address:      address,
+		DocumentType: docType,
+		DocumentHash: hex.EncodeToString(sum[:]),
+		FileName:     header.Filename,
+		ContentType:  contentType,
+		Size:         int64(len(content)),
+		Status:       AttestationPending,
+		SubmittedAt:  time.Now().UTC(),
+	}
+	a.ObjectKey = "kyc/accreditation/" + address + "/" + a.ID
+
+	if err := h.documents.Put(c.Request.Context(), a.ObjectKey, bytes.NewReader(content), a.Size, contentType); err != nil {
+		h.logger.Error("failed to store attestation document", zap.String("address", address), zap.Error(err))
+		c.JSON(http.StatusBadGateway, AttestationResponse{Success: false, Message: "The document could not be stored"})
+		return
+	}
+
+	h.mu.Lock()
+	h.attestations[a.ID] = a
+	h.attestationsByAddress[address] = append(h.attestationsByAddress[address], a)
+	h.addAuditLog("ACCREDITATION_UPLOAD", address, address, "Accreditation attestation uploaded: "+docType+" sha256:"+a.DocumentHash,
+		c.ClientIP(), "", string(AttestationPending))
+	h.mu.Unlock()
+
+	h.logger.Info("accreditation attestation uploaded",
+		zap.String("address", address),
+		zap.String("attestation_id", a.ID),
+		zap.String("document_type", docType),
+	)
+
+	c.JSON(http.StatusOK, AttestationResponse{
+		Success:     true,
+		Attestation: a.view(time.Now()),
+		Message:     "Attestation submitted for review",
+	})
+}
+
+// ListAttestations handles GET /api/v1/kyc/accreditation/:address
+// @Summary List an address's accreditation attestations
+// @Description Returns the address's uploaded attestations, oldest first
+// @Tags kyc
+// @Produce json
+// @Param address path string true "Ethereum address"
+// @Success 200 {object} AttestationListResponse
+// @Failure 400 {object} AttestationListResponse
+// @Router /api/v1/kyc/accreditation/{address} [get]
+func (h *KYCHandler) ListAttestations(c *gin.Context) {
+	address := c.Param("address")
+	if !isValidAddress(address) {
+		c.JSON(http.StatusBadRequest, AttestationListResponse{Success: false, Message: "Invalid address format"})
+		return
+	}
+	address = strings.ToLower(address)
+
+	now := time.Now()
+	h.mu.RLock()
+	list := make([]*AccreditationAttestation, 0, len(h.attestationsByAddress[address]))
+	for _, a := range h.attestationsByAddress[address] {
+		list = append(list, a.view(now))
+	}
+	h.mu.RUnlock()
+
+	c.JSON(http.StatusOK, AttestationListResponse{
+		Success:      true,
+		Attestations: list,
+		Total:        len(list),
+	})
+}
+
+// ListPendingAttestations handles GET /api/v1/admin/kyc/accreditation/pending
+// @Summary Accreditation review queue
+// @Description Returns attestations awaiting review, oldest first (compliance officer only)
+// @Tags kyc
+// @Produce json
+// @Param officer query string true "Compliance officer address"
+// @Param page query int false "Page number (default: 1)"
+// @Param page_size query int false "Page size (default: 20, max: 100)"
+// @Success 200 {object} AttestationListResponse
+// @Failure 403 {object} AttestationListResponse
+// @Router /api/v1/admin/kyc/accreditation/pending [get]
+func (h *KYCHandler) ListPendingAttestations(c *gin.Context) {
+	page, pageSize, ok := queryPage(c, 20)
+	if !ok {
+		return
+	}
+
+	h.mu.RLock()
+	if !h.complianceOfficers[strings.ToLower(c.Query("officer"))] {
+		h.mu.RUnlock()
+		c.JSON(http.StatusForbidden, AttestationListResponse{Success: false, Message: "Only compliance officers can review attestations"})
+		return
+	}
+	var pending []*AccreditationAttestation
+	for _, a := range h.attestations {
+		if a.Status == AttestationPending {
+			cp := *a
+			pending = append(pending, &cp)
+		}
+	}
+	h.mu.RUnlock()
+
+	sort.Slice(pending, func(i, j int) bool {
+		return pending[i].SubmittedAt.Before(pending[j].SubmittedAt)
+	})
+
+	total := len(pending)
+	start := (page - 1) * pageSize
+	if start > total {
+		start = total
+	}
+	end := start + pageSize
+	if end > total {
+		end = total
+	}
+
+	c.JSON(http.StatusOK, AttestationListResponse{
+		Success:      true,
+		Attestations: pending[start:end],
+		Total:        total,
+		Page:         page,
+		PageSize:     pageSize,
+	})
+}
+
+// ReviewAttestation handles POST /api/v1/admin/kyc/accreditation/:id/review
+// @Summary Review an accreditation attestation
+// @Description Approves or rejects a pending attestation (compliance officer only). Approval marks the registration accredited until expires_at (default 90 days).
+// @Tags kyc
+// @Accept json
+// @Produce json
+// @Param id path string true "Attestation ID"
+// @Param request body ReviewAttestationRequest true "Decision"
+// @Success 200 {object} AttestationResponse
+// @Failure 400 {object} AttestationResponse
+// @Failure 403 {object} AttestationResponse
+// @Failure 404 {object} AttestationResponse
+// @Failure 409 {object} AttestationResponse
+// @Router /api/v1/admin/kyc/accreditation/{id}/review [post]
+func (h *KYCHandler) ReviewAttestation(c *gin.Context) {
+	var req ReviewAttestationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, AttestationResponse{Success: false, Message: "Invalid request: " + err.Error()})
+		return
+	}
+	if !isValidAddress(req.Officer) {
+		c.JSON(http.StatusBadRequest, AttestationResponse{Success: false, Message: "Invalid officer address format"})
+		return
+	}
+	officer := strings.ToLower(req.Officer)
+
+	now := time.Now().UTC()
+	expires := now.Add(defaultAccreditationValidity)
+	if req.Approve && req.ExpiresAt != nil {
+		if !req.ExpiresAt.After(now) {
+			c.JSON(http.StatusBadRequest, AttestationResponse{Success: false, Message: "expires_at must be in the future"})
+			return
+		}
+		expires = req.ExpiresAt.UTC()
+	}
+	if !req.Approve && strings.TrimSpace(req.Reason) == "" {
+		c.JSON(http.StatusBadRequest, AttestationResponse{Success: false, Message: "A reason is required to reject"})
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if !h.complianceOfficers[officer] {
+		c.JSON(http.StatusForbidden, AttestationResponse{Success: false, Message: "Only compliance officers can review attestations"})
+		return
+	}
+	a, exists := h.attestations[c.Param("id")]
+	if !exists {
+		c.JSON(http.StatusNotFound, AttestationResponse{Success: false, Message: "Attestation not found"})
+		return
+	}
+	if a.Status != AttestationPending {
+		c.JSON(http.StatusConflict, AttestationResponse{Success: false, Message: "Attestation was already reviewed"})
+		return
+	}
+	// Officers do not review their own attestations
+	if a.Address == officer {
+		c.JSON(http.StatusForbidden, AttestationResponse{Success: false, Message: "Officers cannot review their own attestation"})
+		return
+	}
+
+	a.ReviewedBy = officer
+	a.ReviewedAt = &now
+	if req.Approve {
+		a.Status = AttestationApproved
+		a.ExpiresAt = &expires
+		if registration, ok := h.registrations[a.Address]; ok {
+			registration.AccreditedInvestor = true
+			registration.AccreditationExpiresAt = &expires
+			registration.UpdatedAt = now
+			registration.Version++
+		}
+	} else {
+		a.Status = AttestationRejected
+		a.RejectionReason = req.Reason
+	}
+
+	h.addAuditLog("ACCREDITATION_REVIEW", officer, a.Address, "Accreditation attestation "+a.ID+" reviewed: "+req.Reason,
+		c.ClientIP(), string(AttestationPending), string(a.Status))
+
+	h.logger.Info("accreditation attestation reviewed",
+		zap.String("attestation_id", a.ID),
+		zap.String("address", a.Address),
+		zap.String("officer", officer),
+		zap.String("status", string(a.Status)),
+	)
+
+	c.JSON(http.StatusOK, AttestationResponse{
+		Success:     true,
+		Attestation: a.view(now),
+		Message:     "Attestation " + string(a.Status),
+	})
+}
+
+// GetAttestationDocument handles GET /api/v1/admin/kyc/accreditation/documents/:id
+// @Summary Download an attestation document
+// @Description Returns a presigned URL for the attestation's document, valid for five minutes (compliance officer only)
+// @Tags kyc
+// @Produce json
+// @Param id path string true "Attestation ID"
+// @Param officer query string true "Compliance officer address"
+// @Success 200 {object} AttestationDocumentResponse
+// @Failure 403 {object} AttestationDocumentResponse
+// @Failure 404 {object} AttestationDocumentResponse
+// @Failure 503 {object} AttestationDocumentResponse
+// @Router /api/v1/admin/kyc/accreditation/documents/{id} [get]
+func (h *KYCHandler) GetAttestationDocument(c *gin.Context) {
+	if h.documents == nil {
+		c.JSON(http.StatusServiceUnavailable, AttestationDocumentResponse{Success: false, Message: "Document storage is not configured"})
+		return
+	}
+	officer := strings.ToLower(c.Query("officer"))
+
+	h.mu.RLock()
+	isOfficer := h.complianceOfficers[officer]
+	a, exists := h.attestations[c.Param("id")]
+	var key, address string
+	if exists {
+		key, address = a.ObjectKey, a.Address
+	}
+	h.mu.RUnlock()
+
+	if !isOfficer {
+		c.JSON(http.StatusForbidden, AttestationDocumentResponse{Success: false, Message: "Only compliance officers can view attestation documents"})
+		return
+	}
+	if !exists {
+		c.JSON(http.StatusNotFound, AttestationDocumentResponse{Success: false, Message: "Attestation not found"})
+		return
+	}
+
+	link, err := h.documents.PresignGet(c.Request.Context(), key, attestationDownloadTTL)
+	if err != nil {
+		h.logger.Error("failed to presign attestation document", zap.String("attestation_id", c.Param("id")), zap.Error(err))
+		c.JSON(http.StatusServiceUnavailable, AttestationDocumentResponse{Success: false, Message: "Document download is unavailable"})
+		return
+	}
+	expires := time.Now().UTC().Add(attestationDownloadTTL)
+
+	h.mu.Lock()
+	h.addAuditLog("ACCREDITATION_DOCUMENT_VIEW", officer, address, "Attestation "+c.Param("id")+" document link issued", c.ClientIP(), "", "")
+	h.mu.Unlock()
+
+	c.JSON(http.StatusOK, AttestationDocumentResponse{Success: true, URL: link, ExpiresAt: &expires})
+}
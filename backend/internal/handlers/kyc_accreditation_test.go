@@ -0,0 +1,138 @@
+package handlers_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/handlers"
+)
+
+// documents keeps uploaded files by key
+type documents map[string][]byte
+
+func (d documents) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	b, err := io.ReadAll(body)
+	d[key] = b
+	return err
+}
+
+func (d documents) PresignGet(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	return "https://documents.example/" + key + "?ttl=" + ttl.String(), nil
+}
+
+func TestKYCHandler_AccreditationAttestation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	const (
+		investor = "0x00000000000000000000000000000000000000aa"
+		officer  = "0x00000000000000000000000000000000000000cc"
+	)
+	store := documents{}
+	handler := handlers.NewKYCHandler(zap.NewNop())
+	handler.SetDocumentStore(store)
+	handler.Seed(handlers.KYCFixtures{ComplianceOfficers: []string{officer}})
+
+	router := gin.New()
+	router.POST("/kyc/register", handler.Register)
+	router.GET("/kyc/check/:address", handler.CheckCompliance)
+	router.POST("/kyc/accreditation", handler.UploadAttestation)
+	router.GET("/kyc/accreditation/pending", handler.ListPendingAttestations)
+	router.GET("/kyc/accreditation/:address", handler.ListAttestations)
+	router.POST("/kyc/accreditation/:id/review", handler.ReviewAttestation)
+	router.GET("/kyc/accreditation/documents/:id", handler.GetAttestationDocument)
+
+	do := func(req *http.Request) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	postJSON := func(path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		return do(req)
+	}
+	upload := func(docType string, content []byte) *httptest.ResponseRecorder {
+		var buf bytes.Buffer
+		form := multipart.NewWriter(&buf)
+		require.NoError(t, form.WriteField("address", investor))
+		require.NoError(t, form.WriteField("document_type", docType))
+		part, err := form.CreateFormFile("file", "letter.pdf")
+		require.NoError(t, err)
+		_, _ = part.Write(content)
+		require.NoError(t, form.Close())
+		req := httptest.NewRequest(http.MethodPost, "/kyc/accreditation", &buf)
+		req.Header.Set("Content-Type", form.FormDataContentType())
+		return do(req)
+	}
+	check := func() map[string]interface{} {
+		w := do(httptest.NewRequest(http.MethodGet, "/kyc/check/"+investor, nil))
+		var body map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		return body
+	}
+
+	// The registrant's own claim no longer counts
+	require.Equal(t, http.StatusOK, postJSON("/kyc/register", `{"address":"`+investor+`","jurisdiction":"US","accredited_investor":true}`).Code)
+	assert.Equal(t, false, check()["accredited_investor"])
+	assert.Contains(t, check()["restrictions"], "Accredited investor status required")
+
+	pdf := []byte("%PDF-1.4\n% CPA verification letter\n")
+	assert.Equal(t, http.StatusBadRequest, upload("selfie", pdf).Code)
+	assert.Equal(t, http.StatusBadRequest, upload("accountant_letter", []byte("just text")).Code)
+	w := upload("accountant_letter", pdf)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var uploaded handlers.AttestationResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &uploaded))
+	a := uploaded.Attestation
+	assert.Equal(t, handlers.AttestationPending, a.Status)
+	assert.Equal(t, "application/pdf", a.ContentType)
+	assert.Len(t, a.DocumentHash, 64)
+	assert.Equal(t, pdf, store[a.ObjectKey])
+	assert.Equal(t, "pending", check()["accreditation"].(map[string]interface{})["status"])
+
+	// The review queue is for compliance officers
+	assert.Equal(t, http.StatusForbidden, do(httptest.NewRequest(http.MethodGet, "/kyc/accreditation/pending?officer="+investor, nil)).Code)
+	w = do(httptest.NewRequest(http.MethodGet, "/kyc/accreditation/pending?officer="+officer, nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), a.ID)
+
+	// Reviewers read the document through a short-lived link
+	document := "/kyc/accreditation/documents/" + a.ID + "?officer="
+	assert.Equal(t, http.StatusForbidden, do(httptest.NewRequest(http.MethodGet, document+investor, nil)).Code)
+	assert.Equal(t, http.StatusNotFound, do(httptest.NewRequest(http.MethodGet, "/kyc/accreditation/documents/att-missing?officer="+officer, nil)).Code)
+	w = do(httptest.NewRequest(http.MethodGet, document+officer, nil))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var link handlers.AttestationDocumentResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &link))
+	assert.Equal(t, "https://documents.example/"+a.ObjectKey+"?ttl=5m0s", link.URL)
+	assert.NotNil(t, link.ExpiresAt)
+
+	review := "/kyc/accreditation/" + a.ID + "/review"
+	assert.Equal(t, http.StatusForbidden, postJSON(review, `{"officer":"`+investor+`","approve":true}`).Code)
+	assert.Equal(t, http.StatusBadRequest, postJSON(review, `{"officer":"`+officer+`","approve":true,"expires_at":"2020-01-01T00:00:00Z"}`).Code)
+	w = postJSON(review, `{"officer":"`+officer+`","approve":true}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, http.StatusConflict, postJSON(review, `{"officer":"`+officer+`","approve":false,"reason":"again"}`).Code)
+
+	body := check()
+	assert.Equal(t, true, body["accredited_investor"])
+	assert.Equal(t, "approved", body["accreditation"].(map[string]interface{})["status"])
+	assert.NotNil(t, body["accreditation"].(map[string]interface{})["expires_at"])
+	assert.Empty(t, body["restrictions"])
+
+	w = do(httptest.NewRequest(http.MethodGet, "/kyc/accreditation/"+investor, nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"status":"approved"`)
+}
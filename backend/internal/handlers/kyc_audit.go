@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// SetAuditStore persists the compliance audit log to store instead of the
+// handler's memory. GetAuditLog then pages it by keyset (cursor and
+// next_cursor) rather than by page number.
+func (h *KYCHandler) SetAuditStore(store repository.KYCAuditRepository) {
+	h.auditStore = store
+}
+
+// appendAudit writes entry to the audit store. The state change it records
+// has already been made, so a failed write is logged rather than returned.
+func (h *KYCHandler) appendAudit(entry *AuditLogEntry) {
+	e := &repository.KYCAuditEntry{
+		Timestamp:     entry.Timestamp,
+		Action:        entry.Action,
+		Actor:         entry.Actor,
+		Subject:       entry.Subject,
+		Details:       entry.Details,
+		IPAddress:     entry.IPAddress,
+		Country:       entry.Country,
+		PreviousState: entry.PreviousState,
+		NewState:      entry.NewState,
+	}
+	if err := h.auditStore.AppendAuditEntry(context.Background(), e); err != nil {
+		h.logger.Error("failed to persist audit entry",
+			zap.String("action", entry.Action), zap.String("subject", entry.Subject), zap.Error(err))
+	}
+}
+
+// listAuditLog answers GetAuditLog from the audit store: up to pageSize
+// entries of subject (all without one) older than the cursor query parameter.
+// Only the first page can be asked for by number.
+func (h *KYCHandler) listAuditLog(c *gin.Context, subject string, page, pageSize int) {
+	filter := repository.KYCAuditFilter{Subject: subject}
+	if raw := c.Query("cursor"); raw != "" {
+		cursor, ok := decodeAuditCursor(raw)
+		if !ok {
+			c.JSON(http.StatusBadRequest, AuditLogResponse{Success: false, Message: "Invalid cursor"})
+			return
+		}
+		filter.After = &cursor
+	} else if page > 1 {
+		c.JSON(http.StatusBadRequest, AuditLogResponse{Success: false, Message: "page is not supported; follow next_cursor"})
+		return
+	}
+
+	ctx := c.Request.Context()
+	// One extra row tells whether there is a next page
+	stored, err := h.auditStore.ListAuditEntries(ctx, filter, pageSize+1)
+	if err != nil {
+		h.logger.Error("failed to list audit entries", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, AuditLogResponse{Success: false, Message: "Internal server error"})
+		return
+	}
+	total, err := h.auditStore.CountAuditEntries(ctx, filter)
+	if err != nil {
+		h.logger.Error("failed to count audit entries", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, AuditLogResponse{Success: false, Message: "Internal server error"})
+		return
+	}
+
+	resp := AuditLogResponse{Success: true, Entries: []*AuditLogEntry{}, Total: int(total), PageSize: pageSize}
+	if len(stored) > pageSize {
+		stored = stored[:pageSize]
+		resp.NextCursor = encodeAuditCursor(stored[pageSize-1].Cursor())
+	}
+	for _, e := range stored {
+		resp.Entries = append(resp.Entries, &AuditLogEntry{
+			ID:            e.ID,
+			Timestamp:     e.Timestamp,
+			Action:        e.Action,
+			Actor:         e.Actor,
+			Subject:       e.Subject,
+			Details:       e.Details,
+			IPAddress:     e.IPAddress,
+			Country:       e.Country,
+			PreviousState: e.PreviousState,
+			NewState:      e.NewState,
+		})
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// encodeAuditCursor makes the opaque next_cursor of an audit page
+func encodeAuditCursor(c repository.KYCAuditCursor) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(c.Timestamp.UTC().Format(time.RFC3339Nano) + "|" + c.ID))
+}
+
+// decodeAuditCursor parses a cursor made by encodeAuditCursor
+func decodeAuditCursor(s string) (repository.KYCAuditCursor, bool) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return repository.KYCAuditCursor{}, false
+	}
+	ts, id, ok := strings.Cut(string(raw), "|")
+	if !ok || !uuidPattern.MatchString(id) {
+		return repository.KYCAuditCursor{}, false
+	}
+	t, err := time.Parse(time.RFC3339Nano, ts)
+	if err != nil {
+		return repository.KYCAuditCursor{}, false
+	}
+	return repository.KYCAuditCursor{Timestamp: t, ID: id}, true
+}
@@ -0,0 +1,76 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/handlers"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/storage/memory"
+)
+
+func TestKYCHandler_AuditLogPagesByCursor(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	handler := handlers.NewKYCHandler(zap.NewNop())
+	handler.SetAuditStore(memory.NewMemoryKYCAuditRepo())
+	router := gin.New()
+	router.POST("/kyc/register", handler.Register)
+	router.GET("/kyc/audit-log", handler.GetAuditLog)
+
+	var addresses []string
+	for i := 1; i <= 5; i++ {
+		address := fmt.Sprintf("0x%040x", i)
+		addresses = append(addresses, address)
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/kyc/register", strings.NewReader(`{"address":"`+address+`","jurisdiction":"GB"}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	}
+
+	get := func(query string) (int, handlers.AuditLogResponse) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/kyc/audit-log?"+query, nil))
+		var resp handlers.AuditLogResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		return w.Code, resp
+	}
+
+	// Newest first, two at a time, until next_cursor runs out
+	var subjects []string
+	query := "page_size=2"
+	for pages := 0; ; pages++ {
+		require.Less(t, pages, 3)
+		code, resp := get(query)
+		require.Equal(t, http.StatusOK, code, resp.Message)
+		assert.Equal(t, 5, resp.Total)
+		for _, e := range resp.Entries {
+			assert.Equal(t, "KYC_REGISTER", e.Action)
+			subjects = append(subjects, e.Subject)
+		}
+		if resp.NextCursor == "" {
+			break
+		}
+		query = "page_size=2&cursor=" + resp.NextCursor
+	}
+	assert.Equal(t, []string{addresses[4], addresses[3], addresses[2], addresses[1], addresses[0]}, subjects)
+
+	code, resp := get("subject=0x" + strings.ToUpper(addresses[2][2:]))
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, resp.Entries, 1)
+	assert.Equal(t, addresses[2], resp.Entries[0].Subject)
+	assert.Empty(t, resp.NextCursor)
+
+	code, _ = get("page=2")
+	assert.Equal(t, http.StatusBadRequest, code, "page numbers past the first need a cursor")
+	code, _ = get("cursor=not-a-cursor")
+	assert.Equal(t, http.StatusBadRequest, code)
+}
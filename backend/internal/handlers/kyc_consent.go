@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/auth"
+)
+
+// ConsentRecord is an address's acceptance of a privacy policy version for
+// KYC data processing, kept as evidence of consent
+type ConsentRecord struct {
+	Address       string    `json:"address"`
+	PolicyVersion string    `json:"policy_version"`
+	Message       string    `json:"message"`   // what was signed, see ConsentMessage
+	Signature     string    `json:"signature"` // personal_sign of Message, hex
+	IPAddress     string    `json:"ip_address,omitempty"`
+	AcceptedAt    time.Time `json:"accepted_at"`
+}
+
+// KYCConsent is the consent given with a registration
+type KYCConsent struct {
+	PolicyVersion string `json:"policy_version" binding:"required"`
+	Signature     string `json:"signature" binding:"required"` // personal_sign of ConsentMessage, hex
+}
+
+// ConsentHistoryResponse wraps an address's consent history
+type ConsentHistoryResponse struct {
+	Success        bool             `json:"success"`
+	Address        string           `json:"address,omitempty"`
+	CurrentVersion string           `json:"current_version,omitempty"`
+	Accepted       bool             `json:"accepted"`                  // the current version
+	ConsentMessage string           `json:"consent_message,omitempty"` // to sign for the current version
+	Consents       []*ConsentRecord `json:"consents"`
+	Message        string           `json:"message,omitempty"`
+}
+
+// ConsentMessage is the text a wallet signs to accept a privacy policy version
+func ConsentMessage(address, policyVersion string) string {
+	return fmt.Sprintf("I consent to Nexus Protocol processing my identity verification data "+
+		"under privacy policy version %s.\n\nAddress: %s", policyVersion, strings.ToLower(address))
+}
+
+// DefaultConsentPolicyVersion is the privacy policy version the server
+// requires when none is configured
+const DefaultConsentPolicyVersion = "2026-09-01"
+
+// SetConsentPolicy requires registrations to accept privacy policy version
+// (e.g. a date such as "2026-09-01"). An address accepts each version once;
+// publishing a new one blocks registration until it is accepted.
+func (h *KYCHandler) SetConsentPolicy(version string) {
+	h.consentVersion = version
+}
+
+// hasConsented reports whether address accepted the current policy
+// version; h.mu must be held
+func (h *KYCHandler) hasConsented(address string) bool {
+	for _, r := range h.consents[address] {
+		if r.PolicyVersion == h.consentVersion {
+			return true
+		}
+	}
+	return false
+}
+
+// verifyConsent checks the consent given with address's registration,
+// returning the record to keep (nil when none was given or none is
+// required) or the status and message to answer with
+func (h *KYCHandler) verifyConsent(c *gin.Context, address string, consent *KYCConsent) (*ConsentRecord, int, string) {
+	if h.consentVersion == "" || consent == nil {
+		return nil, 0, ""
+	}
+	if consent.PolicyVersion != h.consentVersion {
+		return nil, http.StatusForbidden, "Consent must be to the current privacy policy version (" + h.consentVersion + ")"
+	}
+
+	message := ConsentMessage(address, consent.PolicyVersion)
+	signer, err := auth.RecoverSigner(message, consent.Signature)
+	if err != nil || strings.ToLower(signer.Hex()) != address {
+		return nil, http.StatusBadRequest, "Consent signature does not match the address"
+	}
+
+	return &ConsentRecord{
+		Address:       address,
+		PolicyVersion: consent.PolicyVersion,
+		Message:       message,
+		Signature:     consent.Signature,
+		IPAddress:     c.ClientIP(),
+		AcceptedAt:    time.Now().UTC(),
+	}, 0, ""
+}
+
+// recordConsent keeps r; h.mu must be held
+func (h *KYCHandler) recordConsent(r *ConsentRecord) {
+	h.consents[r.Address] = append(h.consents[r.Address], r)
+	h.addAuditLog("KYC_CONSENT", r.Address, r.Address, "Privacy policy version "+r.PolicyVersion+" accepted", r.IPAddress, "", "")
+	h.logger.Info("KYC consent recorded",
+		zap.String("address", r.Address),
+		zap.String("policy_version", r.PolicyVersion),
+	)
+}
+
+// GetConsentHistory handles GET /api/v1/kyc/consent/:address
+// @Summary Get consent history
+// @Description Returns the privacy policy versions an address accepted, oldest first, whether it accepted the current one, and the message to sign for it
+// @Tags kyc
+// @Produce json
+// @Param address path string true "Ethereum address"
+// @Success 200 {object} ConsentHistoryResponse
+// @Failure 400 {object} ConsentHistoryResponse
+// @Router /api/v1/kyc/consent/{address} [get]
+func (h *KYCHandler) GetConsentHistory(c *gin.Context) {
+	address := c.Param("address")
+	if !isValidAddress(address) {
+		c.JSON(http.StatusBadRequest, ConsentHistoryResponse{Success: false, Message: "Invalid address format"})
+		return
+	}
+	address = strings.ToLower(address)
+
+	h.mu.RLock()
+	consents := append([]*ConsentRecord{}, h.consents[address]...)
+	accepted := h.consentVersion == "" || h.hasConsented(address)
+	h.mu.RUnlock()
+
+	resp := ConsentHistoryResponse{
+		Success:        true,
+		Address:        address,
+		CurrentVersion: h.consentVersion,
+		Accepted:       accepted,
+		Consents:       consents,
+	}
+	if h.consentVersion != "" {
+		resp.ConsentMessage = ConsentMessage(address, h.consentVersion)
+	}
+	c.JSON(http.StatusOK, resp)
+}
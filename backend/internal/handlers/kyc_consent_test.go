@@ -0,0 +1,80 @@
+package handlers_test
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/handlers"
+)
+
+func TestKYCHandler_RegistrationRequiresCurrentConsent(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	address := strings.ToLower(crypto.PubkeyToAddress(key.PublicKey).Hex())
+	sign := func(signer, version string) string {
+		sig, err := crypto.Sign(accounts.TextHash([]byte(handlers.ConsentMessage(signer, version))), key)
+		require.NoError(t, err)
+		sig[64] += 27
+		return "0x" + hex.EncodeToString(sig)
+	}
+
+	handler := handlers.NewKYCHandler(zap.NewNop())
+	handler.SetConsentPolicy("2026-09-01")
+	router := gin.New()
+	router.POST("/kyc/register", handler.Register)
+	router.GET("/kyc/consent/:address", handler.GetConsentHistory)
+
+	register := func(consent string) *httptest.ResponseRecorder {
+		body := `{"address":"` + address + `","jurisdiction":"GB"`
+		if consent != "" {
+			body += `,"consent":` + consent
+		}
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/kyc/register", strings.NewReader(body+"}"))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+		return w
+	}
+	consent := func(version, signature string) string {
+		return `{"policy_version":"` + version + `","signature":"` + signature + `"}`
+	}
+
+	assert.Equal(t, http.StatusForbidden, register("").Code)
+	assert.Equal(t, http.StatusForbidden, register(consent("2026-01-01", sign(address, "2026-01-01"))).Code)
+	other := "0x00000000000000000000000000000000000000bb"
+	assert.Equal(t, http.StatusBadRequest, register(consent("2026-09-01", sign(other, "2026-09-01"))).Code)
+
+	w := register(consent("2026-09-01", sign(address, "2026-09-01")))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/kyc/consent/"+address, nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	var history handlers.ConsentHistoryResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &history))
+	assert.True(t, history.Accepted)
+	assert.Equal(t, "2026-09-01", history.CurrentVersion)
+	require.Len(t, history.Consents, 1)
+	assert.Equal(t, handlers.ConsentMessage(address, "2026-09-01"), history.Consents[0].Message)
+	assert.NotEmpty(t, history.Consents[0].IPAddress)
+
+	// A new policy version must be accepted again
+	handler.SetConsentPolicy("2026-12-01")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/kyc/consent/"+address, nil))
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &history))
+	assert.False(t, history.Accepted)
+	assert.Equal(t, handlers.ConsentMessage(address, "2026-12-01"), history.ConsentMessage)
+}
@@ -0,0 +1,207 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/emailverify"
+)
+
+// StartEmailVerificationRequest captures the email to verify for a registration
+type StartEmailVerificationRequest struct {
+	Address string `json:"address" binding:"required"`
+	Email   string `json:"email" binding:"required"`
+}
+
+// ConfirmEmailRequest confirms a registration's email with the mailed code
+type ConfirmEmailRequest struct {
+	Address string `json:"address" binding:"required"`
+	Code    string `json:"code" binding:"required"`
+}
+
+// EmailVerificationResponse reports a sent verification email
+type EmailVerificationResponse struct {
+	Success   bool       `json:"success"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	Message   string     `json:"message,omitempty"`
+}
+
+// SetEmailVerification enables the email tier: confirming an email promotes
+// a registration to KYCLevelBasic. linkURL is the page verification links
+// open, which passes their token to ConfirmEmailLink.
+func (h *KYCHandler) SetEmailVerification(verifier *emailverify.Service, linkURL string) {
+	h.emailVerifier = verifier
+	h.emailLinkURL = linkURL
+}
+
+// StartEmailVerification handles POST /api/v1/kyc/email
+// @Summary Start email verification
+// @Description Mails a verification link and a six-digit code to the email given for a registration. Either confirms it within 15 minutes; a new email can be requested after a minute.
+// @Tags kyc
+// @Accept json
+// @Produce json
+// @Param request body StartEmailVerificationRequest true "Address and email"
+// @Success 200 {object} EmailVerificationResponse
+// @Failure 400 {object} EmailVerificationResponse
+// @Failure 404 {object} EmailVerificationResponse
+// @Failure 429 {object} EmailVerificationResponse
+// @Failure 502 {object} EmailVerificationResponse
+// @Failure 503 {object} EmailVerificationResponse
+// @Router /api/v1/kyc/email [post]
+func (h *KYCHandler) StartEmailVerification(c *gin.Context) {
+	if h.emailVerifier == nil {
+		c.JSON(http.StatusServiceUnavailable, EmailVerificationResponse{Success: false, Message: "Email verification is not configured"})
+		return
+	}
+
+	var req StartEmailVerificationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, EmailVerificationResponse{Success: false, Message: "Invalid request: " + err.Error()})
+		return
+	}
+	if !isValidAddress(req.Address) {
+		c.JSON(http.StatusBadRequest, EmailVerificationResponse{Success: false, Message: "Invalid address format"})
+		return
+	}
+	address := strings.ToLower(req.Address)
+
+	h.mu.RLock()
+	_, exists := h.registrations[address]
+	blacklisted := h.blacklist[address]
+	h.mu.RUnlock()
+	if !exists {
+		c.JSON(http.StatusNotFound, EmailVerificationResponse{Success: false, Message: "No KYC registration found for this address"})
+		return
+	}
+	if blacklisted {
+		c.JSON(http.StatusForbidden, EmailVerificationResponse{Success: false, Message: "Address is blacklisted"})
+		return
+	}
+
+	expires, err := h.emailVerifier.Start(c.Request.Context(), address, req.Email, h.emailLinkURL)
+	if err != nil {
+		status, message := http.StatusInternalServerError, "Internal server error"
+		switch {
+		case errors.Is(err, emailverify.ErrInvalidEmail):
+			status, message = http.StatusBadRequest, "Invalid email address"
+		case errors.Is(err, emailverify.ErrTooSoon):
+			status, message = http.StatusTooManyRequests, "A verification email was sent recently, try again in a minute"
+		case errors.Is(err, emailverify.ErrDelivery):
+			status, message = http.StatusBadGateway, "The verification email could not be sent"
+		default:
+			h.logger.Error("failed to start email verification", zap.String("address", address), zap.Error(err))
+		}
+		c.JSON(status, EmailVerificationResponse{Success: false, Message: message})
+		return
+	}
+
+	h.mu.Lock()
+	h.addAuditLog("KYC_EMAIL_SENT", address, address, "Email verification sent", c.ClientIP(), "", "")
+	h.mu.Unlock()
+
+	c.JSON(http.StatusOK, EmailVerificationResponse{
+		Success:   true,
+		ExpiresAt: &expires,
+		Message:   "Verification email sent",
+	})
+}
+
+// ConfirmEmail handles POST /api/v1/kyc/email/verify
+// @Summary Confirm email with code
+// @Description Confirms a registration's email with the mailed code and promotes it to KYC level 1 (Basic). Five wrong codes end the verification.
+// @Tags kyc
+// @Accept json
+// @Produce json
+// @Param request body ConfirmEmailRequest true "Address and code"
+// @Success 200 {object} KYCResponse
+// @Failure 400 {object} KYCResponse
+// @Failure 404 {object} KYCResponse
+// @Failure 503 {object} KYCResponse
+// @Router /api/v1/kyc/email/verify [post]
+func (h *KYCHandler) ConfirmEmail(c *gin.Context) {
+	if h.emailVerifier == nil {
+		c.JSON(http.StatusServiceUnavailable, KYCResponse{Success: false, Message: "Email verification is not configured"})
+		return
+	}
+
+	var req ConfirmEmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, KYCResponse{Success: false, Message: "Invalid request: " + err.Error()})
+		return
+	}
+	if !isValidAddress(req.Address) {
+		c.JSON(http.StatusBadRequest, KYCResponse{Success: false, Message: "Invalid address format"})
+		return
+	}
+
+	confirmation, err := h.emailVerifier.ConfirmCode(req.Address, req.Code)
+	h.completeEmailVerification(c, confirmation, err)
+}
+
+// ConfirmEmailLink handles GET /api/v1/kyc/email/verify
+// @Summary Confirm email with link
+// @Description Confirms a registration's email with the token of a mailed verification link and promotes it to KYC level 1 (Basic)
+// @Tags kyc
+// @Produce json
+// @Param token query string true "Verification token"
+// @Success 200 {object} KYCResponse
+// @Failure 400 {object} KYCResponse
+// @Failure 404 {object} KYCResponse
+// @Failure 503 {object} KYCResponse
+// @Router /api/v1/kyc/email/verify [get]
+func (h *KYCHandler) ConfirmEmailLink(c *gin.Context) {
+	if h.emailVerifier == nil {
+		c.JSON(http.StatusServiceUnavailable, KYCResponse{Success: false, Message: "Email verification is not configured"})
+		return
+	}
+
+	confirmation, err := h.emailVerifier.ConfirmLink(c.Query("token"))
+	h.completeEmailVerification(c, confirmation, err)
+}
+
+// completeEmailVerification stores a confirmed email on its registration and
+// promotes the registration to KYCLevelBasic; higher levels are kept
+func (h *KYCHandler) completeEmailVerification(c *gin.Context, confirmation *emailverify.Confirmation, err error) {
+	if err != nil {
+		c.JSON(http.StatusBadRequest, KYCResponse{Success: false, Message: err.Error()})
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	registration, exists := h.registrations[confirmation.Address]
+	if !exists {
+		c.JSON(http.StatusNotFound, KYCResponse{Success: false, Message: "No KYC registration found for this address"})
+		return
+	}
+
+	prevLevel := registration.Level
+	registration.Email = confirmation.Email
+	registration.EmailVerifiedAt = &confirmation.VerifiedAt
+	if registration.Level < KYCLevelBasic {
+		registration.Level = KYCLevelBasic
+	}
+	registration.UpdatedAt = confirmation.VerifiedAt
+	registration.Version++
+
+	h.addAuditLog("KYC_EMAIL_VERIFIED", confirmation.Address, confirmation.Address, "Email verified",
+		c.ClientIP(), strconv.Itoa(int(prevLevel)), strconv.Itoa(int(registration.Level)))
+
+	h.logger.Info("KYC email verified",
+		zap.String("address", confirmation.Address),
+		zap.Uint8("level", uint8(registration.Level)),
+	)
+
+	c.JSON(http.StatusOK, KYCResponse{
+		Success:      true,
+		Registration: registration,
+		Message:      "Email verified",
+	})
+}
@@ -0,0 +1,69 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/emailverify"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/fieldcrypt"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/handlers"
+)
+
+// lastMail keeps the body of the last email sent
+type lastMail struct{ body string }
+
+func (m *lastMail) SendMail(ctx context.Context, to, subject, body string) error {
+	m.body = body
+	return nil
+}
+
+func TestKYCHandler_EmailVerificationPromotesToBasic(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mail := &lastMail{}
+	handler := handlers.NewKYCHandler(zap.NewNop())
+	handler.SetEmailVerification(emailverify.NewService(mail, fieldcrypt.NewCipher(), func() string { return "test-key" }, zap.NewNop()),
+		"https://app.nexus.example/verify-email")
+
+	router := gin.New()
+	router.POST("/kyc/register", handler.Register)
+	router.GET("/kyc/status/:address", handler.GetKYCStatus)
+	router.POST("/kyc/email", handler.StartEmailVerification)
+	router.POST("/kyc/email/verify", handler.ConfirmEmail)
+
+	post := func(path, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	const address = "0x00000000000000000000000000000000000000aa"
+	assert.Equal(t, http.StatusNotFound, post("/kyc/email", `{"address":"`+address+`","email":"alice@example.com"}`).Code)
+	require.Equal(t, http.StatusOK, post("/kyc/register", `{"address":"`+address+`","jurisdiction":"CH"}`).Code)
+	assert.Equal(t, http.StatusBadRequest, post("/kyc/email", `{"address":"`+address+`","email":"alice"}`).Code)
+	require.Equal(t, http.StatusOK, post("/kyc/email", `{"address":"`+address+`","email":"alice@example.com"}`).Code)
+
+	code := regexp.MustCompile(`code (\d{6})`).FindStringSubmatch(mail.body)[1]
+	assert.Equal(t, http.StatusBadRequest, post("/kyc/email/verify", `{"address":"`+address+`","code":"wrong"}`).Code)
+	w := post("/kyc/email/verify", `{"address":"`+address+`","code":"`+code+`"}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/kyc/status/"+address, nil))
+	var resp handlers.KYCResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, handlers.KYCLevelBasic, resp.Registration.Level)
+	assert.NotNil(t, resp.Registration.EmailVerifiedAt)
+	assert.NotContains(t, w.Body.String(), "alice@example.com")
+}
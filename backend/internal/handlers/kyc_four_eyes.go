@@ -0,0 +1,325 @@
+package handlers
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// DefaultFourEyesRiskScore is the risk score above which approving a
+// registration takes two compliance officers
+const DefaultFourEyesRiskScore uint8 = 70
+
+// FourEyesPolicy selects the registrations whose approval needs a second
+// compliance officer. The first approval moves the registration to
+// KYCStatusPendingSecondApproval; it is approved only when a different
+// officer approves it too.
+type FourEyesPolicy struct {
+	RiskScore     uint8    // registrations scoring above it (100 for none)
+	Jurisdictions []string // ISO 3166-1 alpha-2 codes marked high risk
+}
+
+// SecondApprovalRequest approves or declines a registration awaiting its
+// second approval
+type SecondApprovalRequest struct {
+	Officer string `json:"officer" binding:"required"`
+	Reason  string `json:"reason,omitempty"` // required to decline
+	// ExpectedVersion is the version the decision is based on (or If-Match)
+	ExpectedVersion *int64 `json:"expected_version,omitempty"`
+}
+
+// SetFourEyesPolicy replaces the policy for two-person approval
+// (DefaultFourEyesRiskScore and no high-risk jurisdictions by default)
+func (h *KYCHandler) SetFourEyesPolicy(p FourEyesPolicy) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.fourEyesRiskScore = p.RiskScore
+	highRisk := make(map[string]bool, len(p.Jurisdictions))
+	for _, code := range p.Jurisdictions {
+		highRisk[strings.ToUpper(code)] = true
+	}
+	for code, j := range h.jurisdictions {
+		j.HighRisk = highRisk[code]
+	}
+}
+
+// needsSecondApproval reports whether approving reg at risk score takes two
+// officers (h.mu held)
+func (h *KYCHandler) needsSecondApproval(reg *KYCRegistration, score uint8) bool {
+	if score > h.fourEyesRiskScore {
+		return true
+	}
+	j, ok := h.jurisdictions[reg.Jurisdiction]
+	return ok && j.HighRisk
+}
+
+// fourEyes applies two-person approval to a KYC update (h.mu held). It
+// reports whether it has answered the request itself: a first approval is
+// recorded without approving, and a second approval goes to secondApproval.
+func (h *KYCHandler) fourEyes(c *gin.Context, reg *KYCRegistration, reviewer string, req *UpdateKYCRequest) bool {
+	if req.Status == KYCStatusPendingSecondApproval {
+		c.JSON(http.StatusBadRequest, KYCResponse{
+			Success: false,
+			Message: "pending_second_approval is set by approving a registration that needs two officers",
+		})
+		return true
+	}
+
+	if reg.Status == KYCStatusPendingSecondApproval {
+		if req.Status == KYCStatusApproved {
+			h.secondApproval(c, reg, reviewer)
+			return true
+		}
+		// Any other decision ends the two-person approval
+		clearFirstApproval(reg)
+		return false
+	}
+
+	if req.Status != KYCStatusApproved {
+		return false
+	}
+	// A reviewer lowering the score does not lift the requirement
+	score := reg.RiskScore
+	if req.RiskScore != nil && *req.RiskScore > score {
+		score = *req.RiskScore
+	}
+	if !h.needsSecondApproval(reg, score) {
+		return false
+	}
+
+	now := time.Now()
+	prevStatus := reg.Status
+	reg.Status = KYCStatusPendingSecondApproval
+	reg.RiskScore = score
+	reg.FirstApprovedBy = reviewer
+	reg.FirstApprovedAt = &now
+	reg.ApprovalLevel = req.Level
+	reg.ReviewedBy = reviewer
+	reg.UpdatedAt = now
+	reg.Version++
+	delete(h.assignments, reg.Address) // it leaves the review queue
+	h.addAuditLog("KYC_UPDATE", reviewer, reg.Address,
+		"First approval recorded; a second compliance officer must approve",
+		c.ClientIP(), string(prevStatus), string(KYCStatusPendingSecondApproval))
+
+	h.logger.Info("KYC first approval recorded",
+		zap.String("address", reg.Address),
+		zap.String("reviewer", reviewer),
+		zap.Uint8("risk_score", score),
+	)
+
+	setVersionETag(c, reg.Version)
+	c.JSON(http.StatusAccepted, KYCResponse{
+		Success:      true,
+		Registration: reg,
+		Message:      "Approval recorded; a second compliance officer must approve",
+	})
+	return true
+}
+
+// secondApproval approves reg awaiting its second approval, unless reviewer
+// gave the first (h.mu held)
+func (h *KYCHandler) secondApproval(c *gin.Context, reg *KYCRegistration, reviewer string) {
+	if reviewer == reg.FirstApprovedBy {
+		c.JSON(http.StatusForbidden, KYCResponse{
+			Success:      false,
+			Registration: reg,
+			Message:      "The second approval must come from a different compliance officer",
+		})
+		return
+	}
+
+	now := time.Now()
+	reg.Status = KYCStatusApproved
+	if reg.ApprovalLevel > 0 {
+		reg.Level = reg.ApprovalLevel
+	}
+	reg.ReviewedBy = reviewer
+	reg.UpdatedAt = now
+	reg.Version++
+	h.grantApproval(reg, now)
+	h.addAuditLog("KYC_UPDATE", reviewer, reg.Address,
+		"Second approval; first approval by "+reg.FirstApprovedBy,
+		c.ClientIP(), string(KYCStatusPendingSecondApproval), string(KYCStatusApproved))
+
+	h.logger.Info("KYC second approval recorded",
+		zap.String("address", reg.Address),
+		zap.String("first_approver", reg.FirstApprovedBy),
+		zap.String("second_approver", reviewer),
+	)
+
+	setVersionETag(c, reg.Version)
+	c.JSON(http.StatusOK, KYCResponse{
+		Success:      true,
+		Registration: reg,
+		Message:      "KYC registration approved by two compliance officers",
+	})
+}
+
+// clearFirstApproval forgets a first approval that did not lead to approval
+func clearFirstApproval(reg *KYCRegistration) {
+	reg.FirstApprovedBy = ""
+	reg.FirstApprovedAt = nil
+	reg.ApprovalLevel = KYCLevelNone
+}
+
+// ListPendingApprovals handles GET /api/v1/admin/kyc/approvals/pending
+// @Summary Registrations awaiting a second approval
+// @Description Returns registrations approved once that need a second compliance officer, oldest first approval first (compliance officer only)
+// @Tags kyc
+// @Produce json
+// @Param officer query string true "Compliance officer address"
+// @Param page query int false "Page number (default: 1)"
+// @Param page_size query int false "Page size (default: 20, max: 100)"
+// @Success 200 {object} KYCListResponse
+// @Failure 400 {object} KYCListResponse
+// @Failure 403 {object} KYCResponse
+// @Router /api/v1/admin/kyc/approvals/pending [get]
+func (h *KYCHandler) ListPendingApprovals(c *gin.Context) {
+	page, pageSize, ok := queryPage(c, 20)
+	if !ok {
+		return
+	}
+
+	h.mu.RLock()
+	if !h.complianceOfficers[strings.ToLower(c.Query("officer"))] {
+		h.mu.RUnlock()
+		c.JSON(http.StatusForbidden, KYCResponse{Success: false, Message: "Only compliance officers can review approvals"})
+		return
+	}
+	awaiting := []*KYCRegistration{}
+	for _, reg := range h.registrations {
+		if reg.Status == KYCStatusPendingSecondApproval {
+			cp := *reg
+			awaiting = append(awaiting, &cp)
+		}
+	}
+	h.mu.RUnlock()
+
+	sort.Slice(awaiting, func(i, j int) bool {
+		return awaiting[i].FirstApprovedAt.Before(*awaiting[j].FirstApprovedAt)
+	})
+
+	total := len(awaiting)
+	start := min((page-1)*pageSize, total)
+	end := min(start+pageSize, total)
+	c.JSON(http.StatusOK, KYCListResponse{
+		Success:       true,
+		Registrations: awaiting[start:end],
+		Total:         total,
+		Page:          page,
+		PageSize:      pageSize,
+	})
+}
+
+// ApproveSecond handles POST /api/v1/admin/kyc/approvals/:address/approve
+// @Summary Give the second approval
+// @Description Approves a registration awaiting its second approval. The officer must not be the one who gave the first.
+// @Tags kyc
+// @Accept json
+// @Produce json
+// @Param address path string true "Registration address"
+// @Param request body SecondApprovalRequest true "Approval"
+// @Success 200 {object} KYCResponse
+// @Failure 400 {object} KYCResponse
+// @Failure 403 {object} KYCResponse
+// @Failure 404 {object} KYCResponse
+// @Failure 409 {object} KYCResponse
+// @Router /api/v1/admin/kyc/approvals/{address}/approve [post]
+func (h *KYCHandler) ApproveSecond(c *gin.Context) {
+	h.decideSecond(c, true)
+}
+
+// DeclineSecond handles POST /api/v1/admin/kyc/approvals/:address/decline
+// @Summary Decline the second approval
+// @Description Returns a registration awaiting its second approval to the review queue, with a reason
+// @Tags kyc
+// @Accept json
+// @Produce json
+// @Param address path string true "Registration address"
+// @Param request body SecondApprovalRequest true "Decline, with reason"
+// @Success 200 {object} KYCResponse
+// @Failure 400 {object} KYCResponse
+// @Failure 403 {object} KYCResponse
+// @Failure 404 {object} KYCResponse
+// @Failure 409 {object} KYCResponse
+// @Router /api/v1/admin/kyc/approvals/{address}/decline [post]
+func (h *KYCHandler) DeclineSecond(c *gin.Context) {
+	h.decideSecond(c, false)
+}
+
+// decideSecond approves or declines the registration at :address awaiting
+// its second approval
+func (h *KYCHandler) decideSecond(c *gin.Context, approve bool) {
+	var req SecondApprovalRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, KYCResponse{Success: false, Message: "Invalid request: " + err.Error()})
+		return
+	}
+	address := c.Param("address")
+	if !isValidAddress(address) || !isValidAddress(req.Officer) {
+		c.JSON(http.StatusBadRequest, KYCResponse{Success: false, Message: "Invalid address format"})
+		return
+	}
+	if !approve && strings.TrimSpace(req.Reason) == "" {
+		c.JSON(http.StatusBadRequest, KYCResponse{Success: false, Message: "A reason is required to decline"})
+		return
+	}
+	version, err := expectedVersion(c, req.ExpectedVersion)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, KYCResponse{Success: false, Message: err.Error()})
+		return
+	}
+	address, officer := strings.ToLower(address), strings.ToLower(req.Officer)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if !h.complianceOfficers[officer] {
+		c.JSON(http.StatusForbidden, KYCResponse{Success: false, Message: "Only compliance officers can review approvals"})
+		return
+	}
+	reg, exists := h.registrations[address]
+	if !exists {
+		c.JSON(http.StatusNotFound, KYCResponse{Success: false, Message: "No KYC registration found for this address"})
+		return
+	}
+	if reg.Status != KYCStatusPendingSecondApproval {
+		c.JSON(http.StatusConflict, KYCResponse{Success: false, Registration: reg, Message: "Registration is not awaiting a second approval"})
+		return
+	}
+	if version != nil && *version != reg.Version {
+		c.JSON(http.StatusConflict, KYCResponse{
+			Success:      false,
+			Registration: reg,
+			Message:      "KYC registration was changed by another update; review the current version and retry",
+		})
+		return
+	}
+
+	if approve {
+		h.secondApproval(c, reg, officer)
+		return
+	}
+
+	firstApprover := reg.FirstApprovedBy
+	clearFirstApproval(reg)
+	reg.Status = KYCStatusPending
+	reg.ReviewedBy = officer
+	reg.UpdatedAt = time.Now()
+	reg.Version++
+	h.addAuditLog("KYC_UPDATE", officer, address,
+		"Second approval declined (first approval by "+firstApprover+"): "+req.Reason,
+		c.ClientIP(), string(KYCStatusPendingSecondApproval), string(KYCStatusPending))
+
+	setVersionETag(c, reg.Version)
+	c.JSON(http.StatusOK, KYCResponse{
+		Success:      true,
+		Registration: reg,
+		Message:      "Second approval declined; the registration is back in the review queue",
+	})
+}
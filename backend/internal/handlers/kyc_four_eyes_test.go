@@ -0,0 +1,200 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/handlers"
+)
+
+func TestKYCHandler_FourEyesApproval(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	const (
+		risky    = "0x00000000000000000000000000000000000000b1" // risk score 80
+		declined = "0x00000000000000000000000000000000000000b2" // risk score 90
+		lowRisk  = "0x00000000000000000000000000000000000000b3"
+		swiss    = "0x00000000000000000000000000000000000000b4"
+		alice    = "0x00000000000000000000000000000000000000c1"
+		bob      = "0x00000000000000000000000000000000000000c2"
+		outsider = "0x00000000000000000000000000000000000000ee"
+	)
+	now := time.Now()
+	handler := handlers.NewKYCHandler(zap.NewNop())
+	handler.Seed(handlers.KYCFixtures{
+		ComplianceOfficers: []string{alice, bob},
+		Registrations: []*handlers.KYCRegistration{
+			{Address: risky, Status: handlers.KYCStatusPending, Jurisdiction: "GB", RiskScore: 80, CreatedAt: now.Add(-2 * time.Hour)},
+			{Address: declined, Status: handlers.KYCStatusPending, Jurisdiction: "GB", RiskScore: 90, CreatedAt: now.Add(-time.Hour)},
+			{Address: lowRisk, Status: handlers.KYCStatusPending, Jurisdiction: "GB", RiskScore: 10, CreatedAt: now},
+			{Address: swiss, Status: handlers.KYCStatusPending, Jurisdiction: "CH", RiskScore: 10, CreatedAt: now},
+		},
+	})
+
+	router := gin.New()
+	router.PUT("/kyc/update", handler.UpdateKYC)
+	router.GET("/kyc/approvals/pending", handler.ListPendingApprovals)
+	router.POST("/kyc/approvals/:address/approve", handler.ApproveSecond)
+	router.POST("/kyc/approvals/:address/decline", handler.DeclineSecond)
+
+	send := func(method, path, body string) (int, handlers.KYCResponse) {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		var resp handlers.KYCResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp), w.Body.String())
+		return w.Code, resp
+	}
+	update := func(address, status, reviewer string) (int, handlers.KYCResponse) {
+		return send(http.MethodPut, "/kyc/update",
+			`{"address":"`+address+`","status":"`+status+`","level":2,"reviewer":"`+reviewer+`"}`)
+	}
+	pending := func() []string {
+		req := httptest.NewRequest(http.MethodGet, "/kyc/approvals/pending?officer="+bob, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var resp handlers.KYCListResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		var out []string
+		for _, reg := range resp.Registrations {
+			out = append(out, reg.Address)
+		}
+		return out
+	}
+
+	// The status is only reached through a first approval
+	code, _ := update(risky, string(handlers.KYCStatusPendingSecondApproval), alice)
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	// A high-risk approval waits for a second officer
+	code, resp := update(risky, "approved", alice)
+	require.Equal(t, http.StatusAccepted, code)
+	assert.Equal(t, handlers.KYCStatusPendingSecondApproval, resp.Registration.Status)
+	assert.Equal(t, alice, resp.Registration.FirstApprovedBy)
+	assert.NotNil(t, resp.Registration.FirstApprovedAt)
+	code, _ = update(declined, "approved", alice)
+	require.Equal(t, http.StatusAccepted, code)
+	assert.Equal(t, []string{risky, declined}, pending())
+
+	req := httptest.NewRequest(http.MethodGet, "/kyc/approvals/pending?officer="+outsider, nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	// The first approver cannot give the second, by either route
+	code, _ = update(risky, "approved", alice)
+	assert.Equal(t, http.StatusForbidden, code)
+	code, _ = send(http.MethodPost, "/kyc/approvals/"+risky+"/approve", `{"officer":"`+alice+`"}`)
+	assert.Equal(t, http.StatusForbidden, code)
+	code, _ = send(http.MethodPost, "/kyc/approvals/"+risky+"/approve", `{"officer":"`+outsider+`"}`)
+	assert.Equal(t, http.StatusForbidden, code)
+	code, _ = send(http.MethodPost, "/kyc/approvals/"+risky+"/approve", `{"officer":"`+bob+`","expected_version":99}`)
+	assert.Equal(t, http.StatusConflict, code)
+
+	code, resp = send(http.MethodPost, "/kyc/approvals/"+risky+"/approve", `{"officer":"`+bob+`"}`)
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, handlers.KYCStatusApproved, resp.Registration.Status)
+	assert.Equal(t, handlers.KYCLevelStandard, resp.Registration.Level)
+	assert.Equal(t, bob, resp.Registration.ReviewedBy)
+	assert.Equal(t, alice, resp.Registration.FirstApprovedBy)
+	code, _ = send(http.MethodPost, "/kyc/approvals/"+risky+"/approve", `{"officer":"`+bob+`"}`)
+	assert.Equal(t, http.StatusConflict, code)
+
+	// A decline needs a reason and sends the registration back for review
+	code, _ = send(http.MethodPost, "/kyc/approvals/"+declined+"/decline", `{"officer":"`+bob+`"}`)
+	assert.Equal(t, http.StatusBadRequest, code)
+	code, resp = send(http.MethodPost, "/kyc/approvals/"+declined+"/decline", `{"officer":"`+bob+`","reason":"source of funds unclear"}`)
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, handlers.KYCStatusPending, resp.Registration.Status)
+	assert.Empty(t, resp.Registration.FirstApprovedBy)
+	assert.Empty(t, pending())
+
+	// Low-risk registrations are approved by one officer
+	code, resp = update(lowRisk, "approved", alice)
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, handlers.KYCStatusApproved, resp.Registration.Status)
+
+	// A high-risk jurisdiction needs two officers whatever the score
+	handler.SetFourEyesPolicy(handlers.FourEyesPolicy{RiskScore: handlers.DefaultFourEyesRiskScore, Jurisdictions: []string{"ch"}})
+	code, resp = update(swiss, "approved", alice)
+	require.Equal(t, http.StatusAccepted, code)
+	assert.Equal(t, handlers.KYCStatusPendingSecondApproval, resp.Registration.Status)
+	code, resp = update(swiss, "approved", bob)
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, handlers.KYCStatusApproved, resp.Registration.Status)
+}
+
+func TestKYCHandler_FourEyesCannotBeLifted(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	const (
+		risky     = "0x00000000000000000000000000000000000000b1" // risk score 80
+		suspended = "0x00000000000000000000000000000000000000b2"
+		alice     = "0x00000000000000000000000000000000000000c1"
+		bob       = "0x00000000000000000000000000000000000000c2"
+	)
+	handler := handlers.NewKYCHandler(zap.NewNop())
+	handler.Seed(handlers.KYCFixtures{
+		ComplianceOfficers: []string{alice, bob},
+		Registrations: []*handlers.KYCRegistration{
+			{Address: risky, Status: handlers.KYCStatusPending, Jurisdiction: "GB", RiskScore: 80, CreatedAt: time.Now()},
+			{Address: suspended, Status: handlers.KYCStatusSuspended, Jurisdiction: "GB", RiskScore: 80, CreatedAt: time.Now()},
+		},
+	})
+
+	router := gin.New()
+	router.PUT("/kyc/update", handler.UpdateKYC)
+	router.GET("/kyc/audit-log", handler.GetAuditLog)
+
+	update := func(body string) (int, handlers.KYCResponse) {
+		req := httptest.NewRequest(http.MethodPut, "/kyc/update", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		var resp handlers.KYCResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp), w.Body.String())
+		return w.Code, resp
+	}
+
+	// Lowering the score in a separate update does not stick...
+	code, resp := update(`{"address":"` + risky + `","status":"pending","reviewer":"` + alice + `","risk_score":0}`)
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, uint8(80), resp.Registration.RiskScore)
+
+	// ...so the approval still waits for a second officer
+	code, resp = update(`{"address":"` + risky + `","status":"approved","level":2,"reviewer":"` + alice + `"}`)
+	require.Equal(t, http.StatusAccepted, code)
+	assert.Equal(t, handlers.KYCStatusPendingSecondApproval, resp.Registration.Status)
+
+	// A score can still be raised
+	code, resp = update(`{"address":"` + suspended + `","status":"suspended","reviewer":"` + alice + `","risk_score":95}`)
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, uint8(95), resp.Registration.RiskScore)
+
+	// The first approval's audit entry records the state it left
+	code, _ = update(`{"address":"` + suspended + `","status":"approved","level":2,"reviewer":"` + alice + `"}`)
+	require.Equal(t, http.StatusAccepted, code)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/kyc/audit-log?subject="+suspended, nil))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var audit handlers.AuditLogResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &audit))
+	var first *handlers.AuditLogEntry
+	for _, entry := range audit.Entries {
+		if entry.NewState == string(handlers.KYCStatusPendingSecondApproval) {
+			first = entry
+		}
+	}
+	require.NotNil(t, first, w.Body.String())
+	assert.Equal(t, alice, first.Actor)
+	assert.Equal(t, string(handlers.KYCStatusSuspended), first.PreviousState)
+}
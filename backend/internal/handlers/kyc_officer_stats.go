@@ -0,0 +1,268 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+const (
+	// defaultOfficerStatsWindow is the period officer stats cover without from
+	defaultOfficerStatsWindow = 30 * 24 * time.Hour
+	// officerStatsLookback is how far before the window the audit log is read
+	// for the submissions and assignments that reviews in the window started from
+	officerStatsLookback = 90 * 24 * time.Hour
+	// officerStatsBatch is the page size used to read the audit store
+	officerStatsBatch = 500
+)
+
+// OfficerStats is one compliance officer's review work over a period, derived
+// from the audit log, and their current workload
+type OfficerStats struct {
+	Officer              string `json:"officer"`
+	Active               bool   `json:"active"`  // still a compliance officer
+	Reviews              int    `json:"reviews"` // KYC updates and accreditation reviews
+	KYCReviews           int    `json:"kyc_reviews"`
+	AccreditationReviews int    `json:"accreditation_reviews"`
+	// Decisions count KYC updates by resulting status, AccreditationDecisions
+	// accreditation reviews by resulting attestation status
+	Decisions              map[string]int `json:"decisions"`
+	AccreditationDecisions map[string]int `json:"accreditation_decisions"`
+	// AvgHandlingSeconds averages, over the TimedReviews decided from pending,
+	// the time from the officer taking the item (its assignment, or else its
+	// submission) to the decision. A second approval is timed from the first.
+	TimedReviews       int        `json:"timed_reviews"`
+	AvgHandlingSeconds float64    `json:"avg_handling_seconds"`
+	LastReviewAt       *time.Time `json:"last_review_at,omitempty"`
+	// Pending registrations assigned to the officer now, and those past their SLA
+	OpenAssignments    int `json:"open_assignments"`
+	OverdueAssignments int `json:"overdue_assignments"`
+
+	handling time.Duration
+}
+
+// OfficerStatsResponse wraps the stats of every officer, the busiest first
+type OfficerStatsResponse struct {
+	Success  bool            `json:"success"`
+	From     *time.Time      `json:"from,omitempty"`
+	To       *time.Time      `json:"to,omitempty"`
+	Officers []*OfficerStats `json:"officers"`
+	Message  string          `json:"message,omitempty"`
+}
+
+// GetOfficerStats handles GET /api/v1/admin/kyc/officer-stats
+// @Summary Compliance officer performance
+// @Description Returns per-officer review counts, decision breakdown and average handling time from the audit log, with each officer's open assignments (compliance officer only)
+// @Tags admin
+// @Produce json
+// @Param officer query string true "Compliance officer address"
+// @Param from query string false "Start of the period (RFC3339, default: 30 days before to)"
+// @Param to query string false "End of the period (RFC3339, default: now)"
+// @Success 200 {object} OfficerStatsResponse
+// @Failure 400 {object} OfficerStatsResponse
+// @Failure 403 {object} OfficerStatsResponse
+// @Failure 500 {object} OfficerStatsResponse
+// @Router /api/v1/admin/kyc/officer-stats [get]
+func (h *KYCHandler) GetOfficerStats(c *gin.Context) {
+	now := time.Now()
+	to, err := parseOptionalTime(c.Query("to"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, OfficerStatsResponse{Success: false, Message: "Invalid to: must be RFC3339"})
+		return
+	}
+	if to == nil {
+		to = &now
+	}
+	from, err := parseOptionalTime(c.Query("from"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, OfficerStatsResponse{Success: false, Message: "Invalid from: must be RFC3339"})
+		return
+	}
+	if from == nil {
+		start := to.Add(-defaultOfficerStatsWindow)
+		from = &start
+	}
+	if !from.Before(*to) {
+		c.JSON(http.StatusBadRequest, OfficerStatsResponse{Success: false, Message: "from must be before to"})
+		return
+	}
+
+	h.mu.RLock()
+	isOfficer := h.complianceOfficers[strings.ToLower(c.Query("officer"))]
+	h.mu.RUnlock()
+	if !isOfficer {
+		c.JSON(http.StatusForbidden, OfficerStatsResponse{Success: false, Message: "Only compliance officers can view officer stats"})
+		return
+	}
+
+	entries, err := h.auditEntriesBetween(c.Request.Context(), from.Add(-officerStatsLookback), *to)
+	if err != nil {
+		h.logger.Error("failed to read audit log for officer stats", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, OfficerStatsResponse{Success: false, Message: "Internal server error"})
+		return
+	}
+	stats := officerReviewStats(entries, *from)
+
+	h.mu.RLock()
+	officer := func(address string) *OfficerStats {
+		s, ok := stats[address]
+		if !ok {
+			s = &OfficerStats{Officer: address, Decisions: map[string]int{}, AccreditationDecisions: map[string]int{}}
+			stats[address] = s
+		}
+		return s
+	}
+	for address := range h.complianceOfficers {
+		officer(address).Active = true
+	}
+	for address, a := range h.assignments {
+		reg, ok := h.registrations[address]
+		if !ok || reg.Status != KYCStatusPending {
+			continue
+		}
+		s := officer(a.Officer)
+		s.OpenAssignments++
+		if h.queueItem(reg, now).SLABreached {
+			s.OverdueAssignments++
+		}
+	}
+	h.mu.RUnlock()
+
+	officers := make([]*OfficerStats, 0, len(stats))
+	for _, s := range stats {
+		if s.TimedReviews > 0 {
+			s.AvgHandlingSeconds = s.handling.Seconds() / float64(s.TimedReviews)
+		}
+		officers = append(officers, s)
+	}
+	sort.Slice(officers, func(i, j int) bool {
+		if officers[i].Reviews != officers[j].Reviews {
+			return officers[i].Reviews > officers[j].Reviews
+		}
+		return officers[i].Officer < officers[j].Officer
+	})
+
+	c.JSON(http.StatusOK, OfficerStatsResponse{Success: true, From: from, To: to, Officers: officers})
+}
+
+// officerReviewStats counts the reviews in entries (oldest first) from from
+// on, by officer. Earlier entries only supply the start of reviews.
+func officerReviewStats(entries []*AuditLogEntry, from time.Time) map[string]*OfficerStats {
+	type assignment struct {
+		officer string
+		at      time.Time
+	}
+	registered := map[string]time.Time{} // subject -> latest submission
+	assigned := map[string]assignment{}  // subject -> latest assignment of its registration
+	uploaded := map[string]time.Time{}   // subject -> latest attestation upload
+	approved := map[string]time.Time{}   // subject -> first of two approvals
+
+	stats := map[string]*OfficerStats{}
+	review := func(e *AuditLogEntry, start time.Time, timed bool) *OfficerStats {
+		s, ok := stats[e.Actor]
+		if !ok {
+			s = &OfficerStats{Officer: e.Actor, Decisions: map[string]int{}, AccreditationDecisions: map[string]int{}}
+			stats[e.Actor] = s
+		}
+		s.Reviews++
+		if timed && !start.IsZero() {
+			s.TimedReviews++
+			s.handling += e.Timestamp.Sub(start)
+		}
+		at := e.Timestamp
+		s.LastReviewAt = &at
+		return s
+	}
+
+	for _, e := range entries {
+		counted := !e.Timestamp.Before(from)
+		switch e.Action {
+		case "KYC_REGISTER":
+			registered[e.Subject] = e.Timestamp
+			delete(assigned, e.Subject)
+		case "KYC_ASSIGN":
+			assigned[e.Subject] = assignment{officer: e.NewState, at: e.Timestamp}
+		case "ACCREDITATION_UPLOAD":
+			uploaded[e.Subject] = e.Timestamp
+		case "KYC_UPDATE":
+			if counted {
+				start, timed := registered[e.Subject], e.PreviousState == string(KYCStatusPending)
+				if a, ok := assigned[e.Subject]; ok && a.officer == e.Actor {
+					start = a.at
+				}
+				if e.PreviousState == string(KYCStatusPendingSecondApproval) {
+					start, timed = approved[e.Subject], true
+				}
+				s := review(e, start, timed)
+				s.KYCReviews++
+				s.Decisions[e.NewState]++
+			}
+			if e.NewState == string(KYCStatusPendingSecondApproval) {
+				approved[e.Subject] = e.Timestamp
+			}
+			if e.NewState != string(KYCStatusPending) {
+				delete(assigned, e.Subject)
+			}
+		case "ACCREDITATION_REVIEW":
+			if counted {
+				s := review(e, uploaded[e.Subject], e.PreviousState == string(AttestationPending))
+				s.AccreditationReviews++
+				s.AccreditationDecisions[e.NewState]++
+			}
+		}
+	}
+	return stats
+}
+
+// auditEntriesBetween returns the audit log from from to to, oldest first
+func (h *KYCHandler) auditEntriesBetween(ctx context.Context, from, to time.Time) ([]*AuditLogEntry, error) {
+	if h.auditStore == nil {
+		h.mu.RLock()
+		defer h.mu.RUnlock()
+		var entries []*AuditLogEntry
+		for _, e := range h.auditLog {
+			if !e.Timestamp.Before(from) && !e.Timestamp.After(to) {
+				cp := *e
+				entries = append(entries, &cp)
+			}
+		}
+		return entries, nil
+	}
+
+	var entries []*AuditLogEntry
+	cursor := repository.KYCAuditCursorAt(to)
+	for {
+		batch, err := h.auditStore.ListAuditEntries(ctx, repository.KYCAuditFilter{After: &cursor}, officerStatsBatch)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range batch {
+			if e.Timestamp.Before(from) {
+				slices.Reverse(entries)
+				return entries, nil
+			}
+			entries = append(entries, &AuditLogEntry{
+				ID:            e.ID,
+				Timestamp:     e.Timestamp,
+				Action:        e.Action,
+				Actor:         e.Actor,
+				Subject:       e.Subject,
+				PreviousState: e.PreviousState,
+				NewState:      e.NewState,
+			})
+		}
+		if len(batch) < officerStatsBatch {
+			slices.Reverse(entries)
+			return entries, nil
+		}
+		cursor = batch[len(batch)-1].Cursor()
+	}
+}
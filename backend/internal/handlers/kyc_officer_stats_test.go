@@ -0,0 +1,131 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/handlers"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/storage/memory"
+)
+
+func TestKYCHandler_OfficerStats(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	const (
+		a1       = "0x00000000000000000000000000000000000000a1"
+		a2       = "0x00000000000000000000000000000000000000a2"
+		a3       = "0x00000000000000000000000000000000000000a3"
+		waiting  = "0x00000000000000000000000000000000000000a4"
+		alice    = "0x00000000000000000000000000000000000000c1"
+		bob      = "0x00000000000000000000000000000000000000c2"
+		carol    = "0x00000000000000000000000000000000000000c3"
+		outsider = "0x00000000000000000000000000000000000000ee"
+	)
+	now := time.Now().UTC()
+	t0 := now.Add(-10 * 24 * time.Hour)
+	store := memory.NewMemoryKYCAuditRepo()
+	for _, e := range []*repository.KYCAuditEntry{
+		// Out of the default 30-day window; only its submission is in the lookback
+		{Timestamp: now.Add(-45 * 24 * time.Hour), Action: "KYC_REGISTER", Actor: a3, Subject: a3, NewState: "pending"},
+		{Timestamp: now.Add(-40 * 24 * time.Hour), Action: "KYC_UPDATE", Actor: alice, Subject: a3, PreviousState: "pending", NewState: "approved"},
+
+		{Timestamp: t0, Action: "KYC_REGISTER", Actor: a1, Subject: a1, NewState: "pending"},
+		{Timestamp: t0, Action: "KYC_REGISTER", Actor: a2, Subject: a2, NewState: "pending"},
+		{Timestamp: t0.Add(time.Hour), Action: "KYC_ASSIGN", Actor: alice, Subject: a1, NewState: alice},
+		{Timestamp: t0.Add(time.Hour), Action: "ACCREDITATION_UPLOAD", Actor: a1, Subject: a1},
+		{Timestamp: t0.Add(2 * time.Hour), Action: "ACCREDITATION_REVIEW", Actor: alice, Subject: a1, PreviousState: "pending", NewState: "approved"},
+		// Handled from assignment (2h) and from submission (4h)
+		{Timestamp: t0.Add(3 * time.Hour), Action: "KYC_UPDATE", Actor: alice, Subject: a1, PreviousState: "pending", NewState: "approved"},
+		{Timestamp: t0.Add(4 * time.Hour), Action: "KYC_UPDATE", Actor: bob, Subject: a2, PreviousState: "pending", NewState: "rejected"},
+		// Not from pending: counted, not timed
+		{Timestamp: t0.Add(5 * time.Hour), Action: "KYC_UPDATE", Actor: alice, Subject: a1, PreviousState: "approved", NewState: "suspended"},
+	} {
+		require.NoError(t, store.AppendAuditEntry(context.Background(), e))
+	}
+
+	handler := handlers.NewKYCHandler(zap.NewNop())
+	handler.Seed(handlers.KYCFixtures{
+		ComplianceOfficers: []string{alice, bob, carol},
+		Registrations: []*handlers.KYCRegistration{
+			{Address: waiting, Status: handlers.KYCStatusPending, Jurisdiction: "US", CreatedAt: now.Add(-100 * time.Hour)},
+		},
+	})
+	handler.SetAuditStore(store)
+
+	router := gin.New()
+	router.GET("/admin/kyc/officer-stats", handler.GetOfficerStats)
+	router.POST("/admin/kyc/queue/:address/claim", handler.ClaimQueueItem)
+	get := func(query string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/kyc/officer-stats?officer="+alice+query, nil))
+		return w
+	}
+	stats := func(query string) map[string]*handlers.OfficerStats {
+		w := get(query)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var resp handlers.OfficerStatsResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		byOfficer := map[string]*handlers.OfficerStats{}
+		for i, s := range resp.Officers {
+			if i > 0 {
+				assert.GreaterOrEqual(t, resp.Officers[i-1].Reviews, s.Reviews, "busiest first")
+			}
+			byOfficer[s.Officer] = s
+		}
+		return byOfficer
+	}
+
+	claim := httptest.NewRequest(http.MethodPost, "/admin/kyc/queue/"+waiting+"/claim", strings.NewReader(`{"officer":"`+carol+`"}`))
+	claim.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, claim)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	byOfficer := stats("")
+	require.Len(t, byOfficer, 3)
+	s := byOfficer[alice]
+	assert.True(t, s.Active)
+	assert.Equal(t, 3, s.Reviews)
+	assert.Equal(t, 2, s.KYCReviews)
+	assert.Equal(t, 1, s.AccreditationReviews)
+	assert.Equal(t, map[string]int{"approved": 1, "suspended": 1}, s.Decisions)
+	assert.Equal(t, map[string]int{"approved": 1}, s.AccreditationDecisions)
+	assert.Equal(t, 2, s.TimedReviews)
+	assert.InDelta(t, 1.5*3600, s.AvgHandlingSeconds, 0.001, "(2h + 1h) / 2")
+	require.NotNil(t, s.LastReviewAt)
+	assert.True(t, s.LastReviewAt.Equal(t0.Add(5*time.Hour)))
+
+	s = byOfficer[bob]
+	assert.Equal(t, 1, s.Reviews)
+	assert.Equal(t, map[string]int{"rejected": 1}, s.Decisions)
+	assert.InDelta(t, 4*3600, s.AvgHandlingSeconds, 0.001)
+
+	s = byOfficer[carol]
+	assert.Zero(t, s.Reviews)
+	assert.Equal(t, 1, s.OpenAssignments)
+	assert.Equal(t, 1, s.OverdueAssignments)
+
+	// A period before the reviews
+	byOfficer = stats("&to=" + url.QueryEscape(t0.Format(time.RFC3339)))
+	assert.Zero(t, byOfficer[bob].Reviews)
+	assert.Equal(t, 1, byOfficer[alice].Reviews, "the a3 review 40 days ago")
+	assert.Equal(t, 1, byOfficer[alice].TimedReviews)
+	assert.InDelta(t, 5*24*3600, byOfficer[alice].AvgHandlingSeconds, 0.001)
+
+	assert.Equal(t, http.StatusBadRequest, get("&from=yesterday").Code)
+	assert.Equal(t, http.StatusBadRequest, get("&from="+url.QueryEscape(now.Format(time.RFC3339))+"&to="+url.QueryEscape(t0.Format(time.RFC3339))).Code)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/kyc/officer-stats?officer="+outsider, nil))
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
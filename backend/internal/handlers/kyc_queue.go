@@ -0,0 +1,347 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// DefaultKYCQueueSLA is how long a pending registration may wait for review,
+// by the level being verified. Higher levels need more documents checked.
+var DefaultKYCQueueSLA = map[KYCLevel]time.Duration{
+	KYCLevelNone:     24 * time.Hour,
+	KYCLevelBasic:    24 * time.Hour,
+	KYCLevelStandard: 48 * time.Hour,
+	KYCLevelAdvanced: 72 * time.Hour,
+}
+
+// kycQueueAtRisk is the share of its SLA a registration may wait before it is
+// flagged as at risk of a breach
+const kycQueueAtRisk = 0.75
+
+// KYCVerificationSource looks up the Sumsub verification of an address
+// (implemented by repository.PaymentRepository)
+type KYCVerificationSource interface {
+	GetKYCVerificationByAddress(ctx context.Context, address string) (*repository.KYCVerification, error)
+}
+
+// KYCAssignment is the compliance officer reviewing a pending registration
+type KYCAssignment struct {
+	Officer    string    `json:"officer"`
+	AssignedBy string    `json:"assigned_by"` // the officer itself for a claim
+	AssignedAt time.Time `json:"assigned_at"`
+}
+
+// KYCQueueItem is a pending registration with its review state
+type KYCQueueItem struct {
+	KYCRegistration
+	// ReviewLevel is the level being verified: the jurisdiction's requirement
+	// or the registration's current level, whichever is higher
+	ReviewLevel KYCLevel       `json:"review_level"`
+	AgeSeconds  int64          `json:"age_seconds"`
+	SLADueAt    *time.Time     `json:"sla_due_at,omitempty"` // nil when the level has no SLA
+	SLAAtRisk   bool           `json:"sla_at_risk"`
+	SLABreached bool           `json:"sla_breached"`
+	Assignment  *KYCAssignment `json:"assignment,omitempty"`
+	// Sumsub verification of the address, when one was started
+	SumsubStatus       repository.KYCVerificationStatus `json:"sumsub_status,omitempty"`
+	SumsubReviewStatus string                           `json:"sumsub_review_status,omitempty"`
+}
+
+// KYCQueueSummary counts the whole queue, not only the page
+type KYCQueueSummary struct {
+	Pending    int `json:"pending"`
+	AtRisk     int `json:"at_risk"`
+	Breached   int `json:"breached"`
+	Unassigned int `json:"unassigned"`
+}
+
+// KYCQueueResponse wraps a page of the review queue
+type KYCQueueResponse struct {
+	Success  bool            `json:"success"`
+	Items    []*KYCQueueItem `json:"items"`
+	Summary  KYCQueueSummary `json:"summary"`
+	Total    int             `json:"total"`
+	Page     int             `json:"page,omitempty"`
+	PageSize int             `json:"page_size,omitempty"`
+	Message  string          `json:"message,omitempty"`
+}
+
+// ClaimKYCRequest takes a pending registration for review
+type ClaimKYCRequest struct {
+	Officer string `json:"officer" binding:"required"`
+}
+
+// AssignKYCRequest gives a pending registration to an officer
+type AssignKYCRequest struct {
+	Officer    string `json:"officer" binding:"required"`     // the assignee
+	AssignedBy string `json:"assigned_by" binding:"required"` // a compliance officer
+}
+
+// KYCAssignmentResponse wraps the assignment of a registration
+type KYCAssignmentResponse struct {
+	Success    bool           `json:"success"`
+	Address    string         `json:"address,omitempty"`
+	Assignment *KYCAssignment `json:"assignment,omitempty"`
+	Message    string         `json:"message,omitempty"`
+}
+
+// SetQueueSLA overrides the review SLA of the given levels; the others keep
+// DefaultKYCQueueSLA. A zero duration removes the level's SLA.
+func (h *KYCHandler) SetQueueSLA(targets map[KYCLevel]time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for level, target := range targets {
+		if target <= 0 {
+			delete(h.queueSLA, level)
+			continue
+		}
+		h.queueSLA[level] = target
+	}
+}
+
+// SetVerificationSource adds each queued address's Sumsub status to the queue
+func (h *KYCHandler) SetVerificationSource(src KYCVerificationSource) {
+	h.verifications = src
+}
+
+// GetQueue handles GET /api/v1/admin/kyc/queue
+// @Summary KYC review queue
+// @Description Returns pending registrations, the most urgent SLA first, with their age, SLA flags, assigned officer and Sumsub status (compliance officer only)
+// @Tags admin
+// @Produce json
+// @Param officer query string true "Compliance officer address"
+// @Param assignee query string false "Only registrations assigned to this officer, or \"unassigned\""
+// @Param breached query bool false "Only registrations past their SLA"
+// @Param page query int false "Page number (default: 1)"
+// @Param page_size query int false "Page size (default: 20, max: 100)"
+// @Success 200 {object} KYCQueueResponse
+// @Failure 400 {object} KYCQueueResponse
+// @Failure 403 {object} KYCQueueResponse
+// @Router /api/v1/admin/kyc/queue [get]
+func (h *KYCHandler) GetQueue(c *gin.Context) {
+	page, pageSize, ok := queryPage(c, 20)
+	if !ok {
+		return
+	}
+	breachedOnly, ok := queryBool(c, "breached", false)
+	if !ok {
+		return
+	}
+	assignee := strings.ToLower(c.Query("assignee"))
+	if assignee != "" && assignee != "unassigned" && !isValidAddress(assignee) {
+		c.JSON(http.StatusBadRequest, KYCQueueResponse{Success: false, Message: "Invalid assignee: must be an address or \"unassigned\""})
+		return
+	}
+
+	now := time.Now()
+	h.mu.RLock()
+	if !h.complianceOfficers[strings.ToLower(c.Query("officer"))] {
+		h.mu.RUnlock()
+		c.JSON(http.StatusForbidden, KYCQueueResponse{Success: false, Message: "Only compliance officers can view the review queue"})
+		return
+	}
+	var summary KYCQueueSummary
+	items := []*KYCQueueItem{}
+	for _, reg := range h.registrations {
+		if reg.Status != KYCStatusPending {
+			continue
+		}
+		item := h.queueItem(reg, now)
+		summary.Pending++
+		if item.SLAAtRisk {
+			summary.AtRisk++
+		}
+		if item.SLABreached {
+			summary.Breached++
+		}
+		if item.Assignment == nil {
+			summary.Unassigned++
+		}
+
+		if breachedOnly && !item.SLABreached {
+			continue
+		}
+		switch {
+		case assignee == "":
+		case assignee == "unassigned":
+			if item.Assignment != nil {
+				continue
+			}
+		case item.Assignment == nil || item.Assignment.Officer != assignee:
+			continue
+		}
+		items = append(items, item)
+	}
+	h.mu.RUnlock()
+
+	// Most urgent first; registrations without an SLA go last, oldest first
+	sort.Slice(items, func(i, j int) bool {
+		a, b := items[i], items[j]
+		switch {
+		case a.SLADueAt != nil && b.SLADueAt != nil && !a.SLADueAt.Equal(*b.SLADueAt):
+			return a.SLADueAt.Before(*b.SLADueAt)
+		case (a.SLADueAt == nil) != (b.SLADueAt == nil):
+			return a.SLADueAt != nil
+		}
+		return a.CreatedAt.Before(b.CreatedAt)
+	})
+
+	total := len(items)
+	start := min((page-1)*pageSize, total)
+	end := min(start+pageSize, total)
+	items = items[start:end]
+	h.addSumsubStatus(c.Request.Context(), items)
+
+	c.JSON(http.StatusOK, KYCQueueResponse{
+		Success:  true,
+		Items:    items,
+		Summary:  summary,
+		Total:    total,
+		Page:     page,
+		PageSize: pageSize,
+	})
+}
+
+// queueItem builds the queue view of a pending registration (h.mu held)
+func (h *KYCHandler) queueItem(reg *KYCRegistration, now time.Time) *KYCQueueItem {
+	item := &KYCQueueItem{
+		KYCRegistration: *reg,
+		ReviewLevel:     reg.Level,
+		AgeSeconds:      int64(now.Sub(reg.CreatedAt) / time.Second),
+	}
+	if j, ok := h.jurisdictions[reg.Jurisdiction]; ok && j.RequiredLevel > item.ReviewLevel {
+		item.ReviewLevel = j.RequiredLevel
+	}
+	if target, ok := h.queueSLA[item.ReviewLevel]; ok {
+		due := reg.CreatedAt.Add(target)
+		item.SLADueAt = &due
+		item.SLABreached = now.After(due)
+		item.SLAAtRisk = !item.SLABreached && now.Sub(reg.CreatedAt) >= time.Duration(float64(target)*kycQueueAtRisk)
+	}
+	if a, ok := h.assignments[reg.Address]; ok {
+		cp := *a
+		item.Assignment = &cp
+	}
+	return item
+}
+
+// addSumsubStatus fills in the Sumsub status of items. It is informational:
+// the queue is served without it when the lookup fails.
+func (h *KYCHandler) addSumsubStatus(ctx context.Context, items []*KYCQueueItem) {
+	if h.verifications == nil {
+		return
+	}
+	for _, item := range items {
+		v, err := h.verifications.GetKYCVerificationByAddress(ctx, item.Address)
+		if err != nil {
+			if !errors.Is(err, repository.ErrKYCNotFound) {
+				h.logger.Warn("failed to get Sumsub verification", zap.String("address", item.Address), zap.Error(err))
+			}
+			continue
+		}
+		item.SumsubStatus = v.Status
+		if v.SumsubReviewStatus != nil {
+			item.SumsubReviewStatus = *v.SumsubReviewStatus
+		}
+	}
+}
+
+// ClaimQueueItem handles POST /api/v1/admin/kyc/queue/:address/claim
+// @Summary Claim a queued registration
+// @Description Assigns a pending registration to the calling compliance officer, unless another officer has it
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param address path string true "Registration address"
+// @Param request body ClaimKYCRequest true "Claim request"
+// @Success 200 {object} KYCAssignmentResponse
+// @Failure 400 {object} KYCAssignmentResponse
+// @Failure 403 {object} KYCAssignmentResponse
+// @Failure 404 {object} KYCAssignmentResponse
+// @Failure 409 {object} KYCAssignmentResponse
+// @Router /api/v1/admin/kyc/queue/{address}/claim [post]
+func (h *KYCHandler) ClaimQueueItem(c *gin.Context) {
+	var req ClaimKYCRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, KYCAssignmentResponse{Success: false, Message: "Invalid request: " + err.Error()})
+		return
+	}
+	h.assign(c, req.Officer, req.Officer, false)
+}
+
+// AssignQueueItem handles POST /api/v1/admin/kyc/queue/:address/assign
+// @Summary Assign a queued registration
+// @Description Assigns or reassigns a pending registration to a compliance officer (compliance officer only)
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param address path string true "Registration address"
+// @Param request body AssignKYCRequest true "Assignment request"
+// @Success 200 {object} KYCAssignmentResponse
+// @Failure 400 {object} KYCAssignmentResponse
+// @Failure 403 {object} KYCAssignmentResponse
+// @Failure 404 {object} KYCAssignmentResponse
+// @Router /api/v1/admin/kyc/queue/{address}/assign [post]
+func (h *KYCHandler) AssignQueueItem(c *gin.Context) {
+	var req AssignKYCRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, KYCAssignmentResponse{Success: false, Message: "Invalid request: " + err.Error()})
+		return
+	}
+	h.assign(c, req.Officer, req.AssignedBy, true)
+}
+
+// assign gives the pending registration at :address to officer. A claim
+// (reassign false) fails when another officer already has it.
+func (h *KYCHandler) assign(c *gin.Context, officer, by string, reassign bool) {
+	address := c.Param("address")
+	if !isValidAddress(address) || !isValidAddress(officer) || !isValidAddress(by) {
+		c.JSON(http.StatusBadRequest, KYCAssignmentResponse{Success: false, Message: "Invalid address format"})
+		return
+	}
+	address, officer, by = strings.ToLower(address), strings.ToLower(officer), strings.ToLower(by)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if !h.complianceOfficers[by] {
+		c.JSON(http.StatusForbidden, KYCAssignmentResponse{Success: false, Message: "Only compliance officers can assign registrations"})
+		return
+	}
+	if !h.complianceOfficers[officer] {
+		c.JSON(http.StatusBadRequest, KYCAssignmentResponse{Success: false, Message: "Registrations can only be assigned to compliance officers"})
+		return
+	}
+	reg, exists := h.registrations[address]
+	if !exists || reg.Status != KYCStatusPending {
+		c.JSON(http.StatusNotFound, KYCAssignmentResponse{Success: false, Message: "No pending KYC registration found for this address"})
+		return
+	}
+
+	var previous string
+	if current, ok := h.assignments[address]; ok {
+		if current.Officer == officer {
+			c.JSON(http.StatusOK, KYCAssignmentResponse{Success: true, Address: address, Assignment: current, Message: "Already assigned"})
+			return
+		}
+		if !reassign {
+			c.JSON(http.StatusConflict, KYCAssignmentResponse{Success: false, Address: address, Assignment: current, Message: "Registration is assigned to another officer"})
+			return
+		}
+		previous = current.Officer
+	}
+	a := &KYCAssignment{Officer: officer, AssignedBy: by, AssignedAt: time.Now()}
+	h.assignments[address] = a
+	h.addAuditLog("KYC_ASSIGN", by, address, "Review assigned to "+officer, c.ClientIP(), previous, officer)
+
+	c.JSON(http.StatusOK, KYCAssignmentResponse{Success: true, Address: address, Assignment: a, Message: "Registration assigned"})
+}
@@ -0,0 +1,143 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/handlers"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// verificationsByAddress serves Sumsub verifications from a map
+type verificationsByAddress map[string]*repository.KYCVerification
+
+func (v verificationsByAddress) GetKYCVerificationByAddress(ctx context.Context, address string) (*repository.KYCVerification, error) {
+	if verification, ok := v[address]; ok {
+		return verification, nil
+	}
+	return nil, repository.ErrKYCNotFound
+}
+
+func TestKYCHandler_ReviewQueue(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	const (
+		overdue  = "0x00000000000000000000000000000000000000a1" // US, advanced: 72h SLA
+		atRisk   = "0x00000000000000000000000000000000000000a2" // GB, standard: 48h SLA
+		fresh    = "0x00000000000000000000000000000000000000a3" // CH, basic: 24h SLA
+		approved = "0x00000000000000000000000000000000000000a4"
+		alice    = "0x00000000000000000000000000000000000000c1"
+		bob      = "0x00000000000000000000000000000000000000c2"
+		outsider = "0x00000000000000000000000000000000000000ee"
+	)
+	now := time.Now()
+	handler := handlers.NewKYCHandler(zap.NewNop())
+	handler.Seed(handlers.KYCFixtures{
+		ComplianceOfficers: []string{alice, bob},
+		Registrations: []*handlers.KYCRegistration{
+			{Address: overdue, Status: handlers.KYCStatusPending, Jurisdiction: "US", CreatedAt: now.Add(-80 * time.Hour)},
+			{Address: atRisk, Status: handlers.KYCStatusPending, Jurisdiction: "GB", CreatedAt: now.Add(-40 * time.Hour)},
+			{Address: fresh, Status: handlers.KYCStatusPending, Jurisdiction: "CH", CreatedAt: now.Add(-time.Hour)},
+			{Address: approved, Status: handlers.KYCStatusApproved, Jurisdiction: "CH", CreatedAt: now.Add(-100 * time.Hour)},
+		},
+	})
+	inReview := "init"
+	handler.SetVerificationSource(verificationsByAddress{
+		atRisk: {UserAddress: atRisk, Status: repository.KYCStatusInReview, SumsubReviewStatus: &inReview},
+	})
+
+	router := gin.New()
+	router.GET("/admin/kyc/queue", handler.GetQueue)
+	router.POST("/admin/kyc/queue/:address/claim", handler.ClaimQueueItem)
+	router.POST("/admin/kyc/queue/:address/assign", handler.AssignQueueItem)
+	router.PUT("/kyc/update", handler.UpdateKYC)
+
+	send := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	queue := func(query string) handlers.KYCQueueResponse {
+		w := send(http.MethodGet, "/admin/kyc/queue?officer="+alice+query, "")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var resp handlers.KYCQueueResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		return resp
+	}
+	addresses := func(resp handlers.KYCQueueResponse) []string {
+		var out []string
+		for _, item := range resp.Items {
+			out = append(out, item.Address)
+		}
+		return out
+	}
+
+	assert.Equal(t, http.StatusForbidden, send(http.MethodGet, "/admin/kyc/queue?officer="+outsider, "").Code)
+	assert.Equal(t, http.StatusBadRequest, send(http.MethodGet, "/admin/kyc/queue?officer="+alice+"&breached=maybe", "").Code)
+
+	// Most urgent SLA first, with the flags and Sumsub status
+	resp := queue("")
+	require.Equal(t, []string{overdue, atRisk, fresh}, addresses(resp))
+	assert.Equal(t, handlers.KYCQueueSummary{Pending: 3, AtRisk: 1, Breached: 1, Unassigned: 3}, resp.Summary)
+	first, second, third := resp.Items[0], resp.Items[1], resp.Items[2]
+	assert.Equal(t, handlers.KYCLevelAdvanced, first.ReviewLevel)
+	assert.True(t, first.SLABreached)
+	assert.InDelta(t, 80*3600, first.AgeSeconds, 5)
+	require.NotNil(t, first.SLADueAt)
+	assert.WithinDuration(t, now.Add(-8*time.Hour), *first.SLADueAt, time.Second)
+	assert.True(t, second.SLAAtRisk)
+	assert.False(t, second.SLABreached)
+	assert.Equal(t, repository.KYCStatusInReview, second.SumsubStatus)
+	assert.Equal(t, "init", second.SumsubReviewStatus)
+	assert.False(t, third.SLAAtRisk || third.SLABreached)
+	assert.Empty(t, third.SumsubStatus)
+
+	assert.Equal(t, []string{overdue}, addresses(queue("&breached=true")))
+	page := queue("&page=2&page_size=2")
+	assert.Equal(t, []string{fresh}, addresses(page))
+	assert.Equal(t, 3, page.Total)
+
+	// Claims divide the work; assignment can move it
+	assert.Equal(t, http.StatusForbidden, send(http.MethodPost, "/admin/kyc/queue/"+atRisk+"/claim", `{"officer":"`+outsider+`"}`).Code)
+	assert.Equal(t, http.StatusNotFound, send(http.MethodPost, "/admin/kyc/queue/"+approved+"/claim", `{"officer":"`+alice+`"}`).Code)
+	require.Equal(t, http.StatusOK, send(http.MethodPost, "/admin/kyc/queue/"+atRisk+"/claim", `{"officer":"`+alice+`"}`).Code)
+	assert.Equal(t, http.StatusOK, send(http.MethodPost, "/admin/kyc/queue/"+atRisk+"/claim", `{"officer":"`+alice+`"}`).Code, "claiming again is a no-op")
+	assert.Equal(t, http.StatusConflict, send(http.MethodPost, "/admin/kyc/queue/"+atRisk+"/claim", `{"officer":"`+bob+`"}`).Code)
+	assert.Equal(t, http.StatusBadRequest, send(http.MethodPost, "/admin/kyc/queue/"+overdue+"/assign", `{"officer":"`+outsider+`","assigned_by":"`+alice+`"}`).Code)
+	w := send(http.MethodPost, "/admin/kyc/queue/"+atRisk+"/assign", `{"officer":"`+bob+`","assigned_by":"`+alice+`"}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var assigned handlers.KYCAssignmentResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &assigned))
+	assert.Equal(t, bob, assigned.Assignment.Officer)
+	assert.Equal(t, alice, assigned.Assignment.AssignedBy)
+
+	mine := queue("&assignee=" + bob)
+	require.Equal(t, []string{atRisk}, addresses(mine))
+	assert.Equal(t, bob, mine.Items[0].Assignment.Officer)
+	assert.Equal(t, []string{overdue, fresh}, addresses(queue("&assignee=unassigned")))
+	assert.Equal(t, 2, queue("").Summary.Unassigned)
+
+	// A reviewed registration leaves the queue with its assignment
+	require.Equal(t, http.StatusOK, send(http.MethodPut, "/kyc/update", `{"address":"`+atRisk+`","status":"approved","reviewer":"`+bob+`"}`).Code)
+	assert.Equal(t, handlers.KYCQueueSummary{Pending: 2, AtRisk: 0, Breached: 1, Unassigned: 2}, queue("").Summary)
+	assert.Empty(t, queue("&assignee="+bob).Items)
+
+	// SLA targets are configurable per level
+	handler.SetQueueSLA(map[handlers.KYCLevel]time.Duration{handlers.KYCLevelBasic: 30 * time.Minute, handlers.KYCLevelAdvanced: 0})
+	resp = queue("")
+	require.Equal(t, []string{fresh, overdue}, addresses(resp), "levels without an SLA go last")
+	assert.True(t, resp.Items[0].SLABreached)
+	assert.Nil(t, resp.Items[1].SLADueAt)
+	assert.False(t, resp.Items[1].SLABreached)
+}
@@ -0,0 +1,278 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// ledgerExportPageSize is how many entries an export reads per query
+const ledgerExportPageSize = 500
+
+// LedgerHandler serves the double-entry ledger to finance
+type LedgerHandler struct {
+	repo   repository.LedgerRepository
+	logger *zap.Logger
+}
+
+// NewLedgerHandler creates a new ledger handler with injected dependencies
+func NewLedgerHandler(repo repository.LedgerRepository, logger *zap.Logger) *LedgerHandler {
+	return &LedgerHandler{repo: repo, logger: logger}
+}
+
+// LedgerResponse wraps ledger API responses
+type LedgerResponse struct {
+	Success bool        `json:"success"`
+	Data    interface{} `json:"data,omitempty"`
+	Error   string      `json:"error,omitempty"`
+}
+
+// GetLedgerBalances handles GET /api/v1/admin/ledger/balances
+// @Summary Get ledger account balances
+// @Description Returns each account's debits, credits and balance per currency, in base units (cents for USD, 18 decimals for ETH and NEXUS). With to, the balances as they stood at that time.
+// @Tags admin
+// @Produce json
+// @Param currency query string false "USD, ETH or NEXUS"
+// @Param to query string false "Balances before this time (RFC3339)"
+// @Success 200 {object} LedgerResponse{data=[]repository.LedgerBalance}
+// @Failure 400 {object} LedgerResponse
+// @Failure 401 {object} LedgerResponse
+// @Router /api/v1/admin/ledger/balances [get]
+func (h *LedgerHandler) GetLedgerBalances(c *gin.Context) {
+	to, err := parseOptionalTime(c.Query("to"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, LedgerResponse{
+			Success: false,
+			Error:   "Invalid to time, expected RFC3339",
+		})
+		return
+	}
+
+	balances, err := h.repo.GetLedgerBalances(c.Request.Context(), repository.LedgerBalanceFilter{
+		Currency: strings.ToUpper(c.Query("currency")),
+		To:       to,
+	})
+	if err != nil {
+		h.logger.Error("failed to get ledger balances", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, LedgerResponse{
+			Success: false,
+			Error:   "Internal server error",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, LedgerResponse{
+		Success: true,
+		Data:    balances,
+	})
+}
+
+// ListLedgerEntries handles GET /api/v1/admin/ledger/entries
+// @Summary List ledger entries
+// @Description Returns ledger entries in posting order
+// @Tags admin
+// @Produce json
+// @Param account query string false "customer_funds, disputed_funds, revenue, fees, refunds, provider_costs or provider_payable"
+// @Param currency query string false "USD, ETH or NEXUS"
+// @Param payment_id query string false "Payment ID"
+// @Param event_type query string false "payment.sale, payment.sale_reversed, payment.dispute_opened, payment.dispute_released or payment.refund"
+// @Param from query string false "Start time (RFC3339)"
+// @Param to query string false "End time (RFC3339)"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(50)
+// @Success 200 {object} LedgerResponse
+// @Failure 400 {object} LedgerResponse
+// @Failure 401 {object} LedgerResponse
+// @Router /api/v1/admin/ledger/entries [get]
+func (h *LedgerHandler) ListLedgerEntries(c *gin.Context) {
+	filter, ok := h.entryFilter(c)
+	if !ok {
+		return
+	}
+	page, pageSize, ok := queryPage(c, 50)
+	if !ok {
+		return
+	}
+
+	entries, total, err := h.repo.ListLedgerEntries(c.Request.Context(), filter, repository.Pagination{
+		Page:     page,
+		PageSize: pageSize,
+	})
+	if err != nil {
+		h.logger.Error("failed to list ledger entries", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, LedgerResponse{
+			Success: false,
+			Error:   "Internal server error",
+		})
+		return
+	}
+	if entries == nil {
+		entries = []*repository.LedgerEntry{}
+	}
+
+	c.JSON(http.StatusOK, LedgerResponse{
+		Success: true,
+		Data: gin.H{
+			"entries":   entries,
+			"total":     total,
+			"page":      page,
+			"page_size": pageSize,
+		},
+	})
+}
+
+// GetLedgerTransaction handles GET /api/v1/admin/ledger/transactions/:id
+// @Summary Get ledger transaction
+// @Description Returns a posted transaction with its balanced entries
+// @Tags admin
+// @Produce json
+// @Param id path string true "Ledger transaction ID"
+// @Success 200 {object} LedgerResponse{data=repository.LedgerTransaction}
+// @Failure 400 {object} LedgerResponse
+// @Failure 404 {object} LedgerResponse
+// @Router /api/v1/admin/ledger/transactions/{id} [get]
+func (h *LedgerHandler) GetLedgerTransaction(c *gin.Context) {
+	id := c.Param("id")
+	if !uuidPattern.MatchString(id) {
+		c.JSON(http.StatusBadRequest, LedgerResponse{
+			Success: false,
+			Error:   "Invalid ledger transaction ID",
+		})
+		return
+	}
+
+	tx, err := h.repo.GetLedgerTransaction(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, repository.ErrLedgerTransactionNotFound) {
+			c.JSON(http.StatusNotFound, LedgerResponse{
+				Success: false,
+				Error:   "Ledger transaction not found",
+			})
+			return
+		}
+		h.logger.Error("failed to get ledger transaction", zap.String("id", id), zap.Error(err))
+		c.JSON(http.StatusInternalServerError, LedgerResponse{
+			Success: false,
+			Error:   "Internal server error",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, LedgerResponse{
+		Success: true,
+		Data:    tx,
+	})
+}
+
+// ExportLedgerEntries handles GET /api/v1/admin/ledger/export
+// @Summary Export ledger entries as CSV
+// @Description Streams every entry matching the filters as CSV in posting order, one row per entry with amounts in base units
+// @Tags admin
+// @Produce text/csv
+// @Param account query string false "Account"
+// @Param currency query string false "USD, ETH or NEXUS"
+// @Param payment_id query string false "Payment ID"
+// @Param event_type query string false "Event type"
+// @Param from query string false "Start time (RFC3339)"
+// @Param to query string false "End time (RFC3339)"
+// @Success 200 {string} string "CSV"
+// @Failure 400 {object} LedgerResponse
+// @Failure 401 {object} LedgerResponse
+// @Router /api/v1/admin/ledger/export [get]
+func (h *LedgerHandler) ExportLedgerEntries(c *gin.Context) {
+	filter, ok := h.entryFilter(c)
+	if !ok {
+		return
+	}
+	ctx := c.Request.Context()
+
+	// Read the first page before writing, so a failing query still gets a
+	// JSON error instead of a truncated file
+	entries, _, err := h.repo.ListLedgerEntries(ctx, filter, repository.Pagination{Page: 1, PageSize: ledgerExportPageSize})
+	if err != nil {
+		h.logger.Error("failed to export ledger entries", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, LedgerResponse{
+			Success: false,
+			Error:   "Internal server error",
+		})
+		return
+	}
+
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", `attachment; filename="ledger-`+time.Now().UTC().Format("20060102T150405Z")+`.csv"`)
+	c.Status(http.StatusOK)
+
+	w := csv.NewWriter(c.Writer)
+	_ = w.Write([]string{"entry_id", "transaction_id", "created_at", "event_type", "payment_id", "account", "direction", "amount", "currency"})
+	for page := 1; ; page++ {
+		for _, e := range entries {
+			paymentID := ""
+			if e.PaymentID != nil {
+				paymentID = *e.PaymentID
+			}
+			_ = w.Write([]string{
+				e.ID, e.TransactionID, e.CreatedAt.UTC().Format(time.RFC3339Nano), e.EventType, paymentID,
+				string(e.Account), string(e.Direction), e.Amount, e.Currency,
+			})
+		}
+		if len(entries) < ledgerExportPageSize {
+			break
+		}
+		entries, _, err = h.repo.ListLedgerEntries(ctx, filter, repository.Pagination{Page: page + 1, PageSize: ledgerExportPageSize})
+		if err != nil {
+			// Headers are sent; the truncated file is all that can be returned
+			h.logger.Error("ledger export interrupted", zap.Int("page", page+1), zap.Error(err))
+			break
+		}
+	}
+	w.Flush()
+}
+
+// entryFilter parses the entry filters shared by listing and export,
+// answering 400 for invalid values
+func (h *LedgerHandler) entryFilter(c *gin.Context) (repository.LedgerEntryFilter, bool) {
+	filter := repository.LedgerEntryFilter{
+		Account:   repository.LedgerAccount(c.Query("account")),
+		Currency:  strings.ToUpper(c.Query("currency")),
+		PaymentID: c.Query("payment_id"),
+		EventType: c.Query("event_type"),
+	}
+	if filter.Account != "" && !filter.Account.Valid() {
+		c.JSON(http.StatusBadRequest, LedgerResponse{
+			Success: false,
+			Error:   "Invalid account",
+		})
+		return filter, false
+	}
+	if filter.PaymentID != "" && !uuidPattern.MatchString(filter.PaymentID) {
+		c.JSON(http.StatusBadRequest, LedgerResponse{
+			Success: false,
+			Error:   "Invalid payment ID",
+		})
+		return filter, false
+	}
+
+	var err error
+	if filter.From, err = parseOptionalTime(c.Query("from")); err != nil {
+		c.JSON(http.StatusBadRequest, LedgerResponse{
+			Success: false,
+			Error:   "Invalid from time, expected RFC3339",
+		})
+		return filter, false
+	}
+	if filter.To, err = parseOptionalTime(c.Query("to")); err != nil {
+		c.JSON(http.StatusBadRequest, LedgerResponse{
+			Success: false,
+			Error:   "Invalid to time, expected RFC3339",
+		})
+		return filter, false
+	}
+	return filter, true
+}
@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/logging"
+)
+
+// LoggingHandler handles the admin endpoints of the shared log settings
+type LoggingHandler struct {
+	ctrl   *logging.Controller
+	logger *zap.Logger
+}
+
+// NewLoggingHandler creates a new logging handler with injected dependencies
+func NewLoggingHandler(ctrl *logging.Controller, logger *zap.Logger) *LoggingHandler {
+	return &LoggingHandler{
+		ctrl:   ctrl,
+		logger: logger,
+	}
+}
+
+// LoggingResponse wraps logging API responses
+type LoggingResponse struct {
+	Success bool              `json:"success"`
+	Data    *logging.Settings `json:"data,omitempty"`
+	Message string            `json:"message,omitempty"`
+	Error   string            `json:"error,omitempty"`
+}
+
+// SetLoggingRequest changes the log settings. Omitted fields keep their
+// current value; a module set to "" drops its override.
+type SetLoggingRequest struct {
+	Level     string            `json:"level,omitempty"`
+	Sampling  *bool             `json:"sampling,omitempty"`
+	Modules   map[string]string `json:"modules,omitempty"`
+	UpdatedBy string            `json:"updated_by" binding:"required"`
+}
+
+// GetLogging handles GET /api/v1/admin/logging
+// @Summary Get log settings
+// @Description Returns the log level, sampling and per-module level overrides in effect on this instance
+// @Tags admin
+// @Produce json
+// @Success 200 {object} LoggingResponse
+// @Failure 401 {object} LoggingResponse
+// @Router /api/v1/admin/logging [get]
+func (h *LoggingHandler) GetLogging(c *gin.Context) {
+	settings := h.ctrl.Settings()
+	c.JSON(http.StatusOK, LoggingResponse{
+		Success: true,
+		Data:    &settings,
+	})
+}
+
+// SetLogging handles PUT /api/v1/admin/logging
+// @Summary Set log settings
+// @Description Changes the log level (debug, info, warn, error), sampling of repeated entries and per-module level overrides (kyc, payments, relayer). The settings are stored in app_config and followed by all instances.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param request body SetLoggingRequest true "Log settings"
+// @Success 200 {object} LoggingResponse
+// @Failure 400 {object} LoggingResponse
+// @Failure 401 {object} LoggingResponse
+// @Router /api/v1/admin/logging [put]
+func (h *LoggingHandler) SetLogging(c *gin.Context) {
+	var req SetLoggingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, LoggingResponse{
+			Success: false,
+			Error:   "Invalid request: " + err.Error(),
+		})
+		return
+	}
+
+	settings := h.ctrl.Settings()
+	if req.Level != "" {
+		settings.Level = req.Level
+	}
+	if req.Sampling != nil {
+		settings.Sampling = *req.Sampling
+	}
+	for module, level := range req.Modules {
+		if level == "" {
+			delete(settings.Modules, module)
+			continue
+		}
+		settings.Modules[module] = level
+	}
+
+	settings, err := h.ctrl.Set(c.Request.Context(), settings, req.UpdatedBy)
+	switch {
+	case errors.Is(err, logging.ErrInvalidLevel), errors.Is(err, logging.ErrUnknownModule):
+		c.JSON(http.StatusBadRequest, LoggingResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	case err != nil:
+		// Still in effect here; other instances follow once it is persisted
+		h.logger.Error("failed to persist log settings", zap.Error(err))
+		c.JSON(http.StatusOK, LoggingResponse{
+			Success: true,
+			Data:    &settings,
+			Message: "Applied to this instance only; persisting will be retried",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, LoggingResponse{
+		Success: true,
+		Data:    &settings,
+	})
+}
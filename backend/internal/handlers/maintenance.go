@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/maintenance"
+)
+
+// MaintenanceHandler handles the admin endpoints of the maintenance switch
+type MaintenanceHandler struct {
+	sw     *maintenance.Switch
+	logger *zap.Logger
+}
+
+// NewMaintenanceHandler creates a new maintenance handler with injected dependencies
+func NewMaintenanceHandler(sw *maintenance.Switch, logger *zap.Logger) *MaintenanceHandler {
+	return &MaintenanceHandler{
+		sw:     sw,
+		logger: logger,
+	}
+}
+
+// MaintenanceResponse wraps maintenance API responses
+type MaintenanceResponse struct {
+	Success bool               `json:"success"`
+	Data    *maintenance.State `json:"data,omitempty"`
+	Message string             `json:"message,omitempty"`
+	Error   string             `json:"error,omitempty"`
+}
+
+// SetMaintenanceRequest turns maintenance mode on or off
+type SetMaintenanceRequest struct {
+	Enabled   *bool  `json:"enabled" binding:"required"`
+	Message   string `json:"message,omitempty"`
+	UpdatedBy string `json:"updated_by" binding:"required"`
+}
+
+// GetMaintenance handles GET /api/v1/admin/maintenance
+// @Summary Get maintenance mode
+// @Description Returns whether non-admin API routes are answering 503 for maintenance
+// @Tags admin
+// @Produce json
+// @Success 200 {object} MaintenanceResponse
+// @Failure 401 {object} MaintenanceResponse
+// @Router /api/v1/admin/maintenance [get]
+func (h *MaintenanceHandler) GetMaintenance(c *gin.Context) {
+	state := h.sw.State()
+	c.JSON(http.StatusOK, MaintenanceResponse{
+		Success: true,
+		Data:    &state,
+	})
+}
+
+// SetMaintenance handles PUT /api/v1/admin/maintenance
+// @Summary Set maintenance mode
+// @Description While enabled, every route except health probes and the admin API answers 503 with the message. The setting is stored in app_config and followed by all instances.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param request body SetMaintenanceRequest true "Maintenance setting (an empty message keeps the current one)"
+// @Success 200 {object} MaintenanceResponse
+// @Failure 400 {object} MaintenanceResponse
+// @Failure 401 {object} MaintenanceResponse
+// @Router /api/v1/admin/maintenance [put]
+func (h *MaintenanceHandler) SetMaintenance(c *gin.Context) {
+	var req SetMaintenanceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, MaintenanceResponse{
+			Success: false,
+			Error:   "Invalid request: " + err.Error(),
+		})
+		return
+	}
+
+	state, err := h.sw.Set(c.Request.Context(), *req.Enabled, req.Message, req.UpdatedBy)
+	if err != nil {
+		// Still in effect here; other instances follow once it is persisted
+		h.logger.Error("failed to persist maintenance mode", zap.Error(err))
+		c.JSON(http.StatusOK, MaintenanceResponse{
+			Success: true,
+			Data:    &state,
+			Message: "Applied to this instance only; persisting will be retried",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, MaintenanceResponse{
+		Success: true,
+		Data:    &state,
+	})
+}
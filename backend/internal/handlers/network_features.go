@@ -0,0 +1,153 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Network features reported by GET /api/v1/networks/:chainId/features
+const (
+	FeatureRelaying = "relaying"
+	FeatureNFTMint  = "nft_mint"
+	FeatureStaking  = "staking"
+	FeaturePayments = "payments"
+)
+
+// featuresNamespace holds the per-feature flags (e.g. features.staking),
+// overridable per chain. A missing flag leaves the feature on.
+const featuresNamespace = "features"
+
+// networkFeatures lists each feature with the contracts it needs deployed
+var networkFeatures = []struct {
+	name      string
+	contracts []string
+}{
+	{FeatureRelaying, []string{"nexusForwarder"}},
+	{FeatureNFTMint, []string{"nexusNFT"}},
+	{FeatureStaking, []string{"nexusToken", "nexusStaking"}},
+	{FeaturePayments, []string{"nexusToken"}},
+}
+
+// NetworkFeature reports whether a feature is live on a network
+type NetworkFeature struct {
+	Name      string            `json:"name"`
+	Available bool              `json:"available"` // deployed and enabled
+	Enabled   bool              `json:"enabled"`   // the features.<name> flag
+	Contracts map[string]string `json:"contracts"` // db_name -> address of the deployed contracts it uses
+	Missing   []string          `json:"missing,omitempty"`
+	Reason    string            `json:"reason,omitempty"` // why it is unavailable
+}
+
+// NetworkFeatures is the feature matrix of one network
+type NetworkFeatures struct {
+	ChainID     int64            `json:"chain_id"`
+	NetworkName string           `json:"network_name"`
+	Features    []NetworkFeature `json:"features"`
+}
+
+// GetNetworkFeatures handles GET /api/v1/networks/:chainId/features
+// @Summary Get the features live on a network
+// @Description Returns which services (relaying, NFT mint, staking, payments) are available on the chain, derived from its deployed contracts and the features.* flags
+// @Tags networks
+// @Produce json
+// @Param chainId path int true "Chain ID"
+// @Success 200 {object} ContractResponse
+// @Failure 404 {object} ContractResponse
+// @Router /api/v1/networks/{chainId}/features [get]
+func (h *ContractHandler) GetNetworkFeatures(c *gin.Context) {
+	ctx := c.Request.Context()
+	chainIDStr := c.Param("chainId")
+	chainID, err := strconv.ParseInt(chainIDStr, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ContractResponse{
+			Success: false,
+			Error:   "Invalid chain ID format",
+		})
+		return
+	}
+
+	network, err := h.repo.GetNetworkByChainID(ctx, chainID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNetworkNotFound) {
+			c.JSON(http.StatusNotFound, ContractResponse{
+				Success: false,
+				Error:   "Network not found for chain ID: " + chainIDStr,
+			})
+			return
+		}
+		h.logger.Error("failed to get network", zap.Int64("chainId", chainID), zap.Error(err))
+		c.JSON(http.StatusInternalServerError, ContractResponse{
+			Success: false,
+			Error:   "Internal server error",
+		})
+		return
+	}
+
+	contracts, err := h.repo.GetByChainID(ctx, chainID)
+	if err != nil {
+		h.logger.Error("failed to get contracts", zap.Int64("chainId", chainID), zap.Error(err))
+		c.JSON(http.StatusInternalServerError, ContractResponse{
+			Success: false,
+			Error:   "Internal server error",
+		})
+		return
+	}
+	deployed := make(map[string]string, len(contracts))
+	for _, contract := range contracts {
+		if contract.IsPrimary && contract.Status == "active" {
+			deployed[contract.DBName] = contract.Address
+		}
+	}
+
+	result := NetworkFeatures{ChainID: chainID, NetworkName: network.NetworkName, Features: make([]NetworkFeature, 0, len(networkFeatures))}
+	for _, f := range networkFeatures {
+		feature := NetworkFeature{Name: f.name, Enabled: h.featureEnabled(ctx, f.name, chainID), Contracts: map[string]string{}}
+		for _, name := range f.contracts {
+			if address, ok := deployed[name]; ok {
+				feature.Contracts[name] = address
+			} else {
+				feature.Missing = append(feature.Missing, name)
+			}
+		}
+		switch {
+		case !network.IsActive:
+			feature.Reason = "network is inactive"
+		case !feature.Enabled:
+			feature.Reason = "disabled by the " + featuresNamespace + "." + f.name + " flag"
+		case len(feature.Missing) > 0:
+			feature.Reason = "required contracts are not deployed"
+		default:
+			feature.Available = true
+		}
+		result.Features = append(result.Features, feature)
+	}
+
+	c.JSON(http.StatusOK, ContractResponse{
+		Success: true,
+		Data:    result,
+	})
+}
+
+// featureEnabled reads the features.<name> flag for the chain. A missing
+// flag leaves the feature on; a failed read turns it off.
+func (h *ContractHandler) featureEnabled(ctx context.Context, name string, chainID int64) bool {
+	if h.configRepo == nil {
+		return true
+	}
+	enabled, err := h.configRepo.GetBool(ctx, featuresNamespace, name, chainID)
+	if err != nil {
+		if errors.Is(err, repository.ErrAppConfigNotFound) {
+			return true
+		}
+		h.logger.Warn("failed to read feature flag", zap.String("feature", name), zap.Int64("chainId", chainID), zap.Error(err))
+		return false
+	}
+	return enabled
+}
@@ -0,0 +1,71 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/handlers"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/storage/memory"
+)
+
+func TestContractHandler_GetNetworkFeatures(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryContractRepo()
+	repo.AddNetwork(&repository.NetworkConfig{ChainID: 31337, NetworkName: "localhost", IsActive: true})
+	ids := map[string]string{}
+	for _, name := range []string{"NexusToken", "NexusStaking", "NexusNFT", "NexusForwarder"} {
+		ids[name] = repo.AddMapping(&repository.ContractMapping{SolidityName: name, DBName: "n" + name[1:]})
+	}
+	for _, name := range []string{"NexusToken", "NexusStaking", "NexusNFT"} {
+		_, err := repo.Upsert(ctx, &repository.ContractAddressUpsert{ChainID: 31337, ContractMappingID: ids[name], Address: tokenAddr})
+		require.NoError(t, err)
+	}
+
+	// NFT minting is switched off on this chain only
+	config := memory.NewMemoryAppConfigRepo()
+	off := false
+	require.NoError(t, config.Create(ctx, &repository.AppConfigCreate{
+		Namespace: "features", ConfigKey: "nft_mint", ValueType: "boolean", ValueBoolean: &off, ChainID: 31337,
+	}))
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/networks/:chainId/features", handlers.NewContractHandler(repo, config, nil, zap.NewNop()).GetNetworkFeatures)
+
+	w := send(router, http.MethodGet, "/networks/31337/features", "", nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var resp struct {
+		Data handlers.NetworkFeatures `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "localhost", resp.Data.NetworkName)
+
+	features := map[string]handlers.NetworkFeature{}
+	for _, f := range resp.Data.Features {
+		features[f.Name] = f
+	}
+	require.Len(t, features, 4)
+
+	assert.False(t, features[handlers.FeatureRelaying].Available)
+	assert.True(t, features[handlers.FeatureRelaying].Enabled)
+	assert.Equal(t, []string{"nexusForwarder"}, features[handlers.FeatureRelaying].Missing)
+
+	assert.False(t, features[handlers.FeatureNFTMint].Available)
+	assert.False(t, features[handlers.FeatureNFTMint].Enabled)
+	assert.Contains(t, features[handlers.FeatureNFTMint].Reason, "features.nft_mint")
+
+	assert.True(t, features[handlers.FeatureStaking].Available)
+	assert.Equal(t, map[string]string{"nexusToken": tokenAddr, "nexusStaking": tokenAddr}, features[handlers.FeatureStaking].Contracts)
+	assert.True(t, features[handlers.FeaturePayments].Available)
+
+	assert.Equal(t, http.StatusNotFound, send(router, http.MethodGet, "/networks/1/features", "", nil).Code)
+	assert.Equal(t, http.StatusBadRequest, send(router, http.MethodGet, "/networks/abc/features", "", nil).Code)
+}
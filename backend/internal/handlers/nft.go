@@ -1,19 +1,23 @@
 package handlers
 
 import (
+	"context"
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
 	"math/big"
 	"net/http"
 	"sort"
-	"strconv"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/activity"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
 )
 
 // NFTHandler handles NFT-related API endpoints
@@ -33,8 +37,28 @@ type NFTHandler struct {
 	revealed      bool
 	baseURI       string
 	unrevealedURI string
+	imageBaseURI  string
 	royaltyBps    uint16 // Royalty in basis points (e.g., 500 = 5%)
 	royaltyReceiver string
+	activity        *activity.Recorder
+	// Airdrop jobs (see nft_batch.go), minted by RunAirdrops
+	airdrops     map[string]*AirdropJob
+	airdropsWake chan struct{}
+	holders      HolderSource
+	// Cached rarity statistics, nil until computed (see nft_rarity.go)
+	stats *CollectionStats
+	// Trait layers and rendered images (see nft_image.go)
+	images ImageStore
+	// Escrowed sales (see nft_escrow.go): escrowLocks maps locked tokens
+	// and escrowPaymentIDs attached payments to their escrow
+	escrows          map[string]*Escrow
+	escrowLocks      map[string]string
+	escrowPaymentIDs map[string]string
+	escrowPayments   repository.PaymentRepository
+	escrowPricing    repository.PricingRepository
+	escrowRelayer    EscrowRelayer
+	escrowContract   common.Address
+	escrowSettle     sync.Mutex
 }
 
 // NFTToken represents an NFT token
@@ -150,6 +174,11 @@ func NewNFTHandler(logger *zap.Logger) *NFTHandler {
 		ownership:         make(map[string][]string),
 		approvals:         make(map[string]string),
 		operatorApprovals: make(map[string]map[string]bool),
+		airdrops:          make(map[string]*AirdropJob),
+		airdropsWake:      make(chan struct{}, 1),
+		escrows:           make(map[string]*Escrow),
+		escrowLocks:       make(map[string]string),
+		escrowPaymentIDs:  make(map[string]string),
 		name:              "Nexus Genesis Collection",
 		symbol:            "NXSNFT",
 		maxSupply:         10000,
@@ -158,45 +187,31 @@ func NewNFTHandler(logger *zap.Logger) *NFTHandler {
 		revealed:          true,
 		baseURI:           "https://api.nexusprotocol.io/metadata/",
 		unrevealedURI:     "https://api.nexusprotocol.io/metadata/unrevealed.json",
+		imageBaseURI:      "https://api.nexusprotocol.io/images/",
 		royaltyBps:        500, // 5% royalty
 		royaltyReceiver:   "0x0000000000000000000000000000000000000001",
 	}
 
-	// Seed demo NFTs
-	h.seedDemoNFTs()
-
 	return h
 }
 
-// seedDemoNFTs initializes demo NFTs for testing
-func (h *NFTHandler) seedDemoNFTs() {
-	now := time.Now()
-	demoOwner := "0x0000000000000000000000000000000000000003"
-
-	rarities := []string{"Common", "Uncommon", "Rare", "Epic", "Legendary"}
-	elements := []string{"Fire", "Water", "Earth", "Air", "Lightning"}
+// SetActivity records mints and transfers in the owners' activity feeds
+func (h *NFTHandler) SetActivity(recorder *activity.Recorder) {
+	h.activity = recorder
+}
 
-	for i := 1; i <= 5; i++ {
-		tokenID := fmt.Sprintf("%d", i)
-		token := &NFTToken{
-			TokenID:     tokenID,
-			Owner:       demoOwner,
-			Name:        fmt.Sprintf("Nexus Guardian #%d", i),
-			Description: "A powerful guardian from the Nexus realm, sworn to protect the protocol.",
-			Image:       fmt.Sprintf("https://api.nexusprotocol.io/images/%d.png", i),
-			Attributes: []NFTAttribute{
-				{TraitType: "Rarity", Value: rarities[i-1]},
-				{TraitType: "Element", Value: elements[i-1]},
-				{TraitType: "Power Level", Value: i * 20, DisplayType: "number"},
-				{TraitType: "Generation", Value: 1, DisplayType: "number"},
-			},
-			Soulbound: i == 5, // Last one is soulbound (Legendary)
-			MintedAt:  now.Add(-time.Duration(i) * 24 * time.Hour),
-		}
-		h.tokens[tokenID] = token
-		h.ownership[demoOwner] = append(h.ownership[demoOwner], tokenID)
-		h.totalMinted++
+// recordTransfer records one side of a token's mint or transfer
+func (h *NFTHandler) recordTransfer(ctx context.Context, address, action, tokenID, summary string) {
+	if h.activity == nil {
+		return
 	}
+	h.activity.Record(ctx, &repository.ActivityEvent{
+		Address:   address,
+		Kind:      repository.ActivityNFTTransfer,
+		Action:    action,
+		SubjectID: tokenID,
+		Summary:   summary,
+	})
 }
 
 // generateTokenID generates a unique token ID
@@ -205,6 +220,55 @@ func (h *NFTHandler) generateTokenID() string {
 	return fmt.Sprintf("%d", h.totalMinted)
 }
 
+// mintToken mints the next token to to with attributes derived from its
+// ID; h.mu must be held and supply checked
+func (h *NFTHandler) mintToken(to string, now time.Time) *NFTToken {
+	tokenID := h.generateTokenID()
+
+	// Generate random-ish attributes based on token ID
+	hash := sha256.Sum256([]byte(tokenID + now.String()))
+	hashInt := new(big.Int).SetBytes(hash[:])
+
+	rarityIndex := new(big.Int).Mod(hashInt, big.NewInt(100)).Int64()
+	var rarity string
+	switch {
+	case rarityIndex < 50:
+		rarity = "Common"
+	case rarityIndex < 75:
+		rarity = "Uncommon"
+	case rarityIndex < 90:
+		rarity = "Rare"
+	case rarityIndex < 98:
+		rarity = "Epic"
+	default:
+		rarity = "Legendary"
+	}
+
+	elements := []string{"Fire", "Water", "Earth", "Air", "Lightning"}
+	elementIndex := new(big.Int).Mod(hashInt, big.NewInt(5)).Int64()
+
+	token := &NFTToken{
+		TokenID:     tokenID,
+		Owner:       to,
+		Name:        fmt.Sprintf("Nexus Guardian #%s", tokenID),
+		Description: "A powerful guardian from the Nexus realm, sworn to protect the protocol.",
+		Image:       h.imageBaseURI + tokenID + ".png",
+		Attributes: []NFTAttribute{
+			{TraitType: "Rarity", Value: rarity},
+			{TraitType: "Element", Value: elements[elementIndex]},
+			{TraitType: "Power Level", Value: 10 + (hashInt.Int64() % 90), DisplayType: "number"},
+			{TraitType: "Generation", Value: 1, DisplayType: "number"},
+		},
+		Soulbound: false,
+		MintedAt:  now,
+	}
+
+	h.tokens[tokenID] = token
+	h.ownership[to] = append(h.ownership[to], tokenID)
+	h.invalidateStats()
+	return token
+}
+
 // GetCollectionInfo handles GET /api/v1/nft/collection
 // @Summary Get collection info
 // @Description Returns NFT collection metadata
@@ -291,49 +355,8 @@ func (h *NFTHandler) Mint(c *gin.Context) {
 	var tokens []*NFTToken
 
 	for i := uint64(0); i < req.Quantity; i++ {
-		tokenID := h.generateTokenID()
-
-		// Generate random-ish attributes based on token ID
-		hash := sha256.Sum256([]byte(tokenID + now.String()))
-		hashInt := new(big.Int).SetBytes(hash[:])
-
-		rarityIndex := new(big.Int).Mod(hashInt, big.NewInt(100)).Int64()
-		var rarity string
-		switch {
-		case rarityIndex < 50:
-			rarity = "Common"
-		case rarityIndex < 75:
-			rarity = "Uncommon"
-		case rarityIndex < 90:
-			rarity = "Rare"
-		case rarityIndex < 98:
-			rarity = "Epic"
-		default:
-			rarity = "Legendary"
-		}
-
-		elements := []string{"Fire", "Water", "Earth", "Air", "Lightning"}
-		elementIndex := new(big.Int).Mod(hashInt, big.NewInt(5)).Int64()
-
-		token := &NFTToken{
-			TokenID:     tokenID,
-			Owner:       to,
-			Name:        fmt.Sprintf("Nexus Guardian #%s", tokenID),
-			Description: "A powerful guardian from the Nexus realm, sworn to protect the protocol.",
-			Image:       fmt.Sprintf("https://api.nexusprotocol.io/images/%s.png", tokenID),
-			Attributes: []NFTAttribute{
-				{TraitType: "Rarity", Value: rarity},
-				{TraitType: "Element", Value: elements[elementIndex]},
-				{TraitType: "Power Level", Value: 10 + (hashInt.Int64() % 90), DisplayType: "number"},
-				{TraitType: "Generation", Value: 1, DisplayType: "number"},
-			},
-			Soulbound: false,
-			MintedAt:  now,
-		}
-
-		h.tokens[tokenID] = token
-		h.ownership[to] = append(h.ownership[to], tokenID)
-		tokenIDs = append(tokenIDs, tokenID)
+		token := h.mintToken(to, now)
+		tokenIDs = append(tokenIDs, token.TokenID)
 		tokens = append(tokens, token)
 	}
 
@@ -342,6 +365,9 @@ func (h *NFTHandler) Mint(c *gin.Context) {
 		zap.Uint64("quantity", req.Quantity),
 		zap.Strings("token_ids", tokenIDs),
 	)
+	for _, tokenID := range tokenIDs {
+		h.recordTransfer(c.Request.Context(), to, "minted", tokenID, fmt.Sprintf("Minted %s #%s", h.symbol, tokenID))
+	}
 
 	txID := generateMockTxID()
 
@@ -455,14 +481,9 @@ func (h *NFTHandler) GetTokensByOwner(c *gin.Context) {
 		return
 	}
 
-	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
-	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
-
-	if page < 1 {
-		page = 1
-	}
-	if pageSize < 1 || pageSize > 100 {
-		pageSize = 20
+	page, pageSize, ok := queryPage(c, 20)
+	if !ok {
+		return
 	}
 
 	address = strings.ToLower(address)
@@ -522,6 +543,7 @@ func (h *NFTHandler) GetTokensByOwner(c *gin.Context) {
 // @Failure 400 {object} TransferNFTResponse
 // @Failure 403 {object} TransferNFTResponse
 // @Failure 404 {object} TransferNFTResponse
+// @Failure 409 {object} TransferNFTResponse
 // @Router /api/v1/nft/transfer [post]
 func (h *NFTHandler) Transfer(c *gin.Context) {
 	var req TransferNFTRequest
@@ -585,6 +607,14 @@ func (h *NFTHandler) Transfer(c *gin.Context) {
 		return
 	}
 
+	if h.escrowedToken(req.TokenID) {
+		c.JSON(http.StatusConflict, TransferNFTResponse{
+			Success: false,
+			Message: "Token is locked in escrow",
+		})
+		return
+	}
+
 	// Update ownership
 	token.Owner = to
 	now := time.Now()
@@ -602,6 +632,8 @@ func (h *NFTHandler) Transfer(c *gin.Context) {
 		zap.String("from", from),
 		zap.String("to", to),
 	)
+	h.recordTransfer(c.Request.Context(), from, "sent", req.TokenID, fmt.Sprintf("Sent %s #%s to %s", h.symbol, req.TokenID, to))
+	h.recordTransfer(c.Request.Context(), to, "received", req.TokenID, fmt.Sprintf("Received %s #%s from %s", h.symbol, req.TokenID, from))
 
 	txID := generateMockTxID()
 
@@ -1022,6 +1054,7 @@ func (h *NFTHandler) TotalSupply(c *gin.Context) {
 // @Failure 400 {object} map[string]interface{}
 // @Failure 403 {object} map[string]interface{}
 // @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
 // @Router /api/v1/nft/burn [post]
 func (h *NFTHandler) Burn(c *gin.Context) {
 	var req struct {
@@ -1066,10 +1099,19 @@ func (h *NFTHandler) Burn(c *gin.Context) {
 		return
 	}
 
+	if h.escrowedToken(req.TokenID) {
+		c.JSON(http.StatusConflict, gin.H{
+			"success": false,
+			"message": "Token is locked in escrow",
+		})
+		return
+	}
+
 	// Remove token
 	delete(h.tokens, req.TokenID)
 	delete(h.approvals, req.TokenID)
 	h.removeTokenFromOwner(owner, req.TokenID)
+	h.invalidateStats()
 
 	h.logger.Info("NFT burned",
 		zap.String("token_id", req.TokenID),
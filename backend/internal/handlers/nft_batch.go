@@ -0,0 +1,578 @@
+package handlers
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+const (
+	// maxBatchTransfers caps the tokens moved by one batch transfer
+	maxBatchTransfers = 100
+
+	// maxAirdropRecipients caps the recipients of one airdrop job
+	maxAirdropRecipients = 10000
+
+	// defaultAirdropChunkSize and maxAirdropChunkSize bound the recipients
+	// minted to under one hold of the collection lock
+	defaultAirdropChunkSize = 100
+	maxAirdropChunkSize     = 500
+)
+
+// HolderSource lists the current holders of an NFT collection, e.g. from an
+// indexer, so an airdrop can target another collection's holders
+type HolderSource interface {
+	Holders(ctx context.Context, collection string) ([]string, error)
+}
+
+// SetHolderSource sets where airdrops look up holders_of collections. Without
+// one, airdrops need an explicit recipient list.
+func (h *NFTHandler) SetHolderSource(source HolderSource) {
+	h.holders = source
+}
+
+// BatchTransferItem is one token moved by a batch transfer
+type BatchTransferItem struct {
+	TokenID string `json:"token_id"`
+	To      string `json:"to"`
+}
+
+// BatchTransferRequest represents a batch NFT transfer request
+type BatchTransferRequest struct {
+	From      string              `json:"from" binding:"required"`
+	Transfers []BatchTransferItem `json:"transfers" binding:"required"`
+}
+
+// BatchTransferFailure explains why one item of a batch was refused
+type BatchTransferFailure struct {
+	Index   int    `json:"index"`
+	TokenID string `json:"token_id"`
+	Message string `json:"message"`
+}
+
+// BatchTransferResponse represents a batch NFT transfer response
+type BatchTransferResponse struct {
+	Success       bool                   `json:"success"`
+	TransactionID string                 `json:"transaction_id,omitempty"`
+	From          string                 `json:"from"`
+	Transfers     []BatchTransferItem    `json:"transfers,omitempty"`
+	Failures      []BatchTransferFailure `json:"failures,omitempty"`
+	Message       string                 `json:"message"`
+}
+
+// BatchTransfer handles POST /api/v1/nft/batch-transfer
+// @Summary Transfer several NFTs
+// @Description Transfers up to 100 tokens owned by one address in a single transaction. The batch is all or nothing: if any item is refused nothing moves, and the response lists every refused item with the status of the first.
+// @Tags nft
+// @Accept json
+// @Produce json
+// @Param request body BatchTransferRequest true "Batch transfer request"
+// @Success 200 {object} BatchTransferResponse
+// @Failure 400 {object} BatchTransferResponse
+// @Failure 403 {object} BatchTransferResponse
+// @Failure 404 {object} BatchTransferResponse
+// @Failure 409 {object} BatchTransferResponse
+// @Router /api/v1/nft/batch-transfer [post]
+func (h *NFTHandler) BatchTransfer(c *gin.Context) {
+	var req BatchTransferRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("invalid batch transfer request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, BatchTransferResponse{
+			Success: false,
+			Message: "Invalid request: " + err.Error(),
+		})
+		return
+	}
+
+	if !isValidAddress(req.From) {
+		c.JSON(http.StatusBadRequest, BatchTransferResponse{
+			Success: false,
+			Message: "Invalid 'from' address format",
+		})
+		return
+	}
+	if len(req.Transfers) == 0 || len(req.Transfers) > maxBatchTransfers {
+		c.JSON(http.StatusBadRequest, BatchTransferResponse{
+			Success: false,
+			Message: fmt.Sprintf("A batch must transfer between 1 and %d tokens", maxBatchTransfers),
+		})
+		return
+	}
+
+	from := strings.ToLower(req.From)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	// Check every item before moving anything
+	status := http.StatusOK
+	var failures []BatchTransferFailure
+	refuse := func(i int, code int, message string) {
+		if status == http.StatusOK {
+			status = code
+		}
+		failures = append(failures, BatchTransferFailure{Index: i, TokenID: req.Transfers[i].TokenID, Message: message})
+	}
+	seen := make(map[string]bool, len(req.Transfers))
+	for i, item := range req.Transfers {
+		token, exists := h.tokens[item.TokenID]
+		switch {
+		case !isValidAddress(item.To):
+			refuse(i, http.StatusBadRequest, "Invalid 'to' address format")
+		case seen[item.TokenID]:
+			refuse(i, http.StatusBadRequest, "Token appears more than once in the batch")
+		case !exists:
+			refuse(i, http.StatusNotFound, "Token not found")
+		case token.Owner != from:
+			refuse(i, http.StatusForbidden, "Address does not own this token")
+		case token.Soulbound:
+			refuse(i, http.StatusForbidden, "This token is soulbound and cannot be transferred")
+		case h.escrowedToken(item.TokenID):
+			refuse(i, http.StatusConflict, "Token is locked in escrow")
+		}
+		seen[item.TokenID] = true
+	}
+	if len(failures) > 0 {
+		c.JSON(status, BatchTransferResponse{
+			Success:  false,
+			From:     from,
+			Failures: failures,
+			Message:  fmt.Sprintf("%d of %d transfers refused; nothing was transferred", len(failures), len(req.Transfers)),
+		})
+		return
+	}
+
+	now := time.Now()
+	transfers := make([]BatchTransferItem, len(req.Transfers))
+	for i, item := range req.Transfers {
+		to := strings.ToLower(item.To)
+		token := h.tokens[item.TokenID]
+		token.Owner = to
+		token.TransferredAt = &now
+		h.removeTokenFromOwner(from, item.TokenID)
+		h.ownership[to] = append(h.ownership[to], item.TokenID)
+		delete(h.approvals, item.TokenID)
+		transfers[i] = BatchTransferItem{TokenID: item.TokenID, To: to}
+	}
+
+	h.logger.Info("NFT batch transferred",
+		zap.String("from", from),
+		zap.Int("count", len(transfers)),
+	)
+	for _, t := range transfers {
+		h.recordTransfer(c.Request.Context(), from, "sent", t.TokenID, fmt.Sprintf("Sent %s #%s to %s", h.symbol, t.TokenID, t.To))
+		h.recordTransfer(c.Request.Context(), t.To, "received", t.TokenID, fmt.Sprintf("Received %s #%s from %s", h.symbol, t.TokenID, from))
+	}
+
+	c.JSON(http.StatusOK, BatchTransferResponse{
+		Success:       true,
+		TransactionID: generateMockTxID(),
+		From:          from,
+		Transfers:     transfers,
+		Message:       fmt.Sprintf("Successfully transferred %d NFT(s)", len(transfers)),
+	})
+}
+
+// AirdropStatus is the state of an airdrop job
+type AirdropStatus string
+
+const (
+	AirdropRunning   AirdropStatus = "running"
+	AirdropPaused    AirdropStatus = "paused"
+	AirdropCompleted AirdropStatus = "completed"
+	AirdropFailed    AirdropStatus = "failed"
+)
+
+// AirdropJob records an airdrop's recipients and progress. Recipients are
+// minted to in order, a chunk at a time; Processed is where the next chunk
+// starts, so a paused or failed job resumes without minting twice.
+type AirdropJob struct {
+	ID              string        `json:"id"`
+	Status          AirdropStatus `json:"status"`
+	HoldersOf       string        `json:"holders_of,omitempty"`
+	Recipients      []string      `json:"-"`
+	TotalRecipients int           `json:"total_recipients"`
+	Quantity        uint64        `json:"quantity"`
+	ChunkSize       int           `json:"chunk_size"`
+	Processed       int           `json:"processed"`
+	ChunksCompleted int           `json:"chunks_completed"`
+	Minted          uint64        `json:"minted"`
+	Error           string        `json:"error,omitempty"`
+	CreatedBy       string        `json:"created_by"`
+	CreatedAt       time.Time     `json:"created_at"`
+	UpdatedAt       time.Time     `json:"updated_at"`
+	CompletedAt     *time.Time    `json:"completed_at,omitempty"`
+}
+
+// AirdropRequest starts an airdrop to recipients or to every holder of the
+// holders_of collection (one of the two)
+type AirdropRequest struct {
+	Recipients []string `json:"recipients"`
+	HoldersOf  string   `json:"holders_of"`
+	Quantity   uint64   `json:"quantity"`   // per recipient, default 1
+	ChunkSize  int      `json:"chunk_size"` // default 100
+	Operator   string   `json:"operator" binding:"required"`
+}
+
+// AirdropResponse represents an airdrop job response
+type AirdropResponse struct {
+	Success bool        `json:"success"`
+	Job     *AirdropJob `json:"job,omitempty"`
+	Message string      `json:"message,omitempty"`
+}
+
+// AirdropsListResponse represents a list of airdrop jobs
+type AirdropsListResponse struct {
+	Success bool          `json:"success"`
+	Jobs    []*AirdropJob `json:"jobs"`
+	Total   int           `json:"total"`
+}
+
+// StartAirdrop handles POST /api/v1/admin/nft/airdrops
+// @Summary Start an airdrop
+// @Description Mints quantity tokens to each recipient, or to each holder of another collection, in chunks. Returns the job at once; poll it for progress.
+// @Tags nft
+// @Accept json
+// @Produce json
+// @Param request body AirdropRequest true "Airdrop request"
+// @Success 202 {object} AirdropResponse
+// @Failure 400 {object} AirdropResponse
+// @Failure 502 {object} AirdropResponse
+// @Router /api/v1/admin/nft/airdrops [post]
+func (h *NFTHandler) StartAirdrop(c *gin.Context) {
+	var req AirdropRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("invalid airdrop request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, AirdropResponse{Success: false, Message: "Invalid request: " + err.Error()})
+		return
+	}
+	if !isValidAddress(req.Operator) {
+		c.JSON(http.StatusBadRequest, AirdropResponse{Success: false, Message: "Invalid operator address format"})
+		return
+	}
+	if (len(req.Recipients) == 0) == (req.HoldersOf == "") {
+		c.JSON(http.StatusBadRequest, AirdropResponse{Success: false, Message: "Provide either recipients or holders_of"})
+		return
+	}
+	if req.Quantity == 0 {
+		req.Quantity = 1
+	}
+	if req.Quantity > 10 {
+		c.JSON(http.StatusBadRequest, AirdropResponse{Success: false, Message: "Quantity must be between 1 and 10"})
+		return
+	}
+	if req.ChunkSize == 0 {
+		req.ChunkSize = defaultAirdropChunkSize
+	}
+	if req.ChunkSize < 1 || req.ChunkSize > maxAirdropChunkSize {
+		c.JSON(http.StatusBadRequest, AirdropResponse{Success: false, Message: fmt.Sprintf("chunk_size must be between 1 and %d", maxAirdropChunkSize)})
+		return
+	}
+
+	recipients := req.Recipients
+	holdersOf := strings.ToLower(req.HoldersOf)
+	if holdersOf != "" {
+		if !isValidAddress(holdersOf) {
+			c.JSON(http.StatusBadRequest, AirdropResponse{Success: false, Message: "Invalid holders_of collection address format"})
+			return
+		}
+		if h.holders == nil {
+			c.JSON(http.StatusBadRequest, AirdropResponse{Success: false, Message: "Airdrops to collection holders are not configured"})
+			return
+		}
+		holders, err := h.holders.Holders(c.Request.Context(), holdersOf)
+		if err != nil {
+			h.logger.Error("failed to list collection holders", zap.String("collection", holdersOf), zap.Error(err))
+			c.JSON(http.StatusBadGateway, AirdropResponse{Success: false, Message: "Failed to list collection holders"})
+			return
+		}
+		recipients = holders
+	}
+
+	// Each address receives one share, however often it is listed
+	seen := make(map[string]bool, len(recipients))
+	unique := make([]string, 0, len(recipients))
+	for _, addr := range recipients {
+		if !isValidAddress(addr) {
+			c.JSON(http.StatusBadRequest, AirdropResponse{Success: false, Message: fmt.Sprintf("Invalid recipient address format: %q", addr)})
+			return
+		}
+		addr = strings.ToLower(addr)
+		if !seen[addr] {
+			seen[addr] = true
+			unique = append(unique, addr)
+		}
+	}
+	if len(unique) == 0 || len(unique) > maxAirdropRecipients {
+		c.JSON(http.StatusBadRequest, AirdropResponse{Success: false, Message: fmt.Sprintf("An airdrop needs between 1 and %d recipients", maxAirdropRecipients)})
+		return
+	}
+
+	idBytes := make([]byte, 8)
+	if _, err := rand.Read(idBytes); err != nil {
+		h.logger.Error("failed to generate airdrop ID", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, AirdropResponse{Success: false, Message: "Internal server error"})
+		return
+	}
+
+	h.mu.Lock()
+	needed := uint64(len(unique)) * req.Quantity
+	if h.totalMinted+needed > h.maxSupply {
+		available := h.maxSupply - h.totalMinted
+		h.mu.Unlock()
+		c.JSON(http.StatusBadRequest, AirdropResponse{
+			Success: false,
+			Message: fmt.Sprintf("Not enough supply for %d tokens. Available: %d", needed, available),
+		})
+		return
+	}
+	now := time.Now().UTC()
+	job := &AirdropJob{
+		ID:              "airdrop-" + hex.EncodeToString(idBytes),
+		Status:          AirdropRunning,
+		HoldersOf:       holdersOf,
+		Recipients:      unique,
+		TotalRecipients: len(unique),
+		Quantity:        req.Quantity,
+		ChunkSize:       req.ChunkSize,
+		CreatedBy:       strings.ToLower(req.Operator),
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	h.airdrops[job.ID] = job
+	snapshot := *job
+	h.mu.Unlock()
+
+	h.logger.Info("airdrop started",
+		zap.String("job_id", job.ID),
+		zap.Int("recipients", job.TotalRecipients),
+		zap.Uint64("quantity", job.Quantity),
+		zap.String("operator", job.CreatedBy),
+	)
+	h.wakeAirdrops()
+
+	c.JSON(http.StatusAccepted, AirdropResponse{Success: true, Job: &snapshot})
+}
+
+// RunAirdrops mints running airdrops until ctx is done, one chunk of each job
+// in turn so a large job does not hold up the others. StartAirdrop and
+// ResumeAirdrop wake it; a job still running at shutdown stops between
+// chunks.
+func (h *NFTHandler) RunAirdrops(ctx context.Context) {
+	for {
+		for ctx.Err() == nil && h.runAirdropChunks() {
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-h.airdropsWake:
+		}
+	}
+}
+
+// wakeAirdrops signals RunAirdrops without blocking if a wake-up is pending
+func (h *NFTHandler) wakeAirdrops() {
+	select {
+	case h.airdropsWake <- struct{}{}:
+	default:
+	}
+}
+
+// runAirdropChunks mints the next chunk of every running airdrop, oldest
+// first, reporting whether any has chunks left
+func (h *NFTHandler) runAirdropChunks() bool {
+	h.mu.RLock()
+	var running []*AirdropJob
+	for _, job := range h.airdrops {
+		if job.Status == AirdropRunning {
+			running = append(running, job)
+		}
+	}
+	h.mu.RUnlock()
+	sort.Slice(running, func(i, j int) bool { return running[i].CreatedAt.Before(running[j].CreatedAt) })
+
+	more := false
+	for _, job := range running {
+		if h.airdropChunk(job) {
+			more = true
+		}
+	}
+	return more
+}
+
+// airdropChunk mints to job's next chunk of recipients, reporting whether
+// more chunks remain to run
+func (h *NFTHandler) airdropChunk(job *AirdropJob) bool {
+	h.mu.Lock()
+	if job.Status != AirdropRunning {
+		h.mu.Unlock()
+		return false
+	}
+
+	now := time.Now()
+	end := min(job.Processed+job.ChunkSize, len(job.Recipients))
+	chunk := job.Recipients[job.Processed:end]
+
+	// Other mints may have used the supply checked when the job started
+	if needed := uint64(len(chunk)) * job.Quantity; h.totalMinted+needed > h.maxSupply {
+		job.Status = AirdropFailed
+		job.Error = fmt.Sprintf("Not enough supply for the next chunk. Available: %d", h.maxSupply-h.totalMinted)
+		job.UpdatedAt = now.UTC()
+		processed, reason := job.Processed, job.Error
+		h.mu.Unlock()
+		h.logger.Warn("airdrop failed", zap.String("job_id", job.ID), zap.Int("processed", processed), zap.String("error", reason))
+		return false
+	}
+
+	var minted []*NFTToken
+	for _, to := range chunk {
+		for i := uint64(0); i < job.Quantity; i++ {
+			minted = append(minted, h.mintToken(to, now))
+		}
+	}
+	job.Processed = end
+	job.ChunksCompleted++
+	job.Minted += uint64(len(minted))
+	job.UpdatedAt = now.UTC()
+	if job.Processed == len(job.Recipients) {
+		completed := job.UpdatedAt
+		job.Status = AirdropCompleted
+		job.CompletedAt = &completed
+	}
+	more := job.Status == AirdropRunning
+	processed, total := job.Processed, job.Minted
+	symbol := h.symbol
+	h.mu.Unlock()
+
+	for _, token := range minted {
+		h.recordTransfer(context.Background(), token.Owner, "minted", token.TokenID, fmt.Sprintf("Airdropped %s #%s", symbol, token.TokenID))
+	}
+	if !more {
+		h.logger.Info("airdrop chunks stopped", zap.String("job_id", job.ID), zap.Int("processed", processed), zap.Uint64("minted", total))
+	}
+	return more
+}
+
+// ListAirdrops handles GET /api/v1/admin/nft/airdrops
+// @Summary List airdrops
+// @Description Returns airdrop jobs, newest first
+// @Tags nft
+// @Produce json
+// @Success 200 {object} AirdropsListResponse
+// @Router /api/v1/admin/nft/airdrops [get]
+func (h *NFTHandler) ListAirdrops(c *gin.Context) {
+	h.mu.RLock()
+	jobs := make([]*AirdropJob, 0, len(h.airdrops))
+	for _, job := range h.airdrops {
+		snapshot := *job
+		jobs = append(jobs, &snapshot)
+	}
+	h.mu.RUnlock()
+
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[i].CreatedAt.After(jobs[j].CreatedAt)
+	})
+
+	c.JSON(http.StatusOK, AirdropsListResponse{Success: true, Jobs: jobs, Total: len(jobs)})
+}
+
+// GetAirdrop handles GET /api/v1/admin/nft/airdrops/:id
+// @Summary Get airdrop progress
+// @Description Returns an airdrop job and its progress
+// @Tags nft
+// @Produce json
+// @Param id path string true "Airdrop job ID"
+// @Success 200 {object} AirdropResponse
+// @Failure 404 {object} AirdropResponse
+// @Router /api/v1/admin/nft/airdrops/{id} [get]
+func (h *NFTHandler) GetAirdrop(c *gin.Context) {
+	h.mu.RLock()
+	job, exists := h.airdrops[c.Param("id")]
+	var snapshot AirdropJob
+	if exists {
+		snapshot = *job
+	}
+	h.mu.RUnlock()
+
+	if !exists {
+		c.JSON(http.StatusNotFound, AirdropResponse{Success: false, Message: "Airdrop not found"})
+		return
+	}
+	c.JSON(http.StatusOK, AirdropResponse{Success: true, Job: &snapshot})
+}
+
+// PauseAirdrop handles POST /api/v1/admin/nft/airdrops/:id/pause
+// @Summary Pause an airdrop
+// @Description Stops a running airdrop after its current chunk
+// @Tags nft
+// @Produce json
+// @Param id path string true "Airdrop job ID"
+// @Success 200 {object} AirdropResponse
+// @Failure 404 {object} AirdropResponse
+// @Failure 409 {object} AirdropResponse
+// @Router /api/v1/admin/nft/airdrops/{id}/pause [post]
+func (h *NFTHandler) PauseAirdrop(c *gin.Context) {
+	h.setAirdropStatus(c, AirdropPaused, AirdropRunning)
+}
+
+// ResumeAirdrop handles POST /api/v1/admin/nft/airdrops/:id/resume
+// @Summary Resume an airdrop
+// @Description Continues a paused or failed airdrop from its first unprocessed recipient
+// @Tags nft
+// @Produce json
+// @Param id path string true "Airdrop job ID"
+// @Success 202 {object} AirdropResponse
+// @Failure 404 {object} AirdropResponse
+// @Failure 409 {object} AirdropResponse
+// @Router /api/v1/admin/nft/airdrops/{id}/resume [post]
+func (h *NFTHandler) ResumeAirdrop(c *gin.Context) {
+	h.setAirdropStatus(c, AirdropRunning, AirdropPaused, AirdropFailed)
+}
+
+// setAirdropStatus moves an airdrop in one of the from states to status,
+// starting it again if status is running
+func (h *NFTHandler) setAirdropStatus(c *gin.Context, status AirdropStatus, from ...AirdropStatus) {
+	h.mu.Lock()
+	job, exists := h.airdrops[c.Param("id")]
+	if !exists {
+		h.mu.Unlock()
+		c.JSON(http.StatusNotFound, AirdropResponse{Success: false, Message: "Airdrop not found"})
+		return
+	}
+	allowed := false
+	for _, s := range from {
+		allowed = allowed || job.Status == s
+	}
+	if !allowed {
+		snapshot := *job
+		h.mu.Unlock()
+		c.JSON(http.StatusConflict, AirdropResponse{
+			Success: false,
+			Job:     &snapshot,
+			Message: fmt.Sprintf("Airdrop is %s", snapshot.Status),
+		})
+		return
+	}
+	job.Status = status
+	job.Error = ""
+	job.UpdatedAt = time.Now().UTC()
+	snapshot := *job
+	h.mu.Unlock()
+
+	h.logger.Info("airdrop status changed", zap.String("job_id", job.ID), zap.String("status", string(status)))
+	if status != AirdropRunning {
+		c.JSON(http.StatusOK, AirdropResponse{Success: true, Job: &snapshot})
+		return
+	}
+	h.wakeAirdrops()
+	c.JSON(http.StatusAccepted, AirdropResponse{Success: true, Job: &snapshot})
+}
@@ -0,0 +1,228 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/handlers"
+)
+
+func nftTestRouter(h *handlers.NFTHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/nft/owner/:address", h.GetTokensByOwner)
+	router.POST("/nft/batch-transfer", h.BatchTransfer)
+	router.GET("/admin/nft/airdrops", h.ListAirdrops)
+	router.POST("/admin/nft/airdrops", h.StartAirdrop)
+	router.GET("/admin/nft/airdrops/:id", h.GetAirdrop)
+	router.POST("/admin/nft/airdrops/:id/resume", h.ResumeAirdrop)
+	return router
+}
+
+// testAddress returns a distinct valid address for i
+func testAddress(i int) string {
+	return fmt.Sprintf("0x%040x", i+1)
+}
+
+func TestNFTHandler_BatchTransfer(t *testing.T) {
+	from, to := testAddress(0), testAddress(1)
+	h := handlers.NewNFTHandler(zap.NewNop())
+	h.Seed([]*handlers.NFTToken{
+		{TokenID: "1", Owner: from},
+		{TokenID: "2", Owner: from},
+		{TokenID: "3", Owner: to},
+		{TokenID: "4", Owner: from, Soulbound: true},
+	})
+	router := nftTestRouter(h)
+
+	// One bad item refuses the whole batch
+	w := send(router, http.MethodPost, "/nft/batch-transfer", "", gin.H{"from": from, "transfers": []gin.H{
+		{"token_id": "1", "to": to},
+		{"token_id": "3", "to": from},
+		{"token_id": "4", "to": to},
+		{"token_id": "1", "to": to},
+		{"token_id": "9", "to": to},
+	}})
+	require.Equal(t, http.StatusForbidden, w.Code, w.Body.String())
+	var refused handlers.BatchTransferResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &refused))
+	require.Len(t, refused.Failures, 4)
+	assert.Equal(t, []int{1, 2, 3, 4}, []int{refused.Failures[0].Index, refused.Failures[1].Index, refused.Failures[2].Index, refused.Failures[3].Index})
+
+	w = send(router, http.MethodGet, "/nft/owner/"+from, "", nil)
+	assert.Contains(t, w.Body.String(), `"total":3`, "nothing moved")
+
+	w = send(router, http.MethodPost, "/nft/batch-transfer", "", gin.H{"from": strings.ToUpper(from[:2]) + from[2:], "transfers": []gin.H{
+		{"token_id": "1", "to": to},
+		{"token_id": "2", "to": testAddress(2)},
+	}})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	w = send(router, http.MethodGet, "/nft/owner/"+to, "", nil)
+	assert.Contains(t, w.Body.String(), `"total":2`)
+	w = send(router, http.MethodGet, "/nft/owner/"+from, "", nil)
+	assert.Contains(t, w.Body.String(), `"total":1`)
+
+	w = send(router, http.MethodPost, "/nft/batch-transfer", "", gin.H{"from": from, "transfers": []gin.H{}})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+// fakeHolders serves a fixed holder list per collection
+type fakeHolders map[string][]string
+
+func (f fakeHolders) Holders(_ context.Context, collection string) ([]string, error) {
+	holders, ok := f[collection]
+	if !ok {
+		return nil, fmt.Errorf("unknown collection %s", collection)
+	}
+	return holders, nil
+}
+
+// waitForAirdrop polls an airdrop until it stops running
+func waitForAirdrop(t *testing.T, router *gin.Engine, id string) *handlers.AirdropJob {
+	t.Helper()
+	var resp handlers.AirdropResponse
+	require.Eventually(t, func() bool {
+		w := send(router, http.MethodGet, "/admin/nft/airdrops/"+id, "", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		return resp.Job.Status != handlers.AirdropRunning
+	}, 5*time.Second, 5*time.Millisecond)
+	return resp.Job
+}
+
+// runAirdrops runs h's airdrop runner until the test ends
+func runAirdrops(t *testing.T, h *handlers.NFTHandler) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go h.RunAirdrops(ctx)
+}
+
+func TestNFTHandler_Airdrop(t *testing.T) {
+	h := handlers.NewNFTHandler(zap.NewNop())
+	router := nftTestRouter(h)
+	runAirdrops(t, h)
+
+	recipients := []string{testAddress(10), testAddress(11), testAddress(12), testAddress(10)}
+	w := send(router, http.MethodPost, "/admin/nft/airdrops", "", gin.H{"recipients": recipients, "quantity": 2, "chunk_size": 2, "operator": operator})
+	require.Equal(t, http.StatusAccepted, w.Code, w.Body.String())
+	var started handlers.AirdropResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &started))
+	assert.Equal(t, 3, started.Job.TotalRecipients, "duplicates receive one share")
+
+	job := waitForAirdrop(t, router, started.Job.ID)
+	assert.Equal(t, handlers.AirdropCompleted, job.Status)
+	assert.Equal(t, 3, job.Processed)
+	assert.Equal(t, 2, job.ChunksCompleted)
+	assert.Equal(t, uint64(6), job.Minted)
+	assert.NotNil(t, job.CompletedAt)
+	w = send(router, http.MethodGet, "/nft/owner/"+testAddress(10), "", nil)
+	assert.Contains(t, w.Body.String(), `"total":2`)
+
+	// A completed job cannot be resumed
+	w = send(router, http.MethodPost, "/admin/nft/airdrops/"+job.ID+"/resume", "", nil)
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	// Holders of another collection need a holder source
+	collection := testAddress(99)
+	w = send(router, http.MethodPost, "/admin/nft/airdrops", "", gin.H{"holders_of": collection, "operator": operator})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	h.SetHolderSource(fakeHolders{collection: {testAddress(20), testAddress(21)}})
+	w = send(router, http.MethodPost, "/admin/nft/airdrops", "", gin.H{"holders_of": collection, "operator": operator})
+	require.Equal(t, http.StatusAccepted, w.Code, w.Body.String())
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &started))
+	job = waitForAirdrop(t, router, started.Job.ID)
+	assert.Equal(t, handlers.AirdropCompleted, job.Status)
+	assert.Equal(t, uint64(2), job.Minted)
+	w = send(router, http.MethodPost, "/admin/nft/airdrops", "", gin.H{"holders_of": testAddress(98), "operator": operator})
+	assert.Equal(t, http.StatusBadGateway, w.Code)
+
+	w = send(router, http.MethodGet, "/admin/nft/airdrops", "", nil)
+	var list handlers.AirdropsListResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	assert.Equal(t, 2, list.Total)
+
+	for _, body := range []gin.H{
+		{"recipients": recipients, "holders_of": collection, "operator": operator},
+		{"recipients": []string{"0x123"}, "operator": operator},
+		{"recipients": recipients, "quantity": 11, "operator": operator},
+		{"recipients": recipients, "chunk_size": 501, "operator": operator},
+		{"recipients": make([]string, 0), "operator": operator},
+	} {
+		w = send(router, http.MethodPost, "/admin/nft/airdrops", "", body)
+		assert.Equal(t, http.StatusBadRequest, w.Code, body)
+	}
+}
+
+func TestNFTHandler_AirdropPauseAndResume(t *testing.T) {
+	h := handlers.NewNFTHandler(zap.NewNop())
+	router := nftTestRouter(h)
+	router.POST("/admin/nft/airdrops/:id/pause", h.PauseAirdrop)
+	router.GET("/nft/total-supply", h.TotalSupply)
+	runAirdrops(t, h)
+
+	recipients := make([]string, 5000)
+	for i := range recipients {
+		recipients[i] = testAddress(i)
+	}
+	w := send(router, http.MethodPost, "/admin/nft/airdrops", "", gin.H{"recipients": append(recipients, testAddress(5000)), "quantity": 2, "operator": operator})
+	assert.Equal(t, http.StatusBadRequest, w.Code, "the supply is checked up front")
+
+	w = send(router, http.MethodPost, "/admin/nft/airdrops", "", gin.H{"recipients": recipients, "chunk_size": 1, "operator": operator})
+	require.Equal(t, http.StatusAccepted, w.Code, w.Body.String())
+	var started handlers.AirdropResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &started))
+	id := started.Job.ID
+
+	w = send(router, http.MethodPost, "/admin/nft/airdrops/"+id+"/pause", "", nil)
+	if w.Code == http.StatusConflict {
+		t.Skip("the airdrop completed before it could be paused")
+	}
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	job := waitForAirdrop(t, router, id)
+	assert.Equal(t, handlers.AirdropPaused, job.Status)
+	assert.Less(t, job.Processed, len(recipients))
+
+	// Resuming continues from the first unprocessed recipient
+	w = send(router, http.MethodPost, "/admin/nft/airdrops/"+id+"/resume", "", nil)
+	require.Equal(t, http.StatusAccepted, w.Code, w.Body.String())
+	job = waitForAirdrop(t, router, id)
+	assert.Equal(t, handlers.AirdropCompleted, job.Status)
+	assert.Equal(t, uint64(len(recipients)), job.Minted)
+	w = send(router, http.MethodGet, "/nft/total-supply", "", nil)
+	assert.Contains(t, w.Body.String(), `"total_supply":5000`, "no recipient was minted to twice")
+
+	w = send(router, http.MethodPost, "/admin/nft/airdrops/airdrop-missing/resume", "", nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestNFTHandler_AirdropRunner(t *testing.T) {
+	h := handlers.NewNFTHandler(zap.NewNop())
+	router := nftTestRouter(h)
+
+	w := send(router, http.MethodPost, "/admin/nft/airdrops", "", gin.H{"recipients": []string{testAddress(1), testAddress(2)}, "operator": operator})
+	require.Equal(t, http.StatusAccepted, w.Code, w.Body.String())
+	var started handlers.AirdropResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &started))
+
+	// Jobs are minted by the runner, which picks up jobs started before it
+	w = send(router, http.MethodGet, "/admin/nft/airdrops/"+started.Job.ID, "", nil)
+	var resp handlers.AirdropResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, handlers.AirdropRunning, resp.Job.Status)
+	assert.Zero(t, resp.Job.Minted)
+
+	runAirdrops(t, h)
+	job := waitForAirdrop(t, router, started.Job.ID)
+	assert.Equal(t, handlers.AirdropCompleted, job.Status)
+	assert.Equal(t, uint64(2), job.Minted)
+}
@@ -0,0 +1,609 @@
+package handlers
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Escrowed sales let a buyer pay for a token through the payments module,
+// by card or crypto. The seller locks the token at the price of a pricing
+// service; the buyer attaches a payment for that service; once the payment
+// completes the backend transfers the token, on chain through a relayer key
+// the seller has approved. If the deadline passes first the token is
+// unlocked, and a payment that completes anyway is marked refunded.
+
+const (
+	// defaultEscrowTimeout and maxEscrowTimeout bound how long a token may
+	// stay locked waiting for payment
+	defaultEscrowTimeout = 24 * time.Hour
+	minEscrowTimeout     = 15 * time.Minute
+	maxEscrowTimeout     = 7 * 24 * time.Hour
+
+	// DefaultEscrowSettleInterval is how often RunEscrowSettlement looks at
+	// escrow payments when no interval is given
+	DefaultEscrowSettleInterval = 30 * time.Second
+)
+
+// transferFromSelector is the ERC-721 transferFrom(address,address,uint256)
+// selector
+var transferFromSelector = []byte{0x23, 0xb8, 0x72, 0xdd}
+
+// EscrowStatus is the state of an escrowed sale
+type EscrowStatus string
+
+const (
+	// EscrowOpen: the token is locked, waiting for a buyer's payment
+	EscrowOpen EscrowStatus = "open"
+	// EscrowFunded: a payment is attached, waiting for it to complete
+	EscrowFunded EscrowStatus = "funded"
+	// EscrowReleased: the payment completed and the token was transferred
+	EscrowReleased EscrowStatus = "released"
+	// EscrowAwaitingRefund: the deadline passed while the payment was
+	// pending; the token is unlocked and the payment is refunded if it
+	// completes
+	EscrowAwaitingRefund EscrowStatus = "awaiting_refund"
+	// EscrowRefunded: the payment completed too late and was refunded
+	EscrowRefunded EscrowStatus = "refunded"
+	// EscrowExpired: the deadline passed without a completed payment
+	EscrowExpired EscrowStatus = "expired"
+	// EscrowCancelled: the seller withdrew the token before any payment
+	EscrowCancelled EscrowStatus = "cancelled"
+)
+
+// Escrow is an escrowed sale of one token
+type Escrow struct {
+	ID          string       `json:"id"`
+	TokenID     string       `json:"token_id"`
+	Seller      string       `json:"seller"`
+	Buyer       string       `json:"buyer,omitempty"` // if set at creation, only this address may buy
+	ServiceCode string       `json:"service_code"`
+	PriceUSD    float64      `json:"price_usd"`
+	PaymentID   *string      `json:"payment_id,omitempty"`
+	Status      EscrowStatus `json:"status"`
+	TxHash      string       `json:"tx_hash,omitempty"`
+	LastError   string       `json:"last_error,omitempty"`
+	ExpiresAt   time.Time    `json:"expires_at"`
+	CreatedAt   time.Time    `json:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at"`
+	SettledAt   *time.Time   `json:"settled_at,omitempty"`
+}
+
+// EscrowRelayer sends a transaction from a relayer key (implemented by
+// RelayerHandler.SendTransfer)
+type EscrowRelayer interface {
+	SendTransfer(ctx context.Context, to common.Address, value *big.Int, data []byte) (common.Address, common.Hash, error)
+}
+
+// SetEscrowPayments enables escrowed sales, priced by pricing and paid
+// through payments
+func (h *NFTHandler) SetEscrowPayments(payments repository.PaymentRepository, pricing repository.PricingRepository) {
+	h.escrowPayments = payments
+	h.escrowPricing = pricing
+}
+
+// SetEscrowRelayer transfers released tokens on chain: relayer sends
+// transferFrom(seller, buyer, token) to contract, so sellers must approve
+// the relayer keys. Without a relayer, releases only move the token here.
+func (h *NFTHandler) SetEscrowRelayer(relayer EscrowRelayer, contract common.Address) {
+	h.escrowRelayer = relayer
+	h.escrowContract = contract
+}
+
+// CreateEscrowRequest locks a token for sale
+type CreateEscrowRequest struct {
+	Seller         string `json:"seller" binding:"required"`
+	TokenID        string `json:"token_id" binding:"required"`
+	ServiceCode    string `json:"service_code" binding:"required"`
+	Buyer          string `json:"buyer,omitempty"`
+	TimeoutMinutes int    `json:"timeout_minutes,omitempty"` // default 24 hours
+}
+
+// FundEscrowRequest attaches a buyer's payment to an escrow
+type FundEscrowRequest struct {
+	Buyer     string `json:"buyer" binding:"required"`
+	PaymentID string `json:"payment_id" binding:"required"`
+}
+
+// CancelEscrowRequest withdraws a token from sale
+type CancelEscrowRequest struct {
+	Seller string `json:"seller" binding:"required"`
+}
+
+// EscrowResponse represents an escrow response
+type EscrowResponse struct {
+	Success bool    `json:"success"`
+	Escrow  *Escrow `json:"escrow,omitempty"`
+	Message string  `json:"message,omitempty"`
+}
+
+// escrowedToken reports whether tokenID is locked in an open or funded
+// escrow; h.mu must be held
+func (h *NFTHandler) escrowedToken(tokenID string) bool {
+	_, locked := h.escrowLocks[tokenID]
+	return locked
+}
+
+// CreateEscrow handles POST /api/v1/nft/escrows
+// @Summary Lock a token for an escrowed sale
+// @Description Locks a token until a buyer pays the price of service_code, or the timeout passes
+// @Tags nft
+// @Accept json
+// @Produce json
+// @Param request body CreateEscrowRequest true "Escrow request"
+// @Success 201 {object} EscrowResponse
+// @Failure 400 {object} EscrowResponse
+// @Failure 403 {object} EscrowResponse
+// @Failure 404 {object} EscrowResponse
+// @Failure 409 {object} EscrowResponse
+// @Router /api/v1/nft/escrows [post]
+func (h *NFTHandler) CreateEscrow(c *gin.Context) {
+	if h.escrowPayments == nil {
+		c.JSON(http.StatusConflict, EscrowResponse{Success: false, Message: "Escrowed sales are not enabled"})
+		return
+	}
+
+	var req CreateEscrowRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, EscrowResponse{Success: false, Message: "Invalid request: " + err.Error()})
+		return
+	}
+	if !isValidAddress(req.Seller) {
+		c.JSON(http.StatusBadRequest, EscrowResponse{Success: false, Message: "Invalid seller address format"})
+		return
+	}
+	if req.Buyer != "" && !isValidAddress(req.Buyer) {
+		c.JSON(http.StatusBadRequest, EscrowResponse{Success: false, Message: "Invalid buyer address format"})
+		return
+	}
+	timeout := defaultEscrowTimeout
+	if req.TimeoutMinutes != 0 {
+		timeout = time.Duration(req.TimeoutMinutes) * time.Minute
+	}
+	if timeout < minEscrowTimeout || timeout > maxEscrowTimeout {
+		c.JSON(http.StatusBadRequest, EscrowResponse{Success: false, Message: "timeout_minutes must be between 15 and 10080"})
+		return
+	}
+	if h.escrowRelayer != nil {
+		if _, ok := new(big.Int).SetString(req.TokenID, 10); !ok {
+			c.JSON(http.StatusBadRequest, EscrowResponse{Success: false, Message: "Only on-chain tokens can be escrowed"})
+			return
+		}
+	}
+
+	pricing, err := h.escrowPricing.GetPricing(c.Request.Context(), req.ServiceCode)
+	if err != nil {
+		if errors.Is(err, repository.ErrPricingNotFound) {
+			c.JSON(http.StatusBadRequest, EscrowResponse{Success: false, Message: "Service not found: " + req.ServiceCode})
+			return
+		}
+		h.logger.Error("failed to get escrow pricing", zap.String("service_code", req.ServiceCode), zap.Error(err))
+		c.JSON(http.StatusInternalServerError, EscrowResponse{Success: false, Message: "Internal server error"})
+		return
+	}
+	if !pricing.IsActive {
+		c.JSON(http.StatusBadRequest, EscrowResponse{Success: false, Message: "Service is currently unavailable"})
+		return
+	}
+
+	idBytes := make([]byte, 8)
+	if _, err := rand.Read(idBytes); err != nil {
+		h.logger.Error("failed to generate escrow ID", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, EscrowResponse{Success: false, Message: "Internal server error"})
+		return
+	}
+
+	seller := strings.ToLower(req.Seller)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	token, exists := h.tokens[req.TokenID]
+	switch {
+	case !exists:
+		c.JSON(http.StatusNotFound, EscrowResponse{Success: false, Message: "Token not found"})
+		return
+	case token.Owner != seller:
+		c.JSON(http.StatusForbidden, EscrowResponse{Success: false, Message: "Address does not own this token"})
+		return
+	case token.Soulbound:
+		c.JSON(http.StatusForbidden, EscrowResponse{Success: false, Message: "This token is soulbound and cannot be transferred"})
+		return
+	case h.escrowedToken(req.TokenID):
+		c.JSON(http.StatusConflict, EscrowResponse{Success: false, Message: "Token is already in escrow"})
+		return
+	}
+
+	now := time.Now().UTC()
+	escrow := &Escrow{
+		ID:          "escrow-" + hex.EncodeToString(idBytes),
+		TokenID:     req.TokenID,
+		Seller:      seller,
+		Buyer:       strings.ToLower(req.Buyer),
+		ServiceCode: req.ServiceCode,
+		PriceUSD:    pricing.PriceUSD,
+		Status:      EscrowOpen,
+		ExpiresAt:   now.Add(timeout),
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	h.escrows[escrow.ID] = escrow
+	h.escrowLocks[req.TokenID] = escrow.ID
+	snapshot := *escrow
+
+	h.logger.Info("NFT escrow opened",
+		zap.String("escrow_id", escrow.ID),
+		zap.String("token_id", escrow.TokenID),
+		zap.String("seller", seller),
+		zap.Float64("price_usd", escrow.PriceUSD),
+	)
+
+	c.JSON(http.StatusCreated, EscrowResponse{Success: true, Escrow: &snapshot})
+}
+
+// GetEscrow handles GET /api/v1/nft/escrows/:id
+// @Summary Get an escrow
+// @Description Returns an escrowed sale and its settlement state
+// @Tags nft
+// @Produce json
+// @Param id path string true "Escrow ID"
+// @Success 200 {object} EscrowResponse
+// @Failure 404 {object} EscrowResponse
+// @Router /api/v1/nft/escrows/{id} [get]
+func (h *NFTHandler) GetEscrow(c *gin.Context) {
+	h.mu.RLock()
+	escrow, exists := h.escrows[c.Param("id")]
+	var snapshot Escrow
+	if exists {
+		snapshot = *escrow
+	}
+	h.mu.RUnlock()
+
+	if !exists {
+		c.JSON(http.StatusNotFound, EscrowResponse{Success: false, Message: "Escrow not found"})
+		return
+	}
+	c.JSON(http.StatusOK, EscrowResponse{Success: true, Escrow: &snapshot})
+}
+
+// FundEscrow handles POST /api/v1/nft/escrows/:id/payment
+// @Summary Pay for an escrowed token
+// @Description Attaches the buyer's payment for the escrow's service. A completed payment releases the token at once; a pending one releases it when it completes.
+// @Tags nft
+// @Accept json
+// @Produce json
+// @Param id path string true "Escrow ID"
+// @Param request body FundEscrowRequest true "Payment"
+// @Success 200 {object} EscrowResponse
+// @Failure 400 {object} EscrowResponse
+// @Failure 403 {object} EscrowResponse
+// @Failure 404 {object} EscrowResponse
+// @Failure 409 {object} EscrowResponse
+// @Router /api/v1/nft/escrows/{id}/payment [post]
+func (h *NFTHandler) FundEscrow(c *gin.Context) {
+	var req FundEscrowRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, EscrowResponse{Success: false, Message: "Invalid request: " + err.Error()})
+		return
+	}
+	if !isValidAddress(req.Buyer) {
+		c.JSON(http.StatusBadRequest, EscrowResponse{Success: false, Message: "Invalid buyer address format"})
+		return
+	}
+	buyer := strings.ToLower(req.Buyer)
+	id := c.Param("id")
+
+	h.mu.RLock()
+	escrow, exists := h.escrows[id]
+	var snapshot Escrow
+	if exists {
+		snapshot = *escrow
+	}
+	h.mu.RUnlock()
+	if !exists {
+		c.JSON(http.StatusNotFound, EscrowResponse{Success: false, Message: "Escrow not found"})
+		return
+	}
+	if snapshot.Buyer != "" && snapshot.Buyer != buyer {
+		c.JSON(http.StatusForbidden, EscrowResponse{Success: false, Message: "This token is reserved for another buyer"})
+		return
+	}
+
+	ctx := c.Request.Context()
+	payment, err := h.escrowPayments.GetPayment(ctx, req.PaymentID)
+	if err != nil {
+		if errors.Is(err, repository.ErrPaymentNotFound) {
+			c.JSON(http.StatusBadRequest, EscrowResponse{Success: false, Message: "Payment not found"})
+			return
+		}
+		h.logger.Error("failed to get escrow payment", zap.String("escrow_id", id), zap.Error(err))
+		c.JSON(http.StatusInternalServerError, EscrowResponse{Success: false, Message: "Failed to check payment"})
+		return
+	}
+	var problem string
+	switch {
+	case payment.ServiceCode != snapshot.ServiceCode:
+		problem = "Payment is not for service " + snapshot.ServiceCode
+	case strings.ToLower(payment.PayerAddress) != buyer:
+		problem = "Payment was made by a different address than the buyer"
+	case payment.AmountUSD == nil || *payment.AmountUSD < snapshot.PriceUSD:
+		problem = fmt.Sprintf("Payment does not cover the price of %.2f USD", snapshot.PriceUSD)
+	case payment.Status != repository.PaymentStatusPending && payment.Status != repository.PaymentStatusProcessing &&
+		payment.Status != repository.PaymentStatusCompleted:
+		problem = "Payment is " + string(payment.Status)
+	}
+	if problem != "" {
+		c.JSON(http.StatusBadRequest, EscrowResponse{Success: false, Message: problem})
+		return
+	}
+
+	h.mu.Lock()
+	if escrow.Status != EscrowOpen {
+		snapshot = *escrow
+		h.mu.Unlock()
+		c.JSON(http.StatusConflict, EscrowResponse{Success: false, Escrow: &snapshot, Message: "Escrow is " + string(snapshot.Status)})
+		return
+	}
+	if !time.Now().Before(escrow.ExpiresAt) {
+		h.mu.Unlock()
+		c.JSON(http.StatusConflict, EscrowResponse{Success: false, Message: "Escrow has expired"})
+		return
+	}
+	if other, used := h.escrowPaymentIDs[payment.ID]; used && other != escrow.ID {
+		h.mu.Unlock()
+		c.JSON(http.StatusConflict, EscrowResponse{Success: false, Message: "Payment already funds another escrow"})
+		return
+	}
+	paymentID := payment.ID
+	h.escrowPaymentIDs[paymentID] = escrow.ID
+	escrow.PaymentID = &paymentID
+	escrow.Buyer = buyer
+	escrow.Status = EscrowFunded
+	escrow.UpdatedAt = time.Now().UTC()
+	h.mu.Unlock()
+
+	h.logger.Info("NFT escrow funded",
+		zap.String("escrow_id", escrow.ID),
+		zap.String("buyer", buyer),
+		zap.String("payment_id", paymentID),
+	)
+
+	// Settle now rather than at the next pass if the payment has completed
+	h.settleEscrow(ctx, escrow, time.Now())
+
+	h.mu.RLock()
+	snapshot = *escrow
+	h.mu.RUnlock()
+	c.JSON(http.StatusOK, EscrowResponse{Success: true, Escrow: &snapshot})
+}
+
+// CancelEscrow handles POST /api/v1/nft/escrows/:id/cancel
+// @Summary Cancel an escrow
+// @Description Unlocks the token of an escrow nobody has paid for yet
+// @Tags nft
+// @Accept json
+// @Produce json
+// @Param id path string true "Escrow ID"
+// @Param request body CancelEscrowRequest true "Seller"
+// @Success 200 {object} EscrowResponse
+// @Failure 403 {object} EscrowResponse
+// @Failure 404 {object} EscrowResponse
+// @Failure 409 {object} EscrowResponse
+// @Router /api/v1/nft/escrows/{id}/cancel [post]
+func (h *NFTHandler) CancelEscrow(c *gin.Context) {
+	var req CancelEscrowRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, EscrowResponse{Success: false, Message: "Invalid request: " + err.Error()})
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	escrow, exists := h.escrows[c.Param("id")]
+	switch {
+	case !exists:
+		c.JSON(http.StatusNotFound, EscrowResponse{Success: false, Message: "Escrow not found"})
+		return
+	case escrow.Seller != strings.ToLower(req.Seller):
+		c.JSON(http.StatusForbidden, EscrowResponse{Success: false, Message: "Only the seller can cancel an escrow"})
+		return
+	case escrow.Status != EscrowOpen:
+		snapshot := *escrow
+		c.JSON(http.StatusConflict, EscrowResponse{Success: false, Escrow: &snapshot, Message: "Escrow is " + string(escrow.Status)})
+		return
+	}
+
+	h.closeEscrow(escrow, EscrowCancelled, time.Now())
+	snapshot := *escrow
+	c.JSON(http.StatusOK, EscrowResponse{Success: true, Escrow: &snapshot})
+}
+
+// closeEscrow moves escrow to a final status and unlocks its token; h.mu
+// must be held
+func (h *NFTHandler) closeEscrow(escrow *Escrow, status EscrowStatus, now time.Time) {
+	if h.escrowLocks[escrow.TokenID] == escrow.ID {
+		delete(h.escrowLocks, escrow.TokenID)
+	}
+	settled := now.UTC()
+	escrow.Status = status
+	escrow.UpdatedAt = settled
+	if status != EscrowAwaitingRefund {
+		escrow.SettledAt = &settled
+	}
+	h.logger.Info("NFT escrow closed", zap.String("escrow_id", escrow.ID), zap.String("status", string(status)))
+}
+
+// RunEscrowSettlement settles escrows every interval until ctx is done
+func (h *NFTHandler) RunEscrowSettlement(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultEscrowSettleInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		h.SettleEscrows(ctx, time.Now())
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// SettleEscrows releases escrows whose payment completed, expires those
+// past their deadline and refunds payments that completed too late. It
+// returns how many escrows changed state.
+func (h *NFTHandler) SettleEscrows(ctx context.Context, now time.Time) int {
+	h.mu.RLock()
+	var due []*Escrow
+	for _, escrow := range h.escrows {
+		switch escrow.Status {
+		case EscrowOpen, EscrowFunded, EscrowAwaitingRefund:
+			due = append(due, escrow)
+		}
+	}
+	h.mu.RUnlock()
+
+	changed := 0
+	for _, escrow := range due {
+		if h.settleEscrow(ctx, escrow, now) {
+			changed++
+		}
+	}
+	return changed
+}
+
+// settleEscrow moves one escrow on from its payment's status and the time,
+// reporting whether its state changed. Settlements run one at a time, so an
+// escrow is never released twice.
+func (h *NFTHandler) settleEscrow(ctx context.Context, escrow *Escrow, now time.Time) bool {
+	h.escrowSettle.Lock()
+	defer h.escrowSettle.Unlock()
+
+	h.mu.Lock()
+	status, expired := escrow.Status, !now.Before(escrow.ExpiresAt)
+	if status == EscrowOpen && expired {
+		h.closeEscrow(escrow, EscrowExpired, now)
+	}
+	var paymentID string
+	if escrow.PaymentID != nil {
+		paymentID = *escrow.PaymentID
+	}
+	h.mu.Unlock()
+	if status != EscrowFunded && status != EscrowAwaitingRefund {
+		return status == EscrowOpen && expired
+	}
+
+	payment, err := h.escrowPayments.GetPayment(ctx, paymentID)
+	if err != nil {
+		h.logger.Warn("failed to get escrow payment", zap.String("escrow_id", escrow.ID), zap.Error(err))
+		return false
+	}
+
+	switch payment.Status {
+	case repository.PaymentStatusPending, repository.PaymentStatusProcessing:
+		if status == EscrowFunded && expired {
+			h.mu.Lock()
+			h.closeEscrow(escrow, EscrowAwaitingRefund, now)
+			h.mu.Unlock()
+			return true
+		}
+		return false
+
+	case repository.PaymentStatusCompleted:
+		if status == EscrowFunded && !expired {
+			return h.releaseEscrow(ctx, escrow, now)
+		}
+		// Too late (or the release kept failing): the seller keeps the token
+		reason := "NFT escrow " + escrow.ID + " expired before release"
+		if err := h.escrowPayments.UpdatePaymentStatus(ctx, payment.ID, repository.PaymentStatusRefunded, &repository.PaymentStatusUpdate{
+			ErrorMessage: &reason,
+		}); err != nil {
+			h.logger.Error("failed to refund escrow payment", zap.String("escrow_id", escrow.ID), zap.Error(err))
+			return false
+		}
+		h.mu.Lock()
+		h.closeEscrow(escrow, EscrowRefunded, now)
+		h.mu.Unlock()
+		return true
+
+	default:
+		// Failed, cancelled, expired or disputed: the buyer may pay again
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		delete(h.escrowPaymentIDs, payment.ID)
+		switch {
+		case status == EscrowAwaitingRefund || expired:
+			h.closeEscrow(escrow, EscrowExpired, now)
+		default:
+			escrow.Status = EscrowOpen
+			escrow.PaymentID = nil
+			escrow.LastError = "Payment " + string(payment.Status)
+			escrow.UpdatedAt = now.UTC()
+		}
+		return true
+	}
+}
+
+// releaseEscrow transfers a paid escrow's token to the buyer. A failed
+// on-chain transfer is retried by later settlements until the deadline.
+func (h *NFTHandler) releaseEscrow(ctx context.Context, escrow *Escrow, now time.Time) bool {
+	var txHash string
+	if h.escrowRelayer != nil {
+		tokenID, ok := new(big.Int).SetString(escrow.TokenID, 10)
+		if !ok {
+			tokenID = new(big.Int)
+		}
+		data := append(append(append(append([]byte{}, transferFromSelector...),
+			common.LeftPadBytes(common.HexToAddress(escrow.Seller).Bytes(), 32)...),
+			common.LeftPadBytes(common.HexToAddress(escrow.Buyer).Bytes(), 32)...),
+			common.LeftPadBytes(tokenID.Bytes(), 32)...)
+		_, hash, err := h.escrowRelayer.SendTransfer(ctx, h.escrowContract, new(big.Int), data)
+		if err != nil {
+			h.logger.Warn("failed to release NFT escrow", zap.String("escrow_id", escrow.ID), zap.Error(err))
+			h.mu.Lock()
+			escrow.LastError = "Release failed: " + err.Error()
+			escrow.UpdatedAt = now.UTC()
+			h.mu.Unlock()
+			return false
+		}
+		txHash = hash.Hex()
+	} else {
+		txHash = generateMockTxID()
+	}
+
+	h.mu.Lock()
+	token, exists := h.tokens[escrow.TokenID]
+	if exists {
+		transferred := now
+		token.Owner = escrow.Buyer
+		token.TransferredAt = &transferred
+		h.removeTokenFromOwner(escrow.Seller, escrow.TokenID)
+		h.ownership[escrow.Buyer] = append(h.ownership[escrow.Buyer], escrow.TokenID)
+		delete(h.approvals, escrow.TokenID)
+	}
+	escrow.TxHash = txHash
+	escrow.LastError = ""
+	h.closeEscrow(escrow, EscrowReleased, now)
+	symbol := h.symbol
+	h.mu.Unlock()
+
+	h.recordTransfer(ctx, escrow.Seller, "sent", escrow.TokenID, fmt.Sprintf("Sold %s #%s to %s", symbol, escrow.TokenID, escrow.Buyer))
+	h.recordTransfer(ctx, escrow.Buyer, "received", escrow.TokenID, fmt.Sprintf("Bought %s #%s from %s", symbol, escrow.TokenID, escrow.Seller))
+	return true
+}
@@ -0,0 +1,244 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/handlers"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/storage/memory"
+)
+
+// fakeRelayer records transfers, failing while err is set
+type fakeRelayer struct {
+	to   common.Address
+	data []byte
+	err  error
+}
+
+func (r *fakeRelayer) SendTransfer(_ context.Context, to common.Address, _ *big.Int, data []byte) (common.Address, common.Hash, error) {
+	if r.err != nil {
+		return common.Address{}, common.Hash{}, r.err
+	}
+	r.to, r.data = to, data
+	return common.Address{}, common.HexToHash("0xabc"), nil
+}
+
+type escrowFixture struct {
+	h        *handlers.NFTHandler
+	router   *gin.Engine
+	payments *memory.MemoryPaymentRepo
+	seller   string
+	buyer    string
+}
+
+func newEscrowFixture(t *testing.T) *escrowFixture {
+	f := &escrowFixture{
+		h:        handlers.NewNFTHandler(zap.NewNop()),
+		payments: memory.NewMemoryPaymentRepo(),
+		seller:   testAddress(0),
+		buyer:    testAddress(1),
+	}
+	pricing := memory.NewMemoryPricingRepo()
+	pricing.AddPricing(&repository.Pricing{ServiceCode: "nft_sale", PriceUSD: 50, IsActive: true})
+	f.h.SetEscrowPayments(f.payments, pricing)
+	f.h.Seed([]*handlers.NFTToken{{TokenID: "7", Owner: f.seller}})
+
+	gin.SetMode(gin.TestMode)
+	f.router = gin.New()
+	f.router.POST("/nft/transfer", f.h.Transfer)
+	f.router.POST("/nft/escrows", f.h.CreateEscrow)
+	f.router.GET("/nft/escrows/:id", f.h.GetEscrow)
+	f.router.POST("/nft/escrows/:id/payment", f.h.FundEscrow)
+	f.router.POST("/nft/escrows/:id/cancel", f.h.CancelEscrow)
+	f.router.GET("/nft/owner-of/:id", f.h.OwnerOf)
+	return f
+}
+
+// open locks token 7 for sale and returns the escrow
+func (f *escrowFixture) open(t *testing.T, body gin.H) *handlers.Escrow {
+	t.Helper()
+	if body == nil {
+		body = gin.H{}
+	}
+	body["seller"], body["token_id"], body["service_code"] = f.seller, "7", "nft_sale"
+	w := send(f.router, http.MethodPost, "/nft/escrows", "", body)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	return decodeEscrow(t, w.Body.Bytes())
+}
+
+// pay creates a payment by the buyer and attaches it to escrow
+func (f *escrowFixture) pay(t *testing.T, escrow *handlers.Escrow, status repository.PaymentStatus, amount float64) (*repository.Payment, int, *handlers.Escrow) {
+	t.Helper()
+	payment := &repository.Payment{ServiceCode: "nft_sale", PayerAddress: f.buyer, PaymentMethod: "stripe", AmountUSD: &amount, Status: status}
+	require.NoError(t, f.payments.CreatePayment(t.Context(), payment))
+	w := send(f.router, http.MethodPost, "/nft/escrows/"+escrow.ID+"/payment", "", gin.H{"buyer": f.buyer, "payment_id": payment.ID})
+	var resp handlers.EscrowResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	return payment, w.Code, resp.Escrow
+}
+
+func (f *escrowFixture) owner(t *testing.T) string {
+	t.Helper()
+	w := send(f.router, http.MethodGet, "/nft/owner-of/7", "", nil)
+	var resp struct {
+		Owner string `json:"owner"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	return resp.Owner
+}
+
+func decodeEscrow(t *testing.T, body []byte) *handlers.Escrow {
+	t.Helper()
+	var resp handlers.EscrowResponse
+	require.NoError(t, json.Unmarshal(body, &resp))
+	require.NotNil(t, resp.Escrow)
+	return resp.Escrow
+}
+
+func TestNFTHandler_EscrowReleasesOnPayment(t *testing.T) {
+	f := newEscrowFixture(t)
+	escrow := f.open(t, nil)
+	assert.Equal(t, handlers.EscrowOpen, escrow.Status)
+	assert.Equal(t, 50.0, escrow.PriceUSD)
+
+	// The token cannot move or be sold twice while locked
+	w := send(f.router, http.MethodPost, "/nft/transfer", "", gin.H{"from": f.seller, "to": f.buyer, "token_id": "7"})
+	assert.Equal(t, http.StatusConflict, w.Code)
+	w = send(f.router, http.MethodPost, "/nft/escrows", "", gin.H{"seller": f.seller, "token_id": "7", "service_code": "nft_sale"})
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	_, code, _ := f.pay(t, escrow, repository.PaymentStatusCompleted, 10)
+	assert.Equal(t, http.StatusBadRequest, code, "the payment must cover the price")
+
+	// A pending card payment funds the escrow; completion releases it
+	payment, code, funded := f.pay(t, escrow, repository.PaymentStatusPending, 51.5)
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, handlers.EscrowFunded, funded.Status)
+	w = send(f.router, http.MethodPost, "/nft/escrows/"+escrow.ID+"/cancel", "", gin.H{"seller": f.seller})
+	assert.Equal(t, http.StatusConflict, w.Code, "a funded escrow cannot be cancelled")
+
+	assert.Equal(t, 0, f.h.SettleEscrows(t.Context(), time.Now()))
+	require.NoError(t, f.payments.UpdatePaymentStatus(t.Context(), payment.ID, repository.PaymentStatusCompleted, nil))
+	assert.Equal(t, 1, f.h.SettleEscrows(t.Context(), time.Now()))
+
+	w = send(f.router, http.MethodGet, "/nft/escrows/"+escrow.ID, "", nil)
+	released := decodeEscrow(t, w.Body.Bytes())
+	assert.Equal(t, handlers.EscrowReleased, released.Status)
+	assert.NotEmpty(t, released.TxHash)
+	assert.NotNil(t, released.SettledAt)
+	assert.Equal(t, f.buyer, f.owner(t))
+
+	// Released tokens are free again
+	w = send(f.router, http.MethodPost, "/nft/transfer", "", gin.H{"from": f.buyer, "to": f.seller, "token_id": "7"})
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestNFTHandler_EscrowReservedBuyerAndCancel(t *testing.T) {
+	f := newEscrowFixture(t)
+	escrow := f.open(t, gin.H{"buyer": testAddress(5)})
+
+	_, code, _ := f.pay(t, escrow, repository.PaymentStatusCompleted, 50)
+	assert.Equal(t, http.StatusForbidden, code)
+
+	w := send(f.router, http.MethodPost, "/nft/escrows/"+escrow.ID+"/cancel", "", gin.H{"seller": f.buyer})
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	w = send(f.router, http.MethodPost, "/nft/escrows/"+escrow.ID+"/cancel", "", gin.H{"seller": f.seller})
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, handlers.EscrowCancelled, decodeEscrow(t, w.Body.Bytes()).Status)
+
+	w = send(f.router, http.MethodPost, "/nft/transfer", "", gin.H{"from": f.seller, "to": f.buyer, "token_id": "7"})
+	assert.Equal(t, http.StatusOK, w.Code, "cancelling unlocks the token")
+}
+
+func TestNFTHandler_EscrowTimeouts(t *testing.T) {
+	t.Run("unpaid escrow expires", func(t *testing.T) {
+		f := newEscrowFixture(t)
+		escrow := f.open(t, gin.H{"timeout_minutes": 30})
+		assert.Equal(t, 0, f.h.SettleEscrows(t.Context(), time.Now()))
+		assert.Equal(t, 1, f.h.SettleEscrows(t.Context(), time.Now().Add(31*time.Minute)))
+		w := send(f.router, http.MethodGet, "/nft/escrows/"+escrow.ID, "", nil)
+		assert.Equal(t, handlers.EscrowExpired, decodeEscrow(t, w.Body.Bytes()).Status)
+		_, code, _ := f.pay(t, escrow, repository.PaymentStatusCompleted, 50)
+		assert.Equal(t, http.StatusConflict, code)
+	})
+
+	t.Run("late payment is refunded", func(t *testing.T) {
+		f := newEscrowFixture(t)
+		escrow := f.open(t, gin.H{"timeout_minutes": 30})
+		payment, code, _ := f.pay(t, escrow, repository.PaymentStatusProcessing, 50)
+		require.Equal(t, http.StatusOK, code)
+
+		late := time.Now().Add(time.Hour)
+		assert.Equal(t, 1, f.h.SettleEscrows(t.Context(), late))
+		w := send(f.router, http.MethodGet, "/nft/escrows/"+escrow.ID, "", nil)
+		assert.Equal(t, handlers.EscrowAwaitingRefund, decodeEscrow(t, w.Body.Bytes()).Status)
+		w = send(f.router, http.MethodPost, "/nft/transfer", "", gin.H{"from": f.seller, "to": testAddress(6), "token_id": "7"})
+		require.Equal(t, http.StatusOK, w.Code, "the seller has the token back")
+
+		require.NoError(t, f.payments.UpdatePaymentStatus(t.Context(), payment.ID, repository.PaymentStatusCompleted, nil))
+		assert.Equal(t, 1, f.h.SettleEscrows(t.Context(), late))
+		w = send(f.router, http.MethodGet, "/nft/escrows/"+escrow.ID, "", nil)
+		assert.Equal(t, handlers.EscrowRefunded, decodeEscrow(t, w.Body.Bytes()).Status)
+		refunded, err := f.payments.GetPayment(t.Context(), payment.ID)
+		require.NoError(t, err)
+		assert.Equal(t, repository.PaymentStatusRefunded, refunded.Status)
+		assert.Equal(t, testAddress(6), f.owner(t))
+	})
+
+	t.Run("failed payment reopens the escrow", func(t *testing.T) {
+		f := newEscrowFixture(t)
+		escrow := f.open(t, nil)
+		payment, _, _ := f.pay(t, escrow, repository.PaymentStatusPending, 50)
+		require.NoError(t, f.payments.UpdatePaymentStatus(t.Context(), payment.ID, repository.PaymentStatusFailed, nil))
+		assert.Equal(t, 1, f.h.SettleEscrows(t.Context(), time.Now()))
+		w := send(f.router, http.MethodGet, "/nft/escrows/"+escrow.ID, "", nil)
+		reopened := decodeEscrow(t, w.Body.Bytes())
+		assert.Equal(t, handlers.EscrowOpen, reopened.Status)
+		assert.Nil(t, reopened.PaymentID)
+
+		_, code, paid := f.pay(t, escrow, repository.PaymentStatusCompleted, 50)
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, handlers.EscrowReleased, paid.Status, "a completed payment releases at once")
+	})
+}
+
+func TestNFTHandler_EscrowReleasesThroughRelayer(t *testing.T) {
+	f := newEscrowFixture(t)
+	relayer := &fakeRelayer{err: errors.New("no funded relayer key")}
+	contract := common.HexToAddress("0x00000000000000000000000000000000000000cc")
+	f.h.SetEscrowRelayer(relayer, contract)
+
+	escrow := f.open(t, nil)
+	_, code, funded := f.pay(t, escrow, repository.PaymentStatusCompleted, 50)
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, handlers.EscrowFunded, funded.Status)
+	assert.Contains(t, funded.LastError, "no funded relayer key")
+	assert.Equal(t, f.seller, f.owner(t))
+
+	// The next settlement retries the release
+	relayer.err = nil
+	assert.Equal(t, 1, f.h.SettleEscrows(t.Context(), time.Now()))
+	assert.Equal(t, contract, relayer.to)
+	require.Len(t, relayer.data, 4+3*32)
+	assert.Equal(t, []byte{0x23, 0xb8, 0x72, 0xdd}, relayer.data[:4])
+	assert.Equal(t, common.HexToAddress(f.seller), common.BytesToAddress(relayer.data[4:36]))
+	assert.Equal(t, common.HexToAddress(f.buyer), common.BytesToAddress(relayer.data[36:68]))
+	assert.Equal(t, int64(7), new(big.Int).SetBytes(relayer.data[68:]).Int64())
+
+	w := send(f.router, http.MethodGet, "/nft/escrows/"+escrow.ID, "", nil)
+	released := decodeEscrow(t, w.Body.Bytes())
+	assert.Equal(t, common.HexToHash("0xabc").Hex(), released.TxHash)
+	assert.Equal(t, f.buyer, f.owner(t))
+}
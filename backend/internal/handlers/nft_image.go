@@ -0,0 +1,335 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/objectstore"
+)
+
+// Token images are rendered on request: deterministic placeholder art from
+// the token's ID and traits, with a stored PNG layer drawn over it for each
+// trait value that has one. Renders are cached in the image store under a
+// key derived from the attributes, so an attribute change renders afresh;
+// after replacing layers, delete nft/images/ to re-render.
+
+const (
+	// nftImageSize is the width and height of rendered images and layers
+	nftImageSize = 512
+
+	// nftRenderVersion changes the cache key whenever the art changes
+	nftRenderVersion = "1"
+
+	// maxNFTLayerSize caps an uploaded trait layer
+	maxNFTLayerSize = 2 << 20
+)
+
+// Image store key prefixes
+const (
+	nftLayerPrefix = objectstore.PrefixNFT + "layers/"
+	nftImagePrefix = objectstore.PrefixNFT + "images/"
+)
+
+// ImageStore holds trait layers and rendered token images (implemented by
+// the objectstore backends)
+type ImageStore interface {
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+}
+
+// SetImageStore enables trait layers and caches rendered images. Without
+// one, images are placeholder art rendered on every request.
+func (h *NFTHandler) SetImageStore(store ImageStore) {
+	h.images = store
+}
+
+// SetImageBaseURI sets the image URL prefix of newly minted tokens, i.e.
+// where GetTokenImage is served; a token's image is <base><id>.png
+func (h *NFTHandler) SetImageBaseURI(base string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.imageBaseURI = base
+}
+
+// slugPattern matches the runs of characters not kept in layer keys
+var slugPattern = regexp.MustCompile(`[^a-z0-9]+`)
+
+// slug reduces a trait type or value to a key segment
+func slug(s string) string {
+	return strings.Trim(slugPattern.ReplaceAllString(strings.ToLower(s), "-"), "-")
+}
+
+// nftLayerKey returns the key of the layer drawn for a trait value
+func nftLayerKey(traitType, value string) string {
+	return nftLayerPrefix + slug(traitType) + "/" + slug(value) + ".png"
+}
+
+// nftImageKey returns the cache key of a token's render
+func nftImageKey(tokenID string, attributes []NFTAttribute) string {
+	data, _ := json.Marshal(attributes)
+	sum := sha256.Sum256(append([]byte(nftRenderVersion+"\x00"+tokenID+"\x00"), data...))
+	return hex.EncodeToString(sum[:16])
+}
+
+// GetTokenImage handles GET /api/v1/nft/images/:file
+// @Summary Get token image
+// @Description Returns the PNG image of a token (file is <token id>.png), or the unrevealed image before reveal
+// @Tags nft
+// @Produce png
+// @Param file path string true "Token image file, e.g. 42.png"
+// @Success 200 {file} file
+// @Failure 404 {object} map[string]interface{}
+// @Router /api/v1/nft/images/{file} [get]
+func (h *NFTHandler) GetTokenImage(c *gin.Context) {
+	tokenID, ok := strings.CutSuffix(c.Param("file"), ".png")
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Token not found"})
+		return
+	}
+
+	h.mu.RLock()
+	token, exists := h.tokens[tokenID]
+	var attributes []NFTAttribute
+	if exists {
+		attributes = token.Attributes
+	}
+	revealed := h.revealed
+	h.mu.RUnlock()
+
+	if !exists {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Token not found"})
+		return
+	}
+
+	// Every token shares one image until the reveal
+	if !revealed {
+		tokenID, attributes = "unrevealed", nil
+	}
+
+	key := nftImageKey(tokenID, attributes)
+	data, err := h.tokenImage(c.Request.Context(), key, tokenID, attributes)
+	if err != nil {
+		h.logger.Error("failed to render NFT image", zap.String("token_id", tokenID), zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to render image"})
+		return
+	}
+
+	c.Header("Cache-Control", "public, max-age=3600")
+	c.Header("ETag", `"`+key+`"`)
+	c.Data(http.StatusOK, "image/png", data)
+}
+
+// tokenImage returns the cached render under key, rendering and caching it
+// if there is none
+func (h *NFTHandler) tokenImage(ctx context.Context, key, tokenID string, attributes []NFTAttribute) ([]byte, error) {
+	if h.images != nil {
+		body, err := h.images.Get(ctx, nftImagePrefix+key+".png")
+		if err == nil {
+			defer body.Close()
+			return io.ReadAll(body)
+		}
+		if !errors.Is(err, objectstore.ErrNotFound) {
+			h.logger.Warn("failed to read cached NFT image", zap.String("key", key), zap.Error(err))
+		}
+	}
+
+	canvas := placeholderArt(tokenID, attributes)
+	if err := h.drawLayers(ctx, canvas, attributes); err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, canvas); err != nil {
+		return nil, err
+	}
+
+	if h.images != nil {
+		if err := h.images.Put(ctx, nftImagePrefix+key+".png", bytes.NewReader(buf.Bytes()), int64(buf.Len()), "image/png"); err != nil {
+			h.logger.Warn("failed to cache NFT image", zap.String("key", key), zap.Error(err))
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// drawLayers draws the stored layer of each trait value, in attribute
+// order, over canvas. Values without a layer are skipped.
+func (h *NFTHandler) drawLayers(ctx context.Context, canvas draw.Image, attributes []NFTAttribute) error {
+	if h.images == nil {
+		return nil
+	}
+	for _, attr := range attributes {
+		if attr.DisplayType != "" {
+			continue
+		}
+		key := nftLayerKey(attr.TraitType, fmt.Sprint(attr.Value))
+		body, err := h.images.Get(ctx, key)
+		if errors.Is(err, objectstore.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("reading layer %s: %w", key, err)
+		}
+		layer, err := png.Decode(body)
+		body.Close()
+		if err != nil {
+			// Uploads are checked, so this is a layer replaced out of band
+			h.logger.Warn("skipping undecodable NFT layer", zap.String("key", key), zap.Error(err))
+			continue
+		}
+		draw.Draw(canvas, canvas.Bounds(), layer, image.Point{}, draw.Over)
+	}
+	return nil
+}
+
+// elementColors are the background gradients of the Element trait
+var elementColors = map[string][2]color.RGBA{
+	"Fire":      {{0xb7, 0x1c, 0x1c, 0xff}, {0xff, 0x98, 0x00, 0xff}},
+	"Water":     {{0x0d, 0x47, 0xa1, 0xff}, {0x4f, 0xc3, 0xf7, 0xff}},
+	"Earth":     {{0x3e, 0x27, 0x23, 0xff}, {0x8b, 0xc3, 0x4a, 0xff}},
+	"Air":       {{0x90, 0xa4, 0xae, 0xff}, {0xec, 0xef, 0xf1, 0xff}},
+	"Lightning": {{0x31, 0x1b, 0x92, 0xff}, {0xff, 0xeb, 0x3b, 0xff}},
+}
+
+// rarityColors are the frame colours of the Rarity trait
+var rarityColors = map[string]color.RGBA{
+	"Common":    {0x9e, 0x9e, 0x9e, 0xff},
+	"Uncommon":  {0x43, 0xa0, 0x47, 0xff},
+	"Rare":      {0x1e, 0x88, 0xe5, 0xff},
+	"Epic":      {0x8e, 0x24, 0xaa, 0xff},
+	"Legendary": {0xff, 0xc1, 0x07, 0xff},
+}
+
+// placeholderArt draws a token's deterministic art: a gradient chosen by
+// Element (or the ID's hash), a frame coloured by Rarity and a mirrored
+// 5x5 emblem from the hash
+func placeholderArt(tokenID string, attributes []NFTAttribute) *image.RGBA {
+	sum := sha256.Sum256([]byte(tokenID))
+	top := color.RGBA{sum[0] / 2, sum[1] / 2, sum[2] / 2, 0xff}
+	bottom := color.RGBA{128 + sum[3]/2, 128 + sum[4]/2, 128 + sum[5]/2, 0xff}
+	frame := color.RGBA{0x21, 0x21, 0x21, 0xff}
+	for _, attr := range attributes {
+		switch attr.TraitType {
+		case "Element":
+			if colors, ok := elementColors[fmt.Sprint(attr.Value)]; ok {
+				top, bottom = colors[0], colors[1]
+			}
+		case "Rarity":
+			if c, ok := rarityColors[fmt.Sprint(attr.Value)]; ok {
+				frame = c
+			}
+		}
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, nftImageSize, nftImageSize))
+	for y := 0; y < nftImageSize; y++ {
+		row := &image.Uniform{C: blend(top, bottom, y, nftImageSize-1)}
+		draw.Draw(img, image.Rect(0, y, nftImageSize, y+1), row, image.Point{}, draw.Src)
+	}
+
+	const border = 16
+	frameColor := &image.Uniform{C: frame}
+	for _, r := range []image.Rectangle{
+		image.Rect(0, 0, nftImageSize, border),
+		image.Rect(0, nftImageSize-border, nftImageSize, nftImageSize),
+		image.Rect(0, 0, border, nftImageSize),
+		image.Rect(nftImageSize-border, 0, nftImageSize, nftImageSize),
+	} {
+		draw.Draw(img, r, frameColor, image.Point{}, draw.Src)
+	}
+
+	// Cells of the left three columns are set by hash bits and mirrored
+	const cells, cell = 5, 64
+	origin := (nftImageSize - cells*cell) / 2
+	emblem := &image.Uniform{C: color.RGBA{0xff - sum[6]/4, 0xff - sum[7]/4, 0xff - sum[8]/4, 0xe6}}
+	for row := 0; row < cells; row++ {
+		for col := 0; col < 3; col++ {
+			bit := row*3 + col
+			if sum[9+bit/8]&(1<<(bit%8)) == 0 {
+				continue
+			}
+			for _, x := range []int{col, cells - 1 - col} {
+				r := image.Rect(origin+x*cell, origin+row*cell, origin+(x+1)*cell, origin+(row+1)*cell)
+				draw.Draw(img, r, emblem, image.Point{}, draw.Over)
+			}
+		}
+	}
+	return img
+}
+
+// blend returns the colour i/n of the way from a to b
+func blend(a, b color.RGBA, i, n int) color.RGBA {
+	mix := func(x, y uint8) uint8 {
+		return uint8((int(x)*(n-i) + int(y)*i) / n)
+	}
+	return color.RGBA{mix(a.R, b.R), mix(a.G, b.G), mix(a.B, b.B), 0xff}
+}
+
+// UploadTraitLayer handles PUT /api/v1/admin/nft/layers/:trait/:value
+// @Summary Upload a trait layer
+// @Description Stores the PNG drawn over token images for one trait value. Layers are 512x512 with transparency; body is the PNG itself.
+// @Tags nft
+// @Accept png
+// @Produce json
+// @Param trait path string true "Trait type, e.g. Element"
+// @Param value path string true "Trait value, e.g. Fire"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 413 {object} map[string]interface{}
+// @Failure 503 {object} map[string]interface{}
+// @Router /api/v1/admin/nft/layers/{trait}/{value} [put]
+func (h *NFTHandler) UploadTraitLayer(c *gin.Context) {
+	if h.images == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"success": false, "message": "Image storage is not configured"})
+		return
+	}
+
+	trait, value := c.Param("trait"), c.Param("value")
+	if slug(trait) == "" || slug(value) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": "Trait type and value are required"})
+		return
+	}
+
+	data, err := io.ReadAll(io.LimitReader(c.Request.Body, maxNFTLayerSize+1))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": "Failed to read layer"})
+		return
+	}
+	if len(data) > maxNFTLayerSize {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"success": false, "message": "Layer exceeds 2 MiB"})
+		return
+	}
+	cfg, err := png.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": "Layer must be a PNG"})
+		return
+	}
+	if cfg.Width != nftImageSize || cfg.Height != nftImageSize {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": fmt.Sprintf("Layer must be %dx%d", nftImageSize, nftImageSize)})
+		return
+	}
+
+	key := nftLayerKey(trait, value)
+	if err := h.images.Put(c.Request.Context(), key, bytes.NewReader(data), int64(len(data)), "image/png"); err != nil {
+		h.logger.Error("failed to store NFT layer", zap.String("key", key), zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": "Failed to store layer"})
+		return
+	}
+
+	h.logger.Info("NFT layer uploaded", zap.String("key", key), zap.Int("size", len(data)))
+	c.JSON(http.StatusOK, gin.H{"success": true, "key": key})
+}
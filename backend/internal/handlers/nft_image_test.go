@@ -0,0 +1,123 @@
+package handlers_test
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/handlers"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/objectstore"
+)
+
+// countingStore counts reads of an in-memory store
+type countingStore struct {
+	*objectstore.Memory
+	gets int
+}
+
+func (s *countingStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	s.gets++
+	return s.Memory.Get(ctx, key)
+}
+
+func TestNFTHandler_TokenImage(t *testing.T) {
+	h := handlers.NewNFTHandler(zap.NewNop())
+	h.Seed([]*handlers.NFTToken{
+		{TokenID: "1", Owner: testAddress(0), Attributes: traits("Legendary", "Fire")},
+		{TokenID: "2", Owner: testAddress(0), Attributes: traits("Common", "Water")},
+	})
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/nft/images/:file", h.GetTokenImage)
+	router.PUT("/admin/nft/layers/:trait/:value", h.UploadTraitLayer)
+	router.PUT("/admin/nft/token/:id/attributes", h.UpdateTokenAttributes)
+
+	get := func(file string) *httptest.ResponseRecorder {
+		return send(router, http.MethodGet, "/nft/images/"+file, "", nil)
+	}
+	decode := func(w *httptest.ResponseRecorder) image.Image {
+		t.Helper()
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Equal(t, "image/png", w.Header().Get("Content-Type"))
+		img, err := png.Decode(w.Body)
+		require.NoError(t, err)
+		return img
+	}
+
+	// Placeholder art without a store is deterministic and per token
+	first := get("1.png").Body.Bytes()
+	assert.Equal(t, first, get("1.png").Body.Bytes())
+	assert.NotEqual(t, first, get("2.png").Body.Bytes())
+	img := decode(get("1.png"))
+	assert.Equal(t, image.Rect(0, 0, 512, 512), img.Bounds())
+	assert.Equal(t, http.StatusNotFound, get("3.png").Code)
+	assert.Equal(t, http.StatusNotFound, get("1.jpg").Code)
+
+	w := send(router, http.MethodPut, "/admin/nft/layers/Element/Fire", "", nil)
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+
+	store := &countingStore{Memory: objectstore.NewMemory()}
+	h.SetImageStore(store)
+
+	// A layer for Element=Fire is drawn over token 1 only
+	layer := image.NewRGBA(image.Rect(0, 0, 512, 512))
+	red := color.RGBA{0xff, 0, 0, 0xff}
+	for x := 200; x < 312; x++ {
+		layer.Set(x, 256, red)
+	}
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, layer))
+	req := httptest.NewRequest(http.MethodPut, "/admin/nft/layers/Element/Fire", bytes.NewReader(buf.Bytes()))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	img = decode(get("1.png"))
+	assert.Equal(t, red, color.RGBAModel.Convert(img.At(256, 256)))
+	img = decode(get("2.png"))
+	assert.NotEqual(t, red, color.RGBAModel.Convert(img.At(256, 256)))
+
+	// Renders are cached: a second request reads one object
+	store.gets = 0
+	cached := get("1.png")
+	require.Equal(t, http.StatusOK, cached.Code)
+	assert.Equal(t, 1, store.gets)
+	assert.NotEmpty(t, cached.Header().Get("ETag"))
+
+	// An attribute change renders afresh
+	w = send(router, http.MethodPut, "/admin/nft/token/1/attributes", "", map[string]interface{}{
+		"attributes": traits("Legendary", "Water"),
+		"operator":   operator,
+	})
+	require.Equal(t, http.StatusOK, w.Code)
+	changed := get("1.png")
+	assert.NotEqual(t, cached.Header().Get("ETag"), changed.Header().Get("ETag"))
+	img = decode(changed)
+	assert.NotEqual(t, red, color.RGBAModel.Convert(img.At(256, 256)))
+
+	for _, body := range [][]byte{[]byte("not a png"), encodePNG(t, 64)} {
+		req = httptest.NewRequest(http.MethodPut, "/admin/nft/layers/Element/Water", bytes.NewReader(body))
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	}
+}
+
+// encodePNG returns a blank size x size PNG
+func encodePNG(t *testing.T, size int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, size, size))))
+	return buf.Bytes()
+}
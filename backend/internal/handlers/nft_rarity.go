@@ -0,0 +1,244 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// Rarity uses the usual marketplace score: each trait value a token has
+// adds supply / (tokens with that value), so rare values count for more.
+// Traits with a display_type (numbers, boosts, dates) are ranges rather
+// than categories and are not scored.
+
+// TraitValueStats counts the tokens with one value of a trait
+type TraitValueStats struct {
+	Value     string  `json:"value"`
+	Count     int     `json:"count"`
+	Frequency float64 `json:"frequency"`
+}
+
+// TraitStats lists a trait's values, rarest first
+type TraitStats struct {
+	TraitType string            `json:"trait_type"`
+	Values    []TraitValueStats `json:"values"`
+}
+
+// TokenRarity is a token's rarity score and rank (1 is the rarest; tokens
+// with equal scores share a rank)
+type TokenRarity struct {
+	TokenID string  `json:"token_id"`
+	Score   float64 `json:"score"`
+	Rank    int     `json:"rank"`
+}
+
+// CollectionStats is the trait frequency and rarity ranking of the
+// collection's current tokens
+type CollectionStats struct {
+	TotalSupply int           `json:"total_supply"`
+	Traits      []TraitStats  `json:"traits"`
+	Tokens      []TokenRarity `json:"tokens"`
+	ComputedAt  time.Time     `json:"computed_at"`
+}
+
+// CollectionStatsResponse represents a page of collection statistics
+type CollectionStatsResponse struct {
+	Success     bool          `json:"success"`
+	TotalSupply int           `json:"total_supply"`
+	Traits      []TraitStats  `json:"traits"`
+	Tokens      []TokenRarity `json:"tokens"`
+	ComputedAt  time.Time     `json:"computed_at"`
+	Page        int           `json:"page"`
+	PageSize    int           `json:"page_size"`
+}
+
+// UpdateAttributesRequest replaces a token's attributes, e.g. on reveal
+type UpdateAttributesRequest struct {
+	Attributes []NFTAttribute `json:"attributes" binding:"required"`
+	Operator   string         `json:"operator" binding:"required"`
+}
+
+// invalidateStats drops the cached collection statistics after the token
+// set or a token's attributes change; h.mu must be held
+func (h *NFTHandler) invalidateStats() {
+	h.stats = nil
+}
+
+// collectionStats returns the cached statistics, computing them if a mint,
+// burn or attribute change invalidated them
+func (h *NFTHandler) collectionStats() *CollectionStats {
+	h.mu.RLock()
+	stats := h.stats
+	h.mu.RUnlock()
+	if stats != nil {
+		return stats
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.stats == nil {
+		h.stats = computeCollectionStats(h.tokens)
+	}
+	return h.stats
+}
+
+// computeCollectionStats builds trait frequencies and rarity ranks for tokens
+func computeCollectionStats(tokens map[string]*NFTToken) *CollectionStats {
+	counts := make(map[string]map[string]int)
+	for _, token := range tokens {
+		for _, attr := range token.Attributes {
+			if attr.DisplayType != "" {
+				continue
+			}
+			if counts[attr.TraitType] == nil {
+				counts[attr.TraitType] = make(map[string]int)
+			}
+			counts[attr.TraitType][fmt.Sprint(attr.Value)]++
+		}
+	}
+
+	total := len(tokens)
+	stats := &CollectionStats{
+		TotalSupply: total,
+		Traits:      make([]TraitStats, 0, len(counts)),
+		Tokens:      make([]TokenRarity, 0, total),
+		ComputedAt:  time.Now().UTC(),
+	}
+	for traitType, values := range counts {
+		trait := TraitStats{TraitType: traitType, Values: make([]TraitValueStats, 0, len(values))}
+		for value, count := range values {
+			trait.Values = append(trait.Values, TraitValueStats{
+				Value:     value,
+				Count:     count,
+				Frequency: float64(count) / float64(total),
+			})
+		}
+		sort.Slice(trait.Values, func(i, j int) bool {
+			if trait.Values[i].Count != trait.Values[j].Count {
+				return trait.Values[i].Count < trait.Values[j].Count
+			}
+			return trait.Values[i].Value < trait.Values[j].Value
+		})
+		stats.Traits = append(stats.Traits, trait)
+	}
+	sort.Slice(stats.Traits, func(i, j int) bool {
+		return stats.Traits[i].TraitType < stats.Traits[j].TraitType
+	})
+
+	for _, token := range tokens {
+		var score float64
+		for _, attr := range token.Attributes {
+			if attr.DisplayType == "" {
+				score += float64(total) / float64(counts[attr.TraitType][fmt.Sprint(attr.Value)])
+			}
+		}
+		stats.Tokens = append(stats.Tokens, TokenRarity{TokenID: token.TokenID, Score: score})
+	}
+	sort.Slice(stats.Tokens, func(i, j int) bool {
+		a, b := stats.Tokens[i], stats.Tokens[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		// Numeric IDs in numeric order
+		if len(a.TokenID) != len(b.TokenID) {
+			return len(a.TokenID) < len(b.TokenID)
+		}
+		return a.TokenID < b.TokenID
+	})
+	for i := range stats.Tokens {
+		if i > 0 && stats.Tokens[i].Score == stats.Tokens[i-1].Score {
+			stats.Tokens[i].Rank = stats.Tokens[i-1].Rank
+		} else {
+			stats.Tokens[i].Rank = i + 1
+		}
+	}
+	return stats
+}
+
+// GetCollectionStats handles GET /api/v1/nft/collection/stats
+// @Summary Get collection statistics
+// @Description Returns trait frequencies and a page of tokens ranked by rarity score. Statistics are cached and recomputed after mints, burns and attribute changes.
+// @Tags nft
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Tokens per page (max 100)" default(20)
+// @Success 200 {object} CollectionStatsResponse
+// @Router /api/v1/nft/collection/stats [get]
+func (h *NFTHandler) GetCollectionStats(c *gin.Context) {
+	page, pageSize, ok := queryPage(c, 20)
+	if !ok {
+		return
+	}
+
+	stats := h.collectionStats()
+
+	// Paginate
+	start := min((page-1)*pageSize, len(stats.Tokens))
+	end := min(start+pageSize, len(stats.Tokens))
+
+	c.JSON(http.StatusOK, CollectionStatsResponse{
+		Success:     true,
+		TotalSupply: stats.TotalSupply,
+		Traits:      stats.Traits,
+		Tokens:      stats.Tokens[start:end],
+		ComputedAt:  stats.ComputedAt,
+		Page:        page,
+		PageSize:    pageSize,
+	})
+}
+
+// UpdateTokenAttributes handles PUT /api/v1/admin/nft/token/:id/attributes
+// @Summary Update token attributes
+// @Description Replaces a token's attributes, e.g. when the collection is revealed, and invalidates the collection statistics
+// @Tags nft
+// @Accept json
+// @Produce json
+// @Param id path string true "Token ID"
+// @Param request body UpdateAttributesRequest true "New attributes"
+// @Success 200 {object} TokenResponse
+// @Failure 400 {object} TokenResponse
+// @Failure 404 {object} TokenResponse
+// @Router /api/v1/admin/nft/token/{id}/attributes [put]
+func (h *NFTHandler) UpdateTokenAttributes(c *gin.Context) {
+	var req UpdateAttributesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, TokenResponse{Success: false, Message: "Invalid request: " + err.Error()})
+		return
+	}
+	if !isValidAddress(req.Operator) {
+		c.JSON(http.StatusBadRequest, TokenResponse{Success: false, Message: "Invalid operator address format"})
+		return
+	}
+	for _, attr := range req.Attributes {
+		if strings.TrimSpace(attr.TraitType) == "" || attr.Value == nil {
+			c.JSON(http.StatusBadRequest, TokenResponse{Success: false, Message: "Every attribute needs a trait_type and a value"})
+			return
+		}
+	}
+
+	tokenID := c.Param("id")
+
+	h.mu.Lock()
+	token, exists := h.tokens[tokenID]
+	if !exists {
+		h.mu.Unlock()
+		c.JSON(http.StatusNotFound, TokenResponse{Success: false, Message: "Token not found"})
+		return
+	}
+	token.Attributes = req.Attributes
+	h.invalidateStats()
+	snapshot := *token
+	h.mu.Unlock()
+
+	h.logger.Info("NFT attributes updated",
+		zap.String("token_id", tokenID),
+		zap.String("operator", strings.ToLower(req.Operator)),
+	)
+
+	c.JSON(http.StatusOK, TokenResponse{Success: true, Token: &snapshot})
+}
@@ -0,0 +1,86 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/handlers"
+)
+
+// traits builds the scored attributes of a test token
+func traits(rarity, element string) []handlers.NFTAttribute {
+	return []handlers.NFTAttribute{
+		{TraitType: "Rarity", Value: rarity},
+		{TraitType: "Element", Value: element},
+		{TraitType: "Power Level", Value: 42, DisplayType: "number"},
+	}
+}
+
+func TestNFTHandler_CollectionStats(t *testing.T) {
+	owner := testAddress(0)
+	h := handlers.NewNFTHandler(zap.NewNop())
+	h.Seed([]*handlers.NFTToken{
+		{TokenID: "1", Owner: owner, Attributes: traits("Common", "Fire")},
+		{TokenID: "2", Owner: owner, Attributes: traits("Common", "Fire")},
+		{TokenID: "3", Owner: owner, Attributes: traits("Common", "Water")},
+		{TokenID: "4", Owner: owner, Attributes: traits("Legendary", "Fire")},
+	})
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/nft/collection/stats", h.GetCollectionStats)
+	router.PUT("/admin/nft/token/:id/attributes", h.UpdateTokenAttributes)
+	router.POST("/nft/mint", h.Mint)
+
+	stats := func(query string) handlers.CollectionStatsResponse {
+		t.Helper()
+		w := send(router, http.MethodGet, "/nft/collection/stats"+query, "", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		var resp handlers.CollectionStatsResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		return resp
+	}
+
+	resp := stats("")
+	assert.Equal(t, 4, resp.TotalSupply)
+	require.Len(t, resp.Traits, 2, "numeric traits are not scored")
+	assert.Equal(t, "Element", resp.Traits[0].TraitType)
+	assert.Equal(t, handlers.TraitValueStats{Value: "Water", Count: 1, Frequency: 0.25}, resp.Traits[0].Values[0])
+	assert.Equal(t, "Legendary", resp.Traits[1].Values[0].Value)
+
+	// Legendary: 4/1 + 4/3; Water: 4/3 + 4/1; the rest 4/3 + 4/3
+	require.Len(t, resp.Tokens, 4)
+	assert.Equal(t, handlers.TokenRarity{TokenID: "3", Score: 4.0/3 + 4, Rank: 1}, resp.Tokens[0])
+	assert.Equal(t, handlers.TokenRarity{TokenID: "4", Score: 4 + 4.0/3, Rank: 1}, resp.Tokens[1])
+	assert.Equal(t, 3, resp.Tokens[2].Rank)
+	assert.Equal(t, 3, resp.Tokens[3].Rank)
+
+	page := stats("?page=2&page_size=3")
+	assert.Len(t, page.Tokens, 1)
+	assert.Equal(t, resp.ComputedAt, page.ComputedAt, "statistics are cached")
+
+	// Changing attributes recomputes the ranking
+	w := send(router, http.MethodPut, "/admin/nft/token/1/attributes", "", gin.H{
+		"attributes": traits("Mythic", "Void"),
+		"operator":   operator,
+	})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	resp = stats("")
+	assert.Equal(t, "1", resp.Tokens[0].TokenID)
+	assert.Equal(t, 8.0, resp.Tokens[0].Score)
+
+	w = send(router, http.MethodPut, "/admin/nft/token/99/attributes", "", gin.H{"attributes": []gin.H{}, "operator": operator})
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	w = send(router, http.MethodPut, "/admin/nft/token/1/attributes", "", gin.H{"attributes": []gin.H{{"trait_type": "Rarity"}}, "operator": operator})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// So does minting
+	w = send(router, http.MethodPost, "/nft/mint", "", gin.H{"to": owner, "quantity": 1})
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, 5, stats("").TotalSupply)
+}
@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/oracle"
+)
+
+// OracleHandler serves Chainlink price feed reads
+type OracleHandler struct {
+	service *oracle.Service
+	logger  *zap.Logger
+}
+
+// NewOracleHandler creates a new oracle handler with injected dependencies
+func NewOracleHandler(service *oracle.Service, logger *zap.Logger) *OracleHandler {
+	return &OracleHandler{service: service, logger: logger}
+}
+
+// OracleResponse wraps oracle API responses
+type OracleResponse struct {
+	Success bool        `json:"success"`
+	Data    interface{} `json:"data,omitempty"`
+	Error   string      `json:"error,omitempty"`
+}
+
+// GetPrice handles GET /api/v1/oracle/price/:pair
+// @Summary Get an oracle price
+// @Description Returns the latest Chainlink round for a price pair. Rounds are cached briefly and rejected once stale.
+// @Tags oracle
+// @Produce json
+// @Param pair path string true "Price pair (ETH-USD or NEXUS-USD)"
+// @Success 200 {object} OracleResponse
+// @Failure 400 {object} OracleResponse
+// @Failure 404 {object} OracleResponse
+// @Failure 503 {object} OracleResponse
+// @Router /api/v1/oracle/price/{pair} [get]
+func (h *OracleHandler) GetPrice(c *gin.Context) {
+	pair, err := oracle.ParsePair(c.Param("pair"))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	price, err := h.service.Price(c.Request.Context(), pair)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, OracleResponse{
+		Success: true,
+		Data:    price,
+	})
+}
+
+// respondError maps oracle errors to responses. Feed read failures are
+// reported without the RPC error behind them.
+func (h *OracleHandler) respondError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, oracle.ErrUnknownPair):
+		c.JSON(http.StatusBadRequest, OracleResponse{Success: false, Error: oracle.ErrUnknownPair.Error()})
+		return
+	case errors.Is(err, oracle.ErrNoFeed):
+		c.JSON(http.StatusNotFound, OracleResponse{Success: false, Error: oracle.ErrNoFeed.Error()})
+		return
+	}
+
+	for _, unavailable := range []error{oracle.ErrStalePrice, oracle.ErrInvalidPrice, oracle.ErrUnavailable} {
+		if errors.Is(err, unavailable) {
+			h.logger.Warn("price unavailable", zap.String("pair", c.Param("pair")), zap.Error(err))
+			c.JSON(http.StatusServiceUnavailable, OracleResponse{Success: false, Error: unavailable.Error()})
+			return
+		}
+	}
+
+	h.logger.Error("oracle request failed", zap.Error(err))
+	c.JSON(http.StatusInternalServerError, OracleResponse{
+		Success: false,
+		Error:   "Internal server error",
+	})
+}
@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/overview"
+)
+
+// OverviewHandler serves users' dashboard overviews
+type OverviewHandler struct {
+	service *overview.Service
+	logger  *zap.Logger
+}
+
+// NewOverviewHandler creates a new overview handler with injected dependencies
+func NewOverviewHandler(service *overview.Service, logger *zap.Logger) *OverviewHandler {
+	return &OverviewHandler{service: service, logger: logger}
+}
+
+// OverviewResponse wraps overview API responses
+type OverviewResponse struct {
+	Success bool        `json:"success"`
+	Data    interface{} `json:"data,omitempty"`
+	Error   string      `json:"error,omitempty"`
+}
+
+// GetOverview handles GET /api/v1/users/:address/overview
+// @Summary Get a user's dashboard overview
+// @Description Composes the address's public profile, KYC status, payment summary, NFT holdings, voting power, staking position and pending meta-transactions. Sections are read in parallel; one that cannot be read is left out and named in data.errors, with data.partial set, instead of failing the request.
+// @Tags users
+// @Produce json
+// @Param address path string true "Wallet address"
+// @Success 200 {object} OverviewResponse{data=overview.Overview}
+// @Failure 400 {object} OverviewResponse
+// @Router /api/v1/users/{address}/overview [get]
+func (h *OverviewHandler) GetOverview(c *gin.Context) {
+	address := c.Param("address")
+	if !isValidAddress(address) {
+		c.JSON(http.StatusBadRequest, OverviewResponse{Success: false, Error: "Invalid address"})
+		return
+	}
+
+	o := h.service.Overview(c.Request.Context(), strings.ToLower(address))
+	c.JSON(http.StatusOK, OverviewResponse{Success: true, Data: o})
+}
@@ -0,0 +1,420 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/middleware"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/partners"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// PartnerHandler manages referral partners and serves their revenue-share
+// statements
+type PartnerHandler struct {
+	repo      repository.PartnerRepository
+	appConfig repository.AppConfigRepository
+	logger    *zap.Logger
+}
+
+// NewPartnerHandler creates a new partner handler with injected dependencies
+func NewPartnerHandler(repo repository.PartnerRepository, appConfig repository.AppConfigRepository, logger *zap.Logger) *PartnerHandler {
+	return &PartnerHandler{repo: repo, appConfig: appConfig, logger: logger}
+}
+
+// PartnerResponse wraps partner API responses
+type PartnerResponse struct {
+	Success bool        `json:"success"`
+	Data    interface{} `json:"data,omitempty"`
+	Error   string      `json:"error,omitempty"`
+}
+
+// CreatePartnerRequest registers a partner. Without share_percent the
+// partner gets partners.default_share_percent.
+type CreatePartnerRequest struct {
+	Name         string   `json:"name" binding:"required,max=100"`
+	ReferralCode string   `json:"referral_code" binding:"required"`
+	SharePercent *float64 `json:"share_percent" binding:"omitempty,min=0,max=100"`
+	ContactEmail string   `json:"contact_email,omitempty"`
+}
+
+// UpdatePartnerRequest changes a partner; a new share applies to payments
+// completed from then on
+type UpdatePartnerRequest struct {
+	Name         *string  `json:"name,omitempty" binding:"omitempty,min=1,max=100"`
+	SharePercent *float64 `json:"share_percent,omitempty" binding:"omitempty,min=0,max=100"`
+	ContactEmail *string  `json:"contact_email,omitempty"`
+	IsActive     *bool    `json:"is_active,omitempty"`
+}
+
+// PartnerWithKey is a partner with the API key just issued to it; the key
+// is not stored and cannot be shown again
+type PartnerWithKey struct {
+	*repository.Partner
+	APIKey string `json:"api_key"`
+}
+
+// PartnerStatement is a partner's revenue share over a period
+type PartnerStatement struct {
+	Partner     *repository.Partner                 `json:"partner"`
+	From        time.Time                           `json:"from"`
+	To          time.Time                           `json:"to"`
+	Totals      *repository.PartnerCommissionTotals `json:"totals"`
+	Commissions []*repository.PartnerCommission     `json:"commissions"`
+	Total       int64                               `json:"total"`
+	Page        int                                 `json:"page"`
+	PageSize    int                                 `json:"page_size"`
+}
+
+// CreatePartner handles POST /api/v1/admin/partners
+// @Summary Register a referral partner
+// @Description Registers a partner with its referral code and revenue share, and issues the API key for its statements. The key is returned only here.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body CreatePartnerRequest true "Partner"
+// @Success 201 {object} PartnerResponse{data=PartnerWithKey}
+// @Failure 400 {object} PartnerResponse
+// @Failure 409 {object} PartnerResponse
+// @Router /api/v1/admin/partners [post]
+func (h *PartnerHandler) CreatePartner(c *gin.Context) {
+	var req CreatePartnerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, PartnerResponse{Success: false, Error: "Invalid request: " + err.Error()})
+		return
+	}
+	code, ok := partners.NormalizeCode(req.ReferralCode)
+	if !ok {
+		c.JSON(http.StatusBadRequest, PartnerResponse{Success: false, Error: "referral_code must be 3-32 letters, digits, dashes or underscores"})
+		return
+	}
+	email, ok := partnerEmail(c, req.ContactEmail)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	share := partners.DefaultSharePercent
+	if req.SharePercent != nil {
+		share = *req.SharePercent
+	} else if configured, err := h.appConfig.GetNumber(ctx, partners.ConfigNamespace, "default_share_percent", 0); err == nil && configured >= 0 && configured <= 100 {
+		share = float64(configured)
+	}
+
+	key, hash, err := partners.NewAPIKey()
+	if err != nil {
+		h.logger.Error("failed to generate partner API key", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, PartnerResponse{Success: false, Error: "Internal server error"})
+		return
+	}
+
+	p := &repository.Partner{
+		Name:         req.Name,
+		ReferralCode: code,
+		SharePercent: share,
+		APIKeyHash:   hash,
+		ContactEmail: email,
+		IsActive:     true,
+	}
+	if err := h.repo.CreatePartner(ctx, p); err != nil {
+		if errors.Is(err, repository.ErrPartnerExists) {
+			c.JSON(http.StatusConflict, PartnerResponse{Success: false, Error: "Referral code already in use: " + code})
+			return
+		}
+		h.logger.Error("failed to create partner", zap.String("referral_code", code), zap.Error(err))
+		c.JSON(http.StatusInternalServerError, PartnerResponse{Success: false, Error: "Internal server error"})
+		return
+	}
+
+	h.logger.Info("partner created",
+		zap.String("partner_id", p.ID),
+		zap.String("referral_code", code),
+		zap.Float64("share_percent", share),
+	)
+	c.JSON(http.StatusCreated, PartnerResponse{Success: true, Data: PartnerWithKey{Partner: p, APIKey: key}})
+}
+
+// ListPartners handles GET /api/v1/admin/partners
+// @Summary List referral partners
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(20)
+// @Success 200 {object} PartnerResponse
+// @Router /api/v1/admin/partners [get]
+func (h *PartnerHandler) ListPartners(c *gin.Context) {
+	page, pageSize, ok := queryPage(c, 20)
+	if !ok {
+		return
+	}
+	list, total, err := h.repo.ListPartners(c.Request.Context(), repository.Pagination{Page: page, PageSize: pageSize})
+	if err != nil {
+		h.logger.Error("failed to list partners", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, PartnerResponse{Success: false, Error: "Internal server error"})
+		return
+	}
+	if list == nil {
+		list = []*repository.Partner{}
+	}
+
+	c.JSON(http.StatusOK, PartnerResponse{
+		Success: true,
+		Data: gin.H{
+			"partners":  list,
+			"total":     total,
+			"page":      page,
+			"page_size": pageSize,
+		},
+	})
+}
+
+// UpdatePartner handles PATCH /api/v1/admin/partners/:id
+// @Summary Update a referral partner
+// @Description Changes the partner's name, share, contact or status. A new share applies to payments completed from then on; deactivated partners earn nothing and their API key stops working.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Partner ID"
+// @Param request body UpdatePartnerRequest true "Fields to change"
+// @Success 200 {object} PartnerResponse{data=repository.Partner}
+// @Failure 400 {object} PartnerResponse
+// @Failure 404 {object} PartnerResponse
+// @Router /api/v1/admin/partners/{id} [patch]
+func (h *PartnerHandler) UpdatePartner(c *gin.Context) {
+	id, ok := partnerParam(c)
+	if !ok {
+		return
+	}
+	var req UpdatePartnerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, PartnerResponse{Success: false, Error: "Invalid request: " + err.Error()})
+		return
+	}
+	update := &repository.PartnerUpdate{Name: req.Name, SharePercent: req.SharePercent, IsActive: req.IsActive}
+	if req.ContactEmail != nil {
+		email, ok := partnerEmail(c, *req.ContactEmail)
+		if !ok {
+			return
+		}
+		if email == nil {
+			email = new(string)
+		}
+		update.ContactEmail = email
+	}
+
+	h.applyUpdate(c, id, update, nil)
+}
+
+// RotatePartnerKey handles POST /api/v1/admin/partners/:id/rotate-key
+// @Summary Issue a partner a new API key
+// @Description Replaces the partner's API key; the old one stops working at once. The new key is returned only here.
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Partner ID"
+// @Success 200 {object} PartnerResponse{data=PartnerWithKey}
+// @Failure 404 {object} PartnerResponse
+// @Router /api/v1/admin/partners/{id}/rotate-key [post]
+func (h *PartnerHandler) RotatePartnerKey(c *gin.Context) {
+	id, ok := partnerParam(c)
+	if !ok {
+		return
+	}
+	key, hash, err := partners.NewAPIKey()
+	if err != nil {
+		h.logger.Error("failed to generate partner API key", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, PartnerResponse{Success: false, Error: "Internal server error"})
+		return
+	}
+
+	h.applyUpdate(c, id, &repository.PartnerUpdate{APIKeyHash: &hash}, &key)
+}
+
+// applyUpdate updates partner id and responds with it, along with key when
+// one was just issued
+func (h *PartnerHandler) applyUpdate(c *gin.Context, id string, update *repository.PartnerUpdate, key *string) {
+	ctx := c.Request.Context()
+	if err := h.repo.UpdatePartner(ctx, id, update); err != nil {
+		if errors.Is(err, repository.ErrPartnerNotFound) {
+			c.JSON(http.StatusNotFound, PartnerResponse{Success: false, Error: "Partner not found"})
+			return
+		}
+		h.logger.Error("failed to update partner", zap.String("partner_id", id), zap.Error(err))
+		c.JSON(http.StatusInternalServerError, PartnerResponse{Success: false, Error: "Internal server error"})
+		return
+	}
+	p, err := h.repo.GetPartner(ctx, id)
+	if err != nil {
+		h.logger.Error("failed to get partner", zap.String("partner_id", id), zap.Error(err))
+		c.JSON(http.StatusInternalServerError, PartnerResponse{Success: false, Error: "Internal server error"})
+		return
+	}
+
+	if key != nil {
+		h.logger.Info("partner API key rotated", zap.String("partner_id", id))
+		c.JSON(http.StatusOK, PartnerResponse{Success: true, Data: PartnerWithKey{Partner: p, APIKey: *key}})
+		return
+	}
+	c.JSON(http.StatusOK, PartnerResponse{Success: true, Data: p})
+}
+
+// GetPartnerStatement handles GET /api/v1/admin/partners/:id/statement
+// @Summary Get a partner's revenue-share statement
+// @Description As GET /api/v1/partner/statement, for any partner
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Partner ID"
+// @Param month query string false "YYYY-MM (default: the current month, UTC)"
+// @Param from query string false "Start (RFC3339), overrides month"
+// @Param to query string false "End, exclusive (RFC3339), overrides month"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(20)
+// @Success 200 {object} PartnerResponse{data=PartnerStatement}
+// @Failure 400 {object} PartnerResponse
+// @Failure 404 {object} PartnerResponse
+// @Router /api/v1/admin/partners/{id}/statement [get]
+func (h *PartnerHandler) GetPartnerStatement(c *gin.Context) {
+	id, ok := partnerParam(c)
+	if !ok {
+		return
+	}
+	h.statement(c, id)
+}
+
+// GetStatement handles GET /api/v1/partner/statement
+// @Summary Get your revenue-share statement
+// @Description Lists the commissions the calling partner earned on referred payments completed in the period, newest first, with totals: earned, reversed by refunds and lost disputes, and net. Authenticate with the partner API key as a bearer token.
+// @Tags partners
+// @Produce json
+// @Security BearerAuth
+// @Param month query string false "YYYY-MM (default: the current month, UTC)"
+// @Param from query string false "Start (RFC3339), overrides month"
+// @Param to query string false "End, exclusive (RFC3339), overrides month"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(20)
+// @Success 200 {object} PartnerResponse{data=PartnerStatement}
+// @Failure 400 {object} PartnerResponse
+// @Failure 401 {object} PartnerResponse
+// @Router /api/v1/partner/statement [get]
+func (h *PartnerHandler) GetStatement(c *gin.Context) {
+	h.statement(c, middleware.PartnerID(c))
+}
+
+// statement responds with partner id's statement for the requested period
+func (h *PartnerHandler) statement(c *gin.Context, id string) {
+	from, to, ok := statementPeriod(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	p, err := h.repo.GetPartner(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrPartnerNotFound) {
+			c.JSON(http.StatusNotFound, PartnerResponse{Success: false, Error: "Partner not found"})
+			return
+		}
+		h.logger.Error("failed to get partner", zap.String("partner_id", id), zap.Error(err))
+		c.JSON(http.StatusInternalServerError, PartnerResponse{Success: false, Error: "Internal server error"})
+		return
+	}
+
+	filter := repository.PartnerCommissionFilter{PartnerID: id, From: &from, To: &to}
+	totals, err := h.repo.GetCommissionTotals(ctx, filter)
+	if err != nil {
+		h.logger.Error("failed to total partner commissions", zap.String("partner_id", id), zap.Error(err))
+		c.JSON(http.StatusInternalServerError, PartnerResponse{Success: false, Error: "Internal server error"})
+		return
+	}
+	page, pageSize, ok := queryPage(c, 20)
+	if !ok {
+		return
+	}
+	commissions, total, err := h.repo.ListCommissions(ctx, filter, repository.Pagination{Page: page, PageSize: pageSize})
+	if err != nil {
+		h.logger.Error("failed to list partner commissions", zap.String("partner_id", id), zap.Error(err))
+		c.JSON(http.StatusInternalServerError, PartnerResponse{Success: false, Error: "Internal server error"})
+		return
+	}
+	if commissions == nil {
+		commissions = []*repository.PartnerCommission{}
+	}
+
+	c.JSON(http.StatusOK, PartnerResponse{Success: true, Data: PartnerStatement{
+		Partner:     p,
+		From:        from,
+		To:          to,
+		Totals:      totals,
+		Commissions: commissions,
+		Total:       total,
+		Page:        page,
+		PageSize:    pageSize,
+	}})
+}
+
+// statementPeriod reads the statement's [from, to) from the month or
+// from/to query parameters, having responded if they are invalid
+func statementPeriod(c *gin.Context) (time.Time, time.Time, bool) {
+	month := time.Now().UTC()
+	if m := c.Query("month"); m != "" {
+		var err error
+		if month, err = time.Parse("2006-01", m); err != nil {
+			c.JSON(http.StatusBadRequest, PartnerResponse{Success: false, Error: "Invalid month, expected YYYY-MM"})
+			return time.Time{}, time.Time{}, false
+		}
+	}
+	from := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+
+	if t, err := parseOptionalTime(c.Query("from")); err != nil {
+		c.JSON(http.StatusBadRequest, PartnerResponse{Success: false, Error: "Invalid from time, expected RFC3339"})
+		return time.Time{}, time.Time{}, false
+	} else if t != nil {
+		from = *t
+	}
+	if t, err := parseOptionalTime(c.Query("to")); err != nil {
+		c.JSON(http.StatusBadRequest, PartnerResponse{Success: false, Error: "Invalid to time, expected RFC3339"})
+		return time.Time{}, time.Time{}, false
+	} else if t != nil {
+		to = *t
+	}
+	if !from.Before(to) {
+		c.JSON(http.StatusBadRequest, PartnerResponse{Success: false, Error: "from must be before to"})
+		return time.Time{}, time.Time{}, false
+	}
+	return from, to, true
+}
+
+// partnerParam reads the :id parameter, answering 400 when it is not a UUID
+func partnerParam(c *gin.Context) (string, bool) {
+	id := c.Param("id")
+	if !uuidPattern.MatchString(id) {
+		c.JSON(http.StatusBadRequest, PartnerResponse{Success: false, Error: "Invalid partner ID"})
+		return "", false
+	}
+	return id, true
+}
+
+// partnerEmail validates an optional contact email ("" is none), having
+// responded if it is invalid
+func partnerEmail(c *gin.Context, email string) (*string, bool) {
+	email = strings.TrimSpace(email)
+	if email == "" {
+		return nil, true
+	}
+	if _, err := mail.ParseAddress(email); err != nil || len(email) > 255 {
+		c.JSON(http.StatusBadRequest, PartnerResponse{Success: false, Error: "Invalid contact_email"})
+		return nil, false
+	}
+	return &email, true
+}
@@ -0,0 +1,83 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/handlers"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/middleware"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/partners"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/storage/memory"
+)
+
+func TestPartnerHandler_CreateAndStatement(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctx := context.Background()
+	store := memory.NewStore()
+	handler := handlers.NewPartnerHandler(store.Partners, store.AppConfig, zap.NewNop())
+	router := gin.New()
+	router.POST("/api/v1/admin/partners", handler.CreatePartner)
+	router.POST("/api/v1/admin/partners/:id/rotate-key", handler.RotatePartnerKey)
+	router.GET("/api/v1/partner/statement", middleware.RequirePartner(partners.NewAuthenticator(store.Partners)), handler.GetStatement)
+
+	do := func(method, path, token, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	assert.Equal(t, http.StatusBadRequest, do(http.MethodPost, "/api/v1/admin/partners", "", `{"name":"Acme","referral_code":"a b"}`).Code)
+	assert.Equal(t, http.StatusBadRequest, do(http.MethodPost, "/api/v1/admin/partners", "", `{"name":"Acme","referral_code":"acme","share_percent":101}`).Code)
+
+	w := do(http.MethodPost, "/api/v1/admin/partners", "", `{"name":"Acme","referral_code":"ACME"}`)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var created struct {
+		Data struct {
+			ID           string  `json:"id"`
+			ReferralCode string  `json:"referral_code"`
+			SharePercent float64 `json:"share_percent"`
+			APIKey       string  `json:"api_key"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	assert.Equal(t, "acme", created.Data.ReferralCode)
+	assert.Equal(t, partners.DefaultSharePercent, created.Data.SharePercent)
+	assert.NotContains(t, w.Body.String(), "api_key_hash")
+	assert.Equal(t, http.StatusConflict, do(http.MethodPost, "/api/v1/admin/partners", "", `{"name":"Other","referral_code":"acme"}`).Code)
+
+	_, err := store.Partners.RecordCommission(ctx, &repository.PartnerCommission{PartnerID: created.Data.ID, PaymentID: "p1",
+		ServiceCode: "kyc_verification", AmountUSD: 15, SharePercent: 10, CommissionUSD: 1.5})
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusUnauthorized, do(http.MethodGet, "/api/v1/partner/statement", "", "").Code)
+	assert.Equal(t, http.StatusUnauthorized, do(http.MethodGet, "/api/v1/partner/statement", "nxp_wrong", "").Code)
+	assert.Equal(t, http.StatusBadRequest, do(http.MethodGet, "/api/v1/partner/statement?month=2026-13", created.Data.APIKey, "").Code)
+
+	w = do(http.MethodGet, "/api/v1/partner/statement", created.Data.APIKey, "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Contains(t, w.Body.String(), `"payment_id":"p1"`)
+	assert.Contains(t, w.Body.String(), `"net_usd":1.5`)
+
+	w = do(http.MethodGet, "/api/v1/partner/statement?from=2020-01-01T00:00:00Z&to=2020-02-01T00:00:00Z", created.Data.APIKey, "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Contains(t, w.Body.String(), `"commissions":[]`)
+
+	// Rotating the key retires the old one
+	w = do(http.MethodPost, "/api/v1/admin/partners/"+created.Data.ID+"/rotate-key", "", "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, http.StatusUnauthorized, do(http.MethodGet, "/api/v1/partner/statement", created.Data.APIKey, "").Code)
+}
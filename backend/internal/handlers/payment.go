@@ -1,12 +1,13 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
-	"os"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stripe/stripe-go/v76"
@@ -14,6 +15,12 @@ import (
 	"github.com/stripe/stripe-go/v76/webhook"
 	"go.uber.org/zap"
 
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/breaker"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/callbacks"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/disputes"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/middleware"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/outbound"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/partners"
 	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
 )
 
@@ -22,7 +29,80 @@ type PaymentHandler struct {
 	paymentRepo repository.PaymentRepository
 	pricingRepo repository.PricingRepository
 	logger      *zap.Logger
-	webhookSecret string
+	secrets     SecretSource
+	txTracker   TxTracker
+	stripeBreaker *breaker.Breaker
+	stripeBackend stripe.Backend
+	webhookEvents repository.WebhookEventRepository
+	insecureCallbacks bool
+	disputes      DisputeProcessor
+}
+
+// stripeEventStaleAfter is how long a Stripe event may stay processing before
+// a redelivery or replay may take it over from a worker presumed dead
+const stripeEventStaleAfter = 5 * time.Minute
+
+// errInvalidStripeEvent marks events whose data cannot be decoded; retrying
+// them cannot succeed
+var errInvalidStripeEvent = errors.New("invalid stripe event data")
+
+// TxTracker tracks on-chain transactions backing records until they reach
+// confirmation depth (implemented by chain.ConfirmationTracker)
+type TxTracker interface {
+	Track(ctx context.Context, recordType, recordID, txHash string) error
+}
+
+// DisputeProcessor applies Stripe disputes to the payments they are raised
+// against (implemented by disputes.Service)
+type DisputeProcessor interface {
+	Open(ctx context.Context, p *repository.Payment, d disputes.Dispute) error
+	Close(ctx context.Context, p *repository.Payment, d disputes.Dispute) error
+}
+
+// SetTxTracker enables confirmation tracking for crypto payments. Without a
+// tracker, crypto payments are marked completed immediately.
+func (h *PaymentHandler) SetTxTracker(tracker TxTracker) {
+	h.txTracker = tracker
+}
+
+// SetStripeBreaker sheds Stripe calls with 503 while Stripe is failing
+func (h *PaymentHandler) SetStripeBreaker(b *breaker.Breaker) {
+	h.stripeBreaker = b
+}
+
+// SetStripeHTTPClient sends Stripe API calls with client (e.g. an
+// outbound.Transport client adding rate limiting and retries). Stripe's own
+// retries are turned off so requests are not retried twice.
+func (h *PaymentHandler) SetStripeHTTPClient(client *http.Client) {
+	h.stripeBackend = stripe.GetBackendWithConfig(stripe.APIBackend, &stripe.BackendConfig{
+		HTTPClient:        client,
+		MaxNetworkRetries: stripe.Int64(0),
+	})
+}
+
+// SetSecrets reads STRIPE_SECRET_KEY and STRIPE_WEBHOOK_SECRET from src
+// instead of the environment
+func (h *PaymentHandler) SetSecrets(src SecretSource) {
+	h.secrets = secretSourceOrEnv(src)
+}
+
+// SetWebhookEvents stores Stripe events so redeliveries are processed once
+// and failed events can be replayed. Without a store, every delivery is
+// processed.
+func (h *PaymentHandler) SetWebhookEvents(repo repository.WebhookEventRepository) {
+	h.webhookEvents = repo
+}
+
+// SetDisputes handles charge.dispute.created and charge.dispute.closed
+// events. Without a processor, disputes are only logged.
+func (h *PaymentHandler) SetDisputes(p DisputeProcessor) {
+	h.disputes = p
+}
+
+// SetInsecureCallbacks accepts http callback URLs (for local development);
+// by default callback URLs must be https
+func (h *PaymentHandler) SetInsecureCallbacks(allow bool) {
+	h.insecureCallbacks = allow
 }
 
 // NewPaymentHandler creates a new payment handler with injected dependencies
@@ -31,14 +111,11 @@ func NewPaymentHandler(
 	pricingRepo repository.PricingRepository,
 	logger *zap.Logger,
 ) *PaymentHandler {
-	// Set Stripe API key from environment
-	stripe.Key = os.Getenv("STRIPE_SECRET_KEY")
-
 	return &PaymentHandler{
-		paymentRepo:   paymentRepo,
-		pricingRepo:   pricingRepo,
-		logger:        logger,
-		webhookSecret: os.Getenv("STRIPE_WEBHOOK_SECRET"),
+		paymentRepo: paymentRepo,
+		pricingRepo: pricingRepo,
+		logger:      logger,
+		secrets:     envSecrets{},
 	}
 }
 
@@ -56,6 +133,14 @@ type CreateCheckoutRequest struct {
 	PayerAddress  string `json:"payer_address" binding:"required"`
 	SuccessURL    string `json:"success_url" binding:"required"`
 	CancelURL     string `json:"cancel_url" binding:"required"`
+
+	// Optional: signed status notifications are POSTed to CallbackURL
+	// (requires CallbackSecret, 16-256 characters)
+	CallbackURL    string `json:"callback_url,omitempty"`
+	CallbackSecret string `json:"callback_secret,omitempty"`
+
+	// Optional: the referral code of the partner who sent the payer
+	Referrer string `json:"referrer,omitempty"`
 }
 
 // CryptoPaymentRequest represents a request to process a crypto payment
@@ -65,6 +150,11 @@ type CryptoPaymentRequest struct {
 	PaymentMethod string  `json:"payment_method" binding:"required"` // nexus or eth
 	TxHash        string  `json:"tx_hash" binding:"required"`
 	Amount        float64 `json:"amount" binding:"required"`
+
+	// Optional, as for CreateCheckoutRequest
+	CallbackURL    string `json:"callback_url,omitempty"`
+	CallbackSecret string `json:"callback_secret,omitempty"`
+	Referrer       string `json:"referrer,omitempty"`
 }
 
 // CreateStripeCheckout handles POST /api/v1/payments/stripe/checkout
@@ -96,6 +186,24 @@ func (h *PaymentHandler) CreateStripeCheckout(c *gin.Context) {
 		return
 	}
 
+	callbackURL, callbackSecret, err := h.paymentCallback(req.CallbackURL, req.CallbackSecret)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, PaymentResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	referrer, ok := referrerCode(req.Referrer)
+	if !ok {
+		c.JSON(http.StatusBadRequest, PaymentResponse{
+			Success: false,
+			Error:   "Invalid referrer code",
+		})
+		return
+	}
+
 	ctx := c.Request.Context()
 
 	// Get pricing for the service
@@ -167,9 +275,29 @@ func (h *PaymentHandler) CreateStripeCheckout(c *gin.Context) {
 			"payer_address": strings.ToLower(req.PayerAddress),
 		},
 	}
+	if referrer != nil {
+		params.Metadata["referrer"] = *referrer
+	}
 
-	stripeSession, err := session.New(params)
+	stripeCtx, cancel := context.WithTimeout(ctx, providerTimeout)
+	defer cancel()
+	params.Context = stripeCtx
+
+	var stripeSession *stripe.CheckoutSession
+	err = callProvider(h.stripeBreaker, func() (err error) {
+		// The key is read per call so a rotated key applies immediately
+		backend := h.stripeBackend
+		if backend == nil {
+			backend = stripe.GetBackend(stripe.APIBackend)
+		}
+		client := session.Client{B: backend, Key: h.secrets.Get("STRIPE_SECRET_KEY")}
+		stripeSession, err = client.New(params)
+		return err
+	}, isStripeFailure)
 	if err != nil {
+		if respondUnavailable(c, err) {
+			return
+		}
 		h.logger.Error("failed to create Stripe session", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, PaymentResponse{
 			Success: false,
@@ -189,6 +317,10 @@ func (h *PaymentHandler) CreateStripeCheckout(c *gin.Context) {
 		AmountUSD:       &totalAmount,
 		StripeSessionID: &stripeSession.ID,
 		Status:          repository.PaymentStatusPending,
+		CallbackURL:     callbackURL,
+		CallbackSecret:  callbackSecret,
+		Country:         clientCountry(c),
+		ReferrerCode:    referrer,
 	}
 
 	if err := h.paymentRepo.CreatePayment(ctx, payment); err != nil {
@@ -234,7 +366,7 @@ func (h *PaymentHandler) HandleStripeWebhook(c *gin.Context) {
 
 	sigHeader := c.GetHeader("Stripe-Signature")
 
-	event, err := webhook.ConstructEvent(payload, sigHeader, h.webhookSecret)
+	event, err := webhook.ConstructEvent(payload, sigHeader, h.secrets.Get("STRIPE_WEBHOOK_SECRET"))
 	if err != nil {
 		h.logger.Error("failed to verify webhook signature", zap.Error(err))
 		c.JSON(http.StatusBadRequest, PaymentResponse{
@@ -246,54 +378,277 @@ func (h *PaymentHandler) HandleStripeWebhook(c *gin.Context) {
 
 	ctx := c.Request.Context()
 
+	if h.webhookEvents == nil {
+		if err := h.processStripeEvent(ctx, event); err != nil {
+			if errors.Is(err, errInvalidStripeEvent) {
+				c.JSON(http.StatusBadRequest, PaymentResponse{Success: false, Error: "Invalid event data"})
+				return
+			}
+			h.logger.Error("failed to process stripe event", zap.String("event_id", event.ID), zap.Error(err))
+		}
+		c.JSON(http.StatusOK, PaymentResponse{Success: true})
+		return
+	}
+
+	stored := &repository.WebhookEvent{
+		Provider:  repository.WebhookProviderStripe,
+		EventID:   event.ID,
+		EventType: string(event.Type),
+		Payload:   payload,
+	}
+	if _, err := h.webhookEvents.CreateWebhookEvent(ctx, stored); err != nil {
+		// Non-2xx makes Stripe redeliver once storage is back
+		h.logger.Error("failed to store stripe event", zap.String("event_id", event.ID), zap.Error(err))
+		c.JSON(http.StatusInternalServerError, PaymentResponse{Success: false, Error: "Internal server error"})
+		return
+	}
+
+	if stored.Status == repository.WebhookEventProcessed {
+		h.logger.Info("ignoring duplicate stripe event",
+			zap.String("event_id", event.ID),
+			zap.String("type", stored.EventType),
+		)
+		c.JSON(http.StatusOK, PaymentResponse{Success: true, Message: "Event already processed"})
+		return
+	}
+
+	claimed, err := h.webhookEvents.ClaimWebhookEvent(ctx, stored.ID, stripeEventStaleAfter)
+	if err != nil {
+		h.logger.Error("failed to claim stripe event", zap.String("event_id", event.ID), zap.Error(err))
+		c.JSON(http.StatusInternalServerError, PaymentResponse{Success: false, Error: "Internal server error"})
+		return
+	}
+	if !claimed {
+		// Another delivery is processing it; Stripe retries later and the
+		// retry either sees it processed or takes over a stale claim
+		c.JSON(http.StatusConflict, PaymentResponse{Success: false, Error: "Event is already being processed"})
+		return
+	}
+
+	if err := h.runStoredStripeEvent(ctx, stored.ID, event); err != nil {
+		if errors.Is(err, errInvalidStripeEvent) {
+			c.JSON(http.StatusBadRequest, PaymentResponse{Success: false, Error: "Invalid event data"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, PaymentResponse{Success: false, Error: "Event processing failed"})
+		return
+	}
+
+	c.JSON(http.StatusOK, PaymentResponse{Success: true})
+}
+
+// ReplayStripeEvent handles POST /api/v1/admin/webhooks/stripe/events/:eventId/replay
+// @Summary Replay a stored Stripe event
+// @Description Reprocesses a stored Stripe event from its original payload. Only events that failed, were never processed, or were left processing by a crashed worker can be replayed.
+// @Tags admin
+// @Produce json
+// @Param eventId path string true "Stripe event ID (evt_...)"
+// @Success 200 {object} PaymentResponse
+// @Failure 401 {object} PaymentResponse
+// @Failure 404 {object} PaymentResponse
+// @Failure 409 {object} PaymentResponse
+// @Failure 500 {object} PaymentResponse
+// @Router /api/v1/admin/webhooks/stripe/events/{eventId}/replay [post]
+func (h *PaymentHandler) ReplayStripeEvent(c *gin.Context) {
+	if h.webhookEvents == nil {
+		c.JSON(http.StatusNotFound, PaymentResponse{Success: false, Error: "Webhook event store not configured"})
+		return
+	}
+
+	ctx := c.Request.Context()
+	eventID := c.Param("eventId")
+
+	stored, err := h.webhookEvents.GetWebhookEventByEventID(ctx, repository.WebhookProviderStripe, eventID)
+	if err != nil {
+		if errors.Is(err, repository.ErrWebhookEventNotFound) {
+			c.JSON(http.StatusNotFound, PaymentResponse{Success: false, Error: "Event not found"})
+			return
+		}
+		h.logger.Error("failed to get stripe event", zap.String("event_id", eventID), zap.Error(err))
+		c.JSON(http.StatusInternalServerError, PaymentResponse{Success: false, Error: "Internal server error"})
+		return
+	}
+
+	if stored.Status == repository.WebhookEventProcessed {
+		c.JSON(http.StatusConflict, PaymentResponse{Success: false, Error: "Event already processed"})
+		return
+	}
+
+	// The signature was verified when the event was received
+	var event stripe.Event
+	if err := json.Unmarshal(stored.Payload, &event); err != nil {
+		h.logger.Error("failed to decode stored stripe event", zap.String("event_id", eventID), zap.Error(err))
+		c.JSON(http.StatusInternalServerError, PaymentResponse{Success: false, Error: "Stored event payload is invalid"})
+		return
+	}
+
+	claimed, err := h.webhookEvents.ClaimWebhookEvent(ctx, stored.ID, stripeEventStaleAfter)
+	if err != nil {
+		h.logger.Error("failed to claim stripe event", zap.String("event_id", eventID), zap.Error(err))
+		c.JSON(http.StatusInternalServerError, PaymentResponse{Success: false, Error: "Internal server error"})
+		return
+	}
+	if !claimed {
+		c.JSON(http.StatusConflict, PaymentResponse{Success: false, Error: "Event is already being processed"})
+		return
+	}
+
+	h.logger.Info("replaying stripe event",
+		zap.String("event_id", eventID),
+		zap.String("type", stored.EventType),
+		zap.Int("previous_attempts", stored.Attempts),
+	)
+	processErr := h.runStoredStripeEvent(ctx, stored.ID, event)
+
+	updated, err := h.webhookEvents.GetWebhookEvent(ctx, stored.ID)
+	if err != nil {
+		h.logger.Error("failed to reload stripe event", zap.String("event_id", eventID), zap.Error(err))
+		updated = nil
+	}
+
+	if processErr != nil {
+		c.JSON(http.StatusInternalServerError, PaymentResponse{
+			Success: false,
+			Data:    updated,
+			Error:   "Event processing failed: " + processErr.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, PaymentResponse{
+		Success: true,
+		Data:    updated,
+		Message: "Event replayed",
+	})
+}
+
+// runStoredStripeEvent processes a claimed event and records the outcome
+func (h *PaymentHandler) runStoredStripeEvent(ctx context.Context, id string, event stripe.Event) error {
+	if err := h.processStripeEvent(ctx, event); err != nil {
+		h.logger.Error("failed to process stripe event", zap.String("event_id", event.ID), zap.Error(err))
+		if markErr := h.webhookEvents.MarkWebhookEventFailed(ctx, id, err.Error(), nil); markErr != nil {
+			h.logger.Error("failed to mark stripe event failed", zap.String("event_id", event.ID), zap.Error(markErr))
+		}
+		return err
+	}
+
+	if err := h.webhookEvents.MarkWebhookEventProcessed(ctx, id); err != nil {
+		// The payment update already happened; a redelivery re-applies the
+		// same status, which is harmless
+		h.logger.Error("failed to mark stripe event processed", zap.String("event_id", event.ID), zap.Error(err))
+	}
+	return nil
+}
+
+// processStripeEvent applies a verified Stripe event to the matching payment
+func (h *PaymentHandler) processStripeEvent(ctx context.Context, event stripe.Event) error {
 	switch event.Type {
 	case "checkout.session.completed":
 		var session stripe.CheckoutSession
 		if err := json.Unmarshal(event.Data.Raw, &session); err != nil {
 			h.logger.Error("failed to unmarshal session", zap.Error(err))
-			c.JSON(http.StatusBadRequest, PaymentResponse{Success: false, Error: "Invalid event data"})
-			return
+			return fmt.Errorf("%w: %v", errInvalidStripeEvent, err)
 		}
 
 		// Update payment status
 		payment, err := h.paymentRepo.GetPaymentByStripeSession(ctx, session.ID)
 		if err != nil {
-			h.logger.Warn("payment not found for session", zap.String("session", session.ID))
-		} else {
-			stripePaymentID := session.PaymentIntent.ID
-			if err := h.paymentRepo.UpdatePaymentStatus(ctx, payment.ID, repository.PaymentStatusCompleted, &repository.PaymentStatusUpdate{
-				StripePaymentID: &stripePaymentID,
-			}); err != nil {
-				h.logger.Error("failed to update payment status", zap.Error(err))
+			if !errors.Is(err, repository.ErrPaymentNotFound) {
+				return fmt.Errorf("getting payment for session %s: %w", session.ID, err)
 			}
+			h.logger.Warn("payment not found for session", zap.String("session", session.ID))
+			return nil
+		}
 
-			h.logger.Info("payment completed",
-				zap.String("payment_id", payment.ID),
-				zap.String("payer", payment.PayerAddress),
-				zap.Float64("amount", payment.AmountCharged),
-			)
+		var stripePaymentID string
+		if session.PaymentIntent != nil {
+			stripePaymentID = session.PaymentIntent.ID
 		}
+		if err := h.paymentRepo.UpdatePaymentStatus(ctx, payment.ID, repository.PaymentStatusCompleted, &repository.PaymentStatusUpdate{
+			StripePaymentID: &stripePaymentID,
+		}); err != nil {
+			return fmt.Errorf("updating payment %s status: %w", payment.ID, err)
+		}
+
+		h.logger.Info("payment completed",
+			zap.String("payment_id", payment.ID),
+			zap.String("payer", payment.PayerAddress),
+			zap.Float64("amount", payment.AmountCharged),
+		)
 
 	case "checkout.session.expired":
 		var session stripe.CheckoutSession
 		if err := json.Unmarshal(event.Data.Raw, &session); err != nil {
 			h.logger.Error("failed to unmarshal session", zap.Error(err))
-			c.JSON(http.StatusBadRequest, PaymentResponse{Success: false, Error: "Invalid event data"})
-			return
+			return fmt.Errorf("%w: %v", errInvalidStripeEvent, err)
 		}
 
 		payment, err := h.paymentRepo.GetPaymentByStripeSession(ctx, session.ID)
-		if err == nil {
-			if err := h.paymentRepo.UpdatePaymentStatus(ctx, payment.ID, repository.PaymentStatusCancelled, nil); err != nil {
-				h.logger.Error("failed to update payment status", zap.Error(err))
+		if err != nil {
+			if !errors.Is(err, repository.ErrPaymentNotFound) {
+				return fmt.Errorf("getting payment for session %s: %w", session.ID, err)
 			}
+			return nil
+		}
+		if err := h.paymentRepo.UpdatePaymentStatus(ctx, payment.ID, repository.PaymentStatusCancelled, nil); err != nil {
+			return fmt.Errorf("updating payment %s status: %w", payment.ID, err)
 		}
 
 	case "payment_intent.payment_failed":
 		h.logger.Warn("payment failed", zap.String("event_id", event.ID))
+
+	case "charge.dispute.created", "charge.dispute.closed":
+		return h.processStripeDispute(ctx, event)
 	}
 
-	c.JSON(http.StatusOK, PaymentResponse{Success: true})
+	return nil
+}
+
+// processStripeDispute applies a dispute event to the payment whose
+// PaymentIntent is disputed
+func (h *PaymentHandler) processStripeDispute(ctx context.Context, event stripe.Event) error {
+	var dispute stripe.Dispute
+	if err := json.Unmarshal(event.Data.Raw, &dispute); err != nil {
+		h.logger.Error("failed to unmarshal dispute", zap.Error(err))
+		return fmt.Errorf("%w: %v", errInvalidStripeEvent, err)
+	}
+	if dispute.PaymentIntent == nil || dispute.PaymentIntent.ID == "" {
+		h.logger.Warn("dispute without payment intent", zap.String("dispute_id", dispute.ID))
+		return nil
+	}
+
+	payment, err := h.paymentRepo.GetPaymentByStripePaymentID(ctx, dispute.PaymentIntent.ID)
+	if err != nil {
+		if !errors.Is(err, repository.ErrPaymentNotFound) {
+			return fmt.Errorf("getting payment for payment intent %s: %w", dispute.PaymentIntent.ID, err)
+		}
+		h.logger.Warn("payment not found for dispute",
+			zap.String("dispute_id", dispute.ID),
+			zap.String("payment_intent", dispute.PaymentIntent.ID),
+		)
+		return nil
+	}
+
+	if h.disputes == nil {
+		h.logger.Warn("dispute not applied: dispute handling is not configured",
+			zap.String("dispute_id", dispute.ID),
+			zap.String("payment_id", payment.ID),
+			zap.String("event_type", string(event.Type)),
+		)
+		return nil
+	}
+
+	d := disputes.Dispute{
+		ID:       dispute.ID,
+		Status:   string(dispute.Status),
+		Reason:   string(dispute.Reason),
+		Amount:   dispute.Amount,
+		Currency: string(dispute.Currency),
+	}
+	if event.Type == "charge.dispute.closed" {
+		return h.disputes.Close(ctx, payment, d)
+	}
+	return h.disputes.Open(ctx, payment, d)
 }
 
 // ProcessCryptoPayment handles POST /api/v1/payments/crypto
@@ -341,6 +696,24 @@ func (h *PaymentHandler) ProcessCryptoPayment(c *gin.Context) {
 		return
 	}
 
+	callbackURL, callbackSecret, err := h.paymentCallback(req.CallbackURL, req.CallbackSecret)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, PaymentResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	referrer, ok := referrerCode(req.Referrer)
+	if !ok {
+		c.JSON(http.StatusBadRequest, PaymentResponse{
+			Success: false,
+			Error:   "Invalid referrer code",
+		})
+		return
+	}
+
 	ctx := c.Request.Context()
 
 	// Get pricing
@@ -409,6 +782,11 @@ func (h *PaymentHandler) ProcessCryptoPayment(c *gin.Context) {
 		AmountUSD:     &amountUSD,
 		TxHash:        &req.TxHash,
 		Status:        repository.PaymentStatusProcessing, // Will be confirmed after tx verification
+
+		CallbackURL:    callbackURL,
+		CallbackSecret: callbackSecret,
+		Country:        clientCountry(c),
+		ReferrerCode:   referrer,
 	}
 
 	if err := h.paymentRepo.CreatePayment(ctx, payment); err != nil {
@@ -420,9 +798,20 @@ func (h *PaymentHandler) ProcessCryptoPayment(c *gin.Context) {
 		return
 	}
 
-	// TODO: Queue transaction verification job
-	// For now, mark as completed (in production, verify tx on-chain first)
-	if err := h.paymentRepo.UpdatePaymentStatus(ctx, payment.ID, repository.PaymentStatusCompleted, nil); err != nil {
+	// With a tracker the payment stays processing until the tx reaches
+	// confirmation depth; otherwise mark completed immediately
+	status := repository.PaymentStatusCompleted
+	if h.txTracker != nil {
+		status = repository.PaymentStatusProcessing
+		if err := h.txTracker.Track(ctx, repository.TrackedRecordPayment, payment.ID, req.TxHash); err != nil {
+			h.logger.Error("failed to track payment transaction", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, PaymentResponse{
+				Success: false,
+				Error:   "Failed to record payment",
+			})
+			return
+		}
+	} else if err := h.paymentRepo.UpdatePaymentStatus(ctx, payment.ID, repository.PaymentStatusCompleted, nil); err != nil {
 		h.logger.Error("failed to update payment status", zap.Error(err))
 	}
 
@@ -437,7 +826,7 @@ func (h *PaymentHandler) ProcessCryptoPayment(c *gin.Context) {
 		Success: true,
 		Data: gin.H{
 			"payment_id": payment.ID,
-			"status":     "completed",
+			"status":     string(status),
 			"tx_hash":    req.TxHash,
 		},
 		Message: "Payment recorded successfully",
@@ -450,33 +839,31 @@ func (h *PaymentHandler) ProcessCryptoPayment(c *gin.Context) {
 // @Tags payments
 // @Produce json
 // @Param paymentId path string true "Payment ID"
+// @Param fields query string false "Comma-separated record fields to return (id is always included)"
 // @Success 200 {object} PaymentResponse
 // @Failure 404 {object} PaymentResponse
 // @Router /api/v1/payments/{paymentId} [get]
+// @Router /api/v2/payments/{paymentId} [get]
 func (h *PaymentHandler) GetPayment(c *gin.Context) {
 	paymentID := c.Param("paymentId")
+	if paymentID == "" {
+		// The route must name its parameter :paymentId
+		respondError(c, http.StatusBadRequest, "Payment ID is required")
+		return
+	}
 
 	payment, err := h.paymentRepo.GetPayment(c.Request.Context(), paymentID)
 	if err != nil {
 		if errors.Is(err, repository.ErrPaymentNotFound) {
-			c.JSON(http.StatusNotFound, PaymentResponse{
-				Success: false,
-				Error:   "Payment not found",
-			})
+			respondError(c, http.StatusNotFound, "Payment not found")
 			return
 		}
 		h.logger.Error("failed to get payment", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, PaymentResponse{
-			Success: false,
-			Error:   "Internal server error",
-		})
+		respondError(c, http.StatusInternalServerError, "Internal server error")
 		return
 	}
 
-	c.JSON(http.StatusOK, PaymentResponse{
-		Success: true,
-		Data:    payment,
-	})
+	respondRecord(c, payment)
 }
 
 // GetPaymentBySession handles GET /api/v1/payments/stripe/session/:sessionId
@@ -485,35 +872,131 @@ func (h *PaymentHandler) GetPayment(c *gin.Context) {
 // @Tags payments
 // @Produce json
 // @Param sessionId path string true "Stripe session ID"
+// @Param fields query string false "Comma-separated record fields to return (id is always included)"
 // @Success 200 {object} PaymentResponse
 // @Failure 404 {object} PaymentResponse
 // @Router /api/v1/payments/stripe/session/{sessionId} [get]
+// @Router /api/v2/payments/session/{sessionId} [get]
 func (h *PaymentHandler) GetPaymentBySession(c *gin.Context) {
 	sessionID := c.Param("sessionId")
 
 	payment, err := h.paymentRepo.GetPaymentByStripeSession(c.Request.Context(), sessionID)
 	if err != nil {
 		if errors.Is(err, repository.ErrPaymentNotFound) {
-			c.JSON(http.StatusNotFound, PaymentResponse{
-				Success: false,
-				Error:   "Payment not found for session",
-			})
+			respondError(c, http.StatusNotFound, "Payment not found for session")
 			return
 		}
 		h.logger.Error("failed to get payment by session", zap.Error(err))
+		respondError(c, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	respondRecord(c, payment)
+}
+
+// ListPayments handles GET /api/v1/admin/payments
+// @Summary List payments
+// @Description Returns live payments, newest first. Expired payments (left unpaid past their payment_expiry window) are left out unless include_expired is set or status=expired is asked for. Disputed payments carry dispute_id, dispute_status, dispute_reason and disputed_at; disputed=true keeps only those, whatever the outcome.
+// @Tags admin
+// @Produce json
+// @Param payer_address query string false "Payer address"
+// @Param service_code query string false "Service code"
+// @Param payment_method query string false "Payment method (stripe, eth or nexus)"
+// @Param status query string false "Payment status"
+// @Param include_expired query bool false "Include expired payments" default(false)
+// @Param disputed query bool false "Only payments that have been disputed" default(false)
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(20)
+// @Param fields query string false "Comma-separated record fields to return (id is always included)"
+// @Success 200 {object} PaymentResponse
+// @Failure 401 {object} PaymentResponse
+// @Router /api/v1/admin/payments [get]
+func (h *PaymentHandler) ListPayments(c *gin.Context) {
+	includeExpired, ok := queryBool(c, "include_expired", false)
+	if !ok {
+		return
+	}
+	disputed, ok := queryBool(c, "disputed", false)
+	if !ok {
+		return
+	}
+	filter := repository.PaymentFilter{
+		PayerAddress:   strings.ToLower(c.Query("payer_address")),
+		ServiceCode:    c.Query("service_code"),
+		PaymentMethod:  c.Query("payment_method"),
+		Status:         repository.PaymentStatus(c.Query("status")),
+		IncludeExpired: includeExpired,
+		Disputed:       disputed,
+	}
+
+	page, pageSize, ok := queryPage(c, 20)
+	if !ok {
+		return
+	}
+
+	payments, total, err := h.paymentRepo.ListPayments(c.Request.Context(), filter, repository.Pagination{
+		Page:     page,
+		PageSize: pageSize,
+	})
+	if err != nil {
+		h.logger.Error("failed to list payments", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, PaymentResponse{
 			Success: false,
 			Error:   "Internal server error",
 		})
 		return
 	}
+	if payments == nil {
+		payments = []*repository.Payment{}
+	}
 
 	c.JSON(http.StatusOK, PaymentResponse{
 		Success: true,
-		Data:    payment,
+		Data: gin.H{
+			"payments":  payments,
+			"total":     total,
+			"page":      page,
+			"page_size": pageSize,
+		},
 	})
 }
 
+// isStripeFailure reports whether a Stripe error means Stripe is unhealthy
+// (5xx, rate limiting, network errors) rather than rejecting the request
+func isStripeFailure(err error) bool {
+	if errors.Is(err, outbound.ErrRateLimited) {
+		return false
+	}
+	var stripeErr *stripe.Error
+	if errors.As(err, &stripeErr) {
+		return stripeErr.HTTPStatusCode >= 500 || stripeErr.HTTPStatusCode == http.StatusTooManyRequests
+	}
+	return true
+}
+
+// paymentCallback validates an optional callback URL and secret, returning
+// nil for both when no callback was requested
+func (h *PaymentHandler) paymentCallback(rawURL, secret string) (*string, *string, error) {
+	return validateCallback(rawURL, secret, h.insecureCallbacks)
+}
+
+// validateCallback implements paymentCallback for every payment handler
+func validateCallback(rawURL, secret string, allowInsecure bool) (*string, *string, error) {
+	if rawURL == "" {
+		if secret != "" {
+			return nil, nil, errors.New("callback_secret requires a callback_url")
+		}
+		return nil, nil, nil
+	}
+	if err := callbacks.ValidateURL(rawURL, allowInsecure); err != nil {
+		return nil, nil, err
+	}
+	if err := callbacks.ValidateSecret(secret); err != nil {
+		return nil, nil, err
+	}
+	return &rawURL, &secret, nil
+}
+
 // isValidTxHash validates an Ethereum transaction hash
 func isValidTxHash(hash string) bool {
 	if len(hash) != 66 {
@@ -529,3 +1012,26 @@ func isValidTxHash(hash string) bool {
 	}
 	return true
 }
+
+// clientCountry is the request's GeoIP country for storing on a record (nil
+// when unknown)
+func clientCountry(c *gin.Context) *string {
+	if country := middleware.ClientCountry(c); country != "" {
+		return &country
+	}
+	return nil
+}
+
+// referrerCode normalizes an optional checkout referral code (nil when
+// none), reporting whether it is well formed. Codes of no partner are kept
+// and simply earn nothing.
+func referrerCode(code string) (*string, bool) {
+	if strings.TrimSpace(code) == "" {
+		return nil, true
+	}
+	code, ok := partners.NormalizeCode(code)
+	if !ok {
+		return nil, false
+	}
+	return &code, true
+}
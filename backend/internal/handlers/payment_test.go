@@ -49,6 +49,14 @@ func (m *MockPaymentRepository) GetPaymentByStripeSession(ctx context.Context, s
 	return args.Get(0).(*repository.Payment), args.Error(1)
 }
 
+func (m *MockPaymentRepository) GetPaymentByStripePaymentID(ctx context.Context, paymentIntentID string) (*repository.Payment, error) {
+	args := m.Called(ctx, paymentIntentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repository.Payment), args.Error(1)
+}
+
 func (m *MockPaymentRepository) UpdatePaymentStatus(ctx context.Context, id string, status repository.PaymentStatus, details *repository.PaymentStatusUpdate) error {
 	args := m.Called(ctx, id, status, details)
 	return args.Error(0)
@@ -62,6 +70,35 @@ func (m *MockPaymentRepository) ListPayments(ctx context.Context, filter reposit
 	return args.Get(0).([]*repository.Payment), args.Get(1).(int64), args.Error(2)
 }
 
+func (m *MockPaymentRepository) ArchivePayments(ctx context.Context, before time.Time, limit int) (int64, error) {
+	args := m.Called(ctx, before, limit)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockPaymentRepository) GetArchivedPayment(ctx context.Context, id string) (*repository.Payment, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repository.Payment), args.Error(1)
+}
+
+func (m *MockPaymentRepository) ListArchivedPayments(ctx context.Context, filter repository.PaymentFilter, page repository.Pagination) ([]*repository.Payment, int64, error) {
+	args := m.Called(ctx, filter, page)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]*repository.Payment), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockPaymentRepository) ExpirePayments(ctx context.Context, method string, before time.Time, limit int) ([]string, error) {
+	args := m.Called(ctx, method, before, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
 func (m *MockPaymentRepository) CreateKYCVerification(ctx context.Context, verification *repository.KYCVerification) error {
 	args := m.Called(ctx, verification)
 	return args.Error(0)
@@ -119,6 +156,7 @@ func setupPaymentTestRouter(handler *handlers.PaymentHandler) *gin.Engine {
 			payments.GET("/stripe/session/:sessionId", handler.GetPaymentBySession)
 			payments.POST("/crypto", handler.ProcessCryptoPayment)
 		}
+		api.GET("/admin/payments", handler.ListPayments)
 	}
 
 	return router
@@ -242,6 +280,75 @@ func TestPaymentHandler_GetPayment(t *testing.T) {
 	}
 }
 
+func TestPaymentHandler_GetPayment_RequiresPaymentIDParam(t *testing.T) {
+	mockPayRepo := new(MockPaymentRepository)
+	handler := handlers.NewPaymentHandler(mockPayRepo, new(MockPricingRepository), zap.NewNop())
+
+	// A route registered under another parameter name reads no ID
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/api/v1/payments/:id", handler.GetPayment)
+	req, _ := http.NewRequest("GET", "/api/v1/payments/pay-001", nil)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	mockPayRepo.AssertNotCalled(t, "GetPayment", mock.Anything, mock.Anything)
+}
+
+// Tests for ListPayments
+func TestPaymentHandler_ListPayments(t *testing.T) {
+	tests := []struct {
+		name   string
+		query  string
+		filter repository.PaymentFilter
+	}{
+		{
+			name:   "expired payments are left out by default",
+			query:  "?payer_address=0xABC",
+			filter: repository.PaymentFilter{PayerAddress: "0xabc"},
+		},
+		{
+			name:   "include_expired lists them",
+			query:  "?include_expired=true&payment_method=stripe",
+			filter: repository.PaymentFilter{PaymentMethod: "stripe", IncludeExpired: true},
+		},
+		{
+			name:   "status filter",
+			query:  "?status=expired",
+			filter: repository.PaymentFilter{Status: repository.PaymentStatusExpired},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockPayRepo := new(MockPaymentRepository)
+			mockPayRepo.On("ListPayments", mock.Anything, tt.filter, repository.Pagination{Page: 1, PageSize: 20}).
+				Return([]*repository.Payment{createTestPayment()}, int64(1), nil)
+
+			handler := handlers.NewPaymentHandler(mockPayRepo, new(MockPricingRepository), zap.NewNop())
+			router := setupPaymentTestRouter(handler)
+
+			req, _ := http.NewRequest("GET", "/api/v1/admin/payments"+tt.query, nil)
+			resp := httptest.NewRecorder()
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, http.StatusOK, resp.Code)
+			var body struct {
+				Data struct {
+					Payments []repository.Payment `json:"payments"`
+					Total    int64                `json:"total"`
+				} `json:"data"`
+			}
+			require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &body))
+			assert.Equal(t, int64(1), body.Data.Total)
+			assert.Len(t, body.Data.Payments, 1)
+
+			mockPayRepo.AssertExpectations(t)
+		})
+	}
+}
+
 // Tests for GetPaymentBySession
 func TestPaymentHandler_GetPaymentBySession(t *testing.T) {
 	tests := []struct {
@@ -636,6 +743,39 @@ func TestPaymentHandler_CreateStripeCheckout_Validation(t *testing.T) {
 				assert.Equal(t, "Invalid payer address format", body["error"])
 			},
 		},
+		{
+			name: "bad request - http callback url",
+			requestBody: map[string]interface{}{
+				"service_code":    "kyc_verification",
+				"payer_address":   validAddress,
+				"success_url":     "https://example.com/success",
+				"cancel_url":      "https://example.com/cancel",
+				"callback_url":    "http://example.com/callback",
+				"callback_secret": "callback-secret-123",
+			},
+			setupMock:      func(payRepo *MockPaymentRepository, priceRepo *MockPricingRepository) {},
+			expectedStatus: http.StatusBadRequest,
+			checkBody: func(t *testing.T, body map[string]interface{}) {
+				assert.False(t, body["success"].(bool))
+				assert.Contains(t, body["error"].(string), "invalid callback url")
+			},
+		},
+		{
+			name: "bad request - callback url without secret",
+			requestBody: map[string]interface{}{
+				"service_code":  "kyc_verification",
+				"payer_address": validAddress,
+				"success_url":   "https://example.com/success",
+				"cancel_url":    "https://example.com/cancel",
+				"callback_url":  "https://example.com/callback",
+			},
+			setupMock:      func(payRepo *MockPaymentRepository, priceRepo *MockPricingRepository) {},
+			expectedStatus: http.StatusBadRequest,
+			checkBody: func(t *testing.T, body map[string]interface{}) {
+				assert.False(t, body["success"].(bool))
+				assert.Contains(t, body["error"].(string), "invalid callback secret")
+			},
+		},
 		{
 			name: "bad request - service not found",
 			requestBody: map[string]interface{}{
@@ -878,3 +1018,58 @@ func TestPaymentHandler_ProcessCryptoPayment_Tolerance(t *testing.T) {
 		})
 	}
 }
+
+// MockTxTracker implements handlers.TxTracker for testing
+type MockTxTracker struct {
+	mock.Mock
+}
+
+func (m *MockTxTracker) Track(ctx context.Context, recordType, recordID, txHash string) error {
+	args := m.Called(ctx, recordType, recordID, txHash)
+	return args.Error(0)
+}
+
+func TestPaymentHandler_ProcessCryptoPayment_WithTracker(t *testing.T) {
+	validAddress := "0x1234567890123456789012345678901234567890"
+	validTxHash := "0x1234567890123456789012345678901234567890123456789012345678901234"
+
+	mockPayRepo := new(MockPaymentRepository)
+	mockPriceRepo := new(MockPricingRepository)
+	mockTracker := new(MockTxTracker)
+
+	mockPriceRepo.On("GetPricing", mock.Anything, "kyc_verification").
+		Return(createTestPricingForPayment(), nil)
+	mockPayRepo.On("CreatePayment", mock.Anything, mock.AnythingOfType("*repository.Payment")).
+		Return(nil)
+	mockTracker.On("Track", mock.Anything, repository.TrackedRecordPayment, mock.Anything, validTxHash).
+		Return(nil)
+
+	handler := handlers.NewPaymentHandler(mockPayRepo, mockPriceRepo, zap.NewNop())
+	handler.SetTxTracker(mockTracker)
+	router := setupPaymentTestRouter(handler)
+
+	reqBody, _ := json.Marshal(map[string]interface{}{
+		"service_code":   "kyc_verification",
+		"payer_address":  validAddress,
+		"payment_method": "eth",
+		"tx_hash":        validTxHash,
+		"amount":         0.005,
+	})
+	req, _ := http.NewRequest("POST", "/api/v1/payments/crypto", bytes.NewBuffer(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	resp := httptest.NewRecorder()
+
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	var body map[string]interface{}
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &body))
+	data := body["data"].(map[string]interface{})
+	assert.Equal(t, "processing", data["status"])
+
+	// Payment must not be completed until the tracker confirms it
+	mockPayRepo.AssertNotCalled(t, "UpdatePaymentStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockPayRepo.AssertExpectations(t)
+	mockTracker.AssertExpectations(t)
+}
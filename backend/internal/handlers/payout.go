@@ -0,0 +1,306 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/payouts"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// PayoutHandler handles scheduling and reviewing royalty and grant payouts
+type PayoutHandler struct {
+	service *payouts.Service
+	repo    repository.PayoutRepository
+	logger  *zap.Logger
+}
+
+// NewPayoutHandler creates a new payout handler with injected dependencies
+func NewPayoutHandler(service *payouts.Service, repo repository.PayoutRepository, logger *zap.Logger) *PayoutHandler {
+	return &PayoutHandler{service: service, repo: repo, logger: logger}
+}
+
+// PayoutResponse wraps payout API responses
+type PayoutResponse struct {
+	Success bool        `json:"success"`
+	Data    interface{} `json:"data,omitempty"`
+	Error   string      `json:"error,omitempty"`
+}
+
+// CreatePayoutRequest is the body of POST /api/v1/admin/payouts
+type CreatePayoutRequest struct {
+	Kind         string     `json:"kind" binding:"required"`
+	Recipient    string     `json:"recipient"`
+	Currency     string     `json:"currency" binding:"required"`
+	Amount       string     `json:"amount" binding:"required"`
+	Reference    string     `json:"reference"`
+	Description  string     `json:"description"`
+	ScheduledFor *time.Time `json:"scheduled_for"`
+	CreatedBy    string     `json:"created_by" binding:"required"`
+}
+
+// ReviewPayoutRequest is the body of the approve, reject and cancel endpoints
+type ReviewPayoutRequest struct {
+	Reviewer string `json:"reviewer" binding:"required"`
+	Reason   string `json:"reason"`
+}
+
+// CreatePayout handles POST /api/v1/admin/payouts
+// @Summary Schedule a payout
+// @Description Schedules a royalty or grant payout pending approval. Amount is in base units (18 decimals). A royalty without a recipient goes to payouts.royalty_receiver; a grant needs the governance proposal that approved it as reference.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param request body CreatePayoutRequest true "Payout"
+// @Success 201 {object} PayoutResponse{data=repository.Payout}
+// @Failure 400 {object} PayoutResponse
+// @Failure 409 {object} PayoutResponse
+// @Router /api/v1/admin/payouts [post]
+func (h *PayoutHandler) CreatePayout(c *gin.Context) {
+	var req CreatePayoutRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, PayoutResponse{
+			Success: false,
+			Error:   "Invalid request: " + err.Error(),
+		})
+		return
+	}
+
+	p, err := h.service.Schedule(c.Request.Context(), payouts.Request{
+		Kind:         repository.PayoutKind(strings.ToLower(req.Kind)),
+		Recipient:    req.Recipient,
+		Currency:     req.Currency,
+		Amount:       req.Amount,
+		Reference:    req.Reference,
+		Description:  req.Description,
+		ScheduledFor: req.ScheduledFor,
+		CreatedBy:    req.CreatedBy,
+	})
+	if err != nil {
+		h.respondError(c, "", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, PayoutResponse{
+		Success: true,
+		Data:    p,
+	})
+}
+
+// ListPayouts handles GET /api/v1/admin/payouts
+// @Summary List payouts
+// @Description Returns payouts, newest first
+// @Tags admin
+// @Produce json
+// @Param kind query string false "royalty or grant"
+// @Param status query string false "pending_approval, approved, rejected, cancelled, sending, submitted, confirmed or failed"
+// @Param recipient query string false "Recipient address"
+// @Param reference query string false "Governance proposal ID"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(20)
+// @Success 200 {object} PayoutResponse
+// @Failure 401 {object} PayoutResponse
+// @Router /api/v1/admin/payouts [get]
+func (h *PayoutHandler) ListPayouts(c *gin.Context) {
+	page, pageSize, ok := queryPage(c, 20)
+	if !ok {
+		return
+	}
+
+	list, total, err := h.repo.ListPayouts(c.Request.Context(), repository.PayoutFilter{
+		Kind:      repository.PayoutKind(c.Query("kind")),
+		Status:    repository.PayoutStatus(c.Query("status")),
+		Recipient: strings.ToLower(c.Query("recipient")),
+		Reference: c.Query("reference"),
+	}, repository.Pagination{
+		Page:     page,
+		PageSize: pageSize,
+	})
+	if err != nil {
+		h.logger.Error("failed to list payouts", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, PayoutResponse{
+			Success: false,
+			Error:   "Internal server error",
+		})
+		return
+	}
+	if list == nil {
+		list = []*repository.Payout{}
+	}
+
+	c.JSON(http.StatusOK, PayoutResponse{
+		Success: true,
+		Data: gin.H{
+			"payouts":   list,
+			"total":     total,
+			"page":      page,
+			"page_size": pageSize,
+		},
+	})
+}
+
+// GetPayout handles GET /api/v1/admin/payouts/:id
+// @Summary Get payout
+// @Description Returns a payout with its review and transaction details
+// @Tags admin
+// @Produce json
+// @Param id path string true "Payout ID"
+// @Success 200 {object} PayoutResponse{data=repository.Payout}
+// @Failure 400 {object} PayoutResponse
+// @Failure 404 {object} PayoutResponse
+// @Router /api/v1/admin/payouts/{id} [get]
+func (h *PayoutHandler) GetPayout(c *gin.Context) {
+	id, ok := h.payoutID(c)
+	if !ok {
+		return
+	}
+
+	p, err := h.repo.GetPayout(c.Request.Context(), id)
+	if err != nil {
+		h.respondError(c, id, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, PayoutResponse{
+		Success: true,
+		Data:    p,
+	})
+}
+
+// ApprovePayout handles POST /api/v1/admin/payouts/:id/approve
+// @Summary Approve payout
+// @Description Approves a pending payout; it is sent once due. The reviewer must not be the payout's creator, and a grant's proposal must still have passed.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "Payout ID"
+// @Param request body ReviewPayoutRequest true "Reviewer"
+// @Success 200 {object} PayoutResponse{data=repository.Payout}
+// @Failure 400 {object} PayoutResponse
+// @Failure 403 {object} PayoutResponse
+// @Failure 404 {object} PayoutResponse
+// @Failure 409 {object} PayoutResponse
+// @Router /api/v1/admin/payouts/{id}/approve [post]
+func (h *PayoutHandler) ApprovePayout(c *gin.Context) {
+	h.review(c, func(id string, req ReviewPayoutRequest) (*repository.Payout, error) {
+		return h.service.Approve(c.Request.Context(), id, req.Reviewer)
+	})
+}
+
+// RejectPayout handles POST /api/v1/admin/payouts/:id/reject
+// @Summary Reject payout
+// @Description Rejects a pending payout
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "Payout ID"
+// @Param request body ReviewPayoutRequest true "Reviewer and reason"
+// @Success 200 {object} PayoutResponse{data=repository.Payout}
+// @Failure 400 {object} PayoutResponse
+// @Failure 404 {object} PayoutResponse
+// @Failure 409 {object} PayoutResponse
+// @Router /api/v1/admin/payouts/{id}/reject [post]
+func (h *PayoutHandler) RejectPayout(c *gin.Context) {
+	h.review(c, func(id string, req ReviewPayoutRequest) (*repository.Payout, error) {
+		return h.service.Reject(c.Request.Context(), id, req.Reviewer, req.Reason)
+	})
+}
+
+// CancelPayout handles POST /api/v1/admin/payouts/:id/cancel
+// @Summary Cancel payout
+// @Description Withdraws a pending or approved payout that has not been sent
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "Payout ID"
+// @Param request body ReviewPayoutRequest true "Reviewer and reason"
+// @Success 200 {object} PayoutResponse{data=repository.Payout}
+// @Failure 400 {object} PayoutResponse
+// @Failure 404 {object} PayoutResponse
+// @Failure 409 {object} PayoutResponse
+// @Router /api/v1/admin/payouts/{id}/cancel [post]
+func (h *PayoutHandler) CancelPayout(c *gin.Context) {
+	h.review(c, func(id string, req ReviewPayoutRequest) (*repository.Payout, error) {
+		return h.service.Cancel(c.Request.Context(), id, req.Reviewer, req.Reason)
+	})
+}
+
+// review parses the payout ID and review body, then applies action
+func (h *PayoutHandler) review(c *gin.Context, action func(id string, req ReviewPayoutRequest) (*repository.Payout, error)) {
+	id, ok := h.payoutID(c)
+	if !ok {
+		return
+	}
+	var req ReviewPayoutRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, PayoutResponse{
+			Success: false,
+			Error:   "Invalid request: " + err.Error(),
+		})
+		return
+	}
+
+	p, err := action(id, req)
+	if err != nil {
+		h.respondError(c, id, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, PayoutResponse{
+		Success: true,
+		Data:    p,
+	})
+}
+
+// payoutID reads the :id parameter, answering 400 when it is not a UUID
+func (h *PayoutHandler) payoutID(c *gin.Context) (string, bool) {
+	id := c.Param("id")
+	if !uuidPattern.MatchString(id) {
+		c.JSON(http.StatusBadRequest, PayoutResponse{
+			Success: false,
+			Error:   "Invalid payout ID",
+		})
+		return "", false
+	}
+	return id, true
+}
+
+// respondError maps payout service and repository errors to responses
+func (h *PayoutHandler) respondError(c *gin.Context, id string, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, payouts.ErrUnsupportedKind),
+		errors.Is(err, payouts.ErrUnsupportedCurrency),
+		errors.Is(err, payouts.ErrInvalidRecipient),
+		errors.Is(err, payouts.ErrInvalidAmount),
+		errors.Is(err, payouts.ErrNoRoyaltyReceiver),
+		errors.Is(err, payouts.ErrProposalRequired),
+		errors.Is(err, payouts.ErrTokenUnavailable):
+		status = http.StatusBadRequest
+	case errors.Is(err, payouts.ErrSelfApproval):
+		status = http.StatusForbidden
+	case errors.Is(err, repository.ErrPayoutNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, payouts.ErrProposalNotPassed),
+		errors.Is(err, repository.ErrPayoutClosed):
+		status = http.StatusConflict
+	}
+
+	if status == http.StatusInternalServerError {
+		h.logger.Error("payout request failed", zap.String("payout_id", id), zap.Error(err))
+		c.JSON(status, PayoutResponse{
+			Success: false,
+			Error:   "Internal server error",
+		})
+		return
+	}
+	c.JSON(status, PayoutResponse{
+		Success: false,
+		Error:   err.Error(),
+	})
+}
@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/preflight"
+)
+
+// PreflightHandler serves the contract preflight report
+type PreflightHandler struct {
+	checker *preflight.Checker
+	logger  *zap.Logger
+}
+
+// NewPreflightHandler creates a new preflight handler with injected dependencies
+func NewPreflightHandler(checker *preflight.Checker, logger *zap.Logger) *PreflightHandler {
+	return &PreflightHandler{checker: checker, logger: logger}
+}
+
+// PreflightResponse wraps preflight API responses
+type PreflightResponse struct {
+	Success bool              `json:"success"`
+	Data    *preflight.Report `json:"data,omitempty"`
+	Error   string            `json:"error,omitempty"`
+}
+
+// GetPreflight handles GET /api/v1/admin/preflight
+// @Summary Run the contract preflight
+// @Description Checks that every required contract mapping has an active address on the configured chain, that bytecode exists at the registered addresses and that the relayer's forwarder and its EIP-712 domain match the registry. Each failed check names its fix. Answers 503 while any check fails.
+// @Tags admin
+// @Produce json
+// @Success 200 {object} PreflightResponse
+// @Failure 401 {object} PreflightResponse
+// @Failure 503 {object} PreflightResponse
+// @Router /api/v1/admin/preflight [get]
+func (h *PreflightHandler) GetPreflight(c *gin.Context) {
+	report := h.checker.Run(c.Request.Context())
+	if !report.Passed {
+		c.JSON(http.StatusServiceUnavailable, PreflightResponse{
+			Success: false,
+			Data:    report,
+			Error:   report.Err().Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, PreflightResponse{Success: true, Data: report})
+}
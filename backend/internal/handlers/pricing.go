@@ -1,9 +1,9 @@
 package handlers
 
 import (
+	"context"
 	"errors"
 	"net/http"
-	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
@@ -14,9 +14,16 @@ import (
 // PricingHandler handles pricing-related API endpoints
 type PricingHandler struct {
 	repo   repository.PricingRepository
+	quoter PriceQuoter
 	logger *zap.Logger
 }
 
+// PriceQuoter converts USD prices to ETH or NEXUS (implemented by
+// oracle.Service)
+type PriceQuoter interface {
+	QuoteUSD(ctx context.Context, usd float64, currency string) (float64, error)
+}
+
 // NewPricingHandler creates a new pricing handler with injected dependencies
 func NewPricingHandler(repo repository.PricingRepository, logger *zap.Logger) *PricingHandler {
 	return &PricingHandler{
@@ -25,6 +32,12 @@ func NewPricingHandler(repo repository.PricingRepository, logger *zap.Logger) *P
 	}
 }
 
+// SetPriceQuoter quotes ETH and NEXUS payment options that have no fixed
+// price from the service's USD price
+func (h *PricingHandler) SetPriceQuoter(quoter PriceQuoter) {
+	h.quoter = quoter
+}
+
 // PricingResponse wraps pricing API responses
 type PricingResponse struct {
 	Success bool               `json:"success"`
@@ -42,6 +55,8 @@ type UpdatePricingRequest struct {
 	IsActive      *bool    `json:"is_active,omitempty"`
 	Operator      string   `json:"operator" binding:"required"`
 	Reason        string   `json:"reason,omitempty"`
+	// ExpectedVersion is the version the update is based on (or If-Match)
+	ExpectedVersion *int64 `json:"expected_version,omitempty"`
 }
 
 // UpdatePaymentMethodRequest represents a request to update a payment method
@@ -52,6 +67,8 @@ type UpdatePaymentMethodRequest struct {
 	FeePercent   *float64 `json:"fee_percent,omitempty"`
 	DisplayOrder *int     `json:"display_order,omitempty"`
 	Operator     string   `json:"operator" binding:"required"`
+	// ExpectedVersion is the version the update is based on (or If-Match)
+	ExpectedVersion *int64 `json:"expected_version,omitempty"`
 }
 
 // GetPricing handles GET /api/v1/pricing/:serviceCode
@@ -60,6 +77,7 @@ type UpdatePaymentMethodRequest struct {
 // @Tags pricing
 // @Produce json
 // @Param serviceCode path string true "Service code (e.g., kyc_verification)"
+// @Param fields query string false "Comma-separated record fields to return (id is always included)"
 // @Success 200 {object} PricingResponse
 // @Failure 404 {object} PricingResponse
 // @Router /api/v1/pricing/{serviceCode} [get]
@@ -83,6 +101,7 @@ func (h *PricingHandler) GetPricing(c *gin.Context) {
 		return
 	}
 
+	setVersionETag(c, pricing.Version)
 	c.JSON(http.StatusOK, PricingResponse{
 		Success: true,
 		Data:    pricing,
@@ -95,10 +114,14 @@ func (h *PricingHandler) GetPricing(c *gin.Context) {
 // @Tags pricing
 // @Produce json
 // @Param active_only query bool false "Only return active pricing (default: false)"
+// @Param fields query string false "Comma-separated record fields to return (id is always included)"
 // @Success 200 {object} PricingResponse
 // @Router /api/v1/pricing [get]
 func (h *PricingHandler) ListPricing(c *gin.Context) {
-	activeOnly, _ := strconv.ParseBool(c.DefaultQuery("active_only", "false"))
+	activeOnly, ok := queryBool(c, "active_only", false)
+	if !ok {
+		return
+	}
 
 	pricingList, err := h.repo.ListPricing(c.Request.Context(), activeOnly)
 	if err != nil {
@@ -121,16 +144,18 @@ func (h *PricingHandler) ListPricing(c *gin.Context) {
 
 // UpdatePricing handles PUT /api/v1/pricing/:serviceCode
 // @Summary Update pricing for a service (admin only)
-// @Description Updates pricing information for a specific service
+// @Description Updates pricing information for a specific service. With If-Match (the ETag of GET) or expected_version the update only applies to that version; otherwise 409 returns the current pricing.
 // @Tags pricing
 // @Accept json
 // @Produce json
 // @Param serviceCode path string true "Service code"
+// @Param If-Match header string false "Version the update is based on, e.g. \"3\""
 // @Param request body UpdatePricingRequest true "Pricing update request"
 // @Success 200 {object} PricingResponse
 // @Failure 400 {object} PricingResponse
 // @Failure 403 {object} PricingResponse
 // @Failure 404 {object} PricingResponse
+// @Failure 409 {object} PricingResponse
 // @Router /api/v1/pricing/{serviceCode} [put]
 func (h *PricingHandler) UpdatePricing(c *gin.Context) {
 	serviceCode := c.Param("serviceCode")
@@ -153,18 +178,28 @@ func (h *PricingHandler) UpdatePricing(c *gin.Context) {
 		return
 	}
 
+	version, err := expectedVersion(c, req.ExpectedVersion)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, PricingResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
 	// TODO: Check if operator has ADMIN role via auth middleware
 
 	update := &repository.PricingUpdate{
-		PriceUSD:      req.PriceUSD,
-		PriceETH:      req.PriceETH,
-		PriceNEXUS:    req.PriceNEXUS,
-		MarkupPercent: req.MarkupPercent,
-		IsActive:      req.IsActive,
-		UpdatedBy:     req.Operator,
+		PriceUSD:        req.PriceUSD,
+		PriceETH:        req.PriceETH,
+		PriceNEXUS:      req.PriceNEXUS,
+		MarkupPercent:   req.MarkupPercent,
+		IsActive:        req.IsActive,
+		UpdatedBy:       req.Operator,
+		ExpectedVersion: version,
 	}
 
-	err := h.repo.UpdatePricing(c.Request.Context(), serviceCode, update)
+	err = h.repo.UpdatePricing(c.Request.Context(), serviceCode, update)
 	if err != nil {
 		if errors.Is(err, repository.ErrPricingNotFound) {
 			c.JSON(http.StatusNotFound, PricingResponse{
@@ -173,6 +208,15 @@ func (h *PricingHandler) UpdatePricing(c *gin.Context) {
 			})
 			return
 		}
+		if errors.Is(err, repository.ErrVersionConflict) {
+			current, _ := h.repo.GetPricing(c.Request.Context(), serviceCode)
+			c.JSON(http.StatusConflict, PricingResponse{
+				Success: false,
+				Data:    current,
+				Error:   "Pricing was changed by another update; review the current version and retry",
+			})
+			return
+		}
 		h.logger.Error("failed to update pricing",
 			zap.String("service", serviceCode),
 			zap.String("operator", req.Operator),
@@ -187,6 +231,9 @@ func (h *PricingHandler) UpdatePricing(c *gin.Context) {
 
 	// Fetch updated pricing to return
 	pricing, _ := h.repo.GetPricing(c.Request.Context(), serviceCode)
+	if pricing != nil {
+		setVersionETag(c, pricing.Version)
+	}
 
 	h.logger.Info("pricing updated",
 		zap.String("service", serviceCode),
@@ -211,10 +258,9 @@ func (h *PricingHandler) UpdatePricing(c *gin.Context) {
 // @Router /api/v1/pricing/{serviceCode}/history [get]
 func (h *PricingHandler) GetPricingHistory(c *gin.Context) {
 	serviceCode := c.Param("serviceCode")
-	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
-
-	if limit < 1 || limit > 100 {
-		limit = 20
+	limit, ok := queryLimit(c, "limit", 20, 100)
+	if !ok {
+		return
 	}
 
 	history, err := h.repo.GetPricingHistory(c.Request.Context(), serviceCode, limit)
@@ -243,10 +289,14 @@ func (h *PricingHandler) GetPricingHistory(c *gin.Context) {
 // @Tags pricing
 // @Produce json
 // @Param active_only query bool false "Only return active methods (default: true)"
+// @Param fields query string false "Comma-separated record fields to return (id is always included)"
 // @Success 200 {object} PricingResponse
 // @Router /api/v1/payment-methods [get]
 func (h *PricingHandler) ListPaymentMethods(c *gin.Context) {
-	activeOnly, _ := strconv.ParseBool(c.DefaultQuery("active_only", "true"))
+	activeOnly, ok := queryBool(c, "active_only", true)
+	if !ok {
+		return
+	}
 
 	methods, err := h.repo.ListPaymentMethods(c.Request.Context(), activeOnly)
 	if err != nil {
@@ -296,6 +346,7 @@ func (h *PricingHandler) GetPaymentMethod(c *gin.Context) {
 		return
 	}
 
+	setVersionETag(c, method.Version)
 	c.JSON(http.StatusOK, PricingResponse{
 		Success: true,
 		Data:    method,
@@ -304,15 +355,17 @@ func (h *PricingHandler) GetPaymentMethod(c *gin.Context) {
 
 // UpdatePaymentMethod handles PUT /api/v1/payment-methods/:methodCode
 // @Summary Update a payment method (admin only)
-// @Description Updates a payment method configuration
+// @Description Updates a payment method configuration. With If-Match (the ETag of GET) or expected_version the update only applies to that version; otherwise 409 returns the current method.
 // @Tags pricing
 // @Accept json
 // @Produce json
 // @Param methodCode path string true "Method code"
+// @Param If-Match header string false "Version the update is based on, e.g. \"3\""
 // @Param request body UpdatePaymentMethodRequest true "Update request"
 // @Success 200 {object} PricingResponse
 // @Failure 400 {object} PricingResponse
 // @Failure 404 {object} PricingResponse
+// @Failure 409 {object} PricingResponse
 // @Router /api/v1/payment-methods/{methodCode} [put]
 func (h *PricingHandler) UpdatePaymentMethod(c *gin.Context) {
 	methodCode := c.Param("methodCode")
@@ -334,15 +387,25 @@ func (h *PricingHandler) UpdatePaymentMethod(c *gin.Context) {
 		return
 	}
 
+	version, err := expectedVersion(c, req.ExpectedVersion)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, PricingResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
 	update := &repository.PaymentMethodUpdate{
-		IsActive:     req.IsActive,
-		MinAmountUSD: req.MinAmountUSD,
-		MaxAmountUSD: req.MaxAmountUSD,
-		FeePercent:   req.FeePercent,
-		DisplayOrder: req.DisplayOrder,
+		IsActive:        req.IsActive,
+		MinAmountUSD:    req.MinAmountUSD,
+		MaxAmountUSD:    req.MaxAmountUSD,
+		FeePercent:      req.FeePercent,
+		DisplayOrder:    req.DisplayOrder,
+		ExpectedVersion: version,
 	}
 
-	err := h.repo.UpdatePaymentMethod(c.Request.Context(), methodCode, update)
+	err = h.repo.UpdatePaymentMethod(c.Request.Context(), methodCode, update)
 	if err != nil {
 		if errors.Is(err, repository.ErrPaymentMethodNotFound) {
 			c.JSON(http.StatusNotFound, PricingResponse{
@@ -351,6 +414,15 @@ func (h *PricingHandler) UpdatePaymentMethod(c *gin.Context) {
 			})
 			return
 		}
+		if errors.Is(err, repository.ErrVersionConflict) {
+			current, _ := h.repo.GetPaymentMethod(c.Request.Context(), methodCode)
+			c.JSON(http.StatusConflict, PricingResponse{
+				Success: false,
+				Data:    current,
+				Error:   "Payment method was changed by another update; review the current version and retry",
+			})
+			return
+		}
 		h.logger.Error("failed to update payment method",
 			zap.String("method", methodCode),
 			zap.String("operator", req.Operator),
@@ -364,6 +436,9 @@ func (h *PricingHandler) UpdatePaymentMethod(c *gin.Context) {
 	}
 
 	method, _ := h.repo.GetPaymentMethod(c.Request.Context(), methodCode)
+	if method != nil {
+		setVersionETag(c, method.Version)
+	}
 
 	h.logger.Info("payment method updated",
 		zap.String("method", methodCode),
@@ -379,7 +454,7 @@ func (h *PricingHandler) UpdatePaymentMethod(c *gin.Context) {
 
 // GetKYCPricing handles GET /api/v1/pricing/kyc
 // @Summary Get KYC verification pricing
-// @Description Convenience endpoint for KYC verification pricing with all payment options
+// @Description Convenience endpoint for KYC verification pricing with all payment options. ETH and NEXUS options without a fixed price are quoted from the USD price at the oracle rate (quoted=true).
 // @Tags pricing
 // @Produce json
 // @Success 200 {object} PricingResponse
@@ -417,23 +492,29 @@ func (h *PricingHandler) GetKYCPricing(c *gin.Context) {
 		Currency    string  `json:"currency"`
 		FeePercent  float64 `json:"fee_percent"`
 		TotalAmount float64 `json:"total_amount"`
+		Quoted      bool    `json:"quoted,omitempty"`
 	}
 
 	var options []PaymentOption
 	for _, m := range methods {
 		var amount float64
 		var currency string
+		var quoted bool
 
 		switch m.MethodCode {
 		case "nexus":
+			currency = "NEXUS"
 			if pricing.PriceNEXUS != nil {
 				amount = *pricing.PriceNEXUS
-				currency = "NEXUS"
+			} else {
+				amount, quoted = h.quoteUSD(ctx, pricing.PriceUSD, currency)
 			}
 		case "eth":
+			currency = "ETH"
 			if pricing.PriceETH != nil {
 				amount = *pricing.PriceETH
-				currency = "ETH"
+			} else {
+				amount, quoted = h.quoteUSD(ctx, pricing.PriceUSD, currency)
 			}
 		case "stripe":
 			amount = pricing.PriceUSD
@@ -449,6 +530,7 @@ func (h *PricingHandler) GetKYCPricing(c *gin.Context) {
 				Currency:    currency,
 				FeePercent:  m.FeePercent,
 				TotalAmount: amount + fee,
+				Quoted:      quoted,
 			})
 		}
 	}
@@ -464,3 +546,17 @@ func (h *PricingHandler) GetKYCPricing(c *gin.Context) {
 		},
 	})
 }
+
+// quoteUSD converts a USD price at the oracle rate. Without a quoter or a
+// usable rate the option is left out.
+func (h *PricingHandler) quoteUSD(ctx context.Context, usd float64, currency string) (float64, bool) {
+	if h.quoter == nil || usd <= 0 {
+		return 0, false
+	}
+	amount, err := h.quoter.QuoteUSD(ctx, usd, currency)
+	if err != nil {
+		h.logger.Warn("cannot quote price", zap.String("currency", currency), zap.Error(err))
+		return 0, false
+	}
+	return amount, true
+}
@@ -562,6 +562,17 @@ func TestPricingHandler_GetPricingHistory(t *testing.T) {
 				assert.True(t, body["success"].(bool))
 			},
 		},
+		{
+			name:           "bad request - limit is not an integer",
+			serviceCode:    "kyc_verification",
+			queryParams:    "?limit=ten",
+			setupMock:      func(m *MockPricingRepository) {},
+			expectedStatus: http.StatusBadRequest,
+			checkBody: func(t *testing.T, body map[string]interface{}) {
+				assert.False(t, body["success"].(bool))
+				assert.Contains(t, body["error"], "limit")
+			},
+		},
 		{
 			name:        "success - limit clamped to max 100",
 			serviceCode: "kyc_verification",
@@ -0,0 +1,213 @@
+package handlers
+
+import (
+	"errors"
+	"math/big"
+	"net/http"
+	"net/mail"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/middleware"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// maxDisplayNameLength is the longest display name, in characters
+const maxDisplayNameLength = 50
+
+// profileCurrencies are the currencies a profile can prefer
+var profileCurrencies = map[string]bool{"USD": true, "ETH": true, "NEXUS": true}
+
+// ProfileHandler handles users' self-managed profiles. Changes need a
+// wallet session (middleware.RequireSession); anyone can read the public part.
+type ProfileHandler struct {
+	repo   repository.ProfileRepository
+	logger *zap.Logger
+}
+
+// NewProfileHandler creates a new profile handler with injected dependencies
+func NewProfileHandler(repo repository.ProfileRepository, logger *zap.Logger) *ProfileHandler {
+	return &ProfileHandler{repo: repo, logger: logger}
+}
+
+// ProfileResponse wraps profile API responses
+type ProfileResponse struct {
+	Success bool        `json:"success"`
+	Data    interface{} `json:"data,omitempty"`
+	Error   string      `json:"error,omitempty"`
+}
+
+// ProfileAvatar references the NFT shown as a profile's avatar
+type ProfileAvatar struct {
+	ChainID  int64  `json:"chain_id" binding:"required"`
+	Contract string `json:"contract" binding:"required"`
+	TokenID  string `json:"token_id" binding:"required"` // decimal
+}
+
+// UpsertProfileRequest is the body of PUT /api/v1/profile. Omitted fields
+// are cleared.
+type UpsertProfileRequest struct {
+	DisplayName       *string        `json:"display_name"`
+	Avatar            *ProfileAvatar `json:"avatar"`
+	Email             *string        `json:"email"`              // notifications are sent here
+	PreferredCurrency string         `json:"preferred_currency"` // USD (default), ETH or NEXUS
+}
+
+// GetProfile handles GET /api/v1/profile
+// @Summary Get your profile
+// @Description Returns the signed-in wallet's profile, email included
+// @Tags profiles
+// @Produce json
+// @Success 200 {object} ProfileResponse{data=repository.Profile}
+// @Failure 401 {object} ProfileResponse
+// @Failure 404 {object} ProfileResponse
+// @Router /api/v1/profile [get]
+func (h *ProfileHandler) GetProfile(c *gin.Context) {
+	profile, err := h.repo.GetProfile(c.Request.Context(), middleware.SessionAddress(c))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, ProfileResponse{Success: true, Data: profile})
+}
+
+// UpsertProfile handles PUT /api/v1/profile
+// @Summary Create or replace your profile
+// @Description Sets the signed-in wallet's display name, avatar NFT, notification email and preferred currency. The avatar is a reference only; ownership of the NFT is not checked.
+// @Tags profiles
+// @Accept json
+// @Produce json
+// @Param request body UpsertProfileRequest true "Profile"
+// @Success 200 {object} ProfileResponse{data=repository.Profile}
+// @Failure 400 {object} ProfileResponse
+// @Failure 401 {object} ProfileResponse
+// @Router /api/v1/profile [put]
+func (h *ProfileHandler) UpsertProfile(c *gin.Context) {
+	var req UpsertProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ProfileResponse{
+			Success: false,
+			Error:   "Invalid request: " + err.Error(),
+		})
+		return
+	}
+	profile, err := profileFromRequest(middleware.SessionAddress(c), req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ProfileResponse{Success: false, Error: err.Error()})
+		return
+	}
+
+	if err := h.repo.UpsertProfile(c.Request.Context(), profile); err != nil {
+		h.respondError(c, err)
+		return
+	}
+	h.logger.Info("profile updated", zap.String("address", profile.Address))
+	c.JSON(http.StatusOK, ProfileResponse{Success: true, Data: profile})
+}
+
+// DeleteProfile handles DELETE /api/v1/profile
+// @Summary Delete your profile
+// @Tags profiles
+// @Produce json
+// @Success 200 {object} ProfileResponse
+// @Failure 401 {object} ProfileResponse
+// @Failure 404 {object} ProfileResponse
+// @Router /api/v1/profile [delete]
+func (h *ProfileHandler) DeleteProfile(c *gin.Context) {
+	address := middleware.SessionAddress(c)
+	if err := h.repo.DeleteProfile(c.Request.Context(), address); err != nil {
+		h.respondError(c, err)
+		return
+	}
+	h.logger.Info("profile deleted", zap.String("address", address))
+	c.JSON(http.StatusOK, ProfileResponse{Success: true})
+}
+
+// GetPublicProfile handles GET /api/v1/profiles/:address
+// @Summary Get a public profile
+// @Description Returns an address's profile without its email
+// @Tags profiles
+// @Produce json
+// @Param address path string true "Wallet address"
+// @Success 200 {object} ProfileResponse{data=repository.Profile}
+// @Failure 400 {object} ProfileResponse
+// @Failure 404 {object} ProfileResponse
+// @Router /api/v1/profiles/{address} [get]
+func (h *ProfileHandler) GetPublicProfile(c *gin.Context) {
+	address := c.Param("address")
+	if !isValidAddress(address) {
+		c.JSON(http.StatusBadRequest, ProfileResponse{Success: false, Error: "Invalid address"})
+		return
+	}
+
+	profile, err := h.repo.GetProfile(c.Request.Context(), strings.ToLower(address))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+	profile.Email = nil
+	c.JSON(http.StatusOK, ProfileResponse{Success: true, Data: profile})
+}
+
+// profileFromRequest validates req into address's profile
+func profileFromRequest(address string, req UpsertProfileRequest) (*repository.Profile, error) {
+	profile := &repository.Profile{Address: address, PreferredCurrency: "USD"}
+
+	if req.DisplayName != nil {
+		name := strings.TrimSpace(*req.DisplayName)
+		if utf8.RuneCountInString(name) > maxDisplayNameLength {
+			return nil, errors.New("display_name is longer than 50 characters")
+		}
+		if name != "" {
+			profile.DisplayName = &name
+		}
+	}
+
+	if a := req.Avatar; a != nil {
+		if a.ChainID <= 0 {
+			return nil, errors.New("avatar.chain_id must be positive")
+		}
+		if !isValidAddress(a.Contract) {
+			return nil, errors.New("avatar.contract must be an address")
+		}
+		if id, ok := new(big.Int).SetString(a.TokenID, 10); !ok || id.Sign() < 0 || id.BitLen() > 256 {
+			return nil, errors.New("avatar.token_id must be a decimal token ID")
+		}
+		contract := strings.ToLower(a.Contract)
+		profile.AvatarChainID, profile.AvatarContract, profile.AvatarTokenID = &a.ChainID, &contract, &a.TokenID
+	}
+
+	if req.Email != nil && strings.TrimSpace(*req.Email) != "" {
+		email := strings.TrimSpace(*req.Email)
+		parsed, err := mail.ParseAddress(email)
+		if err != nil || parsed.Address != email || len(email) > 254 {
+			return nil, errors.New("email is not a valid address")
+		}
+		profile.Email = &email
+	}
+
+	if req.PreferredCurrency != "" {
+		currency := strings.ToUpper(req.PreferredCurrency)
+		if !profileCurrencies[currency] {
+			return nil, errors.New("preferred_currency must be USD, ETH or NEXUS")
+		}
+		profile.PreferredCurrency = currency
+	}
+	return profile, nil
+}
+
+// respondError maps profile repository errors to responses
+func (h *ProfileHandler) respondError(c *gin.Context, err error) {
+	if errors.Is(err, repository.ErrProfileNotFound) {
+		c.JSON(http.StatusNotFound, ProfileResponse{Success: false, Error: "Profile not found"})
+		return
+	}
+	h.logger.Error("profile request failed", zap.Error(err))
+	c.JSON(http.StatusInternalServerError, ProfileResponse{
+		Success: false,
+		Error:   "Internal server error",
+	})
+}
@@ -0,0 +1,88 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/handlers"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/middleware"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/storage/memory"
+)
+
+// sessions accepts tokens naming their address
+type sessions map[string]string
+
+func (s sessions) Authenticate(token string) (string, error) {
+	if address, ok := s[token]; ok {
+		return address, nil
+	}
+	return "", errors.New("invalid session")
+}
+
+func TestProfileHandler_SelfServiceAndPublicView(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	store := memory.NewStore()
+	handler := handlers.NewProfileHandler(store.Profiles, zap.NewNop())
+
+	const address = "0x00000000000000000000000000000000000000aa"
+	router := gin.New()
+	own := router.Group("/api/v1/profile", middleware.RequireSession(sessions{"alice-token": address}))
+	own.GET("", handler.GetProfile)
+	own.PUT("", handler.UpsertProfile)
+	own.DELETE("", handler.DeleteProfile)
+	router.GET("/api/v1/profiles/:address", handler.GetPublicProfile)
+
+	do := func(method, path, token, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	profile := `{"display_name":" Alice ","email":"alice@example.com","preferred_currency":"eth",` +
+		`"avatar":{"chain_id":1,"contract":"0x00000000000000000000000000000000000000C1","token_id":"42"}}`
+	assert.Equal(t, http.StatusUnauthorized, do(http.MethodPut, "/api/v1/profile", "", profile).Code)
+	assert.Equal(t, http.StatusUnauthorized, do(http.MethodPut, "/api/v1/profile", "forged", profile).Code)
+
+	for _, bad := range []string{
+		`{"display_name":"` + strings.Repeat("a", 51) + `"}`,
+		`{"email":"Alice <alice@example.com>"}`,
+		`{"preferred_currency":"EUR"}`,
+		`{"avatar":{"chain_id":1,"contract":"0x123","token_id":"42"}}`,
+		`{"avatar":{"chain_id":1,"contract":"0x00000000000000000000000000000000000000C1","token_id":"0x2a"}}`,
+	} {
+		assert.Equal(t, http.StatusBadRequest, do(http.MethodPut, "/api/v1/profile", "alice-token", bad).Code, bad)
+	}
+
+	w := do(http.MethodPut, "/api/v1/profile", "alice-token", profile)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Contains(t, w.Body.String(), `"display_name":"Alice"`)
+	assert.Contains(t, w.Body.String(), `"preferred_currency":"ETH"`)
+	assert.Contains(t, w.Body.String(), `"avatar_contract":"0x00000000000000000000000000000000000000c1"`)
+
+	w = do(http.MethodGet, "/api/v1/profile", "alice-token", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "alice@example.com")
+
+	// The public view leaves the email out
+	w = do(http.MethodGet, "/api/v1/profiles/0x00000000000000000000000000000000000000AA", "", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"display_name":"Alice"`)
+	assert.NotContains(t, w.Body.String(), "alice@example.com")
+
+	assert.Equal(t, http.StatusOK, do(http.MethodDelete, "/api/v1/profile", "alice-token", "").Code)
+	assert.Equal(t, http.StatusNotFound, do(http.MethodGet, "/api/v1/profile", "alice-token", "").Code)
+	assert.Equal(t, http.StatusNotFound, do(http.MethodGet, "/api/v1/profiles/"+address, "", "").Code)
+}
@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Query parameters are parsed strictly: a value that is present but not of
+// the parameter's type is answered with 400 rather than read as the zero
+// value. Values that parse but are out of range still fall back to the
+// default, as clients have long relied on that for page sizes and limits.
+
+// maxPageSize caps page_size on every paged list
+const maxPageSize = 100
+
+// queryInt reads the integer query parameter name, defaultValue when it is
+// absent. When ok is false a 400 has been written.
+func queryInt(c *gin.Context, name string, defaultValue int) (int, bool) {
+	raw := c.Query(name)
+	if raw == "" {
+		return defaultValue, true
+	}
+	v, err := strconv.Atoi(raw)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid "+name+": must be an integer")
+		return 0, false
+	}
+	return v, true
+}
+
+// queryInt64 is queryInt for 64-bit values such as chain IDs
+func queryInt64(c *gin.Context, name string, defaultValue int64) (int64, bool) {
+	raw := c.Query(name)
+	if raw == "" {
+		return defaultValue, true
+	}
+	v, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid "+name+": must be an integer")
+		return 0, false
+	}
+	return v, true
+}
+
+// queryBool reads the boolean query parameter name (true, false, 1, 0, t, f
+// in any case), defaultValue when it is absent. When ok is false a 400 has
+// been written.
+func queryBool(c *gin.Context, name string, defaultValue bool) (bool, bool) {
+	raw := c.Query(name)
+	if raw == "" {
+		return defaultValue, true
+	}
+	v, err := strconv.ParseBool(raw)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid "+name+": must be true or false")
+		return false, false
+	}
+	return v, true
+}
+
+// queryLimit reads an integer between 1 and maxValue, defaultValue when it is
+// absent or out of range
+func queryLimit(c *gin.Context, name string, defaultValue, maxValue int) (int, bool) {
+	v, ok := queryInt(c, name, defaultValue)
+	if !ok {
+		return 0, false
+	}
+	if v < 1 || v > maxValue {
+		v = defaultValue
+	}
+	return v, true
+}
+
+// queryPage reads page (1 if below) and page_size (defaultSize unless
+// between 1 and maxPageSize)
+func queryPage(c *gin.Context, defaultSize int) (page, pageSize int, ok bool) {
+	if page, ok = queryInt(c, "page", 1); !ok {
+		return 0, 0, false
+	}
+	if pageSize, ok = queryLimit(c, "page_size", defaultSize, maxPageSize); !ok {
+		return 0, 0, false
+	}
+	return max(page, 1), pageSize, true
+}
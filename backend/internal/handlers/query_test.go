@@ -0,0 +1,54 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/storage/memory"
+)
+
+func TestListUserMetaTxs_QueryParsing(t *testing.T) {
+	h, _ := newSimulationRelayer(t, memory.NewMemoryRelayerRepo())
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/api/v1/relay/user/:address", h.ListUserMetaTxs)
+
+	tests := []struct {
+		name         string
+		query        string
+		wantStatus   int
+		wantPage     float64
+		wantPageSize float64
+		wantError    string
+	}{
+		{name: "defaults", query: "", wantStatus: http.StatusOK, wantPage: 1, wantPageSize: 20},
+		{name: "explicit", query: "?page=2&page_size=5", wantStatus: http.StatusOK, wantPage: 2, wantPageSize: 5},
+		{name: "out of range falls back", query: "?page=0&page_size=500", wantStatus: http.StatusOK, wantPage: 1, wantPageSize: 20},
+		{name: "page not an integer", query: "?page=two", wantStatus: http.StatusBadRequest, wantError: "Invalid page: must be an integer"},
+		{name: "trailing garbage", query: "?page_size=10abc", wantStatus: http.StatusBadRequest, wantError: "Invalid page_size: must be an integer"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/relay/user/"+simSigner.Hex()+tt.query, nil))
+			require.Equal(t, tt.wantStatus, w.Code, w.Body.String())
+
+			var body map[string]interface{}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+			if tt.wantError != "" {
+				assert.Equal(t, false, body["success"])
+				assert.Equal(t, tt.wantError, body["error"])
+				return
+			}
+			data := body["data"].(map[string]interface{})
+			assert.Equal(t, tt.wantPage, data["page"])
+			assert.Equal(t, tt.wantPageSize, data["page_size"])
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/contracts"
+)
+
+// forwarderDBName is the contract registry name of NexusForwarder
+const forwarderDBName = "nexusForwarder"
+
+// forwarderBinding is the forwarder contract relays are verified against and
+// sent through
+type forwarderBinding struct {
+	address  common.Address
+	contract *contracts.NexusForwarder
+}
+
+// ContractAddressChanged implements contractnotify.Listener. When the
+// chain's nexusForwarder moves, relays switch to the new forwarder and its
+// EIP-712 domain is looked up again; relays already sent stay tracked.
+func (h *RelayerHandler) ContractAddressChanged(chainID int64, dbName, address string) {
+	if chainID != h.chainID.Int64() || dbName != forwarderDBName || !common.IsHexAddress(address) {
+		return
+	}
+	current := h.forwarder.Load()
+	addr := common.HexToAddress(address)
+	if current.address == addr {
+		return
+	}
+
+	forwarder, err := contracts.NewNexusForwarder(addr, h.ethClient)
+	if err != nil {
+		h.logger.Error("failed to bind new forwarder, keeping the current one",
+			zap.String("forwarder", current.address.Hex()),
+			zap.String("new_forwarder", addr.Hex()),
+			zap.Error(err),
+		)
+		return
+	}
+
+	h.domainMu.Lock()
+	h.forwarder.Store(&forwarderBinding{address: addr, contract: forwarder})
+	h.domain = nil
+	h.domainMu.Unlock()
+
+	h.logger.Info("relays switched to new forwarder",
+		zap.String("old_forwarder", current.address.Hex()),
+		zap.String("forwarder", addr.Hex()),
+	)
+}
@@ -0,0 +1,45 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/storage/memory"
+)
+
+func TestRelayerHandler_SwitchesForwarder(t *testing.T) {
+	h, _ := newSimulationRelayer(t, memory.NewMemoryRelayerRepo())
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/relay/forwarder", h.GetForwarderAddress)
+
+	forwarder := func() string {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/relay/forwarder", nil))
+		require.Equal(t, http.StatusOK, w.Code)
+		var resp struct {
+			Data struct {
+				Address string `json:"address"`
+			} `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		return resp.Data.Address
+	}
+	assert.Equal(t, simForwarder.Hex(), forwarder())
+
+	moved := common.HexToAddress("0x00000000000000000000000000000000000000f2")
+	// Other chains and contracts are ignored
+	h.ContractAddressChanged(1, "nexusForwarder", moved.Hex())
+	h.ContractAddressChanged(31337, "nexusToken", moved.Hex())
+	assert.Equal(t, simForwarder.Hex(), forwarder())
+
+	h.ContractAddressChanged(31337, "nexusForwarder", moved.Hex())
+	assert.Equal(t, moved.Hex(), forwarder())
+}
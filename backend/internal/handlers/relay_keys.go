@@ -0,0 +1,367 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/relaykeys"
+)
+
+// relayerKeySource resolves a key ID with the configured signing service
+type relayerKeySource func(ctx context.Context, id string) (relaykeys.Backend, error)
+
+// loadRelayerKeys builds the relayer key pool from the environment.
+// RELAYER_SIGNER picks where keys live:
+//
+//	local (default)   RELAYER_PRIVATE_KEY (one hex key), RELAYER_PRIVATE_KEYS
+//	                  (comma-separated) and RELAYER_KEYSTORE (comma-separated
+//	                  encrypted key files or directories, passphrase in
+//	                  RELAYER_KEYSTORE_PASSWORD or RELAYER_KEYSTORE_PASSWORD_FILE)
+//	aws-kms           AWS_REGION, AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY,
+//	                  AWS_SESSION_TOKEN, AWS_KMS_ENDPOINT
+//	gcp-kms           GCP_ACCESS_TOKEN (else the metadata server), GCP_KMS_ENDPOINT
+//	vault-transit     VAULT_ADDR, VAULT_TOKEN, VAULT_TRANSIT_MOUNT
+//
+// With a signing service, RELAYER_KMS_KEYS lists the key IDs (comma-separated)
+// and local keys are refused. The returned source is nil for local keys.
+//
+// The private keys and keystore passphrase are read through secrets.
+func loadRelayerKeys(ctx context.Context, secrets SecretSource) (*relaykeys.Pool, relayerKeySource, error) {
+	kind := strings.TrimSpace(os.Getenv("RELAYER_SIGNER"))
+	if kind == "" || kind == "local" {
+		keys, err := loadLocalRelayerKeys(secrets)
+		if err != nil {
+			return nil, nil, err
+		}
+		pool, err := relaykeys.NewPool(keys...)
+		return pool, nil, err
+	}
+
+	for _, name := range []string{"RELAYER_PRIVATE_KEY", "RELAYER_PRIVATE_KEYS"} {
+		if secrets.Get(name) != "" {
+			return nil, nil, fmt.Errorf("%s cannot be combined with RELAYER_SIGNER=%s", name, kind)
+		}
+	}
+	if os.Getenv("RELAYER_KEYSTORE") != "" {
+		return nil, nil, fmt.Errorf("RELAYER_KEYSTORE cannot be combined with RELAYER_SIGNER=%s", kind)
+	}
+
+	var source relayerKeySource
+	switch kind {
+	case "aws-kms":
+		region := os.Getenv("AWS_REGION")
+		if region == "" {
+			region = os.Getenv("AWS_DEFAULT_REGION")
+		}
+		cfg := relaykeys.AWSConfig{
+			Region:          region,
+			AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+			SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+			SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+			Endpoint:        os.Getenv("AWS_KMS_ENDPOINT"),
+		}
+		source = func(ctx context.Context, id string) (relaykeys.Backend, error) {
+			return relaykeys.NewAWSKMSKey(ctx, cfg, id)
+		}
+	case "gcp-kms":
+		cfg := relaykeys.GCPConfig{
+			AccessToken: os.Getenv("GCP_ACCESS_TOKEN"),
+			Endpoint:    os.Getenv("GCP_KMS_ENDPOINT"),
+		}
+		source = func(ctx context.Context, id string) (relaykeys.Backend, error) {
+			return relaykeys.NewGCPKMSKey(ctx, cfg, id)
+		}
+	case "vault-transit":
+		cfg := relaykeys.VaultConfig{
+			Address: os.Getenv("VAULT_ADDR"),
+			Token:   os.Getenv("VAULT_TOKEN"),
+			Mount:   os.Getenv("VAULT_TRANSIT_MOUNT"),
+		}
+		source = func(ctx context.Context, id string) (relaykeys.Backend, error) {
+			return relaykeys.NewVaultTransitKey(ctx, cfg, id)
+		}
+	default:
+		return nil, nil, fmt.Errorf("unknown RELAYER_SIGNER %q (local, aws-kms, gcp-kms or vault-transit)", kind)
+	}
+
+	ids := splitList(os.Getenv("RELAYER_KMS_KEYS"))
+	if len(ids) == 0 {
+		return nil, nil, fmt.Errorf("RELAYER_SIGNER=%s requires RELAYER_KMS_KEYS", kind)
+	}
+	keys := make([]relaykeys.Backend, 0, len(ids))
+	for _, id := range ids {
+		key, err := source(ctx, id)
+		if err != nil {
+			return nil, nil, err
+		}
+		keys = append(keys, key)
+	}
+	pool, err := relaykeys.NewPool(keys...)
+	return pool, source, err
+}
+
+// loadLocalRelayerKeys reads in-memory keys from the environment; keys from
+// all sources are combined and at least one is required
+func loadLocalRelayerKeys(secrets SecretSource) ([]relaykeys.Backend, error) {
+	var keys []relaykeys.Backend
+
+	hexKeys := splitList(secrets.Get("RELAYER_PRIVATE_KEYS"))
+	if single := strings.TrimSpace(secrets.Get("RELAYER_PRIVATE_KEY")); single != "" {
+		hexKeys = append([]string{single}, hexKeys...)
+	}
+	for _, hexKey := range hexKeys {
+		key, err := relaykeys.ParsePrivateKey(hexKey)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, relaykeys.NewLocalKey(key))
+	}
+
+	if paths := splitList(os.Getenv("RELAYER_KEYSTORE")); len(paths) > 0 {
+		passphrase := secrets.Get("RELAYER_KEYSTORE_PASSWORD")
+		if file := os.Getenv("RELAYER_KEYSTORE_PASSWORD_FILE"); file != "" {
+			raw, err := os.ReadFile(file)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read RELAYER_KEYSTORE_PASSWORD_FILE: %w", err)
+			}
+			passphrase = strings.TrimRight(string(raw), "\r\n")
+		}
+		for _, path := range paths {
+			loaded, err := relaykeys.LoadKeystore(path, passphrase)
+			if err != nil {
+				return nil, err
+			}
+			for _, key := range loaded {
+				keys = append(keys, relaykeys.NewLocalKey(key))
+			}
+		}
+	}
+
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no relayer keys configured (set RELAYER_PRIVATE_KEY, RELAYER_PRIVATE_KEYS or RELAYER_KEYSTORE)")
+	}
+	return keys, nil
+}
+
+// SyncRelayerKeys reloads the local relayer keys after their secrets changed:
+// new keys are put into rotation, then keys no longer configured are retired.
+// Keys of a signing service are left alone.
+func (h *RelayerHandler) SyncRelayerKeys() {
+	if h.keySource != nil {
+		return
+	}
+	keys, err := loadLocalRelayerKeys(h.secrets)
+	if err != nil {
+		h.logger.Error("relayer keys not reloaded", zap.Error(err))
+		return
+	}
+
+	configured := make(map[common.Address]bool, len(keys))
+	for _, key := range keys {
+		configured[key.Address()] = true
+		if _, err := h.keys.Add(key); err == nil {
+			h.logger.Warn("relayer key added", zap.String("address", key.Address().Hex()))
+		}
+	}
+	for _, info := range h.keys.Keys() {
+		addr := common.HexToAddress(info.Address)
+		if info.Backend != "local" || configured[addr] {
+			continue
+		}
+		if err := h.keys.Retire(addr); err != nil {
+			h.logger.Error("relayer key not retired", zap.String("address", info.Address), zap.Error(err))
+			continue
+		}
+		h.logger.Warn("relayer key retired", zap.String("address", info.Address))
+	}
+}
+
+func splitList(s string) []string {
+	var out []string
+	for _, part := range strings.Split(s, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
+
+// RelayerKeyInfo is a key in the relayer pool with its balance
+type RelayerKeyInfo struct {
+	relaykeys.Info
+	BalanceWei string `json:"balance_wei"`
+}
+
+// AddRelayerKeyRequest adds a key to the relayer pool: a key ID of the
+// configured signing service, or (local signer only) a raw hex private key or
+// an encrypted keystore file with its passphrase
+type AddRelayerKeyRequest struct {
+	KeyID      string          `json:"key_id,omitempty"`
+	PrivateKey string          `json:"private_key,omitempty"`
+	Keystore   json.RawMessage `json:"keystore,omitempty"`
+	Passphrase string          `json:"passphrase,omitempty"`
+}
+
+// ListRelayerKeys handles GET /api/v1/admin/relay/keys
+// @Summary List relayer keys
+// @Description Returns the keys relays are assigned to (round-robin, in this order) with each key's next nonce, relays sent and balance
+// @Tags admin
+// @Produce json
+// @Success 200 {object} RelayerResponse{data=[]RelayerKeyInfo}
+// @Failure 401 {object} RelayerResponse
+// @Router /api/v1/admin/relay/keys [get]
+func (h *RelayerHandler) ListRelayerKeys(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	infos := h.keys.Keys()
+	keys := make([]RelayerKeyInfo, 0, len(infos))
+	for _, info := range infos {
+		balance, err := h.ethClient.BalanceAt(ctx, common.HexToAddress(info.Address), nil)
+		if err != nil {
+			h.logger.Error("failed to get relayer balance", zap.String("address", info.Address), zap.Error(err))
+			balance = big.NewInt(0)
+		}
+		keys = append(keys, RelayerKeyInfo{Info: info, BalanceWei: balance.String()})
+	}
+
+	c.JSON(http.StatusOK, RelayerResponse{
+		Success: true,
+		Data:    keys,
+	})
+}
+
+// AddRelayerKey handles POST /api/v1/admin/relay/keys
+// @Summary Add a relayer key
+// @Description Puts a key into rotation on this instance. Keys added here are not persisted; add them to RELAYER_KMS_KEYS (or RELAYER_KEYSTORE) to keep them across restarts.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param request body AddRelayerKeyRequest true "Key to add"
+// @Success 201 {object} RelayerResponse{data=relaykeys.Info}
+// @Failure 400 {object} RelayerResponse
+// @Failure 401 {object} RelayerResponse
+// @Failure 409 {object} RelayerResponse
+// @Router /api/v1/admin/relay/keys [post]
+func (h *RelayerHandler) AddRelayerKey(c *gin.Context) {
+	var req AddRelayerKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, RelayerResponse{
+			Success: false,
+			Error:   "Invalid request: " + err.Error(),
+		})
+		return
+	}
+
+	key, err := h.resolveRelayerKey(c.Request.Context(), req)
+	if err != nil {
+		if respondUnavailable(c, err) {
+			return
+		}
+		c.JSON(http.StatusBadRequest, RelayerResponse{
+			Success: false,
+			Error:   "Invalid request: " + err.Error(),
+		})
+		return
+	}
+
+	signer, err := h.keys.Add(key)
+	if errors.Is(err, relaykeys.ErrDuplicateKey) {
+		c.JSON(http.StatusConflict, RelayerResponse{
+			Success: false,
+			Error:   "Key is already in the relayer pool",
+		})
+		return
+	}
+
+	h.logger.Warn("relayer key added", zap.String("address", signer.Address.Hex()))
+	c.JSON(http.StatusCreated, RelayerResponse{
+		Success: true,
+		Data:    signer.Info(),
+		Message: "Key added to rotation on this instance",
+	})
+}
+
+// resolveRelayerKey turns an add request into a key for the configured signer
+func (h *RelayerHandler) resolveRelayerKey(ctx context.Context, req AddRelayerKeyRequest) (relaykeys.Backend, error) {
+	local := req.PrivateKey != "" || len(req.Keystore) > 0
+	switch {
+	case h.keySource != nil && local:
+		return nil, errors.New("local keys are refused with a signing service; give key_id")
+	case h.keySource != nil && req.KeyID != "":
+		return h.keySource(ctx, req.KeyID)
+	case h.keySource != nil:
+		return nil, errors.New("key_id is required")
+	case req.KeyID != "":
+		return nil, errors.New("key_id needs RELAYER_SIGNER set to a signing service")
+	case req.PrivateKey != "" && len(req.Keystore) > 0:
+		return nil, errors.New("give either private_key or keystore, not both")
+	case req.PrivateKey != "":
+		key, err := relaykeys.ParsePrivateKey(req.PrivateKey)
+		if err != nil {
+			return nil, err
+		}
+		return relaykeys.NewLocalKey(key), nil
+	case len(req.Keystore) > 0:
+		key, err := relaykeys.DecryptKeystore(req.Keystore, req.Passphrase)
+		if err != nil {
+			return nil, err
+		}
+		return relaykeys.NewLocalKey(key), nil
+	}
+	return nil, errors.New("private_key or keystore is required")
+}
+
+// RetireRelayerKey handles DELETE /api/v1/admin/relay/keys/:address
+// @Summary Retire a relayer key
+// @Description Takes a key out of rotation on this instance; a relay already being sent from it finishes. The last key cannot be retired.
+// @Tags admin
+// @Produce json
+// @Param address path string true "Relayer key address"
+// @Success 200 {object} RelayerResponse
+// @Failure 400 {object} RelayerResponse
+// @Failure 401 {object} RelayerResponse
+// @Failure 404 {object} RelayerResponse
+// @Failure 409 {object} RelayerResponse
+// @Router /api/v1/admin/relay/keys/{address} [delete]
+func (h *RelayerHandler) RetireRelayerKey(c *gin.Context) {
+	address := c.Param("address")
+	if !isValidAddress(address) {
+		c.JSON(http.StatusBadRequest, RelayerResponse{
+			Success: false,
+			Error:   "Invalid address format",
+		})
+		return
+	}
+
+	err := h.keys.Retire(common.HexToAddress(address))
+	switch {
+	case errors.Is(err, relaykeys.ErrKeyNotFound):
+		c.JSON(http.StatusNotFound, RelayerResponse{
+			Success: false,
+			Error:   "Key is not in the relayer pool",
+		})
+		return
+	case errors.Is(err, relaykeys.ErrLastKey):
+		c.JSON(http.StatusConflict, RelayerResponse{
+			Success: false,
+			Error:   "Cannot retire the last relayer key; add its replacement first",
+		})
+		return
+	}
+
+	h.logger.Warn("relayer key retired", zap.String("address", common.HexToAddress(address).Hex()))
+	c.JSON(http.StatusOK, RelayerResponse{
+		Success: true,
+		Message: "Key retired from rotation on this instance",
+	})
+}
@@ -0,0 +1,122 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/storage/memory"
+)
+
+func TestRelayerHandler_KeyRotation(t *testing.T) {
+	// newSimulationRelayer's key; the replacement is a fresh anvil account
+	original := simSigner.Hex()
+	const replacement = "0x90F79bf6EB2c4f870365E785982E1f101E93b906"
+	t.Setenv("RELAYER_PRIVATE_KEYS", "")
+	relayer, _ := newSimulationRelayer(t, memory.NewMemoryRelayerRepo())
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/api/v1/admin/relay/keys", relayer.ListRelayerKeys)
+	router.POST("/api/v1/admin/relay/keys", relayer.AddRelayerKey)
+	router.DELETE("/api/v1/admin/relay/keys/:address", relayer.RetireRelayerKey)
+
+	do := func(method, path string, body interface{}) *httptest.ResponseRecorder {
+		var payload []byte
+		if body != nil {
+			var err error
+			payload, err = json.Marshal(body)
+			require.NoError(t, err)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(method, path, bytes.NewReader(payload)))
+		return w
+	}
+	addresses := func() []string {
+		w := do(http.MethodGet, "/api/v1/admin/relay/keys", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		var resp struct {
+			Data []struct {
+				Address    string `json:"address"`
+				BalanceWei string `json:"balance_wei"`
+			} `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		var out []string
+		for _, k := range resp.Data {
+			out = append(out, k.Address)
+		}
+		return out
+	}
+
+	assert.Equal(t, []string{original}, addresses())
+
+	// The last key cannot be retired
+	w := do(http.MethodDelete, "/api/v1/admin/relay/keys/"+original, nil)
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	w = do(http.MethodPost, "/api/v1/admin/relay/keys", map[string]string{
+		"private_key": "0x7c852118294e51e653712a81e05800f419141751be58f605c371e15141b007a6",
+	})
+	require.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, []string{original, replacement}, addresses())
+
+	w = do(http.MethodPost, "/api/v1/admin/relay/keys", map[string]string{
+		"private_key": "7c852118294e51e653712a81e05800f419141751be58f605c371e15141b007a6",
+	})
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	w = do(http.MethodPost, "/api/v1/admin/relay/keys", map[string]string{"private_key": "0x1234"})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	w = do(http.MethodPost, "/api/v1/admin/relay/keys", map[string]string{})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// KMS key IDs need RELAYER_SIGNER
+	w = do(http.MethodPost, "/api/v1/admin/relay/keys", map[string]string{"key_id": "alias/relayer"})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = do(http.MethodDelete, "/api/v1/admin/relay/keys/"+original, nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, []string{replacement}, addresses())
+
+	w = do(http.MethodDelete, "/api/v1/admin/relay/keys/"+original, nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestRelayerHandler_SyncRelayerKeys(t *testing.T) {
+	relayer, _ := newSimulationRelayer(t, memory.NewMemoryRelayerRepo())
+	const replacement = "0x90F79bf6EB2c4f870365E785982E1f101E93b906"
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/api/v1/relay/info/relayer", relayer.GetRelayerAddress)
+	addresses := func() []string {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/relay/info/relayer", nil))
+		require.Equal(t, http.StatusOK, w.Code)
+		var resp struct {
+			Data struct {
+				Addresses []string `json:"addresses"`
+			} `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		return resp.Data.Addresses
+	}
+
+	// The rotated secret replaces the key (the source here is the environment)
+	t.Setenv("RELAYER_PRIVATE_KEY", "")
+	t.Setenv("RELAYER_PRIVATE_KEYS", "0x7c852118294e51e653712a81e05800f419141751be58f605c371e15141b007a6")
+	relayer.SyncRelayerKeys()
+	assert.Equal(t, []string{replacement}, addresses())
+
+	// An invalid secret leaves the pool as it is
+	t.Setenv("RELAYER_PRIVATE_KEYS", "0x1234")
+	relayer.SyncRelayerKeys()
+	assert.Equal(t, []string{replacement}, addresses())
+}
@@ -0,0 +1,143 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/maintenance"
+)
+
+// RelayPauseHandler handles the admin endpoints that pause and resume
+// meta-transaction relaying
+type RelayPauseHandler struct {
+	sw     *maintenance.Switch
+	logger *zap.Logger
+}
+
+// NewRelayPauseHandler creates a new relay pause handler with injected dependencies
+func NewRelayPauseHandler(sw *maintenance.Switch, logger *zap.Logger) *RelayPauseHandler {
+	return &RelayPauseHandler{
+		sw:     sw,
+		logger: logger,
+	}
+}
+
+// RelayPauseState is whether relaying is paused and why
+type RelayPauseState struct {
+	Paused    bool      `json:"paused"`
+	Reason    string    `json:"reason"`
+	UpdatedBy string    `json:"updated_by,omitempty"`
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+}
+
+// RelayPauseResponse wraps relay pause API responses
+type RelayPauseResponse struct {
+	Success bool             `json:"success"`
+	Data    *RelayPauseState `json:"data,omitempty"`
+	Message string           `json:"message,omitempty"`
+	Error   string           `json:"error,omitempty"`
+}
+
+// PauseRelayRequest pauses relaying
+type PauseRelayRequest struct {
+	Reason    string `json:"reason,omitempty"` // Returned with the 503; empty keeps the current one
+	UpdatedBy string `json:"updated_by" binding:"required"`
+}
+
+// ResumeRelayRequest resumes relaying
+type ResumeRelayRequest struct {
+	UpdatedBy string `json:"updated_by" binding:"required"`
+}
+
+// GetRelayPause handles GET /api/v1/admin/relay/pause
+// @Summary Get relay pause state
+// @Description Returns whether new meta-transaction relays are being rejected and the reason given
+// @Tags admin
+// @Produce json
+// @Success 200 {object} RelayPauseResponse
+// @Failure 401 {object} RelayPauseResponse
+// @Router /api/v1/admin/relay/pause [get]
+func (h *RelayPauseHandler) GetRelayPause(c *gin.Context) {
+	c.JSON(http.StatusOK, RelayPauseResponse{
+		Success: true,
+		Data:    relayPauseState(h.sw.State()),
+	})
+}
+
+// PauseRelay handles POST /api/v1/admin/relay/pause
+// @Summary Pause relaying
+// @Description While paused, POST /api/v1/relay answers 503 with the reason; status, nonce and other read endpoints keep working. The flag is stored in app_config and followed by all instances.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param request body PauseRelayRequest true "Pause request"
+// @Success 200 {object} RelayPauseResponse
+// @Failure 400 {object} RelayPauseResponse
+// @Failure 401 {object} RelayPauseResponse
+// @Router /api/v1/admin/relay/pause [post]
+func (h *RelayPauseHandler) PauseRelay(c *gin.Context) {
+	var req PauseRelayRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, RelayPauseResponse{
+			Success: false,
+			Error:   "Invalid request: " + err.Error(),
+		})
+		return
+	}
+	h.set(c, true, req.Reason, req.UpdatedBy)
+}
+
+// ResumeRelay handles POST /api/v1/admin/relay/resume
+// @Summary Resume relaying
+// @Description Accepts meta-transaction relays again on all instances
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param request body ResumeRelayRequest true "Resume request"
+// @Success 200 {object} RelayPauseResponse
+// @Failure 400 {object} RelayPauseResponse
+// @Failure 401 {object} RelayPauseResponse
+// @Router /api/v1/admin/relay/resume [post]
+func (h *RelayPauseHandler) ResumeRelay(c *gin.Context) {
+	var req ResumeRelayRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, RelayPauseResponse{
+			Success: false,
+			Error:   "Invalid request: " + err.Error(),
+		})
+		return
+	}
+	h.set(c, false, "", req.UpdatedBy)
+}
+
+// set flips the switch and writes the resulting state
+func (h *RelayPauseHandler) set(c *gin.Context, paused bool, reason, updatedBy string) {
+	state, err := h.sw.Set(c.Request.Context(), paused, reason, updatedBy)
+	if err != nil {
+		// Still in effect here; other instances follow once it is persisted
+		h.logger.Error("failed to persist relay pause", zap.Error(err))
+		c.JSON(http.StatusOK, RelayPauseResponse{
+			Success: true,
+			Data:    relayPauseState(state),
+			Message: "Applied to this instance only; persisting will be retried",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, RelayPauseResponse{
+		Success: true,
+		Data:    relayPauseState(state),
+	})
+}
+
+func relayPauseState(state maintenance.State) *RelayPauseState {
+	return &RelayPauseState{
+		Paused:    state.Enabled,
+		Reason:    state.Message,
+		UpdatedBy: state.UpdatedBy,
+		UpdatedAt: state.UpdatedAt,
+	}
+}
@@ -0,0 +1,78 @@
+package handlers_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/handlers"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/maintenance"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/storage/memory"
+)
+
+func TestRelayPauseHandler_PauseAndResume(t *testing.T) {
+	relayer, _ := newSimulationRelayer(t, memory.NewMemoryRelayerRepo())
+	configRepo := memory.NewMemoryAppConfigRepo()
+	sw := maintenance.NewSwitchWithConfig(configRepo, zap.NewNop(), maintenance.RelayPause)
+	relayer.SetPauseSwitch(sw)
+	h := handlers.NewRelayPauseHandler(sw, zap.NewNop())
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/api/v1/relay", relayer.Relay)
+	router.GET("/api/v1/relay/info/forwarder", relayer.GetForwarderAddress)
+	router.GET("/api/v1/admin/relay/pause", h.GetRelayPause)
+	router.POST("/api/v1/admin/relay/pause", h.PauseRelay)
+	router.POST("/api/v1/admin/relay/resume", h.ResumeRelay)
+
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(method, path, bytes.NewBufferString(body)))
+		return w
+	}
+
+	// Not paused: the relay request itself is validated
+	w := do(http.MethodPost, "/api/v1/relay", `{}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = do(http.MethodPost, "/api/v1/admin/relay/pause", `{"reason":"forwarder upgrade"}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code, "updated_by is required")
+
+	w = do(http.MethodPost, "/api/v1/admin/relay/pause", `{"reason":"forwarder upgrade","updated_by":"ops@nexus"}`)
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp handlers.RelayPauseResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.True(t, resp.Data.Paused)
+	assert.Equal(t, "forwarder upgrade", resp.Data.Reason)
+
+	w = do(http.MethodPost, "/api/v1/relay", `{}`)
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Contains(t, w.Body.String(), "forwarder upgrade")
+
+	// Read endpoints keep working
+	w = do(http.MethodGet, "/api/v1/relay/info/forwarder", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// The flag is persisted for other instances
+	other := maintenance.NewSwitchWithConfig(configRepo, zap.NewNop(), maintenance.RelayPause)
+	require.NoError(t, other.Refresh(context.Background()))
+	assert.True(t, other.Enabled())
+	assert.Equal(t, "forwarder upgrade", other.State().Message)
+
+	w = do(http.MethodPost, "/api/v1/admin/relay/resume", `{"updated_by":"ops@nexus"}`)
+	require.Equal(t, http.StatusOK, w.Code)
+	w = do(http.MethodGet, "/api/v1/admin/relay/pause", "")
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.False(t, resp.Data.Paused)
+
+	w = do(http.MethodPost, "/api/v1/relay", `{}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,288 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/contracts"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/relayqueue"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// relayQueueNamespace is the app_config namespace of the relay queue settings
+const relayQueueNamespace = "relay_queue"
+
+// DefaultGasHoldInterval is how often the gas price is checked for holding
+// back low priority relays
+const DefaultGasHoldInterval = 15 * time.Second
+
+// recoverBatchSize is how many pending meta-transactions Recover loads
+const recoverBatchSize = 500
+
+// maxRelayRetryAfter caps the Retry-After of a full queue
+const maxRelayRetryAfter = 5 * time.Minute
+
+// SetQueue makes Relay queue accepted meta-transactions (202) instead of
+// submitting them before responding
+func (h *RelayerHandler) SetQueue(q *relayqueue.Queue) {
+	h.queue = q
+}
+
+// HoldRelays reports whether a priority class is held back. It is the
+// queue's Hold function and only reads the state kept by RunGasHold.
+func (h *RelayerHandler) HoldRelays(p relayqueue.Priority) bool {
+	return p == relayqueue.PriorityLow && h.holdLowPriority.Load()
+}
+
+// RunGasHold holds back low priority relays while the gas price is above
+// app_config relay_queue.defer_low_priority_above_gwei (0 or unset never
+// holds). It checks every interval (DefaultGasHoldInterval if zero) until ctx
+// is cancelled.
+func (h *RelayerHandler) RunGasHold(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultGasHoldInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		hold, err := h.gasAboveHoldThreshold(ctx)
+		if err != nil && ctx.Err() == nil {
+			// Keep the last decision rather than flapping on RPC errors
+			h.logger.Warn("gas hold check failed", zap.Error(err))
+		} else if err == nil && h.holdLowPriority.Swap(hold) != hold {
+			h.logger.Warn("low priority relays hold changed", zap.Bool("held", hold))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (h *RelayerHandler) gasAboveHoldThreshold(ctx context.Context) (bool, error) {
+	gwei, err := h.configRepo.GetNumber(ctx, relayQueueNamespace, "defer_low_priority_above_gwei", h.chainID.Int64())
+	if errors.Is(err, repository.ErrAppConfigNotFound) || (err == nil && gwei <= 0) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	callCtx, cancel := context.WithTimeout(ctx, providerTimeout)
+	defer cancel()
+	gasPrice, err := h.ethClient.SuggestGasPrice(callCtx)
+	if err != nil {
+		return false, fmt.Errorf("failed to get gas price: %w", err)
+	}
+	return gasPrice.Cmp(new(big.Int).Mul(big.NewInt(gwei), big.NewInt(1e9))) > 0, nil
+}
+
+// relayPriority classifies a relay by its target contract using app_config
+// relay_queue.contract_priorities ({"<contract db_name>": "high"|"normal"|"low"}).
+// Unlisted targets are normal priority.
+func (h *RelayerHandler) relayPriority(ctx context.Context, to string) relayqueue.Priority {
+	if h.contractRepo == nil {
+		return relayqueue.PriorityNormal
+	}
+
+	var classes map[string]relayqueue.Priority
+	if err := h.configRepo.GetJSON(ctx, relayQueueNamespace, "contract_priorities", h.chainID.Int64(), &classes); err != nil {
+		if !errors.Is(err, repository.ErrAppConfigNotFound) {
+			h.logger.Warn("failed to load relay priorities", zap.Error(err))
+		}
+		return relayqueue.PriorityNormal
+	}
+
+	for dbName, priority := range classes {
+		contract, err := h.contractRepo.GetByChainAndDBName(ctx, h.chainID.Int64(), dbName)
+		if err != nil {
+			if !errors.Is(err, repository.ErrContractAddressNotFound) {
+				h.logger.Warn("failed to resolve relay priority contract", zap.String("contract", dbName), zap.Error(err))
+			}
+			continue
+		}
+		if strings.EqualFold(contract.Address, to) {
+			return priority
+		}
+	}
+	return relayqueue.PriorityNormal
+}
+
+// enqueueRelay queues an accepted meta-transaction and answers 202 with its
+// queue position. A full queue cancels the record and answers 503, with
+// Retry-After set to the soft limit's backlog when there is one.
+func (h *RelayerHandler) enqueueRelay(c *gin.Context, fwdReq contracts.NexusForwarderForwardRequest, signature []byte, metaTx *repository.MetaTransaction) {
+	ctx := c.Request.Context()
+	priority := h.relayPriority(ctx, metaTx.ToAddress)
+
+	pos, err := h.queue.Enqueue(h.relayJob(metaTx.ID, priority, fwdReq, signature))
+	if err != nil {
+		errMsg := err.Error()
+		if updateErr := h.repo.UpdateMetaTxStatus(ctx, metaTx.ID, &repository.MetaTxStatusUpdate{
+			Status:       repository.MetaTxStatusCancelled,
+			ErrorMessage: &errMsg,
+		}); updateErr != nil {
+			h.logger.Error("failed to cancel unqueued meta-tx", zap.String("id", metaTx.ID), zap.Error(updateErr))
+		}
+
+		h.logger.Warn("relay queue rejected meta-tx", zap.String("id", metaTx.ID), zap.Error(err))
+		retryAfter := 30
+		if backlog := h.queue.Backlog(); backlog > 0 {
+			retryAfter = int(min(backlog, maxRelayRetryAfter).Round(time.Second) / time.Second)
+		}
+		c.Header("Retry-After", strconv.Itoa(max(retryAfter, 1)))
+		c.JSON(http.StatusServiceUnavailable, RelayerResponse{
+			Success: false,
+			Error:   "Relay queue is full; please try again shortly",
+		})
+		return
+	}
+
+	h.logger.Info("meta-transaction queued",
+		zap.String("id", metaTx.ID),
+		zap.String("priority", priority.String()),
+		zap.Int("position", pos.Position),
+		zap.String("from", metaTx.FromAddress),
+		zap.String("to", metaTx.ToAddress),
+	)
+
+	c.JSON(http.StatusAccepted, RelayerResponse{
+		Success: true,
+		Data: gin.H{
+			"id":     metaTx.ID,
+			"status": "queued",
+			"queue":  pos,
+		},
+		Message: "Transaction queued for relay",
+	})
+}
+
+// relayJob submits a queued meta-transaction unless it expired or was
+// handled elsewhere meanwhile
+func (h *RelayerHandler) relayJob(id string, priority relayqueue.Priority, fwdReq contracts.NexusForwarderForwardRequest, signature []byte) *relayqueue.Job {
+	return &relayqueue.Job{
+		ID:       id,
+		Priority: priority,
+		Run: func(ctx context.Context) error {
+			metaTx, err := h.repo.GetMetaTx(ctx, id)
+			if err != nil {
+				return fmt.Errorf("loading queued meta-tx: %w", err)
+			}
+			if metaTx.Status != repository.MetaTxStatusPending {
+				return nil
+			}
+
+			if !metaTx.Deadline.After(time.Now()) {
+				errMsg := "deadline passed before submission"
+				return h.repo.UpdateMetaTxStatus(ctx, id, &repository.MetaTxStatusUpdate{
+					Status:       repository.MetaTxStatusExpired,
+					ErrorMessage: &errMsg,
+				})
+			}
+
+			_, err = h.relayMetaTx(ctx, fwdReq, signature, metaTx)
+			return err
+		},
+	}
+}
+
+// Recover queues meta-transactions left pending by a previous run and expires
+// those whose deadline passed meanwhile. Call it before serving relays.
+func (h *RelayerHandler) Recover(ctx context.Context) error {
+	if h.queue == nil {
+		return nil
+	}
+
+	expired, err := h.repo.GetExpiredMetaTxs(ctx, recoverBatchSize)
+	if err != nil {
+		return fmt.Errorf("loading expired meta-txs: %w", err)
+	}
+	errMsg := "deadline passed before submission"
+	for _, tx := range expired {
+		if err := h.repo.UpdateMetaTxStatus(ctx, tx.ID, &repository.MetaTxStatusUpdate{
+			Status:       repository.MetaTxStatusExpired,
+			ErrorMessage: &errMsg,
+		}); err != nil {
+			return fmt.Errorf("expiring meta-tx %s: %w", tx.ID, err)
+		}
+	}
+
+	pending, err := h.repo.GetPendingMetaTxs(ctx, recoverBatchSize)
+	if err != nil {
+		return fmt.Errorf("loading pending meta-txs: %w", err)
+	}
+	queued := 0
+	for _, tx := range pending {
+		fwdReq, signature, err := buildForwardRequest(RelayRequest{
+			From:      tx.FromAddress,
+			To:        tx.ToAddress,
+			Value:     tx.Value,
+			Gas:       tx.GasLimit,
+			Nonce:     tx.Nonce,
+			Deadline:  uint64(tx.Deadline.Unix()),
+			Data:      tx.Calldata,
+			Signature: tx.Signature,
+		})
+		if err != nil {
+			h.logger.Warn("skipping unrecoverable pending meta-tx", zap.String("id", tx.ID), zap.Error(err))
+			continue
+		}
+		_, err = h.queue.Enqueue(h.relayJob(tx.ID, h.relayPriority(ctx, tx.ToAddress), fwdReq, signature))
+		if errors.Is(err, relayqueue.ErrDuplicate) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("queueing pending meta-tx %s: %w", tx.ID, err)
+		}
+		queued++
+	}
+
+	if len(expired) > 0 || queued > 0 {
+		h.logger.Info("recovered pending meta-transactions",
+			zap.Int("queued", queued),
+			zap.Int("expired", len(expired)),
+		)
+	}
+	return nil
+}
+
+// GetRelayQueue handles GET /api/v1/admin/relay/queue
+// @Summary Get relay queue stats
+// @Description Returns queued meta-transactions by priority class, submissions in flight, the worker count and the classes currently held back
+// @Tags admin
+// @Produce json
+// @Success 200 {object} RelayerResponse{data=relayqueue.Stats}
+// @Failure 401 {object} RelayerResponse
+// @Failure 404 {object} RelayerResponse
+// @Router /api/v1/admin/relay/queue [get]
+func (h *RelayerHandler) GetRelayQueue(c *gin.Context) {
+	if h.queue == nil {
+		c.JSON(http.StatusNotFound, RelayerResponse{
+			Success: false,
+			Error:   "Relay queue is not enabled",
+		})
+		return
+	}
+	c.JSON(http.StatusOK, RelayerResponse{
+		Success: true,
+		Data:    h.queue.Stats(),
+	})
+}
+
+// metaTxStatus is a meta-transaction with its queue position while queued
+type metaTxStatus struct {
+	*repository.MetaTransaction
+	Queue *relayqueue.Position `json:"queue,omitempty"`
+}
@@ -0,0 +1,82 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/relayqueue"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/storage/memory"
+)
+
+func TestRelayerHandler_RecoverQueuesPendingMetaTxs(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRelayerRepo()
+	relayer, _ := newSimulationRelayer(t, repo)
+
+	pendingTx := func(deadline time.Time) *repository.MetaTransaction {
+		tx := &repository.MetaTransaction{
+			FromAddress: "0x70997970c51812dc3a010c7d01b50e0d17dc79c8",
+			ToAddress:   "0xe7f1725e7734ce288f8367e1bb143e90bb3f0512",
+			Calldata:    "0x01",
+			Value:       "0",
+			GasLimit:    100000,
+			Deadline:    deadline,
+			Signature:   "0x" + strings.Repeat("11", 65),
+			Status:      repository.MetaTxStatusPending,
+		}
+		require.NoError(t, repo.CreateMetaTx(ctx, tx))
+		return tx
+	}
+	first := pendingTx(time.Now().Add(time.Hour))
+	second := pendingTx(time.Now().Add(time.Hour))
+	expired := pendingTx(time.Now().Add(-time.Minute))
+
+	// Not started, so recovered jobs stay queued
+	queue := relayqueue.New(relayqueue.Config{}, zap.NewNop())
+	relayer.SetQueue(queue)
+	require.NoError(t, relayer.Recover(ctx))
+
+	got, err := repo.GetMetaTx(ctx, expired.ID)
+	require.NoError(t, err)
+	assert.Equal(t, repository.MetaTxStatusExpired, got.Status)
+
+	// Recovering again does not queue twice
+	require.NoError(t, relayer.Recover(ctx))
+	assert.Equal(t, 2, queue.Stats().Queued["normal"])
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/api/v1/relay/status/:id", relayer.GetStatus)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/relay/status/"+second.ID, nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var resp struct {
+		Data struct {
+			ID     string               `json:"id"`
+			Status string               `json:"status"`
+			Queue  *relayqueue.Position `json:"queue"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, second.ID, resp.Data.ID)
+	assert.Equal(t, "pending", resp.Data.Status)
+	require.NotNil(t, resp.Data.Queue)
+	assert.Equal(t, 2, resp.Data.Queue.Position)
+	assert.Equal(t, relayqueue.PriorityNormal, resp.Data.Queue.Priority)
+
+	_, ok := queue.Position(first.ID)
+	assert.True(t, ok)
+}
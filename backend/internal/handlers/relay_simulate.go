@@ -0,0 +1,326 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/contracts"
+)
+
+// simulationBalance is the balance the simulated caller is given through a
+// state override, so the result does not depend on the relayer's funds
+var simulationBalance = new(big.Int).Lsh(big.NewInt(1), 128)
+
+// SimulateRequest represents a meta-transaction to simulate. Without a
+// signature only the forwarded call is simulated, so dapps can check a
+// request before asking the user to sign it.
+type SimulateRequest struct {
+	From      string `json:"from" binding:"required"`
+	To        string `json:"to" binding:"required"`
+	Value     string `json:"value"`
+	Gas       uint64 `json:"gas" binding:"required"`
+	Nonce     uint64 `json:"nonce"`
+	Deadline  uint64 `json:"deadline" binding:"required"`
+	Data      string `json:"data" binding:"required"`
+	Signature string `json:"signature,omitempty"` // Optional: also simulate signature verification
+}
+
+// SimulationResult is the outcome of a simulated meta-transaction
+type SimulationResult struct {
+	Success      bool    `json:"success"`
+	Mode         string  `json:"mode"`                    // signed (forwarder execute) or unsigned (forwarded call only)
+	RevertReason string  `json:"revert_reason,omitempty"` // decoded Error(string), panic or forwarder error
+	RevertedBy   string  `json:"reverted_by,omitempty"`   // forwarder or target
+	ReturnData   string  `json:"return_data,omitempty"`
+	GasEstimate  *uint64 `json:"gas_estimate,omitempty"` // gas of the simulated call; absent if the node could not estimate it
+	GasLimit     uint64  `json:"gas_limit"`              // gas limit the relayer would submit with
+}
+
+// Simulate handles POST /api/v1/relay/simulate
+// @Summary Simulate a meta-transaction
+// @Description Runs the request via eth_call with a state override funding the caller, without submitting it. With a signature the forwarder's execute() is simulated from the relayer (nonce, deadline, whitelist and signature checks included); without one only the forwarded call (data with the ERC-2771 sender suffix) is simulated after the nonce, deadline and whitelist are checked.
+// @Tags relayer
+// @Accept json
+// @Produce json
+// @Param request body SimulateRequest true "Simulation request"
+// @Success 200 {object} RelayerResponse{data=SimulationResult}
+// @Failure 400 {object} RelayerResponse
+// @Failure 502 {object} RelayerResponse
+// @Failure 503 {object} RelayerResponse
+// @Router /api/v1/relay/simulate [post]
+func (h *RelayerHandler) Simulate(c *gin.Context) {
+	var req SimulateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, RelayerResponse{
+			Success: false,
+			Error:   "Invalid request: " + err.Error(),
+		})
+		return
+	}
+
+	if !isValidAddress(req.From) || !isValidAddress(req.To) {
+		c.JSON(http.StatusBadRequest, RelayerResponse{
+			Success: false,
+			Error:   "Invalid 'from' or 'to' address format",
+		})
+		return
+	}
+
+	if req.Signature != "" && (!strings.HasPrefix(req.Signature, "0x") || len(req.Signature) != 132) {
+		c.JSON(http.StatusBadRequest, RelayerResponse{
+			Success: false,
+			Error:   "Invalid signature format",
+		})
+		return
+	}
+
+	signature := req.Signature
+	if signature == "" {
+		signature = "0x"
+	}
+	fwdReq, sigBytes, err := buildForwardRequest(RelayRequest{
+		From:      req.From,
+		To:        req.To,
+		Value:     req.Value,
+		Gas:       req.Gas,
+		Nonce:     req.Nonce,
+		Deadline:  req.Deadline,
+		Data:      req.Data,
+		Signature: signature,
+	})
+	if err != nil {
+		c.JSON(http.StatusBadRequest, RelayerResponse{
+			Success: false,
+			Error:   "Invalid request: " + err.Error(),
+		})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), providerTimeout)
+	defer cancel()
+
+	var result *SimulationResult
+	if req.Signature != "" {
+		result, err = h.simulateExecute(ctx, fwdReq, sigBytes)
+	} else {
+		result, err = h.simulateForwardedCall(ctx, fwdReq)
+	}
+	if err != nil {
+		if respondUnavailable(c, err) {
+			return
+		}
+		h.logger.Error("failed to simulate meta-tx",
+			zap.String("from", req.From),
+			zap.String("to", req.To),
+			zap.Error(err),
+		)
+		c.JSON(http.StatusBadGateway, RelayerResponse{
+			Success: false,
+			Error:   "Failed to simulate transaction",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, RelayerResponse{
+		Success: true,
+		Data:    result,
+	})
+}
+
+// simulateExecute calls the forwarder's execute() from the relayer. The
+// forwarder returns (success, returnData) for a failed forwarded call and
+// reverts for a rejected request.
+func (h *RelayerHandler) simulateExecute(ctx context.Context, fwdReq contracts.NexusForwarderForwardRequest, signature []byte) (*SimulationResult, error) {
+	forwarderABI, err := contracts.NexusForwarderMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	input, err := forwarderABI.Pack("execute", fwdReq, signature)
+	if err != nil {
+		return nil, fmt.Errorf("packing execute: %w", err)
+	}
+
+	signer, err := h.keys.Peek()
+	if err != nil {
+		return nil, err
+	}
+	relayerAddr := signer.Address
+	forwarder := h.forwarder.Load().address
+	result := &SimulationResult{Mode: "signed", GasLimit: fwdReq.Gas.Uint64() + relayGasOverhead}
+	call := ethereum.CallMsg{
+		From:  relayerAddr,
+		To:    &forwarder,
+		Gas:   result.GasLimit,
+		Value: fwdReq.Value,
+		Data:  input,
+	}
+	overrides := map[common.Address]ethereum.OverrideAccount{relayerAddr: {Balance: simulationBalance}}
+
+	out, err := h.ethClient.CallContractWithOverrides(ctx, call, overrides)
+	if err != nil {
+		reason, ok := revertReason(err, forwarderABI)
+		if !ok {
+			return nil, err
+		}
+		result.RevertReason = reason
+		result.RevertedBy = "forwarder"
+		return result, nil
+	}
+
+	outputs, err := forwarderABI.Unpack("execute", out)
+	if err != nil {
+		return nil, fmt.Errorf("decoding execute result: %w", err)
+	}
+	success, _ := outputs[0].(bool)
+	var returnData []byte
+	if len(outputs) > 1 {
+		returnData, _ = outputs[1].([]byte)
+	}
+	result.ReturnData = hexutil.Encode(returnData)
+	if !success {
+		result.RevertReason = decodeRevertData(returnData, nil)
+		result.RevertedBy = "target"
+		return result, nil
+	}
+
+	result.Success = true
+	call.Gas = 0
+	h.estimateSimulatedGas(ctx, call, overrides, result)
+	return result, nil
+}
+
+// simulateForwardedCall checks what the forwarder would check besides the
+// signature, then calls the target as the forwarder with the ERC-2771
+// sender suffix
+func (h *RelayerHandler) simulateForwardedCall(ctx context.Context, fwdReq contracts.NexusForwarderForwardRequest) (*SimulationResult, error) {
+	result := &SimulationResult{Mode: "unsigned", GasLimit: fwdReq.Gas.Uint64() + relayGasOverhead}
+
+	if deadline := fwdReq.Deadline.Int64(); time.Now().Unix() > deadline {
+		result.RevertReason = fmt.Sprintf("RequestExpired(%d, %d)", deadline, time.Now().Unix())
+		result.RevertedBy = "forwarder"
+		return result, nil
+	}
+
+	fwd := h.forwarder.Load()
+	opts := &bind.CallOpts{Context: ctx}
+	allowed, err := fwd.contract.IsTargetAllowed(opts, fwdReq.To)
+	if err != nil {
+		return nil, fmt.Errorf("checking target whitelist: %w", err)
+	}
+	if !allowed {
+		result.RevertReason = fmt.Sprintf("TargetNotAllowed(%s)", fwdReq.To.Hex())
+		result.RevertedBy = "forwarder"
+		return result, nil
+	}
+
+	nonce, err := fwd.contract.GetNonce(opts, fwdReq.From)
+	if err != nil {
+		return nil, fmt.Errorf("getting nonce: %w", err)
+	}
+	if nonce.Cmp(fwdReq.Nonce) != 0 {
+		result.RevertReason = fmt.Sprintf("InvalidNonce(%s, %s)", nonce, fwdReq.Nonce)
+		result.RevertedBy = "forwarder"
+		return result, nil
+	}
+
+	call := ethereum.CallMsg{
+		From:  fwd.address,
+		To:    &fwdReq.To,
+		Gas:   fwdReq.Gas.Uint64(),
+		Value: fwdReq.Value,
+		Data:  append(append([]byte{}, fwdReq.Data...), fwdReq.From.Bytes()...),
+	}
+	overrides := map[common.Address]ethereum.OverrideAccount{fwd.address: {Balance: simulationBalance}}
+
+	out, err := h.ethClient.CallContractWithOverrides(ctx, call, overrides)
+	if err != nil {
+		reason, ok := revertReason(err, nil)
+		if !ok {
+			return nil, err
+		}
+		result.RevertReason = reason
+		result.RevertedBy = "target"
+		return result, nil
+	}
+
+	result.Success = true
+	result.ReturnData = hexutil.Encode(out)
+	h.estimateSimulatedGas(ctx, call, overrides, result)
+	return result, nil
+}
+
+// estimateSimulatedGas sets the result's gas estimate. Not every node accepts
+// state overrides on eth_estimateGas, so a failed estimate leaves it unset.
+func (h *RelayerHandler) estimateSimulatedGas(ctx context.Context, call ethereum.CallMsg, overrides map[common.Address]ethereum.OverrideAccount, result *SimulationResult) {
+	gas, err := h.ethClient.EstimateGasWithOverrides(ctx, call, overrides)
+	if err != nil {
+		h.logger.Warn("failed to estimate simulated gas", zap.String("mode", result.Mode), zap.Error(err))
+		return
+	}
+	result.GasEstimate = &gas
+}
+
+// revertReason extracts the revert reason from an eth_call error. ok is
+// false for errors that are not reverts (transport failures, open breaker).
+func revertReason(err error, errorsABI *abi.ABI) (reason string, ok bool) {
+	var dataErr rpc.DataError
+	if errors.As(err, &dataErr) {
+		if data, isString := dataErr.ErrorData().(string); isString {
+			if raw, decodeErr := hexutil.Decode(data); decodeErr == nil {
+				return decodeRevertData(raw, errorsABI), true
+			}
+		}
+	}
+
+	// Nodes that don't return revert data still report the revert
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) && strings.Contains(rpcErr.Error(), "revert") {
+		return rpcErr.Error(), true
+	}
+	return "", false
+}
+
+// decodeRevertData decodes Error(string), Panic(uint256) and custom errors
+// declared in errorsABI, falling back to the raw hex
+func decodeRevertData(data []byte, errorsABI *abi.ABI) string {
+	if len(data) == 0 {
+		return "execution reverted"
+	}
+	if reason, err := abi.UnpackRevert(data); err == nil {
+		return reason
+	}
+	if errorsABI != nil && len(data) >= 4 {
+		var id [4]byte
+		copy(id[:], data[:4])
+		if abiErr, err := errorsABI.ErrorByID(id); err == nil {
+			args, err := abiErr.Inputs.Unpack(data[4:])
+			if err == nil {
+				parts := make([]string, len(args))
+				for i, arg := range args {
+					if b, isBytes := arg.([]byte); isBytes {
+						parts[i] = hexutil.Encode(b)
+					} else {
+						parts[i] = fmt.Sprint(arg)
+					}
+				}
+				return abiErr.Name + "(" + strings.Join(parts, ", ") + ")"
+			}
+			return abiErr.Name
+		}
+	}
+	return hexutil.Encode(data)
+}
@@ -0,0 +1,204 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/chain"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/contracts"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/handlers"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/storage/memory"
+)
+
+var (
+	simForwarder = common.HexToAddress("0x5FbDB2315678afecb367f032d99F642f83F7aa3")
+	simTarget    = common.HexToAddress("0xe7f1725E7734CE288F8367e1Bb143E90bb3F0512")
+	simSigner    = common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+)
+
+// simulationNode fakes the JSON-RPC methods the simulation uses. Calls to the
+// target revert with Error("insufficient balance") unless their input starts
+// with 0x01; execute() reverts with InvalidNonce(3, 0).
+type simulationNode struct {
+	t         *testing.T
+	forwarder abi.ABI
+	overrides []map[string]json.RawMessage
+}
+
+func (n *simulationNode) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		ID     json.RawMessage   `json:"id"`
+		Method string            `json:"method"`
+		Params []json.RawMessage `json:"params"`
+	}
+	require.NoError(n.t, json.NewDecoder(r.Body).Decode(&req))
+	resp := map[string]interface{}{"jsonrpc": "2.0", "id": req.ID}
+
+	var call struct {
+		To    common.Address `json:"to"`
+		Data  hexutil.Bytes  `json:"data"`
+		Input hexutil.Bytes  `json:"input"`
+	}
+	if len(req.Params) > 0 {
+		json.Unmarshal(req.Params[0], &call)
+	}
+	if len(req.Params) > 2 {
+		var overrides map[string]json.RawMessage
+		require.NoError(n.t, json.Unmarshal(req.Params[2], &overrides))
+		n.overrides = append(n.overrides, overrides)
+	}
+	input := append(call.Data, call.Input...)
+
+	revert := func(data []byte) {
+		resp["error"] = map[string]interface{}{"code": 3, "message": "execution reverted", "data": hexutil.Encode(data)}
+	}
+	switch {
+	case req.Method == "eth_chainId":
+		resp["result"] = "0x7a69"
+	case req.Method == "eth_estimateGas":
+		resp["result"] = "0x5208"
+	case call.To == simForwarder && bytes.HasPrefix(input, n.forwarder.Methods["isTargetAllowed"].ID):
+		resp["result"] = hexutil.Encode(common.LeftPadBytes([]byte{1}, 32))
+	case call.To == simForwarder && bytes.HasPrefix(input, n.forwarder.Methods["getNonce"].ID):
+		resp["result"] = hexutil.Encode(common.LeftPadBytes(nil, 32))
+	case call.To == simForwarder && bytes.HasPrefix(input, n.forwarder.Methods["execute"].ID):
+		data, err := n.forwarder.Errors["InvalidNonce"].Inputs.Pack(big.NewInt(3), big.NewInt(0))
+		require.NoError(n.t, err)
+		revert(append(n.forwarder.Errors["InvalidNonce"].ID.Bytes()[:4], data...))
+	case call.To == simTarget && bytes.HasPrefix(input, []byte{0x01}):
+		// The sender is appended to the calldata (ERC-2771)
+		assert.Equal(n.t, simSigner, common.BytesToAddress(input[len(input)-20:]))
+		resp["result"] = "0x2a"
+	case call.To == simTarget:
+		typ, _ := abi.NewType("string", "", nil)
+		data, _ := abi.Arguments{{Type: typ}}.Pack("insufficient balance")
+		revert(append([]byte{0x08, 0xc3, 0x79, 0xa0}, data...))
+	default:
+		resp["result"] = "0x"
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// newSimulationRelayer creates a relayer handler backed by a simulationNode
+func newSimulationRelayer(t *testing.T, repo repository.RelayerRepository) (*handlers.RelayerHandler, *simulationNode) {
+	forwarderABI, err := contracts.NexusForwarderMetaData.GetAbi()
+	require.NoError(t, err)
+	node := &simulationNode{t: t, forwarder: *forwarderABI}
+	server := httptest.NewServer(node)
+	t.Cleanup(server.Close)
+
+	t.Setenv("RELAYER_PRIVATE_KEY", "0x59c6995e998f97a5a0044966f0945389dc9e86dae88c7a8412f4603b6b78690d")
+	t.Setenv("FORWARDER_ADDRESS", simForwarder.Hex())
+	manager := chain.NewClientManager(nil, zap.NewNop())
+	manager.AddEndpoints(31337, server.URL)
+	t.Cleanup(manager.Close)
+
+	h, err := handlers.NewRelayerHandler(repo, memory.NewMemoryAppConfigRepo(), manager, zap.NewNop(), 31337, nil)
+	require.NoError(t, err)
+	return h, node
+}
+
+func setupSimulateRouter(t *testing.T) (*gin.Engine, *simulationNode) {
+	h, node := newSimulationRelayer(t, memory.NewMemoryRelayerRepo())
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/api/v1/relay/simulate", h.Simulate)
+	return router, node
+}
+
+func simulate(t *testing.T, router *gin.Engine, body map[string]interface{}) (int, handlers.SimulationResult) {
+	payload, err := json.Marshal(body)
+	require.NoError(t, err)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/relay/simulate", bytes.NewReader(payload)))
+
+	var resp struct {
+		Data handlers.SimulationResult `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	return w.Code, resp.Data
+}
+
+func TestRelayerHandler_Simulate(t *testing.T) {
+	router, node := setupSimulateRouter(t)
+	request := func(data string) map[string]interface{} {
+		return map[string]interface{}{
+			"from":     simSigner.Hex(),
+			"to":       simTarget.Hex(),
+			"value":    "0",
+			"gas":      100000,
+			"nonce":    0,
+			"deadline": time.Now().Add(time.Hour).Unix(),
+			"data":     data,
+		}
+	}
+
+	t.Run("unsigned success", func(t *testing.T) {
+		code, result := simulate(t, router, request("0x01"))
+		assert.Equal(t, http.StatusOK, code)
+		assert.True(t, result.Success)
+		assert.Equal(t, "unsigned", result.Mode)
+		assert.Equal(t, "0x2a", result.ReturnData)
+		require.NotNil(t, result.GasEstimate)
+		assert.Equal(t, uint64(21000), *result.GasEstimate)
+		assert.Equal(t, uint64(150000), result.GasLimit)
+
+		// The forwarder is funded through a state override
+		require.NotEmpty(t, node.overrides)
+		_, ok := node.overrides[0][strings.ToLower(simForwarder.Hex())]
+		assert.True(t, ok)
+	})
+
+	t.Run("unsigned revert", func(t *testing.T) {
+		code, result := simulate(t, router, request("0x02"))
+		assert.Equal(t, http.StatusOK, code)
+		assert.False(t, result.Success)
+		assert.Equal(t, "insufficient balance", result.RevertReason)
+		assert.Equal(t, "target", result.RevertedBy)
+		assert.Nil(t, result.GasEstimate)
+	})
+
+	t.Run("unsigned wrong nonce", func(t *testing.T) {
+		body := request("0x01")
+		body["nonce"] = 4
+		_, result := simulate(t, router, body)
+		assert.False(t, result.Success)
+		assert.Equal(t, "InvalidNonce(0, 4)", result.RevertReason)
+		assert.Equal(t, "forwarder", result.RevertedBy)
+	})
+
+	t.Run("signed forwarder revert", func(t *testing.T) {
+		body := request("0x01")
+		body["signature"] = "0x" + strings.Repeat("11", 65)
+		code, result := simulate(t, router, body)
+		assert.Equal(t, http.StatusOK, code)
+		assert.False(t, result.Success)
+		assert.Equal(t, "signed", result.Mode)
+		assert.Equal(t, "InvalidNonce(3, 0)", result.RevertReason)
+		assert.Equal(t, "forwarder", result.RevertedBy)
+	})
+
+	t.Run("invalid signature format", func(t *testing.T) {
+		body := request("0x01")
+		body["signature"] = "0x1234"
+		code, _ := simulate(t, router, body)
+		assert.Equal(t, http.StatusBadRequest, code)
+	})
+}
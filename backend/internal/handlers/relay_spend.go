@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/reports"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// RelaySpendHandler handles the admin report of gas paid by the relayer
+type RelaySpendHandler struct {
+	repo   repository.RelayerRepository
+	logger *zap.Logger
+}
+
+// NewRelaySpendHandler creates a new relay spend handler with injected dependencies
+func NewRelaySpendHandler(repo repository.RelayerRepository, logger *zap.Logger) *RelaySpendHandler {
+	return &RelaySpendHandler{
+		repo:   repo,
+		logger: logger,
+	}
+}
+
+// RelaySpendResponse wraps relay spend API responses
+type RelaySpendResponse struct {
+	Success bool        `json:"success"`
+	Data    interface{} `json:"data,omitempty"`
+	Error   string      `json:"error,omitempty"`
+}
+
+// RelaySpendReport is the relayer's spend over a period
+type RelaySpendReport struct {
+	GroupBy  repository.RelaySpendGroup `json:"group_by"`
+	Timezone string                     `json:"timezone"` // of the day boundaries
+	Groups   []*repository.RelaySpend   `json:"groups"`
+	Total    *repository.RelaySpend     `json:"total"`
+}
+
+// GetRelaySpend handles GET /api/v1/admin/relay/spend
+// @Summary Relayer spend report
+// @Description Aggregates gas used × effective gas price of every mined meta-transaction (confirmed or reverted, live and archived) by signer, target contract or day. Days start at midnight in timezone (UTC by default); from and to are instants, RFC3339 with an offset.
+// @Tags admin
+// @Produce json
+// @Param group_by query string false "user, contract or day" default(day)
+// @Param from_address query string false "Signer address"
+// @Param to_address query string false "Target contract address"
+// @Param from query string false "Mined at or after (RFC3339)"
+// @Param to query string false "Mined before (RFC3339)"
+// @Param timezone query string false "IANA time zone of the day boundaries" default(UTC)
+// @Success 200 {object} RelaySpendResponse{data=RelaySpendReport}
+// @Failure 400 {object} RelaySpendResponse
+// @Failure 401 {object} RelaySpendResponse
+// @Router /api/v1/admin/relay/spend [get]
+func (h *RelaySpendHandler) GetRelaySpend(c *gin.Context) {
+	filter := repository.RelaySpendFilter{
+		GroupBy:     repository.RelaySpendGroup(c.DefaultQuery("group_by", string(repository.RelaySpendByDay))),
+		FromAddress: strings.ToLower(c.Query("from_address")),
+		ToAddress:   strings.ToLower(c.Query("to_address")),
+	}
+	if !filter.GroupBy.Valid() {
+		c.JSON(http.StatusBadRequest, RelaySpendResponse{
+			Success: false,
+			Error:   "Invalid group_by, expected user, contract or day",
+		})
+		return
+	}
+
+	loc, err := reports.LoadTimezone(c.Query("timezone"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, RelaySpendResponse{
+			Success: false,
+			Error:   "Invalid timezone, expected an IANA name such as Europe/Berlin",
+		})
+		return
+	}
+	filter.Location = loc
+
+	if filter.From, err = parseOptionalTime(c.Query("from")); err != nil {
+		c.JSON(http.StatusBadRequest, RelaySpendResponse{
+			Success: false,
+			Error:   "Invalid from time, expected RFC3339",
+		})
+		return
+	}
+	if filter.To, err = parseOptionalTime(c.Query("to")); err != nil {
+		c.JSON(http.StatusBadRequest, RelaySpendResponse{
+			Success: false,
+			Error:   "Invalid to time, expected RFC3339",
+		})
+		return
+	}
+
+	groups, err := h.repo.GetRelaySpend(c.Request.Context(), filter)
+	if err != nil {
+		h.logger.Error("failed to get relay spend", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, RelaySpendResponse{
+			Success: false,
+			Error:   "Internal server error",
+		})
+		return
+	}
+	total, err := repository.TotalRelaySpend(groups)
+	if err != nil {
+		h.logger.Error("failed to total relay spend", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, RelaySpendResponse{
+			Success: false,
+			Error:   "Internal server error",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, RelaySpendResponse{
+		Success: true,
+		Data: RelaySpendReport{
+			GroupBy:  filter.GroupBy,
+			Timezone: loc.String(),
+			Groups:   groups,
+			Total:    total,
+		},
+	})
+}
@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// transferGasHeadroomPercent is added over a transfer's gas estimate, in percent
+const transferGasHeadroomPercent = 120
+
+// SendTransfer sends a plain transaction (value in wei and optional call
+// data, e.g. an ERC-20 transfer) from the next relayer key, priced within
+// the relayer gas price limits. It returns the sending key and the tx hash;
+// confirmation tracking is left to the caller. Payouts are sent this way, so
+// the keys must hold the funds paid out.
+func (h *RelayerHandler) SendTransfer(ctx context.Context, to common.Address, value *big.Int, data []byte) (common.Address, common.Hash, error) {
+	gasPrice, err := h.relayGasPrice(ctx)
+	if err != nil {
+		return common.Address{}, common.Hash{}, err
+	}
+
+	signer, err := h.keys.Next()
+	if err != nil {
+		return common.Address{}, common.Hash{}, err
+	}
+
+	gas, err := h.ethClient.EstimateGas(ctx, ethereum.CallMsg{From: signer.Address, To: &to, Value: value, Data: data})
+	if err != nil {
+		return signer.Address, common.Hash{}, fmt.Errorf("failed to estimate transfer gas: %w", err)
+	}
+	gas = gas * transferGasHeadroomPercent / 100
+
+	auth := signer.TransactOpts(ctx, h.chainID)
+	var signedTx *types.Transaction
+	err = signer.Send(ctx, h.ethClient.PendingNonceAt, func(nonce uint64) error {
+		tx, err := auth.Signer(signer.Address, types.NewTransaction(nonce, to, value, gas, gasPrice, data))
+		if err != nil {
+			return err
+		}
+		signedTx = tx
+		return h.ethClient.SendTransaction(ctx, tx)
+	})
+	if err != nil {
+		return signer.Address, common.Hash{}, fmt.Errorf("failed to send transaction: %w", err)
+	}
+
+	return signer.Address, signedTx.Hash(), nil
+}
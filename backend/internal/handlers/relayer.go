@@ -2,7 +2,6 @@ package handlers
 
 import (
 	"context"
-	"crypto/ecdsa"
 	"encoding/hex"
 	"errors"
 	"fmt"
@@ -10,6 +9,8 @@ import (
 	"net/http"
 	"os"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
@@ -17,10 +18,16 @@ import (
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
-	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/billing"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/chain"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/contracts"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/maintenance"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/relaykeys"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/relaypolicy"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/relayqueue"
 	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
 )
 
@@ -29,46 +36,100 @@ type RelayerHandler struct {
 	repo            repository.RelayerRepository
 	configRepo      repository.AppConfigRepository
 	logger          *zap.Logger
-	ethClient       *ethclient.Client
-	forwarderAddr   common.Address
-	relayerKey      *ecdsa.PrivateKey
+	ethClient       *chain.FailoverClient
+	forwarder       atomic.Pointer[forwarderBinding]
+	keys            *relaykeys.Pool
+	keySource       relayerKeySource
+	secrets         SecretSource
 	chainID         *big.Int
+	txTracker       TxTracker
+	gasDebt         GasDebtChecker
+	policy          RelayPolicy
+	contractRepo    repository.ContractRepository
+	pause           *maintenance.Switch
+	queue           *relayqueue.Queue
+	holdLowPriority atomic.Bool
+
+	// EIP-712 domain the forwarder verifies signatures against
+	domainMu       sync.Mutex
+	domain         *ForwarderDomain
+	domainLoadedAt time.Time
 }
 
-// NewRelayerHandler creates a new relayer handler with injected dependencies
+// SetTxTracker enables confirmation tracking for relayed meta-transactions
+func (h *RelayerHandler) SetTxTracker(tracker TxTracker) {
+	h.txTracker = tracker
+}
+
+// GasDebtChecker refuses to relay for users whose gas debt is over the limit
+// (implemented by billing.Biller)
+type GasDebtChecker interface {
+	CheckRelay(ctx context.Context, userAddress string) error
+}
+
+// RelayPolicy refuses relays the compliance rules do not allow, with a
+// *relaypolicy.KYCError for a signer below the target's KYC requirement
+// (implemented by relaypolicy.Policy)
+type RelayPolicy interface {
+	CheckRelay(ctx context.Context, from, target string) error
+}
+
+// SetContractRepo lets the forwarder's EIP-712 domain come from its contract
+// metadata (contract_addresses.eip712_name / eip712_version)
+func (h *RelayerHandler) SetContractRepo(repo repository.ContractRepository) {
+	h.contractRepo = repo
+}
+
+// SetPauseSwitch makes Relay answer 503 while the switch is on
+func (h *RelayerHandler) SetPauseSwitch(sw *maintenance.Switch) {
+	h.pause = sw
+}
+
+// SetGasDebtChecker enables the gas debt limit on relays
+func (h *RelayerHandler) SetGasDebtChecker(checker GasDebtChecker) {
+	h.gasDebt = checker
+}
+
+// SetRelayPolicy enables the compliance checks on relays
+func (h *RelayerHandler) SetRelayPolicy(policy RelayPolicy) {
+	h.policy = policy
+}
+
+// NewRelayerHandler creates a new relayer handler with injected dependencies.
+// RPC calls go through the shared client manager so they fail over between
+// the chain's configured endpoints. Relayer keys are read through secrets
+// (the environment if nil).
 func NewRelayerHandler(
 	repo repository.RelayerRepository,
 	configRepo repository.AppConfigRepository,
+	rpc *chain.ClientManager,
 	logger *zap.Logger,
+	chainID int64,
+	secrets SecretSource,
 ) (*RelayerHandler, error) {
-	// Connect to Ethereum node
-	rpcURL := os.Getenv("RPC_URL")
-	if rpcURL == "" {
-		rpcURL = "http://localhost:8545"
-	}
+	secrets = secretSourceOrEnv(secrets)
 
-	client, err := ethclient.Dial(rpcURL)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	client, err := rpc.Client(ctx, chainID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to connect to Ethereum node: %w", err)
+		return nil, fmt.Errorf("failed to get RPC client: %w", err)
 	}
 
-	// Get chain ID
-	chainID, err := client.ChainID(context.Background())
+	// Verify the endpoints serve the expected chain
+	nodeChainID, err := client.ChainID(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get chain ID: %w", err)
 	}
-
-	// Load relayer private key
-	relayerKeyHex := os.Getenv("RELAYER_PRIVATE_KEY")
-	if relayerKeyHex == "" {
-		return nil, fmt.Errorf("RELAYER_PRIVATE_KEY not set")
+	if nodeChainID.Int64() != chainID {
+		return nil, fmt.Errorf("RPC chain ID %s does not match CHAIN_ID %d", nodeChainID, chainID)
 	}
 
-	// Remove 0x prefix if present
-	relayerKeyHex = strings.TrimPrefix(relayerKeyHex, "0x")
-	relayerKey, err := crypto.HexToECDSA(relayerKeyHex)
+	// Load the relayer key pool
+	keys, keySource, err := loadRelayerKeys(ctx, secrets)
 	if err != nil {
-		return nil, fmt.Errorf("invalid relayer private key: %w", err)
+		return nil, err
 	}
 
 	// Get forwarder contract address
@@ -78,15 +139,23 @@ func NewRelayerHandler(
 	}
 	forwarderAddr := common.HexToAddress(forwarderAddrHex)
 
-	return &RelayerHandler{
-		repo:          repo,
-		configRepo:    configRepo,
-		logger:        logger,
-		ethClient:     client,
-		forwarderAddr: forwarderAddr,
-		relayerKey:    relayerKey,
-		chainID:       chainID,
-	}, nil
+	forwarder, err := contracts.NewNexusForwarder(forwarderAddr, client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to bind forwarder contract: %w", err)
+	}
+
+	h := &RelayerHandler{
+		repo:       repo,
+		configRepo: configRepo,
+		logger:     logger,
+		ethClient:  client,
+		keys:       keys,
+		keySource:  keySource,
+		secrets:    secrets,
+		chainID:    nodeChainID,
+	}
+	h.forwarder.Store(&forwarderBinding{address: forwarderAddr, contract: forwarder})
+	return h, nil
 }
 
 // RelayerResponse wraps relayer API responses
@@ -95,15 +164,20 @@ type RelayerResponse struct {
 	Data    interface{} `json:"data,omitempty"`
 	Message string      `json:"message,omitempty"`
 	Error   string      `json:"error,omitempty"`
+	Code    string      `json:"code,omitempty"` // machine-readable reason of a compliance rejection
 }
 
+// RelayErrorKYCRequired is the RelayerResponse code of a relay refused
+// because the signer's KYC level is below the target's requirement
+const RelayErrorKYCRequired = "kyc_required"
+
 // RelayRequest represents a request to relay a meta-transaction
 type RelayRequest struct {
 	From         string `json:"from" binding:"required"`
 	To           string `json:"to" binding:"required"`
 	Value        string `json:"value" binding:"required"`
 	Gas          uint64 `json:"gas" binding:"required"`
-	Nonce        uint64 `json:"nonce" binding:"required"`
+	Nonce        uint64 `json:"nonce"` // 0 for an address's first meta-tx
 	Deadline     uint64 `json:"deadline" binding:"required"`
 	Data         string `json:"data" binding:"required"`
 	Signature    string `json:"signature" binding:"required"`
@@ -112,15 +186,28 @@ type RelayRequest struct {
 
 // Relay handles POST /api/v1/relay
 // @Summary Relay a meta-transaction
-// @Description Relays a signed ERC-2771 meta-transaction through the NexusForwarder
+// @Description Relays a signed ERC-2771 meta-transaction through the NexusForwarder. With the relay queue enabled the request is queued by priority class (202, position in data.queue) and submitted by a worker; follow it via GET /api/v1/relay/status/{id}. Under a soft limit (RELAY_QUEUE_RATE) bursts wait their turn rather than being refused, with data.queue.estimated_wait_seconds; only a full queue answers 503 with Retry-After.
 // @Tags relayer
 // @Accept json
 // @Produce json
 // @Param request body RelayRequest true "Relay request"
 // @Success 200 {object} RelayerResponse
+// @Success 202 {object} RelayerResponse
 // @Failure 400 {object} RelayerResponse
+// @Failure 402 {object} RelayerResponse
+// @Failure 403 {object} RelayerResponse
+// @Failure 503 {object} RelayerResponse
 // @Router /api/v1/relay [post]
 func (h *RelayerHandler) Relay(c *gin.Context) {
+	// Paused by an admin (POST /api/v1/admin/relay/pause)
+	if h.pause != nil && h.pause.Enabled() {
+		c.JSON(http.StatusServiceUnavailable, RelayerResponse{
+			Success: false,
+			Error:   "Relaying is paused: " + h.pause.State().Message,
+		})
+		return
+	}
+
 	var req RelayRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, RelayerResponse{
@@ -168,8 +255,20 @@ func (h *RelayerHandler) Relay(c *gin.Context) {
 
 	ctx := c.Request.Context()
 
-	// Verify the signature (EIP-712)
-	if err := h.verifySignature(req); err != nil {
+	fwdReq, sigBytes, err := buildForwardRequest(req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, RelayerResponse{
+			Success: false,
+			Error:   "Invalid request: " + err.Error(),
+		})
+		return
+	}
+
+	// Verify the signature (EIP-712) and let the forwarder validate nonce/deadline/target
+	if err := h.verifySignature(ctx, fwdReq, sigBytes); err != nil {
+		if respondUnavailable(c, err) {
+			return
+		}
 		h.logger.Warn("invalid signature",
 			zap.String("from", req.From),
 			zap.Error(err),
@@ -181,6 +280,42 @@ func (h *RelayerHandler) Relay(c *gin.Context) {
 		return
 	}
 
+	// Debt is checked only once the signature proves req.From sent the
+	// request, so nobody can probe another account's debt. A failed lookup
+	// does not block relaying
+	if h.gasDebt != nil {
+		if err := h.gasDebt.CheckRelay(ctx, req.From); errors.Is(err, billing.ErrDebtLimit) {
+			c.JSON(http.StatusPaymentRequired, RelayerResponse{
+				Success: false,
+				Error:   "Outstanding gas debt exceeds the limit; settle it to continue relaying",
+			})
+			return
+		} else if err != nil {
+			h.logger.Warn("failed to check gas debt", zap.String("from", req.From), zap.Error(err))
+		}
+	}
+
+	// Targets may require a KYC level of the (verified) signer; an
+	// unreadable policy or verification refuses the relay
+	if h.policy != nil {
+		var kycErr *relaypolicy.KYCError
+		if err := h.policy.CheckRelay(ctx, req.From, req.To); errors.As(err, &kycErr) {
+			c.JSON(http.StatusForbidden, RelayerResponse{
+				Success: false,
+				Error:   "KYC verification required: " + kycErr.Error(),
+				Code:    RelayErrorKYCRequired,
+			})
+			return
+		} else if err != nil {
+			h.logger.Error("failed to check relay policy", zap.String("from", req.From), zap.String("to", req.To), zap.Error(err))
+			c.JSON(http.StatusServiceUnavailable, RelayerResponse{
+				Success: false,
+				Error:   "Compliance checks are unavailable, try again later",
+			})
+			return
+		}
+	}
+
 	// Create meta-transaction record
 	metaTx := &repository.MetaTransaction{
 		FromAddress:  strings.ToLower(req.From),
@@ -204,8 +339,38 @@ func (h *RelayerHandler) Relay(c *gin.Context) {
 		return
 	}
 
-	// Submit the transaction
-	txHash, err := h.submitToChain(ctx, req, metaTx.ID)
+	if h.queue != nil {
+		h.enqueueRelay(c, fwdReq, sigBytes, metaTx)
+		return
+	}
+
+	txHash, err := h.relayMetaTx(ctx, fwdReq, sigBytes, metaTx)
+	if err != nil {
+		if respondUnavailable(c, err) {
+			return
+		}
+		c.JSON(http.StatusInternalServerError, RelayerResponse{
+			Success: false,
+			Error:   "Failed to relay transaction: " + err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, RelayerResponse{
+		Success: true,
+		Data: gin.H{
+			"id":      metaTx.ID,
+			"tx_hash": txHash,
+			"status":  "submitted",
+		},
+		Message: "Transaction relayed successfully",
+	})
+}
+
+// relayMetaTx submits a recorded meta-transaction and starts tracking it. A
+// failed submission marks the record failed.
+func (h *RelayerHandler) relayMetaTx(ctx context.Context, fwdReq contracts.NexusForwarderForwardRequest, sigBytes []byte, metaTx *repository.MetaTransaction) (string, error) {
+	txHash, err := h.submitToChain(ctx, fwdReq, sigBytes, metaTx.ID)
 	if err != nil {
 		h.logger.Error("failed to submit meta-tx",
 			zap.String("id", metaTx.ID),
@@ -218,65 +383,56 @@ func (h *RelayerHandler) Relay(c *gin.Context) {
 			Status:       repository.MetaTxStatusFailed,
 			ErrorMessage: &errMsg,
 		})
+		return "", err
+	}
 
-		c.JSON(http.StatusInternalServerError, RelayerResponse{
-			Success: false,
-			Error:   "Failed to relay transaction: " + err.Error(),
-		})
-		return
+	if h.txTracker != nil {
+		if err := h.txTracker.Track(ctx, repository.TrackedRecordMetaTx, metaTx.ID, txHash); err != nil {
+			h.logger.Error("failed to track meta-tx", zap.String("id", metaTx.ID), zap.Error(err))
+		}
 	}
 
 	h.logger.Info("meta-transaction relayed",
 		zap.String("id", metaTx.ID),
 		zap.String("tx_hash", txHash),
-		zap.String("from", req.From),
-		zap.String("to", req.To),
+		zap.String("from", metaTx.FromAddress),
+		zap.String("to", metaTx.ToAddress),
 	)
-
-	c.JSON(http.StatusOK, RelayerResponse{
-		Success: true,
-		Data: gin.H{
-			"id":      metaTx.ID,
-			"tx_hash": txHash,
-			"status":  "submitted",
-		},
-		Message: "Transaction relayed successfully",
-	})
+	return txHash, nil
 }
 
 // GetStatus handles GET /api/v1/relay/:id
 // @Summary Get meta-transaction status
-// @Description Returns the current status of a meta-transaction
+// @Description Returns the current status of a meta-transaction, with its queue position (queue) while it waits in the relay queue
 // @Tags relayer
 // @Produce json
 // @Param id path string true "Meta-transaction ID"
+// @Param fields query string false "Comma-separated record fields to return (id is always included)"
 // @Success 200 {object} RelayerResponse
 // @Failure 404 {object} RelayerResponse
 // @Router /api/v1/relay/{id} [get]
+// @Router /api/v2/relay/status/{id} [get]
 func (h *RelayerHandler) GetStatus(c *gin.Context) {
 	id := c.Param("id")
 
 	metaTx, err := h.repo.GetMetaTx(c.Request.Context(), id)
 	if err != nil {
 		if errors.Is(err, repository.ErrMetaTxNotFound) {
-			c.JSON(http.StatusNotFound, RelayerResponse{
-				Success: false,
-				Error:   "Meta-transaction not found",
-			})
+			respondError(c, http.StatusNotFound, "Meta-transaction not found")
 			return
 		}
 		h.logger.Error("failed to get meta-tx", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, RelayerResponse{
-			Success: false,
-			Error:   "Internal server error",
-		})
+		respondError(c, http.StatusInternalServerError, "Internal server error")
 		return
 	}
 
-	c.JSON(http.StatusOK, RelayerResponse{
-		Success: true,
-		Data:    metaTx,
-	})
+	status := metaTxStatus{MetaTransaction: metaTx}
+	if h.queue != nil && metaTx.Status == repository.MetaTxStatusPending {
+		if pos, ok := h.queue.Position(id); ok {
+			status.Queue = &pos
+		}
+	}
+	respondRecord(c, status)
 }
 
 // GetByTxHash handles GET /api/v1/relay/tx/:txHash
@@ -285,41 +441,31 @@ func (h *RelayerHandler) GetStatus(c *gin.Context) {
 // @Tags relayer
 // @Produce json
 // @Param txHash path string true "Transaction hash"
+// @Param fields query string false "Comma-separated record fields to return (id is always included)"
 // @Success 200 {object} RelayerResponse
 // @Failure 404 {object} RelayerResponse
 // @Router /api/v1/relay/tx/{txHash} [get]
+// @Router /api/v2/relay/tx/{txHash} [get]
 func (h *RelayerHandler) GetByTxHash(c *gin.Context) {
 	txHash := c.Param("txHash")
 
 	if !isValidTxHash(txHash) {
-		c.JSON(http.StatusBadRequest, RelayerResponse{
-			Success: false,
-			Error:   "Invalid transaction hash format",
-		})
+		respondError(c, http.StatusBadRequest, "Invalid transaction hash format")
 		return
 	}
 
 	metaTx, err := h.repo.GetMetaTxByHash(c.Request.Context(), txHash)
 	if err != nil {
 		if errors.Is(err, repository.ErrMetaTxNotFound) {
-			c.JSON(http.StatusNotFound, RelayerResponse{
-				Success: false,
-				Error:   "Meta-transaction not found for hash",
-			})
+			respondError(c, http.StatusNotFound, "Meta-transaction not found for hash")
 			return
 		}
 		h.logger.Error("failed to get meta-tx by hash", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, RelayerResponse{
-			Success: false,
-			Error:   "Internal server error",
-		})
+		respondError(c, http.StatusInternalServerError, "Internal server error")
 		return
 	}
 
-	c.JSON(http.StatusOK, RelayerResponse{
-		Success: true,
-		Data:    metaTx,
-	})
+	respondRecord(c, metaTx)
 }
 
 // GetNonce handles GET /api/v1/relay/nonce/:address
@@ -341,16 +487,30 @@ func (h *RelayerHandler) GetNonce(c *gin.Context) {
 		return
 	}
 
-	// First check on-chain nonce from forwarder contract
-	// For now, we use the DB-tracked nonce
-	nonce, err := h.repo.GetNextNonce(c.Request.Context(), strings.ToLower(address))
-	if err != nil {
-		h.logger.Error("failed to get nonce", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, RelayerResponse{
-			Success: false,
-			Error:   "Failed to get nonce",
-		})
-		return
+	ctx := c.Request.Context()
+
+	// The forwarder contract is the source of truth for nonces; fall back to
+	// the DB-tracked nonce if the node is unreachable
+	source := "chain"
+	var nonce uint64
+	onChain, err := h.forwarder.Load().contract.GetNonce(&bind.CallOpts{Context: ctx}, common.HexToAddress(address))
+	if err == nil {
+		nonce = onChain.Uint64()
+	} else {
+		h.logger.Warn("failed to read on-chain nonce, using DB nonce",
+			zap.String("address", address),
+			zap.Error(err),
+		)
+		source = "database"
+		nonce, err = h.repo.GetNextNonce(ctx, strings.ToLower(address))
+		if err != nil {
+			h.logger.Error("failed to get nonce", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, RelayerResponse{
+				Success: false,
+				Error:   "Failed to get nonce",
+			})
+			return
+		}
 	}
 
 	c.JSON(http.StatusOK, RelayerResponse{
@@ -358,29 +518,30 @@ func (h *RelayerHandler) GetNonce(c *gin.Context) {
 		Data: gin.H{
 			"address": strings.ToLower(address),
 			"nonce":   nonce,
+			"source":  source,
 		},
 	})
 }
 
 // ListUserMetaTxs handles GET /api/v1/relay/user/:address
 // @Summary List meta-transactions for a user
-// @Description Returns all meta-transactions submitted by a specific address
+// @Description Returns all meta-transactions submitted by a specific address, with the gas the relayer has paid for the address (live and archived)
 // @Tags relayer
 // @Produce json
 // @Param address path string true "User address"
 // @Param status query string false "Filter by status"
 // @Param page query int false "Page number (default: 1)"
-// @Param page_size query int false "Page size (default: 20)"
+// @Param page_size query int false "Page size (default: 20, max: 100)"
+// @Param fields query string false "Comma-separated record fields to return (id is always included)"
 // @Success 200 {object} RelayerResponse
+// @Failure 400 {object} RelayerResponse
 // @Router /api/v1/relay/user/{address} [get]
+// @Router /api/v2/relay/user/{address} [get]
 func (h *RelayerHandler) ListUserMetaTxs(c *gin.Context) {
 	address := c.Param("address")
 
 	if !isValidAddress(address) {
-		c.JSON(http.StatusBadRequest, RelayerResponse{
-			Success: false,
-			Error:   "Invalid address format",
-		})
+		respondError(c, http.StatusBadRequest, "Invalid address format")
 		return
 	}
 
@@ -392,13 +553,9 @@ func (h *RelayerHandler) ListUserMetaTxs(c *gin.Context) {
 		filter.Status = repository.MetaTxStatus(status)
 	}
 
-	page := 1
-	pageSize := 20
-	if p := c.Query("page"); p != "" {
-		fmt.Sscanf(p, "%d", &page)
-	}
-	if ps := c.Query("page_size"); ps != "" {
-		fmt.Sscanf(ps, "%d", &pageSize)
+	page, pageSize, ok := queryPage(c, 20)
+	if !ok {
+		return
 	}
 
 	txs, total, err := h.repo.ListMetaTx(c.Request.Context(), filter, repository.Pagination{
@@ -407,29 +564,63 @@ func (h *RelayerHandler) ListUserMetaTxs(c *gin.Context) {
 	})
 	if err != nil {
 		h.logger.Error("failed to list meta-txs", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, RelayerResponse{
-			Success: false,
-			Error:   "Internal server error",
-		})
+		respondError(c, http.StatusInternalServerError, "Internal server error")
 		return
 	}
 
-	c.JSON(http.StatusOK, RelayerResponse{
-		Success: true,
-		Data: gin.H{
-			"transactions": txs,
-			"total":        total,
-			"page":         page,
-			"page_size":    pageSize,
-		},
+	// Spend is informational; the list is served without it if the report fails
+	var extra gin.H
+	spend, err := h.repo.GetRelaySpend(c.Request.Context(), repository.RelaySpendFilter{
+		GroupBy:     repository.RelaySpendByUser,
+		FromAddress: filter.FromAddress,
 	})
+	if err != nil {
+		h.logger.Warn("failed to get relay spend", zap.String("address", filter.FromAddress), zap.Error(err))
+	} else if spent, err := repository.TotalRelaySpend(spend); err == nil {
+		spent.Key = filter.FromAddress
+		extra = gin.H{"spend": spent}
+	}
+
+	respondList(c, "transactions", txs, PageInfo{Total: total, Page: page, PageSize: pageSize}, extra)
 }
 
-// verifySignature verifies the EIP-712 signature
-func (h *RelayerHandler) verifySignature(req RelayRequest) error {
+// buildForwardRequest converts a relay request into the forwarder's ForwardRequest
+// struct and decodes the signature
+func buildForwardRequest(req RelayRequest) (contracts.NexusForwarderForwardRequest, []byte, error) {
+	value := new(big.Int)
+	if v := strings.TrimPrefix(req.Value, "0x"); v != "" && v != "0" {
+		if _, ok := value.SetString(v, 16); !ok {
+			return contracts.NexusForwarderForwardRequest{}, nil, fmt.Errorf("invalid value %q: expected wei as hex", req.Value)
+		}
+	}
+
+	dataBytes, err := hexutil.Decode(req.Data)
+	if err != nil {
+		return contracts.NexusForwarderForwardRequest{}, nil, fmt.Errorf("invalid calldata: %w", err)
+	}
+
+	sigBytes, err := hexutil.Decode(req.Signature)
+	if err != nil {
+		return contracts.NexusForwarderForwardRequest{}, nil, fmt.Errorf("invalid signature encoding: %w", err)
+	}
+
+	return contracts.NexusForwarderForwardRequest{
+		From:     common.HexToAddress(req.From),
+		To:       common.HexToAddress(req.To),
+		Value:    value,
+		Gas:      new(big.Int).SetUint64(req.Gas),
+		Nonce:    new(big.Int).SetUint64(req.Nonce),
+		Deadline: new(big.Int).SetUint64(req.Deadline),
+		Data:     dataBytes,
+	}, sigBytes, nil
+}
+
+// verifySignature verifies the EIP-712 signature locally, then asks the
+// forwarder to verify the request (nonce, deadline and target whitelist)
+func (h *RelayerHandler) verifySignature(ctx context.Context, fwdReq contracts.NexusForwarderForwardRequest, signature []byte) error {
 	// Build EIP-712 typed data hash
-	domainSeparator := h.buildDomainSeparator()
-	structHash := h.buildStructHash(req)
+	domainSeparator := h.buildDomainSeparator(h.forwarderDomain(ctx))
+	structHash := h.buildStructHash(fwdReq)
 
 	// Final hash: keccak256("\x19\x01" || domainSeparator || structHash)
 	digest := crypto.Keccak256(
@@ -438,15 +629,13 @@ func (h *RelayerHandler) verifySignature(req RelayRequest) error {
 		structHash,
 	)
 
-	// Decode signature
-	sigBytes, err := hexutil.Decode(req.Signature)
-	if err != nil {
-		return fmt.Errorf("invalid signature encoding: %w", err)
+	if len(signature) != 65 {
+		return fmt.Errorf("invalid signature length: %d", len(signature))
 	}
 
-	if len(sigBytes) != 65 {
-		return fmt.Errorf("invalid signature length: %d", len(sigBytes))
-	}
+	// Work on a copy so the original signature is submitted unchanged
+	sigBytes := make([]byte, 65)
+	copy(sigBytes, signature)
 
 	// Adjust v value if needed (Ethereum uses 27/28, but some libraries use 0/1)
 	if sigBytes[64] >= 27 {
@@ -461,18 +650,97 @@ func (h *RelayerHandler) verifySignature(req RelayRequest) error {
 
 	// Get address from public key
 	recoveredAddr := crypto.PubkeyToAddress(*pubKey)
-	expectedAddr := common.HexToAddress(req.From)
 
-	if recoveredAddr != expectedAddr {
+	if recoveredAddr != fwdReq.From {
 		return fmt.Errorf("signature does not match 'from' address: recovered %s, expected %s",
-			recoveredAddr.Hex(), expectedAddr.Hex())
+			recoveredAddr.Hex(), fwdReq.From.Hex())
+	}
+
+	valid, err := h.forwarder.Load().contract.Verify(&bind.CallOpts{Context: ctx}, fwdReq, signature)
+	if err != nil {
+		return fmt.Errorf("failed to verify request on forwarder: %w", err)
+	}
+	if !valid {
+		return fmt.Errorf("request rejected by forwarder (check nonce, deadline and target)")
 	}
 
 	return nil
 }
 
+// Forwarder EIP-712 domain used when neither the contract metadata nor the
+// contract itself (eip712Domain, ERC-5267) provides one
+const (
+	defaultForwarderDomainName    = "NexusForwarder"
+	defaultForwarderDomainVersion = "1"
+)
+
+// forwarderDomainTTL is how long a resolved domain is used before it is
+// looked up again, so an upgraded forwarder is picked up without a restart
+const forwarderDomainTTL = time.Minute
+
+// ForwarderDomain is the EIP-712 domain name and version of the forwarder
+type ForwarderDomain struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Source  string `json:"source"` // contract_metadata, eip712Domain or default
+}
+
+// forwarderDomain returns the forwarder's EIP-712 domain, cached for
+// forwarderDomainTTL. The default domain is not cached so a failed lookup is
+// retried on the next relay.
+func (h *RelayerHandler) forwarderDomain(ctx context.Context) ForwarderDomain {
+	h.domainMu.Lock()
+	defer h.domainMu.Unlock()
+
+	if h.domain != nil && time.Since(h.domainLoadedAt) < forwarderDomainTTL {
+		return *h.domain
+	}
+
+	domain := h.loadForwarderDomain(ctx)
+	if domain.Source != "default" {
+		if h.domain == nil || *h.domain != domain {
+			h.logger.Info("forwarder EIP-712 domain resolved",
+				zap.String("name", domain.Name),
+				zap.String("version", domain.Version),
+				zap.String("source", domain.Source),
+			)
+		}
+		h.domain = &domain
+		h.domainLoadedAt = time.Now()
+	}
+	return domain
+}
+
+// loadForwarderDomain reads the domain from the forwarder's contract
+// metadata, then from the contract's eip712Domain(), then falls back to the
+// default
+func (h *RelayerHandler) loadForwarderDomain(ctx context.Context) ForwarderDomain {
+	if h.contractRepo != nil {
+		contract, err := h.contractRepo.GetByChainAndDBName(ctx, h.chainID.Int64(), forwarderDBName)
+		switch {
+		case err == nil && strings.EqualFold(contract.Address, h.forwarder.Load().address.Hex()) &&
+			contract.EIP712Name != nil && contract.EIP712Version != nil:
+			return ForwarderDomain{Name: *contract.EIP712Name, Version: *contract.EIP712Version, Source: "contract_metadata"}
+		case err != nil && !errors.Is(err, repository.ErrContractAddressNotFound):
+			h.logger.Warn("failed to load forwarder contract metadata", zap.Error(err))
+		}
+	}
+
+	onchain, err := h.forwarder.Load().contract.Eip712Domain(&bind.CallOpts{Context: ctx})
+	if err == nil {
+		return ForwarderDomain{Name: onchain.Name, Version: onchain.Version, Source: "eip712Domain"}
+	}
+
+	h.logger.Warn("forwarder EIP-712 domain unavailable, using default",
+		zap.String("name", defaultForwarderDomainName),
+		zap.String("version", defaultForwarderDomainVersion),
+		zap.Error(err),
+	)
+	return ForwarderDomain{Name: defaultForwarderDomainName, Version: defaultForwarderDomainVersion, Source: "default"}
+}
+
 // buildDomainSeparator builds the EIP-712 domain separator
-func (h *RelayerHandler) buildDomainSeparator() []byte {
+func (h *RelayerHandler) buildDomainSeparator(domain ForwarderDomain) []byte {
 	// Domain separator: keccak256(
 	//   "EIP712Domain(string name,string version,uint256 chainId,address verifyingContract)"
 	// )
@@ -480,11 +748,11 @@ func (h *RelayerHandler) buildDomainSeparator() []byte {
 		"EIP712Domain(string name,string version,uint256 chainId,address verifyingContract)",
 	))
 
-	nameHash := crypto.Keccak256([]byte("NexusForwarder"))
-	versionHash := crypto.Keccak256([]byte("1"))
+	nameHash := crypto.Keccak256([]byte(domain.Name))
+	versionHash := crypto.Keccak256([]byte(domain.Version))
 
 	chainIDBytes := common.LeftPadBytes(h.chainID.Bytes(), 32)
-	contractBytes := common.LeftPadBytes(h.forwarderAddr.Bytes(), 32)
+	contractBytes := common.LeftPadBytes(h.forwarder.Load().address.Bytes(), 32)
 
 	return crypto.Keccak256(
 		typeHash,
@@ -496,47 +764,77 @@ func (h *RelayerHandler) buildDomainSeparator() []byte {
 }
 
 // buildStructHash builds the EIP-712 struct hash for ForwardRequest
-func (h *RelayerHandler) buildStructHash(req RelayRequest) []byte {
-	// ForwardRequest type hash
+func (h *RelayerHandler) buildStructHash(req contracts.NexusForwarderForwardRequest) []byte {
+	// ForwardRequest type hash (must match FORWARD_REQUEST_TYPEHASH in NexusForwarder.sol)
 	typeHash := crypto.Keccak256([]byte(
-		"ForwardRequest(address from,address to,uint256 value,uint256 gas,uint256 nonce,uint256 deadline,bytes data)",
+		"ForwardRequest(address from,address to,uint256 value,uint256 gas,uint256 nonce,uint48 deadline,bytes data)",
 	))
 
-	// Parse value as big.Int
-	value := new(big.Int)
-	if req.Value != "" && req.Value != "0" {
-		value.SetString(strings.TrimPrefix(req.Value, "0x"), 16)
+	return crypto.Keccak256(
+		typeHash,
+		common.LeftPadBytes(req.From.Bytes(), 32),
+		common.LeftPadBytes(req.To.Bytes(), 32),
+		common.LeftPadBytes(req.Value.Bytes(), 32),
+		common.LeftPadBytes(req.Gas.Bytes(), 32),
+		common.LeftPadBytes(req.Nonce.Bytes(), 32),
+		common.LeftPadBytes(req.Deadline.Bytes(), 32),
+		crypto.Keccak256(req.Data),
+	)
+}
+
+// relayGasOverhead is added to the request's gas for the forwarder's own
+// execution (verification, nonce and stats updates)
+const relayGasOverhead = 50000
+
+// submitToChain submits the meta-transaction to the blockchain via the forwarder's execute()
+func (h *RelayerHandler) submitToChain(ctx context.Context, fwdReq contracts.NexusForwarderForwardRequest, signature []byte, metaTxID string) (string, error) {
+	gasPrice, err := h.relayGasPrice(ctx)
+	if err != nil {
+		return "", err
 	}
 
-	// Decode data and hash it
-	dataBytes, _ := hexutil.Decode(req.Data)
-	dataHash := crypto.Keccak256(dataBytes)
+	// Round-robin over the pool; the key's nonce manager assigns the nonce
+	signer, err := h.keys.Next()
+	if err != nil {
+		return "", err
+	}
 
-	fromBytes := common.LeftPadBytes(common.HexToAddress(req.From).Bytes(), 32)
-	toBytes := common.LeftPadBytes(common.HexToAddress(req.To).Bytes(), 32)
-	valueBytes := common.LeftPadBytes(value.Bytes(), 32)
-	gasBytes := common.LeftPadBytes(big.NewInt(int64(req.Gas)).Bytes(), 32)
-	nonceBytes := common.LeftPadBytes(big.NewInt(int64(req.Nonce)).Bytes(), 32)
-	deadlineBytes := common.LeftPadBytes(big.NewInt(int64(req.Deadline)).Bytes(), 32)
+	auth := signer.TransactOpts(ctx, h.chainID)
+	auth.Value = fwdReq.Value
+	auth.GasLimit = fwdReq.Gas.Uint64() + relayGasOverhead
+	auth.GasPrice = gasPrice
 
-	return crypto.Keccak256(
-		typeHash,
-		fromBytes,
-		toBytes,
-		valueBytes,
-		gasBytes,
-		nonceBytes,
-		deadlineBytes,
-		dataHash,
-	)
+	// execute(ForwardRequest calldata request, bytes calldata signature)
+	var signedTx *types.Transaction
+	err = signer.Send(ctx, h.ethClient.PendingNonceAt, func(nonce uint64) error {
+		auth.Nonce = new(big.Int).SetUint64(nonce)
+		tx, err := h.forwarder.Load().contract.Execute(auth, fwdReq, signature)
+		signedTx = tx
+		return err
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to send transaction: %w", err)
+	}
+
+	txHash := signedTx.Hash().Hex()
+
+	// Update meta-tx status to submitted
+	h.repo.UpdateMetaTxStatus(ctx, metaTxID, &repository.MetaTxStatusUpdate{
+		Status: repository.MetaTxStatusSubmitted,
+		TxHash: &txHash,
+	})
+
+	return txHash, nil
 }
 
-// submitToChain submits the meta-transaction to the blockchain
-func (h *RelayerHandler) submitToChain(ctx context.Context, req RelayRequest, metaTxID string) (string, error) {
+// relayGasPrice returns the current gas price within the relayer limits
+// (app_config relayer.max_gas_price_gwei and relayer.min_gas_price_gwei): a
+// price above the maximum is refused, one below the minimum raised to it
+func (h *RelayerHandler) relayGasPrice(ctx context.Context) (*big.Int, error) {
 	// Get current gas price
 	gasPrice, err := h.ethClient.SuggestGasPrice(ctx)
 	if err != nil {
-		return "", fmt.Errorf("failed to get gas price: %w", err)
+		return nil, fmt.Errorf("failed to get gas price: %w", err)
 	}
 
 	// Load gas price limits from database (with fallback defaults)
@@ -557,134 +855,35 @@ func (h *RelayerHandler) submitToChain(ctx context.Context, req RelayRequest, me
 
 	// Check gas price limits
 	if gasPrice.Cmp(maxGasPrice) > 0 {
-		return "", fmt.Errorf("gas price too high: %s gwei", new(big.Int).Div(gasPrice, big.NewInt(1e9)))
+		return nil, fmt.Errorf("gas price too high: %s gwei", new(big.Int).Div(gasPrice, big.NewInt(1e9)))
 	}
 	if gasPrice.Cmp(minGasPrice) < 0 {
 		gasPrice = minGasPrice
 	}
-
-	// Get relayer address and nonce
-	relayerAddr := crypto.PubkeyToAddress(h.relayerKey.PublicKey)
-	nonce, err := h.ethClient.PendingNonceAt(ctx, relayerAddr)
-	if err != nil {
-		return "", fmt.Errorf("failed to get relayer nonce: %w", err)
-	}
-
-	// Build ForwardRequest struct for contract call
-	value := new(big.Int)
-	if req.Value != "" && req.Value != "0" {
-		value.SetString(strings.TrimPrefix(req.Value, "0x"), 16)
-	}
-
-	dataBytes, err := hexutil.Decode(req.Data)
-	if err != nil {
-		return "", fmt.Errorf("invalid calldata: %w", err)
-	}
-
-	sigBytes, err := hexutil.Decode(req.Signature)
-	if err != nil {
-		return "", fmt.Errorf("invalid signature: %w", err)
-	}
-
-	// Build the execute function call
-	// execute(ForwardRequest calldata req, bytes calldata signature)
-	forwarderABI := `[{"inputs":[{"components":[{"internalType":"address","name":"from","type":"address"},{"internalType":"address","name":"to","type":"address"},{"internalType":"uint256","name":"value","type":"uint256"},{"internalType":"uint256","name":"gas","type":"uint256"},{"internalType":"uint256","name":"nonce","type":"uint256"},{"internalType":"uint256","name":"deadline","type":"uint256"},{"internalType":"bytes","name":"data","type":"bytes"}],"internalType":"struct NexusForwarder.ForwardRequest","name":"req","type":"tuple"},{"internalType":"bytes","name":"signature","type":"bytes"}],"name":"execute","outputs":[{"internalType":"bool","name":"","type":"bool"},{"internalType":"bytes","name":"","type":"bytes"}],"stateMutability":"payable","type":"function"}]`
-
-	_ = forwarderABI // We'll use raw transaction encoding
-
-	// For simplicity, use bind.TransactOpts and send raw transaction
-	auth, err := bind.NewKeyedTransactorWithChainID(h.relayerKey, h.chainID)
-	if err != nil {
-		return "", fmt.Errorf("failed to create transactor: %w", err)
-	}
-
-	auth.Nonce = big.NewInt(int64(nonce))
-	auth.Value = value
-	auth.GasLimit = req.Gas + 50000 // Add buffer for forwarding overhead
-	auth.GasPrice = gasPrice
-	auth.Context = ctx
-
-	// Encode the execute function call manually
-	calldata := h.encodeExecuteCall(
-		common.HexToAddress(req.From),
-		common.HexToAddress(req.To),
-		value,
-		big.NewInt(int64(req.Gas)),
-		big.NewInt(int64(req.Nonce)),
-		big.NewInt(int64(req.Deadline)),
-		dataBytes,
-		sigBytes,
-	)
-
-	// Create and sign the transaction
-	tx := createLegacyTx(h.forwarderAddr, value, auth.GasLimit, gasPrice, nonce, calldata)
-	signedTx, err := auth.Signer(relayerAddr, tx)
-	if err != nil {
-		return "", fmt.Errorf("failed to sign transaction: %w", err)
-	}
-
-	// Send transaction
-	if err := h.ethClient.SendTransaction(ctx, signedTx); err != nil {
-		return "", fmt.Errorf("failed to send transaction: %w", err)
-	}
-
-	txHash := signedTx.Hash().Hex()
-
-	// Update meta-tx status to submitted
-	h.repo.UpdateMetaTxStatus(ctx, metaTxID, &repository.MetaTxStatusUpdate{
-		Status: repository.MetaTxStatusSubmitted,
-		TxHash: &txHash,
-	})
-
-	return txHash, nil
-}
-
-// encodeExecuteCall encodes the execute function call
-func (h *RelayerHandler) encodeExecuteCall(
-	from, to common.Address,
-	value, gas, nonce, deadline *big.Int,
-	data, signature []byte,
-) []byte {
-	// Function selector for execute(ForwardRequest,bytes)
-	selector := crypto.Keccak256([]byte("execute((address,address,uint256,uint256,uint256,uint256,bytes),bytes)"))[:4]
-
-	// Encode parameters using ABI encoding
-	// This is a simplified version - in production, use proper ABI encoding
-	encoded := make([]byte, 0)
-	encoded = append(encoded, selector...)
-
-	// Offset to ForwardRequest (64 bytes from start of params)
-	encoded = append(encoded, common.LeftPadBytes(big.NewInt(64).Bytes(), 32)...)
-	// Offset to signature (dynamic, calculated later)
-	// For now, just append the struct fields directly
-
-	// This is a simplified encoding - in production, use go-ethereum's abi package
-	// The actual encoding is more complex due to dynamic types
-
-	return encoded
-}
-
-// createLegacyTx creates a legacy Ethereum transaction
-func createLegacyTx(to common.Address, value *big.Int, gasLimit uint64, gasPrice *big.Int, nonce uint64, data []byte) *types.Transaction {
-	return types.NewTx(&types.LegacyTx{
-		Nonce:    nonce,
-		GasPrice: gasPrice,
-		Gas:      gasLimit,
-		To:       &to,
-		Value:    value,
-		Data:     data,
-	})
+	return gasPrice, nil
 }
 
 // GetRelayerAddress handles GET /api/v1/relay/relayer
 // @Summary Get relayer address
-// @Description Returns the address of the relayer that will submit transactions
+// @Description Returns the address of the relayer key that will submit the next transaction, and every key in the pool (addresses)
 // @Tags relayer
 // @Produce json
 // @Success 200 {object} RelayerResponse
 // @Router /api/v1/relay/relayer [get]
 func (h *RelayerHandler) GetRelayerAddress(c *gin.Context) {
-	relayerAddr := crypto.PubkeyToAddress(h.relayerKey.PublicKey)
+	signer, err := h.keys.Peek()
+	if err != nil {
+		c.JSON(http.StatusServiceUnavailable, RelayerResponse{
+			Success: false,
+			Error:   "No relayer keys available",
+		})
+		return
+	}
+	relayerAddr := signer.Address
+	var addresses []string
+	for _, addr := range h.keys.Addresses() {
+		addresses = append(addresses, addr.Hex())
+	}
 
 	// Get relayer ETH balance
 	balance, err := h.ethClient.BalanceAt(c.Request.Context(), relayerAddr, nil)
@@ -697,16 +896,17 @@ func (h *RelayerHandler) GetRelayerAddress(c *gin.Context) {
 		Success: true,
 		Data: gin.H{
 			"address":     relayerAddr.Hex(),
+			"addresses":   addresses,
 			"balance_wei": balance.String(),
 			"chain_id":    h.chainID.String(),
-			"forwarder":   h.forwarderAddr.Hex(),
+			"forwarder":   h.forwarder.Load().address.Hex(),
 		},
 	})
 }
 
 // GetForwarderAddress handles GET /api/v1/relay/forwarder
 // @Summary Get forwarder contract address
-// @Description Returns the address of the NexusForwarder contract
+// @Description Returns the address of the NexusForwarder contract and the EIP-712 domain requests must be signed for
 // @Tags relayer
 // @Produce json
 // @Success 200 {object} RelayerResponse
@@ -715,8 +915,9 @@ func (h *RelayerHandler) GetForwarderAddress(c *gin.Context) {
 	c.JSON(http.StatusOK, RelayerResponse{
 		Success: true,
 		Data: gin.H{
-			"address":  h.forwarderAddr.Hex(),
-			"chain_id": h.chainID.String(),
+			"address":       h.forwarder.Load().address.Hex(),
+			"chain_id":      h.chainID.String(),
+			"eip712_domain": h.forwarderDomain(c.Request.Context()),
 		},
 	})
 }
@@ -0,0 +1,284 @@
+package handlers_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/billing"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/chain"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/contracts"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/handlers"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/storage/memory"
+)
+
+func TestRelayRequest_Binding(t *testing.T) {
+	req := handlers.RelayRequest{
+		From:      "0x1111111111111111111111111111111111111111",
+		To:        "0x2222222222222222222222222222222222222222",
+		Value:     "0",
+		Gas:       100000,
+		Deadline:  1893456000,
+		Data:      "0x",
+		Signature: "0x00",
+	}
+
+	// An address's first meta-tx uses nonce 0
+	assert.NoError(t, binding.Validator.ValidateStruct(&req))
+
+	req.Gas = 0
+	assert.Error(t, binding.Validator.ValidateStruct(&req))
+}
+
+var (
+	verifyForwarder = common.HexToAddress("0xCf7Ed3AccA5a467e9e704C703E8D87F634fB0Fc9")
+	verifyTarget    = common.HexToAddress("0xDc64a140Aa3E981100a9becA4E685f962f0cF6C9")
+)
+
+// forwarderNode fakes a NexusForwarder deployment: verify() checks the
+// EIP-712 signature, the signer's nonce (0) and the deadline as the contract
+// does, and sent transactions are recorded
+type forwarderNode struct {
+	t         *testing.T
+	forwarder abi.ABI
+	verified  int
+	sent      []*types.Transaction
+}
+
+func (n *forwarderNode) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		ID     json.RawMessage   `json:"id"`
+		Method string            `json:"method"`
+		Params []json.RawMessage `json:"params"`
+	}
+	require.NoError(n.t, json.NewDecoder(r.Body).Decode(&req))
+	resp := map[string]interface{}{"jsonrpc": "2.0", "id": req.ID}
+
+	var call struct {
+		To    common.Address `json:"to"`
+		Data  hexutil.Bytes  `json:"data"`
+		Input hexutil.Bytes  `json:"input"`
+	}
+	if len(req.Params) > 0 {
+		json.Unmarshal(req.Params[0], &call)
+	}
+	input := append(call.Data, call.Input...)
+
+	verify := n.forwarder.Methods["verify"]
+	switch {
+	case req.Method == "eth_chainId":
+		resp["result"] = "0x7a69"
+	case req.Method == "eth_gasPrice":
+		resp["result"] = "0x3b9aca00"
+	case req.Method == "eth_getTransactionCount":
+		resp["result"] = "0x0"
+	case req.Method == "eth_sendRawTransaction":
+		var raw hexutil.Bytes
+		require.NoError(n.t, json.Unmarshal(req.Params[0], &raw))
+		tx := new(types.Transaction)
+		require.NoError(n.t, tx.UnmarshalBinary(raw))
+		n.sent = append(n.sent, tx)
+		resp["result"] = tx.Hash().Hex()
+	case req.Method == "eth_call" && call.To == verifyForwarder && bytes.HasPrefix(input, verify.ID):
+		n.verified++
+		args, err := verify.Inputs.Unpack(input[4:])
+		require.NoError(n.t, err)
+		fwdReq := *abi.ConvertType(args[0], new(contracts.NexusForwarderForwardRequest)).(*contracts.NexusForwarderForwardRequest)
+		valid := fwdReq.Nonce.Sign() == 0 &&
+			fwdReq.Deadline.Int64() >= time.Now().Unix() &&
+			recoverForwardRequest(n.t, fwdReq, args[1].([]byte)) == fwdReq.From
+		out, err := verify.Outputs.Pack(valid)
+		require.NoError(n.t, err)
+		resp["result"] = hexutil.Encode(out)
+	default:
+		// eip712Domain() is not implemented: the default domain is used
+		resp["result"] = "0x"
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// forwardRequestHash is the EIP-712 digest of req under the forwarder's
+// default domain
+func forwardRequestHash(t *testing.T, req contracts.NexusForwarderForwardRequest) []byte {
+	t.Helper()
+	hash, _, err := apitypes.TypedDataAndHash(apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": {
+				{Name: "name", Type: "string"},
+				{Name: "version", Type: "string"},
+				{Name: "chainId", Type: "uint256"},
+				{Name: "verifyingContract", Type: "address"},
+			},
+			"ForwardRequest": {
+				{Name: "from", Type: "address"},
+				{Name: "to", Type: "address"},
+				{Name: "value", Type: "uint256"},
+				{Name: "gas", Type: "uint256"},
+				{Name: "nonce", Type: "uint256"},
+				{Name: "deadline", Type: "uint48"},
+				{Name: "data", Type: "bytes"},
+			},
+		},
+		PrimaryType: "ForwardRequest",
+		Domain: apitypes.TypedDataDomain{
+			Name:              "NexusForwarder",
+			Version:           "1",
+			ChainId:           math.NewHexOrDecimal256(31337),
+			VerifyingContract: verifyForwarder.Hex(),
+		},
+		Message: apitypes.TypedDataMessage{
+			"from":     req.From.Hex(),
+			"to":       req.To.Hex(),
+			"value":    req.Value.String(),
+			"gas":      req.Gas.String(),
+			"nonce":    req.Nonce.String(),
+			"deadline": req.Deadline.String(),
+			"data":     hexutil.Encode(req.Data),
+		},
+	})
+	require.NoError(t, err)
+	return hash
+}
+
+// recoverForwardRequest returns the signer of req
+func recoverForwardRequest(t *testing.T, req contracts.NexusForwarderForwardRequest, signature []byte) common.Address {
+	sig := append([]byte(nil), signature...)
+	if sig[64] >= 27 {
+		sig[64] -= 27
+	}
+	pub, err := crypto.SigToPub(forwardRequestHash(t, req), sig)
+	if err != nil {
+		return common.Address{}
+	}
+	return crypto.PubkeyToAddress(*pub)
+}
+
+func TestRelayerHandler_RelayVerifiesSignature(t *testing.T) {
+	forwarderABI, err := contracts.NexusForwarderMetaData.GetAbi()
+	require.NoError(t, err)
+	node := &forwarderNode{t: t, forwarder: *forwarderABI}
+	server := httptest.NewServer(node)
+	t.Cleanup(server.Close)
+
+	t.Setenv("RELAYER_PRIVATE_KEY", "0x59c6995e998f97a5a0044966f0945389dc9e86dae88c7a8412f4603b6b78690d")
+	t.Setenv("FORWARDER_ADDRESS", verifyForwarder.Hex())
+	manager := chain.NewClientManager(nil, zap.NewNop())
+	manager.AddEndpoints(31337, server.URL)
+	t.Cleanup(manager.Close)
+	repo := memory.NewMemoryRelayerRepo()
+	h, err := handlers.NewRelayerHandler(repo, memory.NewMemoryAppConfigRepo(), manager, zap.NewNop(), 31337, nil)
+	require.NoError(t, err)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/api/v1/relay", h.Relay)
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	deadline := time.Now().Add(time.Hour).Unix()
+	sign := func(nonce, deadline int64) string {
+		hash := forwardRequestHash(t, contracts.NexusForwarderForwardRequest{
+			From:     from,
+			To:       verifyTarget,
+			Value:    big.NewInt(0),
+			Gas:      big.NewInt(100000),
+			Nonce:    big.NewInt(nonce),
+			Deadline: big.NewInt(deadline),
+			Data:     []byte{0x01},
+		})
+		sig, err := crypto.Sign(hash, key)
+		require.NoError(t, err)
+		sig[64] += 27
+		return hexutil.Encode(sig)
+	}
+	relay := func(nonce, deadline int64, value, signature string) (int, handlers.RelayerResponse) {
+		payload, err := json.Marshal(map[string]interface{}{
+			"from":      from.Hex(),
+			"to":        verifyTarget.Hex(),
+			"value":     value,
+			"gas":       100000,
+			"nonce":     nonce,
+			"deadline":  deadline,
+			"data":      "0x01",
+			"signature": signature,
+		})
+		require.NoError(t, err)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/relay", bytes.NewReader(payload)))
+		var resp handlers.RelayerResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp), w.Body.String())
+		return w.Code, resp
+	}
+
+	// A signed request passes the local check and the forwarder's verify()
+	// and is submitted through execute()
+	code, resp := relay(0, deadline, "0", sign(0, deadline))
+	require.Equal(t, http.StatusOK, code, resp.Error)
+	assert.Equal(t, 1, node.verified)
+	require.Len(t, node.sent, 1)
+	assert.Equal(t, verifyForwarder, *node.sent[0].To())
+	assert.Equal(t, forwarderABI.Methods["execute"].ID, node.sent[0].Data()[:4])
+
+	// A nonce or deadline changed after signing no longer matches the signer
+	code, resp = relay(1, deadline, "0", sign(0, deadline))
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Contains(t, resp.Error, "signature does not match")
+	code, resp = relay(0, deadline+60, "0", sign(0, deadline))
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Contains(t, resp.Error, "signature does not match")
+	assert.Equal(t, 1, node.verified, "tampered requests are refused before the forwarder is asked")
+
+	// A correctly signed request the forwarder refuses (stale nonce) is not relayed
+	code, resp = relay(1, deadline, "0", sign(1, deadline))
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Contains(t, resp.Error, "rejected by forwarder")
+	assert.Equal(t, 2, node.verified)
+
+	// The value must be hex wei
+	code, resp = relay(0, deadline, "0xnope", sign(0, deadline))
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Contains(t, resp.Error, "invalid value")
+	assert.Len(t, node.sent, 1)
+
+	// Gas debt is only looked up for a verified signer
+	debt := &debtChecker{err: billing.ErrDebtLimit}
+	h.SetGasDebtChecker(debt)
+	code, _ = relay(1, deadline, "0", sign(0, deadline))
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Empty(t, debt.checked, "an unverified request must not reveal the sender's debt")
+	code, resp = relay(0, deadline, "0", sign(0, deadline))
+	assert.Equal(t, http.StatusPaymentRequired, code, resp.Error)
+	assert.Equal(t, []string{from.Hex()}, debt.checked)
+	assert.Len(t, node.sent, 1)
+}
+
+// debtChecker records gas debt lookups and answers them with err
+type debtChecker struct {
+	checked []string
+	err     error
+}
+
+func (d *debtChecker) CheckRelay(ctx context.Context, userAddress string) error {
+	d.checked = append(d.checked, userAddress)
+	return d.err
+}
@@ -0,0 +1,420 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"path"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/objectstore"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/reports"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// ReportHandler manages the scheduled payment, KYC and relay reports
+type ReportHandler struct {
+	scheduler *reports.Scheduler
+	repo      repository.ReportRepository
+	store     objectstore.Store
+	logger    *zap.Logger
+}
+
+// NewReportHandler creates a new report handler with injected dependencies
+func NewReportHandler(scheduler *reports.Scheduler, repo repository.ReportRepository, store objectstore.Store, logger *zap.Logger) *ReportHandler {
+	return &ReportHandler{scheduler: scheduler, repo: repo, store: store, logger: logger}
+}
+
+// ReportResponse wraps report API responses
+type ReportResponse struct {
+	Success bool        `json:"success"`
+	Data    interface{} `json:"data,omitempty"`
+	Error   string      `json:"error,omitempty"`
+}
+
+// CreateReportScheduleRequest is the body of POST /api/v1/admin/reports/schedules
+type CreateReportScheduleRequest struct {
+	Name       string   `json:"name" binding:"required"`
+	Kind       string   `json:"kind" binding:"required"`
+	Cron       string   `json:"cron" binding:"required"`
+	Timezone   string   `json:"timezone"` // IANA name, defaults to UTC
+	Recipients []string `json:"recipients" binding:"required"`
+	Enabled    *bool    `json:"enabled"` // defaults to true
+	CreatedBy  string   `json:"created_by" binding:"required"`
+}
+
+// UpdateReportScheduleRequest is the body of PUT /api/v1/admin/reports/schedules/:id
+type UpdateReportScheduleRequest struct {
+	Name       string   `json:"name" binding:"required"`
+	Kind       string   `json:"kind" binding:"required"`
+	Cron       string   `json:"cron" binding:"required"`
+	Timezone   string   `json:"timezone"` // IANA name, defaults to UTC
+	Recipients []string `json:"recipients" binding:"required"`
+	Enabled    bool     `json:"enabled"`
+}
+
+// CreateReportSchedule handles POST /api/v1/admin/reports/schedules
+// @Summary Create report schedule
+// @Description Schedules a payments, kyc or relay report. Cron is a five-field expression (or @hourly, @daily, @weekly, @monthly) evaluated in the schedule's IANA timezone (default UTC), so an @daily report covers that zone's calendar day; each run covers the records created since the previous one and emails the recipients a download link.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param request body CreateReportScheduleRequest true "Schedule"
+// @Success 201 {object} ReportResponse{data=repository.ReportSchedule}
+// @Failure 400 {object} ReportResponse
+// @Router /api/v1/admin/reports/schedules [post]
+func (h *ReportHandler) CreateReportSchedule(c *gin.Context) {
+	var req CreateReportScheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ReportResponse{
+			Success: false,
+			Error:   "Invalid request: " + err.Error(),
+		})
+		return
+	}
+
+	sched := &repository.ReportSchedule{
+		Name:       req.Name,
+		Kind:       repository.ReportKind(req.Kind),
+		Cron:       req.Cron,
+		Timezone:   req.Timezone,
+		Recipients: req.Recipients,
+		Enabled:    req.Enabled == nil || *req.Enabled,
+		CreatedBy:  req.CreatedBy,
+	}
+	cron, err := reports.Validate(sched)
+	if err != nil {
+		h.respondError(c, "", err)
+		return
+	}
+	sched.NextRunAt = cron.Next(time.Now())
+
+	if err := h.repo.CreateReportSchedule(c.Request.Context(), sched); err != nil {
+		h.respondError(c, "", err)
+		return
+	}
+
+	h.logger.Info("report schedule created",
+		zap.String("schedule_id", sched.ID),
+		zap.String("kind", string(sched.Kind)),
+		zap.String("created_by", sched.CreatedBy),
+	)
+	c.JSON(http.StatusCreated, ReportResponse{
+		Success: true,
+		Data:    sched,
+	})
+}
+
+// ListReportSchedules handles GET /api/v1/admin/reports/schedules
+// @Summary List report schedules
+// @Description Returns report schedules, newest first
+// @Tags admin
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(20)
+// @Success 200 {object} ReportResponse
+// @Failure 401 {object} ReportResponse
+// @Router /api/v1/admin/reports/schedules [get]
+func (h *ReportHandler) ListReportSchedules(c *gin.Context) {
+	page, pageSize, ok := queryPage(c, 20)
+	if !ok {
+		return
+	}
+
+	list, total, err := h.repo.ListReportSchedules(c.Request.Context(), repository.Pagination{
+		Page:     page,
+		PageSize: pageSize,
+	})
+	if err != nil {
+		h.respondError(c, "", err)
+		return
+	}
+	if list == nil {
+		list = []*repository.ReportSchedule{}
+	}
+
+	c.JSON(http.StatusOK, ReportResponse{
+		Success: true,
+		Data: gin.H{
+			"schedules": list,
+			"total":     total,
+			"page":      page,
+			"page_size": pageSize,
+		},
+	})
+}
+
+// GetReportSchedule handles GET /api/v1/admin/reports/schedules/:id
+// @Summary Get report schedule
+// @Description Returns a report schedule with its next and last run times
+// @Tags admin
+// @Produce json
+// @Param id path string true "Schedule ID"
+// @Success 200 {object} ReportResponse{data=repository.ReportSchedule}
+// @Failure 400 {object} ReportResponse
+// @Failure 404 {object} ReportResponse
+// @Router /api/v1/admin/reports/schedules/{id} [get]
+func (h *ReportHandler) GetReportSchedule(c *gin.Context) {
+	id, ok := h.reportID(c)
+	if !ok {
+		return
+	}
+
+	sched, err := h.repo.GetReportSchedule(c.Request.Context(), id)
+	if err != nil {
+		h.respondError(c, id, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, ReportResponse{
+		Success: true,
+		Data:    sched,
+	})
+}
+
+// UpdateReportSchedule handles PUT /api/v1/admin/reports/schedules/:id
+// @Summary Update report schedule
+// @Description Replaces a schedule's name, kind, cron expression, timezone, recipients and enabled flag. The next run is recomputed from the cron expression.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "Schedule ID"
+// @Param request body UpdateReportScheduleRequest true "Schedule"
+// @Success 200 {object} ReportResponse{data=repository.ReportSchedule}
+// @Failure 400 {object} ReportResponse
+// @Failure 404 {object} ReportResponse
+// @Router /api/v1/admin/reports/schedules/{id} [put]
+func (h *ReportHandler) UpdateReportSchedule(c *gin.Context) {
+	id, ok := h.reportID(c)
+	if !ok {
+		return
+	}
+	var req UpdateReportScheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ReportResponse{
+			Success: false,
+			Error:   "Invalid request: " + err.Error(),
+		})
+		return
+	}
+
+	sched := &repository.ReportSchedule{
+		ID:         id,
+		Name:       req.Name,
+		Kind:       repository.ReportKind(req.Kind),
+		Cron:       req.Cron,
+		Timezone:   req.Timezone,
+		Recipients: req.Recipients,
+		Enabled:    req.Enabled,
+	}
+	cron, err := reports.Validate(sched)
+	if err != nil {
+		h.respondError(c, id, err)
+		return
+	}
+	sched.NextRunAt = cron.Next(time.Now())
+
+	if err := h.repo.UpdateReportSchedule(c.Request.Context(), sched); err != nil {
+		h.respondError(c, id, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, ReportResponse{
+		Success: true,
+		Data:    sched,
+	})
+}
+
+// DeleteReportSchedule handles DELETE /api/v1/admin/reports/schedules/:id
+// @Summary Delete report schedule
+// @Description Deletes a report schedule. Its runs and stored reports are kept.
+// @Tags admin
+// @Produce json
+// @Param id path string true "Schedule ID"
+// @Success 200 {object} ReportResponse
+// @Failure 400 {object} ReportResponse
+// @Failure 404 {object} ReportResponse
+// @Router /api/v1/admin/reports/schedules/{id} [delete]
+func (h *ReportHandler) DeleteReportSchedule(c *gin.Context) {
+	id, ok := h.reportID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.repo.DeleteReportSchedule(c.Request.Context(), id); err != nil {
+		h.respondError(c, id, err)
+		return
+	}
+
+	h.logger.Info("report schedule deleted", zap.String("schedule_id", id))
+	c.JSON(http.StatusOK, ReportResponse{
+		Success: true,
+	})
+}
+
+// RunReportSchedule handles POST /api/v1/admin/reports/schedules/:id/run
+// @Summary Run report now
+// @Description Generates a schedule's report now, covering the records since its last scheduled run, and emails the recipients. The schedule's next run is unchanged.
+// @Tags admin
+// @Produce json
+// @Param id path string true "Schedule ID"
+// @Success 200 {object} ReportResponse{data=repository.ReportRun}
+// @Failure 400 {object} ReportResponse
+// @Failure 404 {object} ReportResponse
+// @Router /api/v1/admin/reports/schedules/{id}/run [post]
+func (h *ReportHandler) RunReportSchedule(c *gin.Context) {
+	id, ok := h.reportID(c)
+	if !ok {
+		return
+	}
+
+	run, err := h.scheduler.RunSchedule(c.Request.Context(), id)
+	if err != nil {
+		h.respondError(c, id, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, ReportResponse{
+		Success: run.Status == repository.ReportRunSucceeded,
+		Data:    run,
+	})
+}
+
+// ListReportRuns handles GET /api/v1/admin/reports/runs
+// @Summary List report runs
+// @Description Returns generated reports, newest first
+// @Tags admin
+// @Produce json
+// @Param schedule_id query string false "Schedule ID"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(20)
+// @Success 200 {object} ReportResponse
+// @Failure 400 {object} ReportResponse
+// @Router /api/v1/admin/reports/runs [get]
+func (h *ReportHandler) ListReportRuns(c *gin.Context) {
+	scheduleID := c.Query("schedule_id")
+	if scheduleID != "" && !uuidPattern.MatchString(scheduleID) {
+		c.JSON(http.StatusBadRequest, ReportResponse{
+			Success: false,
+			Error:   "Invalid schedule ID",
+		})
+		return
+	}
+	page, pageSize, ok := queryPage(c, 20)
+	if !ok {
+		return
+	}
+
+	list, total, err := h.repo.ListReportRuns(c.Request.Context(), scheduleID, repository.Pagination{
+		Page:     page,
+		PageSize: pageSize,
+	})
+	if err != nil {
+		h.respondError(c, "", err)
+		return
+	}
+	if list == nil {
+		list = []*repository.ReportRun{}
+	}
+
+	c.JSON(http.StatusOK, ReportResponse{
+		Success: true,
+		Data: gin.H{
+			"runs":      list,
+			"total":     total,
+			"page":      page,
+			"page_size": pageSize,
+		},
+	})
+}
+
+// DownloadReport handles GET /api/v1/admin/reports/runs/:id/download
+// @Summary Download report
+// @Description Streams a generated report's CSV from object storage
+// @Tags admin
+// @Produce text/csv
+// @Param id path string true "Run ID"
+// @Success 200 {file} file
+// @Failure 400 {object} ReportResponse
+// @Failure 404 {object} ReportResponse
+// @Router /api/v1/admin/reports/runs/{id}/download [get]
+func (h *ReportHandler) DownloadReport(c *gin.Context) {
+	id, ok := h.reportID(c)
+	if !ok {
+		return
+	}
+
+	run, err := h.repo.GetReportRun(c.Request.Context(), id)
+	if err != nil {
+		h.respondError(c, id, err)
+		return
+	}
+	if run.ObjectKey == "" {
+		c.JSON(http.StatusNotFound, ReportResponse{
+			Success: false,
+			Error:   "Report was not generated",
+		})
+		return
+	}
+
+	body, err := h.store.Get(c.Request.Context(), run.ObjectKey)
+	if err != nil {
+		h.respondError(c, id, err)
+		return
+	}
+	defer body.Close()
+
+	c.Header("Content-Type", "text/csv")
+	c.Header("Content-Disposition", `attachment; filename="`+path.Base(run.ObjectKey)+`"`)
+	c.Status(http.StatusOK)
+	if _, err := io.Copy(c.Writer, body); err != nil {
+		h.logger.Warn("report download interrupted", zap.String("run_id", id), zap.Error(err))
+	}
+}
+
+// reportID reads the :id parameter, answering 400 when it is not a UUID
+func (h *ReportHandler) reportID(c *gin.Context) (string, bool) {
+	id := c.Param("id")
+	if !uuidPattern.MatchString(id) {
+		c.JSON(http.StatusBadRequest, ReportResponse{
+			Success: false,
+			Error:   "Invalid ID",
+		})
+		return "", false
+	}
+	return id, true
+}
+
+// respondError maps report validation, repository and storage errors to
+// responses
+func (h *ReportHandler) respondError(c *gin.Context, id string, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, reports.ErrInvalidName),
+		errors.Is(err, reports.ErrInvalidKind),
+		errors.Is(err, reports.ErrInvalidCron),
+		errors.Is(err, reports.ErrInvalidRecipients),
+		errors.Is(err, reports.ErrInvalidTimezone):
+		status = http.StatusBadRequest
+	case errors.Is(err, repository.ErrReportScheduleNotFound),
+		errors.Is(err, repository.ErrReportRunNotFound),
+		errors.Is(err, objectstore.ErrNotFound):
+		status = http.StatusNotFound
+	}
+
+	if status == http.StatusInternalServerError {
+		h.logger.Error("report request failed", zap.String("id", id), zap.Error(err))
+		c.JSON(status, ReportResponse{
+			Success: false,
+			Error:   "Internal server error",
+		})
+		return
+	}
+	c.JSON(status, ReportResponse{
+		Success: false,
+		Error:   err.Error(),
+	})
+}
@@ -0,0 +1,106 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/handlers"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/objectstore"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/reports"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/storage/memory"
+)
+
+func TestReportHandler_ScheduleRunAndDownload(t *testing.T) {
+	store := memory.NewStore()
+	objects := objectstore.NewMemory()
+	scheduler := reports.NewScheduler(store.Reports, store.Payments, store.Relayer, objects, zap.NewNop())
+	h := handlers.NewReportHandler(scheduler, store.Reports, objects, zap.NewNop())
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/api/v1/admin/reports/schedules", h.CreateReportSchedule)
+	router.GET("/api/v1/admin/reports/schedules", h.ListReportSchedules)
+	router.GET("/api/v1/admin/reports/schedules/:id", h.GetReportSchedule)
+	router.PUT("/api/v1/admin/reports/schedules/:id", h.UpdateReportSchedule)
+	router.DELETE("/api/v1/admin/reports/schedules/:id", h.DeleteReportSchedule)
+	router.POST("/api/v1/admin/reports/schedules/:id/run", h.RunReportSchedule)
+	router.GET("/api/v1/admin/reports/runs", h.ListReportRuns)
+	router.GET("/api/v1/admin/reports/runs/:id/download", h.DownloadReport)
+
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(method, path, bytes.NewBufferString(body)))
+		return w
+	}
+
+	w := do(http.MethodPost, "/api/v1/admin/reports/schedules",
+		`{"name":"Weekly KYC","kind":"kyc","cron":"every week","recipients":["compliance@example.com"],"created_by":"ops"}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "invalid cron expression")
+	w = do(http.MethodPost, "/api/v1/admin/reports/schedules",
+		`{"name":"Weekly KYC","kind":"kyc","cron":"0 8 * * 1","timezone":"Mars/Olympus","recipients":["compliance@example.com"],"created_by":"ops"}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "IANA time zone")
+
+	w = do(http.MethodPost, "/api/v1/admin/reports/schedules",
+		`{"name":"Weekly KYC","kind":"kyc","cron":"0 8 * * 1","recipients":["compliance@example.com"],"created_by":"ops"}`)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var created struct {
+		Data repository.ReportSchedule `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	id := created.Data.ID
+	assert.True(t, created.Data.Enabled)
+	assert.Equal(t, 1, int(created.Data.NextRunAt.Weekday()))
+	assert.Equal(t, "UTC", created.Data.Timezone)
+
+	w = do(http.MethodPut, "/api/v1/admin/reports/schedules/"+id,
+		`{"name":"Daily KYC","kind":"kyc","cron":"@daily","timezone":"Asia/Tokyo","recipients":["compliance@example.com"],"enabled":false}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	assert.Equal(t, 15, created.Data.NextRunAt.UTC().Hour(), "Tokyo midnight")
+	w = do(http.MethodGet, "/api/v1/admin/reports/schedules/"+id, "")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"name":"Daily KYC"`)
+	assert.Contains(t, w.Body.String(), `"enabled":false`)
+	assert.Contains(t, w.Body.String(), `"created_by":"ops"`)
+
+	w = do(http.MethodPost, "/api/v1/admin/reports/schedules/"+id+"/run", "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var ran struct {
+		Data repository.ReportRun `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &ran))
+	assert.Equal(t, repository.ReportRunSucceeded, ran.Data.Status)
+	assert.True(t, ran.Data.Manual)
+
+	w = do(http.MethodGet, "/api/v1/admin/reports/runs?schedule_id="+id, "")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"total":1`)
+
+	w = do(http.MethodGet, "/api/v1/admin/reports/runs/"+ran.Data.ID+"/download", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/csv", w.Header().Get("Content-Type"))
+	assert.True(t, strings.HasPrefix(w.Body.String(), "id,created_at,user_address,status"), w.Body.String())
+
+	// Runs outlive their schedule
+	w = do(http.MethodDelete, "/api/v1/admin/reports/schedules/"+id, "")
+	require.Equal(t, http.StatusOK, w.Code)
+	w = do(http.MethodGet, "/api/v1/admin/reports/schedules/"+id, "")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	w = do(http.MethodGet, "/api/v1/admin/reports/runs/"+ran.Data.ID+"/download", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = do(http.MethodGet, "/api/v1/admin/reports/runs/not-a-uuid/download", "")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
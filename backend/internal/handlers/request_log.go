@@ -0,0 +1,200 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// uuidPattern validates archived request log IDs before querying
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// RequestLogHandler handles the support query endpoints for archived requests
+type RequestLogHandler struct {
+	repo   repository.RequestLogRepository
+	logger *zap.Logger
+}
+
+// NewRequestLogHandler creates a new request log handler with injected dependencies
+func NewRequestLogHandler(repo repository.RequestLogRepository, logger *zap.Logger) *RequestLogHandler {
+	return &RequestLogHandler{
+		repo:   repo,
+		logger: logger,
+	}
+}
+
+// RequestLogResponse wraps request log API responses
+type RequestLogResponse struct {
+	Success bool        `json:"success"`
+	Data    interface{} `json:"data,omitempty"`
+	Error   string      `json:"error,omitempty"`
+}
+
+// RequestLogListResponse wraps a page of archived requests
+type RequestLogListResponse struct {
+	Success  bool                          `json:"success"`
+	Logs     []*repository.RequestLogEntry `json:"logs"`
+	Total    int64                         `json:"total"`
+	Page     int                           `json:"page"`
+	PageSize int                           `json:"page_size"`
+}
+
+// ListRequestLogs handles GET /api/v1/admin/request-logs
+// @Summary Search archived requests
+// @Description Returns scrubbed request/response logs, newest first. Signatures, tokens and document hashes are redacted at capture time.
+// @Tags admin
+// @Produce json
+// @Param request_id query string false "Request ID (X-Request-ID)"
+// @Param method query string false "HTTP method"
+// @Param path query string false "Path prefix"
+// @Param status query int false "Exact status code"
+// @Param min_status query int false "Minimum status code (e.g. 500 for server errors)"
+// @Param client_ip query string false "Client IP"
+// @Param from query string false "Start time (RFC3339)"
+// @Param to query string false "End time (RFC3339)"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(50)
+// @Param fields query string false "Comma-separated record fields to return (id is always included)"
+// @Success 200 {object} RequestLogListResponse
+// @Failure 400 {object} RequestLogResponse
+// @Failure 401 {object} RequestLogResponse
+// @Router /api/v1/admin/request-logs [get]
+func (h *RequestLogHandler) ListRequestLogs(c *gin.Context) {
+	page, pageSize, ok := queryPage(c, 50)
+	if !ok {
+		return
+	}
+
+	filter := repository.RequestLogFilter{
+		RequestID: c.Query("request_id"),
+		Method:    strings.ToUpper(c.Query("method")),
+		Path:      c.Query("path"),
+		ClientIP:  c.Query("client_ip"),
+	}
+
+	var err error
+	if filter.StatusCode, err = parseOptionalInt(c.Query("status")); err != nil {
+		c.JSON(http.StatusBadRequest, RequestLogResponse{
+			Success: false,
+			Error:   "Invalid status",
+		})
+		return
+	}
+	if filter.MinStatus, err = parseOptionalInt(c.Query("min_status")); err != nil {
+		c.JSON(http.StatusBadRequest, RequestLogResponse{
+			Success: false,
+			Error:   "Invalid min_status",
+		})
+		return
+	}
+	if filter.From, err = parseOptionalTime(c.Query("from")); err != nil {
+		c.JSON(http.StatusBadRequest, RequestLogResponse{
+			Success: false,
+			Error:   "Invalid from time, expected RFC3339",
+		})
+		return
+	}
+	if filter.To, err = parseOptionalTime(c.Query("to")); err != nil {
+		c.JSON(http.StatusBadRequest, RequestLogResponse{
+			Success: false,
+			Error:   "Invalid to time, expected RFC3339",
+		})
+		return
+	}
+
+	logs, total, err := h.repo.ListRequestLogs(c.Request.Context(), filter, repository.Pagination{
+		Page:     page,
+		PageSize: pageSize,
+	})
+	if err != nil {
+		h.logger.Error("failed to list request logs", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, RequestLogResponse{
+			Success: false,
+			Error:   "Internal server error",
+		})
+		return
+	}
+
+	if logs == nil {
+		logs = []*repository.RequestLogEntry{}
+	}
+
+	c.JSON(http.StatusOK, RequestLogListResponse{
+		Success:  true,
+		Logs:     logs,
+		Total:    total,
+		Page:     page,
+		PageSize: pageSize,
+	})
+}
+
+// GetRequestLog handles GET /api/v1/admin/request-logs/:id
+// @Summary Get an archived request
+// @Description Returns a single scrubbed request/response log entry
+// @Tags admin
+// @Produce json
+// @Param id path string true "Request log ID"
+// @Success 200 {object} RequestLogResponse
+// @Failure 400 {object} RequestLogResponse
+// @Failure 404 {object} RequestLogResponse
+// @Router /api/v1/admin/request-logs/{id} [get]
+func (h *RequestLogHandler) GetRequestLog(c *gin.Context) {
+	id := c.Param("id")
+	if !uuidPattern.MatchString(id) {
+		c.JSON(http.StatusBadRequest, RequestLogResponse{
+			Success: false,
+			Error:   "Invalid request log ID",
+		})
+		return
+	}
+
+	entry, err := h.repo.GetRequestLog(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, repository.ErrRequestLogNotFound) {
+			c.JSON(http.StatusNotFound, RequestLogResponse{
+				Success: false,
+				Error:   "Request log not found",
+			})
+			return
+		}
+		h.logger.Error("failed to get request log", zap.String("id", id), zap.Error(err))
+		c.JSON(http.StatusInternalServerError, RequestLogResponse{
+			Success: false,
+			Error:   "Internal server error",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, RequestLogResponse{
+		Success: true,
+		Data:    entry,
+	})
+}
+
+// parseOptionalInt parses an integer query value; empty means zero (unset)
+func parseOptionalInt(s string) (int, error) {
+	if s == "" {
+		return 0, nil
+	}
+	return strconv.Atoi(s)
+}
+
+// parseOptionalTime parses an RFC3339 query value; empty means nil (unset)
+func parseOptionalTime(s string) (*time.Time, error) {
+	if s == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
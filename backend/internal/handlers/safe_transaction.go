@@ -0,0 +1,365 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/safetx"
+)
+
+// SafeTransactionHandler handles proposing, confirming and executing Safe
+// multisig transactions
+type SafeTransactionHandler struct {
+	service *safetx.Service
+	repo    repository.SafeTransactionRepository
+	logger  *zap.Logger
+}
+
+// NewSafeTransactionHandler creates a new Safe transaction handler with
+// injected dependencies
+func NewSafeTransactionHandler(service *safetx.Service, repo repository.SafeTransactionRepository, logger *zap.Logger) *SafeTransactionHandler {
+	return &SafeTransactionHandler{service: service, repo: repo, logger: logger}
+}
+
+// SafeTransactionResponse wraps Safe transaction API responses
+type SafeTransactionResponse struct {
+	Success bool        `json:"success"`
+	Data    interface{} `json:"data,omitempty"`
+	Error   string      `json:"error,omitempty"`
+}
+
+// ProposeSafeTransactionRequest is the body of POST
+// /api/v1/admin/safe/transactions
+type ProposeSafeTransactionRequest struct {
+	SafeAddress    string  `json:"safe_address" binding:"required"`
+	To             string  `json:"to" binding:"required"`
+	Value          string  `json:"value"`
+	Data           string  `json:"data"`
+	Operation      uint8   `json:"operation"`
+	SafeTxGas      string  `json:"safe_tx_gas"`
+	BaseGas        string  `json:"base_gas"`
+	GasPrice       string  `json:"gas_price"`
+	GasToken       string  `json:"gas_token"`
+	RefundReceiver string  `json:"refund_receiver"`
+	Nonce          *uint64 `json:"nonce"`
+	Description    string  `json:"description"`
+	ProposedBy     string  `json:"proposed_by" binding:"required"`
+	Signature      string  `json:"signature"`
+}
+
+// ConfirmSafeTransactionRequest is the body of POST
+// /api/v1/safe/transactions/:id/confirmations
+type ConfirmSafeTransactionRequest struct {
+	Signature string `json:"signature" binding:"required"`
+}
+
+// CancelSafeTransactionRequest is the body of POST
+// /api/v1/admin/safe/transactions/:id/cancel
+type CancelSafeTransactionRequest struct {
+	CancelledBy string `json:"cancelled_by" binding:"required"`
+	Reason      string `json:"reason"`
+}
+
+// ProposeSafeTransaction handles POST /api/v1/admin/safe/transactions
+// @Summary Propose a Safe transaction
+// @Description Records a transaction for a Safe multisig to collect owner signatures. Amounts are decimal wei; the nonce defaults to the Safe's next one. The response carries the safe_tx_hash owners sign (EIP-712, or eth_sign). A signature in the request is counted as the proposer's confirmation.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param request body ProposeSafeTransactionRequest true "Safe transaction"
+// @Success 201 {object} SafeTransactionResponse{data=repository.SafeTransaction}
+// @Failure 400 {object} SafeTransactionResponse
+// @Failure 409 {object} SafeTransactionResponse
+// @Failure 503 {object} SafeTransactionResponse
+// @Router /api/v1/admin/safe/transactions [post]
+func (h *SafeTransactionHandler) ProposeSafeTransaction(c *gin.Context) {
+	var req ProposeSafeTransactionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, SafeTransactionResponse{
+			Success: false,
+			Error:   "Invalid request: " + err.Error(),
+		})
+		return
+	}
+
+	tx, err := h.service.Propose(c.Request.Context(), safetx.Request{
+		SafeAddress:    req.SafeAddress,
+		To:             req.To,
+		Value:          req.Value,
+		Data:           req.Data,
+		Operation:      repository.SafeOperation(req.Operation),
+		SafeTxGas:      req.SafeTxGas,
+		BaseGas:        req.BaseGas,
+		GasPrice:       req.GasPrice,
+		GasToken:       req.GasToken,
+		RefundReceiver: req.RefundReceiver,
+		Nonce:          req.Nonce,
+		Description:    req.Description,
+		ProposedBy:     req.ProposedBy,
+		Signature:      req.Signature,
+	})
+	if err != nil {
+		h.respondError(c, "", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, SafeTransactionResponse{
+		Success: true,
+		Data:    tx,
+	})
+}
+
+// ListSafeTransactions handles GET /api/v1/safe/transactions
+// @Summary List Safe transactions
+// @Description Returns Safe transactions with their confirmation counts, newest first
+// @Tags safe
+// @Produce json
+// @Param safe query string false "Safe address"
+// @Param status query string false "awaiting_confirmations, ready, cancelled, sending, submitted, executed or failed"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(20)
+// @Success 200 {object} SafeTransactionResponse
+// @Router /api/v1/safe/transactions [get]
+func (h *SafeTransactionHandler) ListSafeTransactions(c *gin.Context) {
+	page, pageSize, ok := queryPage(c, 20)
+	if !ok {
+		return
+	}
+
+	list, total, err := h.repo.ListSafeTransactions(c.Request.Context(), repository.SafeTransactionFilter{
+		SafeAddress: strings.ToLower(c.Query("safe")),
+		Status:      repository.SafeTransactionStatus(c.Query("status")),
+	}, repository.Pagination{
+		Page:     page,
+		PageSize: pageSize,
+	})
+	if err != nil {
+		h.logger.Error("failed to list Safe transactions", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, SafeTransactionResponse{
+			Success: false,
+			Error:   "Internal server error",
+		})
+		return
+	}
+	if list == nil {
+		list = []*repository.SafeTransaction{}
+	}
+
+	c.JSON(http.StatusOK, SafeTransactionResponse{
+		Success: true,
+		Data: gin.H{
+			"transactions": list,
+			"total":        total,
+			"page":         page,
+			"page_size":    pageSize,
+		},
+	})
+}
+
+// GetSafeTransaction handles GET /api/v1/safe/transactions/:id
+// @Summary Get Safe transaction
+// @Description Returns a Safe transaction with its status, confirmation count and the owners' confirmations
+// @Tags safe
+// @Produce json
+// @Param id path string true "Safe transaction ID"
+// @Success 200 {object} SafeTransactionResponse
+// @Failure 400 {object} SafeTransactionResponse
+// @Failure 404 {object} SafeTransactionResponse
+// @Router /api/v1/safe/transactions/{id} [get]
+func (h *SafeTransactionHandler) GetSafeTransaction(c *gin.Context) {
+	id, ok := h.safeTxID(c)
+	if !ok {
+		return
+	}
+
+	tx, err := h.repo.GetSafeTransaction(c.Request.Context(), id)
+	if err != nil {
+		h.respondError(c, id, err)
+		return
+	}
+	confirmations, err := h.repo.ListSafeConfirmations(c.Request.Context(), id)
+	if err != nil {
+		h.respondError(c, id, err)
+		return
+	}
+	if confirmations == nil {
+		confirmations = []*repository.SafeConfirmation{}
+	}
+
+	c.JSON(http.StatusOK, SafeTransactionResponse{
+		Success: true,
+		Data: gin.H{
+			"transaction":   tx,
+			"confirmations": confirmations,
+		},
+	})
+}
+
+// ConfirmSafeTransaction handles POST /api/v1/safe/transactions/:id/confirmations
+// @Summary Confirm a Safe transaction
+// @Description Records a Safe owner's signature of the transaction's safe_tx_hash. The signer must be a current owner of the Safe; the transaction is ready to execute once the Safe's threshold of owners have confirmed.
+// @Tags safe
+// @Accept json
+// @Produce json
+// @Param id path string true "Safe transaction ID"
+// @Param request body ConfirmSafeTransactionRequest true "Owner signature"
+// @Success 200 {object} SafeTransactionResponse{data=repository.SafeTransaction}
+// @Failure 400 {object} SafeTransactionResponse
+// @Failure 403 {object} SafeTransactionResponse
+// @Failure 404 {object} SafeTransactionResponse
+// @Failure 409 {object} SafeTransactionResponse
+// @Router /api/v1/safe/transactions/{id}/confirmations [post]
+func (h *SafeTransactionHandler) ConfirmSafeTransaction(c *gin.Context) {
+	id, ok := h.safeTxID(c)
+	if !ok {
+		return
+	}
+	var req ConfirmSafeTransactionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, SafeTransactionResponse{
+			Success: false,
+			Error:   "Invalid request: " + err.Error(),
+		})
+		return
+	}
+
+	tx, err := h.service.Confirm(c.Request.Context(), id, req.Signature)
+	if err != nil {
+		h.respondError(c, id, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, SafeTransactionResponse{
+		Success: true,
+		Data:    tx,
+	})
+}
+
+// ExecuteSafeTransaction handles POST /api/v1/admin/safe/transactions/:id/execute
+// @Summary Execute a Safe transaction
+// @Description Sends execTransaction with the owners' signatures from a relayer key once the Safe's threshold of current owners have confirmed and the transaction's nonce is the Safe's next. The outcome is reconciled from the receipt.
+// @Tags admin
+// @Produce json
+// @Param id path string true "Safe transaction ID"
+// @Success 200 {object} SafeTransactionResponse{data=repository.SafeTransaction}
+// @Failure 400 {object} SafeTransactionResponse
+// @Failure 404 {object} SafeTransactionResponse
+// @Failure 409 {object} SafeTransactionResponse
+// @Failure 503 {object} SafeTransactionResponse
+// @Router /api/v1/admin/safe/transactions/{id}/execute [post]
+func (h *SafeTransactionHandler) ExecuteSafeTransaction(c *gin.Context) {
+	id, ok := h.safeTxID(c)
+	if !ok {
+		return
+	}
+
+	tx, err := h.service.Execute(c.Request.Context(), id)
+	if err != nil {
+		h.respondError(c, id, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, SafeTransactionResponse{
+		Success: true,
+		Data:    tx,
+	})
+}
+
+// CancelSafeTransaction handles POST /api/v1/admin/safe/transactions/:id/cancel
+// @Summary Cancel a Safe transaction
+// @Description Withdraws a Safe transaction that has not been sent. Owners' signatures stay valid on-chain until another transaction uses the nonce.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "Safe transaction ID"
+// @Param request body CancelSafeTransactionRequest true "Who cancelled and why"
+// @Success 200 {object} SafeTransactionResponse{data=repository.SafeTransaction}
+// @Failure 400 {object} SafeTransactionResponse
+// @Failure 404 {object} SafeTransactionResponse
+// @Failure 409 {object} SafeTransactionResponse
+// @Router /api/v1/admin/safe/transactions/{id}/cancel [post]
+func (h *SafeTransactionHandler) CancelSafeTransaction(c *gin.Context) {
+	id, ok := h.safeTxID(c)
+	if !ok {
+		return
+	}
+	var req CancelSafeTransactionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, SafeTransactionResponse{
+			Success: false,
+			Error:   "Invalid request: " + err.Error(),
+		})
+		return
+	}
+
+	tx, err := h.service.Cancel(c.Request.Context(), id, req.CancelledBy, req.Reason)
+	if err != nil {
+		h.respondError(c, id, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, SafeTransactionResponse{
+		Success: true,
+		Data:    tx,
+	})
+}
+
+// safeTxID reads the :id parameter, answering 400 when it is not a UUID
+func (h *SafeTransactionHandler) safeTxID(c *gin.Context) (string, bool) {
+	id := c.Param("id")
+	if !uuidPattern.MatchString(id) {
+		c.JSON(http.StatusBadRequest, SafeTransactionResponse{
+			Success: false,
+			Error:   "Invalid Safe transaction ID",
+		})
+		return "", false
+	}
+	return id, true
+}
+
+// respondError maps Safe transaction service and repository errors to
+// responses
+func (h *SafeTransactionHandler) respondError(c *gin.Context, id string, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, safetx.ErrInvalidAddress),
+		errors.Is(err, safetx.ErrInvalidAmount),
+		errors.Is(err, safetx.ErrInvalidData),
+		errors.Is(err, safetx.ErrInvalidOperation),
+		errors.Is(err, safetx.ErrNotSafe),
+		errors.Is(err, safetx.ErrInvalidSignature):
+		status = http.StatusBadRequest
+	case errors.Is(err, safetx.ErrNotOwner):
+		status = http.StatusForbidden
+	case errors.Is(err, repository.ErrSafeTransactionNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, repository.ErrSafeTransactionExists),
+		errors.Is(err, repository.ErrSafeTransactionClosed),
+		errors.Is(err, repository.ErrSafeConfirmationExists),
+		errors.Is(err, safetx.ErrStaleNonce),
+		errors.Is(err, safetx.ErrNonceNotDue),
+		errors.Is(err, safetx.ErrBelowThreshold):
+		status = http.StatusConflict
+	case errors.Is(err, safetx.ErrChainUnavailable),
+		errors.Is(err, safetx.ErrExecutionDisabled):
+		status = http.StatusServiceUnavailable
+	}
+
+	if status == http.StatusInternalServerError {
+		h.logger.Error("Safe transaction request failed", zap.String("safe_tx_id", id), zap.Error(err))
+		c.JSON(status, SafeTransactionResponse{
+			Success: false,
+			Error:   "Internal server error",
+		})
+		return
+	}
+	c.JSON(status, SafeTransactionResponse{
+		Success: false,
+		Error:   err.Error(),
+	})
+}
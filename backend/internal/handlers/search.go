@@ -0,0 +1,123 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Search term bounds: trigram matching needs at least three characters
+const (
+	minSearchTermLen = 3
+	maxSearchTermLen = 200
+)
+
+// SearchHandler handles the admin support search endpoint
+type SearchHandler struct {
+	repo   repository.SearchRepository
+	logger *zap.Logger
+}
+
+// NewSearchHandler creates a new search handler with injected dependencies
+func NewSearchHandler(repo repository.SearchRepository, logger *zap.Logger) *SearchHandler {
+	return &SearchHandler{
+		repo:   repo,
+		logger: logger,
+	}
+}
+
+// SearchResponse wraps search API responses
+type SearchResponse struct {
+	Success bool                                `json:"success"`
+	Query   string                              `json:"query,omitempty"`
+	Results []*repository.SearchResult          `json:"results"`
+	Counts  map[repository.SearchResultType]int `json:"counts,omitempty"`
+	Error   string                              `json:"error,omitempty"`
+}
+
+// Search handles GET /api/v1/admin/search
+// @Summary Search payments, KYC verifications and meta-transactions
+// @Description Finds records by partial wallet address, tx hash, Stripe session or payment ID, Sumsub applicant ID or email. Results are typed and ordered by relevance.
+// @Tags admin
+// @Produce json
+// @Param q query string true "Search term (at least 3 characters)"
+// @Param types query string false "Comma-separated record types: payment, kyc_verification, meta_transaction"
+// @Param limit query int false "Maximum results per type" default(20)
+// @Success 200 {object} SearchResponse
+// @Failure 400 {object} SearchResponse
+// @Failure 401 {object} SearchResponse
+// @Router /api/v1/admin/search [get]
+func (h *SearchHandler) Search(c *gin.Context) {
+	term := strings.TrimSpace(c.Query("q"))
+	if len(term) < minSearchTermLen || len(term) > maxSearchTermLen {
+		c.JSON(http.StatusBadRequest, SearchResponse{
+			Success: false,
+			Error:   "Search term must be between 3 and 200 characters",
+		})
+		return
+	}
+
+	limit, ok := queryLimit(c, "limit", 20, 50)
+	if !ok {
+		return
+	}
+
+	query := repository.SearchQuery{Term: term, Limit: limit}
+	if raw := c.Query("types"); raw != "" {
+		for _, t := range strings.Split(raw, ",") {
+			t = strings.TrimSpace(t)
+			if !isSearchResultType(t) {
+				c.JSON(http.StatusBadRequest, SearchResponse{
+					Success: false,
+					Error:   "Invalid type: " + t,
+				})
+				return
+			}
+			query.Types = append(query.Types, repository.SearchResultType(t))
+		}
+	}
+
+	results, err := h.repo.Search(c.Request.Context(), query)
+	if err != nil {
+		h.logger.Error("failed to search records", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, SearchResponse{
+			Success: false,
+			Error:   "Internal server error",
+		})
+		return
+	}
+
+	if results == nil {
+		results = []*repository.SearchResult{}
+	}
+	counts := make(map[repository.SearchResultType]int)
+	for _, t := range repository.SearchResultTypes {
+		if query.Includes(t) {
+			counts[t] = 0
+		}
+	}
+	for _, r := range results {
+		counts[r.Type]++
+	}
+
+	c.JSON(http.StatusOK, SearchResponse{
+		Success: true,
+		Query:   term,
+		Results: results,
+		Counts:  counts,
+	})
+}
+
+// isSearchResultType reports whether t names a searchable record type
+func isSearchResultType(t string) bool {
+	for _, known := range repository.SearchResultTypes {
+		if string(known) == t {
+			return true
+		}
+	}
+	return false
+}
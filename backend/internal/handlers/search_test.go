@@ -0,0 +1,107 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/handlers"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/storage/memory"
+)
+
+func setupSearchTestRouter(t *testing.T) (*gin.Engine, *memory.Store) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	store := memory.NewStore()
+	handler := handlers.NewSearchHandler(store.Search, zap.NewNop())
+
+	router := gin.New()
+	router.GET("/api/v1/admin/search", handler.Search)
+	return router, store
+}
+
+func TestSearchHandler_Search(t *testing.T) {
+	router, store := setupSearchTestRouter(t)
+	ctx := context.Background()
+
+	session := "cs_test_a1b2c3d4"
+	txHash := "0x9f8e7d6c5b4a"
+	require.NoError(t, store.Payments.CreatePayment(ctx, &repository.Payment{
+		PayerAddress: "0x1111111111111111111111111111111111111111", StripeSessionID: &session,
+	}))
+	kyc := &repository.KYCVerification{UserAddress: "0x2222222222222222222222222222222222222222"}
+	require.NoError(t, store.Payments.CreateKYCVerification(ctx, kyc))
+	require.NoError(t, store.Payments.UpdateKYCVerification(ctx, kyc.ID, &repository.KYCVerificationUpdate{
+		SumsubReviewResult: map[string]any{"email": "alice@example.com"},
+	}))
+	metaTx := &repository.MetaTransaction{FromAddress: "0x3333333333333333333333333333333333333333"}
+	require.NoError(t, store.Relayer.CreateMetaTx(ctx, metaTx))
+	require.NoError(t, store.Relayer.UpdateMetaTxStatus(ctx, metaTx.ID, &repository.MetaTxStatusUpdate{
+		Status: repository.MetaTxStatusSubmitted, TxHash: &txHash,
+	}))
+
+	tests := []struct {
+		name      string
+		query     string
+		wantType  repository.SearchResultType
+		wantField string
+	}{
+		{"stripe session", "a1b2c3", repository.SearchResultPayment, "stripe_session_id"},
+		{"email", "ALICE@example", repository.SearchResultKYCVerification, "sumsub_review_result"},
+		{"tx hash", "7d6c5b", repository.SearchResultMetaTx, "tx_hash"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/search?q="+tt.query, nil)
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, http.StatusOK, w.Code)
+			var resp handlers.SearchResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+			require.Len(t, resp.Results, 1)
+			assert.Equal(t, tt.wantType, resp.Results[0].Type)
+			assert.Equal(t, tt.wantField, resp.Results[0].MatchedField)
+			assert.Equal(t, 1, resp.Counts[tt.wantType])
+		})
+	}
+
+	t.Run("types filter", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/search?q=0x3&types=meta_transaction", nil)
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var resp handlers.SearchResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		for _, r := range resp.Results {
+			assert.Equal(t, repository.SearchResultMetaTx, r.Type)
+			assert.NotNil(t, r.MetaTransaction)
+		}
+		assert.NotContains(t, resp.Counts, repository.SearchResultPayment)
+	})
+}
+
+func TestSearchHandler_Search_InvalidInput(t *testing.T) {
+	router, _ := setupSearchTestRouter(t)
+
+	for _, path := range []string{
+		"/api/v1/admin/search",
+		"/api/v1/admin/search?q=ab",
+		"/api/v1/admin/search?q=0xabc&types=invoice",
+	} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		assert.Equal(t, http.StatusBadRequest, w.Code, path)
+	}
+}
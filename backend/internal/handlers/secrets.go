@@ -0,0 +1,25 @@
+package handlers
+
+import "os"
+
+// SecretSource returns the current value of a named secret (implemented by
+// secrets.Store). Handlers read secrets through it on every use so rotated
+// values apply without a restart.
+type SecretSource interface {
+	Get(name string) string
+}
+
+// envSecrets reads secrets from the environment; it is the default source
+type envSecrets struct{}
+
+func (envSecrets) Get(name string) string {
+	return os.Getenv(name)
+}
+
+// secretSourceOrEnv returns src, or the environment if src is nil
+func secretSourceOrEnv(src SecretSource) SecretSource {
+	if src == nil {
+		return envSecrets{}
+	}
+	return src
+}
@@ -0,0 +1,364 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/snapshot"
+)
+
+// unifiedReadPageSize is how many mirrored proposals the unified list reads
+// per query
+const unifiedReadPageSize = 100
+
+// Proposal sources in the unified list
+const (
+	ProposalSourceOnchain  = "onchain"
+	ProposalSourceSnapshot = "snapshot"
+)
+
+// SnapshotHandler serves proposals mirrored from Snapshot and the unified
+// list of on-chain and Snapshot proposals
+type SnapshotHandler struct {
+	repo       repository.SnapshotRepository
+	governance *GovernanceHandler
+	syncer     *snapshot.Syncer
+	logger     *zap.Logger
+}
+
+// NewSnapshotHandler creates a new Snapshot handler with injected dependencies
+func NewSnapshotHandler(repo repository.SnapshotRepository, governance *GovernanceHandler, syncer *snapshot.Syncer, logger *zap.Logger) *SnapshotHandler {
+	return &SnapshotHandler{repo: repo, governance: governance, syncer: syncer, logger: logger}
+}
+
+// SnapshotResponse wraps Snapshot API responses
+type SnapshotResponse struct {
+	Success bool        `json:"success"`
+	Data    interface{} `json:"data,omitempty"`
+	Error   string      `json:"error,omitempty"`
+}
+
+// LinkSnapshotProposalRequest is the body of the Snapshot link endpoint
+type LinkSnapshotProposalRequest struct {
+	OnchainProposalID *string `json:"onchain_proposal_id"` // null unlinks
+}
+
+// UnifiedProposal is an entry of the unified proposal list: an on-chain
+// proposal with the Snapshot votes linked to it, or a Snapshot proposal not
+// linked to one
+type UnifiedProposal struct {
+	ID        string                         `json:"id"`
+	Source    string                         `json:"source"` // onchain or snapshot
+	Title     string                         `json:"title"`
+	State     string                         `json:"state"`
+	StartTime time.Time                      `json:"start_time"`
+	EndTime   time.Time                      `json:"end_time"`
+	Onchain   *Proposal                      `json:"onchain,omitempty"`
+	Snapshot  []*repository.SnapshotProposal `json:"snapshot,omitempty"`
+}
+
+// ListSnapshotProposals handles GET /api/v1/governance/snapshot/proposals
+// @Summary List Snapshot proposals
+// @Description Returns proposals mirrored from Snapshot with their latest results, newest first
+// @Tags governance
+// @Produce json
+// @Param state query string false "pending, active or closed"
+// @Param onchain_proposal_id query string false "Only proposals linked to this on-chain proposal"
+// @Param unlinked query bool false "Only proposals not linked to an on-chain proposal"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(20)
+// @Success 200 {object} SnapshotResponse
+// @Router /api/v1/governance/snapshot/proposals [get]
+func (h *SnapshotHandler) ListSnapshotProposals(c *gin.Context) {
+	page, pageSize, ok := queryPage(c, 20)
+	if !ok {
+		return
+	}
+
+	proposals, total, err := h.repo.ListSnapshotProposals(c.Request.Context(), repository.SnapshotProposalFilter{
+		State:             repository.SnapshotProposalState(c.Query("state")),
+		OnchainProposalID: c.Query("onchain_proposal_id"),
+		Unlinked:          c.Query("unlinked") == "true",
+	}, repository.Pagination{
+		Page:     page,
+		PageSize: pageSize,
+	})
+	if err != nil {
+		h.logger.Error("failed to list snapshot proposals", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, SnapshotResponse{
+			Success: false,
+			Error:   "Internal server error",
+		})
+		return
+	}
+	if proposals == nil {
+		proposals = []*repository.SnapshotProposal{}
+	}
+
+	c.JSON(http.StatusOK, SnapshotResponse{
+		Success: true,
+		Data: gin.H{
+			"proposals": proposals,
+			"total":     total,
+			"page":      page,
+			"page_size": pageSize,
+		},
+	})
+}
+
+// GetSnapshotProposal handles GET /api/v1/governance/snapshot/proposals/:id
+// @Summary Get Snapshot proposal
+// @Description Returns a mirrored Snapshot proposal with its latest results
+// @Tags governance
+// @Produce json
+// @Param id path string true "Snapshot proposal ID"
+// @Success 200 {object} SnapshotResponse{data=repository.SnapshotProposal}
+// @Failure 400 {object} SnapshotResponse
+// @Failure 404 {object} SnapshotResponse
+// @Router /api/v1/governance/snapshot/proposals/{id} [get]
+func (h *SnapshotHandler) GetSnapshotProposal(c *gin.Context) {
+	id, ok := h.proposalID(c)
+	if !ok {
+		return
+	}
+
+	p, err := h.repo.GetSnapshotProposal(c.Request.Context(), id)
+	if err != nil {
+		h.respondError(c, id, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, SnapshotResponse{
+		Success: true,
+		Data:    p,
+	})
+}
+
+// ListUnifiedProposals handles GET /api/v1/governance/proposals/unified
+// @Summary List on-chain and Snapshot proposals
+// @Description Returns on-chain proposals, each with the Snapshot votes linked to it, and the Snapshot proposals not linked to one, latest start first
+// @Tags governance
+// @Produce json
+// @Param source query string false "onchain or snapshot"
+// @Param state query string false "Filter by state (on-chain or Snapshot)"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(10)
+// @Success 200 {object} SnapshotResponse
+// @Router /api/v1/governance/proposals/unified [get]
+func (h *SnapshotHandler) ListUnifiedProposals(c *gin.Context) {
+	page, pageSize, ok := queryPage(c, 10)
+	if !ok {
+		return
+	}
+	source := c.Query("source")
+	state := c.Query("state")
+
+	mirrored, err := h.allSnapshotProposals(c)
+	if err != nil {
+		h.logger.Error("failed to list snapshot proposals", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, SnapshotResponse{
+			Success: false,
+			Error:   "Internal server error",
+		})
+		return
+	}
+
+	linked := make(map[string][]*repository.SnapshotProposal)
+	var entries []*UnifiedProposal
+	for _, p := range mirrored {
+		if p.OnchainProposalID != nil {
+			linked[*p.OnchainProposalID] = append(linked[*p.OnchainProposalID], p)
+			continue
+		}
+		entries = append(entries, &UnifiedProposal{
+			ID:        p.ID,
+			Source:    ProposalSourceSnapshot,
+			Title:     p.Title,
+			State:     string(p.State),
+			StartTime: p.StartTime,
+			EndTime:   p.EndTime,
+			Snapshot:  []*repository.SnapshotProposal{p},
+		})
+	}
+	for _, proposal := range h.governance.proposalCopies() {
+		proposal := proposal
+		entries = append(entries, &UnifiedProposal{
+			ID:        proposal.ID,
+			Source:    ProposalSourceOnchain,
+			Title:     proposal.Title,
+			State:     string(proposal.State),
+			StartTime: proposal.StartTime,
+			EndTime:   proposal.EndTime,
+			Onchain:   &proposal,
+			Snapshot:  linked[proposal.ID],
+		})
+	}
+
+	filtered := entries[:0]
+	for _, e := range entries {
+		if (source == "" || e.Source == source) && (state == "" || e.State == state) {
+			filtered = append(filtered, e)
+		}
+	}
+	sort.SliceStable(filtered, func(i, j int) bool {
+		return filtered[i].StartTime.After(filtered[j].StartTime)
+	})
+
+	total := len(filtered)
+	start := (page - 1) * pageSize
+	if start > total {
+		start = total
+	}
+	end := start + pageSize
+	if end > total {
+		end = total
+	}
+
+	c.JSON(http.StatusOK, SnapshotResponse{
+		Success: true,
+		Data: gin.H{
+			"proposals": append([]*UnifiedProposal{}, filtered[start:end]...),
+			"total":     total,
+			"page":      page,
+			"page_size": pageSize,
+		},
+	})
+}
+
+// SyncSnapshot handles POST /api/v1/admin/governance/snapshot/sync
+// @Summary Sync Snapshot proposals
+// @Description Mirrors the app_config snapshot.space proposals and results now, whether or not background mirroring is enabled
+// @Tags admin
+// @Produce json
+// @Success 200 {object} SnapshotResponse
+// @Failure 409 {object} SnapshotResponse
+// @Failure 502 {object} SnapshotResponse
+// @Router /api/v1/admin/governance/snapshot/sync [post]
+func (h *SnapshotHandler) SyncSnapshot(c *gin.Context) {
+	synced, err := h.syncer.Sync(c.Request.Context())
+	if errors.Is(err, snapshot.ErrNoSpace) {
+		c.JSON(http.StatusConflict, SnapshotResponse{
+			Success: false,
+			Error:   "app_config snapshot.space is not set",
+		})
+		return
+	}
+	if err != nil {
+		h.logger.Warn("snapshot sync failed", zap.Int("synced", synced), zap.Error(err))
+		c.JSON(http.StatusBadGateway, SnapshotResponse{
+			Success: false,
+			Error:   "Snapshot sync failed",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SnapshotResponse{
+		Success: true,
+		Data:    gin.H{"synced": synced},
+	})
+}
+
+// LinkSnapshotProposal handles PUT /api/v1/admin/governance/snapshot/proposals/:id/link
+// @Summary Link Snapshot proposal
+// @Description Links a mirrored Snapshot proposal to the on-chain proposal it went on to, or unlinks it with a null onchain_proposal_id. Syncs link proposals automatically when an on-chain description links to them, so an unlinked proposal may be linked again.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "Snapshot proposal ID"
+// @Param request body LinkSnapshotProposalRequest true "On-chain proposal"
+// @Success 200 {object} SnapshotResponse{data=repository.SnapshotProposal}
+// @Failure 400 {object} SnapshotResponse
+// @Failure 404 {object} SnapshotResponse
+// @Router /api/v1/admin/governance/snapshot/proposals/{id}/link [put]
+func (h *SnapshotHandler) LinkSnapshotProposal(c *gin.Context) {
+	id, ok := h.proposalID(c)
+	if !ok {
+		return
+	}
+	var req LinkSnapshotProposalRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, SnapshotResponse{
+			Success: false,
+			Error:   "Invalid request: " + err.Error(),
+		})
+		return
+	}
+	if req.OnchainProposalID != nil && *req.OnchainProposalID == "" {
+		req.OnchainProposalID = nil
+	}
+	if req.OnchainProposalID != nil && !h.governance.hasProposal(*req.OnchainProposalID) {
+		c.JSON(http.StatusNotFound, SnapshotResponse{
+			Success: false,
+			Error:   "On-chain proposal not found",
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	if err := h.repo.LinkSnapshotProposal(ctx, id, req.OnchainProposalID); err != nil {
+		h.respondError(c, id, err)
+		return
+	}
+	p, err := h.repo.GetSnapshotProposal(ctx, id)
+	if err != nil {
+		h.respondError(c, id, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, SnapshotResponse{
+		Success: true,
+		Data:    p,
+	})
+}
+
+// allSnapshotProposals reads every mirrored proposal
+func (h *SnapshotHandler) allSnapshotProposals(c *gin.Context) ([]*repository.SnapshotProposal, error) {
+	var all []*repository.SnapshotProposal
+	for page := 1; ; page++ {
+		proposals, total, err := h.repo.ListSnapshotProposals(c.Request.Context(), repository.SnapshotProposalFilter{},
+			repository.Pagination{Page: page, PageSize: unifiedReadPageSize})
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, proposals...)
+		if len(proposals) < unifiedReadPageSize || int64(len(all)) >= total {
+			return all, nil
+		}
+	}
+}
+
+// proposalID reads the :id parameter, answering 400 when it is not a
+// Snapshot proposal ID
+func (h *SnapshotHandler) proposalID(c *gin.Context) (string, bool) {
+	id := c.Param("id")
+	if !snapshot.ValidProposalID(id) {
+		c.JSON(http.StatusBadRequest, SnapshotResponse{
+			Success: false,
+			Error:   "Invalid Snapshot proposal ID",
+		})
+		return "", false
+	}
+	return id, true
+}
+
+// respondError maps Snapshot repository errors to responses
+func (h *SnapshotHandler) respondError(c *gin.Context, id string, err error) {
+	if errors.Is(err, repository.ErrSnapshotProposalNotFound) {
+		c.JSON(http.StatusNotFound, SnapshotResponse{
+			Success: false,
+			Error:   "Snapshot proposal not found",
+		})
+		return
+	}
+	h.logger.Error("snapshot proposal request failed", zap.String("snapshot_proposal_id", id), zap.Error(err))
+	c.JSON(http.StatusInternalServerError, SnapshotResponse{
+		Success: false,
+		Error:   "Internal server error",
+	})
+}
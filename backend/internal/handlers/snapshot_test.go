@@ -0,0 +1,116 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/handlers"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/snapshot"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/storage/memory"
+)
+
+func setupSnapshotTestRouter(t *testing.T) (*gin.Engine, *memory.Store) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	store := memory.NewStore()
+	governance := handlers.NewGovernanceHandler(zap.NewNop(), store.GovernanceConfig, 31337)
+	now := time.Now().UTC()
+	governance.Seed([]*handlers.Proposal{
+		{ID: "onchain-1", Title: "Fund the grants program", State: handlers.ProposalStateQueued,
+			StartTime: now.Add(-48 * time.Hour), EndTime: now.Add(-24 * time.Hour), CreatedAt: now.Add(-49 * time.Hour)},
+	})
+	syncer := snapshot.NewSyncer(snapshot.NewClient(), store.Snapshot, store.AppConfig, zap.NewNop())
+	handler := handlers.NewSnapshotHandler(store.Snapshot, governance, syncer, zap.NewNop())
+
+	router := gin.New()
+	router.GET("/api/v1/governance/proposals/unified", handler.ListUnifiedProposals)
+	router.PUT("/api/v1/admin/governance/snapshot/proposals/:id/link", handler.LinkSnapshotProposal)
+	router.POST("/api/v1/admin/governance/snapshot/sync", handler.SyncSnapshot)
+	return router, store
+}
+
+func TestSnapshotHandler_UnifiedList(t *testing.T) {
+	router, store := setupSnapshotTestRouter(t)
+	ctx := context.Background()
+	now := time.Now().UTC()
+
+	temp, final, open := fmt.Sprintf("0x%064x", 1), fmt.Sprintf("0x%064x", 2), fmt.Sprintf("0x%064x", 3)
+	for i, id := range []string{temp, final, open} {
+		state := repository.SnapshotProposalClosed
+		if id == open {
+			state = repository.SnapshotProposalActive
+		}
+		require.NoError(t, store.Snapshot.UpsertSnapshotProposal(ctx, &repository.SnapshotProposal{
+			ID: id, Space: "nexusprotocol.eth", Title: "Snapshot " + id[len(id)-1:], State: state,
+			StartTime: now.Add(time.Duration(i-5) * 24 * time.Hour), EndTime: now.Add(time.Duration(i-4) * 24 * time.Hour), CreatedAt: now,
+		}))
+	}
+
+	link := func(id, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPut, "/api/v1/admin/governance/snapshot/proposals/"+id+"/link", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+		return w
+	}
+	require.Equal(t, http.StatusOK, link(temp, `{"onchain_proposal_id":"onchain-1"}`).Code)
+	require.Equal(t, http.StatusOK, link(final, `{"onchain_proposal_id":"onchain-1"}`).Code)
+	assert.Equal(t, http.StatusNotFound, link(open, `{"onchain_proposal_id":"onchain-9"}`).Code)
+	assert.Equal(t, http.StatusNotFound, link(fmt.Sprintf("0x%064x", 4), `{"onchain_proposal_id":"onchain-1"}`).Code)
+	assert.Equal(t, http.StatusBadRequest, link("onchain-1", `{}`).Code)
+
+	list := func(query string) []handlers.UnifiedProposal {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/governance/proposals/unified"+query, nil))
+		require.Equal(t, http.StatusOK, w.Code)
+		var resp struct {
+			Data struct {
+				Proposals []handlers.UnifiedProposal `json:"proposals"`
+				Total     int                        `json:"total"`
+			} `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Len(t, resp.Data.Proposals, resp.Data.Total)
+		return resp.Data.Proposals
+	}
+
+	all := list("")
+	require.Len(t, all, 2, "linked Snapshot votes are shown with their on-chain proposal")
+	assert.Equal(t, handlers.ProposalSourceOnchain, all[0].Source, "latest start first")
+	assert.Equal(t, "onchain-1", all[0].ID)
+	assert.Equal(t, "queued", all[0].State)
+	require.NotNil(t, all[0].Onchain)
+	assert.Len(t, all[0].Snapshot, 2)
+	assert.Equal(t, handlers.ProposalSourceSnapshot, all[1].Source)
+	assert.Equal(t, open, all[1].ID)
+	assert.Equal(t, "active", all[1].State)
+
+	onchain := list("?source=onchain")
+	require.Len(t, onchain, 1)
+	assert.Equal(t, "onchain-1", onchain[0].ID)
+
+	// Unlinking puts the Snapshot vote back in the list
+	require.Equal(t, http.StatusOK, link(temp, `{"onchain_proposal_id":null}`).Code)
+	assert.Len(t, list("?source=snapshot"), 2)
+}
+
+func TestSnapshotHandler_SyncNeedsSpace(t *testing.T) {
+	router, _ := setupSnapshotTestRouter(t)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/admin/governance/snapshot/sync", nil))
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
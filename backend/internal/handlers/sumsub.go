@@ -10,13 +10,15 @@ import (
 	"fmt"
 	"io"
 	"net/http"
-	"os"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/breaker"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/outbound"
 	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
 )
 
@@ -26,10 +28,19 @@ type SumsubHandler struct {
 	pricingRepo   repository.PricingRepository
 	configRepo    repository.AppConfigRepository
 	logger        *zap.Logger
-	appToken      string
-	secretKey     string
-	webhookSecret string
+	secrets       SecretSource
 	chainID       int64
+	breaker       *breaker.Breaker
+	webhookQueue  WebhookQueue
+	httpClient    *http.Client
+	digestAlgorithms []SumsubDigestAlgorithm
+}
+
+// WebhookQueue stores inbound webhook events for background processing
+// (implemented by webhooks.Processor)
+type WebhookQueue interface {
+	// Enqueue stores e and reports false if it was already delivered
+	Enqueue(ctx context.Context, e *repository.WebhookEvent) (bool, error)
 }
 
 // NewSumsubHandler creates a new Sumsub handler with injected dependencies
@@ -45,13 +56,39 @@ func NewSumsubHandler(
 		pricingRepo:   pricingRepo,
 		configRepo:    configRepo,
 		logger:        logger,
-		appToken:      os.Getenv("SUMSUB_APP_TOKEN"),
-		secretKey:     os.Getenv("SUMSUB_SECRET_KEY"),
-		webhookSecret: os.Getenv("SUMSUB_WEBHOOK_SECRET"),
+		secrets:       envSecrets{},
 		chainID:       chainID,
+		httpClient:    &http.Client{Timeout: providerTimeout},
+		digestAlgorithms: slices.Clone(DefaultSumsubDigestAlgorithms),
 	}
 }
 
+// SetSecrets reads SUMSUB_APP_TOKEN, SUMSUB_SECRET_KEY,
+// SUMSUB_WEBHOOK_SECRET and SUMSUB_WEBHOOK_SECRET_PREVIOUS from src instead
+// of the environment
+func (h *SumsubHandler) SetSecrets(src SecretSource) {
+	h.secrets = secretSourceOrEnv(src)
+}
+
+// SetBreaker sheds Sumsub API calls with 503 while Sumsub is failing
+func (h *SumsubHandler) SetBreaker(b *breaker.Breaker) {
+	h.breaker = b
+}
+
+// SetHTTPClient sends Sumsub API calls with client, shared by all calls
+// (e.g. an outbound.Transport client over a pooled outbound.NewHTTPTransport).
+// The default only bounds each call by providerTimeout.
+func (h *SumsubHandler) SetHTTPClient(client *http.Client) {
+	h.httpClient = client
+}
+
+// SetWebhookQueue makes HandleWebhook persist events and acknowledge them,
+// leaving processing and retries to the queue. Without a queue, webhooks are
+// applied inline.
+func (h *SumsubHandler) SetWebhookQueue(q WebhookQueue) {
+	h.webhookQueue = q
+}
+
 // SumsubResponse wraps Sumsub API responses
 type SumsubResponse struct {
 	Success bool        `json:"success"`
@@ -168,8 +205,15 @@ func (h *SumsubHandler) CreateApplicant(c *gin.Context) {
 	}
 
 	// Create applicant in Sumsub
-	applicant, err := h.createSumsubApplicant(userAddress)
+	var applicant *SumsubApplicant
+	err = callProvider(h.breaker, func() (err error) {
+		applicant, err = h.createSumsubApplicant(ctx, userAddress)
+		return err
+	}, isSumsubFailure)
 	if err != nil {
+		if respondUnavailable(c, err) {
+			return
+		}
 		h.logger.Error("failed to create Sumsub applicant", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, SumsubResponse{
 			Success: false,
@@ -269,8 +313,15 @@ func (h *SumsubHandler) GetAccessToken(c *gin.Context) {
 	}
 
 	// Get access token from Sumsub
-	token, err := h.getSumsubAccessToken(userAddress)
+	var token *SumsubAccessToken
+	err = callProvider(h.breaker, func() (err error) {
+		token, err = h.getSumsubAccessToken(ctx, userAddress)
+		return err
+	}, isSumsubFailure)
 	if err != nil {
+		if respondUnavailable(c, err) {
+			return
+		}
 		h.logger.Error("failed to get Sumsub access token", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, SumsubResponse{
 			Success: false,
@@ -342,7 +393,7 @@ func (h *SumsubHandler) GetVerificationStatus(c *gin.Context) {
 
 // HandleWebhook handles POST /api/v1/kyc/sumsub/webhook
 // @Summary Handle Sumsub webhook events
-// @Description Processes Sumsub webhook events (verification completion, etc.)
+// @Description Verifies and stores Sumsub webhook events (verification completion, etc.); they are applied in the background with retries
 // @Tags kyc
 // @Accept json
 // @Produce json
@@ -360,7 +411,7 @@ func (h *SumsubHandler) HandleWebhook(c *gin.Context) {
 
 	// Verify webhook signature
 	signature := c.GetHeader("X-Payload-Digest")
-	if !h.verifyWebhookSignature(body, signature) {
+	if !h.verifyWebhookSignature(body, signature, c.GetHeader("X-Payload-Digest-Alg")) {
 		h.logger.Warn("invalid webhook signature")
 		c.JSON(http.StatusUnauthorized, SumsubResponse{
 			Success: false,
@@ -388,13 +439,64 @@ func (h *SumsubHandler) HandleWebhook(c *gin.Context) {
 		zap.String("review_status", payload.ReviewStatus),
 	)
 
+	if h.webhookQueue == nil {
+		if err := h.applyWebhook(ctx, &payload); err != nil {
+			h.logger.Error("failed to update verification", zap.Error(err))
+		}
+		c.JSON(http.StatusOK, SumsubResponse{Success: true})
+		return
+	}
+
+	// Sumsub payloads carry no event ID; a redelivery repeats the body, so
+	// its digest identifies the event
+	digest := sha256.Sum256(body)
+	event := &repository.WebhookEvent{
+		Provider:  repository.WebhookProviderSumsub,
+		EventID:   hex.EncodeToString(digest[:]),
+		EventType: payload.Type,
+		Payload:   body,
+	}
+	created, err := h.webhookQueue.Enqueue(ctx, event)
+	if err != nil {
+		// Non-2xx makes Sumsub redeliver once storage is back
+		h.logger.Error("failed to queue sumsub webhook", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, SumsubResponse{
+			Success: false,
+			Error:   "Internal server error",
+		})
+		return
+	}
+	if !created {
+		h.logger.Info("ignoring duplicate sumsub webhook",
+			zap.String("event_id", event.ID),
+			zap.String("status", string(event.Status)),
+		)
+	}
+
+	c.JSON(http.StatusOK, SumsubResponse{Success: true, Message: "Event queued"})
+}
+
+// ProcessWebhookEvent applies a stored Sumsub webhook event (called by the
+// background webhook processor)
+func (h *SumsubHandler) ProcessWebhookEvent(ctx context.Context, e *repository.WebhookEvent) error {
+	var payload SumsubWebhookPayload
+	if err := json.Unmarshal(e.Payload, &payload); err != nil {
+		return fmt.Errorf("parsing stored sumsub webhook: %w", err)
+	}
+	return h.applyWebhook(ctx, &payload)
+}
+
+// applyWebhook updates the verification a Sumsub webhook refers to. Webhooks
+// for unknown applicants are ignored.
+func (h *SumsubHandler) applyWebhook(ctx context.Context, payload *SumsubWebhookPayload) error {
 	// Find verification by applicant ID
 	verification, err := h.paymentRepo.GetKYCVerificationByApplicant(ctx, payload.ApplicantID)
 	if err != nil {
+		if !errors.Is(err, repository.ErrKYCNotFound) {
+			return fmt.Errorf("getting verification for applicant %s: %w", payload.ApplicantID, err)
+		}
 		h.logger.Warn("verification not found for applicant", zap.String("applicant_id", payload.ApplicantID))
-		// Still return success to avoid webhook retries
-		c.JSON(http.StatusOK, SumsubResponse{Success: true})
-		return
+		return nil
 	}
 
 	// Process based on event type
@@ -440,12 +542,18 @@ func (h *SumsubHandler) HandleWebhook(c *gin.Context) {
 		update.Status = &status
 	}
 
+	// A verification suspended over a disputed payment keeps its status
+	// until the dispute closes; only a rejection still applies
+	if verification.Status == repository.KYCStatusSuspended && update.Status != nil && *update.Status != repository.KYCStatusRejected {
+		update.Status = nil
+	}
+
 	// Update verification record
 	if err := h.paymentRepo.UpdateKYCVerification(ctx, verification.ID, update); err != nil {
-		h.logger.Error("failed to update verification", zap.Error(err))
+		return fmt.Errorf("updating verification %s: %w", verification.ID, err)
 	}
 
-	c.JSON(http.StatusOK, SumsubResponse{Success: true})
+	return nil
 }
 
 // getSumsubBaseURL returns the Sumsub base URL from config or default
@@ -469,8 +577,9 @@ func (h *SumsubHandler) getSumsubLevelName(ctx context.Context) string {
 }
 
 // createSumsubApplicant creates an applicant in Sumsub
-func (h *SumsubHandler) createSumsubApplicant(externalUserID string) (*SumsubApplicant, error) {
-	ctx := context.Background()
+func (h *SumsubHandler) createSumsubApplicant(ctx context.Context, externalUserID string) (*SumsubApplicant, error) {
+	ctx, cancel := context.WithTimeout(ctx, providerTimeout)
+	defer cancel()
 	baseURL := h.getSumsubBaseURL(ctx)
 	levelName := h.getSumsubLevelName(ctx)
 
@@ -478,7 +587,7 @@ func (h *SumsubHandler) createSumsubApplicant(externalUserID string) (*SumsubApp
 
 	body := fmt.Sprintf(`{"externalUserId":"%s"}`, externalUserID)
 
-	req, err := http.NewRequest("POST", url, strings.NewReader(body))
+	req, err := http.NewRequestWithContext(ctx, "POST", url, strings.NewReader(body))
 	if err != nil {
 		return nil, err
 	}
@@ -486,7 +595,7 @@ func (h *SumsubHandler) createSumsubApplicant(externalUserID string) (*SumsubApp
 	h.signRequest(req, []byte(body))
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := h.httpClient.Do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -494,7 +603,7 @@ func (h *SumsubHandler) createSumsubApplicant(externalUserID string) (*SumsubApp
 
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
 		respBody, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("Sumsub API error: %s", string(respBody))
+		return nil, &sumsubAPIError{StatusCode: resp.StatusCode, Body: string(respBody)}
 	}
 
 	var applicant SumsubApplicant
@@ -506,21 +615,22 @@ func (h *SumsubHandler) createSumsubApplicant(externalUserID string) (*SumsubApp
 }
 
 // getSumsubAccessToken gets an access token for the WebSDK
-func (h *SumsubHandler) getSumsubAccessToken(externalUserID string) (*SumsubAccessToken, error) {
-	ctx := context.Background()
+func (h *SumsubHandler) getSumsubAccessToken(ctx context.Context, externalUserID string) (*SumsubAccessToken, error) {
+	ctx, cancel := context.WithTimeout(ctx, providerTimeout)
+	defer cancel()
 	baseURL := h.getSumsubBaseURL(ctx)
 	levelName := h.getSumsubLevelName(ctx)
 
 	url := fmt.Sprintf("%s/resources/accessTokens?userId=%s&levelName=%s", baseURL, externalUserID, levelName)
 
-	req, err := http.NewRequest("POST", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "POST", url, nil)
 	if err != nil {
 		return nil, err
 	}
 
 	h.signRequest(req, nil)
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := h.httpClient.Do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -528,7 +638,7 @@ func (h *SumsubHandler) getSumsubAccessToken(externalUserID string) (*SumsubAcce
 
 	if resp.StatusCode != http.StatusOK {
 		respBody, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("Sumsub API error: %s", string(respBody))
+		return nil, &sumsubAPIError{StatusCode: resp.StatusCode, Body: string(respBody)}
 	}
 
 	var token SumsubAccessToken
@@ -539,6 +649,29 @@ func (h *SumsubHandler) getSumsubAccessToken(externalUserID string) (*SumsubAcce
 	return &token, nil
 }
 
+// sumsubAPIError is a non-success response from the Sumsub API
+type sumsubAPIError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *sumsubAPIError) Error() string {
+	return fmt.Sprintf("Sumsub API error: %s", e.Body)
+}
+
+// isSumsubFailure reports whether a Sumsub error means Sumsub is unhealthy
+// (5xx, rate limiting, network errors) rather than rejecting the request
+func isSumsubFailure(err error) bool {
+	if errors.Is(err, outbound.ErrRateLimited) {
+		return false
+	}
+	var apiErr *sumsubAPIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode >= 500 || apiErr.StatusCode == http.StatusTooManyRequests
+	}
+	return true
+}
+
 // signRequest signs a Sumsub API request
 func (h *SumsubHandler) signRequest(req *http.Request, body []byte) {
 	ts := fmt.Sprintf("%d", time.Now().Unix())
@@ -554,24 +687,11 @@ func (h *SumsubHandler) signRequest(req *http.Request, body []byte) {
 		data += string(body)
 	}
 
-	mac := hmac.New(sha256.New, []byte(h.secretKey))
+	mac := hmac.New(sha256.New, []byte(h.secrets.Get("SUMSUB_SECRET_KEY")))
 	mac.Write([]byte(data))
 	signature := hex.EncodeToString(mac.Sum(nil))
 
-	req.Header.Set("X-App-Token", h.appToken)
+	req.Header.Set("X-App-Token", h.secrets.Get("SUMSUB_APP_TOKEN"))
 	req.Header.Set("X-App-Access-Ts", ts)
 	req.Header.Set("X-App-Access-Sig", signature)
 }
-
-// verifyWebhookSignature verifies the Sumsub webhook signature
-func (h *SumsubHandler) verifyWebhookSignature(body []byte, signature string) bool {
-	if h.webhookSecret == "" || signature == "" {
-		return false
-	}
-
-	mac := hmac.New(sha256.New, []byte(h.webhookSecret))
-	mac.Write(body)
-	expected := hex.EncodeToString(mac.Sum(nil))
-
-	return hmac.Equal([]byte(expected), []byte(signature))
-}
@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"slices"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// SumsubDigestAlgorithm is how Sumsub signs a webhook, as sent in its
+// X-Payload-Digest-Alg header
+type SumsubDigestAlgorithm string
+
+const (
+	SumsubDigestSHA1   SumsubDigestAlgorithm = "HMAC_SHA1_HEX"
+	SumsubDigestSHA256 SumsubDigestAlgorithm = "HMAC_SHA256_HEX"
+	SumsubDigestSHA512 SumsubDigestAlgorithm = "HMAC_SHA512_HEX"
+)
+
+// DefaultSumsubDigestAlgorithms are the algorithms accepted unless
+// configured otherwise. SHA-1 has to be enabled explicitly.
+var DefaultSumsubDigestAlgorithms = []SumsubDigestAlgorithm{SumsubDigestSHA256, SumsubDigestSHA512}
+
+// sumsubDigests maps each algorithm to its hash
+var sumsubDigests = map[SumsubDigestAlgorithm]func() hash.Hash{
+	SumsubDigestSHA1:   sha1.New,
+	SumsubDigestSHA256: sha256.New,
+	SumsubDigestSHA512: sha512.New,
+}
+
+// ParseSumsubDigestAlgorithms parses a comma-separated list of algorithms,
+// by header value (HMAC_SHA512_HEX) or hash name (sha512) in any case. An
+// empty spec gives DefaultSumsubDigestAlgorithms.
+func ParseSumsubDigestAlgorithms(spec string) ([]SumsubDigestAlgorithm, error) {
+	var algs []SumsubDigestAlgorithm
+	for _, name := range strings.Split(spec, ",") {
+		name = strings.ToUpper(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		if !strings.HasPrefix(name, "HMAC_") {
+			name = "HMAC_" + name + "_HEX"
+		}
+		alg := SumsubDigestAlgorithm(name)
+		if _, ok := sumsubDigests[alg]; !ok {
+			return nil, fmt.Errorf("unknown Sumsub digest algorithm %q: expected sha1, sha256 or sha512", name)
+		}
+		if !slices.Contains(algs, alg) {
+			algs = append(algs, alg)
+		}
+	}
+	if len(algs) == 0 {
+		return slices.Clone(DefaultSumsubDigestAlgorithms), nil
+	}
+	return algs, nil
+}
+
+// SetWebhookDigestAlgorithms limits the algorithms webhooks may be signed
+// with (DefaultSumsubDigestAlgorithms by default). A webhook with no
+// X-Payload-Digest-Alg header is taken to be HMAC_SHA256_HEX.
+func (h *SumsubHandler) SetWebhookDigestAlgorithms(algs []SumsubDigestAlgorithm) {
+	h.digestAlgorithms = slices.Clone(algs)
+}
+
+// verifyWebhookSignature verifies the Sumsub webhook signature against
+// SUMSUB_WEBHOOK_SECRET and, while a rotation is under way,
+// SUMSUB_WEBHOOK_SECRET_PREVIOUS
+func (h *SumsubHandler) verifyWebhookSignature(body []byte, signature, algorithm string) bool {
+	alg := SumsubDigestSHA256
+	if algorithm != "" {
+		alg = SumsubDigestAlgorithm(strings.ToUpper(algorithm))
+	}
+	if !slices.Contains(h.digestAlgorithms, alg) {
+		h.logger.Warn("webhook signed with a digest algorithm that is not accepted", zap.String("algorithm", algorithm))
+		return false
+	}
+	got, err := hex.DecodeString(signature)
+	if err != nil || len(got) == 0 {
+		return false
+	}
+
+	for i, name := range []string{"SUMSUB_WEBHOOK_SECRET", "SUMSUB_WEBHOOK_SECRET_PREVIOUS"} {
+		secret := h.secrets.Get(name)
+		if secret == "" {
+			continue
+		}
+		mac := hmac.New(sumsubDigests[alg], []byte(secret))
+		mac.Write(body)
+		if hmac.Equal(mac.Sum(nil), got) {
+			if i > 0 {
+				// Once Sumsub signs with the new secret only, the previous one can go
+				h.logger.Info("webhook signed with the previous Sumsub webhook secret")
+			}
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,103 @@
+package handlers_test
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"hash"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/handlers"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/storage/memory"
+)
+
+// secretMap serves secrets from a map
+type secretMap map[string]string
+
+func (m secretMap) Get(name string) string { return m[name] }
+
+func TestParseSumsubDigestAlgorithms(t *testing.T) {
+	algs, err := handlers.ParseSumsubDigestAlgorithms("")
+	require.NoError(t, err)
+	assert.Equal(t, handlers.DefaultSumsubDigestAlgorithms, algs)
+
+	algs, err = handlers.ParseSumsubDigestAlgorithms(" sha1, HMAC_SHA512_HEX,SHA1 ")
+	require.NoError(t, err)
+	assert.Equal(t, []handlers.SumsubDigestAlgorithm{handlers.SumsubDigestSHA1, handlers.SumsubDigestSHA512}, algs)
+
+	_, err = handlers.ParseSumsubDigestAlgorithms("sha256,md5")
+	assert.Error(t, err)
+}
+
+func TestSumsubHandler_HandleWebhook_DigestAlgorithms(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	const (
+		current  = "whsec_current"
+		previous = "whsec_previous"
+	)
+	store := memory.NewStore()
+	handler := handlers.NewSumsubHandler(store.Payments, store.Pricing, store.AppConfig, zap.NewNop(), 1)
+	secrets := secretMap{"SUMSUB_WEBHOOK_SECRET": current, "SUMSUB_WEBHOOK_SECRET_PREVIOUS": previous}
+	handler.SetSecrets(secrets)
+	router := gin.New()
+	router.POST("/api/v1/kyc/sumsub/webhook", handler.HandleWebhook)
+
+	body := []byte(`{"applicantId":"applicant-1","type":"applicantPending","reviewStatus":"pending"}`)
+	sign := func(digest func() hash.Hash, secret string) string {
+		mac := hmac.New(digest, []byte(secret))
+		mac.Write(body)
+		return hex.EncodeToString(mac.Sum(nil))
+	}
+	send := func(signature, algorithm string) int {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/kyc/sumsub/webhook", bytes.NewReader(body))
+		req.Header.Set("X-Payload-Digest", signature)
+		if algorithm != "" {
+			req.Header.Set("X-Payload-Digest-Alg", algorithm)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	tests := []struct {
+		name      string
+		signature string
+		algorithm string
+		want      int
+	}{
+		{"sha256 without header", sign(sha256.New, current), "", http.StatusOK},
+		{"sha256", sign(sha256.New, current), "HMAC_SHA256_HEX", http.StatusOK},
+		{"sha512", sign(sha512.New, current), "HMAC_SHA512_HEX", http.StatusOK},
+		{"previous secret", sign(sha512.New, previous), "HMAC_SHA512_HEX", http.StatusOK},
+		{"sha1 not enabled", sign(sha1.New, current), "HMAC_SHA1_HEX", http.StatusUnauthorized},
+		{"algorithm mismatch", sign(sha512.New, current), "HMAC_SHA256_HEX", http.StatusUnauthorized},
+		{"unknown algorithm", sign(sha256.New, current), "HMAC_MD5_HEX", http.StatusUnauthorized},
+		{"wrong secret", sign(sha256.New, "whsec_other"), "", http.StatusUnauthorized},
+		{"not hex", "not-a-digest", "", http.StatusUnauthorized},
+		{"missing", "", "", http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, send(tt.signature, tt.algorithm))
+		})
+	}
+
+	// Only the configured algorithms are accepted
+	handler.SetWebhookDigestAlgorithms([]handlers.SumsubDigestAlgorithm{handlers.SumsubDigestSHA1})
+	assert.Equal(t, http.StatusOK, send(sign(sha1.New, current), "HMAC_SHA1_HEX"))
+	assert.Equal(t, http.StatusUnauthorized, send(sign(sha256.New, current), ""))
+
+	// Once the rotation is over the previous secret stops working
+	delete(secrets, "SUMSUB_WEBHOOK_SECRET_PREVIOUS")
+	assert.Equal(t, http.StatusUnauthorized, send(sign(sha1.New, previous), "HMAC_SHA1_HEX"))
+}
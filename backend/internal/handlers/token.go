@@ -65,7 +65,6 @@ type TokenInfoResponse struct {
 
 // NewTokenHandler creates a new token handler
 func NewTokenHandler(logger *zap.Logger) *TokenHandler {
-	// Initialize with demo data
 	totalSupply, _ := new(big.Int).SetString("100000000000000000000000000", 10) // 100M tokens with 18 decimals
 
 	h := &TokenHandler{
@@ -77,27 +76,9 @@ func NewTokenHandler(logger *zap.Logger) *TokenHandler {
 		totalSupply: totalSupply,
 	}
 
-	// Seed some demo balances
-	h.seedDemoBalances()
-
 	return h
 }
 
-// seedDemoBalances initializes demo balances for testing
-func (h *TokenHandler) seedDemoBalances() {
-	// Demo treasury address with most of supply
-	treasuryBalance, _ := new(big.Int).SetString("80000000000000000000000000", 10) // 80M
-	h.balances["0x0000000000000000000000000000000000000001"] = treasuryBalance
-
-	// Demo staking pool
-	stakingBalance, _ := new(big.Int).SetString("15000000000000000000000000", 10) // 15M
-	h.balances["0x0000000000000000000000000000000000000002"] = stakingBalance
-
-	// Demo user balance
-	userBalance, _ := new(big.Int).SetString("5000000000000000000000000", 10) // 5M
-	h.balances["0x0000000000000000000000000000000000000003"] = userBalance
-}
-
 // GetBalance handles GET /api/v1/token/balance/:address
 // @Summary Get token balance
 // @Description Returns the NXS token balance for a given address
@@ -0,0 +1,253 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/billing"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/breaker"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/userop"
+)
+
+// UserOpHandler accepts ERC-4337 UserOperations, checks them against the
+// paymaster policy and forwards them to the configured bundler
+type UserOpHandler struct {
+	bundler    UserOpBundler
+	configRepo repository.AppConfigRepository
+	logger     *zap.Logger
+	chainID    int64
+	breaker    *breaker.Breaker
+	gasDebt    GasDebtChecker
+}
+
+// UserOpBundler submits UserOperations (implemented by userop.Bundler)
+type UserOpBundler interface {
+	SendUserOperation(ctx context.Context, op *userop.UserOperation, entryPoint common.Address) (common.Hash, error)
+	GetUserOperationReceipt(ctx context.Context, hash common.Hash) (json.RawMessage, error)
+}
+
+// NewUserOpHandler creates a new UserOperation handler with injected dependencies
+func NewUserOpHandler(bundler UserOpBundler, configRepo repository.AppConfigRepository, logger *zap.Logger, chainID int64) *UserOpHandler {
+	return &UserOpHandler{
+		bundler:    bundler,
+		configRepo: configRepo,
+		logger:     logger,
+		chainID:    chainID,
+	}
+}
+
+// SetBreaker sheds bundler calls with 503 while the bundler is failing
+func (h *UserOpHandler) SetBreaker(b *breaker.Breaker) {
+	h.breaker = b
+}
+
+// SetGasDebtChecker applies the relayer's gas debt limit to senders of
+// sponsored operations
+func (h *UserOpHandler) SetGasDebtChecker(checker GasDebtChecker) {
+	h.gasDebt = checker
+}
+
+// UserOpResponse wraps UserOperation API responses
+type UserOpResponse struct {
+	Success bool        `json:"success"`
+	Data    interface{} `json:"data,omitempty"`
+	Error   string      `json:"error,omitempty"`
+}
+
+// SendUserOp handles POST /api/v1/userops
+// @Summary Forward a UserOperation
+// @Description Checks an ERC-4337 (EntryPoint v0.6) UserOperation against the paymaster policy (sponsoring paymaster, gas caps, relayer fee cap, gas debt limit) and forwards it to the bundler
+// @Tags userops
+// @Accept json
+// @Produce json
+// @Param request body userop.UserOperation true "User operation"
+// @Success 200 {object} UserOpResponse
+// @Failure 400 {object} UserOpResponse
+// @Failure 402 {object} UserOpResponse
+// @Failure 422 {object} UserOpResponse
+// @Failure 502 {object} UserOpResponse
+// @Failure 503 {object} UserOpResponse
+// @Router /api/v1/userops [post]
+func (h *UserOpHandler) SendUserOp(c *gin.Context) {
+	var op userop.UserOperation
+	if err := c.ShouldBindJSON(&op); err != nil {
+		c.JSON(http.StatusBadRequest, UserOpResponse{
+			Success: false,
+			Error:   "Invalid user operation: " + err.Error(),
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	policy, ok := h.policy(c)
+	if !ok {
+		return
+	}
+
+	var rejected *userop.PolicyError
+	if err := policy.Check(&op); errors.Is(err, userop.ErrDisabled) {
+		c.JSON(http.StatusServiceUnavailable, UserOpResponse{
+			Success: false,
+			Error:   "User operations are not enabled",
+		})
+		return
+	} else if errors.As(err, &rejected) {
+		c.JSON(http.StatusBadRequest, UserOpResponse{
+			Success: false,
+			Error:   "Rejected by paymaster policy: " + rejected.Reason,
+		})
+		return
+	}
+
+	sender := strings.ToLower(op.Sender.Hex())
+
+	// A failed debt lookup does not block forwarding
+	if h.gasDebt != nil {
+		if err := h.gasDebt.CheckRelay(ctx, sender); errors.Is(err, billing.ErrDebtLimit) {
+			c.JSON(http.StatusPaymentRequired, UserOpResponse{
+				Success: false,
+				Error:   "Outstanding gas debt exceeds the limit; settle it to continue relaying",
+			})
+			return
+		} else if err != nil {
+			h.logger.Warn("failed to check gas debt", zap.String("sender", sender), zap.Error(err))
+		}
+	}
+
+	var hash common.Hash
+	err := callProvider(h.breaker, func() (err error) {
+		callCtx, cancel := context.WithTimeout(ctx, providerTimeout)
+		defer cancel()
+		hash, err = h.bundler.SendUserOperation(callCtx, &op, policy.EntryPoint)
+		return err
+	}, isBundlerFailure)
+	if err != nil {
+		if respondUnavailable(c, err) {
+			return
+		}
+		if userop.IsRejected(err) {
+			h.logger.Info("bundler rejected user operation", zap.String("sender", sender), zap.Error(err))
+			c.JSON(http.StatusUnprocessableEntity, UserOpResponse{
+				Success: false,
+				Error:   err.Error(),
+			})
+			return
+		}
+		h.logger.Error("failed to forward user operation", zap.String("sender", sender), zap.Error(err))
+		c.JSON(http.StatusBadGateway, UserOpResponse{
+			Success: false,
+			Error:   "Failed to reach bundler",
+		})
+		return
+	}
+
+	h.logger.Info("user operation forwarded",
+		zap.String("user_op_hash", hash.Hex()),
+		zap.String("sender", sender),
+		zap.String("entry_point", policy.EntryPoint.Hex()),
+	)
+
+	c.JSON(http.StatusOK, UserOpResponse{
+		Success: true,
+		Data: gin.H{
+			"user_op_hash": hash.Hex(),
+			"entry_point":  policy.EntryPoint.Hex(),
+		},
+	})
+}
+
+// GetUserOpReceipt handles GET /api/v1/userops/:hash
+// @Summary Get a UserOperation receipt
+// @Description Returns the bundler's receipt (eth_getUserOperationReceipt) for a userOpHash; included is false until the operation is mined
+// @Tags userops
+// @Produce json
+// @Param hash path string true "userOpHash"
+// @Success 200 {object} UserOpResponse
+// @Failure 400 {object} UserOpResponse
+// @Failure 502 {object} UserOpResponse
+// @Router /api/v1/userops/{hash} [get]
+func (h *UserOpHandler) GetUserOpReceipt(c *gin.Context) {
+	hash := c.Param("hash")
+	if !isValidTxHash(hash) {
+		c.JSON(http.StatusBadRequest, UserOpResponse{
+			Success: false,
+			Error:   "Invalid user operation hash format",
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	var receipt json.RawMessage
+	err := callProvider(h.breaker, func() (err error) {
+		callCtx, cancel := context.WithTimeout(ctx, providerTimeout)
+		defer cancel()
+		receipt, err = h.bundler.GetUserOperationReceipt(callCtx, common.HexToHash(hash))
+		return err
+	}, isBundlerFailure)
+	if err != nil {
+		if respondUnavailable(c, err) {
+			return
+		}
+		h.logger.Error("failed to get user operation receipt", zap.String("user_op_hash", hash), zap.Error(err))
+		c.JSON(http.StatusBadGateway, UserOpResponse{
+			Success: false,
+			Error:   "Failed to reach bundler",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, UserOpResponse{
+		Success: true,
+		Data: gin.H{
+			"user_op_hash": strings.ToLower(hash),
+			"included":     receipt != nil,
+			"receipt":      receipt,
+		},
+	})
+}
+
+// GetUserOpPolicy handles GET /api/v1/userops/policy
+// @Summary Get the paymaster policy
+// @Description Returns the entry point, sponsoring paymaster and gas caps UserOperations must satisfy
+// @Tags userops
+// @Produce json
+// @Success 200 {object} UserOpResponse{data=userop.Policy}
+// @Router /api/v1/userops/policy [get]
+func (h *UserOpHandler) GetUserOpPolicy(c *gin.Context) {
+	policy, ok := h.policy(c)
+	if !ok {
+		return
+	}
+	c.JSON(http.StatusOK, UserOpResponse{
+		Success: true,
+		Data:    policy,
+	})
+}
+
+// policy loads the paymaster policy, writing 500 if it cannot
+func (h *UserOpHandler) policy(c *gin.Context) (*userop.Policy, bool) {
+	policy, err := userop.LoadPolicy(c.Request.Context(), h.configRepo, h.chainID)
+	if err != nil {
+		h.logger.Error("failed to load userop policy", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, UserOpResponse{
+			Success: false,
+			Error:   "Internal server error",
+		})
+		return nil, false
+	}
+	return policy, true
+}
+
+// isBundlerFailure counts transport errors against the bundler; rejected
+// operations and cancelled requests do not
+func isBundlerFailure(err error) bool {
+	return !userop.IsRejected(err) && !errors.Is(err, context.Canceled)
+}
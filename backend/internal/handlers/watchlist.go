@@ -0,0 +1,385 @@
+package handlers
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/callbacks"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// maxWatchlistAddresses bounds the addresses of one watchlist
+const maxWatchlistAddresses = 500
+
+// WatchlistHandler handles address watchlists: webhooks alerted when the
+// listed addresses pay, transfer NFTs or have meta-transactions relayed
+type WatchlistHandler struct {
+	repo              repository.WatchlistRepository
+	logger            *zap.Logger
+	insecureCallbacks bool
+}
+
+// NewWatchlistHandler creates a new watchlist handler with injected dependencies
+func NewWatchlistHandler(repo repository.WatchlistRepository, logger *zap.Logger) *WatchlistHandler {
+	return &WatchlistHandler{repo: repo, logger: logger}
+}
+
+// SetInsecureCallbacks accepts http webhook URLs (for local development)
+func (h *WatchlistHandler) SetInsecureCallbacks(allow bool) {
+	h.insecureCallbacks = allow
+}
+
+// WatchlistResponse wraps watchlist API responses
+type WatchlistResponse struct {
+	Success bool        `json:"success"`
+	Data    interface{} `json:"data,omitempty"`
+	Error   string      `json:"error,omitempty"`
+}
+
+// WatchlistRequest is the body of POST /api/v1/watchlists and PUT
+// /api/v1/watchlists/:id
+type WatchlistRequest struct {
+	Name       string   `json:"name"`
+	WebhookURL string   `json:"webhook_url" binding:"required"`
+	Secret     string   `json:"secret" binding:"required"`
+	Addresses  []string `json:"addresses" binding:"required"`
+	Events     []string `json:"events" binding:"required"` // payment, nft_transfer, meta_tx
+}
+
+// CreateWatchlist handles POST /api/v1/watchlists
+// @Summary Create an address watchlist
+// @Description Watches addresses for payments, NFT transfers and relayed meta-transactions. Each matching activity is POSTed to webhook_url signed with secret like payment callbacks. Reading, changing or deleting the watchlist needs the secret in X-Nexus-Subscription-Secret.
+// @Tags watchlists
+// @Accept json
+// @Produce json
+// @Param request body WatchlistRequest true "Watchlist"
+// @Success 201 {object} WatchlistResponse{data=repository.Watchlist}
+// @Failure 400 {object} WatchlistResponse
+// @Router /api/v1/watchlists [post]
+func (h *WatchlistHandler) CreateWatchlist(c *gin.Context) {
+	w, ok := h.bind(c)
+	if !ok {
+		return
+	}
+
+	if err := h.repo.CreateWatchlist(c.Request.Context(), w); err != nil {
+		h.logger.Error("failed to create watchlist", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, WatchlistResponse{
+			Success: false,
+			Error:   "Failed to create watchlist",
+		})
+		return
+	}
+
+	h.logger.Info("watchlist created",
+		zap.String("id", w.ID),
+		zap.Int("addresses", len(w.Addresses)),
+		zap.Strings("events", w.Events),
+	)
+	c.JSON(http.StatusCreated, WatchlistResponse{
+		Success: true,
+		Data:    w,
+	})
+}
+
+// GetWatchlist handles GET /api/v1/watchlists/:id
+// @Summary Get an address watchlist
+// @Description Needs the watchlist's secret in X-Nexus-Subscription-Secret.
+// @Tags watchlists
+// @Produce json
+// @Param id path string true "Watchlist ID"
+// @Success 200 {object} WatchlistResponse{data=repository.Watchlist}
+// @Failure 400 {object} WatchlistResponse
+// @Failure 403 {object} WatchlistResponse
+// @Failure 404 {object} WatchlistResponse
+// @Router /api/v1/watchlists/{id} [get]
+func (h *WatchlistHandler) GetWatchlist(c *gin.Context) {
+	w, ok := h.authorize(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, WatchlistResponse{
+		Success: true,
+		Data:    w,
+	})
+}
+
+// UpdateWatchlist handles PUT /api/v1/watchlists/:id
+// @Summary Replace an address watchlist
+// @Description Replaces the watchlist's name, webhook, secret, addresses and events; alerts still queued for addresses or events no longer watched are dropped. Needs the current secret in X-Nexus-Subscription-Secret.
+// @Tags watchlists
+// @Accept json
+// @Produce json
+// @Param id path string true "Watchlist ID"
+// @Param request body WatchlistRequest true "Watchlist"
+// @Success 200 {object} WatchlistResponse{data=repository.Watchlist}
+// @Failure 400 {object} WatchlistResponse
+// @Failure 403 {object} WatchlistResponse
+// @Failure 404 {object} WatchlistResponse
+// @Router /api/v1/watchlists/{id} [put]
+func (h *WatchlistHandler) UpdateWatchlist(c *gin.Context) {
+	w, ok := h.bind(c)
+	if !ok {
+		return
+	}
+	existing, ok := h.authorize(c)
+	if !ok {
+		return
+	}
+
+	w.ID = existing.ID
+	if err := h.repo.UpdateWatchlist(c.Request.Context(), w); err != nil {
+		if errors.Is(err, repository.ErrWatchlistNotFound) {
+			h.notFound(c)
+			return
+		}
+		h.logger.Error("failed to update watchlist", zap.String("id", w.ID), zap.Error(err))
+		c.JSON(http.StatusInternalServerError, WatchlistResponse{
+			Success: false,
+			Error:   "Failed to update watchlist",
+		})
+		return
+	}
+
+	h.logger.Info("watchlist updated",
+		zap.String("id", w.ID),
+		zap.Int("addresses", len(w.Addresses)),
+		zap.Strings("events", w.Events),
+	)
+	c.JSON(http.StatusOK, WatchlistResponse{
+		Success: true,
+		Data:    w,
+	})
+}
+
+// DeleteWatchlist handles DELETE /api/v1/watchlists/:id
+// @Summary Delete an address watchlist
+// @Description Alerts still queued for it are dropped. Needs the watchlist's secret in X-Nexus-Subscription-Secret.
+// @Tags watchlists
+// @Produce json
+// @Param id path string true "Watchlist ID"
+// @Success 200 {object} WatchlistResponse
+// @Failure 400 {object} WatchlistResponse
+// @Failure 403 {object} WatchlistResponse
+// @Failure 404 {object} WatchlistResponse
+// @Router /api/v1/watchlists/{id} [delete]
+func (h *WatchlistHandler) DeleteWatchlist(c *gin.Context) {
+	w, ok := h.authorize(c)
+	if !ok {
+		return
+	}
+
+	if err := h.repo.DeleteWatchlist(c.Request.Context(), w.ID); err != nil {
+		if errors.Is(err, repository.ErrWatchlistNotFound) {
+			h.notFound(c)
+			return
+		}
+		h.logger.Error("failed to delete watchlist", zap.String("id", w.ID), zap.Error(err))
+		c.JSON(http.StatusInternalServerError, WatchlistResponse{
+			Success: false,
+			Error:   "Failed to delete watchlist",
+		})
+		return
+	}
+
+	h.logger.Info("watchlist deleted", zap.String("id", w.ID))
+	c.JSON(http.StatusOK, WatchlistResponse{
+		Success: true,
+		Data:    gin.H{"id": w.ID},
+	})
+}
+
+// ListWatchlists handles GET /api/v1/admin/watchlists
+// @Summary List address watchlists
+// @Description Returns every watchlist, newest first, for compliance review. Secrets are never returned.
+// @Tags admin
+// @Produce json
+// @Param address query string false "Filter by watched address"
+// @Param event query string false "Filter by watched event (payment, nft_transfer, meta_tx)"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(20)
+// @Success 200 {object} WatchlistResponse
+// @Failure 400 {object} WatchlistResponse
+// @Router /api/v1/admin/watchlists [get]
+func (h *WatchlistHandler) ListWatchlists(c *gin.Context) {
+	var filter repository.WatchlistFilter
+	if address := c.Query("address"); address != "" {
+		if !isValidAddress(address) {
+			c.JSON(http.StatusBadRequest, WatchlistResponse{
+				Success: false,
+				Error:   "Invalid address format",
+			})
+			return
+		}
+		filter.Address = strings.ToLower(address)
+	}
+	if event := c.Query("event"); event != "" {
+		filter.Event = repository.ActivityKind(strings.ToLower(event))
+		if !filter.Event.Watchable() {
+			c.JSON(http.StatusBadRequest, WatchlistResponse{
+				Success: false,
+				Error:   "event must be payment, nft_transfer or meta_tx",
+			})
+			return
+		}
+	}
+	page, pageSize, ok := queryPage(c, 20)
+	if !ok {
+		return
+	}
+
+	list, total, err := h.repo.ListWatchlists(c.Request.Context(), filter, repository.Pagination{
+		Page:     page,
+		PageSize: pageSize,
+	})
+	if err != nil {
+		h.logger.Error("failed to list watchlists", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, WatchlistResponse{
+			Success: false,
+			Error:   "Failed to list watchlists",
+		})
+		return
+	}
+	if list == nil {
+		list = []*repository.Watchlist{}
+	}
+
+	c.JSON(http.StatusOK, WatchlistResponse{
+		Success: true,
+		Data: gin.H{
+			"watchlists": list,
+			"total":      total,
+			"page":       page,
+			"page_size":  pageSize,
+		},
+	})
+}
+
+// bind validates the request body into a watchlist, having responded if it
+// is invalid
+func (h *WatchlistHandler) bind(c *gin.Context) (*repository.Watchlist, bool) {
+	var req WatchlistRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, WatchlistResponse{
+			Success: false,
+			Error:   "Invalid request: " + err.Error(),
+		})
+		return nil, false
+	}
+	w, err := h.watchlist(req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, WatchlistResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return nil, false
+	}
+	return w, true
+}
+
+// watchlist validates req, lowercasing and deduplicating its addresses and
+// events
+func (h *WatchlistHandler) watchlist(req WatchlistRequest) (*repository.Watchlist, error) {
+	if err := callbacks.ValidateURL(req.WebhookURL, h.insecureCallbacks); err != nil {
+		return nil, err
+	}
+	if err := callbacks.ValidateSecret(req.Secret); err != nil {
+		return nil, err
+	}
+	if len(req.Name) > 100 {
+		return nil, errors.New("name must be at most 100 characters")
+	}
+
+	var addresses []string
+	seenAddress := make(map[string]bool)
+	for _, a := range req.Addresses {
+		if !isValidAddress(a) {
+			return nil, fmt.Errorf("invalid address %q", a)
+		}
+		a = strings.ToLower(a)
+		if !seenAddress[a] {
+			seenAddress[a] = true
+			addresses = append(addresses, a)
+		}
+	}
+	if len(addresses) == 0 {
+		return nil, errors.New("at least one address is required")
+	}
+	if len(addresses) > maxWatchlistAddresses {
+		return nil, fmt.Errorf("at most %d addresses can be watched", maxWatchlistAddresses)
+	}
+
+	var events []string
+	seenEvent := make(map[repository.ActivityKind]bool)
+	for _, e := range req.Events {
+		k := repository.ActivityKind(strings.ToLower(e))
+		if !k.Watchable() {
+			return nil, errors.New("events must be payment, nft_transfer or meta_tx")
+		}
+		if !seenEvent[k] {
+			seenEvent[k] = true
+			events = append(events, string(k))
+		}
+	}
+	if len(events) == 0 {
+		return nil, errors.New("at least one event is required")
+	}
+
+	return &repository.Watchlist{
+		Name:       req.Name,
+		WebhookURL: req.WebhookURL,
+		Secret:     req.Secret,
+		Addresses:  addresses,
+		Events:     events,
+	}, nil
+}
+
+// authorize validates and loads the :id watchlist and checks the request carries its
+// secret, having responded if not
+func (h *WatchlistHandler) authorize(c *gin.Context) (*repository.Watchlist, bool) {
+	id := c.Param("id")
+	if !uuidPattern.MatchString(id) {
+		c.JSON(http.StatusBadRequest, WatchlistResponse{
+			Success: false,
+			Error:   "Invalid ID",
+		})
+		return nil, false
+	}
+	w, err := h.repo.GetWatchlist(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, repository.ErrWatchlistNotFound) {
+			h.notFound(c)
+			return nil, false
+		}
+		h.logger.Error("failed to get watchlist", zap.String("id", id), zap.Error(err))
+		c.JSON(http.StatusInternalServerError, WatchlistResponse{
+			Success: false,
+			Error:   "Failed to get watchlist",
+		})
+		return nil, false
+	}
+
+	provided := c.GetHeader(SubscriptionSecretHeader)
+	if subtle.ConstantTimeCompare([]byte(provided), []byte(w.Secret)) != 1 {
+		c.JSON(http.StatusForbidden, WatchlistResponse{
+			Success: false,
+			Error:   "Missing or invalid " + SubscriptionSecretHeader + " header",
+		})
+		return nil, false
+	}
+	return w, true
+}
+
+func (h *WatchlistHandler) notFound(c *gin.Context) {
+	c.JSON(http.StatusNotFound, WatchlistResponse{
+		Success: false,
+		Error:   "Watchlist not found",
+	})
+}
@@ -0,0 +1,80 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/handlers"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/storage/memory"
+)
+
+func TestWatchlistHandler_SecretGuardsChanges(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	store := memory.NewStore()
+	handler := handlers.NewWatchlistHandler(store.Watchlists, zap.NewNop())
+
+	router := gin.New()
+	router.POST("/api/v1/watchlists", handler.CreateWatchlist)
+	router.GET("/api/v1/watchlists/:id", handler.GetWatchlist)
+	router.PUT("/api/v1/watchlists/:id", handler.UpdateWatchlist)
+	router.DELETE("/api/v1/watchlists/:id", handler.DeleteWatchlist)
+	router.GET("/api/v1/admin/watchlists", handler.ListWatchlists)
+
+	do := func(method, path, secret, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if secret != "" {
+			req.Header.Set(handlers.SubscriptionSecretHeader, secret)
+		}
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	const watched = "0x00000000000000000000000000000000000000AA"
+	assert.Equal(t, http.StatusBadRequest, do(http.MethodPost, "/api/v1/watchlists", "", `{"webhook_url":"https://example.com","secret":"0123456789abcdef","addresses":["0xnope"],"events":["payment"]}`).Code)
+	assert.Equal(t, http.StatusBadRequest, do(http.MethodPost, "/api/v1/watchlists", "", `{"webhook_url":"https://example.com","secret":"0123456789abcdef","addresses":["`+watched+`"],"events":["vote"]}`).Code)
+	assert.Equal(t, http.StatusBadRequest, do(http.MethodPost, "/api/v1/watchlists", "", `{"webhook_url":"https://example.com","secret":"0123456789abcdef","addresses":[],"events":["payment"]}`).Code)
+
+	w := do(http.MethodPost, "/api/v1/watchlists", "", `{"name":"sanctions","webhook_url":"https://example.com","secret":"0123456789abcdef","addresses":["`+watched+`","`+strings.ToLower(watched)+`"],"events":["payment","META_TX"]}`)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	assert.NotContains(t, w.Body.String(), "0123456789abcdef")
+	var created struct {
+		Data struct {
+			ID        string   `json:"id"`
+			Addresses []string `json:"addresses"`
+			Events    []string `json:"events"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	assert.Equal(t, []string{strings.ToLower(watched)}, created.Data.Addresses)
+	assert.Equal(t, []string{"payment", "meta_tx"}, created.Data.Events)
+	path := "/api/v1/watchlists/" + created.Data.ID
+
+	// Reading, replacing or deleting needs the secret
+	newer := `{"webhook_url":"https://attacker.example","secret":"fedcba9876543210","addresses":["` + watched + `"],"events":["payment"]}`
+	assert.Equal(t, http.StatusForbidden, do(http.MethodPut, path, "", newer).Code)
+	assert.Equal(t, http.StatusForbidden, do(http.MethodGet, path, "wrong-secret-0000", "").Code)
+	assert.Equal(t, http.StatusForbidden, do(http.MethodDelete, path, "", "").Code)
+	assert.Equal(t, http.StatusBadRequest, do(http.MethodGet, "/api/v1/watchlists/not-an-id", "0123456789abcdef", "").Code)
+	assert.Equal(t, http.StatusOK, do(http.MethodGet, path, "0123456789abcdef", "").Code)
+
+	// Compliance can find the watchlists watching an address
+	w = do(http.MethodGet, "/api/v1/admin/watchlists?address="+watched+"&event=payment", "", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"total":1`)
+	assert.Equal(t, http.StatusBadRequest, do(http.MethodGet, "/api/v1/admin/watchlists?event=kyc", "", "").Code)
+
+	require.Equal(t, http.StatusOK, do(http.MethodPut, path, "0123456789abcdef", newer).Code)
+	assert.Equal(t, http.StatusForbidden, do(http.MethodDelete, path, "0123456789abcdef", "").Code)
+	assert.Equal(t, http.StatusOK, do(http.MethodDelete, path, "fedcba9876543210", "").Code)
+	assert.Equal(t, http.StatusNotFound, do(http.MethodGet, path, "fedcba9876543210", "").Code)
+}
@@ -0,0 +1,251 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/webhooks"
+)
+
+// WebhookEventHandler handles the admin endpoints for stored inbound webhook events
+type WebhookEventHandler struct {
+	repo        repository.WebhookEventRepository
+	reprocessor WebhookReprocessor
+	logger      *zap.Logger
+}
+
+// WebhookReprocessor retries a failed or dead-lettered event immediately
+// (implemented by webhooks.Processor)
+type WebhookReprocessor interface {
+	Reprocess(ctx context.Context, id string) (*repository.WebhookEvent, error)
+}
+
+// SetReprocessor enables POST /api/v1/admin/webhooks/events/:id/reprocess
+func (h *WebhookEventHandler) SetReprocessor(r WebhookReprocessor) {
+	h.reprocessor = r
+}
+
+// NewWebhookEventHandler creates a new webhook event handler with injected dependencies
+func NewWebhookEventHandler(repo repository.WebhookEventRepository, logger *zap.Logger) *WebhookEventHandler {
+	return &WebhookEventHandler{
+		repo:   repo,
+		logger: logger,
+	}
+}
+
+// WebhookEventResponse wraps webhook event API responses
+type WebhookEventResponse struct {
+	Success bool        `json:"success"`
+	Data    interface{} `json:"data,omitempty"`
+	Error   string      `json:"error,omitempty"`
+}
+
+// WebhookEventListResponse wraps a page of stored webhook events
+type WebhookEventListResponse struct {
+	Success  bool                       `json:"success"`
+	Events   []*repository.WebhookEvent `json:"events"`
+	Total    int64                      `json:"total"`
+	Page     int                        `json:"page"`
+	PageSize int                        `json:"page_size"`
+}
+
+// ListWebhookEvents handles GET /api/v1/admin/webhooks/events
+// @Summary List stored webhook events
+// @Description Returns inbound webhook events with their processing status, newest first. Filter by status=failed to find events to replay.
+// @Tags admin
+// @Produce json
+// @Param provider query string false "Provider (stripe, sumsub)"
+// @Param event_type query string false "Provider event type (e.g. checkout.session.completed)"
+// @Param status query string false "received, processing, processed, failed or dead_letter"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(50)
+// @Param fields query string false "Comma-separated record fields to return (id is always included)"
+// @Success 200 {object} WebhookEventListResponse
+// @Failure 400 {object} WebhookEventResponse
+// @Failure 401 {object} WebhookEventResponse
+// @Router /api/v1/admin/webhooks/events [get]
+func (h *WebhookEventHandler) ListWebhookEvents(c *gin.Context) {
+	filter := repository.WebhookEventFilter{
+		Provider:  c.Query("provider"),
+		EventType: c.Query("event_type"),
+		Status:    repository.WebhookEventStatus(c.Query("status")),
+	}
+
+	switch filter.Status {
+	case "", repository.WebhookEventReceived, repository.WebhookEventProcessing,
+		repository.WebhookEventProcessed, repository.WebhookEventFailed, repository.WebhookEventDeadLetter:
+	default:
+		c.JSON(http.StatusBadRequest, WebhookEventResponse{
+			Success: false,
+			Error:   "Invalid status",
+		})
+		return
+	}
+
+	h.listWebhookEvents(c, filter)
+}
+
+// ListDeadLetters handles GET /api/v1/admin/webhooks/dead-letters
+// @Summary List dead-lettered webhook events
+// @Description Returns webhook events that exhausted their background retries, newest first, with the last processing error
+// @Tags admin
+// @Produce json
+// @Param provider query string false "Provider (sumsub)"
+// @Param event_type query string false "Provider event type (e.g. applicantReviewed)"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(50)
+// @Param fields query string false "Comma-separated record fields to return (id is always included)"
+// @Success 200 {object} WebhookEventListResponse
+// @Failure 401 {object} WebhookEventResponse
+// @Router /api/v1/admin/webhooks/dead-letters [get]
+func (h *WebhookEventHandler) ListDeadLetters(c *gin.Context) {
+	h.listWebhookEvents(c, repository.WebhookEventFilter{
+		Provider:  c.Query("provider"),
+		EventType: c.Query("event_type"),
+		Status:    repository.WebhookEventDeadLetter,
+	})
+}
+
+// listWebhookEvents writes one page of events matching filter
+func (h *WebhookEventHandler) listWebhookEvents(c *gin.Context, filter repository.WebhookEventFilter) {
+	page, pageSize, ok := queryPage(c, 50)
+	if !ok {
+		return
+	}
+
+	events, total, err := h.repo.ListWebhookEvents(c.Request.Context(), filter, repository.Pagination{
+		Page:     page,
+		PageSize: pageSize,
+	})
+	if err != nil {
+		h.logger.Error("failed to list webhook events", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, WebhookEventResponse{
+			Success: false,
+			Error:   "Internal server error",
+		})
+		return
+	}
+
+	if events == nil {
+		events = []*repository.WebhookEvent{}
+	}
+
+	c.JSON(http.StatusOK, WebhookEventListResponse{
+		Success:  true,
+		Events:   events,
+		Total:    total,
+		Page:     page,
+		PageSize: pageSize,
+	})
+}
+
+// GetWebhookEvent handles GET /api/v1/admin/webhooks/events/:id
+// @Summary Get a stored webhook event
+// @Description Returns a single stored webhook event including its last processing error
+// @Tags admin
+// @Produce json
+// @Param id path string true "Webhook event ID"
+// @Success 200 {object} WebhookEventResponse
+// @Failure 400 {object} WebhookEventResponse
+// @Failure 404 {object} WebhookEventResponse
+// @Router /api/v1/admin/webhooks/events/{id} [get]
+func (h *WebhookEventHandler) GetWebhookEvent(c *gin.Context) {
+	id := c.Param("id")
+	if !uuidPattern.MatchString(id) {
+		c.JSON(http.StatusBadRequest, WebhookEventResponse{
+			Success: false,
+			Error:   "Invalid webhook event ID",
+		})
+		return
+	}
+
+	event, err := h.repo.GetWebhookEvent(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, repository.ErrWebhookEventNotFound) {
+			c.JSON(http.StatusNotFound, WebhookEventResponse{
+				Success: false,
+				Error:   "Webhook event not found",
+			})
+			return
+		}
+		h.logger.Error("failed to get webhook event", zap.String("id", id), zap.Error(err))
+		c.JSON(http.StatusInternalServerError, WebhookEventResponse{
+			Success: false,
+			Error:   "Internal server error",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, WebhookEventResponse{
+		Success: true,
+		Data:    event,
+	})
+}
+
+// ReprocessWebhookEvent handles POST /api/v1/admin/webhooks/events/:id/reprocess
+// @Summary Reprocess a failed or dead-lettered webhook event
+// @Description Processes a stored background webhook event (e.g. Sumsub) again immediately. The response carries the event after the attempt; a failed attempt is reported via its status and last_error. Stripe events are replayed via /admin/webhooks/stripe/events/{eventId}/replay.
+// @Tags admin
+// @Produce json
+// @Param id path string true "Webhook event ID"
+// @Success 200 {object} WebhookEventResponse
+// @Failure 400 {object} WebhookEventResponse
+// @Failure 404 {object} WebhookEventResponse
+// @Failure 409 {object} WebhookEventResponse
+// @Router /api/v1/admin/webhooks/events/{id}/reprocess [post]
+func (h *WebhookEventHandler) ReprocessWebhookEvent(c *gin.Context) {
+	if h.reprocessor == nil {
+		c.JSON(http.StatusNotFound, WebhookEventResponse{
+			Success: false,
+			Error:   "Webhook reprocessing not configured",
+		})
+		return
+	}
+
+	id := c.Param("id")
+	if !uuidPattern.MatchString(id) {
+		c.JSON(http.StatusBadRequest, WebhookEventResponse{
+			Success: false,
+			Error:   "Invalid webhook event ID",
+		})
+		return
+	}
+
+	event, err := h.reprocessor.Reprocess(c.Request.Context(), id)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrWebhookEventNotFound):
+			c.JSON(http.StatusNotFound, WebhookEventResponse{
+				Success: false,
+				Error:   "Webhook event not found",
+			})
+		case errors.Is(err, repository.ErrWebhookEventNotRetryable):
+			c.JSON(http.StatusConflict, WebhookEventResponse{
+				Success: false,
+				Error:   "Only failed or dead-lettered events can be reprocessed",
+			})
+		case errors.Is(err, webhooks.ErrNoHandler):
+			c.JSON(http.StatusBadRequest, WebhookEventResponse{
+				Success: false,
+				Error:   "Events of this provider are not processed in the background",
+			})
+		default:
+			h.logger.Error("failed to reprocess webhook event", zap.String("id", id), zap.Error(err))
+			c.JSON(http.StatusInternalServerError, WebhookEventResponse{
+				Success: false,
+				Error:   "Internal server error",
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, WebhookEventResponse{
+		Success: event.Status == repository.WebhookEventProcessed,
+		Data:    event,
+	})
+}
@@ -0,0 +1,360 @@
+package handlers_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stripe/stripe-go/v76"
+	"github.com/stripe/stripe-go/v76/webhook"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/disputes"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/handlers"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/storage/memory"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/webhooks"
+)
+
+const testWebhookSecret = "whsec_test_secret"
+
+// flakyPaymentRepo fails the next N payment status and KYC updates
+type flakyPaymentRepo struct {
+	*memory.MemoryPaymentRepo
+	failures    int
+	kycFailures int
+}
+
+func (r *flakyPaymentRepo) UpdatePaymentStatus(ctx context.Context, id string, status repository.PaymentStatus, details *repository.PaymentStatusUpdate) error {
+	if r.failures > 0 {
+		r.failures--
+		return errors.New("database unavailable")
+	}
+	return r.MemoryPaymentRepo.UpdatePaymentStatus(ctx, id, status, details)
+}
+
+func (r *flakyPaymentRepo) UpdateKYCVerification(ctx context.Context, id string, update *repository.KYCVerificationUpdate) error {
+	if r.kycFailures > 0 {
+		r.kycFailures--
+		return errors.New("database unavailable")
+	}
+	return r.MemoryPaymentRepo.UpdateKYCVerification(ctx, id, update)
+}
+
+func setupWebhookEventTestRouter(t *testing.T) (*gin.Engine, *memory.Store, *flakyPaymentRepo) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	t.Setenv("STRIPE_WEBHOOK_SECRET", testWebhookSecret)
+
+	store := memory.NewStore()
+	payments := &flakyPaymentRepo{MemoryPaymentRepo: store.Payments}
+	paymentHandler := handlers.NewPaymentHandler(payments, store.Pricing, zap.NewNop())
+	paymentHandler.SetWebhookEvents(store.WebhookEvents)
+	eventHandler := handlers.NewWebhookEventHandler(store.WebhookEvents, zap.NewNop())
+
+	router := gin.New()
+	router.POST("/api/v1/payments/stripe/webhook", paymentHandler.HandleStripeWebhook)
+	router.GET("/api/v1/admin/webhooks/events", eventHandler.ListWebhookEvents)
+	router.GET("/api/v1/admin/webhooks/events/:id", eventHandler.GetWebhookEvent)
+	router.POST("/api/v1/admin/webhooks/stripe/events/:eventId/replay", paymentHandler.ReplayStripeEvent)
+	return router, store, payments
+}
+
+// sendStripeEvent posts a signed checkout.session.completed event
+func sendStripeEvent(router *gin.Engine, eventID, sessionID string) *httptest.ResponseRecorder {
+	payload := fmt.Sprintf(`{
+		"id": %q,
+		"object": "event",
+		"api_version": %q,
+		"type": "checkout.session.completed",
+		"data": {"object": {"id": %q, "object": "checkout.session", "payment_intent": "pi_test_1"}}
+	}`, eventID, stripe.APIVersion, sessionID)
+
+	signed := webhook.GenerateTestSignedPayload(&webhook.UnsignedPayload{
+		Payload: []byte(payload),
+		Secret:  testWebhookSecret,
+	})
+
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/payments/stripe/webhook", bytes.NewReader(signed.Payload))
+	req.Header.Set("Stripe-Signature", signed.Header)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func createStripePayment(t *testing.T, store *memory.Store, sessionID string) *repository.Payment {
+	t.Helper()
+	payment := &repository.Payment{
+		ServiceCode:     "kyc_verification",
+		PayerAddress:    "0x1111111111111111111111111111111111111111",
+		PaymentMethod:   "stripe",
+		Status:          repository.PaymentStatusPending,
+		StripeSessionID: &sessionID,
+	}
+	require.NoError(t, store.Payments.CreatePayment(context.Background(), payment))
+	return payment
+}
+
+func TestPaymentHandler_HandleStripeWebhook_Dedup(t *testing.T) {
+	router, store, _ := setupWebhookEventTestRouter(t)
+	ctx := context.Background()
+	payment := createStripePayment(t, store, "cs_test_dedup")
+
+	w := sendStripeEvent(router, "evt_dedup", "cs_test_dedup")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	got, err := store.Payments.GetPayment(ctx, payment.ID)
+	require.NoError(t, err)
+	assert.Equal(t, repository.PaymentStatusCompleted, got.Status)
+
+	// Stripe redelivers the same event: acknowledged without reprocessing
+	w = sendStripeEvent(router, "evt_dedup", "cs_test_dedup")
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp handlers.PaymentResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "Event already processed", resp.Message)
+
+	stored, err := store.WebhookEvents.GetWebhookEventByEventID(ctx, repository.WebhookProviderStripe, "evt_dedup")
+	require.NoError(t, err)
+	assert.Equal(t, repository.WebhookEventProcessed, stored.Status)
+	assert.Equal(t, 1, stored.Attempts)
+	assert.NotNil(t, stored.ProcessedAt)
+}
+
+func TestPaymentHandler_ReplayStripeEvent(t *testing.T) {
+	router, store, payments := setupWebhookEventTestRouter(t)
+	ctx := context.Background()
+	payment := createStripePayment(t, store, "cs_test_replay")
+
+	// Processing fails: Stripe gets a 500 and the event is kept as failed
+	payments.failures = 1
+	w := sendStripeEvent(router, "evt_replay", "cs_test_replay")
+	require.Equal(t, http.StatusInternalServerError, w.Code)
+
+	stored, err := store.WebhookEvents.GetWebhookEventByEventID(ctx, repository.WebhookProviderStripe, "evt_replay")
+	require.NoError(t, err)
+	assert.Equal(t, repository.WebhookEventFailed, stored.Status)
+	require.NotNil(t, stored.LastError)
+	assert.Contains(t, *stored.LastError, "database unavailable")
+
+	// Failed events show up for operators
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/admin/webhooks/events?status=failed", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	var list handlers.WebhookEventListResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	require.Len(t, list.Events, 1)
+	assert.Equal(t, "evt_replay", list.Events[0].EventID)
+
+	// Replay applies the stored event
+	req, _ = http.NewRequest(http.MethodPost, "/api/v1/admin/webhooks/stripe/events/evt_replay/replay", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	got, err := store.Payments.GetPayment(ctx, payment.ID)
+	require.NoError(t, err)
+	assert.Equal(t, repository.PaymentStatusCompleted, got.Status)
+
+	stored, err = store.WebhookEvents.GetWebhookEvent(ctx, stored.ID)
+	require.NoError(t, err)
+	assert.Equal(t, repository.WebhookEventProcessed, stored.Status)
+	assert.Equal(t, 2, stored.Attempts)
+	assert.Nil(t, stored.LastError)
+
+	// Processed events cannot be replayed
+	req, _ = http.NewRequest(http.MethodPost, "/api/v1/admin/webhooks/stripe/events/evt_replay/replay", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	// Unknown events
+	req, _ = http.NewRequest(http.MethodPost, "/api/v1/admin/webhooks/stripe/events/evt_missing/replay", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestPaymentHandler_HandleStripeWebhook_Dispute(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Setenv("STRIPE_WEBHOOK_SECRET", testWebhookSecret)
+	ctx := context.Background()
+
+	store := memory.NewStore()
+	processor := webhooks.NewProcessor(store.WebhookEvents, nil, zap.NewNop())
+	paymentHandler := handlers.NewPaymentHandler(store.Payments, store.Pricing, zap.NewNop())
+	paymentHandler.SetWebhookEvents(store.WebhookEvents)
+	paymentHandler.SetDisputes(disputes.NewService(store.Payments, processor, zap.NewNop()))
+	router := gin.New()
+	router.POST("/api/v1/payments/stripe/webhook", paymentHandler.HandleStripeWebhook)
+
+	intentID := "pi_test_disputed"
+	payment := &repository.Payment{
+		ServiceCode:     "kyc_verification",
+		PayerAddress:    "0x1111111111111111111111111111111111111111",
+		PaymentMethod:   "stripe",
+		Status:          repository.PaymentStatusCompleted,
+		StripePaymentID: &intentID,
+	}
+	require.NoError(t, store.Payments.CreatePayment(ctx, payment))
+	kyc := &repository.KYCVerification{PaymentID: &payment.ID, UserAddress: payment.PayerAddress, Status: repository.KYCStatusInReview}
+	require.NoError(t, store.Payments.CreateKYCVerification(ctx, kyc))
+
+	send := func(eventID, eventType, status string) *httptest.ResponseRecorder {
+		payload := fmt.Sprintf(`{
+			"id": %q,
+			"object": "event",
+			"api_version": %q,
+			"type": %q,
+			"data": {"object": {"id": "dp_test_1", "object": "dispute", "status": %q, "reason": "fraudulent",
+				"amount": 1543, "currency": "usd", "payment_intent": %q}}
+		}`, eventID, stripe.APIVersion, eventType, status, intentID)
+		signed := webhook.GenerateTestSignedPayload(&webhook.UnsignedPayload{
+			Payload: []byte(payload),
+			Secret:  testWebhookSecret,
+		})
+		req, _ := http.NewRequest(http.MethodPost, "/api/v1/payments/stripe/webhook", bytes.NewReader(signed.Payload))
+		req.Header.Set("Stripe-Signature", signed.Header)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := send("evt_dispute_created", "charge.dispute.created", "needs_response")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	got, err := store.Payments.GetPayment(ctx, payment.ID)
+	require.NoError(t, err)
+	assert.Equal(t, repository.PaymentStatusDisputed, got.Status)
+	require.NotNil(t, got.DisputeID)
+	assert.Equal(t, "dp_test_1", *got.DisputeID)
+	assert.Equal(t, "fraudulent", *got.DisputeReason)
+	v, err := store.Payments.GetKYCVerification(ctx, kyc.ID)
+	require.NoError(t, err)
+	assert.Equal(t, repository.KYCStatusSuspended, v.Status)
+
+	alert, err := store.WebhookEvents.GetWebhookEventByEventID(ctx, repository.WebhookProviderComplianceAlert, "dispute:dp_test_1:needs_response")
+	require.NoError(t, err)
+	assert.Equal(t, disputes.AlertOpened, alert.EventType)
+
+	w = send("evt_dispute_closed", "charge.dispute.closed", "lost")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	got, err = store.Payments.GetPayment(ctx, payment.ID)
+	require.NoError(t, err)
+	assert.Equal(t, repository.PaymentStatusRefunded, got.Status)
+	assert.Equal(t, "lost", *got.DisputeStatus)
+	v, err = store.Payments.GetKYCVerification(ctx, kyc.ID)
+	require.NoError(t, err)
+	assert.Equal(t, repository.KYCStatusSuspended, v.Status)
+}
+
+func TestWebhookEventHandler_Validation(t *testing.T) {
+	router, _, _ := setupWebhookEventTestRouter(t)
+
+	tests := []struct {
+		name       string
+		path       string
+		wantStatus int
+	}{
+		{"invalid status filter", "/api/v1/admin/webhooks/events?status=bogus", http.StatusBadRequest},
+		{"invalid id", "/api/v1/admin/webhooks/events/not-a-uuid", http.StatusBadRequest},
+		{"unknown id", "/api/v1/admin/webhooks/events/00000000-0000-4000-8000-000000000000", http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodGet, tt.path, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestSumsubHandler_HandleWebhook_DeadLetterAndReprocess(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Setenv("SUMSUB_WEBHOOK_SECRET", testWebhookSecret)
+	ctx := context.Background()
+
+	store := memory.NewStore()
+	maxAttempts := int64(1)
+	require.NoError(t, store.AppConfig.Create(ctx, &repository.AppConfigCreate{
+		Namespace: "webhooks", ConfigKey: "max_attempts", ValueType: "number", ValueNumber: &maxAttempts,
+	}))
+	payments := &flakyPaymentRepo{MemoryPaymentRepo: store.Payments, kycFailures: 1}
+	sumsubHandler := handlers.NewSumsubHandler(payments, store.Pricing, store.AppConfig, zap.NewNop(), 1)
+	processor := webhooks.NewProcessor(store.WebhookEvents, store.AppConfig, zap.NewNop())
+	processor.RegisterHandler(repository.WebhookProviderSumsub, sumsubHandler)
+	sumsubHandler.SetWebhookQueue(processor)
+	eventHandler := handlers.NewWebhookEventHandler(store.WebhookEvents, zap.NewNop())
+	eventHandler.SetReprocessor(processor)
+
+	router := gin.New()
+	router.POST("/api/v1/kyc/sumsub/webhook", sumsubHandler.HandleWebhook)
+	router.GET("/api/v1/admin/webhooks/dead-letters", eventHandler.ListDeadLetters)
+	router.POST("/api/v1/admin/webhooks/events/:id/reprocess", eventHandler.ReprocessWebhookEvent)
+
+	applicantID := "applicant-1"
+	kyc := &repository.KYCVerification{UserAddress: "0x2222222222222222222222222222222222222222", SumsubApplicantID: &applicantID}
+	require.NoError(t, store.Payments.CreateKYCVerification(ctx, kyc))
+
+	body := []byte(`{"applicantId":"applicant-1","type":"applicantReviewed","reviewStatus":"completed","reviewResult":{"reviewAnswer":"GREEN"}}`)
+	mac := hmac.New(sha256.New, []byte(testWebhookSecret))
+	mac.Write(body)
+	send := func() *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(http.MethodPost, "/api/v1/kyc/sumsub/webhook", bytes.NewReader(body))
+		req.Header.Set("X-Payload-Digest", hex.EncodeToString(mac.Sum(nil)))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// Acknowledged on receipt; processing happens in the background
+	require.Equal(t, http.StatusOK, send().Code)
+	require.Equal(t, http.StatusOK, send().Code)
+	got, err := store.Payments.GetKYCVerification(ctx, kyc.ID)
+	require.NoError(t, err)
+	assert.NotEqual(t, repository.KYCStatusApproved, got.Status)
+
+	// The only allowed attempt fails, so the event is dead-lettered
+	assert.Equal(t, 1, processor.RunOnce(ctx))
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/admin/webhooks/dead-letters?provider=sumsub", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	var list handlers.WebhookEventListResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	require.Len(t, list.Events, 1)
+	dead := list.Events[0]
+	assert.Equal(t, "applicantReviewed", dead.EventType)
+	require.NotNil(t, dead.LastError)
+
+	req, _ = http.NewRequest(http.MethodPost, "/api/v1/admin/webhooks/events/"+dead.ID+"/reprocess", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	got, err = store.Payments.GetKYCVerification(ctx, kyc.ID)
+	require.NoError(t, err)
+	assert.Equal(t, repository.KYCStatusApproved, got.Status)
+
+	// Processed events cannot be reprocessed
+	req, _ = http.NewRequest(http.MethodPost, "/api/v1/admin/webhooks/events/"+dead.ID+"/reprocess", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
@@ -0,0 +1,15 @@
+// Package integration holds end-to-end tests that run the HTTP API against
+// real providers instead of stubs. The tests are behind the "integration"
+// build tag and skip themselves when their provider is not configured:
+//
+//	# stripe-mock (docker run -p 12111:12111 stripe/stripe-mock)
+//	STRIPE_API_BASE=http://localhost:12111 go test -tags integration ./internal/integration/...
+//
+//	# Stripe test account (test keys only)
+//	STRIPE_SECRET_KEY=sk_test_... go test -tags integration ./internal/integration/...
+//
+// Checkout sessions are created through Stripe; webhook deliveries are built
+// from the session objects Stripe returns and signed locally, since Stripe
+// cannot reach a test server and a session cannot be paid without its
+// hosted page.
+package integration
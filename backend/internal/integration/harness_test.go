@@ -0,0 +1,221 @@
+//go:build integration
+
+package integration_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"github.com/stripe/stripe-go/v76"
+	"github.com/stripe/stripe-go/v76/webhook"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/handlers"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/storage/memory"
+)
+
+const (
+	harnessWebhookSecret = "whsec_integration"
+
+	// stripeMockKey is accepted by stripe-mock, which ignores keys
+	stripeMockKey = "sk_test_123"
+
+	payerAddress = "0x1111111111111111111111111111111111111111"
+)
+
+// harness is a running API server wired to Stripe (or stripe-mock) with an
+// in-memory store
+type harness struct {
+	t      *testing.T
+	server *httptest.Server
+	store  *memory.Store
+
+	// mock is set against stripe-mock, which returns canned objects
+	mock bool
+}
+
+// newStripeHarness starts the payment API against STRIPE_API_BASE
+// (stripe-mock) or a Stripe test account, and skips the test when neither
+// is configured
+func newStripeHarness(t *testing.T) *harness {
+	t.Helper()
+
+	apiBase := os.Getenv("STRIPE_API_BASE")
+	key := os.Getenv("STRIPE_SECRET_KEY")
+	switch {
+	case apiBase != "":
+		if key == "" {
+			key = stripeMockKey
+		}
+		backend := stripe.GetBackendWithConfig(stripe.APIBackend, &stripe.BackendConfig{
+			URL: stripe.String(apiBase),
+		})
+		stripe.SetBackend(stripe.APIBackend, backend)
+		t.Cleanup(func() { stripe.SetBackend(stripe.APIBackend, nil) })
+	case key == "":
+		t.Skip("set STRIPE_API_BASE (stripe-mock) or STRIPE_SECRET_KEY (test mode) to run Stripe integration tests")
+	case !strings.HasPrefix(key, "sk_test_") && !strings.HasPrefix(key, "rk_test_"):
+		t.Fatal("refusing to run Stripe integration tests with a live key")
+	}
+
+	// NewPaymentHandler reads both from the environment
+	t.Setenv("STRIPE_SECRET_KEY", key)
+	t.Setenv("STRIPE_WEBHOOK_SECRET", harnessWebhookSecret)
+
+	gin.SetMode(gin.TestMode)
+	logger := zap.NewNop()
+	store := memory.NewSeededStore()
+
+	paymentHandler := handlers.NewPaymentHandler(store.Payments, store.Pricing, logger)
+	paymentHandler.SetWebhookEvents(store.WebhookEvents)
+
+	// Same routes as cmd/server
+	router := gin.New()
+	payments := router.Group("/api/v1/payments")
+	payments.POST("/stripe/checkout", paymentHandler.CreateStripeCheckout)
+	payments.POST("/stripe/webhook", paymentHandler.HandleStripeWebhook)
+	payments.GET("/:paymentId", paymentHandler.GetPayment)
+	payments.GET("/session/:sessionId", paymentHandler.GetPaymentBySession)
+
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+
+	return &harness{
+		t:      t,
+		server: server,
+		store:  store,
+		mock:   apiBase != "",
+	}
+}
+
+// do sends a request to the server and decodes the JSON response into out
+func (h *harness) do(req *http.Request, out interface{}) int {
+	h.t.Helper()
+
+	resp, err := h.server.Client().Do(req)
+	require.NoError(h.t, err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(h.t, err)
+	if out != nil {
+		require.NoError(h.t, json.Unmarshal(body, out), string(body))
+	}
+	return resp.StatusCode
+}
+
+// postJSON posts body as JSON to path
+func (h *harness) postJSON(path string, body interface{}, out interface{}) int {
+	h.t.Helper()
+
+	raw, err := json.Marshal(body)
+	require.NoError(h.t, err)
+	req, err := http.NewRequest(http.MethodPost, h.server.URL+path, bytes.NewReader(raw))
+	require.NoError(h.t, err)
+	req.Header.Set("Content-Type", "application/json")
+	return h.do(req, out)
+}
+
+// createCheckout creates a checkout session through the API and returns its ID
+func (h *harness) createCheckout(serviceCode string) string {
+	h.t.Helper()
+
+	var resp struct {
+		Success bool `json:"success"`
+		Data    struct {
+			SessionID   string `json:"session_id"`
+			CheckoutURL string `json:"checkout_url"`
+		} `json:"data"`
+		Error string `json:"error"`
+	}
+	status := h.postJSON("/api/v1/payments/stripe/checkout", handlers.CreateCheckoutRequest{
+		ServiceCode:  serviceCode,
+		PayerAddress: payerAddress,
+		SuccessURL:   "https://example.com/success",
+		CancelURL:    "https://example.com/cancel",
+	}, &resp)
+	require.Equal(h.t, http.StatusOK, status, resp.Error)
+	require.True(h.t, resp.Success)
+	require.NotEmpty(h.t, resp.Data.SessionID)
+	return resp.Data.SessionID
+}
+
+// paymentForSession returns the payment the API reports for a session
+func (h *harness) paymentForSession(sessionID string) *repository.Payment {
+	h.t.Helper()
+
+	req, err := http.NewRequest(http.MethodGet, h.server.URL+"/api/v1/payments/session/"+sessionID, nil)
+	require.NoError(h.t, err)
+
+	var resp struct {
+		Success bool                `json:"success"`
+		Data    *repository.Payment `json:"data"`
+		Error   string              `json:"error"`
+	}
+	require.Equal(h.t, http.StatusOK, h.do(req, &resp), resp.Error)
+	return resp.Data
+}
+
+// deliver signs an event carrying object and posts it to the webhook
+// endpoint the way Stripe does, returning the status and response. The same
+// eventID can be delivered again to simulate a redelivery.
+func (h *harness) deliver(eventID, eventType string, object interface{}) (int, handlers.PaymentResponse) {
+	h.t.Helper()
+
+	raw, err := json.Marshal(object)
+	require.NoError(h.t, err)
+	payload, err := json.Marshal(map[string]interface{}{
+		"id":          eventID,
+		"object":      "event",
+		"type":        eventType,
+		"api_version": stripe.APIVersion,
+		"created":     time.Now().Unix(),
+		"livemode":    false,
+		"data":        map[string]json.RawMessage{"object": raw},
+	})
+	require.NoError(h.t, err)
+
+	signed := webhook.GenerateTestSignedPayload(&webhook.UnsignedPayload{
+		Payload: payload,
+		Secret:  harnessWebhookSecret,
+	})
+	req, err := http.NewRequest(http.MethodPost, h.server.URL+"/api/v1/payments/stripe/webhook", bytes.NewReader(signed.Payload))
+	require.NoError(h.t, err)
+	req.Header.Set("Stripe-Signature", signed.Header)
+
+	var resp handlers.PaymentResponse
+	status := h.do(req, &resp)
+	return status, resp
+}
+
+// newEventID returns an event ID unique to this run
+func newEventID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		panic(fmt.Sprintf("generating event id: %v", err))
+	}
+	return "evt_it_" + hex.EncodeToString(b)
+}
+
+// storedEvent returns the stored webhook event for a Stripe event ID
+func (h *harness) storedEvent(eventID string) *repository.WebhookEvent {
+	h.t.Helper()
+
+	e, err := h.store.WebhookEvents.GetWebhookEventByEventID(context.Background(), repository.WebhookProviderStripe, eventID)
+	require.NoError(h.t, err)
+	return e
+}
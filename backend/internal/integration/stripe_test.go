@@ -0,0 +1,94 @@
+//go:build integration
+
+package integration_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stripe/stripe-go/v76"
+	"github.com/stripe/stripe-go/v76/checkout/session"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+func TestStripeCheckout_CompletedWebhookCompletesPayment(t *testing.T) {
+	h := newStripeHarness(t)
+
+	sessionID := h.createCheckout("kyc_verification")
+	payment := h.paymentForSession(sessionID)
+	assert.Equal(t, repository.PaymentStatusPending, payment.Status)
+	assert.Equal(t, "stripe", payment.PaymentMethod)
+	assert.InDelta(t, 15.435, payment.AmountCharged, 0.001) // $15 + 2.9% card fee
+
+	s, err := session.Get(sessionID, nil)
+	require.NoError(t, err)
+	if !h.mock {
+		assert.Equal(t, int64(1543), s.AmountTotal)
+		assert.Equal(t, payerAddress, s.Metadata["payer_address"])
+	}
+
+	// What Stripe sends once the customer pays on the hosted page
+	s.ID = sessionID
+	s.Status = stripe.CheckoutSessionStatusComplete
+	s.PaymentStatus = stripe.CheckoutSessionPaymentStatusPaid
+	if s.PaymentIntent == nil {
+		s.PaymentIntent = &stripe.PaymentIntent{ID: "pi_it_" + sessionID}
+	}
+
+	eventID := newEventID()
+	status, resp := h.deliver(eventID, "checkout.session.completed", s)
+	require.Equal(t, http.StatusOK, status, resp.Error)
+
+	payment = h.paymentForSession(sessionID)
+	assert.Equal(t, repository.PaymentStatusCompleted, payment.Status)
+	require.NotNil(t, payment.StripePaymentID)
+	assert.Equal(t, s.PaymentIntent.ID, *payment.StripePaymentID)
+
+	// Stripe redelivers until it sees a 2xx; the payment is updated once
+	status, resp = h.deliver(eventID, "checkout.session.completed", s)
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "Event already processed", resp.Message)
+
+	stored := h.storedEvent(eventID)
+	assert.Equal(t, repository.WebhookEventProcessed, stored.Status)
+	assert.Equal(t, 1, stored.Attempts)
+}
+
+func TestStripeCheckout_ExpiredWebhookCancelsPayment(t *testing.T) {
+	h := newStripeHarness(t)
+
+	sessionID := h.createCheckout("kyc_aml_recheck")
+
+	s, err := session.Expire(sessionID, nil)
+	require.NoError(t, err)
+	if h.mock {
+		// stripe-mock returns a canned session
+		s.ID = sessionID
+		s.Status = stripe.CheckoutSessionStatusExpired
+	}
+	require.Equal(t, sessionID, s.ID)
+	assert.Equal(t, stripe.CheckoutSessionStatusExpired, s.Status)
+
+	status, resp := h.deliver(newEventID(), "checkout.session.expired", s)
+	require.Equal(t, http.StatusOK, status, resp.Error)
+
+	payment := h.paymentForSession(sessionID)
+	assert.Equal(t, repository.PaymentStatusCancelled, payment.Status)
+}
+
+func TestStripeCheckout_UnknownSessionIsAcknowledged(t *testing.T) {
+	h := newStripeHarness(t)
+
+	// Sessions created outside this service must not make Stripe retry forever
+	eventID := newEventID()
+	status, resp := h.deliver(eventID, "checkout.session.completed", &stripe.CheckoutSession{
+		ID:     "cs_test_not_ours",
+		Object: "checkout.session",
+		Status: stripe.CheckoutSessionStatusComplete,
+	})
+	require.Equal(t, http.StatusOK, status, resp.Error)
+	assert.Equal(t, repository.WebhookEventProcessed, h.storedEvent(eventID).Status)
+}
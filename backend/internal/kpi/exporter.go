@@ -0,0 +1,185 @@
+// Package kpi exports business KPIs (payments, KYC, relaying, NFT mints) in the
+// OpenMetrics text format so ops dashboards can scrape them instead of
+// querying the database directly
+package kpi
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// ContentType is the OpenMetrics exposition media type
+const ContentType = "application/openmetrics-text; version=1.0.0; charset=utf-8"
+
+// Exporter defaults
+const (
+	// Window is the look-back for activity KPIs (the _24h metrics)
+	Window = 24 * time.Hour
+
+	// DefaultTTL bounds how often a scrape reaches the database
+	DefaultTTL = 30 * time.Second
+)
+
+// Statuses are always exported, with 0 for absent ones, so dashboard series
+// do not appear and vanish as records change state
+var (
+	paymentStatuses = []repository.PaymentStatus{
+		repository.PaymentStatusPending,
+		repository.PaymentStatusProcessing,
+		repository.PaymentStatusCompleted,
+		repository.PaymentStatusFailed,
+		repository.PaymentStatusRefunded,
+		repository.PaymentStatusCancelled,
+		repository.PaymentStatusExpired,
+		repository.PaymentStatusDisputed,
+	}
+	kycStatuses = []repository.KYCVerificationStatus{
+		repository.KYCStatusPending,
+		repository.KYCStatusPaymentRequired,
+		repository.KYCStatusSubmitted,
+		repository.KYCStatusInReview,
+		repository.KYCStatusApproved,
+		repository.KYCStatusRejected,
+		repository.KYCStatusExpired,
+		repository.KYCStatusSuspended,
+	}
+)
+
+// Collector contributes metric families to the business metrics, such as
+// counters a background job keeps in memory
+type Collector interface {
+	// WriteMetrics writes complete metric families (without "# EOF") in the
+	// OpenMetrics text format
+	WriteMetrics(w io.Writer)
+}
+
+// Exporter caches business KPIs and renders them as OpenMetrics
+type Exporter struct {
+	repo       repository.KPIRepository
+	logger     *zap.Logger
+	ttl        time.Duration
+	now        func() time.Time
+	collectors []Collector
+
+	mu          sync.Mutex
+	cached      *repository.BusinessKPIs
+	collectedAt time.Time
+}
+
+// NewExporter creates an exporter that refreshes from repo at most once per
+// ttl (DefaultTTL if ttl <= 0)
+func NewExporter(repo repository.KPIRepository, ttl time.Duration, logger *zap.Logger) *Exporter {
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+	return &Exporter{
+		repo:   repo,
+		logger: logger,
+		ttl:    ttl,
+		now:    time.Now,
+	}
+}
+
+// AddCollector appends c's metric families to every export
+func (e *Exporter) AddCollector(c Collector) {
+	e.collectors = append(e.collectors, c)
+}
+
+// Snapshot returns the cached KPIs, refreshing them once the TTL has passed.
+// A failed refresh serves the previous snapshot if there is one.
+func (e *Exporter) Snapshot(ctx context.Context) (*repository.BusinessKPIs, time.Time, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	now := e.now()
+	if e.cached != nil && now.Sub(e.collectedAt) < e.ttl {
+		return e.cached, e.collectedAt, nil
+	}
+
+	kpis, err := e.repo.GetBusinessKPIs(ctx, now.Add(-Window))
+	if err != nil {
+		if e.cached != nil {
+			e.logger.Warn("failed to refresh business kpis, serving stale snapshot",
+				zap.Time("collected_at", e.collectedAt), zap.Error(err))
+			return e.cached, e.collectedAt, nil
+		}
+		return nil, time.Time{}, err
+	}
+
+	e.cached, e.collectedAt = kpis, now
+	return kpis, now, nil
+}
+
+// WriteOpenMetrics writes the current KPIs to w in the OpenMetrics text format
+func (e *Exporter) WriteOpenMetrics(ctx context.Context, w io.Writer) error {
+	kpis, collectedAt, err := e.Snapshot(ctx)
+	if err != nil {
+		return fmt.Errorf("collecting business kpis: %w", err)
+	}
+
+	b := bufio.NewWriter(w)
+
+	gauge(b, "nexus_payments", "Payments in the live table by status.")
+	for _, s := range paymentStatuses {
+		sample(b, "nexus_payments", "status", string(s), float64(kpis.PaymentsByStatus[s]))
+	}
+
+	gauge(b, "nexus_kyc_verifications", "KYC verifications by status.")
+	for _, s := range kycStatuses {
+		sample(b, "nexus_kyc_verifications", "status", string(s), float64(kpis.KYCByStatus[s]))
+	}
+
+	gauge(b, "nexus_kyc_decisions_24h", "KYC verifications approved or rejected in the last 24 hours.")
+	sample(b, "nexus_kyc_decisions_24h", "result", "approved", float64(kpis.KYCApproved))
+	sample(b, "nexus_kyc_decisions_24h", "result", "rejected", float64(kpis.KYCRejected))
+
+	gauge(b, "nexus_kyc_approval_ratio_24h", "Share of KYC decisions in the last 24 hours that were approvals.")
+	sample(b, "nexus_kyc_approval_ratio_24h", "", "", kpis.KYCApprovalRate())
+
+	gauge(b, "nexus_relay_confirmed_24h", "Meta-transactions confirmed on chain in the last 24 hours.")
+	sample(b, "nexus_relay_confirmed_24h", "", "", float64(kpis.RelayConfirmed))
+
+	gauge(b, "nexus_relay_confirmation_avg_seconds_24h", "Mean time from relay request to on-chain confirmation over the last 24 hours.")
+	sample(b, "nexus_relay_confirmation_avg_seconds_24h", "", "", kpis.AvgRelayConfirmationSeconds())
+
+	if kpis.NFTMints != nil {
+		gauge(b, "nexus_nft_mints_24h", "NFTs minted in the last 24 hours.")
+		sample(b, "nexus_nft_mints_24h", "", "", float64(*kpis.NFTMints))
+	}
+
+	gauge(b, "nexus_kpi_collected_timestamp_seconds", "When these KPIs were read from the database.")
+	sample(b, "nexus_kpi_collected_timestamp_seconds", "", "", float64(collectedAt.UnixMilli())/1000)
+
+	for _, c := range e.collectors {
+		c.WriteMetrics(b)
+	}
+
+	b.WriteString("# EOF\n")
+	return b.Flush()
+}
+
+// gauge writes the metric family metadata
+func gauge(b *bufio.Writer, name, help string) {
+	fmt.Fprintf(b, "# TYPE %s gauge\n# HELP %s %s\n", name, name, help)
+}
+
+// sample writes one sample with at most one label (label values here are
+// fixed status names, so no escaping is needed)
+func sample(b *bufio.Writer, name, label, value string, v float64) {
+	b.WriteString(name)
+	if label != "" {
+		fmt.Fprintf(b, "{%s=%q}", label, value)
+	}
+	b.WriteByte(' ')
+	b.WriteString(strconv.FormatFloat(v, 'g', -1, 64))
+	b.WriteByte('\n')
+}
@@ -0,0 +1,108 @@
+package kpi_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/kpi"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/storage/memory"
+)
+
+// stubKPIRepo returns fixed KPIs and counts calls
+type stubKPIRepo struct {
+	kpis  *repository.BusinessKPIs
+	err   error
+	calls int
+	since time.Time
+}
+
+func (s *stubKPIRepo) GetBusinessKPIs(ctx context.Context, since time.Time) (*repository.BusinessKPIs, error) {
+	s.calls++
+	s.since = since
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.kpis, nil
+}
+
+func TestExporter_WriteOpenMetrics(t *testing.T) {
+	mints := int64(7)
+	repo := &stubKPIRepo{kpis: &repository.BusinessKPIs{
+		PaymentsByStatus:         map[repository.PaymentStatus]int64{repository.PaymentStatusCompleted: 12},
+		KYCByStatus:              map[repository.KYCVerificationStatus]int64{repository.KYCStatusApproved: 3},
+		KYCApproved:              3,
+		KYCRejected:              1,
+		RelayConfirmed:           4,
+		RelayConfirmationSeconds: 50,
+		NFTMints:                 &mints,
+	}}
+	exporter := kpi.NewExporter(repo, time.Minute, zap.NewNop())
+
+	var buf bytes.Buffer
+	require.NoError(t, exporter.WriteOpenMetrics(context.Background(), &buf))
+	out := buf.String()
+
+	assert.Contains(t, out, "# TYPE nexus_payments gauge\n")
+	assert.Contains(t, out, `nexus_payments{status="completed"} 12`+"\n")
+	assert.Contains(t, out, `nexus_payments{status="refunded"} 0`+"\n", "known statuses are exported as 0")
+	assert.Contains(t, out, `nexus_kyc_decisions_24h{result="rejected"} 1`+"\n")
+	assert.Contains(t, out, "nexus_kyc_approval_ratio_24h 0.75\n")
+	assert.Contains(t, out, "nexus_relay_confirmation_avg_seconds_24h 12.5\n")
+	assert.Contains(t, out, "nexus_nft_mints_24h 7\n")
+	assert.True(t, strings.HasSuffix(out, "# EOF\n"))
+	assert.WithinDuration(t, time.Now().Add(-kpi.Window), repo.since, time.Second)
+}
+
+func TestExporter_CachesAndServesStale(t *testing.T) {
+	repo := &stubKPIRepo{kpis: &repository.BusinessKPIs{}}
+	exporter := kpi.NewExporter(repo, time.Hour, zap.NewNop())
+	ctx := context.Background()
+
+	_, first, err := exporter.Snapshot(ctx)
+	require.NoError(t, err)
+	_, _, err = exporter.Snapshot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, repo.calls, "second scrape within the TTL uses the cache")
+
+	// Once the TTL passes a failed refresh keeps serving the old snapshot
+	exporter = kpi.NewExporter(repo, time.Nanosecond, zap.NewNop())
+	_, first, err = exporter.Snapshot(ctx)
+	require.NoError(t, err)
+	repo.err = errors.New("connection refused")
+	time.Sleep(time.Millisecond)
+	got, collectedAt, err := exporter.Snapshot(ctx)
+	require.NoError(t, err)
+	assert.NotNil(t, got)
+	assert.Equal(t, first, collectedAt)
+
+	// Without a previous snapshot the error surfaces
+	_, _, err = kpi.NewExporter(repo, 0, zap.NewNop()).Snapshot(ctx)
+	assert.Error(t, err)
+}
+
+func TestExporter_MemoryStoreOmitsNFTMints(t *testing.T) {
+	store := memory.NewStore()
+	ctx := context.Background()
+
+	tx := &repository.MetaTransaction{FromAddress: "0xabc", Status: repository.MetaTxStatusSubmitted}
+	require.NoError(t, store.Relayer.CreateMetaTx(ctx, tx))
+	require.NoError(t, store.Relayer.UpdateMetaTxStatus(ctx, tx.ID, &repository.MetaTxStatusUpdate{Status: repository.MetaTxStatusConfirmed}))
+	require.NoError(t, store.Payments.CreatePayment(ctx, &repository.Payment{PayerAddress: "0xabc", Status: repository.PaymentStatusPending}))
+
+	var buf bytes.Buffer
+	require.NoError(t, kpi.NewExporter(store.KPIs, 0, zap.NewNop()).WriteOpenMetrics(ctx, &buf))
+	out := buf.String()
+
+	assert.Contains(t, out, `nexus_payments{status="pending"} 1`+"\n")
+	assert.Contains(t, out, "nexus_relay_confirmed_24h 1\n")
+	assert.NotContains(t, out, "nexus_nft_mints_24h")
+}
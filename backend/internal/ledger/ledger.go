@@ -0,0 +1,246 @@
+// Package ledger posts double-entry accounting records for the payment
+// lifecycle. Every change that moves money (a sale, a dispute withholding or
+// releasing funds, a refund, a completed payment rolled back) becomes one
+// balanced ledger transaction:
+//
+//	sale              Dr customer_funds   Cr revenue, fees
+//	                  Dr provider_costs   Cr provider_payable (USD cost of the service)
+//	dispute opened    Dr disputed_funds   Cr customer_funds
+//	dispute released  Dr customer_funds   Cr disputed_funds
+//	refund            Dr refunds          Cr customer_funds (or disputed_funds)
+//	sale reversed     the sale's entries with the sides swapped
+//
+// The ledger is append-only; nothing posted here is ever changed.
+package ledger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Poster turns payment status changes into ledger transactions
+type Poster struct {
+	ledger  repository.LedgerRepository
+	pricing repository.PricingRepository
+	logger  *zap.Logger
+}
+
+// NewPoster creates a poster. Pricing splits a card sale into the service
+// price (revenue) and the payment method fee, and gives the provider cost.
+func NewPoster(ledgerRepo repository.LedgerRepository, pricingRepo repository.PricingRepository, logger *zap.Logger) *Poster {
+	return &Poster{ledger: ledgerRepo, pricing: pricingRepo, logger: logger}
+}
+
+// Record posts the transaction for p moving from previous to its current
+// status. Changes that move no money post nothing. Each status change posts
+// at most once, so replaying one is harmless.
+func (s *Poster) Record(ctx context.Context, p *repository.Payment, previous repository.PaymentStatus) error {
+	event := eventFor(previous, p.Status)
+	if event == "" {
+		return nil
+	}
+
+	var entries []*repository.LedgerEntry
+	var err error
+	if event == repository.LedgerEventSale {
+		entries, err = s.saleEntries(ctx, p)
+	} else {
+		entries, err = s.settlementEntries(ctx, p, previous, event)
+	}
+	if err != nil || len(entries) == 0 {
+		return err
+	}
+
+	id := p.ID
+	tx := &repository.LedgerTransaction{
+		IdempotencyKey: fmt.Sprintf("%s:%s:%d", p.ID, p.Status, p.UpdatedAt.UnixNano()),
+		EventType:      event,
+		PaymentID:      &id,
+		Description:    fmt.Sprintf("%s payment %s → %s", p.ServiceCode, statusLabel(previous), p.Status),
+		Entries:        entries,
+	}
+	posted, err := s.ledger.PostLedgerTransaction(ctx, tx)
+	if err != nil {
+		return fmt.Errorf("posting %s for payment %s: %w", event, p.ID, err)
+	}
+	if posted {
+		s.logger.Debug("ledger transaction posted",
+			zap.String("transaction_id", tx.ID),
+			zap.String("event_type", event),
+			zap.String("payment_id", p.ID),
+		)
+	}
+	return nil
+}
+
+// eventFor maps a payment status change to the ledger event it posts ("" if
+// it moves no money)
+func eventFor(previous, status repository.PaymentStatus) string {
+	switch {
+	case status == repository.PaymentStatusCompleted && previous == repository.PaymentStatusDisputed:
+		return repository.LedgerEventDisputeReleased
+	case status == repository.PaymentStatusCompleted && previous != repository.PaymentStatusCompleted:
+		return repository.LedgerEventSale
+	case status == repository.PaymentStatusDisputed && previous == repository.PaymentStatusCompleted:
+		return repository.LedgerEventDisputeOpened
+	case status == repository.PaymentStatusRefunded &&
+		(previous == repository.PaymentStatusCompleted || previous == repository.PaymentStatusDisputed):
+		return repository.LedgerEventRefund
+	case previous == repository.PaymentStatusCompleted &&
+		(status == repository.PaymentStatusPending || status == repository.PaymentStatusProcessing || status == repository.PaymentStatusFailed):
+		return repository.LedgerEventSaleReversed
+	}
+	return ""
+}
+
+// saleEntries books a completed payment. Card payments are charged the price
+// plus the method fee in USD, so the excess over the price is the fee; crypto
+// payments are all revenue.
+func (s *Poster) saleEntries(ctx context.Context, p *repository.Payment) ([]*repository.LedgerEntry, error) {
+	currency := strings.ToUpper(p.Currency)
+	gross := BaseUnits(p.AmountCharged, currency)
+	if gross.Sign() <= 0 {
+		return nil, nil
+	}
+
+	pricing, err := s.pricing.GetPricing(ctx, p.ServiceCode)
+	if err != nil && !errors.Is(err, repository.ErrPricingNotFound) {
+		return nil, fmt.Errorf("getting pricing for %s: %w", p.ServiceCode, err)
+	}
+
+	revenue := new(big.Int).Set(gross)
+	fee := new(big.Int)
+	if pricing != nil && currency == "USD" {
+		if price := BaseUnits(pricing.PriceUSD, "USD"); price.Sign() > 0 && price.Cmp(gross) < 0 {
+			revenue = price
+			fee.Sub(gross, price)
+		}
+	}
+
+	entries := []*repository.LedgerEntry{
+		entry(repository.LedgerCustomerFunds, repository.LedgerDebit, gross, currency),
+		entry(repository.LedgerRevenue, repository.LedgerCredit, revenue, currency),
+	}
+	if fee.Sign() > 0 {
+		entries = append(entries, entry(repository.LedgerFees, repository.LedgerCredit, fee, currency))
+	}
+	if pricing != nil {
+		if cost := BaseUnits(pricing.CostUSD, "USD"); cost.Sign() > 0 {
+			entries = append(entries,
+				entry(repository.LedgerProviderCosts, repository.LedgerDebit, cost, "USD"),
+				entry(repository.LedgerProviderPayable, repository.LedgerCredit, cost, "USD"),
+			)
+		}
+	}
+	return entries, nil
+}
+
+// settlementEntries books a dispute, refund or reversal against the sale it
+// follows. The amounts come from the posted sale so later pricing changes do
+// not unbalance the accounts; a payment completed before the ledger existed
+// falls back to its charged amount.
+func (s *Poster) settlementEntries(ctx context.Context, p *repository.Payment, previous repository.PaymentStatus, event string) ([]*repository.LedgerEntry, error) {
+	sale, err := s.lastSale(ctx, p.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if event == repository.LedgerEventSaleReversed {
+		if sale == nil {
+			return nil, nil
+		}
+		reversed := make([]*repository.LedgerEntry, len(sale))
+		for i, e := range sale {
+			direction := repository.LedgerDebit
+			if e.Direction == repository.LedgerDebit {
+				direction = repository.LedgerCredit
+			}
+			reversed[i] = &repository.LedgerEntry{Account: e.Account, Direction: direction, Amount: e.Amount, Currency: e.Currency}
+		}
+		return reversed, nil
+	}
+
+	currency := strings.ToUpper(p.Currency)
+	amount := BaseUnits(p.AmountCharged, currency)
+	for _, e := range sale {
+		if e.Account == repository.LedgerCustomerFunds && e.Direction == repository.LedgerDebit {
+			amount, _ = new(big.Int).SetString(e.Amount, 10)
+			currency = e.Currency
+		}
+	}
+	if amount == nil || amount.Sign() <= 0 {
+		return nil, nil
+	}
+
+	debit, credit := repository.LedgerDisputedFunds, repository.LedgerCustomerFunds
+	switch event {
+	case repository.LedgerEventDisputeReleased:
+		debit, credit = repository.LedgerCustomerFunds, repository.LedgerDisputedFunds
+	case repository.LedgerEventRefund:
+		debit = repository.LedgerRefunds
+		if previous == repository.PaymentStatusDisputed {
+			credit = repository.LedgerDisputedFunds
+		}
+	}
+	return []*repository.LedgerEntry{
+		entry(debit, repository.LedgerDebit, amount, currency),
+		entry(credit, repository.LedgerCredit, amount, currency),
+	}, nil
+}
+
+// lastSale returns the entries of the payment's latest sale (nil if none was
+// posted). A payment sold again after a reversal has several.
+func (s *Poster) lastSale(ctx context.Context, paymentID string) ([]*repository.LedgerEntry, error) {
+	entries, _, err := s.ledger.ListLedgerEntries(ctx, repository.LedgerEntryFilter{
+		PaymentID: paymentID,
+		EventType: repository.LedgerEventSale,
+	}, repository.Pagination{Page: 1, PageSize: 100})
+	if err != nil {
+		return nil, fmt.Errorf("getting sale of payment %s: %w", paymentID, err)
+	}
+
+	var last []*repository.LedgerEntry
+	for _, e := range entries {
+		if len(last) > 0 && last[0].TransactionID != e.TransactionID {
+			last = nil
+		}
+		last = append(last, e)
+	}
+	return last, nil
+}
+
+// BaseUnits converts an amount to the currency's base units: cents for USD,
+// 18 decimals for ETH and NEXUS
+func BaseUnits(amount float64, currency string) *big.Int {
+	decimals := int64(18)
+	if strings.EqualFold(currency, "USD") {
+		decimals = 2
+	}
+	r, ok := new(big.Rat).SetString(strconv.FormatFloat(amount, 'f', -1, 64))
+	if !ok {
+		return new(big.Int)
+	}
+	r.Mul(r, new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(decimals), nil)))
+	// Card totals include a percentage fee, so round to the nearest unit
+	r.Add(r, big.NewRat(1, 2))
+	return new(big.Int).Quo(r.Num(), r.Denom())
+}
+
+func entry(account repository.LedgerAccount, direction repository.LedgerDirection, amount *big.Int, currency string) *repository.LedgerEntry {
+	return &repository.LedgerEntry{Account: account, Direction: direction, Amount: amount.String(), Currency: currency}
+}
+
+func statusLabel(s repository.PaymentStatus) string {
+	if s == "" {
+		return "new"
+	}
+	return string(s)
+}
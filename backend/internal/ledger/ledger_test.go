@@ -0,0 +1,141 @@
+package ledger_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/ledger"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/storage/memory"
+)
+
+const payer = "0x1111111111111111111111111111111111111111"
+
+func setup(t *testing.T) (*ledger.RecordingPaymentRepo, *memory.MemoryLedgerRepo) {
+	t.Helper()
+	pricing := memory.NewMemoryPricingRepo()
+	pricing.AddPricing(&repository.Pricing{ServiceCode: "kyc_verification", CostUSD: 5, PriceUSD: 15, IsActive: true})
+	ledgerRepo := memory.NewMemoryLedgerRepo()
+	poster := ledger.NewPoster(ledgerRepo, pricing, zap.NewNop())
+	return ledger.NewRecordingPaymentRepo(memory.NewMemoryPaymentRepo(), poster, zap.NewNop()), ledgerRepo
+}
+
+func balances(t *testing.T, repo *memory.MemoryLedgerRepo, currency string) map[repository.LedgerAccount]string {
+	t.Helper()
+	list, err := repo.GetLedgerBalances(context.Background(), repository.LedgerBalanceFilter{Currency: currency})
+	require.NoError(t, err)
+	out := map[repository.LedgerAccount]string{}
+	for _, b := range list {
+		out[b.Account] = b.Balance
+	}
+	return out
+}
+
+func transactions(t *testing.T, repo *memory.MemoryLedgerRepo, paymentID string) map[string]int {
+	t.Helper()
+	entries, _, err := repo.ListLedgerEntries(context.Background(), repository.LedgerEntryFilter{PaymentID: paymentID}, repository.Pagination{PageSize: 100})
+	require.NoError(t, err)
+	seen := map[string]bool{}
+	out := map[string]int{}
+	for _, e := range entries {
+		if !seen[e.TransactionID] {
+			seen[e.TransactionID] = true
+			out[e.EventType]++
+		}
+	}
+	return out
+}
+
+func TestRecorder_CardSaleDisputeAndRefund(t *testing.T) {
+	payments, ledgerRepo := setup(t)
+	ctx := context.Background()
+
+	// 15 USD plus a 2.9% card fee
+	p := &repository.Payment{ServiceCode: "kyc_verification", PayerAddress: payer, PaymentMethod: "stripe",
+		AmountCharged: 15.435, Currency: "USD", Status: repository.PaymentStatusPending}
+	require.NoError(t, payments.CreatePayment(ctx, p))
+	assert.Empty(t, transactions(t, ledgerRepo, p.ID), "a pending payment moves no money")
+
+	require.NoError(t, payments.UpdatePaymentStatus(ctx, p.ID, repository.PaymentStatusCompleted, nil))
+	require.NoError(t, payments.UpdatePaymentStatus(ctx, p.ID, repository.PaymentStatusCompleted, nil))
+	assert.Equal(t, map[repository.LedgerAccount]string{
+		repository.LedgerCustomerFunds:   "1544",
+		repository.LedgerRevenue:         "1500",
+		repository.LedgerFees:            "44",
+		repository.LedgerProviderCosts:   "500",
+		repository.LedgerProviderPayable: "500",
+	}, balances(t, ledgerRepo, "USD"))
+
+	require.NoError(t, payments.UpdatePaymentStatus(ctx, p.ID, repository.PaymentStatusDisputed, nil))
+	got := balances(t, ledgerRepo, "USD")
+	assert.Equal(t, "0", got[repository.LedgerCustomerFunds])
+	assert.Equal(t, "1544", got[repository.LedgerDisputedFunds])
+
+	require.NoError(t, payments.UpdatePaymentStatus(ctx, p.ID, repository.PaymentStatusCompleted, nil))
+	got = balances(t, ledgerRepo, "USD")
+	assert.Equal(t, "1544", got[repository.LedgerCustomerFunds], "a won dispute releases the funds")
+	assert.Equal(t, "0", got[repository.LedgerDisputedFunds])
+
+	require.NoError(t, payments.UpdatePaymentStatus(ctx, p.ID, repository.PaymentStatusDisputed, nil))
+	require.NoError(t, payments.UpdatePaymentStatus(ctx, p.ID, repository.PaymentStatusRefunded, nil))
+	got = balances(t, ledgerRepo, "USD")
+	assert.Equal(t, "0", got[repository.LedgerCustomerFunds])
+	assert.Equal(t, "0", got[repository.LedgerDisputedFunds], "a lost dispute is refunded from the withheld funds")
+	assert.Equal(t, "1544", got[repository.LedgerRefunds])
+	assert.Equal(t, "1500", got[repository.LedgerRevenue], "revenue is offset by refunds, not changed")
+
+	assert.Equal(t, map[string]int{
+		repository.LedgerEventSale:            1,
+		repository.LedgerEventDisputeOpened:   2,
+		repository.LedgerEventDisputeReleased: 1,
+		repository.LedgerEventRefund:          1,
+	}, transactions(t, ledgerRepo, p.ID))
+}
+
+func TestRecorder_CryptoSaleReversed(t *testing.T) {
+	payments, ledgerRepo := setup(t)
+	ctx := context.Background()
+
+	p := &repository.Payment{ServiceCode: "kyc_verification", PayerAddress: payer, PaymentMethod: "nexus",
+		AmountCharged: 30, Currency: "NEXUS", Status: repository.PaymentStatusProcessing}
+	require.NoError(t, payments.CreatePayment(ctx, p))
+	require.NoError(t, payments.UpdatePaymentStatus(ctx, p.ID, repository.PaymentStatusCompleted, nil))
+
+	assert.Equal(t, map[repository.LedgerAccount]string{
+		repository.LedgerCustomerFunds: "30000000000000000000",
+		repository.LedgerRevenue:       "30000000000000000000",
+	}, balances(t, ledgerRepo, "NEXUS"))
+	assert.Equal(t, "500", balances(t, ledgerRepo, "USD")[repository.LedgerProviderCosts])
+
+	// A reorg drops the confirming block
+	require.NoError(t, payments.UpdatePaymentStatus(ctx, p.ID, repository.PaymentStatusProcessing, nil))
+	for _, currency := range []string{"NEXUS", "USD"} {
+		for account, balance := range balances(t, ledgerRepo, currency) {
+			assert.Equal(t, "0", balance, "%s %s", account, currency)
+		}
+	}
+}
+
+func TestRecorder_CreatedCompleted(t *testing.T) {
+	payments, ledgerRepo := setup(t)
+	ctx := context.Background()
+
+	p := &repository.Payment{ServiceCode: "unpriced", PayerAddress: payer, PaymentMethod: "eth",
+		AmountCharged: 0.005, Currency: "ETH", Status: repository.PaymentStatusCompleted}
+	require.NoError(t, payments.CreatePayment(ctx, p))
+	assert.Equal(t, map[repository.LedgerAccount]string{
+		repository.LedgerCustomerFunds: "5000000000000000",
+		repository.LedgerRevenue:       "5000000000000000",
+	}, balances(t, ledgerRepo, "ETH"))
+	assert.Empty(t, balances(t, ledgerRepo, "USD"), "no pricing, no provider cost")
+}
+
+func TestBaseUnits(t *testing.T) {
+	assert.Equal(t, "1545", ledger.BaseUnits(15.45, "USD").String())
+	assert.Equal(t, "1544", ledger.BaseUnits(15.435, "usd").String())
+	assert.Equal(t, "100000000000000000", ledger.BaseUnits(0.1, "ETH").String())
+}
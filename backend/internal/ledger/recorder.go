@@ -0,0 +1,151 @@
+package ledger
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure RecordingPaymentRepo implements PaymentRepository
+var _ repository.PaymentRepository = (*RecordingPaymentRepo)(nil)
+
+// RecordingPaymentRepo wraps a PaymentRepository and posts to the ledger
+// whenever a payment is created completed or changes status, so every path
+// through the payment lifecycle is accounted for (as NotifyingPaymentRepo
+// does for callbacks)
+type RecordingPaymentRepo struct {
+	next   repository.PaymentRepository
+	poster *Poster
+	logger *zap.Logger
+}
+
+// NewRecordingPaymentRepo wraps next, posting through poster
+func NewRecordingPaymentRepo(next repository.PaymentRepository, poster *Poster, logger *zap.Logger) *RecordingPaymentRepo {
+	return &RecordingPaymentRepo{next: next, poster: poster, logger: logger}
+}
+
+// CreatePayment implements repository.PaymentRepository
+func (r *RecordingPaymentRepo) CreatePayment(ctx context.Context, payment *repository.Payment) error {
+	if err := r.next.CreatePayment(ctx, payment); err != nil {
+		return err
+	}
+	if payment.Status == repository.PaymentStatusCompleted {
+		r.record(ctx, payment, "")
+	}
+	return nil
+}
+
+// GetPayment implements repository.PaymentRepository
+func (r *RecordingPaymentRepo) GetPayment(ctx context.Context, id string) (*repository.Payment, error) {
+	return r.next.GetPayment(ctx, id)
+}
+
+// GetPaymentByStripeSession implements repository.PaymentRepository
+func (r *RecordingPaymentRepo) GetPaymentByStripeSession(ctx context.Context, sessionID string) (*repository.Payment, error) {
+	return r.next.GetPaymentByStripeSession(ctx, sessionID)
+}
+
+// GetPaymentByStripePaymentID implements repository.PaymentRepository
+func (r *RecordingPaymentRepo) GetPaymentByStripePaymentID(ctx context.Context, paymentIntentID string) (*repository.Payment, error) {
+	return r.next.GetPaymentByStripePaymentID(ctx, paymentIntentID)
+}
+
+// UpdatePaymentStatus implements repository.PaymentRepository. Updates that
+// leave the status unchanged post nothing.
+func (r *RecordingPaymentRepo) UpdatePaymentStatus(ctx context.Context, id string, status repository.PaymentStatus, details *repository.PaymentStatusUpdate) error {
+	before, err := r.next.GetPayment(ctx, id)
+	if err != nil {
+		// Let the update report the error (or apply it without posting)
+		return r.next.UpdatePaymentStatus(ctx, id, status, details)
+	}
+	if err := r.next.UpdatePaymentStatus(ctx, id, status, details); err != nil {
+		return err
+	}
+	if before.Status == status {
+		return nil
+	}
+
+	after, err := r.next.GetPayment(ctx, id)
+	if err != nil {
+		r.logger.Error("failed to load payment for ledger",
+			zap.String("payment_id", id),
+			zap.String("status", string(status)),
+			zap.Error(err),
+		)
+		return nil
+	}
+	r.record(ctx, after, before.Status)
+	return nil
+}
+
+// record posts p's status change. The change is already stored, so a posting
+// failure is logged rather than returned.
+func (r *RecordingPaymentRepo) record(ctx context.Context, p *repository.Payment, previous repository.PaymentStatus) {
+	if err := r.poster.Record(ctx, p, previous); err != nil {
+		r.logger.Error("failed to post payment to ledger",
+			zap.String("payment_id", p.ID),
+			zap.String("status", string(p.Status)),
+			zap.Error(err),
+		)
+	}
+}
+
+// ListPayments implements repository.PaymentRepository
+func (r *RecordingPaymentRepo) ListPayments(ctx context.Context, filter repository.PaymentFilter, page repository.Pagination) ([]*repository.Payment, int64, error) {
+	return r.next.ListPayments(ctx, filter, page)
+}
+
+// ArchivePayments implements repository.PaymentRepository. Archived payments
+// keep their ledger transactions.
+func (r *RecordingPaymentRepo) ArchivePayments(ctx context.Context, before time.Time, limit int) (int64, error) {
+	return r.next.ArchivePayments(ctx, before, limit)
+}
+
+// GetArchivedPayment implements repository.PaymentRepository
+func (r *RecordingPaymentRepo) GetArchivedPayment(ctx context.Context, id string) (*repository.Payment, error) {
+	return r.next.GetArchivedPayment(ctx, id)
+}
+
+// ListArchivedPayments implements repository.PaymentRepository
+func (r *RecordingPaymentRepo) ListArchivedPayments(ctx context.Context, filter repository.PaymentFilter, page repository.Pagination) ([]*repository.Payment, int64, error) {
+	return r.next.ListArchivedPayments(ctx, filter, page)
+}
+
+// ExpirePayments implements repository.PaymentRepository. Only pending
+// payments expire, and they moved no money.
+func (r *RecordingPaymentRepo) ExpirePayments(ctx context.Context, method string, before time.Time, limit int) ([]string, error) {
+	return r.next.ExpirePayments(ctx, method, before, limit)
+}
+
+// CreateKYCVerification implements repository.PaymentRepository
+func (r *RecordingPaymentRepo) CreateKYCVerification(ctx context.Context, v *repository.KYCVerification) error {
+	return r.next.CreateKYCVerification(ctx, v)
+}
+
+// GetKYCVerification implements repository.PaymentRepository
+func (r *RecordingPaymentRepo) GetKYCVerification(ctx context.Context, id string) (*repository.KYCVerification, error) {
+	return r.next.GetKYCVerification(ctx, id)
+}
+
+// GetKYCVerificationByAddress implements repository.PaymentRepository
+func (r *RecordingPaymentRepo) GetKYCVerificationByAddress(ctx context.Context, address string) (*repository.KYCVerification, error) {
+	return r.next.GetKYCVerificationByAddress(ctx, address)
+}
+
+// GetKYCVerificationByApplicant implements repository.PaymentRepository
+func (r *RecordingPaymentRepo) GetKYCVerificationByApplicant(ctx context.Context, applicantID string) (*repository.KYCVerification, error) {
+	return r.next.GetKYCVerificationByApplicant(ctx, applicantID)
+}
+
+// UpdateKYCVerification implements repository.PaymentRepository
+func (r *RecordingPaymentRepo) UpdateKYCVerification(ctx context.Context, id string, update *repository.KYCVerificationUpdate) error {
+	return r.next.UpdateKYCVerification(ctx, id, update)
+}
+
+// ListKYCVerifications implements repository.PaymentRepository
+func (r *RecordingPaymentRepo) ListKYCVerifications(ctx context.Context, filter repository.KYCVerificationFilter, page repository.Pagination) ([]*repository.KYCVerification, int64, error) {
+	return r.next.ListKYCVerifications(ctx, filter, page)
+}
@@ -0,0 +1,512 @@
+// Package loadgen fills a database with production-sized volumes of
+// payments, meta-transactions and KYC verifications so list endpoints and
+// indexes can be profiled against realistic data
+package loadgen
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Generator defaults
+const (
+	DefaultWorkers   = 4
+	DefaultBatchSize = 500
+
+	// recordsPerPayer sizes the payer pool when Config.Payers is unset, so
+	// per-address lists return a few dozen rows as they do in production
+	recordsPerPayer = 20
+
+	progressEvery = 10000
+
+	kycServiceCode = "kyc_verification"
+)
+
+// Config selects how much data Run generates
+type Config struct {
+	Payments int
+	KYC      int // each verification also creates its KYC payment
+	MetaTxs  int
+
+	Payers    int    // distinct payer/signer addresses (0 = one per 20 records)
+	Workers   int    // concurrent writers (0 = DefaultWorkers)
+	BatchSize int    // meta-transactions per multi-row insert (0 = DefaultBatchSize)
+	Seed      uint64 // random seed (0 = time-based)
+}
+
+// Result summarises a Run
+type Result struct {
+	Payments int64         `json:"payments"`
+	KYC      int64         `json:"kyc_verifications"`
+	MetaTxs  int64         `json:"meta_transactions"`
+	Seed     uint64        `json:"seed"`
+	Duration time.Duration `json:"duration"`
+}
+
+// weighted is a value drawn with probability proportional to weight
+type weighted[T any] struct {
+	value  T
+	weight int
+}
+
+func pick[T any](rng *rand.Rand, choices []weighted[T]) T {
+	total := 0
+	for _, c := range choices {
+		total += c.weight
+	}
+	n := rng.IntN(total)
+	for _, c := range choices {
+		if n < c.weight {
+			return c.value
+		}
+		n -= c.weight
+	}
+	return choices[len(choices)-1].value
+}
+
+// Status mixes roughly matching production
+var (
+	paymentStatuses = []weighted[repository.PaymentStatus]{
+		{repository.PaymentStatusCompleted, 70},
+		{repository.PaymentStatusPending, 8},
+		{repository.PaymentStatusProcessing, 4},
+		{repository.PaymentStatusFailed, 8},
+		{repository.PaymentStatusCancelled, 8},
+		{repository.PaymentStatusRefunded, 2},
+	}
+	kycStatuses = []weighted[repository.KYCVerificationStatus]{
+		{repository.KYCStatusApproved, 70},
+		{repository.KYCStatusRejected, 10},
+		{repository.KYCStatusInReview, 8},
+		{repository.KYCStatusSubmitted, 7},
+		{repository.KYCStatusExpired, 5},
+	}
+	metaTxStatuses = []weighted[repository.MetaTxStatus]{
+		{repository.MetaTxStatusConfirmed, 80},
+		{repository.MetaTxStatusFailed, 5},
+		{repository.MetaTxStatusExpired, 5},
+		{repository.MetaTxStatusPending, 4},
+		{repository.MetaTxStatusSubmitted, 4},
+		{repository.MetaTxStatusCancelled, 2},
+	}
+	metaTxFunctions = []weighted[string]{
+		{"transfer", 40},
+		{"approve", 20},
+		{"stake", 15},
+		{"unstake", 8},
+		{"delegate", 7},
+		{"castVote", 6},
+		{"claimRewards", 4},
+	}
+)
+
+// Generator writes generated records through the repositories
+type Generator struct {
+	payments repository.PaymentRepository
+	pricing  repository.PricingRepository
+	relayer  repository.RelayerRepository
+	logger   *zap.Logger
+}
+
+// NewGenerator creates a new generator
+func NewGenerator(payments repository.PaymentRepository, pricing repository.PricingRepository, relayer repository.RelayerRepository, logger *zap.Logger) *Generator {
+	return &Generator{
+		payments: payments,
+		pricing:  pricing,
+		relayer:  relayer,
+		logger:   logger,
+	}
+}
+
+// run holds the shared state of one Run
+type run struct {
+	cfg      Config
+	services []*repository.Pricing
+	kyc      *repository.Pricing
+	methods  []*repository.PaymentMethod
+	payers   []string
+	targets  []string
+
+	// nonces hands out per-signer meta-tx nonces across workers
+	mu     sync.Mutex
+	nonces map[string]uint64
+
+	written atomic.Int64
+}
+
+// Run generates the configured records. Records are written as they are
+// generated; on error the ones already written stay in the database.
+func (g *Generator) Run(ctx context.Context, cfg Config) (*Result, error) {
+	if cfg.Payments < 0 || cfg.KYC < 0 || cfg.MetaTxs < 0 || cfg.Payers < 0 {
+		return nil, errors.New("counts must not be negative")
+	}
+	if cfg.Workers <= 0 {
+		cfg.Workers = DefaultWorkers
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = DefaultBatchSize
+	}
+	if cfg.Seed == 0 {
+		cfg.Seed = uint64(time.Now().UnixNano())
+	}
+	if cfg.Payers == 0 {
+		cfg.Payers = max(1, (cfg.Payments+cfg.MetaTxs)/recordsPerPayer)
+	}
+
+	r, err := g.prepare(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	result := &Result{Seed: cfg.Seed}
+
+	g.logger.Info("generating load data",
+		zap.Int("payments", cfg.Payments),
+		zap.Int("kyc", cfg.KYC),
+		zap.Int("meta_txs", cfg.MetaTxs),
+		zap.Int("payers", cfg.Payers),
+		zap.Uint64("seed", cfg.Seed),
+	)
+
+	phases := []struct {
+		name  string
+		count int
+		fn    func(ctx context.Context, r *run, rng *rand.Rand, n int) error
+		out   *int64
+	}{
+		{"payments", cfg.Payments, g.writePayments, &result.Payments},
+		{"kyc", cfg.KYC, g.writeKYC, &result.KYC},
+		{"meta_txs", cfg.MetaTxs, g.writeMetaTxs, &result.MetaTxs},
+	}
+	for i, phase := range phases {
+		if phase.count == 0 {
+			continue
+		}
+		before := r.written.Load()
+		err := r.parallel(ctx, uint64(i), phase.count, phase.fn)
+		*phase.out = r.written.Load() - before
+		if err != nil {
+			result.Duration = time.Since(start)
+			return result, fmt.Errorf("generating %s: %w", phase.name, err)
+		}
+	}
+
+	result.Duration = time.Since(start)
+	return result, nil
+}
+
+// prepare loads pricing and builds the address pools
+func (g *Generator) prepare(ctx context.Context, cfg Config) (*run, error) {
+	services, err := g.pricing.ListPricing(ctx, true)
+	if err != nil {
+		return nil, fmt.Errorf("listing pricing: %w", err)
+	}
+	if cfg.Payments > 0 && len(services) == 0 {
+		return nil, errors.New("no active pricing to generate payments for")
+	}
+
+	methods, err := g.pricing.ListPaymentMethods(ctx, true)
+	if err != nil {
+		return nil, fmt.Errorf("listing payment methods: %w", err)
+	}
+	if cfg.Payments+cfg.KYC > 0 && len(methods) == 0 {
+		return nil, errors.New("no active payment methods to generate payments with")
+	}
+
+	r := &run{
+		cfg:      cfg,
+		services: services,
+		methods:  methods,
+		nonces:   make(map[string]uint64),
+	}
+	if cfg.KYC > 0 {
+		if r.kyc, err = g.pricing.GetPricing(ctx, kycServiceCode); err != nil {
+			return nil, fmt.Errorf("loading %s pricing: %w", kycServiceCode, err)
+		}
+	}
+
+	rng := rand.New(rand.NewPCG(cfg.Seed, 0))
+	r.payers = make([]string, cfg.Payers)
+	for i := range r.payers {
+		r.payers[i] = randomAddress(rng)
+	}
+	// A handful of contracts receive most meta-transactions
+	r.targets = make([]string, 8)
+	for i := range r.targets {
+		r.targets[i] = randomAddress(rng)
+	}
+
+	return r, nil
+}
+
+// parallel splits n records across the workers, each with its own
+// deterministic random source, and returns the first error
+func (r *run) parallel(ctx context.Context, phase uint64, n int, fn func(ctx context.Context, r *run, rng *rand.Rand, n int) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	workers := min(r.cfg.Workers, n)
+	errs := make(chan error, workers)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		share := n / workers
+		if w < n%workers {
+			share++
+		}
+		rng := rand.New(rand.NewPCG(r.cfg.Seed, phase<<32|uint64(w+1)))
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := fn(ctx, r, rng, share); err != nil {
+				errs <- err
+				cancel()
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	return <-errs
+}
+
+// progress counts written records and logs every progressEvery
+func (g *Generator) progress(r *run, n int) {
+	total := r.written.Add(int64(n))
+	if total/progressEvery != (total-int64(n))/progressEvery {
+		g.logger.Info("load data progress", zap.Int64("records", total))
+	}
+}
+
+// writePayments creates n payments across services, methods and statuses
+func (g *Generator) writePayments(ctx context.Context, r *run, rng *rand.Rand, n int) error {
+	for i := 0; i < n; i++ {
+		service := r.services[rng.IntN(len(r.services))]
+		payer := r.payers[rng.IntN(len(r.payers))]
+		status := pick(rng, paymentStatuses)
+		if _, err := g.createPayment(ctx, r, rng, service, payer, status); err != nil {
+			return err
+		}
+		g.progress(r, 1)
+	}
+	return nil
+}
+
+// createPayment creates one payment the way the payment handler records it
+// and moves it to status
+func (g *Generator) createPayment(ctx context.Context, r *run, rng *rand.Rand, service *repository.Pricing, payer string, status repository.PaymentStatus) (*repository.Payment, error) {
+	method := r.methods[rng.IntN(len(r.methods))]
+	payment := &repository.Payment{
+		ServiceCode:   service.ServiceCode,
+		PricingID:     &service.ID,
+		PayerAddress:  payer,
+		PaymentMethod: method.MethodCode,
+		AmountUSD:     &service.PriceUSD,
+	}
+
+	switch {
+	case method.MethodCode == "eth" && service.PriceETH != nil:
+		payment.AmountCharged = *service.PriceETH
+		payment.Currency = "ETH"
+	case method.MethodCode == "nexus" && service.PriceNEXUS != nil:
+		payment.AmountCharged = *service.PriceNEXUS
+		payment.Currency = "NEXUS"
+	default:
+		total := service.PriceUSD * (1 + method.FeePercent/100)
+		payment.PaymentMethod = "stripe"
+		payment.AmountCharged = total
+		payment.Currency = "USD"
+		payment.AmountUSD = &total
+	}
+
+	var details *repository.PaymentStatusUpdate
+	if payment.PaymentMethod == "stripe" {
+		sessionID := "cs_test_" + randomHex(rng, 24)
+		payment.StripeSessionID = &sessionID
+		payment.Status = repository.PaymentStatusPending
+		if status == repository.PaymentStatusCompleted || status == repository.PaymentStatusRefunded {
+			intentID := "pi_" + randomHex(rng, 12)
+			details = &repository.PaymentStatusUpdate{StripePaymentID: &intentID}
+		}
+	} else {
+		txHash := "0x" + randomHex(rng, 32)
+		payment.TxHash = &txHash
+		payment.Status = repository.PaymentStatusProcessing
+	}
+	if status == repository.PaymentStatusFailed {
+		msg := "transaction reverted"
+		if payment.PaymentMethod == "stripe" {
+			msg = "card_declined"
+		}
+		details = &repository.PaymentStatusUpdate{ErrorMessage: &msg}
+	}
+
+	if err := g.payments.CreatePayment(ctx, payment); err != nil {
+		return nil, err
+	}
+	if status != payment.Status {
+		if status == repository.PaymentStatusRefunded {
+			// Refunds happen after completion, which sets completed_at
+			if err := g.payments.UpdatePaymentStatus(ctx, payment.ID, repository.PaymentStatusCompleted, details); err != nil {
+				return nil, err
+			}
+			details = nil
+		}
+		if err := g.payments.UpdatePaymentStatus(ctx, payment.ID, status, details); err != nil {
+			return nil, err
+		}
+		payment.Status = status
+	}
+	return payment, nil
+}
+
+// writeKYC creates n verifications, each for a new address with its
+// completed KYC payment
+func (g *Generator) writeKYC(ctx context.Context, r *run, rng *rand.Rand, n int) error {
+	for i := 0; i < n; i++ {
+		user := randomAddress(rng)
+		payment, err := g.createPayment(ctx, r, rng, r.kyc, user, repository.PaymentStatusCompleted)
+		if err != nil {
+			return err
+		}
+
+		applicantID := randomHex(rng, 12)
+		verification := &repository.KYCVerification{
+			PaymentID:         &payment.ID,
+			UserAddress:       user,
+			SumsubApplicantID: &applicantID,
+			Status:            repository.KYCStatusSubmitted,
+		}
+		if err := g.payments.CreateKYCVerification(ctx, verification); err != nil {
+			return err
+		}
+
+		status := pick(rng, kycStatuses)
+		if status != verification.Status {
+			update := &repository.KYCVerificationUpdate{Status: &status}
+			switch status {
+			case repository.KYCStatusApproved, repository.KYCStatusRejected:
+				inspectionID := randomHex(rng, 12)
+				reviewStatus := "completed"
+				answer := "GREEN"
+				if status == repository.KYCStatusRejected {
+					answer = "RED"
+				}
+				update.SumsubInspectionID = &inspectionID
+				update.SumsubReviewStatus = &reviewStatus
+				update.SumsubReviewResult = map[string]string{"reviewAnswer": answer}
+			case repository.KYCStatusInReview:
+				reviewStatus := "pending"
+				update.SumsubReviewStatus = &reviewStatus
+			}
+			if err := g.payments.UpdateKYCVerification(ctx, verification.ID, update); err != nil {
+				return err
+			}
+		}
+		g.progress(r, 1)
+	}
+	return nil
+}
+
+// writeMetaTxs creates n meta-transactions in batches, then moves each to
+// its status
+func (g *Generator) writeMetaTxs(ctx context.Context, r *run, rng *rand.Rand, n int) error {
+	for written := 0; written < n; {
+		size := min(r.cfg.BatchSize, n-written)
+		batch := make([]*repository.MetaTransaction, size)
+		statuses := make([]repository.MetaTxStatus, size)
+		for i := range batch {
+			from := r.payers[rng.IntN(len(r.payers))]
+			batch[i] = &repository.MetaTransaction{
+				FromAddress:  from,
+				ToAddress:    r.targets[rng.IntN(len(r.targets))],
+				FunctionName: pick(rng, metaTxFunctions),
+				Calldata:     "0x" + randomHex(rng, 4+32*(1+rng.IntN(3))),
+				Value:        "0",
+				GasLimit:     uint64(60000 + rng.IntN(240000)),
+				Nonce:        r.nextNonce(from),
+				Deadline:     time.Now().Add(time.Duration(5+rng.IntN(55)) * time.Minute),
+				Signature:    "0x" + randomHex(rng, 65),
+				Status:       repository.MetaTxStatusPending,
+			}
+			statuses[i] = pick(rng, metaTxStatuses)
+		}
+		if err := g.relayer.CreateMetaTxBatch(ctx, batch); err != nil {
+			return err
+		}
+
+		for i, tx := range batch {
+			if statuses[i] == repository.MetaTxStatusPending {
+				continue
+			}
+			if err := g.relayer.UpdateMetaTxStatus(ctx, tx.ID, metaTxUpdate(rng, statuses[i], tx.GasLimit)); err != nil {
+				return err
+			}
+		}
+
+		written += size
+		g.progress(r, size)
+	}
+	return nil
+}
+
+// metaTxUpdate builds the status update the relayer and confirmation
+// tracker would record for status
+func metaTxUpdate(rng *rand.Rand, status repository.MetaTxStatus, gasLimit uint64) *repository.MetaTxStatusUpdate {
+	update := &repository.MetaTxStatusUpdate{Status: status}
+	switch status {
+	case repository.MetaTxStatusSubmitted, repository.MetaTxStatusConfirmed, repository.MetaTxStatusFailed:
+		txHash := "0x" + randomHex(rng, 32)
+		update.TxHash = &txHash
+	}
+	switch status {
+	case repository.MetaTxStatusConfirmed:
+		gasUsed := gasLimit/2 + uint64(rng.Int64N(int64(gasLimit/2)))
+		gasPrice := uint64(1e9 + rng.Int64N(30e9))
+		price := fmt.Sprint(gasPrice)
+		cost := fmt.Sprintf("%.18f", float64(gasUsed*gasPrice)/1e18)
+		update.GasUsed = &gasUsed
+		update.GasPrice = &price
+		update.RelayCostETH = &cost
+	case repository.MetaTxStatusFailed:
+		msg := "execution reverted"
+		update.ErrorMessage = &msg
+	case repository.MetaTxStatusExpired:
+		msg := "deadline passed before submission"
+		update.ErrorMessage = &msg
+	}
+	return update
+}
+
+// nextNonce returns the next generated nonce for a signer
+func (r *run) nextNonce(address string) uint64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	n := r.nonces[address]
+	r.nonces[address] = n + 1
+	return n
+}
+
+// randomAddress returns a lowercase hex address, as handlers store them
+func randomAddress(rng *rand.Rand) string {
+	return "0x" + randomHex(rng, 20)
+}
+
+func randomHex(rng *rand.Rand, n int) string {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = byte(rng.UintN(256))
+	}
+	return hex.EncodeToString(b)
+}
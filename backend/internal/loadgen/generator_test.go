@@ -0,0 +1,130 @@
+package loadgen_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/loadgen"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/storage/memory"
+)
+
+var all = repository.Pagination{Page: 1, PageSize: 100000}
+
+func newGenerator(store *memory.Store) *loadgen.Generator {
+	return loadgen.NewGenerator(store.Payments, store.Pricing, store.Relayer, zap.NewNop())
+}
+
+func TestGenerator_WritesRequestedVolumes(t *testing.T) {
+	store := memory.NewSeededStore()
+	ctx := context.Background()
+	_, seeded, err := store.Payments.ListPayments(ctx, repository.PaymentFilter{}, all)
+	require.NoError(t, err)
+
+	result, err := newGenerator(store).Run(ctx, loadgen.Config{
+		Payments:  300,
+		KYC:       40,
+		MetaTxs:   250,
+		Payers:    10,
+		Workers:   3,
+		BatchSize: 40,
+		Seed:      7,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(300), result.Payments)
+	assert.Equal(t, int64(40), result.KYC)
+	assert.Equal(t, int64(250), result.MetaTxs)
+	assert.Equal(t, uint64(7), result.Seed)
+
+	// Every KYC verification brings its completed KYC payment
+	payments, total, err := store.Payments.ListPayments(ctx, repository.PaymentFilter{}, all)
+	require.NoError(t, err)
+	assert.Equal(t, seeded+340, total)
+
+	payers := map[string]bool{}
+	statuses := map[repository.PaymentStatus]int{}
+	for _, p := range payments {
+		statuses[p.Status]++
+		if p.ServiceCode != "kyc_verification" {
+			payers[p.PayerAddress] = true
+		}
+		switch p.PaymentMethod {
+		case "stripe":
+			assert.Equal(t, "USD", p.Currency)
+			assert.NotNil(t, p.StripeSessionID)
+		case "eth", "nexus":
+			assert.NotNil(t, p.TxHash)
+		}
+		if p.Status == repository.PaymentStatusCompleted {
+			assert.NotNil(t, p.CompletedAt)
+		}
+	}
+	assert.LessOrEqual(t, len(payers), 10)
+	assert.Greater(t, statuses[repository.PaymentStatusCompleted], statuses[repository.PaymentStatusFailed])
+
+	kyc, total, err := store.Payments.ListKYCVerifications(ctx, repository.KYCVerificationFilter{}, all)
+	require.NoError(t, err)
+	assert.Equal(t, int64(40), total)
+	users := map[string]bool{}
+	for _, v := range kyc {
+		assert.False(t, users[v.UserAddress], "one verification per address")
+		users[v.UserAddress] = true
+		require.NotNil(t, v.PaymentID)
+		payment, err := store.Payments.GetPayment(ctx, *v.PaymentID)
+		require.NoError(t, err)
+		assert.Equal(t, v.UserAddress, payment.PayerAddress)
+	}
+
+	// Nonces per signer run 0..n-1 with no gaps or repeats across workers
+	txs, total, err := store.Relayer.ListMetaTx(ctx, repository.MetaTxFilter{}, all)
+	require.NoError(t, err)
+	assert.Equal(t, int64(250), total)
+	nonces := map[string]map[uint64]bool{}
+	for _, tx := range txs {
+		if nonces[tx.FromAddress] == nil {
+			nonces[tx.FromAddress] = map[uint64]bool{}
+		}
+		assert.False(t, nonces[tx.FromAddress][tx.Nonce])
+		nonces[tx.FromAddress][tx.Nonce] = true
+		if tx.Status == repository.MetaTxStatusConfirmed {
+			assert.NotNil(t, tx.TxHash)
+			assert.NotNil(t, tx.GasUsed)
+		}
+	}
+	for from, seen := range nonces {
+		for n := range seen {
+			assert.Less(t, n, uint64(len(seen)), "nonce gap for %s", from)
+		}
+	}
+}
+
+// failingRelayer rejects every batch insert
+type failingRelayer struct {
+	repository.RelayerRepository
+}
+
+func (failingRelayer) CreateMetaTxBatch(ctx context.Context, txs []*repository.MetaTransaction) error {
+	return errors.New("database unavailable")
+}
+
+func TestGenerator_ReportsPartialProgressOnError(t *testing.T) {
+	store := memory.NewSeededStore()
+	generator := loadgen.NewGenerator(store.Payments, store.Pricing, failingRelayer{store.Relayer}, zap.NewNop())
+
+	result, err := generator.Run(context.Background(), loadgen.Config{Payments: 20, MetaTxs: 10, Seed: 1})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "generating meta_txs")
+	require.NotNil(t, result)
+	assert.Equal(t, int64(20), result.Payments)
+	assert.Zero(t, result.MetaTxs)
+}
+
+func TestGenerator_RejectsNegativeCounts(t *testing.T) {
+	_, err := newGenerator(memory.NewSeededStore()).Run(context.Background(), loadgen.Config{Payments: -1})
+	assert.Error(t, err)
+}
@@ -0,0 +1,322 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// app_config settings
+const (
+	Namespace              = "logging"
+	DefaultRefreshInterval = 10 * time.Second
+
+	keyLevel    = "level"
+	keySampling = "sampling_enabled"
+	keyModules  = "module_levels"
+)
+
+// Validation errors returned by Set
+var (
+	ErrInvalidLevel  = errors.New("level must be debug, info, warn or error")
+	ErrUnknownModule = errors.New("unknown logging module")
+)
+
+// Settings are the log settings shared by all instances. Modules maps a
+// module to its level override.
+type Settings struct {
+	Level     string            `json:"level"`
+	Sampling  bool              `json:"sampling"`
+	Modules   map[string]string `json:"modules"`
+	UpdatedBy string            `json:"updated_by,omitempty"`
+	UpdatedAt time.Time         `json:"updated_at,omitempty"`
+}
+
+// ParseLevel parses a level settable at runtime (debug, info, warn or error)
+func ParseLevel(s string) (zapcore.Level, error) {
+	level, err := zapcore.ParseLevel(strings.TrimSpace(s))
+	if err != nil || level < zapcore.DebugLevel || level > zapcore.ErrorLevel {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidLevel, s)
+	}
+	return level, nil
+}
+
+// Controller persists log settings in app_config and applies them to a
+// Filter. Set changes them for all instances; Run picks up changes made
+// elsewhere. Changes made on the Filter directly (the diagnostics log-level
+// endpoint) last until the stored settings change.
+type Controller struct {
+	filter     *Filter
+	base       zapcore.Level // LOG_LEVEL, used while no level is stored
+	configRepo repository.AppConfigRepository
+	logger     *zap.Logger
+
+	// mu serializes Set and Refresh. loaded is the last stored settings
+	// applied; pending is a change that could not be persisted and is
+	// retried on refresh.
+	mu      sync.Mutex
+	loaded  *Settings
+	pending bool
+}
+
+// NewController creates a controller for filter. The filter's current level
+// is the default while no level is stored.
+func NewController(filter *Filter, configRepo repository.AppConfigRepository, logger *zap.Logger) *Controller {
+	return &Controller{
+		filter:     filter,
+		base:       filter.Level().Level(),
+		configRepo: configRepo,
+		logger:     logger,
+	}
+}
+
+// Settings returns the settings in effect on this instance
+func (c *Controller) Settings() Settings {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.current()
+}
+
+// Set validates and applies settings, then persists them. If they cannot be
+// persisted they still apply here, the error is returned and persisting is
+// retried on refresh.
+func (c *Controller) Set(ctx context.Context, settings Settings, updatedBy string) (Settings, error) {
+	level, modules, err := parseSettings(settings)
+	if err != nil {
+		return Settings{}, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.apply(level, settings.Sampling, modules)
+	settings = c.current()
+	settings.UpdatedBy = updatedBy
+	settings.UpdatedAt = time.Now().UTC()
+	c.loaded = &settings
+
+	c.logger.Warn("log settings changed",
+		zap.String("level", settings.Level),
+		zap.Bool("sampling", settings.Sampling),
+		zap.String("modules", formatModules(settings.Modules)),
+		zap.String("updated_by", updatedBy),
+	)
+
+	if err := c.persist(ctx, settings); err != nil {
+		c.pending = true
+		return settings, err
+	}
+	c.pending = false
+	return settings, nil
+}
+
+// Refresh loads the stored settings and applies them if they changed since
+// the last refresh. Missing settings mean LOG_LEVEL without overrides or
+// sampling; invalid stored values are skipped.
+func (c *Controller) Refresh(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.pending {
+		if err := c.persist(ctx, *c.loaded); err != nil {
+			return err
+		}
+		c.pending = false
+		c.logger.Info("log settings change persisted")
+		return nil
+	}
+
+	stored := Settings{Level: c.base.String(), Modules: map[string]string{}}
+	config, err := c.configRepo.Get(ctx, Namespace, keyLevel, 0)
+	switch {
+	case errors.Is(err, repository.ErrAppConfigNotFound):
+	case err != nil:
+		return fmt.Errorf("loading log settings: %w", err)
+	default:
+		if config.ValueString != nil && *config.ValueString != "" {
+			stored.Level = *config.ValueString
+		}
+		stored.UpdatedAt = config.UpdatedAt
+		if config.UpdatedBy != nil {
+			stored.UpdatedBy = *config.UpdatedBy
+		}
+	}
+	if sampling, err := c.configRepo.GetBool(ctx, Namespace, keySampling, 0); err == nil {
+		stored.Sampling = sampling
+	}
+	if modules, err := c.configRepo.GetString(ctx, Namespace, keyModules, 0); err == nil {
+		stored.Modules = parseModules(modules)
+	}
+
+	if c.loaded != nil && sameSettings(*c.loaded, stored) {
+		return nil
+	}
+
+	level, modules, err := parseSettings(stored)
+	if err != nil {
+		c.logger.Warn("invalid stored log level, keeping the current one", zap.Error(err))
+		stored.Level = c.filter.Level().Level().String()
+		level, modules, _ = parseSettings(stored)
+	}
+	if c.loaded != nil {
+		c.logger.Warn("log settings changed",
+			zap.String("level", stored.Level),
+			zap.Bool("sampling", stored.Sampling),
+			zap.String("modules", formatModules(stored.Modules)),
+			zap.String("updated_by", stored.UpdatedBy),
+		)
+	}
+	c.apply(level, stored.Sampling, modules)
+	c.loaded = &stored
+	return nil
+}
+
+// Run refreshes the settings every interval (DefaultRefreshInterval if zero)
+// until ctx is cancelled
+func (c *Controller) Run(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultRefreshInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := c.Refresh(ctx); err != nil && ctx.Err() == nil {
+			c.logger.Warn("log settings refresh failed", zap.Error(err))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// current reads the settings in effect from the filter
+func (c *Controller) current() Settings {
+	settings := Settings{
+		Level:    c.filter.Level().Level().String(),
+		Sampling: c.filter.Sampling(),
+		Modules:  map[string]string{},
+	}
+	for module, level := range c.filter.ModuleLevels() {
+		settings.Modules[module] = level.String()
+	}
+	if c.loaded != nil {
+		settings.UpdatedBy = c.loaded.UpdatedBy
+		settings.UpdatedAt = c.loaded.UpdatedAt
+	}
+	return settings
+}
+
+// apply sets the filter
+func (c *Controller) apply(level zapcore.Level, sampling bool, modules map[string]zapcore.Level) {
+	c.filter.Level().SetLevel(level)
+	c.filter.SetSampling(sampling)
+	c.filter.SetModuleLevels(modules)
+}
+
+// persist stores settings in app_config, creating the settings if they were
+// never seeded
+func (c *Controller) persist(ctx context.Context, settings Settings) error {
+	modules := formatModules(settings.Modules)
+	if err := c.upsert(ctx, keyLevel, "string", "Global log level (debug, info, warn, error)", &repository.AppConfigUpdate{
+		ValueString: &settings.Level,
+		UpdatedBy:   settings.UpdatedBy,
+	}); err != nil {
+		return fmt.Errorf("persisting log level: %w", err)
+	}
+	if err := c.upsert(ctx, keySampling, "boolean", "Sample repeated log entries", &repository.AppConfigUpdate{
+		ValueBoolean: &settings.Sampling,
+		UpdatedBy:    settings.UpdatedBy,
+	}); err != nil {
+		return fmt.Errorf("persisting log sampling: %w", err)
+	}
+	if err := c.upsert(ctx, keyModules, "string", "Per-module log levels (module=level, comma separated)", &repository.AppConfigUpdate{
+		ValueString: &modules,
+		UpdatedBy:   settings.UpdatedBy,
+	}); err != nil {
+		return fmt.Errorf("persisting module log levels: %w", err)
+	}
+	return nil
+}
+
+// upsert updates a logging setting or creates it
+func (c *Controller) upsert(ctx context.Context, key, valueType, description string, update *repository.AppConfigUpdate) error {
+	err := c.configRepo.Update(ctx, Namespace, key, 0, update)
+	if !errors.Is(err, repository.ErrAppConfigNotFound) {
+		return err
+	}
+	return c.configRepo.Create(ctx, &repository.AppConfigCreate{
+		Namespace:    Namespace,
+		ConfigKey:    key,
+		ValueType:    valueType,
+		ValueString:  update.ValueString,
+		ValueBoolean: update.ValueBoolean,
+		Description:  description,
+		UpdatedBy:    update.UpdatedBy,
+	})
+}
+
+// parseSettings validates the level and module overrides
+func parseSettings(settings Settings) (zapcore.Level, map[string]zapcore.Level, error) {
+	level, err := ParseLevel(settings.Level)
+	if err != nil {
+		return 0, nil, err
+	}
+	modules := make(map[string]zapcore.Level, len(settings.Modules))
+	for module, value := range settings.Modules {
+		if !slices.Contains(Modules, module) {
+			return 0, nil, fmt.Errorf("%w %q (expected one of %s)", ErrUnknownModule, module, strings.Join(Modules, ", "))
+		}
+		moduleLevel, err := ParseLevel(value)
+		if err != nil {
+			return 0, nil, fmt.Errorf("module %s: %w", module, err)
+		}
+		modules[module] = moduleLevel
+	}
+	return level, modules, nil
+}
+
+// parseModules reads "module=level" pairs, skipping malformed entries and
+// unknown modules
+func parseModules(s string) map[string]string {
+	modules := map[string]string{}
+	for _, pair := range strings.Split(s, ",") {
+		module, level, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		module = strings.TrimSpace(module)
+		if !ok || !slices.Contains(Modules, module) {
+			continue
+		}
+		if _, err := ParseLevel(level); err != nil {
+			continue
+		}
+		modules[module] = strings.TrimSpace(level)
+	}
+	return modules
+}
+
+// formatModules writes "module=level" pairs in module order
+func formatModules(modules map[string]string) string {
+	pairs := make([]string, 0, len(modules))
+	for module, level := range modules {
+		pairs = append(pairs, module+"="+level)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+// sameSettings compares the values of two settings, ignoring who changed them
+func sameSettings(a, b Settings) bool {
+	return a.Level == b.Level && a.Sampling == b.Sampling && formatModules(a.Modules) == formatModules(b.Modules)
+}
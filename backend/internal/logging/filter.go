@@ -0,0 +1,143 @@
+// Package logging controls the server's log output at runtime: the global
+// level, per-module level overrides and sampling. Filter applies them to the
+// zap core; Controller stores them in app_config (namespace "logging") so
+// every instance follows a change.
+package logging
+
+import (
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Sampling keeps the first sampleFirst entries with the same level and
+// message each second, then every sampleThereafter-th
+const (
+	sampleTick       = time.Second
+	sampleFirst      = 100
+	sampleThereafter = 100
+)
+
+// Modules accept level overrides. Their loggers are named after them
+// (logger.Named("relayer")), and child loggers ("relayer.queue") inherit the
+// override.
+var Modules = []string{"kyc", "payments", "relayer"}
+
+// Filter decides which entries reach the wrapped core. The wrapped core must
+// accept every level; Filter does the level checks.
+type Filter struct {
+	level    zap.AtomicLevel
+	sampling atomic.Bool
+	modules  atomic.Pointer[map[string]zapcore.Level]
+}
+
+// NewFilter creates a filter logging at level, without overrides or sampling
+func NewFilter(level zapcore.Level) *Filter {
+	f := &Filter{level: zap.NewAtomicLevelAt(level)}
+	f.modules.Store(&map[string]zapcore.Level{})
+	return f
+}
+
+// Level returns the global level. Changing it takes effect immediately.
+func (f *Filter) Level() zap.AtomicLevel {
+	return f.level
+}
+
+// Sampling reports whether sampling is on
+func (f *Filter) Sampling() bool {
+	return f.sampling.Load()
+}
+
+// SetSampling turns sampling on or off
+func (f *Filter) SetSampling(enabled bool) {
+	f.sampling.Store(enabled)
+}
+
+// ModuleLevels returns the per-module level overrides
+func (f *Filter) ModuleLevels() map[string]zapcore.Level {
+	current := *f.modules.Load()
+	levels := make(map[string]zapcore.Level, len(current))
+	for module, level := range current {
+		levels[module] = level
+	}
+	return levels
+}
+
+// SetModuleLevels replaces the per-module level overrides
+func (f *Filter) SetModuleLevels(levels map[string]zapcore.Level) {
+	copied := make(map[string]zapcore.Level, len(levels))
+	for module, level := range levels {
+		copied[module] = level
+	}
+	f.modules.Store(&copied)
+}
+
+// Wrap returns core filtered by f, for zap.WrapCore
+func (f *Filter) Wrap(core zapcore.Core) zapcore.Core {
+	return &filterCore{
+		filter:  f,
+		core:    core,
+		sampled: zapcore.NewSamplerWithOptions(core, sampleTick, sampleFirst, sampleThereafter),
+	}
+}
+
+// levelFor returns the level for entries of the named logger
+func (f *Filter) levelFor(loggerName string) zapcore.Level {
+	module, _, _ := strings.Cut(loggerName, ".")
+	if level, ok := (*f.modules.Load())[module]; ok {
+		return level
+	}
+	return f.level.Level()
+}
+
+// minLevel is the lowest level any logger may write at
+func (f *Filter) minLevel() zapcore.Level {
+	lowest := f.level.Level()
+	for _, level := range *f.modules.Load() {
+		if level < lowest {
+			lowest = level
+		}
+	}
+	return lowest
+}
+
+// filterCore applies a Filter to a core. sampled wraps the same core and
+// shares its counters across With.
+type filterCore struct {
+	filter  *Filter
+	core    zapcore.Core
+	sampled zapcore.Core
+}
+
+func (c *filterCore) Enabled(level zapcore.Level) bool {
+	return level >= c.filter.minLevel()
+}
+
+func (c *filterCore) With(fields []zapcore.Field) zapcore.Core {
+	return &filterCore{
+		filter:  c.filter,
+		core:    c.core.With(fields),
+		sampled: c.sampled.With(fields),
+	}
+}
+
+func (c *filterCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if entry.Level < c.filter.levelFor(entry.LoggerName) {
+		return checked
+	}
+	if c.filter.Sampling() {
+		return c.sampled.Check(entry, checked)
+	}
+	return c.core.Check(entry, checked)
+}
+
+func (c *filterCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	return c.core.Write(entry, fields)
+}
+
+func (c *filterCore) Sync() error {
+	return c.core.Sync()
+}
@@ -0,0 +1,131 @@
+package logging_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/logging"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/storage/memory"
+)
+
+// newLogger returns a logger filtered by f and the entries it wrote
+func newLogger(f *logging.Filter) (*zap.Logger, *observer.ObservedLogs) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	return zap.New(f.Wrap(core)), logs
+}
+
+func TestFilter_ModuleLevels(t *testing.T) {
+	f := logging.NewFilter(zapcore.InfoLevel)
+	logger, logs := newLogger(f)
+	relayer := logger.Named("relayer")
+	queue := relayer.Named("queue")
+	kyc := logger.Named("kyc")
+
+	logger.Debug("dropped")
+	relayer.Debug("dropped")
+	kyc.Info("kept")
+	assert.Equal(t, 1, logs.Len())
+
+	f.SetModuleLevels(map[string]zapcore.Level{"relayer": zapcore.DebugLevel, "kyc": zapcore.ErrorLevel})
+	relayer.Debug("kept")
+	queue.With(zap.String("id", "1")).Debug("kept", zap.Int("n", 1))
+	logger.Debug("dropped")
+	kyc.Warn("dropped")
+	kyc.Error("kept")
+	assert.Equal(t, 4, logs.Len())
+	assert.Equal(t, "relayer.queue", logs.All()[2].LoggerName)
+
+	f.Level().SetLevel(zapcore.WarnLevel)
+	logger.Info("dropped")
+	relayer.Debug("kept")
+	assert.Equal(t, 5, logs.Len())
+}
+
+func TestFilter_Sampling(t *testing.T) {
+	f := logging.NewFilter(zapcore.InfoLevel)
+	logger, logs := newLogger(f)
+
+	for i := 0; i < 300; i++ {
+		logger.Info("repeated")
+	}
+	assert.Equal(t, 300, logs.Len())
+
+	f.SetSampling(true)
+	for i := 0; i < 300; i++ {
+		logger.Info("sampled")
+	}
+	sampled := logs.FilterMessage("sampled").Len()
+	assert.Less(t, sampled, 300)
+	assert.GreaterOrEqual(t, sampled, 100, "the first entries each second are kept")
+}
+
+func TestController_SetIsSharedThroughConfig(t *testing.T) {
+	ctx := context.Background()
+	configRepo := memory.NewMemoryAppConfigRepo()
+	fa := logging.NewFilter(zapcore.InfoLevel)
+	fb := logging.NewFilter(zapcore.InfoLevel)
+	a := logging.NewController(fa, configRepo, zap.NewNop())
+	b := logging.NewController(fb, configRepo, zap.NewNop())
+
+	// Nothing stored: LOG_LEVEL, no overrides or sampling
+	require.NoError(t, b.Refresh(ctx))
+	settings := b.Settings()
+	assert.Equal(t, "info", settings.Level)
+	assert.False(t, settings.Sampling)
+	assert.Empty(t, settings.Modules)
+
+	settings, err := a.Set(ctx, logging.Settings{
+		Level:    "warn",
+		Sampling: true,
+		Modules:  map[string]string{"relayer": "debug", "payments": "ERROR"},
+	}, "ops@nexus")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"relayer": "debug", "payments": "error"}, settings.Modules)
+	assert.Equal(t, zapcore.WarnLevel, fa.Level().Level())
+
+	require.NoError(t, b.Refresh(ctx))
+	assert.Equal(t, zapcore.WarnLevel, fb.Level().Level())
+	assert.True(t, fb.Sampling())
+	assert.Equal(t, map[string]zapcore.Level{"relayer": zapcore.DebugLevel, "payments": zapcore.ErrorLevel}, fb.ModuleLevels())
+	assert.Equal(t, "ops@nexus", b.Settings().UpdatedBy)
+
+	// A local change (diagnostics endpoint) lasts until the stored settings change
+	fb.Level().SetLevel(zapcore.DebugLevel)
+	require.NoError(t, b.Refresh(ctx))
+	assert.Equal(t, zapcore.DebugLevel, fb.Level().Level())
+
+	_, err = a.Set(ctx, logging.Settings{Level: "info"}, "ops@nexus")
+	require.NoError(t, err)
+	require.NoError(t, b.Refresh(ctx))
+	assert.Equal(t, zapcore.InfoLevel, fb.Level().Level())
+	assert.False(t, fb.Sampling())
+	assert.Empty(t, fb.ModuleLevels())
+}
+
+func TestController_SetValidates(t *testing.T) {
+	ctx := context.Background()
+	f := logging.NewFilter(zapcore.InfoLevel)
+	c := logging.NewController(f, memory.NewMemoryAppConfigRepo(), zap.NewNop())
+
+	for _, settings := range []logging.Settings{
+		{Level: "verbose"},
+		{Level: "fatal"},
+		{Level: "info", Modules: map[string]string{"ledger": "debug"}},
+		{Level: "info", Modules: map[string]string{"kyc": "trace"}},
+	} {
+		_, err := c.Set(ctx, settings, "ops@nexus")
+		assert.Error(t, err, fmt.Sprint(settings))
+	}
+	_, err := c.Set(ctx, logging.Settings{Level: "info", Modules: map[string]string{"ledger": "debug"}}, "ops@nexus")
+	assert.ErrorIs(t, err, logging.ErrUnknownModule)
+	_, err = c.Set(ctx, logging.Settings{Level: "loud"}, "ops@nexus")
+	assert.ErrorIs(t, err, logging.ErrInvalidLevel)
+	assert.Equal(t, zapcore.InfoLevel, f.Level().Level())
+}
@@ -0,0 +1,231 @@
+// Package maintenance implements the runtime maintenance switch. The flag is
+// stored in app_config (namespace "maintenance") so every instance follows
+// it, and cached in memory so the per-request check never touches the
+// database. The same Switch backs the relay pause (namespace "relay_pause").
+package maintenance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Defaults
+const (
+	DefaultMessage         = "The service is down for scheduled maintenance. Please try again shortly."
+	DefaultRefreshInterval = 5 * time.Second
+
+	keyEnabled = "enabled"
+	keyMessage = "message"
+)
+
+// Config names the app_config settings a Switch is stored in
+type Config struct {
+	Namespace          string // holds the global "enabled" and "message" settings
+	Name               string // used in log messages
+	DefaultMessage     string
+	EnabledDescription string
+	MessageDescription string
+}
+
+// Maintenance is the maintenance mode switch: 503 for non-admin API routes
+var Maintenance = Config{
+	Namespace:          "maintenance",
+	Name:               "maintenance mode",
+	DefaultMessage:     DefaultMessage,
+	EnabledDescription: "Return 503 for non-admin API routes",
+	MessageDescription: "Message returned while in maintenance mode",
+}
+
+// RelayPause stops accepting meta-transaction relays; the message is the
+// reason returned with the 503
+var RelayPause = Config{
+	Namespace:          "relay_pause",
+	Name:               "relay pause",
+	DefaultMessage:     "Relaying is paused. Please try again later.",
+	EnabledDescription: "Return 503 for new meta-transaction relays",
+	MessageDescription: "Reason returned while relaying is paused",
+}
+
+// State is the current setting of a switch
+type State struct {
+	Enabled   bool      `json:"enabled"`
+	Message   string    `json:"message"`
+	UpdatedBy string    `json:"updated_by,omitempty"`
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+}
+
+// Switch holds the state of one switch on this instance. Set persists a change
+// for all instances; Run picks up changes made elsewhere.
+type Switch struct {
+	configRepo repository.AppConfigRepository
+	logger     *zap.Logger
+	cfg        Config
+
+	state atomic.Pointer[State]
+
+	// mu serializes Set and Refresh. pending is a local change that could
+	// not be persisted (e.g. the database is down during an incident); it is
+	// retried on refresh instead of being overwritten by the stored value.
+	mu      sync.Mutex
+	pending bool
+}
+
+// NewSwitch creates the maintenance switch, initially disabled until the
+// first Refresh
+func NewSwitch(configRepo repository.AppConfigRepository, logger *zap.Logger) *Switch {
+	return NewSwitchWithConfig(configRepo, logger, Maintenance)
+}
+
+// NewSwitchWithConfig creates a switch stored in cfg's settings, initially
+// disabled until the first Refresh
+func NewSwitchWithConfig(configRepo repository.AppConfigRepository, logger *zap.Logger, cfg Config) *Switch {
+	s := &Switch{
+		configRepo: configRepo,
+		logger:     logger,
+		cfg:        cfg,
+	}
+	s.state.Store(&State{Message: cfg.DefaultMessage})
+	return s
+}
+
+// State returns the current state
+func (s *Switch) State() State {
+	return *s.state.Load()
+}
+
+// Enabled reports whether the switch is on
+func (s *Switch) Enabled() bool {
+	return s.state.Load().Enabled
+}
+
+// Set turns the switch on or off. An empty message keeps the current
+// one. The change applies to this instance immediately; if it cannot be
+// persisted the error is returned and persisting is retried on refresh.
+func (s *Switch) Set(ctx context.Context, enabled bool, message, updatedBy string) (State, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state := s.State()
+	state.Enabled = enabled
+	if message != "" {
+		state.Message = message
+	}
+	state.UpdatedBy = updatedBy
+	state.UpdatedAt = time.Now().UTC()
+	s.state.Store(&state)
+
+	s.logger.Warn(s.cfg.Name+" changed",
+		zap.Bool("enabled", enabled),
+		zap.String("updated_by", updatedBy),
+	)
+
+	if err := s.persist(ctx, state); err != nil {
+		s.pending = true
+		return state, err
+	}
+	s.pending = false
+	return state, nil
+}
+
+// Refresh loads the stored state. A missing setting means disabled; on a
+// read error the current state is kept.
+func (s *Switch) Refresh(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.pending {
+		if err := s.persist(ctx, s.State()); err != nil {
+			return err
+		}
+		s.pending = false
+		s.logger.Info(s.cfg.Name + " change persisted")
+		return nil
+	}
+
+	state := State{Message: s.cfg.DefaultMessage}
+	config, err := s.configRepo.Get(ctx, s.cfg.Namespace, keyEnabled, 0)
+	switch {
+	case errors.Is(err, repository.ErrAppConfigNotFound):
+	case err != nil:
+		return fmt.Errorf("loading %s state: %w", s.cfg.Name, err)
+	default:
+		state.Enabled = config.GetBoolValue()
+		state.UpdatedAt = config.UpdatedAt
+		if config.UpdatedBy != nil {
+			state.UpdatedBy = *config.UpdatedBy
+		}
+	}
+	if message, err := s.configRepo.GetString(ctx, s.cfg.Namespace, keyMessage, 0); err == nil && message != "" {
+		state.Message = message
+	}
+
+	if previous := s.State(); previous.Enabled != state.Enabled {
+		s.logger.Warn(s.cfg.Name+" changed", zap.Bool("enabled", state.Enabled), zap.String("updated_by", state.UpdatedBy))
+	}
+	s.state.Store(&state)
+	return nil
+}
+
+// Run refreshes the state every interval (DefaultRefreshInterval if zero)
+// until ctx is cancelled
+func (s *Switch) Run(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultRefreshInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.Refresh(ctx); err != nil && ctx.Err() == nil {
+			s.logger.Warn(s.cfg.Name+" state refresh failed", zap.Error(err))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// persist stores state in app_config, creating the settings if they were
+// never seeded
+func (s *Switch) persist(ctx context.Context, state State) error {
+	if err := s.upsert(ctx, keyEnabled, "boolean", s.cfg.EnabledDescription, &repository.AppConfigUpdate{
+		ValueBoolean: &state.Enabled,
+		UpdatedBy:    state.UpdatedBy,
+	}); err != nil {
+		return fmt.Errorf("persisting %s state: %w", s.cfg.Name, err)
+	}
+	if err := s.upsert(ctx, keyMessage, "string", s.cfg.MessageDescription, &repository.AppConfigUpdate{
+		ValueString: &state.Message,
+		UpdatedBy:   state.UpdatedBy,
+	}); err != nil {
+		return fmt.Errorf("persisting %s message: %w", s.cfg.Name, err)
+	}
+	return nil
+}
+
+// upsert updates a global setting of the switch or creates it
+func (s *Switch) upsert(ctx context.Context, key, valueType, description string, update *repository.AppConfigUpdate) error {
+	err := s.configRepo.Update(ctx, s.cfg.Namespace, key, 0, update)
+	if !errors.Is(err, repository.ErrAppConfigNotFound) {
+		return err
+	}
+	return s.configRepo.Create(ctx, &repository.AppConfigCreate{
+		Namespace:    s.cfg.Namespace,
+		ConfigKey:    key,
+		ValueType:    valueType,
+		ValueString:  update.ValueString,
+		ValueBoolean: update.ValueBoolean,
+		Description:  description,
+		UpdatedBy:    update.UpdatedBy,
+	})
+}
@@ -0,0 +1,109 @@
+package maintenance_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/maintenance"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/storage/memory"
+)
+
+// flakyConfigRepo fails every call while down
+type flakyConfigRepo struct {
+	repository.AppConfigRepository
+	down bool
+}
+
+var errDown = errors.New("database unavailable")
+
+func (r *flakyConfigRepo) Get(ctx context.Context, namespace, key string, chainID int64) (*repository.AppConfig, error) {
+	if r.down {
+		return nil, errDown
+	}
+	return r.AppConfigRepository.Get(ctx, namespace, key, chainID)
+}
+
+func (r *flakyConfigRepo) Update(ctx context.Context, namespace, key string, chainID int64, update *repository.AppConfigUpdate) error {
+	if r.down {
+		return errDown
+	}
+	return r.AppConfigRepository.Update(ctx, namespace, key, chainID, update)
+}
+
+func TestSwitch_SetIsSharedThroughConfig(t *testing.T) {
+	ctx := context.Background()
+	configRepo := memory.NewMemoryAppConfigRepo()
+	a := maintenance.NewSwitch(configRepo, zap.NewNop())
+	b := maintenance.NewSwitch(configRepo, zap.NewNop())
+
+	// Never configured: disabled with the default message
+	require.NoError(t, b.Refresh(ctx))
+	assert.False(t, b.Enabled())
+	assert.Equal(t, maintenance.DefaultMessage, b.State().Message)
+
+	state, err := a.Set(ctx, true, "Upgrading the database, back at 14:00 UTC", "ops@nexus")
+	require.NoError(t, err)
+	assert.True(t, state.Enabled)
+	assert.True(t, a.Enabled())
+
+	require.NoError(t, b.Refresh(ctx))
+	assert.True(t, b.Enabled())
+	assert.Equal(t, "Upgrading the database, back at 14:00 UTC", b.State().Message)
+	assert.Equal(t, "ops@nexus", b.State().UpdatedBy)
+
+	// An empty message keeps the current one
+	_, err = a.Set(ctx, false, "", "ops@nexus")
+	require.NoError(t, err)
+	require.NoError(t, b.Refresh(ctx))
+	assert.False(t, b.Enabled())
+	assert.Equal(t, "Upgrading the database, back at 14:00 UTC", b.State().Message)
+}
+
+func TestSwitch_UnpersistedChangeIsRetried(t *testing.T) {
+	ctx := context.Background()
+	configRepo := &flakyConfigRepo{AppConfigRepository: memory.NewMemoryAppConfigRepo()}
+	sw := maintenance.NewSwitch(configRepo, zap.NewNop())
+	_, err := sw.Set(ctx, false, "", "ops@nexus")
+	require.NoError(t, err)
+
+	// The database is down: the switch still flips on this instance
+	configRepo.down = true
+	_, err = sw.Set(ctx, true, "", "ops@nexus")
+	require.ErrorIs(t, err, errDown)
+	assert.True(t, sw.Enabled())
+	assert.Error(t, sw.Refresh(ctx))
+	assert.True(t, sw.Enabled())
+
+	// Back up: the stored "disabled" does not undo the change, it is persisted
+	configRepo.down = false
+	require.NoError(t, sw.Refresh(ctx))
+	assert.True(t, sw.Enabled())
+
+	enabled, err := configRepo.GetBool(ctx, "maintenance", "enabled", 0)
+	require.NoError(t, err)
+	assert.True(t, enabled)
+}
+
+func TestSwitch_ConfigsAreIndependent(t *testing.T) {
+	ctx := context.Background()
+	configRepo := memory.NewMemoryAppConfigRepo()
+	sw := maintenance.NewSwitch(configRepo, zap.NewNop())
+	pause := maintenance.NewSwitchWithConfig(configRepo, zap.NewNop(), maintenance.RelayPause)
+
+	_, err := pause.Set(ctx, true, "", "ops@nexus")
+	require.NoError(t, err)
+	assert.Equal(t, maintenance.RelayPause.DefaultMessage, pause.State().Message)
+
+	require.NoError(t, sw.Refresh(ctx))
+	assert.False(t, sw.Enabled())
+
+	config, err := configRepo.Get(ctx, "relay_pause", "enabled", 0)
+	require.NoError(t, err)
+	assert.True(t, config.GetBoolValue())
+}
@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AdminAuth requires "Authorization: Bearer <token>" matching the configured
+// admin API token. With no token configured, admin routes are disabled.
+func AdminAuth(token string) gin.HandlerFunc {
+	return AdminAuthFunc(func() string { return token })
+}
+
+// AdminAuthFunc is AdminAuth with the token read on every request, so a
+// rotated token applies without a restart
+func AdminAuthFunc(currentToken func() string) gin.HandlerFunc {
+	return bearerAuth(currentToken, "Admin API is not configured")
+}
+
+// DeployAuthFunc guards the deployment registration and ABI upload endpoints
+// with "Authorization: Bearer <token>" matching the current deploy token.
+// With no token configured, the endpoints are disabled.
+func DeployAuthFunc(currentToken func() string) gin.HandlerFunc {
+	return bearerAuth(currentToken, "Deployment registration is not configured")
+}
+
+// bearerAuth requires the current token as a bearer token, answering 503
+// with notConfigured while no token is set
+func bearerAuth(currentToken func() string, notConfigured string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token := currentToken()
+		if token == "" {
+			c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{
+				"success": false,
+				"error":   notConfigured,
+			})
+			return
+		}
+
+		provided := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"success": false,
+				"error":   "Unauthorized",
+			})
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,168 @@
+package middleware
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Request body limits (overridable via BODY_LIMIT_BYTES,
+// RELAY_BODY_LIMIT_BYTES, WEBHOOK_BODY_LIMIT_BYTES, DEPLOY_BODY_LIMIT_BYTES
+// and UPLOAD_BODY_LIMIT_BYTES). Deployment artifacts carry every contract's
+// init code, so their limit is the largest. Uploads leave room for a 10 MB
+// document plus its multipart framing.
+const (
+	DefaultBodyLimit        = 1 << 20
+	DefaultRelayBodyLimit   = 128 << 10
+	DefaultWebhookBodyLimit = 512 << 10
+	DefaultDeployBodyLimit  = 16 << 20
+	DefaultUploadBodyLimit  = 11 << 20
+)
+
+// BodyLimit caps the request body at maxBytes and answers 413 Request Entity
+// Too Large when a handler reads past it. The body is not buffered: handlers
+// binding JSON or reading a webhook payload consume it as a stream and stop
+// at the limit, so an oversized or endless chunked body costs at most
+// maxBytes of memory. A declared Content-Length over the limit fails on the
+// first read.
+//
+// BodyLimit may be set on a group and again on a route: the innermost limit
+// applies, larger or smaller.
+func BodyLimit(maxBytes int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		req := c.Request
+		if req.Body == nil || req.Body == http.NoBody {
+			c.Next()
+			return
+		}
+
+		// An outer limit has not been read from yet; replace it
+		if body, ok := req.Body.(*limitedBody); ok {
+			body.setLimit(maxBytes)
+			c.Next()
+			return
+		}
+
+		body := &limitedBody{original: req.Body, contentLength: req.ContentLength}
+		body.setLimit(maxBytes)
+		req.Body = body
+		c.Writer = &bodyLimitWriter{ResponseWriter: c.Writer, body: body}
+		c.Next()
+	}
+}
+
+// limitedBody reads the request body up to the limit and records whether the
+// limit was hit
+type limitedBody struct {
+	original      io.ReadCloser
+	reader        *io.LimitedReader
+	contentLength int64
+	maxBytes      int64
+	started       bool
+	exceeded      bool
+}
+
+func (b *limitedBody) setLimit(maxBytes int64) {
+	b.maxBytes = maxBytes
+	// One extra byte distinguishes "exactly the limit" from "over it"
+	b.reader = &io.LimitedReader{R: b.original, N: maxBytes + 1}
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.exceeded {
+		return 0, b.tooLarge()
+	}
+	if !b.started {
+		b.started = true
+		if b.contentLength > b.maxBytes {
+			b.exceeded = true
+			return 0, b.tooLarge()
+		}
+	}
+
+	n, err := b.reader.Read(p)
+	if b.reader.N == 0 {
+		b.exceeded = true
+		if n > 0 {
+			n--
+		}
+		return n, b.tooLarge()
+	}
+	return n, err
+}
+
+func (b *limitedBody) Close() error {
+	return b.original.Close()
+}
+
+func (b *limitedBody) tooLarge() error {
+	return &http.MaxBytesError{Limit: b.maxBytes}
+}
+
+// bodyLimitWriter replaces the handler's response with 413 once the body
+// limit was hit. Handlers see the read error and answer with their own
+// (usually 400) error, which is discarded.
+type bodyLimitWriter struct {
+	gin.ResponseWriter
+	body     *limitedBody
+	rejected bool
+}
+
+func (w *bodyLimitWriter) WriteHeader(code int) {
+	if w.reject() {
+		return
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *bodyLimitWriter) WriteHeaderNow() {
+	if w.reject() {
+		return
+	}
+	w.ResponseWriter.WriteHeaderNow()
+}
+
+func (w *bodyLimitWriter) Write(data []byte) (int, error) {
+	if w.reject() {
+		return len(data), nil
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *bodyLimitWriter) WriteString(s string) (int, error) {
+	if w.reject() {
+		return len(s), nil
+	}
+	return w.ResponseWriter.WriteString(s)
+}
+
+// reject writes the 413 response the first time it is called after the limit
+// was hit and reports whether the handler's output must be dropped
+func (w *bodyLimitWriter) reject() bool {
+	if !w.body.exceeded {
+		return false
+	}
+	if !w.rejected {
+		w.rejected = true
+		writeBodyTooLarge(w.ResponseWriter, w.body.maxBytes)
+	}
+	return true
+}
+
+// writeBodyTooLarge writes the 413 response
+func writeBodyTooLarge(w gin.ResponseWriter, maxBytes int64) {
+	body, _ := json.Marshal(gin.H{
+		"success": false,
+		"error":   fmt.Sprintf("Request body too large (limit %d bytes)", maxBytes),
+	})
+	header := w.Header()
+	header.Del("Content-Length")
+	header.Set("Content-Type", "application/json; charset=utf-8")
+	// The rest of the body is not read; don't reuse the connection
+	header.Set("Connection", "close")
+	w.WriteHeader(http.StatusRequestEntityTooLarge)
+	_, _ = w.Write(body)
+}
@@ -0,0 +1,83 @@
+package middleware_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/middleware"
+)
+
+func setupBodyLimitRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	api := router.Group("/api", middleware.BodyLimit(64))
+
+	// JSON binding, as most handlers read their body
+	api.POST("/relay", func(c *gin.Context) {
+		var req struct {
+			Data string `json:"data"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": "Invalid request"})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"success": true, "length": len(req.Data)})
+	})
+
+	// Raw reads with a route limit above the group's, as the webhook handlers
+	readAll := func(c *gin.Context) {
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": "Failed to read request body"})
+			return
+		}
+		c.String(http.StatusOK, "%d", len(body))
+	}
+	api.POST("/webhook", middleware.BodyLimit(256), readAll)
+	return router
+}
+
+func TestBodyLimit(t *testing.T) {
+	router := setupBodyLimitRouter()
+
+	tests := []struct {
+		name    string
+		path    string
+		body    string
+		chunked bool
+		status  int
+	}{
+		{name: "json under limit", path: "/api/relay", body: `{"data":"0x1234"}`, status: http.StatusOK},
+		{name: "json over limit", path: "/api/relay", body: `{"data":"` + strings.Repeat("a", 100) + `"}`, status: http.StatusRequestEntityTooLarge},
+		{name: "chunked over limit", path: "/api/relay", body: `{"data":"` + strings.Repeat("a", 100) + `"}`, chunked: true, status: http.StatusRequestEntityTooLarge},
+		{name: "exactly the route limit", path: "/api/webhook", body: strings.Repeat("a", 256), status: http.StatusOK},
+		{name: "route limit raises group limit", path: "/api/webhook", body: strings.Repeat("a", 200), chunked: true, status: http.StatusOK},
+		{name: "over route limit", path: "/api/webhook", body: strings.Repeat("a", 257), chunked: true, status: http.StatusRequestEntityTooLarge},
+		{name: "declared length over route limit", path: "/api/webhook", body: strings.Repeat("a", 300), status: http.StatusRequestEntityTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(tt.body))
+			if tt.chunked {
+				req.ContentLength = -1
+			}
+			resp := httptest.NewRecorder()
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, tt.status, resp.Code)
+			if tt.status == http.StatusRequestEntityTooLarge {
+				assert.Contains(t, resp.Body.String(), `"success":false`)
+				assert.Contains(t, resp.Body.String(), "Request body too large")
+				assert.NotContains(t, resp.Body.String(), "Invalid request")
+				assert.NotContains(t, resp.Body.String(), "Failed to read")
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/breaker"
+)
+
+// RejectWhenOpen answers 503 with Retry-After while any of the breakers is
+// open, so requests fail fast instead of queueing on a dependency that is
+// known to be down. Once the cooldown elapses requests pass through again
+// and the first one acts as the breaker's trial call.
+func RejectWhenOpen(breakers ...*breaker.Breaker) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		for _, b := range breakers {
+			if remaining, open := b.OpenFor(); open {
+				setRetryAfter(c, remaining)
+				c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{
+					"success": false,
+					"error":   "Service temporarily unavailable",
+				})
+				return
+			}
+		}
+		c.Next()
+	}
+}
+
+// setRetryAfter sets the Retry-After header in whole seconds (at least 1)
+func setRetryAfter(c *gin.Context, d time.Duration) {
+	seconds := int(math.Ceil(d.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+	c.Header("Retry-After", strconv.Itoa(seconds))
+}
@@ -0,0 +1,41 @@
+package middleware_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/breaker"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/middleware"
+)
+
+func TestRejectWhenOpen(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	b := breaker.New("database", 1, 30*time.Second)
+
+	router := gin.New()
+	router.Use(middleware.RejectWhenOpen(b))
+	router.GET("/api/v1/pricing", func(c *gin.Context) { c.String(http.StatusOK, "ok") })
+
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/api/v1/pricing", nil))
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	_ = b.Do(func() error { return errors.New("connection refused") }, nil)
+
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/api/v1/pricing", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, resp.Code)
+
+	retryAfter, err := strconv.Atoi(resp.Header().Get("Retry-After"))
+	require.NoError(t, err)
+	assert.InDelta(t, 30, retryAfter, 1)
+	assert.Contains(t, resp.Body.String(), `"success":false`)
+}
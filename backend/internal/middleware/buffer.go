@@ -0,0 +1,115 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxBufferedBody bounds how much of a response the rewriting middleware
+// (ETag, SparseFields) buffers. Larger responses are streamed unchanged.
+const maxBufferedBody = 8 << 20
+
+// bufferWriter holds back a response so middleware can inspect or rewrite it
+// after the handler returns
+type bufferWriter struct {
+	gin.ResponseWriter
+	status      int
+	wroteHeader bool
+	body        []byte
+
+	// passthrough is set once the response is streamed directly (flushed or
+	// too large to buffer)
+	passthrough bool
+}
+
+func newBufferWriter(w gin.ResponseWriter) *bufferWriter {
+	return &bufferWriter{ResponseWriter: w, status: http.StatusOK}
+}
+
+func (w *bufferWriter) WriteHeader(code int) {
+	if w.passthrough {
+		w.ResponseWriter.WriteHeader(code)
+		return
+	}
+	w.status = code
+	w.wroteHeader = true
+}
+
+func (w *bufferWriter) WriteHeaderNow() {
+	if w.passthrough {
+		w.ResponseWriter.WriteHeaderNow()
+		return
+	}
+	w.wroteHeader = true
+}
+
+func (w *bufferWriter) Status() int {
+	if w.passthrough {
+		return w.ResponseWriter.Status()
+	}
+	return w.status
+}
+
+func (w *bufferWriter) Size() int {
+	if w.passthrough {
+		return w.ResponseWriter.Size()
+	}
+	if !w.wroteHeader && len(w.body) == 0 {
+		return -1
+	}
+	return len(w.body)
+}
+
+func (w *bufferWriter) Written() bool {
+	if w.passthrough {
+		return w.ResponseWriter.Written()
+	}
+	return w.wroteHeader || len(w.body) > 0
+}
+
+func (w *bufferWriter) Write(b []byte) (int, error) {
+	if w.passthrough {
+		return w.ResponseWriter.Write(b)
+	}
+	w.wroteHeader = true
+	if len(w.body)+len(b) > maxBufferedBody {
+		w.stream()
+		return w.ResponseWriter.Write(b)
+	}
+	w.body = append(w.body, b...)
+	return len(b), nil
+}
+
+func (w *bufferWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+func (w *bufferWriter) Flush() {
+	w.stream()
+	w.ResponseWriter.Flush()
+}
+
+// stream stops buffering and sends what is held so far
+func (w *bufferWriter) stream() {
+	if w.passthrough {
+		return
+	}
+	w.passthrough = true
+	w.send(w.body)
+	w.body = nil
+}
+
+// send writes the buffered status with body to the underlying writer. Nothing
+// is written if the handler wrote nothing, leaving gin's default response.
+func (w *bufferWriter) send(body []byte) {
+	if !w.wroteHeader && len(body) == 0 {
+		return
+	}
+	w.ResponseWriter.WriteHeader(w.status)
+	if len(body) > 0 {
+		w.ResponseWriter.Write(body)
+	} else {
+		w.ResponseWriter.WriteHeaderNow()
+	}
+}
@@ -0,0 +1,228 @@
+package middleware
+
+import (
+	"compress/gzip"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DefaultCompressMinSize is the smallest body Compress gzips when no size is
+// given. Below roughly a kilobyte the gzip framing outweighs the savings.
+const DefaultCompressMinSize = 1024
+
+// compressibleTypes are the content types worth compressing; images, archives
+// and already-encoded bodies pass through unchanged
+var compressibleTypes = []string{
+	"application/json",
+	"application/javascript",
+	"application/xml",
+	"application/openmetrics-text",
+	"text/",
+}
+
+var gzipWriters = sync.Pool{
+	New: func() interface{} {
+		w, _ := gzip.NewWriterLevel(nil, gzip.DefaultCompression)
+		return w
+	},
+}
+
+// Compress gzips responses of at least minSize bytes for clients that accept
+// gzip. Bodies are buffered up to minSize to decide, then streamed. ETags on
+// compressed responses are weakened, since the bytes on the wire no longer
+// match the representation they were computed from.
+func Compress(minSize int) gin.HandlerFunc {
+	if minSize <= 0 {
+		minSize = DefaultCompressMinSize
+	}
+	return func(c *gin.Context) {
+		if c.Request.Method == http.MethodHead || !acceptsGzip(c.Request.Header.Get("Accept-Encoding")) {
+			c.Next()
+			return
+		}
+
+		original := c.Writer
+		w := &gzipWriter{ResponseWriter: original, minSize: minSize, status: http.StatusOK}
+		c.Writer = w
+
+		c.Next()
+
+		w.finish()
+		c.Writer = original
+	}
+}
+
+// acceptsGzip reports whether an Accept-Encoding header allows gzip
+func acceptsGzip(header string) bool {
+	for _, part := range strings.Split(header, ",") {
+		coding, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		coding = strings.ToLower(strings.TrimSpace(coding))
+		if coding != "gzip" && coding != "*" {
+			continue
+		}
+		q := strings.TrimSpace(params)
+		if v, ok := strings.CutPrefix(q, "q="); ok {
+			if weight, err := strconv.ParseFloat(v, 64); err == nil && weight == 0 {
+				continue
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// gzipWriter holds back the response until it knows whether to compress it
+type gzipWriter struct {
+	gin.ResponseWriter
+	minSize int
+
+	status      int
+	wroteHeader bool
+	buf         []byte
+	size        int
+
+	decided bool
+	gz      *gzip.Writer
+}
+
+func (w *gzipWriter) WriteHeader(code int) {
+	if !w.decided {
+		w.status = code
+		w.wroteHeader = true
+	}
+}
+
+func (w *gzipWriter) WriteHeaderNow() {
+	w.wroteHeader = true
+}
+
+func (w *gzipWriter) Status() int {
+	return w.status
+}
+
+func (w *gzipWriter) Size() int {
+	if !w.wroteHeader && w.size == 0 {
+		return -1
+	}
+	return w.size
+}
+
+func (w *gzipWriter) Written() bool {
+	return w.wroteHeader || w.size > 0
+}
+
+func (w *gzipWriter) Write(b []byte) (int, error) {
+	w.wroteHeader = true
+	w.size += len(b)
+	if !w.decided {
+		w.buf = append(w.buf, b...)
+		if len(w.buf) < w.minSize {
+			return len(b), nil
+		}
+		pending := w.buf
+		w.buf = nil
+		if _, err := w.start(pending); err != nil {
+			return 0, err
+		}
+		return len(b), nil
+	}
+	if w.gz != nil {
+		return w.gz.Write(b)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *gzipWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// Flush sends what is buffered so far; streamed responses are compressed
+// if their headers allow it
+func (w *gzipWriter) Flush() {
+	if !w.decided {
+		pending := w.buf
+		w.buf = nil
+		w.start(pending)
+	}
+	if w.gz != nil {
+		w.gz.Flush()
+	}
+	w.ResponseWriter.Flush()
+}
+
+// start decides whether to compress, writes the headers and the pending body
+func (w *gzipWriter) start(pending []byte) (int, error) {
+	w.decided = true
+	header := w.Header()
+	if w.shouldCompress(len(pending)) {
+		header.Set("Content-Encoding", "gzip")
+		header.Add("Vary", "Accept-Encoding")
+		header.Del("Content-Length")
+		if etag := header.Get("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") {
+			header.Set("ETag", "W/"+etag)
+		}
+		w.gz = gzipWriters.Get().(*gzip.Writer)
+		w.gz.Reset(w.ResponseWriter)
+		w.ResponseWriter.WriteHeader(w.status)
+		return w.gz.Write(pending)
+	}
+	w.ResponseWriter.WriteHeader(w.status)
+	if len(pending) == 0 {
+		return 0, nil
+	}
+	return w.ResponseWriter.Write(pending)
+}
+
+// shouldCompress reports whether the response qualifies for gzip
+func (w *gzipWriter) shouldCompress(pending int) bool {
+	if pending < w.minSize || !bodyAllowed(w.status) {
+		return false
+	}
+	header := w.Header()
+	if header.Get("Content-Encoding") != "" {
+		return false
+	}
+	contentType := strings.ToLower(header.Get("Content-Type"))
+	if strings.HasPrefix(contentType, "text/event-stream") {
+		return false
+	}
+	for _, t := range compressibleTypes {
+		if strings.HasPrefix(contentType, t) {
+			return true
+		}
+	}
+	return strings.Contains(contentType, "+json")
+}
+
+// finish writes a response that stayed below minSize uncompressed and closes
+// the gzip stream
+func (w *gzipWriter) finish() {
+	if !w.decided {
+		if !w.wroteHeader {
+			return
+		}
+		pending := w.buf
+		w.buf = nil
+		w.start(pending)
+	}
+	if w.gz != nil {
+		w.gz.Close()
+		gzipWriters.Put(w.gz)
+		w.gz = nil
+	}
+}
+
+// bodyAllowed reports whether a status may carry a body
+func bodyAllowed(status int) bool {
+	switch {
+	case status >= 100 && status <= 199:
+		return false
+	case status == http.StatusNoContent, status == http.StatusNotModified:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,79 @@
+package middleware_test
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/middleware"
+)
+
+func newCompressRouter(body string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.Compress(64))
+	router.GET("/json", func(c *gin.Context) {
+		c.Header("ETag", `"abc"`)
+		c.JSON(http.StatusOK, gin.H{"data": body})
+	})
+	router.GET("/png", func(c *gin.Context) {
+		c.Data(http.StatusOK, "image/png", []byte(body))
+	})
+	return router
+}
+
+func get(router http.Handler, path, acceptEncoding string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	if acceptEncoding != "" {
+		req.Header.Set("Accept-Encoding", acceptEncoding)
+	}
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	return resp
+}
+
+func TestCompress_GzipsLargeResponses(t *testing.T) {
+	body := strings.Repeat("payment ", 100)
+	resp := get(newCompressRouter(body), "/json", "br, gzip")
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, "gzip", resp.Header().Get("Content-Encoding"))
+	assert.Equal(t, "Accept-Encoding", resp.Header().Get("Vary"))
+	assert.Equal(t, `W/"abc"`, resp.Header().Get("ETag"))
+
+	gz, err := gzip.NewReader(resp.Body)
+	require.NoError(t, err)
+	plain, err := io.ReadAll(gz)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"data":"`+body+`"}`, string(plain))
+}
+
+func TestCompress_PassesThroughWhenNotWorthIt(t *testing.T) {
+	large := strings.Repeat("x", 200)
+	tests := []struct {
+		name           string
+		body           string
+		path           string
+		acceptEncoding string
+	}{
+		{"small body", "ok", "/json", "gzip"},
+		{"client without gzip", large, "/json", ""},
+		{"gzip refused", large, "/json", "gzip;q=0, identity"},
+		{"binary content", large, "/png", "gzip"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := get(newCompressRouter(tt.body), tt.path, tt.acceptEncoding)
+			assert.Equal(t, http.StatusOK, resp.Code)
+			assert.Empty(t, resp.Header().Get("Content-Encoding"))
+			assert.Contains(t, resp.Body.String(), tt.body)
+		})
+	}
+}
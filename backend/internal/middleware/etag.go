@@ -0,0 +1,74 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ETag adds a content hash ETag to successful GET and HEAD responses that
+// have none and answers 304 Not Modified when the ETag matches the request's
+// If-None-Match, so clients polling unchanged lists skip the download. The
+// handler still runs; what is saved is the transfer, not the query.
+func ETag() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Method != http.MethodGet && c.Request.Method != http.MethodHead {
+			c.Next()
+			return
+		}
+
+		original := c.Writer
+		w := newBufferWriter(original)
+		c.Writer = w
+
+		c.Next()
+
+		c.Writer = original
+		if w.passthrough {
+			return
+		}
+
+		header := original.Header()
+		if w.status == http.StatusOK && len(w.body) > 0 {
+			// Handlers may set their own ETag (e.g. a record version)
+			etag := header.Get("ETag")
+			if etag == "" {
+				sum := sha256.Sum256(w.body)
+				etag = `"` + hex.EncodeToString(sum[:16]) + `"`
+				header.Set("ETag", etag)
+			}
+			if header.Get("Cache-Control") == "" {
+				// Let clients keep the response but revalidate every time
+				header.Set("Cache-Control", "no-cache")
+			}
+			if etagMatches(c.Request.Header.Get("If-None-Match"), etag) {
+				header.Del("Content-Length")
+				header.Del("Content-Type")
+				original.WriteHeader(http.StatusNotModified)
+				original.WriteHeaderNow()
+				return
+			}
+		}
+
+		w.send(w.body)
+	}
+}
+
+// etagMatches applies the weak comparison RFC 9110 requires for
+// If-None-Match
+func etagMatches(ifNoneMatch, etag string) bool {
+	if ifNoneMatch == "" {
+		return false
+	}
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
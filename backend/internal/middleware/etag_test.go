@@ -0,0 +1,105 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/middleware"
+)
+
+func TestETag_NotModifiedWhenUnchanged(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	payments := []string{"pay_1", "pay_2"}
+
+	router := gin.New()
+	router.Use(middleware.ETag())
+	router.GET("/payments", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"success": true, "data": payments})
+	})
+
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/payments", nil))
+	require.Equal(t, http.StatusOK, resp.Code)
+	etag := resp.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+	assert.Equal(t, "no-cache", resp.Header().Get("Cache-Control"))
+	assert.Contains(t, resp.Body.String(), "pay_2")
+
+	// Same content: 304 without a body, also for the weak form the gzip
+	// middleware sends
+	for _, ifNoneMatch := range []string{etag, "W/" + etag, `"other", ` + etag} {
+		req := httptest.NewRequest(http.MethodGet, "/payments", nil)
+		req.Header.Set("If-None-Match", ifNoneMatch)
+		resp = httptest.NewRecorder()
+		router.ServeHTTP(resp, req)
+		assert.Equal(t, http.StatusNotModified, resp.Code, ifNoneMatch)
+		assert.Empty(t, resp.Body.String())
+		assert.Equal(t, etag, resp.Header().Get("ETag"))
+	}
+
+	// Changed content: new ETag and the full body
+	payments = append(payments, "pay_3")
+	req := httptest.NewRequest(http.MethodGet, "/payments", nil)
+	req.Header.Set("If-None-Match", etag)
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.NotEqual(t, etag, resp.Header().Get("ETag"))
+	assert.Contains(t, resp.Body.String(), "pay_3")
+}
+
+func TestETag_SkipsErrorsAndWrites(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.ETag())
+	router.GET("/missing", func(c *gin.Context) {
+		c.JSON(http.StatusNotFound, gin.H{"success": false, "error": "Payment not found"})
+	})
+	router.POST("/payments", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"success": true})
+	})
+
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/missing", nil))
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+	assert.Empty(t, resp.Header().Get("ETag"))
+	assert.Contains(t, resp.Body.String(), "Payment not found")
+
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, httptest.NewRequest(http.MethodPost, "/payments", nil))
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Empty(t, resp.Header().Get("ETag"))
+}
+
+func TestETag_WithCompress(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.Compress(16))
+	router.Use(middleware.ETag())
+	router.GET("/payments", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"success": true, "data": "a list long enough to compress"})
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/payments", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	require.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, "gzip", resp.Header().Get("Content-Encoding"))
+	etag := resp.Header().Get("ETag")
+	assert.Regexp(t, `^W/"[0-9a-f]{32}"$`, etag)
+
+	req = httptest.NewRequest(http.MethodGet, "/payments", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	req.Header.Set("If-None-Match", etag)
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusNotModified, resp.Code)
+	assert.Empty(t, resp.Body.String())
+	assert.Empty(t, resp.Header().Get("Content-Encoding"))
+}
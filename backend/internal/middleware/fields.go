@@ -0,0 +1,119 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// FieldsParam is the query parameter listing the record fields to return
+const FieldsParam = "fields"
+
+// SparseFields projects successful JSON responses to the comma-separated
+// record fields named in ?fields=, e.g. ?fields=id,status,amount_usd.
+// recordsPath is the dotted path to the endpoint's records in the response:
+// "data" for a single record, or a list such as "data.transactions". Only
+// the records are projected; the envelope (success, total, page, ...) and a
+// record's id are always kept. Unknown fields are ignored, since optional
+// fields are omitted from records that lack them.
+func SparseFields(recordsPath string) gin.HandlerFunc {
+	path := strings.Split(recordsPath, ".")
+	return func(c *gin.Context) {
+		fields := parseFields(c.Query(FieldsParam))
+		if c.Request.Method != http.MethodGet || len(fields) == 0 {
+			c.Next()
+			return
+		}
+
+		original := c.Writer
+		w := newBufferWriter(original)
+		c.Writer = w
+
+		c.Next()
+
+		c.Writer = original
+		if w.passthrough {
+			return
+		}
+
+		body := w.body
+		if w.status == http.StatusOK && strings.HasPrefix(original.Header().Get("Content-Type"), "application/json") {
+			if projected, ok := projectFields(body, path, fields); ok {
+				body = projected
+				original.Header().Del("Content-Length")
+			}
+		}
+		w.send(body)
+	}
+}
+
+// parseFields splits a fields parameter into a set of names
+func parseFields(param string) map[string]bool {
+	if param == "" {
+		return nil
+	}
+	fields := make(map[string]bool)
+	for _, name := range strings.Split(param, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			fields[name] = true
+		}
+	}
+	if len(fields) == 0 {
+		return nil
+	}
+	fields["id"] = true
+	return fields
+}
+
+// projectFields rewrites a JSON response body keeping only fields in the
+// records at path. Numbers are kept verbatim so large amounts and nonces
+// survive.
+func projectFields(body []byte, path []string, fields map[string]bool) ([]byte, bool) {
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	var envelope map[string]interface{}
+	if err := dec.Decode(&envelope); err != nil {
+		return nil, false
+	}
+
+	// Walk to the object holding the records
+	parent := envelope
+	for _, key := range path[:len(path)-1] {
+		next, ok := parent[key].(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		parent = next
+	}
+
+	switch records := parent[path[len(path)-1]].(type) {
+	case []interface{}:
+		for _, item := range records {
+			if record, ok := item.(map[string]interface{}); ok {
+				projectRecord(record, fields)
+			}
+		}
+	case map[string]interface{}:
+		projectRecord(records, fields)
+	default:
+		return nil, false
+	}
+
+	projected, err := json.Marshal(envelope)
+	if err != nil {
+		return nil, false
+	}
+	return projected, true
+}
+
+// projectRecord deletes the fields of record not in fields
+func projectRecord(record map[string]interface{}, fields map[string]bool) {
+	for key := range record {
+		if !fields[key] {
+			delete(record, key)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package middleware_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/middleware"
+)
+
+func newFieldsRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	payment := func(id string) gin.H {
+		return gin.H{"id": id, "status": "completed", "amount_charged": 0.005, "currency": "ETH", "tx_hash": "0xabc"}
+	}
+
+	router := gin.New()
+	router.GET("/payments/:id", middleware.SparseFields("data"), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"success": true, "data": payment(c.Param("id"))})
+	})
+	router.GET("/relay/user/:address", middleware.SparseFields("data.transactions"), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"success": true, "data": gin.H{
+			"transactions": []gin.H{
+				{"id": "tx_1", "status": "confirmed", "nonce": uint64(18446744073709551615), "calldata": "0x1234"},
+				{"id": "tx_2", "status": "failed", "nonce": 1, "calldata": "0x5678"},
+			},
+			"total": 2,
+			"page":  1,
+		}})
+	})
+	router.GET("/archive/payments", middleware.SparseFields("records"), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"success": true, "records": []gin.H{}, "total": 0})
+	})
+	router.GET("/missing", middleware.SparseFields("data"), func(c *gin.Context) {
+		c.JSON(http.StatusNotFound, gin.H{"success": false, "error": "Payment not found"})
+	})
+	return router
+}
+
+func getJSON(t *testing.T, router http.Handler, path string) (int, string, map[string]interface{}) {
+	t.Helper()
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, path, nil))
+	var body map[string]interface{}
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &body), resp.Body.String())
+	return resp.Code, resp.Body.String(), body
+}
+
+func TestSparseFields_ProjectsSingleRecord(t *testing.T) {
+	router := newFieldsRouter()
+
+	code, _, body := getJSON(t, router, "/payments/pay_1?fields=status,%20amount_charged,unknown")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, true, body["success"])
+	assert.Equal(t, map[string]interface{}{"id": "pay_1", "status": "completed", "amount_charged": 0.005}, body["data"])
+
+	// Without fields the response is untouched
+	_, _, body = getJSON(t, router, "/payments/pay_1")
+	assert.Len(t, body["data"], 5)
+}
+
+func TestSparseFields_ProjectsListRecordsAndKeepsEnvelope(t *testing.T) {
+	code, raw, body := getJSON(t, newFieldsRouter(), "/relay/user/0xabc?fields=status,nonce")
+	assert.Equal(t, http.StatusOK, code)
+
+	data := body["data"].(map[string]interface{})
+	assert.Equal(t, float64(2), data["total"])
+	assert.Equal(t, float64(1), data["page"])
+	txs := data["transactions"].([]interface{})
+	require.Len(t, txs, 2)
+	assert.Equal(t, map[string]interface{}{"id": "tx_2", "status": "failed", "nonce": float64(1)}, txs[1])
+
+	// Large integers are not rounded through float64
+	assert.Contains(t, raw, `"nonce":18446744073709551615`)
+	assert.NotContains(t, raw, "calldata")
+}
+
+func TestSparseFields_LeavesEmptyListsAndErrors(t *testing.T) {
+	router := newFieldsRouter()
+
+	code, _, body := getJSON(t, router, "/archive/payments?fields=status")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, []interface{}{}, body["records"])
+	assert.Equal(t, float64(0), body["total"])
+
+	code, _, body = getJSON(t, router, "/missing?fields=status")
+	assert.Equal(t, http.StatusNotFound, code)
+	assert.Equal(t, "Payment not found", body["error"])
+}
@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// countryKey is the context key holding the client's country
+const countryKey = "client_country"
+
+// CountryResolver returns the ISO 3166-1 alpha-2 country of an IP, or ""
+// (implemented by geoip.Reader)
+type CountryResolver interface {
+	Country(ip string) string
+}
+
+// GeoBlocker decides whether a country is restricted (implemented by
+// geoip.Policy)
+type GeoBlocker interface {
+	Blocked(country string) bool
+}
+
+// ResolveCountry looks up the country of the request's ClientIP and makes it
+// available to handlers through ClientCountry. ClientIP only honours
+// X-Forwarded-For from the engine's trusted proxies, so the server must call
+// SetTrustedProxies or the block can be bypassed with a forged header.
+func ResolveCountry(resolver CountryResolver) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if country := resolver.Country(c.ClientIP()); country != "" {
+			c.Set(countryKey, country)
+		}
+		c.Next()
+	}
+}
+
+// ClientCountry returns the country ResolveCountry found for the request
+// ("" when unknown or GeoIP is not configured)
+func ClientCountry(c *gin.Context) string {
+	return c.GetString(countryKey)
+}
+
+// BlockRestrictedCountries answers 451 for requests from a restricted
+// jurisdiction. It must run after ResolveCountry.
+func BlockRestrictedCountries(blocker GeoBlocker, logger *zap.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		country := ClientCountry(c)
+		if !blocker.Blocked(country) {
+			c.Next()
+			return
+		}
+
+		logger.Info("request from restricted jurisdiction blocked",
+			zap.String("country", country),
+			zap.String("path", c.FullPath()),
+		)
+		c.AbortWithStatusJSON(http.StatusUnavailableForLegalReasons, gin.H{
+			"success": false,
+			"error":   "This service is not available in your jurisdiction",
+			"country": country,
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/middleware"
+)
+
+// stubCountries maps IPs to countries
+type stubCountries map[string]string
+
+func (s stubCountries) Country(ip string) string { return s[ip] }
+
+// stubBlocker blocks a fixed set of countries
+type stubBlocker map[string]bool
+
+func (s stubBlocker) Blocked(country string) bool { return s[country] }
+
+func TestGeoBlocking(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.ResolveCountry(stubCountries{"192.0.2.1": "IR", "198.51.100.1": "DE"}))
+	router.POST("/kyc/applicant", middleware.BlockRestrictedCountries(stubBlocker{"IR": true}, zap.NewNop()), func(c *gin.Context) {
+		c.String(http.StatusOK, middleware.ClientCountry(c))
+	})
+
+	post := func(ip string) *httptest.ResponseRecorder {
+		resp := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/kyc/applicant", nil)
+		req.RemoteAddr = ip + ":1234"
+		router.ServeHTTP(resp, req)
+		return resp
+	}
+
+	resp := post("198.51.100.1")
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, "DE", resp.Body.String())
+
+	resp = post("203.0.113.1")
+	assert.Equal(t, http.StatusOK, resp.Code, "unknown countries are left to the blocker")
+	assert.Equal(t, "", resp.Body.String())
+
+	resp = post("192.0.2.1")
+	assert.Equal(t, http.StatusUnavailableForLegalReasons, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"country":"IR"`)
+}
+
+func TestGeoBlocking_IgnoresForwardedForFromUntrustedPeers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	assert.NoError(t, router.SetTrustedProxies([]string{"10.0.0.1"}))
+	router.Use(middleware.ResolveCountry(stubCountries{"192.0.2.1": "IR", "198.51.100.1": "DE"}))
+	router.POST("/kyc/applicant", middleware.BlockRestrictedCountries(stubBlocker{"IR": true}, zap.NewNop()), func(c *gin.Context) {
+		c.String(http.StatusOK, middleware.ClientCountry(c))
+	})
+
+	post := func(peer, forwardedFor string) *httptest.ResponseRecorder {
+		resp := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/kyc/applicant", nil)
+		req.RemoteAddr = peer + ":1234"
+		req.Header.Set("X-Forwarded-For", forwardedFor)
+		router.ServeHTTP(resp, req)
+		return resp
+	}
+
+	// A restricted client cannot claim an allowed address
+	resp := post("192.0.2.1", "198.51.100.1")
+	assert.Equal(t, http.StatusUnavailableForLegalReasons, resp.Code)
+
+	// The load balancer's header is honoured
+	resp = post("10.0.0.1", "192.0.2.1")
+	assert.Equal(t, http.StatusUnavailableForLegalReasons, resp.Code)
+	resp = post("10.0.0.1", "198.51.100.1")
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, "DE", resp.Body.String())
+}
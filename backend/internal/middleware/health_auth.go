@@ -0,0 +1,70 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// healthVerboseKey is the context key marking requests allowed to see
+// internal health details
+const healthVerboseKey = "health_verbose"
+
+// RequireHealthToken guards the detailed health and metrics endpoints with
+// "Authorization: Bearer <token>" matching one of the current tokens. With
+// no token configured the endpoints stay open.
+func RequireHealthToken(currentTokens func() []string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !healthAuthorized(c, currentTokens()) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"success": false,
+				"error":   "Unauthorized",
+			})
+			return
+		}
+		c.Set(healthVerboseKey, true)
+		c.Next()
+	}
+}
+
+// IdentifyHealthToken never rejects; it marks requests RequireHealthToken
+// would let through, so open endpoints such as /health can leave internal
+// details out for everyone else (see HealthVerbose)
+func IdentifyHealthToken(currentTokens func() []string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if healthAuthorized(c, currentTokens()) {
+			c.Set(healthVerboseKey, true)
+		}
+		c.Next()
+	}
+}
+
+// HealthVerbose reports whether the request may see internal health details.
+// It is true outside IdentifyHealthToken and RequireHealthToken.
+func HealthVerbose(c *gin.Context) bool {
+	verbose, ok := c.Get(healthVerboseKey)
+	return !ok || verbose == true
+}
+
+// healthAuthorized reports whether no token is configured or the request's
+// bearer token matches one
+func healthAuthorized(c *gin.Context, tokens []string) bool {
+	configured := false
+	provided, hasBearer := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+	for _, token := range tokens {
+		if token == "" {
+			continue
+		}
+		configured = true
+		if hasBearer && subtle.ConstantTimeCompare([]byte(provided), []byte(token)) == 1 {
+			return true
+		}
+	}
+	if !configured {
+		return true
+	}
+	c.Set(healthVerboseKey, false)
+	return false
+}
@@ -0,0 +1,58 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/middleware"
+)
+
+func TestHealthTokens(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	var tokens []string
+	current := func() []string { return tokens }
+
+	router := gin.New()
+	verbose := func(c *gin.Context) {
+		if middleware.HealthVerbose(c) {
+			c.String(http.StatusOK, "verbose")
+			return
+		}
+		c.String(http.StatusOK, "basic")
+	}
+	router.GET("/health", middleware.IdentifyHealthToken(current), verbose)
+	router.GET("/metrics", middleware.RequireHealthToken(current), verbose)
+
+	get := func(path, auth string) *httptest.ResponseRecorder {
+		resp := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if auth != "" {
+			req.Header.Set("Authorization", auth)
+		}
+		router.ServeHTTP(resp, req)
+		return resp
+	}
+
+	// No token configured: everything is open and verbose
+	assert.Equal(t, "verbose", get("/health", "").Body.String())
+	assert.Equal(t, "verbose", get("/metrics", "").Body.String())
+
+	tokens = []string{"health-token", "", "admin-token"}
+
+	resp := get("/metrics", "")
+	assert.Equal(t, http.StatusUnauthorized, resp.Code)
+	assert.Equal(t, http.StatusUnauthorized, get("/metrics", "Bearer wrong").Code)
+	assert.Equal(t, http.StatusUnauthorized, get("/metrics", "health-token").Code, "the Bearer scheme is required")
+	assert.Equal(t, "verbose", get("/metrics", "Bearer health-token").Body.String())
+	assert.Equal(t, "verbose", get("/metrics", "Bearer admin-token").Body.String())
+	assert.Equal(t, http.StatusUnauthorized, get("/metrics", "Bearer ").Code, "empty tokens never match")
+
+	resp = get("/health", "")
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, "basic", resp.Body.String())
+	assert.Equal(t, "verbose", get("/health", "Bearer health-token").Body.String())
+}
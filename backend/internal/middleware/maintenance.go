@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/maintenance"
+)
+
+// maintenanceRetryAfterSeconds is suggested to clients rejected during
+// maintenance
+const maintenanceRetryAfterSeconds = "60"
+
+// RejectDuringMaintenance answers 503 with the maintenance message while the
+// switch is on. Paths under an exempt prefix (probes, the admin API that
+// turns maintenance off) are always served.
+func RejectDuringMaintenance(sw *maintenance.Switch, exemptPrefixes ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !sw.Enabled() || hasAnyPrefix(c.Request.URL.Path, exemptPrefixes) {
+			c.Next()
+			return
+		}
+
+		state := sw.State()
+		c.Header("Retry-After", maintenanceRetryAfterSeconds)
+		c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{
+			"success":     false,
+			"error":       "Service under maintenance",
+			"message":     state.Message,
+			"maintenance": true,
+		})
+	}
+}
+
+// hasAnyPrefix reports whether path is one of prefixes or below one
+func hasAnyPrefix(path string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if rest, ok := strings.CutPrefix(path, prefix); ok && (rest == "" || rest[0] == '/') {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,60 @@
+package middleware_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/maintenance"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/middleware"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/storage/memory"
+)
+
+func TestRejectDuringMaintenance(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	sw := maintenance.NewSwitch(memory.NewMemoryAppConfigRepo(), zap.NewNop())
+
+	router := gin.New()
+	router.Use(middleware.RejectDuringMaintenance(sw, "/health", "/api/v1/admin"))
+	ok := func(c *gin.Context) { c.String(http.StatusOK, "ok") }
+	router.GET("/health", ok)
+	router.GET("/health/detailed", ok)
+	router.GET("/healthz", ok)
+	router.GET("/api/v1/admin/maintenance", ok)
+	router.GET("/api/v1/payments/:id", ok)
+
+	get := func(path string) *httptest.ResponseRecorder {
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, path, nil))
+		return resp
+	}
+
+	assert.Equal(t, http.StatusOK, get("/api/v1/payments/pay_1").Code)
+
+	_, err := sw.Set(context.Background(), true, "Back soon", "ops")
+	require.NoError(t, err)
+
+	for _, path := range []string{"/health", "/health/detailed", "/api/v1/admin/maintenance"} {
+		assert.Equal(t, http.StatusOK, get(path).Code, path)
+	}
+
+	// A prefix matches whole path segments only
+	for _, path := range []string{"/api/v1/payments/pay_1", "/healthz"} {
+		resp := get(path)
+		require.Equal(t, http.StatusServiceUnavailable, resp.Code, path)
+		assert.Equal(t, "60", resp.Header().Get("Retry-After"))
+
+		var body map[string]interface{}
+		require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &body))
+		assert.Equal(t, false, body["success"])
+		assert.Equal(t, true, body["maintenance"])
+		assert.Equal(t, "Back soon", body["message"])
+	}
+}
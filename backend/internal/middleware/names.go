@@ -0,0 +1,100 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// NamesParam is the query parameter asking for addresses' ENS names
+const NamesParam = "names"
+
+// NameResolver returns the primary names of those addresses that have one
+// (implemented by ens.Service)
+type NameResolver interface {
+	LookupNames(ctx context.Context, addresses []string) map[string]string
+}
+
+// addressPattern matches a string value that is exactly an address
+var addressPattern = regexp.MustCompile(`^0x[0-9a-fA-F]{40}$`)
+
+// ResolveNames adds the ENS names of the addresses in successful JSON
+// responses when ?names=true is given. Names are returned beside the data as
+// "names", keyed by lowercase address; addresses without a primary name are
+// left out.
+func ResolveNames(resolver NameResolver) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if want, _ := strconv.ParseBool(c.Query(NamesParam)); c.Request.Method != http.MethodGet || !want {
+			c.Next()
+			return
+		}
+
+		original := c.Writer
+		w := newBufferWriter(original)
+		c.Writer = w
+
+		c.Next()
+
+		c.Writer = original
+		if w.passthrough {
+			return
+		}
+
+		body := w.body
+		if w.status == http.StatusOK && strings.HasPrefix(original.Header().Get("Content-Type"), "application/json") {
+			if named, ok := addNames(c.Request.Context(), body, resolver); ok {
+				body = named
+				original.Header().Del("Content-Length")
+			}
+		}
+		w.send(body)
+	}
+}
+
+// addNames rewrites a JSON response body with the names of the addresses
+// under "data"
+func addNames(ctx context.Context, body []byte, resolver NameResolver) ([]byte, bool) {
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	var envelope map[string]interface{}
+	if err := dec.Decode(&envelope); err != nil {
+		return nil, false
+	}
+	data, ok := envelope["data"]
+	if !ok {
+		return nil, false
+	}
+
+	envelope["names"] = resolver.LookupNames(ctx, collectAddresses(data, nil))
+
+	named, err := json.Marshal(envelope)
+	if err != nil {
+		return nil, false
+	}
+	return named, true
+}
+
+// collectAddresses appends the address string values found in v
+func collectAddresses(v interface{}, addresses []string) []string {
+	switch v := v.(type) {
+	case string:
+		if addressPattern.MatchString(v) {
+			addresses = append(addresses, strings.ToLower(v))
+		}
+	case []interface{}:
+		for _, item := range v {
+			addresses = collectAddresses(item, addresses)
+		}
+	case map[string]interface{}:
+		for _, item := range v {
+			addresses = collectAddresses(item, addresses)
+		}
+	}
+	return addresses
+}
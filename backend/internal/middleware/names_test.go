@@ -0,0 +1,77 @@
+package middleware_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/middleware"
+)
+
+const (
+	alice = "0x1111111111111111111111111111111111111111"
+	bob   = "0x2222222222222222222222222222222222222222"
+)
+
+// fakeResolver knows alice's name and records what it was asked
+type fakeResolver struct {
+	asked []string
+}
+
+func (f *fakeResolver) LookupNames(ctx context.Context, addresses []string) map[string]string {
+	f.asked = append(f.asked, addresses...)
+	names := make(map[string]string)
+	for _, a := range addresses {
+		if a == alice {
+			names[a] = "alice.eth"
+		}
+	}
+	return names
+}
+
+func newNamesRouter(resolver middleware.NameResolver) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.ResolveNames(resolver))
+	router.GET("/proposals/:id", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"success": true, "data": gin.H{
+			"id":       c.Param("id"),
+			"proposer": alice,
+			"votes": []gin.H{
+				{"voter": bob, "tx_hash": "0x" + strings.Repeat("ab", 32)},
+			},
+		}})
+	})
+	router.GET("/missing", func(c *gin.Context) {
+		c.JSON(http.StatusNotFound, gin.H{"success": false, "error": "Proposal not found"})
+	})
+	return router
+}
+
+func TestResolveNames_AddsNames(t *testing.T) {
+	resolver := &fakeResolver{}
+	router := newNamesRouter(resolver)
+
+	code, _, body := getJSON(t, router, "/proposals/1?names=true")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, map[string]interface{}{alice: "alice.eth"}, body["names"])
+	assert.ElementsMatch(t, []string{alice, bob}, resolver.asked)
+
+	data := body["data"].(map[string]interface{})
+	assert.Equal(t, alice, data["proposer"])
+}
+
+func TestResolveNames_OnlyWhenAsked(t *testing.T) {
+	resolver := &fakeResolver{}
+	router := newNamesRouter(resolver)
+
+	_, _, body := getJSON(t, router, "/proposals/1")
+	assert.NotContains(t, body, "names")
+	_, _, body = getJSON(t, router, "/missing?names=true")
+	assert.NotContains(t, body, "names")
+	assert.Empty(t, resolver.asked)
+}
@@ -0,0 +1,51 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// partnerIDKey is the context key holding the authenticated partner's ID
+const partnerIDKey = "partner_id"
+
+// PartnerAuthenticator returns the ID of the active partner an API key was
+// issued to (implemented by partners.Authenticator)
+type PartnerAuthenticator interface {
+	AuthenticatePartner(ctx context.Context, key string) (string, error)
+}
+
+// RequirePartner requires "Authorization: Bearer <partner API key>" and makes
+// the partner available to handlers through PartnerID
+func RequirePartner(partners PartnerAuthenticator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if !ok || key == "" {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"success": false,
+				"error":   "Partner API key required",
+			})
+			return
+		}
+
+		id, err := partners.AuthenticatePartner(c.Request.Context(), key)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"success": false,
+				"error":   "Unauthorized",
+			})
+			return
+		}
+
+		c.Set(partnerIDKey, id)
+		c.Next()
+	}
+}
+
+// PartnerID returns the partner authenticated on the request ("" outside
+// RequirePartner)
+func PartnerID(c *gin.Context) string {
+	return c.GetString(partnerIDKey)
+}
@@ -0,0 +1,113 @@
+package middleware
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"os"
+	"runtime/debug"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/apiversion"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/errreport"
+)
+
+// Recovery turns a handler panic into a 500 with the standard error envelope
+// of the request's API version. The panic is logged with its stack trace
+// and handed to reporter, tagged with the request ID and route. Panics from
+// a client that went away (broken pipe, http.ErrAbortHandler) are only
+// logged, since no response can be written.
+//
+// Recovery must be the outermost middleware so it also covers the other
+// middleware; the request ID set further in is still on the context.
+func Recovery(logger *zap.Logger, reporter errreport.Reporter) gin.HandlerFunc {
+	if reporter == nil {
+		reporter = errreport.Nop{}
+	}
+	return func(c *gin.Context) {
+		defer func() {
+			value := recover()
+			if value == nil {
+				return
+			}
+
+			if clientGone(value) {
+				logger.Warn("client connection lost",
+					zap.String("path", c.Request.URL.Path),
+					zap.Any("error", value),
+				)
+				c.Abort()
+				return
+			}
+
+			p := &errreport.Panic{
+				Value:     value,
+				Stack:     debug.Stack(),
+				RequestID: c.GetString("request_id"),
+				Route:     c.FullPath(),
+				Method:    c.Request.Method,
+				URL:       requestURL(c.Request),
+				Query:     ScrubQuery(c.Request.URL.RawQuery),
+				Headers:   ScrubHeaders(c.Request.Header),
+			}
+			logger.Error("panic recovered",
+				zap.String("request_id", p.RequestID),
+				zap.String("route", p.Route),
+				zap.String("method", p.Method),
+				zap.String("path", c.Request.URL.Path),
+				zap.String("panic", p.Message()),
+				zap.ByteString("stack", p.Stack),
+			)
+			reporter.ReportPanic(c.Request.Context(), p)
+
+			if c.Writer.Written() {
+				// Part of the response is out; all that can be done is stop
+				c.Abort()
+				return
+			}
+			if apiversion.FromContext(c) >= apiversion.V2 {
+				c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
+					"error": gin.H{"code": "internal_error", "message": "Internal server error"},
+				})
+				return
+			}
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
+				"success": false,
+				"error":   "Internal server error",
+			})
+		}()
+		c.Next()
+	}
+}
+
+// clientGone reports whether a panic value means the client disconnected
+func clientGone(value interface{}) bool {
+	err, ok := value.(error)
+	if !ok {
+		return false
+	}
+	if errors.Is(err, http.ErrAbortHandler) {
+		return true
+	}
+	var opErr *net.OpError
+	if errors.As(err, &opErr) {
+		var syscallErr *os.SyscallError
+		if errors.As(opErr, &syscallErr) {
+			msg := strings.ToLower(syscallErr.Error())
+			return strings.Contains(msg, "broken pipe") || strings.Contains(msg, "connection reset by peer")
+		}
+	}
+	return false
+}
+
+// requestURL returns the URL of r without its query string
+func requestURL(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https" {
+		scheme = "https"
+	}
+	return scheme + "://" + r.Host + r.URL.Path
+}
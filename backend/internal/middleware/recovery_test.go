@@ -0,0 +1,108 @@
+package middleware_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/apiversion"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/errreport"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/middleware"
+)
+
+// recordingReporter keeps reported panics
+type recordingReporter struct {
+	panics []*errreport.Panic
+}
+
+func (r *recordingReporter) ReportPanic(_ context.Context, p *errreport.Panic) {
+	r.panics = append(r.panics, p)
+}
+
+func setupRecoveryRouter(reporter errreport.Reporter) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.Recovery(zap.NewNop(), reporter))
+	router.Use(func(c *gin.Context) {
+		c.Set("request_id", "req-123")
+		c.Next()
+	})
+
+	versions := apiversion.NewRegistry(router)
+	for _, v := range []apiversion.Version{apiversion.V1, apiversion.V2} {
+		api := versions.Group(v, apiversion.Policy{})
+		api.GET("/relay/status/:id", func(c *gin.Context) {
+			var m map[string]int
+			m[c.Param("id")] = 1
+		})
+		api.GET("/stream", func(c *gin.Context) {
+			c.String(http.StatusOK, "partial")
+			panic("after write")
+		})
+		api.GET("/gone", func(c *gin.Context) {
+			panic(http.ErrAbortHandler)
+		})
+	}
+	return router
+}
+
+func TestRecovery_ReportsAndReturnsEnvelope(t *testing.T) {
+	reporter := &recordingReporter{}
+	router := setupRecoveryRouter(reporter)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/relay/status/42?fields=id&token=secret", nil)
+	req.Header.Set("Authorization", "Bearer admin-token")
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	require.Equal(t, http.StatusInternalServerError, resp.Code)
+	assert.JSONEq(t, `{"success":false,"error":"Internal server error"}`, resp.Body.String())
+
+	require.Len(t, reporter.panics, 1)
+	p := reporter.panics[0]
+	assert.Equal(t, "req-123", p.RequestID)
+	assert.Equal(t, "/api/v1/relay/status/:id", p.Route)
+	assert.Equal(t, http.MethodGet, p.Method)
+	assert.Equal(t, "http://example.com/api/v1/relay/status/42", p.URL)
+	assert.Contains(t, p.Message(), "nil map")
+	assert.Contains(t, string(p.Stack), "recovery_test.go")
+	assert.NotContains(t, p.Query, "secret")
+	assert.NotContains(t, p.Headers["Authorization"], "admin-token")
+}
+
+func TestRecovery_V2Envelope(t *testing.T) {
+	router := setupRecoveryRouter(&recordingReporter{})
+
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/api/v2/relay/status/42", nil))
+
+	require.Equal(t, http.StatusInternalServerError, resp.Code)
+	var body map[string]interface{}
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &body))
+	assert.Equal(t, map[string]interface{}{"code": "internal_error", "message": "Internal server error"}, body["error"])
+	assert.NotContains(t, body, "success")
+}
+
+func TestRecovery_AfterWriteAndClientGone(t *testing.T) {
+	reporter := &recordingReporter{}
+	router := setupRecoveryRouter(reporter)
+
+	// The status is already out: reported, but the body is not appended to
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/api/v1/stream", nil))
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, "partial", resp.Body.String())
+	assert.Len(t, reporter.panics, 1)
+
+	// Aborted by the client: nothing to report
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/api/v1/gone", nil))
+	assert.Len(t, reporter.panics, 1)
+}
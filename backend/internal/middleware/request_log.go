@@ -0,0 +1,254 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"io"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/storage/batch"
+)
+
+// RequestIDHeader carries the request ID assigned to every archived request
+const RequestIDHeader = "X-Request-ID"
+
+// Request log defaults (overridable via app_config namespace "request_log")
+const (
+	defaultRetentionDays = 30
+	defaultMaxBodyBytes  = 16 * 1024
+
+	requestLogQueueSize     = 1024
+	requestLogBatchSize     = 100
+	requestLogFlushInterval = 2 * time.Second
+	requestLogFlushTimeout  = 5 * time.Second
+	requestLogPruneInterval = time.Hour
+)
+
+// requestLogSkipPrefixes are probe and scrape paths that are never archived
+var requestLogSkipPrefixes = []string{"/health", "/ready", "/live", "/startup", "/ping", "/metrics", "/version"}
+
+// RequestLog archives scrubbed request/response pairs for support engineers.
+// Entries are queued by the middleware and written in batches by Run, which
+// also enforces the retention policy and drains the queue on shutdown.
+type RequestLog struct {
+	repo       repository.RequestLogRepository
+	configRepo repository.AppConfigRepository
+	logger     *zap.Logger
+
+	writer       *batch.Writer[*repository.RequestLogEntry]
+	maxBodyBytes atomic.Int64
+	done         chan struct{}
+}
+
+// NewRequestLog creates a new request log archiver
+func NewRequestLog(repo repository.RequestLogRepository, configRepo repository.AppConfigRepository, logger *zap.Logger) *RequestLog {
+	l := &RequestLog{
+		repo:       repo,
+		configRepo: configRepo,
+		logger:     logger,
+		done:       make(chan struct{}),
+	}
+	l.writer = batch.NewWriter(batch.Config{
+		Name:          "request_logs",
+		MaxBatch:      requestLogBatchSize,
+		FlushInterval: requestLogFlushInterval,
+		QueueSize:     requestLogQueueSize,
+		FlushTimeout:  requestLogFlushTimeout,
+	}, repo.InsertRequestLogs, logger)
+	l.maxBodyBytes.Store(defaultMaxBodyBytes)
+	return l
+}
+
+// Handler returns the Gin middleware that captures and queues each request.
+// It never blocks the request: entries are dropped if the queue is full.
+func (l *RequestLog) Handler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(RequestIDHeader)
+		if requestID == "" || len(requestID) > 64 {
+			requestID = newRequestID()
+		}
+		c.Set("request_id", requestID)
+		c.Header(RequestIDHeader, requestID)
+
+		path := c.Request.URL.Path
+		if skipRequestLog(path) {
+			c.Next()
+			return
+		}
+
+		maxBody := int(l.maxBodyBytes.Load())
+		start := time.Now()
+
+		// Capture a bounded prefix of the request body without consuming it
+		var reqBody []byte
+		if c.Request.Body != nil {
+			reqBody, _ = io.ReadAll(io.LimitReader(c.Request.Body, int64(maxBody)))
+			c.Request.Body = readCloser{io.MultiReader(bytes.NewReader(reqBody), c.Request.Body), c.Request.Body}
+		}
+
+		writer := &captureWriter{ResponseWriter: c.Writer, limit: maxBody}
+		c.Writer = writer
+
+		c.Next()
+
+		entry := &repository.RequestLogEntry{
+			RequestID:      requestID,
+			Method:         c.Request.Method,
+			Path:           path,
+			Route:          c.FullPath(),
+			Query:          ScrubQuery(c.Request.URL.RawQuery),
+			StatusCode:     writer.Status(),
+			LatencyMs:      time.Since(start).Milliseconds(),
+			ClientIP:       c.ClientIP(),
+			UserAgent:      c.Request.UserAgent(),
+			RequestHeaders: ScrubHeaders(c.Request.Header),
+			CreatedAt:      start,
+		}
+		if len(reqBody) > 0 {
+			body := ScrubBody(reqBody)
+			entry.RequestBody = &body
+		}
+		if writer.body.Len() > 0 {
+			body := ScrubBody(writer.body.Bytes())
+			entry.ResponseBody = &body
+		}
+		if len(c.Errors) > 0 {
+			errText := ScrubText(c.Errors.String())
+			entry.Error = &errText
+		}
+
+		l.writer.Enqueue(entry)
+	}
+}
+
+// Run writes queued entries in batches and prunes expired entries until the
+// context is cancelled, then drains the queue before returning
+func (l *RequestLog) Run(ctx context.Context) {
+	defer close(l.done)
+
+	prune := time.NewTicker(requestLogPruneInterval)
+	defer prune.Stop()
+
+	l.refreshConfig(ctx)
+	l.prune(ctx)
+
+	go l.writer.Run(ctx)
+	defer l.writer.Wait()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-prune.C:
+			l.refreshConfig(ctx)
+			l.prune(ctx)
+		}
+	}
+}
+
+// Wait blocks until Run has drained the queue after shutdown
+func (l *RequestLog) Wait() {
+	<-l.done
+}
+
+// prune deletes entries older than the configured retention
+func (l *RequestLog) prune(ctx context.Context) {
+	days := l.getConfigNumber(ctx, "retention_days", defaultRetentionDays)
+	if days <= 0 {
+		// Retention disabled: keep everything
+		return
+	}
+
+	cutoff := time.Now().AddDate(0, 0, -int(days))
+	deleted, err := l.repo.DeleteRequestLogsBefore(ctx, cutoff)
+	if err != nil {
+		if ctx.Err() == nil {
+			l.logger.Error("failed to prune request logs", zap.Error(err))
+		}
+		return
+	}
+	if deleted > 0 {
+		l.logger.Info("pruned request logs",
+			zap.Int64("deleted", deleted),
+			zap.Int64("retention_days", days),
+		)
+	}
+}
+
+// refreshConfig reloads the body capture limit
+func (l *RequestLog) refreshConfig(ctx context.Context) {
+	if limit := l.getConfigNumber(ctx, "max_body_bytes", defaultMaxBodyBytes); limit > 0 {
+		l.maxBodyBytes.Store(limit)
+	}
+}
+
+// getConfigNumber reads a request_log config value with a code fallback
+func (l *RequestLog) getConfigNumber(ctx context.Context, key string, fallback int64) int64 {
+	if l.configRepo == nil {
+		return fallback
+	}
+	v, err := l.configRepo.GetNumber(ctx, "request_log", key, 0)
+	if err != nil {
+		return fallback
+	}
+	return v
+}
+
+// skipRequestLog reports whether the path is a probe that should not be archived
+func skipRequestLog(path string) bool {
+	for _, prefix := range requestLogSkipPrefixes {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// newRequestID generates a random 128-bit request ID
+func newRequestID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return time.Now().UTC().Format("20060102150405.000000000")
+	}
+	return hex.EncodeToString(b)
+}
+
+// readCloser re-attaches the original body's Close to a replayed reader
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// captureWriter tees up to limit bytes of the response body
+type captureWriter struct {
+	gin.ResponseWriter
+	body  bytes.Buffer
+	limit int
+}
+
+func (w *captureWriter) Write(b []byte) (int, error) {
+	w.capture(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *captureWriter) WriteString(s string) (int, error) {
+	w.capture([]byte(s))
+	return w.ResponseWriter.WriteString(s)
+}
+
+func (w *captureWriter) capture(b []byte) {
+	if remaining := w.limit - w.body.Len(); remaining > 0 {
+		if len(b) > remaining {
+			b = b[:remaining]
+		}
+		w.body.Write(b)
+	}
+}
@@ -0,0 +1,158 @@
+// Package middleware provides shared Gin middleware for the API server
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Redacted replaces scrubbed values in archived requests
+const Redacted = "[REDACTED]"
+
+// sensitiveKeys are normalized (lowercase, no '_' or '-') field, header and
+// query parameter names whose values are always redacted
+var sensitiveKeys = map[string]bool{
+	"sig":            true,
+	"signatures":     true,
+	"authorization":  true,
+	"cookie":         true,
+	"setcookie":      true,
+	"password":       true,
+	"privatekey":     true,
+	"mnemonic":       true,
+	"apikey":         true,
+	"documenthash":   true,
+	"dochash":        true,
+	"payloaddigest":  true,
+	"xpayloaddigest": true,
+	"xappaccesssig":  true,
+}
+
+// sensitiveSuffixes catch variants like userSignature, access_token,
+// client_secret, X-Admin-Token and Stripe-Signature
+var sensitiveSuffixes = []string{
+	"signature",
+	"token",
+	"secret",
+	"password",
+	"privatekey",
+	"apikey",
+	"authorization",
+}
+
+var (
+	// 65-byte ECDSA signatures (r, s, v) as hex
+	hexSignaturePattern = regexp.MustCompile(`0x[0-9a-fA-F]{130}`)
+
+	// JWTs and similar bearer tokens
+	jwtPattern = regexp.MustCompile(`eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`)
+
+	// "key": "value" pairs in bodies that could not be parsed (e.g. truncated JSON)
+	sensitiveFieldPattern = regexp.MustCompile(`(?i)"([a-z0-9_-]*(?:signature|sig|token|secret|password|private_?key|api_?key|document_?hash|doc_?hash|payload_?digest|mnemonic|authorization))"\s*:\s*"[^"]*"`)
+)
+
+// IsSensitiveKey reports whether values under this field, header or query
+// parameter name must be redacted
+func IsSensitiveKey(name string) bool {
+	n := strings.ToLower(name)
+	n = strings.NewReplacer("_", "", "-", "").Replace(n)
+
+	if sensitiveKeys[n] {
+		return true
+	}
+	for _, suffix := range sensitiveSuffixes {
+		if strings.HasSuffix(n, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// ScrubHeaders returns a flattened copy of the headers with credentials,
+// signatures and webhook digests redacted
+func ScrubHeaders(h http.Header) map[string]string {
+	out := make(map[string]string, len(h))
+	for name, values := range h {
+		if IsSensitiveKey(name) {
+			out[name] = Redacted
+			continue
+		}
+		out[name] = ScrubText(strings.Join(values, ", "))
+	}
+	return out
+}
+
+// ScrubQuery redacts sensitive query parameters from a raw query string
+func ScrubQuery(raw string) string {
+	if raw == "" {
+		return ""
+	}
+
+	values, err := url.ParseQuery(raw)
+	if err != nil {
+		return ScrubText(raw)
+	}
+
+	for key, vals := range values {
+		for i := range vals {
+			if IsSensitiveKey(key) {
+				vals[i] = Redacted
+			} else {
+				vals[i] = ScrubText(vals[i])
+			}
+		}
+	}
+	return values.Encode()
+}
+
+// ScrubBody redacts signatures, document hashes, tokens and credentials from a
+// request or response body. JSON bodies are redacted field by field; anything
+// else (including truncated JSON) falls back to pattern matching.
+func ScrubBody(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	var doc interface{}
+	if err := json.Unmarshal(body, &doc); err == nil {
+		if scrubbed, err := json.Marshal(scrubValue(doc)); err == nil {
+			return string(scrubbed)
+		}
+	}
+
+	return ScrubText(sensitiveFieldPattern.ReplaceAllString(string(body), `"$1":"`+Redacted+`"`))
+}
+
+// ScrubText redacts values that look like signatures or bearer tokens
+func ScrubText(s string) string {
+	s = hexSignaturePattern.ReplaceAllString(s, Redacted)
+	s = jwtPattern.ReplaceAllString(s, Redacted)
+	return s
+}
+
+// scrubValue walks a decoded JSON document redacting sensitive fields
+func scrubValue(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for key, child := range val {
+			if IsSensitiveKey(key) {
+				val[key] = Redacted
+			} else {
+				val[key] = scrubValue(child)
+			}
+		}
+		return val
+	case []interface{}:
+		for i, child := range val {
+			val[i] = scrubValue(child)
+		}
+		return val
+	case string:
+		return ScrubText(val)
+	default:
+		return val
+	}
+}
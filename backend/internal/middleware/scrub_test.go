@@ -0,0 +1,151 @@
+package middleware_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/middleware"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+var testSignature = "0x" + strings.Repeat("ab", 65)
+
+func TestScrubBody_RedactsSensitiveJSONFields(t *testing.T) {
+	body := `{
+		"request": {"from": "0x1111111111111111111111111111111111111111", "nonce": "3"},
+		"signature": "` + testSignature + `",
+		"document_hash": "0xdeadbeef",
+		"access_token": "abc",
+		"tx_hash": "0x` + strings.Repeat("cd", 32) + `",
+		"notes": ["signed with ` + testSignature + `"]
+	}`
+
+	out := middleware.ScrubBody([]byte(body))
+
+	assert.NotContains(t, out, testSignature)
+	assert.NotContains(t, out, "0xdeadbeef")
+	assert.NotContains(t, out, `"abc"`)
+	assert.Contains(t, out, "0x1111111111111111111111111111111111111111")
+	assert.Contains(t, out, strings.Repeat("cd", 32), "tx hashes stay searchable")
+}
+
+func TestScrubBody_TruncatedJSONFallsBackToPatterns(t *testing.T) {
+	body := `{"token": "secret-value", "userSignature": "` + testSignature + `", "amount": "10`
+
+	out := middleware.ScrubBody([]byte(body))
+
+	assert.NotContains(t, out, "secret-value")
+	assert.NotContains(t, out, testSignature)
+	assert.Contains(t, out, `"amount": "10`)
+}
+
+func TestScrubHeadersAndQuery(t *testing.T) {
+	h := http.Header{}
+	h.Set("Authorization", "Bearer abc")
+	h.Set("X-Payload-Digest", "digest")
+	h.Set("Stripe-Signature", "t=1,v1=abc")
+	h.Set("Content-Type", "application/json")
+
+	headers := middleware.ScrubHeaders(h)
+	assert.Equal(t, middleware.Redacted, headers["Authorization"])
+	assert.Equal(t, middleware.Redacted, headers["X-Payload-Digest"])
+	assert.Equal(t, middleware.Redacted, headers["Stripe-Signature"])
+	assert.Equal(t, "application/json", headers["Content-Type"])
+
+	query := middleware.ScrubQuery("address=0xabc&api_key=k123")
+	assert.Contains(t, query, "address=0xabc")
+	assert.NotContains(t, query, "k123")
+}
+
+// fakeRequestLogRepo records inserted entries
+type fakeRequestLogRepo struct {
+	mu      sync.Mutex
+	entries []*repository.RequestLogEntry
+}
+
+func (r *fakeRequestLogRepo) InsertRequestLogs(ctx context.Context, entries []*repository.RequestLogEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entries = append(r.entries, entries...)
+	return nil
+}
+
+func (r *fakeRequestLogRepo) GetRequestLog(ctx context.Context, id string) (*repository.RequestLogEntry, error) {
+	return nil, repository.ErrRequestLogNotFound
+}
+
+func (r *fakeRequestLogRepo) ListRequestLogs(ctx context.Context, filter repository.RequestLogFilter, page repository.Pagination) ([]*repository.RequestLogEntry, int64, error) {
+	return nil, 0, nil
+}
+
+func (r *fakeRequestLogRepo) DeleteRequestLogsBefore(ctx context.Context, before time.Time) (int64, error) {
+	return 0, nil
+}
+
+func (r *fakeRequestLogRepo) snapshot() []*repository.RequestLogEntry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]*repository.RequestLogEntry(nil), r.entries...)
+}
+
+func TestRequestLog_ArchivesScrubbedRequests(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	repo := &fakeRequestLogRepo{}
+	requestLog := middleware.NewRequestLog(repo, nil, zap.NewNop())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		requestLog.Run(ctx)
+		close(done)
+	}()
+
+	router := gin.New()
+	router.Use(requestLog.Handler())
+	router.GET("/health", func(c *gin.Context) { c.String(http.StatusOK, "ok") })
+	router.POST("/api/v1/relay", func(c *gin.Context) {
+		var req map[string]interface{}
+		require.NoError(t, c.ShouldBindJSON(&req), "handler still sees the full body")
+		c.JSON(http.StatusOK, gin.H{"token": "issued-token", "status": "pending"})
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	w = httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/relay", strings.NewReader(`{"signature":"`+testSignature+`","from":"0xabc"}`))
+	req.Header.Set("Authorization", "Bearer admin")
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	requestID := w.Header().Get(middleware.RequestIDHeader)
+	assert.NotEmpty(t, requestID)
+
+	cancel()
+	<-done
+
+	entries := repo.snapshot()
+	require.Len(t, entries, 1, "health probes are not archived")
+
+	e := entries[0]
+	assert.Equal(t, requestID, e.RequestID)
+	assert.Equal(t, "/api/v1/relay", e.Route)
+	assert.Equal(t, http.StatusOK, e.StatusCode)
+	assert.Equal(t, middleware.Redacted, e.RequestHeaders["Authorization"])
+	require.NotNil(t, e.RequestBody)
+	assert.NotContains(t, *e.RequestBody, testSignature)
+	assert.Contains(t, *e.RequestBody, "0xabc")
+	require.NotNil(t, e.ResponseBody)
+	assert.NotContains(t, *e.ResponseBody, "issued-token")
+	assert.Contains(t, *e.ResponseBody, "pending")
+}
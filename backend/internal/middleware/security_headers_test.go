@@ -0,0 +1,53 @@
+package middleware_test
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/middleware"
+)
+
+func securityHeadersResponse(cfg middleware.SecurityHeadersConfig, overTLS bool) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.SecurityHeaders(cfg))
+	router.GET("/ping", func(c *gin.Context) { c.JSON(http.StatusOK, gin.H{"success": true}) })
+
+	req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+	if overTLS {
+		req.TLS = &tls.ConnectionState{}
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestSecurityHeaders(t *testing.T) {
+	cfg := middleware.SecurityHeadersConfig{HSTSMaxAge: 2 * time.Hour, HSTSIncludeSubdomains: true, HSTSPreload: true}
+
+	t.Run("tls", func(t *testing.T) {
+		w := securityHeadersResponse(cfg, true)
+		assert.Equal(t, "nosniff", w.Header().Get("X-Content-Type-Options"))
+		assert.Equal(t, "DENY", w.Header().Get("X-Frame-Options"))
+		assert.Equal(t, "no-referrer", w.Header().Get("Referrer-Policy"))
+		assert.Contains(t, w.Header().Get("Content-Security-Policy"), "default-src 'none'")
+		assert.Equal(t, "max-age=7200; includeSubDomains; preload", w.Header().Get("Strict-Transport-Security"))
+	})
+
+	t.Run("plain http has no hsts", func(t *testing.T) {
+		w := securityHeadersResponse(cfg, false)
+		assert.Equal(t, "nosniff", w.Header().Get("X-Content-Type-Options"))
+		assert.Empty(t, w.Header().Get("Strict-Transport-Security"))
+	})
+
+	t.Run("hsts disabled", func(t *testing.T) {
+		w := securityHeadersResponse(middleware.SecurityHeadersConfig{}, true)
+		assert.Empty(t, w.Header().Get("Strict-Transport-Security"))
+	})
+}
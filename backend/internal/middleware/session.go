@@ -0,0 +1,51 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// sessionAddressKey is the context key holding the signed-in address
+const sessionAddressKey = "session_address"
+
+// SessionAuthenticator returns the address a session token was issued to
+// (implemented by auth.Service)
+type SessionAuthenticator interface {
+	Authenticate(token string) (string, error)
+}
+
+// RequireSession requires "Authorization: Bearer <session token>" from a
+// wallet sign-in and makes the signed-in address available to handlers
+// through SessionAddress
+func RequireSession(sessions SessionAuthenticator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if !ok || token == "" {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"success": false,
+				"error":   "Sign-in required",
+			})
+			return
+		}
+
+		address, err := sessions.Authenticate(token)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"success": false,
+				"error":   "Unauthorized",
+			})
+			return
+		}
+
+		c.Set(sessionAddressKey, address)
+		c.Next()
+	}
+}
+
+// SessionAddress returns the address signed in on the request ("" outside
+// RequireSession)
+func SessionAddress(c *gin.Context) string {
+	return c.GetString(sessionAddressKey)
+}
@@ -0,0 +1,151 @@
+package middleware
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/timing"
+)
+
+// DefaultSlowRequestThreshold is the latency above which a request is logged as slow
+const DefaultSlowRequestThreshold = time.Second
+
+// otherComponent labels the time of a slow request not recorded by any
+// component: handler code, serialization, lock waits
+const otherComponent = "other"
+
+// SlowRequests times every request with a timing.Breakdown on its context
+// and logs those slower than a threshold with the time spent in the
+// database, RPC and each external provider. The same numbers are exported
+// as metrics, with the requests in flight for watching a shutdown drain.
+type SlowRequests struct {
+	threshold time.Duration
+	logger    *zap.Logger
+
+	inFlight atomic.Int64
+
+	mu    sync.Mutex
+	slow  map[string]int64         // slow requests by route
+	spent map[string]time.Duration // time of slow requests by component
+}
+
+// NewSlowRequests creates the middleware state (threshold
+// DefaultSlowRequestThreshold if zero)
+func NewSlowRequests(threshold time.Duration, logger *zap.Logger) *SlowRequests {
+	if threshold <= 0 {
+		threshold = DefaultSlowRequestThreshold
+	}
+	return &SlowRequests{
+		threshold: threshold,
+		logger:    logger,
+		slow:      make(map[string]int64),
+		spent:     make(map[string]time.Duration),
+	}
+}
+
+// Handler returns the Gin middleware. It should run before any handler
+// that calls the database or a provider, so their calls land on the
+// breakdown.
+func (s *SlowRequests) Handler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		s.inFlight.Add(1)
+		defer s.inFlight.Add(-1)
+
+		ctx, breakdown := timing.WithBreakdown(c.Request.Context())
+		c.Request = c.Request.WithContext(ctx)
+		start := time.Now()
+
+		c.Next()
+
+		latency := time.Since(start)
+		if latency < s.threshold {
+			return
+		}
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		spans := breakdown.Spans()
+		other := latency
+		for _, span := range spans {
+			other -= span.Duration
+		}
+		// Concurrent calls can add up to more than the request took
+		other = max(other, 0)
+
+		fields := []zap.Field{
+			zap.String("method", c.Request.Method),
+			zap.String("route", route),
+			zap.Int("status", c.Writer.Status()),
+			zap.Duration("latency", latency),
+			zap.String("request_id", c.GetString("request_id")),
+		}
+		dominant, longest := otherComponent, other
+		for _, span := range spans {
+			fields = append(fields,
+				zap.Duration(span.Component+"_time", span.Duration),
+				zap.Int(span.Component+"_calls", span.Calls))
+			if span.Duration > longest {
+				dominant, longest = span.Component, span.Duration
+			}
+		}
+		fields = append(fields, zap.Duration("other_time", other), zap.String("dominant", dominant))
+		s.logger.Warn("slow request", fields...)
+
+		s.mu.Lock()
+		s.slow[route]++
+		for _, span := range spans {
+			s.spent[span.Component] += span.Duration
+		}
+		s.spent[otherComponent] += other
+		s.mu.Unlock()
+	}
+}
+
+// InFlight returns how many requests are being served
+func (s *SlowRequests) InFlight() int64 {
+	return s.inFlight.Load()
+}
+
+// WriteMetrics writes the in-flight gauge and slow request counters in the
+// OpenMetrics text format (implements kpi.Collector)
+func (s *SlowRequests) WriteMetrics(w io.Writer) {
+	s.mu.Lock()
+	routes := make([]string, 0, len(s.slow))
+	for route := range s.slow {
+		routes = append(routes, route)
+	}
+	sort.Strings(routes)
+	components := make([]string, 0, len(s.spent))
+	for component := range s.spent {
+		components = append(components, component)
+	}
+	sort.Strings(components)
+	slow := make([]int64, len(routes))
+	for i, route := range routes {
+		slow[i] = s.slow[route]
+	}
+	spent := make([]time.Duration, len(components))
+	for i, component := range components {
+		spent[i] = s.spent[component]
+	}
+	s.mu.Unlock()
+
+	fmt.Fprint(w, "# TYPE nexus_http_requests_in_flight gauge\n# HELP nexus_http_requests_in_flight Requests being served; falls to 0 as a shutdown drains connections.\n")
+	fmt.Fprintf(w, "nexus_http_requests_in_flight %d\n", s.InFlight())
+	fmt.Fprintf(w, "# TYPE nexus_http_slow_requests counter\n# HELP nexus_http_slow_requests Requests slower than %s, by route.\n", s.threshold)
+	for i, route := range routes {
+		fmt.Fprintf(w, "nexus_http_slow_requests_total{route=%q} %d\n", route, slow[i])
+	}
+	fmt.Fprint(w, "# TYPE nexus_http_slow_request_seconds counter\n# HELP nexus_http_slow_request_seconds Time slow requests spent per component (db, rpc, providers, other).\n")
+	for i, component := range components {
+		fmt.Fprintf(w, "nexus_http_slow_request_seconds_total{component=%q} %g\n", component, spent[i].Seconds())
+	}
+}
@@ -0,0 +1,74 @@
+package middleware_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/breaker"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/middleware"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/storage/guard"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/timing"
+)
+
+// slowPricingRepo takes delay to answer GetPricing
+type slowPricingRepo struct {
+	repository.PricingRepository
+	delay time.Duration
+}
+
+func (r *slowPricingRepo) GetPricing(context.Context, string) (*repository.Pricing, error) {
+	time.Sleep(r.delay)
+	return &repository.Pricing{}, nil
+}
+
+func TestSlowRequests_LogsBreakdown(t *testing.T) {
+	core, logs := observer.New(zap.WarnLevel)
+	slow := middleware.NewSlowRequests(20*time.Millisecond, zap.New(core))
+	repo := guard.NewGuardedPricingRepo(&slowPricingRepo{delay: 30 * time.Millisecond}, guard.New(time.Second, breaker.New("database", 100, time.Minute), nil))
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(slow.Handler())
+	router.GET("/pricing/:code", func(c *gin.Context) {
+		ctx := c.Request.Context()
+		_, err := repo.GetPricing(ctx, c.Param("code"))
+		require.NoError(t, err)
+		timing.Record(ctx, "stripe", 5*time.Millisecond)
+		c.Status(http.StatusOK)
+	})
+	router.GET("/fast", func(c *gin.Context) {
+		assert.Equal(t, int64(1), slow.InFlight())
+		c.Status(http.StatusOK)
+	})
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/fast", nil))
+	assert.Zero(t, logs.Len(), "fast requests are not logged")
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/pricing/kyc_verification", nil))
+	require.Equal(t, 1, logs.Len())
+	fields := logs.All()[0].ContextMap()
+	assert.Equal(t, "/pricing/:code", fields["route"])
+	assert.Equal(t, "db", fields["dominant"])
+	assert.GreaterOrEqual(t, fields["db_time"], 30*time.Millisecond)
+	assert.Equal(t, int64(1), fields["db_calls"])
+	assert.Equal(t, 5*time.Millisecond, fields["stripe_time"])
+	assert.Contains(t, fields, "other_time")
+	assert.Zero(t, slow.InFlight())
+
+	var metrics bytes.Buffer
+	slow.WriteMetrics(&metrics)
+	assert.Contains(t, metrics.String(), "nexus_http_requests_in_flight 0\n")
+	assert.Contains(t, metrics.String(), `nexus_http_slow_requests_total{route="/pricing/:code"} 1`)
+	assert.Contains(t, metrics.String(), `nexus_http_slow_request_seconds_total{component="stripe"} 0.005`)
+}
@@ -0,0 +1,78 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/apiversion"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/storage/guard"
+)
+
+// transientRetryAfter is the Retry-After sent with transient errors
+const transientRetryAfter = time.Second
+
+// transientMessage is the error message of transient errors
+const transientMessage = "Temporary database error, retry the request"
+
+// TransientErrors tells clients which failures are worth retrying. A 500
+// written after a database call failed transiently (see
+// guard.WithTransientFlag) becomes a 503 with Retry-After and a retryable
+// error: code "transient_error" in v2, "retryable": true in v1. Other 500s
+// stay permanent, as far as the client can tell.
+func TransientErrors() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, flag := guard.WithTransientFlag(c.Request.Context())
+		c.Request = c.Request.WithContext(ctx)
+		c.Writer = &transientWriter{ResponseWriter: c.Writer, c: c, flag: flag}
+		c.Next()
+	}
+}
+
+// transientWriter replaces a 500 response once the transient flag is set
+type transientWriter struct {
+	gin.ResponseWriter
+	c    *gin.Context
+	flag *atomic.Bool
+
+	// replaced is set once the handler's response is discarded
+	replaced bool
+}
+
+func (w *transientWriter) WriteHeader(code int) {
+	if code != http.StatusInternalServerError || !w.flag.Load() || w.Written() {
+		w.ResponseWriter.WriteHeader(code)
+		return
+	}
+	w.replaced = true
+
+	var body gin.H
+	if apiversion.FromContext(w.c) >= apiversion.V2 {
+		body = gin.H{"error": gin.H{"code": "transient_error", "message": transientMessage}}
+	} else {
+		body = gin.H{"success": false, "error": transientMessage, "retryable": true}
+	}
+	data, _ := json.Marshal(body)
+
+	setRetryAfter(w.c, transientRetryAfter)
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.ResponseWriter.WriteHeader(http.StatusServiceUnavailable)
+	w.ResponseWriter.Write(data)
+}
+
+func (w *transientWriter) Write(data []byte) (int, error) {
+	if w.replaced {
+		return len(data), nil
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *transientWriter) WriteString(s string) (int, error) {
+	if w.replaced {
+		return len(s), nil
+	}
+	return w.ResponseWriter.WriteString(s)
+}
@@ -0,0 +1,84 @@
+package middleware_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/apiversion"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/breaker"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/middleware"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/storage/guard"
+)
+
+// failingPricingRepo fails every GetPricing with err
+type failingPricingRepo struct {
+	repository.PricingRepository
+	err error
+}
+
+func (r *failingPricingRepo) GetPricing(context.Context, string) (*repository.Pricing, error) {
+	return nil, r.err
+}
+
+func setupTransientRouter() *gin.Engine {
+	errSerialization := errors.New("pq: could not serialize access")
+	g := guard.New(time.Second, breaker.New("database", 100, time.Minute), nil)
+	g.SetRetries(1, func(err error) bool { return errors.Is(err, errSerialization) })
+	transient := guard.NewGuardedPricingRepo(&failingPricingRepo{err: errSerialization}, g)
+	permanent := guard.NewGuardedPricingRepo(&failingPricingRepo{err: errors.New("pq: syntax error")}, g)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.TransientErrors())
+	versions := apiversion.NewRegistry(router)
+	for _, v := range []apiversion.Version{apiversion.V1, apiversion.V2} {
+		api := versions.Group(v, apiversion.Policy{})
+		for path, repo := range map[string]repository.PricingRepository{"/transient": transient, "/permanent": permanent} {
+			api.GET(path, func(c *gin.Context) {
+				if _, err := repo.GetPricing(c.Request.Context(), "kyc_verification"); err != nil {
+					c.JSON(http.StatusInternalServerError, gin.H{"success": false, "error": "Failed to get pricing"})
+					return
+				}
+				c.Status(http.StatusOK)
+			})
+		}
+	}
+	return router
+}
+
+func TestTransientErrors(t *testing.T) {
+	router := setupTransientRouter()
+	get := func(path string) (*httptest.ResponseRecorder, map[string]interface{}) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		var body map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		return w, body
+	}
+
+	w, body := get("/api/v1/transient")
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Equal(t, "1", w.Header().Get("Retry-After"))
+	assert.Equal(t, true, body["retryable"])
+	assert.Equal(t, false, body["success"])
+
+	w, body = get("/api/v2/transient")
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Equal(t, "transient_error", body["error"].(map[string]interface{})["code"])
+
+	w, body = get("/api/v1/permanent")
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Empty(t, w.Header().Get("Retry-After"))
+	assert.Equal(t, "Failed to get pricing", body["error"])
+	assert.Nil(t, body["retryable"])
+}
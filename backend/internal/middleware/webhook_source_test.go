@@ -0,0 +1,122 @@
+package middleware_test
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/middleware"
+)
+
+func webhookRouter(t *testing.T, cfg middleware.WebhookSourceConfig) (*gin.Engine, *observer.ObservedLogs) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	core, logs := observer.New(zap.WarnLevel)
+
+	source, err := middleware.WebhookSource(cfg, zap.New(core))
+	require.NoError(t, err)
+
+	router := gin.New()
+	router.POST("/webhook", source, func(c *gin.Context) { c.String(http.StatusOK, "ok") })
+	return router, logs
+}
+
+func deliver(router *gin.Engine, remoteAddr, forwardedFor string, state *tls.ConnectionState) int {
+	req := httptest.NewRequest(http.MethodPost, "/webhook", nil)
+	req.RemoteAddr = remoteAddr
+	if forwardedFor != "" {
+		req.Header.Set("X-Forwarded-For", forwardedFor)
+	}
+	req.TLS = state
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	return resp.Code
+}
+
+func TestWebhookSource_AllowlistsSourceIPs(t *testing.T) {
+	router, logs := webhookRouter(t, middleware.WebhookSourceConfig{
+		Integration: "stripe",
+		AllowedIPs:  []string{"54.187.174.169", "10.20.0.0/16"},
+	})
+
+	assert.Equal(t, http.StatusOK, deliver(router, "54.187.174.169:443", "", nil))
+	assert.Equal(t, http.StatusOK, deliver(router, "10.20.3.4:5000", "", nil))
+	assert.Equal(t, http.StatusForbidden, deliver(router, "203.0.113.9:5000", "", nil))
+
+	// A spoofed X-Forwarded-For from an untrusted peer is ignored
+	assert.Equal(t, http.StatusForbidden, deliver(router, "203.0.113.9:5000", "54.187.174.169", nil))
+
+	require.Equal(t, 2, logs.Len())
+	entry := logs.All()[0]
+	assert.Equal(t, "webhook request rejected", entry.Message)
+	fields := entry.ContextMap()
+	assert.Equal(t, "stripe", fields["integration"])
+	assert.Equal(t, "source_ip_not_allowed", fields["reason"])
+	assert.Equal(t, "203.0.113.9", fields["source_ip"])
+}
+
+func TestWebhookSource_TrustedProxies(t *testing.T) {
+	router, _ := webhookRouter(t, middleware.WebhookSourceConfig{
+		Integration:    "sumsub",
+		AllowedIPs:     []string{"198.51.100.7"},
+		TrustedProxies: []string{"10.0.0.0/8"},
+	})
+
+	assert.Equal(t, http.StatusOK, deliver(router, "10.0.0.2:5000", "198.51.100.7", nil))
+	assert.Equal(t, http.StatusOK, deliver(router, "10.0.0.2:5000", "198.51.100.7, 10.0.0.9", nil))
+
+	// Only the right-most untrusted hop counts; entries left of it are client-supplied
+	assert.Equal(t, http.StatusForbidden, deliver(router, "10.0.0.2:5000", "198.51.100.7, 203.0.113.9", nil))
+	assert.Equal(t, http.StatusForbidden, deliver(router, "10.0.0.2:5000", "", nil))
+}
+
+func TestWebhookSource_RequiresClientCert(t *testing.T) {
+	router, logs := webhookRouter(t, middleware.WebhookSourceConfig{
+		Integration:       "sumsub",
+		RequireClientCert: true,
+		ClientCertNames:   []string{"webhooks.sumsub.example"},
+	})
+
+	verified := func(cert *x509.Certificate) *tls.ConnectionState {
+		return &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}}
+	}
+
+	assert.Equal(t, http.StatusForbidden, deliver(router, "203.0.113.9:5000", "", nil))
+	assert.Equal(t, http.StatusForbidden, deliver(router, "203.0.113.9:5000", "", &tls.ConnectionState{}))
+	assert.Equal(t, http.StatusOK, deliver(router, "203.0.113.9:5000", "",
+		verified(&x509.Certificate{Subject: pkix.Name{CommonName: "Webhooks.Sumsub.Example"}})))
+	assert.Equal(t, http.StatusOK, deliver(router, "203.0.113.9:5000", "",
+		verified(&x509.Certificate{Subject: pkix.Name{CommonName: "sumsub"}, DNSNames: []string{"webhooks.sumsub.example"}})))
+	assert.Equal(t, http.StatusForbidden, deliver(router, "203.0.113.9:5000", "",
+		verified(&x509.Certificate{Subject: pkix.Name{CommonName: "someone-else"}})))
+
+	reasons := make([]interface{}, 0, logs.Len())
+	for _, entry := range logs.All() {
+		reasons = append(reasons, entry.ContextMap()["reason"])
+	}
+	assert.Equal(t, []interface{}{
+		"client_certificate_missing",
+		"client_certificate_missing",
+		"client_certificate_not_allowed",
+	}, reasons)
+}
+
+func TestWebhookSource_DisabledAndInvalidConfig(t *testing.T) {
+	router, _ := webhookRouter(t, middleware.WebhookSourceConfig{Integration: "stripe"})
+	assert.Equal(t, http.StatusOK, deliver(router, "203.0.113.9:5000", "", nil))
+
+	_, err := middleware.WebhookSource(middleware.WebhookSourceConfig{
+		Integration: "stripe",
+		AllowedIPs:  []string{"not-an-ip"},
+	}, zap.NewNop())
+	assert.ErrorContains(t, err, "stripe webhook allowlist")
+}
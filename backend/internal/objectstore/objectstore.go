@@ -0,0 +1,58 @@
+// Package objectstore keeps compliance documents and exports in an object
+// store. S3 speaks the S3 API, which AWS, MinIO and Google Cloud Storage
+// (its XML API with HMAC keys) all serve; Memory holds objects in process for
+// development and tests.
+package objectstore
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+)
+
+// Object storage errors
+var (
+	ErrNotFound           = errors.New("object not found")
+	ErrPresignUnsupported = errors.New("store cannot presign URLs")
+)
+
+// MaxPresignTTL is the longest a presigned URL may stay valid (S3's limit)
+const MaxPresignTTL = 7 * 24 * time.Hour
+
+// Store reads and writes objects by key
+type Store interface {
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+
+	// PresignPut returns a URL a client can PUT the object to, without
+	// credentials, until ttl passes
+	PresignPut(ctx context.Context, key string, ttl time.Duration) (string, error)
+	// PresignGet returns a URL the object can be downloaded from until ttl
+	// passes
+	PresignGet(ctx context.Context, key string, ttl time.Duration) (string, error)
+}
+
+// LifecycleRule expires the objects under Prefix ExpireDays after they are
+// written
+type LifecycleRule struct {
+	ID         string
+	Prefix     string
+	ExpireDays int
+}
+
+// Key prefixes of the stored document kinds
+const (
+	PrefixKYC     = "kyc/"
+	PrefixExports = "exports/"
+	PrefixNFT     = "nft/"
+)
+
+// DefaultLifecycle keeps KYC documents for the five years AML record-keeping
+// rules require after the relationship ends (plus a margin) and exports for
+// 30 days
+var DefaultLifecycle = []LifecycleRule{
+	{ID: "kyc-documents", Prefix: PrefixKYC, ExpireDays: 7 * 365},
+	{ID: "exports", Prefix: PrefixExports, ExpireDays: 30},
+}
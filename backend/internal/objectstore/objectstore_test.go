@@ -0,0 +1,199 @@
+package objectstore_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/awsv4"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/objectstore"
+)
+
+// fakeS3 is a path-style bucket that records the requests it serves
+type fakeS3 struct {
+	mu       sync.Mutex
+	objects  map[string][]byte
+	requests []*http.Request
+	bodies   []string
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requests = append(f.requests, r)
+	f.bodies = append(f.bodies, string(body))
+
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 ") {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = io.WriteString(w, `<Error><Code>AccessDenied</Code><Message>Access Denied</Message></Error>`)
+		return
+	}
+	if r.URL.RawQuery != "" {
+		// bucket configuration
+		return
+	}
+	switch r.Method {
+	case http.MethodPut:
+		f.objects[r.URL.Path] = body
+	case http.MethodGet:
+		b, ok := f.objects[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = io.WriteString(w, `<Error><Code>NoSuchKey</Code><Message>The specified key does not exist.</Message></Error>`)
+			return
+		}
+		_, _ = w.Write(b)
+	case http.MethodDelete:
+		delete(f.objects, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (f *fakeS3) last() (*http.Request, string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.requests[len(f.requests)-1], f.bodies[len(f.bodies)-1]
+}
+
+func newS3(t *testing.T, sse, kmsKey string) (*objectstore.S3, *fakeS3) {
+	fake := &fakeS3{objects: map[string][]byte{}}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+	store := &objectstore.S3{
+		Client: &awsv4.Client{
+			Service:     "s3",
+			Region:      "us-east-1",
+			Endpoint:    server.URL,
+			Credentials: awsv4.Credentials{AccessKeyID: "AKIDEXAMPLE", SecretAccessKey: "secret"},
+		},
+		Bucket:    "compliance",
+		PathStyle: true,
+		SSE:       sse,
+		KMSKeyID:  kmsKey,
+	}
+	require.NoError(t, store.Validate())
+	return store, fake
+}
+
+func TestS3_PutGetDelete(t *testing.T) {
+	ctx := context.Background()
+	store, fake := newS3(t, "", "")
+	const key = "kyc/accreditation/0xabc/att-1"
+
+	require.NoError(t, store.Put(ctx, key, strings.NewReader("%PDF-1.4"), 8, "application/pdf"))
+	req, _ := fake.last()
+	assert.Equal(t, "/compliance/"+key, req.URL.Path)
+	assert.Equal(t, objectstore.SSES3, req.Header.Get("X-Amz-Server-Side-Encryption"))
+	assert.Equal(t, "application/pdf", req.Header.Get("Content-Type"))
+	assert.Len(t, req.Header.Get("X-Amz-Content-Sha256"), 64)
+	assert.Contains(t, req.Header.Get("Authorization"), "x-amz-server-side-encryption")
+
+	body, err := store.Get(ctx, key)
+	require.NoError(t, err)
+	b, _ := io.ReadAll(body)
+	require.NoError(t, body.Close())
+	assert.Equal(t, "%PDF-1.4", string(b))
+
+	require.NoError(t, store.Delete(ctx, key))
+	_, err = store.Get(ctx, key)
+	assert.ErrorIs(t, err, objectstore.ErrNotFound)
+
+	assert.Error(t, store.Put(ctx, key, strings.NewReader("short"), 8, "application/pdf"), "size mismatch")
+}
+
+func TestS3_KMSEncryption(t *testing.T) {
+	store, fake := newS3(t, objectstore.SSEKMS, "alias/compliance")
+	require.NoError(t, store.Put(context.Background(), "exports/report.csv", strings.NewReader("a,b"), -1, "text/csv"))
+	req, _ := fake.last()
+	assert.Equal(t, "aws:kms", req.Header.Get("X-Amz-Server-Side-Encryption"))
+	assert.Equal(t, "alias/compliance", req.Header.Get("X-Amz-Server-Side-Encryption-Aws-Kms-Key-Id"))
+
+	require.NoError(t, store.ApplyEncryption(context.Background()))
+	req, body := fake.last()
+	assert.Equal(t, "encryption=", req.URL.RawQuery)
+	assert.Contains(t, body, "<SSEAlgorithm>aws:kms</SSEAlgorithm><KMSMasterKeyID>alias/compliance</KMSMasterKeyID>")
+	assert.NotEmpty(t, req.Header.Get("Content-MD5"))
+}
+
+func TestS3_ErrorsCarryTheS3Code(t *testing.T) {
+	store, _ := newS3(t, "", "")
+	store.Client.HTTPClient = &http.Client{Transport: stripAuth{}}
+
+	err := store.Put(context.Background(), "kyc/x", strings.NewReader("x"), 1, "")
+	var apiErr *awsv4.APIError
+	require.True(t, errors.As(err, &apiErr), err)
+	assert.Equal(t, http.StatusForbidden, apiErr.StatusCode)
+	assert.Equal(t, "AccessDenied", apiErr.Type)
+}
+
+// stripAuth drops the Authorization header, as a misconfigured proxy would
+type stripAuth struct{}
+
+func (stripAuth) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Del("Authorization")
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestS3_ApplyLifecycle(t *testing.T) {
+	store, fake := newS3(t, "", "")
+	require.NoError(t, store.ApplyLifecycle(context.Background(), objectstore.DefaultLifecycle))
+	req, body := fake.last()
+	assert.Equal(t, http.MethodPut, req.Method)
+	assert.Equal(t, "/compliance/", req.URL.Path)
+	assert.Equal(t, "lifecycle=", req.URL.RawQuery)
+	assert.NotEmpty(t, req.Header.Get("Content-MD5"))
+	assert.Contains(t, body, "<Rule><ID>kyc-documents</ID><Filter><Prefix>kyc/</Prefix></Filter><Status>Enabled</Status><Expiration><Days>2555</Days></Expiration></Rule>")
+	assert.Contains(t, body, "<Prefix>exports/</Prefix>")
+
+	assert.Error(t, store.ApplyLifecycle(context.Background(), []objectstore.LifecycleRule{{ID: "bad", Prefix: "tmp/"}}))
+}
+
+func TestS3_Presign(t *testing.T) {
+	ctx := context.Background()
+	store, _ := newS3(t, "", "")
+	store.PathStyle = false
+	store.Client.Endpoint = "https://s3.us-east-1.amazonaws.com"
+
+	raw, err := store.PresignGet(ctx, "kyc/accreditation/0xabc/att 1", 5*time.Minute)
+	require.NoError(t, err)
+	u, err := url.Parse(raw)
+	require.NoError(t, err)
+	assert.Equal(t, "compliance.s3.us-east-1.amazonaws.com", u.Host)
+	assert.Equal(t, "/kyc/accreditation/0xabc/att%201", u.EscapedPath())
+	q := u.Query()
+	assert.Equal(t, "300", q.Get("X-Amz-Expires"))
+	assert.Equal(t, "host", q.Get("X-Amz-SignedHeaders"))
+	assert.Len(t, q.Get("X-Amz-Signature"), 64)
+
+	_, err = store.PresignPut(ctx, "kyc/x", objectstore.MaxPresignTTL+time.Second)
+	assert.Error(t, err)
+}
+
+func TestMemory(t *testing.T) {
+	ctx := context.Background()
+	store := objectstore.NewMemory()
+	require.NoError(t, store.Put(ctx, "kyc/a", strings.NewReader("doc"), 3, "application/pdf"))
+	body, err := store.Get(ctx, "kyc/a")
+	require.NoError(t, err)
+	b, _ := io.ReadAll(body)
+	assert.Equal(t, "doc", string(b))
+
+	require.NoError(t, store.Delete(ctx, "kyc/a"))
+	_, err = store.Get(ctx, "kyc/a")
+	assert.ErrorIs(t, err, objectstore.ErrNotFound)
+
+	_, err = store.PresignGet(ctx, "kyc/a", time.Minute)
+	assert.ErrorIs(t, err, objectstore.ErrPresignUnsupported)
+}
@@ -0,0 +1,280 @@
+package objectstore
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/awsv4"
+)
+
+var _ Store = (*S3)(nil)
+
+// Server-side encryption modes
+const (
+	SSES3   = "AES256"  // keys managed by the store
+	SSEKMS  = "aws:kms" // keys in KMS (KMSKeyID, or the account's default key)
+	SSENone = "none"    // for stores that always encrypt and reject the header (GCS)
+)
+
+// S3 stores objects in one bucket over the S3 API. Client.Service must be
+// "s3"; set Client.Endpoint for MinIO (with PathStyle) or GCS
+// (https://storage.googleapis.com).
+type S3 struct {
+	Client    *awsv4.Client
+	Bucket    string
+	PathStyle bool   // https://endpoint/bucket/key rather than https://bucket.endpoint/key
+	SSE       string // SSES3 if empty
+	KMSKeyID  string
+}
+
+// Validate reports missing settings
+func (s *S3) Validate() error {
+	if s.Client == nil || s.Bucket == "" {
+		return errors.New("S3 requires a client and a bucket")
+	}
+	switch s.SSE {
+	case "", SSES3, SSEKMS, SSENone:
+	default:
+		return fmt.Errorf("unknown server-side encryption %q (%s, %s or %s)", s.SSE, SSES3, SSEKMS, SSENone)
+	}
+	return s.Client.Validate()
+}
+
+// objectURL returns the URL of key, or of the bucket when key is empty
+func (s *S3) objectURL(key string) (*url.URL, error) {
+	endpoint := s.Client.Endpoint
+	if endpoint == "" {
+		endpoint = "https://s3." + s.Client.Region + ".amazonaws.com"
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	segments := strings.Split(key, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	path := "/" + strings.Join(segments, "/")
+	if s.PathStyle {
+		path = "/" + url.PathEscape(s.Bucket) + path
+	} else {
+		u.Host = s.Bucket + "." + u.Host
+	}
+	u.Path, err = url.PathUnescape(path)
+	if err != nil {
+		return nil, err
+	}
+	u.RawPath = path
+	return u, nil
+}
+
+func (s *S3) sse() string {
+	if s.SSE == "" {
+		return SSES3
+	}
+	return s.SSE
+}
+
+// do signs and sends a request, returning the response when its status is
+// 2xx and an *awsv4.APIError (or ErrNotFound) otherwise
+func (s *S3) do(ctx context.Context, method string, u *url.URL, body []byte, header http.Header) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	for name := range header {
+		req.Header.Set(name, header.Get(name))
+	}
+	bodyHash := sha256.Sum256(body)
+	req.Header.Set("X-Amz-Content-Sha256", hex.EncodeToString(bodyHash[:]))
+	if s.Client.Credentials.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", s.Client.Credentials.SessionToken)
+	}
+	s.Client.Sign(req, body, time.Now().UTC())
+
+	httpClient := s.Client.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 200 && resp.StatusCode <= 299 {
+		return resp, nil
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound && method != http.MethodPut {
+		return nil, ErrNotFound
+	}
+	apiErr := &awsv4.APIError{StatusCode: resp.StatusCode}
+	var answer struct {
+		Code    string `xml:"Code"`
+		Message string `xml:"Message"`
+	}
+	if respBody, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20)); err == nil && xml.Unmarshal(respBody, &answer) == nil {
+		apiErr.Type = answer.Code
+		apiErr.Message = answer.Message
+	}
+	return nil, apiErr
+}
+
+// Put uploads an object, encrypted at rest with the configured SSE mode.
+// The body is buffered, since S3 signs its hash.
+func (s *S3) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	u, err := s.objectURL(key)
+	if err != nil {
+		return err
+	}
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return err
+	}
+	if size >= 0 && int64(len(data)) != size {
+		return fmt.Errorf("read %d bytes of %s, expected %d", len(data), key, size)
+	}
+	header := http.Header{}
+	if contentType != "" {
+		header.Set("Content-Type", contentType)
+	}
+	if sse := s.sse(); sse != SSENone {
+		header.Set("X-Amz-Server-Side-Encryption", sse)
+		if sse == SSEKMS && s.KMSKeyID != "" {
+			header.Set("X-Amz-Server-Side-Encryption-Aws-Kms-Key-Id", s.KMSKeyID)
+		}
+	}
+	resp, err := s.do(ctx, http.MethodPut, u, data, header)
+	if err != nil {
+		return fmt.Errorf("put %s: %w", key, err)
+	}
+	return resp.Body.Close()
+}
+
+// Get downloads an object; the caller closes the body
+func (s *S3) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	u, err := s.objectURL(key)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.do(ctx, http.MethodGet, u, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("get %s: %w", key, err)
+	}
+	return resp.Body, nil
+}
+
+// Delete removes an object; deleting a missing object is not an error
+func (s *S3) Delete(ctx context.Context, key string) error {
+	u, err := s.objectURL(key)
+	if err != nil {
+		return err
+	}
+	resp, err := s.do(ctx, http.MethodDelete, u, nil, nil)
+	if errors.Is(err, ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("delete %s: %w", key, err)
+	}
+	return resp.Body.Close()
+}
+
+// PresignPut returns a presigned upload URL. Only the host is signed, so the
+// upload cannot carry SSE headers; ApplyEncryption sets the bucket default
+// that covers it.
+func (s *S3) PresignPut(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	return s.presign(http.MethodPut, key, ttl)
+}
+
+// PresignGet returns a presigned download URL
+func (s *S3) PresignGet(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	return s.presign(http.MethodGet, key, ttl)
+}
+
+func (s *S3) presign(method, key string, ttl time.Duration) (string, error) {
+	if ttl <= 0 || ttl > MaxPresignTTL {
+		return "", fmt.Errorf("presigned URL lifetime must be between 1s and %s", MaxPresignTTL)
+	}
+	u, err := s.objectURL(key)
+	if err != nil {
+		return "", err
+	}
+	return s.Client.Presign(method, u.String(), ttl, time.Now().UTC())
+}
+
+// ApplyLifecycle replaces the bucket's lifecycle configuration with rules
+func (s *S3) ApplyLifecycle(ctx context.Context, rules []LifecycleRule) error {
+	type rule struct {
+		ID     string `xml:"ID"`
+		Prefix string `xml:"Filter>Prefix"`
+		Status string `xml:"Status"`
+		Days   int    `xml:"Expiration>Days"`
+	}
+	config := struct {
+		XMLName xml.Name `xml:"LifecycleConfiguration"`
+		Rules   []rule   `xml:"Rule"`
+	}{}
+	for _, r := range rules {
+		if r.ExpireDays <= 0 {
+			return fmt.Errorf("lifecycle rule %s: expiry must be at least one day", r.ID)
+		}
+		config.Rules = append(config.Rules, rule{ID: r.ID, Prefix: r.Prefix, Status: "Enabled", Days: r.ExpireDays})
+	}
+	return s.putBucketConfig(ctx, "lifecycle", config)
+}
+
+// ApplyEncryption sets the bucket's default encryption to the configured SSE
+// mode, so objects uploaded through presigned URLs are encrypted too
+func (s *S3) ApplyEncryption(ctx context.Context) error {
+	sse := s.sse()
+	if sse == SSENone {
+		return nil
+	}
+	type byDefault struct {
+		Algorithm string `xml:"SSEAlgorithm"`
+		KMSKeyID  string `xml:"KMSMasterKeyID,omitempty"`
+	}
+	config := struct {
+		XMLName xml.Name  `xml:"ServerSideEncryptionConfiguration"`
+		Default byDefault `xml:"Rule>ApplyServerSideEncryptionByDefault"`
+	}{Default: byDefault{Algorithm: sse}}
+	if sse == SSEKMS {
+		config.Default.KMSKeyID = s.KMSKeyID
+	}
+	return s.putBucketConfig(ctx, "encryption", config)
+}
+
+// putBucketConfig PUTs an XML bucket subresource (?lifecycle, ?encryption),
+// which S3 requires a Content-MD5 for
+func (s *S3) putBucketConfig(ctx context.Context, subresource string, config interface{}) error {
+	body, err := xml.Marshal(config)
+	if err != nil {
+		return err
+	}
+	u, err := s.objectURL("")
+	if err != nil {
+		return err
+	}
+	u.RawQuery = subresource + "="
+	sum := md5.Sum(body)
+	header := http.Header{}
+	header.Set("Content-Type", "application/xml")
+	header.Set("Content-MD5", base64.StdEncoding.EncodeToString(sum[:]))
+	resp, err := s.do(ctx, http.MethodPut, u, body, header)
+	if err != nil {
+		return fmt.Errorf("put bucket %s: %w", subresource, err)
+	}
+	return resp.Body.Close()
+}
@@ -0,0 +1,273 @@
+// Package oracle reads USD prices from Chainlink aggregators. Feed addresses
+// come from the contract registry (ethUsdPriceFeed and nexusUsdPriceFeed on
+// the server's chain). Rounds are cached for oracle.cache_seconds and
+// rejected once their last update is older than oracle.max_age_seconds
+// (app_config namespace "oracle"); while a feed cannot be read, the last
+// good round is served until it goes stale.
+package oracle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// namespace is the app_config namespace holding the oracle settings
+const namespace = "oracle"
+
+// Defaults for settings missing from app_config
+const (
+	defaultCacheTTL = 30 * time.Second
+	defaultMaxAge   = time.Hour // Chainlink's ETH/USD heartbeat on mainnet
+)
+
+// Pair is a price pair, quoted as base-quote
+type Pair string
+
+const (
+	PairETHUSD   Pair = "ETH-USD"
+	PairNEXUSUSD Pair = "NEXUS-USD"
+)
+
+// feedDBNames are the contract registry names of each pair's aggregator
+var feedDBNames = map[Pair]string{
+	PairETHUSD:   "ethUsdPriceFeed",
+	PairNEXUSUSD: "nexusUsdPriceFeed",
+}
+
+// Oracle errors
+var (
+	ErrUnknownPair  = errors.New("unknown price pair")
+	ErrNoFeed       = errors.New("no price feed configured for this pair")
+	ErrStalePrice   = errors.New("price feed round is stale")
+	ErrInvalidPrice = errors.New("price feed answer is not positive")
+	ErrUnavailable  = errors.New("price feed unavailable")
+)
+
+// aggregatorABIJSON is the part of Chainlink's AggregatorV3Interface the
+// oracle calls
+const aggregatorABIJSON = `[
+	{"type":"function","name":"decimals","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"uint8"}]},
+	{"type":"function","name":"latestRoundData","stateMutability":"view","inputs":[],"outputs":[
+		{"name":"roundId","type":"uint80"},
+		{"name":"answer","type":"int256"},
+		{"name":"startedAt","type":"uint256"},
+		{"name":"updatedAt","type":"uint256"},
+		{"name":"answeredInRound","type":"uint80"}]}
+]`
+
+var aggregatorABI = mustParseABI(aggregatorABIJSON)
+
+func mustParseABI(s string) abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(s))
+	if err != nil {
+		panic(fmt.Sprintf("oracle: invalid aggregator ABI: %v", err))
+	}
+	return parsed
+}
+
+// Client is the chain access the oracle needs (implemented by
+// chain.FailoverClient)
+type Client interface {
+	CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error)
+}
+
+// Price is an aggregator's latest round for a pair
+type Price struct {
+	Pair      Pair      `json:"pair"`
+	Price     string    `json:"price"`  // decimal, e.g. "3521.07"
+	Answer    string    `json:"answer"` // raw answer, Price scaled by 10^Decimals
+	Decimals  uint8     `json:"decimals"`
+	RoundID   string    `json:"round_id"`
+	Feed      string    `json:"feed"`
+	UpdatedAt time.Time `json:"updated_at"` // when the round was last updated on-chain
+	FetchedAt time.Time `json:"fetched_at"`
+
+	answer *big.Int
+}
+
+// Rat returns the price as an exact fraction
+func (p *Price) Rat() *big.Rat {
+	return new(big.Rat).SetFrac(p.answer, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(p.Decimals)), nil))
+}
+
+// Settings are the oracle settings in effect
+type Settings struct {
+	CacheTTL time.Duration `json:"cache_ttl"`
+	MaxAge   time.Duration `json:"max_age"`
+}
+
+// Service reads and caches aggregator prices
+type Service struct {
+	contracts  repository.ContractRepository
+	configRepo repository.AppConfigRepository
+	client     Client
+	logger     *zap.Logger
+	chainID    int64
+	now        func() time.Time
+
+	mu    sync.Mutex
+	cache map[Pair]*Price
+}
+
+// NewService creates a new price oracle for the feeds on chainID
+func NewService(contracts repository.ContractRepository, configRepo repository.AppConfigRepository, logger *zap.Logger, chainID int64) *Service {
+	return &Service{
+		contracts:  contracts,
+		configRepo: configRepo,
+		logger:     logger,
+		chainID:    chainID,
+		now:        time.Now,
+		cache:      make(map[Pair]*Price),
+	}
+}
+
+// SetClient sets the chain client the aggregators are read through
+func (s *Service) SetClient(client Client) {
+	s.client = client
+}
+
+// ParsePair parses a pair such as "ETH-USD", "eth_usd" or "ETH/USD"
+func ParsePair(s string) (Pair, error) {
+	pair := Pair(strings.NewReplacer("_", "-", "/", "-").Replace(strings.ToUpper(strings.TrimSpace(s))))
+	if _, ok := feedDBNames[pair]; !ok {
+		return "", ErrUnknownPair
+	}
+	return pair, nil
+}
+
+// Settings loads the oracle settings, falling back to defaults for missing
+// or unreadable ones
+func (s *Service) Settings(ctx context.Context) Settings {
+	settings := Settings{CacheTTL: defaultCacheTTL, MaxAge: defaultMaxAge}
+	if seconds, err := s.configRepo.GetNumber(ctx, namespace, "cache_seconds", s.chainID); err == nil && seconds >= 0 {
+		settings.CacheTTL = time.Duration(seconds) * time.Second
+	}
+	if seconds, err := s.configRepo.GetNumber(ctx, namespace, "max_age_seconds", s.chainID); err == nil && seconds > 0 {
+		settings.MaxAge = time.Duration(seconds) * time.Second
+	}
+	return settings
+}
+
+// Price returns the latest round of pair, from the cache if it was fetched
+// within the cache TTL
+func (s *Service) Price(ctx context.Context, pair Pair) (*Price, error) {
+	if _, ok := feedDBNames[pair]; !ok {
+		return nil, ErrUnknownPair
+	}
+	settings := s.Settings(ctx)
+	now := s.now()
+
+	s.mu.Lock()
+	cached := s.cache[pair]
+	s.mu.Unlock()
+	if cached != nil && now.Sub(cached.FetchedAt) < settings.CacheTTL && now.Sub(cached.UpdatedAt) <= settings.MaxAge {
+		cp := *cached
+		return &cp, nil
+	}
+
+	p, err := s.fetch(ctx, pair, now)
+	if err == nil && now.Sub(p.UpdatedAt) > settings.MaxAge {
+		err = ErrStalePrice
+	}
+	if err != nil {
+		if cached != nil && now.Sub(cached.UpdatedAt) <= settings.MaxAge && !errors.Is(err, ErrNoFeed) {
+			s.logger.Warn("price feed read failed, serving cached round", zap.String("pair", string(pair)), zap.Error(err))
+			cp := *cached
+			return &cp, nil
+		}
+		return nil, err
+	}
+
+	s.mu.Lock()
+	s.cache[pair] = p
+	s.mu.Unlock()
+	cp := *p
+	return &cp, nil
+}
+
+// fetch reads the latest round of pair's aggregator
+func (s *Service) fetch(ctx context.Context, pair Pair, now time.Time) (*Price, error) {
+	feed, err := s.contracts.GetByChainAndDBName(ctx, s.chainID, feedDBNames[pair])
+	if errors.Is(err, repository.ErrContractAddressNotFound) {
+		return nil, ErrNoFeed
+	}
+	if err != nil {
+		return nil, fmt.Errorf("loading %s feed: %w", pair, err)
+	}
+	if s.client == nil {
+		return nil, ErrUnavailable
+	}
+	address := common.HexToAddress(feed.Address)
+
+	out, err := s.call(ctx, address, "decimals")
+	if err != nil {
+		return nil, err
+	}
+	decimals, ok := out[0].(uint8)
+	if !ok {
+		return nil, fmt.Errorf("%w: decimals returned %T", ErrUnavailable, out[0])
+	}
+
+	out, err = s.call(ctx, address, "latestRoundData")
+	if err != nil {
+		return nil, err
+	}
+	roundID, _ := out[0].(*big.Int)
+	answer, _ := out[1].(*big.Int)
+	updatedAt, _ := out[3].(*big.Int)
+	answeredInRound, _ := out[4].(*big.Int)
+	if roundID == nil || answer == nil || updatedAt == nil || answeredInRound == nil {
+		return nil, fmt.Errorf("%w: unexpected latestRoundData output", ErrUnavailable)
+	}
+	if answer.Sign() <= 0 {
+		return nil, ErrInvalidPrice
+	}
+	if updatedAt.Sign() == 0 || !updatedAt.IsInt64() || answeredInRound.Cmp(roundID) < 0 {
+		return nil, ErrStalePrice
+	}
+
+	p := &Price{
+		Pair:      pair,
+		Answer:    answer.String(),
+		Decimals:  decimals,
+		RoundID:   roundID.String(),
+		Feed:      strings.ToLower(address.Hex()),
+		UpdatedAt: time.Unix(updatedAt.Int64(), 0).UTC(),
+		FetchedAt: now,
+		answer:    answer,
+	}
+	p.Price = p.Rat().FloatString(int(decimals))
+	if decimals > 0 {
+		p.Price = strings.TrimRight(strings.TrimRight(p.Price, "0"), ".")
+	}
+	return p, nil
+}
+
+// call calls a read-only aggregator method
+func (s *Service) call(ctx context.Context, address common.Address, method string) ([]interface{}, error) {
+	data, err := aggregatorABI.Pack(method)
+	if err != nil {
+		return nil, err
+	}
+	result, err := s.client.CallContract(ctx, ethereum.CallMsg{To: &address, Data: data}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", ErrUnavailable, method, err)
+	}
+	out, err := aggregatorABI.Unpack(method, result)
+	if err != nil || len(out) == 0 {
+		return nil, fmt.Errorf("%w: decoding %s: %v", ErrUnavailable, method, err)
+	}
+	return out, nil
+}
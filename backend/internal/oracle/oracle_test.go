@@ -0,0 +1,224 @@
+package oracle_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/oracle"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/storage/memory"
+)
+
+const (
+	chainID = 31337
+
+	ethFeed   = "0x00000000000000000000000000000000000000e1"
+	nexusFeed = "0x00000000000000000000000000000000000000e2"
+)
+
+var (
+	decimalsSelector        = []byte{0x31, 0x3c, 0xe5, 0x67}
+	latestRoundDataSelector = []byte{0xfe, 0xaf, 0x96, 0x8c}
+)
+
+// round is an aggregator's latest round
+type round struct {
+	decimals        uint8
+	roundID         int64
+	answer          int64
+	updatedAt       int64
+	answeredInRound int64
+}
+
+// fakeAggregators answers decimals and latestRoundData per feed address
+type fakeAggregators struct {
+	mu     sync.Mutex
+	rounds map[common.Address]round
+	calls  int
+	err    error
+}
+
+func (f *fakeAggregators) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls++
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	r := f.rounds[*call.To]
+	switch {
+	case bytes.Equal(call.Data, decimalsSelector):
+		return words(big.NewInt(int64(r.decimals))), nil
+	case bytes.Equal(call.Data, latestRoundDataSelector):
+		return words(big.NewInt(r.roundID), big.NewInt(r.answer), big.NewInt(r.updatedAt), big.NewInt(r.updatedAt), big.NewInt(r.answeredInRound)), nil
+	}
+	return nil, errors.New("unexpected call")
+}
+
+func (f *fakeAggregators) set(feed string, r round) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.rounds[common.HexToAddress(feed)] = r
+}
+
+// words ABI-encodes non-negative values
+func words(values ...*big.Int) []byte {
+	var out []byte
+	for _, v := range values {
+		out = append(out, common.LeftPadBytes(v.Bytes(), 32)...)
+	}
+	return out
+}
+
+func newService(t *testing.T) (*oracle.Service, *fakeAggregators, *memory.Store) {
+	t.Helper()
+	ctx := context.Background()
+	store := memory.NewSeededStore()
+
+	for dbName, addr := range map[string]string{"ethUsdPriceFeed": ethFeed, "nexusUsdPriceFeed": nexusFeed} {
+		mapping, err := store.Contracts.GetMappingByDBName(ctx, dbName)
+		require.NoError(t, err)
+		_, err = store.Contracts.Upsert(ctx, &repository.ContractAddressUpsert{
+			ChainID: chainID, ContractMappingID: mapping.ID, Address: addr,
+		})
+		require.NoError(t, err)
+	}
+
+	updated := time.Now().Unix() - 60
+	feeds := &fakeAggregators{rounds: map[common.Address]round{}}
+	feeds.set(ethFeed, round{decimals: 8, roundID: 7, answer: 352107000000, updatedAt: updated, answeredInRound: 7})
+	feeds.set(nexusFeed, round{decimals: 8, roundID: 3, answer: 50000000, updatedAt: updated, answeredInRound: 3})
+
+	svc := oracle.NewService(store.Contracts, store.AppConfig, zap.NewNop(), chainID)
+	svc.SetClient(feeds)
+	return svc, feeds, store
+}
+
+func setSeconds(t *testing.T, store *memory.Store, key string, seconds int64) {
+	t.Helper()
+	require.NoError(t, store.AppConfig.Create(context.Background(), &repository.AppConfigCreate{
+		Namespace: "oracle", ConfigKey: key, ValueType: "number", ValueNumber: &seconds,
+	}))
+}
+
+func TestParsePair(t *testing.T) {
+	for _, s := range []string{"ETH-USD", "eth_usd", "Eth/Usd"} {
+		pair, err := oracle.ParsePair(s)
+		require.NoError(t, err, s)
+		assert.Equal(t, oracle.PairETHUSD, pair)
+	}
+	_, err := oracle.ParsePair("BTC-USD")
+	assert.ErrorIs(t, err, oracle.ErrUnknownPair)
+}
+
+func TestPrice_FormatsAndCaches(t *testing.T) {
+	svc, feeds, _ := newService(t)
+	ctx := context.Background()
+
+	p, err := svc.Price(ctx, oracle.PairETHUSD)
+	require.NoError(t, err)
+	assert.Equal(t, "3521.07", p.Price)
+	assert.Equal(t, "352107000000", p.Answer)
+	assert.Equal(t, uint8(8), p.Decimals)
+	assert.Equal(t, "7", p.RoundID)
+	assert.Equal(t, ethFeed, p.Feed)
+
+	// Served from cache within the TTL (30s by default)
+	calls := feeds.calls
+	_, err = svc.Price(ctx, oracle.PairETHUSD)
+	require.NoError(t, err)
+	assert.Equal(t, calls, feeds.calls)
+}
+
+func TestPrice_RejectsBadRounds(t *testing.T) {
+	for name, tc := range map[string]struct {
+		edit func(*round)
+		err  error
+	}{
+		"older than max age":    {func(r *round) { r.updatedAt -= 7200 }, oracle.ErrStalePrice},
+		"never updated":         {func(r *round) { r.updatedAt = 0 }, oracle.ErrStalePrice},
+		"answered in old round": {func(r *round) { r.answeredInRound = r.roundID - 1 }, oracle.ErrStalePrice},
+		"non-positive answer":   {func(r *round) { r.answer = 0 }, oracle.ErrInvalidPrice},
+	} {
+		t.Run(name, func(t *testing.T) {
+			svc, feeds, _ := newService(t)
+			r := round{decimals: 8, roundID: 9, answer: 352107000000, updatedAt: time.Now().Unix(), answeredInRound: 9}
+			tc.edit(&r)
+			feeds.set(ethFeed, r)
+
+			_, err := svc.Price(context.Background(), oracle.PairETHUSD)
+			assert.ErrorIs(t, err, tc.err)
+		})
+	}
+}
+
+func TestPrice_MaxAgeFromConfig(t *testing.T) {
+	svc, _, store := newService(t)
+	setSeconds(t, store, "max_age_seconds", 30)
+
+	// The rounds were updated 60s ago
+	_, err := svc.Price(context.Background(), oracle.PairETHUSD)
+	assert.ErrorIs(t, err, oracle.ErrStalePrice)
+}
+
+func TestPrice_ServesCachedRoundWhileFeedUnreadable(t *testing.T) {
+	svc, feeds, store := newService(t)
+	ctx := context.Background()
+	setSeconds(t, store, "cache_seconds", 0)
+
+	_, err := svc.Price(ctx, oracle.PairETHUSD)
+	require.NoError(t, err)
+
+	feeds.err = errors.New("connection refused")
+	calls := feeds.calls
+	p, err := svc.Price(ctx, oracle.PairETHUSD)
+	require.NoError(t, err)
+	assert.Greater(t, feeds.calls, calls)
+	assert.Equal(t, "3521.07", p.Price)
+
+	// Not once the cached round is stale
+	setSeconds(t, store, "max_age_seconds", 30)
+	_, err = svc.Price(ctx, oracle.PairETHUSD)
+	assert.ErrorIs(t, err, oracle.ErrUnavailable)
+}
+
+func TestPrice_NoFeed(t *testing.T) {
+	store := memory.NewSeededStore()
+	svc := oracle.NewService(store.Contracts, store.AppConfig, zap.NewNop(), chainID)
+
+	_, err := svc.Price(context.Background(), oracle.PairNEXUSUSD)
+	assert.ErrorIs(t, err, oracle.ErrNoFeed)
+}
+
+func TestNexusPerETH(t *testing.T) {
+	svc, _, _ := newService(t)
+
+	// 3521.07 USD / 0.5 USD = 7042.14 NEXUS per ETH
+	rate, err := svc.NexusPerETH(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "7042140000000000000000", rate.String())
+}
+
+func TestQuoteUSD(t *testing.T) {
+	svc, _, _ := newService(t)
+	ctx := context.Background()
+
+	nexus, err := svc.QuoteUSD(ctx, 25, "nexus")
+	require.NoError(t, err)
+	assert.InDelta(t, 50, nexus, 1e-9)
+
+	_, err = svc.QuoteUSD(ctx, 25, "BTC")
+	assert.ErrorIs(t, err, oracle.ErrUnknownPair)
+}
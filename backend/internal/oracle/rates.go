@@ -0,0 +1,48 @@
+package oracle
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// wei is 10^18, the base units of one ETH (and one NEXUS)
+var wei = big.NewInt(1e18)
+
+// currencyPairs are the USD pairs of the currencies QuoteUSD converts to
+var currencyPairs = map[string]Pair{
+	"ETH":   PairETHUSD,
+	"NEXUS": PairNEXUSUSD,
+}
+
+// NexusPerETH returns how many NEXUS base units one ETH buys at the ETH/USD
+// and NEXUS/USD prices (billing.PriceOracle)
+func (s *Service) NexusPerETH(ctx context.Context) (*big.Int, error) {
+	eth, err := s.Price(ctx, PairETHUSD)
+	if err != nil {
+		return nil, err
+	}
+	nexus, err := s.Price(ctx, PairNEXUSUSD)
+	if err != nil {
+		return nil, err
+	}
+
+	rate := new(big.Rat).Quo(eth.Rat(), nexus.Rat())
+	rate.Mul(rate, new(big.Rat).SetInt(wei))
+	return new(big.Int).Quo(rate.Num(), rate.Denom()), nil
+}
+
+// QuoteUSD converts an amount in USD to ETH or NEXUS at the oracle price
+func (s *Service) QuoteUSD(ctx context.Context, usd float64, currency string) (float64, error) {
+	pair, ok := currencyPairs[strings.ToUpper(currency)]
+	if !ok {
+		return 0, fmt.Errorf("%w: %s/USD", ErrUnknownPair, currency)
+	}
+	p, err := s.Price(ctx, pair)
+	if err != nil {
+		return 0, err
+	}
+	amount, _ := new(big.Rat).Quo(new(big.Rat).SetFloat64(usd), p.Rat()).Float64()
+	return amount, nil
+}
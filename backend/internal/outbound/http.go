@@ -0,0 +1,74 @@
+package outbound
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// HTTP defaults
+const (
+	DefaultDialTimeout           = 5 * time.Second
+	DefaultTLSHandshakeTimeout   = 5 * time.Second
+	DefaultResponseHeaderTimeout = 10 * time.Second
+	DefaultIdleConnTimeout       = 90 * time.Second
+	DefaultMaxIdleConnsPerHost   = 10
+)
+
+// HTTPConfig configures the connection pool of one provider
+type HTTPConfig struct {
+	DialTimeout           time.Duration // TCP connect (DefaultDialTimeout if zero)
+	TLSHandshakeTimeout   time.Duration // DefaultTLSHandshakeTimeout if zero
+	ResponseHeaderTimeout time.Duration // from request sent to response headers (DefaultResponseHeaderTimeout if zero)
+	IdleConnTimeout       time.Duration // keep-alive connections closed after (DefaultIdleConnTimeout if zero)
+	MaxIdleConnsPerHost   int           // keep-alive connections kept per host (DefaultMaxIdleConnsPerHost if zero)
+
+	// ProxyURL sends requests through an HTTP(S) proxy; "" uses
+	// HTTPS_PROXY/HTTP_PROXY/NO_PROXY from the environment
+	ProxyURL string
+}
+
+// NewHTTPTransport creates a pooled transport for a provider's API, bounded
+// at every stage so a hung provider cannot hold a connection indefinitely.
+// It is meant to be shared by all calls to the provider, as next of New.
+func NewHTTPTransport(cfg HTTPConfig) (*http.Transport, error) {
+	if cfg.DialTimeout <= 0 {
+		cfg.DialTimeout = DefaultDialTimeout
+	}
+	if cfg.TLSHandshakeTimeout <= 0 {
+		cfg.TLSHandshakeTimeout = DefaultTLSHandshakeTimeout
+	}
+	if cfg.ResponseHeaderTimeout <= 0 {
+		cfg.ResponseHeaderTimeout = DefaultResponseHeaderTimeout
+	}
+	if cfg.IdleConnTimeout <= 0 {
+		cfg.IdleConnTimeout = DefaultIdleConnTimeout
+	}
+	if cfg.MaxIdleConnsPerHost <= 0 {
+		cfg.MaxIdleConnsPerHost = DefaultMaxIdleConnsPerHost
+	}
+
+	proxy := http.ProxyFromEnvironment
+	if cfg.ProxyURL != "" {
+		u, err := url.Parse(cfg.ProxyURL)
+		if err != nil || u.Host == "" {
+			return nil, fmt.Errorf("invalid proxy URL %q", cfg.ProxyURL)
+		}
+		proxy = http.ProxyURL(u)
+	}
+
+	dialer := &net.Dialer{Timeout: cfg.DialTimeout, KeepAlive: 30 * time.Second}
+	return &http.Transport{
+		Proxy:                 proxy,
+		DialContext:           dialer.DialContext,
+		ForceAttemptHTTP2:     true,
+		TLSHandshakeTimeout:   cfg.TLSHandshakeTimeout,
+		ResponseHeaderTimeout: cfg.ResponseHeaderTimeout,
+		ExpectContinueTimeout: time.Second,
+		IdleConnTimeout:       cfg.IdleConnTimeout,
+		MaxIdleConns:          cfg.MaxIdleConnsPerHost * 2,
+		MaxIdleConnsPerHost:   cfg.MaxIdleConnsPerHost,
+	}, nil
+}
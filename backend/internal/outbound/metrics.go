@@ -0,0 +1,108 @@
+package outbound
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/breaker"
+)
+
+// outcomes are the attempt outcome labels, in metrics order
+var outcomes = []string{"success", "client_error", "rate_limited", "server_error", "network_error"}
+
+// Stats are a transport's counters since the server started
+type Stats struct {
+	Provider    string           `json:"provider"`
+	Attempts    map[string]int64 `json:"attempts"` // by outcome
+	Retries     int64            `json:"retries"`
+	RateLimited int64            `json:"rate_limited"` // requests refused by the client-side limit
+	WaitSeconds float64          `json:"wait_seconds"` // time spent waiting for the limit
+	AvgLatency  float64          `json:"avg_latency_seconds"`
+}
+
+// Stats returns the transport's counters
+func (t *Transport) Stats() Stats {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	stats := Stats{
+		Provider:    t.cfg.Provider,
+		Attempts:    make(map[string]int64, len(outcomes)),
+		Retries:     t.retries,
+		RateLimited: t.limited,
+		WaitSeconds: t.waited.Seconds(),
+	}
+	for _, o := range outcomes {
+		stats.Attempts[o] = t.outcomes[o]
+	}
+	if t.attempts > 0 {
+		stats.AvgLatency = t.latency.Seconds() / float64(t.attempts)
+	}
+	return stats
+}
+
+// Providers exports the metrics of several transports as one set of metric
+// families (implements kpi.Collector)
+type Providers struct {
+	transports []*Transport
+}
+
+// Add registers transports
+func (p *Providers) Add(transports ...*Transport) {
+	p.transports = append(p.transports, transports...)
+	sort.Slice(p.transports, func(i, j int) bool { return p.transports[i].cfg.Provider < p.transports[j].cfg.Provider })
+}
+
+// WriteMetrics writes the outbound metrics in the OpenMetrics text format
+func (p *Providers) WriteMetrics(w io.Writer) {
+	if len(p.transports) == 0 {
+		return
+	}
+	stats := make([]Stats, len(p.transports))
+	for i, t := range p.transports {
+		stats[i] = t.Stats()
+	}
+
+	fmt.Fprint(w, "# TYPE nexus_outbound_attempts counter\n# HELP nexus_outbound_attempts HTTP attempts to external providers by outcome, retries included.\n")
+	for _, s := range stats {
+		for _, o := range outcomes {
+			fmt.Fprintf(w, "nexus_outbound_attempts_total{provider=%q,outcome=%q} %d\n", s.Provider, o, s.Attempts[o])
+		}
+	}
+	fmt.Fprint(w, "# TYPE nexus_outbound_retries counter\n# HELP nexus_outbound_retries Retried attempts to external providers.\n")
+	for _, s := range stats {
+		fmt.Fprintf(w, "nexus_outbound_retries_total{provider=%q} %d\n", s.Provider, s.Retries)
+	}
+	fmt.Fprint(w, "# TYPE nexus_outbound_rate_limited counter\n# HELP nexus_outbound_rate_limited Requests refused by the client-side rate limit.\n")
+	for _, s := range stats {
+		fmt.Fprintf(w, "nexus_outbound_rate_limited_total{provider=%q} %d\n", s.Provider, s.RateLimited)
+	}
+	fmt.Fprint(w, "# TYPE nexus_outbound_throttle_wait_seconds counter\n# HELP nexus_outbound_throttle_wait_seconds Time requests waited for the client-side rate limit.\n")
+	for _, s := range stats {
+		fmt.Fprintf(w, "nexus_outbound_throttle_wait_seconds_total{provider=%q} %g\n", s.Provider, s.WaitSeconds)
+	}
+	fmt.Fprint(w, "# TYPE nexus_outbound_latency_seconds gauge\n# HELP nexus_outbound_latency_seconds Average latency of an attempt since the server started.\n")
+	for _, s := range stats {
+		fmt.Fprintf(w, "nexus_outbound_latency_seconds{provider=%q} %g\n", s.Provider, s.AvgLatency)
+	}
+
+	header := false
+	for _, t := range p.transports {
+		if t.cfg.Breaker == nil {
+			continue
+		}
+		if !header {
+			fmt.Fprint(w, "# TYPE nexus_outbound_circuit_open gauge\n# HELP nexus_outbound_circuit_open Whether the provider's circuit breaker is shedding calls (1 open, 0.5 half-open, 0 closed).\n")
+			header = true
+		}
+		value := 0.0
+		switch t.cfg.Breaker.State() {
+		case breaker.StateOpen:
+			value = 1
+		case breaker.StateHalfOpen:
+			value = 0.5
+		}
+		fmt.Fprintf(w, "nexus_outbound_circuit_open{provider=%q} %g\n", t.cfg.Provider, value)
+	}
+}
@@ -0,0 +1,311 @@
+// Package outbound wraps the HTTP clients of external providers (Stripe,
+// Sumsub) with client-side rate limiting, retries with exponential backoff
+// and per-provider metrics. Circuit breaking stays with the caller, around
+// the whole call, so a call that is retried counts once against the breaker.
+package outbound
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/breaker"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/timing"
+)
+
+// Defaults
+const (
+	DefaultRate        = 10 // requests per second
+	DefaultBurst       = 20
+	DefaultMaxRetries  = 2
+	DefaultBaseBackoff = 200 * time.Millisecond
+	DefaultMaxBackoff  = 5 * time.Second
+	DefaultTimeout     = 10 * time.Second
+)
+
+// ErrRateLimited is returned when the provider's client-side rate limit
+// leaves no room for the request before its deadline
+var ErrRateLimited = errors.New("outbound rate limit exceeded")
+
+// Config configures the transport of one provider
+type Config struct {
+	Provider    string        // metrics label, e.g. "stripe"
+	Rate        float64       // requests per second (DefaultRate if zero)
+	Burst       int           // requests allowed at once (DefaultBurst if zero)
+	MaxRetries  int           // retries after the first attempt (DefaultMaxRetries if zero, negative disables)
+	BaseBackoff time.Duration // first retry delay, doubled per retry (DefaultBaseBackoff if zero)
+	MaxBackoff  time.Duration // cap on a retry delay, including Retry-After (DefaultMaxBackoff if zero)
+	Timeout     time.Duration // Client timeout for a call including retries (DefaultTimeout if zero)
+
+	// RetryUnsafe retries POST and PATCH requests without an
+	// Idempotency-Key, for providers whose write endpoints are safe to repeat
+	RetryUnsafe bool
+
+	// Breaker, if set, is reported in the metrics
+	Breaker *breaker.Breaker
+}
+
+// Transport is an http.RoundTripper throttling and retrying requests to one
+// provider
+type Transport struct {
+	cfg  Config
+	next http.RoundTripper
+
+	bucket tokenBucket
+
+	mu       sync.Mutex
+	outcomes map[string]int64 // by outcome label
+	retries  int64
+	limited  int64
+	waited   time.Duration
+	latency  time.Duration // of attempts, for the average
+	attempts int64
+}
+
+// New creates a transport sending requests with next (http.DefaultTransport
+// if nil)
+func New(cfg Config, next http.RoundTripper) *Transport {
+	if cfg.Rate <= 0 {
+		cfg.Rate = DefaultRate
+	}
+	if cfg.Burst <= 0 {
+		cfg.Burst = DefaultBurst
+	}
+	if cfg.MaxRetries == 0 {
+		cfg.MaxRetries = DefaultMaxRetries
+	}
+	if cfg.BaseBackoff <= 0 {
+		cfg.BaseBackoff = DefaultBaseBackoff
+	}
+	if cfg.MaxBackoff <= 0 {
+		cfg.MaxBackoff = DefaultMaxBackoff
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = DefaultTimeout
+	}
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	return &Transport{
+		cfg:      cfg,
+		next:     next,
+		bucket:   newTokenBucket(cfg.Rate, cfg.Burst),
+		outcomes: make(map[string]int64),
+	}
+}
+
+// Client returns an HTTP client using the transport, bounded by the
+// configured timeout
+func (t *Transport) Client() *http.Client {
+	return &http.Client{Transport: t, Timeout: t.cfg.Timeout}
+}
+
+// Provider returns the provider name
+func (t *Transport) Provider() string {
+	return t.cfg.Provider
+}
+
+// RoundTrip sends req once a rate limit token is available, retrying
+// network errors, 429 and 502-504 with backoff. The whole call, waits
+// included, is recorded on the request's timing breakdown under the
+// provider's name.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	defer timing.Track(ctx, t.cfg.Provider)()
+	retryable := t.cfg.RetryUnsafe || isIdempotent(req)
+
+	for attempt := 0; ; attempt++ {
+		if err := t.throttle(ctx); err != nil {
+			return nil, err
+		}
+
+		attemptReq := req
+		if attempt > 0 {
+			var err error
+			if attemptReq, err = rewind(req); err != nil {
+				return nil, err
+			}
+		}
+
+		start := time.Now()
+		resp, err := t.next.RoundTrip(attemptReq)
+		t.record(attempt, time.Since(start), outcome(resp, err))
+
+		// 429 means the provider did not process the request, so it is
+		// retried even when repeating it would not be safe otherwise
+		retry := err != nil && ctx.Err() == nil && retryable ||
+			resp != nil && (resp.StatusCode == http.StatusTooManyRequests || retryable && retryStatus(resp.StatusCode))
+		if !retry || attempt >= t.cfg.MaxRetries || req.Body != nil && req.GetBody == nil {
+			return resp, err
+		}
+
+		delay := t.backoff(attempt, resp)
+		if resp != nil {
+			io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+			resp.Body.Close()
+		}
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+			// No time left to retry; report the last failure
+			return nil, fmt.Errorf("%s: giving up after %d attempts: %w", t.cfg.Provider, attempt+1, lastError(resp, err))
+		}
+		if err := sleep(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// throttle waits for a rate limit token, failing with ErrRateLimited if it
+// would not arrive before ctx's deadline
+func (t *Transport) throttle(ctx context.Context) error {
+	wait := t.bucket.reserve(ctx)
+	if wait < 0 {
+		t.mu.Lock()
+		t.limited++
+		t.mu.Unlock()
+		return fmt.Errorf("%s: %w", t.cfg.Provider, ErrRateLimited)
+	}
+	if wait == 0 {
+		return nil
+	}
+
+	t.mu.Lock()
+	t.waited += wait
+	t.mu.Unlock()
+	return sleep(ctx, wait)
+}
+
+// backoff returns the delay before retry attempt+1: the provider's
+// Retry-After if given, otherwise exponential with full jitter, capped at
+// MaxBackoff
+func (t *Transport) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds >= 0 {
+			return min(time.Duration(seconds)*time.Second, t.cfg.MaxBackoff)
+		}
+	}
+	delay := min(t.cfg.BaseBackoff<<attempt, t.cfg.MaxBackoff)
+	return delay/2 + rand.N(delay/2+1)
+}
+
+// record counts an attempt
+func (t *Transport) record(attempt int, latency time.Duration, outcome string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if attempt > 0 {
+		t.retries++
+	}
+	t.outcomes[outcome]++
+	t.attempts++
+	t.latency += latency
+}
+
+// isIdempotent reports whether repeating req is safe: idempotent methods
+// and writes carrying an Idempotency-Key (Stripe sets one on every POST)
+func isIdempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return req.Header.Get("Idempotency-Key") != ""
+}
+
+// retryStatus reports whether a response status is a transient provider
+// failure
+func retryStatus(status int) bool {
+	switch status {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// outcome labels an attempt for the metrics
+func outcome(resp *http.Response, err error) string {
+	switch {
+	case err != nil:
+		return "network_error"
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return "rate_limited"
+	case resp.StatusCode >= 500:
+		return "server_error"
+	case resp.StatusCode >= 400:
+		return "client_error"
+	default:
+		return "success"
+	}
+}
+
+// lastError describes the final failed attempt
+func lastError(resp *http.Response, err error) error {
+	if err != nil {
+		return err
+	}
+	return fmt.Errorf("status %d", resp.StatusCode)
+}
+
+// rewind returns a copy of req with a fresh body for a retry
+func rewind(req *http.Request) (*http.Request, error) {
+	clone := req.Clone(req.Context())
+	if req.Body == nil || req.GetBody == nil {
+		return clone, nil
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	clone.Body = body
+	return clone, nil
+}
+
+// sleep waits for d or until ctx is done
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// tokenBucket is a token bucket rate limiter
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64 // tokens per second
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate float64, burst int) tokenBucket {
+	return tokenBucket{rate: rate, burst: float64(burst), tokens: float64(burst), last: time.Now()}
+}
+
+// reserve takes a token and returns how long to wait until it is available,
+// or -1 (taking nothing) if that would be past ctx's deadline
+func (b *tokenBucket) reserve(ctx context.Context) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	b.tokens = min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+
+	var wait time.Duration
+	if b.tokens < 1 {
+		wait = time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+	}
+	if deadline, ok := ctx.Deadline(); ok && now.Add(wait).After(deadline) {
+		return -1
+	}
+	b.tokens--
+	return wait
+}
@@ -0,0 +1,182 @@
+package outbound_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/breaker"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/outbound"
+)
+
+// flakyServer fails the first failures requests with status, echoing the
+// request body once it succeeds
+func flakyServer(t *testing.T, failures int32, status int) (*httptest.Server, *atomic.Int32) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if calls.Add(1) <= failures {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(status)
+			return
+		}
+		w.Write(body)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &calls
+}
+
+func newTransport(cfg outbound.Config) *outbound.Transport {
+	cfg.BaseBackoff = time.Millisecond
+	cfg.MaxBackoff = 5 * time.Millisecond
+	return outbound.New(cfg, nil)
+}
+
+func TestTransport_RetriesTransientFailures(t *testing.T) {
+	srv, calls := flakyServer(t, 2, http.StatusServiceUnavailable)
+	tr := newTransport(outbound.Config{Provider: "stripe"})
+
+	req, _ := http.NewRequest(http.MethodPost, srv.URL, strings.NewReader(`{"a":1}`))
+	req.Header.Set("Idempotency-Key", "key-1")
+	resp, err := tr.Client().Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, `{"a":1}`, string(body), "the body is resent on retries")
+	assert.Equal(t, int32(3), calls.Load())
+
+	stats := tr.Stats()
+	assert.Equal(t, int64(2), stats.Retries)
+	assert.Equal(t, int64(2), stats.Attempts["server_error"])
+	assert.Equal(t, int64(1), stats.Attempts["success"])
+}
+
+func TestTransport_UnsafeRequestsAreNotRetried(t *testing.T) {
+	srv, calls := flakyServer(t, 5, http.StatusBadGateway)
+	tr := newTransport(outbound.Config{Provider: "sumsub"})
+
+	resp, err := tr.Client().Post(srv.URL, "application/json", strings.NewReader("{}"))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadGateway, resp.StatusCode)
+	assert.Equal(t, int32(1), calls.Load(), "a POST without Idempotency-Key may have been applied")
+
+	// 429 means the request was not processed
+	srv, calls = flakyServer(t, 1, http.StatusTooManyRequests)
+	resp, err = tr.Client().Post(srv.URL, "application/json", strings.NewReader("{}"))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int32(2), calls.Load())
+
+	// Providers whose writes are safe to repeat opt in
+	srv, calls = flakyServer(t, 1, http.StatusBadGateway)
+	tr = newTransport(outbound.Config{Provider: "sumsub", RetryUnsafe: true})
+	resp, err = tr.Client().Post(srv.URL, "application/json", strings.NewReader("{}"))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestTransport_GivesUpAfterMaxRetries(t *testing.T) {
+	srv, calls := flakyServer(t, 10, http.StatusServiceUnavailable)
+	tr := newTransport(outbound.Config{Provider: "stripe", MaxRetries: 3})
+
+	resp, err := tr.Client().Get(srv.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Equal(t, int32(4), calls.Load())
+
+	srv, calls = flakyServer(t, 10, http.StatusServiceUnavailable)
+	tr = newTransport(outbound.Config{Provider: "stripe", MaxRetries: -1})
+	resp, err = tr.Client().Get(srv.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, int32(1), calls.Load(), "negative MaxRetries disables retries")
+}
+
+func TestTransport_RateLimit(t *testing.T) {
+	srv, _ := flakyServer(t, 0, 0)
+	tr := newTransport(outbound.Config{Provider: "sumsub", Rate: 1, Burst: 2})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	get := func() error {
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+		resp, err := tr.Client().Do(req)
+		if err == nil {
+			resp.Body.Close()
+		}
+		return err
+	}
+
+	require.NoError(t, get())
+	require.NoError(t, get())
+	err := get()
+	assert.ErrorIs(t, err, outbound.ErrRateLimited, "the next token comes after the deadline")
+	assert.Equal(t, int64(1), tr.Stats().RateLimited)
+}
+
+func TestProviders_WriteMetrics(t *testing.T) {
+	srv, _ := flakyServer(t, 0, 0)
+	b := breaker.New("stripe", 0, 0)
+	stripeTr := newTransport(outbound.Config{Provider: "stripe", Breaker: b})
+	sumsubTr := newTransport(outbound.Config{Provider: "sumsub"})
+	resp, err := stripeTr.Client().Get(srv.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	var p outbound.Providers
+	p.Add(sumsubTr, stripeTr)
+	var out strings.Builder
+	p.WriteMetrics(&out)
+	metrics := out.String()
+
+	assert.Equal(t, 1, strings.Count(metrics, "# TYPE nexus_outbound_attempts counter"))
+	assert.Contains(t, metrics, `nexus_outbound_attempts_total{provider="stripe",outcome="success"} 1`)
+	assert.Contains(t, metrics, `nexus_outbound_attempts_total{provider="sumsub",outcome="success"} 0`)
+	assert.Contains(t, metrics, `nexus_outbound_circuit_open{provider="stripe"} 0`)
+	assert.NotContains(t, metrics, `nexus_outbound_circuit_open{provider="sumsub"}`)
+	assert.Less(t, strings.Index(metrics, `provider="stripe"`), strings.Index(metrics, `provider="sumsub"`))
+}
+
+func TestNewHTTPTransport(t *testing.T) {
+	_, err := outbound.NewHTTPTransport(outbound.HTTPConfig{ProxyURL: "not a url"})
+	assert.Error(t, err)
+
+	// Requests go through the proxy
+	var proxied atomic.Int32
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied.Add(1)
+		assert.Equal(t, "api.sumsub.test", r.URL.Host)
+	}))
+	t.Cleanup(proxy.Close)
+	tr, err := outbound.NewHTTPTransport(outbound.HTTPConfig{ProxyURL: proxy.URL})
+	require.NoError(t, err)
+	resp, err := (&http.Client{Transport: tr}).Get("http://api.sumsub.test/resources/status")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, int32(1), proxied.Load())
+
+	// A provider that never answers is cut off
+	hung := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	t.Cleanup(hung.Close)
+	tr, err = outbound.NewHTTPTransport(outbound.HTTPConfig{ResponseHeaderTimeout: 50 * time.Millisecond})
+	require.NoError(t, err)
+	_, err = (&http.Client{Transport: tr}).Get(hung.URL)
+	assert.ErrorContains(t, err, "timeout awaiting response headers")
+}
@@ -0,0 +1,442 @@
+// Package overview composes a user's dashboard: profile, KYC status, payment
+// summary, NFT holdings, voting power, staking position and pending
+// meta-transactions, read in parallel from the repositories and the
+// contracts registered on the server's chain (nexusNFT, nexusToken,
+// nexusStaking). A section that cannot be read is left out and named in the
+// overview's errors rather than failing the whole overview.
+package overview
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+const (
+	// sectionTimeout bounds each section, so one slow source can't hold up
+	// the rest
+	sectionTimeout = 5 * time.Second
+
+	// recentPayments and maxPendingMetaTxs cap the listed items
+	recentPayments    = 5
+	maxPendingMetaTxs = 20
+)
+
+// Section names, as reported in Overview.Errors
+const (
+	SectionProfile  = "profile"
+	SectionKYC      = "kyc"
+	SectionPayments = "payments"
+	SectionNFTs     = "nfts"
+	SectionVoting   = "voting"
+	SectionStaking  = "staking"
+	SectionMetaTxs  = "pending_meta_txs"
+)
+
+// Section errors, reported in place of the failure behind them
+var (
+	ErrNotDeployed = errors.New("contract not registered on this chain")
+	ErrUnavailable = errors.New("temporarily unavailable")
+)
+
+// overviewABIJSON is the part of NexusNFT, NexusToken and NexusStaking the
+// overview calls
+const overviewABIJSON = `[
+	{"type":"function","name":"balanceOf","stateMutability":"view","inputs":[{"name":"owner","type":"address"}],"outputs":[{"name":"","type":"uint256"}]},
+	{"type":"function","name":"getVotes","stateMutability":"view","inputs":[{"name":"account","type":"address"}],"outputs":[{"name":"","type":"uint256"}]},
+	{"type":"function","name":"delegates","stateMutability":"view","inputs":[{"name":"account","type":"address"}],"outputs":[{"name":"","type":"address"}]},
+	{"type":"function","name":"stakes","stateMutability":"view","inputs":[{"name":"","type":"address"}],"outputs":[
+		{"name":"amount","type":"uint256"},
+		{"name":"stakedAt","type":"uint256"},
+		{"name":"lastStakeTime","type":"uint256"},
+		{"name":"delegatee","type":"address"},
+		{"name":"delegatedToMe","type":"uint256"},
+		{"name":"lastSlashedAt","type":"uint256"},
+		{"name":"totalSlashed","type":"uint256"}]},
+	{"type":"function","name":"getPendingUnbonding","stateMutability":"view","inputs":[{"name":"staker","type":"address"}],"outputs":[{"name":"","type":"uint256"}]},
+	{"type":"function","name":"getWithdrawableUnbonding","stateMutability":"view","inputs":[{"name":"staker","type":"address"}],"outputs":[{"name":"","type":"uint256"}]}
+]`
+
+var overviewABI = mustParseABI(overviewABIJSON)
+
+func mustParseABI(s string) abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(s))
+	if err != nil {
+		panic(fmt.Sprintf("overview: invalid ABI: %v", err))
+	}
+	return parsed
+}
+
+// Client is the chain access the overview needs (implemented by
+// chain.FailoverClient)
+type Client interface {
+	CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error)
+}
+
+// Overview is a user's dashboard. Sections that could not be read are nil
+// and listed in Errors.
+type Overview struct {
+	Address        string                        `json:"address"`
+	Profile        *repository.Profile           `json:"profile,omitempty"` // public part, without the email
+	KYC            *KYCStatus                    `json:"kyc,omitempty"`
+	Payments       *PaymentSummary               `json:"payments,omitempty"`
+	NFTs           *NFTHoldings                  `json:"nfts,omitempty"`
+	Voting         *VotingPower                  `json:"voting,omitempty"`
+	Staking        *StakingPosition              `json:"staking,omitempty"`
+	PendingMetaTxs []*repository.MetaTransaction `json:"pending_meta_txs"`
+	Errors         map[string]string             `json:"errors,omitempty"` // section -> why it is missing
+	Partial        bool                          `json:"partial"`
+	GeneratedAt    time.Time                     `json:"generated_at"`
+}
+
+// KYCStatus is the state of the address's latest KYC verification
+type KYCStatus struct {
+	Status     string     `json:"status"` // repository.KYCVerificationStatus, or "none"
+	VerifiedAt *time.Time `json:"verified_at,omitempty"`
+	UpdatedAt  *time.Time `json:"updated_at,omitempty"`
+}
+
+// PaymentSummary counts the address's payments and lists the latest
+type PaymentSummary struct {
+	Total     int64                 `json:"total"`
+	Completed int64                 `json:"completed"`
+	Pending   int64                 `json:"pending"` // pending or processing
+	Failed    int64                 `json:"failed"`
+	Refunded  int64                 `json:"refunded"`
+	Recent    []*PaymentSummaryItem `json:"recent"`
+}
+
+// PaymentSummaryItem is a payment as listed in the summary
+type PaymentSummaryItem struct {
+	ID            string                   `json:"id"`
+	ServiceCode   string                   `json:"service_code"`
+	PaymentMethod string                   `json:"payment_method"`
+	AmountCharged float64                  `json:"amount_charged"`
+	Currency      string                   `json:"currency"`
+	AmountUSD     *float64                 `json:"amount_usd,omitempty"`
+	Status        repository.PaymentStatus `json:"status"`
+	CreatedAt     time.Time                `json:"created_at"`
+}
+
+// NFTHoldings is how many Nexus NFTs the address holds
+type NFTHoldings struct {
+	Contract string `json:"contract"`
+	Balance  string `json:"balance"`
+}
+
+// VotingPower is the address's governance votes (NexusToken checkpoints)
+type VotingPower struct {
+	Votes       string `json:"votes"` // wei
+	DelegatedTo string `json:"delegated_to,omitempty"`
+}
+
+// StakingPosition is the address's NexusStaking stake, amounts in wei
+type StakingPosition struct {
+	Staked                string     `json:"staked"`
+	StakedAt              *time.Time `json:"staked_at,omitempty"`
+	Delegatee             string     `json:"delegatee,omitempty"`
+	DelegatedToMe         string     `json:"delegated_to_me"`
+	PendingUnbonding      string     `json:"pending_unbonding"`
+	WithdrawableUnbonding string     `json:"withdrawable_unbonding"`
+}
+
+// Service composes overviews
+type Service struct {
+	payments  repository.PaymentRepository
+	relayer   repository.RelayerRepository
+	profiles  repository.ProfileRepository
+	contracts repository.ContractRepository
+	client    Client
+	logger    *zap.Logger
+	chainID   int64
+	now       func() time.Time
+}
+
+// NewService creates an overview service reading contracts on chainID
+func NewService(payments repository.PaymentRepository, relayer repository.RelayerRepository, profiles repository.ProfileRepository, contracts repository.ContractRepository, logger *zap.Logger, chainID int64) *Service {
+	return &Service{
+		payments:  payments,
+		relayer:   relayer,
+		profiles:  profiles,
+		contracts: contracts,
+		logger:    logger,
+		chainID:   chainID,
+		now:       time.Now,
+	}
+}
+
+// SetClient sets the chain client the contracts are read through
+func (s *Service) SetClient(client Client) {
+	s.client = client
+}
+
+// Overview composes address's dashboard. address must be a lowercase hex
+// address. Sections are read in parallel; failures are recorded in the
+// overview, so it is always returned.
+func (s *Service) Overview(ctx context.Context, address string) *Overview {
+	o := &Overview{Address: address, PendingMetaTxs: []*repository.MetaTransaction{}}
+
+	var (
+		wg sync.WaitGroup
+		mu sync.Mutex
+	)
+	run := func(section string, read func(ctx context.Context) error) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(ctx, sectionTimeout)
+			defer cancel()
+			if err := read(ctx); err != nil {
+				mu.Lock()
+				defer mu.Unlock()
+				if o.Errors == nil {
+					o.Errors = make(map[string]string)
+				}
+				o.Errors[section] = s.reason(section, address, err)
+			}
+		}()
+	}
+
+	run(SectionProfile, func(ctx context.Context) error {
+		p, err := s.profiles.GetProfile(ctx, address)
+		if errors.Is(err, repository.ErrProfileNotFound) {
+			return nil
+		}
+		if err == nil {
+			p.Email = nil
+			o.Profile = p
+		}
+		return err
+	})
+	run(SectionKYC, func(ctx context.Context) (err error) {
+		o.KYC, err = s.kyc(ctx, address)
+		return err
+	})
+	run(SectionPayments, func(ctx context.Context) (err error) {
+		o.Payments, err = s.paymentSummary(ctx, address)
+		return err
+	})
+	run(SectionNFTs, func(ctx context.Context) (err error) {
+		o.NFTs, err = s.nfts(ctx, address)
+		return err
+	})
+	run(SectionVoting, func(ctx context.Context) (err error) {
+		o.Voting, err = s.voting(ctx, address)
+		return err
+	})
+	run(SectionStaking, func(ctx context.Context) (err error) {
+		o.Staking, err = s.staking(ctx, address)
+		return err
+	})
+	run(SectionMetaTxs, func(ctx context.Context) error {
+		txs, err := s.pendingMetaTxs(ctx, address)
+		if err == nil {
+			o.PendingMetaTxs = txs
+		}
+		return err
+	})
+	wg.Wait()
+
+	o.Partial = len(o.Errors) > 0
+	o.GeneratedAt = s.now().UTC()
+	return o
+}
+
+// reason logs a section failure and returns what the overview reports
+func (s *Service) reason(section, address string, err error) string {
+	if errors.Is(err, ErrNotDeployed) {
+		return ErrNotDeployed.Error()
+	}
+	s.logger.Warn("overview section unavailable",
+		zap.String("section", section),
+		zap.String("address", address),
+		zap.Error(err),
+	)
+	return ErrUnavailable.Error()
+}
+
+// kyc reads the address's KYC verification; none is not an error
+func (s *Service) kyc(ctx context.Context, address string) (*KYCStatus, error) {
+	v, err := s.payments.GetKYCVerificationByAddress(ctx, address)
+	if errors.Is(err, repository.ErrKYCNotFound) {
+		return &KYCStatus{Status: "none"}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &KYCStatus{Status: string(v.Status), VerifiedAt: v.VerifiedAt, UpdatedAt: &v.UpdatedAt}, nil
+}
+
+// paymentSummary counts the address's payments by status
+func (s *Service) paymentSummary(ctx context.Context, address string) (*PaymentSummary, error) {
+	recent, total, err := s.payments.ListPayments(ctx, repository.PaymentFilter{PayerAddress: address}, repository.Pagination{Page: 1, PageSize: recentPayments})
+	if err != nil {
+		return nil, fmt.Errorf("listing payments: %w", err)
+	}
+	summary := &PaymentSummary{Total: total, Recent: make([]*PaymentSummaryItem, 0, len(recent))}
+	for _, p := range recent {
+		summary.Recent = append(summary.Recent, &PaymentSummaryItem{
+			ID:            p.ID,
+			ServiceCode:   p.ServiceCode,
+			PaymentMethod: p.PaymentMethod,
+			AmountCharged: p.AmountCharged,
+			Currency:      p.Currency,
+			AmountUSD:     p.AmountUSD,
+			Status:        p.Status,
+			CreatedAt:     p.CreatedAt,
+		})
+	}
+
+	counts := []struct {
+		status repository.PaymentStatus
+		count  *int64
+	}{
+		{repository.PaymentStatusCompleted, &summary.Completed},
+		{repository.PaymentStatusPending, &summary.Pending},
+		{repository.PaymentStatusProcessing, &summary.Pending},
+		{repository.PaymentStatusFailed, &summary.Failed},
+		{repository.PaymentStatusRefunded, &summary.Refunded},
+	}
+	for _, c := range counts {
+		filter := repository.PaymentFilter{PayerAddress: address, Status: c.status}
+		_, n, err := s.payments.ListPayments(ctx, filter, repository.Pagination{Page: 1, PageSize: 1})
+		if err != nil {
+			return nil, fmt.Errorf("counting %s payments: %w", c.status, err)
+		}
+		*c.count += n
+	}
+	return summary, nil
+}
+
+// pendingMetaTxs lists the address's meta-transactions not yet confirmed
+func (s *Service) pendingMetaTxs(ctx context.Context, address string) ([]*repository.MetaTransaction, error) {
+	txs := []*repository.MetaTransaction{}
+	for _, status := range []repository.MetaTxStatus{repository.MetaTxStatusPending, repository.MetaTxStatusSubmitted} {
+		filter := repository.MetaTxFilter{FromAddress: address, Status: status}
+		page, _, err := s.relayer.ListMetaTx(ctx, filter, repository.Pagination{Page: 1, PageSize: maxPendingMetaTxs})
+		if err != nil {
+			return nil, fmt.Errorf("listing %s meta-transactions: %w", status, err)
+		}
+		txs = append(txs, page...)
+	}
+	if len(txs) > maxPendingMetaTxs {
+		txs = txs[:maxPendingMetaTxs]
+	}
+	return txs, nil
+}
+
+// nfts reads the address's NexusNFT balance
+func (s *Service) nfts(ctx context.Context, address string) (*NFTHoldings, error) {
+	contract, err := s.contract(ctx, "nexusNFT")
+	if err != nil {
+		return nil, err
+	}
+	out, err := s.call(ctx, contract, "balanceOf", common.HexToAddress(address))
+	if err != nil {
+		return nil, err
+	}
+	return &NFTHoldings{Contract: strings.ToLower(contract.Hex()), Balance: out[0].(*big.Int).String()}, nil
+}
+
+// voting reads the address's NexusToken votes and delegate
+func (s *Service) voting(ctx context.Context, address string) (*VotingPower, error) {
+	contract, err := s.contract(ctx, "nexusToken")
+	if err != nil {
+		return nil, err
+	}
+	account := common.HexToAddress(address)
+	votes, err := s.call(ctx, contract, "getVotes", account)
+	if err != nil {
+		return nil, err
+	}
+	delegate, err := s.call(ctx, contract, "delegates", account)
+	if err != nil {
+		return nil, err
+	}
+
+	power := &VotingPower{Votes: votes[0].(*big.Int).String()}
+	if d := delegate[0].(common.Address); d != (common.Address{}) {
+		power.DelegatedTo = strings.ToLower(d.Hex())
+	}
+	return power, nil
+}
+
+// staking reads the address's NexusStaking stake and unbonding amounts
+func (s *Service) staking(ctx context.Context, address string) (*StakingPosition, error) {
+	contract, err := s.contract(ctx, "nexusStaking")
+	if err != nil {
+		return nil, err
+	}
+	account := common.HexToAddress(address)
+	stake, err := s.call(ctx, contract, "stakes", account)
+	if err != nil {
+		return nil, err
+	}
+	pending, err := s.call(ctx, contract, "getPendingUnbonding", account)
+	if err != nil {
+		return nil, err
+	}
+	withdrawable, err := s.call(ctx, contract, "getWithdrawableUnbonding", account)
+	if err != nil {
+		return nil, err
+	}
+
+	position := &StakingPosition{
+		Staked:                stake[0].(*big.Int).String(),
+		DelegatedToMe:         stake[4].(*big.Int).String(),
+		PendingUnbonding:      pending[0].(*big.Int).String(),
+		WithdrawableUnbonding: withdrawable[0].(*big.Int).String(),
+	}
+	if at := stake[1].(*big.Int); at.Sign() > 0 && at.IsInt64() {
+		t := time.Unix(at.Int64(), 0).UTC()
+		position.StakedAt = &t
+	}
+	if d := stake[3].(common.Address); d != (common.Address{}) {
+		position.Delegatee = strings.ToLower(d.Hex())
+	}
+	return position, nil
+}
+
+// contract returns the address a contract is registered at on the chain
+func (s *Service) contract(ctx context.Context, dbName string) (common.Address, error) {
+	c, err := s.contracts.GetByChainAndDBName(ctx, s.chainID, dbName)
+	if errors.Is(err, repository.ErrContractAddressNotFound) {
+		return common.Address{}, ErrNotDeployed
+	}
+	if err != nil {
+		return common.Address{}, fmt.Errorf("loading %s: %w", dbName, err)
+	}
+	if s.client == nil {
+		return common.Address{}, fmt.Errorf("%w: no RPC client", ErrUnavailable)
+	}
+	return common.HexToAddress(c.Address), nil
+}
+
+// call performs a view call, checking the output has the types the ABI
+// declares
+func (s *Service) call(ctx context.Context, contract common.Address, method string, args ...interface{}) ([]interface{}, error) {
+	data, err := overviewABI.Pack(method, args...)
+	if err != nil {
+		return nil, err
+	}
+	result, err := s.client.CallContract(ctx, ethereum.CallMsg{To: &contract, Data: data}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", ErrUnavailable, method, err)
+	}
+	out, err := overviewABI.Unpack(method, result)
+	if err != nil || len(out) != len(overviewABI.Methods[method].Outputs) {
+		return nil, fmt.Errorf("%w: decoding %s: %v", ErrUnavailable, method, err)
+	}
+	return out, nil
+}
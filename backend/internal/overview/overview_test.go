@@ -0,0 +1,131 @@
+package overview_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/overview"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/storage/memory"
+)
+
+const (
+	chainID   = 31337
+	user      = "0x00000000000000000000000000000000000000aa"
+	delegate  = "0x00000000000000000000000000000000000000dd"
+	nftAddr   = "0x00000000000000000000000000000000000000c1"
+	tokenAddr = "0x00000000000000000000000000000000000000c2"
+)
+
+// fakeChain answers balanceOf, getVotes and delegates
+type fakeChain struct {
+	err error
+}
+
+func (f *fakeChain) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	selector := func(sig string) []byte { return crypto.Keccak256([]byte(sig))[:4] }
+	switch {
+	case *call.To == common.HexToAddress(nftAddr) && bytes.Equal(call.Data[:4], selector("balanceOf(address)")):
+		return common.LeftPadBytes(big.NewInt(3).Bytes(), 32), nil
+	case *call.To == common.HexToAddress(tokenAddr) && bytes.Equal(call.Data[:4], selector("getVotes(address)")):
+		return common.LeftPadBytes(big.NewInt(1000).Bytes(), 32), nil
+	case *call.To == common.HexToAddress(tokenAddr) && bytes.Equal(call.Data[:4], selector("delegates(address)")):
+		return common.LeftPadBytes(common.HexToAddress(delegate).Bytes(), 32), nil
+	}
+	return nil, errors.New("unexpected call")
+}
+
+// newService registers the NFT and token contracts; staking is not deployed
+func newService(t *testing.T, chain *fakeChain) (*overview.Service, *memory.Store) {
+	t.Helper()
+	ctx := context.Background()
+	store := memory.NewSeededStore()
+	for dbName, addr := range map[string]string{"nexusNFT": nftAddr, "nexusToken": tokenAddr} {
+		mapping, err := store.Contracts.GetMappingByDBName(ctx, dbName)
+		require.NoError(t, err)
+		_, err = store.Contracts.Upsert(ctx, &repository.ContractAddressUpsert{
+			ChainID: chainID, ContractMappingID: mapping.ID, Address: addr,
+		})
+		require.NoError(t, err)
+	}
+
+	svc := overview.NewService(store.Payments, store.Relayer, store.Profiles, store.Contracts, zap.NewNop(), chainID)
+	svc.SetClient(chain)
+	return svc, store
+}
+
+func TestOverview_ComposesSections(t *testing.T) {
+	ctx := context.Background()
+	svc, store := newService(t, &fakeChain{})
+
+	name, email := "Alice", "alice@example.com"
+	require.NoError(t, store.Profiles.UpsertProfile(ctx, &repository.Profile{Address: user, DisplayName: &name, Email: &email, PreferredCurrency: "USD"}))
+	require.NoError(t, store.Payments.CreateKYCVerification(ctx, &repository.KYCVerification{UserAddress: user, Status: repository.KYCStatusApproved}))
+	for _, status := range []repository.PaymentStatus{repository.PaymentStatusCompleted, repository.PaymentStatusCompleted, repository.PaymentStatusPending} {
+		require.NoError(t, store.Payments.CreatePayment(ctx, &repository.Payment{
+			ServiceCode: "kyc_verification", PayerAddress: user, PaymentMethod: "stripe", AmountCharged: 15, Currency: "USD", Status: status,
+		}))
+	}
+	require.NoError(t, store.Relayer.CreateMetaTx(ctx, &repository.MetaTransaction{
+		FromAddress: user, ToAddress: tokenAddr, FunctionName: "transfer", Nonce: 1, Deadline: time.Now().Add(time.Hour), Status: repository.MetaTxStatusPending,
+	}))
+
+	o := svc.Overview(ctx, user)
+	assert.Equal(t, user, o.Address)
+	require.NotNil(t, o.Profile)
+	assert.Equal(t, "Alice", *o.Profile.DisplayName)
+	assert.Nil(t, o.Profile.Email, "the email is not public")
+
+	require.NotNil(t, o.KYC)
+	assert.Equal(t, "approved", o.KYC.Status)
+
+	require.NotNil(t, o.Payments)
+	assert.Equal(t, int64(3), o.Payments.Total)
+	assert.Equal(t, int64(2), o.Payments.Completed)
+	assert.Equal(t, int64(1), o.Payments.Pending)
+	assert.Len(t, o.Payments.Recent, 3)
+
+	require.NotNil(t, o.NFTs)
+	assert.Equal(t, "3", o.NFTs.Balance)
+	require.NotNil(t, o.Voting)
+	assert.Equal(t, "1000", o.Voting.Votes)
+	assert.Equal(t, delegate, o.Voting.DelegatedTo)
+	assert.Len(t, o.PendingMetaTxs, 1)
+
+	// Staking is not registered on the chain, which alone is reported
+	assert.Nil(t, o.Staking)
+	assert.True(t, o.Partial)
+	assert.Equal(t, map[string]string{overview.SectionStaking: overview.ErrNotDeployed.Error()}, o.Errors)
+}
+
+func TestOverview_ToleratesFailingSources(t *testing.T) {
+	svc, _ := newService(t, &fakeChain{err: errors.New("connection refused")})
+
+	o := svc.Overview(context.Background(), user)
+	assert.True(t, o.Partial)
+	assert.Equal(t, overview.ErrUnavailable.Error(), o.Errors[overview.SectionNFTs])
+	assert.Equal(t, overview.ErrUnavailable.Error(), o.Errors[overview.SectionVoting])
+	assert.NotContains(t, o.Errors[overview.SectionVoting], "connection refused")
+
+	// The repository sections still come through
+	require.NotNil(t, o.KYC)
+	assert.Equal(t, "none", o.KYC.Status)
+	require.NotNil(t, o.Payments)
+	assert.Zero(t, o.Payments.Total)
+	assert.Empty(t, o.PendingMetaTxs)
+	assert.NotContains(t, o.Errors, overview.SectionPayments)
+}
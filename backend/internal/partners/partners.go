@@ -0,0 +1,158 @@
+// Package partners attributes completed payments to the referral partners
+// whose codes were given at checkout and computes their revenue share
+package partners
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// ConfigNamespace is the app_config namespace of partner settings
+const ConfigNamespace = "partners"
+
+// DefaultSharePercent is the revenue share of partners created without one
+// while partners.default_share_percent is unset
+const DefaultSharePercent = 10.0
+
+// apiKeyPrefix marks partner API keys so a leaked one is recognizable
+const apiKeyPrefix = "nxp_"
+
+// referralCodePattern is a lowercased referral code: 3-32 letters, digits,
+// dashes and underscores, starting with a letter or digit
+var referralCodePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{2,31}$`)
+
+// NormalizeCode lowercases a referral code, reporting whether it is well formed
+func NormalizeCode(code string) (string, bool) {
+	code = strings.ToLower(strings.TrimSpace(code))
+	return code, referralCodePattern.MatchString(code)
+}
+
+// NewAPIKey generates a partner API key and the hash stored for it
+func NewAPIKey() (key, hash string, err error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", fmt.Errorf("generating partner API key: %w", err)
+	}
+	key = apiKeyPrefix + hex.EncodeToString(b)
+	return key, HashKey(key), nil
+}
+
+// HashKey returns the stored form of a partner API key
+func HashKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// Commission is sharePercent of amountUSD, rounded to the cent
+func Commission(amountUSD, sharePercent float64) float64 {
+	return math.Round(amountUSD*sharePercent) / 100
+}
+
+// ErrInvalidKey is returned for API keys of no active partner
+var ErrInvalidKey = errors.New("invalid partner API key")
+
+// Authenticator resolves partner API keys (implements
+// middleware.PartnerAuthenticator)
+type Authenticator struct {
+	repo repository.PartnerRepository
+}
+
+// NewAuthenticator creates an authenticator looking keys up in repo
+func NewAuthenticator(repo repository.PartnerRepository) *Authenticator {
+	return &Authenticator{repo: repo}
+}
+
+// AuthenticatePartner returns the ID of the active partner key was issued to
+func (a *Authenticator) AuthenticatePartner(ctx context.Context, key string) (string, error) {
+	if !strings.HasPrefix(key, apiKeyPrefix) {
+		return "", ErrInvalidKey
+	}
+	p, err := a.repo.GetPartnerByKeyHash(ctx, HashKey(key))
+	if errors.Is(err, repository.ErrPartnerNotFound) {
+		return "", ErrInvalidKey
+	}
+	if err != nil {
+		return "", fmt.Errorf("looking up partner API key: %w", err)
+	}
+	if !p.IsActive {
+		return "", ErrInvalidKey
+	}
+	return p.ID, nil
+}
+
+// Attributor records and reverses the commissions of referred payments
+type Attributor struct {
+	repo   repository.PartnerRepository
+	logger *zap.Logger
+}
+
+// NewAttributor creates an attributor storing commissions in repo
+func NewAttributor(repo repository.PartnerRepository, logger *zap.Logger) *Attributor {
+	return &Attributor{repo: repo, logger: logger}
+}
+
+// Attribute records the commission a completed payment earns its referrer.
+// Payments without a referrer code, or whose code names no active partner,
+// earn nothing; a payment already attributed keeps its commission.
+func (a *Attributor) Attribute(ctx context.Context, p *repository.Payment) (*repository.PartnerCommission, error) {
+	if p.ReferrerCode == nil || *p.ReferrerCode == "" {
+		return nil, nil
+	}
+	partner, err := a.repo.GetPartnerByCode(ctx, *p.ReferrerCode)
+	if errors.Is(err, repository.ErrPartnerNotFound) {
+		a.logger.Warn("payment referred by unknown partner",
+			zap.String("payment_id", p.ID),
+			zap.String("referrer_code", *p.ReferrerCode),
+		)
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("getting partner %s: %w", *p.ReferrerCode, err)
+	}
+	if !partner.IsActive {
+		return nil, nil
+	}
+
+	amount := p.AmountCharged
+	if p.AmountUSD != nil {
+		amount = *p.AmountUSD
+	} else if p.Currency != "USD" {
+		a.logger.Warn("referred payment has no USD amount",
+			zap.String("payment_id", p.ID),
+			zap.String("currency", p.Currency),
+		)
+		return nil, nil
+	}
+
+	c := &repository.PartnerCommission{
+		PartnerID:     partner.ID,
+		PaymentID:     p.ID,
+		ServiceCode:   p.ServiceCode,
+		AmountUSD:     amount,
+		SharePercent:  partner.SharePercent,
+		CommissionUSD: Commission(amount, partner.SharePercent),
+	}
+	if _, err := a.repo.RecordCommission(ctx, c); err != nil {
+		return nil, fmt.Errorf("recording commission on payment %s: %w", p.ID, err)
+	}
+	return c, nil
+}
+
+// Reverse takes back the commission of a refunded payment, if it earned one
+func (a *Attributor) Reverse(ctx context.Context, paymentID string) error {
+	if _, err := a.repo.ReverseCommission(ctx, paymentID); err != nil {
+		return fmt.Errorf("reversing commission on payment %s: %w", paymentID, err)
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package partners_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/partners"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/storage/memory"
+)
+
+func newPartner(t *testing.T, store *memory.Store, code string, share float64) (*repository.Partner, string) {
+	t.Helper()
+	key, hash, err := partners.NewAPIKey()
+	require.NoError(t, err)
+	p := &repository.Partner{Name: code, ReferralCode: code, SharePercent: share, APIKeyHash: hash, IsActive: true}
+	require.NoError(t, store.Partners.CreatePartner(context.Background(), p))
+	return p, key
+}
+
+func referred(t *testing.T, payments repository.PaymentRepository, code string, amountUSD float64) *repository.Payment {
+	t.Helper()
+	p := &repository.Payment{ServiceCode: "kyc_verification", PayerAddress: "0x00000000000000000000000000000000000000aa",
+		PaymentMethod: "stripe", AmountCharged: amountUSD, Currency: "USD", AmountUSD: &amountUSD,
+		Status: repository.PaymentStatusPending, ReferrerCode: &code}
+	require.NoError(t, payments.CreatePayment(context.Background(), p))
+	return p
+}
+
+func TestAttributingPaymentRepo_EarnsOnCompletionAndReversesOnRefund(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	partner, _ := newPartner(t, store, "acme", 12.5)
+	payments := partners.NewAttributingPaymentRepo(store.Payments, partners.NewAttributor(store.Partners, zap.NewNop()), zap.NewNop())
+
+	p := referred(t, payments, "acme", 15)
+	filter := repository.PartnerCommissionFilter{PartnerID: partner.ID}
+	_, total, err := store.Partners.ListCommissions(ctx, filter, repository.Pagination{Page: 1, PageSize: 20})
+	require.NoError(t, err)
+	assert.Zero(t, total, "pending payments earn nothing")
+
+	require.NoError(t, payments.UpdatePaymentStatus(ctx, p.ID, repository.PaymentStatusCompleted, nil))
+	require.NoError(t, payments.UpdatePaymentStatus(ctx, p.ID, repository.PaymentStatusCompleted, nil))
+	commissions, total, err := store.Partners.ListCommissions(ctx, filter, repository.Pagination{Page: 1, PageSize: 20})
+	require.NoError(t, err)
+	require.EqualValues(t, 1, total)
+	assert.Equal(t, 1.88, commissions[0].CommissionUSD)
+	assert.Equal(t, 12.5, commissions[0].SharePercent)
+	assert.Equal(t, repository.PartnerCommissionEarned, commissions[0].Status)
+
+	// A dispute keeps the commission until it is lost
+	require.NoError(t, payments.UpdatePaymentStatus(ctx, p.ID, repository.PaymentStatusDisputed, nil))
+	totals, err := store.Partners.GetCommissionTotals(ctx, filter)
+	require.NoError(t, err)
+	assert.Equal(t, 1.88, totals.NetUSD)
+
+	require.NoError(t, payments.UpdatePaymentStatus(ctx, p.ID, repository.PaymentStatusRefunded, nil))
+	totals, err = store.Partners.GetCommissionTotals(ctx, filter)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, totals.Payments)
+	assert.Equal(t, 15.0, totals.AmountUSD)
+	assert.Equal(t, 1.88, totals.EarnedUSD)
+	assert.Equal(t, 1.88, totals.ReversedUSD)
+	assert.Zero(t, totals.NetUSD)
+}
+
+func TestAttributor_SkipsUnknownAndInactivePartners(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	partner, _ := newPartner(t, store, "acme", 10)
+	attributor := partners.NewAttributor(store.Partners, zap.NewNop())
+
+	amount := 20.0
+	unknown := "nobody"
+	c, err := attributor.Attribute(ctx, &repository.Payment{ID: "p1", Currency: "USD", AmountUSD: &amount, ReferrerCode: &unknown})
+	require.NoError(t, err)
+	assert.Nil(t, c)
+	c, err = attributor.Attribute(ctx, &repository.Payment{ID: "p2", Currency: "USD", AmountUSD: &amount})
+	require.NoError(t, err)
+	assert.Nil(t, c)
+
+	inactive := false
+	require.NoError(t, store.Partners.UpdatePartner(ctx, partner.ID, &repository.PartnerUpdate{IsActive: &inactive}))
+	code := "acme"
+	c, err = attributor.Attribute(ctx, &repository.Payment{ID: "p3", Currency: "USD", AmountUSD: &amount, ReferrerCode: &code})
+	require.NoError(t, err)
+	assert.Nil(t, c)
+}
+
+func TestAuthenticator_ResolvesActivePartnerKeys(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	partner, key := newPartner(t, store, "acme", 10)
+	auth := partners.NewAuthenticator(store.Partners)
+
+	id, err := auth.AuthenticatePartner(ctx, key)
+	require.NoError(t, err)
+	assert.Equal(t, partner.ID, id)
+	_, err = auth.AuthenticatePartner(ctx, "nxp_"+partners.HashKey(key))
+	assert.ErrorIs(t, err, partners.ErrInvalidKey)
+	_, err = auth.AuthenticatePartner(ctx, "not-a-key")
+	assert.ErrorIs(t, err, partners.ErrInvalidKey)
+
+	inactive := false
+	require.NoError(t, store.Partners.UpdatePartner(ctx, partner.ID, &repository.PartnerUpdate{IsActive: &inactive}))
+	_, err = auth.AuthenticatePartner(ctx, key)
+	assert.ErrorIs(t, err, partners.ErrInvalidKey)
+}
+
+func TestNormalizeCode(t *testing.T) {
+	code, ok := partners.NormalizeCode("  Acme-Labs_1 ")
+	assert.True(t, ok)
+	assert.Equal(t, "acme-labs_1", code)
+	for _, bad := range []string{"ab", "-acme", "acme labs", "acme!", "a123456789012345678901234567890123"} {
+		_, ok := partners.NormalizeCode(bad)
+		assert.False(t, ok, bad)
+	}
+}
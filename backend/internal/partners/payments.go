@@ -0,0 +1,95 @@
+package partners
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure AttributingPaymentRepo implements PaymentRepository
+var _ repository.PaymentRepository = (*AttributingPaymentRepo)(nil)
+
+// AttributingPaymentRepo wraps a PaymentRepository and attributes payments
+// to their referrers when they are created completed or their status
+// changes to completed, and reverses the commission when a completed (or
+// disputed) payment is refunded or its sale is undone. All other methods go
+// straight to the wrapped repository.
+type AttributingPaymentRepo struct {
+	repository.PaymentRepository
+	attributor *Attributor
+	logger     *zap.Logger
+}
+
+// NewAttributingPaymentRepo wraps next, attributing through a
+func NewAttributingPaymentRepo(next repository.PaymentRepository, a *Attributor, logger *zap.Logger) *AttributingPaymentRepo {
+	return &AttributingPaymentRepo{PaymentRepository: next, attributor: a, logger: logger}
+}
+
+// CreatePayment implements repository.PaymentRepository
+func (r *AttributingPaymentRepo) CreatePayment(ctx context.Context, payment *repository.Payment) error {
+	if err := r.PaymentRepository.CreatePayment(ctx, payment); err != nil {
+		return err
+	}
+	if payment.Status == repository.PaymentStatusCompleted {
+		r.attribute(ctx, payment)
+	}
+	return nil
+}
+
+// UpdatePaymentStatus implements repository.PaymentRepository. Updates that
+// leave the status unchanged attribute nothing.
+func (r *AttributingPaymentRepo) UpdatePaymentStatus(ctx context.Context, id string, status repository.PaymentStatus, details *repository.PaymentStatusUpdate) error {
+	before, err := r.PaymentRepository.GetPayment(ctx, id)
+	if err != nil {
+		// Let the update report the error (or apply it without attributing)
+		return r.PaymentRepository.UpdatePaymentStatus(ctx, id, status, details)
+	}
+	if err := r.PaymentRepository.UpdatePaymentStatus(ctx, id, status, details); err != nil {
+		return err
+	}
+	if before.Status == status || before.ReferrerCode == nil {
+		return nil
+	}
+
+	switch {
+	case status == repository.PaymentStatusCompleted:
+		after, err := r.PaymentRepository.GetPayment(ctx, id)
+		if err != nil {
+			r.logger.Error("failed to load payment for partner attribution", zap.String("payment_id", id), zap.Error(err))
+			return nil
+		}
+		r.attribute(ctx, after)
+	case reversesSale(before.Status, status):
+		if err := r.attributor.Reverse(ctx, id); err != nil {
+			r.logger.Error("failed to reverse partner commission", zap.String("payment_id", id), zap.Error(err))
+		}
+	}
+	return nil
+}
+
+// reversesSale reports whether moving from previous to status undoes a
+// completed sale. Disputes keep the commission until they are lost, which
+// refunds the payment.
+func reversesSale(previous, status repository.PaymentStatus) bool {
+	switch previous {
+	case repository.PaymentStatusCompleted:
+		return status == repository.PaymentStatusRefunded || status == repository.PaymentStatusPending ||
+			status == repository.PaymentStatusProcessing || status == repository.PaymentStatusFailed
+	case repository.PaymentStatusDisputed:
+		return status == repository.PaymentStatusRefunded
+	}
+	return false
+}
+
+// attribute records p's commission. The completion is already stored, so a
+// failure is logged rather than returned.
+func (r *AttributingPaymentRepo) attribute(ctx context.Context, p *repository.Payment) {
+	if _, err := r.attributor.Attribute(ctx, p); err != nil {
+		r.logger.Error("failed to attribute payment to partner",
+			zap.String("payment_id", p.ID),
+			zap.Error(err),
+		)
+	}
+}
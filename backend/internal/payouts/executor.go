@@ -0,0 +1,155 @@
+package payouts
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// DefaultInterval is how often Run looks for due payouts when no interval is
+// given
+const DefaultInterval = 30 * time.Second
+
+// dueBatchSize is how many due payouts one pass sends
+const dueBatchSize = 20
+
+// transferSelector is the ERC-20 transfer(address,uint256) selector
+var transferSelector = []byte{0xa9, 0x05, 0x9c, 0xbb}
+
+// Sender sends a transaction from a relayer key (implemented by
+// handlers.RelayerHandler)
+type Sender interface {
+	SendTransfer(ctx context.Context, to common.Address, value *big.Int, data []byte) (common.Address, common.Hash, error)
+}
+
+// Tracker follows a sent transaction to confirmation depth (implemented by
+// chain.ConfirmationTracker)
+type Tracker interface {
+	Track(ctx context.Context, recordType, recordID, txHash string) error
+}
+
+// SetSender enables execution: due payouts are sent through sender and
+// tracked until confirmed
+func (s *Service) SetSender(sender Sender, tracker Tracker) {
+	s.sender = sender
+	s.tracker = tracker
+}
+
+// Run sends due payouts every interval until ctx is done
+func (s *Service) Run(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.RunOnce(ctx); err != nil {
+			s.logger.Warn("payout execution failed", zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce sends the approved payouts that are due, earliest first. Nothing
+// is sent without a sender or while payouts.enabled is off.
+func (s *Service) RunOnce(ctx context.Context) error {
+	if s.sender == nil {
+		return nil
+	}
+	if enabled, err := s.configRepo.GetBool(ctx, namespace, "enabled", s.chainID); err != nil || !enabled {
+		return nil
+	}
+
+	due, err := s.repo.ListDuePayouts(ctx, s.now().UTC(), dueBatchSize)
+	if err != nil {
+		return err
+	}
+	for _, p := range due {
+		if err := s.execute(ctx, p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// execute claims a payout and sends it. The claim (status sending) comes
+// first so a payout is never sent twice: one whose send outcome could not be
+// recorded stays in sending for an operator to check on-chain.
+func (s *Service) execute(ctx context.Context, p *repository.Payout) error {
+	err := s.repo.UpdatePayoutStatus(ctx, p.ID, &repository.PayoutUpdate{Status: repository.PayoutSending})
+	if errors.Is(err, repository.ErrPayoutClosed) {
+		return nil // cancelled, or claimed by another instance
+	}
+	if err != nil {
+		return err
+	}
+
+	to, value, data := transfer(p)
+	from, hash, err := s.sender.SendTransfer(ctx, to, value, data)
+	if err != nil {
+		msg := err.Error()
+		s.logger.Warn("payout not sent", zap.String("payout_id", p.ID), zap.Error(err))
+		return s.repo.UpdatePayoutStatus(ctx, p.ID, &repository.PayoutUpdate{
+			Status:       repository.PayoutFailed,
+			ErrorMessage: &msg,
+		})
+	}
+
+	sender := strings.ToLower(from.Hex())
+	txHash := hash.Hex()
+	if err := s.repo.UpdatePayoutStatus(ctx, p.ID, &repository.PayoutUpdate{
+		Status:        repository.PayoutSubmitted,
+		SenderAddress: &sender,
+		TxHash:        &txHash,
+	}); err != nil {
+		s.logger.Error("payout sent but not recorded",
+			zap.String("payout_id", p.ID),
+			zap.String("tx_hash", txHash),
+			zap.Error(err),
+		)
+		return err
+	}
+
+	if s.tracker != nil {
+		if err := s.tracker.Track(ctx, repository.TrackedRecordPayout, p.ID, txHash); err != nil {
+			s.logger.Error("failed to track payout transaction", zap.String("payout_id", p.ID), zap.String("tx_hash", txHash), zap.Error(err))
+		}
+	}
+
+	s.logger.Info("payout sent",
+		zap.String("payout_id", p.ID),
+		zap.String("recipient", p.Recipient),
+		zap.String("amount", p.Amount),
+		zap.String("currency", p.Currency),
+		zap.String("sender", sender),
+		zap.String("tx_hash", txHash),
+	)
+	return nil
+}
+
+// transfer builds the transaction paying p: a plain ETH transfer, or an
+// ERC-20 transfer call on the NEXUS token
+func transfer(p *repository.Payout) (common.Address, *big.Int, []byte) {
+	amount, _ := new(big.Int).SetString(p.Amount, 10)
+	recipient := common.HexToAddress(p.Recipient)
+	if p.TokenAddress == nil {
+		return recipient, amount, nil
+	}
+
+	data := append(append([]byte{}, transferSelector...), common.LeftPadBytes(recipient.Bytes(), 32)...)
+	data = append(data, common.LeftPadBytes(amount.Bytes(), 32)...)
+	return common.HexToAddress(*p.TokenAddress), new(big.Int), data
+}
@@ -0,0 +1,243 @@
+// Package payouts schedules and sends the protocol's outbound transfers: NFT
+// royalties to the royalty receiver and grants approved by governance. A
+// payout is created pending approval and must be approved by someone other
+// than its creator. Once due, the executor sends it from a relayer key and
+// the confirmation tracker reconciles it against its receipt
+// (chain.PayoutRecordHandler). Sending is off until app_config
+// payouts.enabled is set.
+package payouts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// namespace is the app_config namespace holding the payout settings
+const namespace = "payouts"
+
+// nexusTokenDBName is the contract registry name of the NEXUS token
+const nexusTokenDBName = "nexusToken"
+
+// Payout errors
+var (
+	ErrUnsupportedKind     = errors.New("payout kind must be royalty or grant")
+	ErrUnsupportedCurrency = errors.New("payout currency must be ETH or NEXUS")
+	ErrInvalidRecipient    = errors.New("invalid payout recipient address")
+	ErrInvalidAmount       = errors.New("payout amount must be a positive integer of base units")
+	ErrNoRoyaltyReceiver   = errors.New("no recipient given and payouts.royalty_receiver is not set")
+	ErrProposalRequired    = errors.New("grant payouts need the governance proposal that approved them")
+	ErrProposalNotPassed   = errors.New("governance proposal has not passed")
+	ErrTokenUnavailable    = errors.New("NEXUS token is not deployed on this chain")
+	ErrSelfApproval        = errors.New("a payout cannot be approved by its creator")
+)
+
+// ProposalChecker reports whether a governance proposal has passed
+// (implemented by handlers.GovernanceHandler)
+type ProposalChecker interface {
+	ProposalPassed(ctx context.Context, id string) (bool, error)
+}
+
+// Request describes a payout to schedule. Amount is in base units (18
+// decimals); a royalty without a recipient goes to payouts.royalty_receiver.
+type Request struct {
+	Kind         repository.PayoutKind
+	Recipient    string
+	Currency     string
+	Amount       string
+	Reference    string // governance proposal ID for grants
+	Description  string
+	ScheduledFor *time.Time // unset = due once approved
+	CreatedBy    string
+}
+
+// Service schedules, reviews and executes payouts
+type Service struct {
+	repo       repository.PayoutRepository
+	contracts  repository.ContractRepository
+	configRepo repository.AppConfigRepository
+	proposals  ProposalChecker
+	sender     Sender
+	tracker    Tracker
+	logger     *zap.Logger
+	chainID    int64
+	now        func() time.Time
+}
+
+// NewService creates a new payout service
+func NewService(repo repository.PayoutRepository, contracts repository.ContractRepository, configRepo repository.AppConfigRepository, logger *zap.Logger, chainID int64) *Service {
+	return &Service{
+		repo:       repo,
+		contracts:  contracts,
+		configRepo: configRepo,
+		logger:     logger,
+		chainID:    chainID,
+		now:        time.Now,
+	}
+}
+
+// SetProposals gates grants on their governance proposal having passed,
+// when they are scheduled and again when they are approved
+func (s *Service) SetProposals(proposals ProposalChecker) {
+	s.proposals = proposals
+}
+
+// Schedule validates req and records it as a payout pending approval
+func (s *Service) Schedule(ctx context.Context, req Request) (*repository.Payout, error) {
+	p := &repository.Payout{
+		Kind:        req.Kind,
+		Recipient:   strings.ToLower(req.Recipient),
+		Currency:    strings.ToUpper(req.Currency),
+		Amount:      req.Amount,
+		Description: req.Description,
+		Status:      repository.PayoutPendingApproval,
+		CreatedBy:   req.CreatedBy,
+	}
+	if req.Reference != "" {
+		ref := req.Reference
+		p.Reference = &ref
+	}
+	if req.ScheduledFor != nil {
+		p.ScheduledFor = req.ScheduledFor.UTC()
+	}
+
+	switch p.Kind {
+	case repository.PayoutRoyalty:
+		if p.Recipient == "" {
+			receiver, err := s.configRepo.GetString(ctx, namespace, "royalty_receiver", s.chainID)
+			if err != nil || !common.IsHexAddress(receiver) {
+				return nil, ErrNoRoyaltyReceiver
+			}
+			p.Recipient = strings.ToLower(receiver)
+		}
+	case repository.PayoutGrant:
+		if p.Reference == nil {
+			return nil, ErrProposalRequired
+		}
+		if err := s.checkProposal(ctx, *p.Reference); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, ErrUnsupportedKind
+	}
+
+	if !common.IsHexAddress(p.Recipient) || common.HexToAddress(p.Recipient) == (common.Address{}) {
+		return nil, ErrInvalidRecipient
+	}
+	if amount, ok := new(big.Int).SetString(p.Amount, 10); !ok || amount.Sign() <= 0 {
+		return nil, ErrInvalidAmount
+	}
+
+	switch p.Currency {
+	case "ETH":
+	case "NEXUS":
+		token, err := s.contracts.GetByChainAndDBName(ctx, s.chainID, nexusTokenDBName)
+		if errors.Is(err, repository.ErrContractAddressNotFound) {
+			return nil, ErrTokenUnavailable
+		}
+		if err != nil {
+			return nil, fmt.Errorf("resolving NEXUS token: %w", err)
+		}
+		addr := strings.ToLower(token.Address)
+		p.TokenAddress = &addr
+	default:
+		return nil, ErrUnsupportedCurrency
+	}
+
+	if err := s.repo.CreatePayout(ctx, p); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("payout scheduled",
+		zap.String("payout_id", p.ID),
+		zap.String("kind", string(p.Kind)),
+		zap.String("recipient", p.Recipient),
+		zap.String("amount", p.Amount),
+		zap.String("currency", p.Currency),
+		zap.String("created_by", p.CreatedBy),
+	)
+	return p, nil
+}
+
+// Approve approves a pending payout. The approver must not be its creator.
+func (s *Service) Approve(ctx context.Context, id, approver string) (*repository.Payout, error) {
+	p, err := s.repo.GetPayout(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if strings.EqualFold(strings.TrimSpace(p.CreatedBy), strings.TrimSpace(approver)) {
+		return nil, ErrSelfApproval
+	}
+	if p.Kind == repository.PayoutGrant && p.Reference != nil {
+		if err := s.checkProposal(ctx, *p.Reference); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.repo.UpdatePayoutStatus(ctx, id, &repository.PayoutUpdate{
+		Status:     repository.PayoutApproved,
+		ReviewedBy: &approver,
+	}); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("payout approved", zap.String("payout_id", id), zap.String("approved_by", approver))
+	return s.repo.GetPayout(ctx, id)
+}
+
+// Reject refuses a pending payout
+func (s *Service) Reject(ctx context.Context, id, reviewer, reason string) (*repository.Payout, error) {
+	update := &repository.PayoutUpdate{
+		Status:     repository.PayoutRejected,
+		ReviewedBy: &reviewer,
+	}
+	if reason != "" {
+		update.ErrorMessage = &reason
+	}
+	if err := s.repo.UpdatePayoutStatus(ctx, id, update); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("payout rejected", zap.String("payout_id", id), zap.String("rejected_by", reviewer))
+	return s.repo.GetPayout(ctx, id)
+}
+
+// Cancel withdraws a payout that has not been sent
+func (s *Service) Cancel(ctx context.Context, id, cancelledBy, reason string) (*repository.Payout, error) {
+	msg := "cancelled by " + cancelledBy
+	if reason != "" {
+		msg += ": " + reason
+	}
+	if err := s.repo.UpdatePayoutStatus(ctx, id, &repository.PayoutUpdate{
+		Status:       repository.PayoutCancelled,
+		ErrorMessage: &msg,
+	}); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("payout cancelled", zap.String("payout_id", id), zap.String("cancelled_by", cancelledBy))
+	return s.repo.GetPayout(ctx, id)
+}
+
+// checkProposal refuses a grant whose proposal has not passed
+func (s *Service) checkProposal(ctx context.Context, proposalID string) error {
+	if s.proposals == nil {
+		return nil
+	}
+	passed, err := s.proposals.ProposalPassed(ctx, proposalID)
+	if err != nil {
+		return fmt.Errorf("checking proposal %s: %w", proposalID, err)
+	}
+	if !passed {
+		return ErrProposalNotPassed
+	}
+	return nil
+}
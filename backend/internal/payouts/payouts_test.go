@@ -0,0 +1,202 @@
+package payouts_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/payouts"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/storage/memory"
+)
+
+const (
+	chainID   = 31337
+	recipient = "0x00000000000000000000000000000000000000cc"
+	royalties = "0x00000000000000000000000000000000000000dd"
+	token     = "0x00000000000000000000000000000000000000bb"
+	relayer   = "0x00000000000000000000000000000000000000ee"
+)
+
+// fakeSender records transfers instead of sending them
+type fakeSender struct {
+	sent []sentTransfer
+	err  error
+}
+
+type sentTransfer struct {
+	to    common.Address
+	value *big.Int
+	data  []byte
+}
+
+func (f *fakeSender) SendTransfer(ctx context.Context, to common.Address, value *big.Int, data []byte) (common.Address, common.Hash, error) {
+	if f.err != nil {
+		return common.Address{}, common.Hash{}, f.err
+	}
+	f.sent = append(f.sent, sentTransfer{to: to, value: value, data: data})
+	return common.HexToAddress(relayer), common.BigToHash(big.NewInt(int64(len(f.sent)))), nil
+}
+
+// fakeTracker records tracked transactions
+type fakeTracker map[string]string
+
+func (f fakeTracker) Track(ctx context.Context, recordType, recordID, txHash string) error {
+	f[recordID] = recordType + ":" + txHash
+	return nil
+}
+
+// passed is a ProposalChecker passing the listed proposals
+type passed map[string]bool
+
+func (p passed) ProposalPassed(ctx context.Context, id string) (bool, error) {
+	return p[id], nil
+}
+
+func newService(t *testing.T) (*payouts.Service, *memory.Store, *fakeSender, fakeTracker) {
+	t.Helper()
+	ctx := context.Background()
+	store := memory.NewSeededStore()
+
+	mapping, err := store.Contracts.GetMappingByDBName(ctx, "nexusToken")
+	require.NoError(t, err)
+	_, err = store.Contracts.Upsert(ctx, &repository.ContractAddressUpsert{ChainID: chainID, ContractMappingID: mapping.ID, Address: token})
+	require.NoError(t, err)
+
+	enabled, receiver := true, royalties
+	require.NoError(t, store.AppConfig.Create(ctx, &repository.AppConfigCreate{
+		Namespace: "payouts", ConfigKey: "enabled", ValueType: "boolean", ValueBoolean: &enabled, UpdatedBy: "test",
+	}))
+	require.NoError(t, store.AppConfig.Create(ctx, &repository.AppConfigCreate{
+		Namespace: "payouts", ConfigKey: "royalty_receiver", ValueType: "address", ValueString: &receiver, UpdatedBy: "test",
+	}))
+
+	svc := payouts.NewService(store.Payout, store.Contracts, store.AppConfig, zap.NewNop(), chainID)
+	svc.SetProposals(passed{"42": true})
+	sender, tracker := &fakeSender{}, fakeTracker{}
+	svc.SetSender(sender, tracker)
+	return svc, store, sender, tracker
+}
+
+func TestSchedule_Validates(t *testing.T) {
+	svc, _, _, _ := newService(t)
+	ctx := context.Background()
+
+	royalty, err := svc.Schedule(ctx, payouts.Request{Kind: repository.PayoutRoyalty, Currency: "eth", Amount: "1000", CreatedBy: "alice"})
+	require.NoError(t, err)
+	assert.Equal(t, royalties, royalty.Recipient, "royalties default to the royalty receiver")
+	assert.Equal(t, "ETH", royalty.Currency)
+	assert.Nil(t, royalty.TokenAddress)
+	assert.Equal(t, repository.PayoutPendingApproval, royalty.Status)
+
+	grant, err := svc.Schedule(ctx, payouts.Request{Kind: repository.PayoutGrant, Recipient: recipient, Currency: "NEXUS",
+		Amount: "5000", Reference: "42", CreatedBy: "alice"})
+	require.NoError(t, err)
+	require.NotNil(t, grant.TokenAddress)
+	assert.Equal(t, token, *grant.TokenAddress)
+
+	for name, tc := range map[string]struct {
+		req  payouts.Request
+		want error
+	}{
+		"grant without proposal": {payouts.Request{Kind: repository.PayoutGrant, Recipient: recipient, Currency: "ETH", Amount: "1"}, payouts.ErrProposalRequired},
+		"proposal not passed":    {payouts.Request{Kind: repository.PayoutGrant, Recipient: recipient, Currency: "ETH", Amount: "1", Reference: "7"}, payouts.ErrProposalNotPassed},
+		"zero amount":            {payouts.Request{Kind: repository.PayoutRoyalty, Currency: "ETH", Amount: "0"}, payouts.ErrInvalidAmount},
+		"bad recipient":          {payouts.Request{Kind: repository.PayoutRoyalty, Recipient: "0x12", Currency: "ETH", Amount: "1"}, payouts.ErrInvalidRecipient},
+		"unknown currency":       {payouts.Request{Kind: repository.PayoutRoyalty, Currency: "BTC", Amount: "1"}, payouts.ErrUnsupportedCurrency},
+		"unknown kind":           {payouts.Request{Kind: "bonus", Recipient: recipient, Currency: "ETH", Amount: "1"}, payouts.ErrUnsupportedKind},
+	} {
+		_, err := svc.Schedule(ctx, tc.req)
+		assert.ErrorIs(t, err, tc.want, name)
+	}
+}
+
+func TestApprove_RequiresSecondPerson(t *testing.T) {
+	svc, _, _, _ := newService(t)
+	ctx := context.Background()
+
+	p, err := svc.Schedule(ctx, payouts.Request{Kind: repository.PayoutRoyalty, Currency: "ETH", Amount: "1000", CreatedBy: "alice"})
+	require.NoError(t, err)
+
+	_, err = svc.Approve(ctx, p.ID, "Alice")
+	assert.ErrorIs(t, err, payouts.ErrSelfApproval)
+
+	approved, err := svc.Approve(ctx, p.ID, "bob")
+	require.NoError(t, err)
+	assert.Equal(t, repository.PayoutApproved, approved.Status)
+	assert.Equal(t, "bob", *approved.ReviewedBy)
+	assert.NotNil(t, approved.ReviewedAt)
+
+	_, err = svc.Reject(ctx, p.ID, "carol", "changed my mind")
+	assert.ErrorIs(t, err, repository.ErrPayoutClosed, "an approved payout can be cancelled, not rejected")
+}
+
+func TestRunOnce_SendsDuePayouts(t *testing.T) {
+	svc, store, sender, tracker := newService(t)
+	ctx := context.Background()
+
+	schedule := func(req payouts.Request) *repository.Payout {
+		req.CreatedBy = "alice"
+		p, err := svc.Schedule(ctx, req)
+		require.NoError(t, err)
+		_, err = svc.Approve(ctx, p.ID, "bob")
+		require.NoError(t, err)
+		return p
+	}
+	later := time.Now().Add(time.Hour)
+	eth := schedule(payouts.Request{Kind: repository.PayoutRoyalty, Currency: "ETH", Amount: "1000"})
+	nexus := schedule(payouts.Request{Kind: repository.PayoutGrant, Recipient: recipient, Currency: "NEXUS", Amount: "5000", Reference: "42"})
+	scheduled := schedule(payouts.Request{Kind: repository.PayoutRoyalty, Currency: "ETH", Amount: "1", ScheduledFor: &later})
+	cancelled := schedule(payouts.Request{Kind: repository.PayoutRoyalty, Currency: "ETH", Amount: "1"})
+	_, err := svc.Cancel(ctx, cancelled.ID, "carol", "duplicate")
+	require.NoError(t, err)
+
+	require.NoError(t, svc.RunOnce(ctx))
+	require.Len(t, sender.sent, 2, "only due, approved payouts are sent")
+
+	assert.Equal(t, common.HexToAddress(royalties), sender.sent[0].to)
+	assert.Equal(t, "1000", sender.sent[0].value.String())
+	assert.Equal(t, common.HexToAddress(token), sender.sent[1].to, "NEXUS is sent by calling the token")
+	assert.Equal(t, common.HexToAddress(recipient), common.BytesToAddress(sender.sent[1].data[4:36]))
+	assert.Equal(t, "5000", new(big.Int).SetBytes(sender.sent[1].data[36:]).String())
+
+	for _, id := range []string{eth.ID, nexus.ID} {
+		got, err := store.Payout.GetPayout(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, repository.PayoutSubmitted, got.Status)
+		assert.Equal(t, relayer, *got.SenderAddress)
+		assert.Equal(t, repository.TrackedRecordPayout+":"+*got.TxHash, tracker[id])
+	}
+	got, err := store.Payout.GetPayout(ctx, scheduled.ID)
+	require.NoError(t, err)
+	assert.Equal(t, repository.PayoutApproved, got.Status)
+
+	// Sent payouts are not sent again
+	require.NoError(t, svc.RunOnce(ctx))
+	assert.Len(t, sender.sent, 2)
+}
+
+func TestRunOnce_SendFailure(t *testing.T) {
+	svc, store, sender, _ := newService(t)
+	ctx := context.Background()
+	sender.err = errors.New("gas price too high: 500 gwei")
+
+	p, err := svc.Schedule(ctx, payouts.Request{Kind: repository.PayoutRoyalty, Currency: "ETH", Amount: "1000", CreatedBy: "alice"})
+	require.NoError(t, err)
+	_, err = svc.Approve(ctx, p.ID, "bob")
+	require.NoError(t, err)
+
+	require.NoError(t, svc.RunOnce(ctx))
+	got, err := store.Payout.GetPayout(ctx, p.ID)
+	require.NoError(t, err)
+	assert.Equal(t, repository.PayoutFailed, got.Status)
+	assert.Contains(t, *got.ErrorMessage, "gas price too high")
+	assert.Nil(t, got.TxHash)
+}
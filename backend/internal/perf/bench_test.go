@@ -0,0 +1,38 @@
+//go:build perf
+
+package perf_test
+
+import (
+	"testing"
+)
+
+func BenchmarkPricingList(b *testing.B) {
+	benchmarkScenario(b, "pricing_list")
+}
+
+func BenchmarkRelayValidation(b *testing.B) {
+	benchmarkScenario(b, "relay_validation")
+}
+
+func BenchmarkComplianceCheck(b *testing.B) {
+	benchmarkScenario(b, "compliance_check")
+}
+
+// benchmarkScenario times the named scenario of newServer
+func benchmarkScenario(b *testing.B, name string) {
+	router, scenarios := newServer(b)
+	for _, s := range scenarios {
+		if s.name != name {
+			continue
+		}
+		s.serve(b, router)
+
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			s.serve(b, router)
+		}
+		return
+	}
+	b.Fatalf("no scenario %q", name)
+}
@@ -0,0 +1,87 @@
+//go:build perf
+
+package perf_test
+
+import (
+	"encoding/json"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// defaultSamples is how many requests are timed per scenario
+const defaultSamples = 2000
+
+// TestLatencyBudgets fails when a scenario's p95 latency exceeds its budget
+// in testdata/budgets.json (times PERF_BUDGET_SCALE)
+func TestLatencyBudgets(t *testing.T) {
+	budgets := loadBudgets(t)
+	scale := envFloat(t, "PERF_BUDGET_SCALE", 1)
+	samples := int(envFloat(t, "PERF_SAMPLES", defaultSamples))
+	router, scenarios := newServer(t)
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			budget, ok := budgets[s.name]
+			require.True(t, ok, "no budget for %s in testdata/budgets.json", s.name)
+			limit := time.Duration(float64(budget) * scale)
+
+			// Warm caches and the allocator before timing
+			for i := 0; i < samples/10; i++ {
+				s.serve(t, router)
+			}
+			latencies := make([]time.Duration, samples)
+			for i := range latencies {
+				start := time.Now()
+				s.serve(t, router)
+				latencies[i] = time.Since(start)
+			}
+			sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+
+			p50, p95 := percentile(latencies, 50), percentile(latencies, 95)
+			t.Logf("p50 %s, p95 %s, max %s (budget %s, %d samples)", p50, p95, latencies[len(latencies)-1], limit, samples)
+			if p95 > limit {
+				t.Errorf("p95 latency %s exceeds the %s budget", p95, limit)
+			}
+		})
+	}
+}
+
+// loadBudgets reads the p95 budget of each scenario
+func loadBudgets(t *testing.T) map[string]time.Duration {
+	raw, err := os.ReadFile("testdata/budgets.json")
+	require.NoError(t, err)
+	var spec map[string]string
+	require.NoError(t, json.Unmarshal(raw, &spec))
+
+	budgets := make(map[string]time.Duration, len(spec))
+	for name, value := range spec {
+		d, err := time.ParseDuration(value)
+		require.NoError(t, err, "budget %s", name)
+		budgets[name] = d
+	}
+	return budgets
+}
+
+// percentile returns the pth percentile of sorted latencies (nearest rank)
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	return sorted[max(rank, 1)-1]
+}
+
+// envFloat reads a positive number from the environment
+func envFloat(t *testing.T, key string, fallback float64) float64 {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+	f, err := strconv.ParseFloat(value, 64)
+	require.NoError(t, err, key)
+	require.Positive(t, f, key)
+	return f
+}
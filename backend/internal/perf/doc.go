@@ -0,0 +1,15 @@
+// Package perf holds httptest benchmarks of the hot API endpoints against
+// the in-memory backend, and the latency budgets they must stay within.
+// The tests are behind the "perf" build tag:
+//
+//	# benchmarks
+//	go test -tags perf -run '^$' -bench . -benchmem ./internal/perf/...
+//
+//	# budgets: fails when a scenario's p95 latency exceeds its budget
+//	go test -tags perf -run TestLatencyBudgets -count=1 ./internal/perf/...
+//
+// Budgets are p95 latencies per scenario in testdata/budgets.json, set for
+// a CI runner with headroom. PERF_BUDGET_SCALE multiplies them on slower
+// machines (e.g. 2 under -race) and PERF_SAMPLES sets how many requests are
+// timed per scenario (default 2000).
+package perf
@@ -0,0 +1,130 @@
+//go:build perf
+
+package perf_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/chain"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/fixtures"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/handlers"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/middleware"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/storage/memory"
+)
+
+const (
+	chainID = 31337
+
+	// Anvil's second default account; the relayer only needs a key to start
+	relayerKey       = "0x59c6995e998f97a5a0044966f0945389dc9e86dae88c7a8412f4603b6b78690d"
+	forwarderAddress = "0x5FbDB2315678afecb367f032d93F642f64180aa3"
+	signerAddress    = "0x70997970C51812dc3A010C7d01b50e0d17dc79C8"
+	targetAddress    = "0xe7f1725E7734CE288F8367e1Bb143E90bb3F0512"
+)
+
+// scenario is one request timed by the benchmarks and budgets
+type scenario struct {
+	name   string
+	method string
+	path   string
+	body   string
+	status int // expected, so a broken route is not timed as a fast one
+}
+
+// request builds a fresh request for s
+func (s scenario) request() *http.Request {
+	req := httptest.NewRequest(s.method, s.path, strings.NewReader(s.body))
+	if s.body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return req
+}
+
+// serve sends one request for s through router and checks its status
+func (s scenario) serve(tb testing.TB, router http.Handler) {
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, s.request())
+	if w.Code != s.status {
+		tb.Fatalf("%s: status %d, want %d: %s", s.name, w.Code, s.status, w.Body.String())
+	}
+}
+
+// newServer routes the benchmarked endpoints as cmd/server does, against
+// the seeded in-memory store and the test KYC fixtures. It returns the
+// scenarios to time.
+func newServer(tb testing.TB) (*gin.Engine, []scenario) {
+	tb.Helper()
+	gin.SetMode(gin.TestMode)
+	logger := zap.NewNop()
+	store := memory.NewSeededStore()
+
+	f, err := fixtures.For("test", time.Now())
+	require.NoError(tb, err)
+	kycHandler := handlers.NewKYCHandler(logger)
+	kycHandler.Seed(f.KYC)
+	approved := f.KYC.Registrations[0].Address
+
+	node := httptest.NewServer(http.HandlerFunc(serveChainID))
+	tb.Cleanup(node.Close)
+	tb.Setenv("RELAYER_PRIVATE_KEY", relayerKey)
+	tb.Setenv("FORWARDER_ADDRESS", forwarderAddress)
+	manager := chain.NewClientManager(nil, logger)
+	manager.AddEndpoints(chainID, node.URL)
+	tb.Cleanup(manager.Close)
+	relayerHandler, err := handlers.NewRelayerHandler(store.Relayer, store.AppConfig, manager, logger, chainID, nil)
+	require.NoError(tb, err)
+
+	pricingHandler := handlers.NewPricingHandler(store.Pricing, logger)
+
+	router := gin.New()
+	api := router.Group("/api/v1")
+	api.GET("/pricing", middleware.SparseFields("data.pricing"), pricingHandler.ListPricing)
+	api.POST("/relay", middleware.BodyLimit(middleware.DefaultRelayBodyLimit), relayerHandler.Relay)
+	api.GET("/kyc/check/:address", kycHandler.CheckCompliance)
+
+	// Well formed up to the signature, which is rejected before any chain call
+	relay, err := json.Marshal(map[string]interface{}{
+		"from":      signerAddress,
+		"to":        targetAddress,
+		"value":     "0",
+		"gas":       100000,
+		"nonce":     0,
+		"deadline":  time.Now().Add(time.Hour).Unix(),
+		"data":      "0x" + strings.Repeat("ab", 68),
+		"signature": "0x1234",
+	})
+	require.NoError(tb, err)
+
+	return router, []scenario{
+		{name: "pricing_list", method: http.MethodGet, path: "/api/v1/pricing", status: http.StatusOK},
+		{name: "relay_validation", method: http.MethodPost, path: "/api/v1/relay", body: string(relay), status: http.StatusBadRequest},
+		{name: "compliance_check", method: http.MethodGet, path: "/api/v1/kyc/check/" + approved, status: http.StatusOK},
+	}
+}
+
+// serveChainID is a JSON-RPC node that only knows its chain ID, which is
+// all the relayer asks for at startup
+func serveChainID(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		ID     json.RawMessage `json:"id"`
+		Method string          `json:"method"`
+	}
+	_ = json.NewDecoder(r.Body).Decode(&req)
+	resp := map[string]interface{}{"jsonrpc": "2.0", "id": req.ID}
+	if req.Method == "eth_chainId" {
+		resp["result"] = "0x7a69"
+	} else {
+		resp["error"] = map[string]interface{}{"code": -32601, "message": "method not found"}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
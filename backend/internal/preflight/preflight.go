@@ -0,0 +1,310 @@
+// Package preflight validates the configured contracts before the API takes
+// traffic. It checks that every required contract mapping has an active
+// address on the configured chain, that bytecode exists at each registered
+// address, and that the forwarder relays go through is the one registered
+// and signs for the expected EIP-712 domain. Failing checks name what to fix;
+// unless disabled they hold /ready and /startup until they pass.
+package preflight
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/contracts"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// forwarderDBName is the contract registry name of NexusForwarder
+const forwarderDBName = "nexusForwarder"
+
+// Check names
+const (
+	CheckRegistered = "registered" // a required mapping has an active address
+	CheckBytecode   = "bytecode"   // code exists at a registered address
+	CheckForwarder  = "forwarder"  // the relayer's forwarder is the registered one
+	CheckDomain     = "eip712_domain"
+)
+
+// Result statuses
+const (
+	StatusPass = "pass"
+	StatusFail = "fail"
+	StatusWarn = "warn" // reported, but does not fail the preflight
+	StatusSkip = "skip" // not checked (no RPC endpoint configured)
+)
+
+// Client is the chain access the on-chain checks need (implemented by
+// chain.FailoverClient)
+type Client interface {
+	bind.ContractCaller
+}
+
+// ClientFunc returns the configured chain's client, nil without an RPC
+// endpoint
+type ClientFunc func(ctx context.Context) (Client, error)
+
+// Result is the outcome of one check of one contract
+type Result struct {
+	Check    string `json:"check"`
+	Contract string `json:"contract,omitempty"` // db_name
+	Address  string `json:"address,omitempty"`
+	Status   string `json:"status"`
+	Message  string `json:"message"`
+	Fix      string `json:"fix,omitempty"`
+}
+
+// Report is the outcome of a preflight run
+type Report struct {
+	ChainID   int64     `json:"chain_id"`
+	Passed    bool      `json:"passed"`
+	Results   []Result  `json:"results"`
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+// Failures returns the failed results
+func (r *Report) Failures() []Result {
+	var failed []Result
+	for _, res := range r.Results {
+		if res.Status == StatusFail {
+			failed = append(failed, res)
+		}
+	}
+	return failed
+}
+
+// Err summarizes the failed checks, nil when the preflight passed
+func (r *Report) Err() error {
+	failed := r.Failures()
+	if len(failed) == 0 {
+		return nil
+	}
+	msgs := make([]string, len(failed))
+	for i, f := range failed {
+		msgs[i] = f.Message
+		if f.Fix != "" {
+			msgs[i] += " (" + f.Fix + ")"
+		}
+	}
+	return fmt.Errorf("contract preflight failed: %s", strings.Join(msgs, "; "))
+}
+
+// Checker runs the preflight checks for one chain
+type Checker struct {
+	contracts repository.ContractRepository
+	client    ClientFunc
+	chainID   int64
+	forwarder string // FORWARDER_ADDRESS, "" when the relayer is not configured
+	logger    *zap.Logger
+	now       func() time.Time
+}
+
+// NewChecker creates a checker of chainID's contracts
+func NewChecker(contracts repository.ContractRepository, client ClientFunc, chainID int64, logger *zap.Logger) *Checker {
+	return &Checker{
+		contracts: contracts,
+		client:    client,
+		chainID:   chainID,
+		logger:    logger,
+		now:       time.Now,
+	}
+}
+
+// SetForwarderAddress sets the forwarder the relayer is configured with
+// (FORWARDER_ADDRESS), checked against the registered nexusForwarder
+func (c *Checker) SetForwarderAddress(address string) {
+	c.forwarder = address
+}
+
+// Run runs every check. Failing to read the registry fails the preflight
+// rather than returning an error.
+func (c *Checker) Run(ctx context.Context) *Report {
+	report := &Report{ChainID: c.chainID, Results: []Result{}, CheckedAt: c.now().UTC()}
+	add := func(r Result) { report.Results = append(report.Results, r) }
+
+	if _, err := c.contracts.GetNetworkByChainID(ctx, c.chainID); err != nil {
+		add(Result{Check: CheckRegistered, Status: StatusFail,
+			Message: fmt.Sprintf("chain %d has no network configuration: %v", c.chainID, err),
+			Fix:     "add the network to network_config or set CHAIN_ID to a configured chain",
+		})
+		return report
+	}
+	mappings, err := c.contracts.GetAllMappings(ctx)
+	if err != nil {
+		add(Result{Check: CheckRegistered, Status: StatusFail, Message: "reading contract mappings: " + err.Error()})
+		return report
+	}
+	deployed, err := c.contracts.GetByChainID(ctx, c.chainID)
+	if err != nil {
+		add(Result{Check: CheckRegistered, Status: StatusFail, Message: "reading contract addresses: " + err.Error()})
+		return report
+	}
+	byMapping := make(map[string]*repository.ContractAddress, len(deployed))
+	for _, d := range deployed {
+		if d.IsPrimary {
+			byMapping[d.ContractMappingID] = d
+		}
+	}
+
+	client, clientErr := c.client(ctx)
+	var active []*repository.ContractAddress
+	for _, m := range mappings {
+		d := byMapping[m.ID]
+		switch {
+		case d == nil && m.IsRequired:
+			add(Result{Check: CheckRegistered, Contract: m.DBName, Status: StatusFail,
+				Message: fmt.Sprintf("%s has no address on chain %d", m.DBName, c.chainID),
+				Fix:     "register the " + m.SolidityName + " deployment with POST /api/v1/contracts/deployments",
+			})
+			continue
+		case d == nil:
+			continue
+		case d.Status != "active" && m.IsRequired:
+			add(Result{Check: CheckRegistered, Contract: m.DBName, Address: d.Address, Status: StatusFail,
+				Message: fmt.Sprintf("%s at %s is %s", m.DBName, d.Address, d.Status),
+				Fix:     "register the active " + m.SolidityName + " deployment",
+			})
+			continue
+		case d.Status != "active":
+			continue
+		case m.IsRequired:
+			add(Result{Check: CheckRegistered, Contract: m.DBName, Address: d.Address, Status: StatusPass, Message: "registered"})
+		}
+		active = append(active, d)
+	}
+
+	if clientErr != nil || client == nil {
+		msg := "no RPC endpoint configured"
+		status := StatusSkip
+		if clientErr != nil {
+			msg, status = "RPC unavailable: "+clientErr.Error(), StatusFail
+		}
+		add(Result{Check: CheckBytecode, Status: status, Message: msg, Fix: fixRPC(clientErr)})
+	} else {
+		for _, d := range active {
+			add(c.checkBytecode(ctx, client, d))
+		}
+	}
+
+	for _, r := range c.checkForwarder(ctx, client, byMapping, mappings) {
+		add(r)
+	}
+
+	report.Passed = report.Err() == nil
+	return report
+}
+
+// Check runs the preflight and returns its failures as one error (a
+// handlers.StartupCheck)
+func (c *Checker) Check(ctx context.Context) error {
+	err := c.Run(ctx).Err()
+	if err != nil {
+		c.logger.Warn("contract preflight failed", zap.Int64("chain_id", c.chainID), zap.Error(err))
+	}
+	return err
+}
+
+// checkBytecode checks that code exists at d's address
+func (c *Checker) checkBytecode(ctx context.Context, client Client, d *repository.ContractAddress) Result {
+	r := Result{Check: CheckBytecode, Contract: d.DBName, Address: d.Address}
+	if !common.IsHexAddress(d.Address) {
+		r.Status, r.Message = StatusFail, fmt.Sprintf("%s address %q is not an address", d.DBName, d.Address)
+		r.Fix = "register the deployment again with its contract address"
+		return r
+	}
+	code, err := client.CodeAt(ctx, common.HexToAddress(d.Address), nil)
+	switch {
+	case err != nil:
+		r.Status, r.Message = StatusFail, fmt.Sprintf("reading %s bytecode: %v", d.DBName, err)
+		r.Fix = fixRPC(err)
+	case len(code) == 0:
+		r.Status, r.Message = StatusFail, fmt.Sprintf("no bytecode at %s (%s) on chain %d", d.Address, d.DBName, c.chainID)
+		r.Fix = "deploy " + d.SolidityName + " or check that RPC_URL serves chain " + fmt.Sprint(c.chainID)
+	default:
+		r.Status, r.Message = StatusPass, fmt.Sprintf("%d bytes of code", len(code))
+	}
+	return r
+}
+
+// checkForwarder checks the relayer's forwarder against the registry and
+// its EIP-712 domain against the chain and the registered metadata
+func (c *Checker) checkForwarder(ctx context.Context, client Client, byMapping map[string]*repository.ContractAddress, mappings []*repository.ContractMapping) []Result {
+	var registered *repository.ContractAddress
+	for _, m := range mappings {
+		if m.DBName == forwarderDBName {
+			registered = byMapping[m.ID]
+		}
+	}
+	if registered == nil {
+		// Reported by the registration check when the forwarder is required
+		return nil
+	}
+
+	var results []Result
+	if c.forwarder != "" {
+		r := Result{Check: CheckForwarder, Contract: forwarderDBName, Address: registered.Address, Status: StatusPass, Message: "FORWARDER_ADDRESS matches the registry"}
+		if !strings.EqualFold(c.forwarder, registered.Address) {
+			r.Status = StatusFail
+			r.Message = fmt.Sprintf("FORWARDER_ADDRESS %s is not the registered nexusForwarder %s", c.forwarder, registered.Address)
+			r.Fix = "set FORWARDER_ADDRESS to the registered forwarder, or register the forwarder the relayer uses"
+		}
+		results = append(results, r)
+	}
+	if client == nil || !common.IsHexAddress(registered.Address) {
+		return results
+	}
+
+	r := Result{Check: CheckDomain, Contract: forwarderDBName, Address: registered.Address}
+	address := common.HexToAddress(registered.Address)
+	caller, err := contracts.NewNexusForwarderCaller(address, client)
+	if err != nil {
+		r.Status, r.Message = StatusFail, "binding forwarder: "+err.Error()
+		return append(results, r)
+	}
+	domain, err := caller.Eip712Domain(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		// The relayer falls back to the registered metadata or the default
+		r.Status, r.Message = StatusWarn, "eip712Domain() unavailable: "+err.Error()
+		r.Fix = "set eip712_name and eip712_version on the nexusForwarder registration"
+		if errors.Is(err, bind.ErrNoCode) {
+			r.Status = StatusFail
+		}
+		return append(results, r)
+	}
+
+	var mismatches []string
+	if domain.ChainId == nil || domain.ChainId.Int64() != c.chainID {
+		mismatches = append(mismatches, fmt.Sprintf("chainId %v, expected %d", domain.ChainId, c.chainID))
+	}
+	if domain.VerifyingContract != address {
+		mismatches = append(mismatches, fmt.Sprintf("verifyingContract %s, expected %s", domain.VerifyingContract.Hex(), address.Hex()))
+	}
+	if registered.EIP712Name != nil && *registered.EIP712Name != domain.Name {
+		mismatches = append(mismatches, fmt.Sprintf("name %q, registered %q", domain.Name, *registered.EIP712Name))
+	}
+	if registered.EIP712Version != nil && *registered.EIP712Version != domain.Version {
+		mismatches = append(mismatches, fmt.Sprintf("version %q, registered %q", domain.Version, *registered.EIP712Version))
+	}
+	if len(mismatches) > 0 {
+		r.Status = StatusFail
+		r.Message = "forwarder EIP-712 domain has " + strings.Join(mismatches, ", ")
+		r.Fix = "register the forwarder's actual domain (eip712_name, eip712_version) or the forwarder deployed on this chain"
+		return append(results, r)
+	}
+	r.Status, r.Message = StatusPass, fmt.Sprintf("domain %s version %s", domain.Name, domain.Version)
+	return append(results, r)
+}
+
+// fixRPC is the fix for a failed RPC call
+func fixRPC(err error) string {
+	if err == nil {
+		return ""
+	}
+	return "check RPC_URL and the network's RPC endpoints"
+}
@@ -0,0 +1,157 @@
+package preflight_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/contracts"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/preflight"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/storage/memory"
+)
+
+const chainID = 31337
+
+var (
+	token     = common.HexToAddress("0x00000000000000000000000000000000000000c1")
+	forwarder = common.HexToAddress("0x00000000000000000000000000000000000000f1")
+)
+
+// fakeChain has code at the deployed addresses and answers the forwarder's
+// eip712Domain()
+type fakeChain struct {
+	code         map[common.Address][]byte
+	domainName   string
+	domainChain  int64
+	noDomainCall bool
+}
+
+func (f *fakeChain) CodeAt(_ context.Context, account common.Address, _ *big.Int) ([]byte, error) {
+	return f.code[account], nil
+}
+
+func (f *fakeChain) CallContract(_ context.Context, call ethereum.CallMsg, _ *big.Int) ([]byte, error) {
+	forwarderABI, err := contracts.NexusForwarderMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	method := forwarderABI.Methods["eip712Domain"]
+	if f.noDomainCall || !bytes.HasPrefix(call.Data, method.ID) {
+		return nil, errors.New("execution reverted")
+	}
+	return method.Outputs.Pack([1]byte{0x0f}, f.domainName, "1", big.NewInt(f.domainChain), *call.To, [32]byte{}, []*big.Int{})
+}
+
+func setup(t *testing.T) (*memory.MemoryContractRepo, map[string]string) {
+	t.Helper()
+	repo := memory.NewMemoryContractRepo()
+	repo.AddNetwork(&repository.NetworkConfig{ChainID: chainID, NetworkName: "localhost", IsActive: true})
+	ids := map[string]string{
+		"nexusToken":     repo.AddMapping(&repository.ContractMapping{SolidityName: "NexusToken", DBName: "nexusToken", IsRequired: true}),
+		"nexusForwarder": repo.AddMapping(&repository.ContractMapping{SolidityName: "NexusForwarder", DBName: "nexusForwarder", IsRequired: true}),
+		"nexusBridge":    repo.AddMapping(&repository.ContractMapping{SolidityName: "NexusBridge", DBName: "nexusBridge"}),
+	}
+	return repo, ids
+}
+
+func register(t *testing.T, repo *memory.MemoryContractRepo, mappingID string, address common.Address, name *string) {
+	t.Helper()
+	_, err := repo.Upsert(context.Background(), &repository.ContractAddressUpsert{
+		ChainID: chainID, ContractMappingID: mappingID, Address: address.Hex(), EIP712Name: name,
+	})
+	require.NoError(t, err)
+}
+
+// statuses indexes results by check and contract
+func statuses(report *preflight.Report) map[string]string {
+	out := make(map[string]string)
+	for _, r := range report.Results {
+		out[r.Check+"/"+r.Contract] = r.Status
+	}
+	return out
+}
+
+func TestChecker_Passes(t *testing.T) {
+	repo, ids := setup(t)
+	name := "NexusForwarder"
+	register(t, repo, ids["nexusToken"], token, nil)
+	register(t, repo, ids["nexusForwarder"], forwarder, &name)
+	chain := &fakeChain{code: map[common.Address][]byte{token: {0x60}, forwarder: {0x60}}, domainName: "NexusForwarder", domainChain: chainID}
+
+	checker := preflight.NewChecker(repo, func(context.Context) (preflight.Client, error) { return chain, nil }, chainID, zap.NewNop())
+	checker.SetForwarderAddress(forwarder.Hex())
+	report := checker.Run(context.Background())
+	require.True(t, report.Passed, report.Err())
+	assert.NoError(t, checker.Check(context.Background()))
+	assert.Equal(t, map[string]string{
+		"registered/nexusToken":        preflight.StatusPass,
+		"registered/nexusForwarder":    preflight.StatusPass,
+		"bytecode/nexusToken":          preflight.StatusPass,
+		"bytecode/nexusForwarder":      preflight.StatusPass,
+		"forwarder/nexusForwarder":     preflight.StatusPass,
+		"eip712_domain/nexusForwarder": preflight.StatusPass,
+	}, statuses(report))
+}
+
+func TestChecker_ReportsFailuresWithFixes(t *testing.T) {
+	repo, ids := setup(t)
+	name := "NexusForwarder"
+	register(t, repo, ids["nexusForwarder"], forwarder, &name)
+	// The forwarder has no code here, and signs for another chain and name
+	chain := &fakeChain{code: map[common.Address][]byte{}, domainName: "Other", domainChain: 1}
+
+	checker := preflight.NewChecker(repo, func(context.Context) (preflight.Client, error) { return chain, nil }, chainID, zap.NewNop())
+	checker.SetForwarderAddress("0x00000000000000000000000000000000000000f9")
+	report := checker.Run(context.Background())
+	assert.False(t, report.Passed)
+	assert.Equal(t, map[string]string{
+		"registered/nexusToken":        preflight.StatusFail,
+		"registered/nexusForwarder":    preflight.StatusPass,
+		"bytecode/nexusForwarder":      preflight.StatusFail,
+		"forwarder/nexusForwarder":     preflight.StatusFail,
+		"eip712_domain/nexusForwarder": preflight.StatusFail,
+	}, statuses(report))
+	for _, f := range report.Failures() {
+		assert.NotEmpty(t, f.Fix, f.Message)
+	}
+
+	err := checker.Check(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "nexusToken has no address on chain 31337")
+	assert.Contains(t, err.Error(), "POST /api/v1/contracts/deployments")
+}
+
+func TestChecker_WithoutRPC(t *testing.T) {
+	repo, ids := setup(t)
+	register(t, repo, ids["nexusToken"], token, nil)
+	register(t, repo, ids["nexusForwarder"], forwarder, nil)
+
+	// Without an RPC endpoint only the registry is checked
+	checker := preflight.NewChecker(repo, func(context.Context) (preflight.Client, error) { return nil, nil }, chainID, zap.NewNop())
+	report := checker.Run(context.Background())
+	assert.True(t, report.Passed, report.Err())
+	assert.Equal(t, preflight.StatusSkip, statuses(report)["bytecode/"])
+
+	// An unreachable endpoint fails it
+	checker = preflight.NewChecker(repo, func(context.Context) (preflight.Client, error) {
+		return nil, errors.New("dial tcp: connection refused")
+	}, chainID, zap.NewNop())
+	report = checker.Run(context.Background())
+	assert.False(t, report.Passed)
+	assert.Equal(t, preflight.StatusFail, statuses(report)["bytecode/"])
+
+	// An unknown chain fails before anything else is checked
+	checker = preflight.NewChecker(repo, func(context.Context) (preflight.Client, error) { return nil, nil }, 1, zap.NewNop())
+	report = checker.Run(context.Background())
+	assert.False(t, report.Passed)
+	require.Len(t, report.Results, 1)
+}
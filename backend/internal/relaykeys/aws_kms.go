@@ -0,0 +1,71 @@
+package relaykeys
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/awsv4"
+)
+
+// AWSConfig configures AWS KMS signing. Keys must be ECC_SECG_P256K1 with
+// usage SIGN_VERIFY. Credentials are static (the AWS_* environment variables).
+type AWSConfig struct {
+	Region          string
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+	Endpoint        string // default https://kms.<region>.amazonaws.com
+	HTTPClient      *http.Client
+}
+
+// NewAWSKMSKey returns a Backend for an AWS KMS key (ID, ARN or alias)
+func NewAWSKMSKey(ctx context.Context, cfg AWSConfig, keyID string) (Backend, error) {
+	client := &awsv4.Client{
+		Service:  "kms",
+		Region:   cfg.Region,
+		Endpoint: cfg.Endpoint,
+		Credentials: awsv4.Credentials{
+			AccessKeyID:     cfg.AccessKeyID,
+			SecretAccessKey: cfg.SecretAccessKey,
+			SessionToken:    cfg.SessionToken,
+		},
+		HTTPClient: cfg.HTTPClient,
+	}
+	if err := client.Validate(); err != nil {
+		return nil, err
+	}
+	return newRemoteKey(ctx, "aws-kms", keyID, &awsKMS{client: client, keyID: keyID})
+}
+
+type awsKMS struct {
+	client *awsv4.Client
+	keyID  string
+}
+
+func (k *awsKMS) publicKey(ctx context.Context) ([]byte, error) {
+	var out struct {
+		PublicKey []byte `json:"PublicKey"`
+		KeySpec   string `json:"KeySpec"`
+	}
+	if err := k.client.Call(ctx, "TrentService.GetPublicKey", map[string]interface{}{"KeyId": k.keyID}, &out); err != nil {
+		return nil, err
+	}
+	if out.KeySpec != "ECC_SECG_P256K1" {
+		return nil, fmt.Errorf("key spec is %s, not ECC_SECG_P256K1", out.KeySpec)
+	}
+	return out.PublicKey, nil
+}
+
+func (k *awsKMS) signDigest(ctx context.Context, digest []byte) ([]byte, error) {
+	var out struct {
+		Signature []byte `json:"Signature"`
+	}
+	err := k.client.Call(ctx, "TrentService.Sign", map[string]interface{}{
+		"KeyId":            k.keyID,
+		"Message":          digest, // base64 in JSON
+		"MessageType":      "DIGEST",
+		"SigningAlgorithm": "ECDSA_SHA_256",
+	}, &out)
+	return out.Signature, err
+}
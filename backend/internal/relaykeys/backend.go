@@ -0,0 +1,200 @@
+package relaykeys
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/asn1"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Backend holds a secp256k1 key and signs digests with it. The key itself may
+// never leave the backend (KMS, HSM, Vault).
+type Backend interface {
+	// Address is the key's Ethereum address
+	Address() common.Address
+	// SignHash signs a 32-byte digest, returning [R || S || V] with V 0 or 1
+	SignHash(ctx context.Context, hash []byte) ([]byte, error)
+	// Kind names the backend ("local", "aws-kms", "gcp-kms", "vault-transit")
+	Kind() string
+}
+
+// remoteTimeout bounds each call to a signing service
+const remoteTimeout = 10 * time.Second
+
+// LocalKey is a key held in process memory
+type LocalKey struct {
+	key *ecdsa.PrivateKey
+}
+
+// NewLocalKey wraps an in-memory private key
+func NewLocalKey(key *ecdsa.PrivateKey) *LocalKey {
+	return &LocalKey{key: key}
+}
+
+// Address implements Backend
+func (k *LocalKey) Address() common.Address {
+	return crypto.PubkeyToAddress(k.key.PublicKey)
+}
+
+// SignHash implements Backend
+func (k *LocalKey) SignHash(_ context.Context, hash []byte) ([]byte, error) {
+	return crypto.Sign(hash, k.key)
+}
+
+// Kind implements Backend
+func (k *LocalKey) Kind() string {
+	return "local"
+}
+
+// remoteSigner is a signing service that returns DER (ASN.1) signatures
+type remoteSigner interface {
+	publicKey(ctx context.Context) ([]byte, error) // DER SubjectPublicKeyInfo
+	signDigest(ctx context.Context, digest []byte) ([]byte, error)
+}
+
+// remoteKey adapts a remoteSigner to Backend: it turns the service's DER
+// signatures into Ethereum's recoverable form
+type remoteKey struct {
+	kind    string
+	id      string
+	signer  remoteSigner
+	pubkey  []byte // uncompressed, 65 bytes
+	address common.Address
+}
+
+func newRemoteKey(ctx context.Context, kind, id string, signer remoteSigner) (*remoteKey, error) {
+	ctx, cancel := context.WithTimeout(ctx, remoteTimeout)
+	defer cancel()
+
+	der, err := signer.publicKey(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s key %s: fetching public key: %w", kind, id, err)
+	}
+	pub, err := parsePublicKey(der)
+	if err != nil {
+		return nil, fmt.Errorf("%s key %s: %w", kind, id, err)
+	}
+	return &remoteKey{
+		kind:    kind,
+		id:      id,
+		signer:  signer,
+		pubkey:  crypto.FromECDSAPub(pub),
+		address: crypto.PubkeyToAddress(*pub),
+	}, nil
+}
+
+// Address implements Backend
+func (k *remoteKey) Address() common.Address {
+	return k.address
+}
+
+// Kind implements Backend
+func (k *remoteKey) Kind() string {
+	return k.kind
+}
+
+// SignHash implements Backend
+func (k *remoteKey) SignHash(ctx context.Context, hash []byte) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, remoteTimeout)
+	defer cancel()
+
+	der, err := k.signer.signDigest(ctx, hash)
+	if err != nil {
+		return nil, fmt.Errorf("%s key %s: signing: %w", k.kind, k.id, err)
+	}
+	sig, err := recoverableSignature(hash, der, k.pubkey)
+	if err != nil {
+		return nil, fmt.Errorf("%s key %s: %w", k.kind, k.id, err)
+	}
+	return sig, nil
+}
+
+var (
+	secp256k1N     = crypto.S256().Params().N
+	secp256k1HalfN = new(big.Int).Rsh(secp256k1N, 1)
+
+	// id-ecPublicKey and the secp256k1 curve
+	oidECPublicKey = asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1}
+	oidSecp256k1   = asn1.ObjectIdentifier{1, 3, 132, 0, 10}
+)
+
+// parsePublicKey parses a DER (or PEM) SubjectPublicKeyInfo holding a
+// secp256k1 key, which crypto/x509 does not support
+func parsePublicKey(der []byte) (*ecdsa.PublicKey, error) {
+	if block, _ := pem.Decode(der); block != nil {
+		der = block.Bytes
+	}
+
+	var spki struct {
+		Algorithm struct {
+			Algorithm  asn1.ObjectIdentifier
+			Parameters asn1.ObjectIdentifier
+		}
+		PublicKey asn1.BitString
+	}
+	if _, err := asn1.Unmarshal(der, &spki); err != nil {
+		return nil, fmt.Errorf("invalid public key: %w", err)
+	}
+	if !spki.Algorithm.Algorithm.Equal(oidECPublicKey) || !spki.Algorithm.Parameters.Equal(oidSecp256k1) {
+		return nil, errors.New("key is not a secp256k1 key")
+	}
+	return crypto.UnmarshalPubkey(spki.PublicKey.Bytes)
+}
+
+// recoverableSignature turns a DER ECDSA signature into [R || S || V]. S is
+// normalized to the lower half of the curve order (EIP-2) and V found by
+// recovering the public key.
+func recoverableSignature(hash, der, pubkey []byte) ([]byte, error) {
+	var rs struct{ R, S *big.Int }
+	if _, err := asn1.Unmarshal(der, &rs); err != nil {
+		return nil, fmt.Errorf("invalid signature: %w", err)
+	}
+	if rs.R.Sign() <= 0 || rs.S.Sign() <= 0 || rs.R.Cmp(secp256k1N) >= 0 || rs.S.Cmp(secp256k1N) >= 0 {
+		return nil, errors.New("invalid signature: r or s out of range")
+	}
+	if rs.S.Cmp(secp256k1HalfN) > 0 {
+		rs.S = new(big.Int).Sub(secp256k1N, rs.S)
+	}
+
+	sig := make([]byte, crypto.SignatureLength)
+	rs.R.FillBytes(sig[:32])
+	rs.S.FillBytes(sig[32:64])
+	for v := byte(0); v < 2; v++ {
+		sig[64] = v
+		if recovered, err := crypto.Ecrecover(hash, sig); err == nil && string(recovered) == string(pubkey) {
+			return sig, nil
+		}
+	}
+	return nil, errors.New("signature does not match the key's public key")
+}
+
+// doJSON sends req and decodes a JSON response; non-2xx answers are errors
+func doJSON(client *http.Client, req *http.Request, out interface{}) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		if len(body) > 512 {
+			body = body[:512]
+		}
+		return fmt.Errorf("HTTP %d: %s", resp.StatusCode, body)
+	}
+	return json.Unmarshal(body, out)
+}
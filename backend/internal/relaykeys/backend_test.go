@@ -0,0 +1,195 @@
+package relaykeys_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/relaykeys"
+)
+
+// hsm stands in for a signing service: DER public keys and DER signatures,
+// with S in the upper half of the curve order as services are free to return
+type hsm struct {
+	t   *testing.T
+	key *ecdsa.PrivateKey
+}
+
+func (h hsm) publicKeyDER() []byte {
+	der, err := asn1.Marshal(struct {
+		Algorithm struct{ Algorithm, Parameters asn1.ObjectIdentifier }
+		PublicKey asn1.BitString
+	}{
+		Algorithm: struct{ Algorithm, Parameters asn1.ObjectIdentifier }{
+			asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1}, asn1.ObjectIdentifier{1, 3, 132, 0, 10},
+		},
+		PublicKey: asn1.BitString{Bytes: crypto.FromECDSAPub(&h.key.PublicKey), BitLength: 65 * 8},
+	})
+	require.NoError(h.t, err)
+	return der
+}
+
+func (h hsm) sign(digest []byte) []byte {
+	require.Len(h.t, digest, 32)
+	sig, err := crypto.Sign(digest, h.key)
+	require.NoError(h.t, err)
+	s := new(big.Int).SetBytes(sig[32:64])
+	der, err := asn1.Marshal(struct{ R, S *big.Int }{
+		new(big.Int).SetBytes(sig[:32]),
+		new(big.Int).Sub(crypto.S256().Params().N, s),
+	})
+	require.NoError(h.t, err)
+	return der
+}
+
+func (h hsm) reply(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	require.NoError(h.t, json.NewEncoder(w).Encode(v))
+}
+
+func (h hsm) pem() string {
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: h.publicKeyDER()}))
+}
+
+func TestRemoteBackends(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	fake := hsm{t: t, key: key}
+	ctx := context.Background()
+
+	aws := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.True(t, strings.HasPrefix(r.Header.Get("Authorization"),
+			"AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/"), r.Header.Get("Authorization"))
+		assert.Contains(t, r.Header.Get("Authorization"), "/eu-west-1/kms/aws4_request, SignedHeaders=content-type;host;x-amz-date;x-amz-target, Signature=")
+		var in struct {
+			KeyId   string
+			Message []byte
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&in))
+		assert.Equal(t, "alias/relayer", in.KeyId)
+		switch r.Header.Get("X-Amz-Target") {
+		case "TrentService.GetPublicKey":
+			fake.reply(w, map[string]interface{}{"PublicKey": fake.publicKeyDER(), "KeySpec": "ECC_SECG_P256K1"})
+		case "TrentService.Sign":
+			fake.reply(w, map[string]interface{}{"Signature": fake.sign(in.Message)})
+		default:
+			t.Errorf("unexpected target %q", r.Header.Get("X-Amz-Target"))
+		}
+	}))
+	defer aws.Close()
+
+	gcpName := "projects/p/locations/global/keyRings/r/cryptoKeys/relayer/cryptoKeyVersions/1"
+	gcp := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/token":
+			assert.Equal(t, "Google", r.Header.Get("Metadata-Flavor"))
+			fake.reply(w, map[string]interface{}{"access_token": "ya29.token", "expires_in": 3600})
+		case "/v1/" + gcpName + "/publicKey":
+			assert.Equal(t, "Bearer ya29.token", r.Header.Get("Authorization"))
+			fake.reply(w, map[string]interface{}{"pem": fake.pem(), "algorithm": "EC_SIGN_SECP256K1_SHA256"})
+		case "/v1/" + gcpName + ":asymmetricSign":
+			var in struct{ Digest struct{ Sha256 []byte } }
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&in))
+			fake.reply(w, map[string]interface{}{"signature": fake.sign(in.Digest.Sha256)})
+		default:
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+	}))
+	defer gcp.Close()
+
+	vault := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "s.token", r.Header.Get("X-Vault-Token"))
+		switch r.URL.Path {
+		case "/v1/eth-transit/keys/relayer":
+			fake.reply(w, map[string]interface{}{"data": map[string]interface{}{
+				"latest_version": 2,
+				"keys":           map[string]interface{}{"2": map[string]string{"public_key": fake.pem()}},
+			}})
+		case "/v1/eth-transit/sign/relayer":
+			var in struct {
+				Input      string `json:"input"`
+				Prehashed  bool   `json:"prehashed"`
+				KeyVersion int    `json:"key_version"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&in))
+			assert.True(t, in.Prehashed)
+			assert.Equal(t, 2, in.KeyVersion)
+			digest, err := base64.StdEncoding.DecodeString(in.Input)
+			require.NoError(t, err)
+			fake.reply(w, map[string]interface{}{"data": map[string]string{
+				"signature": "vault:v2:" + base64.StdEncoding.EncodeToString(fake.sign(digest)),
+			}})
+		default:
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+	}))
+	defer vault.Close()
+
+	backends := map[string]func() (relaykeys.Backend, error){
+		"aws-kms": func() (relaykeys.Backend, error) {
+			return relaykeys.NewAWSKMSKey(ctx, relaykeys.AWSConfig{
+				Region: "eu-west-1", AccessKeyID: "AKIDEXAMPLE", SecretAccessKey: "secret", Endpoint: aws.URL,
+			}, "alias/relayer")
+		},
+		"gcp-kms": func() (relaykeys.Backend, error) {
+			return relaykeys.NewGCPKMSKey(ctx, relaykeys.GCPConfig{Endpoint: gcp.URL, TokenURL: gcp.URL + "/token"}, gcpName)
+		},
+		"vault-transit": func() (relaykeys.Backend, error) {
+			return relaykeys.NewVaultTransitKey(ctx, relaykeys.VaultConfig{Address: vault.URL, Token: "s.token", Mount: "eth-transit"}, "relayer")
+		},
+	}
+	for kind, newBackend := range backends {
+		t.Run(kind, func(t *testing.T) {
+			backend, err := newBackend()
+			require.NoError(t, err)
+			assert.Equal(t, kind, backend.Kind())
+			assert.Equal(t, crypto.PubkeyToAddress(key.PublicKey), backend.Address())
+
+			// Transactions signed through the pool recover to the key
+			pool, err := relaykeys.NewPool(backend)
+			require.NoError(t, err)
+			signer, err := pool.Next()
+			require.NoError(t, err)
+
+			chainID := big.NewInt(31337)
+			opts := signer.TransactOpts(ctx, chainID)
+			to := common.HexToAddress("0x5FbDB2315678afecb367f032d99F642f83F7aa3")
+			tx, err := opts.Signer(signer.Address, types.NewTx(&types.LegacyTx{Nonce: 1, To: &to, Gas: 21000, GasPrice: big.NewInt(1)}))
+			require.NoError(t, err)
+			sender, err := types.Sender(types.LatestSignerForChainID(chainID), tx)
+			require.NoError(t, err)
+			assert.Equal(t, signer.Address, sender)
+
+			// Low S (EIP-2)
+			_, _, s := tx.RawSignatureValues()
+			assert.LessOrEqual(t, s.Cmp(new(big.Int).Rsh(crypto.S256().Params().N, 1)), 0)
+		})
+	}
+}
+
+func TestRemoteBackends_RejectWrongCurve(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"PublicKey": []byte{1, 2, 3}, "KeySpec": "ECC_NIST_P256"})
+	}))
+	defer server.Close()
+
+	_, err := relaykeys.NewAWSKMSKey(context.Background(), relaykeys.AWSConfig{
+		Region: "eu-west-1", AccessKeyID: "AKIDEXAMPLE", SecretAccessKey: "secret", Endpoint: server.URL,
+	}, "alias/relayer")
+	assert.ErrorContains(t, err, "ECC_SECG_P256K1")
+}
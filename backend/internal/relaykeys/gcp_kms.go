@@ -0,0 +1,131 @@
+package relaykeys
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// gcpMetadataTokenURL serves the attached service account's access token on
+// GCE, GKE (workload identity) and Cloud Run
+const gcpMetadataTokenURL = "http://metadata.google.internal/computeMetadata/v1/instance/service-accounts/default/token"
+
+// GCPConfig configures Cloud KMS signing. Keys must be EC_SIGN_SECP256K1_SHA256.
+// Without AccessToken, tokens come from the metadata server.
+type GCPConfig struct {
+	AccessToken string
+	Endpoint    string // default https://cloudkms.googleapis.com
+	TokenURL    string // default the metadata server
+	HTTPClient  *http.Client
+}
+
+// NewGCPKMSKey returns a Backend for a Cloud KMS key version
+// (projects/<p>/locations/<l>/keyRings/<r>/cryptoKeys/<k>/cryptoKeyVersions/<v>)
+func NewGCPKMSKey(ctx context.Context, cfg GCPConfig, name string) (Backend, error) {
+	if !strings.Contains(name, "/cryptoKeyVersions/") {
+		return nil, fmt.Errorf("cloud KMS key %q is not a key version name", name)
+	}
+	if cfg.Endpoint == "" {
+		cfg.Endpoint = "https://cloudkms.googleapis.com"
+	}
+	if cfg.TokenURL == "" {
+		cfg.TokenURL = gcpMetadataTokenURL
+	}
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = http.DefaultClient
+	}
+	return newRemoteKey(ctx, "gcp-kms", name, &gcpKMS{cfg: cfg, name: name})
+}
+
+type gcpKMS struct {
+	cfg  GCPConfig
+	name string
+
+	mu          sync.Mutex
+	token       string
+	tokenExpiry time.Time
+}
+
+func (k *gcpKMS) publicKey(ctx context.Context) ([]byte, error) {
+	var out struct {
+		PEM       string `json:"pem"`
+		Algorithm string `json:"algorithm"`
+	}
+	if err := k.call(ctx, http.MethodGet, "/publicKey", nil, &out); err != nil {
+		return nil, err
+	}
+	if out.Algorithm != "EC_SIGN_SECP256K1_SHA256" {
+		return nil, fmt.Errorf("key algorithm is %s, not EC_SIGN_SECP256K1_SHA256", out.Algorithm)
+	}
+	return []byte(out.PEM), nil
+}
+
+func (k *gcpKMS) signDigest(ctx context.Context, digest []byte) ([]byte, error) {
+	var out struct {
+		Signature []byte `json:"signature"`
+	}
+	in := map[string]interface{}{"digest": map[string][]byte{"sha256": digest}}
+	err := k.call(ctx, http.MethodPost, ":asymmetricSign", in, &out)
+	return out.Signature, err
+}
+
+func (k *gcpKMS) call(ctx context.Context, method, suffix string, in, out interface{}) error {
+	token, err := k.accessToken(ctx)
+	if err != nil {
+		return fmt.Errorf("getting access token: %w", err)
+	}
+
+	var body []byte
+	if in != nil {
+		if body, err = json.Marshal(in); err != nil {
+			return err
+		}
+	}
+	req, err := http.NewRequestWithContext(ctx, method, k.cfg.Endpoint+"/v1/"+k.name+suffix, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+	return doJSON(k.cfg.HTTPClient, req, out)
+}
+
+// accessToken returns the configured token or a cached metadata server token
+func (k *gcpKMS) accessToken(ctx context.Context) (string, error) {
+	if k.cfg.AccessToken != "" {
+		return k.cfg.AccessToken, nil
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if k.token != "" && time.Now().Before(k.tokenExpiry) {
+		return k.token, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, k.cfg.TokenURL, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Metadata-Flavor", "Google")
+	var out struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int64  `json:"expires_in"`
+	}
+	if err := doJSON(k.cfg.HTTPClient, req, &out); err != nil {
+		return "", err
+	}
+	if out.AccessToken == "" {
+		return "", errors.New("metadata server returned no token")
+	}
+
+	// Refresh a minute early
+	k.token = out.AccessToken
+	k.tokenExpiry = time.Now().Add(time.Duration(out.ExpiresIn)*time.Second - time.Minute)
+	return k.token, nil
+}
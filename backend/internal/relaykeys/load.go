@@ -0,0 +1,71 @@
+package relaykeys
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// ParsePrivateKey parses a hex private key, with or without 0x
+func ParsePrivateKey(hexKey string) (*ecdsa.PrivateKey, error) {
+	key, err := crypto.HexToECDSA(strings.TrimPrefix(strings.TrimSpace(hexKey), "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid relayer private key: %w", err)
+	}
+	return key, nil
+}
+
+// DecryptKeystore decrypts an encrypted (Web3 Secret Storage) key file's JSON
+func DecryptKeystore(keyJSON []byte, passphrase string) (*ecdsa.PrivateKey, error) {
+	key, err := keystore.DecryptKey(keyJSON, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt keystore: %w", err)
+	}
+	return key.PrivateKey, nil
+}
+
+// LoadKeystore decrypts the keys at path: a key file, or every key file in a
+// directory (hidden files and subdirectories are skipped, as geth does). All
+// files must share the passphrase.
+func LoadKeystore(path, passphrase string) ([]*ecdsa.PrivateKey, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read keystore: %w", err)
+	}
+
+	files := []string{path}
+	if info.IsDir() {
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read keystore directory: %w", err)
+		}
+		files = files[:0]
+		for _, e := range entries {
+			if e.IsDir() || strings.HasPrefix(e.Name(), ".") {
+				continue
+			}
+			files = append(files, filepath.Join(path, e.Name()))
+		}
+		sort.Strings(files)
+	}
+
+	keys := make([]*ecdsa.PrivateKey, 0, len(files))
+	for _, file := range files {
+		keyJSON, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read keystore: %w", err)
+		}
+		key, err := DecryptKeystore(keyJSON, passphrase)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
@@ -0,0 +1,245 @@
+// Package relaykeys holds the relayer's signing keys. A key is held in memory
+// or by a signing service (AWS KMS, Cloud KMS, Vault transit) behind the
+// Backend interface. Relays are assigned to
+// the keys round-robin; each key keeps its own nonce so transactions from
+// different keys are sent in parallel while those from one key are sent one
+// at a time, in nonce order.
+//
+// Keys are added and retired at runtime for rotation. A retired key gets no
+// new relays; a send already holding it finishes normally.
+package relaykeys
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// ErrNoKeys is returned by Next when the pool has no keys
+var ErrNoKeys = errors.New("no relayer keys available")
+
+// ErrDuplicateKey is returned by Add for a key already in the pool
+var ErrDuplicateKey = errors.New("relayer key is already in the pool")
+
+// ErrKeyNotFound is returned by Retire for an address not in the pool
+var ErrKeyNotFound = errors.New("relayer key not found")
+
+// ErrLastKey is returned by Retire for the only key left in the pool
+var ErrLastKey = errors.New("cannot retire the last relayer key")
+
+// PendingNonceFunc returns an account's nonce in the pending state
+type PendingNonceFunc func(ctx context.Context, account common.Address) (uint64, error)
+
+// Signer is one relayer key with its nonce manager
+type Signer struct {
+	Address common.Address
+
+	backend Backend
+	addedAt time.Time
+
+	// sendMu is held for the whole of a send so nonces are used in order;
+	// stateMu guards the fields below so Keys does not wait on a send
+	sendMu  sync.Mutex
+	stateMu sync.Mutex
+	nonce   uint64
+	synced  bool
+	sent    uint64
+}
+
+// Info describes a key in the pool
+type Info struct {
+	Address   string    `json:"address"`
+	Backend   string    `json:"backend"`
+	NextNonce *uint64   `json:"next_nonce,omitempty"` // unset until the key first sends (or after a failed send)
+	Sent      uint64    `json:"sent"`
+	AddedAt   time.Time `json:"added_at"`
+}
+
+// TransactOpts returns transaction options that sign with the key for
+// chainID. Nonce and gas are left for the caller to set.
+func (s *Signer) TransactOpts(ctx context.Context, chainID *big.Int) *bind.TransactOpts {
+	txSigner := types.LatestSignerForChainID(chainID)
+	return &bind.TransactOpts{
+		From:    s.Address,
+		Context: ctx,
+		Signer: func(addr common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			if addr != s.Address {
+				return nil, bind.ErrNotAuthorized
+			}
+			hash := txSigner.Hash(tx)
+			sig, err := s.backend.SignHash(ctx, hash[:])
+			if err != nil {
+				return nil, err
+			}
+			return tx.WithSignature(txSigner, sig)
+		},
+	}
+}
+
+// Send calls send with the key's next nonce. The nonce is read from the chain
+// (pending state) on first use and then counted locally; a failed send drops
+// it so the next send reads it again, since the node may or may not have
+// accepted the transaction.
+func (s *Signer) Send(ctx context.Context, pending PendingNonceFunc, send func(nonce uint64) error) error {
+	s.sendMu.Lock()
+	defer s.sendMu.Unlock()
+
+	s.stateMu.Lock()
+	nonce, synced := s.nonce, s.synced
+	s.stateMu.Unlock()
+
+	if !synced {
+		var err error
+		if nonce, err = pending(ctx, s.Address); err != nil {
+			return fmt.Errorf("failed to get relayer nonce: %w", err)
+		}
+	}
+
+	err := send(nonce)
+
+	s.stateMu.Lock()
+	defer s.stateMu.Unlock()
+	if err != nil {
+		s.synced = false
+		return err
+	}
+	s.nonce = nonce + 1
+	s.synced = true
+	s.sent++
+	return nil
+}
+
+// Info describes the key
+func (s *Signer) Info() Info {
+	s.stateMu.Lock()
+	defer s.stateMu.Unlock()
+
+	info := Info{
+		Address: s.Address.Hex(),
+		Backend: s.backend.Kind(),
+		Sent:    s.sent,
+		AddedAt: s.addedAt,
+	}
+	if s.synced {
+		nonce := s.nonce
+		info.NextNonce = &nonce
+	}
+	return info
+}
+
+// Pool is a set of relayer keys used round-robin
+type Pool struct {
+	mu      sync.Mutex
+	signers []*Signer
+	next    int
+}
+
+// NewPool creates a pool from at least one key
+func NewPool(keys ...Backend) (*Pool, error) {
+	if len(keys) == 0 {
+		return nil, ErrNoKeys
+	}
+	p := &Pool{}
+	for _, key := range keys {
+		if _, err := p.Add(key); err != nil {
+			return nil, fmt.Errorf("%s: %w", key.Address().Hex(), err)
+		}
+	}
+	return p, nil
+}
+
+// Next returns the key for the next relay
+func (p *Pool) Next() (*Signer, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if len(p.signers) == 0 {
+		return nil, ErrNoKeys
+	}
+	s := p.signers[p.next%len(p.signers)]
+	p.next = (p.next + 1) % len(p.signers)
+	return s, nil
+}
+
+// Peek returns the key the next relay will use without assigning it
+func (p *Pool) Peek() (*Signer, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if len(p.signers) == 0 {
+		return nil, ErrNoKeys
+	}
+	return p.signers[p.next%len(p.signers)], nil
+}
+
+// Add puts a key into rotation
+func (p *Pool) Add(key Backend) (*Signer, error) {
+	addr := key.Address()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, s := range p.signers {
+		if s.Address == addr {
+			return nil, ErrDuplicateKey
+		}
+	}
+	s := &Signer{Address: addr, backend: key, addedAt: time.Now().UTC()}
+	p.signers = append(p.signers, s)
+	return s, nil
+}
+
+// Retire takes a key out of rotation. The last key cannot be retired; add
+// its replacement first.
+func (p *Pool) Retire(addr common.Address) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for i, s := range p.signers {
+		if s.Address != addr {
+			continue
+		}
+		if len(p.signers) == 1 {
+			return ErrLastKey
+		}
+		p.signers = append(p.signers[:i], p.signers[i+1:]...)
+		if p.next > i {
+			p.next--
+		}
+		p.next %= len(p.signers)
+		return nil
+	}
+	return ErrKeyNotFound
+}
+
+// Keys describes the keys in rotation, in assignment order
+func (p *Pool) Keys() []Info {
+	p.mu.Lock()
+	signers := append([]*Signer(nil), p.signers...)
+	p.mu.Unlock()
+
+	infos := make([]Info, 0, len(signers))
+	for _, s := range signers {
+		infos = append(infos, s.Info())
+	}
+	return infos
+}
+
+// Addresses returns the addresses of the keys in rotation
+func (p *Pool) Addresses() []common.Address {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	addrs := make([]common.Address, 0, len(p.signers))
+	for _, s := range p.signers {
+		addrs = append(addrs, s.Address)
+	}
+	return addrs
+}
@@ -0,0 +1,140 @@
+package relaykeys_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/relaykeys"
+)
+
+func newKeys(t *testing.T, n int) []*ecdsa.PrivateKey {
+	keys := make([]*ecdsa.PrivateKey, n)
+	for i := range keys {
+		key, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		keys[i] = key
+	}
+	return keys
+}
+
+func address(key *ecdsa.PrivateKey) common.Address {
+	return crypto.PubkeyToAddress(key.PublicKey)
+}
+
+func localKeys(keys ...*ecdsa.PrivateKey) []relaykeys.Backend {
+	backends := make([]relaykeys.Backend, len(keys))
+	for i, key := range keys {
+		backends[i] = relaykeys.NewLocalKey(key)
+	}
+	return backends
+}
+
+func TestPool_RoundRobinAndRotation(t *testing.T) {
+	keys := newKeys(t, 3)
+	pool, err := relaykeys.NewPool(localKeys(keys[0], keys[1])...)
+	require.NoError(t, err)
+
+	next := func() common.Address {
+		s, err := pool.Next()
+		require.NoError(t, err)
+		return s.Address
+	}
+	assert.Equal(t, address(keys[0]), next())
+	assert.Equal(t, address(keys[1]), next())
+	assert.Equal(t, address(keys[0]), next())
+
+	_, err = pool.Add(relaykeys.NewLocalKey(keys[1]))
+	assert.ErrorIs(t, err, relaykeys.ErrDuplicateKey)
+
+	// Rotate keys[0] out for keys[2]
+	_, err = pool.Add(relaykeys.NewLocalKey(keys[2]))
+	require.NoError(t, err)
+	require.NoError(t, pool.Retire(address(keys[0])))
+	assert.Equal(t, []common.Address{address(keys[1]), address(keys[2])}, pool.Addresses())
+	assert.Equal(t, address(keys[1]), next())
+	assert.Equal(t, address(keys[2]), next())
+
+	assert.ErrorIs(t, pool.Retire(address(keys[0])), relaykeys.ErrKeyNotFound)
+	require.NoError(t, pool.Retire(address(keys[1])))
+	assert.ErrorIs(t, pool.Retire(address(keys[2])), relaykeys.ErrLastKey)
+
+	_, err = relaykeys.NewPool()
+	assert.ErrorIs(t, err, relaykeys.ErrNoKeys)
+}
+
+func TestSigner_NonceManager(t *testing.T) {
+	pool, err := relaykeys.NewPool(localKeys(newKeys(t, 1)...)...)
+	require.NoError(t, err)
+	signer, err := pool.Next()
+	require.NoError(t, err)
+
+	chainNonce, fetches := uint64(7), 0
+	pending := func(ctx context.Context, account common.Address) (uint64, error) {
+		assert.Equal(t, signer.Address, account)
+		fetches++
+		return chainNonce, nil
+	}
+
+	var used []uint64
+	send := func(nonce uint64) error {
+		used = append(used, nonce)
+		return nil
+	}
+	ctx := context.Background()
+	require.NoError(t, signer.Send(ctx, pending, send))
+	require.NoError(t, signer.Send(ctx, pending, send))
+	assert.Equal(t, []uint64{7, 8}, used)
+	assert.Equal(t, 1, fetches, "nonce is read from the chain once")
+
+	info := signer.Info()
+	require.NotNil(t, info.NextNonce)
+	assert.Equal(t, uint64(9), *info.NextNonce)
+	assert.Equal(t, uint64(2), info.Sent)
+
+	// A failed send re-reads the nonce
+	sendErr := errors.New("nonce too low")
+	assert.ErrorIs(t, signer.Send(ctx, pending, func(uint64) error { return sendErr }), sendErr)
+	assert.Nil(t, signer.Info().NextNonce)
+
+	chainNonce = 12
+	require.NoError(t, signer.Send(ctx, pending, send))
+	assert.Equal(t, []uint64{7, 8, 12}, used)
+	assert.Equal(t, 2, fetches)
+}
+
+func TestLoadKeystore(t *testing.T) {
+	dir := t.TempDir()
+	keys := newKeys(t, 2)
+	for i, key := range keys {
+		keyJSON, err := keystore.EncryptKey(&keystore.Key{
+			Address:    address(key),
+			PrivateKey: key,
+		}, "secret", keystore.LightScryptN, keystore.LightScryptP)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "key"+string(rune('a'+i))), keyJSON, 0o600))
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".hidden"), []byte("not a key"), 0o600))
+
+	loaded, err := relaykeys.LoadKeystore(dir, "secret")
+	require.NoError(t, err)
+	require.Len(t, loaded, 2)
+	assert.Equal(t, address(keys[0]), address(loaded[0]))
+	assert.Equal(t, address(keys[1]), address(loaded[1]))
+
+	single, err := relaykeys.LoadKeystore(filepath.Join(dir, "keyb"), "secret")
+	require.NoError(t, err)
+	assert.Equal(t, address(keys[1]), address(single[0]))
+
+	_, err = relaykeys.LoadKeystore(dir, "wrong")
+	assert.Error(t, err)
+}
@@ -0,0 +1,110 @@
+package relaykeys
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// VaultConfig configures signing with a Vault transit secrets engine. The
+// transit key must be a secp256k1 key; stock transit has no such key type, so
+// this needs a transit-compatible engine that provides one.
+type VaultConfig struct {
+	Address    string // e.g. https://vault.internal:8200
+	Token      string
+	Mount      string // default "transit"
+	HTTPClient *http.Client
+}
+
+// NewVaultTransitKey returns a Backend for a transit key, signing with its
+// latest version
+func NewVaultTransitKey(ctx context.Context, cfg VaultConfig, name string) (Backend, error) {
+	if cfg.Address == "" || cfg.Token == "" {
+		return nil, errors.New("vault transit requires an address and a token")
+	}
+	if cfg.Mount == "" {
+		cfg.Mount = "transit"
+	}
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = http.DefaultClient
+	}
+	cfg.Address = strings.TrimRight(cfg.Address, "/")
+	return newRemoteKey(ctx, "vault-transit", name, &vaultTransit{cfg: cfg, name: name})
+}
+
+type vaultTransit struct {
+	cfg     VaultConfig
+	name    string
+	version int
+}
+
+func (k *vaultTransit) publicKey(ctx context.Context) ([]byte, error) {
+	var out struct {
+		Data struct {
+			LatestVersion int `json:"latest_version"`
+			Keys          map[string]struct {
+				PublicKey string `json:"public_key"`
+			} `json:"keys"`
+		} `json:"data"`
+	}
+	if err := k.call(ctx, http.MethodGet, "keys", nil, &out); err != nil {
+		return nil, err
+	}
+	latest, ok := out.Data.Keys[strconv.Itoa(out.Data.LatestVersion)]
+	if !ok || latest.PublicKey == "" {
+		return nil, errors.New("transit key has no public key (is it an asymmetric key?)")
+	}
+
+	// Pin the version so a rotation in Vault does not change the address
+	// under a running relayer
+	k.version = out.Data.LatestVersion
+	return []byte(latest.PublicKey), nil
+}
+
+func (k *vaultTransit) signDigest(ctx context.Context, digest []byte) ([]byte, error) {
+	var out struct {
+		Data struct {
+			Signature string `json:"signature"` // vault:v<version>:<base64>
+		} `json:"data"`
+	}
+	err := k.call(ctx, http.MethodPost, "sign", map[string]interface{}{
+		"input":                base64.StdEncoding.EncodeToString(digest),
+		"prehashed":            true,
+		"hash_algorithm":       "sha2-256",
+		"marshaling_algorithm": "asn1",
+		"key_version":          k.version,
+	}, &out)
+	if err != nil {
+		return nil, err
+	}
+
+	parts := strings.SplitN(out.Data.Signature, ":", 3)
+	if len(parts) != 3 || parts[0] != "vault" {
+		return nil, fmt.Errorf("unexpected signature format %q", out.Data.Signature)
+	}
+	return base64.StdEncoding.DecodeString(parts[2])
+}
+
+func (k *vaultTransit) call(ctx context.Context, method, op string, in, out interface{}) error {
+	var body []byte
+	if in != nil {
+		var err error
+		if body, err = json.Marshal(in); err != nil {
+			return err
+		}
+	}
+	url := fmt.Sprintf("%s/v1/%s/%s/%s", k.cfg.Address, k.cfg.Mount, op, k.name)
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("X-Vault-Token", k.cfg.Token)
+	req.Header.Set("Content-Type", "application/json")
+	return doJSON(k.cfg.HTTPClient, req, out)
+}
@@ -0,0 +1,145 @@
+// Package relaypolicy holds the compliance rules a meta-transaction must
+// satisfy before it is relayed. Target contracts can require a minimum KYC
+// level of the signer: app_config relayer.kyc_requirements maps a target
+// (a contract db_name, which follows redeployments, or an address) to the
+// level, e.g. {"nexusNFT": 2}. The signer's level comes from their KYC
+// verification: an approved verification counts as kyc.approved_level
+// (default 2, ID verification), anything else as 0.
+package relaypolicy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// defaultApprovedLevel is the level of an approved (ID) verification
+const defaultApprovedLevel = 2
+
+// KYCRepository reads users' KYC verifications (implemented by
+// repository.PaymentRepository)
+type KYCRepository interface {
+	GetKYCVerificationByAddress(ctx context.Context, address string) (*repository.KYCVerification, error)
+}
+
+// KYCError is a relay rejected because the signer's KYC level is below the
+// target's requirement
+type KYCError struct {
+	Target   string // as configured: db_name or address
+	Required uint8
+	Level    uint8
+}
+
+func (e *KYCError) Error() string {
+	return fmt.Sprintf("%s requires KYC level %d, signer has level %d", e.Target, e.Required, e.Level)
+}
+
+// Policy checks relays against the configured requirements
+type Policy struct {
+	configRepo repository.AppConfigRepository
+	contracts  repository.ContractRepository
+	kyc        KYCRepository
+	chainID    int64
+}
+
+// NewPolicy creates the relay policy of chainID
+func NewPolicy(configRepo repository.AppConfigRepository, contracts repository.ContractRepository, kyc KYCRepository, chainID int64) *Policy {
+	return &Policy{
+		configRepo: configRepo,
+		contracts:  contracts,
+		kyc:        kyc,
+		chainID:    chainID,
+	}
+}
+
+// CheckRelay returns a *KYCError if from may not call target. Failing to
+// read the requirements, a required contract's address or the signer's
+// verification is returned as is, so the relay is refused rather than let
+// through unchecked.
+func (p *Policy) CheckRelay(ctx context.Context, from, target string) error {
+	name, required, err := p.requirement(ctx, target)
+	if err != nil || required == 0 {
+		return err
+	}
+	level, err := p.level(ctx, from)
+	if err != nil {
+		return err
+	}
+	if level < required {
+		return &KYCError{Target: name, Required: required, Level: level}
+	}
+	return nil
+}
+
+// requirement returns the level target requires (0 = none) and the entry
+// requiring it
+func (p *Policy) requirement(ctx context.Context, target string) (string, uint8, error) {
+	var requirements map[string]uint8
+	err := p.configRepo.GetJSON(ctx, "relayer", "kyc_requirements", p.chainID, &requirements)
+	if errors.Is(err, repository.ErrAppConfigNotFound) {
+		return "", 0, nil
+	}
+	if err != nil {
+		return "", 0, fmt.Errorf("loading relayer.kyc_requirements: %w", err)
+	}
+
+	var name string
+	var required uint8
+	for key, level := range requirements {
+		if level <= required {
+			continue
+		}
+		ok, err := p.matches(ctx, key, target)
+		if err != nil {
+			return "", 0, err
+		}
+		if ok {
+			name, required = key, level
+		}
+	}
+	return name, required, nil
+}
+
+// matches reports whether a requirement key names target. A db_name not
+// deployed on the chain matches nothing.
+func (p *Policy) matches(ctx context.Context, key, target string) (bool, error) {
+	if common.IsHexAddress(key) {
+		return strings.EqualFold(key, target), nil
+	}
+	contract, err := p.contracts.GetByChainAndDBName(ctx, p.chainID, key)
+	if errors.Is(err, repository.ErrContractAddressNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("resolving KYC requirement target %s: %w", key, err)
+	}
+	return strings.EqualFold(contract.Address, target), nil
+}
+
+// level returns address's KYC level
+func (p *Policy) level(ctx context.Context, address string) (uint8, error) {
+	v, err := p.kyc.GetKYCVerificationByAddress(ctx, strings.ToLower(address))
+	if errors.Is(err, repository.ErrKYCNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("reading KYC verification: %w", err)
+	}
+	if v.Status != repository.KYCStatusApproved {
+		return 0, nil
+	}
+
+	approved, err := p.configRepo.GetNumber(ctx, "kyc", "approved_level", p.chainID)
+	if errors.Is(err, repository.ErrAppConfigNotFound) || (err == nil && approved <= 0) {
+		return defaultApprovedLevel, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("loading kyc.approved_level: %w", err)
+	}
+	return uint8(min(approved, 255)), nil
+}
@@ -0,0 +1,120 @@
+package relaypolicy_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/relaypolicy"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/storage/memory"
+)
+
+const (
+	chainID  = 31337
+	nft      = "0x5FbDB2315678afecb367f032d93F642f64180aa3"
+	vault    = "0xe7f1725E7734CE288F8367e1Bb143E90bb3F0512"
+	open     = "0x9fE46736679d2D9a65F0992F2272dE9f3c7fa6e0"
+	approved = "0x70997970C51812dc3A010C7d01b50e0d17dc79C8"
+	pending  = "0x3C44CdDdB6a900fa2b585dd299e03d12FA4293BC"
+	unknown  = "0x90F79bf6EB2c4f870365E785982E1f101E93b906"
+)
+
+func setup(t *testing.T, requirements string) (*relaypolicy.Policy, *memory.MemoryAppConfigRepo) {
+	t.Helper()
+	ctx := context.Background()
+
+	config := memory.NewMemoryAppConfigRepo()
+	require.NoError(t, config.Create(ctx, &repository.AppConfigCreate{
+		Namespace: "relayer", ConfigKey: "kyc_requirements", ValueType: "json", ValueString: &requirements,
+	}))
+
+	contracts := memory.NewMemoryContractRepo()
+	contracts.AddNetwork(&repository.NetworkConfig{ChainID: chainID, NetworkName: "localhost", IsActive: true})
+	mappingID := contracts.AddMapping(&repository.ContractMapping{SolidityName: "NexusNFT", DBName: "nexusNFT"})
+	_, err := contracts.Upsert(ctx, &repository.ContractAddressUpsert{ChainID: chainID, ContractMappingID: mappingID, Address: nft})
+	require.NoError(t, err)
+
+	payments := memory.NewMemoryPaymentRepo()
+	for address, status := range map[string]repository.KYCVerificationStatus{
+		approved: repository.KYCStatusApproved,
+		pending:  repository.KYCStatusInReview,
+	} {
+		// The KYC handlers store addresses lowercased
+		require.NoError(t, payments.CreateKYCVerification(ctx, &repository.KYCVerification{
+			UserAddress: strings.ToLower(address), Status: status,
+		}))
+	}
+	return relaypolicy.NewPolicy(config, contracts, payments, chainID), config
+}
+
+func TestPolicy_KYCRequirements(t *testing.T) {
+	ctx := context.Background()
+	policy, _ := setup(t, `{"nexusNFT": 2, "`+vault+`": 3, "nexusBridge": 1}`)
+
+	// The NFT contract (by db_name) needs an approved verification
+	assert.NoError(t, policy.CheckRelay(ctx, approved, nft))
+	var kycErr *relaypolicy.KYCError
+	require.ErrorAs(t, policy.CheckRelay(ctx, pending, nft), &kycErr)
+	assert.Equal(t, relaypolicy.KYCError{Target: "nexusNFT", Required: 2, Level: 0}, *kycErr)
+	require.ErrorAs(t, policy.CheckRelay(ctx, unknown, nft), &kycErr)
+
+	// The vault (by address) needs more than an approved verification gives
+	require.ErrorAs(t, policy.CheckRelay(ctx, approved, vault), &kycErr)
+	assert.Equal(t, uint8(3), kycErr.Required)
+	assert.Equal(t, uint8(2), kycErr.Level)
+
+	// Other targets, and undeployed ones named in the policy, are open
+	assert.NoError(t, policy.CheckRelay(ctx, unknown, open))
+}
+
+func TestPolicy_ApprovedLevelAndFailures(t *testing.T) {
+	ctx := context.Background()
+	policy, config := setup(t, `{"`+vault+`": 3}`)
+
+	level := int64(3)
+	require.NoError(t, config.Create(ctx, &repository.AppConfigCreate{
+		Namespace: "kyc", ConfigKey: "approved_level", ValueType: "number", ValueNumber: &level,
+	}))
+	assert.NoError(t, policy.CheckRelay(ctx, approved, vault))
+
+	// A malformed policy refuses relays rather than skipping the check
+	broken, _ := setup(t, `{"nexusNFT": "two"}`)
+	err := broken.CheckRelay(ctx, approved, nft)
+	require.Error(t, err)
+	var kycErr *relaypolicy.KYCError
+	assert.False(t, errors.As(err, &kycErr))
+
+	// Without a policy nothing is checked
+	none := relaypolicy.NewPolicy(memory.NewMemoryAppConfigRepo(), memory.NewMemoryContractRepo(), memory.NewMemoryPaymentRepo(), chainID)
+	assert.NoError(t, none.CheckRelay(ctx, unknown, nft))
+}
+
+// failingContracts cannot read contract addresses
+type failingContracts struct {
+	*memory.MemoryContractRepo
+}
+
+func (failingContracts) GetByChainAndDBName(context.Context, int64, string) (*repository.ContractAddress, error) {
+	return nil, errors.New("connection refused")
+}
+
+func TestPolicy_UnresolvableTargetRefusesRelay(t *testing.T) {
+	ctx := context.Background()
+	config := memory.NewMemoryAppConfigRepo()
+	requirements := `{"nexusNFT": 2}`
+	require.NoError(t, config.Create(ctx, &repository.AppConfigCreate{
+		Namespace: "relayer", ConfigKey: "kyc_requirements", ValueType: "json", ValueString: &requirements,
+	}))
+	policy := relaypolicy.NewPolicy(config, failingContracts{memory.NewMemoryContractRepo()}, memory.NewMemoryPaymentRepo(), chainID)
+
+	// The requirement is not skipped because its address could not be read
+	err := policy.CheckRelay(ctx, unknown, nft)
+	require.ErrorContains(t, err, "connection refused")
+	var kycErr *relaypolicy.KYCError
+	assert.False(t, errors.As(err, &kycErr))
+}
@@ -0,0 +1,57 @@
+package relayqueue
+
+import (
+	"math"
+	"time"
+)
+
+// softLimit is the token bucket behind Config.Rate. It is only used with
+// the queue's mutex held.
+type softLimit struct {
+	rate   float64 // tokens per second
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newSoftLimit(rate float64, burst int) *softLimit {
+	return &softLimit{rate: rate, burst: float64(burst), tokens: float64(burst), last: time.Now()}
+}
+
+// refill adds the tokens earned since the last call
+func (l *softLimit) refill(now time.Time) {
+	if now.After(l.last) {
+		l.tokens = min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+		l.last = now
+	}
+}
+
+// take takes a token if one is available
+func (l *softLimit) take(now time.Time) bool {
+	l.refill(now)
+	if l.tokens < 1 {
+		return false
+	}
+	l.tokens--
+	return true
+}
+
+// delay is how long until a token is available
+func (l *softLimit) delay(now time.Time) time.Duration {
+	l.refill(now)
+	if l.tokens >= 1 {
+		return 0
+	}
+	return time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+}
+
+// wait estimates how long the job dispatched after ahead others waits for a
+// token, rounded up to the second
+func (l *softLimit) wait(now time.Time, ahead int) time.Duration {
+	l.refill(now)
+	short := float64(ahead+1) - l.tokens
+	if short <= 0 {
+		return 0
+	}
+	return time.Duration(math.Ceil(short/l.rate)) * time.Second
+}
@@ -0,0 +1,360 @@
+// Package relayqueue holds accepted meta-transactions until a worker submits
+// them. Jobs are dispatched by priority class, then in arrival order, by a
+// configurable number of workers; a class can be held back (e.g. low priority
+// relays during a gas spike) without blocking the others. An optional soft
+// limit caps the dispatch rate: bursts, such as an NFT mint opening, wait in
+// the (bounded) queue with an estimated wait instead of hitting the chain at
+// once.
+//
+// The queue is in memory. Jobs still queued when the process stops remain
+// pending in the database and are re-queued on startup (see Recover in the
+// relayer handler).
+package relayqueue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Priority is a relay priority class; lower values are dispatched first
+type Priority int
+
+// Priority classes
+const (
+	PriorityHigh Priority = iota
+	PriorityNormal
+	PriorityLow
+
+	numPriorities = int(PriorityLow) + 1
+)
+
+// Defaults
+const (
+	DefaultConcurrency = 1
+	DefaultMaxSize     = 1000
+
+	// holdRecheckInterval is how often held classes are re-checked while
+	// only held jobs are queued
+	holdRecheckInterval = 5 * time.Second
+)
+
+// ErrFull is returned by Enqueue while MaxSize jobs are queued
+var ErrFull = errors.New("relay queue is full")
+
+// ErrDuplicate is returned by Enqueue for a job ID that is already queued
+var ErrDuplicate = errors.New("job is already queued")
+
+func (p Priority) String() string {
+	switch p {
+	case PriorityHigh:
+		return "high"
+	case PriorityNormal:
+		return "normal"
+	case PriorityLow:
+		return "low"
+	}
+	return fmt.Sprintf("priority(%d)", int(p))
+}
+
+// ParsePriority parses a class name ("high", "normal", "low")
+func ParsePriority(s string) (Priority, error) {
+	for p := PriorityHigh; int(p) < numPriorities; p++ {
+		if p.String() == s {
+			return p, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown relay priority %q", s)
+}
+
+// MarshalText encodes the class name
+func (p Priority) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+// UnmarshalText decodes a class name
+func (p *Priority) UnmarshalText(text []byte) error {
+	parsed, err := ParsePriority(string(text))
+	if err != nil {
+		return err
+	}
+	*p = parsed
+	return nil
+}
+
+// Job is one queued relay. Run submits it; the queue only logs its error.
+type Job struct {
+	ID       string
+	Priority Priority
+	Run      func(ctx context.Context) error
+
+	enqueuedAt time.Time
+}
+
+// Position is where a queued job stands
+type Position struct {
+	Position   int       `json:"position"` // 1 = next to be dispatched
+	Priority   Priority  `json:"priority"`
+	Held       bool      `json:"held"` // its class is currently held back
+	EnqueuedAt time.Time `json:"enqueued_at"`
+
+	// EstimatedWait is how long the soft limit keeps the job queued, in
+	// seconds, assuming no held classes and no higher priority arrivals
+	EstimatedWait int `json:"estimated_wait_seconds,omitempty"`
+}
+
+// Stats is a snapshot of the queue
+type Stats struct {
+	Queued      map[string]int `json:"queued"` // by priority class
+	InFlight    int            `json:"in_flight"`
+	Concurrency int            `json:"concurrency"`
+	Held        []string       `json:"held,omitempty"`
+	Rate        float64        `json:"rate,omitempty"` // soft limit, jobs dispatched per second
+	Burst       int            `json:"burst,omitempty"`
+}
+
+// Config configures a Queue. Hold, if set, reports whether a class must
+// wait; it is called before every dispatch and should be cheap.
+//
+// Rate, if set, is the soft limit: at most Burst jobs are dispatched at
+// once (Rate rounded up if zero), then Rate per second. Jobs over the limit
+// stay queued rather than being refused; only MaxSize bounds them.
+type Config struct {
+	Concurrency int // workers (DefaultConcurrency if zero)
+	MaxSize     int // queued jobs (DefaultMaxSize if zero)
+	Hold        func(Priority) bool
+	Rate        float64
+	Burst       int
+}
+
+// Queue is a priority queue of relay jobs
+type Queue struct {
+	cfg    Config
+	logger *zap.Logger
+
+	mu       sync.Mutex
+	classes  [numPriorities][]*Job
+	index    map[string]*Job
+	inFlight int
+	limit    *softLimit // nil without Config.Rate
+	wake     chan struct{}
+	workers  sync.WaitGroup
+}
+
+// New creates a queue; call Run to start dispatching
+func New(cfg Config, logger *zap.Logger) *Queue {
+	if cfg.Concurrency <= 0 {
+		cfg.Concurrency = DefaultConcurrency
+	}
+	if cfg.MaxSize <= 0 {
+		cfg.MaxSize = DefaultMaxSize
+	}
+	q := &Queue{
+		cfg:    cfg,
+		logger: logger,
+		index:  make(map[string]*Job),
+		wake:   make(chan struct{}, 1),
+	}
+	if cfg.Rate > 0 {
+		if q.cfg.Burst <= 0 {
+			q.cfg.Burst = int(math.Ceil(cfg.Rate))
+		}
+		q.limit = newSoftLimit(cfg.Rate, q.cfg.Burst)
+	}
+	return q
+}
+
+// Enqueue adds a job and returns its position
+func (q *Queue) Enqueue(job *Job) (Position, error) {
+	if job.Priority < PriorityHigh || int(job.Priority) >= numPriorities {
+		job.Priority = PriorityNormal
+	}
+
+	q.mu.Lock()
+	if _, ok := q.index[job.ID]; ok {
+		q.mu.Unlock()
+		return Position{}, ErrDuplicate
+	}
+	if len(q.index) >= q.cfg.MaxSize {
+		q.mu.Unlock()
+		return Position{}, ErrFull
+	}
+	job.enqueuedAt = time.Now().UTC()
+	q.classes[job.Priority] = append(q.classes[job.Priority], job)
+	q.index[job.ID] = job
+	pos, _ := q.positionLocked(job.ID)
+	q.mu.Unlock()
+
+	q.signal()
+	return pos, nil
+}
+
+// Position returns where a queued job stands; ok is false once it has been
+// dispatched (or was never queued)
+func (q *Queue) Position(id string) (Position, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.positionLocked(id)
+}
+
+// Stats returns a snapshot of the queue
+func (q *Queue) Stats() Stats {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	st := Stats{
+		Queued:      make(map[string]int, numPriorities),
+		InFlight:    q.inFlight,
+		Concurrency: q.cfg.Concurrency,
+	}
+	if q.limit != nil {
+		st.Rate, st.Burst = q.cfg.Rate, q.cfg.Burst
+	}
+	for p := PriorityHigh; int(p) < numPriorities; p++ {
+		st.Queued[p.String()] = len(q.classes[p])
+		if q.held(p) {
+			st.Held = append(st.Held, p.String())
+		}
+	}
+	return st
+}
+
+// Backlog estimates how long a job queued now would wait for the soft limit
+// (0 without one): the time to dispatch every job already queued
+func (q *Queue) Backlog() time.Duration {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.limit == nil {
+		return 0
+	}
+	return q.limit.wait(time.Now(), len(q.index))
+}
+
+// Run dispatches jobs with cfg.Concurrency workers until ctx is cancelled.
+// Jobs being submitted then run to completion (their context is not
+// cancelled); jobs still queued are left for Recover on the next start.
+func (q *Queue) Run(ctx context.Context) {
+	for i := 0; i < q.cfg.Concurrency; i++ {
+		q.workers.Add(1)
+		go func() {
+			defer q.workers.Done()
+			q.work(ctx)
+		}()
+	}
+	q.workers.Wait()
+}
+
+// Wait blocks until the workers have stopped after shutdown
+func (q *Queue) Wait() {
+	q.workers.Wait()
+}
+
+func (q *Queue) work(ctx context.Context) {
+	for {
+		job, retry := q.next()
+		if job == nil {
+			if retry <= 0 {
+				retry = holdRecheckInterval
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-q.wake:
+			case <-time.After(retry):
+			}
+			continue
+		}
+
+		if err := job.Run(context.WithoutCancel(ctx)); err != nil {
+			q.logger.Warn("queued relay failed",
+				zap.String("id", job.ID),
+				zap.String("priority", job.Priority.String()),
+				zap.Error(err),
+			)
+		}
+
+		q.mu.Lock()
+		q.inFlight--
+		q.mu.Unlock()
+
+		if ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+// next pops the first job of the highest priority class that is not held.
+// While the soft limit has no token it pops nothing and returns how long
+// until it has one.
+func (q *Queue) next() (*Job, time.Duration) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for p := PriorityHigh; int(p) < numPriorities; p++ {
+		if len(q.classes[p]) == 0 || q.held(p) {
+			continue
+		}
+		if now := time.Now(); q.limit != nil && !q.limit.take(now) {
+			return nil, q.limit.delay(now)
+		}
+		job := q.classes[p][0]
+		q.classes[p][0] = nil
+		q.classes[p] = q.classes[p][1:]
+		delete(q.index, job.ID)
+		q.inFlight++
+
+		// Let another idle worker look at the rest
+		if len(q.index) > 0 {
+			q.signal()
+		}
+		return job, 0
+	}
+	return nil, 0
+}
+
+// positionLocked counts the jobs dispatched before id: every job of a higher
+// class plus the ones ahead of it in its own class
+func (q *Queue) positionLocked(id string) (Position, bool) {
+	job, ok := q.index[id]
+	if !ok {
+		return Position{}, false
+	}
+
+	ahead := 0
+	for p := PriorityHigh; p < job.Priority; p++ {
+		ahead += len(q.classes[p])
+	}
+	for _, j := range q.classes[job.Priority] {
+		if j == job {
+			break
+		}
+		ahead++
+	}
+	pos := Position{
+		Position:   ahead + 1,
+		Priority:   job.Priority,
+		Held:       q.held(job.Priority),
+		EnqueuedAt: job.enqueuedAt,
+	}
+	if q.limit != nil {
+		pos.EstimatedWait = int(q.limit.wait(time.Now(), ahead) / time.Second)
+	}
+	return pos, true
+}
+
+func (q *Queue) held(p Priority) bool {
+	return q.cfg.Hold != nil && q.cfg.Hold(p)
+}
+
+// signal wakes one idle worker
+func (q *Queue) signal() {
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+}
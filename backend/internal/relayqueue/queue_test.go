@@ -0,0 +1,164 @@
+package relayqueue_test
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/relayqueue"
+)
+
+// recorder collects the order jobs run in
+type recorder struct {
+	mu  sync.Mutex
+	ran []string
+}
+
+func (r *recorder) job(id string, p relayqueue.Priority) *relayqueue.Job {
+	return &relayqueue.Job{ID: id, Priority: p, Run: func(ctx context.Context) error {
+		r.mu.Lock()
+		r.ran = append(r.ran, id)
+		r.mu.Unlock()
+		return nil
+	}}
+}
+
+func (r *recorder) order() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string{}, r.ran...)
+}
+
+func TestQueue_DispatchesByPriorityThenArrival(t *testing.T) {
+	q := relayqueue.New(relayqueue.Config{}, zap.NewNop())
+	rec := &recorder{}
+
+	for _, j := range []struct {
+		id string
+		p  relayqueue.Priority
+	}{
+		{"low-1", relayqueue.PriorityLow},
+		{"normal-1", relayqueue.PriorityNormal},
+		{"high-1", relayqueue.PriorityHigh},
+		{"normal-2", relayqueue.PriorityNormal},
+		{"high-2", relayqueue.PriorityHigh},
+	} {
+		_, err := q.Enqueue(rec.job(j.id, j.p))
+		require.NoError(t, err)
+	}
+
+	pos, ok := q.Position("normal-2")
+	require.True(t, ok)
+	assert.Equal(t, 4, pos.Position)
+	assert.Equal(t, relayqueue.PriorityNormal, pos.Priority)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go q.Run(ctx)
+
+	require.Eventually(t, func() bool { return len(rec.order()) == 5 }, time.Second, 5*time.Millisecond)
+	assert.Equal(t, []string{"high-1", "high-2", "normal-1", "normal-2", "low-1"}, rec.order())
+
+	_, ok = q.Position("low-1")
+	assert.False(t, ok, "dispatched jobs have no position")
+}
+
+func TestQueue_HeldClassWaitsWithoutBlockingOthers(t *testing.T) {
+	var hold atomic.Bool
+	hold.Store(true)
+	q := relayqueue.New(relayqueue.Config{
+		Hold: func(p relayqueue.Priority) bool { return p == relayqueue.PriorityLow && hold.Load() },
+	}, zap.NewNop())
+	rec := &recorder{}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go q.Run(ctx)
+
+	_, err := q.Enqueue(rec.job("low", relayqueue.PriorityLow))
+	require.NoError(t, err)
+	_, err = q.Enqueue(rec.job("normal", relayqueue.PriorityNormal))
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool { return len(rec.order()) == 1 }, time.Second, 5*time.Millisecond)
+	assert.Equal(t, []string{"normal"}, rec.order())
+
+	pos, ok := q.Position("low")
+	require.True(t, ok)
+	assert.True(t, pos.Held)
+	assert.Equal(t, []string{"low"}, q.Stats().Held)
+
+	// Released on the next enqueue or recheck
+	hold.Store(false)
+	_, err = q.Enqueue(rec.job("normal-2", relayqueue.PriorityNormal))
+	require.NoError(t, err)
+	require.Eventually(t, func() bool { return len(rec.order()) == 3 }, time.Second, 5*time.Millisecond)
+	assert.Equal(t, []string{"normal", "normal-2", "low"}, rec.order())
+}
+
+func TestQueue_RejectsFullAndDuplicate(t *testing.T) {
+	q := relayqueue.New(relayqueue.Config{MaxSize: 2}, zap.NewNop())
+	rec := &recorder{}
+
+	_, err := q.Enqueue(rec.job("a", relayqueue.PriorityNormal))
+	require.NoError(t, err)
+	_, err = q.Enqueue(rec.job("a", relayqueue.PriorityNormal))
+	assert.ErrorIs(t, err, relayqueue.ErrDuplicate)
+	_, err = q.Enqueue(rec.job("b", relayqueue.PriorityNormal))
+	require.NoError(t, err)
+	_, err = q.Enqueue(rec.job("c", relayqueue.PriorityHigh))
+	assert.ErrorIs(t, err, relayqueue.ErrFull)
+
+	assert.Equal(t, map[string]int{"high": 0, "normal": 2, "low": 0}, q.Stats().Queued)
+}
+
+func TestQueue_SoftLimitPacesBursts(t *testing.T) {
+	q := relayqueue.New(relayqueue.Config{Concurrency: 4, Rate: 10, Burst: 2}, zap.NewNop())
+	rec := &recorder{}
+
+	for _, id := range []string{"a", "b", "c", "d", "e"} {
+		_, err := q.Enqueue(rec.job(id, relayqueue.PriorityNormal))
+		require.NoError(t, err, "bursts are queued, not refused")
+	}
+	first, ok := q.Position("a")
+	require.True(t, ok)
+	assert.Zero(t, first.EstimatedWait, "within the burst")
+	last, ok := q.Position("e")
+	require.True(t, ok)
+	assert.Equal(t, 1, last.EstimatedWait, "three over the burst at 10/s")
+	assert.Equal(t, 10.0, q.Stats().Rate)
+	assert.Equal(t, 2, q.Stats().Burst)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go q.Run(ctx)
+
+	require.Eventually(t, func() bool { return len(rec.order()) >= 2 }, time.Second, time.Millisecond)
+	time.Sleep(20 * time.Millisecond)
+	assert.Len(t, rec.order(), 2, "the rest wait for the rate")
+	assert.Positive(t, q.Backlog())
+
+	require.Eventually(t, func() bool { return len(rec.order()) == 5 }, 2*time.Second, 5*time.Millisecond)
+	assert.Equal(t, []string{"a", "b", "c", "d", "e"}, rec.order())
+	assert.LessOrEqual(t, q.Backlog(), time.Second, "only the next token is owed")
+}
+
+func TestPriority_JSON(t *testing.T) {
+	var classes map[string]relayqueue.Priority
+	require.NoError(t, json.Unmarshal([]byte(`{"nexusKYC": "high", "nexusNFT": "low"}`), &classes))
+	assert.Equal(t, relayqueue.PriorityHigh, classes["nexusKYC"])
+	assert.Equal(t, relayqueue.PriorityLow, classes["nexusNFT"])
+
+	assert.Error(t, json.Unmarshal([]byte(`{"nexusKYC": "urgent"}`), &classes))
+
+	out, err := json.Marshal(relayqueue.Position{Position: 1, Priority: relayqueue.PriorityNormal})
+	require.NoError(t, err)
+	assert.Contains(t, string(out), `"priority":"normal"`)
+}
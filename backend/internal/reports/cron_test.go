@@ -0,0 +1,92 @@
+package reports_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/reports"
+)
+
+func TestCron_Next(t *testing.T) {
+	// 2026-03-04 is a Wednesday
+	from := time.Date(2026, 3, 4, 10, 17, 30, 0, time.UTC)
+
+	for _, tc := range []struct {
+		expr string
+		want time.Time
+	}{
+		{"* * * * *", time.Date(2026, 3, 4, 10, 18, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2026, 3, 4, 10, 30, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2026, 3, 4, 11, 0, 0, 0, time.UTC)},
+		{"@daily", time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC)},
+		{"0 8 * * 1", time.Date(2026, 3, 9, 8, 0, 0, 0, time.UTC)},
+		{"0 8 * * 7", time.Date(2026, 3, 8, 8, 0, 0, 0, time.UTC)},
+		{"30 9 1,15 * *", time.Date(2026, 3, 15, 9, 30, 0, 0, time.UTC)},
+		{"0 0 1 */3 *", time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 6-18/6 * * 1-5", time.Date(2026, 3, 4, 12, 0, 0, 0, time.UTC)},
+		// Both day fields restricted: the 20th or any Friday
+		{"0 0 20 * 5", time.Date(2026, 3, 6, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+	} {
+		c, err := reports.ParseCron(tc.expr)
+		require.NoError(t, err, tc.expr)
+		assert.Equal(t, tc.want, c.Next(from), tc.expr)
+	}
+
+	// The result is in UTC whatever the input's zone
+	c, err := reports.ParseCron("0 0 * * *")
+	require.NoError(t, err)
+	tokyo := time.FixedZone("JST", 9*3600)
+	assert.Equal(t, time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC), c.Next(time.Date(2026, 3, 4, 12, 0, 0, 0, tokyo)))
+}
+
+func TestCron_NextInLocation(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		expr string
+		from time.Time
+		want time.Time
+	}{
+		// Local midnight is 05:00 UTC in winter
+		{"@daily", time.Date(2026, 3, 4, 10, 17, 0, 0, time.UTC), time.Date(2026, 3, 5, 5, 0, 0, 0, time.UTC)},
+		// and 04:00 UTC in summer
+		{"@monthly", time.Date(2026, 5, 15, 0, 0, 0, 0, time.UTC), time.Date(2026, 6, 1, 4, 0, 0, 0, time.UTC)},
+		// 02:30 does not exist on 2026-03-08, when clocks go forward
+		{"30 2 * * *", time.Date(2026, 3, 7, 12, 0, 0, 0, time.UTC), time.Date(2026, 3, 9, 6, 30, 0, 0, time.UTC)},
+		// 01:30 happens twice on 2026-11-01, when clocks go back; it fires once
+		{"30 1 * * *", time.Date(2026, 11, 1, 5, 31, 0, 0, time.UTC), time.Date(2026, 11, 2, 6, 30, 0, 0, time.UTC)},
+	} {
+		c, err := reports.ParseCron(tc.expr)
+		require.NoError(t, err, tc.expr)
+		assert.Equal(t, tc.want, c.In(ny).Next(tc.from), tc.expr)
+	}
+
+	c, err := reports.ParseCron("@daily")
+	require.NoError(t, err)
+	assert.Equal(t, time.UTC, c.Location())
+	assert.Equal(t, ny, c.In(ny).Location())
+}
+
+func TestParseCron_Invalid(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"a * * * *",
+		"0 0 31 2 *",
+	} {
+		_, err := reports.ParseCron(expr)
+		assert.ErrorIs(t, err, reports.ErrInvalidCron, expr)
+	}
+}
@@ -0,0 +1,191 @@
+package reports
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+const (
+	// listPageSize is how many records each list call reads
+	listPageSize = 500
+
+	// MaxRows bounds one report; a period with more records fails the run
+	MaxRows = 100000
+)
+
+// generate renders the kind's records created in [start, end) as CSV, oldest
+// first, and returns the row count
+func (s *Scheduler) generate(ctx context.Context, kind repository.ReportKind, start, end time.Time) ([]byte, int64, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	var rows [][]string
+	var err error
+	switch kind {
+	case repository.ReportPayments:
+		rows, err = collect(ctx, start, end,
+			func(ctx context.Context, page repository.Pagination) ([]*repository.Payment, error) {
+				list, _, err := s.payments.ListPayments(ctx, repository.PaymentFilter{IncludeExpired: true}, page)
+				return list, err
+			},
+			func(p *repository.Payment) (string, time.Time) { return p.ID, p.CreatedAt },
+			paymentRow,
+		)
+		_ = w.Write([]string{"id", "created_at", "payer_address", "service_code", "payment_method",
+			"amount_charged", "currency", "amount_usd", "status", "tx_hash", "completed_at"})
+	case repository.ReportKYC:
+		rows, err = collect(ctx, start, end,
+			func(ctx context.Context, page repository.Pagination) ([]*repository.KYCVerification, error) {
+				list, _, err := s.payments.ListKYCVerifications(ctx, repository.KYCVerificationFilter{}, page)
+				return list, err
+			},
+			func(v *repository.KYCVerification) (string, time.Time) { return v.ID, v.CreatedAt },
+			kycRow,
+		)
+		_ = w.Write([]string{"id", "created_at", "user_address", "status", "payment_id",
+			"submitted_at", "verified_at", "rejected_at"})
+	case repository.ReportRelay:
+		rows, err = collect(ctx, start, end,
+			func(ctx context.Context, page repository.Pagination) ([]*repository.MetaTransaction, error) {
+				list, _, err := s.relayer.ListMetaTx(ctx, repository.MetaTxFilter{}, page)
+				return list, err
+			},
+			func(tx *repository.MetaTransaction) (string, time.Time) { return tx.ID, tx.CreatedAt },
+			relayRow,
+		)
+		_ = w.Write([]string{"id", "created_at", "from_address", "to_address", "function_name",
+			"status", "tx_hash", "gas_used", "relay_cost_eth", "confirmed_at"})
+	default:
+		return nil, 0, fmt.Errorf("%w: %q", ErrInvalidKind, kind)
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+
+	for _, row := range rows {
+		_ = w.Write(row)
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, 0, fmt.Errorf("writing csv: %w", err)
+	}
+	return buf.Bytes(), int64(len(rows)), nil
+}
+
+// collect pages through a newest-first list until it passes start, keeping
+// the records created in [start, end) as rows, oldest first. Records read
+// twice because of inserts shifting the pages are kept once.
+func collect[T any](
+	ctx context.Context,
+	start, end time.Time,
+	list func(ctx context.Context, page repository.Pagination) ([]T, error),
+	key func(T) (string, time.Time),
+	row func(T) []string,
+) ([][]string, error) {
+	var rows [][]string
+	seen := make(map[string]bool)
+
+	for page := 1; ; page++ {
+		items, err := list(ctx, repository.Pagination{Page: page, PageSize: listPageSize})
+		if err != nil {
+			return nil, fmt.Errorf("listing page %d: %w", page, err)
+		}
+
+		passed := false
+		for _, item := range items {
+			id, created := key(item)
+			if created.Before(start) {
+				passed = true
+				break
+			}
+			if !created.Before(end) || seen[id] {
+				continue
+			}
+			seen[id] = true
+			rows = append(rows, row(item))
+			if len(rows) > MaxRows {
+				return nil, fmt.Errorf("report exceeds %d rows", MaxRows)
+			}
+		}
+		if passed || len(items) < listPageSize {
+			break
+		}
+	}
+
+	for i, j := 0, len(rows)-1; i < j; i, j = i+1, j-1 {
+		rows[i], rows[j] = rows[j], rows[i]
+	}
+	return rows, nil
+}
+
+func paymentRow(p *repository.Payment) []string {
+	amountUSD := ""
+	if p.AmountUSD != nil {
+		amountUSD = strconv.FormatFloat(*p.AmountUSD, 'f', -1, 64)
+	}
+	return []string{
+		p.ID,
+		formatTime(&p.CreatedAt),
+		p.PayerAddress,
+		p.ServiceCode,
+		p.PaymentMethod,
+		strconv.FormatFloat(p.AmountCharged, 'f', -1, 64),
+		p.Currency,
+		amountUSD,
+		string(p.Status),
+		deref(p.TxHash),
+		formatTime(p.CompletedAt),
+	}
+}
+
+func kycRow(v *repository.KYCVerification) []string {
+	return []string{
+		v.ID,
+		formatTime(&v.CreatedAt),
+		v.UserAddress,
+		string(v.Status),
+		deref(v.PaymentID),
+		formatTime(v.SubmittedAt),
+		formatTime(v.VerifiedAt),
+		formatTime(v.RejectedAt),
+	}
+}
+
+func relayRow(tx *repository.MetaTransaction) []string {
+	gasUsed := ""
+	if tx.GasUsed != nil {
+		gasUsed = strconv.FormatUint(*tx.GasUsed, 10)
+	}
+	return []string{
+		tx.ID,
+		formatTime(&tx.CreatedAt),
+		tx.FromAddress,
+		tx.ToAddress,
+		tx.FunctionName,
+		string(tx.Status),
+		deref(tx.TxHash),
+		gasUsed,
+		deref(tx.RelayCostETH),
+		formatTime(tx.ConfirmedAt),
+	}
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,354 @@
+// Package reports generates the scheduled payment, KYC and relay reports:
+// each schedule's cron expression (stored in the database) decides when a
+// CSV of the records created since its previous run is written to object
+// storage, and the recipients are emailed a download link. Expressions are
+// evaluated in the schedule's time zone, so a daily report covers its
+// accounting day; stored periods and CSV timestamps are UTC.
+package reports
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/emailverify"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/objectstore"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+const (
+	// DefaultInterval is how often Run looks for due schedules when no
+	// interval is given
+	DefaultInterval = time.Minute
+
+	// dueBatchSize bounds the schedules one pass generates
+	dueBatchSize = 20
+
+	// maxRecipients bounds a schedule's recipient list
+	maxRecipients = 20
+
+	// maxNameLength bounds a schedule's name
+	maxNameLength = 100
+
+	// linkTTL is how long an emailed download link works
+	linkTTL = objectstore.MaxPresignTTL
+)
+
+var (
+	ErrInvalidName       = errors.New("report schedule name is required (at most 100 characters)")
+	ErrInvalidKind       = errors.New("report kind must be payments, kyc or relay")
+	ErrInvalidCron       = errors.New("invalid cron expression")
+	ErrInvalidRecipients = errors.New("report schedules need 1 to 20 valid recipient emails")
+	ErrInvalidTimezone   = errors.New("timezone must be an IANA time zone name, e.g. UTC or Europe/Berlin")
+)
+
+// Mailer sends one plain-text email; emailverify.SMTPMailer implements it
+type Mailer interface {
+	SendMail(ctx context.Context, to, subject, body string) error
+}
+
+// RunStats summarises one scheduling pass
+type RunStats struct {
+	StartedAt time.Time `json:"started_at"`
+	Duration  string    `json:"duration"`
+	Generated int       `json:"generated"`
+	Failed    int       `json:"failed"`
+	Errors    []string  `json:"errors,omitempty"`
+}
+
+// Scheduler runs the due report schedules
+type Scheduler struct {
+	repo     repository.ReportRepository
+	payments repository.PaymentRepository
+	relayer  repository.RelayerRepository
+	store    objectstore.Store
+	mailer   Mailer
+	logger   *zap.Logger
+}
+
+// NewScheduler creates a new report scheduler writing reports to store
+func NewScheduler(repo repository.ReportRepository, payments repository.PaymentRepository, relayer repository.RelayerRepository, store objectstore.Store, logger *zap.Logger) *Scheduler {
+	return &Scheduler{
+		repo:     repo,
+		payments: payments,
+		relayer:  relayer,
+		store:    store,
+		logger:   logger,
+	}
+}
+
+// SetMailer enables emailing recipients; without it reports are only stored
+func (s *Scheduler) SetMailer(mailer Mailer) {
+	s.mailer = mailer
+}
+
+// LoadTimezone returns the location of an IANA time zone name ("" is UTC).
+// The server's Local zone is refused so results do not depend on where the
+// server runs.
+func LoadTimezone(name string) (*time.Location, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return time.UTC, nil
+	}
+	if strings.EqualFold(name, "local") {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidTimezone, name)
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidTimezone, name)
+	}
+	return loc, nil
+}
+
+// ScheduleCron parses a stored schedule's cron expression, evaluated in its
+// time zone
+func ScheduleCron(sched *repository.ReportSchedule) (*Cron, error) {
+	cron, err := ParseCron(sched.Cron)
+	if err != nil {
+		return nil, err
+	}
+	loc, err := LoadTimezone(sched.Timezone)
+	if err != nil {
+		return nil, err
+	}
+	return cron.In(loc), nil
+}
+
+// Validate normalizes a schedule's name, recipients and time zone (UTC when
+// empty) and checks its kind and cron expression, returning the parsed
+// expression evaluated in the time zone
+func Validate(sched *repository.ReportSchedule) (*Cron, error) {
+	sched.Name = strings.TrimSpace(sched.Name)
+	if sched.Name == "" || len(sched.Name) > maxNameLength {
+		return nil, ErrInvalidName
+	}
+	sched.Kind = repository.ReportKind(strings.ToLower(string(sched.Kind)))
+	if !sched.Kind.Valid() {
+		return nil, ErrInvalidKind
+	}
+	cron, err := ParseCron(sched.Cron)
+	if err != nil {
+		return nil, err
+	}
+	sched.Cron = strings.TrimSpace(sched.Cron)
+	loc, err := LoadTimezone(sched.Timezone)
+	if err != nil {
+		return nil, err
+	}
+	sched.Timezone = loc.String()
+
+	if len(sched.Recipients) == 0 || len(sched.Recipients) > maxRecipients {
+		return nil, ErrInvalidRecipients
+	}
+	recipients := make([]string, 0, len(sched.Recipients))
+	seen := make(map[string]bool)
+	for _, r := range sched.Recipients {
+		email, err := emailverify.NormalizeEmail(r)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidRecipients, r)
+		}
+		if !seen[email] {
+			seen[email] = true
+			recipients = append(recipients, email)
+		}
+	}
+	sched.Recipients = recipients
+	return cron.In(loc), nil
+}
+
+// Run generates due reports every interval until ctx is cancelled
+func (s *Scheduler) Run(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	s.RunOnce(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.RunOnce(ctx)
+		}
+	}
+}
+
+// RunOnce claims and generates each due schedule. A schedule another
+// instance claimed first is skipped.
+func (s *Scheduler) RunOnce(ctx context.Context) RunStats {
+	start := time.Now()
+	stats := RunStats{StartedAt: start}
+
+	due, err := s.repo.ListDueReportSchedules(ctx, start, dueBatchSize)
+	if err != nil {
+		s.logger.Error("failed to list due report schedules", zap.Error(err))
+		stats.Errors = append(stats.Errors, err.Error())
+		due = nil
+	}
+
+	for _, sched := range due {
+		if ctx.Err() != nil {
+			break
+		}
+		cron, err := ScheduleCron(sched)
+		if err != nil {
+			s.logger.Error("report schedule has an invalid cron expression or time zone",
+				zap.String("schedule_id", sched.ID), zap.Error(err))
+			stats.Errors = append(stats.Errors, sched.ID+": "+err.Error())
+			continue
+		}
+
+		// Missed runs are not replayed: the next period starts where this one
+		// ends, so it covers the gap
+		dueAt := sched.NextRunAt
+		claimed, err := s.repo.ClaimReportSchedule(ctx, sched.ID, dueAt, cron.Next(start))
+		if err != nil {
+			s.logger.Error("failed to claim report schedule", zap.String("schedule_id", sched.ID), zap.Error(err))
+			stats.Errors = append(stats.Errors, sched.ID+": "+err.Error())
+			continue
+		}
+		if !claimed {
+			continue
+		}
+
+		run := s.generateRun(ctx, sched, periodStart(sched, cron, dueAt), dueAt, false)
+		if run.Status == repository.ReportRunSucceeded {
+			stats.Generated++
+		} else {
+			stats.Failed++
+			stats.Errors = append(stats.Errors, sched.ID+": "+deref(run.ErrorMessage))
+		}
+	}
+
+	stats.Duration = time.Since(start).Round(time.Millisecond).String()
+	if stats.Generated > 0 || stats.Failed > 0 {
+		s.logger.Info("generated scheduled reports",
+			zap.Int("generated", stats.Generated),
+			zap.Int("failed", stats.Failed),
+			zap.String("duration", stats.Duration),
+		)
+	}
+	return stats
+}
+
+// RunSchedule generates a schedule's report now, covering the records since
+// its last scheduled run. The schedule's next run is left as it is.
+func (s *Scheduler) RunSchedule(ctx context.Context, id string) (*repository.ReportRun, error) {
+	sched, err := s.repo.GetReportSchedule(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	cron, err := ScheduleCron(sched)
+	if err != nil {
+		return nil, err
+	}
+	end := time.Now().UTC()
+	return s.generateRun(ctx, sched, periodStart(sched, cron, end), end, true), nil
+}
+
+// periodStart is where a run ending at end starts: the previous scheduled
+// run, or one cron interval back for a schedule that has not run yet
+func periodStart(sched *repository.ReportSchedule, cron *Cron, end time.Time) time.Time {
+	if sched.LastRunAt != nil && sched.LastRunAt.Before(end) {
+		return *sched.LastRunAt
+	}
+	next := cron.Next(end)
+	return end.Add(-cron.Next(next).Sub(next))
+}
+
+// generateRun writes the report for [start, end), emails the recipients and
+// records the run. A report that was stored but could not be emailed still
+// succeeds, with the delivery error noted.
+func (s *Scheduler) generateRun(ctx context.Context, sched *repository.ReportSchedule, start, end time.Time, manual bool) *repository.ReportRun {
+	run := &repository.ReportRun{
+		ScheduleID:  sched.ID,
+		Kind:        sched.Kind,
+		PeriodStart: start.UTC(),
+		PeriodEnd:   end.UTC(),
+		Manual:      manual,
+		StartedAt:   time.Now().UTC(),
+	}
+
+	fail := func(err error) {
+		msg := err.Error()
+		run.Status = repository.ReportRunFailed
+		run.ErrorMessage = &msg
+		s.logger.Error("report generation failed",
+			zap.String("schedule_id", sched.ID),
+			zap.String("kind", string(sched.Kind)),
+			zap.Error(err),
+		)
+	}
+
+	body, rows, err := s.generate(ctx, sched.Kind, run.PeriodStart, run.PeriodEnd)
+	if err != nil {
+		fail(fmt.Errorf("generating report: %w", err))
+	} else {
+		run.Rows = rows
+		key := fmt.Sprintf("%sreports/%s/%s-%s.csv", objectstore.PrefixExports, sched.ID, sched.Kind,
+			run.StartedAt.Format("20060102T150405Z"))
+		if err := s.store.Put(ctx, key, bytes.NewReader(body), int64(len(body)), "text/csv"); err != nil {
+			fail(fmt.Errorf("storing report: %w", err))
+		} else {
+			run.ObjectKey = key
+			run.Status = repository.ReportRunSucceeded
+			if err := s.notify(ctx, sched, run); err != nil {
+				msg := err.Error()
+				run.ErrorMessage = &msg
+				s.logger.Warn("report stored but not delivered", zap.String("schedule_id", sched.ID), zap.Error(err))
+			}
+		}
+	}
+
+	run.FinishedAt = time.Now().UTC()
+	if err := s.repo.CreateReportRun(ctx, run); err != nil {
+		s.logger.Error("failed to record report run", zap.String("schedule_id", sched.ID), zap.Error(err))
+	}
+	return run
+}
+
+// notify emails each recipient a download link. Stores that cannot presign
+// get a pointer to the admin download endpoint instead.
+func (s *Scheduler) notify(ctx context.Context, sched *repository.ReportSchedule, run *repository.ReportRun) error {
+	if s.mailer == nil {
+		return nil
+	}
+
+	loc, err := LoadTimezone(sched.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "The %s report %q for %s to %s (%s) is ready (%d rows).\n\n",
+		sched.Kind, sched.Name, run.PeriodStart.In(loc).Format(time.RFC3339), run.PeriodEnd.In(loc).Format(time.RFC3339),
+		loc, run.Rows)
+	link, err := s.store.PresignGet(ctx, run.ObjectKey, linkTTL)
+	switch {
+	case err == nil:
+		fmt.Fprintf(&b, "Download it before %s:\n%s\n", run.StartedAt.Add(linkTTL).Format(time.RFC3339), link)
+	case errors.Is(err, objectstore.ErrPresignUnsupported):
+		fmt.Fprintf(&b, "Download it from the admin API: GET /api/v1/admin/reports/runs (object %s).\n", run.ObjectKey)
+	default:
+		return fmt.Errorf("presigning report link: %w", err)
+	}
+
+	subject := "Nexus report: " + sched.Name
+	var failed []string
+	for _, to := range sched.Recipients {
+		if err := s.mailer.SendMail(ctx, to, subject, b.String()); err != nil {
+			failed = append(failed, to)
+			s.logger.Warn("failed to email report", zap.String("schedule_id", sched.ID), zap.Error(err))
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("emailing %s failed", strings.Join(failed, ", "))
+	}
+	return nil
+}
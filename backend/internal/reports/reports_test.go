@@ -0,0 +1,246 @@
+package reports_test
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/objectstore"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/reports"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/storage/memory"
+)
+
+// stubPayments lists a fixed set of payments, newest first
+type stubPayments struct {
+	repository.PaymentRepository
+	payments []*repository.Payment
+}
+
+func (s *stubPayments) ListPayments(ctx context.Context, filter repository.PaymentFilter, page repository.Pagination) ([]*repository.Payment, int64, error) {
+	start := (page.Page - 1) * page.PageSize
+	if start >= len(s.payments) {
+		return nil, int64(len(s.payments)), nil
+	}
+	end := min(start+page.PageSize, len(s.payments))
+	return s.payments[start:end], int64(len(s.payments)), nil
+}
+
+// outbox records the emails sent
+type outbox struct {
+	mu   sync.Mutex
+	sent map[string]string
+	err  error
+}
+
+func (o *outbox) SendMail(ctx context.Context, to, subject, body string) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.err != nil {
+		return o.err
+	}
+	if o.sent == nil {
+		o.sent = map[string]string{}
+	}
+	o.sent[to] = subject + "\n" + body
+	return nil
+}
+
+func TestScheduler_RunOnceGeneratesDueReports(t *testing.T) {
+	ctx := context.Background()
+	// Last Monday 08:00 UTC, so the weekly schedule below is due
+	due := time.Now().UTC().Truncate(24 * time.Hour).Add(8 * time.Hour)
+	for due.Weekday() != time.Monday || !due.Before(time.Now()) {
+		due = due.Add(-24 * time.Hour)
+	}
+	week := 7 * 24 * time.Hour
+
+	usd := 49.5
+	payments := &stubPayments{payments: []*repository.Payment{
+		{ID: "after", CreatedAt: due.Add(time.Minute), Status: repository.PaymentStatusPending},
+		{ID: "p2", CreatedAt: due.Add(-time.Hour), PayerAddress: "0xabc", ServiceCode: "kyc_verification",
+			PaymentMethod: "stripe", AmountCharged: 49.5, Currency: "USD", AmountUSD: &usd, Status: repository.PaymentStatusCompleted},
+		{ID: "p1", CreatedAt: due.Add(-week), PayerAddress: "0xdef", Status: repository.PaymentStatusFailed},
+		{ID: "before", CreatedAt: due.Add(-week - time.Second)},
+	}}
+	repo := memory.NewMemoryReportRepo()
+	store := objectstore.NewMemory()
+	mail := &outbox{}
+	s := reports.NewScheduler(repo, payments, nil, store, zap.NewNop())
+	s.SetMailer(mail)
+
+	sched := &repository.ReportSchedule{
+		Name: "Weekly payments", Kind: repository.ReportPayments, Cron: "0 8 * * 1",
+		Recipients: []string{"finance@example.com"}, Enabled: true, CreatedBy: "ops", NextRunAt: due,
+	}
+	require.NoError(t, repo.CreateReportSchedule(ctx, sched))
+
+	stats := s.RunOnce(ctx)
+	assert.Equal(t, 1, stats.Generated)
+	assert.Empty(t, stats.Errors)
+
+	runs, _, err := repo.ListReportRuns(ctx, sched.ID, repository.Pagination{})
+	require.NoError(t, err)
+	require.Len(t, runs, 1)
+	run := runs[0]
+	assert.Equal(t, repository.ReportRunSucceeded, run.Status)
+	assert.True(t, run.PeriodStart.Equal(due.Add(-week)))
+	assert.True(t, run.PeriodEnd.Equal(due))
+	assert.Equal(t, int64(2), run.Rows)
+	assert.False(t, run.Manual)
+	assert.True(t, strings.HasPrefix(run.ObjectKey, "exports/reports/"+sched.ID+"/payments-"), run.ObjectKey)
+
+	body, err := store.Get(ctx, run.ObjectKey)
+	require.NoError(t, err)
+	records, err := csv.NewReader(body).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	assert.Equal(t, "p1", records[1][0], "oldest first")
+	assert.Equal(t, []string{"p2", due.Add(-time.Hour).Format(time.RFC3339), "0xabc", "kyc_verification",
+		"stripe", "49.5", "USD", "49.5", "completed", "", ""}, records[2])
+
+	// The memory store cannot presign, so the email points to the admin API
+	require.Contains(t, mail.sent, "finance@example.com")
+	assert.Contains(t, mail.sent["finance@example.com"], "Nexus report: Weekly payments")
+	assert.Contains(t, mail.sent["finance@example.com"], run.ObjectKey)
+
+	// Claimed: the next run moved forward and the same run is not repeated
+	got, err := repo.GetReportSchedule(ctx, sched.ID)
+	require.NoError(t, err)
+	assert.True(t, got.NextRunAt.After(time.Now()))
+	require.NotNil(t, got.LastRunAt)
+	assert.True(t, got.LastRunAt.Equal(due))
+	assert.Equal(t, 0, s.RunOnce(ctx).Generated)
+}
+
+func TestScheduler_RunOnceUsesScheduleTimezone(t *testing.T) {
+	ctx := context.Background()
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+	// Today's midnight in Berlin, so the daily schedule below is due
+	now := time.Now().In(berlin)
+	due := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, berlin)
+	yesterday := due.AddDate(0, 0, -1).UTC()
+
+	repo := memory.NewMemoryReportRepo()
+	mail := &outbox{}
+	s := reports.NewScheduler(repo, &stubPayments{}, nil, objectstore.NewMemory(), zap.NewNop())
+	s.SetMailer(mail)
+
+	sched := &repository.ReportSchedule{
+		Name: "Daily payments", Kind: repository.ReportPayments, Cron: "@daily", Timezone: "Europe/Berlin",
+		Recipients: []string{"finance@example.com"}, Enabled: true, CreatedBy: "ops", NextRunAt: due.UTC(),
+		LastRunAt: &yesterday,
+	}
+	require.NoError(t, repo.CreateReportSchedule(ctx, sched))
+	require.Equal(t, 1, s.RunOnce(ctx).Generated)
+
+	// The period is the Berlin calendar day, stored in UTC
+	runs, _, err := repo.ListReportRuns(ctx, sched.ID, repository.Pagination{})
+	require.NoError(t, err)
+	require.Len(t, runs, 1)
+	assert.Equal(t, time.UTC, runs[0].PeriodStart.Location())
+	assert.True(t, runs[0].PeriodStart.Equal(yesterday), runs[0].PeriodStart)
+	assert.True(t, runs[0].PeriodEnd.Equal(due), runs[0].PeriodEnd)
+	assert.Contains(t, mail.sent["finance@example.com"], due.Format(time.RFC3339)+" (Europe/Berlin)")
+
+	got, err := repo.GetReportSchedule(ctx, sched.ID)
+	require.NoError(t, err)
+	assert.True(t, got.NextRunAt.Equal(due.AddDate(0, 0, 1)), got.NextRunAt)
+}
+
+func TestScheduler_RunScheduleRecordsFailures(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryReportRepo()
+	store := &failingStore{Store: objectstore.NewMemory()}
+	s := reports.NewScheduler(repo, &stubPayments{}, nil, store, zap.NewNop())
+
+	sched := &repository.ReportSchedule{
+		Name: "Daily payments", Kind: repository.ReportPayments, Cron: "@daily",
+		Recipients: []string{"finance@example.com"}, Enabled: true, NextRunAt: time.Now().Add(time.Hour),
+	}
+	require.NoError(t, repo.CreateReportSchedule(ctx, sched))
+
+	run, err := s.RunSchedule(ctx, sched.ID)
+	require.NoError(t, err)
+	assert.Equal(t, repository.ReportRunFailed, run.Status)
+	assert.True(t, run.Manual)
+	require.NotNil(t, run.ErrorMessage)
+	assert.Contains(t, *run.ErrorMessage, "bucket unavailable")
+	assert.InDelta(t, 24*time.Hour, run.PeriodEnd.Sub(run.PeriodStart), float64(time.Minute))
+
+	got, err := repo.GetReportRun(ctx, run.ID)
+	require.NoError(t, err)
+	assert.Equal(t, repository.ReportRunFailed, got.Status)
+
+	// A manual run leaves the schedule alone
+	after, err := repo.GetReportSchedule(ctx, sched.ID)
+	require.NoError(t, err)
+	assert.Nil(t, after.LastRunAt)
+
+	_, err = s.RunSchedule(ctx, "missing")
+	assert.ErrorIs(t, err, repository.ErrReportScheduleNotFound)
+}
+
+// failingStore refuses uploads
+type failingStore struct {
+	objectstore.Store
+}
+
+func (f *failingStore) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	return errors.New("bucket unavailable")
+}
+
+func TestValidate(t *testing.T) {
+	valid := func() *repository.ReportSchedule {
+		return &repository.ReportSchedule{
+			Name: " Relay costs ", Kind: "RELAY", Cron: " @weekly ",
+			Recipients: []string{"Ops@Example.COM", "ops@example.com"},
+		}
+	}
+
+	s := valid()
+	c, err := reports.Validate(s)
+	require.NoError(t, err)
+	require.NotNil(t, c)
+	assert.Equal(t, "Relay costs", s.Name)
+	assert.Equal(t, repository.ReportRelay, s.Kind)
+	assert.Equal(t, "@weekly", s.Cron)
+	assert.Equal(t, []string{"Ops@example.com", "ops@example.com"}, s.Recipients)
+	assert.Equal(t, "UTC", s.Timezone)
+	assert.Equal(t, time.UTC, c.Location())
+
+	s = valid()
+	s.Timezone = " America/New_York "
+	c, err = reports.Validate(s)
+	require.NoError(t, err)
+	assert.Equal(t, "America/New_York", s.Timezone)
+	assert.Equal(t, "America/New_York", c.Location().String())
+
+	for name, tc := range map[string]struct {
+		edit func(s *repository.ReportSchedule)
+		want error
+	}{
+		"no name":       {func(s *repository.ReportSchedule) { s.Name = " " }, reports.ErrInvalidName},
+		"unknown kind":  {func(s *repository.ReportSchedule) { s.Kind = "ledger" }, reports.ErrInvalidKind},
+		"bad cron":      {func(s *repository.ReportSchedule) { s.Cron = "every monday" }, reports.ErrInvalidCron},
+		"no recipients": {func(s *repository.ReportSchedule) { s.Recipients = nil }, reports.ErrInvalidRecipients},
+		"bad recipient": {func(s *repository.ReportSchedule) { s.Recipients = []string{"not-an-email"} }, reports.ErrInvalidRecipients},
+		"bad timezone":  {func(s *repository.ReportSchedule) { s.Timezone = "Mars/Olympus" }, reports.ErrInvalidTimezone},
+		"server zone":   {func(s *repository.ReportSchedule) { s.Timezone = "Local" }, reports.ErrInvalidTimezone},
+	} {
+		s := valid()
+		tc.edit(s)
+		_, err := reports.Validate(s)
+		assert.ErrorIs(t, err, tc.want, name)
+	}
+}
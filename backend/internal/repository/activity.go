@@ -0,0 +1,57 @@
+// Package repository defines the interfaces for data access
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// ActivityRepository is the domain-event store behind users' activity
+// feeds: an append-only log of what happened to each address
+type ActivityRepository interface {
+	// RecordActivity appends e, setting its ID and, if unset, OccurredAt
+	RecordActivity(ctx context.Context, e *ActivityEvent) error
+
+	// ListActivity lists an address's events, newest first
+	ListActivity(ctx context.Context, filter ActivityFilter, page Pagination) ([]*ActivityEvent, int64, error)
+}
+
+// ActivityKind is the domain an activity event comes from
+type ActivityKind string
+
+const (
+	ActivityPayment     ActivityKind = "payment"
+	ActivityKYC         ActivityKind = "kyc"
+	ActivityNFTTransfer ActivityKind = "nft_transfer"
+	ActivityVote        ActivityKind = "vote"
+	ActivityMetaTx      ActivityKind = "meta_tx"
+)
+
+// Valid reports whether k is a known kind
+func (k ActivityKind) Valid() bool {
+	switch k {
+	case ActivityPayment, ActivityKYC, ActivityNFTTransfer, ActivityVote, ActivityMetaTx:
+		return true
+	}
+	return false
+}
+
+// ActivityEvent is one entry in an address's timeline. Action is what
+// happened to the subject, e.g. a payment's new status or "sent" and
+// "received" for an NFT transfer.
+type ActivityEvent struct {
+	ID         string       `json:"id" db:"id"`
+	Address    string       `json:"address" db:"address"`
+	Kind       ActivityKind `json:"kind" db:"kind"`
+	Action     string       `json:"action" db:"action"`
+	SubjectID  string       `json:"subject_id" db:"subject_id"` // payment, verification, token, proposal or meta-tx ID
+	Summary    string       `json:"summary" db:"summary"`
+	TxHash     *string      `json:"tx_hash,omitempty" db:"tx_hash"`
+	OccurredAt time.Time    `json:"occurred_at" db:"occurred_at"`
+}
+
+// ActivityFilter selects an address's events, optionally of some kinds only
+type ActivityFilter struct {
+	Address string
+	Kinds   []ActivityKind
+}
@@ -0,0 +1,79 @@
+// Package repository defines the interfaces for data access
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// AnomalyRepository defines the contract for the activity aggregates anomaly
+// detection reads and the compliance cases it opens
+type AnomalyRepository interface {
+	// CountPaymentsByPayer counts the payments created since since per
+	// payer, keeping payers with at least min
+	CountPaymentsByPayer(ctx context.Context, since time.Time, min int64) ([]SubjectCount, error)
+
+	// CountFailedRelaysBySender counts the failed meta-transactions created
+	// since since per sender, keeping senders with at least min
+	CountFailedRelaysBySender(ctx context.Context, since time.Time, min int64) ([]SubjectCount, error)
+
+	// HourlyActivity counts the payments and meta-transactions created since
+	// since per UTC hour, oldest first. Hours without either are left out.
+	HourlyActivity(ctx context.Context, since time.Time) ([]HourlyCount, error)
+
+	// OpenComplianceCase opens c, setting its ID, status and opened time,
+	// unless a case for the same rule and subject is still open. It reports
+	// whether c was opened.
+	OpenComplianceCase(ctx context.Context, c *ComplianceCase) (bool, error)
+	GetComplianceCase(ctx context.Context, id string) (*ComplianceCase, error)
+	ListComplianceCases(ctx context.Context, filter ComplianceCaseFilter, page Pagination) ([]*ComplianceCase, int64, error)
+
+	// CloseComplianceCase closes an open case with the reviewer's resolution
+	CloseComplianceCase(ctx context.Context, id, closedBy, resolution string) (*ComplianceCase, error)
+}
+
+// SubjectCount is the number of records for one address
+type SubjectCount struct {
+	Subject string `json:"subject"`
+	Count   int64  `json:"count"`
+}
+
+// HourlyCount is the number of payments and meta-transactions created in
+// the hour starting at Hour
+type HourlyCount struct {
+	Hour     time.Time `json:"hour"`
+	Payments int64     `json:"payments"`
+	Relays   int64     `json:"relays"`
+}
+
+// ComplianceCaseStatus represents compliance case states
+type ComplianceCaseStatus string
+
+const (
+	ComplianceCaseOpen   ComplianceCaseStatus = "open"
+	ComplianceCaseClosed ComplianceCaseStatus = "closed"
+)
+
+// ComplianceCase is unusual activity flagged for compliance review. Subject
+// is the address the rule flagged, or the activity and hour for volume
+// rules.
+type ComplianceCase struct {
+	ID         string               `json:"id" db:"id"`
+	Rule       string               `json:"rule" db:"rule"`
+	Subject    string               `json:"subject" db:"subject"`
+	Summary    string               `json:"summary" db:"summary"`
+	Observed   float64              `json:"observed" db:"observed"`
+	Threshold  float64              `json:"threshold" db:"threshold"`
+	Status     ComplianceCaseStatus `json:"status" db:"status"`
+	OpenedAt   time.Time            `json:"opened_at" db:"opened_at"`
+	ClosedAt   *time.Time           `json:"closed_at,omitempty" db:"closed_at"`
+	ClosedBy   *string              `json:"closed_by,omitempty" db:"closed_by"`
+	Resolution *string              `json:"resolution,omitempty" db:"resolution"`
+}
+
+// ComplianceCaseFilter defines filtering options for listing cases
+type ComplianceCaseFilter struct {
+	Rule    string
+	Subject string
+	Status  ComplianceCaseStatus
+}
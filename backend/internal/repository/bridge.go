@@ -0,0 +1,113 @@
+// Package repository defines the interfaces for data access
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// BridgeRepository defines the contract for NEXUS bridge transfers between
+// chains and the block cursors of the bridge watcher. Amounts are base
+// units as decimal strings.
+type BridgeRepository interface {
+	// CreateBridgeTransfer records a transfer: a user's intent before its
+	// source event is seen, or a transfer first seen on-chain. It returns
+	// ErrBridgeTransferExists if the source chain and nonce are recorded.
+	CreateBridgeTransfer(ctx context.Context, t *BridgeTransfer) error
+	GetBridgeTransfer(ctx context.Context, id string) (*BridgeTransfer, error)
+	GetBridgeTransferByNonce(ctx context.Context, sourceChainID int64, nonce uint64) (*BridgeTransfer, error)
+	GetBridgeTransferByDestID(ctx context.Context, destTransferID string) (*BridgeTransfer, error)
+
+	// MatchBridgeIntent returns the oldest intent not yet matched to a
+	// source event that the event fits, or ErrBridgeTransferNotFound
+	MatchBridgeIntent(ctx context.Context, match BridgeIntentMatch) (*BridgeTransfer, error)
+
+	UpdateBridgeTransfer(ctx context.Context, id string, update *BridgeTransferUpdate) error
+
+	// ListBridgeTransfers lists transfers sent or received by address,
+	// newest first
+	ListBridgeTransfers(ctx context.Context, address string, page Pagination) ([]*BridgeTransfer, int64, error)
+
+	// GetBridgeCursor returns the first block of chainID the watcher has not
+	// scanned, or ErrBridgeCursorNotFound before its first scan
+	GetBridgeCursor(ctx context.Context, chainID int64) (uint64, error)
+	SetBridgeCursor(ctx context.Context, chainID int64, nextBlock uint64) error
+}
+
+// BridgeDirection is which way a transfer crosses the bridge
+type BridgeDirection string
+
+const (
+	BridgeLockMint    BridgeDirection = "lock_mint"    // locked on the source chain, minted on the destination
+	BridgeBurnRelease BridgeDirection = "burn_release" // burned on the destination chain, unlocked back on the source
+)
+
+// BridgeLegStatus represents the state of one side of a bridge transfer
+type BridgeLegStatus string
+
+const (
+	BridgeLegPending   BridgeLegStatus = "pending"   // not seen on-chain yet
+	BridgeLegConfirmed BridgeLegStatus = "confirmed" // source lock or burn at confirmation depth
+	BridgeLegQueued    BridgeLegStatus = "queued"    // large transfer waiting out the bridge's timelock
+	BridgeLegCompleted BridgeLegStatus = "completed" // minted or unlocked to the recipient
+	BridgeLegCancelled BridgeLegStatus = "cancelled" // queued large transfer cancelled by a bridge admin
+)
+
+// BridgeTransfer is a NEXUS transfer across the bridge. The source leg is
+// the lock or burn on SourceChainID, the destination leg the mint or unlock
+// on DestChainID; Nonce is the source bridge's outbound nonce and pairs the
+// two. Direction is empty for an intent until either leg is seen, and Sender
+// for a transfer seen on its destination chain first.
+type BridgeTransfer struct {
+	ID                string          `json:"id" db:"id"`
+	Direction         BridgeDirection `json:"direction,omitempty" db:"direction"`
+	Sender            string          `json:"sender" db:"sender"`
+	Recipient         string          `json:"recipient" db:"recipient"`
+	Amount            string          `json:"amount" db:"amount"`
+	SourceChainID     int64           `json:"source_chain_id" db:"source_chain_id"`
+	DestChainID       int64           `json:"dest_chain_id" db:"dest_chain_id"`
+	Nonce             *uint64         `json:"nonce,omitempty" db:"nonce"`
+	SourceStatus      BridgeLegStatus `json:"source_status" db:"source_status"`
+	SourceTransferID  *string         `json:"source_transfer_id,omitempty" db:"source_transfer_id"`
+	SourceTxHash      *string         `json:"source_tx_hash,omitempty" db:"source_tx_hash"` // for an intent, the expected lock or burn tx
+	SourceBlock       *uint64         `json:"source_block,omitempty" db:"source_block"`
+	SourceConfirmedAt *time.Time      `json:"source_confirmed_at,omitempty" db:"source_confirmed_at"`
+	DestStatus        BridgeLegStatus `json:"dest_status" db:"dest_status"`
+	DestTransferID    *string         `json:"dest_transfer_id,omitempty" db:"dest_transfer_id"`
+	DestTxHash        *string         `json:"dest_tx_hash,omitempty" db:"dest_tx_hash"`
+	DestBlock         *uint64         `json:"dest_block,omitempty" db:"dest_block"`
+	DestUnlockAt      *time.Time      `json:"dest_unlock_at,omitempty" db:"dest_unlock_at"` // end of a queued transfer's timelock
+	DestCompletedAt   *time.Time      `json:"dest_completed_at,omitempty" db:"dest_completed_at"`
+	CreatedAt         time.Time       `json:"created_at" db:"created_at"`
+	UpdatedAt         time.Time       `json:"updated_at" db:"updated_at"`
+}
+
+// BridgeIntentMatch is a source event looked up against recorded intents.
+// An intent matches on sender, recipient, amount and chains, and on TxHash
+// if the intent names one.
+type BridgeIntentMatch struct {
+	Sender        string
+	Recipient     string
+	Amount        string
+	SourceChainID int64
+	DestChainID   int64
+	TxHash        string
+}
+
+// BridgeTransferUpdate contains changes to a bridge transfer; unset fields
+// are kept. SourceConfirmedAt and DestCompletedAt are stamped when the
+// corresponding leg moves to confirmed or completed.
+type BridgeTransferUpdate struct {
+	Direction        *BridgeDirection
+	Sender           *string
+	Nonce            *uint64
+	SourceStatus     *BridgeLegStatus
+	SourceTransferID *string
+	SourceTxHash     *string
+	SourceBlock      *uint64
+	DestStatus       *BridgeLegStatus
+	DestTransferID   *string
+	DestTxHash       *string
+	DestBlock        *uint64
+	DestUnlockAt     *time.Time
+}
@@ -0,0 +1,79 @@
+// Package repository defines the interfaces for data access
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// ChainRepository defines the contract for block and transaction tracking used
+// for confirmation depth and reorg handling
+type ChainRepository interface {
+	// Block tracking (recent canonical block hashes per chain)
+	SaveBlock(ctx context.Context, block *BlockRecord) error
+	GetBlock(ctx context.Context, chainID int64, number uint64) (*BlockRecord, error)
+	GetLatestBlock(ctx context.Context, chainID int64) (*BlockRecord, error)
+	DeleteBlocksAbove(ctx context.Context, chainID int64, number uint64) (int64, error)
+	PruneBlocksBelow(ctx context.Context, chainID int64, number uint64) (int64, error)
+
+	// Tracked transactions
+	TrackTx(ctx context.Context, tx *TrackedTx) error
+	ListUnfinalizedTxs(ctx context.Context, chainID int64, limit int) ([]*TrackedTx, error)
+	UpdateTrackedTx(ctx context.Context, id string, update *TrackedTxUpdate) error
+	RollbackTxsAbove(ctx context.Context, chainID int64, number uint64) ([]*TrackedTx, error)
+}
+
+// Tracked record types
+const (
+	TrackedRecordPayment       = "payment"
+	TrackedRecordMetaTx        = "meta_tx"
+	TrackedRecordGasSettlement = "gas_settlement"
+	TrackedRecordPayout        = "payout"
+	TrackedRecordSafeTx        = "safe_tx"
+)
+
+// TrackedTxStatus represents confirmation states of a tracked transaction
+type TrackedTxStatus string
+
+const (
+	TrackedTxStatusPending   TrackedTxStatus = "pending"   // Not yet seen in a block
+	TrackedTxStatusIncluded  TrackedTxStatus = "included"  // Mined, waiting for confirmation depth
+	TrackedTxStatusConfirmed TrackedTxStatus = "confirmed" // Reached confirmation depth and succeeded
+	TrackedTxStatusFailed    TrackedTxStatus = "failed"    // Reached confirmation depth but reverted
+)
+
+// BlockRecord represents a block hash seen by the tracker
+type BlockRecord struct {
+	ChainID    int64     `json:"chain_id" db:"chain_id"`
+	Number     uint64    `json:"block_number" db:"block_number"`
+	Hash       string    `json:"block_hash" db:"block_hash"`
+	ParentHash string    `json:"parent_hash" db:"parent_hash"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+}
+
+// TrackedTx represents an on-chain transaction backing an application record
+type TrackedTx struct {
+	ID            string          `json:"id" db:"id"`
+	ChainID       int64           `json:"chain_id" db:"chain_id"`
+	TxHash        string          `json:"tx_hash" db:"tx_hash"`
+	RecordType    string          `json:"record_type" db:"record_type"`
+	RecordID      string          `json:"record_id" db:"record_id"`
+	Status        TrackedTxStatus `json:"status" db:"status"`
+	BlockNumber   *uint64         `json:"block_number,omitempty" db:"block_number"`
+	BlockHash     *string         `json:"block_hash,omitempty" db:"block_hash"`
+	Confirmations int             `json:"confirmations" db:"confirmations"`
+	ReorgCount    int             `json:"reorg_count" db:"reorg_count"`
+	CreatedAt     time.Time       `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time       `json:"updated_at" db:"updated_at"`
+	FinalizedAt   *time.Time      `json:"finalized_at,omitempty" db:"finalized_at"`
+}
+
+// TrackedTxUpdate contains update details for a tracked transaction.
+// ClearBlock resets block_number/block_hash (used when a tx drops out of the chain).
+type TrackedTxUpdate struct {
+	Status        TrackedTxStatus `json:"status"`
+	BlockNumber   *uint64         `json:"block_number,omitempty"`
+	BlockHash     *string         `json:"block_hash,omitempty"`
+	Confirmations *int            `json:"confirmations,omitempty"`
+	ClearBlock    bool            `json:"clear_block,omitempty"`
+}
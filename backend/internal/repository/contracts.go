@@ -3,6 +3,7 @@ package repository
 
 import (
 	"context"
+	"encoding/json"
 	"time"
 )
 
@@ -29,6 +30,13 @@ type ContractRepository interface {
 
 	// Combined endpoint for deploy scripts - returns everything needed for deployment
 	GetDeploymentConfig(ctx context.Context, chainID int64) (*DeploymentConfig, error)
+
+	// ABI artifacts (versioned per contract mapping). A published version is
+	// immutable: UpsertABI returns it again for the same ABI and
+	// ErrABIVersionConflict for a different one.
+	UpsertABI(ctx context.Context, artifact *ABIArtifactUpsert) (*ABIArtifact, error)
+	GetABI(ctx context.Context, dbName, version string) (*ABIArtifact, error) // version "latest" returns the newest upload
+	ListABIVersions(ctx context.Context, dbName string) ([]*ABIArtifact, error)
 }
 
 // NetworkConfig represents per-network configuration from DB
@@ -38,6 +46,8 @@ type NetworkConfig struct {
 	NetworkName     string    `json:"network_name" db:"network_name"`
 	DisplayName     string    `json:"display_name" db:"display_name"`
 	RPCUrl          *string   `json:"rpc_url,omitempty" db:"rpc_url"`
+	RPCFallbackUrls []string  `json:"rpc_fallback_urls,omitempty" db:"rpc_fallback_urls"`
+	WSUrl           *string   `json:"ws_url,omitempty" db:"ws_url"` // eth_subscribe endpoint; NULL = HTTP polling only
 	ExplorerUrl     *string   `json:"explorer_url,omitempty" db:"explorer_url"`
 	DefaultDeployer *string   `json:"default_deployer,omitempty" db:"default_deployer"`
 	IsTestnet       bool      `json:"is_testnet" db:"is_testnet"`
@@ -74,6 +84,8 @@ type ContractAddress struct {
 	IsPrimary         bool      `json:"is_primary" db:"is_primary"`
 	DeployedBy        *string   `json:"deployed_by,omitempty" db:"deployed_by"`
 	Notes             *string   `json:"notes,omitempty" db:"notes"`
+	EIP712Name        *string   `json:"eip712_name,omitempty" db:"eip712_name"` // EIP-712 domain, for contracts verifying typed signatures
+	EIP712Version     *string   `json:"eip712_version,omitempty" db:"eip712_version"`
 	CreatedAt         time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt         time.Time `json:"updated_at" db:"updated_at"`
 }
@@ -88,6 +100,8 @@ type ContractAddressUpsert struct {
 	ABIVersion        *string `json:"abi_version,omitempty"`
 	DeployedBy        *string `json:"deployed_by,omitempty"`
 	Notes             *string `json:"notes,omitempty"`
+	EIP712Name        *string `json:"eip712_name,omitempty"`
+	EIP712Version     *string `json:"eip712_version,omitempty"`
 }
 
 // ContractAddressHistory represents an audit trail entry for address changes
@@ -107,3 +121,31 @@ type DeploymentConfig struct {
 	Mappings  []*ContractMapping `json:"mappings"`
 	Contracts []*ContractAddress `json:"contracts"`
 }
+
+// ABIVersionLatest resolves to the most recently uploaded ABI for a contract
+const ABIVersionLatest = "latest"
+
+// ABIArtifact represents a stored contract ABI for a specific version
+type ABIArtifact struct {
+	ID                string          `json:"id" db:"id"`
+	ContractMappingID string          `json:"contract_mapping_id" db:"contract_mapping_id"`
+	DBName            string          `json:"db_name" db:"db_name"`             // Joined from contract_mappings
+	SolidityName      string          `json:"solidity_name" db:"solidity_name"` // Joined from contract_mappings
+	Version           string          `json:"version" db:"version"`
+	ABI               json.RawMessage `json:"abi,omitempty" db:"abi"` // Omitted in version listings
+	ABIHash           string          `json:"abi_hash" db:"abi_hash"`
+	CompilerVersion   *string         `json:"compiler_version,omitempty" db:"compiler_version"`
+	UploadedBy        *string         `json:"uploaded_by,omitempty" db:"uploaded_by"`
+	CreatedAt         time.Time       `json:"created_at" db:"created_at"`
+	UpdatedAt         time.Time       `json:"updated_at" db:"updated_at"`
+}
+
+// ABIArtifactUpsert represents data for publishing an ABI artifact
+type ABIArtifactUpsert struct {
+	DBName          string          `json:"contract_name"`
+	Version         string          `json:"version"`
+	ABI             json.RawMessage `json:"abi"`
+	ABIHash         string          `json:"abi_hash"`
+	CompilerVersion *string         `json:"compiler_version,omitempty"`
+	UploadedBy      *string         `json:"uploaded_by,omitempty"`
+}
@@ -0,0 +1,93 @@
+// Package repository defines the interfaces for data access
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// DepositRepository defines the contract for crypto payment intents paid by
+// transfer to a unique deposit address. Each intent takes the next index of
+// the deposit HD wallet, so an address is never handed out twice. Amounts are
+// base units (wei, or NEXUS with 18 decimals) as decimal strings.
+type DepositRepository interface {
+	// NextDepositIndex reserves the next unused derivation index. Indexes
+	// reserved for intents that are never created are skipped.
+	NextDepositIndex(ctx context.Context) (int64, error)
+
+	CreateDepositIntent(ctx context.Context, d *DepositIntent) error
+	GetDepositIntent(ctx context.Context, id string) (*DepositIntent, error)
+	ListDepositIntents(ctx context.Context, filter DepositIntentFilter, page Pagination) ([]*DepositIntent, int64, error)
+	UpdateDepositIntent(ctx context.Context, id string, update *DepositIntentUpdate) error
+}
+
+// DepositStatus represents deposit intent states
+type DepositStatus string
+
+const (
+	DepositAwaiting   DepositStatus = "awaiting"   // no (sufficient) transfer seen yet
+	DepositConfirming DepositStatus = "confirming" // enough received, waiting for confirmation depth
+	DepositPaid       DepositStatus = "paid"       // confirmed; the payment is completed
+	DepositUnderpaid  DepositStatus = "underpaid"  // expired holding less than expected; the payment failed
+	DepositSwept      DepositStatus = "swept"      // funds moved to the treasury
+	DepositExpired    DepositStatus = "expired"    // expired without funds; the payment is cancelled
+)
+
+// Watched reports whether the address is still watched for incoming transfers
+func (s DepositStatus) Watched() bool {
+	return s == DepositAwaiting || s == DepositConfirming
+}
+
+// Sweepable reports whether the address holds funds waiting to be swept
+func (s DepositStatus) Sweepable() bool {
+	return s == DepositPaid || s == DepositUnderpaid
+}
+
+// DepositIntent is a crypto payment awaiting a transfer to its deposit address
+type DepositIntent struct {
+	ID              string        `json:"id" db:"id"`
+	PaymentID       string        `json:"payment_id" db:"payment_id"`
+	PayerAddress    string        `json:"payer_address" db:"payer_address"`
+	Currency        string        `json:"currency" db:"currency"`                     // ETH or NEXUS
+	TokenAddress    *string       `json:"token_address,omitempty" db:"token_address"` // NEXUS token; nil for ETH
+	DepositAddress  string        `json:"deposit_address" db:"deposit_address"`
+	DerivationIndex int64         `json:"derivation_index" db:"derivation_index"`
+	ExpectedAmount  string        `json:"expected_amount" db:"expected_amount"`
+	ReceivedAmount  string        `json:"received_amount" db:"received_amount"` // last balance observed
+	Status          DepositStatus `json:"status" db:"status"`
+	ExpiresAt       time.Time     `json:"expires_at" db:"expires_at"`
+	CreatedAt       time.Time     `json:"created_at" db:"created_at"`
+	UpdatedAt       time.Time     `json:"updated_at" db:"updated_at"`
+	DetectedAt      *time.Time    `json:"detected_at,omitempty" db:"detected_at"` // first seen holding enough
+	PaidAt          *time.Time    `json:"paid_at,omitempty" db:"paid_at"`
+
+	// SweepTxHash is the last transaction sent sweeping the address (a gas
+	// top-up for NEXUS deposits, then the transfer) and SweepAttemptedAt
+	// when it was sent
+	SweepTxHash      *string    `json:"sweep_tx_hash,omitempty" db:"sweep_tx_hash"`
+	SweepAttemptedAt *time.Time `json:"sweep_attempted_at,omitempty" db:"sweep_attempted_at"`
+	SweptAt          *time.Time `json:"swept_at,omitempty" db:"swept_at"`
+}
+
+// DepositIntentUpdate changes an intent's status. DetectedAt, PaidAt and
+// SweptAt are set on the first move to confirming, paid and swept; a
+// SweepTxHash also sets SweepAttemptedAt.
+type DepositIntentUpdate struct {
+	Status         DepositStatus
+	ReceivedAmount *string
+	SweepTxHash    *string
+}
+
+// DepositIntentFilter defines filtering options for listing intents
+type DepositIntentFilter struct {
+	PayerAddress string
+	Status       DepositStatus
+
+	// As for PaymentFilter, expired intents are left out unless asked for
+	IncludeExpired bool
+}
+
+// ExcludesExpired reports whether the filter leaves expired intents out
+func (f DepositIntentFilter) ExcludesExpired() bool {
+	return !f.IncludeExpired && f.Status == ""
+}
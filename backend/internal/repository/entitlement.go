@@ -0,0 +1,49 @@
+// Package repository defines the interfaces for data access
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// EntitlementRepository stores prepaid service credits: each address's
+// balance per service, topped up once per paying payment and spent later
+type EntitlementRepository interface {
+	// GrantCredits adds g.Quantity credits once per payment, reporting false
+	// when its payment was already granted
+	GrantCredits(ctx context.Context, g *EntitlementGrant) (bool, error)
+
+	// ConsumeCredits takes quantity credits in one step, failing with
+	// ErrInsufficientCredits (and taking none) when the balance is short
+	ConsumeCredits(ctx context.Context, address, serviceCode string, quantity int) (*Entitlement, error)
+
+	// GetEntitlement returns an address's credits for a service
+	GetEntitlement(ctx context.Context, address, serviceCode string) (*Entitlement, error)
+
+	// ListEntitlements lists an address's credits, by service code
+	ListEntitlements(ctx context.Context, address string) ([]*Entitlement, error)
+}
+
+// Entitlement is an address's credits for a service
+type Entitlement struct {
+	Address     string    `json:"address" db:"address"` // lowercased
+	ServiceCode string    `json:"service_code" db:"service_code"`
+	Granted     int       `json:"granted" db:"granted"` // ever granted
+	Balance     int       `json:"balance" db:"balance"` // still to spend
+	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// Consumed is how many of the granted credits were spent
+func (e *Entitlement) Consumed() int {
+	return e.Granted - e.Balance
+}
+
+// EntitlementGrant is the credits one payment bought
+type EntitlementGrant struct {
+	ID          string    `json:"id" db:"id"`
+	PaymentID   string    `json:"payment_id" db:"payment_id"`
+	Address     string    `json:"address" db:"address"`
+	ServiceCode string    `json:"service_code" db:"service_code"` // the service credited, not the pack bought
+	Quantity    int       `json:"quantity" db:"quantity"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+}
@@ -55,9 +55,84 @@ var (
 	ErrContractAlreadyDeployed  = errors.New("contract already deployed on this chain")
 	ErrInvalidChainID           = errors.New("invalid chain ID")
 
+	// Chain tracking errors
+	ErrBlockNotFound     = errors.New("block not found")
+	ErrTrackedTxNotFound = errors.New("tracked transaction not found")
+
+	// Request log errors
+	ErrRequestLogNotFound = errors.New("request log not found")
+
+	// Webhook event errors
+	ErrWebhookEventNotFound     = errors.New("webhook event not found")
+	ErrWebhookEventNotRetryable = errors.New("webhook event is not failed or dead-lettered")
+
+	// Gas billing errors
+	ErrGasSettlementNotFound = errors.New("gas settlement not found")
+	ErrGasSettlementExists   = errors.New("gas settlement already recorded for this transaction")
+	ErrGasSettlementClosed   = errors.New("gas settlement is already closed")
+
+	// Deposit errors
+	ErrDepositIntentNotFound = errors.New("deposit intent not found")
+
+	// Ledger errors
+	ErrLedgerTransactionNotFound = errors.New("ledger transaction not found")
+	ErrLedgerUnbalanced          = errors.New("ledger transaction is unbalanced")
+
+	// Payout errors
+	ErrPayoutNotFound = errors.New("payout not found")
+	ErrPayoutClosed   = errors.New("payout cannot move to that status")
+
+	// Snapshot errors
+	ErrSnapshotProposalNotFound = errors.New("snapshot proposal not found")
+
+	// Governance notification errors
+	ErrGovernanceSubscriptionNotFound = errors.New("governance subscription not found")
+
+	// Watchlist errors
+	ErrWatchlistNotFound = errors.New("watchlist not found")
+
+	// Fulfillment errors
+	ErrFulfillmentNotFound = errors.New("fulfillment not found")
+
+	// Entitlement errors
+	ErrEntitlementNotFound = errors.New("entitlement not found")
+	ErrInsufficientCredits = errors.New("insufficient credits")
+
+	// Partner errors
+	ErrPartnerNotFound = errors.New("partner not found")
+	ErrPartnerExists   = errors.New("partner referral code already exists")
+
+	// Safe transaction errors
+	ErrSafeTransactionNotFound = errors.New("safe transaction not found")
+	ErrSafeTransactionExists   = errors.New("safe transaction already proposed")
+	ErrSafeTransactionClosed   = errors.New("safe transaction cannot move to that status")
+	ErrSafeConfirmationExists  = errors.New("owner has already confirmed this safe transaction")
+
+	// Bridge errors
+	ErrBridgeTransferNotFound = errors.New("bridge transfer not found")
+	ErrBridgeTransferExists   = errors.New("bridge transfer already recorded")
+	ErrBridgeCursorNotFound   = errors.New("bridge cursor not found")
+
+	// Profile errors
+	ErrProfileNotFound = errors.New("profile not found")
+
+	// Report errors
+	ErrReportScheduleNotFound = errors.New("report schedule not found")
+	ErrReportRunNotFound      = errors.New("report run not found")
+
+	// Compliance case errors
+	ErrComplianceCaseNotFound = errors.New("compliance case not found")
+	ErrComplianceCaseClosed   = errors.New("compliance case is already closed")
+
+	// ABI artifact errors
+	ErrABIArtifactNotFound = errors.New("abi artifact not found")
+	ErrInvalidABI          = errors.New("invalid contract abi")
+	ErrABIVersionConflict  = errors.New("abi version already published with a different abi")
+
 	// General errors
 	ErrInvalidAddress      = errors.New("invalid ethereum address")
 	ErrUnauthorized        = errors.New("unauthorized operation")
 	ErrDatabaseError       = errors.New("database operation failed")
 	ErrInvalidInput        = errors.New("invalid input")
+	ErrVersionConflict     = errors.New("record was modified by another update")
 )
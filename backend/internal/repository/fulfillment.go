@@ -0,0 +1,54 @@
+// Package repository defines the interfaces for data access
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// FulfillmentRepository stores what each completed payment delivered: one
+// fulfillment per payment, recorded by the service's fulfiller
+type FulfillmentRepository interface {
+	// RecordFulfillment stores f once per payment, reporting false when its
+	// payment was already fulfilled
+	RecordFulfillment(ctx context.Context, f *Fulfillment) (bool, error)
+
+	// GetFulfillmentByPayment returns a payment's fulfillment
+	GetFulfillmentByPayment(ctx context.Context, paymentID string) (*Fulfillment, error)
+
+	// ListFulfillments lists fulfillments with filtering, newest first
+	ListFulfillments(ctx context.Context, filter FulfillmentFilter, page Pagination) ([]*Fulfillment, int64, error)
+}
+
+// FulfillmentKind is what a service delivers once paid for
+type FulfillmentKind string
+
+const (
+	FulfillmentKYC             FulfillmentKind = "kyc"              // a KYC verification to start
+	FulfillmentNFTMintCredit   FulfillmentKind = "nft_mint_credit"  // mints an address may make
+	FulfillmentAPISubscription FulfillmentKind = "api_subscription" // access until ValidUntil
+	FulfillmentCredits         FulfillmentKind = "credits"          // prepaid credits, see EntitlementRepository
+)
+
+// Fulfillment is what a completed payment delivered
+type Fulfillment struct {
+	ID          string          `json:"id" db:"id"`
+	PaymentID   string          `json:"payment_id" db:"payment_id"`
+	ServiceCode string          `json:"service_code" db:"service_code"`
+	Address     string          `json:"address" db:"address"` // the payer, lowercased
+	Kind        FulfillmentKind `json:"kind" db:"kind"`
+	Quantity    int             `json:"quantity" db:"quantity"`
+	ValidFrom   *time.Time      `json:"valid_from,omitempty" db:"valid_from"`   // subscriptions
+	ValidUntil  *time.Time      `json:"valid_until,omitempty" db:"valid_until"` // subscriptions
+	Reference   *string         `json:"reference,omitempty" db:"reference"`     // e.g. the KYC verification ID
+	CreatedAt   time.Time       `json:"created_at" db:"created_at"`
+}
+
+// FulfillmentFilter selects fulfillments. ActiveAt keeps those without a
+// validity period or valid at that time.
+type FulfillmentFilter struct {
+	Address     string
+	ServiceCode string
+	Kind        FulfillmentKind
+	ActiveAt    *time.Time
+}
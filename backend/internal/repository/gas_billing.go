@@ -0,0 +1,243 @@
+// Package repository defines the interfaces for data access
+package repository
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+)
+
+// GasBillingRepository defines the contract for sponsored-gas billing: a
+// ledger of relayed gas charged to users in NEXUS and the settlements paying
+// it off. Amounts are NEXUS base units (18 decimals) as decimal strings.
+type GasBillingRepository interface {
+	// CreateGasCharge records the charge for a meta-transaction and reports
+	// true. Each meta-transaction is charged once; charging it again leaves
+	// the stored charge unchanged and reports false.
+	CreateGasCharge(ctx context.Context, charge *GasCharge) (bool, error)
+	ListGasCharges(ctx context.Context, userAddress string, page Pagination) ([]*GasCharge, int64, error)
+
+	// CreateGasSettlement records a settlement. On-chain settlements are
+	// unique per tx hash (ErrGasSettlementExists).
+	CreateGasSettlement(ctx context.Context, s *GasSettlement) error
+	GetGasSettlement(ctx context.Context, id string) (*GasSettlement, error)
+	ListGasSettlements(ctx context.Context, filter GasSettlementFilter, page Pagination) ([]*GasSettlement, int64, error)
+
+	// UpdateGasSettlementStatus moves a settlement to another status if
+	// CanMoveTo allows it, and returns ErrGasSettlementClosed otherwise
+	UpdateGasSettlementStatus(ctx context.Context, id string, update *GasSettlementUpdate) error
+
+	// GetGasDebt totals a user's charges and settlements. Users never
+	// charged get a zero debt.
+	GetGasDebt(ctx context.Context, userAddress string) (*GasDebt, error)
+
+	// ListGasDebts lists users with outstanding debt, largest first
+	ListGasDebts(ctx context.Context, page Pagination) ([]*GasDebt, int64, error)
+}
+
+// GasCharge is the NEXUS charged to a user for one relayed meta-transaction
+type GasCharge struct {
+	ID            string    `json:"id" db:"id"`
+	MetaTxID      string    `json:"meta_tx_id" db:"meta_tx_id"`
+	UserAddress   string    `json:"user_address" db:"user_address"`
+	GasUsed       uint64    `json:"gas_used" db:"gas_used"`
+	GasPrice      string    `json:"gas_price" db:"gas_price"`         // effective gas price in wei
+	CostWei       string    `json:"cost_wei" db:"cost_wei"`           // gas used × gas price
+	NexusPerETH   string    `json:"nexus_per_eth" db:"nexus_per_eth"` // oracle rate applied, NEXUS base units per ETH
+	MarkupPercent int64     `json:"markup_percent" db:"markup_percent"`
+	AmountNexus   string    `json:"amount_nexus" db:"amount_nexus"`
+	CreatedAt     time.Time `json:"created_at" db:"created_at"`
+}
+
+// GasSettlementMethod is how a user pays off gas debt
+type GasSettlementMethod string
+
+const (
+	GasSettlementOnchain GasSettlementMethod = "onchain" // NEXUS transfer to the treasury, verified on confirmation
+	GasSettlementInvoice GasSettlementMethod = "invoice" // billed off-chain, marked settled by an admin
+)
+
+// GasSettlementStatus represents settlement states
+type GasSettlementStatus string
+
+const (
+	GasSettlementPending  GasSettlementStatus = "pending"  // on-chain transfer awaiting confirmation
+	GasSettlementInvoiced GasSettlementStatus = "invoiced" // invoice issued, unpaid
+	GasSettlementSettled  GasSettlementStatus = "settled"
+	GasSettlementFailed   GasSettlementStatus = "failed" // transfer reverted or did not pay the treasury
+	GasSettlementVoid     GasSettlementStatus = "void"   // invoice cancelled
+)
+
+// Open reports whether the settlement still counts as pending payment
+func (s GasSettlementStatus) Open() bool {
+	return s == GasSettlementPending || s == GasSettlementInvoiced
+}
+
+// GasSettlement is a payment against a user's gas debt
+type GasSettlement struct {
+	ID              string              `json:"id" db:"id"`
+	UserAddress     string              `json:"user_address" db:"user_address"`
+	Method          GasSettlementMethod `json:"method" db:"method"`
+	AmountNexus     string              `json:"amount_nexus" db:"amount_nexus"`
+	Status          GasSettlementStatus `json:"status" db:"status"`
+	TxHash          *string             `json:"tx_hash,omitempty" db:"tx_hash"`
+	TokenAddress    *string             `json:"token_address,omitempty" db:"token_address"`       // NEXUS token the transfer must use
+	TreasuryAddress *string             `json:"treasury_address,omitempty" db:"treasury_address"` // recipient the transfer must pay
+	InvoiceRef      *string             `json:"invoice_ref,omitempty" db:"invoice_ref"`
+	ErrorMessage    *string             `json:"error_message,omitempty" db:"error_message"`
+	CreatedBy       *string             `json:"created_by,omitempty" db:"created_by"`
+	CreatedAt       time.Time           `json:"created_at" db:"created_at"`
+	UpdatedAt       time.Time           `json:"updated_at" db:"updated_at"`
+	SettledAt       *time.Time          `json:"settled_at,omitempty" db:"settled_at"`
+}
+
+// CanMoveTo reports whether the settlement may move to status. Pending
+// on-chain transfers settle or fail on confirmation and invoices are settled
+// or voided by an admin; either may be voided while open. A confirmed
+// transfer returns to pending when its block is reorged out.
+func (s *GasSettlement) CanMoveTo(status GasSettlementStatus) bool {
+	switch s.Status {
+	case GasSettlementPending:
+		return status == GasSettlementSettled || status == GasSettlementFailed || status == GasSettlementVoid
+	case GasSettlementInvoiced:
+		return status == GasSettlementSettled || status == GasSettlementVoid
+	case GasSettlementSettled, GasSettlementFailed:
+		return s.Method == GasSettlementOnchain && status == GasSettlementPending
+	}
+	return false
+}
+
+// GasSettlementUpdate contains a settlement status change
+type GasSettlementUpdate struct {
+	Status       GasSettlementStatus
+	ErrorMessage *string
+}
+
+// GasSettlementFilter defines filtering options for listing settlements
+type GasSettlementFilter struct {
+	UserAddress string
+	Method      GasSettlementMethod
+	Status      GasSettlementStatus
+}
+
+// GasDebt is a user's gas billing balance. Outstanding is charged minus
+// settled; pending settlements are reported but not deducted until they
+// settle.
+type GasDebt struct {
+	UserAddress      string     `json:"user_address"`
+	ChargeCount      int64      `json:"charge_count"`
+	Charged          string     `json:"charged"`
+	Settled          string     `json:"settled"`
+	Pending          string     `json:"pending"`
+	Outstanding      string     `json:"outstanding"`
+	OutstandingNexus string     `json:"outstanding_nexus"` // Outstanding in whole NEXUS
+	LastChargedAt    *time.Time `json:"last_charged_at,omitempty"`
+}
+
+// OutstandingAmount parses Outstanding
+func (d *GasDebt) OutstandingAmount() *big.Int {
+	amount, ok := new(big.Int).SetString(d.Outstanding, 10)
+	if !ok {
+		return new(big.Int)
+	}
+	return amount
+}
+
+// GasDebtTally totals charges and settlements in Go for backends that cannot
+// sum 256-bit amounts exactly
+type GasDebtTally struct {
+	users map[string]*gasDebtTotals
+}
+
+type gasDebtTotals struct {
+	chargeCount   int64
+	charged       *big.Int
+	settled       *big.Int
+	pending       *big.Int
+	lastChargedAt *time.Time
+}
+
+// NewGasDebtTally creates an empty tally
+func NewGasDebtTally() *GasDebtTally {
+	return &GasDebtTally{users: make(map[string]*gasDebtTotals)}
+}
+
+func (t *GasDebtTally) totals(user string) *gasDebtTotals {
+	u, ok := t.users[user]
+	if !ok {
+		u = &gasDebtTotals{charged: new(big.Int), settled: new(big.Int), pending: new(big.Int)}
+		t.users[user] = u
+	}
+	return u
+}
+
+// AddCharge counts a charge
+func (t *GasDebtTally) AddCharge(user, amount string, chargedAt time.Time) error {
+	value, ok := new(big.Int).SetString(amount, 10)
+	if !ok {
+		return fmt.Errorf("invalid charge amount %q", amount)
+	}
+	u := t.totals(user)
+	u.chargeCount++
+	u.charged.Add(u.charged, value)
+	if u.lastChargedAt == nil || chargedAt.After(*u.lastChargedAt) {
+		at := chargedAt
+		u.lastChargedAt = &at
+	}
+	return nil
+}
+
+// AddSettlement counts a settlement; failed and void ones are ignored
+func (t *GasDebtTally) AddSettlement(user, amount string, status GasSettlementStatus) error {
+	value, ok := new(big.Int).SetString(amount, 10)
+	if !ok {
+		return fmt.Errorf("invalid settlement amount %q", amount)
+	}
+	switch {
+	case status == GasSettlementSettled:
+		u := t.totals(user)
+		u.settled.Add(u.settled, value)
+	case status.Open():
+		u := t.totals(user)
+		u.pending.Add(u.pending, value)
+	}
+	return nil
+}
+
+// Debt returns a user's debt (zero if the user was never counted)
+func (t *GasDebtTally) Debt(user string) *GasDebt {
+	u, ok := t.users[user]
+	if !ok {
+		u = &gasDebtTotals{charged: new(big.Int), settled: new(big.Int), pending: new(big.Int)}
+	}
+	outstanding := new(big.Int).Sub(u.charged, u.settled)
+	return &GasDebt{
+		UserAddress:      user,
+		ChargeCount:      u.chargeCount,
+		Charged:          u.charged.String(),
+		Settled:          u.settled.String(),
+		Pending:          u.pending.String(),
+		Outstanding:      outstanding.String(),
+		OutstandingNexus: FormatWeiETH(outstanding), // NEXUS has 18 decimals like ETH
+		LastChargedAt:    u.lastChargedAt,
+	}
+}
+
+// Outstanding returns the users with outstanding debt, largest first
+func (t *GasDebtTally) Outstanding() []*GasDebt {
+	var debts []*GasDebt
+	for user, u := range t.users {
+		if u.charged.Cmp(u.settled) > 0 {
+			debts = append(debts, t.Debt(user))
+		}
+	}
+	sort.Slice(debts, func(i, j int) bool {
+		if c := debts[i].OutstandingAmount().Cmp(debts[j].OutstandingAmount()); c != 0 {
+			return c > 0
+		}
+		return debts[i].UserAddress < debts[j].UserAddress
+	})
+	return debts
+}
@@ -0,0 +1,115 @@
+// Package repository defines the interfaces for data access
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// GovernanceNotificationRepository defines the contract for token holders'
+// governance notification subscriptions and the proposal lifecycle events
+// they are notified of
+type GovernanceNotificationRepository interface {
+	// UpsertGovernanceSubscription creates or replaces the subscription of
+	// s.Address. The digest schedule (LastDigestAt) is kept on replace.
+	UpsertGovernanceSubscription(ctx context.Context, s *GovernanceSubscription) error
+	GetGovernanceSubscription(ctx context.Context, address string) (*GovernanceSubscription, error)
+	DeleteGovernanceSubscription(ctx context.Context, address string) error
+	ListGovernanceSubscriptions(ctx context.Context, filter GovernanceSubscriptionFilter, page Pagination) ([]*GovernanceSubscription, int64, error)
+
+	// MarkGovernanceDigestSent records when a subscriber's digest was last
+	// sent
+	MarkGovernanceDigestSent(ctx context.Context, address string, at time.Time) error
+
+	// RecordGovernanceEvent records a lifecycle event once per proposal and
+	// event type, reporting false if it was already recorded
+	RecordGovernanceEvent(ctx context.Context, e *GovernanceEvent) (bool, error)
+
+	// ListGovernanceEvents lists events recorded after since, oldest first
+	ListGovernanceEvents(ctx context.Context, since time.Time, limit int) ([]*GovernanceEvent, error)
+}
+
+// GovernanceEventType is a proposal lifecycle event subscribers can be
+// notified of
+type GovernanceEventType string
+
+const (
+	GovernanceEventProposalOpened GovernanceEventType = "proposal_opened" // voting started
+	GovernanceEventNearQuorum     GovernanceEventType = "near_quorum"     // votes reached the near-quorum threshold
+	GovernanceEventProposalQueued GovernanceEventType = "proposal_queued" // passed and queued in the timelock
+)
+
+// Valid reports whether t is a known event type
+func (t GovernanceEventType) Valid() bool {
+	switch t {
+	case GovernanceEventProposalOpened, GovernanceEventNearQuorum, GovernanceEventProposalQueued:
+		return true
+	}
+	return false
+}
+
+// GovernanceDigest is how often a subscriber is notified
+type GovernanceDigest string
+
+const (
+	GovernanceDigestNone   GovernanceDigest = "none"   // each event as it happens
+	GovernanceDigestDaily  GovernanceDigest = "daily"  // one notification a day listing the day's events
+	GovernanceDigestWeekly GovernanceDigest = "weekly" // one notification a week
+)
+
+// Period returns how often the digest is sent (0 for GovernanceDigestNone)
+func (d GovernanceDigest) Period() time.Duration {
+	switch d {
+	case GovernanceDigestDaily:
+		return 24 * time.Hour
+	case GovernanceDigestWeekly:
+		return 7 * 24 * time.Hour
+	}
+	return 0
+}
+
+// Valid reports whether d is a known digest option
+func (d GovernanceDigest) Valid() bool {
+	return d == GovernanceDigestNone || d.Period() > 0
+}
+
+// GovernanceSubscription is a token holder's notification preferences.
+// Notifications are POSTed to WebhookURL signed with Secret, like payment
+// callbacks.
+type GovernanceSubscription struct {
+	Address      string           `json:"address" db:"address"`
+	WebhookURL   string           `json:"webhook_url" db:"webhook_url"`
+	Secret       string           `json:"-" db:"secret"`
+	Events       []string         `json:"events" db:"events"` // GovernanceEventType values
+	Digest       GovernanceDigest `json:"digest" db:"digest"`
+	LastDigestAt *time.Time       `json:"last_digest_at,omitempty" db:"last_digest_at"`
+	CreatedAt    time.Time        `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time        `json:"updated_at" db:"updated_at"`
+}
+
+// Wants reports whether the subscriber asked for events of type t
+func (s *GovernanceSubscription) Wants(t GovernanceEventType) bool {
+	for _, e := range s.Events {
+		if GovernanceEventType(e) == t {
+			return true
+		}
+	}
+	return false
+}
+
+// GovernanceSubscriptionFilter defines filtering options for listing
+// subscriptions
+type GovernanceSubscriptionFilter struct {
+	Event  GovernanceEventType // subscribed to this event type
+	Digest GovernanceDigest
+}
+
+// GovernanceEvent is a recorded proposal lifecycle event
+type GovernanceEvent struct {
+	ID         string              `json:"id" db:"id"`
+	ProposalID string              `json:"proposal_id" db:"proposal_id"`
+	EventType  GovernanceEventType `json:"event_type" db:"event_type"`
+	Title      string              `json:"title" db:"title"`
+	Detail     string              `json:"detail" db:"detail"`
+	OccurredAt time.Time           `json:"occurred_at" db:"occurred_at"`
+}
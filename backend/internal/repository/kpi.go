@@ -0,0 +1,54 @@
+// Package repository defines the interfaces for data access
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// KPIRepository defines the contract for business KPI aggregates exported to
+// ops dashboards
+type KPIRepository interface {
+	// GetBusinessKPIs returns current record counts plus activity since the
+	// given time (approvals, relay confirmations, NFT mints)
+	GetBusinessKPIs(ctx context.Context, since time.Time) (*BusinessKPIs, error)
+}
+
+// BusinessKPIs holds business-level aggregates
+type BusinessKPIs struct {
+	// Live record counts by status
+	PaymentsByStatus map[PaymentStatus]int64         `json:"payments_by_status"`
+	KYCByStatus      map[KYCVerificationStatus]int64 `json:"kyc_by_status"`
+
+	// KYC decisions made since the window start
+	KYCApproved int64 `json:"kyc_approved"`
+	KYCRejected int64 `json:"kyc_rejected"`
+
+	// Meta-transactions confirmed since the window start and the total time
+	// from request to confirmation across them
+	RelayConfirmed           int64   `json:"relay_confirmed"`
+	RelayConfirmationSeconds float64 `json:"relay_confirmation_seconds"`
+
+	// NFTs minted since the window start (nil when the backend does not
+	// store NFTs)
+	NFTMints *int64 `json:"nft_mints,omitempty"`
+}
+
+// KYCApprovalRate returns approved / (approved + rejected), or 0 when no
+// decisions were made
+func (k *BusinessKPIs) KYCApprovalRate() float64 {
+	decided := k.KYCApproved + k.KYCRejected
+	if decided == 0 {
+		return 0
+	}
+	return float64(k.KYCApproved) / float64(decided)
+}
+
+// AvgRelayConfirmationSeconds returns the mean request-to-confirmation time,
+// or 0 when nothing was confirmed
+func (k *BusinessKPIs) AvgRelayConfirmationSeconds() float64 {
+	if k.RelayConfirmed == 0 {
+		return 0
+	}
+	return k.RelayConfirmationSeconds / float64(k.RelayConfirmed)
+}
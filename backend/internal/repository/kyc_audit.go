@@ -0,0 +1,63 @@
+// Package repository defines the interfaces for data access
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// KYCAuditRepository defines the contract for the compliance audit log. It is
+// append-only and read newest first by keyset: each page starts strictly
+// after the (timestamp, id) of the last entry of the page before.
+type KYCAuditRepository interface {
+	// AppendAuditEntry stores e, assigning its ID (and Timestamp when zero)
+	AppendAuditEntry(ctx context.Context, e *KYCAuditEntry) error
+
+	// ListAuditEntries returns up to limit entries matching filter, newest first
+	ListAuditEntries(ctx context.Context, filter KYCAuditFilter, limit int) ([]*KYCAuditEntry, error)
+	CountAuditEntries(ctx context.Context, filter KYCAuditFilter) (int64, error)
+}
+
+// KYCAuditEntry represents a compliance audit log entry
+type KYCAuditEntry struct {
+	ID            string    `json:"id" db:"id"`
+	Timestamp     time.Time `json:"timestamp" db:"timestamp"`
+	Action        string    `json:"action" db:"action"`
+	Actor         string    `json:"actor" db:"actor"`
+	Subject       string    `json:"subject" db:"subject"`
+	Details       string    `json:"details" db:"details"`
+	IPAddress     string    `json:"ip_address,omitempty" db:"ip_address"`
+	Country       string    `json:"country,omitempty" db:"country"`
+	PreviousState string    `json:"previous_state,omitempty" db:"previous_state"`
+	NewState      string    `json:"new_state,omitempty" db:"new_state"`
+}
+
+// KYCAuditCursor is the keyset position of an audit entry
+type KYCAuditCursor struct {
+	Timestamp time.Time
+	ID        string
+}
+
+// KYCAuditFilter defines filtering options for listing audit entries
+type KYCAuditFilter struct {
+	Subject string          // exact, lowercase address
+	After   *KYCAuditCursor // only entries older than this position (the previous page's last)
+}
+
+// Cursor returns the keyset position of e
+func (e *KYCAuditEntry) Cursor() KYCAuditCursor {
+	return KYCAuditCursor{Timestamp: e.Timestamp, ID: e.ID}
+}
+
+// Precedes reports whether c comes before e newest first, i.e. e is older
+// (or as old with a lower ID) and belongs on a later page
+func (c KYCAuditCursor) Precedes(e *KYCAuditEntry) bool {
+	return e.Timestamp.Before(c.Timestamp) || (e.Timestamp.Equal(c.Timestamp) && e.ID < c.ID)
+}
+
+// KYCAuditCursorAt returns the position of time t, so that listing after it
+// starts with the newest entries at or before t
+func KYCAuditCursorAt(t time.Time) KYCAuditCursor {
+	// Sorts after every entry ID (a UUID) at t
+	return KYCAuditCursor{Timestamp: t, ID: "ffffffff-ffff-ffff-ffff-ffffffffffff"}
+}
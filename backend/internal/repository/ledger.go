@@ -0,0 +1,268 @@
+// Package repository defines the interfaces for data access
+package repository
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+)
+
+// LedgerRepository defines the contract for the double-entry accounting
+// ledger. Transactions are append-only: once posted they are never updated
+// or deleted, and a mistake is corrected by posting a reversing transaction.
+// Amounts are base units of the currency (cents for USD, 18 decimals for ETH
+// and NEXUS) as decimal strings.
+type LedgerRepository interface {
+	// PostLedgerTransaction validates tx, writes it with its entries and
+	// reports true. Transactions are unique per idempotency key; posting the
+	// same key again leaves the stored transaction unchanged and reports
+	// false. Entries whose debits and credits differ in any currency are
+	// refused with ErrLedgerUnbalanced.
+	PostLedgerTransaction(ctx context.Context, tx *LedgerTransaction) (bool, error)
+	GetLedgerTransaction(ctx context.Context, id string) (*LedgerTransaction, error)
+
+	// ListLedgerEntries lists entries, oldest first so an export reads in
+	// posting order
+	ListLedgerEntries(ctx context.Context, filter LedgerEntryFilter, page Pagination) ([]*LedgerEntry, int64, error)
+
+	// GetLedgerBalances totals entries per account and currency, ordered by
+	// account then currency. Accounts without entries are left out.
+	GetLedgerBalances(ctx context.Context, filter LedgerBalanceFilter) ([]*LedgerBalance, error)
+}
+
+// LedgerAccount is an account in the chart of accounts
+type LedgerAccount string
+
+const (
+	LedgerCustomerFunds   LedgerAccount = "customer_funds"   // asset: payments received and held
+	LedgerDisputedFunds   LedgerAccount = "disputed_funds"   // asset: funds withheld by an open dispute
+	LedgerRevenue         LedgerAccount = "revenue"          // revenue: service price earned
+	LedgerFees            LedgerAccount = "fees"             // revenue: payment method fee charged on top of the price
+	LedgerRefunds         LedgerAccount = "refunds"          // contra-revenue: money returned to customers
+	LedgerProviderCosts   LedgerAccount = "provider_costs"   // expense: cost of the service provider (e.g. Sumsub)
+	LedgerProviderPayable LedgerAccount = "provider_payable" // liability: provider costs owed
+)
+
+// LedgerAccounts lists every account in the chart of accounts
+var LedgerAccounts = []LedgerAccount{
+	LedgerCustomerFunds,
+	LedgerDisputedFunds,
+	LedgerRevenue,
+	LedgerFees,
+	LedgerRefunds,
+	LedgerProviderCosts,
+	LedgerProviderPayable,
+}
+
+// Valid reports whether a is in the chart of accounts
+func (a LedgerAccount) Valid() bool {
+	for _, known := range LedgerAccounts {
+		if a == known {
+			return true
+		}
+	}
+	return false
+}
+
+// DebitNormal reports whether debits increase the account's balance (assets,
+// expenses and contra-revenue); credits increase the others
+func (a LedgerAccount) DebitNormal() bool {
+	switch a {
+	case LedgerCustomerFunds, LedgerDisputedFunds, LedgerRefunds, LedgerProviderCosts:
+		return true
+	}
+	return false
+}
+
+// LedgerDirection is the side of an entry
+type LedgerDirection string
+
+const (
+	LedgerDebit  LedgerDirection = "debit"
+	LedgerCredit LedgerDirection = "credit"
+)
+
+// Ledger transaction event types (the payment lifecycle event posted)
+const (
+	LedgerEventSale            = "payment.sale"             // payment completed
+	LedgerEventSaleReversed    = "payment.sale_reversed"    // completed payment rolled back (e.g. chain reorg)
+	LedgerEventDisputeOpened   = "payment.dispute_opened"   // funds withheld by a dispute
+	LedgerEventDisputeReleased = "payment.dispute_released" // dispute won, funds returned
+	LedgerEventRefund          = "payment.refund"           // payment refunded, or dispute lost
+)
+
+// LedgerTransaction is a balanced set of entries posted together
+type LedgerTransaction struct {
+	ID             string         `json:"id" db:"id"`
+	IdempotencyKey string         `json:"idempotency_key" db:"idempotency_key"`
+	EventType      string         `json:"event_type" db:"event_type"`
+	PaymentID      *string        `json:"payment_id,omitempty" db:"payment_id"`
+	Description    string         `json:"description" db:"description"`
+	CreatedAt      time.Time      `json:"created_at" db:"created_at"`
+	Entries        []*LedgerEntry `json:"entries"`
+}
+
+// Validate checks tx before it is posted: at least two entries, each on a
+// known account with a positive amount, and debits equal to credits in
+// every currency
+func (tx *LedgerTransaction) Validate() error {
+	if tx.IdempotencyKey == "" || tx.EventType == "" {
+		return fmt.Errorf("%w: ledger transaction needs an idempotency key and event type", ErrInvalidInput)
+	}
+	if len(tx.Entries) < 2 {
+		return fmt.Errorf("%w: ledger transaction needs at least two entries", ErrLedgerUnbalanced)
+	}
+
+	net := make(map[string]*big.Int)
+	for _, e := range tx.Entries {
+		if !e.Account.Valid() {
+			return fmt.Errorf("%w: unknown ledger account %q", ErrInvalidInput, e.Account)
+		}
+		if e.Currency == "" {
+			return fmt.Errorf("%w: ledger entry on %s has no currency", ErrInvalidInput, e.Account)
+		}
+		amount, ok := new(big.Int).SetString(e.Amount, 10)
+		if !ok || amount.Sign() <= 0 {
+			return fmt.Errorf("%w: ledger entry amount %q is not a positive integer", ErrInvalidInput, e.Amount)
+		}
+		sum, ok := net[e.Currency]
+		if !ok {
+			sum = new(big.Int)
+			net[e.Currency] = sum
+		}
+		switch e.Direction {
+		case LedgerDebit:
+			sum.Add(sum, amount)
+		case LedgerCredit:
+			sum.Sub(sum, amount)
+		default:
+			return fmt.Errorf("%w: unknown ledger direction %q", ErrInvalidInput, e.Direction)
+		}
+	}
+	for currency, sum := range net {
+		if sum.Sign() != 0 {
+			return fmt.Errorf("%w: %s debits and credits differ by %s", ErrLedgerUnbalanced, currency, sum.String())
+		}
+	}
+	return nil
+}
+
+// LedgerEntry is one debit or credit. The transaction's event type and
+// payment are repeated on each entry read back.
+type LedgerEntry struct {
+	ID            string          `json:"id" db:"id"`
+	TransactionID string          `json:"transaction_id" db:"transaction_id"`
+	Account       LedgerAccount   `json:"account" db:"account"`
+	Direction     LedgerDirection `json:"direction" db:"direction"`
+	Amount        string          `json:"amount" db:"amount"`
+	Currency      string          `json:"currency" db:"currency"`
+	EventType     string          `json:"event_type" db:"event_type"`
+	PaymentID     *string         `json:"payment_id,omitempty" db:"payment_id"`
+	CreatedAt     time.Time       `json:"created_at" db:"created_at"`
+}
+
+// LedgerEntryFilter defines filtering options for listing entries. From and
+// To bound the posting time (To is exclusive).
+type LedgerEntryFilter struct {
+	Account   LedgerAccount
+	Currency  string
+	PaymentID string
+	EventType string
+	From      *time.Time
+	To        *time.Time
+}
+
+// LedgerBalanceFilter bounds a balance query. To (exclusive) gives the
+// balances as they stood at that time.
+type LedgerBalanceFilter struct {
+	Currency string
+	To       *time.Time
+}
+
+// LedgerBalance is an account's total in one currency. Balance is on the
+// account's normal side: debits minus credits for debit-normal accounts,
+// credits minus debits otherwise.
+type LedgerBalance struct {
+	Account    LedgerAccount `json:"account"`
+	Currency   string        `json:"currency"`
+	EntryCount int64         `json:"entry_count"`
+	Debits     string        `json:"debits"`
+	Credits    string        `json:"credits"`
+	Balance    string        `json:"balance"`
+}
+
+// NewLedgerBalance builds a balance from debit and credit totals
+func NewLedgerBalance(account LedgerAccount, currency string, entryCount int64, debits, credits *big.Int) *LedgerBalance {
+	balance := new(big.Int).Sub(credits, debits)
+	if account.DebitNormal() {
+		balance.Neg(balance)
+	}
+	return &LedgerBalance{
+		Account:    account,
+		Currency:   currency,
+		EntryCount: entryCount,
+		Debits:     debits.String(),
+		Credits:    credits.String(),
+		Balance:    balance.String(),
+	}
+}
+
+// LedgerTally totals entries in Go for backends that cannot sum 256-bit
+// amounts exactly
+type LedgerTally struct {
+	totals map[ledgerTallyKey]*ledgerTotals
+}
+
+type ledgerTallyKey struct {
+	account  LedgerAccount
+	currency string
+}
+
+type ledgerTotals struct {
+	count   int64
+	debits  *big.Int
+	credits *big.Int
+}
+
+// NewLedgerTally creates an empty tally
+func NewLedgerTally() *LedgerTally {
+	return &LedgerTally{totals: make(map[ledgerTallyKey]*ledgerTotals)}
+}
+
+// Add counts an entry
+func (t *LedgerTally) Add(account LedgerAccount, currency string, direction LedgerDirection, amount string) error {
+	value, ok := new(big.Int).SetString(amount, 10)
+	if !ok {
+		return fmt.Errorf("invalid ledger amount %q", amount)
+	}
+	key := ledgerTallyKey{account: account, currency: currency}
+	totals, ok := t.totals[key]
+	if !ok {
+		totals = &ledgerTotals{debits: new(big.Int), credits: new(big.Int)}
+		t.totals[key] = totals
+	}
+	totals.count++
+	if direction == LedgerDebit {
+		totals.debits.Add(totals.debits, value)
+	} else {
+		totals.credits.Add(totals.credits, value)
+	}
+	return nil
+}
+
+// Balances returns the totals ordered by account then currency
+func (t *LedgerTally) Balances() []*LedgerBalance {
+	balances := make([]*LedgerBalance, 0, len(t.totals))
+	for key, totals := range t.totals {
+		balances = append(balances, NewLedgerBalance(key.account, key.currency, totals.count, totals.debits, totals.credits))
+	}
+	sort.Slice(balances, func(i, j int) bool {
+		if balances[i].Account != balances[j].Account {
+			return balances[i].Account < balances[j].Account
+		}
+		return balances[i].Currency < balances[j].Currency
+	})
+	return balances
+}
@@ -0,0 +1,98 @@
+// Package repository defines the interfaces for data access
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// PartnerRepository stores referral partners and the revenue share their
+// referred payments earn
+type PartnerRepository interface {
+	// Partners
+	CreatePartner(ctx context.Context, p *Partner) error
+	GetPartner(ctx context.Context, id string) (*Partner, error)
+	GetPartnerByCode(ctx context.Context, referralCode string) (*Partner, error)
+	GetPartnerByKeyHash(ctx context.Context, keyHash string) (*Partner, error)
+	ListPartners(ctx context.Context, page Pagination) ([]*Partner, int64, error)
+	UpdatePartner(ctx context.Context, id string, update *PartnerUpdate) error
+
+	// RecordCommission stores c once per payment, reporting false when its
+	// payment was already attributed
+	RecordCommission(ctx context.Context, c *PartnerCommission) (bool, error)
+
+	// ReverseCommission marks a payment's commission reversed, reporting
+	// false when it has none or it was already reversed
+	ReverseCommission(ctx context.Context, paymentID string) (bool, error)
+
+	// ListCommissions lists commissions with filtering, newest first
+	ListCommissions(ctx context.Context, filter PartnerCommissionFilter, page Pagination) ([]*PartnerCommission, int64, error)
+
+	// GetCommissionTotals totals the commissions matching filter
+	GetCommissionTotals(ctx context.Context, filter PartnerCommissionFilter) (*PartnerCommissionTotals, error)
+}
+
+// Partner is a referral partner. The API key authenticating its statement
+// requests is stored as a SHA-256 hash.
+type Partner struct {
+	ID           string    `json:"id" db:"id"`
+	Name         string    `json:"name" db:"name"`
+	ReferralCode string    `json:"referral_code" db:"referral_code"` // lowercased
+	SharePercent float64   `json:"share_percent" db:"share_percent"` // of each referred payment's USD amount
+	APIKeyHash   string    `json:"-" db:"api_key_hash"`
+	ContactEmail *string   `json:"contact_email,omitempty" db:"contact_email"`
+	IsActive     bool      `json:"is_active" db:"is_active"`
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// PartnerUpdate represents fields that can be updated; APIKeyHash rotates
+// the partner's API key
+type PartnerUpdate struct {
+	Name         *string  `json:"name,omitempty"`
+	SharePercent *float64 `json:"share_percent,omitempty"`
+	ContactEmail *string  `json:"contact_email,omitempty"`
+	IsActive     *bool    `json:"is_active,omitempty"`
+	APIKeyHash   *string  `json:"-"`
+}
+
+// PartnerCommissionStatus is the state of a commission
+type PartnerCommissionStatus string
+
+const (
+	PartnerCommissionEarned   PartnerCommissionStatus = "earned"
+	PartnerCommissionReversed PartnerCommissionStatus = "reversed" // the payment was refunded or lost a dispute
+)
+
+// PartnerCommission is the share a partner earned on a referred payment.
+// SharePercent is the partner's share when the payment completed, so later
+// changes to it do not rewrite past statements.
+type PartnerCommission struct {
+	ID            string                  `json:"id" db:"id"`
+	PartnerID     string                  `json:"partner_id" db:"partner_id"`
+	PaymentID     string                  `json:"payment_id" db:"payment_id"`
+	ServiceCode   string                  `json:"service_code" db:"service_code"`
+	AmountUSD     float64                 `json:"amount_usd" db:"amount_usd"`
+	SharePercent  float64                 `json:"share_percent" db:"share_percent"`
+	CommissionUSD float64                 `json:"commission_usd" db:"commission_usd"`
+	Status        PartnerCommissionStatus `json:"status" db:"status"`
+	EarnedAt      time.Time               `json:"earned_at" db:"earned_at"`
+	ReversedAt    *time.Time              `json:"reversed_at,omitempty" db:"reversed_at"`
+}
+
+// PartnerCommissionFilter selects commissions earned in [From, To)
+type PartnerCommissionFilter struct {
+	PartnerID string
+	From      *time.Time
+	To        *time.Time
+}
+
+// PartnerCommissionTotals sums commissions: EarnedUSD every one recorded,
+// ReversedUSD those refunds and lost disputes took back, NetUSD the rest
+type PartnerCommissionTotals struct {
+	Payments    int64   `json:"payments"`
+	AmountUSD   float64 `json:"amount_usd"`
+	EarnedUSD   float64 `json:"earned_usd"`
+	ReversedUSD float64 `json:"reversed_usd"`
+	NetUSD      float64 `json:"net_usd"`
+}
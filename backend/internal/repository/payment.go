@@ -12,9 +12,18 @@ type PaymentRepository interface {
 	CreatePayment(ctx context.Context, payment *Payment) error
 	GetPayment(ctx context.Context, id string) (*Payment, error)
 	GetPaymentByStripeSession(ctx context.Context, sessionID string) (*Payment, error)
+	GetPaymentByStripePaymentID(ctx context.Context, paymentIntentID string) (*Payment, error)
 	UpdatePaymentStatus(ctx context.Context, id string, status PaymentStatus, details *PaymentStatusUpdate) error
 	ListPayments(ctx context.Context, filter PaymentFilter, page Pagination) ([]*Payment, int64, error)
 
+	// Archival (settled payments older than the retention window move to payments_archive)
+	ArchivePayments(ctx context.Context, before time.Time, limit int) (int64, error)
+	GetArchivedPayment(ctx context.Context, id string) (*Payment, error)
+	ListArchivedPayments(ctx context.Context, filter PaymentFilter, page Pagination) ([]*Payment, int64, error)
+
+	// Expiry (pending payments left unpaid past their window lapse to expired)
+	ExpirePayments(ctx context.Context, method string, before time.Time, limit int) ([]string, error)
+
 	// KYC Verification
 	CreateKYCVerification(ctx context.Context, verification *KYCVerification) error
 	GetKYCVerification(ctx context.Context, id string) (*KYCVerification, error)
@@ -34,6 +43,8 @@ const (
 	PaymentStatusFailed     PaymentStatus = "failed"
 	PaymentStatusRefunded   PaymentStatus = "refunded"
 	PaymentStatusCancelled  PaymentStatus = "cancelled"
+	PaymentStatusExpired    PaymentStatus = "expired"
+	PaymentStatusDisputed   PaymentStatus = "disputed"
 )
 
 // Payment represents a payment transaction
@@ -54,6 +65,27 @@ type Payment struct {
 	CreatedAt       time.Time     `json:"created_at" db:"created_at"`
 	UpdatedAt       time.Time     `json:"updated_at" db:"updated_at"`
 	CompletedAt     *time.Time    `json:"completed_at,omitempty" db:"completed_at"`
+	ArchivedAt      *time.Time    `json:"archived_at,omitempty" db:"archived_at"` // set only on archived payments
+
+	// CallbackURL receives signed status notifications for the payment;
+	// CallbackSecret is the dapp's signing key and is never returned
+	CallbackURL    *string `json:"callback_url,omitempty" db:"callback_url"`
+	CallbackSecret *string `json:"-" db:"callback_secret"`
+
+	// Set once the card holder disputes the charge; DisputeStatus follows
+	// Stripe's dispute status (needs_response, under_review, won, lost, ...)
+	DisputeID     *string    `json:"dispute_id,omitempty" db:"dispute_id"`
+	DisputeStatus *string    `json:"dispute_status,omitempty" db:"dispute_status"`
+	DisputeReason *string    `json:"dispute_reason,omitempty" db:"dispute_reason"`
+	DisputedAt    *time.Time `json:"disputed_at,omitempty" db:"disputed_at"`
+
+	// Country is the ISO 3166-1 alpha-2 code the payer's IP resolved to
+	// when the payment was created, if GeoIP is configured
+	Country *string `json:"country,omitempty" db:"country"`
+
+	// ReferrerCode is the partner referral code given at checkout; completed
+	// payments with a known code earn the partner its revenue share
+	ReferrerCode *string `json:"referrer_code,omitempty" db:"referrer_code"`
 }
 
 // PaymentStatusUpdate contains update details for payment status
@@ -61,6 +93,11 @@ type PaymentStatusUpdate struct {
 	TxHash          *string `json:"tx_hash,omitempty"`
 	StripePaymentID *string `json:"stripe_payment_id,omitempty"`
 	ErrorMessage    *string `json:"error_message,omitempty"`
+
+	// DisputeID also stamps disputed_at the first time it is set
+	DisputeID     *string `json:"dispute_id,omitempty"`
+	DisputeStatus *string `json:"dispute_status,omitempty"`
+	DisputeReason *string `json:"dispute_reason,omitempty"`
 }
 
 // PaymentFilter defines filtering options for listing payments
@@ -69,6 +106,19 @@ type PaymentFilter struct {
 	ServiceCode   string
 	PaymentMethod string
 	Status        PaymentStatus
+
+	// Expired payments are left out unless IncludeExpired is set or Status
+	// asks for them
+	IncludeExpired bool
+
+	// Disputed keeps only payments that have been disputed, whatever the
+	// dispute's outcome
+	Disputed bool
+}
+
+// ExcludesExpired reports whether the filter leaves expired payments out
+func (f PaymentFilter) ExcludesExpired() bool {
+	return !f.IncludeExpired && f.Status == ""
 }
 
 // Pagination defines pagination parameters
@@ -88,6 +138,7 @@ const (
 	KYCStatusApproved        KYCVerificationStatus = "approved"
 	KYCStatusRejected        KYCVerificationStatus = "rejected"
 	KYCStatusExpired         KYCVerificationStatus = "expired"
+	KYCStatusSuspended       KYCVerificationStatus = "suspended" // the payment behind it is disputed
 )
 
 // KYCVerification represents a KYC verification request
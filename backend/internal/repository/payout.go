@@ -0,0 +1,118 @@
+// Package repository defines the interfaces for data access
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// PayoutRepository defines the contract for outbound transfers paid by the
+// protocol: NFT royalties to the royalty receiver and grants approved by
+// governance. Amounts are base units of the currency (18 decimals for ETH and
+// NEXUS) as decimal strings.
+type PayoutRepository interface {
+	CreatePayout(ctx context.Context, p *Payout) error
+	GetPayout(ctx context.Context, id string) (*Payout, error)
+	ListPayouts(ctx context.Context, filter PayoutFilter, page Pagination) ([]*Payout, int64, error)
+
+	// ListDuePayouts lists approved payouts scheduled at or before now,
+	// earliest first
+	ListDuePayouts(ctx context.Context, now time.Time, limit int) ([]*Payout, error)
+
+	// UpdatePayoutStatus moves a payout to another status if CanMoveTo
+	// allows it, and returns ErrPayoutClosed otherwise
+	UpdatePayoutStatus(ctx context.Context, id string, update *PayoutUpdate) error
+}
+
+// PayoutKind is what a payout pays for
+type PayoutKind string
+
+const (
+	PayoutRoyalty PayoutKind = "royalty" // NFT royalties to the royalty receiver
+	PayoutGrant   PayoutKind = "grant"   // grant approved by a governance proposal
+)
+
+// PayoutStatus represents payout states
+type PayoutStatus string
+
+const (
+	PayoutPendingApproval PayoutStatus = "pending_approval"
+	PayoutApproved        PayoutStatus = "approved"  // waiting for its scheduled time
+	PayoutRejected        PayoutStatus = "rejected"  // refused by the reviewer
+	PayoutCancelled       PayoutStatus = "cancelled" // withdrawn before it was sent
+	PayoutSending         PayoutStatus = "sending"   // claimed by the executor, transaction being sent
+	PayoutSubmitted       PayoutStatus = "submitted" // sent, awaiting confirmation
+	PayoutConfirmed       PayoutStatus = "confirmed" // receipt reconciled at confirmation depth
+	PayoutFailed          PayoutStatus = "failed"    // not sent, reverted or the receipt did not match
+)
+
+// Payout is an outbound transfer from a relayer key
+type Payout struct {
+	ID            string       `json:"id" db:"id"`
+	Kind          PayoutKind   `json:"kind" db:"kind"`
+	Recipient     string       `json:"recipient" db:"recipient"`
+	Currency      string       `json:"currency" db:"currency"`
+	TokenAddress  *string      `json:"token_address,omitempty" db:"token_address"` // NEXUS token; unset for ETH
+	Amount        string       `json:"amount" db:"amount"`
+	Reference     *string      `json:"reference,omitempty" db:"reference"` // governance proposal ID for grants
+	Description   string       `json:"description" db:"description"`
+	Status        PayoutStatus `json:"status" db:"status"`
+	ScheduledFor  time.Time    `json:"scheduled_for" db:"scheduled_for"`
+	CreatedBy     string       `json:"created_by" db:"created_by"`
+	ReviewedBy    *string      `json:"reviewed_by,omitempty" db:"reviewed_by"` // approver or rejecter
+	ReviewedAt    *time.Time   `json:"reviewed_at,omitempty" db:"reviewed_at"`
+	SenderAddress *string      `json:"sender_address,omitempty" db:"sender_address"` // relayer key that sent it
+	TxHash        *string      `json:"tx_hash,omitempty" db:"tx_hash"`
+	BlockNumber   *uint64      `json:"block_number,omitempty" db:"block_number"`
+	GasUsed       *uint64      `json:"gas_used,omitempty" db:"gas_used"`
+	FeeWei        *string      `json:"fee_wei,omitempty" db:"fee_wei"` // gas used × effective gas price
+	ErrorMessage  *string      `json:"error_message,omitempty" db:"error_message"`
+	CreatedAt     time.Time    `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time    `json:"updated_at" db:"updated_at"`
+	SubmittedAt   *time.Time   `json:"submitted_at,omitempty" db:"submitted_at"`
+	ConfirmedAt   *time.Time   `json:"confirmed_at,omitempty" db:"confirmed_at"`
+}
+
+// CanMoveTo reports whether the payout may move to status. A payout is
+// approved, rejected or cancelled by an admin, and an approved one may still
+// be cancelled until the executor claims it. A claimed payout is submitted
+// once its transaction is sent (or fails to send), then confirmed or failed
+// by its receipt. A sent transfer returns to submitted when its block is
+// reorged out.
+func (p *Payout) CanMoveTo(status PayoutStatus) bool {
+	switch p.Status {
+	case PayoutPendingApproval:
+		return status == PayoutApproved || status == PayoutRejected || status == PayoutCancelled
+	case PayoutApproved:
+		return status == PayoutSending || status == PayoutCancelled
+	case PayoutSending:
+		return status == PayoutSubmitted || status == PayoutFailed
+	case PayoutSubmitted:
+		return status == PayoutConfirmed || status == PayoutFailed
+	case PayoutConfirmed, PayoutFailed:
+		return p.TxHash != nil && status == PayoutSubmitted
+	}
+	return false
+}
+
+// PayoutUpdate contains a payout status change. ReviewedBy, SenderAddress
+// and TxHash are kept when unset; the receipt fields and ErrorMessage are
+// replaced, so a reorged payout loses its block.
+type PayoutUpdate struct {
+	Status        PayoutStatus
+	ReviewedBy    *string
+	SenderAddress *string
+	TxHash        *string
+	BlockNumber   *uint64
+	GasUsed       *uint64
+	FeeWei        *string
+	ErrorMessage  *string
+}
+
+// PayoutFilter defines filtering options for listing payouts
+type PayoutFilter struct {
+	Kind      PayoutKind
+	Status    PayoutStatus
+	Recipient string
+	Reference string
+}
@@ -38,6 +38,7 @@ type Pricing struct {
 	CreatedAt     time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt     time.Time `json:"updated_at" db:"updated_at"`
 	UpdatedBy     string   `json:"updated_by,omitempty" db:"updated_by"`
+	Version       int64    `json:"version" db:"version"` // incremented by every update
 }
 
 // PricingUpdate represents fields that can be updated
@@ -48,6 +49,10 @@ type PricingUpdate struct {
 	MarkupPercent *float64 `json:"markup_percent,omitempty"`
 	IsActive      *bool    `json:"is_active,omitempty"`
 	UpdatedBy     string   `json:"updated_by"`
+
+	// ExpectedVersion, if set, makes the update fail with
+	// ErrVersionConflict unless the record is still at that version
+	ExpectedVersion *int64 `json:"expected_version,omitempty"`
 }
 
 // PaymentMethod represents a payment method configuration
@@ -63,6 +68,7 @@ type PaymentMethod struct {
 	DisplayOrder    int       `json:"display_order" db:"display_order"`
 	CreatedAt       time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt       time.Time `json:"updated_at" db:"updated_at"`
+	Version         int64     `json:"version" db:"version"` // incremented by every update
 }
 
 // PaymentMethodUpdate represents fields that can be updated
@@ -72,6 +78,10 @@ type PaymentMethodUpdate struct {
 	MaxAmountUSD *float64 `json:"max_amount_usd,omitempty"`
 	FeePercent   *float64 `json:"fee_percent,omitempty"`
 	DisplayOrder *int     `json:"display_order,omitempty"`
+
+	// ExpectedVersion, if set, makes the update fail with
+	// ErrVersionConflict unless the record is still at that version
+	ExpectedVersion *int64 `json:"expected_version,omitempty"`
 }
 
 // PricingHistoryEntry represents a pricing change record
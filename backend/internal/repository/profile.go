@@ -0,0 +1,34 @@
+// Package repository defines the interfaces for data access
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// ProfileRepository defines the contract for user profiles, one per wallet
+// address
+type ProfileRepository interface {
+	// UpsertProfile creates or replaces the profile of p.Address
+	UpsertProfile(ctx context.Context, p *Profile) error
+	GetProfile(ctx context.Context, address string) (*Profile, error)
+	DeleteProfile(ctx context.Context, address string) error
+
+	// GetProfiles returns the profiles of those addresses that have one,
+	// keyed by address
+	GetProfiles(ctx context.Context, addresses []string) (map[string]*Profile, error)
+}
+
+// Profile is a user's self-managed profile. Email is where notifications go
+// and is never shown publicly.
+type Profile struct {
+	Address           string    `json:"address" db:"address"`
+	DisplayName       *string   `json:"display_name,omitempty" db:"display_name"`
+	AvatarChainID     *int64    `json:"avatar_chain_id,omitempty" db:"avatar_chain_id"`
+	AvatarContract    *string   `json:"avatar_contract,omitempty" db:"avatar_contract"` // NFT contract of the avatar
+	AvatarTokenID     *string   `json:"avatar_token_id,omitempty" db:"avatar_token_id"`
+	Email             *string   `json:"email,omitempty" db:"email"`
+	PreferredCurrency string    `json:"preferred_currency" db:"preferred_currency"` // USD, ETH or NEXUS
+	CreatedAt         time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at" db:"updated_at"`
+}
@@ -0,0 +1,159 @@
+package repository
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+)
+
+// RelaySpendGroup selects how relayer spend is aggregated
+type RelaySpendGroup string
+
+const (
+	RelaySpendByUser     RelaySpendGroup = "user"     // signer (from_address)
+	RelaySpendByContract RelaySpendGroup = "contract" // target (to_address)
+	RelaySpendByDay      RelaySpendGroup = "day"      // date mined in the filter's Location, YYYY-MM-DD
+)
+
+// Valid reports whether g is a known grouping
+func (g RelaySpendGroup) Valid() bool {
+	switch g {
+	case RelaySpendByUser, RelaySpendByContract, RelaySpendByDay:
+		return true
+	}
+	return false
+}
+
+// RelaySpendFilter restricts a spend report. Spend is gas used × effective
+// gas price of every meta-transaction mined with a receipt, confirmed or
+// reverted, since the relayer pays for both. Times refer to when it was
+// mined.
+type RelaySpendFilter struct {
+	GroupBy     RelaySpendGroup
+	FromAddress string
+	ToAddress   string
+	From        *time.Time // inclusive
+	To          *time.Time // exclusive
+
+	// Location sets the day boundaries of RelaySpendByDay; nil is UTC
+	Location *time.Location
+}
+
+// Day is the RelaySpendByDay key of a meta-transaction mined at minedAt
+func (f RelaySpendFilter) Day(minedAt time.Time) string {
+	loc := f.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+	return minedAt.In(loc).Format("2006-01-02")
+}
+
+// RelaySpend is the relayer's spend for one group. Users and contracts are
+// ordered by spend, highest first; days oldest first.
+type RelaySpend struct {
+	Key      string `json:"key"` // address or date, depending on GroupBy
+	TxCount  int64  `json:"tx_count"`
+	GasUsed  uint64 `json:"gas_used"`
+	SpendWei string `json:"spend_wei"`
+	SpendETH string `json:"spend_eth"`
+}
+
+// RelaySpendTally aggregates spend in Go for backends that cannot sum wei
+// amounts exactly
+type RelaySpendTally struct {
+	groups map[string]*relaySpendGroup
+}
+
+type relaySpendGroup struct {
+	txCount int64
+	gasUsed uint64
+	wei     *big.Int
+}
+
+// NewRelaySpendTally creates an empty tally
+func NewRelaySpendTally() *RelaySpendTally {
+	return &RelaySpendTally{groups: make(map[string]*relaySpendGroup)}
+}
+
+// Add counts one meta-transaction. gasPrice is the effective gas price in
+// wei, as stored by the confirmation tracker.
+func (t *RelaySpendTally) Add(key string, gasUsed uint64, gasPrice string) error {
+	price, ok := new(big.Int).SetString(gasPrice, 10)
+	if !ok {
+		return fmt.Errorf("invalid gas price %q", gasPrice)
+	}
+
+	g, ok := t.groups[key]
+	if !ok {
+		g = &relaySpendGroup{wei: new(big.Int)}
+		t.groups[key] = g
+	}
+	g.txCount++
+	g.gasUsed += gasUsed
+	g.wei.Add(g.wei, price.Mul(price, new(big.Int).SetUint64(gasUsed)))
+	return nil
+}
+
+// Result returns the groups in report order
+func (t *RelaySpendTally) Result(groupBy RelaySpendGroup) []*RelaySpend {
+	type entry struct {
+		key string
+		*relaySpendGroup
+	}
+	entries := make([]entry, 0, len(t.groups))
+	for key, g := range t.groups {
+		entries = append(entries, entry{key, g})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if groupBy == RelaySpendByDay {
+			return entries[i].key < entries[j].key
+		}
+		if c := entries[i].wei.Cmp(entries[j].wei); c != 0 {
+			return c > 0
+		}
+		return entries[i].key < entries[j].key
+	})
+
+	spend := make([]*RelaySpend, len(entries))
+	for i, e := range entries {
+		spend[i] = &RelaySpend{
+			Key:      e.key,
+			TxCount:  e.txCount,
+			GasUsed:  e.gasUsed,
+			SpendWei: e.wei.String(),
+			SpendETH: FormatWeiETH(e.wei),
+		}
+	}
+	return spend
+}
+
+// FormatWeiETH renders a wei amount as ETH with all 18 decimals
+func FormatWeiETH(wei *big.Int) string {
+	sign := ""
+	abs := new(big.Int).Set(wei)
+	if abs.Sign() < 0 {
+		sign = "-"
+		abs.Neg(abs)
+	}
+	whole, frac := new(big.Int).QuoRem(abs, big.NewInt(1e18), new(big.Int))
+	return fmt.Sprintf("%s%d.%018d", sign, whole, frac)
+}
+
+// TotalRelaySpend sums a report's groups. The total's key is empty.
+func TotalRelaySpend(groups []*RelaySpend) (*RelaySpend, error) {
+	total := &RelaySpend{}
+	wei := new(big.Int)
+	for _, g := range groups {
+		amount, ok := new(big.Int).SetString(g.SpendWei, 10)
+		if !ok {
+			return nil, fmt.Errorf("invalid wei amount %q", g.SpendWei)
+		}
+		wei.Add(wei, amount)
+		total.TxCount += g.TxCount
+		total.GasUsed += g.GasUsed
+	}
+	total.SpendWei = wei.String()
+	total.SpendETH = FormatWeiETH(wei)
+	return total, nil
+}
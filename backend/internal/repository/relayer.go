@@ -10,6 +10,7 @@ import (
 type RelayerRepository interface {
 	// Meta-transaction CRUD
 	CreateMetaTx(ctx context.Context, tx *MetaTransaction) error
+	CreateMetaTxBatch(ctx context.Context, txs []*MetaTransaction) error // single multi-row insert; all or nothing
 	GetMetaTx(ctx context.Context, id string) (*MetaTransaction, error)
 	GetMetaTxByHash(ctx context.Context, txHash string) (*MetaTransaction, error)
 	UpdateMetaTxStatus(ctx context.Context, id string, update *MetaTxStatusUpdate) error
@@ -21,6 +22,14 @@ type RelayerRepository interface {
 	// Pending transaction management
 	GetPendingMetaTxs(ctx context.Context, limit int) ([]*MetaTransaction, error)
 	GetExpiredMetaTxs(ctx context.Context, limit int) ([]*MetaTransaction, error)
+
+	// Archival (finished meta-transactions older than the retention window move to meta_transactions_archive)
+	ArchiveMetaTxs(ctx context.Context, before time.Time, limit int) (int64, error)
+	GetArchivedMetaTx(ctx context.Context, id string) (*MetaTransaction, error)
+	ListArchivedMetaTx(ctx context.Context, filter MetaTxFilter, page Pagination) ([]*MetaTransaction, int64, error)
+
+	// Spend reporting (gas the relayer paid, live and archived meta-transactions)
+	GetRelaySpend(ctx context.Context, filter RelaySpendFilter) ([]*RelaySpend, error)
 }
 
 // MetaTxStatus represents meta-transaction states
@@ -58,6 +67,7 @@ type MetaTransaction struct {
 	UpdatedAt    time.Time    `json:"updated_at" db:"updated_at"`
 	SubmittedAt  *time.Time   `json:"submitted_at,omitempty" db:"submitted_at"`
 	ConfirmedAt  *time.Time   `json:"confirmed_at,omitempty" db:"confirmed_at"`
+	ArchivedAt   *time.Time   `json:"archived_at,omitempty" db:"archived_at"` // set only on archived meta-transactions
 }
 
 // MetaTxStatusUpdate contains update details for meta-transaction status
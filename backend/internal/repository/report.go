@@ -0,0 +1,97 @@
+// Package repository defines the interfaces for data access
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// ReportRepository defines the contract for scheduled compliance and finance
+// reports and the runs that generated them
+type ReportRepository interface {
+	CreateReportSchedule(ctx context.Context, s *ReportSchedule) error
+	GetReportSchedule(ctx context.Context, id string) (*ReportSchedule, error)
+
+	// UpdateReportSchedule replaces a schedule's name, kind, cron
+	// expression, time zone, recipients, enabled flag and next run time
+	UpdateReportSchedule(ctx context.Context, s *ReportSchedule) error
+	DeleteReportSchedule(ctx context.Context, id string) error
+	ListReportSchedules(ctx context.Context, page Pagination) ([]*ReportSchedule, int64, error)
+
+	// ListDueReportSchedules lists enabled schedules whose next run is at or
+	// before now, earliest first
+	ListDueReportSchedules(ctx context.Context, now time.Time, limit int) ([]*ReportSchedule, error)
+
+	// ClaimReportSchedule records that the run due at due has started and
+	// moves the next run to next. It reports false when the schedule is no
+	// longer due at due: claimed by another instance, edited or disabled.
+	ClaimReportSchedule(ctx context.Context, id string, due, next time.Time) (bool, error)
+
+	// CreateReportRun records a finished run, setting its ID
+	CreateReportRun(ctx context.Context, run *ReportRun) error
+	GetReportRun(ctx context.Context, id string) (*ReportRun, error)
+
+	// ListReportRuns lists a schedule's runs (every run when scheduleID is
+	// empty), newest first
+	ListReportRuns(ctx context.Context, scheduleID string, page Pagination) ([]*ReportRun, int64, error)
+}
+
+// ReportKind is the records a report lists
+type ReportKind string
+
+const (
+	ReportPayments ReportKind = "payments" // payments created in the period
+	ReportKYC      ReportKind = "kyc"      // KYC verifications started in the period
+	ReportRelay    ReportKind = "relay"    // meta-transactions relayed in the period
+)
+
+// Valid reports whether k is a known kind
+func (k ReportKind) Valid() bool {
+	switch k {
+	case ReportPayments, ReportKYC, ReportRelay:
+		return true
+	}
+	return false
+}
+
+// ReportSchedule generates a report whenever its cron expression fires (on
+// Timezone's wall clock) and emails a download link to Recipients
+type ReportSchedule struct {
+	ID         string     `json:"id" db:"id"`
+	Name       string     `json:"name" db:"name"`
+	Kind       ReportKind `json:"kind" db:"kind"`
+	Cron       string     `json:"cron" db:"cron"`
+	Timezone   string     `json:"timezone" db:"timezone"` // IANA name, e.g. Europe/Berlin
+	Recipients []string   `json:"recipients" db:"recipients"`
+	Enabled    bool       `json:"enabled" db:"enabled"`
+	CreatedBy  string     `json:"created_by" db:"created_by"`
+	NextRunAt  time.Time  `json:"next_run_at" db:"next_run_at"`
+	LastRunAt  *time.Time `json:"last_run_at,omitempty" db:"last_run_at"` // scheduled time of the last claimed run
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// ReportRunStatus is the outcome of a report run
+type ReportRunStatus string
+
+const (
+	ReportRunSucceeded ReportRunStatus = "succeeded"
+	ReportRunFailed    ReportRunStatus = "failed"
+)
+
+// ReportRun is one generated report, covering records created from
+// PeriodStart up to PeriodEnd
+type ReportRun struct {
+	ID           string          `json:"id" db:"id"`
+	ScheduleID   string          `json:"schedule_id" db:"schedule_id"`
+	Kind         ReportKind      `json:"kind" db:"kind"`
+	PeriodStart  time.Time       `json:"period_start" db:"period_start"`
+	PeriodEnd    time.Time       `json:"period_end" db:"period_end"`
+	Status       ReportRunStatus `json:"status" db:"status"`
+	ObjectKey    string          `json:"object_key,omitempty" db:"object_key"`
+	Rows         int64           `json:"rows" db:"row_count"`
+	Manual       bool            `json:"manual" db:"manual"` // started from the admin API rather than the schedule
+	ErrorMessage *string         `json:"error_message,omitempty" db:"error_message"`
+	StartedAt    time.Time       `json:"started_at" db:"started_at"`
+	FinishedAt   time.Time       `json:"finished_at" db:"finished_at"`
+}
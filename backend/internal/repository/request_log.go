@@ -0,0 +1,51 @@
+// Package repository defines the interfaces for data access
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// RequestLogRepository defines the contract for archived API request logs
+type RequestLogRepository interface {
+	// Archive (entries are already scrubbed of PII/secrets)
+	InsertRequestLogs(ctx context.Context, entries []*RequestLogEntry) error
+
+	// Support queries
+	GetRequestLog(ctx context.Context, id string) (*RequestLogEntry, error)
+	ListRequestLogs(ctx context.Context, filter RequestLogFilter, page Pagination) ([]*RequestLogEntry, int64, error)
+
+	// Retention
+	DeleteRequestLogsBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+// RequestLogEntry represents an archived request/response pair
+type RequestLogEntry struct {
+	ID             string            `json:"id" db:"id"`
+	RequestID      string            `json:"request_id" db:"request_id"`
+	Method         string            `json:"method" db:"method"`
+	Path           string            `json:"path" db:"path"`
+	Route          string            `json:"route" db:"route"` // Gin route pattern, e.g. /api/v1/payments/:id
+	Query          string            `json:"query,omitempty" db:"query"`
+	StatusCode     int               `json:"status_code" db:"status_code"`
+	LatencyMs      int64             `json:"latency_ms" db:"latency_ms"`
+	ClientIP       string            `json:"client_ip" db:"client_ip"`
+	UserAgent      string            `json:"user_agent,omitempty" db:"user_agent"`
+	RequestHeaders map[string]string `json:"request_headers,omitempty" db:"request_headers"`
+	RequestBody    *string           `json:"request_body,omitempty" db:"request_body"`
+	ResponseBody   *string           `json:"response_body,omitempty" db:"response_body"`
+	Error          *string           `json:"error,omitempty" db:"error"`
+	CreatedAt      time.Time         `json:"created_at" db:"created_at"`
+}
+
+// RequestLogFilter defines filtering options for listing request logs
+type RequestLogFilter struct {
+	RequestID  string
+	Method     string
+	Path       string // Prefix match
+	StatusCode int
+	MinStatus  int // e.g. 500 for server errors only
+	ClientIP   string
+	From       *time.Time
+	To         *time.Time
+}
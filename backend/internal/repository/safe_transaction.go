@@ -0,0 +1,148 @@
+// Package repository defines the interfaces for data access
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// SafeTransactionRepository defines the contract for Safe (Gnosis Safe)
+// multisig transactions coordinated off-chain: proposed transactions, the
+// owners' signatures confirming them, and their execution. Values are wei as
+// decimal strings.
+type SafeTransactionRepository interface {
+	// CreateSafeTransaction records a proposed transaction and returns
+	// ErrSafeTransactionExists if its safe_tx_hash is already proposed
+	CreateSafeTransaction(ctx context.Context, tx *SafeTransaction) error
+	GetSafeTransaction(ctx context.Context, id string) (*SafeTransaction, error)
+	ListSafeTransactions(ctx context.Context, filter SafeTransactionFilter, page Pagination) ([]*SafeTransaction, int64, error)
+
+	// AddSafeConfirmation records an owner's signature and counts it on the
+	// transaction. It returns ErrSafeConfirmationExists if the owner has
+	// already confirmed and ErrSafeTransactionClosed once the transaction
+	// no longer takes confirmations.
+	AddSafeConfirmation(ctx context.Context, c *SafeConfirmation) error
+
+	// ListSafeConfirmations lists a transaction's confirmations by owner
+	// address ascending, the order execTransaction takes signatures in
+	ListSafeConfirmations(ctx context.Context, safeTxID string) ([]*SafeConfirmation, error)
+
+	// UpdateSafeTransactionStatus moves a transaction to another status if
+	// CanMoveTo allows it, and returns ErrSafeTransactionClosed otherwise
+	UpdateSafeTransactionStatus(ctx context.Context, id string, update *SafeTransactionUpdate) error
+}
+
+// SafeOperation is how the Safe makes the call
+type SafeOperation uint8
+
+const (
+	SafeOperationCall         SafeOperation = 0
+	SafeOperationDelegateCall SafeOperation = 1
+)
+
+// SafeTransactionStatus represents Safe transaction states
+type SafeTransactionStatus string
+
+const (
+	SafeTxAwaitingConfirmations SafeTransactionStatus = "awaiting_confirmations"
+	SafeTxReady                 SafeTransactionStatus = "ready"     // confirmed by the threshold of owners
+	SafeTxCancelled             SafeTransactionStatus = "cancelled" // withdrawn before it was sent
+	SafeTxSending               SafeTransactionStatus = "sending"   // claimed for execution, transaction being sent
+	SafeTxSubmitted             SafeTransactionStatus = "submitted" // execTransaction sent, awaiting confirmation
+	SafeTxExecuted              SafeTransactionStatus = "executed"  // the Safe emitted ExecutionSuccess at confirmation depth
+	SafeTxFailed                SafeTransactionStatus = "failed"    // not sent, reverted or the Safe emitted ExecutionFailure
+)
+
+// SafeTransaction is a transaction of a Safe awaiting or past execution.
+// SafeTxHash is the EIP-712 hash the owners sign.
+type SafeTransaction struct {
+	ID              string                `json:"id" db:"id"`
+	ChainID         int64                 `json:"chain_id" db:"chain_id"`
+	SafeAddress     string                `json:"safe_address" db:"safe_address"`
+	To              string                `json:"to" db:"to_address"`
+	Value           string                `json:"value" db:"value"`
+	Data            string                `json:"data" db:"data"` // 0x-prefixed hex
+	Operation       SafeOperation         `json:"operation" db:"operation"`
+	SafeTxGas       string                `json:"safe_tx_gas" db:"safe_tx_gas"`
+	BaseGas         string                `json:"base_gas" db:"base_gas"`
+	GasPrice        string                `json:"gas_price" db:"gas_price"`
+	GasToken        string                `json:"gas_token" db:"gas_token"`
+	RefundReceiver  string                `json:"refund_receiver" db:"refund_receiver"`
+	Nonce           uint64                `json:"nonce" db:"nonce"`
+	SafeTxHash      string                `json:"safe_tx_hash" db:"safe_tx_hash"`
+	Threshold       int                   `json:"threshold" db:"threshold"` // the Safe's threshold when last checked
+	Confirmations   int                   `json:"confirmations" db:"confirmations"`
+	Status          SafeTransactionStatus `json:"status" db:"status"`
+	Description     string                `json:"description" db:"description"`
+	ProposedBy      string                `json:"proposed_by" db:"proposed_by"`
+	ExecutorAddress *string               `json:"executor_address,omitempty" db:"executor_address"` // relayer key that sent execTransaction
+	TxHash          *string               `json:"tx_hash,omitempty" db:"tx_hash"`
+	BlockNumber     *uint64               `json:"block_number,omitempty" db:"block_number"`
+	ErrorMessage    *string               `json:"error_message,omitempty" db:"error_message"`
+	CreatedAt       time.Time             `json:"created_at" db:"created_at"`
+	UpdatedAt       time.Time             `json:"updated_at" db:"updated_at"`
+	SubmittedAt     *time.Time            `json:"submitted_at,omitempty" db:"submitted_at"`
+	ExecutedAt      *time.Time            `json:"executed_at,omitempty" db:"executed_at"`
+}
+
+// TakesConfirmations reports whether owners may still confirm the
+// transaction
+func (t *SafeTransaction) TakesConfirmations() bool {
+	return t.Status == SafeTxAwaitingConfirmations || t.Status == SafeTxReady
+}
+
+// CanMoveTo reports whether the transaction may move to status. A
+// transaction becomes ready once enough owners confirm it and may be
+// cancelled until it is claimed for execution. A claimed transaction is
+// submitted once execTransaction is sent, then executed or failed by its
+// receipt; one that failed to send may be claimed again. An executed or
+// failed transaction returns to submitted when its block is reorged out.
+func (t *SafeTransaction) CanMoveTo(status SafeTransactionStatus) bool {
+	switch t.Status {
+	case SafeTxAwaitingConfirmations:
+		return status == SafeTxReady || status == SafeTxCancelled
+	case SafeTxReady:
+		return status == SafeTxSending || status == SafeTxCancelled || status == SafeTxAwaitingConfirmations
+	case SafeTxSending:
+		return status == SafeTxSubmitted || status == SafeTxFailed
+	case SafeTxSubmitted:
+		return status == SafeTxExecuted || status == SafeTxFailed
+	case SafeTxExecuted:
+		return status == SafeTxSubmitted
+	case SafeTxFailed:
+		if t.TxHash == nil {
+			return status == SafeTxSending || status == SafeTxCancelled
+		}
+		return status == SafeTxSubmitted
+	}
+	return false
+}
+
+// SafeTransactionUpdate contains a Safe transaction status change.
+// Threshold, ExecutorAddress and TxHash are kept when unset; BlockNumber and
+// ErrorMessage are replaced.
+type SafeTransactionUpdate struct {
+	Status          SafeTransactionStatus
+	Threshold       *int
+	ExecutorAddress *string
+	TxHash          *string
+	BlockNumber     *uint64
+	ErrorMessage    *string
+}
+
+// SafeConfirmation is a Safe owner's signature of a transaction's
+// SafeTxHash, in the form execTransaction takes it (65 bytes, v 27/28 for
+// EIP-712 signatures and 31/32 for eth_sign ones)
+type SafeConfirmation struct {
+	SafeTxID  string    `json:"safe_tx_id" db:"safe_tx_id"`
+	Owner     string    `json:"owner" db:"owner"`
+	Signature string    `json:"signature" db:"signature"` // 0x-prefixed hex
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// SafeTransactionFilter defines filtering options for listing Safe
+// transactions
+type SafeTransactionFilter struct {
+	SafeAddress string
+	Status      SafeTransactionStatus
+}
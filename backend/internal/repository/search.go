@@ -0,0 +1,63 @@
+// Package repository defines the interfaces for data access
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// SearchRepository defines the contract for the admin support search across
+// payments, KYC verifications and meta-transactions
+type SearchRepository interface {
+	Search(ctx context.Context, query SearchQuery) ([]*SearchResult, error)
+}
+
+// SearchResultType identifies the kind of record a search result holds
+type SearchResultType string
+
+const (
+	SearchResultPayment         SearchResultType = "payment"
+	SearchResultKYCVerification SearchResultType = "kyc_verification"
+	SearchResultMetaTx          SearchResultType = "meta_transaction"
+)
+
+// SearchResultTypes lists every searchable record type
+var SearchResultTypes = []SearchResultType{
+	SearchResultPayment,
+	SearchResultKYCVerification,
+	SearchResultMetaTx,
+}
+
+// SearchQuery defines a support search
+type SearchQuery struct {
+	Term  string             // Partial address, tx hash, Stripe ID, applicant ID or email
+	Types []SearchResultType // Record types to search (empty searches all)
+	Limit int                // Maximum results per record type
+}
+
+// Includes reports whether the query searches records of type t
+func (q SearchQuery) Includes(t SearchResultType) bool {
+	if len(q.Types) == 0 {
+		return true
+	}
+	for _, qt := range q.Types {
+		if qt == t {
+			return true
+		}
+	}
+	return false
+}
+
+// SearchResult is one matching record. Exactly one of Payment,
+// KYCVerification and MetaTransaction is set, according to Type.
+type SearchResult struct {
+	Type         SearchResultType `json:"type"`
+	ID           string           `json:"id"`
+	MatchedField string           `json:"matched_field"` // Column that matched, e.g. tx_hash
+	Score        float64          `json:"score"`         // Relevance in [0, 1], higher is better
+	CreatedAt    time.Time        `json:"created_at"`
+
+	Payment         *Payment         `json:"payment,omitempty"`
+	KYCVerification *KYCVerification `json:"kyc_verification,omitempty"`
+	MetaTransaction *MetaTransaction `json:"meta_transaction,omitempty"`
+}
@@ -0,0 +1,62 @@
+// Package repository defines the interfaces for data access
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// SnapshotRepository defines the contract for governance proposals mirrored
+// from Snapshot (off-chain voting), keyed by their Snapshot proposal ID
+type SnapshotRepository interface {
+	// UpsertSnapshotProposal records a mirrored proposal or refreshes its
+	// content and results. The on-chain link is kept: only
+	// LinkSnapshotProposal changes it.
+	UpsertSnapshotProposal(ctx context.Context, p *SnapshotProposal) error
+	GetSnapshotProposal(ctx context.Context, id string) (*SnapshotProposal, error)
+	ListSnapshotProposals(ctx context.Context, filter SnapshotProposalFilter, page Pagination) ([]*SnapshotProposal, int64, error)
+
+	// LinkSnapshotProposal links a mirrored proposal to the on-chain proposal
+	// it went on to, or unlinks it when onchainProposalID is nil
+	LinkSnapshotProposal(ctx context.Context, id string, onchainProposalID *string) error
+}
+
+// SnapshotProposalState is a Snapshot proposal's voting state
+type SnapshotProposalState string
+
+const (
+	SnapshotProposalPending SnapshotProposalState = "pending" // voting has not started
+	SnapshotProposalActive  SnapshotProposalState = "active"
+	SnapshotProposalClosed  SnapshotProposalState = "closed" // final results
+)
+
+// SnapshotProposal is a proposal and its results as last read from Snapshot.
+// Scores are the voting power per choice, in the order of Choices.
+type SnapshotProposal struct {
+	ID                string                `json:"id" db:"id"`
+	Space             string                `json:"space" db:"space"`
+	Title             string                `json:"title" db:"title"`
+	Body              string                `json:"body" db:"body"`
+	Author            string                `json:"author" db:"author"`
+	Choices           []string              `json:"choices" db:"choices"`
+	Scores            []float64             `json:"scores" db:"scores"`
+	ScoresTotal       float64               `json:"scores_total" db:"scores_total"`
+	Votes             int                   `json:"votes" db:"votes"`
+	State             SnapshotProposalState `json:"state" db:"state"`
+	SnapshotBlock     string                `json:"snapshot_block" db:"snapshot_block"` // block voting power is read at
+	Link              string                `json:"link" db:"link"`
+	OnchainProposalID *string               `json:"onchain_proposal_id,omitempty" db:"onchain_proposal_id"`
+	StartTime         time.Time             `json:"start_time" db:"start_time"`
+	EndTime           time.Time             `json:"end_time" db:"end_time"`
+	CreatedAt         time.Time             `json:"created_at" db:"created_at"` // created on Snapshot
+	SyncedAt          time.Time             `json:"synced_at" db:"synced_at"`
+}
+
+// SnapshotProposalFilter defines filtering options for listing mirrored
+// proposals
+type SnapshotProposalFilter struct {
+	Space             string
+	State             SnapshotProposalState
+	OnchainProposalID string
+	Unlinked          bool // only proposals not linked to an on-chain proposal
+}
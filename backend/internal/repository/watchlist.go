@@ -0,0 +1,56 @@
+// Package repository defines the interfaces for data access
+package repository
+
+import (
+	"context"
+	"slices"
+	"time"
+)
+
+// WatchlistRepository defines the contract for address watchlists: webhooks
+// alerted when any of a set of addresses transacts
+type WatchlistRepository interface {
+	// CreateWatchlist stores w, setting its ID and timestamps
+	CreateWatchlist(ctx context.Context, w *Watchlist) error
+	GetWatchlist(ctx context.Context, id string) (*Watchlist, error)
+
+	// UpdateWatchlist replaces w's name, webhook, secret, addresses and
+	// events
+	UpdateWatchlist(ctx context.Context, w *Watchlist) error
+	DeleteWatchlist(ctx context.Context, id string) error
+	ListWatchlists(ctx context.Context, filter WatchlistFilter, page Pagination) ([]*Watchlist, int64, error)
+}
+
+// Watchable reports whether activity of kind k can be watched: payments,
+// NFT transfers and relayed meta-transactions
+func (k ActivityKind) Watchable() bool {
+	switch k {
+	case ActivityPayment, ActivityNFTTransfer, ActivityMetaTx:
+		return true
+	}
+	return false
+}
+
+// Watchlist is a set of addresses whose activity is POSTed to WebhookURL,
+// signed with Secret like payment callbacks. Addresses are lowercased.
+type Watchlist struct {
+	ID         string    `json:"id" db:"id"`
+	Name       string    `json:"name" db:"name"`
+	WebhookURL string    `json:"webhook_url" db:"webhook_url"`
+	Secret     string    `json:"-" db:"secret"`
+	Addresses  []string  `json:"addresses" db:"addresses"`
+	Events     []string  `json:"events" db:"events"` // watchable ActivityKind values
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// Watches reports whether the watchlist wants activity of kind k by address
+func (w *Watchlist) Watches(address string, k ActivityKind) bool {
+	return slices.Contains(w.Events, string(k)) && slices.Contains(w.Addresses, address)
+}
+
+// WatchlistFilter defines filtering options for listing watchlists
+type WatchlistFilter struct {
+	Address string       // watching this (lowercased) address
+	Event   ActivityKind // watching this kind of activity
+}
@@ -0,0 +1,114 @@
+// Package repository defines the interfaces for data access
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// WebhookEventRepository defines the contract for stored inbound webhook
+// events. Events are keyed by (provider, event_id) so redelivered events are
+// recognised and processed once. Events that keep failing background
+// processing are moved to a dead-letter state for manual reprocessing.
+type WebhookEventRepository interface {
+	// CreateWebhookEvent stores a new event and reports true. If the event
+	// was already stored, e is replaced with the stored copy and false is
+	// returned.
+	CreateWebhookEvent(ctx context.Context, e *WebhookEvent) (bool, error)
+	GetWebhookEvent(ctx context.Context, id string) (*WebhookEvent, error)
+	GetWebhookEventByEventID(ctx context.Context, provider, eventID string) (*WebhookEvent, error)
+	ListWebhookEvents(ctx context.Context, filter WebhookEventFilter, page Pagination) ([]*WebhookEvent, int64, error)
+
+	// ListDueWebhookEvents returns up to limit events of a provider that are
+	// ready for background processing, oldest first: received or failed
+	// events whose next attempt is due, and events left processing for
+	// longer than staleAfter
+	ListDueWebhookEvents(ctx context.Context, provider string, staleAfter time.Duration, limit int) ([]*WebhookEvent, error)
+
+	// ClaimWebhookEvent moves a received or failed event (or one left
+	// processing for longer than staleAfter) to processing and increments its
+	// attempts. It reports false when another worker holds the event or it
+	// was already processed.
+	ClaimWebhookEvent(ctx context.Context, id string, staleAfter time.Duration) (bool, error)
+	MarkWebhookEventProcessed(ctx context.Context, id string) error
+
+	// MarkWebhookEventFailed records a processing failure. retryAt schedules
+	// the next background attempt; nil leaves the event for provider
+	// redelivery or a manual replay.
+	MarkWebhookEventFailed(ctx context.Context, id string, errMsg string, retryAt *time.Time) error
+
+	// MarkWebhookEventDeadLetter parks an event that exhausted its retries
+	MarkWebhookEventDeadLetter(ctx context.Context, id string, errMsg string) error
+
+	// RequeueWebhookEvent makes a failed or dead-lettered event due for
+	// processing again. It returns ErrWebhookEventNotRetryable for events in
+	// any other state.
+	RequeueWebhookEvent(ctx context.Context, id string) error
+}
+
+// Webhook providers
+const (
+	WebhookProviderStripe = "stripe"
+	WebhookProviderSumsub = "sumsub"
+
+	// WebhookProviderPaymentCallback is outbound: payment status
+	// notifications queued for delivery to the originating dapp
+	WebhookProviderPaymentCallback = "payment_callback"
+
+	// WebhookProviderComplianceAlert is outbound: dispute and anomaly alerts
+	// queued for delivery to the compliance webhook
+	WebhookProviderComplianceAlert = "compliance_alert"
+
+	// WebhookProviderGovernanceAlert is outbound: proposal lifecycle alerts
+	// and digests queued for delivery to subscribed token holders
+	WebhookProviderGovernanceAlert = "governance_alert"
+
+	// WebhookProviderContractChange is outbound: primary contract address
+	// changes queued for delivery to the contract change webhook
+	WebhookProviderContractChange = "contract_change"
+
+	// WebhookProviderWatchlistAlert is outbound: activity of watched
+	// addresses queued for delivery to the watchlists' webhooks
+	WebhookProviderWatchlistAlert = "watchlist_alert"
+
+	// WebhookProviderFulfillment is internal: completed payments queued for
+	// their service's fulfiller, retried like deliveries
+	WebhookProviderFulfillment = "fulfillment"
+)
+
+// WebhookEventStatus represents webhook event processing states
+type WebhookEventStatus string
+
+const (
+	WebhookEventReceived   WebhookEventStatus = "received"
+	WebhookEventProcessing WebhookEventStatus = "processing"
+	WebhookEventProcessed  WebhookEventStatus = "processed"
+	WebhookEventFailed     WebhookEventStatus = "failed"
+	WebhookEventDeadLetter WebhookEventStatus = "dead_letter"
+)
+
+// WebhookEvent represents a stored inbound webhook event
+type WebhookEvent struct {
+	ID          string             `json:"id" db:"id"`
+	Provider    string             `json:"provider" db:"provider"`
+	EventID     string             `json:"event_id" db:"event_id"`
+	EventType   string             `json:"event_type" db:"event_type"`
+	Payload     []byte             `json:"-" db:"payload"` // Raw body as received (signature already verified)
+	Status      WebhookEventStatus `json:"status" db:"status"`
+	Attempts    int                `json:"attempts" db:"attempts"`
+	LastError   *string            `json:"last_error,omitempty" db:"last_error"`
+	CreatedAt   time.Time          `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time          `json:"updated_at" db:"updated_at"`
+	ProcessedAt *time.Time         `json:"processed_at,omitempty" db:"processed_at"`
+
+	// NextAttemptAt is when background processing picks the event up (nil
+	// when no background attempt is scheduled)
+	NextAttemptAt *time.Time `json:"next_attempt_at,omitempty" db:"next_attempt_at"`
+}
+
+// WebhookEventFilter defines filtering options for listing webhook events
+type WebhookEventFilter struct {
+	Provider  string
+	EventType string
+	Status    WebhookEventStatus
+}
@@ -0,0 +1,177 @@
+// Package retention moves settled payments and finished meta-transactions
+// out of the live tables once they pass the configured retention window, so
+// the hot tables stay small while old records remain queryable in archive
+// tables
+package retention
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Retention defaults (overridable via app_config namespace "retention")
+const (
+	defaultPaymentsMonths = 24
+	defaultMetaTxMonths   = 6
+	defaultBatchSize      = 1000
+
+	// DefaultInterval is how often Run archives when no interval is given
+	DefaultInterval = time.Hour
+
+	// maxBatchesPerRun bounds one pass so a large backlog is worked off over
+	// several intervals instead of holding the database for minutes
+	maxBatchesPerRun = 100
+)
+
+// RunStats summarises one archive pass
+type RunStats struct {
+	StartedAt        time.Time  `json:"started_at"`
+	Duration         string     `json:"duration"`
+	PaymentsArchived int64      `json:"payments_archived"`
+	MetaTxsArchived  int64      `json:"meta_txs_archived"`
+	PaymentsCutoff   *time.Time `json:"payments_cutoff,omitempty"`
+	MetaTxsCutoff    *time.Time `json:"meta_txs_cutoff,omitempty"`
+	Errors           []string   `json:"errors,omitempty"`
+}
+
+// Archiver periodically moves old records into the archive tables
+type Archiver struct {
+	payments   repository.PaymentRepository
+	relayer    repository.RelayerRepository
+	configRepo repository.AppConfigRepository
+	logger     *zap.Logger
+
+	mu   sync.RWMutex
+	last *RunStats
+}
+
+// NewArchiver creates a new archiver
+func NewArchiver(payments repository.PaymentRepository, relayer repository.RelayerRepository, configRepo repository.AppConfigRepository, logger *zap.Logger) *Archiver {
+	return &Archiver{
+		payments:   payments,
+		relayer:    relayer,
+		configRepo: configRepo,
+		logger:     logger,
+	}
+}
+
+// Run archives every interval until ctx is cancelled
+func (a *Archiver) Run(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	a.RunOnce(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			a.RunOnce(ctx)
+		}
+	}
+}
+
+// RunOnce performs a single archive pass
+func (a *Archiver) RunOnce(ctx context.Context) RunStats {
+	start := time.Now()
+	stats := RunStats{StartedAt: start}
+	batchSize := int(a.getConfigNumber(ctx, "batch_size", defaultBatchSize))
+	if batchSize <= 0 {
+		batchSize = defaultBatchSize
+	}
+
+	if months := a.getConfigNumber(ctx, "payments_months", defaultPaymentsMonths); months > 0 {
+		cutoff := start.AddDate(0, -int(months), 0)
+		stats.PaymentsCutoff = &cutoff
+		moved, err := a.drain(ctx, batchSize, func(ctx context.Context) (int64, error) {
+			return a.payments.ArchivePayments(ctx, cutoff, batchSize)
+		})
+		stats.PaymentsArchived = moved
+		if err != nil {
+			stats.Errors = append(stats.Errors, "payments: "+err.Error())
+			a.logger.Error("failed to archive payments", zap.Int64("archived", moved), zap.Error(err))
+		}
+	}
+
+	if months := a.getConfigNumber(ctx, "meta_tx_months", defaultMetaTxMonths); months > 0 {
+		cutoff := start.AddDate(0, -int(months), 0)
+		stats.MetaTxsCutoff = &cutoff
+		moved, err := a.drain(ctx, batchSize, func(ctx context.Context) (int64, error) {
+			return a.relayer.ArchiveMetaTxs(ctx, cutoff, batchSize)
+		})
+		stats.MetaTxsArchived = moved
+		if err != nil {
+			stats.Errors = append(stats.Errors, "meta_transactions: "+err.Error())
+			a.logger.Error("failed to archive meta-transactions", zap.Int64("archived", moved), zap.Error(err))
+		}
+	}
+
+	stats.Duration = time.Since(start).Round(time.Millisecond).String()
+	if stats.PaymentsArchived > 0 || stats.MetaTxsArchived > 0 {
+		a.logger.Info("archived old records",
+			zap.Int64("payments", stats.PaymentsArchived),
+			zap.Int64("meta_transactions", stats.MetaTxsArchived),
+			zap.String("duration", stats.Duration),
+		)
+	}
+
+	a.mu.Lock()
+	a.last = &stats
+	a.mu.Unlock()
+
+	return stats
+}
+
+// LastRun returns the stats of the most recent pass, or nil before the first
+func (a *Archiver) LastRun() *RunStats {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	if a.last == nil {
+		return nil
+	}
+	cp := *a.last
+	return &cp
+}
+
+// drain calls archive until it moves less than a full batch, ctx ends or the
+// per-run batch limit is reached
+func (a *Archiver) drain(ctx context.Context, batchSize int, archive func(context.Context) (int64, error)) (int64, error) {
+	var total int64
+	for i := 0; i < maxBatchesPerRun; i++ {
+		if ctx.Err() != nil {
+			return total, nil
+		}
+		moved, err := archive(ctx)
+		total += moved
+		if err != nil {
+			if ctx.Err() != nil {
+				return total, nil
+			}
+			return total, err
+		}
+		if moved < int64(batchSize) {
+			break
+		}
+	}
+	return total, nil
+}
+
+// getConfigNumber reads a retention config value with a code fallback
+func (a *Archiver) getConfigNumber(ctx context.Context, key string, fallback int64) int64 {
+	if a.configRepo == nil {
+		return fallback
+	}
+	v, err := a.configRepo.GetNumber(ctx, "retention", key, 0)
+	if err != nil {
+		return fallback
+	}
+	return v
+}
@@ -0,0 +1,105 @@
+package retention_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/retention"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/storage/memory"
+)
+
+// stubPayments archives from a fixed backlog and records each call
+type stubPayments struct {
+	repository.PaymentRepository
+	backlog int64
+	calls   []time.Time
+	err     error
+}
+
+func (s *stubPayments) ArchivePayments(ctx context.Context, before time.Time, limit int) (int64, error) {
+	s.calls = append(s.calls, before)
+	if s.err != nil {
+		return 0, s.err
+	}
+	moved := min(s.backlog, int64(limit))
+	s.backlog -= moved
+	return moved, nil
+}
+
+// stubRelayer archives from a fixed backlog
+type stubRelayer struct {
+	repository.RelayerRepository
+	backlog int64
+	calls   int
+}
+
+func (s *stubRelayer) ArchiveMetaTxs(ctx context.Context, before time.Time, limit int) (int64, error) {
+	s.calls++
+	moved := min(s.backlog, int64(limit))
+	s.backlog -= moved
+	return moved, nil
+}
+
+func setNumber(t *testing.T, cfg *memory.MemoryAppConfigRepo, key string, v int64) {
+	t.Helper()
+	require.NoError(t, cfg.Create(context.Background(), &repository.AppConfigCreate{
+		Namespace:   "retention",
+		ConfigKey:   key,
+		ValueType:   "number",
+		ValueNumber: &v,
+	}))
+}
+
+func TestArchiver_DrainsBacklogInBatches(t *testing.T) {
+	cfg := memory.NewMemoryAppConfigRepo()
+	setNumber(t, cfg, "batch_size", 10)
+	setNumber(t, cfg, "payments_months", 12)
+
+	payments := &stubPayments{backlog: 25}
+	relayer := &stubRelayer{backlog: 3}
+	a := retention.NewArchiver(payments, relayer, cfg, zap.NewNop())
+
+	assert.Nil(t, a.LastRun())
+	stats := a.RunOnce(context.Background())
+
+	assert.Equal(t, int64(25), stats.PaymentsArchived)
+	assert.Equal(t, int64(3), stats.MetaTxsArchived)
+	assert.Len(t, payments.calls, 3, "two full batches then a partial one")
+	assert.Equal(t, 1, relayer.calls)
+	assert.Empty(t, stats.Errors)
+
+	require.NotNil(t, stats.PaymentsCutoff)
+	assert.WithinDuration(t, time.Now().AddDate(0, -12, 0), *stats.PaymentsCutoff, time.Minute)
+	assert.Equal(t, *stats.PaymentsCutoff, payments.calls[0])
+
+	require.NotNil(t, a.LastRun())
+	assert.Equal(t, int64(25), a.LastRun().PaymentsArchived)
+}
+
+func TestArchiver_ZeroMonthsDisables(t *testing.T) {
+	cfg := memory.NewMemoryAppConfigRepo()
+	setNumber(t, cfg, "meta_tx_months", 0)
+
+	payments := &stubPayments{}
+	relayer := &stubRelayer{backlog: 5}
+	stats := retention.NewArchiver(payments, relayer, cfg, zap.NewNop()).RunOnce(context.Background())
+
+	assert.Len(t, payments.calls, 1, "payments use the default window")
+	assert.Equal(t, 0, relayer.calls)
+	assert.Nil(t, stats.MetaTxsCutoff)
+}
+
+func TestArchiver_ReportsErrors(t *testing.T) {
+	payments := &stubPayments{err: errors.New("connection refused")}
+	stats := retention.NewArchiver(payments, &stubRelayer{}, nil, zap.NewNop()).RunOnce(context.Background())
+
+	require.Len(t, stats.Errors, 1)
+	assert.Contains(t, stats.Errors[0], "connection refused")
+}
@@ -0,0 +1,177 @@
+package safetx
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Sender sends a transaction from a relayer key (implemented by
+// handlers.RelayerHandler)
+type Sender interface {
+	SendTransfer(ctx context.Context, to common.Address, value *big.Int, data []byte) (common.Address, common.Hash, error)
+}
+
+// Tracker follows a sent transaction to confirmation depth (implemented by
+// chain.ConfirmationTracker)
+type Tracker interface {
+	Track(ctx context.Context, recordType, recordID, txHash string) error
+}
+
+// SetSender enables execution: confirmed transactions are sent to their
+// Safe through sender and tracked until confirmed
+func (s *Service) SetSender(sender Sender, tracker Tracker) {
+	s.sender = sender
+	s.tracker = tracker
+}
+
+// Execute sends execTransaction for a transaction the Safe's threshold of
+// current owners have confirmed and whose nonce is the Safe's next. The
+// Safe's threshold is re-read first: a transaction short of a raised
+// threshold returns to awaiting confirmations. The claim (status sending)
+// comes before the send so a transaction is never sent twice.
+func (s *Service) Execute(ctx context.Context, id string) (*repository.SafeTransaction, error) {
+	if s.sender == nil {
+		return nil, ErrExecutionDisabled
+	}
+	tx, err := s.repo.GetSafeTransaction(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if tx.Status != repository.SafeTxAwaitingConfirmations && !tx.CanMoveTo(repository.SafeTxSending) {
+		return nil, repository.ErrSafeTransactionClosed
+	}
+	call, err := decodeCall(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := s.readSafe(ctx, call.safe)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case tx.Nonce < state.nonce:
+		return nil, ErrStaleNonce
+	case tx.Nonce > state.nonce:
+		return nil, ErrNonceNotDue
+	}
+
+	signatures, err := s.signatures(ctx, id, state)
+	if err != nil {
+		return nil, err
+	}
+	threshold := state.threshold
+	if signatures == nil {
+		if tx.Status == repository.SafeTxReady {
+			if err := s.repo.UpdateSafeTransactionStatus(ctx, id, &repository.SafeTransactionUpdate{
+				Status:    repository.SafeTxAwaitingConfirmations,
+				Threshold: &threshold,
+			}); err != nil && !errors.Is(err, repository.ErrSafeTransactionClosed) {
+				return nil, err
+			}
+		}
+		return nil, ErrBelowThreshold
+	}
+	if tx.Status == repository.SafeTxAwaitingConfirmations {
+		// The threshold was lowered since the transaction was proposed
+		if err := s.repo.UpdateSafeTransactionStatus(ctx, id, &repository.SafeTransactionUpdate{
+			Status:    repository.SafeTxReady,
+			Threshold: &threshold,
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	calldata, err := call.execCalldata(signatures)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.UpdateSafeTransactionStatus(ctx, id, &repository.SafeTransactionUpdate{
+		Status:    repository.SafeTxSending,
+		Threshold: &threshold,
+	}); err != nil {
+		return nil, err
+	}
+
+	from, hash, err := s.sender.SendTransfer(ctx, call.safe, new(big.Int), calldata)
+	if err != nil {
+		msg := err.Error()
+		s.logger.Warn("Safe transaction not sent", zap.String("safe_tx_id", id), zap.Error(err))
+		if err := s.repo.UpdateSafeTransactionStatus(ctx, id, &repository.SafeTransactionUpdate{
+			Status:       repository.SafeTxFailed,
+			ErrorMessage: &msg,
+		}); err != nil {
+			return nil, err
+		}
+		return s.repo.GetSafeTransaction(ctx, id)
+	}
+
+	executor := strings.ToLower(from.Hex())
+	txHash := hash.Hex()
+	if err := s.repo.UpdateSafeTransactionStatus(ctx, id, &repository.SafeTransactionUpdate{
+		Status:          repository.SafeTxSubmitted,
+		ExecutorAddress: &executor,
+		TxHash:          &txHash,
+	}); err != nil {
+		s.logger.Error("Safe transaction sent but not recorded",
+			zap.String("safe_tx_id", id),
+			zap.String("tx_hash", txHash),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	if s.tracker != nil {
+		if err := s.tracker.Track(ctx, repository.TrackedRecordSafeTx, id, txHash); err != nil {
+			s.logger.Error("failed to track Safe transaction", zap.String("safe_tx_id", id), zap.String("tx_hash", txHash), zap.Error(err))
+		}
+	}
+
+	s.logger.Info("Safe transaction sent",
+		zap.String("safe_tx_id", id),
+		zap.String("safe", tx.SafeAddress),
+		zap.String("safe_tx_hash", tx.SafeTxHash),
+		zap.String("executor", executor),
+		zap.String("tx_hash", txHash),
+	)
+	return s.repo.GetSafeTransaction(ctx, id)
+}
+
+// signatures concatenates the signatures of threshold current owners by
+// owner address ascending, as the Safe requires. It returns nil if fewer
+// than threshold current owners have confirmed.
+func (s *Service) signatures(ctx context.Context, id string, state *safeState) ([]byte, error) {
+	confirmations, err := s.repo.ListSafeConfirmations(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []byte
+	count := 0
+	for _, c := range confirmations {
+		if count == state.threshold {
+			break
+		}
+		if !state.owners[common.HexToAddress(c.Owner)] {
+			continue // removed as owner since confirming
+		}
+		sig, err := hexutil.Decode(c.Signature)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, sig...)
+		count++
+	}
+	if count < state.threshold {
+		return nil, nil
+	}
+	return out, nil
+}
@@ -0,0 +1,250 @@
+package safetx
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// safeABIJSON is the part of the Safe (v1.3+) ABI the service calls
+const safeABIJSON = `[
+	{"type":"function","name":"getOwners","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"address[]"}]},
+	{"type":"function","name":"getThreshold","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"uint256"}]},
+	{"type":"function","name":"nonce","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"uint256"}]},
+	{"type":"function","name":"execTransaction","stateMutability":"payable","inputs":[
+		{"name":"to","type":"address"},
+		{"name":"value","type":"uint256"},
+		{"name":"data","type":"bytes"},
+		{"name":"operation","type":"uint8"},
+		{"name":"safeTxGas","type":"uint256"},
+		{"name":"baseGas","type":"uint256"},
+		{"name":"gasPrice","type":"uint256"},
+		{"name":"gasToken","type":"address"},
+		{"name":"refundReceiver","type":"address"},
+		{"name":"signatures","type":"bytes"}
+	],"outputs":[{"name":"success","type":"bool"}]}
+]`
+
+var safeABI = mustParseABI(safeABIJSON)
+
+// EIP-712 type hashes of the Safe's domain and SafeTx struct
+var (
+	domainTypeHash = crypto.Keccak256([]byte("EIP712Domain(uint256 chainId,address verifyingContract)"))
+	safeTxTypeHash = crypto.Keccak256([]byte("SafeTx(address to,uint256 value,bytes data,uint8 operation,uint256 safeTxGas,uint256 baseGas,uint256 gasPrice,address gasToken,address refundReceiver,uint256 nonce)"))
+)
+
+func mustParseABI(s string) abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(s))
+	if err != nil {
+		panic(fmt.Sprintf("safetx: invalid Safe ABI: %v", err))
+	}
+	return parsed
+}
+
+// safeCall is a Safe transaction's SafeTx fields decoded from their stored
+// form
+type safeCall struct {
+	safe           common.Address
+	to             common.Address
+	value          *big.Int
+	data           []byte
+	operation      uint8
+	safeTxGas      *big.Int
+	baseGas        *big.Int
+	gasPrice       *big.Int
+	gasToken       common.Address
+	refundReceiver common.Address
+	nonce          *big.Int
+}
+
+// decodeCall decodes tx's SafeTx fields. They are validated when proposed,
+// so an error means the stored transaction is corrupt.
+func decodeCall(tx *repository.SafeTransaction) (*safeCall, error) {
+	data, err := hexutil.Decode(tx.Data)
+	if err != nil {
+		return nil, ErrInvalidData
+	}
+	call := &safeCall{
+		safe:           common.HexToAddress(tx.SafeAddress),
+		to:             common.HexToAddress(tx.To),
+		data:           data,
+		operation:      uint8(tx.Operation),
+		gasToken:       common.HexToAddress(tx.GasToken),
+		refundReceiver: common.HexToAddress(tx.RefundReceiver),
+		nonce:          new(big.Int).SetUint64(tx.Nonce),
+	}
+	for _, f := range []struct {
+		dst **big.Int
+		src string
+	}{
+		{&call.value, tx.Value},
+		{&call.safeTxGas, tx.SafeTxGas},
+		{&call.baseGas, tx.BaseGas},
+		{&call.gasPrice, tx.GasPrice},
+	} {
+		n, ok := new(big.Int).SetString(f.src, 10)
+		if !ok || n.Sign() < 0 {
+			return nil, ErrInvalidAmount
+		}
+		*f.dst = n
+	}
+	return call, nil
+}
+
+// hash returns the EIP-712 safeTxHash of the call on chainID, the digest
+// the owners sign
+func (c *safeCall) hash(chainID int64) common.Hash {
+	domainSeparator := crypto.Keccak256(
+		domainTypeHash,
+		common.LeftPadBytes(big.NewInt(chainID).Bytes(), 32),
+		common.LeftPadBytes(c.safe.Bytes(), 32),
+	)
+	structHash := crypto.Keccak256(
+		safeTxTypeHash,
+		common.LeftPadBytes(c.to.Bytes(), 32),
+		common.LeftPadBytes(c.value.Bytes(), 32),
+		crypto.Keccak256(c.data),
+		common.LeftPadBytes([]byte{c.operation}, 32),
+		common.LeftPadBytes(c.safeTxGas.Bytes(), 32),
+		common.LeftPadBytes(c.baseGas.Bytes(), 32),
+		common.LeftPadBytes(c.gasPrice.Bytes(), 32),
+		common.LeftPadBytes(c.gasToken.Bytes(), 32),
+		common.LeftPadBytes(c.refundReceiver.Bytes(), 32),
+		common.LeftPadBytes(c.nonce.Bytes(), 32),
+	)
+	return crypto.Keccak256Hash([]byte{0x19, 0x01}, domainSeparator, structHash)
+}
+
+// execCalldata encodes the execTransaction call executing c with signatures
+func (c *safeCall) execCalldata(signatures []byte) ([]byte, error) {
+	return safeABI.Pack("execTransaction",
+		c.to, c.value, c.data, c.operation,
+		c.safeTxGas, c.baseGas, c.gasPrice,
+		c.gasToken, c.refundReceiver, signatures,
+	)
+}
+
+// safeState is a Safe's owners, threshold and nonce as read from the chain
+type safeState struct {
+	owners    map[common.Address]bool
+	threshold int
+	nonce     uint64
+}
+
+// readSafe reads safe's owners, threshold and nonce. An address that does
+// not answer them is not a Safe.
+func (s *Service) readSafe(ctx context.Context, safe common.Address) (*safeState, error) {
+	if s.client == nil {
+		return nil, ErrChainUnavailable
+	}
+
+	var owners []common.Address
+	var threshold, nonce *big.Int
+	for _, read := range []struct {
+		method string
+		out    interface{}
+	}{
+		{"getOwners", &owners},
+		{"getThreshold", &threshold},
+		{"nonce", &nonce},
+	} {
+		data, err := safeABI.Pack(read.method)
+		if err != nil {
+			return nil, err
+		}
+		out, err := s.client.CallContract(ctx, ethereum.CallMsg{To: &safe, Data: data}, nil)
+		if err != nil {
+			return nil, fmt.Errorf("calling %s on Safe %s: %w", read.method, safe.Hex(), err)
+		}
+		if len(out) == 0 {
+			return nil, ErrNotSafe
+		}
+		if err := safeABI.UnpackIntoInterface(read.out, read.method, out); err != nil {
+			return nil, ErrNotSafe
+		}
+	}
+	if len(owners) == 0 || threshold.Sign() <= 0 || !threshold.IsInt64() || !nonce.IsUint64() {
+		return nil, ErrNotSafe
+	}
+
+	state := &safeState{
+		owners:    make(map[common.Address]bool, len(owners)),
+		threshold: int(threshold.Int64()),
+		nonce:     nonce.Uint64(),
+	}
+	for _, owner := range owners {
+		state.owners[owner] = true
+	}
+	return state, nil
+}
+
+// recoverOwner returns the owner who signed hash and the signature in the
+// form execTransaction verifies it. An EIP-712 signature (v 27/28, or 0/1)
+// is recovered from hash itself; an eth_sign one from the prefixed message
+// hash, and is stored with v 31/32 as the Safe expects. A v 27/28
+// signature that does not recover to an owner is tried as eth_sign, since
+// wallets sign personal messages with those values too. Contract
+// signatures and approved hashes are not supported.
+func recoverOwner(hash common.Hash, sig []byte, owners map[common.Address]bool) (common.Address, []byte, error) {
+	if len(sig) != 65 {
+		return common.Address{}, nil, ErrInvalidSignature
+	}
+	v := sig[64]
+	if v < 27 {
+		v += 27
+	}
+
+	switch v {
+	case 27, 28:
+		if signer, err := recoverSigner(hash.Bytes(), sig, v-27); err == nil && owners[signer] {
+			return signer, withV(sig, v), nil
+		}
+		signer, err := recoverSigner(accounts.TextHash(hash.Bytes()), sig, v-27)
+		if err != nil {
+			return common.Address{}, nil, ErrInvalidSignature
+		}
+		if !owners[signer] {
+			return signer, nil, ErrNotOwner
+		}
+		return signer, withV(sig, v+4), nil
+	case 31, 32:
+		signer, err := recoverSigner(accounts.TextHash(hash.Bytes()), sig, v-31)
+		if err != nil {
+			return common.Address{}, nil, ErrInvalidSignature
+		}
+		if !owners[signer] {
+			return signer, nil, ErrNotOwner
+		}
+		return signer, withV(sig, v), nil
+	}
+	return common.Address{}, nil, ErrInvalidSignature
+}
+
+// recoverSigner recovers the address that signed digest with sig, using
+// recovery id recID in place of sig's v
+func recoverSigner(digest, sig []byte, recID byte) (common.Address, error) {
+	raw := withV(sig, recID)
+	pub, err := crypto.SigToPub(digest, raw)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return crypto.PubkeyToAddress(*pub), nil
+}
+
+// withV returns a copy of sig with its v byte set to v
+func withV(sig []byte, v byte) []byte {
+	out := make([]byte, len(sig))
+	copy(out, sig)
+	out[64] = v
+	return out
+}
@@ -0,0 +1,247 @@
+// Package safetx coordinates transactions of the Safe (Gnosis Safe)
+// multisigs that hold the treasury and admin roles. A transaction is
+// proposed with its SafeTx fields and the Safe's EIP-712 safeTxHash; owners
+// sign that hash off-chain and each signature is checked against the Safe's
+// current owners as it is collected. Once the Safe's threshold of owners
+// have confirmed, execTransaction is sent with their signatures from a
+// relayer key and the confirmation tracker reconciles it against its
+// receipt (chain.SafeTxRecordHandler).
+package safetx
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Safe transaction errors
+var (
+	ErrInvalidAddress    = errors.New("safe, to, gas_token and refund_receiver must be addresses")
+	ErrInvalidAmount     = errors.New("value, safe_tx_gas, base_gas and gas_price must be non-negative integers")
+	ErrInvalidData       = errors.New("data must be 0x-prefixed hex")
+	ErrInvalidOperation  = errors.New("operation must be 0 (call) or 1 (delegatecall)")
+	ErrNotSafe           = errors.New("address is not a Safe on this chain")
+	ErrStaleNonce        = errors.New("the Safe has already used this nonce")
+	ErrNonceNotDue       = errors.New("the Safe must execute its transactions with lower nonces first")
+	ErrInvalidSignature  = errors.New("signature must be 65 bytes of an EIP-712 or eth_sign signature of safe_tx_hash")
+	ErrNotOwner          = errors.New("signer is not an owner of the Safe")
+	ErrBelowThreshold    = errors.New("fewer owners than the Safe's threshold have confirmed")
+	ErrChainUnavailable  = errors.New("no RPC endpoint for the Safe's chain")
+	ErrExecutionDisabled = errors.New("Safe execution is not enabled: no relayer key")
+)
+
+// Client is the chain access the service needs (implemented by
+// chain.FailoverClient)
+type Client interface {
+	CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error)
+}
+
+// Request describes a Safe transaction to propose. Amounts are decimal wei;
+// unset gas fields, gas token and refund receiver are zero, the Safe's
+// default of no refund. An unset nonce is the Safe's next one. Signature,
+// if set, is the proposer's own confirmation.
+type Request struct {
+	SafeAddress    string
+	To             string
+	Value          string
+	Data           string
+	Operation      repository.SafeOperation
+	SafeTxGas      string
+	BaseGas        string
+	GasPrice       string
+	GasToken       string
+	RefundReceiver string
+	Nonce          *uint64
+	Description    string
+	ProposedBy     string
+	Signature      string
+}
+
+// Service proposes, confirms and executes Safe transactions
+type Service struct {
+	repo    repository.SafeTransactionRepository
+	client  Client
+	sender  Sender
+	tracker Tracker
+	logger  *zap.Logger
+	chainID int64
+}
+
+// NewService creates a new Safe transaction service
+func NewService(repo repository.SafeTransactionRepository, logger *zap.Logger, chainID int64) *Service {
+	return &Service{repo: repo, logger: logger, chainID: chainID}
+}
+
+// SetClient sets the chain client Safe owners, thresholds and nonces are
+// read through. Without one, proposing, confirming and executing fail with
+// ErrChainUnavailable.
+func (s *Service) SetClient(client Client) {
+	s.client = client
+}
+
+// Propose validates req against the Safe's state and records it awaiting
+// confirmations
+func (s *Service) Propose(ctx context.Context, req Request) (*repository.SafeTransaction, error) {
+	tx, err := newTransaction(req)
+	if err != nil {
+		return nil, err
+	}
+	tx.ChainID = s.chainID
+
+	call, err := decodeCall(tx)
+	if err != nil {
+		return nil, err
+	}
+	state, err := s.readSafe(ctx, call.safe)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case req.Nonce == nil:
+		tx.Nonce = state.nonce
+	case *req.Nonce < state.nonce:
+		return nil, ErrStaleNonce
+	}
+	call.nonce.SetUint64(tx.Nonce)
+	tx.SafeTxHash = call.hash(s.chainID).Hex()
+	tx.Threshold = state.threshold
+
+	if err := s.repo.CreateSafeTransaction(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Safe transaction proposed",
+		zap.String("safe_tx_id", tx.ID),
+		zap.String("safe", tx.SafeAddress),
+		zap.String("to", tx.To),
+		zap.Uint64("nonce", tx.Nonce),
+		zap.String("safe_tx_hash", tx.SafeTxHash),
+		zap.String("proposed_by", tx.ProposedBy),
+	)
+
+	if req.Signature != "" {
+		return s.Confirm(ctx, tx.ID, req.Signature)
+	}
+	return tx, nil
+}
+
+// Confirm records an owner's signature of the transaction's safeTxHash. The
+// owner is the signer; the signature must recover to a current owner of the
+// Safe.
+func (s *Service) Confirm(ctx context.Context, id, signature string) (*repository.SafeTransaction, error) {
+	tx, err := s.repo.GetSafeTransaction(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !tx.TakesConfirmations() {
+		return nil, repository.ErrSafeTransactionClosed
+	}
+	sig, err := hexutil.Decode(signature)
+	if err != nil {
+		return nil, ErrInvalidSignature
+	}
+
+	state, err := s.readSafe(ctx, common.HexToAddress(tx.SafeAddress))
+	if err != nil {
+		return nil, err
+	}
+	owner, sig, err := recoverOwner(common.HexToHash(tx.SafeTxHash), sig, state.owners)
+	if err != nil {
+		return nil, err
+	}
+
+	ownerHex := strings.ToLower(owner.Hex())
+	if err := s.repo.AddSafeConfirmation(ctx, &repository.SafeConfirmation{
+		SafeTxID:  id,
+		Owner:     ownerHex,
+		Signature: hexutil.Encode(sig),
+	}); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Safe transaction confirmed",
+		zap.String("safe_tx_id", id),
+		zap.String("owner", ownerHex),
+	)
+	return s.repo.GetSafeTransaction(ctx, id)
+}
+
+// Cancel withdraws a transaction that has not been sent. The Safe itself
+// is unaffected: owners who signed could still execute it elsewhere until
+// another transaction uses its nonce.
+func (s *Service) Cancel(ctx context.Context, id, cancelledBy, reason string) (*repository.SafeTransaction, error) {
+	msg := "cancelled by " + cancelledBy
+	if reason != "" {
+		msg += ": " + reason
+	}
+	if err := s.repo.UpdateSafeTransactionStatus(ctx, id, &repository.SafeTransactionUpdate{
+		Status:       repository.SafeTxCancelled,
+		ErrorMessage: &msg,
+	}); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Safe transaction cancelled", zap.String("safe_tx_id", id), zap.String("cancelled_by", cancelledBy))
+	return s.repo.GetSafeTransaction(ctx, id)
+}
+
+// newTransaction validates req and normalises it into a transaction
+// awaiting confirmations
+func newTransaction(req Request) (*repository.SafeTransaction, error) {
+	tx := &repository.SafeTransaction{
+		SafeAddress:    req.SafeAddress,
+		To:             req.To,
+		Value:          orDefault(req.Value, "0"),
+		Data:           strings.ToLower(orDefault(req.Data, "0x")),
+		Operation:      req.Operation,
+		SafeTxGas:      orDefault(req.SafeTxGas, "0"),
+		BaseGas:        orDefault(req.BaseGas, "0"),
+		GasPrice:       orDefault(req.GasPrice, "0"),
+		GasToken:       orDefault(req.GasToken, zeroAddress),
+		RefundReceiver: orDefault(req.RefundReceiver, zeroAddress),
+		Status:         repository.SafeTxAwaitingConfirmations,
+		Description:    req.Description,
+		ProposedBy:     req.ProposedBy,
+	}
+	if req.Nonce != nil {
+		tx.Nonce = *req.Nonce
+	}
+
+	for _, addr := range []*string{&tx.SafeAddress, &tx.To, &tx.GasToken, &tx.RefundReceiver} {
+		if !common.IsHexAddress(*addr) {
+			return nil, ErrInvalidAddress
+		}
+		*addr = strings.ToLower(common.HexToAddress(*addr).Hex())
+	}
+	for _, amount := range []string{tx.Value, tx.SafeTxGas, tx.BaseGas, tx.GasPrice} {
+		n, ok := new(big.Int).SetString(amount, 10)
+		if !ok || n.Sign() < 0 || n.BitLen() > 256 {
+			return nil, ErrInvalidAmount
+		}
+	}
+	if _, err := hexutil.Decode(tx.Data); err != nil {
+		return nil, ErrInvalidData
+	}
+	if tx.Operation != repository.SafeOperationCall && tx.Operation != repository.SafeOperationDelegateCall {
+		return nil, ErrInvalidOperation
+	}
+	return tx, nil
+}
+
+// zeroAddress is the Safe's "none" for gas token and refund receiver
+var zeroAddress = strings.ToLower(common.Address{}.Hex())
+
+func orDefault(v, def string) string {
+	if v == "" {
+		return def
+	}
+	return v
+}
@@ -0,0 +1,273 @@
+package safetx_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/safetx"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/storage/memory"
+)
+
+const chainID = 11155111
+
+var (
+	safeAddress = common.HexToAddress("0x5afe00000000000000000000000000000000cafe")
+	target      = common.HexToAddress("0x00000000000000000000000000000000000000aa")
+)
+
+// fakeSafe answers the Safe's getOwners, getThreshold and nonce calls
+type fakeSafe struct {
+	owners    []common.Address
+	threshold int64
+	nonce     uint64
+}
+
+var (
+	addressesType, _ = abi.NewType("address[]", "", nil)
+	uintType, _      = abi.NewType("uint256", "", nil)
+)
+
+func (f *fakeSafe) CallContract(_ context.Context, call ethereum.CallMsg, _ *big.Int) ([]byte, error) {
+	if call.To == nil || *call.To != safeAddress {
+		return nil, nil // no code
+	}
+	switch hexutil.Encode(call.Data[:4]) {
+	case "0xa0e67e2b": // getOwners()
+		return abi.Arguments{{Type: addressesType}}.Pack(f.owners)
+	case "0xe75235b8": // getThreshold()
+		return abi.Arguments{{Type: uintType}}.Pack(big.NewInt(f.threshold))
+	case "0xaffed0e0": // nonce()
+		return abi.Arguments{{Type: uintType}}.Pack(new(big.Int).SetUint64(f.nonce))
+	}
+	return nil, nil
+}
+
+// fakeSender records execTransaction calls
+type fakeSender struct {
+	to   common.Address
+	data []byte
+}
+
+func (f *fakeSender) SendTransfer(_ context.Context, to common.Address, _ *big.Int, data []byte) (common.Address, common.Hash, error) {
+	f.to, f.data = to, data
+	return common.HexToAddress("0x00000000000000000000000000000000000000e0"), common.HexToHash("0xfeed"), nil
+}
+
+type fakeTracker struct{ tracked []string }
+
+func (f *fakeTracker) Track(_ context.Context, recordType, recordID, _ string) error {
+	f.tracked = append(f.tracked, recordType+":"+recordID)
+	return nil
+}
+
+type owner struct {
+	key  *ecdsa.PrivateKey
+	addr common.Address
+}
+
+// newOwners generates n owner keys sorted by address
+func newOwners(t *testing.T, n int) []owner {
+	t.Helper()
+	out := make([]owner, n)
+	for i := range out {
+		key, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		out[i] = owner{key: key, addr: crypto.PubkeyToAddress(key.PublicKey)}
+	}
+	sort.Slice(out, func(i, j int) bool { return bytes.Compare(out[i].addr.Bytes(), out[j].addr.Bytes()) < 0 })
+	return out
+}
+
+// signTyped signs hash as an EIP-712 signature (v 27/28)
+func signTyped(t *testing.T, o owner, hash string) string {
+	t.Helper()
+	sig, err := crypto.Sign(common.HexToHash(hash).Bytes(), o.key)
+	require.NoError(t, err)
+	sig[64] += 27
+	return hexutil.Encode(sig)
+}
+
+// signMessage signs hash the way eth_sign / personal_sign does
+func signMessage(t *testing.T, o owner, hash string) string {
+	t.Helper()
+	sig, err := crypto.Sign(accounts.TextHash(common.HexToHash(hash).Bytes()), o.key)
+	require.NoError(t, err)
+	sig[64] += 27
+	return hexutil.Encode(sig)
+}
+
+func newService(t *testing.T, safe *fakeSafe) (*safetx.Service, *memory.Store) {
+	t.Helper()
+	store := memory.NewStore()
+	svc := safetx.NewService(store.SafeTxs, zap.NewNop(), chainID)
+	svc.SetClient(safe)
+	return svc, store
+}
+
+func propose(t *testing.T, svc *safetx.Service) *repository.SafeTransaction {
+	t.Helper()
+	tx, err := svc.Propose(context.Background(), safetx.Request{
+		SafeAddress: safeAddress.Hex(),
+		To:          target.Hex(),
+		Value:       "1000000000000000000",
+		Data:        "0xa9059cbb",
+		Description: "Fund the grants multisig",
+		ProposedBy:  "ops",
+	})
+	require.NoError(t, err)
+	return tx
+}
+
+func TestPropose_HashesSafeTx(t *testing.T) {
+	owners := newOwners(t, 2)
+	svc, _ := newService(t, &fakeSafe{owners: []common.Address{owners[0].addr, owners[1].addr}, threshold: 2, nonce: 7})
+
+	tx := propose(t, svc)
+	assert.Equal(t, repository.SafeTxAwaitingConfirmations, tx.Status)
+	assert.Equal(t, uint64(7), tx.Nonce, "the nonce defaults to the Safe's next")
+	assert.Equal(t, 2, tx.Threshold)
+	assert.Equal(t, strings.ToLower(common.Address{}.Hex()), tx.GasToken)
+
+	typed := apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": {{Name: "chainId", Type: "uint256"}, {Name: "verifyingContract", Type: "address"}},
+			"SafeTx": {
+				{Name: "to", Type: "address"}, {Name: "value", Type: "uint256"}, {Name: "data", Type: "bytes"},
+				{Name: "operation", Type: "uint8"}, {Name: "safeTxGas", Type: "uint256"}, {Name: "baseGas", Type: "uint256"},
+				{Name: "gasPrice", Type: "uint256"}, {Name: "gasToken", Type: "address"}, {Name: "refundReceiver", Type: "address"},
+				{Name: "nonce", Type: "uint256"},
+			},
+		},
+		PrimaryType: "SafeTx",
+		Domain:      apitypes.TypedDataDomain{ChainId: math.NewHexOrDecimal256(chainID), VerifyingContract: safeAddress.Hex()},
+		Message: apitypes.TypedDataMessage{
+			"to": target.Hex(), "value": "1000000000000000000", "data": "0xa9059cbb", "operation": "0",
+			"safeTxGas": "0", "baseGas": "0", "gasPrice": "0",
+			"gasToken": common.Address{}.Hex(), "refundReceiver": common.Address{}.Hex(), "nonce": "7",
+		},
+	}
+	want, _, err := apitypes.TypedDataAndHash(typed)
+	require.NoError(t, err)
+	assert.Equal(t, hexutil.Encode(want), tx.SafeTxHash)
+}
+
+func TestPropose_Validates(t *testing.T) {
+	owners := newOwners(t, 1)
+	svc, _ := newService(t, &fakeSafe{owners: []common.Address{owners[0].addr}, threshold: 1, nonce: 3})
+	ctx := context.Background()
+
+	stale := uint64(2)
+	for name, tc := range map[string]struct {
+		req  safetx.Request
+		want error
+	}{
+		"bad target":     {safetx.Request{SafeAddress: safeAddress.Hex(), To: "nope"}, safetx.ErrInvalidAddress},
+		"negative value": {safetx.Request{SafeAddress: safeAddress.Hex(), To: target.Hex(), Value: "-1"}, safetx.ErrInvalidAmount},
+		"bad data":       {safetx.Request{SafeAddress: safeAddress.Hex(), To: target.Hex(), Data: "0xzz"}, safetx.ErrInvalidData},
+		"bad operation":  {safetx.Request{SafeAddress: safeAddress.Hex(), To: target.Hex(), Operation: 2}, safetx.ErrInvalidOperation},
+		"stale nonce":    {safetx.Request{SafeAddress: safeAddress.Hex(), To: target.Hex(), Nonce: &stale}, safetx.ErrStaleNonce},
+		"not a Safe":     {safetx.Request{SafeAddress: target.Hex(), To: target.Hex()}, safetx.ErrNotSafe},
+	} {
+		_, err := svc.Propose(ctx, tc.req)
+		assert.ErrorIs(t, err, tc.want, name)
+	}
+
+	_ = propose(t, svc)
+	_, err := svc.Propose(ctx, safetx.Request{
+		SafeAddress: safeAddress.Hex(), To: target.Hex(), Value: "1000000000000000000", Data: "0xa9059cbb", ProposedBy: "ops",
+	})
+	assert.ErrorIs(t, err, repository.ErrSafeTransactionExists, "the same SafeTx and nonce hash the same")
+}
+
+func TestConfirmAndExecute(t *testing.T) {
+	ctx := context.Background()
+	owners := newOwners(t, 3)
+	safe := &fakeSafe{owners: []common.Address{owners[0].addr, owners[1].addr, owners[2].addr}, threshold: 2, nonce: 0}
+	svc, store := newService(t, safe)
+	sender, tracker := &fakeSender{}, &fakeTracker{}
+	tx := propose(t, svc)
+
+	_, err := svc.Execute(ctx, tx.ID)
+	assert.ErrorIs(t, err, safetx.ErrExecutionDisabled)
+	svc.SetSender(sender, tracker)
+
+	outsider := newOwners(t, 1)[0]
+	_, err = svc.Confirm(ctx, tx.ID, signTyped(t, outsider, tx.SafeTxHash))
+	assert.ErrorIs(t, err, safetx.ErrNotOwner)
+	_, err = svc.Confirm(ctx, tx.ID, "0x1234")
+	assert.ErrorIs(t, err, safetx.ErrInvalidSignature)
+
+	// Owners 2 and 0 confirm, one by eth_sign
+	got, err := svc.Confirm(ctx, tx.ID, signMessage(t, owners[2], tx.SafeTxHash))
+	require.NoError(t, err)
+	assert.Equal(t, 1, got.Confirmations)
+	_, err = svc.Execute(ctx, tx.ID)
+	assert.ErrorIs(t, err, safetx.ErrBelowThreshold)
+
+	_, err = svc.Confirm(ctx, tx.ID, signTyped(t, owners[2], tx.SafeTxHash))
+	assert.ErrorIs(t, err, repository.ErrSafeConfirmationExists)
+
+	got, err = svc.Confirm(ctx, tx.ID, signTyped(t, owners[0], tx.SafeTxHash))
+	require.NoError(t, err)
+	assert.Equal(t, 2, got.Confirmations)
+	assert.Equal(t, repository.SafeTxReady, got.Status)
+
+	// A raised threshold sends it back for another confirmation
+	safe.threshold = 3
+	_, err = svc.Execute(ctx, tx.ID)
+	assert.ErrorIs(t, err, safetx.ErrBelowThreshold)
+	got, err = store.SafeTxs.GetSafeTransaction(ctx, tx.ID)
+	require.NoError(t, err)
+	assert.Equal(t, repository.SafeTxAwaitingConfirmations, got.Status)
+	assert.Equal(t, 3, got.Threshold)
+	safe.threshold = 2
+
+	safe.nonce = 1
+	_, err = svc.Execute(ctx, tx.ID)
+	assert.ErrorIs(t, err, safetx.ErrStaleNonce)
+	safe.nonce = 0
+
+	got, err = svc.Execute(ctx, tx.ID)
+	require.NoError(t, err)
+	assert.Equal(t, repository.SafeTxSubmitted, got.Status)
+	require.NotNil(t, got.TxHash)
+	assert.Equal(t, []string{repository.TrackedRecordSafeTx + ":" + tx.ID}, tracker.tracked)
+
+	// execTransaction goes to the Safe with the signatures by owner ascending
+	assert.Equal(t, safeAddress, sender.to)
+	assert.Equal(t, "0x6a761202", hexutil.Encode(sender.data[:4]))
+	method, err := abi.JSON(strings.NewReader(`[{"type":"function","name":"execTransaction","inputs":[
+		{"name":"to","type":"address"},{"name":"value","type":"uint256"},{"name":"data","type":"bytes"},
+		{"name":"operation","type":"uint8"},{"name":"safeTxGas","type":"uint256"},{"name":"baseGas","type":"uint256"},
+		{"name":"gasPrice","type":"uint256"},{"name":"gasToken","type":"address"},{"name":"refundReceiver","type":"address"},
+		{"name":"signatures","type":"bytes"}],"outputs":[]}]`))
+	require.NoError(t, err)
+	args, err := method.Methods["execTransaction"].Inputs.Unpack(sender.data[4:])
+	require.NoError(t, err)
+	assert.Equal(t, target, args[0])
+	sigs := args[9].([]byte)
+	require.Len(t, sigs, 130)
+	assert.Equal(t, signTyped(t, owners[0], tx.SafeTxHash), hexutil.Encode(sigs[:65]))
+	assert.Contains(t, []byte{31, 32}, sigs[129], "the eth_sign signature is marked with v+4")
+
+	_, err = svc.Cancel(ctx, tx.ID, "ops", "")
+	assert.ErrorIs(t, err, repository.ErrSafeTransactionClosed, "a sent transaction cannot be cancelled")
+}
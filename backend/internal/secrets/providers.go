@@ -0,0 +1,205 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/awsv4"
+)
+
+// Env reads secrets from environment variables of the same name
+type Env struct{}
+
+// Name implements Provider
+func (Env) Name() string { return "env" }
+
+// Fetch implements Provider
+func (Env) Fetch(_ context.Context, names []string) (map[string]string, error) {
+	values := make(map[string]string, len(names))
+	for _, name := range names {
+		if v, ok := os.LookupEnv(name); ok && v != "" {
+			values[name] = v
+		}
+	}
+	return values, nil
+}
+
+// Files reads each secret from a file named after it in Dir, as mounted by
+// Docker and Kubernetes secrets. A trailing newline is dropped.
+type Files struct {
+	Dir string
+}
+
+// Name implements Provider
+func (Files) Name() string { return "file" }
+
+// Fetch implements Provider
+func (f Files) Fetch(_ context.Context, names []string) (map[string]string, error) {
+	if _, err := os.Stat(f.Dir); err != nil {
+		return nil, fmt.Errorf("secrets directory: %w", err)
+	}
+	values := make(map[string]string, len(names))
+	for _, name := range names {
+		raw, err := os.ReadFile(filepath.Join(f.Dir, name))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading secret %s: %w", name, err)
+		}
+		values[name] = strings.TrimRight(string(raw), "\r\n")
+	}
+	return values, nil
+}
+
+// Vault reads secrets from one HashiCorp Vault KV version 2 secret, whose
+// keys are the secret names
+type Vault struct {
+	Address    string // e.g. https://vault.internal:8200
+	Token      string
+	Mount      string // default "secret"
+	Path       string // e.g. "nexus/backend"
+	HTTPClient *http.Client
+}
+
+// Name implements Provider
+func (Vault) Name() string { return "vault" }
+
+// Fetch implements Provider
+func (v Vault) Fetch(ctx context.Context, names []string) (map[string]string, error) {
+	if v.Address == "" || v.Token == "" || v.Path == "" {
+		return nil, errors.New("vault secrets require an address, a token and a path")
+	}
+	mount := v.Mount
+	if mount == "" {
+		mount = "secret"
+	}
+	url := fmt.Sprintf("%s/v1/%s/data/%s", strings.TrimRight(v.Address, "/"), mount, strings.Trim(v.Path, "/"))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-Vault-Token", v.Token)
+
+	client := v.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("reading vault secret: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("reading vault secret: HTTP %d", resp.StatusCode)
+	}
+
+	var out struct {
+		Data struct {
+			Data map[string]interface{} `json:"data"`
+		} `json:"data"`
+	}
+	if err := decodeJSON(resp, &out); err != nil {
+		return nil, fmt.Errorf("reading vault secret: %w", err)
+	}
+	values := make(map[string]string, len(names))
+	for _, name := range names {
+		if s, ok := out.Data.Data[name].(string); ok && s != "" {
+			values[name] = s
+		}
+	}
+	return values, nil
+}
+
+// ssmBatchSize is the most names GetParameters accepts
+const ssmBatchSize = 10
+
+// SSM reads secrets from AWS Systems Manager Parameter Store, as SecureString
+// (or String) parameters named Prefix + secret name
+type SSM struct {
+	Client *awsv4.Client // Service "ssm"
+	Prefix string        // e.g. "/nexus/backend/"
+}
+
+// Name implements Provider
+func (SSM) Name() string { return "ssm" }
+
+// Fetch implements Provider
+func (s SSM) Fetch(ctx context.Context, names []string) (map[string]string, error) {
+	if err := s.Client.Validate(); err != nil {
+		return nil, err
+	}
+	values := make(map[string]string, len(names))
+	for start := 0; start < len(names); start += ssmBatchSize {
+		batch := names[start:min(start+ssmBatchSize, len(names))]
+		params := make([]string, len(batch))
+		for i, name := range batch {
+			params[i] = s.Prefix + name
+		}
+
+		// Missing parameters are listed in InvalidParameters, not an error
+		var out struct {
+			Parameters []struct {
+				Name  string `json:"Name"`
+				Value string `json:"Value"`
+			} `json:"Parameters"`
+		}
+		if err := s.Client.Call(ctx, "AmazonSSM.GetParameters", map[string]interface{}{
+			"Names":          params,
+			"WithDecryption": true,
+		}, &out); err != nil {
+			return nil, fmt.Errorf("reading SSM parameters: %w", err)
+		}
+		for _, p := range out.Parameters {
+			values[strings.TrimPrefix(p.Name, s.Prefix)] = p.Value
+		}
+	}
+	return values, nil
+}
+
+// WithFallback reads from primary, then from fallback for the names primary
+// does not hold (e.g. Vault first, then the environment during a migration)
+func WithFallback(primary, fallback Provider) Provider {
+	return fallbackProvider{primary: primary, fallback: fallback}
+}
+
+type fallbackProvider struct {
+	primary, fallback Provider
+}
+
+func (p fallbackProvider) Name() string { return p.primary.Name() }
+
+func (p fallbackProvider) Fetch(ctx context.Context, names []string) (map[string]string, error) {
+	values, err := p.primary.Fetch(ctx, names)
+	if err != nil {
+		return nil, err
+	}
+	var missing []string
+	for _, name := range names {
+		if _, ok := values[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) == 0 {
+		return values, nil
+	}
+	rest, err := p.fallback.Fetch(ctx, missing)
+	if err != nil {
+		return nil, err
+	}
+	for name, v := range rest {
+		values[name] = v
+	}
+	return values, nil
+}
+
+func decodeJSON(resp *http.Response, out interface{}) error {
+	return json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(out)
+}
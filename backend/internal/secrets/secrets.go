@@ -0,0 +1,138 @@
+// Package secrets loads credentials (Stripe, Sumsub, relayer, admin and
+// health tokens, field encryption keys, session signing key) from a provider
+// (environment, files, HashiCorp Vault, AWS SSM Parameter Store) and
+// refreshes them periodically, so a rotated secret takes effect without a
+// redeploy.
+package secrets
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// DefaultRefreshInterval is how often Run re-reads the secrets
+const DefaultRefreshInterval = 5 * time.Minute
+
+// Names are the secrets the backend reads through the store
+var Names = []string{
+	"ADMIN_API_TOKEN",
+	"HEALTH_API_TOKEN",
+	"CONTRACT_DEPLOY_TOKEN",
+	"STRIPE_SECRET_KEY",
+	"STRIPE_WEBHOOK_SECRET",
+	"SUMSUB_APP_TOKEN",
+	"SUMSUB_SECRET_KEY",
+	"SUMSUB_WEBHOOK_SECRET",
+	"SUMSUB_WEBHOOK_SECRET_PREVIOUS",
+	"RELAYER_PRIVATE_KEY",
+	"RELAYER_PRIVATE_KEYS",
+	"RELAYER_KEYSTORE_PASSWORD",
+	"FIELD_ENCRYPTION_KEYS",
+	"DEPOSIT_WALLET_SEED",
+	"COMPLIANCE_WEBHOOK_SECRET",
+	"CONTRACT_WEBHOOK_SECRET",
+	"SESSION_SIGNING_KEY",
+	"SMTP_PASSWORD",
+}
+
+// Provider reads secrets by name. Names it does not hold are left out of the
+// result; an error means none of the values could be read.
+type Provider interface {
+	Fetch(ctx context.Context, names []string) (map[string]string, error)
+	Name() string
+}
+
+// Store holds the current value of each secret
+type Store struct {
+	provider Provider
+	names    []string
+	logger   *zap.Logger
+
+	mu       sync.RWMutex
+	values   map[string]string
+	loaded   bool
+	onChange []func(name string)
+}
+
+// NewStore creates a store for names; call Refresh to load them
+func NewStore(provider Provider, names []string, logger *zap.Logger) *Store {
+	return &Store{
+		provider: provider,
+		names:    names,
+		logger:   logger,
+		values:   make(map[string]string),
+	}
+}
+
+// Get returns a secret's current value ("" if unset)
+func (s *Store) Get(name string) string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.values[name]
+}
+
+// OnChange registers fn to run after a refresh changes a secret. It must not
+// block.
+func (s *Store) OnChange(fn func(name string)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onChange = append(s.onChange, fn)
+}
+
+// Refresh re-reads every secret. On error the previous values are kept.
+func (s *Store) Refresh(ctx context.Context) error {
+	values, err := s.provider.Fetch(ctx, s.names)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	var changed []string
+	for _, name := range s.names {
+		if s.loaded && values[name] != s.values[name] {
+			changed = append(changed, name)
+		}
+	}
+	s.loaded = true
+	// Only listed names are kept
+	s.values = make(map[string]string, len(s.names))
+	for _, name := range s.names {
+		if v, ok := values[name]; ok {
+			s.values[name] = v
+		}
+	}
+	hooks := append([]func(string){}, s.onChange...)
+	s.mu.Unlock()
+
+	for _, name := range changed {
+		s.logger.Info("secret changed", zap.String("name", name), zap.String("provider", s.provider.Name()))
+		for _, fn := range hooks {
+			fn(name)
+		}
+	}
+	return nil
+}
+
+// Run refreshes the secrets every interval (DefaultRefreshInterval if zero)
+// until ctx is cancelled
+func (s *Store) Run(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultRefreshInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if err := s.Refresh(ctx); err != nil && ctx.Err() == nil {
+			s.logger.Warn("secrets refresh failed", zap.String("provider", s.provider.Name()), zap.Error(err))
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package secrets_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/awsv4"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/secrets"
+)
+
+// fakeProvider serves values until err is set
+type fakeProvider struct {
+	values map[string]string
+	err    error
+}
+
+func (p *fakeProvider) Name() string { return "fake" }
+
+func (p *fakeProvider) Fetch(_ context.Context, names []string) (map[string]string, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+	out := make(map[string]string)
+	for _, name := range names {
+		if v, ok := p.values[name]; ok {
+			out[name] = v
+		}
+	}
+	return out, nil
+}
+
+func TestStore_RefreshAndRotation(t *testing.T) {
+	ctx := context.Background()
+	provider := &fakeProvider{values: map[string]string{"STRIPE_SECRET_KEY": "sk_1", "OTHER": "ignored"}}
+	store := secrets.NewStore(provider, []string{"STRIPE_SECRET_KEY", "SUMSUB_APP_TOKEN"}, zap.NewNop())
+
+	var changed []string
+	store.OnChange(func(name string) { changed = append(changed, name) })
+
+	require.NoError(t, store.Refresh(ctx))
+	assert.Equal(t, "sk_1", store.Get("STRIPE_SECRET_KEY"))
+	assert.Equal(t, "", store.Get("OTHER"), "only listed names are loaded")
+	assert.Empty(t, changed, "the first load is not a change")
+
+	provider.values = map[string]string{"STRIPE_SECRET_KEY": "sk_2", "SUMSUB_APP_TOKEN": "tok"}
+	require.NoError(t, store.Refresh(ctx))
+	assert.Equal(t, "sk_2", store.Get("STRIPE_SECRET_KEY"))
+	assert.Equal(t, []string{"STRIPE_SECRET_KEY", "SUMSUB_APP_TOKEN"}, changed)
+
+	// A failed refresh keeps the last values
+	provider.err = errors.New("vault sealed")
+	assert.Error(t, store.Refresh(ctx))
+	assert.Equal(t, "sk_2", store.Get("STRIPE_SECRET_KEY"))
+}
+
+func TestFiles(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "STRIPE_SECRET_KEY"), []byte("sk_file\n"), 0o600))
+
+	values, err := secrets.Files{Dir: dir}.Fetch(context.Background(), []string{"STRIPE_SECRET_KEY", "SUMSUB_APP_TOKEN"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"STRIPE_SECRET_KEY": "sk_file"}, values)
+
+	_, err = secrets.Files{Dir: filepath.Join(dir, "missing")}.Fetch(context.Background(), nil)
+	assert.Error(t, err)
+}
+
+func TestVault(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/kv/data/nexus/backend", r.URL.Path)
+		if r.Header.Get("X-Vault-Token") != "s.token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": map[string]interface{}{"data": map[string]interface{}{"STRIPE_SECRET_KEY": "sk_vault"}},
+		})
+	}))
+	defer server.Close()
+
+	vault := secrets.Vault{Address: server.URL, Token: "s.token", Mount: "kv", Path: "nexus/backend"}
+	values, err := vault.Fetch(context.Background(), []string{"STRIPE_SECRET_KEY", "SUMSUB_APP_TOKEN"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"STRIPE_SECRET_KEY": "sk_vault"}, values)
+
+	vault.Token = "wrong"
+	_, err = vault.Fetch(context.Background(), []string{"STRIPE_SECRET_KEY"})
+	assert.ErrorContains(t, err, "403")
+}
+
+func TestSSM_BatchesAndFallsBack(t *testing.T) {
+	var batches [][]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "AmazonSSM.GetParameters", r.Header.Get("X-Amz-Target"))
+		assert.Contains(t, r.Header.Get("Authorization"), "/us-east-1/ssm/aws4_request")
+		var in struct {
+			Names          []string
+			WithDecryption bool
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&in))
+		assert.True(t, in.WithDecryption)
+		batches = append(batches, in.Names)
+
+		var params []map[string]string
+		for _, name := range in.Names {
+			if strings.HasSuffix(name, "_0") || name == "/nexus/STRIPE_SECRET_KEY" {
+				params = append(params, map[string]string{"Name": name, "Value": "v:" + name})
+			}
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"Parameters": params})
+	}))
+	defer server.Close()
+
+	names := []string{"STRIPE_SECRET_KEY", "SUMSUB_APP_TOKEN"}
+	for i := 0; i < 10; i++ {
+		names = append(names, "EXTRA_"+string(rune('a'+i))+"_0")
+	}
+	ssm := secrets.SSM{
+		Client: &awsv4.Client{
+			Service: "ssm", Region: "us-east-1", Endpoint: server.URL,
+			Credentials: awsv4.Credentials{AccessKeyID: "AKID", SecretAccessKey: "secret"},
+		},
+		Prefix: "/nexus/",
+	}
+	t.Setenv("SUMSUB_APP_TOKEN", "from-env")
+
+	values, err := secrets.WithFallback(ssm, secrets.Env{}).Fetch(context.Background(), names)
+	require.NoError(t, err)
+	require.Len(t, batches, 2, "GetParameters takes 10 names at a time")
+	assert.Len(t, batches[0], 10)
+	assert.Equal(t, "v:/nexus/STRIPE_SECRET_KEY", values["STRIPE_SECRET_KEY"])
+	assert.Equal(t, "v:/nexus/EXTRA_j_0", values["EXTRA_j_0"])
+	assert.Equal(t, "from-env", values["SUMSUB_APP_TOKEN"])
+	assert.Len(t, values, 12)
+}
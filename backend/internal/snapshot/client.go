@@ -0,0 +1,153 @@
+package snapshot
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// DefaultHubURL is the public Snapshot hub GraphQL endpoint, used when
+// app_config snapshot.hub_url is not set
+const DefaultHubURL = "https://hub.snapshot.org/graphql"
+
+// requestTimeout bounds one GraphQL request to the hub
+const requestTimeout = 15 * time.Second
+
+// maxResponseBytes caps how much of a hub response is read
+const maxResponseBytes = 8 << 20
+
+// proposalsQuery reads a page of a space's proposals, newest first
+const proposalsQuery = `query Proposals($space: String!, $first: Int!, $skip: Int!) {
+  proposals(first: $first, skip: $skip, where: {space: $space}, orderBy: "created", orderDirection: desc) {
+    id title body author choices start end snapshot state scores scores_total votes link created
+    space { id }
+  }
+}`
+
+// Client reads proposals from a Snapshot hub
+type Client struct {
+	http *http.Client
+}
+
+// NewClient creates a Snapshot hub client
+func NewClient() *Client {
+	return &Client{http: &http.Client{Timeout: requestTimeout}}
+}
+
+// hubProposal is a proposal as returned by the hub
+type hubProposal struct {
+	ID          string    `json:"id"`
+	Title       string    `json:"title"`
+	Body        string    `json:"body"`
+	Author      string    `json:"author"`
+	Choices     []string  `json:"choices"`
+	Start       int64     `json:"start"`
+	End         int64     `json:"end"`
+	Snapshot    string    `json:"snapshot"`
+	State       string    `json:"state"`
+	Scores      []float64 `json:"scores"`
+	ScoresTotal float64   `json:"scores_total"`
+	Votes       int       `json:"votes"`
+	Link        string    `json:"link"`
+	Created     int64     `json:"created"`
+	Space       struct {
+		ID string `json:"id"`
+	} `json:"space"`
+}
+
+// Proposals reads one page of space's proposals from the hub at hubURL,
+// newest first
+func (c *Client) Proposals(ctx context.Context, hubURL, space string, first, skip int) ([]*repository.SnapshotProposal, error) {
+	body, err := json.Marshal(map[string]interface{}{
+		"query": proposalsQuery,
+		"variables": map[string]interface{}{
+			"space": space,
+			"first": first,
+			"skip":  skip,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("encoding snapshot query: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hubURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("building snapshot request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("querying snapshot hub: %w", err)
+	}
+	defer resp.Body.Close()
+
+	raw, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBytes))
+	if err != nil {
+		return nil, fmt.Errorf("reading snapshot response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("snapshot hub returned %d", resp.StatusCode)
+	}
+
+	var out struct {
+		Data struct {
+			Proposals []hubProposal `json:"proposals"`
+		} `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return nil, fmt.Errorf("parsing snapshot response: %w", err)
+	}
+	if len(out.Errors) > 0 {
+		return nil, errors.New("snapshot query failed: " + out.Errors[0].Message)
+	}
+
+	proposals := make([]*repository.SnapshotProposal, 0, len(out.Data.Proposals))
+	for _, hp := range out.Data.Proposals {
+		proposals = append(proposals, hp.proposal(space))
+	}
+	return proposals, nil
+}
+
+// proposal converts a hub proposal to its mirrored form
+func (hp hubProposal) proposal(space string) *repository.SnapshotProposal {
+	if hp.Space.ID != "" {
+		space = hp.Space.ID
+	}
+	state := repository.SnapshotProposalState(hp.State)
+	switch state {
+	case repository.SnapshotProposalPending, repository.SnapshotProposalActive, repository.SnapshotProposalClosed:
+	default:
+		// An unknown state is not treated as final, so it is read again
+		state = repository.SnapshotProposalActive
+	}
+
+	return &repository.SnapshotProposal{
+		ID:            hp.ID,
+		Space:         space,
+		Title:         hp.Title,
+		Body:          hp.Body,
+		Author:        hp.Author,
+		Choices:       append([]string{}, hp.Choices...),
+		Scores:        append([]float64{}, hp.Scores...),
+		ScoresTotal:   hp.ScoresTotal,
+		Votes:         hp.Votes,
+		State:         state,
+		SnapshotBlock: hp.Snapshot,
+		Link:          hp.Link,
+		StartTime:     time.Unix(hp.Start, 0).UTC(),
+		EndTime:       time.Unix(hp.End, 0).UTC(),
+		CreatedAt:     time.Unix(hp.Created, 0).UTC(),
+	}
+}
@@ -0,0 +1,230 @@
+// Package snapshot mirrors a space's off-chain governance proposals and
+// their results from Snapshot (snapshot.org) through the hub's GraphQL API.
+// Mirrored proposals are linked to the on-chain proposal they went on to,
+// either by an admin or automatically when an on-chain proposal's
+// description links to the Snapshot proposal. Background mirroring is off
+// until app_config snapshot.enabled is set.
+package snapshot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// namespace is the app_config namespace holding the Snapshot settings
+const namespace = "snapshot"
+
+// DefaultInterval is how often Run mirrors the space when no interval is
+// given
+const DefaultInterval = 5 * time.Minute
+
+// pageSize is how many proposals one hub request reads
+const pageSize = 100
+
+// maxPages bounds one sync, so a first sync of a large space reads at most
+// maxPages*pageSize proposals
+const maxPages = 50
+
+// ErrNoSpace is returned by Sync when app_config snapshot.space is not set
+var ErrNoSpace = errors.New("snapshot.space is not set")
+
+var (
+	// proposalIDPattern matches Snapshot proposal IDs: a hex hash, or an
+	// IPFS hash for older proposals
+	proposalIDPattern = regexp.MustCompile(`^(0x[0-9a-fA-F]{64}|Qm[1-9A-HJ-NP-Za-km-z]{44})$`)
+
+	// proposalLinkPattern matches links to a Snapshot proposal
+	proposalLinkPattern = regexp.MustCompile(`snapshot\.(?:org|box)/#/[^\s/]+/proposal/(0x[0-9a-fA-F]{64}|Qm[1-9A-HJ-NP-Za-km-z]{44})`)
+)
+
+// ValidProposalID reports whether id has the form of a Snapshot proposal ID
+func ValidProposalID(id string) bool {
+	return proposalIDPattern.MatchString(id)
+}
+
+// LinkedProposals returns the Snapshot proposal IDs linked from text, in
+// order of appearance
+func LinkedProposals(text string) []string {
+	var ids []string
+	seen := make(map[string]bool)
+	for _, m := range proposalLinkPattern.FindAllStringSubmatch(text, -1) {
+		if !seen[m[1]] {
+			seen[m[1]] = true
+			ids = append(ids, m[1])
+		}
+	}
+	return ids
+}
+
+// OnchainProposals lists on-chain proposal descriptions by proposal ID
+// (implemented by handlers.GovernanceHandler)
+type OnchainProposals interface {
+	ProposalDescriptions() map[string]string
+}
+
+// Syncer mirrors the configured space into the Snapshot repository
+type Syncer struct {
+	client     *Client
+	repo       repository.SnapshotRepository
+	configRepo repository.AppConfigRepository
+	onchain    OnchainProposals
+	logger     *zap.Logger
+}
+
+// NewSyncer creates a new Snapshot syncer
+func NewSyncer(client *Client, repo repository.SnapshotRepository, configRepo repository.AppConfigRepository, logger *zap.Logger) *Syncer {
+	return &Syncer{
+		client:     client,
+		repo:       repo,
+		configRepo: configRepo,
+		logger:     logger,
+	}
+}
+
+// SetOnchainProposals links mirrored proposals to the on-chain proposals
+// whose descriptions link to them
+func (s *Syncer) SetOnchainProposals(onchain OnchainProposals) {
+	s.onchain = onchain
+}
+
+// Run mirrors the space every interval until ctx is done
+func (s *Syncer) Run(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.RunOnce(ctx); err != nil {
+			s.logger.Warn("snapshot sync failed", zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce mirrors the space if snapshot.enabled is set and a space is
+// configured
+func (s *Syncer) RunOnce(ctx context.Context) error {
+	if enabled, err := s.configRepo.GetBool(ctx, namespace, "enabled", 0); err != nil || !enabled {
+		return nil
+	}
+	_, err := s.Sync(ctx)
+	if errors.Is(err, ErrNoSpace) {
+		return nil
+	}
+	return err
+}
+
+// Sync mirrors the space's proposals, newest first, and returns how many
+// were recorded or refreshed. Paging stops at the first page on which every
+// proposal is closed and already mirrored with the same results, so after
+// the first sync only new and open proposals are read.
+func (s *Syncer) Sync(ctx context.Context) (int, error) {
+	space, _ := s.configRepo.GetString(ctx, namespace, "space", 0)
+	if space == "" {
+		return 0, ErrNoSpace
+	}
+	hubURL, _ := s.configRepo.GetString(ctx, namespace, "hub_url", 0)
+	if hubURL == "" {
+		hubURL = DefaultHubURL
+	}
+
+	synced := 0
+	for page := 0; page < maxPages; page++ {
+		proposals, err := s.client.Proposals(ctx, hubURL, space, pageSize, page*pageSize)
+		if err != nil {
+			return synced, err
+		}
+
+		changed := 0
+		for _, p := range proposals {
+			unchanged, err := s.unchanged(ctx, p)
+			if err != nil {
+				return synced, err
+			}
+			if unchanged {
+				continue
+			}
+			if err := s.repo.UpsertSnapshotProposal(ctx, p); err != nil {
+				return synced, err
+			}
+			changed++
+		}
+		synced += changed
+
+		if len(proposals) < pageSize || changed == 0 {
+			break
+		}
+	}
+
+	if err := s.link(ctx); err != nil {
+		return synced, err
+	}
+
+	s.logger.Info("snapshot space synced", zap.String("space", space), zap.Int("proposals", synced))
+	return synced, nil
+}
+
+// unchanged reports whether p is closed and already mirrored with its final
+// results
+func (s *Syncer) unchanged(ctx context.Context, p *repository.SnapshotProposal) (bool, error) {
+	if p.State != repository.SnapshotProposalClosed {
+		return false, nil
+	}
+	stored, err := s.repo.GetSnapshotProposal(ctx, p.ID)
+	if errors.Is(err, repository.ErrSnapshotProposalNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return stored.State == repository.SnapshotProposalClosed &&
+		stored.Votes == p.Votes &&
+		stored.ScoresTotal == p.ScoresTotal, nil
+}
+
+// link links unlinked mirrored proposals to the on-chain proposals whose
+// descriptions link to them. An existing link, automatic or set by an admin,
+// is never replaced.
+func (s *Syncer) link(ctx context.Context) error {
+	if s.onchain == nil {
+		return nil
+	}
+	for onchainID, description := range s.onchain.ProposalDescriptions() {
+		for _, id := range LinkedProposals(description) {
+			p, err := s.repo.GetSnapshotProposal(ctx, id)
+			if errors.Is(err, repository.ErrSnapshotProposalNotFound) {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			if p.OnchainProposalID != nil {
+				continue
+			}
+
+			linked := onchainID
+			if err := s.repo.LinkSnapshotProposal(ctx, id, &linked); err != nil {
+				return fmt.Errorf("linking snapshot proposal %s: %w", id, err)
+			}
+			s.logger.Info("snapshot proposal linked",
+				zap.String("snapshot_proposal_id", id),
+				zap.String("onchain_proposal_id", onchainID),
+			)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,167 @@
+package snapshot_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/snapshot"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/storage/memory"
+)
+
+const space = "nexusprotocol.eth"
+
+// proposalID returns a Snapshot-style proposal ID for n
+func proposalID(n int) string {
+	return fmt.Sprintf("0x%064x", n)
+}
+
+// fakeHub serves proposals newest first from a GraphQL endpoint
+type fakeHub struct {
+	proposals []map[string]interface{}
+	requests  int
+}
+
+func (h *fakeHub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.requests++
+	var req struct {
+		Query     string `json:"query"`
+		Variables struct {
+			Space string `json:"space"`
+			First int    `json:"first"`
+			Skip  int    `json:"skip"`
+		} `json:"variables"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || !strings.Contains(req.Query, "proposals(") {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	page := []map[string]interface{}{}
+	for i := req.Variables.Skip; i < len(h.proposals) && i < req.Variables.Skip+req.Variables.First; i++ {
+		page = append(page, h.proposals[i])
+	}
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{"proposals": page}})
+}
+
+func hubProposal(n int, state string, votes int) map[string]interface{} {
+	return map[string]interface{}{
+		"id": proposalID(n), "title": fmt.Sprintf("Proposal %d", n), "body": "", "author": "0x00000000000000000000000000000000000000aa",
+		"choices": []string{"For", "Against"}, "start": 1_700_000_000 + n, "end": 1_700_100_000 + n, "snapshot": "19000000",
+		"state": state, "scores": []float64{float64(votes) * 10, 5}, "scores_total": float64(votes)*10 + 5, "votes": votes,
+		"link": "https://snapshot.org/#/" + space + "/proposal/" + proposalID(n), "created": 1_699_000_000 + n,
+		"space": map[string]string{"id": space},
+	}
+}
+
+// descriptions is an OnchainProposals serving fixed descriptions
+type descriptions map[string]string
+
+func (d descriptions) ProposalDescriptions() map[string]string { return d }
+
+func newSyncer(t *testing.T, hub *fakeHub) (*snapshot.Syncer, *memory.Store) {
+	t.Helper()
+	ctx := context.Background()
+	server := httptest.NewServer(hub)
+	t.Cleanup(server.Close)
+
+	store := memory.NewStore()
+	spaceName, hubURL := space, server.URL
+	require.NoError(t, store.AppConfig.Create(ctx, &repository.AppConfigCreate{
+		Namespace: "snapshot", ConfigKey: "space", ValueType: "string", ValueString: &spaceName, UpdatedBy: "test",
+	}))
+	require.NoError(t, store.AppConfig.Create(ctx, &repository.AppConfigCreate{
+		Namespace: "snapshot", ConfigKey: "hub_url", ValueType: "string", ValueString: &hubURL, UpdatedBy: "test",
+	}))
+	return snapshot.NewSyncer(snapshot.NewClient(), store.Snapshot, store.AppConfig, zap.NewNop()), store
+}
+
+func TestSync_MirrorsAndLinks(t *testing.T) {
+	ctx := context.Background()
+	hub := &fakeHub{}
+	for n := 250; n > 0; n-- {
+		hub.proposals = append(hub.proposals, hubProposal(n, "closed", n))
+	}
+	hub.proposals[0] = hubProposal(250, "active", 3)
+	syncer, store := newSyncer(t, hub)
+	syncer.SetOnchainProposals(descriptions{
+		"onchain-1": "Ratified off-chain at https://snapshot.org/#/" + space + "/proposal/" + proposalID(7),
+		"onchain-2": "No Snapshot vote",
+	})
+
+	synced, err := syncer.Sync(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 250, synced, "the first sync pages through the whole space")
+
+	active, err := store.Snapshot.GetSnapshotProposal(ctx, proposalID(250))
+	require.NoError(t, err)
+	assert.Equal(t, repository.SnapshotProposalActive, active.State)
+	assert.Equal(t, []string{"For", "Against"}, active.Choices)
+	assert.Equal(t, []float64{30, 5}, active.Scores)
+	assert.Equal(t, space, active.Space)
+	assert.Nil(t, active.OnchainProposalID)
+
+	linked, err := store.Snapshot.GetSnapshotProposal(ctx, proposalID(7))
+	require.NoError(t, err)
+	require.NotNil(t, linked.OnchainProposalID)
+	assert.Equal(t, "onchain-1", *linked.OnchainProposalID)
+
+	// Later syncs stop at the first page of unchanged closed proposals
+	hub.requests = 0
+	hub.proposals[0] = hubProposal(250, "closed", 40)
+	synced, err = syncer.Sync(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, synced)
+	assert.Equal(t, 2, hub.requests)
+
+	closed, err := store.Snapshot.GetSnapshotProposal(ctx, proposalID(250))
+	require.NoError(t, err)
+	assert.Equal(t, repository.SnapshotProposalClosed, closed.State)
+	assert.Equal(t, 40, closed.Votes)
+
+	// An admin's link is kept by syncs
+	other := "onchain-2"
+	require.NoError(t, store.Snapshot.LinkSnapshotProposal(ctx, proposalID(7), &other))
+	_, err = syncer.Sync(ctx)
+	require.NoError(t, err)
+	linked, err = store.Snapshot.GetSnapshotProposal(ctx, proposalID(7))
+	require.NoError(t, err)
+	assert.Equal(t, "onchain-2", *linked.OnchainProposalID)
+}
+
+func TestRunOnce_Disabled(t *testing.T) {
+	ctx := context.Background()
+	hub := &fakeHub{proposals: []map[string]interface{}{hubProposal(1, "active", 1)}}
+	syncer, store := newSyncer(t, hub)
+
+	require.NoError(t, syncer.RunOnce(ctx))
+	assert.Zero(t, hub.requests, "nothing is read until snapshot.enabled is set")
+
+	enabled := true
+	require.NoError(t, store.AppConfig.Create(ctx, &repository.AppConfigCreate{
+		Namespace: "snapshot", ConfigKey: "enabled", ValueType: "boolean", ValueBoolean: &enabled, UpdatedBy: "test",
+	}))
+	require.NoError(t, syncer.RunOnce(ctx))
+	_, err := store.Snapshot.GetSnapshotProposal(ctx, proposalID(1))
+	assert.NoError(t, err)
+}
+
+func TestLinkedProposals(t *testing.T) {
+	text := "See https://snapshot.org/#/" + space + "/proposal/" + proposalID(1) +
+		" and snapshot.box/#/s:" + space + "/proposal/" + proposalID(2) +
+		" (again: https://snapshot.org/#/" + space + "/proposal/" + proposalID(1) + ")"
+	assert.Equal(t, []string{proposalID(1), proposalID(2)}, snapshot.LinkedProposals(text))
+	assert.Empty(t, snapshot.LinkedProposals("https://example.com/proposal/"+proposalID(3)))
+
+	assert.True(t, snapshot.ValidProposalID(proposalID(1)))
+	assert.False(t, snapshot.ValidProposalID("1"))
+}
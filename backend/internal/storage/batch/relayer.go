@@ -0,0 +1,56 @@
+package batch
+
+import (
+	"context"
+	"errors"
+
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure BatchRelayerRepo implements RelayerRepository
+var _ repository.RelayerRepository = (*BatchRelayerRepo)(nil)
+
+// BatchRelayerRepo groups concurrent CreateMetaTx calls into multi-row
+// inserts. Callers still block until their record is written, so the ID and
+// timestamps are set on return exactly as with a direct insert. All other
+// methods go straight to the wrapped repository.
+type BatchRelayerRepo struct {
+	repository.RelayerRepository
+	writer *Writer[*repository.MetaTransaction]
+}
+
+// NewBatchRelayerRepo wraps next. Run must be started before serving requests.
+func NewBatchRelayerRepo(next repository.RelayerRepository, cfg Config, logger *zap.Logger) *BatchRelayerRepo {
+	if cfg.Name == "" {
+		cfg.Name = "meta_transactions"
+	}
+	// A rejected meta-tx (e.g. a constraint violation) must not fail the
+	// other requests that shared its batch
+	cfg.RetryIndividually = true
+	return &BatchRelayerRepo{
+		RelayerRepository: next,
+		writer:            NewWriter(cfg, next.CreateMetaTxBatch, logger),
+	}
+}
+
+// CreateMetaTx buffers tx and waits for its batch to be written. If the
+// buffer is full it inserts tx directly rather than rejecting the request.
+func (r *BatchRelayerRepo) CreateMetaTx(ctx context.Context, tx *repository.MetaTransaction) error {
+	err := r.writer.Write(ctx, tx)
+	if errors.Is(err, ErrQueueFull) {
+		return r.RelayerRepository.CreateMetaTx(ctx, tx)
+	}
+	return err
+}
+
+// Run flushes buffered meta-transactions until ctx is cancelled, then drains
+func (r *BatchRelayerRepo) Run(ctx context.Context) {
+	r.writer.Run(ctx)
+}
+
+// Wait blocks until the buffer has been drained after shutdown
+func (r *BatchRelayerRepo) Wait() {
+	r.writer.Wait()
+}
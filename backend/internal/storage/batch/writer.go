@@ -0,0 +1,292 @@
+// Package batch buffers high-volume inserts (meta-transactions, request log
+// entries) and writes them with one multi-row statement per flush instead of
+// one round trip per record
+package batch
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Default writer settings
+const (
+	DefaultMaxBatch      = 100
+	DefaultFlushInterval = 50 * time.Millisecond
+	DefaultQueueSize     = 1024
+	DefaultFlushTimeout  = 5 * time.Second
+)
+
+// ErrQueueFull is returned by Write when the buffer is at capacity. Callers
+// that cannot drop the record should fall back to a direct insert.
+var ErrQueueFull = errors.New("batch queue full")
+
+// FlushFunc writes one batch. It must be all-or-nothing: on error none of the
+// items are assumed written.
+type FlushFunc[T any] func(ctx context.Context, items []T) error
+
+// Config tunes a Writer. Zero values use the defaults.
+type Config struct {
+	// Name identifies the writer in logs
+	Name string
+	// MaxBatch is the largest batch passed to FlushFunc; reaching it triggers
+	// an immediate flush
+	MaxBatch int
+	// FlushInterval bounds how long a buffered item waits for a flush
+	FlushInterval time.Duration
+	// QueueSize caps the number of buffered items
+	QueueSize int
+	// FlushTimeout is the deadline for each FlushFunc call. Flushes use a
+	// fresh context so the final drain survives shutdown.
+	FlushTimeout time.Duration
+	// RetryIndividually re-flushes each item of a failed batch on its own so
+	// one bad record does not fail the others
+	RetryIndividually bool
+}
+
+// pending is a buffered item and, for Write, where its result is delivered
+type pending[T any] struct {
+	item   T
+	result chan error
+}
+
+// Writer buffers items and flushes them on an interval or when MaxBatch is
+// reached. Run must be running for Write to return before its context ends.
+type Writer[T any] struct {
+	cfg    Config
+	flush  FlushFunc[T]
+	logger *zap.Logger
+
+	mu      sync.Mutex
+	buf     []pending[T]
+	closed  bool
+	kick    chan struct{}
+	done    chan struct{}
+	dropped atomic.Int64
+}
+
+// NewWriter creates a writer that flushes through fn
+func NewWriter[T any](cfg Config, fn FlushFunc[T], logger *zap.Logger) *Writer[T] {
+	if cfg.MaxBatch <= 0 {
+		cfg.MaxBatch = DefaultMaxBatch
+	}
+	if cfg.FlushInterval <= 0 {
+		cfg.FlushInterval = DefaultFlushInterval
+	}
+	if cfg.QueueSize <= 0 {
+		cfg.QueueSize = DefaultQueueSize
+	}
+	if cfg.FlushTimeout <= 0 {
+		cfg.FlushTimeout = DefaultFlushTimeout
+	}
+	return &Writer[T]{
+		cfg:    cfg,
+		flush:  fn,
+		logger: logger,
+		kick:   make(chan struct{}, 1),
+		done:   make(chan struct{}),
+	}
+}
+
+// Write buffers item and waits until the batch containing it has been
+// flushed, returning that flush's error. If ctx ends while the item is still
+// buffered it is withdrawn and Write returns ctx.Err(), so the item is never
+// written; if its batch is already being flushed Write waits for the result
+// (bounded by FlushTimeout). Once the writer has shut down, items are flushed
+// synchronously.
+func (w *Writer[T]) Write(ctx context.Context, item T) error {
+	result := make(chan error, 1)
+
+	w.mu.Lock()
+	if w.closed {
+		w.mu.Unlock()
+		return w.flushOne(item)
+	}
+	if len(w.buf) >= w.cfg.QueueSize {
+		w.mu.Unlock()
+		return ErrQueueFull
+	}
+	w.buf = append(w.buf, pending[T]{item: item, result: result})
+	full := len(w.buf) >= w.cfg.MaxBatch
+	w.mu.Unlock()
+
+	if full {
+		w.signal()
+	}
+
+	select {
+	case err := <-result:
+		return err
+	case <-ctx.Done():
+		if w.withdraw(result) {
+			return ctx.Err()
+		}
+		return <-result
+	}
+}
+
+// withdraw removes the buffered item that reports to result. It returns false
+// if the item has already been taken for a flush.
+func (w *Writer[T]) withdraw(result chan error) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for i, p := range w.buf {
+		if p.result == result {
+			w.buf = append(w.buf[:i], w.buf[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Enqueue buffers item without waiting. It returns false, and counts the item
+// as dropped, if the buffer is full or the writer has shut down.
+func (w *Writer[T]) Enqueue(item T) bool {
+	w.mu.Lock()
+	if w.closed || len(w.buf) >= w.cfg.QueueSize {
+		w.mu.Unlock()
+		w.dropped.Add(1)
+		return false
+	}
+	w.buf = append(w.buf, pending[T]{item: item})
+	full := len(w.buf) >= w.cfg.MaxBatch
+	w.mu.Unlock()
+
+	if full {
+		w.signal()
+	}
+	return true
+}
+
+// Run flushes buffered items until ctx is cancelled, then stops accepting
+// new items and drains the buffer before returning
+func (w *Writer[T]) Run(ctx context.Context) {
+	defer close(w.done)
+
+	ticker := time.NewTicker(w.cfg.FlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.mu.Lock()
+			w.closed = true
+			w.mu.Unlock()
+			w.flushBuffered()
+			return
+		case <-w.kick:
+			w.flushBuffered()
+		case <-ticker.C:
+			w.flushBuffered()
+		}
+	}
+}
+
+// Wait blocks until Run has drained the buffer after shutdown
+func (w *Writer[T]) Wait() {
+	<-w.done
+}
+
+// Dropped returns the number of items Enqueue has rejected
+func (w *Writer[T]) Dropped() int64 {
+	return w.dropped.Load()
+}
+
+// signal wakes Run without blocking if a wake-up is already pending
+func (w *Writer[T]) signal() {
+	select {
+	case w.kick <- struct{}{}:
+	default:
+	}
+}
+
+// flushBuffered writes everything buffered so far in MaxBatch chunks
+func (w *Writer[T]) flushBuffered() {
+	w.mu.Lock()
+	buf := w.buf
+	w.buf = nil
+	w.mu.Unlock()
+
+	for len(buf) > 0 {
+		n := min(len(buf), w.cfg.MaxBatch)
+		w.flushBatch(buf[:n])
+		buf = buf[n:]
+	}
+
+	if dropped := w.dropped.Swap(0); dropped > 0 {
+		w.logger.Warn("batch queue full, items dropped",
+			zap.String("writer", w.cfg.Name),
+			zap.Int64("dropped", dropped),
+		)
+	}
+}
+
+// flushBatch writes one batch and delivers the outcome to each waiting Write
+func (w *Writer[T]) flushBatch(batch []pending[T]) {
+	items := make([]T, len(batch))
+	for i, p := range batch {
+		items[i] = p.item
+	}
+
+	err := w.call(items)
+	if err == nil {
+		w.deliver(batch, nil)
+		return
+	}
+
+	if !w.cfg.RetryIndividually || len(batch) == 1 {
+		w.logger.Error("batch flush failed",
+			zap.String("writer", w.cfg.Name),
+			zap.Int("count", len(batch)),
+			zap.Error(err),
+		)
+		w.deliver(batch, err)
+		return
+	}
+
+	w.logger.Warn("batch flush failed, retrying items individually",
+		zap.String("writer", w.cfg.Name),
+		zap.Int("count", len(batch)),
+		zap.Error(err),
+	)
+	failed := 0
+	for _, p := range batch {
+		itemErr := w.call([]T{p.item})
+		if itemErr != nil {
+			failed++
+		}
+		w.deliver([]pending[T]{p}, itemErr)
+	}
+	if failed > 0 {
+		w.logger.Error("batch items failed",
+			zap.String("writer", w.cfg.Name),
+			zap.Int("failed", failed),
+		)
+	}
+}
+
+// flushOne writes a single item outside the buffer (after shutdown)
+func (w *Writer[T]) flushOne(item T) error {
+	return w.call([]T{item})
+}
+
+// call runs FlushFunc with a fresh deadline
+func (w *Writer[T]) call(items []T) error {
+	ctx, cancel := context.WithTimeout(context.Background(), w.cfg.FlushTimeout)
+	defer cancel()
+	return w.flush(ctx, items)
+}
+
+// deliver reports err to every waiting Write in batch
+func (w *Writer[T]) deliver(batch []pending[T], err error) {
+	for _, p := range batch {
+		if p.result != nil {
+			p.result <- err
+		}
+	}
+}
@@ -0,0 +1,225 @@
+package batch_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/storage/batch"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/storage/memory"
+)
+
+// recorder collects flushed batches
+type recorder struct {
+	mu      sync.Mutex
+	batches [][]int
+	fail    func(items []int) error
+}
+
+func (r *recorder) flush(ctx context.Context, items []int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.fail != nil {
+		if err := r.fail(items); err != nil {
+			return err
+		}
+	}
+	r.batches = append(r.batches, append([]int(nil), items...))
+	return nil
+}
+
+func (r *recorder) flushed() [][]int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.batches
+}
+
+func TestWriter_GroupsConcurrentWrites(t *testing.T) {
+	rec := &recorder{}
+	w := batch.NewWriter(batch.Config{MaxBatch: 10, FlushInterval: time.Hour}, rec.flush, zap.NewNop())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go w.Run(ctx)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			assert.NoError(t, w.Write(context.Background(), i))
+		}(i)
+	}
+	wg.Wait()
+
+	// Reaching MaxBatch flushes without waiting for the interval
+	require.Len(t, rec.flushed(), 1)
+	assert.ElementsMatch(t, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, rec.flushed()[0])
+}
+
+func TestWriter_FlushesOnInterval(t *testing.T) {
+	rec := &recorder{}
+	w := batch.NewWriter(batch.Config{MaxBatch: 100, FlushInterval: 10 * time.Millisecond}, rec.flush, zap.NewNop())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go w.Run(ctx)
+
+	require.True(t, w.Enqueue(1))
+	require.True(t, w.Enqueue(2))
+	assert.Eventually(t, func() bool { return len(rec.flushed()) == 1 }, time.Second, 5*time.Millisecond)
+	assert.Equal(t, []int{1, 2}, rec.flushed()[0])
+}
+
+func TestWriter_DrainsOnShutdown(t *testing.T) {
+	rec := &recorder{}
+	w := batch.NewWriter(batch.Config{MaxBatch: 2, FlushInterval: time.Hour}, rec.flush, zap.NewNop())
+
+	for i := 1; i <= 5; i++ {
+		require.True(t, w.Enqueue(i))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	w.Run(ctx)
+	w.Wait()
+
+	assert.Equal(t, [][]int{{1, 2}, {3, 4}, {5}}, rec.flushed())
+
+	// After shutdown Enqueue drops and Write goes straight through
+	assert.False(t, w.Enqueue(6))
+	require.NoError(t, w.Write(context.Background(), 7))
+	assert.Equal(t, []int{7}, rec.flushed()[3])
+}
+
+func TestWriter_QueueFull(t *testing.T) {
+	rec := &recorder{}
+	w := batch.NewWriter(batch.Config{MaxBatch: 10, QueueSize: 2}, rec.flush, zap.NewNop())
+
+	assert.True(t, w.Enqueue(1))
+	assert.True(t, w.Enqueue(2))
+	assert.False(t, w.Enqueue(3))
+	assert.Equal(t, int64(1), w.Dropped())
+	assert.ErrorIs(t, w.Write(context.Background(), 4), batch.ErrQueueFull)
+}
+
+func TestWriter_CancelledWriteIsWithdrawn(t *testing.T) {
+	rec := &recorder{}
+	w := batch.NewWriter(batch.Config{MaxBatch: 10, FlushInterval: time.Hour}, rec.flush, zap.NewNop())
+
+	runCtx, stop := context.WithCancel(context.Background())
+	go w.Run(runCtx)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, w.Write(ctx, 1), context.DeadlineExceeded)
+
+	// The caller was told the write failed, so the drain must not write it
+	require.True(t, w.Enqueue(2))
+	stop()
+	w.Wait()
+	assert.Equal(t, [][]int{{2}}, rec.flushed())
+}
+
+func TestWriter_CancelledWriteWaitsForInFlightFlush(t *testing.T) {
+	started, release := make(chan struct{}), make(chan struct{})
+	rec := &recorder{}
+	flush := func(ctx context.Context, items []int) error {
+		close(started)
+		<-release
+		return rec.flush(ctx, items)
+	}
+	w := batch.NewWriter(batch.Config{MaxBatch: 1, FlushInterval: time.Hour}, flush, zap.NewNop())
+
+	runCtx, stop := context.WithCancel(context.Background())
+	defer stop()
+	go w.Run(runCtx)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	result := make(chan error, 1)
+	go func() { result <- w.Write(ctx, 1) }()
+
+	<-started
+	cancel()
+	select {
+	case err := <-result:
+		t.Fatalf("Write returned %v before its batch finished flushing", err)
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	close(release)
+	assert.NoError(t, <-result, "the item was written, so Write reports the flush result")
+	assert.Equal(t, [][]int{{1}}, rec.flushed())
+}
+
+func TestWriter_RetryIndividually(t *testing.T) {
+	errBad := errors.New("violates check constraint")
+	rec := &recorder{fail: func(items []int) error {
+		for _, item := range items {
+			if item < 0 {
+				return errBad
+			}
+		}
+		return nil
+	}}
+	w := batch.NewWriter(batch.Config{MaxBatch: 3, FlushInterval: time.Hour, RetryIndividually: true}, rec.flush, zap.NewNop())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go w.Run(ctx)
+
+	results := make(map[int]error)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, item := range []int{1, -1, 2} {
+		wg.Add(1)
+		go func(item int) {
+			defer wg.Done()
+			err := w.Write(context.Background(), item)
+			mu.Lock()
+			results[item] = err
+			mu.Unlock()
+		}(item)
+	}
+	wg.Wait()
+
+	assert.NoError(t, results[1])
+	assert.NoError(t, results[2])
+	assert.ErrorIs(t, results[-1], errBad)
+	assert.ElementsMatch(t, [][]int{{1}, {2}}, rec.flushed())
+}
+
+func TestBatchRelayerRepo_CreateMetaTx(t *testing.T) {
+	store := memory.NewStore()
+	repo := batch.NewBatchRelayerRepo(store.Relayer, batch.Config{}, zap.NewNop())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go repo.Run(ctx)
+
+	tx := &repository.MetaTransaction{
+		FromAddress:  "0x1111111111111111111111111111111111111111",
+		ToAddress:    "0x2222222222222222222222222222222222222222",
+		FunctionName: "transfer",
+		Calldata:     "0x",
+		Value:        "0",
+		GasLimit:     100000,
+		Deadline:     time.Now().Add(time.Hour),
+		Signature:    "0xsig",
+		Status:       "pending",
+	}
+	require.NoError(t, repo.CreateMetaTx(context.Background(), tx))
+	require.NotEmpty(t, tx.ID)
+	assert.False(t, tx.CreatedAt.IsZero())
+
+	got, err := repo.GetMetaTx(context.Background(), tx.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "transfer", got.FunctionName)
+}
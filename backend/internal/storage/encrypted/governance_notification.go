@@ -0,0 +1,98 @@
+package encrypted
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/fieldcrypt"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// fieldSubscriptionSecret is the field governance subscription secrets are bound to
+const fieldSubscriptionSecret = "governance_subscriptions.secret"
+
+// Ensure EncryptedGovernanceNotificationRepo implements GovernanceNotificationRepository
+var _ repository.GovernanceNotificationRepository = (*EncryptedGovernanceNotificationRepo)(nil)
+
+// EncryptedGovernanceNotificationRepo wraps a GovernanceNotificationRepository,
+// encrypting subscription secrets on write and decrypting them on read.
+// Events pass through.
+type EncryptedGovernanceNotificationRepo struct {
+	next   repository.GovernanceNotificationRepository
+	cipher *fieldcrypt.Cipher
+}
+
+// NewEncryptedGovernanceNotificationRepo wraps next with c
+func NewEncryptedGovernanceNotificationRepo(next repository.GovernanceNotificationRepository, c *fieldcrypt.Cipher) *EncryptedGovernanceNotificationRepo {
+	return &EncryptedGovernanceNotificationRepo{next: next, cipher: c}
+}
+
+// UpsertGovernanceSubscription implements repository.GovernanceNotificationRepository
+func (r *EncryptedGovernanceNotificationRepo) UpsertGovernanceSubscription(ctx context.Context, s *repository.GovernanceSubscription) error {
+	plain := s.Secret
+	secret, err := r.cipher.Encrypt(fieldSubscriptionSecret, plain)
+	if err != nil {
+		return fmt.Errorf("encrypting subscription secret: %w", err)
+	}
+	s.Secret = secret
+	err = r.next.UpsertGovernanceSubscription(ctx, s)
+	s.Secret = plain
+	return err
+}
+
+// GetGovernanceSubscription implements repository.GovernanceNotificationRepository
+func (r *EncryptedGovernanceNotificationRepo) GetGovernanceSubscription(ctx context.Context, address string) (*repository.GovernanceSubscription, error) {
+	s, err := r.next.GetGovernanceSubscription(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.decryptSubscription(s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// DeleteGovernanceSubscription implements repository.GovernanceNotificationRepository
+func (r *EncryptedGovernanceNotificationRepo) DeleteGovernanceSubscription(ctx context.Context, address string) error {
+	return r.next.DeleteGovernanceSubscription(ctx, address)
+}
+
+// ListGovernanceSubscriptions implements repository.GovernanceNotificationRepository
+func (r *EncryptedGovernanceNotificationRepo) ListGovernanceSubscriptions(ctx context.Context, filter repository.GovernanceSubscriptionFilter, page repository.Pagination) ([]*repository.GovernanceSubscription, int64, error) {
+	out, total, err := r.next.ListGovernanceSubscriptions(ctx, filter, page)
+	if err != nil {
+		return nil, 0, err
+	}
+	for _, s := range out {
+		if err := r.decryptSubscription(s); err != nil {
+			return nil, 0, err
+		}
+	}
+	return out, total, nil
+}
+
+// MarkGovernanceDigestSent implements repository.GovernanceNotificationRepository
+func (r *EncryptedGovernanceNotificationRepo) MarkGovernanceDigestSent(ctx context.Context, address string, at time.Time) error {
+	return r.next.MarkGovernanceDigestSent(ctx, address, at)
+}
+
+// RecordGovernanceEvent implements repository.GovernanceNotificationRepository
+func (r *EncryptedGovernanceNotificationRepo) RecordGovernanceEvent(ctx context.Context, e *repository.GovernanceEvent) (bool, error) {
+	return r.next.RecordGovernanceEvent(ctx, e)
+}
+
+// ListGovernanceEvents implements repository.GovernanceNotificationRepository
+func (r *EncryptedGovernanceNotificationRepo) ListGovernanceEvents(ctx context.Context, since time.Time, limit int) ([]*repository.GovernanceEvent, error) {
+	return r.next.ListGovernanceEvents(ctx, since, limit)
+}
+
+// decryptSubscription decrypts s's secret in place
+func (r *EncryptedGovernanceNotificationRepo) decryptSubscription(s *repository.GovernanceSubscription) error {
+	secret, err := r.cipher.Decrypt(fieldSubscriptionSecret, s.Secret)
+	if err != nil {
+		return fmt.Errorf("decrypting secret of governance subscription %s: %w", s.Address, err)
+	}
+	s.Secret = secret
+	return nil
+}
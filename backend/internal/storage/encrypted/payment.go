@@ -0,0 +1,364 @@
+// Package encrypted wraps repositories so sensitive KYC fields are encrypted
+// at rest with fieldcrypt. Handlers see plaintext; the wrapped repository
+// only ever stores ciphertext (once a key is configured).
+//
+// The encrypted fields are the Sumsub applicant and inspection IDs and the
+// Sumsub review result, which carries the rejection labels and moderator
+// comments. The applicant ID is encrypted deterministically since webhooks
+// look verifications up by it. Payment callback secrets, which sign the
+// status notifications sent to dapps, and governance subscription secrets,
+// which sign proposal alerts, are encrypted too.
+package encrypted
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/fieldcrypt"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Field names the ciphertexts are bound to
+const (
+	fieldApplicantID  = "kyc_verifications.sumsub_applicant_id"
+	fieldInspectionID = "kyc_verifications.sumsub_inspection_id"
+	fieldReviewResult = "kyc_verifications.sumsub_review_result"
+
+	fieldCallbackSecret = "payments.callback_secret"
+)
+
+// rotationPageSize is how many verifications RotateKYCVerifications reads at a time
+const rotationPageSize = 100
+
+// Ensure EncryptedPaymentRepo implements PaymentRepository
+var _ repository.PaymentRepository = (*EncryptedPaymentRepo)(nil)
+
+// EncryptedPaymentRepo wraps a PaymentRepository, encrypting KYC verification
+// fields and payment callback secrets on write and decrypting them on read.
+// Other payment fields pass through.
+type EncryptedPaymentRepo struct {
+	next   repository.PaymentRepository
+	cipher *fieldcrypt.Cipher
+}
+
+// NewEncryptedPaymentRepo wraps next with c
+func NewEncryptedPaymentRepo(next repository.PaymentRepository, c *fieldcrypt.Cipher) *EncryptedPaymentRepo {
+	return &EncryptedPaymentRepo{next: next, cipher: c}
+}
+
+// CreatePayment implements repository.PaymentRepository
+func (r *EncryptedPaymentRepo) CreatePayment(ctx context.Context, payment *repository.Payment) error {
+	plain := payment.CallbackSecret
+	if plain != nil {
+		s, err := r.cipher.Encrypt(fieldCallbackSecret, *plain)
+		if err != nil {
+			return fmt.Errorf("encrypting callback secret: %w", err)
+		}
+		payment.CallbackSecret = &s
+	}
+	err := r.next.CreatePayment(ctx, payment)
+	payment.CallbackSecret = plain
+	return err
+}
+
+// GetPayment implements repository.PaymentRepository
+func (r *EncryptedPaymentRepo) GetPayment(ctx context.Context, id string) (*repository.Payment, error) {
+	return r.decryptedPayment(r.next.GetPayment(ctx, id))
+}
+
+// GetPaymentByStripeSession implements repository.PaymentRepository
+func (r *EncryptedPaymentRepo) GetPaymentByStripeSession(ctx context.Context, sessionID string) (*repository.Payment, error) {
+	return r.decryptedPayment(r.next.GetPaymentByStripeSession(ctx, sessionID))
+}
+
+// GetPaymentByStripePaymentID implements repository.PaymentRepository
+func (r *EncryptedPaymentRepo) GetPaymentByStripePaymentID(ctx context.Context, paymentIntentID string) (*repository.Payment, error) {
+	return r.decryptedPayment(r.next.GetPaymentByStripePaymentID(ctx, paymentIntentID))
+}
+
+// UpdatePaymentStatus implements repository.PaymentRepository
+func (r *EncryptedPaymentRepo) UpdatePaymentStatus(ctx context.Context, id string, status repository.PaymentStatus, details *repository.PaymentStatusUpdate) error {
+	return r.next.UpdatePaymentStatus(ctx, id, status, details)
+}
+
+// ListPayments implements repository.PaymentRepository
+func (r *EncryptedPaymentRepo) ListPayments(ctx context.Context, filter repository.PaymentFilter, page repository.Pagination) ([]*repository.Payment, int64, error) {
+	out, total, err := r.next.ListPayments(ctx, filter, page)
+	if err != nil {
+		return nil, 0, err
+	}
+	for _, p := range out {
+		if err := r.decryptPayment(p); err != nil {
+			return nil, 0, err
+		}
+	}
+	return out, total, nil
+}
+
+// ArchivePayments implements repository.PaymentRepository
+func (r *EncryptedPaymentRepo) ArchivePayments(ctx context.Context, before time.Time, limit int) (int64, error) {
+	return r.next.ArchivePayments(ctx, before, limit)
+}
+
+// GetArchivedPayment implements repository.PaymentRepository
+func (r *EncryptedPaymentRepo) GetArchivedPayment(ctx context.Context, id string) (*repository.Payment, error) {
+	return r.next.GetArchivedPayment(ctx, id)
+}
+
+// ListArchivedPayments implements repository.PaymentRepository
+func (r *EncryptedPaymentRepo) ListArchivedPayments(ctx context.Context, filter repository.PaymentFilter, page repository.Pagination) ([]*repository.Payment, int64, error) {
+	return r.next.ListArchivedPayments(ctx, filter, page)
+}
+
+// ExpirePayments implements repository.PaymentRepository
+func (r *EncryptedPaymentRepo) ExpirePayments(ctx context.Context, method string, before time.Time, limit int) ([]string, error) {
+	return r.next.ExpirePayments(ctx, method, before, limit)
+}
+
+// CreateKYCVerification implements repository.PaymentRepository
+func (r *EncryptedPaymentRepo) CreateKYCVerification(ctx context.Context, v *repository.KYCVerification) error {
+	// The caller's struct is encrypted for the insert and restored after, so
+	// it keeps the plaintext along with the ID and timestamps the insert sets
+	plain := *v
+	if err := r.encryptVerification(v); err != nil {
+		return err
+	}
+	err := r.next.CreateKYCVerification(ctx, v)
+	v.SumsubApplicantID = plain.SumsubApplicantID
+	v.SumsubInspectionID = plain.SumsubInspectionID
+	v.SumsubReviewResult = plain.SumsubReviewResult
+	return err
+}
+
+// GetKYCVerification implements repository.PaymentRepository
+func (r *EncryptedPaymentRepo) GetKYCVerification(ctx context.Context, id string) (*repository.KYCVerification, error) {
+	return r.decrypted(r.next.GetKYCVerification(ctx, id))
+}
+
+// GetKYCVerificationByAddress implements repository.PaymentRepository
+func (r *EncryptedPaymentRepo) GetKYCVerificationByAddress(ctx context.Context, address string) (*repository.KYCVerification, error) {
+	return r.decrypted(r.next.GetKYCVerificationByAddress(ctx, address))
+}
+
+// GetKYCVerificationByApplicant implements repository.PaymentRepository. The
+// applicant ID is looked up under every configured key, then as plaintext,
+// so rows not yet rotated are still found.
+func (r *EncryptedPaymentRepo) GetKYCVerificationByApplicant(ctx context.Context, applicantID string) (*repository.KYCVerification, error) {
+	for _, candidate := range r.cipher.Candidates(fieldApplicantID, applicantID) {
+		v, err := r.next.GetKYCVerificationByApplicant(ctx, candidate)
+		if errors.Is(err, repository.ErrKYCNotFound) {
+			continue
+		}
+		return r.decrypted(v, err)
+	}
+	return nil, repository.ErrKYCNotFound
+}
+
+// UpdateKYCVerification implements repository.PaymentRepository
+func (r *EncryptedPaymentRepo) UpdateKYCVerification(ctx context.Context, id string, update *repository.KYCVerificationUpdate) error {
+	enc := *update
+	if enc.SumsubApplicantID != nil {
+		s := r.cipher.EncryptDeterministic(fieldApplicantID, *enc.SumsubApplicantID)
+		enc.SumsubApplicantID = &s
+	}
+	if enc.SumsubInspectionID != nil {
+		s, err := r.cipher.Encrypt(fieldInspectionID, *enc.SumsubInspectionID)
+		if err != nil {
+			return fmt.Errorf("encrypting inspection id: %w", err)
+		}
+		enc.SumsubInspectionID = &s
+	}
+	if enc.SumsubReviewResult != nil {
+		result, err := r.encryptReviewResult(enc.SumsubReviewResult)
+		if err != nil {
+			return err
+		}
+		enc.SumsubReviewResult = result
+	}
+	return r.next.UpdateKYCVerification(ctx, id, &enc)
+}
+
+// ListKYCVerifications implements repository.PaymentRepository
+func (r *EncryptedPaymentRepo) ListKYCVerifications(ctx context.Context, filter repository.KYCVerificationFilter, page repository.Pagination) ([]*repository.KYCVerification, int64, error) {
+	out, total, err := r.next.ListKYCVerifications(ctx, filter, page)
+	if err != nil {
+		return nil, 0, err
+	}
+	for _, v := range out {
+		if err := decryptVerification(r.cipher, v); err != nil {
+			return nil, 0, err
+		}
+	}
+	return out, total, nil
+}
+
+// RotateKYCVerifications rewrites every verification whose fields are
+// plaintext or encrypted under an old key, so the old key can be dropped. It
+// returns the number of verifications rewritten. Rewriting bumps a row's
+// updated_at.
+func (r *EncryptedPaymentRepo) RotateKYCVerifications(ctx context.Context) (int, error) {
+	if !r.cipher.Enabled() {
+		return 0, nil
+	}
+
+	rotated := 0
+	for page := 1; ; page++ {
+		// Rewrites leave created_at alone, so the newest-first pages are stable
+		rows, total, err := r.next.ListKYCVerifications(ctx, repository.KYCVerificationFilter{}, repository.Pagination{Page: page, PageSize: rotationPageSize})
+		if err != nil {
+			return rotated, err
+		}
+		for _, v := range rows {
+			update, err := r.rotationUpdate(v)
+			if err != nil {
+				return rotated, fmt.Errorf("verification %s: %w", v.ID, err)
+			}
+			if update == nil {
+				continue
+			}
+			if err := r.UpdateKYCVerification(ctx, v.ID, update); err != nil {
+				return rotated, fmt.Errorf("verification %s: %w", v.ID, err)
+			}
+			rotated++
+		}
+		if len(rows) < rotationPageSize || int64(page*rotationPageSize) >= total {
+			return rotated, nil
+		}
+	}
+}
+
+// rotationUpdate returns the plaintext update that re-encrypts v's stale
+// fields under the current key, or nil if v is current
+func (r *EncryptedPaymentRepo) rotationUpdate(v *repository.KYCVerification) (*repository.KYCVerificationUpdate, error) {
+	stale := false
+	if v.SumsubApplicantID != nil && r.cipher.NeedsRotation(*v.SumsubApplicantID) {
+		stale = true
+	}
+	if v.SumsubInspectionID != nil && r.cipher.NeedsRotation(*v.SumsubInspectionID) {
+		stale = true
+	}
+	if v.SumsubReviewResult != nil {
+		s, ok := v.SumsubReviewResult.(string)
+		if !ok || r.cipher.NeedsRotation(s) {
+			stale = true
+		}
+	}
+	if !stale {
+		return nil, nil
+	}
+
+	if err := decryptVerification(r.cipher, v); err != nil {
+		return nil, err
+	}
+	return &repository.KYCVerificationUpdate{
+		SumsubApplicantID:  v.SumsubApplicantID,
+		SumsubInspectionID: v.SumsubInspectionID,
+		SumsubReviewResult: v.SumsubReviewResult,
+	}, nil
+}
+
+func (r *EncryptedPaymentRepo) decryptedPayment(p *repository.Payment, err error) (*repository.Payment, error) {
+	if err != nil {
+		return nil, err
+	}
+	if err := r.decryptPayment(p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// decryptPayment decrypts p's callback secret in place
+func (r *EncryptedPaymentRepo) decryptPayment(p *repository.Payment) error {
+	if p.CallbackSecret == nil {
+		return nil
+	}
+	s, err := r.cipher.Decrypt(fieldCallbackSecret, *p.CallbackSecret)
+	if err != nil {
+		return fmt.Errorf("decrypting callback secret of payment %s: %w", p.ID, err)
+	}
+	p.CallbackSecret = &s
+	return nil
+}
+
+func (r *EncryptedPaymentRepo) decrypted(v *repository.KYCVerification, err error) (*repository.KYCVerification, error) {
+	if err != nil {
+		return nil, err
+	}
+	if err := decryptVerification(r.cipher, v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// encryptVerification encrypts v's sensitive fields in place
+func (r *EncryptedPaymentRepo) encryptVerification(v *repository.KYCVerification) error {
+	if v.SumsubApplicantID != nil {
+		s := r.cipher.EncryptDeterministic(fieldApplicantID, *v.SumsubApplicantID)
+		v.SumsubApplicantID = &s
+	}
+	if v.SumsubInspectionID != nil {
+		s, err := r.cipher.Encrypt(fieldInspectionID, *v.SumsubInspectionID)
+		if err != nil {
+			return fmt.Errorf("encrypting inspection id: %w", err)
+		}
+		v.SumsubInspectionID = &s
+	}
+	if v.SumsubReviewResult != nil {
+		result, err := r.encryptReviewResult(v.SumsubReviewResult)
+		if err != nil {
+			return err
+		}
+		v.SumsubReviewResult = result
+	}
+	return nil
+}
+
+// encryptReviewResult encrypts the review result's JSON. The ciphertext is
+// stored as a JSON string, which the JSONB column accepts.
+func (r *EncryptedPaymentRepo) encryptReviewResult(result any) (any, error) {
+	if !r.cipher.Enabled() {
+		return result, nil
+	}
+	raw, err := json.Marshal(result)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling review result: %w", err)
+	}
+	s, err := r.cipher.Encrypt(fieldReviewResult, string(raw))
+	if err != nil {
+		return nil, fmt.Errorf("encrypting review result: %w", err)
+	}
+	return s, nil
+}
+
+// decryptVerification decrypts v's sensitive fields in place
+func decryptVerification(c *fieldcrypt.Cipher, v *repository.KYCVerification) error {
+	if v.SumsubApplicantID != nil {
+		s, err := c.Decrypt(fieldApplicantID, *v.SumsubApplicantID)
+		if err != nil {
+			return fmt.Errorf("decrypting applicant id: %w", err)
+		}
+		v.SumsubApplicantID = &s
+	}
+	if v.SumsubInspectionID != nil {
+		s, err := c.Decrypt(fieldInspectionID, *v.SumsubInspectionID)
+		if err != nil {
+			return fmt.Errorf("decrypting inspection id: %w", err)
+		}
+		v.SumsubInspectionID = &s
+	}
+	if s, ok := v.SumsubReviewResult.(string); ok && fieldcrypt.IsEncrypted(s) {
+		raw, err := c.Decrypt(fieldReviewResult, s)
+		if err != nil {
+			return fmt.Errorf("decrypting review result: %w", err)
+		}
+		var result any
+		if err := json.Unmarshal([]byte(raw), &result); err != nil {
+			return fmt.Errorf("parsing review result: %w", err)
+		}
+		v.SumsubReviewResult = result
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+package encrypted_test
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/fieldcrypt"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/storage/encrypted"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/storage/memory"
+)
+
+func keySpec(id string, b byte) string {
+	return id + ":" + base64.StdEncoding.EncodeToString([]byte(strings.Repeat(string(b), fieldcrypt.KeySize)))
+}
+
+func strPtr(s string) *string { return &s }
+
+func TestEncryptedPaymentRepo_KYCVerification(t *testing.T) {
+	ctx := context.Background()
+	c := fieldcrypt.NewCipher()
+	require.NoError(t, c.SetKeys(keySpec("k1", 'a')))
+	raw := memory.NewMemoryPaymentRepo()
+	repo := encrypted.NewEncryptedPaymentRepo(raw, c)
+
+	v := &repository.KYCVerification{
+		UserAddress:       "0x1111111111111111111111111111111111111111",
+		SumsubApplicantID: strPtr("applicant-1"),
+		Status:            repository.KYCStatusPending,
+	}
+	require.NoError(t, repo.CreateKYCVerification(ctx, v))
+	require.NotEmpty(t, v.ID)
+	assert.Equal(t, "applicant-1", *v.SumsubApplicantID, "caller keeps the plaintext")
+
+	status := repository.KYCStatusRejected
+	require.NoError(t, repo.UpdateKYCVerification(ctx, v.ID, &repository.KYCVerificationUpdate{
+		SumsubInspectionID: strPtr("inspection-1"),
+		SumsubReviewResult: map[string]interface{}{"reviewAnswer": "RED", "rejectLabels": []interface{}{"FORGERY"}},
+		Status:             &status,
+	}))
+
+	// The wrapped repository holds ciphertext only
+	stored, err := raw.GetKYCVerification(ctx, v.ID)
+	require.NoError(t, err)
+	assert.True(t, fieldcrypt.IsEncrypted(*stored.SumsubApplicantID))
+	assert.True(t, fieldcrypt.IsEncrypted(*stored.SumsubInspectionID))
+	result, ok := stored.SumsubReviewResult.(string)
+	require.True(t, ok)
+	assert.True(t, fieldcrypt.IsEncrypted(result))
+	assert.NotContains(t, result, "FORGERY")
+
+	got, err := repo.GetKYCVerificationByApplicant(ctx, "applicant-1")
+	require.NoError(t, err)
+	assert.Equal(t, v.ID, got.ID)
+	assert.Equal(t, "applicant-1", *got.SumsubApplicantID)
+	assert.Equal(t, "inspection-1", *got.SumsubInspectionID)
+	assert.Equal(t, map[string]interface{}{"reviewAnswer": "RED", "rejectLabels": []interface{}{"FORGERY"}}, got.SumsubReviewResult)
+
+	list, total, err := repo.ListKYCVerifications(ctx, repository.KYCVerificationFilter{}, repository.Pagination{Page: 1, PageSize: 10})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Equal(t, "applicant-1", *list[0].SumsubApplicantID)
+}
+
+func TestEncryptedPaymentRepo_Rotation(t *testing.T) {
+	ctx := context.Background()
+	raw := memory.NewMemoryPaymentRepo()
+
+	// A row written before encryption was enabled
+	legacy := &repository.KYCVerification{UserAddress: "0x2222222222222222222222222222222222222222", SumsubApplicantID: strPtr("legacy"), Status: repository.KYCStatusPending}
+	require.NoError(t, raw.CreateKYCVerification(ctx, legacy))
+
+	c := fieldcrypt.NewCipher()
+	require.NoError(t, c.SetKeys(keySpec("k1", 'a')))
+	repo := encrypted.NewEncryptedPaymentRepo(raw, c)
+	v := &repository.KYCVerification{UserAddress: "0x3333333333333333333333333333333333333333", SumsubApplicantID: strPtr("applicant-2"), Status: repository.KYCStatusPending}
+	require.NoError(t, repo.CreateKYCVerification(ctx, v))
+
+	// Plaintext rows are still found
+	got, err := repo.GetKYCVerificationByApplicant(ctx, "legacy")
+	require.NoError(t, err)
+	assert.Equal(t, legacy.ID, got.ID)
+
+	// Rotate to k2, keeping k1 for reading
+	require.NoError(t, c.SetKeys(keySpec("k2", 'b')+","+keySpec("k1", 'a')))
+	got, err = repo.GetKYCVerificationByApplicant(ctx, "applicant-2")
+	require.NoError(t, err)
+	assert.Equal(t, v.ID, got.ID)
+
+	n, err := repo.RotateKYCVerifications(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	n, err = repo.RotateKYCVerifications(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, n, "rows are current after one pass")
+
+	// k1 can now be dropped
+	require.NoError(t, c.SetKeys(keySpec("k2", 'b')))
+	for id, applicant := range map[string]string{legacy.ID: "legacy", v.ID: "applicant-2"} {
+		stored, err := raw.GetKYCVerification(ctx, id)
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(*stored.SumsubApplicantID, "enc:v1:k2:"))
+
+		got, err := repo.GetKYCVerificationByApplicant(ctx, applicant)
+		require.NoError(t, err)
+		assert.Equal(t, id, got.ID)
+	}
+}
+
+func TestEncryptedPaymentRepo_CallbackSecret(t *testing.T) {
+	ctx := context.Background()
+	c := fieldcrypt.NewCipher()
+	require.NoError(t, c.SetKeys(keySpec("k1", 'a')))
+	raw := memory.NewMemoryPaymentRepo()
+	repo := encrypted.NewEncryptedPaymentRepo(raw, c)
+
+	p := &repository.Payment{
+		ServiceCode:    "kyc_verification",
+		PayerAddress:   "0x1111111111111111111111111111111111111111",
+		Status:         repository.PaymentStatusPending,
+		CallbackURL:    strPtr("https://dapp.example/callback"),
+		CallbackSecret: strPtr("callback-secret-123"),
+	}
+	require.NoError(t, repo.CreatePayment(ctx, p))
+	assert.Equal(t, "callback-secret-123", *p.CallbackSecret, "caller keeps the plaintext")
+
+	stored, err := raw.GetPayment(ctx, p.ID)
+	require.NoError(t, err)
+	assert.True(t, fieldcrypt.IsEncrypted(*stored.CallbackSecret))
+	assert.Equal(t, "https://dapp.example/callback", *stored.CallbackURL)
+
+	got, err := repo.GetPayment(ctx, p.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "callback-secret-123", *got.CallbackSecret)
+
+	list, _, err := repo.ListPayments(ctx, repository.PaymentFilter{}, repository.Pagination{Page: 1, PageSize: 10})
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, "callback-secret-123", *list[0].CallbackSecret)
+}
@@ -0,0 +1,86 @@
+package encrypted
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/fieldcrypt"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// fieldProfileEmail is the field profile emails are bound to
+const fieldProfileEmail = "user_profiles.email"
+
+// Ensure EncryptedProfileRepo implements ProfileRepository
+var _ repository.ProfileRepository = (*EncryptedProfileRepo)(nil)
+
+// EncryptedProfileRepo wraps a ProfileRepository, encrypting profile emails
+// on write and decrypting them on read
+type EncryptedProfileRepo struct {
+	next   repository.ProfileRepository
+	cipher *fieldcrypt.Cipher
+}
+
+// NewEncryptedProfileRepo wraps next with c
+func NewEncryptedProfileRepo(next repository.ProfileRepository, c *fieldcrypt.Cipher) *EncryptedProfileRepo {
+	return &EncryptedProfileRepo{next: next, cipher: c}
+}
+
+// UpsertProfile implements repository.ProfileRepository
+func (r *EncryptedProfileRepo) UpsertProfile(ctx context.Context, p *repository.Profile) error {
+	plain := p.Email
+	if plain != nil {
+		email, err := r.cipher.Encrypt(fieldProfileEmail, *plain)
+		if err != nil {
+			return fmt.Errorf("encrypting profile email: %w", err)
+		}
+		p.Email = &email
+	}
+	err := r.next.UpsertProfile(ctx, p)
+	p.Email = plain
+	return err
+}
+
+// GetProfile implements repository.ProfileRepository
+func (r *EncryptedProfileRepo) GetProfile(ctx context.Context, address string) (*repository.Profile, error) {
+	p, err := r.next.GetProfile(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.decryptProfile(p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// DeleteProfile implements repository.ProfileRepository
+func (r *EncryptedProfileRepo) DeleteProfile(ctx context.Context, address string) error {
+	return r.next.DeleteProfile(ctx, address)
+}
+
+// GetProfiles implements repository.ProfileRepository
+func (r *EncryptedProfileRepo) GetProfiles(ctx context.Context, addresses []string) (map[string]*repository.Profile, error) {
+	out, err := r.next.GetProfiles(ctx, addresses)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range out {
+		if err := r.decryptProfile(p); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+// decryptProfile decrypts p's email in place
+func (r *EncryptedProfileRepo) decryptProfile(p *repository.Profile) error {
+	if p.Email == nil {
+		return nil
+	}
+	email, err := r.cipher.Decrypt(fieldProfileEmail, *p.Email)
+	if err != nil {
+		return fmt.Errorf("decrypting email of profile %s: %w", p.Address, err)
+	}
+	p.Email = &email
+	return nil
+}
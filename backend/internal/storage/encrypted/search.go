@@ -0,0 +1,41 @@
+package encrypted
+
+import (
+	"context"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/fieldcrypt"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure EncryptedSearchRepo implements SearchRepository
+var _ repository.SearchRepository = (*EncryptedSearchRepo)(nil)
+
+// EncryptedSearchRepo decrypts the KYC verifications in search results.
+// The database cannot match encrypted fields, so once a key is configured
+// verifications are found by their unencrypted columns only.
+type EncryptedSearchRepo struct {
+	next   repository.SearchRepository
+	cipher *fieldcrypt.Cipher
+}
+
+// NewEncryptedSearchRepo wraps next with c
+func NewEncryptedSearchRepo(next repository.SearchRepository, c *fieldcrypt.Cipher) *EncryptedSearchRepo {
+	return &EncryptedSearchRepo{next: next, cipher: c}
+}
+
+// Search implements repository.SearchRepository
+func (r *EncryptedSearchRepo) Search(ctx context.Context, query repository.SearchQuery) ([]*repository.SearchResult, error) {
+	out, err := r.next.Search(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	for _, result := range out {
+		if result.KYCVerification == nil {
+			continue
+		}
+		if err := decryptVerification(r.cipher, result.KYCVerification); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
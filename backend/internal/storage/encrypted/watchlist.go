@@ -0,0 +1,96 @@
+package encrypted
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/fieldcrypt"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// fieldWatchlistSecret is the field watchlist secrets are bound to
+const fieldWatchlistSecret = "watchlists.secret"
+
+// Ensure EncryptedWatchlistRepo implements WatchlistRepository
+var _ repository.WatchlistRepository = (*EncryptedWatchlistRepo)(nil)
+
+// EncryptedWatchlistRepo wraps a WatchlistRepository, encrypting watchlist
+// secrets on write and decrypting them on read
+type EncryptedWatchlistRepo struct {
+	next   repository.WatchlistRepository
+	cipher *fieldcrypt.Cipher
+}
+
+// NewEncryptedWatchlistRepo wraps next with c
+func NewEncryptedWatchlistRepo(next repository.WatchlistRepository, c *fieldcrypt.Cipher) *EncryptedWatchlistRepo {
+	return &EncryptedWatchlistRepo{next: next, cipher: c}
+}
+
+// CreateWatchlist implements repository.WatchlistRepository
+func (r *EncryptedWatchlistRepo) CreateWatchlist(ctx context.Context, w *repository.Watchlist) error {
+	return r.withEncryptedSecret(w, func() error {
+		return r.next.CreateWatchlist(ctx, w)
+	})
+}
+
+// GetWatchlist implements repository.WatchlistRepository
+func (r *EncryptedWatchlistRepo) GetWatchlist(ctx context.Context, id string) (*repository.Watchlist, error) {
+	w, err := r.next.GetWatchlist(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.decryptWatchlist(w); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// UpdateWatchlist implements repository.WatchlistRepository
+func (r *EncryptedWatchlistRepo) UpdateWatchlist(ctx context.Context, w *repository.Watchlist) error {
+	return r.withEncryptedSecret(w, func() error {
+		return r.next.UpdateWatchlist(ctx, w)
+	})
+}
+
+// DeleteWatchlist implements repository.WatchlistRepository
+func (r *EncryptedWatchlistRepo) DeleteWatchlist(ctx context.Context, id string) error {
+	return r.next.DeleteWatchlist(ctx, id)
+}
+
+// ListWatchlists implements repository.WatchlistRepository
+func (r *EncryptedWatchlistRepo) ListWatchlists(ctx context.Context, filter repository.WatchlistFilter, page repository.Pagination) ([]*repository.Watchlist, int64, error) {
+	out, total, err := r.next.ListWatchlists(ctx, filter, page)
+	if err != nil {
+		return nil, 0, err
+	}
+	for _, w := range out {
+		if err := r.decryptWatchlist(w); err != nil {
+			return nil, 0, err
+		}
+	}
+	return out, total, nil
+}
+
+// withEncryptedSecret runs write with w's secret encrypted, restoring the
+// plaintext afterwards
+func (r *EncryptedWatchlistRepo) withEncryptedSecret(w *repository.Watchlist, write func() error) error {
+	plain := w.Secret
+	secret, err := r.cipher.Encrypt(fieldWatchlistSecret, plain)
+	if err != nil {
+		return fmt.Errorf("encrypting watchlist secret: %w", err)
+	}
+	w.Secret = secret
+	err = write()
+	w.Secret = plain
+	return err
+}
+
+// decryptWatchlist decrypts w's secret in place
+func (r *EncryptedWatchlistRepo) decryptWatchlist(w *repository.Watchlist) error {
+	secret, err := r.cipher.Decrypt(fieldWatchlistSecret, w.Secret)
+	if err != nil {
+		return fmt.Errorf("decrypting secret of watchlist %s: %w", w.ID, err)
+	}
+	w.Secret = secret
+	return nil
+}
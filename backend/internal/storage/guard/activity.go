@@ -0,0 +1,39 @@
+package guard
+
+import (
+	"context"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure GuardedActivityRepo implements ActivityRepository
+var _ repository.ActivityRepository = (*GuardedActivityRepo)(nil)
+
+// GuardedActivityRepo wraps an ActivityRepository with query deadlines and the database breaker
+type GuardedActivityRepo struct {
+	next  repository.ActivityRepository
+	guard *Guard
+}
+
+// NewGuardedActivityRepo wraps next with g
+func NewGuardedActivityRepo(next repository.ActivityRepository, g *Guard) *GuardedActivityRepo {
+	return &GuardedActivityRepo{next: next, guard: g}
+}
+
+// RecordActivity implements repository.ActivityRepository
+func (r *GuardedActivityRepo) RecordActivity(ctx context.Context, e *repository.ActivityEvent) error {
+	return r.guard.do(ctx, func(ctx context.Context) error {
+		return r.next.RecordActivity(ctx, e)
+	})
+}
+
+// ListActivity implements repository.ActivityRepository
+func (r *GuardedActivityRepo) ListActivity(ctx context.Context, filter repository.ActivityFilter, page repository.Pagination) ([]*repository.ActivityEvent, int64, error) {
+	var out []*repository.ActivityEvent
+	var total int64
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, total, err = r.next.ListActivity(ctx, filter, page)
+		return err
+	})
+	return out, total, err
+}
@@ -0,0 +1,93 @@
+package guard
+
+import (
+	"context"
+	"time"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure GuardedAnomalyRepo implements AnomalyRepository
+var _ repository.AnomalyRepository = (*GuardedAnomalyRepo)(nil)
+
+// GuardedAnomalyRepo wraps an AnomalyRepository with query deadlines and the database breaker
+type GuardedAnomalyRepo struct {
+	next  repository.AnomalyRepository
+	guard *Guard
+}
+
+// NewGuardedAnomalyRepo wraps next with g
+func NewGuardedAnomalyRepo(next repository.AnomalyRepository, g *Guard) *GuardedAnomalyRepo {
+	return &GuardedAnomalyRepo{next: next, guard: g}
+}
+
+// CountPaymentsByPayer implements repository.AnomalyRepository
+func (r *GuardedAnomalyRepo) CountPaymentsByPayer(ctx context.Context, since time.Time, min int64) ([]repository.SubjectCount, error) {
+	var out []repository.SubjectCount
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.CountPaymentsByPayer(ctx, since, min)
+		return err
+	})
+	return out, err
+}
+
+// CountFailedRelaysBySender implements repository.AnomalyRepository
+func (r *GuardedAnomalyRepo) CountFailedRelaysBySender(ctx context.Context, since time.Time, min int64) ([]repository.SubjectCount, error) {
+	var out []repository.SubjectCount
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.CountFailedRelaysBySender(ctx, since, min)
+		return err
+	})
+	return out, err
+}
+
+// HourlyActivity implements repository.AnomalyRepository
+func (r *GuardedAnomalyRepo) HourlyActivity(ctx context.Context, since time.Time) ([]repository.HourlyCount, error) {
+	var out []repository.HourlyCount
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.HourlyActivity(ctx, since)
+		return err
+	})
+	return out, err
+}
+
+// OpenComplianceCase implements repository.AnomalyRepository
+func (r *GuardedAnomalyRepo) OpenComplianceCase(ctx context.Context, c *repository.ComplianceCase) (bool, error) {
+	var opened bool
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		opened, err = r.next.OpenComplianceCase(ctx, c)
+		return err
+	})
+	return opened, err
+}
+
+// GetComplianceCase implements repository.AnomalyRepository
+func (r *GuardedAnomalyRepo) GetComplianceCase(ctx context.Context, id string) (*repository.ComplianceCase, error) {
+	var out *repository.ComplianceCase
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.GetComplianceCase(ctx, id)
+		return err
+	})
+	return out, err
+}
+
+// ListComplianceCases implements repository.AnomalyRepository
+func (r *GuardedAnomalyRepo) ListComplianceCases(ctx context.Context, filter repository.ComplianceCaseFilter, page repository.Pagination) ([]*repository.ComplianceCase, int64, error) {
+	var out []*repository.ComplianceCase
+	var total int64
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, total, err = r.next.ListComplianceCases(ctx, filter, page)
+		return err
+	})
+	return out, total, err
+}
+
+// CloseComplianceCase implements repository.AnomalyRepository
+func (r *GuardedAnomalyRepo) CloseComplianceCase(ctx context.Context, id, closedBy, resolution string) (*repository.ComplianceCase, error) {
+	var out *repository.ComplianceCase
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.CloseComplianceCase(ctx, id, closedBy, resolution)
+		return err
+	})
+	return out, err
+}
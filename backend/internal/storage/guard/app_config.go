@@ -0,0 +1,140 @@
+package guard
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure GuardedAppConfigRepo implements AppConfigRepository
+var _ repository.AppConfigRepository = (*GuardedAppConfigRepo)(nil)
+
+// GuardedAppConfigRepo wraps an AppConfigRepository with query deadlines and the database breaker
+type GuardedAppConfigRepo struct {
+	next  repository.AppConfigRepository
+	guard *Guard
+}
+
+// NewGuardedAppConfigRepo wraps next with g
+func NewGuardedAppConfigRepo(next repository.AppConfigRepository, g *Guard) *GuardedAppConfigRepo {
+	return &GuardedAppConfigRepo{next: next, guard: g}
+}
+
+// Get implements repository.AppConfigRepository
+func (r *GuardedAppConfigRepo) Get(ctx context.Context, namespace, key string, chainID int64) (*repository.AppConfig, error) {
+	var out *repository.AppConfig
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.Get(ctx, namespace, key, chainID)
+		return err
+	})
+	return out, err
+}
+
+// GetWithFallback implements repository.AppConfigRepository
+func (r *GuardedAppConfigRepo) GetWithFallback(ctx context.Context, namespace, key string, chainID int64) (*repository.AppConfig, error) {
+	var out *repository.AppConfig
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.GetWithFallback(ctx, namespace, key, chainID)
+		return err
+	})
+	return out, err
+}
+
+// ListByNamespace implements repository.AppConfigRepository
+func (r *GuardedAppConfigRepo) ListByNamespace(ctx context.Context, namespace string, chainID int64) ([]*repository.AppConfig, error) {
+	var out []*repository.AppConfig
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.ListByNamespace(ctx, namespace, chainID)
+		return err
+	})
+	return out, err
+}
+
+// ListAll implements repository.AppConfigRepository
+func (r *GuardedAppConfigRepo) ListAll(ctx context.Context) ([]*repository.AppConfig, error) {
+	var out []*repository.AppConfig
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.ListAll(ctx)
+		return err
+	})
+	return out, err
+}
+
+// GetString implements repository.AppConfigRepository
+func (r *GuardedAppConfigRepo) GetString(ctx context.Context, namespace, key string, chainID int64) (string, error) {
+	var out string
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.GetString(ctx, namespace, key, chainID)
+		return err
+	})
+	return out, err
+}
+
+// GetNumber implements repository.AppConfigRepository
+func (r *GuardedAppConfigRepo) GetNumber(ctx context.Context, namespace, key string, chainID int64) (int64, error) {
+	var out int64
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.GetNumber(ctx, namespace, key, chainID)
+		return err
+	})
+	return out, err
+}
+
+// GetWei implements repository.AppConfigRepository
+func (r *GuardedAppConfigRepo) GetWei(ctx context.Context, namespace, key string, chainID int64) (*big.Int, error) {
+	var out *big.Int
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.GetWei(ctx, namespace, key, chainID)
+		return err
+	})
+	return out, err
+}
+
+// GetBool implements repository.AppConfigRepository
+func (r *GuardedAppConfigRepo) GetBool(ctx context.Context, namespace, key string, chainID int64) (bool, error) {
+	var out bool
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.GetBool(ctx, namespace, key, chainID)
+		return err
+	})
+	return out, err
+}
+
+// GetJSON implements repository.AppConfigRepository
+func (r *GuardedAppConfigRepo) GetJSON(ctx context.Context, namespace, key string, chainID int64, dest interface{}) error {
+	return r.guard.do(ctx, func(ctx context.Context) error {
+		return r.next.GetJSON(ctx, namespace, key, chainID, dest)
+	})
+}
+
+// Update implements repository.AppConfigRepository
+func (r *GuardedAppConfigRepo) Update(ctx context.Context, namespace, key string, chainID int64, update *repository.AppConfigUpdate) error {
+	return r.guard.do(ctx, func(ctx context.Context) error {
+		return r.next.Update(ctx, namespace, key, chainID, update)
+	})
+}
+
+// Create implements repository.AppConfigRepository
+func (r *GuardedAppConfigRepo) Create(ctx context.Context, config *repository.AppConfigCreate) error {
+	return r.guard.do(ctx, func(ctx context.Context) error {
+		return r.next.Create(ctx, config)
+	})
+}
+
+// Delete implements repository.AppConfigRepository
+func (r *GuardedAppConfigRepo) Delete(ctx context.Context, namespace, key string, chainID int64, deletedBy string) error {
+	return r.guard.do(ctx, func(ctx context.Context) error {
+		return r.next.Delete(ctx, namespace, key, chainID, deletedBy)
+	})
+}
+
+// GetHistory implements repository.AppConfigRepository
+func (r *GuardedAppConfigRepo) GetHistory(ctx context.Context, namespace, key string, chainID int64, limit int) ([]*repository.AppConfigHistoryEntry, error) {
+	var out []*repository.AppConfigHistoryEntry
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.GetHistory(ctx, namespace, key, chainID, limit)
+		return err
+	})
+	return out, err
+}
@@ -0,0 +1,103 @@
+package guard
+
+import (
+	"context"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure GuardedBridgeRepo implements BridgeRepository
+var _ repository.BridgeRepository = (*GuardedBridgeRepo)(nil)
+
+// GuardedBridgeRepo wraps a BridgeRepository with query deadlines and the database breaker
+type GuardedBridgeRepo struct {
+	next  repository.BridgeRepository
+	guard *Guard
+}
+
+// NewGuardedBridgeRepo wraps next with g
+func NewGuardedBridgeRepo(next repository.BridgeRepository, g *Guard) *GuardedBridgeRepo {
+	return &GuardedBridgeRepo{next: next, guard: g}
+}
+
+// CreateBridgeTransfer implements repository.BridgeRepository
+func (r *GuardedBridgeRepo) CreateBridgeTransfer(ctx context.Context, t *repository.BridgeTransfer) error {
+	return r.guard.do(ctx, func(ctx context.Context) error {
+		return r.next.CreateBridgeTransfer(ctx, t)
+	})
+}
+
+// GetBridgeTransfer implements repository.BridgeRepository
+func (r *GuardedBridgeRepo) GetBridgeTransfer(ctx context.Context, id string) (*repository.BridgeTransfer, error) {
+	var out *repository.BridgeTransfer
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.GetBridgeTransfer(ctx, id)
+		return err
+	})
+	return out, err
+}
+
+// GetBridgeTransferByNonce implements repository.BridgeRepository
+func (r *GuardedBridgeRepo) GetBridgeTransferByNonce(ctx context.Context, sourceChainID int64, nonce uint64) (*repository.BridgeTransfer, error) {
+	var out *repository.BridgeTransfer
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.GetBridgeTransferByNonce(ctx, sourceChainID, nonce)
+		return err
+	})
+	return out, err
+}
+
+// GetBridgeTransferByDestID implements repository.BridgeRepository
+func (r *GuardedBridgeRepo) GetBridgeTransferByDestID(ctx context.Context, destTransferID string) (*repository.BridgeTransfer, error) {
+	var out *repository.BridgeTransfer
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.GetBridgeTransferByDestID(ctx, destTransferID)
+		return err
+	})
+	return out, err
+}
+
+// MatchBridgeIntent implements repository.BridgeRepository
+func (r *GuardedBridgeRepo) MatchBridgeIntent(ctx context.Context, match repository.BridgeIntentMatch) (*repository.BridgeTransfer, error) {
+	var out *repository.BridgeTransfer
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.MatchBridgeIntent(ctx, match)
+		return err
+	})
+	return out, err
+}
+
+// UpdateBridgeTransfer implements repository.BridgeRepository
+func (r *GuardedBridgeRepo) UpdateBridgeTransfer(ctx context.Context, id string, update *repository.BridgeTransferUpdate) error {
+	return r.guard.do(ctx, func(ctx context.Context) error {
+		return r.next.UpdateBridgeTransfer(ctx, id, update)
+	})
+}
+
+// ListBridgeTransfers implements repository.BridgeRepository
+func (r *GuardedBridgeRepo) ListBridgeTransfers(ctx context.Context, address string, page repository.Pagination) ([]*repository.BridgeTransfer, int64, error) {
+	var out []*repository.BridgeTransfer
+	var total int64
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, total, err = r.next.ListBridgeTransfers(ctx, address, page)
+		return err
+	})
+	return out, total, err
+}
+
+// GetBridgeCursor implements repository.BridgeRepository
+func (r *GuardedBridgeRepo) GetBridgeCursor(ctx context.Context, chainID int64) (uint64, error) {
+	var out uint64
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.GetBridgeCursor(ctx, chainID)
+		return err
+	})
+	return out, err
+}
+
+// SetBridgeCursor implements repository.BridgeRepository
+func (r *GuardedBridgeRepo) SetBridgeCursor(ctx context.Context, chainID int64, nextBlock uint64) error {
+	return r.guard.do(ctx, func(ctx context.Context) error {
+		return r.next.SetBridgeCursor(ctx, chainID, nextBlock)
+	})
+}
@@ -0,0 +1,102 @@
+package guard
+
+import (
+	"context"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure GuardedChainRepo implements ChainRepository
+var _ repository.ChainRepository = (*GuardedChainRepo)(nil)
+
+// GuardedChainRepo wraps a ChainRepository with query deadlines and the database breaker
+type GuardedChainRepo struct {
+	next  repository.ChainRepository
+	guard *Guard
+}
+
+// NewGuardedChainRepo wraps next with g
+func NewGuardedChainRepo(next repository.ChainRepository, g *Guard) *GuardedChainRepo {
+	return &GuardedChainRepo{next: next, guard: g}
+}
+
+// SaveBlock implements repository.ChainRepository
+func (r *GuardedChainRepo) SaveBlock(ctx context.Context, block *repository.BlockRecord) error {
+	return r.guard.do(ctx, func(ctx context.Context) error {
+		return r.next.SaveBlock(ctx, block)
+	})
+}
+
+// GetBlock implements repository.ChainRepository
+func (r *GuardedChainRepo) GetBlock(ctx context.Context, chainID int64, number uint64) (*repository.BlockRecord, error) {
+	var out *repository.BlockRecord
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.GetBlock(ctx, chainID, number)
+		return err
+	})
+	return out, err
+}
+
+// GetLatestBlock implements repository.ChainRepository
+func (r *GuardedChainRepo) GetLatestBlock(ctx context.Context, chainID int64) (*repository.BlockRecord, error) {
+	var out *repository.BlockRecord
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.GetLatestBlock(ctx, chainID)
+		return err
+	})
+	return out, err
+}
+
+// DeleteBlocksAbove implements repository.ChainRepository
+func (r *GuardedChainRepo) DeleteBlocksAbove(ctx context.Context, chainID int64, number uint64) (int64, error) {
+	var out int64
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.DeleteBlocksAbove(ctx, chainID, number)
+		return err
+	})
+	return out, err
+}
+
+// PruneBlocksBelow implements repository.ChainRepository
+func (r *GuardedChainRepo) PruneBlocksBelow(ctx context.Context, chainID int64, number uint64) (int64, error) {
+	var out int64
+	err := r.guard.doMaintenance(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.PruneBlocksBelow(ctx, chainID, number)
+		return err
+	})
+	return out, err
+}
+
+// TrackTx implements repository.ChainRepository
+func (r *GuardedChainRepo) TrackTx(ctx context.Context, tx *repository.TrackedTx) error {
+	return r.guard.do(ctx, func(ctx context.Context) error {
+		return r.next.TrackTx(ctx, tx)
+	})
+}
+
+// ListUnfinalizedTxs implements repository.ChainRepository
+func (r *GuardedChainRepo) ListUnfinalizedTxs(ctx context.Context, chainID int64, limit int) ([]*repository.TrackedTx, error) {
+	var out []*repository.TrackedTx
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.ListUnfinalizedTxs(ctx, chainID, limit)
+		return err
+	})
+	return out, err
+}
+
+// UpdateTrackedTx implements repository.ChainRepository
+func (r *GuardedChainRepo) UpdateTrackedTx(ctx context.Context, id string, update *repository.TrackedTxUpdate) error {
+	return r.guard.do(ctx, func(ctx context.Context) error {
+		return r.next.UpdateTrackedTx(ctx, id, update)
+	})
+}
+
+// RollbackTxsAbove implements repository.ChainRepository
+func (r *GuardedChainRepo) RollbackTxsAbove(ctx context.Context, chainID int64, number uint64) ([]*repository.TrackedTx, error) {
+	var out []*repository.TrackedTx
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.RollbackTxsAbove(ctx, chainID, number)
+		return err
+	})
+	return out, err
+}
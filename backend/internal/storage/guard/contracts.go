@@ -0,0 +1,171 @@
+package guard
+
+import (
+	"context"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure GuardedContractRepo implements ContractRepository
+var _ repository.ContractRepository = (*GuardedContractRepo)(nil)
+
+// GuardedContractRepo wraps a ContractRepository with query deadlines and the database breaker
+type GuardedContractRepo struct {
+	next  repository.ContractRepository
+	guard *Guard
+}
+
+// NewGuardedContractRepo wraps next with g
+func NewGuardedContractRepo(next repository.ContractRepository, g *Guard) *GuardedContractRepo {
+	return &GuardedContractRepo{next: next, guard: g}
+}
+
+// GetNetworkByChainID implements repository.ContractRepository
+func (r *GuardedContractRepo) GetNetworkByChainID(ctx context.Context, chainID int64) (*repository.NetworkConfig, error) {
+	var out *repository.NetworkConfig
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.GetNetworkByChainID(ctx, chainID)
+		return err
+	})
+	return out, err
+}
+
+// GetNetworkByName implements repository.ContractRepository
+func (r *GuardedContractRepo) GetNetworkByName(ctx context.Context, name string) (*repository.NetworkConfig, error) {
+	var out *repository.NetworkConfig
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.GetNetworkByName(ctx, name)
+		return err
+	})
+	return out, err
+}
+
+// GetActiveNetworks implements repository.ContractRepository
+func (r *GuardedContractRepo) GetActiveNetworks(ctx context.Context) ([]*repository.NetworkConfig, error) {
+	var out []*repository.NetworkConfig
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.GetActiveNetworks(ctx)
+		return err
+	})
+	return out, err
+}
+
+// GetAllMappings implements repository.ContractRepository
+func (r *GuardedContractRepo) GetAllMappings(ctx context.Context) ([]*repository.ContractMapping, error) {
+	var out []*repository.ContractMapping
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.GetAllMappings(ctx)
+		return err
+	})
+	return out, err
+}
+
+// GetMappingBySolidityName implements repository.ContractRepository
+func (r *GuardedContractRepo) GetMappingBySolidityName(ctx context.Context, name string) (*repository.ContractMapping, error) {
+	var out *repository.ContractMapping
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.GetMappingBySolidityName(ctx, name)
+		return err
+	})
+	return out, err
+}
+
+// GetMappingByDBName implements repository.ContractRepository
+func (r *GuardedContractRepo) GetMappingByDBName(ctx context.Context, dbName string) (*repository.ContractMapping, error) {
+	var out *repository.ContractMapping
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.GetMappingByDBName(ctx, dbName)
+		return err
+	})
+	return out, err
+}
+
+// GetByChainID implements repository.ContractRepository
+func (r *GuardedContractRepo) GetByChainID(ctx context.Context, chainID int64) ([]*repository.ContractAddress, error) {
+	var out []*repository.ContractAddress
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.GetByChainID(ctx, chainID)
+		return err
+	})
+	return out, err
+}
+
+// GetByChainAndDBName implements repository.ContractRepository
+func (r *GuardedContractRepo) GetByChainAndDBName(ctx context.Context, chainID int64, dbName string) (*repository.ContractAddress, error) {
+	var out *repository.ContractAddress
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.GetByChainAndDBName(ctx, chainID, dbName)
+		return err
+	})
+	return out, err
+}
+
+// GetByID implements repository.ContractRepository
+func (r *GuardedContractRepo) GetByID(ctx context.Context, id string) (*repository.ContractAddress, error) {
+	var out *repository.ContractAddress
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.GetByID(ctx, id)
+		return err
+	})
+	return out, err
+}
+
+// Upsert implements repository.ContractRepository
+func (r *GuardedContractRepo) Upsert(ctx context.Context, contract *repository.ContractAddressUpsert) (*repository.ContractAddress, error) {
+	var out *repository.ContractAddress
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.Upsert(ctx, contract)
+		return err
+	})
+	return out, err
+}
+
+// GetHistory implements repository.ContractRepository
+func (r *GuardedContractRepo) GetHistory(ctx context.Context, contractID string, limit int) ([]*repository.ContractAddressHistory, error) {
+	var out []*repository.ContractAddressHistory
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.GetHistory(ctx, contractID, limit)
+		return err
+	})
+	return out, err
+}
+
+// GetDeploymentConfig implements repository.ContractRepository
+func (r *GuardedContractRepo) GetDeploymentConfig(ctx context.Context, chainID int64) (*repository.DeploymentConfig, error) {
+	var out *repository.DeploymentConfig
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.GetDeploymentConfig(ctx, chainID)
+		return err
+	})
+	return out, err
+}
+
+// UpsertABI implements repository.ContractRepository
+func (r *GuardedContractRepo) UpsertABI(ctx context.Context, artifact *repository.ABIArtifactUpsert) (*repository.ABIArtifact, error) {
+	var out *repository.ABIArtifact
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.UpsertABI(ctx, artifact)
+		return err
+	})
+	return out, err
+}
+
+// GetABI implements repository.ContractRepository
+func (r *GuardedContractRepo) GetABI(ctx context.Context, dbName, version string) (*repository.ABIArtifact, error) {
+	var out *repository.ABIArtifact
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.GetABI(ctx, dbName, version)
+		return err
+	})
+	return out, err
+}
+
+// ListABIVersions implements repository.ContractRepository
+func (r *GuardedContractRepo) ListABIVersions(ctx context.Context, dbName string) ([]*repository.ABIArtifact, error) {
+	var out []*repository.ABIArtifact
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.ListABIVersions(ctx, dbName)
+		return err
+	})
+	return out, err
+}
@@ -0,0 +1,66 @@
+package guard
+
+import (
+	"context"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure GuardedDepositRepo implements DepositRepository
+var _ repository.DepositRepository = (*GuardedDepositRepo)(nil)
+
+// GuardedDepositRepo wraps a DepositRepository with query deadlines and the database breaker
+type GuardedDepositRepo struct {
+	next  repository.DepositRepository
+	guard *Guard
+}
+
+// NewGuardedDepositRepo wraps next with g
+func NewGuardedDepositRepo(next repository.DepositRepository, g *Guard) *GuardedDepositRepo {
+	return &GuardedDepositRepo{next: next, guard: g}
+}
+
+// NextDepositIndex implements repository.DepositRepository
+func (r *GuardedDepositRepo) NextDepositIndex(ctx context.Context) (int64, error) {
+	var out int64
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.NextDepositIndex(ctx)
+		return err
+	})
+	return out, err
+}
+
+// CreateDepositIntent implements repository.DepositRepository
+func (r *GuardedDepositRepo) CreateDepositIntent(ctx context.Context, d *repository.DepositIntent) error {
+	return r.guard.do(ctx, func(ctx context.Context) error {
+		return r.next.CreateDepositIntent(ctx, d)
+	})
+}
+
+// GetDepositIntent implements repository.DepositRepository
+func (r *GuardedDepositRepo) GetDepositIntent(ctx context.Context, id string) (*repository.DepositIntent, error) {
+	var out *repository.DepositIntent
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.GetDepositIntent(ctx, id)
+		return err
+	})
+	return out, err
+}
+
+// ListDepositIntents implements repository.DepositRepository
+func (r *GuardedDepositRepo) ListDepositIntents(ctx context.Context, filter repository.DepositIntentFilter, page repository.Pagination) ([]*repository.DepositIntent, int64, error) {
+	var out []*repository.DepositIntent
+	var total int64
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, total, err = r.next.ListDepositIntents(ctx, filter, page)
+		return err
+	})
+	return out, total, err
+}
+
+// UpdateDepositIntent implements repository.DepositRepository
+func (r *GuardedDepositRepo) UpdateDepositIntent(ctx context.Context, id string, update *repository.DepositIntentUpdate) error {
+	return r.guard.do(ctx, func(ctx context.Context) error {
+		return r.next.UpdateDepositIntent(ctx, id, update)
+	})
+}
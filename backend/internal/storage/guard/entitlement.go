@@ -0,0 +1,61 @@
+package guard
+
+import (
+	"context"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure GuardedEntitlementRepo implements EntitlementRepository
+var _ repository.EntitlementRepository = (*GuardedEntitlementRepo)(nil)
+
+// GuardedEntitlementRepo wraps an EntitlementRepository with query deadlines and the database breaker
+type GuardedEntitlementRepo struct {
+	next  repository.EntitlementRepository
+	guard *Guard
+}
+
+// NewGuardedEntitlementRepo wraps next with g
+func NewGuardedEntitlementRepo(next repository.EntitlementRepository, g *Guard) *GuardedEntitlementRepo {
+	return &GuardedEntitlementRepo{next: next, guard: g}
+}
+
+// GrantCredits implements repository.EntitlementRepository
+func (r *GuardedEntitlementRepo) GrantCredits(ctx context.Context, g *repository.EntitlementGrant) (bool, error) {
+	var out bool
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.GrantCredits(ctx, g)
+		return err
+	})
+	return out, err
+}
+
+// ConsumeCredits implements repository.EntitlementRepository
+func (r *GuardedEntitlementRepo) ConsumeCredits(ctx context.Context, address, serviceCode string, quantity int) (*repository.Entitlement, error) {
+	var out *repository.Entitlement
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.ConsumeCredits(ctx, address, serviceCode, quantity)
+		return err
+	})
+	return out, err
+}
+
+// GetEntitlement implements repository.EntitlementRepository
+func (r *GuardedEntitlementRepo) GetEntitlement(ctx context.Context, address, serviceCode string) (*repository.Entitlement, error) {
+	var out *repository.Entitlement
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.GetEntitlement(ctx, address, serviceCode)
+		return err
+	})
+	return out, err
+}
+
+// ListEntitlements implements repository.EntitlementRepository
+func (r *GuardedEntitlementRepo) ListEntitlements(ctx context.Context, address string) ([]*repository.Entitlement, error) {
+	var out []*repository.Entitlement
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.ListEntitlements(ctx, address)
+		return err
+	})
+	return out, err
+}
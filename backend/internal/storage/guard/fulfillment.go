@@ -0,0 +1,52 @@
+package guard
+
+import (
+	"context"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure GuardedFulfillmentRepo implements FulfillmentRepository
+var _ repository.FulfillmentRepository = (*GuardedFulfillmentRepo)(nil)
+
+// GuardedFulfillmentRepo wraps a FulfillmentRepository with query deadlines and the database breaker
+type GuardedFulfillmentRepo struct {
+	next  repository.FulfillmentRepository
+	guard *Guard
+}
+
+// NewGuardedFulfillmentRepo wraps next with g
+func NewGuardedFulfillmentRepo(next repository.FulfillmentRepository, g *Guard) *GuardedFulfillmentRepo {
+	return &GuardedFulfillmentRepo{next: next, guard: g}
+}
+
+// RecordFulfillment implements repository.FulfillmentRepository
+func (r *GuardedFulfillmentRepo) RecordFulfillment(ctx context.Context, f *repository.Fulfillment) (bool, error) {
+	var out bool
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.RecordFulfillment(ctx, f)
+		return err
+	})
+	return out, err
+}
+
+// GetFulfillmentByPayment implements repository.FulfillmentRepository
+func (r *GuardedFulfillmentRepo) GetFulfillmentByPayment(ctx context.Context, paymentID string) (*repository.Fulfillment, error) {
+	var out *repository.Fulfillment
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.GetFulfillmentByPayment(ctx, paymentID)
+		return err
+	})
+	return out, err
+}
+
+// ListFulfillments implements repository.FulfillmentRepository
+func (r *GuardedFulfillmentRepo) ListFulfillments(ctx context.Context, filter repository.FulfillmentFilter, page repository.Pagination) ([]*repository.Fulfillment, int64, error) {
+	var out []*repository.Fulfillment
+	var total int64
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, total, err = r.next.ListFulfillments(ctx, filter, page)
+		return err
+	})
+	return out, total, err
+}
@@ -0,0 +1,98 @@
+package guard
+
+import (
+	"context"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure GuardedGasBillingRepo implements GasBillingRepository
+var _ repository.GasBillingRepository = (*GuardedGasBillingRepo)(nil)
+
+// GuardedGasBillingRepo wraps a GasBillingRepository with query deadlines and the database breaker
+type GuardedGasBillingRepo struct {
+	next  repository.GasBillingRepository
+	guard *Guard
+}
+
+// NewGuardedGasBillingRepo wraps next with g
+func NewGuardedGasBillingRepo(next repository.GasBillingRepository, g *Guard) *GuardedGasBillingRepo {
+	return &GuardedGasBillingRepo{next: next, guard: g}
+}
+
+// CreateGasCharge implements repository.GasBillingRepository
+func (r *GuardedGasBillingRepo) CreateGasCharge(ctx context.Context, c *repository.GasCharge) (bool, error) {
+	var out bool
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.CreateGasCharge(ctx, c)
+		return err
+	})
+	return out, err
+}
+
+// ListGasCharges implements repository.GasBillingRepository
+func (r *GuardedGasBillingRepo) ListGasCharges(ctx context.Context, userAddress string, page repository.Pagination) ([]*repository.GasCharge, int64, error) {
+	var out []*repository.GasCharge
+	var total int64
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, total, err = r.next.ListGasCharges(ctx, userAddress, page)
+		return err
+	})
+	return out, total, err
+}
+
+// CreateGasSettlement implements repository.GasBillingRepository
+func (r *GuardedGasBillingRepo) CreateGasSettlement(ctx context.Context, s *repository.GasSettlement) error {
+	return r.guard.do(ctx, func(ctx context.Context) error {
+		return r.next.CreateGasSettlement(ctx, s)
+	})
+}
+
+// GetGasSettlement implements repository.GasBillingRepository
+func (r *GuardedGasBillingRepo) GetGasSettlement(ctx context.Context, id string) (*repository.GasSettlement, error) {
+	var out *repository.GasSettlement
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.GetGasSettlement(ctx, id)
+		return err
+	})
+	return out, err
+}
+
+// ListGasSettlements implements repository.GasBillingRepository
+func (r *GuardedGasBillingRepo) ListGasSettlements(ctx context.Context, filter repository.GasSettlementFilter, page repository.Pagination) ([]*repository.GasSettlement, int64, error) {
+	var out []*repository.GasSettlement
+	var total int64
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, total, err = r.next.ListGasSettlements(ctx, filter, page)
+		return err
+	})
+	return out, total, err
+}
+
+// UpdateGasSettlementStatus implements repository.GasBillingRepository
+func (r *GuardedGasBillingRepo) UpdateGasSettlementStatus(ctx context.Context, id string, update *repository.GasSettlementUpdate) error {
+	return r.guard.do(ctx, func(ctx context.Context) error {
+		return r.next.UpdateGasSettlementStatus(ctx, id, update)
+	})
+}
+
+// GetGasDebt implements repository.GasBillingRepository
+func (r *GuardedGasBillingRepo) GetGasDebt(ctx context.Context, userAddress string) (*repository.GasDebt, error) {
+	var out *repository.GasDebt
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.GetGasDebt(ctx, userAddress)
+		return err
+	})
+	return out, err
+}
+
+// ListGasDebts implements repository.GasBillingRepository
+func (r *GuardedGasBillingRepo) ListGasDebts(ctx context.Context, page repository.Pagination) ([]*repository.GasDebt, int64, error) {
+	var out []*repository.GasDebt
+	var total int64
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, total, err = r.next.ListGasDebts(ctx, page)
+		return err
+	})
+	return out, total, err
+}
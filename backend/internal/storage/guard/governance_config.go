@@ -0,0 +1,65 @@
+package guard
+
+import (
+	"context"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure GuardedGovernanceConfigRepo implements GovernanceConfigRepository
+var _ repository.GovernanceConfigRepository = (*GuardedGovernanceConfigRepo)(nil)
+
+// GuardedGovernanceConfigRepo wraps a GovernanceConfigRepository with query deadlines and the database breaker
+type GuardedGovernanceConfigRepo struct {
+	next  repository.GovernanceConfigRepository
+	guard *Guard
+}
+
+// NewGuardedGovernanceConfigRepo wraps next with g
+func NewGuardedGovernanceConfigRepo(next repository.GovernanceConfigRepository, g *Guard) *GuardedGovernanceConfigRepo {
+	return &GuardedGovernanceConfigRepo{next: next, guard: g}
+}
+
+// GetConfig implements repository.GovernanceConfigRepository
+func (r *GuardedGovernanceConfigRepo) GetConfig(ctx context.Context, configKey string, chainID int64) (*repository.GovernanceConfig, error) {
+	var out *repository.GovernanceConfig
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.GetConfig(ctx, configKey, chainID)
+		return err
+	})
+	return out, err
+}
+
+// ListConfigs implements repository.GovernanceConfigRepository
+func (r *GuardedGovernanceConfigRepo) ListConfigs(ctx context.Context, chainID int64, activeOnly bool) ([]*repository.GovernanceConfig, error) {
+	var out []*repository.GovernanceConfig
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.ListConfigs(ctx, chainID, activeOnly)
+		return err
+	})
+	return out, err
+}
+
+// UpdateConfig implements repository.GovernanceConfigRepository
+func (r *GuardedGovernanceConfigRepo) UpdateConfig(ctx context.Context, configKey string, chainID int64, update *repository.GovernanceConfigUpdate) error {
+	return r.guard.do(ctx, func(ctx context.Context) error {
+		return r.next.UpdateConfig(ctx, configKey, chainID, update)
+	})
+}
+
+// MarkSynced implements repository.GovernanceConfigRepository
+func (r *GuardedGovernanceConfigRepo) MarkSynced(ctx context.Context, configKey string, chainID int64, txHash string) error {
+	return r.guard.do(ctx, func(ctx context.Context) error {
+		return r.next.MarkSynced(ctx, configKey, chainID, txHash)
+	})
+}
+
+// GetConfigHistory implements repository.GovernanceConfigRepository
+func (r *GuardedGovernanceConfigRepo) GetConfigHistory(ctx context.Context, configKey string, chainID int64, limit int) ([]*repository.GovernanceConfigHistoryEntry, error) {
+	var out []*repository.GovernanceConfigHistoryEntry
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.GetConfigHistory(ctx, configKey, chainID, limit)
+		return err
+	})
+	return out, err
+}
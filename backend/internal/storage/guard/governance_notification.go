@@ -0,0 +1,84 @@
+package guard
+
+import (
+	"context"
+	"time"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure GuardedGovernanceNotificationRepo implements GovernanceNotificationRepository
+var _ repository.GovernanceNotificationRepository = (*GuardedGovernanceNotificationRepo)(nil)
+
+// GuardedGovernanceNotificationRepo wraps a GovernanceNotificationRepository with query deadlines and the database breaker
+type GuardedGovernanceNotificationRepo struct {
+	next  repository.GovernanceNotificationRepository
+	guard *Guard
+}
+
+// NewGuardedGovernanceNotificationRepo wraps next with g
+func NewGuardedGovernanceNotificationRepo(next repository.GovernanceNotificationRepository, g *Guard) *GuardedGovernanceNotificationRepo {
+	return &GuardedGovernanceNotificationRepo{next: next, guard: g}
+}
+
+// UpsertGovernanceSubscription implements repository.GovernanceNotificationRepository
+func (r *GuardedGovernanceNotificationRepo) UpsertGovernanceSubscription(ctx context.Context, s *repository.GovernanceSubscription) error {
+	return r.guard.do(ctx, func(ctx context.Context) error {
+		return r.next.UpsertGovernanceSubscription(ctx, s)
+	})
+}
+
+// GetGovernanceSubscription implements repository.GovernanceNotificationRepository
+func (r *GuardedGovernanceNotificationRepo) GetGovernanceSubscription(ctx context.Context, address string) (*repository.GovernanceSubscription, error) {
+	var out *repository.GovernanceSubscription
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.GetGovernanceSubscription(ctx, address)
+		return err
+	})
+	return out, err
+}
+
+// DeleteGovernanceSubscription implements repository.GovernanceNotificationRepository
+func (r *GuardedGovernanceNotificationRepo) DeleteGovernanceSubscription(ctx context.Context, address string) error {
+	return r.guard.do(ctx, func(ctx context.Context) error {
+		return r.next.DeleteGovernanceSubscription(ctx, address)
+	})
+}
+
+// ListGovernanceSubscriptions implements repository.GovernanceNotificationRepository
+func (r *GuardedGovernanceNotificationRepo) ListGovernanceSubscriptions(ctx context.Context, filter repository.GovernanceSubscriptionFilter, page repository.Pagination) ([]*repository.GovernanceSubscription, int64, error) {
+	var out []*repository.GovernanceSubscription
+	var total int64
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, total, err = r.next.ListGovernanceSubscriptions(ctx, filter, page)
+		return err
+	})
+	return out, total, err
+}
+
+// MarkGovernanceDigestSent implements repository.GovernanceNotificationRepository
+func (r *GuardedGovernanceNotificationRepo) MarkGovernanceDigestSent(ctx context.Context, address string, at time.Time) error {
+	return r.guard.do(ctx, func(ctx context.Context) error {
+		return r.next.MarkGovernanceDigestSent(ctx, address, at)
+	})
+}
+
+// RecordGovernanceEvent implements repository.GovernanceNotificationRepository
+func (r *GuardedGovernanceNotificationRepo) RecordGovernanceEvent(ctx context.Context, e *repository.GovernanceEvent) (bool, error) {
+	var recorded bool
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		recorded, err = r.next.RecordGovernanceEvent(ctx, e)
+		return err
+	})
+	return recorded, err
+}
+
+// ListGovernanceEvents implements repository.GovernanceNotificationRepository
+func (r *GuardedGovernanceNotificationRepo) ListGovernanceEvents(ctx context.Context, since time.Time, limit int) ([]*repository.GovernanceEvent, error) {
+	var out []*repository.GovernanceEvent
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.ListGovernanceEvents(ctx, since, limit)
+		return err
+	})
+	return out, err
+}
@@ -0,0 +1,204 @@
+// Package guard wraps repositories with per-query deadlines and a circuit
+// breaker, so a slow or unreachable database fails requests fast instead of
+// holding them until the server timeout. Calls failing with a retryable
+// error (a serialization failure, a connection that could not be made) are
+// retried a bounded number of times.
+package guard
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"sync/atomic"
+	"time"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/breaker"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/timing"
+)
+
+// Default query deadlines
+const (
+	DefaultQueryTimeout = 5 * time.Second
+
+	// maintenanceTimeout applies to batch inserts and retention deletes run by
+	// background workers
+	maintenanceTimeout = 60 * time.Second
+
+	// DefaultMaxRetries is how often a call failing with a retryable error is
+	// repeated
+	DefaultMaxRetries = 2
+
+	// retryBackoff is the delay before the first retry, doubled for each
+	// further one (with jitter)
+	retryBackoff = 20 * time.Millisecond
+)
+
+// ErrQueryTimeout is wrapped into errors from queries that hit the guard's deadline
+var ErrQueryTimeout = errors.New("query timed out")
+
+// Guard applies a deadline to every repository call and feeds the outcome to
+// a breaker shared by all repositories on the same database
+type Guard struct {
+	timeout     time.Duration
+	breaker     *breaker.Breaker
+	isFailure   func(error) bool
+	maxRetries  int
+	isRetryable func(error) bool
+}
+
+// New creates a guard. isFailure adds backend specific unavailability checks
+// (e.g. PostgreSQL connection error codes) to IsUnavailable; it may be nil.
+func New(timeout time.Duration, b *breaker.Breaker, isFailure func(error) bool) *Guard {
+	if timeout <= 0 {
+		timeout = DefaultQueryTimeout
+	}
+	return &Guard{timeout: timeout, breaker: b, isFailure: isFailure, maxRetries: DefaultMaxRetries}
+}
+
+// SetRetries sets how often a call is retried (0 disables retries) and adds
+// backend specific checks (e.g. PostgreSQL serialization failures) to
+// IsRetryable; isRetryable may be nil
+func (g *Guard) SetRetries(maxRetries int, isRetryable func(error) bool) {
+	if maxRetries < 0 {
+		maxRetries = 0
+	}
+	g.maxRetries = maxRetries
+	g.isRetryable = isRetryable
+}
+
+// Breaker returns the breaker guarding the database
+func (g *Guard) Breaker() *breaker.Breaker {
+	return g.breaker
+}
+
+// do runs a request-path repository call
+func (g *Guard) do(ctx context.Context, fn func(context.Context) error) error {
+	return g.run(ctx, g.timeout, fn)
+}
+
+// doMaintenance runs a background batch call with a longer deadline
+func (g *Guard) doMaintenance(ctx context.Context, fn func(context.Context) error) error {
+	return g.run(ctx, maintenanceTimeout, fn)
+}
+
+// run calls fn, retrying retryable errors while ctx leaves time for it. A
+// call that still fails with a transient error is flagged on ctx (see
+// WithTransientFlag).
+func (g *Guard) run(ctx context.Context, timeout time.Duration, fn func(context.Context) error) error {
+	for attempt := 0; ; attempt++ {
+		err := g.attempt(ctx, timeout, fn)
+		if err == nil || ctx.Err() != nil {
+			return err
+		}
+
+		retryable := g.retryable(err)
+		delay := retryBackoff << attempt
+		delay = delay/2 + rand.N(delay/2+1)
+		if deadline, ok := ctx.Deadline(); !retryable || attempt >= g.maxRetries || ok && time.Until(deadline) < delay {
+			if retryable || g.unavailable(err) || errors.Is(err, breaker.ErrOpen) {
+				flagTransient(ctx)
+			}
+			return err
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+// attempt runs fn once through the breaker with the query deadline,
+// recording its time as timing.DB
+func (g *Guard) attempt(ctx context.Context, timeout time.Duration, fn func(context.Context) error) error {
+	if err := g.breaker.Allow(); err != nil {
+		return err
+	}
+
+	queryCtx, cancel := context.WithTimeout(ctx, timeout)
+	stop := timing.Track(ctx, timing.DB)
+	err := fn(queryCtx)
+	stop()
+	timedOut := queryCtx.Err() != nil && ctx.Err() == nil
+	cancel()
+
+	switch {
+	case ctx.Err() != nil:
+		// The caller gave up; that says nothing about the database
+		g.breaker.Release()
+	case err == nil:
+		g.breaker.Done(false, nil)
+	case timedOut:
+		err = fmt.Errorf("%w after %s: %w", ErrQueryTimeout, timeout, err)
+		g.breaker.Done(true, err)
+	default:
+		g.breaker.Done(g.unavailable(err), err)
+	}
+
+	return err
+}
+
+// unavailable reports whether err means the database could not answer, as
+// opposed to answering with not-found or a constraint violation
+func (g *Guard) unavailable(err error) bool {
+	if IsUnavailable(err) {
+		return true
+	}
+	return g.isFailure != nil && g.isFailure(err)
+}
+
+// retryable reports whether repeating the call that failed with err is safe
+// and may succeed
+func (g *Guard) retryable(err error) bool {
+	if IsRetryable(err) {
+		return true
+	}
+	return g.isRetryable != nil && g.isRetryable(err)
+}
+
+// IsRetryable reports errors common to all database/sql drivers after which
+// the query certainly did not run, so repeating it is safe. A connection
+// lost mid-query is not one of them: a write may have been committed.
+func IsRetryable(err error) bool {
+	return errors.Is(err, driver.ErrBadConn)
+}
+
+// transientFlagKey is the context key of the flag set by WithTransientFlag
+type transientFlagKey struct{}
+
+// WithTransientFlag returns a context whose guarded calls set the returned
+// flag when they fail with a transient error: a retryable error that
+// persisted through the retries, a database that could not answer or an
+// open breaker. The request may succeed if the client repeats it.
+func WithTransientFlag(ctx context.Context) (context.Context, *atomic.Bool) {
+	flag := &atomic.Bool{}
+	return context.WithValue(ctx, transientFlagKey{}, flag), flag
+}
+
+// flagTransient sets ctx's transient flag, if it has one
+func flagTransient(ctx context.Context) {
+	if flag, ok := ctx.Value(transientFlagKey{}).(*atomic.Bool); ok {
+		flag.Store(true)
+	}
+}
+
+// IsUnavailable reports connection level errors common to all database/sql drivers
+func IsUnavailable(err error) bool {
+	if errors.Is(err, driver.ErrBadConn) ||
+		errors.Is(err, sql.ErrConnDone) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
@@ -0,0 +1,163 @@
+package guard_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/breaker"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/storage/guard"
+)
+
+// stubPricingRepo answers GetPricing with a configurable behaviour
+type stubPricingRepo struct {
+	repository.PricingRepository
+	getPricing func(ctx context.Context) (*repository.Pricing, error)
+	calls      int
+}
+
+func (s *stubPricingRepo) GetPricing(ctx context.Context, serviceCode string) (*repository.Pricing, error) {
+	s.calls++
+	return s.getPricing(ctx)
+}
+
+// hang blocks until the query context is done, like a stuck query
+func hang(ctx context.Context) (*repository.Pricing, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestGuard_QueryDeadline(t *testing.T) {
+	stub := &stubPricingRepo{getPricing: hang}
+	repo := guard.NewGuardedPricingRepo(stub, guard.New(20*time.Millisecond, breaker.New("database", 5, time.Minute), nil))
+
+	start := time.Now()
+	_, err := repo.GetPricing(context.Background(), "kyc_verification")
+	assert.ErrorIs(t, err, guard.ErrQueryTimeout)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestGuard_BreakerOpensOnTimeouts(t *testing.T) {
+	b := breaker.New("database", 2, time.Minute)
+	stub := &stubPricingRepo{getPricing: hang}
+	repo := guard.NewGuardedPricingRepo(stub, guard.New(10*time.Millisecond, b, nil))
+
+	for i := 0; i < 2; i++ {
+		_, err := repo.GetPricing(context.Background(), "kyc_verification")
+		require.ErrorIs(t, err, guard.ErrQueryTimeout)
+	}
+	assert.Equal(t, breaker.StateOpen, b.State())
+
+	_, err := repo.GetPricing(context.Background(), "kyc_verification")
+	assert.ErrorIs(t, err, breaker.ErrOpen)
+	assert.Equal(t, 2, stub.calls, "open breaker must not reach the database")
+}
+
+func TestGuard_DomainErrorsDoNotTrip(t *testing.T) {
+	b := breaker.New("database", 1, time.Minute)
+	stub := &stubPricingRepo{getPricing: func(context.Context) (*repository.Pricing, error) {
+		return nil, repository.ErrPricingNotFound
+	}}
+	repo := guard.NewGuardedPricingRepo(stub, guard.New(time.Second, b, nil))
+
+	_, err := repo.GetPricing(context.Background(), "missing")
+	assert.ErrorIs(t, err, repository.ErrPricingNotFound)
+	assert.Equal(t, breaker.StateClosed, b.State())
+}
+
+func TestGuard_BackendClassifier(t *testing.T) {
+	errTooManyConns := errors.New("pq: sorry, too many clients already")
+	b := breaker.New("database", 1, time.Minute)
+	stub := &stubPricingRepo{getPricing: func(context.Context) (*repository.Pricing, error) {
+		return nil, errTooManyConns
+	}}
+	repo := guard.NewGuardedPricingRepo(stub, guard.New(time.Second, b, func(err error) bool {
+		return errors.Is(err, errTooManyConns)
+	}))
+
+	_, err := repo.GetPricing(context.Background(), "kyc_verification")
+	assert.ErrorIs(t, err, errTooManyConns)
+	assert.Equal(t, breaker.StateOpen, b.State())
+}
+
+func TestGuard_CallerCancellationDoesNotTrip(t *testing.T) {
+	b := breaker.New("database", 1, time.Minute)
+	stub := &stubPricingRepo{getPricing: hang}
+	repo := guard.NewGuardedPricingRepo(stub, guard.New(time.Minute, b, nil))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := repo.GetPricing(ctx, "kyc_verification")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.NotErrorIs(t, err, guard.ErrQueryTimeout)
+	assert.Equal(t, breaker.StateClosed, b.State())
+}
+
+func TestGuard_RetriesRetryableErrors(t *testing.T) {
+	errSerialization := errors.New("pq: could not serialize access")
+	isRetryable := func(err error) bool { return errors.Is(err, errSerialization) }
+
+	// Succeeds on the second attempt
+	stub := &stubPricingRepo{}
+	stub.getPricing = func(context.Context) (*repository.Pricing, error) {
+		if stub.calls < 2 {
+			return nil, errSerialization
+		}
+		return &repository.Pricing{ServiceCode: "kyc_verification"}, nil
+	}
+	g := guard.New(time.Second, breaker.New("database", 5, time.Minute), nil)
+	g.SetRetries(2, isRetryable)
+	ctx, transient := guard.WithTransientFlag(context.Background())
+
+	pricing, err := guard.NewGuardedPricingRepo(stub, g).GetPricing(ctx, "kyc_verification")
+	require.NoError(t, err)
+	assert.Equal(t, "kyc_verification", pricing.ServiceCode)
+	assert.Equal(t, 2, stub.calls)
+	assert.False(t, transient.Load())
+
+	// Gives up after the retries and flags the request
+	stub = &stubPricingRepo{getPricing: func(context.Context) (*repository.Pricing, error) {
+		return nil, errSerialization
+	}}
+	_, err = guard.NewGuardedPricingRepo(stub, g).GetPricing(ctx, "kyc_verification")
+	assert.ErrorIs(t, err, errSerialization)
+	assert.Equal(t, 3, stub.calls)
+	assert.True(t, transient.Load())
+
+	// Permanent errors are neither retried nor flagged
+	stub = &stubPricingRepo{getPricing: func(context.Context) (*repository.Pricing, error) {
+		return nil, repository.ErrPricingNotFound
+	}}
+	ctx, transient = guard.WithTransientFlag(context.Background())
+	_, err = guard.NewGuardedPricingRepo(stub, g).GetPricing(ctx, "missing")
+	assert.ErrorIs(t, err, repository.ErrPricingNotFound)
+	assert.Equal(t, 1, stub.calls)
+	assert.False(t, transient.Load())
+
+	// Disabled
+	stub = &stubPricingRepo{getPricing: func(context.Context) (*repository.Pricing, error) {
+		return nil, errSerialization
+	}}
+	g.SetRetries(0, isRetryable)
+	_, err = guard.NewGuardedPricingRepo(stub, g).GetPricing(context.Background(), "kyc_verification")
+	assert.ErrorIs(t, err, errSerialization)
+	assert.Equal(t, 1, stub.calls)
+}
+
+func TestGuard_UnavailableIsTransient(t *testing.T) {
+	stub := &stubPricingRepo{getPricing: hang}
+	g := guard.New(10*time.Millisecond, breaker.New("database", 5, time.Minute), nil)
+	ctx, transient := guard.WithTransientFlag(context.Background())
+
+	_, err := guard.NewGuardedPricingRepo(stub, g).GetPricing(ctx, "kyc_verification")
+	assert.ErrorIs(t, err, guard.ErrQueryTimeout)
+	assert.Equal(t, 1, stub.calls, "a timed out query may have run")
+	assert.True(t, transient.Load())
+}
@@ -0,0 +1,32 @@
+package guard
+
+import (
+	"context"
+	"time"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure GuardedKPIRepo implements KPIRepository
+var _ repository.KPIRepository = (*GuardedKPIRepo)(nil)
+
+// GuardedKPIRepo wraps a KPIRepository with query deadlines and the database breaker
+type GuardedKPIRepo struct {
+	next  repository.KPIRepository
+	guard *Guard
+}
+
+// NewGuardedKPIRepo wraps next with g
+func NewGuardedKPIRepo(next repository.KPIRepository, g *Guard) *GuardedKPIRepo {
+	return &GuardedKPIRepo{next: next, guard: g}
+}
+
+// GetBusinessKPIs implements repository.KPIRepository
+func (r *GuardedKPIRepo) GetBusinessKPIs(ctx context.Context, since time.Time) (*repository.BusinessKPIs, error) {
+	var out *repository.BusinessKPIs
+	err := r.guard.doMaintenance(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.GetBusinessKPIs(ctx, since)
+		return err
+	})
+	return out, err
+}
@@ -0,0 +1,48 @@
+package guard
+
+import (
+	"context"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure GuardedKYCAuditRepo implements KYCAuditRepository
+var _ repository.KYCAuditRepository = (*GuardedKYCAuditRepo)(nil)
+
+// GuardedKYCAuditRepo wraps a KYCAuditRepository with query deadlines and the database breaker
+type GuardedKYCAuditRepo struct {
+	next  repository.KYCAuditRepository
+	guard *Guard
+}
+
+// NewGuardedKYCAuditRepo wraps next with g
+func NewGuardedKYCAuditRepo(next repository.KYCAuditRepository, g *Guard) *GuardedKYCAuditRepo {
+	return &GuardedKYCAuditRepo{next: next, guard: g}
+}
+
+// AppendAuditEntry implements repository.KYCAuditRepository
+func (r *GuardedKYCAuditRepo) AppendAuditEntry(ctx context.Context, e *repository.KYCAuditEntry) error {
+	return r.guard.do(ctx, func(ctx context.Context) error {
+		return r.next.AppendAuditEntry(ctx, e)
+	})
+}
+
+// ListAuditEntries implements repository.KYCAuditRepository
+func (r *GuardedKYCAuditRepo) ListAuditEntries(ctx context.Context, filter repository.KYCAuditFilter, limit int) ([]*repository.KYCAuditEntry, error) {
+	var out []*repository.KYCAuditEntry
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.ListAuditEntries(ctx, filter, limit)
+		return err
+	})
+	return out, err
+}
+
+// CountAuditEntries implements repository.KYCAuditRepository
+func (r *GuardedKYCAuditRepo) CountAuditEntries(ctx context.Context, filter repository.KYCAuditFilter) (int64, error) {
+	var out int64
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.CountAuditEntries(ctx, filter)
+		return err
+	})
+	return out, err
+}
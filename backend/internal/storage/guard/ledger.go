@@ -0,0 +1,62 @@
+package guard
+
+import (
+	"context"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure GuardedLedgerRepo implements LedgerRepository
+var _ repository.LedgerRepository = (*GuardedLedgerRepo)(nil)
+
+// GuardedLedgerRepo wraps a LedgerRepository with query deadlines and the database breaker
+type GuardedLedgerRepo struct {
+	next  repository.LedgerRepository
+	guard *Guard
+}
+
+// NewGuardedLedgerRepo wraps next with g
+func NewGuardedLedgerRepo(next repository.LedgerRepository, g *Guard) *GuardedLedgerRepo {
+	return &GuardedLedgerRepo{next: next, guard: g}
+}
+
+// PostLedgerTransaction implements repository.LedgerRepository
+func (r *GuardedLedgerRepo) PostLedgerTransaction(ctx context.Context, tx *repository.LedgerTransaction) (bool, error) {
+	var out bool
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.PostLedgerTransaction(ctx, tx)
+		return err
+	})
+	return out, err
+}
+
+// GetLedgerTransaction implements repository.LedgerRepository
+func (r *GuardedLedgerRepo) GetLedgerTransaction(ctx context.Context, id string) (*repository.LedgerTransaction, error) {
+	var out *repository.LedgerTransaction
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.GetLedgerTransaction(ctx, id)
+		return err
+	})
+	return out, err
+}
+
+// ListLedgerEntries implements repository.LedgerRepository
+func (r *GuardedLedgerRepo) ListLedgerEntries(ctx context.Context, filter repository.LedgerEntryFilter, page repository.Pagination) ([]*repository.LedgerEntry, int64, error) {
+	var out []*repository.LedgerEntry
+	var total int64
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, total, err = r.next.ListLedgerEntries(ctx, filter, page)
+		return err
+	})
+	return out, total, err
+}
+
+// GetLedgerBalances implements repository.LedgerRepository
+func (r *GuardedLedgerRepo) GetLedgerBalances(ctx context.Context, filter repository.LedgerBalanceFilter) ([]*repository.LedgerBalance, error) {
+	var out []*repository.LedgerBalance
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.GetLedgerBalances(ctx, filter)
+		return err
+	})
+	return out, err
+}
@@ -0,0 +1,117 @@
+package guard
+
+import (
+	"context"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure GuardedPartnerRepo implements PartnerRepository
+var _ repository.PartnerRepository = (*GuardedPartnerRepo)(nil)
+
+// GuardedPartnerRepo wraps a PartnerRepository with query deadlines and the database breaker
+type GuardedPartnerRepo struct {
+	next  repository.PartnerRepository
+	guard *Guard
+}
+
+// NewGuardedPartnerRepo wraps next with g
+func NewGuardedPartnerRepo(next repository.PartnerRepository, g *Guard) *GuardedPartnerRepo {
+	return &GuardedPartnerRepo{next: next, guard: g}
+}
+
+// CreatePartner implements repository.PartnerRepository
+func (r *GuardedPartnerRepo) CreatePartner(ctx context.Context, p *repository.Partner) error {
+	return r.guard.do(ctx, func(ctx context.Context) error {
+		return r.next.CreatePartner(ctx, p)
+	})
+}
+
+// GetPartner implements repository.PartnerRepository
+func (r *GuardedPartnerRepo) GetPartner(ctx context.Context, id string) (*repository.Partner, error) {
+	var out *repository.Partner
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.GetPartner(ctx, id)
+		return err
+	})
+	return out, err
+}
+
+// GetPartnerByCode implements repository.PartnerRepository
+func (r *GuardedPartnerRepo) GetPartnerByCode(ctx context.Context, referralCode string) (*repository.Partner, error) {
+	var out *repository.Partner
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.GetPartnerByCode(ctx, referralCode)
+		return err
+	})
+	return out, err
+}
+
+// GetPartnerByKeyHash implements repository.PartnerRepository
+func (r *GuardedPartnerRepo) GetPartnerByKeyHash(ctx context.Context, keyHash string) (*repository.Partner, error) {
+	var out *repository.Partner
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.GetPartnerByKeyHash(ctx, keyHash)
+		return err
+	})
+	return out, err
+}
+
+// ListPartners implements repository.PartnerRepository
+func (r *GuardedPartnerRepo) ListPartners(ctx context.Context, page repository.Pagination) ([]*repository.Partner, int64, error) {
+	var out []*repository.Partner
+	var total int64
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, total, err = r.next.ListPartners(ctx, page)
+		return err
+	})
+	return out, total, err
+}
+
+// UpdatePartner implements repository.PartnerRepository
+func (r *GuardedPartnerRepo) UpdatePartner(ctx context.Context, id string, update *repository.PartnerUpdate) error {
+	return r.guard.do(ctx, func(ctx context.Context) error {
+		return r.next.UpdatePartner(ctx, id, update)
+	})
+}
+
+// RecordCommission implements repository.PartnerRepository
+func (r *GuardedPartnerRepo) RecordCommission(ctx context.Context, c *repository.PartnerCommission) (bool, error) {
+	var out bool
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.RecordCommission(ctx, c)
+		return err
+	})
+	return out, err
+}
+
+// ReverseCommission implements repository.PartnerRepository
+func (r *GuardedPartnerRepo) ReverseCommission(ctx context.Context, paymentID string) (bool, error) {
+	var out bool
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.ReverseCommission(ctx, paymentID)
+		return err
+	})
+	return out, err
+}
+
+// ListCommissions implements repository.PartnerRepository
+func (r *GuardedPartnerRepo) ListCommissions(ctx context.Context, filter repository.PartnerCommissionFilter, page repository.Pagination) ([]*repository.PartnerCommission, int64, error) {
+	var out []*repository.PartnerCommission
+	var total int64
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, total, err = r.next.ListCommissions(ctx, filter, page)
+		return err
+	})
+	return out, total, err
+}
+
+// GetCommissionTotals implements repository.PartnerRepository
+func (r *GuardedPartnerRepo) GetCommissionTotals(ctx context.Context, filter repository.PartnerCommissionFilter) (*repository.PartnerCommissionTotals, error) {
+	var out *repository.PartnerCommissionTotals
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.GetCommissionTotals(ctx, filter)
+		return err
+	})
+	return out, err
+}
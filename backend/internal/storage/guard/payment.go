@@ -0,0 +1,173 @@
+package guard
+
+import (
+	"context"
+	"time"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure GuardedPaymentRepo implements PaymentRepository
+var _ repository.PaymentRepository = (*GuardedPaymentRepo)(nil)
+
+// GuardedPaymentRepo wraps a PaymentRepository with query deadlines and the database breaker
+type GuardedPaymentRepo struct {
+	next  repository.PaymentRepository
+	guard *Guard
+}
+
+// NewGuardedPaymentRepo wraps next with g
+func NewGuardedPaymentRepo(next repository.PaymentRepository, g *Guard) *GuardedPaymentRepo {
+	return &GuardedPaymentRepo{next: next, guard: g}
+}
+
+// CreatePayment implements repository.PaymentRepository
+func (r *GuardedPaymentRepo) CreatePayment(ctx context.Context, payment *repository.Payment) error {
+	return r.guard.do(ctx, func(ctx context.Context) error {
+		return r.next.CreatePayment(ctx, payment)
+	})
+}
+
+// GetPayment implements repository.PaymentRepository
+func (r *GuardedPaymentRepo) GetPayment(ctx context.Context, id string) (*repository.Payment, error) {
+	var out *repository.Payment
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.GetPayment(ctx, id)
+		return err
+	})
+	return out, err
+}
+
+// GetPaymentByStripeSession implements repository.PaymentRepository
+func (r *GuardedPaymentRepo) GetPaymentByStripeSession(ctx context.Context, sessionID string) (*repository.Payment, error) {
+	var out *repository.Payment
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.GetPaymentByStripeSession(ctx, sessionID)
+		return err
+	})
+	return out, err
+}
+
+// GetPaymentByStripePaymentID implements repository.PaymentRepository
+func (r *GuardedPaymentRepo) GetPaymentByStripePaymentID(ctx context.Context, paymentIntentID string) (*repository.Payment, error) {
+	var out *repository.Payment
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.GetPaymentByStripePaymentID(ctx, paymentIntentID)
+		return err
+	})
+	return out, err
+}
+
+// UpdatePaymentStatus implements repository.PaymentRepository
+func (r *GuardedPaymentRepo) UpdatePaymentStatus(ctx context.Context, id string, status repository.PaymentStatus, details *repository.PaymentStatusUpdate) error {
+	return r.guard.do(ctx, func(ctx context.Context) error {
+		return r.next.UpdatePaymentStatus(ctx, id, status, details)
+	})
+}
+
+// ListPayments implements repository.PaymentRepository
+func (r *GuardedPaymentRepo) ListPayments(ctx context.Context, filter repository.PaymentFilter, page repository.Pagination) ([]*repository.Payment, int64, error) {
+	var out []*repository.Payment
+	var total int64
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, total, err = r.next.ListPayments(ctx, filter, page)
+		return err
+	})
+	return out, total, err
+}
+
+// ArchivePayments implements repository.PaymentRepository
+func (r *GuardedPaymentRepo) ArchivePayments(ctx context.Context, before time.Time, limit int) (int64, error) {
+	var out int64
+	err := r.guard.doMaintenance(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.ArchivePayments(ctx, before, limit)
+		return err
+	})
+	return out, err
+}
+
+// GetArchivedPayment implements repository.PaymentRepository
+func (r *GuardedPaymentRepo) GetArchivedPayment(ctx context.Context, id string) (*repository.Payment, error) {
+	var out *repository.Payment
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.GetArchivedPayment(ctx, id)
+		return err
+	})
+	return out, err
+}
+
+// ListArchivedPayments implements repository.PaymentRepository
+func (r *GuardedPaymentRepo) ListArchivedPayments(ctx context.Context, filter repository.PaymentFilter, page repository.Pagination) ([]*repository.Payment, int64, error) {
+	var out []*repository.Payment
+	var total int64
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, total, err = r.next.ListArchivedPayments(ctx, filter, page)
+		return err
+	})
+	return out, total, err
+}
+
+// ExpirePayments implements repository.PaymentRepository
+func (r *GuardedPaymentRepo) ExpirePayments(ctx context.Context, method string, before time.Time, limit int) ([]string, error) {
+	var out []string
+	err := r.guard.doMaintenance(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.ExpirePayments(ctx, method, before, limit)
+		return err
+	})
+	return out, err
+}
+
+// CreateKYCVerification implements repository.PaymentRepository
+func (r *GuardedPaymentRepo) CreateKYCVerification(ctx context.Context, verification *repository.KYCVerification) error {
+	return r.guard.do(ctx, func(ctx context.Context) error {
+		return r.next.CreateKYCVerification(ctx, verification)
+	})
+}
+
+// GetKYCVerification implements repository.PaymentRepository
+func (r *GuardedPaymentRepo) GetKYCVerification(ctx context.Context, id string) (*repository.KYCVerification, error) {
+	var out *repository.KYCVerification
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.GetKYCVerification(ctx, id)
+		return err
+	})
+	return out, err
+}
+
+// GetKYCVerificationByAddress implements repository.PaymentRepository
+func (r *GuardedPaymentRepo) GetKYCVerificationByAddress(ctx context.Context, address string) (*repository.KYCVerification, error) {
+	var out *repository.KYCVerification
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.GetKYCVerificationByAddress(ctx, address)
+		return err
+	})
+	return out, err
+}
+
+// GetKYCVerificationByApplicant implements repository.PaymentRepository
+func (r *GuardedPaymentRepo) GetKYCVerificationByApplicant(ctx context.Context, applicantID string) (*repository.KYCVerification, error) {
+	var out *repository.KYCVerification
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.GetKYCVerificationByApplicant(ctx, applicantID)
+		return err
+	})
+	return out, err
+}
+
+// UpdateKYCVerification implements repository.PaymentRepository
+func (r *GuardedPaymentRepo) UpdateKYCVerification(ctx context.Context, id string, update *repository.KYCVerificationUpdate) error {
+	return r.guard.do(ctx, func(ctx context.Context) error {
+		return r.next.UpdateKYCVerification(ctx, id, update)
+	})
+}
+
+// ListKYCVerifications implements repository.PaymentRepository
+func (r *GuardedPaymentRepo) ListKYCVerifications(ctx context.Context, filter repository.KYCVerificationFilter, page repository.Pagination) ([]*repository.KYCVerification, int64, error) {
+	var out []*repository.KYCVerification
+	var total int64
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, total, err = r.next.ListKYCVerifications(ctx, filter, page)
+		return err
+	})
+	return out, total, err
+}
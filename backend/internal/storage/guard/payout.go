@@ -0,0 +1,67 @@
+package guard
+
+import (
+	"context"
+	"time"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure GuardedPayoutRepo implements PayoutRepository
+var _ repository.PayoutRepository = (*GuardedPayoutRepo)(nil)
+
+// GuardedPayoutRepo wraps a PayoutRepository with query deadlines and the database breaker
+type GuardedPayoutRepo struct {
+	next  repository.PayoutRepository
+	guard *Guard
+}
+
+// NewGuardedPayoutRepo wraps next with g
+func NewGuardedPayoutRepo(next repository.PayoutRepository, g *Guard) *GuardedPayoutRepo {
+	return &GuardedPayoutRepo{next: next, guard: g}
+}
+
+// CreatePayout implements repository.PayoutRepository
+func (r *GuardedPayoutRepo) CreatePayout(ctx context.Context, p *repository.Payout) error {
+	return r.guard.do(ctx, func(ctx context.Context) error {
+		return r.next.CreatePayout(ctx, p)
+	})
+}
+
+// GetPayout implements repository.PayoutRepository
+func (r *GuardedPayoutRepo) GetPayout(ctx context.Context, id string) (*repository.Payout, error) {
+	var out *repository.Payout
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.GetPayout(ctx, id)
+		return err
+	})
+	return out, err
+}
+
+// ListPayouts implements repository.PayoutRepository
+func (r *GuardedPayoutRepo) ListPayouts(ctx context.Context, filter repository.PayoutFilter, page repository.Pagination) ([]*repository.Payout, int64, error) {
+	var out []*repository.Payout
+	var total int64
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, total, err = r.next.ListPayouts(ctx, filter, page)
+		return err
+	})
+	return out, total, err
+}
+
+// ListDuePayouts implements repository.PayoutRepository
+func (r *GuardedPayoutRepo) ListDuePayouts(ctx context.Context, now time.Time, limit int) ([]*repository.Payout, error) {
+	var out []*repository.Payout
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.ListDuePayouts(ctx, now, limit)
+		return err
+	})
+	return out, err
+}
+
+// UpdatePayoutStatus implements repository.PayoutRepository
+func (r *GuardedPayoutRepo) UpdatePayoutStatus(ctx context.Context, id string, update *repository.PayoutUpdate) error {
+	return r.guard.do(ctx, func(ctx context.Context) error {
+		return r.next.UpdatePayoutStatus(ctx, id, update)
+	})
+}
@@ -0,0 +1,85 @@
+package guard
+
+import (
+	"context"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure GuardedPricingRepo implements PricingRepository
+var _ repository.PricingRepository = (*GuardedPricingRepo)(nil)
+
+// GuardedPricingRepo wraps a PricingRepository with query deadlines and the database breaker
+type GuardedPricingRepo struct {
+	next  repository.PricingRepository
+	guard *Guard
+}
+
+// NewGuardedPricingRepo wraps next with g
+func NewGuardedPricingRepo(next repository.PricingRepository, g *Guard) *GuardedPricingRepo {
+	return &GuardedPricingRepo{next: next, guard: g}
+}
+
+// GetPricing implements repository.PricingRepository
+func (r *GuardedPricingRepo) GetPricing(ctx context.Context, serviceCode string) (*repository.Pricing, error) {
+	var out *repository.Pricing
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.GetPricing(ctx, serviceCode)
+		return err
+	})
+	return out, err
+}
+
+// ListPricing implements repository.PricingRepository
+func (r *GuardedPricingRepo) ListPricing(ctx context.Context, activeOnly bool) ([]*repository.Pricing, error) {
+	var out []*repository.Pricing
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.ListPricing(ctx, activeOnly)
+		return err
+	})
+	return out, err
+}
+
+// UpdatePricing implements repository.PricingRepository
+func (r *GuardedPricingRepo) UpdatePricing(ctx context.Context, serviceCode string, update *repository.PricingUpdate) error {
+	return r.guard.do(ctx, func(ctx context.Context) error {
+		return r.next.UpdatePricing(ctx, serviceCode, update)
+	})
+}
+
+// GetPaymentMethod implements repository.PricingRepository
+func (r *GuardedPricingRepo) GetPaymentMethod(ctx context.Context, methodCode string) (*repository.PaymentMethod, error) {
+	var out *repository.PaymentMethod
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.GetPaymentMethod(ctx, methodCode)
+		return err
+	})
+	return out, err
+}
+
+// ListPaymentMethods implements repository.PricingRepository
+func (r *GuardedPricingRepo) ListPaymentMethods(ctx context.Context, activeOnly bool) ([]*repository.PaymentMethod, error) {
+	var out []*repository.PaymentMethod
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.ListPaymentMethods(ctx, activeOnly)
+		return err
+	})
+	return out, err
+}
+
+// UpdatePaymentMethod implements repository.PricingRepository
+func (r *GuardedPricingRepo) UpdatePaymentMethod(ctx context.Context, methodCode string, update *repository.PaymentMethodUpdate) error {
+	return r.guard.do(ctx, func(ctx context.Context) error {
+		return r.next.UpdatePaymentMethod(ctx, methodCode, update)
+	})
+}
+
+// GetPricingHistory implements repository.PricingRepository
+func (r *GuardedPricingRepo) GetPricingHistory(ctx context.Context, serviceCode string, limit int) ([]*repository.PricingHistoryEntry, error) {
+	var out []*repository.PricingHistoryEntry
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.GetPricingHistory(ctx, serviceCode, limit)
+		return err
+	})
+	return out, err
+}
@@ -0,0 +1,55 @@
+package guard
+
+import (
+	"context"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure GuardedProfileRepo implements ProfileRepository
+var _ repository.ProfileRepository = (*GuardedProfileRepo)(nil)
+
+// GuardedProfileRepo wraps a ProfileRepository with query deadlines and the database breaker
+type GuardedProfileRepo struct {
+	next  repository.ProfileRepository
+	guard *Guard
+}
+
+// NewGuardedProfileRepo wraps next with g
+func NewGuardedProfileRepo(next repository.ProfileRepository, g *Guard) *GuardedProfileRepo {
+	return &GuardedProfileRepo{next: next, guard: g}
+}
+
+// UpsertProfile implements repository.ProfileRepository
+func (r *GuardedProfileRepo) UpsertProfile(ctx context.Context, p *repository.Profile) error {
+	return r.guard.do(ctx, func(ctx context.Context) error {
+		return r.next.UpsertProfile(ctx, p)
+	})
+}
+
+// GetProfile implements repository.ProfileRepository
+func (r *GuardedProfileRepo) GetProfile(ctx context.Context, address string) (*repository.Profile, error) {
+	var out *repository.Profile
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.GetProfile(ctx, address)
+		return err
+	})
+	return out, err
+}
+
+// DeleteProfile implements repository.ProfileRepository
+func (r *GuardedProfileRepo) DeleteProfile(ctx context.Context, address string) error {
+	return r.guard.do(ctx, func(ctx context.Context) error {
+		return r.next.DeleteProfile(ctx, address)
+	})
+}
+
+// GetProfiles implements repository.ProfileRepository
+func (r *GuardedProfileRepo) GetProfiles(ctx context.Context, addresses []string) (map[string]*repository.Profile, error) {
+	var out map[string]*repository.Profile
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.GetProfiles(ctx, addresses)
+		return err
+	})
+	return out, err
+}
@@ -0,0 +1,145 @@
+package guard
+
+import (
+	"context"
+	"time"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure GuardedRelayerRepo implements RelayerRepository
+var _ repository.RelayerRepository = (*GuardedRelayerRepo)(nil)
+
+// GuardedRelayerRepo wraps a RelayerRepository with query deadlines and the database breaker
+type GuardedRelayerRepo struct {
+	next  repository.RelayerRepository
+	guard *Guard
+}
+
+// NewGuardedRelayerRepo wraps next with g
+func NewGuardedRelayerRepo(next repository.RelayerRepository, g *Guard) *GuardedRelayerRepo {
+	return &GuardedRelayerRepo{next: next, guard: g}
+}
+
+// CreateMetaTx implements repository.RelayerRepository
+func (r *GuardedRelayerRepo) CreateMetaTx(ctx context.Context, tx *repository.MetaTransaction) error {
+	return r.guard.do(ctx, func(ctx context.Context) error {
+		return r.next.CreateMetaTx(ctx, tx)
+	})
+}
+
+// CreateMetaTxBatch implements repository.RelayerRepository
+func (r *GuardedRelayerRepo) CreateMetaTxBatch(ctx context.Context, txs []*repository.MetaTransaction) error {
+	return r.guard.do(ctx, func(ctx context.Context) error {
+		return r.next.CreateMetaTxBatch(ctx, txs)
+	})
+}
+
+// GetMetaTx implements repository.RelayerRepository
+func (r *GuardedRelayerRepo) GetMetaTx(ctx context.Context, id string) (*repository.MetaTransaction, error) {
+	var out *repository.MetaTransaction
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.GetMetaTx(ctx, id)
+		return err
+	})
+	return out, err
+}
+
+// GetMetaTxByHash implements repository.RelayerRepository
+func (r *GuardedRelayerRepo) GetMetaTxByHash(ctx context.Context, txHash string) (*repository.MetaTransaction, error) {
+	var out *repository.MetaTransaction
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.GetMetaTxByHash(ctx, txHash)
+		return err
+	})
+	return out, err
+}
+
+// UpdateMetaTxStatus implements repository.RelayerRepository
+func (r *GuardedRelayerRepo) UpdateMetaTxStatus(ctx context.Context, id string, update *repository.MetaTxStatusUpdate) error {
+	return r.guard.do(ctx, func(ctx context.Context) error {
+		return r.next.UpdateMetaTxStatus(ctx, id, update)
+	})
+}
+
+// ListMetaTx implements repository.RelayerRepository
+func (r *GuardedRelayerRepo) ListMetaTx(ctx context.Context, filter repository.MetaTxFilter, page repository.Pagination) ([]*repository.MetaTransaction, int64, error) {
+	var out []*repository.MetaTransaction
+	var total int64
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, total, err = r.next.ListMetaTx(ctx, filter, page)
+		return err
+	})
+	return out, total, err
+}
+
+// GetNextNonce implements repository.RelayerRepository
+func (r *GuardedRelayerRepo) GetNextNonce(ctx context.Context, fromAddress string) (uint64, error) {
+	var out uint64
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.GetNextNonce(ctx, fromAddress)
+		return err
+	})
+	return out, err
+}
+
+// GetPendingMetaTxs implements repository.RelayerRepository
+func (r *GuardedRelayerRepo) GetPendingMetaTxs(ctx context.Context, limit int) ([]*repository.MetaTransaction, error) {
+	var out []*repository.MetaTransaction
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.GetPendingMetaTxs(ctx, limit)
+		return err
+	})
+	return out, err
+}
+
+// GetExpiredMetaTxs implements repository.RelayerRepository
+func (r *GuardedRelayerRepo) GetExpiredMetaTxs(ctx context.Context, limit int) ([]*repository.MetaTransaction, error) {
+	var out []*repository.MetaTransaction
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.GetExpiredMetaTxs(ctx, limit)
+		return err
+	})
+	return out, err
+}
+
+// ArchiveMetaTxs implements repository.RelayerRepository
+func (r *GuardedRelayerRepo) ArchiveMetaTxs(ctx context.Context, before time.Time, limit int) (int64, error) {
+	var out int64
+	err := r.guard.doMaintenance(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.ArchiveMetaTxs(ctx, before, limit)
+		return err
+	})
+	return out, err
+}
+
+// GetArchivedMetaTx implements repository.RelayerRepository
+func (r *GuardedRelayerRepo) GetArchivedMetaTx(ctx context.Context, id string) (*repository.MetaTransaction, error) {
+	var out *repository.MetaTransaction
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.GetArchivedMetaTx(ctx, id)
+		return err
+	})
+	return out, err
+}
+
+// ListArchivedMetaTx implements repository.RelayerRepository
+func (r *GuardedRelayerRepo) ListArchivedMetaTx(ctx context.Context, filter repository.MetaTxFilter, page repository.Pagination) ([]*repository.MetaTransaction, int64, error) {
+	var out []*repository.MetaTransaction
+	var total int64
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, total, err = r.next.ListArchivedMetaTx(ctx, filter, page)
+		return err
+	})
+	return out, total, err
+}
+
+// GetRelaySpend implements repository.RelayerRepository
+func (r *GuardedRelayerRepo) GetRelaySpend(ctx context.Context, filter repository.RelaySpendFilter) ([]*repository.RelaySpend, error) {
+	var out []*repository.RelaySpend
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.GetRelaySpend(ctx, filter)
+		return err
+	})
+	return out, err
+}
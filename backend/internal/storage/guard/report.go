@@ -0,0 +1,112 @@
+package guard
+
+import (
+	"context"
+	"time"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure GuardedReportRepo implements ReportRepository
+var _ repository.ReportRepository = (*GuardedReportRepo)(nil)
+
+// GuardedReportRepo wraps a ReportRepository with query deadlines and the database breaker
+type GuardedReportRepo struct {
+	next  repository.ReportRepository
+	guard *Guard
+}
+
+// NewGuardedReportRepo wraps next with g
+func NewGuardedReportRepo(next repository.ReportRepository, g *Guard) *GuardedReportRepo {
+	return &GuardedReportRepo{next: next, guard: g}
+}
+
+// CreateReportSchedule implements repository.ReportRepository
+func (r *GuardedReportRepo) CreateReportSchedule(ctx context.Context, s *repository.ReportSchedule) error {
+	return r.guard.do(ctx, func(ctx context.Context) error {
+		return r.next.CreateReportSchedule(ctx, s)
+	})
+}
+
+// GetReportSchedule implements repository.ReportRepository
+func (r *GuardedReportRepo) GetReportSchedule(ctx context.Context, id string) (*repository.ReportSchedule, error) {
+	var out *repository.ReportSchedule
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.GetReportSchedule(ctx, id)
+		return err
+	})
+	return out, err
+}
+
+// UpdateReportSchedule implements repository.ReportRepository
+func (r *GuardedReportRepo) UpdateReportSchedule(ctx context.Context, s *repository.ReportSchedule) error {
+	return r.guard.do(ctx, func(ctx context.Context) error {
+		return r.next.UpdateReportSchedule(ctx, s)
+	})
+}
+
+// DeleteReportSchedule implements repository.ReportRepository
+func (r *GuardedReportRepo) DeleteReportSchedule(ctx context.Context, id string) error {
+	return r.guard.do(ctx, func(ctx context.Context) error {
+		return r.next.DeleteReportSchedule(ctx, id)
+	})
+}
+
+// ListReportSchedules implements repository.ReportRepository
+func (r *GuardedReportRepo) ListReportSchedules(ctx context.Context, page repository.Pagination) ([]*repository.ReportSchedule, int64, error) {
+	var out []*repository.ReportSchedule
+	var total int64
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, total, err = r.next.ListReportSchedules(ctx, page)
+		return err
+	})
+	return out, total, err
+}
+
+// ListDueReportSchedules implements repository.ReportRepository
+func (r *GuardedReportRepo) ListDueReportSchedules(ctx context.Context, now time.Time, limit int) ([]*repository.ReportSchedule, error) {
+	var out []*repository.ReportSchedule
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.ListDueReportSchedules(ctx, now, limit)
+		return err
+	})
+	return out, err
+}
+
+// ClaimReportSchedule implements repository.ReportRepository
+func (r *GuardedReportRepo) ClaimReportSchedule(ctx context.Context, id string, due, next time.Time) (bool, error) {
+	var claimed bool
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		claimed, err = r.next.ClaimReportSchedule(ctx, id, due, next)
+		return err
+	})
+	return claimed, err
+}
+
+// CreateReportRun implements repository.ReportRepository
+func (r *GuardedReportRepo) CreateReportRun(ctx context.Context, run *repository.ReportRun) error {
+	return r.guard.do(ctx, func(ctx context.Context) error {
+		return r.next.CreateReportRun(ctx, run)
+	})
+}
+
+// GetReportRun implements repository.ReportRepository
+func (r *GuardedReportRepo) GetReportRun(ctx context.Context, id string) (*repository.ReportRun, error) {
+	var out *repository.ReportRun
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.GetReportRun(ctx, id)
+		return err
+	})
+	return out, err
+}
+
+// ListReportRuns implements repository.ReportRepository
+func (r *GuardedReportRepo) ListReportRuns(ctx context.Context, scheduleID string, page repository.Pagination) ([]*repository.ReportRun, int64, error) {
+	var out []*repository.ReportRun
+	var total int64
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, total, err = r.next.ListReportRuns(ctx, scheduleID, page)
+		return err
+	})
+	return out, total, err
+}
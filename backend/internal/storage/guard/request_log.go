@@ -0,0 +1,60 @@
+package guard
+
+import (
+	"context"
+	"time"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure GuardedRequestLogRepo implements RequestLogRepository
+var _ repository.RequestLogRepository = (*GuardedRequestLogRepo)(nil)
+
+// GuardedRequestLogRepo wraps a RequestLogRepository with query deadlines and the database breaker
+type GuardedRequestLogRepo struct {
+	next  repository.RequestLogRepository
+	guard *Guard
+}
+
+// NewGuardedRequestLogRepo wraps next with g
+func NewGuardedRequestLogRepo(next repository.RequestLogRepository, g *Guard) *GuardedRequestLogRepo {
+	return &GuardedRequestLogRepo{next: next, guard: g}
+}
+
+// InsertRequestLogs implements repository.RequestLogRepository
+func (r *GuardedRequestLogRepo) InsertRequestLogs(ctx context.Context, entries []*repository.RequestLogEntry) error {
+	return r.guard.doMaintenance(ctx, func(ctx context.Context) error {
+		return r.next.InsertRequestLogs(ctx, entries)
+	})
+}
+
+// GetRequestLog implements repository.RequestLogRepository
+func (r *GuardedRequestLogRepo) GetRequestLog(ctx context.Context, id string) (*repository.RequestLogEntry, error) {
+	var out *repository.RequestLogEntry
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.GetRequestLog(ctx, id)
+		return err
+	})
+	return out, err
+}
+
+// ListRequestLogs implements repository.RequestLogRepository
+func (r *GuardedRequestLogRepo) ListRequestLogs(ctx context.Context, filter repository.RequestLogFilter, page repository.Pagination) ([]*repository.RequestLogEntry, int64, error) {
+	var out []*repository.RequestLogEntry
+	var total int64
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, total, err = r.next.ListRequestLogs(ctx, filter, page)
+		return err
+	})
+	return out, total, err
+}
+
+// DeleteRequestLogsBefore implements repository.RequestLogRepository
+func (r *GuardedRequestLogRepo) DeleteRequestLogsBefore(ctx context.Context, before time.Time) (int64, error) {
+	var out int64
+	err := r.guard.doMaintenance(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.DeleteRequestLogsBefore(ctx, before)
+		return err
+	})
+	return out, err
+}
@@ -0,0 +1,73 @@
+package guard
+
+import (
+	"context"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure GuardedSafeTransactionRepo implements SafeTransactionRepository
+var _ repository.SafeTransactionRepository = (*GuardedSafeTransactionRepo)(nil)
+
+// GuardedSafeTransactionRepo wraps a SafeTransactionRepository with query deadlines and the database breaker
+type GuardedSafeTransactionRepo struct {
+	next  repository.SafeTransactionRepository
+	guard *Guard
+}
+
+// NewGuardedSafeTransactionRepo wraps next with g
+func NewGuardedSafeTransactionRepo(next repository.SafeTransactionRepository, g *Guard) *GuardedSafeTransactionRepo {
+	return &GuardedSafeTransactionRepo{next: next, guard: g}
+}
+
+// CreateSafeTransaction implements repository.SafeTransactionRepository
+func (r *GuardedSafeTransactionRepo) CreateSafeTransaction(ctx context.Context, tx *repository.SafeTransaction) error {
+	return r.guard.do(ctx, func(ctx context.Context) error {
+		return r.next.CreateSafeTransaction(ctx, tx)
+	})
+}
+
+// GetSafeTransaction implements repository.SafeTransactionRepository
+func (r *GuardedSafeTransactionRepo) GetSafeTransaction(ctx context.Context, id string) (*repository.SafeTransaction, error) {
+	var out *repository.SafeTransaction
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.GetSafeTransaction(ctx, id)
+		return err
+	})
+	return out, err
+}
+
+// ListSafeTransactions implements repository.SafeTransactionRepository
+func (r *GuardedSafeTransactionRepo) ListSafeTransactions(ctx context.Context, filter repository.SafeTransactionFilter, page repository.Pagination) ([]*repository.SafeTransaction, int64, error) {
+	var out []*repository.SafeTransaction
+	var total int64
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, total, err = r.next.ListSafeTransactions(ctx, filter, page)
+		return err
+	})
+	return out, total, err
+}
+
+// AddSafeConfirmation implements repository.SafeTransactionRepository
+func (r *GuardedSafeTransactionRepo) AddSafeConfirmation(ctx context.Context, c *repository.SafeConfirmation) error {
+	return r.guard.do(ctx, func(ctx context.Context) error {
+		return r.next.AddSafeConfirmation(ctx, c)
+	})
+}
+
+// ListSafeConfirmations implements repository.SafeTransactionRepository
+func (r *GuardedSafeTransactionRepo) ListSafeConfirmations(ctx context.Context, safeTxID string) ([]*repository.SafeConfirmation, error) {
+	var out []*repository.SafeConfirmation
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.ListSafeConfirmations(ctx, safeTxID)
+		return err
+	})
+	return out, err
+}
+
+// UpdateSafeTransactionStatus implements repository.SafeTransactionRepository
+func (r *GuardedSafeTransactionRepo) UpdateSafeTransactionStatus(ctx context.Context, id string, update *repository.SafeTransactionUpdate) error {
+	return r.guard.do(ctx, func(ctx context.Context) error {
+		return r.next.UpdateSafeTransactionStatus(ctx, id, update)
+	})
+}
@@ -0,0 +1,31 @@
+package guard
+
+import (
+	"context"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure GuardedSearchRepo implements SearchRepository
+var _ repository.SearchRepository = (*GuardedSearchRepo)(nil)
+
+// GuardedSearchRepo wraps a SearchRepository with query deadlines and the database breaker
+type GuardedSearchRepo struct {
+	next  repository.SearchRepository
+	guard *Guard
+}
+
+// NewGuardedSearchRepo wraps next with g
+func NewGuardedSearchRepo(next repository.SearchRepository, g *Guard) *GuardedSearchRepo {
+	return &GuardedSearchRepo{next: next, guard: g}
+}
+
+// Search implements repository.SearchRepository
+func (r *GuardedSearchRepo) Search(ctx context.Context, query repository.SearchQuery) ([]*repository.SearchResult, error) {
+	var out []*repository.SearchResult
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.Search(ctx, query)
+		return err
+	})
+	return out, err
+}
@@ -0,0 +1,56 @@
+package guard
+
+import (
+	"context"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure GuardedSnapshotRepo implements SnapshotRepository
+var _ repository.SnapshotRepository = (*GuardedSnapshotRepo)(nil)
+
+// GuardedSnapshotRepo wraps a SnapshotRepository with query deadlines and the database breaker
+type GuardedSnapshotRepo struct {
+	next  repository.SnapshotRepository
+	guard *Guard
+}
+
+// NewGuardedSnapshotRepo wraps next with g
+func NewGuardedSnapshotRepo(next repository.SnapshotRepository, g *Guard) *GuardedSnapshotRepo {
+	return &GuardedSnapshotRepo{next: next, guard: g}
+}
+
+// UpsertSnapshotProposal implements repository.SnapshotRepository
+func (r *GuardedSnapshotRepo) UpsertSnapshotProposal(ctx context.Context, p *repository.SnapshotProposal) error {
+	return r.guard.do(ctx, func(ctx context.Context) error {
+		return r.next.UpsertSnapshotProposal(ctx, p)
+	})
+}
+
+// GetSnapshotProposal implements repository.SnapshotRepository
+func (r *GuardedSnapshotRepo) GetSnapshotProposal(ctx context.Context, id string) (*repository.SnapshotProposal, error) {
+	var out *repository.SnapshotProposal
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.GetSnapshotProposal(ctx, id)
+		return err
+	})
+	return out, err
+}
+
+// ListSnapshotProposals implements repository.SnapshotRepository
+func (r *GuardedSnapshotRepo) ListSnapshotProposals(ctx context.Context, filter repository.SnapshotProposalFilter, page repository.Pagination) ([]*repository.SnapshotProposal, int64, error) {
+	var out []*repository.SnapshotProposal
+	var total int64
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, total, err = r.next.ListSnapshotProposals(ctx, filter, page)
+		return err
+	})
+	return out, total, err
+}
+
+// LinkSnapshotProposal implements repository.SnapshotRepository
+func (r *GuardedSnapshotRepo) LinkSnapshotProposal(ctx context.Context, id string, onchainProposalID *string) error {
+	return r.guard.do(ctx, func(ctx context.Context) error {
+		return r.next.LinkSnapshotProposal(ctx, id, onchainProposalID)
+	})
+}
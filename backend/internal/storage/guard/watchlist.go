@@ -0,0 +1,63 @@
+package guard
+
+import (
+	"context"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure GuardedWatchlistRepo implements WatchlistRepository
+var _ repository.WatchlistRepository = (*GuardedWatchlistRepo)(nil)
+
+// GuardedWatchlistRepo wraps a WatchlistRepository with query deadlines and the database breaker
+type GuardedWatchlistRepo struct {
+	next  repository.WatchlistRepository
+	guard *Guard
+}
+
+// NewGuardedWatchlistRepo wraps next with g
+func NewGuardedWatchlistRepo(next repository.WatchlistRepository, g *Guard) *GuardedWatchlistRepo {
+	return &GuardedWatchlistRepo{next: next, guard: g}
+}
+
+// CreateWatchlist implements repository.WatchlistRepository
+func (r *GuardedWatchlistRepo) CreateWatchlist(ctx context.Context, w *repository.Watchlist) error {
+	return r.guard.do(ctx, func(ctx context.Context) error {
+		return r.next.CreateWatchlist(ctx, w)
+	})
+}
+
+// GetWatchlist implements repository.WatchlistRepository
+func (r *GuardedWatchlistRepo) GetWatchlist(ctx context.Context, id string) (*repository.Watchlist, error) {
+	var out *repository.Watchlist
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.GetWatchlist(ctx, id)
+		return err
+	})
+	return out, err
+}
+
+// UpdateWatchlist implements repository.WatchlistRepository
+func (r *GuardedWatchlistRepo) UpdateWatchlist(ctx context.Context, w *repository.Watchlist) error {
+	return r.guard.do(ctx, func(ctx context.Context) error {
+		return r.next.UpdateWatchlist(ctx, w)
+	})
+}
+
+// DeleteWatchlist implements repository.WatchlistRepository
+func (r *GuardedWatchlistRepo) DeleteWatchlist(ctx context.Context, id string) error {
+	return r.guard.do(ctx, func(ctx context.Context) error {
+		return r.next.DeleteWatchlist(ctx, id)
+	})
+}
+
+// ListWatchlists implements repository.WatchlistRepository
+func (r *GuardedWatchlistRepo) ListWatchlists(ctx context.Context, filter repository.WatchlistFilter, page repository.Pagination) ([]*repository.Watchlist, int64, error) {
+	var out []*repository.Watchlist
+	var total int64
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, total, err = r.next.ListWatchlists(ctx, filter, page)
+		return err
+	})
+	return out, total, err
+}
@@ -0,0 +1,111 @@
+package guard
+
+import (
+	"context"
+	"time"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure GuardedWebhookEventRepo implements WebhookEventRepository
+var _ repository.WebhookEventRepository = (*GuardedWebhookEventRepo)(nil)
+
+// GuardedWebhookEventRepo wraps a WebhookEventRepository with query deadlines and the database breaker
+type GuardedWebhookEventRepo struct {
+	next  repository.WebhookEventRepository
+	guard *Guard
+}
+
+// NewGuardedWebhookEventRepo wraps next with g
+func NewGuardedWebhookEventRepo(next repository.WebhookEventRepository, g *Guard) *GuardedWebhookEventRepo {
+	return &GuardedWebhookEventRepo{next: next, guard: g}
+}
+
+// CreateWebhookEvent implements repository.WebhookEventRepository
+func (r *GuardedWebhookEventRepo) CreateWebhookEvent(ctx context.Context, e *repository.WebhookEvent) (bool, error) {
+	var out bool
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.CreateWebhookEvent(ctx, e)
+		return err
+	})
+	return out, err
+}
+
+// GetWebhookEvent implements repository.WebhookEventRepository
+func (r *GuardedWebhookEventRepo) GetWebhookEvent(ctx context.Context, id string) (*repository.WebhookEvent, error) {
+	var out *repository.WebhookEvent
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.GetWebhookEvent(ctx, id)
+		return err
+	})
+	return out, err
+}
+
+// GetWebhookEventByEventID implements repository.WebhookEventRepository
+func (r *GuardedWebhookEventRepo) GetWebhookEventByEventID(ctx context.Context, provider, eventID string) (*repository.WebhookEvent, error) {
+	var out *repository.WebhookEvent
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.GetWebhookEventByEventID(ctx, provider, eventID)
+		return err
+	})
+	return out, err
+}
+
+// ListWebhookEvents implements repository.WebhookEventRepository
+func (r *GuardedWebhookEventRepo) ListWebhookEvents(ctx context.Context, filter repository.WebhookEventFilter, page repository.Pagination) ([]*repository.WebhookEvent, int64, error) {
+	var out []*repository.WebhookEvent
+	var total int64
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, total, err = r.next.ListWebhookEvents(ctx, filter, page)
+		return err
+	})
+	return out, total, err
+}
+
+// ListDueWebhookEvents implements repository.WebhookEventRepository
+func (r *GuardedWebhookEventRepo) ListDueWebhookEvents(ctx context.Context, provider string, staleAfter time.Duration, limit int) ([]*repository.WebhookEvent, error) {
+	var out []*repository.WebhookEvent
+	err := r.guard.doMaintenance(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.ListDueWebhookEvents(ctx, provider, staleAfter, limit)
+		return err
+	})
+	return out, err
+}
+
+// ClaimWebhookEvent implements repository.WebhookEventRepository
+func (r *GuardedWebhookEventRepo) ClaimWebhookEvent(ctx context.Context, id string, staleAfter time.Duration) (bool, error) {
+	var out bool
+	err := r.guard.do(ctx, func(ctx context.Context) (err error) {
+		out, err = r.next.ClaimWebhookEvent(ctx, id, staleAfter)
+		return err
+	})
+	return out, err
+}
+
+// MarkWebhookEventProcessed implements repository.WebhookEventRepository
+func (r *GuardedWebhookEventRepo) MarkWebhookEventProcessed(ctx context.Context, id string) error {
+	return r.guard.do(ctx, func(ctx context.Context) error {
+		return r.next.MarkWebhookEventProcessed(ctx, id)
+	})
+}
+
+// MarkWebhookEventFailed implements repository.WebhookEventRepository
+func (r *GuardedWebhookEventRepo) MarkWebhookEventFailed(ctx context.Context, id string, errMsg string, retryAt *time.Time) error {
+	return r.guard.do(ctx, func(ctx context.Context) error {
+		return r.next.MarkWebhookEventFailed(ctx, id, errMsg, retryAt)
+	})
+}
+
+// MarkWebhookEventDeadLetter implements repository.WebhookEventRepository
+func (r *GuardedWebhookEventRepo) MarkWebhookEventDeadLetter(ctx context.Context, id string, errMsg string) error {
+	return r.guard.do(ctx, func(ctx context.Context) error {
+		return r.next.MarkWebhookEventDeadLetter(ctx, id, errMsg)
+	})
+}
+
+// RequeueWebhookEvent implements repository.WebhookEventRepository
+func (r *GuardedWebhookEventRepo) RequeueWebhookEvent(ctx context.Context, id string) error {
+	return r.guard.do(ctx, func(ctx context.Context) error {
+		return r.next.RequeueWebhookEvent(ctx, id)
+	})
+}
@@ -0,0 +1,68 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure MemoryActivityRepo implements ActivityRepository
+var _ repository.ActivityRepository = (*MemoryActivityRepo)(nil)
+
+// MemoryActivityRepo implements ActivityRepository in memory
+type MemoryActivityRepo struct {
+	mu     sync.RWMutex
+	events []*repository.ActivityEvent // in recording order
+}
+
+// NewMemoryActivityRepo creates a new in-memory activity repository
+func NewMemoryActivityRepo() *MemoryActivityRepo {
+	return &MemoryActivityRepo{}
+}
+
+// RecordActivity appends an event
+func (r *MemoryActivityRepo) RecordActivity(ctx context.Context, e *repository.ActivityEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	e.ID = newID()
+	if e.OccurredAt.IsZero() {
+		e.OccurredAt = now()
+	}
+	cp := *e
+	r.events = append(r.events, &cp)
+	return nil
+}
+
+// ListActivity lists an address's events, newest first
+func (r *MemoryActivityRepo) ListActivity(ctx context.Context, filter repository.ActivityFilter, page repository.Pagination) ([]*repository.ActivityEvent, int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	// Walking backwards keeps events recorded at the same instant newest
+	// first through the stable sort
+	var matched []*repository.ActivityEvent
+	for i := len(r.events) - 1; i >= 0; i-- {
+		e := r.events[i]
+		if e.Address != filter.Address || (len(filter.Kinds) > 0 && !containsKind(filter.Kinds, e.Kind)) {
+			continue
+		}
+		cp := *e
+		matched = append(matched, &cp)
+	}
+
+	sortNewestFirst(matched, func(e *repository.ActivityEvent) time.Time { return e.OccurredAt })
+
+	return paginate(matched, page, 20), int64(len(matched)), nil
+}
+
+func containsKind(kinds []repository.ActivityKind, k repository.ActivityKind) bool {
+	for _, kind := range kinds {
+		if kind == k {
+			return true
+		}
+	}
+	return false
+}
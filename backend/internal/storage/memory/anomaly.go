@@ -0,0 +1,187 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure MemoryAnomalyRepo implements AnomalyRepository
+var _ repository.AnomalyRepository = (*MemoryAnomalyRepo)(nil)
+
+// MemoryAnomalyRepo implements AnomalyRepository over the in-memory payment
+// and relayer stores
+type MemoryAnomalyRepo struct {
+	payments *MemoryPaymentRepo
+	relayer  *MemoryRelayerRepo
+
+	mu    sync.RWMutex
+	cases map[string]*repository.ComplianceCase
+}
+
+// NewMemoryAnomalyRepo creates an anomaly repository over payments and relayer
+func NewMemoryAnomalyRepo(payments *MemoryPaymentRepo, relayer *MemoryRelayerRepo) *MemoryAnomalyRepo {
+	return &MemoryAnomalyRepo{
+		payments: payments,
+		relayer:  relayer,
+		cases:    make(map[string]*repository.ComplianceCase),
+	}
+}
+
+// CountPaymentsByPayer counts recent payments per payer
+func (r *MemoryAnomalyRepo) CountPaymentsByPayer(ctx context.Context, since time.Time, min int64) ([]repository.SubjectCount, error) {
+	counts := make(map[string]int64)
+	r.payments.mu.RLock()
+	for _, p := range r.payments.payments {
+		if !p.CreatedAt.Before(since) {
+			counts[p.PayerAddress]++
+		}
+	}
+	r.payments.mu.RUnlock()
+	return subjectCounts(counts, min), nil
+}
+
+// CountFailedRelaysBySender counts recent failed meta-transactions per sender
+func (r *MemoryAnomalyRepo) CountFailedRelaysBySender(ctx context.Context, since time.Time, min int64) ([]repository.SubjectCount, error) {
+	counts := make(map[string]int64)
+	r.relayer.mu.RLock()
+	for _, tx := range r.relayer.txs {
+		if tx.Status == repository.MetaTxStatusFailed && !tx.CreatedAt.Before(since) {
+			counts[tx.FromAddress]++
+		}
+	}
+	r.relayer.mu.RUnlock()
+	return subjectCounts(counts, min), nil
+}
+
+// subjectCounts keeps the counts of at least min, highest first
+func subjectCounts(counts map[string]int64, min int64) []repository.SubjectCount {
+	var result []repository.SubjectCount
+	for subject, n := range counts {
+		if n >= min {
+			result = append(result, repository.SubjectCount{Subject: subject, Count: n})
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].Subject < result[j].Subject
+	})
+	return result
+}
+
+// HourlyActivity counts payments and meta-transactions per UTC hour
+func (r *MemoryAnomalyRepo) HourlyActivity(ctx context.Context, since time.Time) ([]repository.HourlyCount, error) {
+	hours := make(map[time.Time]*repository.HourlyCount)
+	bucket := func(t time.Time) *repository.HourlyCount {
+		h := t.UTC().Truncate(time.Hour)
+		if hours[h] == nil {
+			hours[h] = &repository.HourlyCount{Hour: h}
+		}
+		return hours[h]
+	}
+
+	r.payments.mu.RLock()
+	for _, p := range r.payments.payments {
+		if !p.CreatedAt.Before(since) {
+			bucket(p.CreatedAt).Payments++
+		}
+	}
+	r.payments.mu.RUnlock()
+
+	r.relayer.mu.RLock()
+	for _, tx := range r.relayer.txs {
+		if !tx.CreatedAt.Before(since) {
+			bucket(tx.CreatedAt).Relays++
+		}
+	}
+	r.relayer.mu.RUnlock()
+
+	result := make([]repository.HourlyCount, 0, len(hours))
+	for _, h := range hours {
+		result = append(result, *h)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Hour.Before(result[j].Hour) })
+	return result, nil
+}
+
+// OpenComplianceCase opens a case unless one is open for its rule and subject
+func (r *MemoryAnomalyRepo) OpenComplianceCase(ctx context.Context, c *repository.ComplianceCase) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.cases {
+		if existing.Status == repository.ComplianceCaseOpen && existing.Rule == c.Rule && existing.Subject == c.Subject {
+			return false, nil
+		}
+	}
+	c.ID = newID()
+	c.Status = repository.ComplianceCaseOpen
+	c.OpenedAt = now()
+	cp := *c
+	r.cases[c.ID] = &cp
+	return true, nil
+}
+
+// GetComplianceCase retrieves a case by ID
+func (r *MemoryAnomalyRepo) GetComplianceCase(ctx context.Context, id string) (*repository.ComplianceCase, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	c, ok := r.cases[id]
+	if !ok {
+		return nil, repository.ErrComplianceCaseNotFound
+	}
+	cp := *c
+	return &cp, nil
+}
+
+// ListComplianceCases lists cases, newest first
+func (r *MemoryAnomalyRepo) ListComplianceCases(ctx context.Context, filter repository.ComplianceCaseFilter, page repository.Pagination) ([]*repository.ComplianceCase, int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var matched []*repository.ComplianceCase
+	for _, c := range r.cases {
+		if filter.Rule != "" && c.Rule != filter.Rule {
+			continue
+		}
+		if filter.Subject != "" && c.Subject != filter.Subject {
+			continue
+		}
+		if filter.Status != "" && c.Status != filter.Status {
+			continue
+		}
+		cp := *c
+		matched = append(matched, &cp)
+	}
+
+	sortNewestFirst(matched, func(c *repository.ComplianceCase) time.Time { return c.OpenedAt })
+
+	return paginate(matched, page, 20), int64(len(matched)), nil
+}
+
+// CloseComplianceCase closes an open case
+func (r *MemoryAnomalyRepo) CloseComplianceCase(ctx context.Context, id, closedBy, resolution string) (*repository.ComplianceCase, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	c, ok := r.cases[id]
+	if !ok {
+		return nil, repository.ErrComplianceCaseNotFound
+	}
+	if c.Status != repository.ComplianceCaseOpen {
+		return nil, repository.ErrComplianceCaseClosed
+	}
+	ts := now()
+	c.Status = repository.ComplianceCaseClosed
+	c.ClosedAt = &ts
+	c.ClosedBy = &closedBy
+	c.Resolution = &resolution
+	cp := *c
+	return &cp, nil
+}
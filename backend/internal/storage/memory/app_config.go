@@ -0,0 +1,319 @@
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure MemoryAppConfigRepo implements AppConfigRepository
+var _ repository.AppConfigRepository = (*MemoryAppConfigRepo)(nil)
+
+// appConfigKey identifies an app config row (namespace, key, chain)
+type appConfigKey struct {
+	namespace string
+	key       string
+	chainID   int64
+}
+
+// MemoryAppConfigRepo implements AppConfigRepository in memory
+type MemoryAppConfigRepo struct {
+	mu      sync.RWMutex
+	configs map[appConfigKey]*repository.AppConfig
+	history []*repository.AppConfigHistoryEntry
+}
+
+// NewMemoryAppConfigRepo creates a new in-memory app config repository
+func NewMemoryAppConfigRepo() *MemoryAppConfigRepo {
+	return &MemoryAppConfigRepo{
+		configs: make(map[appConfigKey]*repository.AppConfig),
+	}
+}
+
+// Get retrieves a single active config by namespace, key, and chain ID
+func (r *MemoryAppConfigRepo) Get(ctx context.Context, namespace, key string, chainID int64) (*repository.AppConfig, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	c, ok := r.configs[appConfigKey{namespace, key, chainID}]
+	if !ok || !c.IsActive {
+		return nil, repository.ErrAppConfigNotFound
+	}
+	return copyAppConfig(c), nil
+}
+
+// GetWithFallback tries chain-specific first, then falls back to chain_id=0 (global)
+func (r *MemoryAppConfigRepo) GetWithFallback(ctx context.Context, namespace, key string, chainID int64) (*repository.AppConfig, error) {
+	config, err := r.Get(ctx, namespace, key, chainID)
+	if err == nil {
+		return config, nil
+	}
+
+	config, err = r.Get(ctx, namespace, key, 0)
+	if err != nil {
+		return nil, fmt.Errorf("config %s.%s not found for chain %d or globally: %w", namespace, key, chainID, repository.ErrAppConfigNotFound)
+	}
+	return config, nil
+}
+
+// ListByNamespace retrieves active configs for a namespace (chain-specific and global)
+func (r *MemoryAppConfigRepo) ListByNamespace(ctx context.Context, namespace string, chainID int64) ([]*repository.AppConfig, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []*repository.AppConfig
+	for k, c := range r.configs {
+		if k.namespace != namespace || (k.chainID != chainID && k.chainID != 0) || !c.IsActive {
+			continue
+		}
+		result = append(result, copyAppConfig(c))
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ConfigKey < result[j].ConfigKey
+	})
+
+	return result, nil
+}
+
+// ListAll retrieves all configs, including inactive ones (for admin)
+func (r *MemoryAppConfigRepo) ListAll(ctx context.Context) ([]*repository.AppConfig, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]*repository.AppConfig, 0, len(r.configs))
+	for _, c := range r.configs {
+		result = append(result, copyAppConfig(c))
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		if a.ConfigKey != b.ConfigKey {
+			return a.ConfigKey < b.ConfigKey
+		}
+		return a.ChainID < b.ChainID
+	})
+
+	return result, nil
+}
+
+// Typed getters with fallback
+
+// GetString returns the string value for a config
+func (r *MemoryAppConfigRepo) GetString(ctx context.Context, namespace, key string, chainID int64) (string, error) {
+	config, err := r.GetWithFallback(ctx, namespace, key, chainID)
+	if err != nil {
+		return "", err
+	}
+	return config.GetStringValue(), nil
+}
+
+// GetNumber returns the number value for a config
+func (r *MemoryAppConfigRepo) GetNumber(ctx context.Context, namespace, key string, chainID int64) (int64, error) {
+	config, err := r.GetWithFallback(ctx, namespace, key, chainID)
+	if err != nil {
+		return 0, err
+	}
+	return config.GetNumberValue(), nil
+}
+
+// GetWei returns the wei value for a config
+func (r *MemoryAppConfigRepo) GetWei(ctx context.Context, namespace, key string, chainID int64) (*big.Int, error) {
+	config, err := r.GetWithFallback(ctx, namespace, key, chainID)
+	if err != nil {
+		return nil, err
+	}
+	return config.GetWeiValue(), nil
+}
+
+// GetBool returns the boolean value for a config
+func (r *MemoryAppConfigRepo) GetBool(ctx context.Context, namespace, key string, chainID int64) (bool, error) {
+	config, err := r.GetWithFallback(ctx, namespace, key, chainID)
+	if err != nil {
+		return false, err
+	}
+	return config.GetBoolValue(), nil
+}
+
+// GetJSON parses the string value as JSON into dest
+func (r *MemoryAppConfigRepo) GetJSON(ctx context.Context, namespace, key string, chainID int64, dest interface{}) error {
+	config, err := r.GetWithFallback(ctx, namespace, key, chainID)
+	if err != nil {
+		return err
+	}
+	if config.ValueString == nil {
+		return nil
+	}
+	return json.Unmarshal([]byte(*config.ValueString), dest)
+}
+
+// Update updates an existing config, recording value changes in history
+func (r *MemoryAppConfigRepo) Update(ctx context.Context, namespace, key string, chainID int64, update *repository.AppConfigUpdate) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	c, ok := r.configs[appConfigKey{namespace, key, chainID}]
+	if !ok {
+		return repository.ErrAppConfigNotFound
+	}
+
+	old := copyAppConfig(c)
+	c.UpdatedBy = strPtr(update.UpdatedBy)
+	if update.ValueString != nil {
+		v := *update.ValueString
+		c.ValueString = &v
+	}
+	if update.ValueNumber != nil {
+		v := *update.ValueNumber
+		c.ValueNumber = &v
+	}
+	if update.ValueWei != nil {
+		c.ValueWei = copyWei(update.ValueWei)
+	}
+	if update.ValueBoolean != nil {
+		v := *update.ValueBoolean
+		c.ValueBoolean = &v
+	}
+	if update.Description != nil {
+		c.Description = *update.Description
+	}
+	if update.IsActive != nil {
+		c.IsActive = *update.IsActive
+	}
+	c.UpdatedAt = now()
+
+	r.logChange(old, c)
+	return nil
+}
+
+// Create creates a new config. Creating an existing namespace/key/chain fails
+// like the unique constraint in PostgreSQL.
+func (r *MemoryAppConfigRepo) Create(ctx context.Context, config *repository.AppConfigCreate) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	k := appConfigKey{config.Namespace, config.ConfigKey, config.ChainID}
+	if _, exists := r.configs[k]; exists {
+		return fmt.Errorf("creating app config %s.%s: %w", config.Namespace, config.ConfigKey, errAppConfigExists)
+	}
+
+	ts := now()
+	c := &repository.AppConfig{
+		ID:          newID(),
+		Namespace:   config.Namespace,
+		ConfigKey:   config.ConfigKey,
+		ValueType:   config.ValueType,
+		ValueWei:    copyWei(config.ValueWei),
+		Description: config.Description,
+		IsSecret:    config.IsSecret,
+		IsActive:    true,
+		ChainID:     config.ChainID,
+		CreatedAt:   ts,
+		UpdatedAt:   ts,
+	}
+	if config.ValueString != nil {
+		c.ValueString = strPtr(*config.ValueString)
+	}
+	if config.ValueNumber != nil {
+		v := *config.ValueNumber
+		c.ValueNumber = &v
+	}
+	if config.ValueBoolean != nil {
+		v := *config.ValueBoolean
+		c.ValueBoolean = &v
+	}
+	if config.UpdatedBy != "" {
+		c.UpdatedBy = strPtr(config.UpdatedBy)
+	}
+
+	r.configs[k] = c
+	return nil
+}
+
+// errAppConfigExists mirrors the app_config_namespace_key_chain_unique violation
+var errAppConfigExists = errors.New("duplicate key value violates unique constraint")
+
+// Delete soft-deletes a config by setting is_active=false
+func (r *MemoryAppConfigRepo) Delete(ctx context.Context, namespace, key string, chainID int64, deletedBy string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	c, ok := r.configs[appConfigKey{namespace, key, chainID}]
+	if !ok {
+		return repository.ErrAppConfigNotFound
+	}
+
+	c.IsActive = false
+	c.UpdatedBy = strPtr(deletedBy)
+	c.UpdatedAt = now()
+
+	return nil
+}
+
+// GetHistory retrieves change history for a config, newest first
+func (r *MemoryAppConfigRepo) GetHistory(ctx context.Context, namespace, key string, chainID int64, limitN int) ([]*repository.AppConfigHistoryEntry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	c, ok := r.configs[appConfigKey{namespace, key, chainID}]
+	if !ok {
+		return nil, nil
+	}
+
+	var result []*repository.AppConfigHistoryEntry
+	for i := len(r.history) - 1; i >= 0; i-- {
+		if r.history[i].AppConfigID == c.ID {
+			cp := *r.history[i]
+			result = append(result, &cp)
+		}
+	}
+
+	return limit(result, limitN), nil
+}
+
+// logChange mirrors the log_app_config_change trigger (caller holds the lock)
+func (r *MemoryAppConfigRepo) logChange(old, c *repository.AppConfig) {
+	if !distinct(old.ValueString, c.ValueString) &&
+		!distinct(old.ValueNumber, c.ValueNumber) &&
+		!distinctWei(old.ValueWei, c.ValueWei) &&
+		!distinct(old.ValueBoolean, c.ValueBoolean) {
+		return
+	}
+
+	changedBy := "system"
+	if c.UpdatedBy != nil && *c.UpdatedBy != "" {
+		changedBy = *c.UpdatedBy
+	}
+
+	r.history = append(r.history, &repository.AppConfigHistoryEntry{
+		ID:              newID(),
+		AppConfigID:     c.ID,
+		OldValueString:  old.ValueString,
+		OldValueNumber:  old.ValueNumber,
+		OldValueWei:     old.ValueWei,
+		OldValueBoolean: old.ValueBoolean,
+		NewValueString:  c.ValueString,
+		NewValueNumber:  c.ValueNumber,
+		NewValueWei:     copyWei(c.ValueWei),
+		NewValueBoolean: c.ValueBoolean,
+		ChangedBy:       changedBy,
+		ChangedAt:       c.UpdatedAt,
+		ChangeReason:    strPtr("Config update"),
+	})
+}
+
+// copyAppConfig returns a copy that does not share the wei value
+func copyAppConfig(c *repository.AppConfig) *repository.AppConfig {
+	cp := *c
+	cp.ValueWei = copyWei(c.ValueWei)
+	return &cp
+}
@@ -0,0 +1,182 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// ArchivePayments moves up to limit settled payments created before the
+// cutoff into the archive. Payments linked to a KYC verification are kept.
+// Chain tracking lives in a separate store, so unlike PostgreSQL this does not
+// check for unconfirmed transactions.
+func (r *MemoryPaymentRepo) ArchivePayments(ctx context.Context, before time.Time, limitN int) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	linked := make(map[string]bool)
+	for _, v := range r.verifications {
+		if v.PaymentID != nil {
+			linked[*v.PaymentID] = true
+		}
+	}
+
+	var candidates []*repository.Payment
+	for _, p := range r.payments {
+		if !p.CreatedAt.Before(before) || linked[p.ID] {
+			continue
+		}
+		switch p.Status {
+		case repository.PaymentStatusCompleted, repository.PaymentStatusFailed,
+			repository.PaymentStatusRefunded, repository.PaymentStatusCancelled, repository.PaymentStatusExpired:
+			candidates = append(candidates, p)
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].CreatedAt.Before(candidates[j].CreatedAt) })
+	candidates = limit(candidates, limitN)
+
+	archivedAt := now()
+	for _, p := range candidates {
+		p.ArchivedAt = &archivedAt
+		r.archived[p.ID] = p
+		delete(r.payments, p.ID)
+	}
+
+	return int64(len(candidates)), nil
+}
+
+// GetArchivedPayment retrieves an archived payment by ID
+func (r *MemoryPaymentRepo) GetArchivedPayment(ctx context.Context, id string) (*repository.Payment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	p, ok := r.archived[id]
+	if !ok {
+		return nil, repository.ErrPaymentNotFound
+	}
+	cp := *p
+	return &cp, nil
+}
+
+// ListArchivedPayments lists archived payments with filtering, newest first
+func (r *MemoryPaymentRepo) ListArchivedPayments(ctx context.Context, filter repository.PaymentFilter, page repository.Pagination) ([]*repository.Payment, int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var matched []*repository.Payment
+	for _, p := range r.archived {
+		if filter.PayerAddress != "" && p.PayerAddress != filter.PayerAddress {
+			continue
+		}
+		if filter.ServiceCode != "" && p.ServiceCode != filter.ServiceCode {
+			continue
+		}
+		if filter.PaymentMethod != "" && p.PaymentMethod != filter.PaymentMethod {
+			continue
+		}
+		if filter.Status != "" && p.Status != filter.Status {
+			continue
+		}
+		if filter.ExcludesExpired() && p.Status == repository.PaymentStatusExpired {
+			continue
+		}
+		if filter.Disputed && p.DisputeID == nil {
+			continue
+		}
+		cp := *p
+		matched = append(matched, &cp)
+	}
+
+	sortNewestFirst(matched, func(p *repository.Payment) time.Time { return p.CreatedAt })
+
+	return paginate(matched, page, 20), int64(len(matched)), nil
+}
+
+// ArchiveMetaTxs moves up to limit finished meta-transactions created before
+// the cutoff into the archive, keeping each sender's highest live nonce so
+// GetNextNonce does not go backwards
+func (r *MemoryRelayerRepo) ArchiveMetaTxs(ctx context.Context, before time.Time, limitN int) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	maxNonce := make(map[string]uint64)
+	for _, tx := range r.txs {
+		switch tx.Status {
+		case repository.MetaTxStatusFailed, repository.MetaTxStatusExpired, repository.MetaTxStatusCancelled:
+			continue
+		}
+		if tx.Nonce > maxNonce[tx.FromAddress] {
+			maxNonce[tx.FromAddress] = tx.Nonce
+		}
+	}
+
+	var candidates []*repository.MetaTransaction
+	for _, tx := range r.txs {
+		if !tx.CreatedAt.Before(before) {
+			continue
+		}
+		switch tx.Status {
+		case repository.MetaTxStatusConfirmed:
+			if tx.Nonce == maxNonce[tx.FromAddress] {
+				continue
+			}
+			candidates = append(candidates, tx)
+		case repository.MetaTxStatusFailed, repository.MetaTxStatusExpired, repository.MetaTxStatusCancelled:
+			candidates = append(candidates, tx)
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].CreatedAt.Before(candidates[j].CreatedAt) })
+	candidates = limit(candidates, limitN)
+
+	archivedAt := now()
+	for _, tx := range candidates {
+		tx.ArchivedAt = &archivedAt
+		r.archived[tx.ID] = tx
+		delete(r.txs, tx.ID)
+	}
+
+	return int64(len(candidates)), nil
+}
+
+// GetArchivedMetaTx retrieves an archived meta-transaction by ID
+func (r *MemoryRelayerRepo) GetArchivedMetaTx(ctx context.Context, id string) (*repository.MetaTransaction, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	tx, ok := r.archived[id]
+	if !ok {
+		return nil, repository.ErrMetaTxNotFound
+	}
+	cp := *tx
+	return &cp, nil
+}
+
+// ListArchivedMetaTx lists archived meta-transactions with filtering, newest first
+func (r *MemoryRelayerRepo) ListArchivedMetaTx(ctx context.Context, filter repository.MetaTxFilter, page repository.Pagination) ([]*repository.MetaTransaction, int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var matched []*repository.MetaTransaction
+	for _, tx := range r.archived {
+		if filter.FromAddress != "" && tx.FromAddress != filter.FromAddress {
+			continue
+		}
+		if filter.ToAddress != "" && tx.ToAddress != filter.ToAddress {
+			continue
+		}
+		if filter.FunctionName != "" && tx.FunctionName != filter.FunctionName {
+			continue
+		}
+		if filter.Status != "" && tx.Status != filter.Status {
+			continue
+		}
+		cp := *tx
+		matched = append(matched, &cp)
+	}
+
+	sortNewestFirst(matched, func(tx *repository.MetaTransaction) time.Time { return tx.CreatedAt })
+
+	return paginate(matched, page, 20), int64(len(matched)), nil
+}
@@ -0,0 +1,214 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure MemoryBridgeRepo implements BridgeRepository
+var _ repository.BridgeRepository = (*MemoryBridgeRepo)(nil)
+
+// MemoryBridgeRepo implements BridgeRepository in memory
+type MemoryBridgeRepo struct {
+	mu        sync.RWMutex
+	transfers map[string]*repository.BridgeTransfer
+	cursors   map[int64]uint64 // chain ID -> next block
+}
+
+// NewMemoryBridgeRepo creates a new in-memory bridge repository
+func NewMemoryBridgeRepo() *MemoryBridgeRepo {
+	return &MemoryBridgeRepo{
+		transfers: make(map[string]*repository.BridgeTransfer),
+		cursors:   make(map[int64]uint64),
+	}
+}
+
+// CreateBridgeTransfer records a transfer, refusing a source chain and nonce
+// recorded before
+func (r *MemoryBridgeRepo) CreateBridgeTransfer(ctx context.Context, t *repository.BridgeTransfer) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if t.Nonce != nil {
+		for _, existing := range r.transfers {
+			if existing.SourceChainID == t.SourceChainID && existing.Nonce != nil && *existing.Nonce == *t.Nonce {
+				return repository.ErrBridgeTransferExists
+			}
+		}
+	}
+
+	ts := now()
+	t.ID = newID()
+	t.SourceConfirmedAt = nil
+	if t.SourceStatus == repository.BridgeLegConfirmed {
+		t.SourceConfirmedAt = &ts
+	}
+	t.DestCompletedAt = nil
+	if t.DestStatus == repository.BridgeLegCompleted {
+		t.DestCompletedAt = &ts
+	}
+	t.CreatedAt = ts
+	t.UpdatedAt = ts
+	cp := *t
+	r.transfers[t.ID] = &cp
+	return nil
+}
+
+// GetBridgeTransfer retrieves a bridge transfer by ID
+func (r *MemoryBridgeRepo) GetBridgeTransfer(ctx context.Context, id string) (*repository.BridgeTransfer, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	t, ok := r.transfers[id]
+	if !ok {
+		return nil, repository.ErrBridgeTransferNotFound
+	}
+	cp := *t
+	return &cp, nil
+}
+
+// GetBridgeTransferByNonce retrieves a bridge transfer by source chain and nonce
+func (r *MemoryBridgeRepo) GetBridgeTransferByNonce(ctx context.Context, sourceChainID int64, nonce uint64) (*repository.BridgeTransfer, error) {
+	return r.find(func(t *repository.BridgeTransfer) bool {
+		return t.SourceChainID == sourceChainID && t.Nonce != nil && *t.Nonce == nonce
+	})
+}
+
+// GetBridgeTransferByDestID retrieves a bridge transfer by its destination
+// transfer ID
+func (r *MemoryBridgeRepo) GetBridgeTransferByDestID(ctx context.Context, destTransferID string) (*repository.BridgeTransfer, error) {
+	return r.find(func(t *repository.BridgeTransfer) bool {
+		return t.DestTransferID != nil && *t.DestTransferID == destTransferID
+	})
+}
+
+// MatchBridgeIntent finds the oldest unmatched intent the source event fits
+func (r *MemoryBridgeRepo) MatchBridgeIntent(ctx context.Context, match repository.BridgeIntentMatch) (*repository.BridgeTransfer, error) {
+	return r.find(func(t *repository.BridgeTransfer) bool {
+		return t.Nonce == nil &&
+			t.Sender == match.Sender &&
+			t.Recipient == match.Recipient &&
+			t.Amount == match.Amount &&
+			t.SourceChainID == match.SourceChainID &&
+			t.DestChainID == match.DestChainID &&
+			(t.SourceTxHash == nil || *t.SourceTxHash == match.TxHash)
+	})
+}
+
+// find returns a copy of the oldest transfer satisfying fn
+func (r *MemoryBridgeRepo) find(fn func(*repository.BridgeTransfer) bool) (*repository.BridgeTransfer, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var found *repository.BridgeTransfer
+	for _, t := range r.transfers {
+		if fn(t) && (found == nil || t.CreatedAt.Before(found.CreatedAt)) {
+			found = t
+		}
+	}
+	if found == nil {
+		return nil, repository.ErrBridgeTransferNotFound
+	}
+	cp := *found
+	return &cp, nil
+}
+
+// UpdateBridgeTransfer applies the set fields of update
+func (r *MemoryBridgeRepo) UpdateBridgeTransfer(ctx context.Context, id string, update *repository.BridgeTransferUpdate) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	t, ok := r.transfers[id]
+	if !ok {
+		return repository.ErrBridgeTransferNotFound
+	}
+
+	ts := now()
+	if update.Direction != nil {
+		t.Direction = *update.Direction
+	}
+	if update.Sender != nil {
+		t.Sender = *update.Sender
+	}
+	if update.Nonce != nil {
+		t.Nonce = update.Nonce
+	}
+	if update.SourceStatus != nil {
+		t.SourceStatus = *update.SourceStatus
+		if t.SourceStatus == repository.BridgeLegConfirmed && t.SourceConfirmedAt == nil {
+			t.SourceConfirmedAt = &ts
+		}
+	}
+	if update.SourceTransferID != nil {
+		t.SourceTransferID = update.SourceTransferID
+	}
+	if update.SourceTxHash != nil {
+		t.SourceTxHash = update.SourceTxHash
+	}
+	if update.SourceBlock != nil {
+		t.SourceBlock = update.SourceBlock
+	}
+	if update.DestStatus != nil {
+		t.DestStatus = *update.DestStatus
+		if t.DestStatus == repository.BridgeLegCompleted && t.DestCompletedAt == nil {
+			t.DestCompletedAt = &ts
+		}
+	}
+	if update.DestTransferID != nil {
+		t.DestTransferID = update.DestTransferID
+	}
+	if update.DestTxHash != nil {
+		t.DestTxHash = update.DestTxHash
+	}
+	if update.DestBlock != nil {
+		t.DestBlock = update.DestBlock
+	}
+	if update.DestUnlockAt != nil {
+		t.DestUnlockAt = update.DestUnlockAt
+	}
+	t.UpdatedAt = ts
+	return nil
+}
+
+// ListBridgeTransfers lists transfers sent or received by address, newest first
+func (r *MemoryBridgeRepo) ListBridgeTransfers(ctx context.Context, address string, page repository.Pagination) ([]*repository.BridgeTransfer, int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var matched []*repository.BridgeTransfer
+	for _, t := range r.transfers {
+		if t.Sender != address && t.Recipient != address {
+			continue
+		}
+		cp := *t
+		matched = append(matched, &cp)
+	}
+
+	sortNewestFirst(matched, func(t *repository.BridgeTransfer) time.Time { return t.CreatedAt })
+
+	return paginate(matched, page, 20), int64(len(matched)), nil
+}
+
+// GetBridgeCursor returns the first block of chainID not yet scanned
+func (r *MemoryBridgeRepo) GetBridgeCursor(ctx context.Context, chainID int64) (uint64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	next, ok := r.cursors[chainID]
+	if !ok {
+		return 0, repository.ErrBridgeCursorNotFound
+	}
+	return next, nil
+}
+
+// SetBridgeCursor records the first block of chainID not yet scanned
+func (r *MemoryBridgeRepo) SetBridgeCursor(ctx context.Context, chainID int64, nextBlock uint64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.cursors[chainID] = nextBlock
+	return nil
+}
@@ -0,0 +1,246 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure MemoryChainRepo implements ChainRepository
+var _ repository.ChainRepository = (*MemoryChainRepo)(nil)
+
+// blockKey identifies a recorded block
+type blockKey struct {
+	chainID int64
+	number  uint64
+}
+
+// MemoryChainRepo implements ChainRepository in memory
+type MemoryChainRepo struct {
+	mu     sync.RWMutex
+	blocks map[blockKey]*repository.BlockRecord
+	txs    map[string]*repository.TrackedTx // by id
+}
+
+// NewMemoryChainRepo creates a new in-memory chain tracking repository
+func NewMemoryChainRepo() *MemoryChainRepo {
+	return &MemoryChainRepo{
+		blocks: make(map[blockKey]*repository.BlockRecord),
+		txs:    make(map[string]*repository.TrackedTx),
+	}
+}
+
+// ============================================================================
+// Block Tracking Methods
+// ============================================================================
+
+// SaveBlock records (or replaces) the canonical block hash at a height
+func (r *MemoryChainRepo) SaveBlock(ctx context.Context, block *repository.BlockRecord) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	block.CreatedAt = now()
+	cp := *block
+	r.blocks[blockKey{block.ChainID, block.Number}] = &cp
+
+	return nil
+}
+
+// GetBlock retrieves the recorded block at a height
+func (r *MemoryChainRepo) GetBlock(ctx context.Context, chainID int64, number uint64) (*repository.BlockRecord, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	b, ok := r.blocks[blockKey{chainID, number}]
+	if !ok {
+		return nil, repository.ErrBlockNotFound
+	}
+	cp := *b
+	return &cp, nil
+}
+
+// GetLatestBlock retrieves the highest recorded block for a chain
+func (r *MemoryChainRepo) GetLatestBlock(ctx context.Context, chainID int64) (*repository.BlockRecord, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var latest *repository.BlockRecord
+	for k, b := range r.blocks {
+		if k.chainID == chainID && (latest == nil || b.Number > latest.Number) {
+			latest = b
+		}
+	}
+	if latest == nil {
+		return nil, repository.ErrBlockNotFound
+	}
+	cp := *latest
+	return &cp, nil
+}
+
+// DeleteBlocksAbove removes recorded blocks above a height (orphaned by a reorg)
+func (r *MemoryChainRepo) DeleteBlocksAbove(ctx context.Context, chainID int64, number uint64) (int64, error) {
+	return r.deleteBlocks(func(k blockKey) bool { return k.chainID == chainID && k.number > number }), nil
+}
+
+// PruneBlocksBelow removes recorded blocks below a height (beyond the reorg window)
+func (r *MemoryChainRepo) PruneBlocksBelow(ctx context.Context, chainID int64, number uint64) (int64, error) {
+	return r.deleteBlocks(func(k blockKey) bool { return k.chainID == chainID && k.number < number }), nil
+}
+
+// deleteBlocks removes blocks matching the predicate and returns the count
+func (r *MemoryChainRepo) deleteBlocks(match func(blockKey) bool) int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var deleted int64
+	for k := range r.blocks {
+		if match(k) {
+			delete(r.blocks, k)
+			deleted++
+		}
+	}
+	return deleted
+}
+
+// ============================================================================
+// Tracked Transaction Methods
+// ============================================================================
+
+// TrackTx starts tracking a transaction for a record. Re-tracking the same
+// record and hash resets it to pending.
+func (r *MemoryChainRepo) TrackTx(ctx context.Context, tx *repository.TrackedTx) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ts := now()
+	for _, existing := range r.txs {
+		if existing.ChainID == tx.ChainID && existing.TxHash == tx.TxHash &&
+			existing.RecordType == tx.RecordType && existing.RecordID == tx.RecordID {
+
+			existing.Status = repository.TrackedTxStatusPending
+			existing.BlockNumber = nil
+			existing.BlockHash = nil
+			existing.Confirmations = 0
+			existing.FinalizedAt = nil
+			existing.UpdatedAt = ts
+
+			tx.ID, tx.Status, tx.CreatedAt, tx.UpdatedAt = existing.ID, existing.Status, existing.CreatedAt, existing.UpdatedAt
+			return nil
+		}
+	}
+
+	tx.ID = newID()
+	tx.Status = repository.TrackedTxStatusPending
+	tx.CreatedAt = ts
+	tx.UpdatedAt = ts
+
+	r.txs[tx.ID] = &repository.TrackedTx{
+		ID:         tx.ID,
+		ChainID:    tx.ChainID,
+		TxHash:     tx.TxHash,
+		RecordType: tx.RecordType,
+		RecordID:   tx.RecordID,
+		Status:     tx.Status,
+		CreatedAt:  tx.CreatedAt,
+		UpdatedAt:  tx.UpdatedAt,
+	}
+
+	return nil
+}
+
+// ListUnfinalizedTxs lists tracked transactions that have not reached
+// confirmation depth, oldest first
+func (r *MemoryChainRepo) ListUnfinalizedTxs(ctx context.Context, chainID int64, limitN int) ([]*repository.TrackedTx, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []*repository.TrackedTx
+	for _, tx := range r.txs {
+		if tx.ChainID != chainID {
+			continue
+		}
+		if tx.Status != repository.TrackedTxStatusPending && tx.Status != repository.TrackedTxStatusIncluded {
+			continue
+		}
+		cp := *tx
+		result = append(result, &cp)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].CreatedAt.Before(result[j].CreatedAt)
+	})
+
+	return limit(result, limitN), nil
+}
+
+// UpdateTrackedTx updates the confirmation state of a tracked transaction
+func (r *MemoryChainRepo) UpdateTrackedTx(ctx context.Context, id string, update *repository.TrackedTxUpdate) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tx, ok := r.txs[id]
+	if !ok {
+		return repository.ErrTrackedTxNotFound
+	}
+
+	tx.Status = update.Status
+	tx.UpdatedAt = now()
+
+	if update.ClearBlock {
+		tx.BlockNumber = nil
+		tx.BlockHash = nil
+		tx.Confirmations = 0
+	} else {
+		if update.BlockNumber != nil {
+			n := *update.BlockNumber
+			tx.BlockNumber = &n
+		}
+		if update.BlockHash != nil {
+			h := *update.BlockHash
+			tx.BlockHash = &h
+		}
+		if update.Confirmations != nil {
+			tx.Confirmations = *update.Confirmations
+		}
+	}
+
+	switch update.Status {
+	case repository.TrackedTxStatusConfirmed, repository.TrackedTxStatusFailed:
+		finalizedAt := tx.UpdatedAt
+		tx.FinalizedAt = &finalizedAt
+	default:
+		tx.FinalizedAt = nil
+	}
+
+	return nil
+}
+
+// RollbackTxsAbove resets transactions included above a height back to pending
+// and returns the affected records
+func (r *MemoryChainRepo) RollbackTxsAbove(ctx context.Context, chainID int64, number uint64) ([]*repository.TrackedTx, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ts := now()
+	var result []*repository.TrackedTx
+	for _, tx := range r.txs {
+		if tx.ChainID != chainID || tx.BlockNumber == nil || *tx.BlockNumber <= number {
+			continue
+		}
+
+		tx.Status = repository.TrackedTxStatusPending
+		tx.BlockNumber = nil
+		tx.BlockHash = nil
+		tx.Confirmations = 0
+		tx.ReorgCount++
+		tx.FinalizedAt = nil
+		tx.UpdatedAt = ts
+
+		cp := *tx
+		result = append(result, &cp)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,471 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure MemoryContractRepo implements ContractRepository
+var _ repository.ContractRepository = (*MemoryContractRepo)(nil)
+
+// MemoryContractRepo implements ContractRepository in memory
+type MemoryContractRepo struct {
+	mu        sync.RWMutex
+	networks  map[int64]*repository.NetworkConfig
+	mappings  map[string]*repository.ContractMapping // by id
+	contracts map[string]*repository.ContractAddress // by id
+	history   []*repository.ContractAddressHistory
+	abis      map[string]*repository.ABIArtifact // by id
+}
+
+// NewMemoryContractRepo creates a new in-memory contract repository
+func NewMemoryContractRepo() *MemoryContractRepo {
+	return &MemoryContractRepo{
+		networks:  make(map[int64]*repository.NetworkConfig),
+		mappings:  make(map[string]*repository.ContractMapping),
+		contracts: make(map[string]*repository.ContractAddress),
+		abis:      make(map[string]*repository.ABIArtifact),
+	}
+}
+
+// AddNetwork inserts or replaces a network configuration (seeding)
+func (r *MemoryContractRepo) AddNetwork(n *repository.NetworkConfig) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cp := *n
+	if cp.ID == "" {
+		cp.ID = newID()
+	}
+	if cp.CreatedAt.IsZero() {
+		cp.CreatedAt = now()
+		cp.UpdatedAt = cp.CreatedAt
+	}
+	r.networks[cp.ChainID] = &cp
+}
+
+// AddMapping inserts a contract name mapping (seeding) and returns its ID
+func (r *MemoryContractRepo) AddMapping(m *repository.ContractMapping) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cp := *m
+	if cp.ID == "" {
+		cp.ID = newID()
+	}
+	if cp.CreatedAt.IsZero() {
+		cp.CreatedAt = now()
+	}
+	r.mappings[cp.ID] = &cp
+	return cp.ID
+}
+
+// ============================================================================
+// Network Methods
+// ============================================================================
+
+// GetNetworkByChainID retrieves network config by chain ID
+func (r *MemoryContractRepo) GetNetworkByChainID(ctx context.Context, chainID int64) (*repository.NetworkConfig, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	n, ok := r.networks[chainID]
+	if !ok {
+		return nil, repository.ErrNetworkNotFound
+	}
+	return copyNetwork(n), nil
+}
+
+// GetNetworkByName retrieves network config by network name
+func (r *MemoryContractRepo) GetNetworkByName(ctx context.Context, name string) (*repository.NetworkConfig, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, n := range r.networks {
+		if n.NetworkName == name {
+			return copyNetwork(n), nil
+		}
+	}
+	return nil, repository.ErrNetworkNotFound
+}
+
+// GetActiveNetworks retrieves all active networks ordered by chain ID
+func (r *MemoryContractRepo) GetActiveNetworks(ctx context.Context) ([]*repository.NetworkConfig, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []*repository.NetworkConfig
+	for _, n := range r.networks {
+		if n.IsActive {
+			result = append(result, copyNetwork(n))
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ChainID < result[j].ChainID
+	})
+
+	return result, nil
+}
+
+// copyNetwork returns a copy that does not share the fallback URL slice
+func copyNetwork(n *repository.NetworkConfig) *repository.NetworkConfig {
+	cp := *n
+	cp.RPCFallbackUrls = append([]string{}, n.RPCFallbackUrls...)
+	return &cp
+}
+
+// ============================================================================
+// Mapping Methods
+// ============================================================================
+
+// GetAllMappings retrieves all contract name mappings in deploy order
+func (r *MemoryContractRepo) GetAllMappings(ctx context.Context) ([]*repository.ContractMapping, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]*repository.ContractMapping, 0, len(r.mappings))
+	for _, m := range r.mappings {
+		cp := *m
+		result = append(result, &cp)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].SortOrder != result[j].SortOrder {
+			return result[i].SortOrder < result[j].SortOrder
+		}
+		return result[i].SolidityName < result[j].SolidityName
+	})
+
+	return result, nil
+}
+
+// GetMappingBySolidityName retrieves a mapping by Solidity contract name
+func (r *MemoryContractRepo) GetMappingBySolidityName(ctx context.Context, name string) (*repository.ContractMapping, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	m := r.mappingBy(func(m *repository.ContractMapping) bool { return m.SolidityName == name })
+	if m == nil {
+		return nil, repository.ErrContractMappingNotFound
+	}
+	cp := *m
+	return &cp, nil
+}
+
+// GetMappingByDBName retrieves a mapping by database name
+func (r *MemoryContractRepo) GetMappingByDBName(ctx context.Context, dbName string) (*repository.ContractMapping, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	m := r.mappingBy(func(m *repository.ContractMapping) bool { return m.DBName == dbName })
+	if m == nil {
+		return nil, repository.ErrContractMappingNotFound
+	}
+	cp := *m
+	return &cp, nil
+}
+
+// mappingBy finds a mapping matching the predicate (caller holds the lock)
+func (r *MemoryContractRepo) mappingBy(match func(*repository.ContractMapping) bool) *repository.ContractMapping {
+	for _, m := range r.mappings {
+		if match(m) {
+			return m
+		}
+	}
+	return nil
+}
+
+// ============================================================================
+// Contract Address Methods
+// ============================================================================
+
+// GetByChainID retrieves all active primary contracts for a chain
+func (r *MemoryContractRepo) GetByChainID(ctx context.Context, chainID int64) ([]*repository.ContractAddress, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []*repository.ContractAddress
+	for _, c := range r.contracts {
+		if c.ChainID == chainID && c.Status == "active" && c.IsPrimary {
+			result = append(result, r.joinMapping(c))
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		mi, mj := r.mappings[result[i].ContractMappingID], r.mappings[result[j].ContractMappingID]
+		if mi.SortOrder != mj.SortOrder {
+			return mi.SortOrder < mj.SortOrder
+		}
+		return mi.SolidityName < mj.SolidityName
+	})
+
+	return result, nil
+}
+
+// GetByChainAndDBName retrieves the active primary contract by chain and db name
+func (r *MemoryContractRepo) GetByChainAndDBName(ctx context.Context, chainID int64, dbName string) (*repository.ContractAddress, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, c := range r.contracts {
+		if c.ChainID != chainID || c.Status != "active" || !c.IsPrimary {
+			continue
+		}
+		if m := r.mappings[c.ContractMappingID]; m != nil && m.DBName == dbName {
+			return r.joinMapping(c), nil
+		}
+	}
+	return nil, repository.ErrContractAddressNotFound
+}
+
+// GetByID retrieves a contract address by ID
+func (r *MemoryContractRepo) GetByID(ctx context.Context, id string) (*repository.ContractAddress, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	c, ok := r.contracts[id]
+	if !ok {
+		return nil, repository.ErrContractAddressNotFound
+	}
+	return r.joinMapping(c), nil
+}
+
+// joinMapping returns a copy with the mapping names filled in (caller holds the lock)
+func (r *MemoryContractRepo) joinMapping(c *repository.ContractAddress) *repository.ContractAddress {
+	cp := *c
+	if m := r.mappings[c.ContractMappingID]; m != nil {
+		cp.DBName = m.DBName
+		cp.SolidityName = m.SolidityName
+	}
+	return &cp
+}
+
+// Upsert creates or updates the primary contract for a chain and mapping,
+// logging address changes in history
+func (r *MemoryContractRepo) Upsert(ctx context.Context, contract *repository.ContractAddressUpsert) (*repository.ContractAddress, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	network, ok := r.networks[contract.ChainID]
+	if !ok {
+		return nil, repository.ErrNetworkNotFound
+	}
+	if _, ok := r.mappings[contract.ContractMappingID]; !ok {
+		return nil, repository.ErrContractMappingNotFound
+	}
+
+	// Get default deployer from network if not provided
+	deployedBy := contract.DeployedBy
+	if deployedBy == nil || *deployedBy == "" {
+		deployedBy = network.DefaultDeployer
+	}
+	changedBy := "unknown"
+	if deployedBy != nil {
+		changedBy = *deployedBy
+	}
+
+	abiVersion := "1.0.0"
+	if contract.ABIVersion != nil {
+		abiVersion = *contract.ABIVersion
+	}
+
+	var existing *repository.ContractAddress
+	for _, c := range r.contracts {
+		if c.ChainID == contract.ChainID && c.ContractMappingID == contract.ContractMappingID && c.IsPrimary {
+			existing = c
+			break
+		}
+	}
+
+	ts := now()
+	if existing == nil {
+		existing = &repository.ContractAddress{
+			ID:                newID(),
+			ChainID:           contract.ChainID,
+			ContractMappingID: contract.ContractMappingID,
+			Status:            "active",
+			IsPrimary:         true,
+			CreatedAt:         ts,
+		}
+		r.contracts[existing.ID] = existing
+		r.history = append(r.history, &repository.ContractAddressHistory{
+			ID:           newID(),
+			ContractID:   existing.ID,
+			NewAddress:   contract.Address,
+			ChangeReason: strPtr("Initial deployment"),
+			ChangedBy:    changedBy,
+			ChangedAt:    ts,
+		})
+	} else if existing.Address != contract.Address {
+		oldAddress := existing.Address
+		r.history = append(r.history, &repository.ContractAddressHistory{
+			ID:           newID(),
+			ContractID:   existing.ID,
+			OldAddress:   &oldAddress,
+			NewAddress:   contract.Address,
+			ChangeReason: strPtr("Contract redeployed"),
+			ChangedBy:    changedBy,
+			ChangedAt:    ts,
+		})
+	}
+
+	existing.Address = contract.Address
+	existing.DeploymentTxHash = contract.DeploymentTxHash
+	existing.DeploymentBlock = contract.DeploymentBlock
+	existing.ABIVersion = abiVersion
+	existing.DeployedBy = deployedBy
+	existing.Notes = contract.Notes
+	existing.EIP712Name = contract.EIP712Name
+	existing.EIP712Version = contract.EIP712Version
+	existing.UpdatedAt = ts
+
+	return r.joinMapping(existing), nil
+}
+
+// ============================================================================
+// History Methods
+// ============================================================================
+
+// GetHistory retrieves address change history for a contract, newest first
+func (r *MemoryContractRepo) GetHistory(ctx context.Context, contractID string, limitN int) ([]*repository.ContractAddressHistory, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []*repository.ContractAddressHistory
+	for i := len(r.history) - 1; i >= 0; i-- {
+		if r.history[i].ContractID == contractID {
+			cp := *r.history[i]
+			result = append(result, &cp)
+		}
+	}
+
+	return limit(result, limitN), nil
+}
+
+// GetDeploymentConfig returns the network, mappings and deployed contracts for a chain
+func (r *MemoryContractRepo) GetDeploymentConfig(ctx context.Context, chainID int64) (*repository.DeploymentConfig, error) {
+	network, err := r.GetNetworkByChainID(ctx, chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	mappings, err := r.GetAllMappings(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	contracts, err := r.GetByChainID(ctx, chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &repository.DeploymentConfig{
+		Network:   network,
+		Mappings:  mappings,
+		Contracts: contracts,
+	}, nil
+}
+
+// ============================================================================
+// ABI Artifact Methods
+// ============================================================================
+
+// UpsertABI publishes an ABI for a contract mapping and version. An existing
+// version is returned unchanged when its ABI hash matches, and refused with
+// repository.ErrABIVersionConflict otherwise.
+func (r *MemoryContractRepo) UpsertABI(ctx context.Context, artifact *repository.ABIArtifactUpsert) (*repository.ABIArtifact, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	mapping := r.mappingBy(func(m *repository.ContractMapping) bool { return m.DBName == artifact.DBName })
+	if mapping == nil {
+		return nil, repository.ErrContractMappingNotFound
+	}
+
+	var stored *repository.ABIArtifact
+	for _, a := range r.abis {
+		if a.ContractMappingID == mapping.ID && a.Version == artifact.Version {
+			stored = a
+			break
+		}
+	}
+	if stored != nil && stored.ABIHash != artifact.ABIHash {
+		return nil, repository.ErrABIVersionConflict
+	}
+	if stored == nil {
+		ts := now()
+		stored = &repository.ABIArtifact{
+			ID:                newID(),
+			ContractMappingID: mapping.ID,
+			Version:           artifact.Version,
+			ABI:               append([]byte{}, artifact.ABI...),
+			ABIHash:           artifact.ABIHash,
+			CompilerVersion:   artifact.CompilerVersion,
+			UploadedBy:        artifact.UploadedBy,
+			CreatedAt:         ts,
+			UpdatedAt:         ts,
+		}
+		r.abis[stored.ID] = stored
+	}
+
+	cp := *stored
+	cp.DBName = mapping.DBName
+	cp.SolidityName = mapping.SolidityName
+	return &cp, nil
+}
+
+// GetABI retrieves the ABI for a contract by db_name and version.
+// Passing repository.ABIVersionLatest returns the most recent upload.
+func (r *MemoryContractRepo) GetABI(ctx context.Context, dbName, version string) (*repository.ABIArtifact, error) {
+	versions := r.abiVersions(dbName)
+	for _, a := range versions {
+		if version == repository.ABIVersionLatest || a.Version == version {
+			return a, nil
+		}
+	}
+	return nil, repository.ErrABIArtifactNotFound
+}
+
+// ListABIVersions lists stored ABI versions for a contract, newest first.
+// The ABI body is omitted.
+func (r *MemoryContractRepo) ListABIVersions(ctx context.Context, dbName string) ([]*repository.ABIArtifact, error) {
+	versions := r.abiVersions(dbName)
+	for _, a := range versions {
+		a.ABI = nil
+	}
+	return versions, nil
+}
+
+// abiVersions returns copies of a contract's ABI artifacts, newest first
+func (r *MemoryContractRepo) abiVersions(dbName string) []*repository.ABIArtifact {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	mapping := r.mappingBy(func(m *repository.ContractMapping) bool { return m.DBName == dbName })
+	if mapping == nil {
+		return nil
+	}
+
+	var result []*repository.ABIArtifact
+	for _, a := range r.abis {
+		if a.ContractMappingID == mapping.ID {
+			cp := *a
+			cp.DBName = mapping.DBName
+			cp.SolidityName = mapping.SolidityName
+			result = append(result, &cp)
+		}
+	}
+
+	sortNewestFirst(result, func(a *repository.ABIArtifact) time.Time { return a.CreatedAt })
+	return result
+}
+
+// strPtr returns a pointer to s
+func strPtr(s string) *string {
+	return &s
+}
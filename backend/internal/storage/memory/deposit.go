@@ -0,0 +1,121 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure MemoryDepositRepo implements DepositRepository
+var _ repository.DepositRepository = (*MemoryDepositRepo)(nil)
+
+// MemoryDepositRepo implements DepositRepository in memory
+type MemoryDepositRepo struct {
+	mu        sync.RWMutex
+	nextIndex int64
+	intents   map[string]*repository.DepositIntent
+}
+
+// NewMemoryDepositRepo creates a new in-memory deposit repository
+func NewMemoryDepositRepo() *MemoryDepositRepo {
+	return &MemoryDepositRepo{intents: make(map[string]*repository.DepositIntent)}
+}
+
+// NextDepositIndex reserves the next unused derivation index
+func (r *MemoryDepositRepo) NextDepositIndex(ctx context.Context) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	index := r.nextIndex
+	r.nextIndex++
+	return index, nil
+}
+
+// CreateDepositIntent records a new intent, setting ID and timestamps
+func (r *MemoryDepositRepo) CreateDepositIntent(ctx context.Context, d *repository.DepositIntent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ts := now()
+	d.ID = newID()
+	d.CreatedAt = ts
+	d.UpdatedAt = ts
+	if d.ReceivedAmount == "" {
+		d.ReceivedAmount = "0"
+	}
+	cp := *d
+	r.intents[d.ID] = &cp
+	return nil
+}
+
+// GetDepositIntent retrieves an intent by ID
+func (r *MemoryDepositRepo) GetDepositIntent(ctx context.Context, id string) (*repository.DepositIntent, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	d, ok := r.intents[id]
+	if !ok {
+		return nil, repository.ErrDepositIntentNotFound
+	}
+	cp := *d
+	return &cp, nil
+}
+
+// ListDepositIntents lists intents with filtering, newest first
+func (r *MemoryDepositRepo) ListDepositIntents(ctx context.Context, filter repository.DepositIntentFilter, page repository.Pagination) ([]*repository.DepositIntent, int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var matched []*repository.DepositIntent
+	for _, d := range r.intents {
+		if filter.PayerAddress != "" && d.PayerAddress != filter.PayerAddress {
+			continue
+		}
+		if filter.Status != "" && d.Status != filter.Status {
+			continue
+		}
+		if filter.ExcludesExpired() && d.Status == repository.DepositExpired {
+			continue
+		}
+		cp := *d
+		matched = append(matched, &cp)
+	}
+
+	sortNewestFirst(matched, func(d *repository.DepositIntent) time.Time { return d.CreatedAt })
+
+	return paginate(matched, page, 20), int64(len(matched)), nil
+}
+
+// UpdateDepositIntent changes an intent's status
+func (r *MemoryDepositRepo) UpdateDepositIntent(ctx context.Context, id string, update *repository.DepositIntentUpdate) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	d, ok := r.intents[id]
+	if !ok {
+		return repository.ErrDepositIntentNotFound
+	}
+
+	ts := now()
+	d.Status = update.Status
+	d.UpdatedAt = ts
+	if update.ReceivedAmount != nil {
+		d.ReceivedAmount = *update.ReceivedAmount
+	}
+	if update.SweepTxHash != nil {
+		v := *update.SweepTxHash
+		d.SweepTxHash = &v
+		d.SweepAttemptedAt = &ts
+	}
+	switch {
+	case update.Status == repository.DepositConfirming && d.DetectedAt == nil:
+		d.DetectedAt = &ts
+	case update.Status == repository.DepositPaid && d.PaidAt == nil:
+		d.PaidAt = &ts
+	case update.Status == repository.DepositSwept && d.SweptAt == nil:
+		d.SweptAt = &ts
+	}
+	return nil
+}
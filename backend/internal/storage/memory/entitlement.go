@@ -0,0 +1,100 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure MemoryEntitlementRepo implements EntitlementRepository
+var _ repository.EntitlementRepository = (*MemoryEntitlementRepo)(nil)
+
+// MemoryEntitlementRepo implements EntitlementRepository in memory
+type MemoryEntitlementRepo struct {
+	mu           sync.Mutex
+	grants       map[string]*repository.EntitlementGrant // by payment ID
+	entitlements map[string]*repository.Entitlement      // by address and service code
+}
+
+// NewMemoryEntitlementRepo creates a new in-memory entitlement repository
+func NewMemoryEntitlementRepo() *MemoryEntitlementRepo {
+	return &MemoryEntitlementRepo{
+		grants:       make(map[string]*repository.EntitlementGrant),
+		entitlements: make(map[string]*repository.Entitlement),
+	}
+}
+
+func entitlementKey(address, serviceCode string) string {
+	return address + "|" + serviceCode
+}
+
+// GrantCredits adds a payment's credits once
+func (r *MemoryEntitlementRepo) GrantCredits(ctx context.Context, g *repository.EntitlementGrant) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.grants[g.PaymentID]; exists {
+		return false, nil
+	}
+	g.ID = newID()
+	g.CreatedAt = now()
+	cp := *g
+	r.grants[g.PaymentID] = &cp
+
+	key := entitlementKey(g.Address, g.ServiceCode)
+	e, ok := r.entitlements[key]
+	if !ok {
+		e = &repository.Entitlement{Address: g.Address, ServiceCode: g.ServiceCode}
+		r.entitlements[key] = e
+	}
+	e.Granted += g.Quantity
+	e.Balance += g.Quantity
+	e.UpdatedAt = g.CreatedAt
+	return true, nil
+}
+
+// ConsumeCredits takes credits if the balance covers them
+func (r *MemoryEntitlementRepo) ConsumeCredits(ctx context.Context, address, serviceCode string, quantity int) (*repository.Entitlement, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	e, ok := r.entitlements[entitlementKey(address, serviceCode)]
+	if !ok || e.Balance < quantity {
+		return nil, repository.ErrInsufficientCredits
+	}
+	e.Balance -= quantity
+	e.UpdatedAt = now()
+	cp := *e
+	return &cp, nil
+}
+
+// GetEntitlement retrieves an address's credits for a service
+func (r *MemoryEntitlementRepo) GetEntitlement(ctx context.Context, address, serviceCode string) (*repository.Entitlement, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	e, ok := r.entitlements[entitlementKey(address, serviceCode)]
+	if !ok {
+		return nil, repository.ErrEntitlementNotFound
+	}
+	cp := *e
+	return &cp, nil
+}
+
+// ListEntitlements lists an address's credits by service code
+func (r *MemoryEntitlementRepo) ListEntitlements(ctx context.Context, address string) ([]*repository.Entitlement, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var result []*repository.Entitlement
+	for _, e := range r.entitlements {
+		if e.Address == address {
+			cp := *e
+			result = append(result, &cp)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ServiceCode < result[j].ServiceCode })
+	return result, nil
+}
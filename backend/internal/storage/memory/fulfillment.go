@@ -0,0 +1,84 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure MemoryFulfillmentRepo implements FulfillmentRepository
+var _ repository.FulfillmentRepository = (*MemoryFulfillmentRepo)(nil)
+
+// MemoryFulfillmentRepo implements FulfillmentRepository in memory
+type MemoryFulfillmentRepo struct {
+	mu        sync.RWMutex
+	byPayment map[string]*repository.Fulfillment
+}
+
+// NewMemoryFulfillmentRepo creates a new in-memory fulfillment repository
+func NewMemoryFulfillmentRepo() *MemoryFulfillmentRepo {
+	return &MemoryFulfillmentRepo{byPayment: make(map[string]*repository.Fulfillment)}
+}
+
+// RecordFulfillment stores a payment's fulfillment once
+func (r *MemoryFulfillmentRepo) RecordFulfillment(ctx context.Context, f *repository.Fulfillment) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.byPayment[f.PaymentID]; exists {
+		return false, nil
+	}
+	f.ID = newID()
+	f.CreatedAt = now()
+	cp := *f
+	r.byPayment[f.PaymentID] = &cp
+	return true, nil
+}
+
+// GetFulfillmentByPayment retrieves a payment's fulfillment
+func (r *MemoryFulfillmentRepo) GetFulfillmentByPayment(ctx context.Context, paymentID string) (*repository.Fulfillment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	f, ok := r.byPayment[paymentID]
+	if !ok {
+		return nil, repository.ErrFulfillmentNotFound
+	}
+	cp := *f
+	return &cp, nil
+}
+
+// ListFulfillments lists fulfillments with filtering, newest first
+func (r *MemoryFulfillmentRepo) ListFulfillments(ctx context.Context, filter repository.FulfillmentFilter, page repository.Pagination) ([]*repository.Fulfillment, int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var matched []*repository.Fulfillment
+	for _, f := range r.byPayment {
+		if filter.Address != "" && f.Address != filter.Address {
+			continue
+		}
+		if filter.ServiceCode != "" && f.ServiceCode != filter.ServiceCode {
+			continue
+		}
+		if filter.Kind != "" && f.Kind != filter.Kind {
+			continue
+		}
+		if filter.ActiveAt != nil && !fulfillmentActiveAt(f, *filter.ActiveAt) {
+			continue
+		}
+		cp := *f
+		matched = append(matched, &cp)
+	}
+
+	sortNewestFirst(matched, func(f *repository.Fulfillment) time.Time { return f.CreatedAt })
+
+	return paginate(matched, page, 20), int64(len(matched)), nil
+}
+
+// fulfillmentActiveAt reports whether f has no validity period or is valid at t
+func fulfillmentActiveAt(f *repository.Fulfillment, t time.Time) bool {
+	return (f.ValidFrom == nil || !t.Before(*f.ValidFrom)) && (f.ValidUntil == nil || t.Before(*f.ValidUntil))
+}
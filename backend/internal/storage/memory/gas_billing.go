@@ -0,0 +1,190 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure MemoryGasBillingRepo implements GasBillingRepository
+var _ repository.GasBillingRepository = (*MemoryGasBillingRepo)(nil)
+
+// MemoryGasBillingRepo implements GasBillingRepository in memory
+type MemoryGasBillingRepo struct {
+	mu          sync.RWMutex
+	charges     map[string]*repository.GasCharge // meta_tx_id -> charge
+	settlements map[string]*repository.GasSettlement
+}
+
+// NewMemoryGasBillingRepo creates a new in-memory gas billing repository
+func NewMemoryGasBillingRepo() *MemoryGasBillingRepo {
+	return &MemoryGasBillingRepo{
+		charges:     make(map[string]*repository.GasCharge),
+		settlements: make(map[string]*repository.GasSettlement),
+	}
+}
+
+// CreateGasCharge records a meta-transaction's charge once
+func (r *MemoryGasBillingRepo) CreateGasCharge(ctx context.Context, c *repository.GasCharge) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.charges[c.MetaTxID]; ok {
+		return false, nil
+	}
+
+	c.ID = newID()
+	c.CreatedAt = now()
+	cp := *c
+	r.charges[c.MetaTxID] = &cp
+	return true, nil
+}
+
+// ListGasCharges lists a user's charges, newest first
+func (r *MemoryGasBillingRepo) ListGasCharges(ctx context.Context, userAddress string, page repository.Pagination) ([]*repository.GasCharge, int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var matched []*repository.GasCharge
+	for _, c := range r.charges {
+		if c.UserAddress != userAddress {
+			continue
+		}
+		cp := *c
+		matched = append(matched, &cp)
+	}
+
+	sortNewestFirst(matched, func(c *repository.GasCharge) time.Time { return c.CreatedAt })
+
+	return paginate(matched, page, 20), int64(len(matched)), nil
+}
+
+// CreateGasSettlement records a settlement, refusing a tx hash used before
+func (r *MemoryGasBillingRepo) CreateGasSettlement(ctx context.Context, s *repository.GasSettlement) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if s.TxHash != nil {
+		for _, existing := range r.settlements {
+			if existing.TxHash != nil && *existing.TxHash == *s.TxHash {
+				return repository.ErrGasSettlementExists
+			}
+		}
+	}
+
+	ts := now()
+	s.ID = newID()
+	s.CreatedAt = ts
+	s.UpdatedAt = ts
+	cp := *s
+	r.settlements[s.ID] = &cp
+	return nil
+}
+
+// GetGasSettlement retrieves a settlement by ID
+func (r *MemoryGasBillingRepo) GetGasSettlement(ctx context.Context, id string) (*repository.GasSettlement, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	s, ok := r.settlements[id]
+	if !ok {
+		return nil, repository.ErrGasSettlementNotFound
+	}
+	cp := *s
+	return &cp, nil
+}
+
+// ListGasSettlements lists settlements with filtering, newest first
+func (r *MemoryGasBillingRepo) ListGasSettlements(ctx context.Context, filter repository.GasSettlementFilter, page repository.Pagination) ([]*repository.GasSettlement, int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var matched []*repository.GasSettlement
+	for _, s := range r.settlements {
+		if filter.UserAddress != "" && s.UserAddress != filter.UserAddress {
+			continue
+		}
+		if filter.Method != "" && s.Method != filter.Method {
+			continue
+		}
+		if filter.Status != "" && s.Status != filter.Status {
+			continue
+		}
+		cp := *s
+		matched = append(matched, &cp)
+	}
+
+	sortNewestFirst(matched, func(s *repository.GasSettlement) time.Time { return s.CreatedAt })
+
+	return paginate(matched, page, 20), int64(len(matched)), nil
+}
+
+// UpdateGasSettlementStatus applies an allowed status change
+func (r *MemoryGasBillingRepo) UpdateGasSettlementStatus(ctx context.Context, id string, update *repository.GasSettlementUpdate) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s, ok := r.settlements[id]
+	if !ok {
+		return repository.ErrGasSettlementNotFound
+	}
+	if !s.CanMoveTo(update.Status) {
+		return repository.ErrGasSettlementClosed
+	}
+
+	ts := now()
+	s.Status = update.Status
+	s.ErrorMessage = update.ErrorMessage
+	s.UpdatedAt = ts
+	s.SettledAt = nil
+	if update.Status == repository.GasSettlementSettled {
+		s.SettledAt = &ts
+	}
+	return nil
+}
+
+// GetGasDebt totals a user's charges and settlements
+func (r *MemoryGasBillingRepo) GetGasDebt(ctx context.Context, userAddress string) (*repository.GasDebt, error) {
+	tally, err := r.tally(userAddress)
+	if err != nil {
+		return nil, err
+	}
+	return tally.Debt(userAddress), nil
+}
+
+// ListGasDebts lists users with outstanding debt, largest first
+func (r *MemoryGasBillingRepo) ListGasDebts(ctx context.Context, page repository.Pagination) ([]*repository.GasDebt, int64, error) {
+	tally, err := r.tally("")
+	if err != nil {
+		return nil, 0, err
+	}
+	debts := tally.Outstanding()
+	return paginate(debts, page, 50), int64(len(debts)), nil
+}
+
+// tally totals the charges and settlements of one user ("" for all)
+func (r *MemoryGasBillingRepo) tally(userAddress string) (*repository.GasDebtTally, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	tally := repository.NewGasDebtTally()
+	for _, c := range r.charges {
+		if userAddress != "" && c.UserAddress != userAddress {
+			continue
+		}
+		if err := tally.AddCharge(c.UserAddress, c.AmountNexus, c.CreatedAt); err != nil {
+			return nil, err
+		}
+	}
+	for _, s := range r.settlements {
+		if userAddress != "" && s.UserAddress != userAddress {
+			continue
+		}
+		if err := tally.AddSettlement(s.UserAddress, s.AmountNexus, s.Status); err != nil {
+			return nil, err
+		}
+	}
+	return tally, nil
+}
@@ -0,0 +1,195 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure MemoryGovernanceConfigRepo implements GovernanceConfigRepository
+var _ repository.GovernanceConfigRepository = (*MemoryGovernanceConfigRepo)(nil)
+
+// governanceConfigKey identifies a governance config row
+type governanceConfigKey struct {
+	key     string
+	chainID int64
+}
+
+// MemoryGovernanceConfigRepo implements GovernanceConfigRepository in memory
+type MemoryGovernanceConfigRepo struct {
+	mu      sync.RWMutex
+	configs map[governanceConfigKey]*repository.GovernanceConfig
+	history []*repository.GovernanceConfigHistoryEntry
+}
+
+// NewMemoryGovernanceConfigRepo creates a new in-memory governance config repository
+func NewMemoryGovernanceConfigRepo() *MemoryGovernanceConfigRepo {
+	return &MemoryGovernanceConfigRepo{
+		configs: make(map[governanceConfigKey]*repository.GovernanceConfig),
+	}
+}
+
+// AddConfig inserts or replaces a governance config (seeding)
+func (r *MemoryGovernanceConfigRepo) AddConfig(c *repository.GovernanceConfig) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cp := copyGovernanceConfig(c)
+	if cp.ID == "" {
+		cp.ID = newID()
+	}
+	if cp.CreatedAt.IsZero() {
+		cp.CreatedAt = now()
+		cp.UpdatedAt = cp.CreatedAt
+	}
+	r.configs[governanceConfigKey{cp.ConfigKey, cp.ChainID}] = cp
+}
+
+// GetConfig retrieves a governance config by key and chain ID
+func (r *MemoryGovernanceConfigRepo) GetConfig(ctx context.Context, configKey string, chainID int64) (*repository.GovernanceConfig, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	c, ok := r.configs[governanceConfigKey{configKey, chainID}]
+	if !ok {
+		return nil, repository.ErrGovernanceConfigNotFound
+	}
+	return copyGovernanceConfig(c), nil
+}
+
+// ListConfigs lists governance configs for a chain ordered by key
+func (r *MemoryGovernanceConfigRepo) ListConfigs(ctx context.Context, chainID int64, activeOnly bool) ([]*repository.GovernanceConfig, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []*repository.GovernanceConfig
+	for k, c := range r.configs {
+		if k.chainID != chainID || (activeOnly && !c.IsActive) {
+			continue
+		}
+		result = append(result, copyGovernanceConfig(c))
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ConfigKey < result[j].ConfigKey
+	})
+
+	return result, nil
+}
+
+// UpdateConfig updates a governance config. Value changes are recorded in
+// history and reset the contract sync flag.
+func (r *MemoryGovernanceConfigRepo) UpdateConfig(ctx context.Context, configKey string, chainID int64, update *repository.GovernanceConfigUpdate) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	c, ok := r.configs[governanceConfigKey{configKey, chainID}]
+	if !ok {
+		return repository.ErrGovernanceConfigNotFound
+	}
+
+	old := copyGovernanceConfig(c)
+	c.UpdatedBy = strPtr(update.UpdatedBy)
+	if update.ValueWei != nil {
+		c.ValueWei = copyWei(update.ValueWei)
+	}
+	if update.ValueNumber != nil {
+		v := *update.ValueNumber
+		c.ValueNumber = &v
+	}
+	if update.ValuePercent != nil {
+		v := *update.ValuePercent
+		c.ValuePercent = &v
+	}
+	if update.ValueString != nil {
+		v := *update.ValueString
+		c.ValueString = &v
+	}
+	if update.IsActive != nil {
+		c.IsActive = *update.IsActive
+	}
+	c.UpdatedAt = now()
+
+	// Mirrors the log_governance_config_change trigger
+	if distinctWei(old.ValueWei, c.ValueWei) ||
+		distinct(old.ValueNumber, c.ValueNumber) ||
+		distinct(old.ValuePercent, c.ValuePercent) ||
+		distinct(old.ValueString, c.ValueString) {
+
+		changedBy := update.UpdatedBy
+		if changedBy == "" {
+			changedBy = "system"
+		}
+		wasSynced := old.ContractSynced
+		r.history = append(r.history, &repository.GovernanceConfigHistoryEntry{
+			ID:                 newID(),
+			GovernanceConfigID: c.ID,
+			OldValueWei:        old.ValueWei,
+			OldValueNumber:     old.ValueNumber,
+			OldValuePercent:    old.ValuePercent,
+			OldValueString:     old.ValueString,
+			NewValueWei:        copyWei(c.ValueWei),
+			NewValueNumber:     c.ValueNumber,
+			NewValuePercent:    c.ValuePercent,
+			NewValueString:     c.ValueString,
+			WasSynced:          &wasSynced,
+			SyncTx:             old.LastSyncTx,
+			ChangedBy:          changedBy,
+			ChangedAt:          c.UpdatedAt,
+			ChangeReason:       strPtr("Governance config update"),
+		})
+
+		c.ContractSynced = false
+	}
+
+	return nil
+}
+
+// MarkSynced marks a governance config as synced with the smart contract
+func (r *MemoryGovernanceConfigRepo) MarkSynced(ctx context.Context, configKey string, chainID int64, txHash string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	c, ok := r.configs[governanceConfigKey{configKey, chainID}]
+	if !ok {
+		return repository.ErrGovernanceConfigNotFound
+	}
+
+	syncedAt := time.Now()
+	c.ContractSynced = true
+	c.LastSyncTx = strPtr(txHash)
+	c.LastSyncAt = &syncedAt
+
+	return nil
+}
+
+// GetConfigHistory retrieves config change history, newest first
+func (r *MemoryGovernanceConfigRepo) GetConfigHistory(ctx context.Context, configKey string, chainID int64, limitN int) ([]*repository.GovernanceConfigHistoryEntry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	c, ok := r.configs[governanceConfigKey{configKey, chainID}]
+	if !ok {
+		return nil, nil
+	}
+
+	var result []*repository.GovernanceConfigHistoryEntry
+	for i := len(r.history) - 1; i >= 0; i-- {
+		if r.history[i].GovernanceConfigID == c.ID {
+			cp := *r.history[i]
+			result = append(result, &cp)
+		}
+	}
+
+	return limit(result, limitN), nil
+}
+
+// copyGovernanceConfig returns a copy that does not share the wei value
+func copyGovernanceConfig(c *repository.GovernanceConfig) *repository.GovernanceConfig {
+	cp := *c
+	cp.ValueWei = copyWei(c.ValueWei)
+	return &cp
+}
@@ -0,0 +1,142 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure MemoryGovernanceNotificationRepo implements GovernanceNotificationRepository
+var _ repository.GovernanceNotificationRepository = (*MemoryGovernanceNotificationRepo)(nil)
+
+// MemoryGovernanceNotificationRepo implements GovernanceNotificationRepository in memory
+type MemoryGovernanceNotificationRepo struct {
+	mu            sync.RWMutex
+	subscriptions map[string]*repository.GovernanceSubscription
+	events        []*repository.GovernanceEvent // in recording order
+}
+
+// NewMemoryGovernanceNotificationRepo creates a new in-memory governance notification repository
+func NewMemoryGovernanceNotificationRepo() *MemoryGovernanceNotificationRepo {
+	return &MemoryGovernanceNotificationRepo{subscriptions: make(map[string]*repository.GovernanceSubscription)}
+}
+
+// UpsertGovernanceSubscription creates or replaces a subscription, keeping its digest schedule
+func (r *MemoryGovernanceNotificationRepo) UpsertGovernanceSubscription(ctx context.Context, s *repository.GovernanceSubscription) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s.UpdatedAt = now()
+	s.CreatedAt = s.UpdatedAt
+	s.LastDigestAt = nil
+	if existing, ok := r.subscriptions[s.Address]; ok {
+		s.CreatedAt = existing.CreatedAt
+		s.LastDigestAt = existing.LastDigestAt
+	}
+	r.subscriptions[s.Address] = copyGovernanceSubscription(s)
+	return nil
+}
+
+// GetGovernanceSubscription retrieves the subscription of address
+func (r *MemoryGovernanceNotificationRepo) GetGovernanceSubscription(ctx context.Context, address string) (*repository.GovernanceSubscription, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	s, ok := r.subscriptions[address]
+	if !ok {
+		return nil, repository.ErrGovernanceSubscriptionNotFound
+	}
+	return copyGovernanceSubscription(s), nil
+}
+
+// DeleteGovernanceSubscription removes the subscription of address
+func (r *MemoryGovernanceNotificationRepo) DeleteGovernanceSubscription(ctx context.Context, address string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.subscriptions[address]; !ok {
+		return repository.ErrGovernanceSubscriptionNotFound
+	}
+	delete(r.subscriptions, address)
+	return nil
+}
+
+// ListGovernanceSubscriptions lists subscriptions with filtering, newest first
+func (r *MemoryGovernanceNotificationRepo) ListGovernanceSubscriptions(ctx context.Context, filter repository.GovernanceSubscriptionFilter, page repository.Pagination) ([]*repository.GovernanceSubscription, int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var matched []*repository.GovernanceSubscription
+	for _, s := range r.subscriptions {
+		if filter.Event != "" && !s.Wants(filter.Event) {
+			continue
+		}
+		if filter.Digest != "" && s.Digest != filter.Digest {
+			continue
+		}
+		matched = append(matched, copyGovernanceSubscription(s))
+	}
+
+	sortNewestFirst(matched, func(s *repository.GovernanceSubscription) time.Time { return s.CreatedAt })
+
+	return paginate(matched, page, 20), int64(len(matched)), nil
+}
+
+// MarkGovernanceDigestSent records when a subscriber's digest was last sent
+func (r *MemoryGovernanceNotificationRepo) MarkGovernanceDigestSent(ctx context.Context, address string, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s, ok := r.subscriptions[address]
+	if !ok {
+		return repository.ErrGovernanceSubscriptionNotFound
+	}
+	at = at.Truncate(time.Microsecond)
+	s.LastDigestAt = &at
+	return nil
+}
+
+// RecordGovernanceEvent records a lifecycle event once per proposal and event type
+func (r *MemoryGovernanceNotificationRepo) RecordGovernanceEvent(ctx context.Context, e *repository.GovernanceEvent) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.events {
+		if existing.ProposalID == e.ProposalID && existing.EventType == e.EventType {
+			return false, nil
+		}
+	}
+	e.ID = newID()
+	e.OccurredAt = now()
+	cp := *e
+	r.events = append(r.events, &cp)
+	return true, nil
+}
+
+// ListGovernanceEvents lists events recorded after since, oldest first
+func (r *MemoryGovernanceNotificationRepo) ListGovernanceEvents(ctx context.Context, since time.Time, n int) ([]*repository.GovernanceEvent, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var out []*repository.GovernanceEvent
+	for _, e := range r.events {
+		if e.OccurredAt.After(since) {
+			cp := *e
+			out = append(out, &cp)
+		}
+	}
+	return limit(out, n), nil
+}
+
+// copyGovernanceSubscription copies s, including its event types
+func copyGovernanceSubscription(s *repository.GovernanceSubscription) *repository.GovernanceSubscription {
+	cp := *s
+	cp.Events = append([]string(nil), s.Events...)
+	if s.LastDigestAt != nil {
+		at := *s.LastDigestAt
+		cp.LastDigestAt = &at
+	}
+	return &cp
+}
@@ -0,0 +1,58 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure MemoryKPIRepo implements KPIRepository
+var _ repository.KPIRepository = (*MemoryKPIRepo)(nil)
+
+// MemoryKPIRepo implements KPIRepository over the in-memory payment and
+// relayer stores. NFTs are not stored, so NFTMints is always nil.
+type MemoryKPIRepo struct {
+	payments *MemoryPaymentRepo
+	relayer  *MemoryRelayerRepo
+}
+
+// NewMemoryKPIRepo creates a KPI repository over payments and relayer
+func NewMemoryKPIRepo(payments *MemoryPaymentRepo, relayer *MemoryRelayerRepo) *MemoryKPIRepo {
+	return &MemoryKPIRepo{payments: payments, relayer: relayer}
+}
+
+// GetBusinessKPIs aggregates payments, KYC verifications and meta-transactions
+func (r *MemoryKPIRepo) GetBusinessKPIs(ctx context.Context, since time.Time) (*repository.BusinessKPIs, error) {
+	kpis := &repository.BusinessKPIs{
+		PaymentsByStatus: make(map[repository.PaymentStatus]int64),
+		KYCByStatus:      make(map[repository.KYCVerificationStatus]int64),
+	}
+
+	r.payments.mu.RLock()
+	for _, p := range r.payments.payments {
+		kpis.PaymentsByStatus[p.Status]++
+	}
+	for _, v := range r.payments.verifications {
+		kpis.KYCByStatus[v.Status]++
+		if v.VerifiedAt != nil && !v.VerifiedAt.Before(since) {
+			kpis.KYCApproved++
+		}
+		if v.RejectedAt != nil && !v.RejectedAt.Before(since) {
+			kpis.KYCRejected++
+		}
+	}
+	r.payments.mu.RUnlock()
+
+	r.relayer.mu.RLock()
+	for _, tx := range r.relayer.txs {
+		if tx.Status != repository.MetaTxStatusConfirmed || tx.ConfirmedAt == nil || tx.ConfirmedAt.Before(since) {
+			continue
+		}
+		kpis.RelayConfirmed++
+		kpis.RelayConfirmationSeconds += tx.ConfirmedAt.Sub(tx.CreatedAt).Seconds()
+	}
+	r.relayer.mu.RUnlock()
+
+	return kpis, nil
+}
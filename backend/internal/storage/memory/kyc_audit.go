@@ -0,0 +1,85 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure MemoryKYCAuditRepo implements KYCAuditRepository
+var _ repository.KYCAuditRepository = (*MemoryKYCAuditRepo)(nil)
+
+// MemoryKYCAuditRepo implements KYCAuditRepository in memory. Like the
+// (subject, timestamp, id) index of the SQL tables, entries are kept sorted
+// per subject so a page is a binary search and a walk, not a full scan.
+type MemoryKYCAuditRepo struct {
+	mu        sync.RWMutex
+	all       []*repository.KYCAuditEntry            // oldest first
+	bySubject map[string][]*repository.KYCAuditEntry // oldest first
+}
+
+// NewMemoryKYCAuditRepo creates a new in-memory KYC audit repository
+func NewMemoryKYCAuditRepo() *MemoryKYCAuditRepo {
+	return &MemoryKYCAuditRepo{bySubject: make(map[string][]*repository.KYCAuditEntry)}
+}
+
+// AppendAuditEntry stores an audit entry
+func (r *MemoryKYCAuditRepo) AppendAuditEntry(ctx context.Context, e *repository.KYCAuditEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	e.ID = newID()
+	if e.Timestamp.IsZero() {
+		e.Timestamp = now()
+	}
+	cp := *e
+	r.all = insertAuditEntry(r.all, &cp)
+	r.bySubject[cp.Subject] = insertAuditEntry(r.bySubject[cp.Subject], &cp)
+	return nil
+}
+
+// ListAuditEntries returns up to limit entries after filter.After, newest first
+func (r *MemoryKYCAuditRepo) ListAuditEntries(ctx context.Context, filter repository.KYCAuditFilter, limit int) ([]*repository.KYCAuditEntry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	entries := r.entries(filter)
+	end := len(entries)
+	if filter.After != nil {
+		end = sort.Search(len(entries), func(i int) bool { return !filter.After.Precedes(entries[i]) })
+	}
+
+	result := make([]*repository.KYCAuditEntry, 0, min(limit, end))
+	for i := end - 1; i >= 0 && len(result) < limit; i-- {
+		cp := *entries[i]
+		result = append(result, &cp)
+	}
+	return result, nil
+}
+
+// CountAuditEntries counts the entries of filter.Subject (all without one)
+func (r *MemoryKYCAuditRepo) CountAuditEntries(ctx context.Context, filter repository.KYCAuditFilter) (int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return int64(len(r.entries(repository.KYCAuditFilter{Subject: filter.Subject}))), nil
+}
+
+// entries returns the sorted entries filter's subject selects
+func (r *MemoryKYCAuditRepo) entries(filter repository.KYCAuditFilter) []*repository.KYCAuditEntry {
+	if filter.Subject != "" {
+		return r.bySubject[filter.Subject]
+	}
+	return r.all
+}
+
+// insertAuditEntry inserts e into entries, keeping them oldest first
+func insertAuditEntry(entries []*repository.KYCAuditEntry, e *repository.KYCAuditEntry) []*repository.KYCAuditEntry {
+	i := sort.Search(len(entries), func(i int) bool { return !e.Cursor().Precedes(entries[i]) })
+	entries = append(entries, nil)
+	copy(entries[i+1:], entries[i:])
+	entries[i] = e
+	return entries
+}
@@ -0,0 +1,138 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure MemoryLedgerRepo implements LedgerRepository
+var _ repository.LedgerRepository = (*MemoryLedgerRepo)(nil)
+
+// MemoryLedgerRepo implements LedgerRepository in memory. Like the database
+// tables, posted transactions are never changed.
+type MemoryLedgerRepo struct {
+	mu           sync.RWMutex
+	transactions map[string]*repository.LedgerTransaction
+	byKey        map[string]string
+	entries      []*repository.LedgerEntry // posting order
+}
+
+// NewMemoryLedgerRepo creates a new in-memory ledger repository
+func NewMemoryLedgerRepo() *MemoryLedgerRepo {
+	return &MemoryLedgerRepo{
+		transactions: make(map[string]*repository.LedgerTransaction),
+		byKey:        make(map[string]string),
+	}
+}
+
+// PostLedgerTransaction writes a balanced transaction and its entries once
+func (r *MemoryLedgerRepo) PostLedgerTransaction(ctx context.Context, tx *repository.LedgerTransaction) (bool, error) {
+	if err := tx.Validate(); err != nil {
+		return false, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.byKey[tx.IdempotencyKey]; ok {
+		return false, nil
+	}
+
+	tx.ID = newID()
+	tx.CreatedAt = now()
+	stored := *tx
+	stored.Entries = make([]*repository.LedgerEntry, len(tx.Entries))
+	for i, e := range tx.Entries {
+		e.ID = newID()
+		e.TransactionID = tx.ID
+		e.EventType = tx.EventType
+		e.PaymentID = tx.PaymentID
+		e.CreatedAt = tx.CreatedAt
+		cp := *e
+		stored.Entries[i] = &cp
+		r.entries = append(r.entries, &cp)
+	}
+	r.transactions[tx.ID] = &stored
+	r.byKey[tx.IdempotencyKey] = tx.ID
+	return true, nil
+}
+
+// GetLedgerTransaction retrieves a transaction with its entries
+func (r *MemoryLedgerRepo) GetLedgerTransaction(ctx context.Context, id string) (*repository.LedgerTransaction, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	tx, ok := r.transactions[id]
+	if !ok {
+		return nil, repository.ErrLedgerTransactionNotFound
+	}
+	cp := *tx
+	cp.Entries = make([]*repository.LedgerEntry, len(tx.Entries))
+	for i, e := range tx.Entries {
+		entry := *e
+		cp.Entries[i] = &entry
+	}
+	// Debits first, as the SQL backends order them
+	sort.SliceStable(cp.Entries, func(i, j int) bool {
+		if cp.Entries[i].Direction != cp.Entries[j].Direction {
+			return cp.Entries[i].Direction == repository.LedgerDebit
+		}
+		return cp.Entries[i].Account < cp.Entries[j].Account
+	})
+	return &cp, nil
+}
+
+// ListLedgerEntries lists entries with filtering, oldest first
+func (r *MemoryLedgerRepo) ListLedgerEntries(ctx context.Context, filter repository.LedgerEntryFilter, page repository.Pagination) ([]*repository.LedgerEntry, int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var matched []*repository.LedgerEntry
+	for _, e := range r.entries {
+		if filter.Account != "" && e.Account != filter.Account {
+			continue
+		}
+		if filter.Currency != "" && e.Currency != filter.Currency {
+			continue
+		}
+		if filter.PaymentID != "" && (e.PaymentID == nil || *e.PaymentID != filter.PaymentID) {
+			continue
+		}
+		if filter.EventType != "" && e.EventType != filter.EventType {
+			continue
+		}
+		if filter.From != nil && e.CreatedAt.Before(*filter.From) {
+			continue
+		}
+		if filter.To != nil && !e.CreatedAt.Before(*filter.To) {
+			continue
+		}
+		cp := *e
+		matched = append(matched, &cp)
+	}
+
+	return paginate(matched, page, 50), int64(len(matched)), nil
+}
+
+// GetLedgerBalances totals entries per account and currency
+func (r *MemoryLedgerRepo) GetLedgerBalances(ctx context.Context, filter repository.LedgerBalanceFilter) ([]*repository.LedgerBalance, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	tally := repository.NewLedgerTally()
+	for _, e := range r.entries {
+		if filter.Currency != "" && e.Currency != filter.Currency {
+			continue
+		}
+		if filter.To != nil && !e.CreatedAt.Before(*filter.To) {
+			continue
+		}
+		if err := tally.Add(e.Account, e.Currency, e.Direction, e.Amount); err != nil {
+			return nil, err
+		}
+	}
+	return tally.Balances(), nil
+}
@@ -0,0 +1,160 @@
+// Package memory implements repository interfaces in process memory for
+// integration tests, load tests and local demos that run without Postgres.
+// Behavior mirrors the PostgreSQL implementations, including the audit
+// history normally written by database triggers.
+package memory
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Store bundles one in-memory implementation of every repository
+type Store struct {
+	Pricing          *MemoryPricingRepo
+	Payments         *MemoryPaymentRepo
+	Relayer          *MemoryRelayerRepo
+	Contracts        *MemoryContractRepo
+	GovernanceConfig *MemoryGovernanceConfigRepo
+	AppConfig        *MemoryAppConfigRepo
+	Chain            *MemoryChainRepo
+	RequestLogs      *MemoryRequestLogRepo
+	Search           *MemorySearchRepo
+	KPIs             *MemoryKPIRepo
+	WebhookEvents    *MemoryWebhookEventRepo
+	GasBilling       *MemoryGasBillingRepo
+	Deposits         *MemoryDepositRepo
+	Ledger           *MemoryLedgerRepo
+	Payout           *MemoryPayoutRepo
+	Snapshot         *MemorySnapshotRepo
+	GovernanceAlerts *MemoryGovernanceNotificationRepo
+	SafeTxs          *MemorySafeTransactionRepo
+	Bridge           *MemoryBridgeRepo
+	Profiles         *MemoryProfileRepo
+	Activity         *MemoryActivityRepo
+	Reports          *MemoryReportRepo
+	Anomalies        *MemoryAnomalyRepo
+	Watchlists       *MemoryWatchlistRepo
+	Fulfillments     *MemoryFulfillmentRepo
+	Entitlements     *MemoryEntitlementRepo
+	Partners         *MemoryPartnerRepo
+	KYCAudit         *MemoryKYCAuditRepo
+}
+
+// NewStore creates an empty in-memory store
+func NewStore() *Store {
+	payments := NewMemoryPaymentRepo()
+	relayer := NewMemoryRelayerRepo()
+
+	return &Store{
+		Pricing:          NewMemoryPricingRepo(),
+		Payments:         payments,
+		Relayer:          relayer,
+		Contracts:        NewMemoryContractRepo(),
+		GovernanceConfig: NewMemoryGovernanceConfigRepo(),
+		AppConfig:        NewMemoryAppConfigRepo(),
+		Chain:            NewMemoryChainRepo(),
+		RequestLogs:      NewMemoryRequestLogRepo(),
+		Search:           NewMemorySearchRepo(payments, relayer),
+		KPIs:             NewMemoryKPIRepo(payments, relayer),
+		WebhookEvents:    NewMemoryWebhookEventRepo(),
+		GasBilling:       NewMemoryGasBillingRepo(),
+		Deposits:         NewMemoryDepositRepo(),
+		Ledger:           NewMemoryLedgerRepo(),
+		Payout:           NewMemoryPayoutRepo(),
+		Snapshot:         NewMemorySnapshotRepo(),
+		GovernanceAlerts: NewMemoryGovernanceNotificationRepo(),
+		SafeTxs:          NewMemorySafeTransactionRepo(),
+		Bridge:           NewMemoryBridgeRepo(),
+		Profiles:         NewMemoryProfileRepo(),
+		Activity:         NewMemoryActivityRepo(),
+		Reports:          NewMemoryReportRepo(),
+		Anomalies:        NewMemoryAnomalyRepo(payments, relayer),
+		Watchlists:       NewMemoryWatchlistRepo(),
+		Fulfillments:     NewMemoryFulfillmentRepo(),
+		Entitlements:     NewMemoryEntitlementRepo(),
+		Partners:         NewMemoryPartnerRepo(),
+		KYCAudit:         NewMemoryKYCAuditRepo(),
+	}
+}
+
+// newID generates a random (version 4) UUID like uuid_generate_v4()
+func newID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(fmt.Sprintf("generating id: %v", err))
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+// now returns the current time truncated to microseconds (TIMESTAMPTZ precision)
+func now() time.Time {
+	return time.Now().Truncate(time.Microsecond)
+}
+
+// paginate returns one page of items using the same defaults as the
+// PostgreSQL repositories
+func paginate[T any](items []T, page repository.Pagination, defaultSize int) []T {
+	if page.PageSize <= 0 {
+		page.PageSize = defaultSize
+	}
+	if page.Page <= 0 {
+		page.Page = 1
+	}
+
+	offset := (page.Page - 1) * page.PageSize
+	if offset >= len(items) {
+		return nil
+	}
+	end := offset + page.PageSize
+	if end > len(items) {
+		end = len(items)
+	}
+	return items[offset:end]
+}
+
+// sortNewestFirst orders items by created_at DESC
+func sortNewestFirst[T any](items []T, createdAt func(T) time.Time) {
+	sort.SliceStable(items, func(i, j int) bool {
+		return createdAt(items[i]).After(createdAt(items[j]))
+	})
+}
+
+// limit truncates items to n (n <= 0 means no limit)
+func limit[T any](items []T, n int) []T {
+	if n > 0 && len(items) > n {
+		return items[:n]
+	}
+	return items
+}
+
+// distinct reports whether two nullable values differ (SQL IS DISTINCT FROM)
+func distinct[T comparable](a, b *T) bool {
+	if a == nil || b == nil {
+		return a != b
+	}
+	return *a != *b
+}
+
+// distinctWei reports whether two nullable wei values differ
+func distinctWei(a, b *big.Int) bool {
+	if a == nil || b == nil {
+		return a != b
+	}
+	return a.Cmp(b) != 0
+}
+
+// copyWei returns an independent copy of a wei value
+func copyWei(v *big.Int) *big.Int {
+	if v == nil {
+		return nil
+	}
+	return new(big.Int).Set(v)
+}
@@ -0,0 +1,417 @@
+package memory_test
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/storage/memory"
+)
+
+func TestPricingRepo_SeededPricingAndHistory(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewSeededStore()
+
+	pricing, err := store.Pricing.ListPricing(ctx, true)
+	require.NoError(t, err)
+	require.Len(t, pricing, 8)
+	assert.Equal(t, "governance_proposal", pricing[0].ServiceCode, "ordered by service code")
+
+	_, err = store.Pricing.GetPricing(ctx, "unknown")
+	assert.ErrorIs(t, err, repository.ErrPricingNotFound)
+
+	newPrice := 20.0
+	require.NoError(t, store.Pricing.UpdatePricing(ctx, "kyc_verification", &repository.PricingUpdate{
+		PriceUSD:  &newPrice,
+		UpdatedBy: "admin",
+	}))
+
+	p, err := store.Pricing.GetPricing(ctx, "kyc_verification")
+	require.NoError(t, err)
+	assert.Equal(t, 20.0, p.PriceUSD)
+
+	history, err := store.Pricing.GetPricingHistory(ctx, "kyc_verification", 10)
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	assert.Equal(t, 15.0, *history[0].OldPriceUSD)
+	assert.Equal(t, 20.0, *history[0].NewPriceUSD)
+	assert.Equal(t, "admin", history[0].ChangedBy)
+
+	methods, err := store.Pricing.ListPaymentMethods(ctx, true)
+	require.NoError(t, err)
+	require.Len(t, methods, 3)
+	assert.Equal(t, "nexus", methods[0].MethodCode, "ordered by display order")
+
+	assert.ErrorIs(t, store.Pricing.UpdatePaymentMethod(ctx, "paypal", &repository.PaymentMethodUpdate{}), repository.ErrPaymentMethodNotFound)
+}
+
+func TestPaymentRepo_LifecycleAndFilters(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryPaymentRepo()
+
+	session := "cs_test_123"
+	payment := &repository.Payment{
+		ServiceCode:     "kyc_verification",
+		PayerAddress:    "0xabc",
+		PaymentMethod:   "stripe",
+		AmountCharged:   15,
+		Currency:        "USD",
+		StripeSessionID: &session,
+		Status:          repository.PaymentStatusPending,
+	}
+	require.NoError(t, repo.CreatePayment(ctx, payment))
+	require.NotEmpty(t, payment.ID)
+	assert.False(t, payment.CreatedAt.IsZero())
+
+	got, err := repo.GetPaymentByStripeSession(ctx, session)
+	require.NoError(t, err)
+	assert.Equal(t, payment.ID, got.ID)
+
+	// Returned records are copies
+	got.Status = repository.PaymentStatusFailed
+	stored, err := repo.GetPayment(ctx, payment.ID)
+	require.NoError(t, err)
+	assert.Equal(t, repository.PaymentStatusPending, stored.Status)
+
+	require.NoError(t, repo.UpdatePaymentStatus(ctx, payment.ID, repository.PaymentStatusCompleted, nil))
+	stored, _ = repo.GetPayment(ctx, payment.ID)
+	assert.NotNil(t, stored.CompletedAt)
+
+	// Reorg rollback clears completion
+	require.NoError(t, repo.UpdatePaymentStatus(ctx, payment.ID, repository.PaymentStatusProcessing, nil))
+	stored, _ = repo.GetPayment(ctx, payment.ID)
+	assert.Nil(t, stored.CompletedAt)
+
+	assert.ErrorIs(t, repo.UpdatePaymentStatus(ctx, "missing", repository.PaymentStatusCompleted, nil), repository.ErrPaymentNotFound)
+
+	for i := 0; i < 3; i++ {
+		require.NoError(t, repo.CreatePayment(ctx, &repository.Payment{
+			ServiceCode:   "nft_mint",
+			PayerAddress:  "0xdef",
+			PaymentMethod: "eth",
+			Status:        repository.PaymentStatusPending,
+		}))
+	}
+
+	page, total, err := repo.ListPayments(ctx, repository.PaymentFilter{PayerAddress: "0xdef"}, repository.Pagination{Page: 1, PageSize: 2})
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), total)
+	assert.Len(t, page, 2)
+}
+
+func TestPaymentRepo_KYCVerificationTimestamps(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryPaymentRepo()
+
+	applicant := "applicant-1"
+	v := &repository.KYCVerification{
+		UserAddress:       "0xabc",
+		SumsubApplicantID: &applicant,
+		Status:            repository.KYCStatusPending,
+	}
+	require.NoError(t, repo.CreateKYCVerification(ctx, v))
+
+	approved := repository.KYCStatusApproved
+	require.NoError(t, repo.UpdateKYCVerification(ctx, v.ID, &repository.KYCVerificationUpdate{Status: &approved}))
+
+	got, err := repo.GetKYCVerificationByApplicant(ctx, applicant)
+	require.NoError(t, err)
+	assert.Equal(t, repository.KYCStatusApproved, got.Status)
+	assert.NotNil(t, got.VerifiedAt)
+
+	_, err = repo.GetKYCVerificationByAddress(ctx, "0xnone")
+	assert.ErrorIs(t, err, repository.ErrKYCNotFound)
+}
+
+func TestRelayerRepo_NonceAndPendingQueues(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRelayerRepo()
+
+	nonce, err := repo.GetNextNonce(ctx, "0xabc")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), nonce)
+
+	live := &repository.MetaTransaction{FromAddress: "0xabc", Nonce: 1, Deadline: time.Now().Add(time.Hour), Status: repository.MetaTxStatusPending}
+	expired := &repository.MetaTransaction{FromAddress: "0xabc", Nonce: 2, Deadline: time.Now().Add(-time.Minute), Status: repository.MetaTxStatusPending}
+	failed := &repository.MetaTransaction{FromAddress: "0xabc", Nonce: 7, Deadline: time.Now().Add(time.Hour), Status: repository.MetaTxStatusFailed}
+	for _, tx := range []*repository.MetaTransaction{live, expired, failed} {
+		require.NoError(t, repo.CreateMetaTx(ctx, tx))
+	}
+
+	nonce, err = repo.GetNextNonce(ctx, "0xabc")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), nonce, "failed meta-txs do not consume nonces")
+
+	pending, err := repo.GetPendingMetaTxs(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, live.ID, pending[0].ID)
+
+	stale, err := repo.GetExpiredMetaTxs(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, stale, 1)
+	assert.Equal(t, expired.ID, stale[0].ID)
+
+	hash := "0xhash"
+	require.NoError(t, repo.UpdateMetaTxStatus(ctx, live.ID, &repository.MetaTxStatusUpdate{Status: repository.MetaTxStatusSubmitted, TxHash: &hash}))
+	got, err := repo.GetMetaTxByHash(ctx, hash)
+	require.NoError(t, err)
+	assert.NotNil(t, got.SubmittedAt)
+}
+
+func TestRelayerRepo_ArchiveKeepsHighestNonce(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRelayerRepo()
+
+	first := &repository.MetaTransaction{FromAddress: "0xabc", Nonce: 1, Status: repository.MetaTxStatusConfirmed}
+	latest := &repository.MetaTransaction{FromAddress: "0xabc", Nonce: 2, Status: repository.MetaTxStatusConfirmed}
+	pending := &repository.MetaTransaction{FromAddress: "0xdef", Nonce: 1, Status: repository.MetaTxStatusPending}
+	failed := &repository.MetaTransaction{FromAddress: "0xdef", Nonce: 9, Status: repository.MetaTxStatusFailed}
+	for _, tx := range []*repository.MetaTransaction{first, latest, pending, failed} {
+		require.NoError(t, repo.CreateMetaTx(ctx, tx))
+	}
+
+	moved, err := repo.ArchiveMetaTxs(ctx, time.Now().Add(time.Hour), 100)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), moved)
+
+	_, err = repo.GetMetaTx(ctx, first.ID)
+	assert.ErrorIs(t, err, repository.ErrMetaTxNotFound)
+	archived, err := repo.GetArchivedMetaTx(ctx, first.ID)
+	require.NoError(t, err)
+	assert.NotNil(t, archived.ArchivedAt)
+
+	nonce, err := repo.GetNextNonce(ctx, "0xabc")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), nonce, "archiving must not rewind the DB nonce")
+
+	list, total, err := repo.ListArchivedMetaTx(ctx, repository.MetaTxFilter{FromAddress: "0xdef"}, repository.Pagination{Page: 1, PageSize: 10})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Equal(t, failed.ID, list[0].ID)
+}
+
+func TestRelayerRepo_RelaySpendByContract(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRelayerRepo()
+
+	for i, target := range []string{"0xaaa", "0xbbb", "0xbbb"} {
+		tx := &repository.MetaTransaction{FromAddress: "0xabc", ToAddress: target, Nonce: uint64(i + 1), Status: repository.MetaTxStatusSubmitted}
+		require.NoError(t, repo.CreateMetaTx(ctx, tx))
+		gasUsed, gasPrice := uint64(21000), "2000000000"
+		require.NoError(t, repo.UpdateMetaTxStatus(ctx, tx.ID, &repository.MetaTxStatusUpdate{
+			Status: repository.MetaTxStatusConfirmed, GasUsed: &gasUsed, GasPrice: &gasPrice}))
+	}
+	// Not mined: no spend
+	require.NoError(t, repo.CreateMetaTx(ctx, &repository.MetaTransaction{FromAddress: "0xabc", ToAddress: "0xaaa", Nonce: 4, Status: repository.MetaTxStatusPending}))
+
+	spend, err := repo.GetRelaySpend(ctx, repository.RelaySpendFilter{GroupBy: repository.RelaySpendByContract})
+	require.NoError(t, err)
+	require.Len(t, spend, 2)
+	assert.Equal(t, "0xbbb", spend[0].Key, "highest spend first")
+	assert.Equal(t, int64(2), spend[0].TxCount)
+	assert.Equal(t, "84000000000000", spend[0].SpendWei)
+	assert.Equal(t, "0.000042000000000000", spend[1].SpendETH)
+
+	total, err := repository.TotalRelaySpend(spend)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(63000), total.GasUsed)
+	assert.Equal(t, "0.000126000000000000", total.SpendETH)
+
+	_, err = repo.GetRelaySpend(ctx, repository.RelaySpendFilter{GroupBy: "week"})
+	assert.Error(t, err)
+}
+
+func TestPaymentRepo_ArchiveSkipsKYCLinkedPayments(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryPaymentRepo()
+
+	linked := &repository.Payment{PayerAddress: "0xabc", Status: repository.PaymentStatusCompleted}
+	settled := &repository.Payment{PayerAddress: "0xabc", Status: repository.PaymentStatusCompleted}
+	open := &repository.Payment{PayerAddress: "0xabc", Status: repository.PaymentStatusPending}
+	for _, p := range []*repository.Payment{linked, settled, open} {
+		require.NoError(t, repo.CreatePayment(ctx, p))
+	}
+	require.NoError(t, repo.CreateKYCVerification(ctx, &repository.KYCVerification{UserAddress: "0xabc", PaymentID: &linked.ID}))
+
+	// Not old enough yet
+	moved, err := repo.ArchivePayments(ctx, time.Now().Add(-time.Hour), 100)
+	require.NoError(t, err)
+	assert.Zero(t, moved)
+
+	moved, err = repo.ArchivePayments(ctx, time.Now().Add(time.Hour), 100)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), moved)
+
+	_, err = repo.GetPayment(ctx, settled.ID)
+	assert.ErrorIs(t, err, repository.ErrPaymentNotFound)
+	got, err := repo.GetArchivedPayment(ctx, settled.ID)
+	require.NoError(t, err)
+	assert.Equal(t, repository.PaymentStatusCompleted, got.Status)
+
+	_, err = repo.GetArchivedPayment(ctx, linked.ID)
+	assert.ErrorIs(t, err, repository.ErrPaymentNotFound)
+}
+
+func TestContractRepo_UpsertRecordsHistory(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewSeededStore()
+
+	mapping, err := store.Contracts.GetMappingByDBName(ctx, "nexusToken")
+	require.NoError(t, err)
+
+	first, err := store.Contracts.Upsert(ctx, &repository.ContractAddressUpsert{ChainID: 31337, ContractMappingID: mapping.ID, Address: "0x1"})
+	require.NoError(t, err)
+	assert.Equal(t, "NexusToken", first.SolidityName)
+	assert.Equal(t, "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266", *first.DeployedBy, "defaults to the network deployer")
+
+	second, err := store.Contracts.Upsert(ctx, &repository.ContractAddressUpsert{ChainID: 31337, ContractMappingID: mapping.ID, Address: "0x2"})
+	require.NoError(t, err)
+	assert.Equal(t, first.ID, second.ID)
+
+	history, err := store.Contracts.GetHistory(ctx, first.ID, 10)
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	assert.Equal(t, "0x1", *history[0].OldAddress)
+	assert.Equal(t, "0x2", history[0].NewAddress)
+
+	_, err = store.Contracts.Upsert(ctx, &repository.ContractAddressUpsert{ChainID: 999, ContractMappingID: mapping.ID, Address: "0x3"})
+	assert.ErrorIs(t, err, repository.ErrNetworkNotFound)
+
+	got, err := store.Contracts.GetByChainAndDBName(ctx, 31337, "nexusToken")
+	require.NoError(t, err)
+	assert.Equal(t, "0x2", got.Address)
+}
+
+func TestContractRepo_ABIVersionsAreImmutable(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewSeededStore()
+
+	v1 := json.RawMessage(`[{"type":"function","name":"name"}]`)
+	first, err := store.Contracts.UpsertABI(ctx, &repository.ABIArtifactUpsert{DBName: "nexusToken", Version: "1.0.0", ABI: v1, ABIHash: "0xv1"})
+	require.NoError(t, err)
+
+	again, err := store.Contracts.UpsertABI(ctx, &repository.ABIArtifactUpsert{DBName: "nexusToken", Version: "1.0.0", ABI: v1, ABIHash: "0xv1"})
+	require.NoError(t, err)
+	assert.Equal(t, first.ID, again.ID)
+
+	v2 := json.RawMessage(`[{"type":"function","name":"symbol"}]`)
+	_, err = store.Contracts.UpsertABI(ctx, &repository.ABIArtifactUpsert{DBName: "nexusToken", Version: "1.0.0", ABI: v2, ABIHash: "0xv2"})
+	assert.ErrorIs(t, err, repository.ErrABIVersionConflict)
+	got, err := store.Contracts.GetABI(ctx, "nexusToken", "1.0.0")
+	require.NoError(t, err)
+	assert.JSONEq(t, string(v1), string(got.ABI))
+
+	time.Sleep(time.Millisecond)
+	_, err = store.Contracts.UpsertABI(ctx, &repository.ABIArtifactUpsert{DBName: "nexusToken", Version: "2.0.0", ABI: v2, ABIHash: "0xv2"})
+	require.NoError(t, err)
+	got, err = store.Contracts.GetABI(ctx, "nexusToken", repository.ABIVersionLatest)
+	require.NoError(t, err)
+	assert.Equal(t, "2.0.0", got.Version)
+}
+
+func TestAppConfigRepo_FallbackAndSoftDelete(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryAppConfigRepo()
+
+	global, chainSpecific := int64(12), int64(1)
+	require.NoError(t, repo.Create(ctx, &repository.AppConfigCreate{Namespace: "chain", ConfigKey: "confirmation_depth", ValueType: "number", ValueNumber: &global}))
+	require.NoError(t, repo.Create(ctx, &repository.AppConfigCreate{Namespace: "chain", ConfigKey: "confirmation_depth", ValueType: "number", ValueNumber: &chainSpecific, ChainID: 31337}))
+	assert.Error(t, repo.Create(ctx, &repository.AppConfigCreate{Namespace: "chain", ConfigKey: "confirmation_depth", ChainID: 31337}))
+
+	depth, err := repo.GetNumber(ctx, "chain", "confirmation_depth", 31337)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), depth)
+
+	depth, err = repo.GetNumber(ctx, "chain", "confirmation_depth", 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(12), depth)
+
+	require.NoError(t, repo.Delete(ctx, "chain", "confirmation_depth", 31337, "admin"))
+	depth, err = repo.GetNumber(ctx, "chain", "confirmation_depth", 31337)
+	require.NoError(t, err)
+	assert.Equal(t, int64(12), depth, "soft-deleted chain override falls back to global")
+
+	_, err = repo.GetNumber(ctx, "chain", "missing", 1)
+	assert.ErrorIs(t, err, repository.ErrAppConfigNotFound)
+
+	updated := int64(6)
+	require.NoError(t, repo.Update(ctx, "chain", "confirmation_depth", 0, &repository.AppConfigUpdate{ValueNumber: &updated, UpdatedBy: "admin"}))
+	history, err := repo.GetHistory(ctx, "chain", "confirmation_depth", 0, 10)
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	assert.Equal(t, int64(12), *history[0].OldValueNumber)
+}
+
+func TestGovernanceConfigRepo_ValueChangeResetsSync(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryGovernanceConfigRepo()
+
+	repo.AddConfig(&repository.GovernanceConfig{
+		ConfigKey:      "proposal_threshold",
+		ValueWei:       big.NewInt(100),
+		ValueType:      "wei",
+		ChainID:        31337,
+		ContractSynced: true,
+		IsActive:       true,
+	})
+
+	require.NoError(t, repo.UpdateConfig(ctx, "proposal_threshold", 31337, &repository.GovernanceConfigUpdate{ValueWei: big.NewInt(200), UpdatedBy: "dao"}))
+
+	c, err := repo.GetConfig(ctx, "proposal_threshold", 31337)
+	require.NoError(t, err)
+	assert.Equal(t, "200", c.ValueWei.String())
+	assert.False(t, c.ContractSynced)
+
+	require.NoError(t, repo.MarkSynced(ctx, "proposal_threshold", 31337, "0xsync"))
+	c, _ = repo.GetConfig(ctx, "proposal_threshold", 31337)
+	assert.True(t, c.ContractSynced)
+
+	history, err := repo.GetConfigHistory(ctx, "proposal_threshold", 31337, 10)
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	assert.True(t, *history[0].WasSynced)
+
+	assert.ErrorIs(t, repo.MarkSynced(ctx, "missing", 31337, "0x"), repository.ErrGovernanceConfigNotFound)
+}
+
+func TestChainRepo_RollbackTxsAbove(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryChainRepo()
+
+	tx := &repository.TrackedTx{ChainID: 31337, TxHash: "0xtx", RecordType: repository.TrackedRecordPayment, RecordID: "p1"}
+	require.NoError(t, repo.TrackTx(ctx, tx))
+	assert.Equal(t, repository.TrackedTxStatusPending, tx.Status)
+
+	block, hash, confs := uint64(10), "0xblock", 3
+	require.NoError(t, repo.UpdateTrackedTx(ctx, tx.ID, &repository.TrackedTxUpdate{
+		Status:        repository.TrackedTxStatusIncluded,
+		BlockNumber:   &block,
+		BlockHash:     &hash,
+		Confirmations: &confs,
+	}))
+
+	rolledBack, err := repo.RollbackTxsAbove(ctx, 31337, 9)
+	require.NoError(t, err)
+	require.Len(t, rolledBack, 1)
+	assert.Equal(t, repository.TrackedTxStatusPending, rolledBack[0].Status)
+	assert.Nil(t, rolledBack[0].BlockNumber)
+	assert.Equal(t, 1, rolledBack[0].ReorgCount)
+
+	for n := uint64(1); n <= 5; n++ {
+		require.NoError(t, repo.SaveBlock(ctx, &repository.BlockRecord{ChainID: 31337, Number: n, Hash: "0x"}))
+	}
+	deleted, err := repo.DeleteBlocksAbove(ctx, 31337, 3)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), deleted)
+
+	latest, err := repo.GetLatestBlock(ctx, 31337)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), latest.Number)
+}
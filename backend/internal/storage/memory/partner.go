@@ -0,0 +1,202 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure MemoryPartnerRepo implements PartnerRepository
+var _ repository.PartnerRepository = (*MemoryPartnerRepo)(nil)
+
+// MemoryPartnerRepo implements PartnerRepository in memory
+type MemoryPartnerRepo struct {
+	mu          sync.RWMutex
+	partners    map[string]*repository.Partner
+	commissions map[string]*repository.PartnerCommission // by payment ID
+}
+
+// NewMemoryPartnerRepo creates a new in-memory partner repository
+func NewMemoryPartnerRepo() *MemoryPartnerRepo {
+	return &MemoryPartnerRepo{
+		partners:    make(map[string]*repository.Partner),
+		commissions: make(map[string]*repository.PartnerCommission),
+	}
+}
+
+// CreatePartner stores a partner, refusing a referral code in use
+func (r *MemoryPartnerRepo) CreatePartner(ctx context.Context, p *repository.Partner) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.partners {
+		if existing.ReferralCode == p.ReferralCode {
+			return repository.ErrPartnerExists
+		}
+	}
+	p.ID = newID()
+	p.CreatedAt = now()
+	p.UpdatedAt = p.CreatedAt
+	cp := *p
+	r.partners[p.ID] = &cp
+	return nil
+}
+
+// GetPartner retrieves a partner by ID
+func (r *MemoryPartnerRepo) GetPartner(ctx context.Context, id string) (*repository.Partner, error) {
+	return r.findPartner(func(p *repository.Partner) bool { return p.ID == id })
+}
+
+// GetPartnerByCode retrieves a partner by referral code
+func (r *MemoryPartnerRepo) GetPartnerByCode(ctx context.Context, referralCode string) (*repository.Partner, error) {
+	return r.findPartner(func(p *repository.Partner) bool { return p.ReferralCode == referralCode })
+}
+
+// GetPartnerByKeyHash retrieves the partner an API key was issued to
+func (r *MemoryPartnerRepo) GetPartnerByKeyHash(ctx context.Context, keyHash string) (*repository.Partner, error) {
+	return r.findPartner(func(p *repository.Partner) bool { return p.APIKeyHash == keyHash })
+}
+
+func (r *MemoryPartnerRepo) findPartner(match func(*repository.Partner) bool) (*repository.Partner, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, p := range r.partners {
+		if match(p) {
+			cp := *p
+			return &cp, nil
+		}
+	}
+	return nil, repository.ErrPartnerNotFound
+}
+
+// ListPartners lists partners by name
+func (r *MemoryPartnerRepo) ListPartners(ctx context.Context, page repository.Pagination) ([]*repository.Partner, int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]*repository.Partner, 0, len(r.partners))
+	for _, p := range r.partners {
+		cp := *p
+		result = append(result, &cp)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Name != result[j].Name {
+			return result[i].Name < result[j].Name
+		}
+		return result[i].CreatedAt.Before(result[j].CreatedAt)
+	})
+
+	return paginate(result, page, 20), int64(len(result)), nil
+}
+
+// UpdatePartner applies the set fields of update
+func (r *MemoryPartnerRepo) UpdatePartner(ctx context.Context, id string, update *repository.PartnerUpdate) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	p, ok := r.partners[id]
+	if !ok {
+		return repository.ErrPartnerNotFound
+	}
+	if update.Name != nil {
+		p.Name = *update.Name
+	}
+	if update.SharePercent != nil {
+		p.SharePercent = *update.SharePercent
+	}
+	if update.ContactEmail != nil {
+		email := *update.ContactEmail
+		p.ContactEmail = &email
+	}
+	if update.IsActive != nil {
+		p.IsActive = *update.IsActive
+	}
+	if update.APIKeyHash != nil {
+		p.APIKeyHash = *update.APIKeyHash
+	}
+	p.UpdatedAt = now()
+	return nil
+}
+
+// RecordCommission stores a payment's commission once
+func (r *MemoryPartnerRepo) RecordCommission(ctx context.Context, c *repository.PartnerCommission) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.commissions[c.PaymentID]; exists {
+		return false, nil
+	}
+	c.ID = newID()
+	c.Status = repository.PartnerCommissionEarned
+	c.EarnedAt = now()
+	cp := *c
+	r.commissions[c.PaymentID] = &cp
+	return true, nil
+}
+
+// ReverseCommission marks a payment's earned commission reversed
+func (r *MemoryPartnerRepo) ReverseCommission(ctx context.Context, paymentID string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	c, ok := r.commissions[paymentID]
+	if !ok || c.Status != repository.PartnerCommissionEarned {
+		return false, nil
+	}
+	reversedAt := now()
+	c.Status = repository.PartnerCommissionReversed
+	c.ReversedAt = &reversedAt
+	return true, nil
+}
+
+// matchingCommissions returns copies of the commissions filter selects
+func (r *MemoryPartnerRepo) matchingCommissions(filter repository.PartnerCommissionFilter) []*repository.PartnerCommission {
+	var matched []*repository.PartnerCommission
+	for _, c := range r.commissions {
+		if filter.PartnerID != "" && c.PartnerID != filter.PartnerID {
+			continue
+		}
+		if filter.From != nil && c.EarnedAt.Before(*filter.From) {
+			continue
+		}
+		if filter.To != nil && !c.EarnedAt.Before(*filter.To) {
+			continue
+		}
+		cp := *c
+		matched = append(matched, &cp)
+	}
+	return matched
+}
+
+// ListCommissions lists commissions with filtering, newest first
+func (r *MemoryPartnerRepo) ListCommissions(ctx context.Context, filter repository.PartnerCommissionFilter, page repository.Pagination) ([]*repository.PartnerCommission, int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	matched := r.matchingCommissions(filter)
+	sortNewestFirst(matched, func(c *repository.PartnerCommission) time.Time { return c.EarnedAt })
+
+	return paginate(matched, page, 20), int64(len(matched)), nil
+}
+
+// GetCommissionTotals totals the commissions matching filter
+func (r *MemoryPartnerRepo) GetCommissionTotals(ctx context.Context, filter repository.PartnerCommissionFilter) (*repository.PartnerCommissionTotals, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	t := &repository.PartnerCommissionTotals{}
+	for _, c := range r.matchingCommissions(filter) {
+		t.Payments++
+		t.AmountUSD += c.AmountUSD
+		t.EarnedUSD += c.CommissionUSD
+		if c.Status == repository.PartnerCommissionReversed {
+			t.ReversedUSD += c.CommissionUSD
+		}
+	}
+	t.NetUSD = t.EarnedUSD - t.ReversedUSD
+	return t, nil
+}
@@ -0,0 +1,351 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure MemoryPaymentRepo implements PaymentRepository
+var _ repository.PaymentRepository = (*MemoryPaymentRepo)(nil)
+
+// MemoryPaymentRepo implements PaymentRepository in memory
+type MemoryPaymentRepo struct {
+	mu            sync.RWMutex
+	payments      map[string]*repository.Payment
+	verifications map[string]*repository.KYCVerification
+	archived      map[string]*repository.Payment
+}
+
+// NewMemoryPaymentRepo creates a new in-memory payment repository
+func NewMemoryPaymentRepo() *MemoryPaymentRepo {
+	return &MemoryPaymentRepo{
+		payments:      make(map[string]*repository.Payment),
+		verifications: make(map[string]*repository.KYCVerification),
+		archived:      make(map[string]*repository.Payment),
+	}
+}
+
+// ============================================================================
+// Payment Methods
+// ============================================================================
+
+// CreatePayment creates a new payment record, setting ID and timestamps
+func (r *MemoryPaymentRepo) CreatePayment(ctx context.Context, payment *repository.Payment) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	payment.ID = newID()
+	payment.CreatedAt = now()
+	payment.UpdatedAt = payment.CreatedAt
+
+	cp := *payment
+	cp.CompletedAt = nil
+	cp.ErrorMessage = nil
+	r.payments[cp.ID] = &cp
+
+	return nil
+}
+
+// GetPayment retrieves a payment by ID
+func (r *MemoryPaymentRepo) GetPayment(ctx context.Context, id string) (*repository.Payment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	p, ok := r.payments[id]
+	if !ok {
+		return nil, repository.ErrPaymentNotFound
+	}
+	cp := *p
+	return &cp, nil
+}
+
+// GetPaymentByStripeSession retrieves a payment by Stripe session ID
+func (r *MemoryPaymentRepo) GetPaymentByStripeSession(ctx context.Context, sessionID string) (*repository.Payment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, p := range r.payments {
+		if p.StripeSessionID != nil && *p.StripeSessionID == sessionID {
+			cp := *p
+			return &cp, nil
+		}
+	}
+	return nil, repository.ErrPaymentNotFound
+}
+
+// GetPaymentByStripePaymentID retrieves a payment by Stripe PaymentIntent ID
+func (r *MemoryPaymentRepo) GetPaymentByStripePaymentID(ctx context.Context, paymentIntentID string) (*repository.Payment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, p := range r.payments {
+		if p.StripePaymentID != nil && *p.StripePaymentID == paymentIntentID {
+			cp := *p
+			return &cp, nil
+		}
+	}
+	return nil, repository.ErrPaymentNotFound
+}
+
+// UpdatePaymentStatus updates a payment's status and details
+func (r *MemoryPaymentRepo) UpdatePaymentStatus(ctx context.Context, id string, status repository.PaymentStatus, details *repository.PaymentStatusUpdate) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	p, ok := r.payments[id]
+	if !ok {
+		return repository.ErrPaymentNotFound
+	}
+
+	p.Status = status
+	p.UpdatedAt = now()
+
+	switch status {
+	case repository.PaymentStatusCompleted:
+		completedAt := p.UpdatedAt
+		p.CompletedAt = &completedAt
+	case repository.PaymentStatusPending, repository.PaymentStatusProcessing:
+		// Rolled back (e.g. chain reorg) - no longer completed
+		p.CompletedAt = nil
+	}
+
+	if details != nil {
+		if details.TxHash != nil {
+			v := *details.TxHash
+			p.TxHash = &v
+		}
+		if details.StripePaymentID != nil {
+			v := *details.StripePaymentID
+			p.StripePaymentID = &v
+		}
+		if details.ErrorMessage != nil {
+			v := *details.ErrorMessage
+			p.ErrorMessage = &v
+		}
+		if details.DisputeID != nil {
+			v := *details.DisputeID
+			p.DisputeID = &v
+			if p.DisputedAt == nil {
+				disputedAt := p.UpdatedAt
+				p.DisputedAt = &disputedAt
+			}
+		}
+		if details.DisputeStatus != nil {
+			v := *details.DisputeStatus
+			p.DisputeStatus = &v
+		}
+		if details.DisputeReason != nil {
+			v := *details.DisputeReason
+			p.DisputeReason = &v
+		}
+	}
+
+	return nil
+}
+
+// ListPayments lists payments with filtering, newest first
+func (r *MemoryPaymentRepo) ListPayments(ctx context.Context, filter repository.PaymentFilter, page repository.Pagination) ([]*repository.Payment, int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var matched []*repository.Payment
+	for _, p := range r.payments {
+		if filter.PayerAddress != "" && p.PayerAddress != filter.PayerAddress {
+			continue
+		}
+		if filter.ServiceCode != "" && p.ServiceCode != filter.ServiceCode {
+			continue
+		}
+		if filter.PaymentMethod != "" && p.PaymentMethod != filter.PaymentMethod {
+			continue
+		}
+		if filter.Status != "" && p.Status != filter.Status {
+			continue
+		}
+		if filter.ExcludesExpired() && p.Status == repository.PaymentStatusExpired {
+			continue
+		}
+		if filter.Disputed && p.DisputeID == nil {
+			continue
+		}
+		cp := *p
+		matched = append(matched, &cp)
+	}
+
+	sortNewestFirst(matched, func(p *repository.Payment) time.Time { return p.CreatedAt })
+
+	return paginate(matched, page, 20), int64(len(matched)), nil
+}
+
+// ExpirePayments moves up to limit pending payments of method created before
+// the cutoff to expired, oldest first, and returns their IDs
+func (r *MemoryPaymentRepo) ExpirePayments(ctx context.Context, method string, before time.Time, limitN int) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var candidates []*repository.Payment
+	for _, p := range r.payments {
+		if p.PaymentMethod == method && p.Status == repository.PaymentStatusPending && p.CreatedAt.Before(before) {
+			candidates = append(candidates, p)
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].CreatedAt.Before(candidates[j].CreatedAt) })
+	candidates = limit(candidates, limitN)
+
+	expiredAt := now()
+	ids := make([]string, 0, len(candidates))
+	for _, p := range candidates {
+		p.Status = repository.PaymentStatusExpired
+		p.UpdatedAt = expiredAt
+		ids = append(ids, p.ID)
+	}
+
+	return ids, nil
+}
+
+// ============================================================================
+// KYC Verification Methods
+// ============================================================================
+
+// CreateKYCVerification creates a new KYC verification record
+func (r *MemoryPaymentRepo) CreateKYCVerification(ctx context.Context, v *repository.KYCVerification) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	v.ID = newID()
+	v.CreatedAt = now()
+	v.UpdatedAt = v.CreatedAt
+
+	// Only the inserted columns are stored, as in PostgreSQL
+	r.verifications[v.ID] = &repository.KYCVerification{
+		ID:                v.ID,
+		PaymentID:         v.PaymentID,
+		UserAddress:       v.UserAddress,
+		SumsubApplicantID: v.SumsubApplicantID,
+		Status:            v.Status,
+		CreatedAt:         v.CreatedAt,
+		UpdatedAt:         v.UpdatedAt,
+	}
+
+	return nil
+}
+
+// GetKYCVerification retrieves a KYC verification by ID
+func (r *MemoryPaymentRepo) GetKYCVerification(ctx context.Context, id string) (*repository.KYCVerification, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	v, ok := r.verifications[id]
+	if !ok {
+		return nil, repository.ErrKYCNotFound
+	}
+	cp := *v
+	return &cp, nil
+}
+
+// GetKYCVerificationByAddress retrieves the latest KYC verification for an address
+func (r *MemoryPaymentRepo) GetKYCVerificationByAddress(ctx context.Context, address string) (*repository.KYCVerification, error) {
+	return r.latestKYCVerification(func(v *repository.KYCVerification) bool {
+		return v.UserAddress == address
+	})
+}
+
+// GetKYCVerificationByApplicant retrieves the latest KYC verification for a Sumsub applicant
+func (r *MemoryPaymentRepo) GetKYCVerificationByApplicant(ctx context.Context, applicantID string) (*repository.KYCVerification, error) {
+	return r.latestKYCVerification(func(v *repository.KYCVerification) bool {
+		return v.SumsubApplicantID != nil && *v.SumsubApplicantID == applicantID
+	})
+}
+
+// latestKYCVerification returns the newest verification matching the predicate
+func (r *MemoryPaymentRepo) latestKYCVerification(match func(*repository.KYCVerification) bool) (*repository.KYCVerification, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var latest *repository.KYCVerification
+	for _, v := range r.verifications {
+		if match(v) && (latest == nil || v.CreatedAt.After(latest.CreatedAt)) {
+			latest = v
+		}
+	}
+	if latest == nil {
+		return nil, repository.ErrKYCNotFound
+	}
+	cp := *latest
+	return &cp, nil
+}
+
+// UpdateKYCVerification updates a KYC verification
+func (r *MemoryPaymentRepo) UpdateKYCVerification(ctx context.Context, id string, update *repository.KYCVerificationUpdate) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	v, ok := r.verifications[id]
+	if !ok {
+		return repository.ErrKYCNotFound
+	}
+
+	v.UpdatedAt = now()
+
+	if update.SumsubApplicantID != nil {
+		s := *update.SumsubApplicantID
+		v.SumsubApplicantID = &s
+	}
+	if update.SumsubInspectionID != nil {
+		s := *update.SumsubInspectionID
+		v.SumsubInspectionID = &s
+	}
+	if update.SumsubReviewStatus != nil {
+		s := *update.SumsubReviewStatus
+		v.SumsubReviewStatus = &s
+	}
+	if update.SumsubReviewResult != nil {
+		v.SumsubReviewResult = update.SumsubReviewResult
+	}
+	if update.Status != nil {
+		v.Status = *update.Status
+
+		// Update timestamp fields based on status
+		ts := v.UpdatedAt
+		switch *update.Status {
+		case repository.KYCStatusSubmitted:
+			v.SubmittedAt = &ts
+		case repository.KYCStatusApproved:
+			v.VerifiedAt = &ts
+		case repository.KYCStatusRejected:
+			v.RejectedAt = &ts
+		}
+	}
+	if update.WhitelistTxHash != nil {
+		s := *update.WhitelistTxHash
+		v.WhitelistTxHash = &s
+	}
+
+	return nil
+}
+
+// ListKYCVerifications lists KYC verifications with filtering, newest first
+func (r *MemoryPaymentRepo) ListKYCVerifications(ctx context.Context, filter repository.KYCVerificationFilter, page repository.Pagination) ([]*repository.KYCVerification, int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var matched []*repository.KYCVerification
+	for _, v := range r.verifications {
+		if filter.UserAddress != "" && v.UserAddress != filter.UserAddress {
+			continue
+		}
+		if filter.Status != "" && v.Status != filter.Status {
+			continue
+		}
+		cp := *v
+		matched = append(matched, &cp)
+	}
+
+	sortNewestFirst(matched, func(v *repository.KYCVerification) time.Time { return v.CreatedAt })
+
+	return paginate(matched, page, 20), int64(len(matched)), nil
+}
@@ -0,0 +1,140 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure MemoryPayoutRepo implements PayoutRepository
+var _ repository.PayoutRepository = (*MemoryPayoutRepo)(nil)
+
+// MemoryPayoutRepo implements PayoutRepository in memory
+type MemoryPayoutRepo struct {
+	mu      sync.RWMutex
+	payouts map[string]*repository.Payout
+}
+
+// NewMemoryPayoutRepo creates a new in-memory payout repository
+func NewMemoryPayoutRepo() *MemoryPayoutRepo {
+	return &MemoryPayoutRepo{payouts: make(map[string]*repository.Payout)}
+}
+
+// CreatePayout records a payout
+func (r *MemoryPayoutRepo) CreatePayout(ctx context.Context, p *repository.Payout) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ts := now()
+	p.ID = newID()
+	p.CreatedAt = ts
+	p.UpdatedAt = ts
+	if p.ScheduledFor.IsZero() {
+		p.ScheduledFor = ts
+	}
+	cp := *p
+	r.payouts[p.ID] = &cp
+	return nil
+}
+
+// GetPayout retrieves a payout by ID
+func (r *MemoryPayoutRepo) GetPayout(ctx context.Context, id string) (*repository.Payout, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	p, ok := r.payouts[id]
+	if !ok {
+		return nil, repository.ErrPayoutNotFound
+	}
+	cp := *p
+	return &cp, nil
+}
+
+// ListPayouts lists payouts with filtering, newest first
+func (r *MemoryPayoutRepo) ListPayouts(ctx context.Context, filter repository.PayoutFilter, page repository.Pagination) ([]*repository.Payout, int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var matched []*repository.Payout
+	for _, p := range r.payouts {
+		if filter.Kind != "" && p.Kind != filter.Kind {
+			continue
+		}
+		if filter.Status != "" && p.Status != filter.Status {
+			continue
+		}
+		if filter.Recipient != "" && p.Recipient != filter.Recipient {
+			continue
+		}
+		if filter.Reference != "" && (p.Reference == nil || *p.Reference != filter.Reference) {
+			continue
+		}
+		cp := *p
+		matched = append(matched, &cp)
+	}
+
+	sortNewestFirst(matched, func(p *repository.Payout) time.Time { return p.CreatedAt })
+
+	return paginate(matched, page, 20), int64(len(matched)), nil
+}
+
+// ListDuePayouts lists approved payouts scheduled at or before now
+func (r *MemoryPayoutRepo) ListDuePayouts(ctx context.Context, now time.Time, limit int) ([]*repository.Payout, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var due []*repository.Payout
+	for _, p := range r.payouts {
+		if p.Status == repository.PayoutApproved && !p.ScheduledFor.After(now) {
+			cp := *p
+			due = append(due, &cp)
+		}
+	}
+
+	sort.Slice(due, func(i, j int) bool { return due[i].ScheduledFor.Before(due[j].ScheduledFor) })
+	if len(due) > limit {
+		due = due[:limit]
+	}
+	return due, nil
+}
+
+// UpdatePayoutStatus applies an allowed status change
+func (r *MemoryPayoutRepo) UpdatePayoutStatus(ctx context.Context, id string, update *repository.PayoutUpdate) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	p, ok := r.payouts[id]
+	if !ok {
+		return repository.ErrPayoutNotFound
+	}
+	if !p.CanMoveTo(update.Status) {
+		return repository.ErrPayoutClosed
+	}
+
+	ts := now()
+	p.Status = update.Status
+	if update.ReviewedBy != nil {
+		p.ReviewedBy = update.ReviewedBy
+		p.ReviewedAt = &ts
+	}
+	if update.SenderAddress != nil {
+		p.SenderAddress = update.SenderAddress
+	}
+	if update.TxHash != nil {
+		p.TxHash = update.TxHash
+		p.SubmittedAt = &ts
+	}
+	p.BlockNumber = update.BlockNumber
+	p.GasUsed = update.GasUsed
+	p.FeeWei = update.FeeWei
+	p.ErrorMessage = update.ErrorMessage
+	p.UpdatedAt = ts
+	p.ConfirmedAt = nil
+	if update.Status == repository.PayoutConfirmed {
+		p.ConfirmedAt = &ts
+	}
+	return nil
+}
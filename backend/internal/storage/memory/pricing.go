@@ -0,0 +1,260 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure MemoryPricingRepo implements PricingRepository
+var _ repository.PricingRepository = (*MemoryPricingRepo)(nil)
+
+// MemoryPricingRepo implements PricingRepository in memory
+type MemoryPricingRepo struct {
+	mu      sync.RWMutex
+	pricing map[string]*repository.Pricing       // by service_code
+	methods map[string]*repository.PaymentMethod // by method_code
+	history []*repository.PricingHistoryEntry
+}
+
+// NewMemoryPricingRepo creates a new in-memory pricing repository
+func NewMemoryPricingRepo() *MemoryPricingRepo {
+	return &MemoryPricingRepo{
+		pricing: make(map[string]*repository.Pricing),
+		methods: make(map[string]*repository.PaymentMethod),
+	}
+}
+
+// AddPricing inserts or replaces a pricing entry (seeding)
+func (r *MemoryPricingRepo) AddPricing(p *repository.Pricing) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cp := *p
+	if cp.ID == "" {
+		cp.ID = newID()
+	}
+	if cp.CreatedAt.IsZero() {
+		cp.CreatedAt = now()
+		cp.UpdatedAt = cp.CreatedAt
+	}
+	if cp.Version == 0 {
+		cp.Version = 1
+	}
+	r.pricing[cp.ServiceCode] = &cp
+}
+
+// AddPaymentMethod inserts or replaces a payment method (seeding)
+func (r *MemoryPricingRepo) AddPaymentMethod(pm *repository.PaymentMethod) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cp := *pm
+	if cp.ID == "" {
+		cp.ID = newID()
+	}
+	if cp.CreatedAt.IsZero() {
+		cp.CreatedAt = now()
+		cp.UpdatedAt = cp.CreatedAt
+	}
+	if cp.Version == 0 {
+		cp.Version = 1
+	}
+	r.methods[cp.MethodCode] = &cp
+}
+
+// GetPricing retrieves pricing for a service by code
+func (r *MemoryPricingRepo) GetPricing(ctx context.Context, serviceCode string) (*repository.Pricing, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	p, ok := r.pricing[serviceCode]
+	if !ok {
+		return nil, repository.ErrPricingNotFound
+	}
+	cp := *p
+	return &cp, nil
+}
+
+// ListPricing retrieves all pricing entries ordered by service code
+func (r *MemoryPricingRepo) ListPricing(ctx context.Context, activeOnly bool) ([]*repository.Pricing, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []*repository.Pricing
+	for _, p := range r.pricing {
+		if activeOnly && !p.IsActive {
+			continue
+		}
+		cp := *p
+		result = append(result, &cp)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ServiceCode < result[j].ServiceCode
+	})
+
+	return result, nil
+}
+
+// UpdatePricing updates pricing for a service, recording price changes in history
+func (r *MemoryPricingRepo) UpdatePricing(ctx context.Context, serviceCode string, update *repository.PricingUpdate) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	p, ok := r.pricing[serviceCode]
+	if !ok {
+		return repository.ErrPricingNotFound
+	}
+	if update.ExpectedVersion != nil && *update.ExpectedVersion != p.Version {
+		return repository.ErrVersionConflict
+	}
+
+	old := *p
+	p.Version++
+	p.UpdatedBy = update.UpdatedBy
+	if update.PriceUSD != nil {
+		p.PriceUSD = *update.PriceUSD
+	}
+	if update.PriceETH != nil {
+		v := *update.PriceETH
+		p.PriceETH = &v
+	}
+	if update.PriceNEXUS != nil {
+		v := *update.PriceNEXUS
+		p.PriceNEXUS = &v
+	}
+	if update.MarkupPercent != nil {
+		p.MarkupPercent = *update.MarkupPercent
+	}
+	if update.IsActive != nil {
+		p.IsActive = *update.IsActive
+	}
+	p.UpdatedAt = now()
+
+	// Mirrors the log_pricing_change trigger
+	if old.PriceUSD != p.PriceUSD ||
+		distinct(old.PriceETH, p.PriceETH) ||
+		distinct(old.PriceNEXUS, p.PriceNEXUS) ||
+		old.MarkupPercent != p.MarkupPercent {
+
+		changedBy := p.UpdatedBy
+		if changedBy == "" {
+			changedBy = "system"
+		}
+		oldUSD, newUSD := old.PriceUSD, p.PriceUSD
+		oldMarkup, newMarkup := old.MarkupPercent, p.MarkupPercent
+		r.history = append(r.history, &repository.PricingHistoryEntry{
+			ID:               newID(),
+			PricingID:        p.ID,
+			OldPriceUSD:      &oldUSD,
+			OldPriceETH:      old.PriceETH,
+			OldPriceNEXUS:    old.PriceNEXUS,
+			OldMarkupPercent: &oldMarkup,
+			NewPriceUSD:      &newUSD,
+			NewPriceETH:      p.PriceETH,
+			NewPriceNEXUS:    p.PriceNEXUS,
+			NewMarkupPercent: &newMarkup,
+			ChangedBy:        changedBy,
+			ChangedAt:        p.UpdatedAt,
+			ChangeReason:     "Price update",
+		})
+	}
+
+	return nil
+}
+
+// GetPaymentMethod retrieves a payment method by code
+func (r *MemoryPricingRepo) GetPaymentMethod(ctx context.Context, methodCode string) (*repository.PaymentMethod, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	pm, ok := r.methods[methodCode]
+	if !ok {
+		return nil, repository.ErrPaymentMethodNotFound
+	}
+	cp := *pm
+	return &cp, nil
+}
+
+// ListPaymentMethods retrieves all payment methods ordered for display
+func (r *MemoryPricingRepo) ListPaymentMethods(ctx context.Context, activeOnly bool) ([]*repository.PaymentMethod, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []*repository.PaymentMethod
+	for _, pm := range r.methods {
+		if activeOnly && !pm.IsActive {
+			continue
+		}
+		cp := *pm
+		result = append(result, &cp)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].DisplayOrder != result[j].DisplayOrder {
+			return result[i].DisplayOrder < result[j].DisplayOrder
+		}
+		return result[i].MethodCode < result[j].MethodCode
+	})
+
+	return result, nil
+}
+
+// UpdatePaymentMethod updates a payment method
+func (r *MemoryPricingRepo) UpdatePaymentMethod(ctx context.Context, methodCode string, update *repository.PaymentMethodUpdate) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	pm, ok := r.methods[methodCode]
+	if !ok {
+		return repository.ErrPaymentMethodNotFound
+	}
+	if update.ExpectedVersion != nil && *update.ExpectedVersion != pm.Version {
+		return repository.ErrVersionConflict
+	}
+
+	pm.Version++
+	if update.IsActive != nil {
+		pm.IsActive = *update.IsActive
+	}
+	if update.MinAmountUSD != nil {
+		pm.MinAmountUSD = *update.MinAmountUSD
+	}
+	if update.MaxAmountUSD != nil {
+		v := *update.MaxAmountUSD
+		pm.MaxAmountUSD = &v
+	}
+	if update.FeePercent != nil {
+		pm.FeePercent = *update.FeePercent
+	}
+	if update.DisplayOrder != nil {
+		pm.DisplayOrder = *update.DisplayOrder
+	}
+	pm.UpdatedAt = now()
+
+	return nil
+}
+
+// GetPricingHistory retrieves pricing change history, newest first
+func (r *MemoryPricingRepo) GetPricingHistory(ctx context.Context, serviceCode string, limitN int) ([]*repository.PricingHistoryEntry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	p, ok := r.pricing[serviceCode]
+	if !ok {
+		return nil, nil
+	}
+
+	var result []*repository.PricingHistoryEntry
+	for i := len(r.history) - 1; i >= 0; i-- {
+		if r.history[i].PricingID == p.ID {
+			cp := *r.history[i]
+			result = append(result, &cp)
+		}
+	}
+
+	return limit(result, limitN), nil
+}
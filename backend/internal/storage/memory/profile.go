@@ -0,0 +1,80 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure MemoryProfileRepo implements ProfileRepository
+var _ repository.ProfileRepository = (*MemoryProfileRepo)(nil)
+
+// MemoryProfileRepo implements ProfileRepository in memory
+type MemoryProfileRepo struct {
+	mu       sync.RWMutex
+	profiles map[string]*repository.Profile
+}
+
+// NewMemoryProfileRepo creates a new in-memory profile repository
+func NewMemoryProfileRepo() *MemoryProfileRepo {
+	return &MemoryProfileRepo{profiles: make(map[string]*repository.Profile)}
+}
+
+// UpsertProfile creates or replaces a profile
+func (r *MemoryProfileRepo) UpsertProfile(ctx context.Context, p *repository.Profile) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	p.UpdatedAt = now()
+	p.CreatedAt = p.UpdatedAt
+	if existing, ok := r.profiles[p.Address]; ok {
+		p.CreatedAt = existing.CreatedAt
+	}
+	r.profiles[p.Address] = copyProfile(p)
+	return nil
+}
+
+// GetProfile retrieves the profile of address
+func (r *MemoryProfileRepo) GetProfile(ctx context.Context, address string) (*repository.Profile, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	p, ok := r.profiles[address]
+	if !ok {
+		return nil, repository.ErrProfileNotFound
+	}
+	return copyProfile(p), nil
+}
+
+// DeleteProfile removes the profile of address
+func (r *MemoryProfileRepo) DeleteProfile(ctx context.Context, address string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.profiles[address]; !ok {
+		return repository.ErrProfileNotFound
+	}
+	delete(r.profiles, address)
+	return nil
+}
+
+// GetProfiles returns the profiles of those addresses that have one
+func (r *MemoryProfileRepo) GetProfiles(ctx context.Context, addresses []string) (map[string]*repository.Profile, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	out := make(map[string]*repository.Profile)
+	for _, address := range addresses {
+		if p, ok := r.profiles[address]; ok {
+			out[address] = copyProfile(p)
+		}
+	}
+	return out, nil
+}
+
+// copyProfile copies p
+func copyProfile(p *repository.Profile) *repository.Profile {
+	cp := *p
+	return &cp
+}
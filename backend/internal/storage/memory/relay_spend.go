@@ -0,0 +1,65 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// GetRelaySpend aggregates gas used × gas price over live and archived
+// meta-transactions mined with a receipt
+func (r *MemoryRelayerRepo) GetRelaySpend(ctx context.Context, filter repository.RelaySpendFilter) ([]*repository.RelaySpend, error) {
+	if !filter.GroupBy.Valid() {
+		return nil, fmt.Errorf("unknown relay spend grouping %q", filter.GroupBy)
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	tally := repository.NewRelaySpendTally()
+	for _, txs := range []map[string]*repository.MetaTransaction{r.txs, r.archived} {
+		for _, tx := range txs {
+			if tx.Status != repository.MetaTxStatusConfirmed && tx.Status != repository.MetaTxStatusFailed {
+				continue
+			}
+			if tx.GasUsed == nil || tx.GasPrice == nil {
+				continue
+			}
+			if filter.FromAddress != "" && tx.FromAddress != filter.FromAddress {
+				continue
+			}
+			if filter.ToAddress != "" && tx.ToAddress != filter.ToAddress {
+				continue
+			}
+
+			// Reverted meta-transactions have no confirmed_at; their last
+			// update is the failure
+			minedAt := tx.UpdatedAt
+			if tx.ConfirmedAt != nil {
+				minedAt = *tx.ConfirmedAt
+			}
+			if filter.From != nil && minedAt.Before(*filter.From) {
+				continue
+			}
+			if filter.To != nil && !minedAt.Before(*filter.To) {
+				continue
+			}
+
+			var key string
+			switch filter.GroupBy {
+			case repository.RelaySpendByUser:
+				key = tx.FromAddress
+			case repository.RelaySpendByContract:
+				key = tx.ToAddress
+			case repository.RelaySpendByDay:
+				key = filter.Day(minedAt)
+			}
+			if err := tally.Add(key, *tx.GasUsed, *tx.GasPrice); err != nil {
+				return nil, fmt.Errorf("aggregating relay spend: %w", err)
+			}
+		}
+	}
+
+	return tally.Result(filter.GroupBy), nil
+}
@@ -0,0 +1,228 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure MemoryRelayerRepo implements RelayerRepository
+var _ repository.RelayerRepository = (*MemoryRelayerRepo)(nil)
+
+// MemoryRelayerRepo implements RelayerRepository in memory
+type MemoryRelayerRepo struct {
+	mu       sync.RWMutex
+	txs      map[string]*repository.MetaTransaction
+	archived map[string]*repository.MetaTransaction
+}
+
+// NewMemoryRelayerRepo creates a new in-memory relayer repository
+func NewMemoryRelayerRepo() *MemoryRelayerRepo {
+	return &MemoryRelayerRepo{
+		txs:      make(map[string]*repository.MetaTransaction),
+		archived: make(map[string]*repository.MetaTransaction),
+	}
+}
+
+// CreateMetaTx creates a new meta-transaction record, setting ID and timestamps
+func (r *MemoryRelayerRepo) CreateMetaTx(ctx context.Context, tx *repository.MetaTransaction) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tx.ID = newID()
+	tx.CreatedAt = now()
+	tx.UpdatedAt = tx.CreatedAt
+
+	// Only the inserted columns are stored, as in PostgreSQL
+	r.txs[tx.ID] = &repository.MetaTransaction{
+		ID:           tx.ID,
+		FromAddress:  tx.FromAddress,
+		ToAddress:    tx.ToAddress,
+		FunctionName: tx.FunctionName,
+		Calldata:     tx.Calldata,
+		Value:        tx.Value,
+		GasLimit:     tx.GasLimit,
+		Nonce:        tx.Nonce,
+		Deadline:     tx.Deadline,
+		Signature:    tx.Signature,
+		Status:       tx.Status,
+		CreatedAt:    tx.CreatedAt,
+		UpdatedAt:    tx.UpdatedAt,
+	}
+
+	return nil
+}
+
+// CreateMetaTxBatch creates each meta-transaction in turn
+func (r *MemoryRelayerRepo) CreateMetaTxBatch(ctx context.Context, txs []*repository.MetaTransaction) error {
+	for _, tx := range txs {
+		if err := r.CreateMetaTx(ctx, tx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetMetaTx retrieves a meta-transaction by ID
+func (r *MemoryRelayerRepo) GetMetaTx(ctx context.Context, id string) (*repository.MetaTransaction, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	tx, ok := r.txs[id]
+	if !ok {
+		return nil, repository.ErrMetaTxNotFound
+	}
+	cp := *tx
+	return &cp, nil
+}
+
+// GetMetaTxByHash retrieves a meta-transaction by its on-chain transaction hash
+func (r *MemoryRelayerRepo) GetMetaTxByHash(ctx context.Context, txHash string) (*repository.MetaTransaction, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, tx := range r.txs {
+		if tx.TxHash != nil && *tx.TxHash == txHash {
+			cp := *tx
+			return &cp, nil
+		}
+	}
+	return nil, repository.ErrMetaTxNotFound
+}
+
+// UpdateMetaTxStatus updates the status and related fields of a meta-transaction
+func (r *MemoryRelayerRepo) UpdateMetaTxStatus(ctx context.Context, id string, update *repository.MetaTxStatusUpdate) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tx, ok := r.txs[id]
+	if !ok {
+		return repository.ErrMetaTxNotFound
+	}
+
+	tx.Status = update.Status
+	tx.UpdatedAt = now()
+
+	if update.TxHash != nil {
+		v := *update.TxHash
+		tx.TxHash = &v
+	}
+	if update.GasUsed != nil {
+		v := *update.GasUsed
+		tx.GasUsed = &v
+	}
+	if update.GasPrice != nil {
+		v := *update.GasPrice
+		tx.GasPrice = &v
+	}
+	if update.RelayCostETH != nil {
+		v := *update.RelayCostETH
+		tx.RelayCostETH = &v
+	}
+	if update.ErrorMessage != nil {
+		v := *update.ErrorMessage
+		tx.ErrorMessage = &v
+	}
+
+	// Set timestamp based on status
+	ts := tx.UpdatedAt
+	switch update.Status {
+	case repository.MetaTxStatusSubmitted:
+		tx.SubmittedAt = &ts
+	case repository.MetaTxStatusConfirmed:
+		tx.ConfirmedAt = &ts
+	}
+
+	return nil
+}
+
+// ListMetaTx lists meta-transactions with filtering and pagination, newest first
+func (r *MemoryRelayerRepo) ListMetaTx(ctx context.Context, filter repository.MetaTxFilter, page repository.Pagination) ([]*repository.MetaTransaction, int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var matched []*repository.MetaTransaction
+	for _, tx := range r.txs {
+		if filter.FromAddress != "" && tx.FromAddress != filter.FromAddress {
+			continue
+		}
+		if filter.ToAddress != "" && tx.ToAddress != filter.ToAddress {
+			continue
+		}
+		if filter.FunctionName != "" && tx.FunctionName != filter.FunctionName {
+			continue
+		}
+		if filter.Status != "" && tx.Status != filter.Status {
+			continue
+		}
+		cp := *tx
+		matched = append(matched, &cp)
+	}
+
+	sortNewestFirst(matched, func(tx *repository.MetaTransaction) time.Time { return tx.CreatedAt })
+
+	return paginate(matched, page, 20), int64(len(matched)), nil
+}
+
+// GetNextNonce returns one past the highest nonce used by live meta-transactions
+func (r *MemoryRelayerRepo) GetNextNonce(ctx context.Context, fromAddress string) (uint64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var maxNonce uint64
+	for _, tx := range r.txs {
+		if tx.FromAddress != fromAddress {
+			continue
+		}
+		switch tx.Status {
+		case repository.MetaTxStatusFailed, repository.MetaTxStatusExpired, repository.MetaTxStatusCancelled:
+			continue
+		}
+		if tx.Nonce > maxNonce {
+			maxNonce = tx.Nonce
+		}
+	}
+
+	return maxNonce + 1, nil
+}
+
+// GetPendingMetaTxs retrieves pending meta-transactions ready for submission, oldest first
+func (r *MemoryRelayerRepo) GetPendingMetaTxs(ctx context.Context, limitN int) ([]*repository.MetaTransaction, error) {
+	result := r.pending(func(tx *repository.MetaTransaction, t time.Time) bool { return tx.Deadline.After(t) })
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].CreatedAt.Before(result[j].CreatedAt)
+	})
+
+	return limit(result, limitN), nil
+}
+
+// GetExpiredMetaTxs retrieves pending meta-transactions past their deadline, earliest deadline first
+func (r *MemoryRelayerRepo) GetExpiredMetaTxs(ctx context.Context, limitN int) ([]*repository.MetaTransaction, error) {
+	result := r.pending(func(tx *repository.MetaTransaction, t time.Time) bool { return !tx.Deadline.After(t) })
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Deadline.Before(result[j].Deadline)
+	})
+
+	return limit(result, limitN), nil
+}
+
+// pending returns copies of pending meta-transactions matching the deadline predicate
+func (r *MemoryRelayerRepo) pending(match func(*repository.MetaTransaction, time.Time) bool) []*repository.MetaTransaction {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	t := time.Now()
+	var result []*repository.MetaTransaction
+	for _, tx := range r.txs {
+		if tx.Status == repository.MetaTxStatusPending && match(tx, t) {
+			cp := *tx
+			result = append(result, &cp)
+		}
+	}
+	return result
+}
@@ -0,0 +1,188 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure MemoryReportRepo implements ReportRepository
+var _ repository.ReportRepository = (*MemoryReportRepo)(nil)
+
+// MemoryReportRepo implements ReportRepository in memory
+type MemoryReportRepo struct {
+	mu        sync.RWMutex
+	schedules map[string]*repository.ReportSchedule
+	runs      map[string]*repository.ReportRun
+}
+
+// NewMemoryReportRepo creates a new in-memory report repository
+func NewMemoryReportRepo() *MemoryReportRepo {
+	return &MemoryReportRepo{
+		schedules: make(map[string]*repository.ReportSchedule),
+		runs:      make(map[string]*repository.ReportRun),
+	}
+}
+
+// copySchedule returns a copy of s that shares no slices with it
+func copySchedule(s *repository.ReportSchedule) *repository.ReportSchedule {
+	cp := *s
+	cp.Recipients = append([]string{}, s.Recipients...)
+	return &cp
+}
+
+// CreateReportSchedule records a schedule
+func (r *MemoryReportRepo) CreateReportSchedule(ctx context.Context, s *repository.ReportSchedule) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ts := now()
+	s.ID = newID()
+	s.CreatedAt = ts
+	s.UpdatedAt = ts
+	r.schedules[s.ID] = copySchedule(s)
+	return nil
+}
+
+// GetReportSchedule retrieves a schedule by ID
+func (r *MemoryReportRepo) GetReportSchedule(ctx context.Context, id string) (*repository.ReportSchedule, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	s, ok := r.schedules[id]
+	if !ok {
+		return nil, repository.ErrReportScheduleNotFound
+	}
+	return copySchedule(s), nil
+}
+
+// UpdateReportSchedule replaces a schedule's settings
+func (r *MemoryReportRepo) UpdateReportSchedule(ctx context.Context, s *repository.ReportSchedule) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current, ok := r.schedules[s.ID]
+	if !ok {
+		return repository.ErrReportScheduleNotFound
+	}
+	current.Name = s.Name
+	current.Kind = s.Kind
+	current.Cron = s.Cron
+	current.Timezone = s.Timezone
+	current.Recipients = append([]string{}, s.Recipients...)
+	current.Enabled = s.Enabled
+	current.NextRunAt = s.NextRunAt
+	current.UpdatedAt = now()
+	s.LastRunAt = current.LastRunAt
+	s.CreatedBy = current.CreatedBy
+	s.CreatedAt = current.CreatedAt
+	s.UpdatedAt = current.UpdatedAt
+	return nil
+}
+
+// DeleteReportSchedule removes a schedule; its runs are kept
+func (r *MemoryReportRepo) DeleteReportSchedule(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.schedules[id]; !ok {
+		return repository.ErrReportScheduleNotFound
+	}
+	delete(r.schedules, id)
+	return nil
+}
+
+// ListReportSchedules lists schedules, newest first
+func (r *MemoryReportRepo) ListReportSchedules(ctx context.Context, page repository.Pagination) ([]*repository.ReportSchedule, int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var matched []*repository.ReportSchedule
+	for _, s := range r.schedules {
+		matched = append(matched, copySchedule(s))
+	}
+
+	sortNewestFirst(matched, func(s *repository.ReportSchedule) time.Time { return s.CreatedAt })
+
+	return paginate(matched, page, 20), int64(len(matched)), nil
+}
+
+// ListDueReportSchedules lists enabled schedules due at or before now
+func (r *MemoryReportRepo) ListDueReportSchedules(ctx context.Context, now time.Time, limit int) ([]*repository.ReportSchedule, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var due []*repository.ReportSchedule
+	for _, s := range r.schedules {
+		if s.Enabled && !s.NextRunAt.After(now) {
+			due = append(due, copySchedule(s))
+		}
+	}
+
+	sort.Slice(due, func(i, j int) bool { return due[i].NextRunAt.Before(due[j].NextRunAt) })
+	if len(due) > limit {
+		due = due[:limit]
+	}
+	return due, nil
+}
+
+// ClaimReportSchedule moves a due schedule's next run from due to next
+func (r *MemoryReportRepo) ClaimReportSchedule(ctx context.Context, id string, due, next time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s, ok := r.schedules[id]
+	if !ok || !s.Enabled || !s.NextRunAt.Equal(due) {
+		return false, nil
+	}
+	last := due
+	s.LastRunAt = &last
+	s.NextRunAt = next
+	return true, nil
+}
+
+// CreateReportRun records a finished run
+func (r *MemoryReportRepo) CreateReportRun(ctx context.Context, run *repository.ReportRun) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	run.ID = newID()
+	cp := *run
+	r.runs[run.ID] = &cp
+	return nil
+}
+
+// GetReportRun retrieves a run by ID
+func (r *MemoryReportRepo) GetReportRun(ctx context.Context, id string) (*repository.ReportRun, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	run, ok := r.runs[id]
+	if !ok {
+		return nil, repository.ErrReportRunNotFound
+	}
+	cp := *run
+	return &cp, nil
+}
+
+// ListReportRuns lists runs, newest first
+func (r *MemoryReportRepo) ListReportRuns(ctx context.Context, scheduleID string, page repository.Pagination) ([]*repository.ReportRun, int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var matched []*repository.ReportRun
+	for _, run := range r.runs {
+		if scheduleID != "" && run.ScheduleID != scheduleID {
+			continue
+		}
+		cp := *run
+		matched = append(matched, &cp)
+	}
+
+	sortNewestFirst(matched, func(run *repository.ReportRun) time.Time { return run.StartedAt })
+
+	return paginate(matched, page, 20), int64(len(matched)), nil
+}
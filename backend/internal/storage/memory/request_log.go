@@ -0,0 +1,122 @@
+package memory
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure MemoryRequestLogRepo implements RequestLogRepository
+var _ repository.RequestLogRepository = (*MemoryRequestLogRepo)(nil)
+
+// maxRequestLogEntries bounds memory use under load tests; the oldest
+// entries are dropped first
+const maxRequestLogEntries = 100000
+
+// MemoryRequestLogRepo implements RequestLogRepository in memory
+type MemoryRequestLogRepo struct {
+	mu      sync.RWMutex
+	entries []*repository.RequestLogEntry // insertion order
+}
+
+// NewMemoryRequestLogRepo creates a new in-memory request log repository
+func NewMemoryRequestLogRepo() *MemoryRequestLogRepo {
+	return &MemoryRequestLogRepo{}
+}
+
+// InsertRequestLogs archives a batch of request log entries
+func (r *MemoryRequestLogRepo) InsertRequestLogs(ctx context.Context, entries []*repository.RequestLogEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, e := range entries {
+		cp := *e
+		cp.ID = newID()
+		if cp.CreatedAt.IsZero() {
+			cp.CreatedAt = now()
+		}
+		r.entries = append(r.entries, &cp)
+	}
+
+	if overflow := len(r.entries) - maxRequestLogEntries; overflow > 0 {
+		r.entries = append(r.entries[:0:0], r.entries[overflow:]...)
+	}
+
+	return nil
+}
+
+// GetRequestLog retrieves a single archived request by ID
+func (r *MemoryRequestLogRepo) GetRequestLog(ctx context.Context, id string) (*repository.RequestLogEntry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, e := range r.entries {
+		if e.ID == id {
+			cp := *e
+			return &cp, nil
+		}
+	}
+	return nil, repository.ErrRequestLogNotFound
+}
+
+// ListRequestLogs lists archived requests with filtering, newest first
+func (r *MemoryRequestLogRepo) ListRequestLogs(ctx context.Context, filter repository.RequestLogFilter, page repository.Pagination) ([]*repository.RequestLogEntry, int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var matched []*repository.RequestLogEntry
+	for _, e := range r.entries {
+		if filter.RequestID != "" && e.RequestID != filter.RequestID {
+			continue
+		}
+		if filter.Method != "" && e.Method != filter.Method {
+			continue
+		}
+		if filter.Path != "" && !strings.HasPrefix(e.Path, filter.Path) {
+			continue
+		}
+		if filter.StatusCode != 0 && e.StatusCode != filter.StatusCode {
+			continue
+		}
+		if filter.MinStatus != 0 && e.StatusCode < filter.MinStatus {
+			continue
+		}
+		if filter.ClientIP != "" && e.ClientIP != filter.ClientIP {
+			continue
+		}
+		if filter.From != nil && e.CreatedAt.Before(*filter.From) {
+			continue
+		}
+		if filter.To != nil && !e.CreatedAt.Before(*filter.To) {
+			continue
+		}
+		cp := *e
+		matched = append(matched, &cp)
+	}
+
+	sortNewestFirst(matched, func(e *repository.RequestLogEntry) time.Time { return e.CreatedAt })
+
+	return paginate(matched, page, 50), int64(len(matched)), nil
+}
+
+// DeleteRequestLogsBefore removes archived requests older than the cutoff
+func (r *MemoryRequestLogRepo) DeleteRequestLogsBefore(ctx context.Context, before time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	kept := r.entries[:0]
+	var deleted int64
+	for _, e := range r.entries {
+		if e.CreatedAt.Before(before) {
+			deleted++
+			continue
+		}
+		kept = append(kept, e)
+	}
+	r.entries = kept
+
+	return deleted, nil
+}
@@ -0,0 +1,165 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure MemorySafeTransactionRepo implements SafeTransactionRepository
+var _ repository.SafeTransactionRepository = (*MemorySafeTransactionRepo)(nil)
+
+// MemorySafeTransactionRepo implements SafeTransactionRepository in memory
+type MemorySafeTransactionRepo struct {
+	mu            sync.RWMutex
+	txs           map[string]*repository.SafeTransaction
+	confirmations map[string][]*repository.SafeConfirmation // safe tx ID -> confirmations
+}
+
+// NewMemorySafeTransactionRepo creates a new in-memory Safe transaction repository
+func NewMemorySafeTransactionRepo() *MemorySafeTransactionRepo {
+	return &MemorySafeTransactionRepo{
+		txs:           make(map[string]*repository.SafeTransaction),
+		confirmations: make(map[string][]*repository.SafeConfirmation),
+	}
+}
+
+// CreateSafeTransaction records a proposed transaction
+func (r *MemorySafeTransactionRepo) CreateSafeTransaction(ctx context.Context, tx *repository.SafeTransaction) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.txs {
+		if existing.SafeTxHash == tx.SafeTxHash {
+			return repository.ErrSafeTransactionExists
+		}
+	}
+
+	ts := now()
+	tx.ID = newID()
+	tx.Confirmations = 0
+	tx.CreatedAt = ts
+	tx.UpdatedAt = ts
+	cp := *tx
+	r.txs[tx.ID] = &cp
+	return nil
+}
+
+// GetSafeTransaction retrieves a Safe transaction by ID
+func (r *MemorySafeTransactionRepo) GetSafeTransaction(ctx context.Context, id string) (*repository.SafeTransaction, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	tx, ok := r.txs[id]
+	if !ok {
+		return nil, repository.ErrSafeTransactionNotFound
+	}
+	cp := *tx
+	return &cp, nil
+}
+
+// ListSafeTransactions lists Safe transactions with filtering, newest first
+func (r *MemorySafeTransactionRepo) ListSafeTransactions(ctx context.Context, filter repository.SafeTransactionFilter, page repository.Pagination) ([]*repository.SafeTransaction, int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var matched []*repository.SafeTransaction
+	for _, tx := range r.txs {
+		if filter.SafeAddress != "" && tx.SafeAddress != filter.SafeAddress {
+			continue
+		}
+		if filter.Status != "" && tx.Status != filter.Status {
+			continue
+		}
+		cp := *tx
+		matched = append(matched, &cp)
+	}
+
+	sortNewestFirst(matched, func(tx *repository.SafeTransaction) time.Time { return tx.CreatedAt })
+
+	return paginate(matched, page, 20), int64(len(matched)), nil
+}
+
+// AddSafeConfirmation records an owner's signature, marking the transaction
+// ready once the threshold is reached
+func (r *MemorySafeTransactionRepo) AddSafeConfirmation(ctx context.Context, c *repository.SafeConfirmation) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tx, ok := r.txs[c.SafeTxID]
+	if !ok {
+		return repository.ErrSafeTransactionNotFound
+	}
+	if !tx.TakesConfirmations() {
+		return repository.ErrSafeTransactionClosed
+	}
+	for _, existing := range r.confirmations[c.SafeTxID] {
+		if existing.Owner == c.Owner {
+			return repository.ErrSafeConfirmationExists
+		}
+	}
+
+	ts := now()
+	c.CreatedAt = ts
+	cp := *c
+	r.confirmations[c.SafeTxID] = append(r.confirmations[c.SafeTxID], &cp)
+
+	tx.Confirmations++
+	if tx.Status == repository.SafeTxAwaitingConfirmations && tx.Confirmations >= tx.Threshold {
+		tx.Status = repository.SafeTxReady
+	}
+	tx.UpdatedAt = ts
+	return nil
+}
+
+// ListSafeConfirmations lists a transaction's confirmations by owner
+func (r *MemorySafeTransactionRepo) ListSafeConfirmations(ctx context.Context, safeTxID string) ([]*repository.SafeConfirmation, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	out := make([]*repository.SafeConfirmation, 0, len(r.confirmations[safeTxID]))
+	for _, c := range r.confirmations[safeTxID] {
+		cp := *c
+		out = append(out, &cp)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Owner < out[j].Owner })
+	return out, nil
+}
+
+// UpdateSafeTransactionStatus applies an allowed status change
+func (r *MemorySafeTransactionRepo) UpdateSafeTransactionStatus(ctx context.Context, id string, update *repository.SafeTransactionUpdate) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tx, ok := r.txs[id]
+	if !ok {
+		return repository.ErrSafeTransactionNotFound
+	}
+	if !tx.CanMoveTo(update.Status) {
+		return repository.ErrSafeTransactionClosed
+	}
+
+	ts := now()
+	tx.Status = update.Status
+	if update.Threshold != nil {
+		tx.Threshold = *update.Threshold
+	}
+	if update.ExecutorAddress != nil {
+		tx.ExecutorAddress = update.ExecutorAddress
+	}
+	if update.TxHash != nil {
+		tx.TxHash = update.TxHash
+		tx.SubmittedAt = &ts
+	}
+	tx.BlockNumber = update.BlockNumber
+	tx.ErrorMessage = update.ErrorMessage
+	tx.UpdatedAt = ts
+	tx.ExecutedAt = nil
+	if update.Status == repository.SafeTxExecuted {
+		tx.ExecutedAt = &ts
+	}
+	return nil
+}
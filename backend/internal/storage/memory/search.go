@@ -0,0 +1,155 @@
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure MemorySearchRepo implements SearchRepository
+var _ repository.SearchRepository = (*MemorySearchRepo)(nil)
+
+// MemorySearchRepo implements SearchRepository over the in-memory payment and
+// relayer stores. Matching is a case-insensitive substring test and the score
+// is the share of the matched value covered by the term, as in SQLite.
+type MemorySearchRepo struct {
+	payments *MemoryPaymentRepo
+	relayer  *MemoryRelayerRepo
+}
+
+// NewMemorySearchRepo creates a search repository over payments and relayer
+func NewMemorySearchRepo(payments *MemoryPaymentRepo, relayer *MemoryRelayerRepo) *MemorySearchRepo {
+	return &MemorySearchRepo{payments: payments, relayer: relayer}
+}
+
+// searchField is one candidate column of a record
+type searchField struct {
+	name  string
+	value *string
+}
+
+// Search finds payments, KYC verifications and meta-transactions whose
+// identifiers contain the term
+func (r *MemorySearchRepo) Search(ctx context.Context, query repository.SearchQuery) ([]*repository.SearchResult, error) {
+	limitN := query.Limit
+	if limitN <= 0 {
+		limitN = 20
+	}
+	term := strings.ToLower(strings.TrimSpace(query.Term))
+
+	var results []*repository.SearchResult
+
+	if query.Includes(repository.SearchResultPayment) {
+		var found []*repository.SearchResult
+		r.payments.mu.RLock()
+		for _, p := range r.payments.payments {
+			field, score, ok := matchFields(term,
+				searchField{"tx_hash", p.TxHash},
+				searchField{"stripe_session_id", p.StripeSessionID},
+				searchField{"stripe_payment_id", p.StripePaymentID},
+				searchField{"payer_address", &p.PayerAddress},
+			)
+			if !ok {
+				continue
+			}
+			cp := *p
+			found = append(found, &repository.SearchResult{
+				Type: repository.SearchResultPayment, ID: p.ID, MatchedField: field,
+				Score: score, CreatedAt: p.CreatedAt, Payment: &cp,
+			})
+		}
+		r.payments.mu.RUnlock()
+		results = append(results, topResults(found, limitN)...)
+	}
+
+	if query.Includes(repository.SearchResultKYCVerification) {
+		var found []*repository.SearchResult
+		r.payments.mu.RLock()
+		for _, v := range r.payments.verifications {
+			var review *string
+			if v.SumsubReviewResult != nil {
+				if b, err := json.Marshal(v.SumsubReviewResult); err == nil {
+					s := string(b)
+					review = &s
+				}
+			}
+			field, score, ok := matchFields(term,
+				searchField{"user_address", &v.UserAddress},
+				searchField{"sumsub_applicant_id", v.SumsubApplicantID},
+				searchField{"whitelist_tx_hash", v.WhitelistTxHash},
+				searchField{"sumsub_review_result", review},
+			)
+			if !ok {
+				continue
+			}
+			cp := *v
+			found = append(found, &repository.SearchResult{
+				Type: repository.SearchResultKYCVerification, ID: v.ID, MatchedField: field,
+				Score: score, CreatedAt: v.CreatedAt, KYCVerification: &cp,
+			})
+		}
+		r.payments.mu.RUnlock()
+		results = append(results, topResults(found, limitN)...)
+	}
+
+	if query.Includes(repository.SearchResultMetaTx) {
+		var found []*repository.SearchResult
+		r.relayer.mu.RLock()
+		for _, tx := range r.relayer.txs {
+			field, score, ok := matchFields(term,
+				searchField{"tx_hash", tx.TxHash},
+				searchField{"from_address", &tx.FromAddress},
+				searchField{"to_address", &tx.ToAddress},
+			)
+			if !ok {
+				continue
+			}
+			cp := *tx
+			found = append(found, &repository.SearchResult{
+				Type: repository.SearchResultMetaTx, ID: tx.ID, MatchedField: field,
+				Score: score, CreatedAt: tx.CreatedAt, MetaTransaction: &cp,
+			})
+		}
+		r.relayer.mu.RUnlock()
+		results = append(results, topResults(found, limitN)...)
+	}
+
+	sortSearchResults(results)
+
+	return results, nil
+}
+
+// matchFields returns the first field containing term and its score
+func matchFields(term string, fields ...searchField) (string, float64, bool) {
+	if term == "" {
+		return "", 0, false
+	}
+	for _, f := range fields {
+		if f.value == nil || *f.value == "" {
+			continue
+		}
+		if strings.Contains(strings.ToLower(*f.value), term) {
+			return f.name, float64(len(term)) / float64(len(*f.value)), true
+		}
+	}
+	return "", 0, false
+}
+
+// topResults returns the limitN best results
+func topResults(results []*repository.SearchResult, limitN int) []*repository.SearchResult {
+	sortSearchResults(results)
+	return limit(results, limitN)
+}
+
+// sortSearchResults orders results by score, then newest first
+func sortSearchResults(results []*repository.SearchResult) {
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].CreatedAt.After(results[j].CreatedAt)
+	})
+}
@@ -0,0 +1,106 @@
+package memory
+
+import (
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// NewSeededStore creates an in-memory store preloaded with the same demo
+// pricing, payment methods, networks and contract mappings as init-db.sql
+func NewSeededStore() *Store {
+	s := NewStore()
+	Seed(s)
+	return s
+}
+
+// Seed loads the init-db.sql demo data into a store
+func Seed(s *Store) {
+	for _, pm := range []*repository.PaymentMethod{
+		{MethodCode: "nexus", MethodName: "NEXUS Token", IsActive: true, FeePercent: 0, DisplayOrder: 1,
+			ProcessorConfig: map[string]interface{}{"contract": "NexusToken", "discount_percent": float64(10)}},
+		{MethodCode: "eth", MethodName: "Ethereum (ETH)", IsActive: true, FeePercent: 0, DisplayOrder: 2,
+			ProcessorConfig: map[string]interface{}{"min_confirmations": float64(2)}},
+		{MethodCode: "stripe", MethodName: "Credit Card (Stripe)", IsActive: true, FeePercent: 2.9, DisplayOrder: 3,
+			ProcessorConfig: map[string]interface{}{"currency": "usd", "payment_method_types": []interface{}{"card"}}},
+	} {
+		s.Pricing.AddPaymentMethod(pm)
+	}
+
+	for _, p := range []struct {
+		code, name, description string
+		cost                    float64
+		provider                string
+		usd, eth, nexus, markup float64
+	}{
+		{"kyc_verification", "KYC Identity Verification", "Full identity verification with document check and AML screening via Sumsub", 5.00, "sumsub", 15.00, 0.005, 150, 200.00},
+		{"kyc_aml_recheck", "AML Re-screening", "Periodic AML/sanctions re-check for existing users", 1.00, "sumsub", 3.00, 0.001, 30, 200.00},
+		{"kyc_enhanced", "Enhanced Due Diligence", "Enhanced verification for high-value accounts", 15.00, "sumsub", 45.00, 0.015, 450, 200.00},
+		{"meta_tx_relay", "Meta-Transaction Relay", "Gasless transaction relay fee per meta-transaction", 0.10, "gas", 0.50, 0.000167, 5, 400.00},
+		{"nft_mint", "NFT Minting Fee", "Platform fee for minting new NFTs (includes gas subsidy)", 5.00, "platform", 25.00, 0.00833, 250, 400.00},
+		{"premium_monthly", "Premium Features (Monthly)", "Monthly subscription for premium platform features", 2.00, "platform", 10.00, 0.00333, 100, 400.00},
+		{"governance_proposal", "Governance Proposal Fee", "Fee for submitting governance proposals (refundable if passed)", 0, "platform", 10.00, 0.00333, 100, 0},
+		{"kyc_verification_10", "KYC Verification Credits (10)", "Ten prepaid KYC identity verifications, spent one per check", 50.00, "sumsub", 135.00, 0.045, 1350, 170.00},
+	} {
+		eth, nexus := p.eth, p.nexus
+		s.Pricing.AddPricing(&repository.Pricing{
+			ServiceCode:   p.code,
+			ServiceName:   p.name,
+			Description:   p.description,
+			CostUSD:       p.cost,
+			CostProvider:  p.provider,
+			PriceUSD:      p.usd,
+			PriceETH:      &eth,
+			PriceNEXUS:    &nexus,
+			MarkupPercent: p.markup,
+			IsActive:      true,
+		})
+	}
+
+	s.Contracts.AddNetwork(&repository.NetworkConfig{
+		ChainID:         31337,
+		NetworkName:     "localhost",
+		DisplayName:     "Local Development (Anvil)",
+		RPCUrl:          strPtr("http://localhost:8545"),
+		WSUrl:           strPtr("ws://localhost:8545"),
+		DefaultDeployer: strPtr("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"),
+		IsTestnet:       true,
+		IsActive:        true,
+	})
+	s.Contracts.AddNetwork(&repository.NetworkConfig{
+		ChainID:         11155111,
+		NetworkName:     "sepolia",
+		DisplayName:     "Sepolia Testnet",
+		RPCFallbackUrls: []string{"https://ethereum-sepolia-rpc.publicnode.com", "https://sepolia.drpc.org"},
+		ExplorerUrl:     strPtr("https://sepolia.etherscan.io"),
+		IsTestnet:       true,
+		IsActive:        true,
+	})
+
+	for i, m := range []struct {
+		solidity, db, display, category, description string
+		required                                     bool
+	}{
+		{"NexusToken", "nexusToken", "Nexus Token", "core", "ERC-20 governance token with snapshot, permit, votes", true},
+		{"NexusStaking", "nexusStaking", "Nexus Staking", "defi", "Token staking with rewards and delegation", true},
+		{"NexusNFT", "nexusNFT", "Nexus NFT", "core", "ERC-721A NFT collection with royalties", true},
+		{"NexusAccessControl", "nexusAccessControl", "Access Control", "security", "Role-based access control (ADMIN, OPERATOR, COMPLIANCE, PAUSER)", true},
+		{"NexusKYCRegistry", "nexusKYC", "KYC Registry", "security", "Whitelist/blacklist management for compliance", true},
+		{"NexusEmergency", "nexusEmergency", "Emergency", "security", "Circuit breakers and global pause functionality", true},
+		{"NexusTimelock", "nexusTimelock", "Timelock", "governance", "Governance execution delay (24h minimum)", true},
+		{"NexusGovernor", "nexusGovernor", "Governor", "governance", "DAO governance with proposal/vote system", true},
+		{"NexusForwarder", "nexusForwarder", "Forwarder", "metatx", "ERC-2771 meta-transactions for gasless UX", false},
+		{"RewardsDistributor", "rewardsDistributor", "Rewards Distributor", "defi", "Merkle-based reward distribution", false},
+		{"NexusBridge", "nexusBridge", "Bridge", "bridge", "Lock/mint and burn/release NEXUS bridge between chains", false},
+		{"ChainlinkEthUsd", "ethUsdPriceFeed", "ETH/USD Price Feed", "oracle", "Chainlink ETH/USD aggregator", false},
+		{"ChainlinkNexusUsd", "nexusUsdPriceFeed", "NEXUS/USD Price Feed", "oracle", "Chainlink NEXUS/USD aggregator", false},
+	} {
+		s.Contracts.AddMapping(&repository.ContractMapping{
+			SolidityName: m.solidity,
+			DBName:       m.db,
+			DisplayName:  m.display,
+			Category:     m.category,
+			Description:  strPtr(m.description),
+			IsRequired:   m.required,
+			SortOrder:    i + 1,
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure MemorySnapshotRepo implements SnapshotRepository
+var _ repository.SnapshotRepository = (*MemorySnapshotRepo)(nil)
+
+// MemorySnapshotRepo implements SnapshotRepository in memory
+type MemorySnapshotRepo struct {
+	mu        sync.RWMutex
+	proposals map[string]*repository.SnapshotProposal
+}
+
+// NewMemorySnapshotRepo creates a new in-memory Snapshot proposal repository
+func NewMemorySnapshotRepo() *MemorySnapshotRepo {
+	return &MemorySnapshotRepo{proposals: make(map[string]*repository.SnapshotProposal)}
+}
+
+// UpsertSnapshotProposal records or refreshes a mirrored proposal, keeping its link
+func (r *MemorySnapshotRepo) UpsertSnapshotProposal(ctx context.Context, p *repository.SnapshotProposal) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if existing, ok := r.proposals[p.ID]; ok {
+		p.OnchainProposalID = existing.OnchainProposalID
+	}
+	p.SyncedAt = now()
+	r.proposals[p.ID] = copySnapshotProposal(p)
+	return nil
+}
+
+// GetSnapshotProposal retrieves a mirrored proposal by its Snapshot ID
+func (r *MemorySnapshotRepo) GetSnapshotProposal(ctx context.Context, id string) (*repository.SnapshotProposal, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	p, ok := r.proposals[id]
+	if !ok {
+		return nil, repository.ErrSnapshotProposalNotFound
+	}
+	return copySnapshotProposal(p), nil
+}
+
+// ListSnapshotProposals lists mirrored proposals with filtering, newest first
+func (r *MemorySnapshotRepo) ListSnapshotProposals(ctx context.Context, filter repository.SnapshotProposalFilter, page repository.Pagination) ([]*repository.SnapshotProposal, int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var matched []*repository.SnapshotProposal
+	for _, p := range r.proposals {
+		if filter.Space != "" && p.Space != filter.Space {
+			continue
+		}
+		if filter.State != "" && p.State != filter.State {
+			continue
+		}
+		if filter.OnchainProposalID != "" && (p.OnchainProposalID == nil || *p.OnchainProposalID != filter.OnchainProposalID) {
+			continue
+		}
+		if filter.Unlinked && p.OnchainProposalID != nil {
+			continue
+		}
+		matched = append(matched, copySnapshotProposal(p))
+	}
+
+	sortNewestFirst(matched, func(p *repository.SnapshotProposal) time.Time { return p.CreatedAt })
+
+	return paginate(matched, page, 20), int64(len(matched)), nil
+}
+
+// LinkSnapshotProposal links a mirrored proposal to an on-chain proposal
+func (r *MemorySnapshotRepo) LinkSnapshotProposal(ctx context.Context, id string, onchainProposalID *string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	p, ok := r.proposals[id]
+	if !ok {
+		return repository.ErrSnapshotProposalNotFound
+	}
+	if onchainProposalID != nil {
+		linked := *onchainProposalID
+		onchainProposalID = &linked
+	}
+	p.OnchainProposalID = onchainProposalID
+	return nil
+}
+
+// copySnapshotProposal copies p, including its choices and scores
+func copySnapshotProposal(p *repository.SnapshotProposal) *repository.SnapshotProposal {
+	cp := *p
+	cp.Choices = append([]string(nil), p.Choices...)
+	cp.Scores = append([]float64(nil), p.Scores...)
+	return &cp
+}
@@ -0,0 +1,104 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure MemoryWatchlistRepo implements WatchlistRepository
+var _ repository.WatchlistRepository = (*MemoryWatchlistRepo)(nil)
+
+// MemoryWatchlistRepo implements WatchlistRepository in memory
+type MemoryWatchlistRepo struct {
+	mu         sync.RWMutex
+	watchlists map[string]*repository.Watchlist
+}
+
+// NewMemoryWatchlistRepo creates a new in-memory watchlist repository
+func NewMemoryWatchlistRepo() *MemoryWatchlistRepo {
+	return &MemoryWatchlistRepo{watchlists: make(map[string]*repository.Watchlist)}
+}
+
+// CreateWatchlist stores a watchlist
+func (r *MemoryWatchlistRepo) CreateWatchlist(ctx context.Context, w *repository.Watchlist) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	w.ID = newID()
+	w.CreatedAt = now()
+	w.UpdatedAt = w.CreatedAt
+	r.watchlists[w.ID] = copyWatchlist(w)
+	return nil
+}
+
+// GetWatchlist retrieves a watchlist by ID
+func (r *MemoryWatchlistRepo) GetWatchlist(ctx context.Context, id string) (*repository.Watchlist, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	w, ok := r.watchlists[id]
+	if !ok {
+		return nil, repository.ErrWatchlistNotFound
+	}
+	return copyWatchlist(w), nil
+}
+
+// UpdateWatchlist replaces a watchlist's settings
+func (r *MemoryWatchlistRepo) UpdateWatchlist(ctx context.Context, w *repository.Watchlist) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.watchlists[w.ID]
+	if !ok {
+		return repository.ErrWatchlistNotFound
+	}
+	w.CreatedAt = existing.CreatedAt
+	w.UpdatedAt = now()
+	r.watchlists[w.ID] = copyWatchlist(w)
+	return nil
+}
+
+// DeleteWatchlist removes a watchlist
+func (r *MemoryWatchlistRepo) DeleteWatchlist(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.watchlists[id]; !ok {
+		return repository.ErrWatchlistNotFound
+	}
+	delete(r.watchlists, id)
+	return nil
+}
+
+// ListWatchlists lists watchlists with filtering, newest first
+func (r *MemoryWatchlistRepo) ListWatchlists(ctx context.Context, filter repository.WatchlistFilter, page repository.Pagination) ([]*repository.Watchlist, int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var matched []*repository.Watchlist
+	for _, w := range r.watchlists {
+		if filter.Address != "" && !slices.Contains(w.Addresses, filter.Address) {
+			continue
+		}
+		if filter.Event != "" && !slices.Contains(w.Events, string(filter.Event)) {
+			continue
+		}
+		matched = append(matched, copyWatchlist(w))
+	}
+
+	sortNewestFirst(matched, func(w *repository.Watchlist) time.Time { return w.CreatedAt })
+
+	return paginate(matched, page, 20), int64(len(matched)), nil
+}
+
+// copyWatchlist copies w, including its addresses and events
+func copyWatchlist(w *repository.Watchlist) *repository.Watchlist {
+	cp := *w
+	cp.Addresses = append([]string(nil), w.Addresses...)
+	cp.Events = append([]string(nil), w.Events...)
+	return &cp
+}
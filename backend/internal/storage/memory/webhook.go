@@ -0,0 +1,258 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure MemoryWebhookEventRepo implements WebhookEventRepository
+var _ repository.WebhookEventRepository = (*MemoryWebhookEventRepo)(nil)
+
+// MemoryWebhookEventRepo implements WebhookEventRepository in memory
+type MemoryWebhookEventRepo struct {
+	mu      sync.RWMutex
+	events  map[string]*repository.WebhookEvent
+	byEvent map[string]string // provider + "/" + event_id -> id
+}
+
+// NewMemoryWebhookEventRepo creates a new in-memory webhook event repository
+func NewMemoryWebhookEventRepo() *MemoryWebhookEventRepo {
+	return &MemoryWebhookEventRepo{
+		events:  make(map[string]*repository.WebhookEvent),
+		byEvent: make(map[string]string),
+	}
+}
+
+// CreateWebhookEvent stores a new event, or loads the stored copy if the
+// provider already delivered it
+func (r *MemoryWebhookEventRepo) CreateWebhookEvent(ctx context.Context, e *repository.WebhookEvent) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := e.Provider + "/" + e.EventID
+	if id, ok := r.byEvent[key]; ok {
+		*e = copyWebhookEvent(r.events[id])
+		return false, nil
+	}
+
+	ts := now()
+	e.ID = newID()
+	e.Status = repository.WebhookEventReceived
+	e.Attempts = 0
+	e.LastError = nil
+	e.CreatedAt = ts
+	e.UpdatedAt = ts
+	e.ProcessedAt = nil
+	e.NextAttemptAt = &ts
+
+	cp := copyWebhookEvent(e)
+	r.events[e.ID] = &cp
+	r.byEvent[key] = e.ID
+	return true, nil
+}
+
+// GetWebhookEvent retrieves a webhook event by ID
+func (r *MemoryWebhookEventRepo) GetWebhookEvent(ctx context.Context, id string) (*repository.WebhookEvent, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	e, ok := r.events[id]
+	if !ok {
+		return nil, repository.ErrWebhookEventNotFound
+	}
+	cp := copyWebhookEvent(e)
+	return &cp, nil
+}
+
+// GetWebhookEventByEventID retrieves a webhook event by the provider's event ID
+func (r *MemoryWebhookEventRepo) GetWebhookEventByEventID(ctx context.Context, provider, eventID string) (*repository.WebhookEvent, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	id, ok := r.byEvent[provider+"/"+eventID]
+	if !ok {
+		return nil, repository.ErrWebhookEventNotFound
+	}
+	cp := copyWebhookEvent(r.events[id])
+	return &cp, nil
+}
+
+// ListWebhookEvents lists webhook events with filtering, newest first
+func (r *MemoryWebhookEventRepo) ListWebhookEvents(ctx context.Context, filter repository.WebhookEventFilter, page repository.Pagination) ([]*repository.WebhookEvent, int64, error) {
+	r.mu.RLock()
+	var matched []*repository.WebhookEvent
+	for _, e := range r.events {
+		if filter.Provider != "" && e.Provider != filter.Provider {
+			continue
+		}
+		if filter.EventType != "" && e.EventType != filter.EventType {
+			continue
+		}
+		if filter.Status != "" && e.Status != filter.Status {
+			continue
+		}
+		cp := copyWebhookEvent(e)
+		matched = append(matched, &cp)
+	}
+	r.mu.RUnlock()
+
+	sortNewestFirst(matched, func(e *repository.WebhookEvent) time.Time { return e.CreatedAt })
+	return paginate(matched, page, 50), int64(len(matched)), nil
+}
+
+// ListDueWebhookEvents returns events ready for background processing
+func (r *MemoryWebhookEventRepo) ListDueWebhookEvents(ctx context.Context, provider string, staleAfter time.Duration, n int) ([]*repository.WebhookEvent, error) {
+	r.mu.RLock()
+	ts := now()
+	var due []*repository.WebhookEvent
+	for _, e := range r.events {
+		if e.Provider != provider || !webhookEventDue(e, ts, staleAfter) {
+			continue
+		}
+		cp := copyWebhookEvent(e)
+		due = append(due, &cp)
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(due, func(i, j int) bool { return due[i].CreatedAt.Before(due[j].CreatedAt) })
+	return limit(due, n), nil
+}
+
+// webhookEventDue reports whether background processing should pick e up
+func webhookEventDue(e *repository.WebhookEvent, ts time.Time, staleAfter time.Duration) bool {
+	switch e.Status {
+	case repository.WebhookEventReceived, repository.WebhookEventFailed:
+		return e.NextAttemptAt != nil && !e.NextAttemptAt.After(ts)
+	case repository.WebhookEventProcessing:
+		return e.UpdatedAt.Before(ts.Add(-staleAfter))
+	}
+	return false
+}
+
+// ClaimWebhookEvent moves a claimable event to processing
+func (r *MemoryWebhookEventRepo) ClaimWebhookEvent(ctx context.Context, id string, staleAfter time.Duration) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	e, ok := r.events[id]
+	if !ok {
+		return false, nil
+	}
+
+	ts := now()
+	switch e.Status {
+	case repository.WebhookEventReceived, repository.WebhookEventFailed:
+	case repository.WebhookEventProcessing:
+		if !e.UpdatedAt.Before(ts.Add(-staleAfter)) {
+			return false, nil
+		}
+	default:
+		return false, nil
+	}
+
+	e.Status = repository.WebhookEventProcessing
+	e.Attempts++
+	e.UpdatedAt = ts
+	return true, nil
+}
+
+// MarkWebhookEventProcessed records successful processing
+func (r *MemoryWebhookEventRepo) MarkWebhookEventProcessed(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	e, ok := r.events[id]
+	if !ok {
+		return repository.ErrWebhookEventNotFound
+	}
+
+	ts := now()
+	e.Status = repository.WebhookEventProcessed
+	e.LastError = nil
+	e.NextAttemptAt = nil
+	e.ProcessedAt = &ts
+	e.UpdatedAt = ts
+	return nil
+}
+
+// MarkWebhookEventFailed records a processing failure and schedules the
+// next background attempt, if any
+func (r *MemoryWebhookEventRepo) MarkWebhookEventFailed(ctx context.Context, id string, errMsg string, retryAt *time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	e, ok := r.events[id]
+	if !ok {
+		return repository.ErrWebhookEventNotFound
+	}
+
+	e.Status = repository.WebhookEventFailed
+	e.LastError = &errMsg
+	e.NextAttemptAt = nil
+	if retryAt != nil {
+		next := *retryAt
+		e.NextAttemptAt = &next
+	}
+	e.UpdatedAt = now()
+	return nil
+}
+
+// MarkWebhookEventDeadLetter parks an event that exhausted its retries
+func (r *MemoryWebhookEventRepo) MarkWebhookEventDeadLetter(ctx context.Context, id string, errMsg string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	e, ok := r.events[id]
+	if !ok {
+		return repository.ErrWebhookEventNotFound
+	}
+
+	e.Status = repository.WebhookEventDeadLetter
+	e.LastError = &errMsg
+	e.NextAttemptAt = nil
+	e.UpdatedAt = now()
+	return nil
+}
+
+// RequeueWebhookEvent makes a failed or dead-lettered event due now
+func (r *MemoryWebhookEventRepo) RequeueWebhookEvent(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	e, ok := r.events[id]
+	if !ok {
+		return repository.ErrWebhookEventNotFound
+	}
+	if e.Status != repository.WebhookEventFailed && e.Status != repository.WebhookEventDeadLetter {
+		return repository.ErrWebhookEventNotRetryable
+	}
+
+	ts := now()
+	e.Status = repository.WebhookEventReceived
+	e.NextAttemptAt = &ts
+	e.UpdatedAt = ts
+	return nil
+}
+
+// copyWebhookEvent returns a copy that shares no mutable state with e
+func copyWebhookEvent(e *repository.WebhookEvent) repository.WebhookEvent {
+	cp := *e
+	cp.Payload = append([]byte(nil), e.Payload...)
+	if e.LastError != nil {
+		msg := *e.LastError
+		cp.LastError = &msg
+	}
+	if e.ProcessedAt != nil {
+		ts := *e.ProcessedAt
+		cp.ProcessedAt = &ts
+	}
+	if e.NextAttemptAt != nil {
+		ts := *e.NextAttemptAt
+		cp.NextAttemptAt = &ts
+	}
+	return cp
+}
@@ -0,0 +1,102 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure PostgresActivityRepo implements ActivityRepository
+var _ repository.ActivityRepository = (*PostgresActivityRepo)(nil)
+
+// PostgresActivityRepo implements ActivityRepository using PostgreSQL
+type PostgresActivityRepo struct {
+	db *sql.DB
+}
+
+// NewPostgresActivityRepo creates a new PostgreSQL activity repository
+func NewPostgresActivityRepo(db *sql.DB) *PostgresActivityRepo {
+	return &PostgresActivityRepo{db: db}
+}
+
+// RecordActivity appends an event
+func (r *PostgresActivityRepo) RecordActivity(ctx context.Context, e *repository.ActivityEvent) error {
+	query := `
+		INSERT INTO activity_events (address, kind, action, subject_id, summary, tx_hash, occurred_at)
+		VALUES ($1, $2, $3, $4, $5, $6, COALESCE($7, NOW()))
+		RETURNING id, occurred_at
+	`
+
+	var occurredAt *time.Time
+	if !e.OccurredAt.IsZero() {
+		occurredAt = &e.OccurredAt
+	}
+	err := r.db.QueryRowContext(ctx, query, e.Address, e.Kind, e.Action, e.SubjectID, e.Summary, e.TxHash, occurredAt).
+		Scan(&e.ID, &e.OccurredAt)
+	if err != nil {
+		return fmt.Errorf("recording %s activity for %s: %w", e.Kind, e.Address, err)
+	}
+	return nil
+}
+
+// ListActivity lists an address's events, newest first
+func (r *PostgresActivityRepo) ListActivity(ctx context.Context, filter repository.ActivityFilter, page repository.Pagination) ([]*repository.ActivityEvent, int64, error) {
+	whereClause := "WHERE address = $1"
+	args := []interface{}{filter.Address}
+	argNum := 2
+
+	if len(filter.Kinds) > 0 {
+		kinds := make([]string, len(filter.Kinds))
+		for i, k := range filter.Kinds {
+			kinds[i] = string(k)
+		}
+		whereClause += fmt.Sprintf(" AND kind = ANY($%d)", argNum)
+		args = append(args, pq.Array(kinds))
+		argNum++
+	}
+
+	var total int64
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM activity_events "+whereClause, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("counting activity events: %w", err)
+	}
+
+	if page.PageSize <= 0 {
+		page.PageSize = 20
+	}
+	if page.Page <= 0 {
+		page.Page = 1
+	}
+	offset := (page.Page - 1) * page.PageSize
+
+	query := fmt.Sprintf(`
+		SELECT id, address, kind, action, subject_id, summary, tx_hash, occurred_at
+		FROM activity_events
+		%s
+		ORDER BY occurred_at DESC, seq DESC
+		LIMIT $%d OFFSET $%d
+	`, whereClause, argNum, argNum+1)
+
+	args = append(args, page.PageSize, offset)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("listing activity events: %w", err)
+	}
+	defer rows.Close()
+
+	var result []*repository.ActivityEvent
+	for rows.Next() {
+		e := &repository.ActivityEvent{}
+		if err := rows.Scan(&e.ID, &e.Address, &e.Kind, &e.Action, &e.SubjectID, &e.Summary, &e.TxHash, &e.OccurredAt); err != nil {
+			return nil, 0, fmt.Errorf("scanning activity event row: %w", err)
+		}
+		result = append(result, e)
+	}
+
+	return result, total, rows.Err()
+}
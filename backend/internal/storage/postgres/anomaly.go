@@ -0,0 +1,239 @@
+// Package postgres implements repository interfaces using PostgreSQL
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure PostgresAnomalyRepo implements AnomalyRepository
+var _ repository.AnomalyRepository = (*PostgresAnomalyRepo)(nil)
+
+// PostgresAnomalyRepo implements AnomalyRepository using PostgreSQL
+type PostgresAnomalyRepo struct {
+	db *sql.DB
+}
+
+// NewPostgresAnomalyRepo creates a new PostgreSQL anomaly repository
+func NewPostgresAnomalyRepo(db *sql.DB) *PostgresAnomalyRepo {
+	return &PostgresAnomalyRepo{db: db}
+}
+
+// complianceCaseColumns is the column list read by every case query
+const complianceCaseColumns = `id, rule, subject, summary, observed, threshold, status, opened_at, closed_at, closed_by, resolution`
+
+// CountPaymentsByPayer counts recent payments per payer
+func (r *PostgresAnomalyRepo) CountPaymentsByPayer(ctx context.Context, since time.Time, min int64) ([]repository.SubjectCount, error) {
+	return r.countBy(ctx, "payments", `
+		SELECT payer_address, COUNT(*) FROM payments
+		WHERE created_at >= $1
+		GROUP BY payer_address HAVING COUNT(*) >= $2
+		ORDER BY COUNT(*) DESC, payer_address
+	`, since, min)
+}
+
+// CountFailedRelaysBySender counts recent failed meta-transactions per sender
+func (r *PostgresAnomalyRepo) CountFailedRelaysBySender(ctx context.Context, since time.Time, min int64) ([]repository.SubjectCount, error) {
+	return r.countBy(ctx, "failed meta-transactions", `
+		SELECT from_address, COUNT(*) FROM meta_transactions
+		WHERE status = 'failed' AND created_at >= $1
+		GROUP BY from_address HAVING COUNT(*) >= $2
+		ORDER BY COUNT(*) DESC, from_address
+	`, since, min)
+}
+
+func (r *PostgresAnomalyRepo) countBy(ctx context.Context, what, query string, since time.Time, min int64) ([]repository.SubjectCount, error) {
+	rows, err := r.db.QueryContext(ctx, query, since, min)
+	if err != nil {
+		return nil, fmt.Errorf("counting %s: %w", what, err)
+	}
+	defer rows.Close()
+
+	var result []repository.SubjectCount
+	for rows.Next() {
+		var c repository.SubjectCount
+		if err := rows.Scan(&c.Subject, &c.Count); err != nil {
+			return nil, fmt.Errorf("scanning %s count: %w", what, err)
+		}
+		result = append(result, c)
+	}
+	return result, rows.Err()
+}
+
+// HourlyActivity counts payments and meta-transactions per UTC hour
+func (r *PostgresAnomalyRepo) HourlyActivity(ctx context.Context, since time.Time) ([]repository.HourlyCount, error) {
+	query := `
+		WITH p AS (
+			SELECT date_trunc('hour', created_at AT TIME ZONE 'UTC') AS hour, COUNT(*) AS n
+			FROM payments WHERE created_at >= $1 GROUP BY 1
+		), m AS (
+			SELECT date_trunc('hour', created_at AT TIME ZONE 'UTC') AS hour, COUNT(*) AS n
+			FROM meta_transactions WHERE created_at >= $1 GROUP BY 1
+		)
+		SELECT COALESCE(p.hour, m.hour) AS hour, COALESCE(p.n, 0), COALESCE(m.n, 0)
+		FROM p FULL OUTER JOIN m ON p.hour = m.hour
+		ORDER BY 1
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, since)
+	if err != nil {
+		return nil, fmt.Errorf("counting hourly activity: %w", err)
+	}
+	defer rows.Close()
+
+	var result []repository.HourlyCount
+	for rows.Next() {
+		var h repository.HourlyCount
+		if err := rows.Scan(&h.Hour, &h.Payments, &h.Relays); err != nil {
+			return nil, fmt.Errorf("scanning hourly activity: %w", err)
+		}
+		// date_trunc of a UTC timestamp without time zone scans as UTC
+		h.Hour = time.Date(h.Hour.Year(), h.Hour.Month(), h.Hour.Day(), h.Hour.Hour(), 0, 0, 0, time.UTC)
+		result = append(result, h)
+	}
+	return result, rows.Err()
+}
+
+// OpenComplianceCase opens a case unless one is open for its rule and subject
+func (r *PostgresAnomalyRepo) OpenComplianceCase(ctx context.Context, c *repository.ComplianceCase) (bool, error) {
+	query := `
+		INSERT INTO compliance_cases (rule, subject, summary, observed, threshold)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (rule, subject) WHERE status = 'open' DO NOTHING
+		RETURNING id, status, opened_at
+	`
+
+	err := r.db.QueryRowContext(ctx, query, c.Rule, c.Subject, c.Summary, c.Observed, c.Threshold).
+		Scan(&c.ID, &c.Status, &c.OpenedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, fmt.Errorf("opening compliance case: %w", err)
+	}
+	return true, nil
+}
+
+// GetComplianceCase retrieves a case by ID
+func (r *PostgresAnomalyRepo) GetComplianceCase(ctx context.Context, id string) (*repository.ComplianceCase, error) {
+	query := `SELECT ` + complianceCaseColumns + ` FROM compliance_cases WHERE id = $1`
+
+	c, err := scanComplianceCase(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrComplianceCaseNotFound
+		}
+		return nil, fmt.Errorf("getting compliance case %s: %w", id, err)
+	}
+	return c, nil
+}
+
+// ListComplianceCases lists cases, newest first
+func (r *PostgresAnomalyRepo) ListComplianceCases(ctx context.Context, filter repository.ComplianceCaseFilter, page repository.Pagination) ([]*repository.ComplianceCase, int64, error) {
+	whereClause := "WHERE 1=1"
+	args := []interface{}{}
+	argNum := 1
+
+	if filter.Rule != "" {
+		whereClause += fmt.Sprintf(" AND rule = $%d", argNum)
+		args = append(args, filter.Rule)
+		argNum++
+	}
+	if filter.Subject != "" {
+		whereClause += fmt.Sprintf(" AND subject = $%d", argNum)
+		args = append(args, filter.Subject)
+		argNum++
+	}
+	if filter.Status != "" {
+		whereClause += fmt.Sprintf(" AND status = $%d", argNum)
+		args = append(args, filter.Status)
+		argNum++
+	}
+
+	var total int64
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM compliance_cases "+whereClause, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("counting compliance cases: %w", err)
+	}
+
+	if page.PageSize <= 0 {
+		page.PageSize = 20
+	}
+	if page.Page <= 0 {
+		page.Page = 1
+	}
+	offset := (page.Page - 1) * page.PageSize
+
+	query := fmt.Sprintf(`
+		SELECT `+complianceCaseColumns+`
+		FROM compliance_cases
+		%s
+		ORDER BY opened_at DESC
+		LIMIT $%d OFFSET $%d
+	`, whereClause, argNum, argNum+1)
+
+	args = append(args, page.PageSize, offset)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("listing compliance cases: %w", err)
+	}
+	defer rows.Close()
+
+	var result []*repository.ComplianceCase
+	for rows.Next() {
+		c, err := scanComplianceCase(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("scanning compliance case row: %w", err)
+		}
+		result = append(result, c)
+	}
+
+	return result, total, rows.Err()
+}
+
+// CloseComplianceCase closes an open case
+func (r *PostgresAnomalyRepo) CloseComplianceCase(ctx context.Context, id, closedBy, resolution string) (*repository.ComplianceCase, error) {
+	query := `
+		UPDATE compliance_cases
+		SET status = 'closed', closed_at = NOW(), closed_by = $2, resolution = $3
+		WHERE id = $1 AND status = 'open'
+		RETURNING ` + complianceCaseColumns
+
+	c, err := scanComplianceCase(r.db.QueryRowContext(ctx, query, id, closedBy, resolution))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			if _, getErr := r.GetComplianceCase(ctx, id); getErr != nil {
+				return nil, getErr
+			}
+			return nil, repository.ErrComplianceCaseClosed
+		}
+		return nil, fmt.Errorf("closing compliance case %s: %w", id, err)
+	}
+	return c, nil
+}
+
+func scanComplianceCase(row rowScanner) (*repository.ComplianceCase, error) {
+	c := &repository.ComplianceCase{}
+	err := row.Scan(
+		&c.ID,
+		&c.Rule,
+		&c.Subject,
+		&c.Summary,
+		&c.Observed,
+		&c.Threshold,
+		&c.Status,
+		&c.OpenedAt,
+		&c.ClosedAt,
+		&c.ClosedBy,
+		&c.Resolution,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
@@ -17,12 +17,18 @@ var _ repository.AppConfigRepository = (*PostgresAppConfigRepo)(nil)
 
 // PostgresAppConfigRepo implements AppConfigRepository using PostgreSQL
 type PostgresAppConfigRepo struct {
-	db *sql.DB
+	db   *sql.DB
+	read querier // replica-safe reads; db unless UseReadRouter was called
 }
 
 // NewPostgresAppConfigRepo creates a new PostgreSQL app config repository
 func NewPostgresAppConfigRepo(db *sql.DB) *PostgresAppConfigRepo {
-	return &PostgresAppConfigRepo{db: db}
+	return &PostgresAppConfigRepo{db: db, read: db}
+}
+
+// UseReadRouter sends replica-safe reads through router
+func (r *PostgresAppConfigRepo) UseReadRouter(router *ReadRouter) {
+	r.read = router
 }
 
 // scanConfig is a helper to scan a config row into an AppConfig struct
@@ -89,7 +95,7 @@ func (r *PostgresAppConfigRepo) Get(ctx context.Context, namespace, key string,
 		FROM app_config
 		WHERE namespace = $1 AND config_key = $2 AND chain_id = $3 AND is_active = true`
 
-	row := r.db.QueryRowContext(ctx, query, namespace, key, chainID)
+	row := r.read.QueryRowContext(ctx, query, namespace, key, chainID)
 	config, err := r.scanConfig(row)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -131,7 +137,7 @@ func (r *PostgresAppConfigRepo) ListByNamespace(ctx context.Context, namespace s
 		WHERE namespace = $1 AND (chain_id = $2 OR chain_id = 0) AND is_active = true
 		ORDER BY config_key`
 
-	rows, err := r.db.QueryContext(ctx, query, namespace, chainID)
+	rows, err := r.read.QueryContext(ctx, query, namespace, chainID)
 	if err != nil {
 		return nil, fmt.Errorf("listing app configs for namespace %s: %w", namespace, err)
 	}
@@ -159,7 +165,7 @@ func (r *PostgresAppConfigRepo) ListAll(ctx context.Context) ([]*repository.AppC
 		FROM app_config
 		ORDER BY namespace, config_key, chain_id`
 
-	rows, err := r.db.QueryContext(ctx, query)
+	rows, err := r.read.QueryContext(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("listing all app configs: %w", err)
 	}
@@ -362,7 +368,7 @@ func (r *PostgresAppConfigRepo) GetHistory(ctx context.Context, namespace, key s
 		LIMIT $4
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, namespace, key, chainID, limit)
+	rows, err := r.read.QueryContext(ctx, query, namespace, key, chainID, limit)
 	if err != nil {
 		return nil, fmt.Errorf("getting app config history: %w", err)
 	}
@@ -7,6 +7,8 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/lib/pq"
+
 	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
 )
 
@@ -15,12 +17,18 @@ var _ repository.ContractRepository = (*PostgresContractRepo)(nil)
 
 // PostgresContractRepo implements ContractRepository using PostgreSQL
 type PostgresContractRepo struct {
-	db *sql.DB
+	db   *sql.DB
+	read querier // replica-safe reads; db unless UseReadRouter was called
 }
 
 // NewPostgresContractRepo creates a new PostgreSQL contract repository
 func NewPostgresContractRepo(db *sql.DB) *PostgresContractRepo {
-	return &PostgresContractRepo{db: db}
+	return &PostgresContractRepo{db: db, read: db}
+}
+
+// UseReadRouter sends replica-safe reads through router
+func (r *PostgresContractRepo) UseReadRouter(router *ReadRouter) {
+	r.read = router
 }
 
 // ============================================================================
@@ -30,19 +38,21 @@ func NewPostgresContractRepo(db *sql.DB) *PostgresContractRepo {
 // GetNetworkByChainID retrieves network configuration by chain ID
 func (r *PostgresContractRepo) GetNetworkByChainID(ctx context.Context, chainID int64) (*repository.NetworkConfig, error) {
 	query := `
-		SELECT id, chain_id, network_name, display_name, rpc_url, explorer_url,
-		       default_deployer, is_testnet, is_active, created_at, updated_at
+		SELECT id, chain_id, network_name, display_name, rpc_url, rpc_fallback_urls,
+		       ws_url, explorer_url, default_deployer, is_testnet, is_active, created_at, updated_at
 		FROM network_config
 		WHERE chain_id = $1
 	`
 
 	nc := &repository.NetworkConfig{}
-	err := r.db.QueryRowContext(ctx, query, chainID).Scan(
+	err := r.read.QueryRowContext(ctx, query, chainID).Scan(
 		&nc.ID,
 		&nc.ChainID,
 		&nc.NetworkName,
 		&nc.DisplayName,
 		&nc.RPCUrl,
+		pq.Array(&nc.RPCFallbackUrls),
+		&nc.WSUrl,
 		&nc.ExplorerUrl,
 		&nc.DefaultDeployer,
 		&nc.IsTestnet,
@@ -64,19 +74,21 @@ func (r *PostgresContractRepo) GetNetworkByChainID(ctx context.Context, chainID
 // GetNetworkByName retrieves network configuration by network name
 func (r *PostgresContractRepo) GetNetworkByName(ctx context.Context, name string) (*repository.NetworkConfig, error) {
 	query := `
-		SELECT id, chain_id, network_name, display_name, rpc_url, explorer_url,
-		       default_deployer, is_testnet, is_active, created_at, updated_at
+		SELECT id, chain_id, network_name, display_name, rpc_url, rpc_fallback_urls,
+		       ws_url, explorer_url, default_deployer, is_testnet, is_active, created_at, updated_at
 		FROM network_config
 		WHERE network_name = $1
 	`
 
 	nc := &repository.NetworkConfig{}
-	err := r.db.QueryRowContext(ctx, query, name).Scan(
+	err := r.read.QueryRowContext(ctx, query, name).Scan(
 		&nc.ID,
 		&nc.ChainID,
 		&nc.NetworkName,
 		&nc.DisplayName,
 		&nc.RPCUrl,
+		pq.Array(&nc.RPCFallbackUrls),
+		&nc.WSUrl,
 		&nc.ExplorerUrl,
 		&nc.DefaultDeployer,
 		&nc.IsTestnet,
@@ -98,14 +110,14 @@ func (r *PostgresContractRepo) GetNetworkByName(ctx context.Context, name string
 // GetActiveNetworks retrieves all active network configurations
 func (r *PostgresContractRepo) GetActiveNetworks(ctx context.Context) ([]*repository.NetworkConfig, error) {
 	query := `
-		SELECT id, chain_id, network_name, display_name, rpc_url, explorer_url,
-		       default_deployer, is_testnet, is_active, created_at, updated_at
+		SELECT id, chain_id, network_name, display_name, rpc_url, rpc_fallback_urls,
+		       ws_url, explorer_url, default_deployer, is_testnet, is_active, created_at, updated_at
 		FROM network_config
 		WHERE is_active = true
 		ORDER BY chain_id
 	`
 
-	rows, err := r.db.QueryContext(ctx, query)
+	rows, err := r.read.QueryContext(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("listing active networks: %w", err)
 	}
@@ -120,6 +132,8 @@ func (r *PostgresContractRepo) GetActiveNetworks(ctx context.Context) ([]*reposi
 			&nc.NetworkName,
 			&nc.DisplayName,
 			&nc.RPCUrl,
+			pq.Array(&nc.RPCFallbackUrls),
+			&nc.WSUrl,
 			&nc.ExplorerUrl,
 			&nc.DefaultDeployer,
 			&nc.IsTestnet,
@@ -153,7 +167,7 @@ func (r *PostgresContractRepo) GetAllMappings(ctx context.Context) ([]*repositor
 		ORDER BY sort_order, solidity_name
 	`
 
-	rows, err := r.db.QueryContext(ctx, query)
+	rows, err := r.read.QueryContext(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("listing contract mappings: %w", err)
 	}
@@ -196,7 +210,7 @@ func (r *PostgresContractRepo) GetMappingBySolidityName(ctx context.Context, nam
 	`
 
 	cm := &repository.ContractMapping{}
-	err := r.db.QueryRowContext(ctx, query, name).Scan(
+	err := r.read.QueryRowContext(ctx, query, name).Scan(
 		&cm.ID,
 		&cm.SolidityName,
 		&cm.DBName,
@@ -228,7 +242,7 @@ func (r *PostgresContractRepo) GetMappingByDBName(ctx context.Context, dbName st
 	`
 
 	cm := &repository.ContractMapping{}
-	err := r.db.QueryRowContext(ctx, query, dbName).Scan(
+	err := r.read.QueryRowContext(ctx, query, dbName).Scan(
 		&cm.ID,
 		&cm.SolidityName,
 		&cm.DBName,
@@ -259,14 +273,14 @@ func (r *PostgresContractRepo) GetByChainID(ctx context.Context, chainID int64)
 	query := `
 		SELECT ca.id, ca.chain_id, ca.contract_mapping_id, cm.db_name, cm.solidity_name,
 		       ca.address, ca.deployment_tx_hash, ca.deployment_block, ca.abi_version,
-		       ca.status, ca.is_primary, ca.deployed_by, ca.notes, ca.created_at, ca.updated_at
+		       ca.status, ca.is_primary, ca.deployed_by, ca.notes, ca.eip712_name, ca.eip712_version, ca.created_at, ca.updated_at
 		FROM contract_addresses ca
 		JOIN contract_mappings cm ON ca.contract_mapping_id = cm.id
 		WHERE ca.chain_id = $1 AND ca.status = 'active' AND ca.is_primary = true
 		ORDER BY cm.sort_order, cm.solidity_name
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, chainID)
+	rows, err := r.read.QueryContext(ctx, query, chainID)
 	if err != nil {
 		return nil, fmt.Errorf("listing contracts for chain %d: %w", chainID, err)
 	}
@@ -289,6 +303,8 @@ func (r *PostgresContractRepo) GetByChainID(ctx context.Context, chainID int64)
 			&ca.IsPrimary,
 			&ca.DeployedBy,
 			&ca.Notes,
+			&ca.EIP712Name,
+			&ca.EIP712Version,
 			&ca.CreatedAt,
 			&ca.UpdatedAt,
 		)
@@ -310,14 +326,14 @@ func (r *PostgresContractRepo) GetByChainAndDBName(ctx context.Context, chainID
 	query := `
 		SELECT ca.id, ca.chain_id, ca.contract_mapping_id, cm.db_name, cm.solidity_name,
 		       ca.address, ca.deployment_tx_hash, ca.deployment_block, ca.abi_version,
-		       ca.status, ca.is_primary, ca.deployed_by, ca.notes, ca.created_at, ca.updated_at
+		       ca.status, ca.is_primary, ca.deployed_by, ca.notes, ca.eip712_name, ca.eip712_version, ca.created_at, ca.updated_at
 		FROM contract_addresses ca
 		JOIN contract_mappings cm ON ca.contract_mapping_id = cm.id
 		WHERE ca.chain_id = $1 AND cm.db_name = $2 AND ca.status = 'active' AND ca.is_primary = true
 	`
 
 	ca := &repository.ContractAddress{}
-	err := r.db.QueryRowContext(ctx, query, chainID, dbName).Scan(
+	err := r.read.QueryRowContext(ctx, query, chainID, dbName).Scan(
 		&ca.ID,
 		&ca.ChainID,
 		&ca.ContractMappingID,
@@ -331,6 +347,8 @@ func (r *PostgresContractRepo) GetByChainAndDBName(ctx context.Context, chainID
 		&ca.IsPrimary,
 		&ca.DeployedBy,
 		&ca.Notes,
+		&ca.EIP712Name,
+		&ca.EIP712Version,
 		&ca.CreatedAt,
 		&ca.UpdatedAt,
 	)
@@ -350,14 +368,14 @@ func (r *PostgresContractRepo) GetByID(ctx context.Context, id string) (*reposit
 	query := `
 		SELECT ca.id, ca.chain_id, ca.contract_mapping_id, cm.db_name, cm.solidity_name,
 		       ca.address, ca.deployment_tx_hash, ca.deployment_block, ca.abi_version,
-		       ca.status, ca.is_primary, ca.deployed_by, ca.notes, ca.created_at, ca.updated_at
+		       ca.status, ca.is_primary, ca.deployed_by, ca.notes, ca.eip712_name, ca.eip712_version, ca.created_at, ca.updated_at
 		FROM contract_addresses ca
 		JOIN contract_mappings cm ON ca.contract_mapping_id = cm.id
 		WHERE ca.id = $1
 	`
 
 	ca := &repository.ContractAddress{}
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
+	err := r.read.QueryRowContext(ctx, query, id).Scan(
 		&ca.ID,
 		&ca.ChainID,
 		&ca.ContractMappingID,
@@ -371,6 +389,8 @@ func (r *PostgresContractRepo) GetByID(ctx context.Context, id string) (*reposit
 		&ca.IsPrimary,
 		&ca.DeployedBy,
 		&ca.Notes,
+		&ca.EIP712Name,
+		&ca.EIP712Version,
 		&ca.CreatedAt,
 		&ca.UpdatedAt,
 	)
@@ -448,8 +468,9 @@ func (r *PostgresContractRepo) Upsert(ctx context.Context, contract *repository.
 		insertQuery := `
 			INSERT INTO contract_addresses (
 				chain_id, contract_mapping_id, address, deployment_tx_hash,
-				deployment_block, abi_version, deployed_by, notes, status, is_primary
-			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, 'active', true)
+				deployment_block, abi_version, deployed_by, notes, eip712_name, eip712_version,
+				status, is_primary
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, 'active', true)
 			RETURNING id
 		`
 		err = tx.QueryRowContext(ctx, insertQuery,
@@ -461,6 +482,8 @@ func (r *PostgresContractRepo) Upsert(ctx context.Context, contract *repository.
 			abiVersion,
 			deployedBy,
 			contract.Notes,
+			contract.EIP712Name,
+			contract.EIP712Version,
 		).Scan(&contractID)
 		if err != nil {
 			return nil, fmt.Errorf("inserting contract address: %w", err)
@@ -489,8 +512,9 @@ func (r *PostgresContractRepo) Upsert(ctx context.Context, contract *repository.
 		updateQuery := `
 			UPDATE contract_addresses
 			SET address = $1, deployment_tx_hash = $2, deployment_block = $3,
-			    abi_version = $4, deployed_by = $5, notes = $6, updated_at = NOW()
-			WHERE id = $7
+			    abi_version = $4, deployed_by = $5, notes = $6,
+			    eip712_name = $7, eip712_version = $8, updated_at = NOW()
+			WHERE id = $9
 		`
 		_, err = tx.ExecContext(ctx, updateQuery,
 			contract.Address,
@@ -499,6 +523,8 @@ func (r *PostgresContractRepo) Upsert(ctx context.Context, contract *repository.
 			abiVersion,
 			deployedBy,
 			contract.Notes,
+			contract.EIP712Name,
+			contract.EIP712Version,
 			contractID,
 		)
 		if err != nil {
@@ -549,7 +575,7 @@ func (r *PostgresContractRepo) GetHistory(ctx context.Context, contractID string
 		LIMIT $2
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, contractID, limit)
+	rows, err := r.read.QueryContext(ctx, query, contractID, limit)
 	if err != nil {
 		return nil, fmt.Errorf("getting contract history: %w", err)
 	}
@@ -610,3 +636,176 @@ func (r *PostgresContractRepo) GetDeploymentConfig(ctx context.Context, chainID
 		Contracts: contracts,
 	}, nil
 }
+
+// ============================================================================
+// ABI Artifact Methods
+// ============================================================================
+
+// UpsertABI publishes an ABI for a contract mapping and version. An existing
+// version is returned unchanged when its ABI hash matches, and refused with
+// repository.ErrABIVersionConflict otherwise.
+func (r *PostgresContractRepo) UpsertABI(ctx context.Context, artifact *repository.ABIArtifactUpsert) (*repository.ABIArtifact, error) {
+	mapping, err := r.GetMappingByDBName(ctx, artifact.DBName)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		INSERT INTO abi_artifacts (
+			contract_mapping_id, version, abi, abi_hash, compiler_version, uploaded_by
+		) VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (contract_mapping_id, version) DO NOTHING
+		RETURNING id, created_at, updated_at
+	`
+
+	result := &repository.ABIArtifact{
+		ContractMappingID: mapping.ID,
+		DBName:            mapping.DBName,
+		SolidityName:      mapping.SolidityName,
+		Version:           artifact.Version,
+		ABI:               artifact.ABI,
+		ABIHash:           artifact.ABIHash,
+		CompilerVersion:   artifact.CompilerVersion,
+		UploadedBy:        artifact.UploadedBy,
+	}
+
+	err = r.db.QueryRowContext(ctx, query,
+		mapping.ID,
+		artifact.Version,
+		[]byte(artifact.ABI),
+		artifact.ABIHash,
+		artifact.CompilerVersion,
+		artifact.UploadedBy,
+	).Scan(&result.ID, &result.CreatedAt, &result.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return r.publishedABI(ctx, result)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("upserting abi for %s@%s: %w", artifact.DBName, artifact.Version, err)
+	}
+
+	return result, nil
+}
+
+// publishedABI returns the stored artifact of upload's version if its ABI
+// hash matches upload's
+func (r *PostgresContractRepo) publishedABI(ctx context.Context, upload *repository.ABIArtifact) (*repository.ABIArtifact, error) {
+	query := `
+		SELECT id, abi, abi_hash, compiler_version, uploaded_by, created_at, updated_at
+		FROM abi_artifacts
+		WHERE contract_mapping_id = $1 AND version = $2
+	`
+
+	stored := *upload
+	var abiJSON []byte
+	err := r.db.QueryRowContext(ctx, query, upload.ContractMappingID, upload.Version).Scan(
+		&stored.ID,
+		&abiJSON,
+		&stored.ABIHash,
+		&stored.CompilerVersion,
+		&stored.UploadedBy,
+		&stored.CreatedAt,
+		&stored.UpdatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("getting published abi for %s@%s: %w", upload.DBName, upload.Version, err)
+	}
+	if stored.ABIHash != upload.ABIHash {
+		return nil, repository.ErrABIVersionConflict
+	}
+
+	stored.ABI = abiJSON
+	return &stored, nil
+}
+
+// GetABI retrieves the ABI for a contract by db_name and version.
+// Passing repository.ABIVersionLatest returns the most recent upload.
+func (r *PostgresContractRepo) GetABI(ctx context.Context, dbName, version string) (*repository.ABIArtifact, error) {
+	query := `
+		SELECT aa.id, aa.contract_mapping_id, cm.db_name, cm.solidity_name, aa.version,
+		       aa.abi, aa.abi_hash, aa.compiler_version, aa.uploaded_by, aa.created_at, aa.updated_at
+		FROM abi_artifacts aa
+		JOIN contract_mappings cm ON aa.contract_mapping_id = cm.id
+		WHERE cm.db_name = $1
+	`
+	args := []interface{}{dbName}
+
+	if version == repository.ABIVersionLatest {
+		query += " ORDER BY aa.created_at DESC LIMIT 1"
+	} else {
+		query += " AND aa.version = $2"
+		args = append(args, version)
+	}
+
+	aa := &repository.ABIArtifact{}
+	var abiJSON []byte
+	err := r.read.QueryRowContext(ctx, query, args...).Scan(
+		&aa.ID,
+		&aa.ContractMappingID,
+		&aa.DBName,
+		&aa.SolidityName,
+		&aa.Version,
+		&abiJSON,
+		&aa.ABIHash,
+		&aa.CompilerVersion,
+		&aa.UploadedBy,
+		&aa.CreatedAt,
+		&aa.UpdatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrABIArtifactNotFound
+		}
+		return nil, fmt.Errorf("getting abi for %s@%s: %w", dbName, version, err)
+	}
+
+	aa.ABI = abiJSON
+	return aa, nil
+}
+
+// ListABIVersions lists the stored ABI versions for a contract (newest first).
+// The ABI body is not included; fetch a specific version with GetABI.
+func (r *PostgresContractRepo) ListABIVersions(ctx context.Context, dbName string) ([]*repository.ABIArtifact, error) {
+	query := `
+		SELECT aa.id, aa.contract_mapping_id, cm.db_name, cm.solidity_name, aa.version,
+		       aa.abi_hash, aa.compiler_version, aa.uploaded_by, aa.created_at, aa.updated_at
+		FROM abi_artifacts aa
+		JOIN contract_mappings cm ON aa.contract_mapping_id = cm.id
+		WHERE cm.db_name = $1
+		ORDER BY aa.created_at DESC
+	`
+
+	rows, err := r.read.QueryContext(ctx, query, dbName)
+	if err != nil {
+		return nil, fmt.Errorf("listing abi versions for %s: %w", dbName, err)
+	}
+	defer rows.Close()
+
+	var result []*repository.ABIArtifact
+	for rows.Next() {
+		aa := &repository.ABIArtifact{}
+		err := rows.Scan(
+			&aa.ID,
+			&aa.ContractMappingID,
+			&aa.DBName,
+			&aa.SolidityName,
+			&aa.Version,
+			&aa.ABIHash,
+			&aa.CompilerVersion,
+			&aa.UploadedBy,
+			&aa.CreatedAt,
+			&aa.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("scanning abi artifact row: %w", err)
+		}
+		result = append(result, aa)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating abi artifact rows: %w", err)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,194 @@
+// Package postgres implements repository interfaces using PostgreSQL
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure PostgresDepositRepo implements DepositRepository
+var _ repository.DepositRepository = (*PostgresDepositRepo)(nil)
+
+// PostgresDepositRepo implements DepositRepository using PostgreSQL
+type PostgresDepositRepo struct {
+	db *sql.DB
+}
+
+// NewPostgresDepositRepo creates a new PostgreSQL deposit repository
+func NewPostgresDepositRepo(db *sql.DB) *PostgresDepositRepo {
+	return &PostgresDepositRepo{db: db}
+}
+
+// depositIntentColumns is the column list read by every deposit intent query
+const depositIntentColumns = `id, payment_id, payer_address, currency, token_address, deposit_address,
+		       derivation_index, expected_amount::TEXT, received_amount::TEXT, status, expires_at,
+		       created_at, updated_at, detected_at, paid_at, sweep_tx_hash, sweep_attempted_at, swept_at`
+
+// NextDepositIndex reserves the next derivation index from a sequence, so
+// concurrent intents never share an address
+func (r *PostgresDepositRepo) NextDepositIndex(ctx context.Context) (int64, error) {
+	var index int64
+	if err := r.db.QueryRowContext(ctx, "SELECT nextval('deposit_derivation_index')").Scan(&index); err != nil {
+		return 0, fmt.Errorf("reserving deposit index: %w", err)
+	}
+	return index, nil
+}
+
+// CreateDepositIntent records a new intent
+func (r *PostgresDepositRepo) CreateDepositIntent(ctx context.Context, d *repository.DepositIntent) error {
+	query := `
+		INSERT INTO deposit_intents (payment_id, payer_address, currency, token_address, deposit_address,
+		                             derivation_index, expected_amount, status, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id, received_amount::TEXT, created_at, updated_at
+	`
+
+	err := r.db.QueryRowContext(ctx, query,
+		d.PaymentID, d.PayerAddress, d.Currency, d.TokenAddress, d.DepositAddress,
+		d.DerivationIndex, d.ExpectedAmount, d.Status, d.ExpiresAt,
+	).Scan(&d.ID, &d.ReceivedAmount, &d.CreatedAt, &d.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("creating deposit intent: %w", err)
+	}
+	return nil
+}
+
+// GetDepositIntent retrieves an intent by ID
+func (r *PostgresDepositRepo) GetDepositIntent(ctx context.Context, id string) (*repository.DepositIntent, error) {
+	query := `SELECT ` + depositIntentColumns + ` FROM deposit_intents WHERE id = $1`
+
+	d, err := scanDepositIntent(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrDepositIntentNotFound
+		}
+		return nil, fmt.Errorf("getting deposit intent %s: %w", id, err)
+	}
+
+	return d, nil
+}
+
+// ListDepositIntents lists intents with filtering, newest first
+func (r *PostgresDepositRepo) ListDepositIntents(ctx context.Context, filter repository.DepositIntentFilter, page repository.Pagination) ([]*repository.DepositIntent, int64, error) {
+	whereClause := "WHERE 1=1"
+	args := []interface{}{}
+	argNum := 1
+
+	if filter.PayerAddress != "" {
+		whereClause += fmt.Sprintf(" AND payer_address = $%d", argNum)
+		args = append(args, filter.PayerAddress)
+		argNum++
+	}
+	if filter.Status != "" {
+		whereClause += fmt.Sprintf(" AND status = $%d", argNum)
+		args = append(args, filter.Status)
+		argNum++
+	}
+	if filter.ExcludesExpired() {
+		whereClause += " AND status <> 'expired'"
+	}
+
+	var total int64
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM deposit_intents "+whereClause, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("counting deposit intents: %w", err)
+	}
+
+	if page.PageSize <= 0 {
+		page.PageSize = 20
+	}
+	if page.Page <= 0 {
+		page.Page = 1
+	}
+	offset := (page.Page - 1) * page.PageSize
+
+	query := fmt.Sprintf(`
+		SELECT `+depositIntentColumns+`
+		FROM deposit_intents
+		%s
+		ORDER BY created_at DESC
+		LIMIT $%d OFFSET $%d
+	`, whereClause, argNum, argNum+1)
+
+	args = append(args, page.PageSize, offset)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("listing deposit intents: %w", err)
+	}
+	defer rows.Close()
+
+	var result []*repository.DepositIntent
+	for rows.Next() {
+		d, err := scanDepositIntent(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("scanning deposit intent row: %w", err)
+		}
+		result = append(result, d)
+	}
+
+	return result, total, rows.Err()
+}
+
+// UpdateDepositIntent changes an intent's status
+func (r *PostgresDepositRepo) UpdateDepositIntent(ctx context.Context, id string, update *repository.DepositIntentUpdate) error {
+	query := `
+		UPDATE deposit_intents
+		SET status = $2,
+		    received_amount = COALESCE($3::NUMERIC, received_amount),
+		    sweep_tx_hash = COALESCE($4, sweep_tx_hash),
+		    sweep_attempted_at = CASE WHEN $4::VARCHAR IS NULL THEN sweep_attempted_at ELSE NOW() END,
+		    detected_at = COALESCE(detected_at, CASE WHEN $2 = 'confirming' THEN NOW() END),
+		    paid_at = COALESCE(paid_at, CASE WHEN $2 = 'paid' THEN NOW() END),
+		    swept_at = COALESCE(swept_at, CASE WHEN $2 = 'swept' THEN NOW() END),
+		    updated_at = NOW()
+		WHERE id = $1
+	`
+
+	result, err := r.db.ExecContext(ctx, query, id, update.Status, update.ReceivedAmount, update.SweepTxHash)
+	if err != nil {
+		return fmt.Errorf("updating deposit intent %s: %w", id, err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("checking rows affected: %w", err)
+	}
+	if rows == 0 {
+		return repository.ErrDepositIntentNotFound
+	}
+
+	return nil
+}
+
+// scanDepositIntent scans a deposit_intents row
+func scanDepositIntent(row rowScanner) (*repository.DepositIntent, error) {
+	d := &repository.DepositIntent{}
+	err := row.Scan(
+		&d.ID,
+		&d.PaymentID,
+		&d.PayerAddress,
+		&d.Currency,
+		&d.TokenAddress,
+		&d.DepositAddress,
+		&d.DerivationIndex,
+		&d.ExpectedAmount,
+		&d.ReceivedAmount,
+		&d.Status,
+		&d.ExpiresAt,
+		&d.CreatedAt,
+		&d.UpdatedAt,
+		&d.DetectedAt,
+		&d.PaidAt,
+		&d.SweepTxHash,
+		&d.SweepAttemptedAt,
+		&d.SweptAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return d, nil
+}
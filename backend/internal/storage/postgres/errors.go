@@ -0,0 +1,49 @@
+// Package postgres implements repository interfaces using PostgreSQL
+package postgres
+
+import (
+	"errors"
+
+	"github.com/lib/pq"
+)
+
+// IsUnavailable reports whether err is a PostgreSQL error meaning the server
+// could not serve the query (connection failures, resource exhaustion,
+// shutdown, statement timeout) rather than rejecting it
+func IsUnavailable(err error) bool {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return false
+	}
+
+	switch pqErr.Code.Class() {
+	case "08", // connection exception
+		"53", // insufficient resources (too many connections, out of memory)
+		"57", // operator intervention (admin shutdown, query canceled)
+		"58": // system error (I/O)
+		return true
+	}
+	return false
+}
+
+// IsRetryable reports whether err is a PostgreSQL error after which the
+// statement or transaction was rolled back or never started, so repeating it
+// is safe: serialization failures, deadlocks, and connections refused while
+// the server starts, shuts down or runs out of connection slots
+func IsRetryable(err error) bool {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return false
+	}
+
+	switch pqErr.Code {
+	case "40001", // serialization_failure
+		"40P01", // deadlock_detected
+		"08001", // sqlclient_unable_to_establish_sqlconnection
+		"08004", // sqlserver_rejected_establishment_of_sqlconnection
+		"53300", // too_many_connections
+		"57P03": // cannot_connect_now
+		return true
+	}
+	return false
+}
@@ -0,0 +1,365 @@
+// Package postgres implements repository interfaces using PostgreSQL
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure PostgresGasBillingRepo implements GasBillingRepository
+var _ repository.GasBillingRepository = (*PostgresGasBillingRepo)(nil)
+
+// PostgresGasBillingRepo implements GasBillingRepository using PostgreSQL.
+// Debt checks gate relaying right after charges are written, so every query
+// uses the primary.
+type PostgresGasBillingRepo struct {
+	db *sql.DB
+}
+
+// NewPostgresGasBillingRepo creates a new PostgreSQL gas billing repository
+func NewPostgresGasBillingRepo(db *sql.DB) *PostgresGasBillingRepo {
+	return &PostgresGasBillingRepo{db: db}
+}
+
+// gasChargeColumns is the column list read by every gas charge query
+const gasChargeColumns = `id, meta_tx_id, user_address, gas_used, gas_price::TEXT, cost_wei::TEXT,
+		       nexus_per_eth::TEXT, markup_percent, amount_nexus::TEXT, created_at`
+
+// gasSettlementColumns is the column list read by every gas settlement query
+const gasSettlementColumns = `id, user_address, method, amount_nexus::TEXT, status, tx_hash,
+		       token_address, treasury_address, invoice_ref, error_message, created_by,
+		       created_at, updated_at, settled_at`
+
+// CreateGasCharge records a meta-transaction's charge once
+func (r *PostgresGasBillingRepo) CreateGasCharge(ctx context.Context, c *repository.GasCharge) (bool, error) {
+	query := `
+		INSERT INTO gas_charges (meta_tx_id, user_address, gas_used, gas_price, cost_wei,
+		                         nexus_per_eth, markup_percent, amount_nexus)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT ON CONSTRAINT gas_charges_meta_tx_unique DO NOTHING
+		RETURNING id, created_at
+	`
+
+	err := r.db.QueryRowContext(ctx, query,
+		c.MetaTxID, c.UserAddress, c.GasUsed, c.GasPrice, c.CostWei,
+		c.NexusPerETH, c.MarkupPercent, c.AmountNexus,
+	).Scan(&c.ID, &c.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("creating gas charge: %w", err)
+	}
+	return true, nil
+}
+
+// ListGasCharges lists a user's charges, newest first
+func (r *PostgresGasBillingRepo) ListGasCharges(ctx context.Context, userAddress string, page repository.Pagination) ([]*repository.GasCharge, int64, error) {
+	var total int64
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM gas_charges WHERE user_address = $1", userAddress).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("counting gas charges: %w", err)
+	}
+
+	if page.PageSize <= 0 {
+		page.PageSize = 20
+	}
+	if page.Page <= 0 {
+		page.Page = 1
+	}
+	offset := (page.Page - 1) * page.PageSize
+
+	query := `
+		SELECT ` + gasChargeColumns + `
+		FROM gas_charges
+		WHERE user_address = $1
+		ORDER BY created_at DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userAddress, page.PageSize, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("listing gas charges: %w", err)
+	}
+	defer rows.Close()
+
+	var result []*repository.GasCharge
+	for rows.Next() {
+		c := &repository.GasCharge{}
+		if err := rows.Scan(
+			&c.ID, &c.MetaTxID, &c.UserAddress, &c.GasUsed, &c.GasPrice, &c.CostWei,
+			&c.NexusPerETH, &c.MarkupPercent, &c.AmountNexus, &c.CreatedAt,
+		); err != nil {
+			return nil, 0, fmt.Errorf("scanning gas charge row: %w", err)
+		}
+		result = append(result, c)
+	}
+
+	return result, total, rows.Err()
+}
+
+// CreateGasSettlement records a settlement, refusing a tx hash used before
+func (r *PostgresGasBillingRepo) CreateGasSettlement(ctx context.Context, s *repository.GasSettlement) error {
+	query := `
+		INSERT INTO gas_settlements (user_address, method, amount_nexus, status, tx_hash,
+		                             token_address, treasury_address, invoice_ref, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT ON CONSTRAINT gas_settlements_tx_hash_unique DO NOTHING
+		RETURNING id, created_at, updated_at
+	`
+
+	err := r.db.QueryRowContext(ctx, query,
+		s.UserAddress, s.Method, s.AmountNexus, s.Status, s.TxHash,
+		s.TokenAddress, s.TreasuryAddress, s.InvoiceRef, s.CreatedBy,
+	).Scan(&s.ID, &s.CreatedAt, &s.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return repository.ErrGasSettlementExists
+	}
+	if err != nil {
+		return fmt.Errorf("creating gas settlement: %w", err)
+	}
+	return nil
+}
+
+// GetGasSettlement retrieves a settlement by ID
+func (r *PostgresGasBillingRepo) GetGasSettlement(ctx context.Context, id string) (*repository.GasSettlement, error) {
+	query := `SELECT ` + gasSettlementColumns + ` FROM gas_settlements WHERE id = $1`
+
+	s, err := scanGasSettlement(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrGasSettlementNotFound
+		}
+		return nil, fmt.Errorf("getting gas settlement %s: %w", id, err)
+	}
+
+	return s, nil
+}
+
+// ListGasSettlements lists settlements with filtering, newest first
+func (r *PostgresGasBillingRepo) ListGasSettlements(ctx context.Context, filter repository.GasSettlementFilter, page repository.Pagination) ([]*repository.GasSettlement, int64, error) {
+	whereClause := "WHERE 1=1"
+	args := []interface{}{}
+	argNum := 1
+
+	if filter.UserAddress != "" {
+		whereClause += fmt.Sprintf(" AND user_address = $%d", argNum)
+		args = append(args, filter.UserAddress)
+		argNum++
+	}
+	if filter.Method != "" {
+		whereClause += fmt.Sprintf(" AND method = $%d", argNum)
+		args = append(args, filter.Method)
+		argNum++
+	}
+	if filter.Status != "" {
+		whereClause += fmt.Sprintf(" AND status = $%d", argNum)
+		args = append(args, filter.Status)
+		argNum++
+	}
+
+	var total int64
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM gas_settlements "+whereClause, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("counting gas settlements: %w", err)
+	}
+
+	if page.PageSize <= 0 {
+		page.PageSize = 20
+	}
+	if page.Page <= 0 {
+		page.Page = 1
+	}
+	offset := (page.Page - 1) * page.PageSize
+
+	query := fmt.Sprintf(`
+		SELECT `+gasSettlementColumns+`
+		FROM gas_settlements
+		%s
+		ORDER BY created_at DESC
+		LIMIT $%d OFFSET $%d
+	`, whereClause, argNum, argNum+1)
+
+	args = append(args, page.PageSize, offset)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("listing gas settlements: %w", err)
+	}
+	defer rows.Close()
+
+	var result []*repository.GasSettlement
+	for rows.Next() {
+		s, err := scanGasSettlement(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("scanning gas settlement row: %w", err)
+		}
+		result = append(result, s)
+	}
+
+	return result, total, rows.Err()
+}
+
+// UpdateGasSettlementStatus applies an allowed status change. The row is
+// locked so a confirmation and an admin action cannot both apply.
+func (r *PostgresGasBillingRepo) UpdateGasSettlementStatus(ctx context.Context, id string, update *repository.GasSettlementUpdate) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginning gas settlement update: %w", err)
+	}
+	defer tx.Rollback()
+
+	current, err := scanGasSettlement(tx.QueryRowContext(ctx,
+		`SELECT `+gasSettlementColumns+` FROM gas_settlements WHERE id = $1 FOR UPDATE`, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return repository.ErrGasSettlementNotFound
+		}
+		return fmt.Errorf("getting gas settlement %s: %w", id, err)
+	}
+	if !current.CanMoveTo(update.Status) {
+		return repository.ErrGasSettlementClosed
+	}
+
+	query := `
+		UPDATE gas_settlements
+		SET status = $2, error_message = $3, updated_at = NOW(),
+		    settled_at = CASE WHEN $2 = 'settled' THEN NOW() END
+		WHERE id = $1
+	`
+	if _, err := tx.ExecContext(ctx, query, id, update.Status, update.ErrorMessage); err != nil {
+		return fmt.Errorf("updating gas settlement %s: %w", id, err)
+	}
+
+	return tx.Commit()
+}
+
+// gasDebts totals charges and settlements per user; outstanding is
+// charged - settled
+const gasDebts = `
+	WITH charged AS (
+		SELECT user_address, COUNT(*) AS charge_count, SUM(amount_nexus) AS amount, MAX(created_at) AS last_charged_at
+		FROM gas_charges
+		GROUP BY user_address
+	), settled AS (
+		SELECT user_address,
+		       COALESCE(SUM(amount_nexus) FILTER (WHERE status = 'settled'), 0) AS amount,
+		       COALESCE(SUM(amount_nexus) FILTER (WHERE status IN ('pending', 'invoiced')), 0) AS pending
+		FROM gas_settlements
+		GROUP BY user_address
+	)
+	SELECT COALESCE(c.user_address, s.user_address) AS user_address,
+	       COALESCE(c.charge_count, 0),
+	       COALESCE(c.amount, 0)::TEXT,
+	       COALESCE(s.amount, 0)::TEXT,
+	       COALESCE(s.pending, 0)::TEXT,
+	       COALESCE(c.amount, 0) - COALESCE(s.amount, 0) AS outstanding,
+	       c.last_charged_at
+	FROM charged c
+	FULL OUTER JOIN settled s ON s.user_address = c.user_address
+`
+
+// GetGasDebt totals a user's charges and settlements
+func (r *PostgresGasBillingRepo) GetGasDebt(ctx context.Context, userAddress string) (*repository.GasDebt, error) {
+	query := `SELECT * FROM (` + gasDebts + `) debts WHERE user_address = $1`
+
+	d, err := scanGasDebt(r.db.QueryRowContext(ctx, query, userAddress))
+	if errors.Is(err, sql.ErrNoRows) {
+		return repository.NewGasDebtTally().Debt(userAddress), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("getting gas debt for %s: %w", userAddress, err)
+	}
+	return d, nil
+}
+
+// ListGasDebts lists users with outstanding debt, largest first
+func (r *PostgresGasBillingRepo) ListGasDebts(ctx context.Context, page repository.Pagination) ([]*repository.GasDebt, int64, error) {
+	var total int64
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM (`+gasDebts+`) debts WHERE outstanding > 0`).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("counting gas debts: %w", err)
+	}
+
+	if page.PageSize <= 0 {
+		page.PageSize = 50
+	}
+	if page.Page <= 0 {
+		page.Page = 1
+	}
+	offset := (page.Page - 1) * page.PageSize
+
+	query := `
+		SELECT * FROM (` + gasDebts + `) debts
+		WHERE outstanding > 0
+		ORDER BY outstanding DESC, user_address
+		LIMIT $1 OFFSET $2
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, page.PageSize, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("listing gas debts: %w", err)
+	}
+	defer rows.Close()
+
+	var result []*repository.GasDebt
+	for rows.Next() {
+		d, err := scanGasDebt(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("scanning gas debt row: %w", err)
+		}
+		result = append(result, d)
+	}
+
+	return result, total, rows.Err()
+}
+
+// scanGasSettlement scans a gas_settlements row
+func scanGasSettlement(row rowScanner) (*repository.GasSettlement, error) {
+	s := &repository.GasSettlement{}
+	err := row.Scan(
+		&s.ID,
+		&s.UserAddress,
+		&s.Method,
+		&s.AmountNexus,
+		&s.Status,
+		&s.TxHash,
+		&s.TokenAddress,
+		&s.TreasuryAddress,
+		&s.InvoiceRef,
+		&s.ErrorMessage,
+		&s.CreatedBy,
+		&s.CreatedAt,
+		&s.UpdatedAt,
+		&s.SettledAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// scanGasDebt scans a gasDebts row
+func scanGasDebt(row rowScanner) (*repository.GasDebt, error) {
+	d := &repository.GasDebt{}
+	err := row.Scan(
+		&d.UserAddress,
+		&d.ChargeCount,
+		&d.Charged,
+		&d.Settled,
+		&d.Pending,
+		&d.Outstanding,
+		&d.LastChargedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	outstanding, ok := new(big.Int).SetString(d.Outstanding, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid outstanding amount %q", d.Outstanding)
+	}
+	d.OutstandingNexus = repository.FormatWeiETH(outstanding) // NEXUS has 18 decimals like ETH
+	return d, nil
+}
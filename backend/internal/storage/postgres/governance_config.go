@@ -17,12 +17,18 @@ var _ repository.GovernanceConfigRepository = (*PostgresGovernanceConfigRepo)(ni
 
 // PostgresGovernanceConfigRepo implements GovernanceConfigRepository using PostgreSQL
 type PostgresGovernanceConfigRepo struct {
-	db *sql.DB
+	db   *sql.DB
+	read querier // replica-safe reads; db unless UseReadRouter was called
 }
 
 // NewPostgresGovernanceConfigRepo creates a new PostgreSQL governance config repository
 func NewPostgresGovernanceConfigRepo(db *sql.DB) *PostgresGovernanceConfigRepo {
-	return &PostgresGovernanceConfigRepo{db: db}
+	return &PostgresGovernanceConfigRepo{db: db, read: db}
+}
+
+// UseReadRouter sends replica-safe reads through router
+func (r *PostgresGovernanceConfigRepo) UseReadRouter(router *ReadRouter) {
+	r.read = router
 }
 
 // GetConfig retrieves a governance config by key and chain ID
@@ -47,7 +53,7 @@ func (r *PostgresGovernanceConfigRepo) GetConfig(ctx context.Context, configKey
 		updatedBy    sql.NullString
 	)
 
-	err := r.db.QueryRowContext(ctx, query, configKey, chainID).Scan(
+	err := r.read.QueryRowContext(ctx, query, configKey, chainID).Scan(
 		&c.ID,
 		&c.ConfigKey,
 		&c.ConfigName,
@@ -116,7 +122,7 @@ func (r *PostgresGovernanceConfigRepo) ListConfigs(ctx context.Context, chainID
 	}
 	query += " ORDER BY config_key"
 
-	rows, err := r.db.QueryContext(ctx, query, chainID)
+	rows, err := r.read.QueryContext(ctx, query, chainID)
 	if err != nil {
 		return nil, fmt.Errorf("listing governance configs for chain %d: %w", chainID, err)
 	}
@@ -285,7 +291,7 @@ func (r *PostgresGovernanceConfigRepo) GetConfigHistory(ctx context.Context, con
 		LIMIT $3
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, configKey, chainID, limit)
+	rows, err := r.read.QueryContext(ctx, query, configKey, chainID, limit)
 	if err != nil {
 		return nil, fmt.Errorf("getting governance config history: %w", err)
 	}
@@ -0,0 +1,214 @@
+// Package postgres implements repository interfaces using PostgreSQL
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure PostgresGovernanceNotificationRepo implements GovernanceNotificationRepository
+var _ repository.GovernanceNotificationRepository = (*PostgresGovernanceNotificationRepo)(nil)
+
+// PostgresGovernanceNotificationRepo implements GovernanceNotificationRepository using PostgreSQL
+type PostgresGovernanceNotificationRepo struct {
+	db *sql.DB
+}
+
+// NewPostgresGovernanceNotificationRepo creates a new PostgreSQL governance notification repository
+func NewPostgresGovernanceNotificationRepo(db *sql.DB) *PostgresGovernanceNotificationRepo {
+	return &PostgresGovernanceNotificationRepo{db: db}
+}
+
+// governanceSubscriptionColumns is the column list read by every subscription query
+const governanceSubscriptionColumns = `address, webhook_url, secret, events, digest, last_digest_at, created_at, updated_at`
+
+// UpsertGovernanceSubscription creates or replaces a subscription, keeping its digest schedule
+func (r *PostgresGovernanceNotificationRepo) UpsertGovernanceSubscription(ctx context.Context, s *repository.GovernanceSubscription) error {
+	query := `
+		INSERT INTO governance_subscriptions (address, webhook_url, secret, events, digest)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (address) DO UPDATE SET
+		    webhook_url = excluded.webhook_url, secret = excluded.secret, events = excluded.events,
+		    digest = excluded.digest, updated_at = NOW()
+		RETURNING last_digest_at, created_at, updated_at
+	`
+
+	err := r.db.QueryRowContext(ctx, query, s.Address, s.WebhookURL, s.Secret, pq.Array(append([]string{}, s.Events...)), s.Digest).
+		Scan(&s.LastDigestAt, &s.CreatedAt, &s.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("upserting governance subscription %s: %w", s.Address, err)
+	}
+	return nil
+}
+
+// GetGovernanceSubscription retrieves the subscription of address
+func (r *PostgresGovernanceNotificationRepo) GetGovernanceSubscription(ctx context.Context, address string) (*repository.GovernanceSubscription, error) {
+	query := `SELECT ` + governanceSubscriptionColumns + ` FROM governance_subscriptions WHERE address = $1`
+
+	s, err := scanGovernanceSubscription(r.db.QueryRowContext(ctx, query, address))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrGovernanceSubscriptionNotFound
+		}
+		return nil, fmt.Errorf("getting governance subscription %s: %w", address, err)
+	}
+
+	return s, nil
+}
+
+// DeleteGovernanceSubscription removes the subscription of address
+func (r *PostgresGovernanceNotificationRepo) DeleteGovernanceSubscription(ctx context.Context, address string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM governance_subscriptions WHERE address = $1`, address)
+	if err != nil {
+		return fmt.Errorf("deleting governance subscription %s: %w", address, err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return repository.ErrGovernanceSubscriptionNotFound
+	}
+	return nil
+}
+
+// ListGovernanceSubscriptions lists subscriptions with filtering, newest first
+func (r *PostgresGovernanceNotificationRepo) ListGovernanceSubscriptions(ctx context.Context, filter repository.GovernanceSubscriptionFilter, page repository.Pagination) ([]*repository.GovernanceSubscription, int64, error) {
+	whereClause := "WHERE 1=1"
+	args := []interface{}{}
+	argNum := 1
+
+	if filter.Event != "" {
+		whereClause += fmt.Sprintf(" AND $%d = ANY(events)", argNum)
+		args = append(args, filter.Event)
+		argNum++
+	}
+	if filter.Digest != "" {
+		whereClause += fmt.Sprintf(" AND digest = $%d", argNum)
+		args = append(args, filter.Digest)
+		argNum++
+	}
+
+	var total int64
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM governance_subscriptions "+whereClause, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("counting governance subscriptions: %w", err)
+	}
+
+	if page.PageSize <= 0 {
+		page.PageSize = 20
+	}
+	if page.Page <= 0 {
+		page.Page = 1
+	}
+	offset := (page.Page - 1) * page.PageSize
+
+	query := fmt.Sprintf(`
+		SELECT `+governanceSubscriptionColumns+`
+		FROM governance_subscriptions
+		%s
+		ORDER BY created_at DESC
+		LIMIT $%d OFFSET $%d
+	`, whereClause, argNum, argNum+1)
+
+	args = append(args, page.PageSize, offset)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("listing governance subscriptions: %w", err)
+	}
+	defer rows.Close()
+
+	var result []*repository.GovernanceSubscription
+	for rows.Next() {
+		s, err := scanGovernanceSubscription(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("scanning governance subscription row: %w", err)
+		}
+		result = append(result, s)
+	}
+
+	return result, total, rows.Err()
+}
+
+// MarkGovernanceDigestSent records when a subscriber's digest was last sent
+func (r *PostgresGovernanceNotificationRepo) MarkGovernanceDigestSent(ctx context.Context, address string, at time.Time) error {
+	result, err := r.db.ExecContext(ctx,
+		`UPDATE governance_subscriptions SET last_digest_at = $2 WHERE address = $1`, address, at)
+	if err != nil {
+		return fmt.Errorf("marking governance digest sent for %s: %w", address, err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return repository.ErrGovernanceSubscriptionNotFound
+	}
+	return nil
+}
+
+// RecordGovernanceEvent records a lifecycle event once per proposal and event type
+func (r *PostgresGovernanceNotificationRepo) RecordGovernanceEvent(ctx context.Context, e *repository.GovernanceEvent) (bool, error) {
+	query := `
+		INSERT INTO governance_events (proposal_id, event_type, title, detail)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (proposal_id, event_type) DO NOTHING
+		RETURNING id, occurred_at
+	`
+
+	err := r.db.QueryRowContext(ctx, query, e.ProposalID, e.EventType, e.Title, e.Detail).
+		Scan(&e.ID, &e.OccurredAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, fmt.Errorf("recording governance event %s for proposal %s: %w", e.EventType, e.ProposalID, err)
+	}
+	return true, nil
+}
+
+// ListGovernanceEvents lists events recorded after since, oldest first
+func (r *PostgresGovernanceNotificationRepo) ListGovernanceEvents(ctx context.Context, since time.Time, limit int) ([]*repository.GovernanceEvent, error) {
+	query := `
+		SELECT id, proposal_id, event_type, title, detail, occurred_at
+		FROM governance_events
+		WHERE occurred_at > $1
+		ORDER BY occurred_at ASC
+		LIMIT $2
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, since, limit)
+	if err != nil {
+		return nil, fmt.Errorf("listing governance events: %w", err)
+	}
+	defer rows.Close()
+
+	var result []*repository.GovernanceEvent
+	for rows.Next() {
+		e := &repository.GovernanceEvent{}
+		if err := rows.Scan(&e.ID, &e.ProposalID, &e.EventType, &e.Title, &e.Detail, &e.OccurredAt); err != nil {
+			return nil, fmt.Errorf("scanning governance event row: %w", err)
+		}
+		result = append(result, e)
+	}
+
+	return result, rows.Err()
+}
+
+// scanGovernanceSubscription scans a governance_subscriptions row
+func scanGovernanceSubscription(row rowScanner) (*repository.GovernanceSubscription, error) {
+	s := &repository.GovernanceSubscription{}
+	err := row.Scan(
+		&s.Address,
+		&s.WebhookURL,
+		&s.Secret,
+		pq.Array(&s.Events),
+		&s.Digest,
+		&s.LastDigestAt,
+		&s.CreatedAt,
+		&s.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
@@ -0,0 +1,100 @@
+// Package postgres implements repository interfaces using PostgreSQL
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure PostgresKPIRepo implements KPIRepository
+var _ repository.KPIRepository = (*PostgresKPIRepo)(nil)
+
+// PostgresKPIRepo implements KPIRepository using PostgreSQL
+type PostgresKPIRepo struct {
+	read querier // replica-safe reads; db unless UseReadRouter was called
+}
+
+// NewPostgresKPIRepo creates a new PostgreSQL KPI repository
+func NewPostgresKPIRepo(db *sql.DB) *PostgresKPIRepo {
+	return &PostgresKPIRepo{read: db}
+}
+
+// UseReadRouter sends replica-safe reads through router
+func (r *PostgresKPIRepo) UseReadRouter(router *ReadRouter) {
+	r.read = router
+}
+
+// GetBusinessKPIs aggregates payments, KYC verifications, meta-transactions
+// and NFT mints
+func (r *PostgresKPIRepo) GetBusinessKPIs(ctx context.Context, since time.Time) (*repository.BusinessKPIs, error) {
+	kpis := &repository.BusinessKPIs{
+		PaymentsByStatus: make(map[repository.PaymentStatus]int64),
+		KYCByStatus:      make(map[repository.KYCVerificationStatus]int64),
+	}
+
+	rows, err := r.read.QueryContext(ctx, `SELECT status, COUNT(*) FROM payments GROUP BY status`)
+	if err != nil {
+		return nil, fmt.Errorf("counting payments by status: %w", err)
+	}
+	for rows.Next() {
+		var status repository.PaymentStatus
+		var count int64
+		if err := rows.Scan(&status, &count); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("scanning payment count: %w", err)
+		}
+		kpis.PaymentsByStatus[status] = count
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("counting payments by status: %w", err)
+	}
+
+	rows, err = r.read.QueryContext(ctx, `SELECT status, COUNT(*) FROM kyc_verifications GROUP BY status`)
+	if err != nil {
+		return nil, fmt.Errorf("counting kyc verifications by status: %w", err)
+	}
+	for rows.Next() {
+		var status repository.KYCVerificationStatus
+		var count int64
+		if err := rows.Scan(&status, &count); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("scanning kyc verification count: %w", err)
+		}
+		kpis.KYCByStatus[status] = count
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("counting kyc verifications by status: %w", err)
+	}
+
+	query := `
+		SELECT
+			(SELECT COUNT(*) FROM kyc_verifications WHERE verified_at >= $1) AS kyc_approved,
+			(SELECT COUNT(*) FROM kyc_verifications WHERE rejected_at >= $1) AS kyc_rejected,
+			COUNT(*) AS relay_confirmed,
+			COALESCE(SUM(EXTRACT(EPOCH FROM confirmed_at - created_at)), 0) AS relay_seconds,
+			(SELECT COUNT(*) FROM nft_tokens WHERE minted_at >= $1) AS nft_mints
+		FROM meta_transactions
+		WHERE status = 'confirmed' AND confirmed_at >= $1
+	`
+
+	var nftMints int64
+	err = r.read.QueryRowContext(ctx, query, since).Scan(
+		&kpis.KYCApproved,
+		&kpis.KYCRejected,
+		&kpis.RelayConfirmed,
+		&kpis.RelayConfirmationSeconds,
+		&nftMints,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("getting activity kpis: %w", err)
+	}
+	kpis.NFTMints = &nftMints
+
+	return kpis, nil
+}
@@ -0,0 +1,114 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure PostgresKYCAuditRepo implements KYCAuditRepository
+var _ repository.KYCAuditRepository = (*PostgresKYCAuditRepo)(nil)
+
+// PostgresKYCAuditRepo implements KYCAuditRepository using PostgreSQL. Pages
+// are keyset queries on (timestamp, id), served by idx_audit_timestamp or,
+// for one subject, idx_audit_subject.
+type PostgresKYCAuditRepo struct {
+	db *sql.DB
+}
+
+// NewPostgresKYCAuditRepo creates a new PostgreSQL KYC audit repository
+func NewPostgresKYCAuditRepo(db *sql.DB) *PostgresKYCAuditRepo {
+	return &PostgresKYCAuditRepo{db: db}
+}
+
+// auditColumns is the column list read by every audit query
+const auditColumns = `id, timestamp, action, actor, COALESCE(subject, ''), COALESCE(details, ''),
+	COALESCE(host(ip_address), ''), COALESCE(country, ''), COALESCE(previous_state, ''), COALESCE(new_state, '')`
+
+// AppendAuditEntry stores an audit entry
+func (r *PostgresKYCAuditRepo) AppendAuditEntry(ctx context.Context, e *repository.KYCAuditEntry) error {
+	query := `
+		INSERT INTO audit_log (timestamp, action, actor, subject, details, ip_address, country, previous_state, new_state)
+		VALUES (COALESCE($1::timestamptz, NOW()), $2, $3, $4, $5, NULLIF($6, '')::inet, NULLIF($7, ''), $8, $9)
+		RETURNING id, timestamp
+	`
+
+	var ts sql.NullTime
+	if !e.Timestamp.IsZero() {
+		ts = sql.NullTime{Time: e.Timestamp, Valid: true}
+	}
+	err := r.db.QueryRowContext(ctx, query,
+		ts, e.Action, e.Actor, e.Subject, e.Details, e.IPAddress, e.Country, e.PreviousState, e.NewState,
+	).Scan(&e.ID, &e.Timestamp)
+	if err != nil {
+		return fmt.Errorf("appending audit entry: %w", err)
+	}
+	return nil
+}
+
+// ListAuditEntries returns up to limit entries after filter.After, newest first
+func (r *PostgresKYCAuditRepo) ListAuditEntries(ctx context.Context, filter repository.KYCAuditFilter, limit int) ([]*repository.KYCAuditEntry, error) {
+	where, args, argNum := auditWhere(filter)
+	if filter.After != nil {
+		where = append(where, fmt.Sprintf("(timestamp, id) < ($%d, $%d::uuid)", argNum, argNum+1))
+		args = append(args, filter.After.Timestamp, filter.After.ID)
+		argNum += 2
+	}
+
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM audit_log
+		WHERE %s
+		ORDER BY timestamp DESC, id DESC
+		LIMIT $%d
+	`, auditColumns, strings.Join(where, " AND "), argNum)
+	args = append(args, limit)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("listing audit entries: %w", err)
+	}
+	defer rows.Close()
+
+	var result []*repository.KYCAuditEntry
+	for rows.Next() {
+		e := &repository.KYCAuditEntry{}
+		if err := rows.Scan(&e.ID, &e.Timestamp, &e.Action, &e.Actor, &e.Subject, &e.Details,
+			&e.IPAddress, &e.Country, &e.PreviousState, &e.NewState); err != nil {
+			return nil, fmt.Errorf("scanning audit entry: %w", err)
+		}
+		result = append(result, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating audit entries: %w", err)
+	}
+	return result, nil
+}
+
+// CountAuditEntries counts the entries of filter.Subject (all without one)
+func (r *PostgresKYCAuditRepo) CountAuditEntries(ctx context.Context, filter repository.KYCAuditFilter) (int64, error) {
+	where, args, _ := auditWhere(filter)
+
+	var total int64
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM audit_log WHERE "+strings.Join(where, " AND "), args...).Scan(&total); err != nil {
+		return 0, fmt.Errorf("counting audit entries: %w", err)
+	}
+	return total, nil
+}
+
+// auditWhere builds the subject condition shared by list and count
+func auditWhere(filter repository.KYCAuditFilter) ([]string, []interface{}, int) {
+	where := []string{"1=1"}
+	args := []interface{}{}
+	argNum := 1
+
+	if filter.Subject != "" {
+		where = append(where, fmt.Sprintf("subject = $%d", argNum))
+		args = append(args, filter.Subject)
+		argNum++
+	}
+	return where, args, argNum
+}
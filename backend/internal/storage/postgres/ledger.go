@@ -0,0 +1,268 @@
+// Package postgres implements repository interfaces using PostgreSQL
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure PostgresLedgerRepo implements LedgerRepository
+var _ repository.LedgerRepository = (*PostgresLedgerRepo)(nil)
+
+// PostgresLedgerRepo implements LedgerRepository using PostgreSQL. Postings
+// are read back straight after they are written, so every query uses the
+// primary.
+type PostgresLedgerRepo struct {
+	db *sql.DB
+}
+
+// NewPostgresLedgerRepo creates a new PostgreSQL ledger repository
+func NewPostgresLedgerRepo(db *sql.DB) *PostgresLedgerRepo {
+	return &PostgresLedgerRepo{db: db}
+}
+
+// ledgerEntryColumns is the column list read by every ledger entry query
+const ledgerEntryColumns = `e.id, e.transaction_id, e.account, e.direction, e.amount::TEXT, e.currency,
+		       t.event_type, t.payment_id, e.created_at`
+
+// PostLedgerTransaction writes a balanced transaction and its entries once
+func (r *PostgresLedgerRepo) PostLedgerTransaction(ctx context.Context, lt *repository.LedgerTransaction) (bool, error) {
+	if err := lt.Validate(); err != nil {
+		return false, err
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("beginning ledger transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO ledger_transactions (idempotency_key, event_type, payment_id, description)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT ON CONSTRAINT ledger_transactions_idempotency_unique DO NOTHING
+		RETURNING id, created_at
+	`, lt.IdempotencyKey, lt.EventType, lt.PaymentID, lt.Description).Scan(&lt.ID, &lt.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("posting ledger transaction: %w", err)
+	}
+
+	for _, e := range lt.Entries {
+		err := tx.QueryRowContext(ctx, `
+			INSERT INTO ledger_entries (transaction_id, account, direction, amount, currency)
+			VALUES ($1, $2, $3, $4, $5)
+			RETURNING id
+		`, lt.ID, e.Account, e.Direction, e.Amount, e.Currency).Scan(&e.ID)
+		if err != nil {
+			return false, fmt.Errorf("posting ledger entry: %w", err)
+		}
+		e.TransactionID = lt.ID
+		e.EventType = lt.EventType
+		e.PaymentID = lt.PaymentID
+		e.CreatedAt = lt.CreatedAt
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("committing ledger transaction: %w", err)
+	}
+	return true, nil
+}
+
+// GetLedgerTransaction retrieves a transaction with its entries
+func (r *PostgresLedgerRepo) GetLedgerTransaction(ctx context.Context, id string) (*repository.LedgerTransaction, error) {
+	lt := &repository.LedgerTransaction{}
+	err := r.db.QueryRowContext(ctx, `
+		SELECT id, idempotency_key, event_type, payment_id, description, created_at
+		FROM ledger_transactions WHERE id = $1
+	`, id).Scan(&lt.ID, &lt.IdempotencyKey, &lt.EventType, &lt.PaymentID, &lt.Description, &lt.CreatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrLedgerTransactionNotFound
+		}
+		return nil, fmt.Errorf("getting ledger transaction %s: %w", id, err)
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+ledgerEntryColumns+`
+		FROM ledger_entries e JOIN ledger_transactions t ON t.id = e.transaction_id
+		WHERE e.transaction_id = $1
+		ORDER BY e.direction DESC, e.account
+	`, id)
+	if err != nil {
+		return nil, fmt.Errorf("getting ledger entries of %s: %w", id, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		e, err := scanLedgerEntry(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning ledger entry row: %w", err)
+		}
+		lt.Entries = append(lt.Entries, e)
+	}
+
+	return lt, rows.Err()
+}
+
+// ListLedgerEntries lists entries with filtering, oldest first
+func (r *PostgresLedgerRepo) ListLedgerEntries(ctx context.Context, filter repository.LedgerEntryFilter, page repository.Pagination) ([]*repository.LedgerEntry, int64, error) {
+	whereClause := "WHERE 1=1"
+	args := []interface{}{}
+	argNum := 1
+
+	if filter.Account != "" {
+		whereClause += fmt.Sprintf(" AND e.account = $%d", argNum)
+		args = append(args, filter.Account)
+		argNum++
+	}
+	if filter.Currency != "" {
+		whereClause += fmt.Sprintf(" AND e.currency = $%d", argNum)
+		args = append(args, filter.Currency)
+		argNum++
+	}
+	if filter.PaymentID != "" {
+		whereClause += fmt.Sprintf(" AND t.payment_id = $%d", argNum)
+		args = append(args, filter.PaymentID)
+		argNum++
+	}
+	if filter.EventType != "" {
+		whereClause += fmt.Sprintf(" AND t.event_type = $%d", argNum)
+		args = append(args, filter.EventType)
+		argNum++
+	}
+	if filter.From != nil {
+		whereClause += fmt.Sprintf(" AND e.created_at >= $%d", argNum)
+		args = append(args, *filter.From)
+		argNum++
+	}
+	if filter.To != nil {
+		whereClause += fmt.Sprintf(" AND e.created_at < $%d", argNum)
+		args = append(args, *filter.To)
+		argNum++
+	}
+
+	from := "FROM ledger_entries e JOIN ledger_transactions t ON t.id = e.transaction_id "
+
+	var total int64
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) "+from+whereClause, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("counting ledger entries: %w", err)
+	}
+
+	if page.PageSize <= 0 {
+		page.PageSize = 50
+	}
+	if page.Page <= 0 {
+		page.Page = 1
+	}
+	offset := (page.Page - 1) * page.PageSize
+
+	query := fmt.Sprintf(`
+		SELECT `+ledgerEntryColumns+`
+		`+from+`
+		%s
+		ORDER BY e.created_at, e.transaction_id, e.direction DESC, e.account
+		LIMIT $%d OFFSET $%d
+	`, whereClause, argNum, argNum+1)
+
+	args = append(args, page.PageSize, offset)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("listing ledger entries: %w", err)
+	}
+	defer rows.Close()
+
+	var result []*repository.LedgerEntry
+	for rows.Next() {
+		e, err := scanLedgerEntry(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("scanning ledger entry row: %w", err)
+		}
+		result = append(result, e)
+	}
+
+	return result, total, rows.Err()
+}
+
+// GetLedgerBalances totals entries per account and currency. NUMERIC sums
+// are exact, so the totals are computed in the database.
+func (r *PostgresLedgerRepo) GetLedgerBalances(ctx context.Context, filter repository.LedgerBalanceFilter) ([]*repository.LedgerBalance, error) {
+	whereClause := "WHERE 1=1"
+	args := []interface{}{}
+	argNum := 1
+
+	if filter.Currency != "" {
+		whereClause += fmt.Sprintf(" AND currency = $%d", argNum)
+		args = append(args, filter.Currency)
+		argNum++
+	}
+	if filter.To != nil {
+		whereClause += fmt.Sprintf(" AND created_at < $%d", argNum)
+		args = append(args, *filter.To)
+	}
+
+	query := `
+		SELECT account, currency, COUNT(*),
+		       COALESCE(SUM(amount) FILTER (WHERE direction = 'debit'), 0)::TEXT,
+		       COALESCE(SUM(amount) FILTER (WHERE direction = 'credit'), 0)::TEXT
+		FROM ledger_entries
+		` + whereClause + `
+		GROUP BY account, currency
+		ORDER BY account, currency
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("totalling ledger entries: %w", err)
+	}
+	defer rows.Close()
+
+	result := []*repository.LedgerBalance{}
+	for rows.Next() {
+		var account repository.LedgerAccount
+		var currency, debitText, creditText string
+		var count int64
+		if err := rows.Scan(&account, &currency, &count, &debitText, &creditText); err != nil {
+			return nil, fmt.Errorf("scanning ledger balance row: %w", err)
+		}
+		debits, ok := new(big.Int).SetString(debitText, 10)
+		if !ok {
+			return nil, fmt.Errorf("invalid ledger total %q", debitText)
+		}
+		credits, ok := new(big.Int).SetString(creditText, 10)
+		if !ok {
+			return nil, fmt.Errorf("invalid ledger total %q", creditText)
+		}
+		result = append(result, repository.NewLedgerBalance(account, currency, count, debits, credits))
+	}
+
+	return result, rows.Err()
+}
+
+// scanLedgerEntry scans a ledger_entries row joined with its transaction
+func scanLedgerEntry(row rowScanner) (*repository.LedgerEntry, error) {
+	e := &repository.LedgerEntry{}
+	err := row.Scan(
+		&e.ID,
+		&e.TransactionID,
+		&e.Account,
+		&e.Direction,
+		&e.Amount,
+		&e.Currency,
+		&e.EventType,
+		&e.PaymentID,
+		&e.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return e, nil
+}
@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
 )
@@ -16,12 +17,18 @@ var _ repository.PaymentRepository = (*PostgresPaymentRepo)(nil)
 
 // PostgresPaymentRepo implements PaymentRepository using PostgreSQL
 type PostgresPaymentRepo struct {
-	db *sql.DB
+	db   *sql.DB
+	read querier // replica-safe reads; db unless UseReadRouter was called
 }
 
 // NewPostgresPaymentRepo creates a new PostgreSQL payment repository
 func NewPostgresPaymentRepo(db *sql.DB) *PostgresPaymentRepo {
-	return &PostgresPaymentRepo{db: db}
+	return &PostgresPaymentRepo{db: db, read: db}
+}
+
+// UseReadRouter sends replica-safe reads through router
+func (r *PostgresPaymentRepo) UseReadRouter(router *ReadRouter) {
+	r.read = router
 }
 
 // CreatePayment creates a new payment record
@@ -30,8 +37,9 @@ func (r *PostgresPaymentRepo) CreatePayment(ctx context.Context, payment *reposi
 		INSERT INTO payments (
 			service_code, pricing_id, payer_address, payment_method,
 			amount_charged, currency, amount_usd, tx_hash,
-			stripe_payment_id, stripe_session_id, status
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+			stripe_payment_id, stripe_session_id, status,
+			callback_url, callback_secret, country, referrer_code
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
 		RETURNING id, created_at, updated_at
 	`
 
@@ -47,6 +55,10 @@ func (r *PostgresPaymentRepo) CreatePayment(ctx context.Context, payment *reposi
 		payment.StripePaymentID,
 		payment.StripeSessionID,
 		payment.Status,
+		payment.CallbackURL,
+		payment.CallbackSecret,
+		payment.Country,
+		payment.ReferrerCode,
 	).Scan(&payment.ID, &payment.CreatedAt, &payment.UpdatedAt)
 
 	if err != nil {
@@ -62,7 +74,8 @@ func (r *PostgresPaymentRepo) GetPayment(ctx context.Context, id string) (*repos
 		SELECT id, service_code, pricing_id, payer_address, payment_method,
 		       amount_charged, currency, amount_usd, tx_hash,
 		       stripe_payment_id, stripe_session_id, status, error_message,
-		       created_at, updated_at, completed_at
+		       created_at, updated_at, completed_at, callback_url, callback_secret,
+		       dispute_id, dispute_status, dispute_reason, disputed_at, country, referrer_code
 		FROM payments
 		WHERE id = $1
 	`
@@ -85,6 +98,14 @@ func (r *PostgresPaymentRepo) GetPayment(ctx context.Context, id string) (*repos
 		&p.CreatedAt,
 		&p.UpdatedAt,
 		&p.CompletedAt,
+		&p.CallbackURL,
+		&p.CallbackSecret,
+		&p.DisputeID,
+		&p.DisputeStatus,
+		&p.DisputeReason,
+		&p.DisputedAt,
+		&p.Country,
+		&p.ReferrerCode,
 	)
 
 	if err != nil {
@@ -103,7 +124,8 @@ func (r *PostgresPaymentRepo) GetPaymentByStripeSession(ctx context.Context, ses
 		SELECT id, service_code, pricing_id, payer_address, payment_method,
 		       amount_charged, currency, amount_usd, tx_hash,
 		       stripe_payment_id, stripe_session_id, status, error_message,
-		       created_at, updated_at, completed_at
+		       created_at, updated_at, completed_at, callback_url, callback_secret,
+		       dispute_id, dispute_status, dispute_reason, disputed_at, country, referrer_code
 		FROM payments
 		WHERE stripe_session_id = $1
 	`
@@ -126,6 +148,14 @@ func (r *PostgresPaymentRepo) GetPaymentByStripeSession(ctx context.Context, ses
 		&p.CreatedAt,
 		&p.UpdatedAt,
 		&p.CompletedAt,
+		&p.CallbackURL,
+		&p.CallbackSecret,
+		&p.DisputeID,
+		&p.DisputeStatus,
+		&p.DisputeReason,
+		&p.DisputedAt,
+		&p.Country,
+		&p.ReferrerCode,
 	)
 
 	if err != nil {
@@ -138,14 +168,68 @@ func (r *PostgresPaymentRepo) GetPaymentByStripeSession(ctx context.Context, ses
 	return p, nil
 }
 
+// GetPaymentByStripePaymentID retrieves a payment by Stripe PaymentIntent ID
+func (r *PostgresPaymentRepo) GetPaymentByStripePaymentID(ctx context.Context, paymentIntentID string) (*repository.Payment, error) {
+	query := `
+		SELECT id, service_code, pricing_id, payer_address, payment_method,
+		       amount_charged, currency, amount_usd, tx_hash,
+		       stripe_payment_id, stripe_session_id, status, error_message,
+		       created_at, updated_at, completed_at, callback_url, callback_secret,
+		       dispute_id, dispute_status, dispute_reason, disputed_at, country, referrer_code
+		FROM payments
+		WHERE stripe_payment_id = $1
+	`
+
+	p := &repository.Payment{}
+	err := r.db.QueryRowContext(ctx, query, paymentIntentID).Scan(
+		&p.ID,
+		&p.ServiceCode,
+		&p.PricingID,
+		&p.PayerAddress,
+		&p.PaymentMethod,
+		&p.AmountCharged,
+		&p.Currency,
+		&p.AmountUSD,
+		&p.TxHash,
+		&p.StripePaymentID,
+		&p.StripeSessionID,
+		&p.Status,
+		&p.ErrorMessage,
+		&p.CreatedAt,
+		&p.UpdatedAt,
+		&p.CompletedAt,
+		&p.CallbackURL,
+		&p.CallbackSecret,
+		&p.DisputeID,
+		&p.DisputeStatus,
+		&p.DisputeReason,
+		&p.DisputedAt,
+		&p.Country,
+		&p.ReferrerCode,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrPaymentNotFound
+		}
+		return nil, fmt.Errorf("getting payment by payment intent %s: %w", paymentIntentID, err)
+	}
+
+	return p, nil
+}
+
 // UpdatePaymentStatus updates the status of a payment
 func (r *PostgresPaymentRepo) UpdatePaymentStatus(ctx context.Context, id string, status repository.PaymentStatus, details *repository.PaymentStatusUpdate) error {
 	query := "UPDATE payments SET status = $2"
 	args := []interface{}{id, status}
 	argNum := 3
 
-	if status == repository.PaymentStatusCompleted {
+	switch status {
+	case repository.PaymentStatusCompleted:
 		query += ", completed_at = NOW()"
+	case repository.PaymentStatusPending, repository.PaymentStatusProcessing:
+		// Rolled back (e.g. chain reorg) - no longer completed
+		query += ", completed_at = NULL"
 	}
 
 	if details != nil {
@@ -162,6 +246,21 @@ func (r *PostgresPaymentRepo) UpdatePaymentStatus(ctx context.Context, id string
 		if details.ErrorMessage != nil {
 			query += fmt.Sprintf(", error_message = $%d", argNum)
 			args = append(args, *details.ErrorMessage)
+			argNum++
+		}
+		if details.DisputeID != nil {
+			query += fmt.Sprintf(", dispute_id = $%d, disputed_at = COALESCE(disputed_at, NOW())", argNum)
+			args = append(args, *details.DisputeID)
+			argNum++
+		}
+		if details.DisputeStatus != nil {
+			query += fmt.Sprintf(", dispute_status = $%d", argNum)
+			args = append(args, *details.DisputeStatus)
+			argNum++
+		}
+		if details.DisputeReason != nil {
+			query += fmt.Sprintf(", dispute_reason = $%d", argNum)
+			args = append(args, *details.DisputeReason)
 		}
 	}
 
@@ -207,13 +306,19 @@ func (r *PostgresPaymentRepo) ListPayments(ctx context.Context, filter repositor
 		args = append(args, filter.Status)
 		argNum++
 	}
+	if filter.ExcludesExpired() {
+		where = append(where, "status <> 'expired'")
+	}
+	if filter.Disputed {
+		where = append(where, "dispute_id IS NOT NULL")
+	}
 
 	whereClause := "WHERE " + join(where, " AND ")
 
 	// Count total
 	countQuery := "SELECT COUNT(*) FROM payments " + whereClause
 	var total int64
-	if err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+	if err := r.read.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
 		return nil, 0, fmt.Errorf("counting payments: %w", err)
 	}
 
@@ -230,7 +335,8 @@ func (r *PostgresPaymentRepo) ListPayments(ctx context.Context, filter repositor
 		SELECT id, service_code, pricing_id, payer_address, payment_method,
 		       amount_charged, currency, amount_usd, tx_hash,
 		       stripe_payment_id, stripe_session_id, status, error_message,
-		       created_at, updated_at, completed_at
+		       created_at, updated_at, completed_at, callback_url, callback_secret,
+		       dispute_id, dispute_status, dispute_reason, disputed_at, country, referrer_code
 		FROM payments
 		%s
 		ORDER BY created_at DESC
@@ -239,7 +345,7 @@ func (r *PostgresPaymentRepo) ListPayments(ctx context.Context, filter repositor
 
 	args = append(args, page.PageSize, offset)
 
-	rows, err := r.db.QueryContext(ctx, query, args...)
+	rows, err := r.read.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, 0, fmt.Errorf("listing payments: %w", err)
 	}
@@ -265,6 +371,14 @@ func (r *PostgresPaymentRepo) ListPayments(ctx context.Context, filter repositor
 			&p.CreatedAt,
 			&p.UpdatedAt,
 			&p.CompletedAt,
+			&p.CallbackURL,
+			&p.CallbackSecret,
+			&p.DisputeID,
+			&p.DisputeStatus,
+			&p.DisputeReason,
+			&p.DisputedAt,
+			&p.Country,
+			&p.ReferrerCode,
 		)
 		if err != nil {
 			return nil, 0, fmt.Errorf("scanning payment row: %w", err)
@@ -275,6 +389,44 @@ func (r *PostgresPaymentRepo) ListPayments(ctx context.Context, filter repositor
 	return result, total, nil
 }
 
+// ExpirePayments moves up to limit pending payments of method created before
+// the cutoff to expired, oldest first, and returns their IDs. The status is
+// checked in the same statement, so a payment completed meanwhile is kept.
+func (r *PostgresPaymentRepo) ExpirePayments(ctx context.Context, method string, before time.Time, limit int) ([]string, error) {
+	query := `
+		UPDATE payments SET status = 'expired'
+		WHERE id IN (
+			SELECT id FROM payments
+			WHERE status = 'pending' AND payment_method = $1 AND created_at < $2
+			ORDER BY created_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		AND status = 'pending'
+		RETURNING id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, method, before, limit)
+	if err != nil {
+		return nil, fmt.Errorf("expiring %s payments: %w", method, err)
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("scanning expired payment: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("expiring %s payments: %w", method, err)
+	}
+
+	return ids, nil
+}
+
 // CreateKYCVerification creates a new KYC verification record
 func (r *PostgresPaymentRepo) CreateKYCVerification(ctx context.Context, v *repository.KYCVerification) error {
 	query := `
@@ -446,7 +598,7 @@ func (r *PostgresPaymentRepo) ListKYCVerifications(ctx context.Context, filter r
 	// Count total
 	countQuery := "SELECT COUNT(*) FROM kyc_verifications " + whereClause
 	var total int64
-	if err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+	if err := r.read.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
 		return nil, 0, fmt.Errorf("counting verifications: %w", err)
 	}
 
@@ -471,7 +623,7 @@ func (r *PostgresPaymentRepo) ListKYCVerifications(ctx context.Context, filter r
 
 	args = append(args, page.PageSize, offset)
 
-	rows, err := r.db.QueryContext(ctx, query, args...)
+	rows, err := r.read.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, 0, fmt.Errorf("listing verifications: %w", err)
 	}
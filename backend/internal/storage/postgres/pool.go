@@ -0,0 +1,107 @@
+// Package postgres implements repository interfaces using PostgreSQL
+package postgres
+
+import (
+	"database/sql"
+	"sync"
+	"time"
+)
+
+// Default connection pool settings
+const (
+	DefaultMaxOpenConns    = 25
+	DefaultMaxIdleConns    = 5
+	DefaultConnMaxLifetime = 5 * time.Minute
+)
+
+// PoolConfig sizes a database/sql connection pool. lib/pq does not cache
+// prepared statements client-side (repositories use unnamed statements), so
+// there is no statement cache to configure.
+type PoolConfig struct {
+	MaxOpenConns    int           // 0 = unlimited
+	MaxIdleConns    int           // 0 = keep no idle connections
+	ConnMaxLifetime time.Duration // 0 = reuse forever
+	ConnMaxIdleTime time.Duration // 0 = no idle timeout
+}
+
+// DefaultPoolConfig returns the pool settings used when none are configured
+func DefaultPoolConfig() PoolConfig {
+	return PoolConfig{
+		MaxOpenConns:    DefaultMaxOpenConns,
+		MaxIdleConns:    DefaultMaxIdleConns,
+		ConnMaxLifetime: DefaultConnMaxLifetime,
+	}
+}
+
+// Apply configures db's pool
+func (c PoolConfig) Apply(db *sql.DB) {
+	db.SetMaxOpenConns(c.MaxOpenConns)
+	db.SetMaxIdleConns(c.MaxIdleConns)
+	db.SetConnMaxLifetime(c.ConnMaxLifetime)
+	db.SetConnMaxIdleTime(c.ConnMaxIdleTime)
+}
+
+// PoolStats is a point-in-time snapshot of one connection pool
+type PoolStats struct {
+	Name              string  `json:"name"`
+	MaxOpen           int     `json:"max_open"`
+	Open              int     `json:"open"`
+	InUse             int     `json:"in_use"`
+	Idle              int     `json:"idle"`
+	Utilization       float64 `json:"utilization"` // in_use / max_open; 0 when unlimited
+	WaitCount         int64   `json:"wait_count"`
+	WaitDurationMs    int64   `json:"wait_duration_ms"`
+	MaxIdleClosed     int64   `json:"max_idle_closed"`
+	MaxIdleTimeClosed int64   `json:"max_idle_time_closed"`
+	MaxLifetimeClosed int64   `json:"max_lifetime_closed"`
+}
+
+// PoolMonitor reports statistics for the pools registered with Add
+type PoolMonitor struct {
+	mu    sync.RWMutex
+	names []string
+	pools map[string]*sql.DB
+}
+
+// NewPoolMonitor creates an empty pool monitor
+func NewPoolMonitor() *PoolMonitor {
+	return &PoolMonitor{pools: make(map[string]*sql.DB)}
+}
+
+// Add registers db under name (e.g. "primary", "replica")
+func (m *PoolMonitor) Add(name string, db *sql.DB) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.pools[name]; !ok {
+		m.names = append(m.names, name)
+	}
+	m.pools[name] = db
+}
+
+// PoolStats returns a snapshot of every registered pool in registration order
+func (m *PoolMonitor) PoolStats() []PoolStats {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	stats := make([]PoolStats, 0, len(m.names))
+	for _, name := range m.names {
+		s := m.pools[name].Stats()
+		st := PoolStats{
+			Name:              name,
+			MaxOpen:           s.MaxOpenConnections,
+			Open:              s.OpenConnections,
+			InUse:             s.InUse,
+			Idle:              s.Idle,
+			WaitCount:         s.WaitCount,
+			WaitDurationMs:    s.WaitDuration.Milliseconds(),
+			MaxIdleClosed:     s.MaxIdleClosed,
+			MaxIdleTimeClosed: s.MaxIdleTimeClosed,
+			MaxLifetimeClosed: s.MaxLifetimeClosed,
+		}
+		if s.MaxOpenConnections > 0 {
+			st.Utilization = float64(s.InUse) / float64(s.MaxOpenConnections)
+		}
+		stats = append(stats, st)
+	}
+	return stats
+}
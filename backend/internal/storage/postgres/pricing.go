@@ -16,12 +16,18 @@ var _ repository.PricingRepository = (*PostgresPricingRepo)(nil)
 
 // PostgresPricingRepo implements PricingRepository using PostgreSQL
 type PostgresPricingRepo struct {
-	db *sql.DB
+	db   *sql.DB
+	read querier // replica-safe reads; db unless UseReadRouter was called
 }
 
 // NewPostgresPricingRepo creates a new PostgreSQL pricing repository
 func NewPostgresPricingRepo(db *sql.DB) *PostgresPricingRepo {
-	return &PostgresPricingRepo{db: db}
+	return &PostgresPricingRepo{db: db, read: db}
+}
+
+// UseReadRouter sends replica-safe reads through router
+func (r *PostgresPricingRepo) UseReadRouter(router *ReadRouter) {
+	r.read = router
 }
 
 // GetPricing retrieves pricing for a service by code
@@ -29,14 +35,14 @@ func (r *PostgresPricingRepo) GetPricing(ctx context.Context, serviceCode string
 	query := `
 		SELECT id, service_code, service_name, description, cost_usd, cost_provider,
 		       price_usd, price_eth, price_nexus, markup_percent, is_active,
-		       created_at, updated_at, updated_by
+		       created_at, updated_at, updated_by, version
 		FROM pricing
 		WHERE service_code = $1
 	`
 
 	p := &repository.Pricing{}
 	var updatedBy sql.NullString
-	err := r.db.QueryRowContext(ctx, query, serviceCode).Scan(
+	err := r.read.QueryRowContext(ctx, query, serviceCode).Scan(
 		&p.ID,
 		&p.ServiceCode,
 		&p.ServiceName,
@@ -51,6 +57,7 @@ func (r *PostgresPricingRepo) GetPricing(ctx context.Context, serviceCode string
 		&p.CreatedAt,
 		&p.UpdatedAt,
 		&updatedBy,
+		&p.Version,
 	)
 
 	if err != nil {
@@ -72,7 +79,7 @@ func (r *PostgresPricingRepo) ListPricing(ctx context.Context, activeOnly bool)
 	query := `
 		SELECT id, service_code, service_name, description, cost_usd, cost_provider,
 		       price_usd, price_eth, price_nexus, markup_percent, is_active,
-		       created_at, updated_at, updated_by
+		       created_at, updated_at, updated_by, version
 		FROM pricing
 	`
 	if activeOnly {
@@ -80,7 +87,7 @@ func (r *PostgresPricingRepo) ListPricing(ctx context.Context, activeOnly bool)
 	}
 	query += " ORDER BY service_code"
 
-	rows, err := r.db.QueryContext(ctx, query)
+	rows, err := r.read.QueryContext(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("listing pricing: %w", err)
 	}
@@ -105,6 +112,7 @@ func (r *PostgresPricingRepo) ListPricing(ctx context.Context, activeOnly bool)
 			&p.CreatedAt,
 			&p.UpdatedAt,
 			&updatedBy,
+			&p.Version,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("scanning pricing row: %w", err)
@@ -134,7 +142,7 @@ func (r *PostgresPricingRepo) UpdatePricing(ctx context.Context, serviceCode str
 	}
 
 	// Build dynamic update query
-	query := "UPDATE pricing SET updated_by = $2"
+	query := "UPDATE pricing SET updated_by = $2, version = version + 1"
 	args := []interface{}{serviceCode, update.UpdatedBy}
 	argNum := 3
 
@@ -161,9 +169,14 @@ func (r *PostgresPricingRepo) UpdatePricing(ctx context.Context, serviceCode str
 	if update.IsActive != nil {
 		query += fmt.Sprintf(", is_active = $%d", argNum)
 		args = append(args, *update.IsActive)
+		argNum++
 	}
 
 	query += " WHERE service_code = $1"
+	if update.ExpectedVersion != nil {
+		query += fmt.Sprintf(" AND version = $%d", argNum)
+		args = append(args, *update.ExpectedVersion)
+	}
 
 	result, err := r.db.ExecContext(ctx, query, args...)
 	if err != nil {
@@ -172,6 +185,10 @@ func (r *PostgresPricingRepo) UpdatePricing(ctx context.Context, serviceCode str
 
 	rows, _ := result.RowsAffected()
 	if rows == 0 {
+		if update.ExpectedVersion != nil {
+			// It existed a moment ago, so the version did not match
+			return repository.ErrVersionConflict
+		}
 		return repository.ErrPricingNotFound
 	}
 
@@ -194,14 +211,14 @@ func (r *PostgresPricingRepo) GetPaymentMethod(ctx context.Context, methodCode s
 	query := `
 		SELECT id, method_code, method_name, is_active, processor_config,
 		       min_amount_usd, max_amount_usd, fee_percent, display_order,
-		       created_at, updated_at
+		       created_at, updated_at, version
 		FROM payment_methods
 		WHERE method_code = $1
 	`
 
 	pm := &repository.PaymentMethod{}
 	var configJSON []byte
-	err := r.db.QueryRowContext(ctx, query, methodCode).Scan(
+	err := r.read.QueryRowContext(ctx, query, methodCode).Scan(
 		&pm.ID,
 		&pm.MethodCode,
 		&pm.MethodName,
@@ -213,6 +230,7 @@ func (r *PostgresPricingRepo) GetPaymentMethod(ctx context.Context, methodCode s
 		&pm.DisplayOrder,
 		&pm.CreatedAt,
 		&pm.UpdatedAt,
+		&pm.Version,
 	)
 
 	if err != nil {
@@ -237,7 +255,7 @@ func (r *PostgresPricingRepo) ListPaymentMethods(ctx context.Context, activeOnly
 	query := `
 		SELECT id, method_code, method_name, is_active, processor_config,
 		       min_amount_usd, max_amount_usd, fee_percent, display_order,
-		       created_at, updated_at
+		       created_at, updated_at, version
 		FROM payment_methods
 	`
 	if activeOnly {
@@ -245,7 +263,7 @@ func (r *PostgresPricingRepo) ListPaymentMethods(ctx context.Context, activeOnly
 	}
 	query += " ORDER BY display_order, method_code"
 
-	rows, err := r.db.QueryContext(ctx, query)
+	rows, err := r.read.QueryContext(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("listing payment methods: %w", err)
 	}
@@ -267,6 +285,7 @@ func (r *PostgresPricingRepo) ListPaymentMethods(ctx context.Context, activeOnly
 			&pm.DisplayOrder,
 			&pm.CreatedAt,
 			&pm.UpdatedAt,
+			&pm.Version,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("scanning payment method row: %w", err)
@@ -291,7 +310,7 @@ func (r *PostgresPricingRepo) ListPaymentMethods(ctx context.Context, activeOnly
 // UpdatePaymentMethod updates a payment method
 func (r *PostgresPricingRepo) UpdatePaymentMethod(ctx context.Context, methodCode string, update *repository.PaymentMethodUpdate) error {
 	// Build dynamic update query
-	query := "UPDATE payment_methods SET updated_at = NOW()"
+	query := "UPDATE payment_methods SET updated_at = NOW(), version = version + 1"
 	args := []interface{}{methodCode}
 	argNum := 2
 
@@ -318,9 +337,14 @@ func (r *PostgresPricingRepo) UpdatePaymentMethod(ctx context.Context, methodCod
 	if update.DisplayOrder != nil {
 		query += fmt.Sprintf(", display_order = $%d", argNum)
 		args = append(args, *update.DisplayOrder)
+		argNum++
 	}
 
 	query += " WHERE method_code = $1"
+	if update.ExpectedVersion != nil {
+		query += fmt.Sprintf(" AND version = $%d", argNum)
+		args = append(args, *update.ExpectedVersion)
+	}
 
 	result, err := r.db.ExecContext(ctx, query, args...)
 	if err != nil {
@@ -329,12 +353,30 @@ func (r *PostgresPricingRepo) UpdatePaymentMethod(ctx context.Context, methodCod
 
 	rows, _ := result.RowsAffected()
 	if rows == 0 {
+		if update.ExpectedVersion != nil {
+			if exists, err := r.paymentMethodExists(ctx, methodCode); err != nil {
+				return err
+			} else if exists {
+				return repository.ErrVersionConflict
+			}
+		}
 		return repository.ErrPaymentMethodNotFound
 	}
 
 	return nil
 }
 
+// paymentMethodExists checks if a payment method exists
+func (r *PostgresPricingRepo) paymentMethodExists(ctx context.Context, methodCode string) (bool, error) {
+	query := "SELECT EXISTS(SELECT 1 FROM payment_methods WHERE method_code = $1)"
+	var exists bool
+	err := r.db.QueryRowContext(ctx, query, methodCode).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("checking payment method exists: %w", err)
+	}
+	return exists, nil
+}
+
 // GetPricingHistory retrieves pricing change history
 func (r *PostgresPricingRepo) GetPricingHistory(ctx context.Context, serviceCode string, limit int) ([]*repository.PricingHistoryEntry, error) {
 	query := `
@@ -348,7 +390,7 @@ func (r *PostgresPricingRepo) GetPricingHistory(ctx context.Context, serviceCode
 		LIMIT $2
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, serviceCode, limit)
+	rows, err := r.read.QueryContext(ctx, query, serviceCode, limit)
 	if err != nil {
 		return nil, fmt.Errorf("getting pricing history: %w", err)
 	}
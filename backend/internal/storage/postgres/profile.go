@@ -0,0 +1,119 @@
+// Package postgres implements repository interfaces using PostgreSQL
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure PostgresProfileRepo implements ProfileRepository
+var _ repository.ProfileRepository = (*PostgresProfileRepo)(nil)
+
+// PostgresProfileRepo implements ProfileRepository using PostgreSQL
+type PostgresProfileRepo struct {
+	db *sql.DB
+}
+
+// NewPostgresProfileRepo creates a new PostgreSQL profile repository
+func NewPostgresProfileRepo(db *sql.DB) *PostgresProfileRepo {
+	return &PostgresProfileRepo{db: db}
+}
+
+// profileColumns is the column list read by every profile query
+const profileColumns = `address, display_name, avatar_chain_id, avatar_contract, avatar_token_id, email, preferred_currency, created_at, updated_at`
+
+// UpsertProfile creates or replaces a profile
+func (r *PostgresProfileRepo) UpsertProfile(ctx context.Context, p *repository.Profile) error {
+	query := `
+		INSERT INTO user_profiles (address, display_name, avatar_chain_id, avatar_contract, avatar_token_id, email, preferred_currency)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (address) DO UPDATE SET
+		    display_name = EXCLUDED.display_name, avatar_chain_id = EXCLUDED.avatar_chain_id,
+		    avatar_contract = EXCLUDED.avatar_contract, avatar_token_id = EXCLUDED.avatar_token_id,
+		    email = EXCLUDED.email, preferred_currency = EXCLUDED.preferred_currency, updated_at = NOW()
+		RETURNING created_at, updated_at
+	`
+
+	err := r.db.QueryRowContext(ctx, query,
+		p.Address, p.DisplayName, p.AvatarChainID, p.AvatarContract, p.AvatarTokenID, p.Email, p.PreferredCurrency,
+	).Scan(&p.CreatedAt, &p.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("upserting profile %s: %w", p.Address, err)
+	}
+	return nil
+}
+
+// GetProfile retrieves the profile of address
+func (r *PostgresProfileRepo) GetProfile(ctx context.Context, address string) (*repository.Profile, error) {
+	query := `SELECT ` + profileColumns + ` FROM user_profiles WHERE address = $1`
+
+	p, err := scanProfile(r.db.QueryRowContext(ctx, query, address))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrProfileNotFound
+		}
+		return nil, fmt.Errorf("getting profile %s: %w", address, err)
+	}
+	return p, nil
+}
+
+// DeleteProfile removes the profile of address
+func (r *PostgresProfileRepo) DeleteProfile(ctx context.Context, address string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM user_profiles WHERE address = $1`, address)
+	if err != nil {
+		return fmt.Errorf("deleting profile %s: %w", address, err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return repository.ErrProfileNotFound
+	}
+	return nil
+}
+
+// GetProfiles returns the profiles of those addresses that have one
+func (r *PostgresProfileRepo) GetProfiles(ctx context.Context, addresses []string) (map[string]*repository.Profile, error) {
+	out := make(map[string]*repository.Profile)
+	if len(addresses) == 0 {
+		return out, nil
+	}
+
+	query := `SELECT ` + profileColumns + ` FROM user_profiles WHERE address = ANY($1)`
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(addresses))
+	if err != nil {
+		return nil, fmt.Errorf("getting profiles: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		p, err := scanProfile(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning profile: %w", err)
+		}
+		out[p.Address] = p
+	}
+	return out, rows.Err()
+}
+
+func scanProfile(row rowScanner) (*repository.Profile, error) {
+	p := &repository.Profile{}
+	err := row.Scan(
+		&p.Address,
+		&p.DisplayName,
+		&p.AvatarChainID,
+		&p.AvatarContract,
+		&p.AvatarTokenID,
+		&p.Email,
+		&p.PreferredCurrency,
+		&p.CreatedAt,
+		&p.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
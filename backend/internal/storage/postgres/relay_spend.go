@@ -0,0 +1,113 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// relaySpendKeys maps a grouping to its key expression over minedMetaTxs.
+// Days in another time zone use relaySpendDayIn instead.
+var relaySpendKeys = map[repository.RelaySpendGroup]string{
+	repository.RelaySpendByUser:     "from_address",
+	repository.RelaySpendByContract: "to_address",
+	repository.RelaySpendByDay:      "to_char(mined_at AT TIME ZONE 'UTC', 'YYYY-MM-DD')",
+}
+
+// relaySpendDayIn is the RelaySpendByDay key in the time zone bound to the
+// given placeholder
+const relaySpendDayIn = "to_char(mined_at AT TIME ZONE $%d, 'YYYY-MM-DD')"
+
+// minedMetaTxs selects live and archived meta-transactions that were mined
+// with a receipt. Reverted ones have no confirmed_at; their last update is
+// the failure.
+const minedMetaTxs = `
+	SELECT from_address, to_address, gas_used, gas_price, COALESCE(confirmed_at, updated_at) AS mined_at
+	FROM meta_transactions
+	WHERE status IN ('confirmed', 'failed') AND gas_used IS NOT NULL AND gas_price IS NOT NULL
+	UNION ALL
+	SELECT from_address, to_address, gas_used, gas_price, COALESCE(confirmed_at, updated_at) AS mined_at
+	FROM meta_transactions_archive
+	WHERE status IN ('confirmed', 'failed') AND gas_used IS NOT NULL AND gas_price IS NOT NULL
+`
+
+// GetRelaySpend aggregates gas used × gas price in exact NUMERIC arithmetic
+func (r *PostgresRelayerRepo) GetRelaySpend(ctx context.Context, filter repository.RelaySpendFilter) ([]*repository.RelaySpend, error) {
+	key, ok := relaySpendKeys[filter.GroupBy]
+	if !ok {
+		return nil, fmt.Errorf("unknown relay spend grouping %q", filter.GroupBy)
+	}
+
+	whereClause := "WHERE 1=1"
+	args := []interface{}{}
+	argNum := 1
+
+	if filter.FromAddress != "" {
+		whereClause += fmt.Sprintf(" AND from_address = $%d", argNum)
+		args = append(args, filter.FromAddress)
+		argNum++
+	}
+	if filter.ToAddress != "" {
+		whereClause += fmt.Sprintf(" AND to_address = $%d", argNum)
+		args = append(args, filter.ToAddress)
+		argNum++
+	}
+	if filter.From != nil {
+		whereClause += fmt.Sprintf(" AND mined_at >= $%d", argNum)
+		args = append(args, *filter.From)
+		argNum++
+	}
+	if filter.To != nil {
+		whereClause += fmt.Sprintf(" AND mined_at < $%d", argNum)
+		args = append(args, *filter.To)
+		argNum++
+	}
+	if filter.GroupBy == repository.RelaySpendByDay && filter.Location != nil {
+		key = fmt.Sprintf(relaySpendDayIn, argNum)
+		args = append(args, filter.Location.String())
+		argNum++
+	}
+
+	orderBy := "spend_wei DESC, key"
+	if filter.GroupBy == repository.RelaySpendByDay {
+		orderBy = "key"
+	}
+
+	query := fmt.Sprintf(`
+		SELECT %s AS key,
+		       COUNT(*),
+		       COALESCE(SUM(gas_used), 0)::BIGINT,
+		       COALESCE(SUM(gas_used::NUMERIC * gas_price), 0) AS spend_wei
+		FROM (%s) mined
+		%s
+		GROUP BY key
+		ORDER BY %s
+	`, key, minedMetaTxs, whereClause, orderBy)
+
+	rows, err := r.read.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("aggregating relay spend: %w", err)
+	}
+	defer rows.Close()
+
+	spend := []*repository.RelaySpend{}
+	for rows.Next() {
+		s := &repository.RelaySpend{}
+		if err := rows.Scan(&s.Key, &s.TxCount, &s.GasUsed, &s.SpendWei); err != nil {
+			return nil, fmt.Errorf("scanning relay spend: %w", err)
+		}
+		wei, ok := new(big.Int).SetString(s.SpendWei, 10)
+		if !ok {
+			return nil, fmt.Errorf("scanning relay spend: invalid wei amount %q", s.SpendWei)
+		}
+		s.SpendETH = repository.FormatWeiETH(wei)
+		spend = append(spend, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("aggregating relay spend: %w", err)
+	}
+
+	return spend, nil
+}
@@ -3,9 +3,11 @@ package postgres
 
 import (
 	"context"
+	"crypto/rand"
 	"database/sql"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
@@ -16,12 +18,18 @@ var _ repository.RelayerRepository = (*PostgresRelayerRepo)(nil)
 
 // PostgresRelayerRepo implements RelayerRepository using PostgreSQL
 type PostgresRelayerRepo struct {
-	db *sql.DB
+	db   *sql.DB
+	read querier // replica-safe reads; db unless UseReadRouter was called
 }
 
 // NewPostgresRelayerRepo creates a new PostgreSQL relayer repository
 func NewPostgresRelayerRepo(db *sql.DB) *PostgresRelayerRepo {
-	return &PostgresRelayerRepo{db: db}
+	return &PostgresRelayerRepo{db: db, read: db}
+}
+
+// UseReadRouter sends replica-safe reads through router
+func (r *PostgresRelayerRepo) UseReadRouter(router *ReadRouter) {
+	r.read = router
 }
 
 // CreateMetaTx creates a new meta-transaction record
@@ -54,6 +62,76 @@ func (r *PostgresRelayerRepo) CreateMetaTx(ctx context.Context, tx *repository.M
 	return nil
 }
 
+// CreateMetaTxBatch inserts meta-transactions with one multi-row INSERT,
+// setting ID and timestamps on each. IDs are assigned here so RETURNING rows
+// can be matched back to their inputs.
+func (r *PostgresRelayerRepo) CreateMetaTxBatch(ctx context.Context, txs []*repository.MetaTransaction) error {
+	if len(txs) == 0 {
+		return nil
+	}
+
+	const cols = 11
+	values := make([]string, 0, len(txs))
+	args := make([]interface{}, 0, len(txs)*cols)
+	byID := make(map[string]*repository.MetaTransaction, len(txs))
+	for i, tx := range txs {
+		if tx.ID == "" {
+			tx.ID = newID()
+		}
+		byID[tx.ID] = tx
+
+		placeholders := make([]string, cols)
+		for j := range placeholders {
+			placeholders[j] = fmt.Sprintf("$%d", i*cols+j+1)
+		}
+		values = append(values, "("+strings.Join(placeholders, ", ")+")")
+		args = append(args,
+			tx.ID,
+			tx.FromAddress,
+			tx.ToAddress,
+			tx.FunctionName,
+			tx.Calldata,
+			tx.Value,
+			tx.GasLimit,
+			tx.Nonce,
+			tx.Deadline,
+			tx.Signature,
+			tx.Status,
+		)
+	}
+
+	query := `
+		INSERT INTO meta_transactions (
+			id, from_address, to_address, function_name, calldata, value,
+			gas_limit, nonce, deadline, signature, status
+		) VALUES ` + strings.Join(values, ", ") + `
+		RETURNING id, created_at, updated_at
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("creating meta-transaction batch: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id string
+		var createdAt, updatedAt time.Time
+		if err := rows.Scan(&id, &createdAt, &updatedAt); err != nil {
+			return fmt.Errorf("scanning meta-transaction batch: %w", err)
+		}
+		if tx, ok := byID[id]; ok {
+			tx.CreatedAt = createdAt
+			tx.UpdatedAt = updatedAt
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("creating meta-transaction batch: %w", err)
+	}
+
+	return nil
+}
+
 // GetMetaTx retrieves a meta-transaction by ID
 func (r *PostgresRelayerRepo) GetMetaTx(ctx context.Context, id string) (*repository.MetaTransaction, error) {
 	query := `
@@ -233,7 +311,7 @@ func (r *PostgresRelayerRepo) ListMetaTx(ctx context.Context, filter repository.
 	// Count total matching records
 	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM meta_transactions %s", whereClause)
 	var total int64
-	err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total)
+	err := r.read.QueryRowContext(ctx, countQuery, args...).Scan(&total)
 	if err != nil {
 		return nil, 0, fmt.Errorf("counting meta-transactions: %w", err)
 	}
@@ -253,7 +331,7 @@ func (r *PostgresRelayerRepo) ListMetaTx(ctx context.Context, filter repository.
 
 	args = append(args, page.PageSize, offset)
 
-	rows, err := r.db.QueryContext(ctx, query, args...)
+	rows, err := r.read.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, 0, fmt.Errorf("listing meta-transactions: %w", err)
 	}
@@ -459,7 +537,7 @@ func (r *PostgresRelayerRepo) GetStats(ctx context.Context, since time.Time) (*M
 
 	stats := &MetaTxStats{}
 	var totalRelayCost float64
-	err := r.db.QueryRowContext(ctx, query, since).Scan(
+	err := r.read.QueryRowContext(ctx, query, since).Scan(
 		&stats.PendingCount,
 		&stats.SubmittedCount,
 		&stats.ConfirmedCount,
@@ -486,3 +564,15 @@ type MetaTxStats struct {
 	TotalGasUsed      uint64 `json:"total_gas_used"`
 	TotalRelayCostETH string `json:"total_relay_cost_eth"`
 }
+
+// newID returns a random (version 4) UUID for rows whose ID must be known
+// before the insert
+func newID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(fmt.Sprintf("generating id: %v", err))
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
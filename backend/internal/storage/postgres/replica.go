@@ -0,0 +1,199 @@
+// Package postgres implements repository interfaces using PostgreSQL
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// querier is the read surface shared by *sql.DB and ReadRouter. Repositories
+// issue replica-safe reads through it and everything else through the primary.
+type querier interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// Ensure ReadRouter can stand in for *sql.DB on the read path
+var _ querier = (*ReadRouter)(nil)
+
+// ReplicaStatus is the last observed state of the read replica
+type ReplicaStatus struct {
+	Healthy   bool          `json:"healthy"`
+	Lag       time.Duration `json:"lag"`
+	MaxLag    time.Duration `json:"max_lag"`
+	Routing   string        `json:"routing"` // "replica" or "primary"
+	CheckedAt time.Time     `json:"checked_at"`
+	Error     string        `json:"error,omitempty"`
+}
+
+// Replica routing targets (ReplicaStatus.Routing)
+const (
+	RouteReplica = "replica"
+	RoutePrimary = "primary"
+)
+
+// Default replica check settings
+const (
+	DefaultReplicaMaxLag        = 10 * time.Second
+	DefaultReplicaCheckInterval = 5 * time.Second
+	replicaCheckTimeout         = 3 * time.Second
+)
+
+// replicationLagQuery returns the replay delay in seconds. An idle primary
+// stops producing WAL, so a replica that has replayed everything it received
+// reports zero rather than the time since the last transaction.
+const replicationLagQuery = `
+	SELECT CASE
+		WHEN NOT pg_is_in_recovery() THEN 0
+		WHEN pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() THEN 0
+		ELSE COALESCE(EXTRACT(EPOCH FROM NOW() - pg_last_xact_replay_timestamp()), 0)
+	END
+`
+
+// ReadRouter sends read-only queries to a replica while it is reachable and
+// within MaxLag of the primary, and to the primary otherwise
+type ReadRouter struct {
+	primary *sql.DB
+	replica *sql.DB
+	maxLag  time.Duration
+	logger  *zap.Logger
+
+	mu     sync.RWMutex
+	status ReplicaStatus
+}
+
+// NewReadRouter creates a router over a primary and a replica connection.
+// Reads go to the primary until the first successful Check.
+func NewReadRouter(primary, replica *sql.DB, maxLag time.Duration, logger *zap.Logger) *ReadRouter {
+	if maxLag <= 0 {
+		maxLag = DefaultReplicaMaxLag
+	}
+	return &ReadRouter{
+		primary: primary,
+		replica: replica,
+		maxLag:  maxLag,
+		logger:  logger,
+		status: ReplicaStatus{
+			MaxLag:  maxLag,
+			Routing: RoutePrimary,
+			Error:   "not checked yet",
+		},
+	}
+}
+
+// Run checks the replica every interval until ctx is cancelled
+func (r *ReadRouter) Run(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultReplicaCheckInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	r.Check(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.Check(ctx)
+		}
+	}
+}
+
+// Check measures replica reachability and lag and updates the routing decision
+func (r *ReadRouter) Check(ctx context.Context) ReplicaStatus {
+	ctx, cancel := context.WithTimeout(ctx, replicaCheckTimeout)
+	defer cancel()
+
+	status := ReplicaStatus{MaxLag: r.maxLag, CheckedAt: time.Now(), Routing: RoutePrimary}
+
+	var lagSeconds float64
+	if err := r.replica.QueryRowContext(ctx, replicationLagQuery).Scan(&lagSeconds); err != nil {
+		status.Error = fmt.Sprintf("checking replication lag: %v", err)
+	} else {
+		status.Healthy = true
+		status.Lag = time.Duration(lagSeconds * float64(time.Second))
+		if status.Lag <= r.maxLag {
+			status.Routing = RouteReplica
+		} else {
+			status.Error = fmt.Sprintf("replication lag %s exceeds %s", status.Lag.Round(time.Millisecond), r.maxLag)
+		}
+	}
+
+	r.mu.Lock()
+	previous := r.status.Routing
+	r.status = status
+	r.mu.Unlock()
+
+	if previous != status.Routing {
+		if status.Routing == RouteReplica {
+			r.logger.Info("routing reads to replica", zap.Duration("lag", status.Lag))
+		} else {
+			r.logger.Warn("routing reads to primary", zap.String("reason", status.Error))
+		}
+	}
+
+	return status
+}
+
+// ReplicaStatus returns the result of the last check
+func (r *ReadRouter) ReplicaStatus() ReplicaStatus {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.status
+}
+
+// reader returns the connection reads should currently use
+func (r *ReadRouter) reader() *sql.DB {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.status.Routing == RouteReplica {
+		return r.replica
+	}
+	return r.primary
+}
+
+// QueryContext runs a read on the current target, retrying on the primary if
+// the replica fails. Rows are only returned once the query has succeeded, so
+// the retry never repeats partially consumed results.
+func (r *ReadRouter) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	db := r.reader()
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err == nil || db == r.primary || ctx.Err() != nil {
+		return rows, err
+	}
+
+	r.markFailed(err)
+	return r.primary.QueryContext(ctx, query, args...)
+}
+
+// QueryRowContext runs a single-row read on the current target. Errors only
+// surface at Scan, so a failing replica is detected by the next Check (or by a
+// failing QueryContext) rather than retried here.
+func (r *ReadRouter) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return r.reader().QueryRowContext(ctx, query, args...)
+}
+
+// markFailed routes reads to the primary until the next successful Check
+func (r *ReadRouter) markFailed(err error) {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return
+	}
+
+	r.mu.Lock()
+	wasReplica := r.status.Routing == RouteReplica
+	r.status.Healthy = false
+	r.status.Routing = RoutePrimary
+	r.status.Error = fmt.Sprintf("replica query failed: %v", err)
+	r.mu.Unlock()
+
+	if wasReplica {
+		r.logger.Warn("replica query failed, routing reads to primary", zap.Error(err))
+	}
+}
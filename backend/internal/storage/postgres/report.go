@@ -0,0 +1,310 @@
+// Package postgres implements repository interfaces using PostgreSQL
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure PostgresReportRepo implements ReportRepository
+var _ repository.ReportRepository = (*PostgresReportRepo)(nil)
+
+// PostgresReportRepo implements ReportRepository using PostgreSQL
+type PostgresReportRepo struct {
+	db *sql.DB
+}
+
+// NewPostgresReportRepo creates a new PostgreSQL report repository
+func NewPostgresReportRepo(db *sql.DB) *PostgresReportRepo {
+	return &PostgresReportRepo{db: db}
+}
+
+// reportScheduleColumns is the column list read by every schedule query
+const reportScheduleColumns = `id, name, kind, cron, timezone, recipients, enabled, created_by, next_run_at, last_run_at, created_at, updated_at`
+
+// reportRunColumns is the column list read by every run query
+const reportRunColumns = `id, schedule_id, kind, period_start, period_end, status, object_key, row_count, manual, error_message, started_at, finished_at`
+
+// CreateReportSchedule records a schedule
+func (r *PostgresReportRepo) CreateReportSchedule(ctx context.Context, s *repository.ReportSchedule) error {
+	query := `
+		INSERT INTO report_schedules (name, kind, cron, timezone, recipients, enabled, created_by, next_run_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id, created_at, updated_at
+	`
+
+	err := r.db.QueryRowContext(ctx, query,
+		s.Name, s.Kind, s.Cron, s.Timezone, pq.Array(append([]string{}, s.Recipients...)), s.Enabled, s.CreatedBy, s.NextRunAt,
+	).Scan(&s.ID, &s.CreatedAt, &s.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("creating report schedule: %w", err)
+	}
+	return nil
+}
+
+// GetReportSchedule retrieves a schedule by ID
+func (r *PostgresReportRepo) GetReportSchedule(ctx context.Context, id string) (*repository.ReportSchedule, error) {
+	query := `SELECT ` + reportScheduleColumns + ` FROM report_schedules WHERE id = $1`
+
+	s, err := scanReportSchedule(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrReportScheduleNotFound
+		}
+		return nil, fmt.Errorf("getting report schedule %s: %w", id, err)
+	}
+
+	return s, nil
+}
+
+// UpdateReportSchedule replaces a schedule's settings
+func (r *PostgresReportRepo) UpdateReportSchedule(ctx context.Context, s *repository.ReportSchedule) error {
+	query := `
+		UPDATE report_schedules
+		SET name = $2, kind = $3, cron = $4, timezone = $5, recipients = $6, enabled = $7, next_run_at = $8, updated_at = NOW()
+		WHERE id = $1
+		RETURNING created_by, last_run_at, created_at, updated_at
+	`
+
+	err := r.db.QueryRowContext(ctx, query,
+		s.ID, s.Name, s.Kind, s.Cron, s.Timezone, pq.Array(append([]string{}, s.Recipients...)), s.Enabled, s.NextRunAt,
+	).Scan(&s.CreatedBy, &s.LastRunAt, &s.CreatedAt, &s.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return repository.ErrReportScheduleNotFound
+		}
+		return fmt.Errorf("updating report schedule %s: %w", s.ID, err)
+	}
+	return nil
+}
+
+// DeleteReportSchedule removes a schedule; its runs are kept
+func (r *PostgresReportRepo) DeleteReportSchedule(ctx context.Context, id string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM report_schedules WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("deleting report schedule %s: %w", id, err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return repository.ErrReportScheduleNotFound
+	}
+	return nil
+}
+
+// ListReportSchedules lists schedules, newest first
+func (r *PostgresReportRepo) ListReportSchedules(ctx context.Context, page repository.Pagination) ([]*repository.ReportSchedule, int64, error) {
+	var total int64
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM report_schedules").Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("counting report schedules: %w", err)
+	}
+
+	if page.PageSize <= 0 {
+		page.PageSize = 20
+	}
+	if page.Page <= 0 {
+		page.Page = 1
+	}
+	offset := (page.Page - 1) * page.PageSize
+
+	query := `
+		SELECT ` + reportScheduleColumns + `
+		FROM report_schedules
+		ORDER BY created_at DESC
+		LIMIT $1 OFFSET $2
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, page.PageSize, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("listing report schedules: %w", err)
+	}
+	defer rows.Close()
+
+	var result []*repository.ReportSchedule
+	for rows.Next() {
+		s, err := scanReportSchedule(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("scanning report schedule row: %w", err)
+		}
+		result = append(result, s)
+	}
+
+	return result, total, rows.Err()
+}
+
+// ListDueReportSchedules lists enabled schedules due at or before now
+func (r *PostgresReportRepo) ListDueReportSchedules(ctx context.Context, now time.Time, limit int) ([]*repository.ReportSchedule, error) {
+	query := `
+		SELECT ` + reportScheduleColumns + `
+		FROM report_schedules
+		WHERE enabled AND next_run_at <= $1
+		ORDER BY next_run_at
+		LIMIT $2
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("listing due report schedules: %w", err)
+	}
+	defer rows.Close()
+
+	var result []*repository.ReportSchedule
+	for rows.Next() {
+		s, err := scanReportSchedule(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning report schedule row: %w", err)
+		}
+		result = append(result, s)
+	}
+
+	return result, rows.Err()
+}
+
+// ClaimReportSchedule moves a due schedule's next run from due to next
+func (r *PostgresReportRepo) ClaimReportSchedule(ctx context.Context, id string, due, next time.Time) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE report_schedules SET next_run_at = $3, last_run_at = $2
+		WHERE id = $1 AND enabled AND next_run_at = $2
+	`, id, due, next)
+	if err != nil {
+		return false, fmt.Errorf("claiming report schedule %s: %w", id, err)
+	}
+	n, _ := result.RowsAffected()
+	return n > 0, nil
+}
+
+// CreateReportRun records a finished run
+func (r *PostgresReportRepo) CreateReportRun(ctx context.Context, run *repository.ReportRun) error {
+	query := `
+		INSERT INTO report_runs (schedule_id, kind, period_start, period_end, status, object_key, row_count,
+		                         manual, error_message, started_at, finished_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		RETURNING id
+	`
+
+	err := r.db.QueryRowContext(ctx, query,
+		run.ScheduleID, run.Kind, run.PeriodStart, run.PeriodEnd, run.Status, run.ObjectKey, run.Rows,
+		run.Manual, run.ErrorMessage, run.StartedAt, run.FinishedAt,
+	).Scan(&run.ID)
+	if err != nil {
+		return fmt.Errorf("creating report run: %w", err)
+	}
+	return nil
+}
+
+// GetReportRun retrieves a run by ID
+func (r *PostgresReportRepo) GetReportRun(ctx context.Context, id string) (*repository.ReportRun, error) {
+	query := `SELECT ` + reportRunColumns + ` FROM report_runs WHERE id = $1`
+
+	run, err := scanReportRun(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrReportRunNotFound
+		}
+		return nil, fmt.Errorf("getting report run %s: %w", id, err)
+	}
+
+	return run, nil
+}
+
+// ListReportRuns lists runs, newest first
+func (r *PostgresReportRepo) ListReportRuns(ctx context.Context, scheduleID string, page repository.Pagination) ([]*repository.ReportRun, int64, error) {
+	whereClause := "WHERE 1=1"
+	args := []interface{}{}
+	argNum := 1
+
+	if scheduleID != "" {
+		whereClause += fmt.Sprintf(" AND schedule_id = $%d", argNum)
+		args = append(args, scheduleID)
+		argNum++
+	}
+
+	var total int64
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM report_runs "+whereClause, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("counting report runs: %w", err)
+	}
+
+	if page.PageSize <= 0 {
+		page.PageSize = 20
+	}
+	if page.Page <= 0 {
+		page.Page = 1
+	}
+	offset := (page.Page - 1) * page.PageSize
+
+	query := fmt.Sprintf(`
+		SELECT `+reportRunColumns+`
+		FROM report_runs
+		%s
+		ORDER BY started_at DESC
+		LIMIT $%d OFFSET $%d
+	`, whereClause, argNum, argNum+1)
+
+	args = append(args, page.PageSize, offset)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("listing report runs: %w", err)
+	}
+	defer rows.Close()
+
+	var result []*repository.ReportRun
+	for rows.Next() {
+		run, err := scanReportRun(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("scanning report run row: %w", err)
+		}
+		result = append(result, run)
+	}
+
+	return result, total, rows.Err()
+}
+
+func scanReportSchedule(row rowScanner) (*repository.ReportSchedule, error) {
+	s := &repository.ReportSchedule{}
+	err := row.Scan(
+		&s.ID,
+		&s.Name,
+		&s.Kind,
+		&s.Cron,
+		&s.Timezone,
+		pq.Array(&s.Recipients),
+		&s.Enabled,
+		&s.CreatedBy,
+		&s.NextRunAt,
+		&s.LastRunAt,
+		&s.CreatedAt,
+		&s.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func scanReportRun(row rowScanner) (*repository.ReportRun, error) {
+	run := &repository.ReportRun{}
+	err := row.Scan(
+		&run.ID,
+		&run.ScheduleID,
+		&run.Kind,
+		&run.PeriodStart,
+		&run.PeriodEnd,
+		&run.Status,
+		&run.ObjectKey,
+		&run.Rows,
+		&run.Manual,
+		&run.ErrorMessage,
+		&run.StartedAt,
+		&run.FinishedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return run, nil
+}
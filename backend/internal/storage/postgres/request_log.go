@@ -0,0 +1,273 @@
+// Package postgres implements repository interfaces using PostgreSQL
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure PostgresRequestLogRepo implements RequestLogRepository
+var _ repository.RequestLogRepository = (*PostgresRequestLogRepo)(nil)
+
+// PostgresRequestLogRepo implements RequestLogRepository using PostgreSQL
+type PostgresRequestLogRepo struct {
+	db   *sql.DB
+	read querier // replica-safe reads; db unless UseReadRouter was called
+}
+
+// NewPostgresRequestLogRepo creates a new PostgreSQL request log repository
+func NewPostgresRequestLogRepo(db *sql.DB) *PostgresRequestLogRepo {
+	return &PostgresRequestLogRepo{db: db, read: db}
+}
+
+// UseReadRouter sends replica-safe reads through router
+func (r *PostgresRequestLogRepo) UseReadRouter(router *ReadRouter) {
+	r.read = router
+}
+
+// InsertRequestLogs archives a batch of request log entries in one transaction
+func (r *PostgresRequestLogRepo) InsertRequestLogs(ctx context.Context, entries []*repository.RequestLogEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginning request log transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO request_logs (
+			request_id, method, path, route, query, status_code, latency_ms,
+			client_ip, user_agent, request_headers, request_body, response_body,
+			error, created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+	`)
+	if err != nil {
+		return fmt.Errorf("preparing request log insert: %w", err)
+	}
+	defer stmt.Close()
+
+	for _, e := range entries {
+		headers, err := json.Marshal(e.RequestHeaders)
+		if err != nil {
+			return fmt.Errorf("marshaling request headers: %w", err)
+		}
+
+		createdAt := e.CreatedAt
+		if createdAt.IsZero() {
+			createdAt = time.Now()
+		}
+
+		_, err = stmt.ExecContext(ctx,
+			e.RequestID,
+			e.Method,
+			e.Path,
+			e.Route,
+			e.Query,
+			e.StatusCode,
+			e.LatencyMs,
+			e.ClientIP,
+			e.UserAgent,
+			headers,
+			e.RequestBody,
+			e.ResponseBody,
+			e.Error,
+			createdAt,
+		)
+		if err != nil {
+			return fmt.Errorf("inserting request log: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing request logs: %w", err)
+	}
+
+	return nil
+}
+
+// GetRequestLog retrieves a single archived request by ID
+func (r *PostgresRequestLogRepo) GetRequestLog(ctx context.Context, id string) (*repository.RequestLogEntry, error) {
+	query := `
+		SELECT id, request_id, method, path, route, query, status_code, latency_ms,
+		       client_ip, user_agent, request_headers, request_body, response_body,
+		       error, created_at
+		FROM request_logs
+		WHERE id = $1
+	`
+
+	entry, err := scanRequestLog(r.read.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrRequestLogNotFound
+		}
+		return nil, fmt.Errorf("getting request log %s: %w", id, err)
+	}
+
+	return entry, nil
+}
+
+// ListRequestLogs lists archived requests with filtering, newest first
+func (r *PostgresRequestLogRepo) ListRequestLogs(ctx context.Context, filter repository.RequestLogFilter, page repository.Pagination) ([]*repository.RequestLogEntry, int64, error) {
+	// Build where clause
+	where := []string{"1=1"}
+	args := []interface{}{}
+	argNum := 1
+
+	if filter.RequestID != "" {
+		where = append(where, fmt.Sprintf("request_id = $%d", argNum))
+		args = append(args, filter.RequestID)
+		argNum++
+	}
+	if filter.Method != "" {
+		where = append(where, fmt.Sprintf("method = $%d", argNum))
+		args = append(args, filter.Method)
+		argNum++
+	}
+	if filter.Path != "" {
+		where = append(where, fmt.Sprintf("path LIKE $%d", argNum))
+		args = append(args, escapeLike(filter.Path)+"%")
+		argNum++
+	}
+	if filter.StatusCode != 0 {
+		where = append(where, fmt.Sprintf("status_code = $%d", argNum))
+		args = append(args, filter.StatusCode)
+		argNum++
+	}
+	if filter.MinStatus != 0 {
+		where = append(where, fmt.Sprintf("status_code >= $%d", argNum))
+		args = append(args, filter.MinStatus)
+		argNum++
+	}
+	if filter.ClientIP != "" {
+		where = append(where, fmt.Sprintf("client_ip = $%d", argNum))
+		args = append(args, filter.ClientIP)
+		argNum++
+	}
+	if filter.From != nil {
+		where = append(where, fmt.Sprintf("created_at >= $%d", argNum))
+		args = append(args, *filter.From)
+		argNum++
+	}
+	if filter.To != nil {
+		where = append(where, fmt.Sprintf("created_at < $%d", argNum))
+		args = append(args, *filter.To)
+		argNum++
+	}
+
+	whereClause := "WHERE " + join(where, " AND ")
+
+	// Count total
+	countQuery := "SELECT COUNT(*) FROM request_logs " + whereClause
+	var total int64
+	if err := r.read.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("counting request logs: %w", err)
+	}
+
+	// Get page
+	if page.PageSize <= 0 {
+		page.PageSize = 50
+	}
+	if page.Page <= 0 {
+		page.Page = 1
+	}
+	offset := (page.Page - 1) * page.PageSize
+
+	query := fmt.Sprintf(`
+		SELECT id, request_id, method, path, route, query, status_code, latency_ms,
+		       client_ip, user_agent, request_headers, request_body, response_body,
+		       error, created_at
+		FROM request_logs
+		%s
+		ORDER BY created_at DESC
+		LIMIT $%d OFFSET $%d
+	`, whereClause, argNum, argNum+1)
+
+	args = append(args, page.PageSize, offset)
+
+	rows, err := r.read.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("listing request logs: %w", err)
+	}
+	defer rows.Close()
+
+	var result []*repository.RequestLogEntry
+	for rows.Next() {
+		entry, err := scanRequestLog(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("scanning request log row: %w", err)
+		}
+		result = append(result, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("iterating request log rows: %w", err)
+	}
+
+	return result, total, nil
+}
+
+// DeleteRequestLogsBefore removes archived requests older than the cutoff
+func (r *PostgresRequestLogRepo) DeleteRequestLogsBefore(ctx context.Context, before time.Time) (int64, error) {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM request_logs WHERE created_at < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("deleting request logs before %s: %w", before.Format(time.RFC3339), err)
+	}
+
+	rows, _ := result.RowsAffected()
+	return rows, nil
+}
+
+// rowScanner is satisfied by *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanRequestLog scans a request_logs row
+func scanRequestLog(row rowScanner) (*repository.RequestLogEntry, error) {
+	e := &repository.RequestLogEntry{}
+	var headers []byte
+
+	err := row.Scan(
+		&e.ID,
+		&e.RequestID,
+		&e.Method,
+		&e.Path,
+		&e.Route,
+		&e.Query,
+		&e.StatusCode,
+		&e.LatencyMs,
+		&e.ClientIP,
+		&e.UserAgent,
+		&headers,
+		&e.RequestBody,
+		&e.ResponseBody,
+		&e.Error,
+		&e.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(headers) > 0 {
+		if err := json.Unmarshal(headers, &e.RequestHeaders); err != nil {
+			return nil, fmt.Errorf("unmarshaling request headers: %w", err)
+		}
+	}
+
+	return e, nil
+}
+
+// escapeLike escapes LIKE wildcards so user input matches literally
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
@@ -0,0 +1,23 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// schemaMarker is the last table created by infrastructure/docker/init-db.sql;
+// once it exists the schema script has run to completion
+const schemaMarker = "abi_artifacts"
+
+// CheckSchema reports an error until the database schema has been applied
+func CheckSchema(ctx context.Context, db *sql.DB) error {
+	var exists bool
+	if err := db.QueryRowContext(ctx, "SELECT to_regclass($1) IS NOT NULL", schemaMarker).Scan(&exists); err != nil {
+		return fmt.Errorf("checking schema: %w", err)
+	}
+	if !exists {
+		return fmt.Errorf("schema not applied: table %s missing", schemaMarker)
+	}
+	return nil
+}
@@ -0,0 +1,307 @@
+// Package postgres implements repository interfaces using PostgreSQL
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure PostgresSearchRepo implements SearchRepository
+var _ repository.SearchRepository = (*PostgresSearchRepo)(nil)
+
+// defaultSearchLimit is the per-type result limit when the query sets none
+const defaultSearchLimit = 20
+
+// PostgresSearchRepo implements SearchRepository using PostgreSQL trigram
+// (pg_trgm) and full-text indexes
+type PostgresSearchRepo struct {
+	read querier // replica-safe reads; db unless UseReadRouter was called
+}
+
+// NewPostgresSearchRepo creates a new PostgreSQL search repository
+func NewPostgresSearchRepo(db *sql.DB) *PostgresSearchRepo {
+	return &PostgresSearchRepo{read: db}
+}
+
+// UseReadRouter sends replica-safe reads through router
+func (r *PostgresSearchRepo) UseReadRouter(router *ReadRouter) {
+	r.read = router
+}
+
+// Search finds payments, KYC verifications and meta-transactions whose
+// identifiers contain the term, ordered by trigram similarity
+func (r *PostgresSearchRepo) Search(ctx context.Context, query repository.SearchQuery) ([]*repository.SearchResult, error) {
+	limit := query.Limit
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+	term := strings.TrimSpace(query.Term)
+	pattern := "%" + escapeLike(term) + "%"
+
+	var results []*repository.SearchResult
+
+	if query.Includes(repository.SearchResultPayment) {
+		found, err := r.searchPayments(ctx, term, pattern, limit)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, found...)
+	}
+
+	if query.Includes(repository.SearchResultKYCVerification) {
+		found, err := r.searchKYCVerifications(ctx, term, pattern, limit)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, found...)
+	}
+
+	if query.Includes(repository.SearchResultMetaTx) {
+		found, err := r.searchMetaTxs(ctx, term, pattern, limit)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, found...)
+	}
+
+	sortSearchResults(results)
+
+	return results, nil
+}
+
+func (r *PostgresSearchRepo) searchPayments(ctx context.Context, term, pattern string, limit int) ([]*repository.SearchResult, error) {
+	query := `
+		SELECT ` + paymentColumns + `,
+		       CASE
+		           WHEN tx_hash ILIKE $2 THEN 'tx_hash'
+		           WHEN stripe_session_id ILIKE $2 THEN 'stripe_session_id'
+		           WHEN stripe_payment_id ILIKE $2 THEN 'stripe_payment_id'
+		           ELSE 'payer_address'
+		       END AS matched_field,
+		       GREATEST(
+		           similarity(payer_address, $1),
+		           similarity(COALESCE(tx_hash, ''), $1),
+		           similarity(COALESCE(stripe_session_id, ''), $1),
+		           similarity(COALESCE(stripe_payment_id, ''), $1)
+		       ) AS score
+		FROM payments
+		WHERE payer_address ILIKE $2
+		   OR tx_hash ILIKE $2
+		   OR stripe_session_id ILIKE $2
+		   OR stripe_payment_id ILIKE $2
+		ORDER BY score DESC, created_at DESC
+		LIMIT $3
+	`
+
+	rows, err := r.read.QueryContext(ctx, query, term, pattern, limit)
+	if err != nil {
+		return nil, fmt.Errorf("searching payments: %w", err)
+	}
+	defer rows.Close()
+
+	var results []*repository.SearchResult
+	for rows.Next() {
+		p := &repository.Payment{}
+		res := &repository.SearchResult{Type: repository.SearchResultPayment, Payment: p}
+		err := rows.Scan(
+			&p.ID,
+			&p.ServiceCode,
+			&p.PricingID,
+			&p.PayerAddress,
+			&p.PaymentMethod,
+			&p.AmountCharged,
+			&p.Currency,
+			&p.AmountUSD,
+			&p.TxHash,
+			&p.StripePaymentID,
+			&p.StripeSessionID,
+			&p.Status,
+			&p.ErrorMessage,
+			&p.CreatedAt,
+			&p.UpdatedAt,
+			&p.CompletedAt,
+			&p.DisputeID,
+			&p.DisputeStatus,
+			&p.DisputeReason,
+			&p.DisputedAt,
+			&p.Country,
+			&p.ReferrerCode,
+			&res.MatchedField,
+			&res.Score,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("scanning payment search row: %w", err)
+		}
+		res.ID, res.CreatedAt = p.ID, p.CreatedAt
+		results = append(results, res)
+	}
+
+	return results, rows.Err()
+}
+
+func (r *PostgresSearchRepo) searchKYCVerifications(ctx context.Context, term, pattern string, limit int) ([]*repository.SearchResult, error) {
+	// The review payload is matched by word prefix so a partial email or
+	// name still hits the full-text index
+	query := `
+		SELECT id, payment_id, user_address, sumsub_applicant_id, sumsub_inspection_id,
+		       sumsub_review_status, sumsub_review_result, status, whitelist_tx_hash,
+		       created_at, updated_at, submitted_at, verified_at, rejected_at,
+		       CASE
+		           WHEN user_address ILIKE $2 THEN 'user_address'
+		           WHEN sumsub_applicant_id ILIKE $2 THEN 'sumsub_applicant_id'
+		           WHEN whitelist_tx_hash ILIKE $2 THEN 'whitelist_tx_hash'
+		           ELSE 'sumsub_review_result'
+		       END AS matched_field,
+		       GREATEST(
+		           similarity(user_address, $1),
+		           similarity(COALESCE(sumsub_applicant_id, ''), $1),
+		           similarity(COALESCE(whitelist_tx_hash, ''), $1),
+		           ts_rank(to_tsvector('simple', COALESCE(sumsub_review_result, '{}'::jsonb)), to_tsquery('simple', $4), 32)
+		       ) AS score
+		FROM kyc_verifications
+		WHERE user_address ILIKE $2
+		   OR sumsub_applicant_id ILIKE $2
+		   OR whitelist_tx_hash ILIKE $2
+		   OR to_tsvector('simple', COALESCE(sumsub_review_result, '{}'::jsonb)) @@ to_tsquery('simple', $4)
+		ORDER BY score DESC, created_at DESC
+		LIMIT $3
+	`
+
+	rows, err := r.read.QueryContext(ctx, query, term, pattern, limit, prefixTSQuery(term))
+	if err != nil {
+		return nil, fmt.Errorf("searching kyc verifications: %w", err)
+	}
+	defer rows.Close()
+
+	var results []*repository.SearchResult
+	for rows.Next() {
+		v := &repository.KYCVerification{}
+		res := &repository.SearchResult{Type: repository.SearchResultKYCVerification, KYCVerification: v}
+		var reviewResultJSON []byte
+		err := rows.Scan(
+			&v.ID,
+			&v.PaymentID,
+			&v.UserAddress,
+			&v.SumsubApplicantID,
+			&v.SumsubInspectionID,
+			&v.SumsubReviewStatus,
+			&reviewResultJSON,
+			&v.Status,
+			&v.WhitelistTxHash,
+			&v.CreatedAt,
+			&v.UpdatedAt,
+			&v.SubmittedAt,
+			&v.VerifiedAt,
+			&v.RejectedAt,
+			&res.MatchedField,
+			&res.Score,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("scanning kyc verification search row: %w", err)
+		}
+		if reviewResultJSON != nil {
+			if err := json.Unmarshal(reviewResultJSON, &v.SumsubReviewResult); err != nil {
+				return nil, fmt.Errorf("parsing review result: %w", err)
+			}
+		}
+		res.ID, res.CreatedAt = v.ID, v.CreatedAt
+		results = append(results, res)
+	}
+
+	return results, rows.Err()
+}
+
+func (r *PostgresSearchRepo) searchMetaTxs(ctx context.Context, term, pattern string, limit int) ([]*repository.SearchResult, error) {
+	query := `
+		SELECT ` + metaTxColumns + `,
+		       CASE
+		           WHEN tx_hash ILIKE $2 THEN 'tx_hash'
+		           WHEN from_address ILIKE $2 THEN 'from_address'
+		           ELSE 'to_address'
+		       END AS matched_field,
+		       GREATEST(
+		           similarity(from_address, $1),
+		           similarity(to_address, $1),
+		           similarity(COALESCE(tx_hash, ''), $1)
+		       ) AS score
+		FROM meta_transactions
+		WHERE from_address ILIKE $2
+		   OR to_address ILIKE $2
+		   OR tx_hash ILIKE $2
+		ORDER BY score DESC, created_at DESC
+		LIMIT $3
+	`
+
+	rows, err := r.read.QueryContext(ctx, query, term, pattern, limit)
+	if err != nil {
+		return nil, fmt.Errorf("searching meta-transactions: %w", err)
+	}
+	defer rows.Close()
+
+	var results []*repository.SearchResult
+	for rows.Next() {
+		tx := &repository.MetaTransaction{}
+		res := &repository.SearchResult{Type: repository.SearchResultMetaTx, MetaTransaction: tx}
+		err := rows.Scan(
+			&tx.ID,
+			&tx.FromAddress,
+			&tx.ToAddress,
+			&tx.FunctionName,
+			&tx.Calldata,
+			&tx.Value,
+			&tx.GasLimit,
+			&tx.Nonce,
+			&tx.Deadline,
+			&tx.Signature,
+			&tx.Status,
+			&tx.TxHash,
+			&tx.GasUsed,
+			&tx.GasPrice,
+			&tx.RelayCostETH,
+			&tx.ErrorMessage,
+			&tx.RetryCount,
+			&tx.CreatedAt,
+			&tx.UpdatedAt,
+			&tx.SubmittedAt,
+			&tx.ConfirmedAt,
+			&res.MatchedField,
+			&res.Score,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("scanning meta-transaction search row: %w", err)
+		}
+		res.ID, res.CreatedAt = tx.ID, tx.CreatedAt
+		results = append(results, res)
+	}
+
+	return results, rows.Err()
+}
+
+// prefixTSQuery builds a to_tsquery expression matching every word of term
+// as a prefix, e.g. "alice@example" becomes 'alice@example':*
+func prefixTSQuery(term string) string {
+	var words []string
+	for _, w := range strings.Fields(term) {
+		w = strings.NewReplacer(`'`, "", `\`, "").Replace(w)
+		if w != "" {
+			words = append(words, "'"+w+"':*")
+		}
+	}
+	return strings.Join(words, " & ")
+}
+
+// sortSearchResults orders results by score, then newest first
+func sortSearchResults(results []*repository.SearchResult) {
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].CreatedAt.After(results[j].CreatedAt)
+	})
+}
@@ -0,0 +1,179 @@
+// Package postgres implements repository interfaces using PostgreSQL
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure PostgresSnapshotRepo implements SnapshotRepository
+var _ repository.SnapshotRepository = (*PostgresSnapshotRepo)(nil)
+
+// PostgresSnapshotRepo implements SnapshotRepository using PostgreSQL
+type PostgresSnapshotRepo struct {
+	db *sql.DB
+}
+
+// NewPostgresSnapshotRepo creates a new PostgreSQL Snapshot proposal repository
+func NewPostgresSnapshotRepo(db *sql.DB) *PostgresSnapshotRepo {
+	return &PostgresSnapshotRepo{db: db}
+}
+
+// snapshotProposalColumns is the column list read by every Snapshot proposal query
+const snapshotProposalColumns = `id, space, title, body, author, choices, scores, scores_total, votes,
+		       state, snapshot_block, link, onchain_proposal_id, start_time, end_time,
+		       created_at, synced_at`
+
+// UpsertSnapshotProposal records or refreshes a mirrored proposal, keeping its link
+func (r *PostgresSnapshotRepo) UpsertSnapshotProposal(ctx context.Context, p *repository.SnapshotProposal) error {
+	query := `
+		INSERT INTO snapshot_proposals (id, space, title, body, author, choices, scores, scores_total,
+		                                votes, state, snapshot_block, link, start_time, end_time, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+		ON CONFLICT (id) DO UPDATE SET
+		    space = excluded.space, title = excluded.title, body = excluded.body,
+		    author = excluded.author, choices = excluded.choices, scores = excluded.scores,
+		    scores_total = excluded.scores_total, votes = excluded.votes, state = excluded.state,
+		    snapshot_block = excluded.snapshot_block, link = excluded.link,
+		    start_time = excluded.start_time, end_time = excluded.end_time,
+		    created_at = excluded.created_at, synced_at = NOW()
+		RETURNING onchain_proposal_id, synced_at
+	`
+
+	err := r.db.QueryRowContext(ctx, query,
+		p.ID, p.Space, p.Title, p.Body, p.Author, pq.Array(append([]string{}, p.Choices...)), pq.Array(append([]float64{}, p.Scores...)), p.ScoresTotal,
+		p.Votes, p.State, p.SnapshotBlock, p.Link, p.StartTime, p.EndTime, p.CreatedAt,
+	).Scan(&p.OnchainProposalID, &p.SyncedAt)
+	if err != nil {
+		return fmt.Errorf("upserting snapshot proposal %s: %w", p.ID, err)
+	}
+	return nil
+}
+
+// GetSnapshotProposal retrieves a mirrored proposal by its Snapshot ID
+func (r *PostgresSnapshotRepo) GetSnapshotProposal(ctx context.Context, id string) (*repository.SnapshotProposal, error) {
+	query := `SELECT ` + snapshotProposalColumns + ` FROM snapshot_proposals WHERE id = $1`
+
+	p, err := scanSnapshotProposal(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrSnapshotProposalNotFound
+		}
+		return nil, fmt.Errorf("getting snapshot proposal %s: %w", id, err)
+	}
+
+	return p, nil
+}
+
+// ListSnapshotProposals lists mirrored proposals with filtering, newest first
+func (r *PostgresSnapshotRepo) ListSnapshotProposals(ctx context.Context, filter repository.SnapshotProposalFilter, page repository.Pagination) ([]*repository.SnapshotProposal, int64, error) {
+	whereClause := "WHERE 1=1"
+	args := []interface{}{}
+	argNum := 1
+
+	if filter.Space != "" {
+		whereClause += fmt.Sprintf(" AND space = $%d", argNum)
+		args = append(args, filter.Space)
+		argNum++
+	}
+	if filter.State != "" {
+		whereClause += fmt.Sprintf(" AND state = $%d", argNum)
+		args = append(args, filter.State)
+		argNum++
+	}
+	if filter.OnchainProposalID != "" {
+		whereClause += fmt.Sprintf(" AND onchain_proposal_id = $%d", argNum)
+		args = append(args, filter.OnchainProposalID)
+		argNum++
+	}
+	if filter.Unlinked {
+		whereClause += " AND onchain_proposal_id IS NULL"
+	}
+
+	var total int64
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM snapshot_proposals "+whereClause, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("counting snapshot proposals: %w", err)
+	}
+
+	if page.PageSize <= 0 {
+		page.PageSize = 20
+	}
+	if page.Page <= 0 {
+		page.Page = 1
+	}
+	offset := (page.Page - 1) * page.PageSize
+
+	query := fmt.Sprintf(`
+		SELECT `+snapshotProposalColumns+`
+		FROM snapshot_proposals
+		%s
+		ORDER BY created_at DESC
+		LIMIT $%d OFFSET $%d
+	`, whereClause, argNum, argNum+1)
+
+	args = append(args, page.PageSize, offset)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("listing snapshot proposals: %w", err)
+	}
+	defer rows.Close()
+
+	var result []*repository.SnapshotProposal
+	for rows.Next() {
+		p, err := scanSnapshotProposal(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("scanning snapshot proposal row: %w", err)
+		}
+		result = append(result, p)
+	}
+
+	return result, total, rows.Err()
+}
+
+// LinkSnapshotProposal links a mirrored proposal to an on-chain proposal
+func (r *PostgresSnapshotRepo) LinkSnapshotProposal(ctx context.Context, id string, onchainProposalID *string) error {
+	result, err := r.db.ExecContext(ctx,
+		`UPDATE snapshot_proposals SET onchain_proposal_id = $2 WHERE id = $1`, id, onchainProposalID)
+	if err != nil {
+		return fmt.Errorf("linking snapshot proposal %s: %w", id, err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return repository.ErrSnapshotProposalNotFound
+	}
+	return nil
+}
+
+// scanSnapshotProposal scans a snapshot_proposals row
+func scanSnapshotProposal(row rowScanner) (*repository.SnapshotProposal, error) {
+	p := &repository.SnapshotProposal{}
+	err := row.Scan(
+		&p.ID,
+		&p.Space,
+		&p.Title,
+		&p.Body,
+		&p.Author,
+		pq.Array(&p.Choices),
+		pq.Array(&p.Scores),
+		&p.ScoresTotal,
+		&p.Votes,
+		&p.State,
+		&p.SnapshotBlock,
+		&p.Link,
+		&p.OnchainProposalID,
+		&p.StartTime,
+		&p.EndTime,
+		&p.CreatedAt,
+		&p.SyncedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
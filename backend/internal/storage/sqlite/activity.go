@@ -0,0 +1,103 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure SQLiteActivityRepo implements ActivityRepository
+var _ repository.ActivityRepository = (*SQLiteActivityRepo)(nil)
+
+// SQLiteActivityRepo implements ActivityRepository using SQLite
+type SQLiteActivityRepo struct {
+	db *sql.DB
+}
+
+// NewSQLiteActivityRepo creates a new SQLite activity repository
+func NewSQLiteActivityRepo(db *sql.DB) *SQLiteActivityRepo {
+	return &SQLiteActivityRepo{db: db}
+}
+
+// RecordActivity appends an event
+func (r *SQLiteActivityRepo) RecordActivity(ctx context.Context, e *repository.ActivityEvent) error {
+	query := `
+		INSERT INTO activity_events (id, address, kind, action, subject_id, summary, tx_hash, occurred_at)
+		VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7, COALESCE(?8, ` + sqlNow + `))
+		RETURNING id, occurred_at
+	`
+
+	var occurredAt *string
+	if !e.OccurredAt.IsZero() {
+		t := timeArg(e.OccurredAt)
+		occurredAt = &t
+	}
+	err := r.db.QueryRowContext(ctx, query, newID(), e.Address, e.Kind, e.Action, e.SubjectID, e.Summary, e.TxHash, occurredAt).
+		Scan(&e.ID, &e.OccurredAt)
+	if err != nil {
+		return fmt.Errorf("recording %s activity for %s: %w", e.Kind, e.Address, err)
+	}
+	return nil
+}
+
+// ListActivity lists an address's events, newest first
+func (r *SQLiteActivityRepo) ListActivity(ctx context.Context, filter repository.ActivityFilter, page repository.Pagination) ([]*repository.ActivityEvent, int64, error) {
+	whereClause := "WHERE address = ?1"
+	args := []interface{}{filter.Address}
+	argNum := 2
+
+	if len(filter.Kinds) > 0 {
+		kinds, err := json.Marshal(filter.Kinds)
+		if err != nil {
+			return nil, 0, fmt.Errorf("encoding activity kinds: %w", err)
+		}
+		whereClause += fmt.Sprintf(" AND kind IN (SELECT value FROM json_each(?%d))", argNum)
+		args = append(args, string(kinds))
+		argNum++
+	}
+
+	var total int64
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM activity_events "+whereClause, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("counting activity events: %w", err)
+	}
+
+	if page.PageSize <= 0 {
+		page.PageSize = 20
+	}
+	if page.Page <= 0 {
+		page.Page = 1
+	}
+	offset := (page.Page - 1) * page.PageSize
+
+	// julianday orders the instants rather than their text, which differs in
+	// fractional digits between sqlNow and timeArg; rowid breaks ties
+	query := fmt.Sprintf(`
+		SELECT id, address, kind, action, subject_id, summary, tx_hash, occurred_at
+		FROM activity_events
+		%s
+		ORDER BY julianday(occurred_at) DESC, rowid DESC
+		LIMIT ?%d OFFSET ?%d
+	`, whereClause, argNum, argNum+1)
+
+	args = append(args, page.PageSize, offset)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("listing activity events: %w", err)
+	}
+	defer rows.Close()
+
+	var result []*repository.ActivityEvent
+	for rows.Next() {
+		e := &repository.ActivityEvent{}
+		if err := rows.Scan(&e.ID, &e.Address, &e.Kind, &e.Action, &e.SubjectID, &e.Summary, &e.TxHash, &e.OccurredAt); err != nil {
+			return nil, 0, fmt.Errorf("scanning activity event row: %w", err)
+		}
+		result = append(result, e)
+	}
+
+	return result, total, rows.Err()
+}
@@ -0,0 +1,239 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure SQLiteAnomalyRepo implements AnomalyRepository
+var _ repository.AnomalyRepository = (*SQLiteAnomalyRepo)(nil)
+
+// SQLiteAnomalyRepo implements AnomalyRepository using SQLite
+type SQLiteAnomalyRepo struct {
+	db *sql.DB
+}
+
+// NewSQLiteAnomalyRepo creates a new SQLite anomaly repository
+func NewSQLiteAnomalyRepo(db *sql.DB) *SQLiteAnomalyRepo {
+	return &SQLiteAnomalyRepo{db: db}
+}
+
+// complianceCaseColumns is the column list read by every case query
+const complianceCaseColumns = `id, rule, subject, summary, observed, threshold, status, opened_at, closed_at, closed_by, resolution`
+
+// CountPaymentsByPayer counts recent payments per payer
+func (r *SQLiteAnomalyRepo) CountPaymentsByPayer(ctx context.Context, since time.Time, min int64) ([]repository.SubjectCount, error) {
+	return r.countBy(ctx, "payments", `
+		SELECT payer_address, COUNT(*) FROM payments
+		WHERE julianday(created_at) >= julianday(?1)
+		GROUP BY payer_address HAVING COUNT(*) >= ?2
+		ORDER BY COUNT(*) DESC, payer_address
+	`, since, min)
+}
+
+// CountFailedRelaysBySender counts recent failed meta-transactions per sender
+func (r *SQLiteAnomalyRepo) CountFailedRelaysBySender(ctx context.Context, since time.Time, min int64) ([]repository.SubjectCount, error) {
+	return r.countBy(ctx, "failed meta-transactions", `
+		SELECT from_address, COUNT(*) FROM meta_transactions
+		WHERE status = 'failed' AND julianday(created_at) >= julianday(?1)
+		GROUP BY from_address HAVING COUNT(*) >= ?2
+		ORDER BY COUNT(*) DESC, from_address
+	`, since, min)
+}
+
+func (r *SQLiteAnomalyRepo) countBy(ctx context.Context, what, query string, since time.Time, min int64) ([]repository.SubjectCount, error) {
+	rows, err := r.db.QueryContext(ctx, query, timeArg(since), min)
+	if err != nil {
+		return nil, fmt.Errorf("counting %s: %w", what, err)
+	}
+	defer rows.Close()
+
+	var result []repository.SubjectCount
+	for rows.Next() {
+		var c repository.SubjectCount
+		if err := rows.Scan(&c.Subject, &c.Count); err != nil {
+			return nil, fmt.Errorf("scanning %s count: %w", what, err)
+		}
+		result = append(result, c)
+	}
+	return result, rows.Err()
+}
+
+// HourlyActivity counts payments and meta-transactions per UTC hour
+func (r *SQLiteAnomalyRepo) HourlyActivity(ctx context.Context, since time.Time) ([]repository.HourlyCount, error) {
+	query := `
+		SELECT hour, SUM(payments), SUM(relays) FROM (
+			SELECT strftime('%Y-%m-%dT%H:00:00Z', created_at) AS hour, 1 AS payments, 0 AS relays
+			FROM payments WHERE julianday(created_at) >= julianday(?1)
+			UNION ALL
+			SELECT strftime('%Y-%m-%dT%H:00:00Z', created_at), 0, 1
+			FROM meta_transactions WHERE julianday(created_at) >= julianday(?1)
+		)
+		GROUP BY hour
+		ORDER BY hour
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, timeArg(since))
+	if err != nil {
+		return nil, fmt.Errorf("counting hourly activity: %w", err)
+	}
+	defer rows.Close()
+
+	var result []repository.HourlyCount
+	for rows.Next() {
+		var h repository.HourlyCount
+		var hour string
+		if err := rows.Scan(&hour, &h.Payments, &h.Relays); err != nil {
+			return nil, fmt.Errorf("scanning hourly activity: %w", err)
+		}
+		if h.Hour, err = time.Parse(time.RFC3339, hour); err != nil {
+			return nil, fmt.Errorf("parsing activity hour %q: %w", hour, err)
+		}
+		result = append(result, h)
+	}
+	return result, rows.Err()
+}
+
+// OpenComplianceCase opens a case unless one is open for its rule and subject
+func (r *SQLiteAnomalyRepo) OpenComplianceCase(ctx context.Context, c *repository.ComplianceCase) (bool, error) {
+	query := `
+		INSERT INTO compliance_cases (id, rule, subject, summary, observed, threshold)
+		VALUES (?1, ?2, ?3, ?4, ?5, ?6)
+		ON CONFLICT (rule, subject) WHERE status = 'open' DO NOTHING
+		RETURNING id, status, opened_at
+	`
+
+	err := r.db.QueryRowContext(ctx, query, newID(), c.Rule, c.Subject, c.Summary, c.Observed, c.Threshold).
+		Scan(&c.ID, &c.Status, &c.OpenedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, fmt.Errorf("opening compliance case: %w", err)
+	}
+	return true, nil
+}
+
+// GetComplianceCase retrieves a case by ID
+func (r *SQLiteAnomalyRepo) GetComplianceCase(ctx context.Context, id string) (*repository.ComplianceCase, error) {
+	query := `SELECT ` + complianceCaseColumns + ` FROM compliance_cases WHERE id = ?1`
+
+	c, err := scanComplianceCase(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrComplianceCaseNotFound
+		}
+		return nil, fmt.Errorf("getting compliance case %s: %w", id, err)
+	}
+	return c, nil
+}
+
+// ListComplianceCases lists cases, newest first
+func (r *SQLiteAnomalyRepo) ListComplianceCases(ctx context.Context, filter repository.ComplianceCaseFilter, page repository.Pagination) ([]*repository.ComplianceCase, int64, error) {
+	whereClause := "WHERE 1=1"
+	args := []interface{}{}
+	argNum := 1
+
+	if filter.Rule != "" {
+		whereClause += fmt.Sprintf(" AND rule = $%d", argNum)
+		args = append(args, filter.Rule)
+		argNum++
+	}
+	if filter.Subject != "" {
+		whereClause += fmt.Sprintf(" AND subject = $%d", argNum)
+		args = append(args, filter.Subject)
+		argNum++
+	}
+	if filter.Status != "" {
+		whereClause += fmt.Sprintf(" AND status = $%d", argNum)
+		args = append(args, filter.Status)
+		argNum++
+	}
+
+	var total int64
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM compliance_cases "+whereClause, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("counting compliance cases: %w", err)
+	}
+
+	if page.PageSize <= 0 {
+		page.PageSize = 20
+	}
+	if page.Page <= 0 {
+		page.Page = 1
+	}
+	offset := (page.Page - 1) * page.PageSize
+
+	query := fmt.Sprintf(`
+		SELECT `+complianceCaseColumns+`
+		FROM compliance_cases
+		%s
+		ORDER BY opened_at DESC
+		LIMIT $%d OFFSET $%d
+	`, whereClause, argNum, argNum+1)
+
+	args = append(args, page.PageSize, offset)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("listing compliance cases: %w", err)
+	}
+	defer rows.Close()
+
+	var result []*repository.ComplianceCase
+	for rows.Next() {
+		c, err := scanComplianceCase(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("scanning compliance case row: %w", err)
+		}
+		result = append(result, c)
+	}
+
+	return result, total, rows.Err()
+}
+
+// CloseComplianceCase closes an open case
+func (r *SQLiteAnomalyRepo) CloseComplianceCase(ctx context.Context, id, closedBy, resolution string) (*repository.ComplianceCase, error) {
+	query := `
+		UPDATE compliance_cases
+		SET status = 'closed', closed_at = ` + sqlNow + `, closed_by = ?2, resolution = ?3
+		WHERE id = ?1 AND status = 'open'
+		RETURNING ` + complianceCaseColumns
+
+	c, err := scanComplianceCase(r.db.QueryRowContext(ctx, query, id, closedBy, resolution))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			if _, getErr := r.GetComplianceCase(ctx, id); getErr != nil {
+				return nil, getErr
+			}
+			return nil, repository.ErrComplianceCaseClosed
+		}
+		return nil, fmt.Errorf("closing compliance case %s: %w", id, err)
+	}
+	return c, nil
+}
+
+func scanComplianceCase(row rowScanner) (*repository.ComplianceCase, error) {
+	c := &repository.ComplianceCase{}
+	err := row.Scan(
+		&c.ID,
+		&c.Rule,
+		&c.Subject,
+		&c.Summary,
+		&c.Observed,
+		&c.Threshold,
+		&c.Status,
+		&c.OpenedAt,
+		&c.ClosedAt,
+		&c.ClosedBy,
+		&c.Resolution,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
@@ -0,0 +1,371 @@
+// Package sqlite implements repository interfaces using SQLite
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// paymentColumns is the column list shared by payments and payments_archive
+const paymentColumns = `id, service_code, pricing_id, payer_address, payment_method,
+		       amount_charged, currency, amount_usd, tx_hash,
+		       stripe_payment_id, stripe_session_id, status, error_message,
+		       created_at, updated_at, completed_at,
+		       dispute_id, dispute_status, dispute_reason, disputed_at, country, referrer_code`
+
+// metaTxColumns is the column list shared by meta_transactions and meta_transactions_archive
+const metaTxColumns = `id, from_address, to_address, function_name, calldata, value,
+		       gas_limit, nonce, deadline, signature, status, tx_hash,
+		       gas_used, gas_price, relay_cost_eth, error_message, retry_count,
+		       created_at, updated_at, submitted_at, confirmed_at`
+
+// ArchivePayments moves up to limit settled payments created before the
+// cutoff into payments_archive. Payments linked to a KYC verification, or
+// whose transaction is still awaiting confirmation, are kept.
+func (r *SQLitePaymentRepo) ArchivePayments(ctx context.Context, before time.Time, limit int) (int64, error) {
+	candidates := `
+		SELECT p.id FROM payments p
+		WHERE p.created_at < ?1
+		  AND p.status IN ('completed', 'failed', 'refunded', 'cancelled', 'expired')
+		  AND NOT EXISTS (SELECT 1 FROM kyc_verifications k WHERE k.payment_id = p.id)
+		  AND NOT EXISTS (
+			SELECT 1 FROM chain_tx_tracking t
+			WHERE t.record_type = ?3 AND t.record_id = p.id
+			  AND t.status IN ('pending', 'included')
+		  )
+		ORDER BY p.created_at
+		LIMIT ?2
+	`
+
+	moved, err := archiveRows(ctx, r.db, "payments", "payments_archive", paymentColumns, candidates,
+		timeArg(before), limit, repository.TrackedRecordPayment)
+	if err != nil {
+		return 0, fmt.Errorf("archiving payments: %w", err)
+	}
+
+	return moved, nil
+}
+
+// GetArchivedPayment retrieves an archived payment by ID
+func (r *SQLitePaymentRepo) GetArchivedPayment(ctx context.Context, id string) (*repository.Payment, error) {
+	query := `
+		SELECT ` + paymentColumns + `, archived_at
+		FROM payments_archive
+		WHERE id = ?1
+	`
+
+	p, err := scanArchivedPayment(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrPaymentNotFound
+		}
+		return nil, fmt.Errorf("getting archived payment %s: %w", id, err)
+	}
+
+	return p, nil
+}
+
+// ListArchivedPayments lists archived payments with filtering, newest first
+func (r *SQLitePaymentRepo) ListArchivedPayments(ctx context.Context, filter repository.PaymentFilter, page repository.Pagination) ([]*repository.Payment, int64, error) {
+	where := []string{"1=1"}
+	args := []interface{}{}
+	argNum := 1
+
+	if filter.PayerAddress != "" {
+		where = append(where, fmt.Sprintf("payer_address = ?%d", argNum))
+		args = append(args, filter.PayerAddress)
+		argNum++
+	}
+	if filter.ServiceCode != "" {
+		where = append(where, fmt.Sprintf("service_code = ?%d", argNum))
+		args = append(args, filter.ServiceCode)
+		argNum++
+	}
+	if filter.PaymentMethod != "" {
+		where = append(where, fmt.Sprintf("payment_method = ?%d", argNum))
+		args = append(args, filter.PaymentMethod)
+		argNum++
+	}
+	if filter.Status != "" {
+		where = append(where, fmt.Sprintf("status = ?%d", argNum))
+		args = append(args, filter.Status)
+		argNum++
+	}
+	if filter.ExcludesExpired() {
+		where = append(where, "status <> 'expired'")
+	}
+	if filter.Disputed {
+		where = append(where, "dispute_id IS NOT NULL")
+	}
+
+	whereClause := "WHERE " + join(where, " AND ")
+
+	var total int64
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM payments_archive "+whereClause, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("counting archived payments: %w", err)
+	}
+
+	if page.PageSize <= 0 {
+		page.PageSize = 20
+	}
+	if page.Page <= 0 {
+		page.Page = 1
+	}
+	offset := (page.Page - 1) * page.PageSize
+
+	query := fmt.Sprintf(`
+		SELECT `+paymentColumns+`, archived_at
+		FROM payments_archive
+		%s
+		ORDER BY created_at DESC
+		LIMIT ?%d OFFSET ?%d
+	`, whereClause, argNum, argNum+1)
+
+	args = append(args, page.PageSize, offset)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("listing archived payments: %w", err)
+	}
+	defer rows.Close()
+
+	var result []*repository.Payment
+	for rows.Next() {
+		p, err := scanArchivedPayment(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("scanning archived payment row: %w", err)
+		}
+		result = append(result, p)
+	}
+
+	return result, total, rows.Err()
+}
+
+// scanArchivedPayment scans a payments_archive row
+func scanArchivedPayment(row rowScanner) (*repository.Payment, error) {
+	p := &repository.Payment{}
+	err := row.Scan(
+		&p.ID,
+		&p.ServiceCode,
+		&p.PricingID,
+		&p.PayerAddress,
+		&p.PaymentMethod,
+		&p.AmountCharged,
+		&p.Currency,
+		&p.AmountUSD,
+		&p.TxHash,
+		&p.StripePaymentID,
+		&p.StripeSessionID,
+		&p.Status,
+		&p.ErrorMessage,
+		&p.CreatedAt,
+		&p.UpdatedAt,
+		&p.CompletedAt,
+		&p.DisputeID,
+		&p.DisputeStatus,
+		&p.DisputeReason,
+		&p.DisputedAt,
+		&p.Country,
+		&p.ReferrerCode,
+		&p.ArchivedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// ArchiveMetaTxs moves up to limit finished meta-transactions created before
+// the cutoff into meta_transactions_archive. Meta-transactions whose relayed
+// transaction is still awaiting confirmation are kept, as is each sender's
+// highest live nonce so GetNextNonce does not go backwards.
+func (r *SQLiteRelayerRepo) ArchiveMetaTxs(ctx context.Context, before time.Time, limit int) (int64, error) {
+	candidates := `
+		SELECT m.id FROM meta_transactions m
+		WHERE m.created_at < ?1
+		  AND m.status IN ('confirmed', 'failed', 'expired', 'cancelled')
+		  AND NOT EXISTS (
+			SELECT 1 FROM chain_tx_tracking t
+			WHERE t.record_type = ?3 AND t.record_id = m.id
+			  AND t.status IN ('pending', 'included')
+		  )
+		  AND NOT (m.status = 'confirmed' AND m.nonce = (
+			SELECT MAX(x.nonce) FROM meta_transactions x
+			WHERE x.from_address = m.from_address
+			  AND x.status NOT IN ('failed', 'expired', 'cancelled')
+		  ))
+		ORDER BY m.created_at
+		LIMIT ?2
+	`
+
+	moved, err := archiveRows(ctx, r.db, "meta_transactions", "meta_transactions_archive", metaTxColumns, candidates,
+		timeArg(before), limit, repository.TrackedRecordMetaTx)
+	if err != nil {
+		return 0, fmt.Errorf("archiving meta-transactions: %w", err)
+	}
+
+	return moved, nil
+}
+
+// GetArchivedMetaTx retrieves an archived meta-transaction by ID
+func (r *SQLiteRelayerRepo) GetArchivedMetaTx(ctx context.Context, id string) (*repository.MetaTransaction, error) {
+	query := `
+		SELECT ` + metaTxColumns + `, archived_at
+		FROM meta_transactions_archive
+		WHERE id = ?1
+	`
+
+	tx, err := scanArchivedMetaTx(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrMetaTxNotFound
+		}
+		return nil, fmt.Errorf("getting archived meta-transaction %s: %w", id, err)
+	}
+
+	return tx, nil
+}
+
+// ListArchivedMetaTx lists archived meta-transactions with filtering, newest first
+func (r *SQLiteRelayerRepo) ListArchivedMetaTx(ctx context.Context, filter repository.MetaTxFilter, page repository.Pagination) ([]*repository.MetaTransaction, int64, error) {
+	whereClause := "WHERE 1=1"
+	args := []interface{}{}
+	argNum := 1
+
+	if filter.FromAddress != "" {
+		whereClause += fmt.Sprintf(" AND from_address = ?%d", argNum)
+		args = append(args, filter.FromAddress)
+		argNum++
+	}
+	if filter.ToAddress != "" {
+		whereClause += fmt.Sprintf(" AND to_address = ?%d", argNum)
+		args = append(args, filter.ToAddress)
+		argNum++
+	}
+	if filter.FunctionName != "" {
+		whereClause += fmt.Sprintf(" AND function_name = ?%d", argNum)
+		args = append(args, filter.FunctionName)
+		argNum++
+	}
+	if filter.Status != "" {
+		whereClause += fmt.Sprintf(" AND status = ?%d", argNum)
+		args = append(args, filter.Status)
+		argNum++
+	}
+
+	var total int64
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM meta_transactions_archive "+whereClause, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("counting archived meta-transactions: %w", err)
+	}
+
+	if page.PageSize <= 0 {
+		page.PageSize = 20
+	}
+	if page.Page <= 0 {
+		page.Page = 1
+	}
+	offset := (page.Page - 1) * page.PageSize
+
+	query := fmt.Sprintf(`
+		SELECT `+metaTxColumns+`, archived_at
+		FROM meta_transactions_archive
+		%s
+		ORDER BY created_at DESC
+		LIMIT ?%d OFFSET ?%d
+	`, whereClause, argNum, argNum+1)
+
+	args = append(args, page.PageSize, offset)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("listing archived meta-transactions: %w", err)
+	}
+	defer rows.Close()
+
+	var result []*repository.MetaTransaction
+	for rows.Next() {
+		tx, err := scanArchivedMetaTx(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("scanning archived meta-transaction row: %w", err)
+		}
+		result = append(result, tx)
+	}
+
+	return result, total, rows.Err()
+}
+
+// scanArchivedMetaTx scans a meta_transactions_archive row
+func scanArchivedMetaTx(row rowScanner) (*repository.MetaTransaction, error) {
+	tx := &repository.MetaTransaction{}
+	err := row.Scan(
+		&tx.ID,
+		&tx.FromAddress,
+		&tx.ToAddress,
+		&tx.FunctionName,
+		&tx.Calldata,
+		&tx.Value,
+		&tx.GasLimit,
+		&tx.Nonce,
+		&tx.Deadline,
+		&tx.Signature,
+		&tx.Status,
+		&tx.TxHash,
+		&tx.GasUsed,
+		&tx.GasPrice,
+		&tx.RelayCostETH,
+		&tx.ErrorMessage,
+		&tx.RetryCount,
+		&tx.CreatedAt,
+		&tx.UpdatedAt,
+		&tx.SubmittedAt,
+		&tx.ConfirmedAt,
+		&tx.ArchivedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+// archiveRows copies the rows selected by candidates (an id query) from table
+// into archive and deletes them, in one transaction. SQLite cannot feed
+// DELETE ... RETURNING into an INSERT, so the ids are staged in a temp table.
+func archiveRows(ctx context.Context, db *sql.DB, table, archive, columns, candidates string, args ...interface{}) (int64, error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, "CREATE TEMP TABLE IF NOT EXISTS archive_ids (id TEXT PRIMARY KEY)"); err != nil {
+		return 0, err
+	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM archive_ids"); err != nil {
+		return 0, err
+	}
+	if _, err := tx.ExecContext(ctx, "INSERT INTO archive_ids (id) "+candidates, args...); err != nil {
+		return 0, err
+	}
+
+	insert := "INSERT INTO " + archive + " (" + columns + ", archived_at) SELECT " + columns + ", ?1 FROM " + table +
+		" WHERE id IN (SELECT id FROM archive_ids)"
+	if _, err := tx.ExecContext(ctx, insert, timeArg(time.Now())); err != nil {
+		return 0, err
+	}
+
+	result, err := tx.ExecContext(ctx, "DELETE FROM "+table+" WHERE id IN (SELECT id FROM archive_ids)")
+	if err != nil {
+		return 0, err
+	}
+	moved, _ := result.RowsAffected()
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return moved, nil
+}
@@ -0,0 +1,260 @@
+// Package sqlite implements repository interfaces using SQLite
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure SQLiteBridgeRepo implements BridgeRepository
+var _ repository.BridgeRepository = (*SQLiteBridgeRepo)(nil)
+
+// SQLiteBridgeRepo implements BridgeRepository using SQLite
+type SQLiteBridgeRepo struct {
+	db *sql.DB
+}
+
+// NewSQLiteBridgeRepo creates a new SQLite bridge repository
+func NewSQLiteBridgeRepo(db *sql.DB) *SQLiteBridgeRepo {
+	return &SQLiteBridgeRepo{db: db}
+}
+
+// bridgeTransferColumns is the column list read by every bridge transfer query
+const bridgeTransferColumns = `id, direction, sender, recipient, amount, source_chain_id, dest_chain_id, nonce,
+		       source_status, source_transfer_id, source_tx_hash, source_block, source_confirmed_at,
+		       dest_status, dest_transfer_id, dest_tx_hash, dest_block, dest_unlock_at, dest_completed_at,
+		       created_at, updated_at`
+
+// CreateBridgeTransfer records a transfer, refusing a source chain and nonce
+// recorded before
+func (r *SQLiteBridgeRepo) CreateBridgeTransfer(ctx context.Context, t *repository.BridgeTransfer) error {
+	query := `
+		INSERT INTO bridge_transfers (direction, sender, recipient, amount, source_chain_id, dest_chain_id, nonce,
+		                              source_status, source_transfer_id, source_tx_hash, source_block,
+		                              source_confirmed_at,
+		                              dest_status, dest_transfer_id, dest_tx_hash, dest_block, dest_unlock_at,
+		                              dest_completed_at)
+		VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7, ?8, ?9, ?10, ?11,
+		        CASE WHEN ?8 = 'confirmed' THEN ` + sqlNow + ` END,
+		        ?12, ?13, ?14, ?15, ?16,
+		        CASE WHEN ?12 = 'completed' THEN ` + sqlNow + ` END)
+		ON CONFLICT (source_chain_id, nonce) DO NOTHING
+		RETURNING id, source_confirmed_at, dest_completed_at, created_at, updated_at
+	`
+
+	var unlockAt interface{}
+	if t.DestUnlockAt != nil {
+		unlockAt = timeArg(*t.DestUnlockAt)
+	}
+	err := r.db.QueryRowContext(ctx, query,
+		t.Direction, t.Sender, t.Recipient, t.Amount, t.SourceChainID, t.DestChainID, t.Nonce,
+		t.SourceStatus, t.SourceTransferID, t.SourceTxHash, t.SourceBlock,
+		t.DestStatus, t.DestTransferID, t.DestTxHash, t.DestBlock, unlockAt,
+	).Scan(&t.ID, &t.SourceConfirmedAt, &t.DestCompletedAt, &t.CreatedAt, &t.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return repository.ErrBridgeTransferExists
+	}
+	if err != nil {
+		return fmt.Errorf("creating bridge transfer: %w", err)
+	}
+	return nil
+}
+
+// GetBridgeTransfer retrieves a bridge transfer by ID
+func (r *SQLiteBridgeRepo) GetBridgeTransfer(ctx context.Context, id string) (*repository.BridgeTransfer, error) {
+	return r.getBridgeTransfer(ctx, "id = ?1", id)
+}
+
+// GetBridgeTransferByNonce retrieves a bridge transfer by source chain and nonce
+func (r *SQLiteBridgeRepo) GetBridgeTransferByNonce(ctx context.Context, sourceChainID int64, nonce uint64) (*repository.BridgeTransfer, error) {
+	return r.getBridgeTransfer(ctx, "source_chain_id = ?1 AND nonce = ?2", sourceChainID, nonce)
+}
+
+// GetBridgeTransferByDestID retrieves a bridge transfer by its destination
+// transfer ID
+func (r *SQLiteBridgeRepo) GetBridgeTransferByDestID(ctx context.Context, destTransferID string) (*repository.BridgeTransfer, error) {
+	return r.getBridgeTransfer(ctx, "dest_transfer_id = ?1", destTransferID)
+}
+
+func (r *SQLiteBridgeRepo) getBridgeTransfer(ctx context.Context, where string, args ...interface{}) (*repository.BridgeTransfer, error) {
+	query := `SELECT ` + bridgeTransferColumns + ` FROM bridge_transfers WHERE ` + where
+
+	t, err := scanBridgeTransfer(r.db.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrBridgeTransferNotFound
+		}
+		return nil, fmt.Errorf("getting bridge transfer: %w", err)
+	}
+
+	return t, nil
+}
+
+// MatchBridgeIntent finds the oldest unmatched intent the source event fits
+func (r *SQLiteBridgeRepo) MatchBridgeIntent(ctx context.Context, match repository.BridgeIntentMatch) (*repository.BridgeTransfer, error) {
+	query := `
+		SELECT ` + bridgeTransferColumns + `
+		FROM bridge_transfers
+		WHERE nonce IS NULL AND sender = ?1 AND recipient = ?2 AND amount = ?3
+		  AND source_chain_id = ?4 AND dest_chain_id = ?5
+		  AND (source_tx_hash IS NULL OR source_tx_hash = ?6)
+		ORDER BY created_at
+		LIMIT 1
+	`
+
+	t, err := scanBridgeTransfer(r.db.QueryRowContext(ctx, query,
+		match.Sender, match.Recipient, match.Amount, match.SourceChainID, match.DestChainID, match.TxHash))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrBridgeTransferNotFound
+		}
+		return nil, fmt.Errorf("matching bridge intent: %w", err)
+	}
+
+	return t, nil
+}
+
+// UpdateBridgeTransfer applies the set fields of update
+func (r *SQLiteBridgeRepo) UpdateBridgeTransfer(ctx context.Context, id string, update *repository.BridgeTransferUpdate) error {
+	query := `
+		UPDATE bridge_transfers
+		SET direction = COALESCE(?2, direction),
+		    sender = COALESCE(?3, sender),
+		    nonce = COALESCE(?4, nonce),
+		    source_status = COALESCE(?5, source_status),
+		    source_transfer_id = COALESCE(?6, source_transfer_id),
+		    source_tx_hash = COALESCE(?7, source_tx_hash),
+		    source_block = COALESCE(?8, source_block),
+		    source_confirmed_at = CASE WHEN ?5 = 'confirmed' THEN COALESCE(source_confirmed_at, ` + sqlNow + `) ELSE source_confirmed_at END,
+		    dest_status = COALESCE(?9, dest_status),
+		    dest_transfer_id = COALESCE(?10, dest_transfer_id),
+		    dest_tx_hash = COALESCE(?11, dest_tx_hash),
+		    dest_block = COALESCE(?12, dest_block),
+		    dest_unlock_at = COALESCE(?13, dest_unlock_at),
+		    dest_completed_at = CASE WHEN ?9 = 'completed' THEN COALESCE(dest_completed_at, ` + sqlNow + `) ELSE dest_completed_at END,
+		    updated_at = ` + sqlNow + `
+		WHERE id = ?1
+	`
+
+	var unlockAt interface{}
+	if update.DestUnlockAt != nil {
+		unlockAt = timeArg(*update.DestUnlockAt)
+	}
+	result, err := r.db.ExecContext(ctx, query, id,
+		update.Direction, update.Sender, update.Nonce,
+		update.SourceStatus, update.SourceTransferID, update.SourceTxHash, update.SourceBlock,
+		update.DestStatus, update.DestTransferID, update.DestTxHash, update.DestBlock, unlockAt,
+	)
+	if err != nil {
+		return fmt.Errorf("updating bridge transfer %s: %w", id, err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return repository.ErrBridgeTransferNotFound
+	}
+	return nil
+}
+
+// ListBridgeTransfers lists transfers sent or received by address, newest first
+func (r *SQLiteBridgeRepo) ListBridgeTransfers(ctx context.Context, address string, page repository.Pagination) ([]*repository.BridgeTransfer, int64, error) {
+	whereClause := "WHERE sender = ?1 OR recipient = ?1"
+
+	var total int64
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM bridge_transfers "+whereClause, address).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("counting bridge transfers: %w", err)
+	}
+
+	if page.PageSize <= 0 {
+		page.PageSize = 20
+	}
+	if page.Page <= 0 {
+		page.Page = 1
+	}
+	offset := (page.Page - 1) * page.PageSize
+
+	query := `
+		SELECT ` + bridgeTransferColumns + `
+		FROM bridge_transfers
+		` + whereClause + `
+		ORDER BY created_at DESC
+		LIMIT ?2 OFFSET ?3
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, address, page.PageSize, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("listing bridge transfers: %w", err)
+	}
+	defer rows.Close()
+
+	var result []*repository.BridgeTransfer
+	for rows.Next() {
+		t, err := scanBridgeTransfer(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("scanning bridge transfer row: %w", err)
+		}
+		result = append(result, t)
+	}
+
+	return result, total, rows.Err()
+}
+
+// GetBridgeCursor returns the first block of chainID not yet scanned
+func (r *SQLiteBridgeRepo) GetBridgeCursor(ctx context.Context, chainID int64) (uint64, error) {
+	var next uint64
+	err := r.db.QueryRowContext(ctx, `SELECT next_block FROM bridge_cursors WHERE chain_id = ?1`, chainID).Scan(&next)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, repository.ErrBridgeCursorNotFound
+	}
+	if err != nil {
+		return 0, fmt.Errorf("getting bridge cursor for chain %d: %w", chainID, err)
+	}
+	return next, nil
+}
+
+// SetBridgeCursor records the first block of chainID not yet scanned
+func (r *SQLiteBridgeRepo) SetBridgeCursor(ctx context.Context, chainID int64, nextBlock uint64) error {
+	query := `
+		INSERT INTO bridge_cursors (chain_id, next_block)
+		VALUES (?1, ?2)
+		ON CONFLICT (chain_id) DO UPDATE SET next_block = excluded.next_block, updated_at = ` + sqlNow + `
+	`
+	if _, err := r.db.ExecContext(ctx, query, chainID, nextBlock); err != nil {
+		return fmt.Errorf("setting bridge cursor for chain %d: %w", chainID, err)
+	}
+	return nil
+}
+
+// scanBridgeTransfer scans a bridge_transfers row
+func scanBridgeTransfer(row rowScanner) (*repository.BridgeTransfer, error) {
+	t := &repository.BridgeTransfer{}
+	err := row.Scan(
+		&t.ID,
+		&t.Direction,
+		&t.Sender,
+		&t.Recipient,
+		&t.Amount,
+		&t.SourceChainID,
+		&t.DestChainID,
+		&t.Nonce,
+		&t.SourceStatus,
+		&t.SourceTransferID,
+		&t.SourceTxHash,
+		&t.SourceBlock,
+		&t.SourceConfirmedAt,
+		&t.DestStatus,
+		&t.DestTransferID,
+		&t.DestTxHash,
+		&t.DestBlock,
+		&t.DestUnlockAt,
+		&t.DestCompletedAt,
+		&t.CreatedAt,
+		&t.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
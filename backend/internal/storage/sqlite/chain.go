@@ -0,0 +1,310 @@
+// Package sqlite implements repository interfaces using SQLite
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure SQLiteChainRepo implements ChainRepository
+var _ repository.ChainRepository = (*SQLiteChainRepo)(nil)
+
+// SQLiteChainRepo implements ChainRepository using SQLite
+type SQLiteChainRepo struct {
+	db *sql.DB
+}
+
+// NewSQLiteChainRepo creates a new SQLite chain tracking repository
+func NewSQLiteChainRepo(db *sql.DB) *SQLiteChainRepo {
+	return &SQLiteChainRepo{db: db}
+}
+
+// ============================================================================
+// Block Tracking Methods
+// ============================================================================
+
+// SaveBlock records (or replaces) the canonical block hash at a height
+func (r *SQLiteChainRepo) SaveBlock(ctx context.Context, block *repository.BlockRecord) error {
+	query := `
+		INSERT INTO chain_blocks (chain_id, block_number, block_hash, parent_hash)
+		VALUES (?1, ?2, ?3, ?4)
+		ON CONFLICT (chain_id, block_number) DO UPDATE SET
+			block_hash = EXCLUDED.block_hash,
+			parent_hash = EXCLUDED.parent_hash,
+			created_at = ` + sqlNow + `
+		RETURNING created_at
+	`
+
+	err := r.db.QueryRowContext(ctx, query,
+		block.ChainID,
+		block.Number,
+		block.Hash,
+		block.ParentHash,
+	).Scan(&block.CreatedAt)
+
+	if err != nil {
+		return fmt.Errorf("saving block %d for chain %d: %w", block.Number, block.ChainID, err)
+	}
+
+	return nil
+}
+
+// GetBlock retrieves the recorded block at a height
+func (r *SQLiteChainRepo) GetBlock(ctx context.Context, chainID int64, number uint64) (*repository.BlockRecord, error) {
+	query := `
+		SELECT chain_id, block_number, block_hash, parent_hash, created_at
+		FROM chain_blocks
+		WHERE chain_id = ?1 AND block_number = ?2
+	`
+
+	b := &repository.BlockRecord{}
+	err := r.db.QueryRowContext(ctx, query, chainID, number).Scan(
+		&b.ChainID,
+		&b.Number,
+		&b.Hash,
+		&b.ParentHash,
+		&b.CreatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrBlockNotFound
+		}
+		return nil, fmt.Errorf("getting block %d for chain %d: %w", number, chainID, err)
+	}
+
+	return b, nil
+}
+
+// GetLatestBlock retrieves the highest recorded block for a chain
+func (r *SQLiteChainRepo) GetLatestBlock(ctx context.Context, chainID int64) (*repository.BlockRecord, error) {
+	query := `
+		SELECT chain_id, block_number, block_hash, parent_hash, created_at
+		FROM chain_blocks
+		WHERE chain_id = ?1
+		ORDER BY block_number DESC
+		LIMIT 1
+	`
+
+	b := &repository.BlockRecord{}
+	err := r.db.QueryRowContext(ctx, query, chainID).Scan(
+		&b.ChainID,
+		&b.Number,
+		&b.Hash,
+		&b.ParentHash,
+		&b.CreatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrBlockNotFound
+		}
+		return nil, fmt.Errorf("getting latest block for chain %d: %w", chainID, err)
+	}
+
+	return b, nil
+}
+
+// DeleteBlocksAbove removes recorded blocks above a height (orphaned by a reorg)
+func (r *SQLiteChainRepo) DeleteBlocksAbove(ctx context.Context, chainID int64, number uint64) (int64, error) {
+	result, err := r.db.ExecContext(ctx,
+		`DELETE FROM chain_blocks WHERE chain_id = ?1 AND block_number > ?2`,
+		chainID, number,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("deleting blocks above %d for chain %d: %w", number, chainID, err)
+	}
+
+	rows, _ := result.RowsAffected()
+	return rows, nil
+}
+
+// PruneBlocksBelow removes recorded blocks below a height (beyond the reorg window)
+func (r *SQLiteChainRepo) PruneBlocksBelow(ctx context.Context, chainID int64, number uint64) (int64, error) {
+	result, err := r.db.ExecContext(ctx,
+		`DELETE FROM chain_blocks WHERE chain_id = ?1 AND block_number < ?2`,
+		chainID, number,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("pruning blocks below %d for chain %d: %w", number, chainID, err)
+	}
+
+	rows, _ := result.RowsAffected()
+	return rows, nil
+}
+
+// ============================================================================
+// Tracked Transaction Methods
+// ============================================================================
+
+// TrackTx starts tracking a transaction for a record. Re-tracking the same
+// record and hash resets it to pending.
+func (r *SQLiteChainRepo) TrackTx(ctx context.Context, tx *repository.TrackedTx) error {
+	query := `
+		INSERT INTO chain_tx_tracking (chain_id, tx_hash, record_type, record_id, status)
+		VALUES (?1, ?2, ?3, ?4, 'pending')
+		ON CONFLICT (chain_id, tx_hash, record_type, record_id) DO UPDATE SET
+			status = 'pending',
+			block_number = NULL,
+			block_hash = NULL,
+			confirmations = 0,
+			finalized_at = NULL,
+			updated_at = ` + sqlNow + `
+		RETURNING id, status, created_at, updated_at
+	`
+
+	err := r.db.QueryRowContext(ctx, query,
+		tx.ChainID,
+		tx.TxHash,
+		tx.RecordType,
+		tx.RecordID,
+	).Scan(&tx.ID, &tx.Status, &tx.CreatedAt, &tx.UpdatedAt)
+
+	if err != nil {
+		return fmt.Errorf("tracking tx %s for %s %s: %w", tx.TxHash, tx.RecordType, tx.RecordID, err)
+	}
+
+	return nil
+}
+
+// ListUnfinalizedTxs lists tracked transactions that have not reached confirmation depth
+func (r *SQLiteChainRepo) ListUnfinalizedTxs(ctx context.Context, chainID int64, limit int) ([]*repository.TrackedTx, error) {
+	query := `
+		SELECT id, chain_id, tx_hash, record_type, record_id, status, block_number,
+		       block_hash, confirmations, reorg_count, created_at, updated_at, finalized_at
+		FROM chain_tx_tracking
+		WHERE chain_id = ?1 AND status IN ('pending', 'included')
+		ORDER BY created_at ASC
+		LIMIT ?2
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, chainID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("listing unfinalized txs for chain %d: %w", chainID, err)
+	}
+	defer rows.Close()
+
+	return r.scanTrackedTxRows(rows)
+}
+
+// UpdateTrackedTx updates the confirmation state of a tracked transaction
+func (r *SQLiteChainRepo) UpdateTrackedTx(ctx context.Context, id string, update *repository.TrackedTxUpdate) error {
+	// Build dynamic update query
+	query := "UPDATE chain_tx_tracking SET status = ?2, updated_at = " + sqlNow
+	args := []interface{}{id, update.Status}
+	argNum := 3
+
+	if update.ClearBlock {
+		query += ", block_number = NULL, block_hash = NULL, confirmations = 0"
+	} else {
+		if update.BlockNumber != nil {
+			query += fmt.Sprintf(", block_number = ?%d", argNum)
+			args = append(args, *update.BlockNumber)
+			argNum++
+		}
+		if update.BlockHash != nil {
+			query += fmt.Sprintf(", block_hash = ?%d", argNum)
+			args = append(args, *update.BlockHash)
+			argNum++
+		}
+		if update.Confirmations != nil {
+			query += fmt.Sprintf(", confirmations = ?%d", argNum)
+			args = append(args, *update.Confirmations)
+		}
+	}
+
+	switch update.Status {
+	case repository.TrackedTxStatusConfirmed, repository.TrackedTxStatusFailed:
+		query += ", finalized_at = " + sqlNow
+	default:
+		query += ", finalized_at = NULL"
+	}
+
+	query += " WHERE id = ?1"
+
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("updating tracked tx %s: %w", id, err)
+	}
+
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return repository.ErrTrackedTxNotFound
+	}
+
+	return nil
+}
+
+// RollbackTxsAbove resets transactions included above a height back to pending
+// and returns the affected records
+func (r *SQLiteChainRepo) RollbackTxsAbove(ctx context.Context, chainID int64, number uint64) ([]*repository.TrackedTx, error) {
+	query := `
+		UPDATE chain_tx_tracking
+		SET status = 'pending',
+		    block_number = NULL,
+		    block_hash = NULL,
+		    confirmations = 0,
+		    reorg_count = reorg_count + 1,
+		    finalized_at = NULL,
+		    updated_at = ` + sqlNow + `
+		WHERE chain_id = ?1 AND block_number > ?2
+		RETURNING id, chain_id, tx_hash, record_type, record_id, status, block_number,
+		          block_hash, confirmations, reorg_count, created_at, updated_at, finalized_at
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, chainID, number)
+	if err != nil {
+		return nil, fmt.Errorf("rolling back txs above %d for chain %d: %w", number, chainID, err)
+	}
+	defer rows.Close()
+
+	return r.scanTrackedTxRows(rows)
+}
+
+// scanTrackedTxRows is a helper to scan multiple tracked transaction rows
+func (r *SQLiteChainRepo) scanTrackedTxRows(rows *sql.Rows) ([]*repository.TrackedTx, error) {
+	var result []*repository.TrackedTx
+	for rows.Next() {
+		tx := &repository.TrackedTx{}
+		var (
+			blockNumber sql.NullInt64
+			blockHash   sql.NullString
+		)
+		err := rows.Scan(
+			&tx.ID,
+			&tx.ChainID,
+			&tx.TxHash,
+			&tx.RecordType,
+			&tx.RecordID,
+			&tx.Status,
+			&blockNumber,
+			&blockHash,
+			&tx.Confirmations,
+			&tx.ReorgCount,
+			&tx.CreatedAt,
+			&tx.UpdatedAt,
+			&tx.FinalizedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("scanning tracked tx row: %w", err)
+		}
+		if blockNumber.Valid {
+			n := uint64(blockNumber.Int64)
+			tx.BlockNumber = &n
+		}
+		if blockHash.Valid {
+			tx.BlockHash = &blockHash.String
+		}
+		result = append(result, tx)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating tracked tx rows: %w", err)
+	}
+
+	return result, nil
+}
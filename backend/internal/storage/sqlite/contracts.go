@@ -0,0 +1,804 @@
+// Package sqlite implements repository interfaces using SQLite
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure SQLiteContractRepo implements ContractRepository
+var _ repository.ContractRepository = (*SQLiteContractRepo)(nil)
+
+// SQLiteContractRepo implements ContractRepository using SQLite
+type SQLiteContractRepo struct {
+	db *sql.DB
+}
+
+// NewSQLiteContractRepo creates a new SQLite contract repository
+func NewSQLiteContractRepo(db *sql.DB) *SQLiteContractRepo {
+	return &SQLiteContractRepo{db: db}
+}
+
+// ============================================================================
+// Network Configuration Methods
+// ============================================================================
+
+// GetNetworkByChainID retrieves network configuration by chain ID
+func (r *SQLiteContractRepo) GetNetworkByChainID(ctx context.Context, chainID int64) (*repository.NetworkConfig, error) {
+	query := `
+		SELECT id, chain_id, network_name, display_name, rpc_url, rpc_fallback_urls,
+		       ws_url, explorer_url, default_deployer, is_testnet, is_active, created_at, updated_at
+		FROM network_config
+		WHERE chain_id = ?1
+	`
+
+	nc := &repository.NetworkConfig{}
+	err := r.db.QueryRowContext(ctx, query, chainID).Scan(
+		&nc.ID,
+		&nc.ChainID,
+		&nc.NetworkName,
+		&nc.DisplayName,
+		&nc.RPCUrl,
+		stringArray(&nc.RPCFallbackUrls),
+		&nc.WSUrl,
+		&nc.ExplorerUrl,
+		&nc.DefaultDeployer,
+		&nc.IsTestnet,
+		&nc.IsActive,
+		&nc.CreatedAt,
+		&nc.UpdatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrNetworkNotFound
+		}
+		return nil, fmt.Errorf("getting network config for chain %d: %w", chainID, err)
+	}
+
+	return nc, nil
+}
+
+// GetNetworkByName retrieves network configuration by network name
+func (r *SQLiteContractRepo) GetNetworkByName(ctx context.Context, name string) (*repository.NetworkConfig, error) {
+	query := `
+		SELECT id, chain_id, network_name, display_name, rpc_url, rpc_fallback_urls,
+		       ws_url, explorer_url, default_deployer, is_testnet, is_active, created_at, updated_at
+		FROM network_config
+		WHERE network_name = ?1
+	`
+
+	nc := &repository.NetworkConfig{}
+	err := r.db.QueryRowContext(ctx, query, name).Scan(
+		&nc.ID,
+		&nc.ChainID,
+		&nc.NetworkName,
+		&nc.DisplayName,
+		&nc.RPCUrl,
+		stringArray(&nc.RPCFallbackUrls),
+		&nc.WSUrl,
+		&nc.ExplorerUrl,
+		&nc.DefaultDeployer,
+		&nc.IsTestnet,
+		&nc.IsActive,
+		&nc.CreatedAt,
+		&nc.UpdatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrNetworkNotFound
+		}
+		return nil, fmt.Errorf("getting network config for %s: %w", name, err)
+	}
+
+	return nc, nil
+}
+
+// GetActiveNetworks retrieves all active network configurations
+func (r *SQLiteContractRepo) GetActiveNetworks(ctx context.Context) ([]*repository.NetworkConfig, error) {
+	query := `
+		SELECT id, chain_id, network_name, display_name, rpc_url, rpc_fallback_urls,
+		       ws_url, explorer_url, default_deployer, is_testnet, is_active, created_at, updated_at
+		FROM network_config
+		WHERE is_active = true
+		ORDER BY chain_id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("listing active networks: %w", err)
+	}
+	defer rows.Close()
+
+	var result []*repository.NetworkConfig
+	for rows.Next() {
+		nc := &repository.NetworkConfig{}
+		err := rows.Scan(
+			&nc.ID,
+			&nc.ChainID,
+			&nc.NetworkName,
+			&nc.DisplayName,
+			&nc.RPCUrl,
+			stringArray(&nc.RPCFallbackUrls),
+			&nc.WSUrl,
+			&nc.ExplorerUrl,
+			&nc.DefaultDeployer,
+			&nc.IsTestnet,
+			&nc.IsActive,
+			&nc.CreatedAt,
+			&nc.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("scanning network config row: %w", err)
+		}
+		result = append(result, nc)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating network config rows: %w", err)
+	}
+
+	return result, nil
+}
+
+// ============================================================================
+// Contract Mapping Methods
+// ============================================================================
+
+// GetAllMappings retrieves all contract name mappings from DB
+func (r *SQLiteContractRepo) GetAllMappings(ctx context.Context) ([]*repository.ContractMapping, error) {
+	query := `
+		SELECT id, solidity_name, db_name, display_name, category, description,
+		       is_required, sort_order, created_at
+		FROM contract_mappings
+		ORDER BY sort_order, solidity_name
+	`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("listing contract mappings: %w", err)
+	}
+	defer rows.Close()
+
+	var result []*repository.ContractMapping
+	for rows.Next() {
+		cm := &repository.ContractMapping{}
+		err := rows.Scan(
+			&cm.ID,
+			&cm.SolidityName,
+			&cm.DBName,
+			&cm.DisplayName,
+			&cm.Category,
+			&cm.Description,
+			&cm.IsRequired,
+			&cm.SortOrder,
+			&cm.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("scanning contract mapping row: %w", err)
+		}
+		result = append(result, cm)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating contract mapping rows: %w", err)
+	}
+
+	return result, nil
+}
+
+// GetMappingBySolidityName retrieves a contract mapping by Solidity contract name
+func (r *SQLiteContractRepo) GetMappingBySolidityName(ctx context.Context, name string) (*repository.ContractMapping, error) {
+	query := `
+		SELECT id, solidity_name, db_name, display_name, category, description,
+		       is_required, sort_order, created_at
+		FROM contract_mappings
+		WHERE solidity_name = ?1
+	`
+
+	cm := &repository.ContractMapping{}
+	err := r.db.QueryRowContext(ctx, query, name).Scan(
+		&cm.ID,
+		&cm.SolidityName,
+		&cm.DBName,
+		&cm.DisplayName,
+		&cm.Category,
+		&cm.Description,
+		&cm.IsRequired,
+		&cm.SortOrder,
+		&cm.CreatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrContractMappingNotFound
+		}
+		return nil, fmt.Errorf("getting contract mapping for %s: %w", name, err)
+	}
+
+	return cm, nil
+}
+
+// GetMappingByDBName retrieves a contract mapping by database name
+func (r *SQLiteContractRepo) GetMappingByDBName(ctx context.Context, dbName string) (*repository.ContractMapping, error) {
+	query := `
+		SELECT id, solidity_name, db_name, display_name, category, description,
+		       is_required, sort_order, created_at
+		FROM contract_mappings
+		WHERE db_name = ?1
+	`
+
+	cm := &repository.ContractMapping{}
+	err := r.db.QueryRowContext(ctx, query, dbName).Scan(
+		&cm.ID,
+		&cm.SolidityName,
+		&cm.DBName,
+		&cm.DisplayName,
+		&cm.Category,
+		&cm.Description,
+		&cm.IsRequired,
+		&cm.SortOrder,
+		&cm.CreatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrContractMappingNotFound
+		}
+		return nil, fmt.Errorf("getting contract mapping for db_name %s: %w", dbName, err)
+	}
+
+	return cm, nil
+}
+
+// ============================================================================
+// Contract Address Methods
+// ============================================================================
+
+// GetByChainID retrieves all contract addresses for a specific chain
+func (r *SQLiteContractRepo) GetByChainID(ctx context.Context, chainID int64) ([]*repository.ContractAddress, error) {
+	query := `
+		SELECT ca.id, ca.chain_id, ca.contract_mapping_id, cm.db_name, cm.solidity_name,
+		       ca.address, ca.deployment_tx_hash, ca.deployment_block, ca.abi_version,
+		       ca.status, ca.is_primary, ca.deployed_by, ca.notes, ca.eip712_name, ca.eip712_version, ca.created_at, ca.updated_at
+		FROM contract_addresses ca
+		JOIN contract_mappings cm ON ca.contract_mapping_id = cm.id
+		WHERE ca.chain_id = ?1 AND ca.status = 'active' AND ca.is_primary = true
+		ORDER BY cm.sort_order, cm.solidity_name
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, chainID)
+	if err != nil {
+		return nil, fmt.Errorf("listing contracts for chain %d: %w", chainID, err)
+	}
+	defer rows.Close()
+
+	var result []*repository.ContractAddress
+	for rows.Next() {
+		ca := &repository.ContractAddress{}
+		err := rows.Scan(
+			&ca.ID,
+			&ca.ChainID,
+			&ca.ContractMappingID,
+			&ca.DBName,
+			&ca.SolidityName,
+			&ca.Address,
+			&ca.DeploymentTxHash,
+			&ca.DeploymentBlock,
+			&ca.ABIVersion,
+			&ca.Status,
+			&ca.IsPrimary,
+			&ca.DeployedBy,
+			&ca.Notes,
+			&ca.EIP712Name,
+			&ca.EIP712Version,
+			&ca.CreatedAt,
+			&ca.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("scanning contract address row: %w", err)
+		}
+		result = append(result, ca)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating contract address rows: %w", err)
+	}
+
+	return result, nil
+}
+
+// GetByChainAndDBName retrieves a specific contract address by chain ID and db_name
+func (r *SQLiteContractRepo) GetByChainAndDBName(ctx context.Context, chainID int64, dbName string) (*repository.ContractAddress, error) {
+	query := `
+		SELECT ca.id, ca.chain_id, ca.contract_mapping_id, cm.db_name, cm.solidity_name,
+		       ca.address, ca.deployment_tx_hash, ca.deployment_block, ca.abi_version,
+		       ca.status, ca.is_primary, ca.deployed_by, ca.notes, ca.eip712_name, ca.eip712_version, ca.created_at, ca.updated_at
+		FROM contract_addresses ca
+		JOIN contract_mappings cm ON ca.contract_mapping_id = cm.id
+		WHERE ca.chain_id = ?1 AND cm.db_name = ?2 AND ca.status = 'active' AND ca.is_primary = true
+	`
+
+	ca := &repository.ContractAddress{}
+	err := r.db.QueryRowContext(ctx, query, chainID, dbName).Scan(
+		&ca.ID,
+		&ca.ChainID,
+		&ca.ContractMappingID,
+		&ca.DBName,
+		&ca.SolidityName,
+		&ca.Address,
+		&ca.DeploymentTxHash,
+		&ca.DeploymentBlock,
+		&ca.ABIVersion,
+		&ca.Status,
+		&ca.IsPrimary,
+		&ca.DeployedBy,
+		&ca.Notes,
+		&ca.EIP712Name,
+		&ca.EIP712Version,
+		&ca.CreatedAt,
+		&ca.UpdatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrContractAddressNotFound
+		}
+		return nil, fmt.Errorf("getting contract %s on chain %d: %w", dbName, chainID, err)
+	}
+
+	return ca, nil
+}
+
+// GetByID retrieves a contract address by its ID
+func (r *SQLiteContractRepo) GetByID(ctx context.Context, id string) (*repository.ContractAddress, error) {
+	query := `
+		SELECT ca.id, ca.chain_id, ca.contract_mapping_id, cm.db_name, cm.solidity_name,
+		       ca.address, ca.deployment_tx_hash, ca.deployment_block, ca.abi_version,
+		       ca.status, ca.is_primary, ca.deployed_by, ca.notes, ca.eip712_name, ca.eip712_version, ca.created_at, ca.updated_at
+		FROM contract_addresses ca
+		JOIN contract_mappings cm ON ca.contract_mapping_id = cm.id
+		WHERE ca.id = ?1
+	`
+
+	ca := &repository.ContractAddress{}
+	err := r.db.QueryRowContext(ctx, query, id).Scan(
+		&ca.ID,
+		&ca.ChainID,
+		&ca.ContractMappingID,
+		&ca.DBName,
+		&ca.SolidityName,
+		&ca.Address,
+		&ca.DeploymentTxHash,
+		&ca.DeploymentBlock,
+		&ca.ABIVersion,
+		&ca.Status,
+		&ca.IsPrimary,
+		&ca.DeployedBy,
+		&ca.Notes,
+		&ca.EIP712Name,
+		&ca.EIP712Version,
+		&ca.CreatedAt,
+		&ca.UpdatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrContractAddressNotFound
+		}
+		return nil, fmt.Errorf("getting contract by id %s: %w", id, err)
+	}
+
+	return ca, nil
+}
+
+// Upsert creates or updates a contract address
+// If a contract with the same chain_id + contract_mapping_id + is_primary=true exists,
+// it updates the address and logs history. Otherwise, it creates a new record.
+func (r *SQLiteContractRepo) Upsert(ctx context.Context, contract *repository.ContractAddressUpsert) (*repository.ContractAddress, error) {
+	// Start transaction
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Validate chain exists
+	var chainExists bool
+	err = tx.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM network_config WHERE chain_id = ?1)", contract.ChainID).Scan(&chainExists)
+	if err != nil {
+		return nil, fmt.Errorf("checking chain existence: %w", err)
+	}
+	if !chainExists {
+		return nil, repository.ErrNetworkNotFound
+	}
+
+	// Validate mapping exists
+	var mappingExists bool
+	err = tx.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM contract_mappings WHERE id = ?1)", contract.ContractMappingID).Scan(&mappingExists)
+	if err != nil {
+		return nil, fmt.Errorf("checking mapping existence: %w", err)
+	}
+	if !mappingExists {
+		return nil, repository.ErrContractMappingNotFound
+	}
+
+	// Get default deployer from network if not provided
+	deployedBy := contract.DeployedBy
+	if deployedBy == nil || *deployedBy == "" {
+		var defaultDeployer sql.NullString
+		err = tx.QueryRowContext(ctx, "SELECT default_deployer FROM network_config WHERE chain_id = ?1", contract.ChainID).Scan(&defaultDeployer)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("getting default deployer: %w", err)
+		}
+		if defaultDeployer.Valid {
+			deployedBy = &defaultDeployer.String
+		}
+	}
+
+	// Check if primary contract already exists for this chain+mapping
+	var existingID sql.NullString
+	var existingAddress sql.NullString
+	err = tx.QueryRowContext(ctx, `
+		SELECT id, address FROM contract_addresses
+		WHERE chain_id = ?1 AND contract_mapping_id = ?2 AND is_primary = true
+	`, contract.ChainID, contract.ContractMappingID).Scan(&existingID, &existingAddress)
+
+	abiVersion := "1.0.0"
+	if contract.ABIVersion != nil {
+		abiVersion = *contract.ABIVersion
+	}
+
+	var contractID string
+	if errors.Is(err, sql.ErrNoRows) {
+		// INSERT new contract
+		insertQuery := `
+			INSERT INTO contract_addresses (
+				chain_id, contract_mapping_id, address, deployment_tx_hash,
+				deployment_block, abi_version, deployed_by, notes, eip712_name, eip712_version,
+				status, is_primary
+			) VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7, ?8, ?9, ?10, 'active', true)
+			RETURNING id
+		`
+		err = tx.QueryRowContext(ctx, insertQuery,
+			contract.ChainID,
+			contract.ContractMappingID,
+			contract.Address,
+			contract.DeploymentTxHash,
+			contract.DeploymentBlock,
+			abiVersion,
+			deployedBy,
+			contract.Notes,
+			contract.EIP712Name,
+			contract.EIP712Version,
+		).Scan(&contractID)
+		if err != nil {
+			return nil, fmt.Errorf("inserting contract address: %w", err)
+		}
+
+		// Log initial deployment in history
+		historyQuery := `
+			INSERT INTO contract_addresses_history (contract_id, old_address, new_address, change_reason, changed_by)
+			VALUES (?1, NULL, ?2, 'Initial deployment', ?3)
+		`
+		changedBy := "unknown"
+		if deployedBy != nil {
+			changedBy = *deployedBy
+		}
+		_, err = tx.ExecContext(ctx, historyQuery, contractID, contract.Address, changedBy)
+		if err != nil {
+			return nil, fmt.Errorf("logging deployment history: %w", err)
+		}
+	} else if err != nil {
+		return nil, fmt.Errorf("checking existing contract: %w", err)
+	} else {
+		// UPDATE existing contract
+		contractID = existingID.String
+		oldAddress := existingAddress.String
+
+		updateQuery := `
+			UPDATE contract_addresses
+			SET address = ?1, deployment_tx_hash = ?2, deployment_block = ?3,
+			    abi_version = ?4, deployed_by = ?5, notes = ?6,
+			    eip712_name = ?7, eip712_version = ?8, updated_at = ` + sqlNow + `
+			WHERE id = ?9
+		`
+		_, err = tx.ExecContext(ctx, updateQuery,
+			contract.Address,
+			contract.DeploymentTxHash,
+			contract.DeploymentBlock,
+			abiVersion,
+			deployedBy,
+			contract.Notes,
+			contract.EIP712Name,
+			contract.EIP712Version,
+			contractID,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("updating contract address: %w", err)
+		}
+
+		// Log update in history (if address changed)
+		if oldAddress != contract.Address {
+			historyQuery := `
+				INSERT INTO contract_addresses_history (contract_id, old_address, new_address, change_reason, changed_by)
+				VALUES (?1, ?2, ?3, 'Contract redeployed', ?4)
+			`
+			changedBy := "unknown"
+			if deployedBy != nil {
+				changedBy = *deployedBy
+			}
+			_, err = tx.ExecContext(ctx, historyQuery, contractID, oldAddress, contract.Address, changedBy)
+			if err != nil {
+				return nil, fmt.Errorf("logging address change history: %w", err)
+			}
+		}
+	}
+
+	// Commit transaction
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("committing transaction: %w", err)
+	}
+
+	// Fetch and return the complete contract record
+	return r.GetByID(ctx, contractID)
+}
+
+// ============================================================================
+// History Methods
+// ============================================================================
+
+// GetHistory retrieves deployment history for a contract
+func (r *SQLiteContractRepo) GetHistory(ctx context.Context, contractID string, limit int) ([]*repository.ContractAddressHistory, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+
+	query := `
+		SELECT id, contract_id, old_address, new_address, change_reason, changed_by, changed_at
+		FROM contract_addresses_history
+		WHERE contract_id = ?1
+		ORDER BY changed_at DESC
+		LIMIT ?2
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, contractID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("getting contract history: %w", err)
+	}
+	defer rows.Close()
+
+	var result []*repository.ContractAddressHistory
+	for rows.Next() {
+		h := &repository.ContractAddressHistory{}
+		err := rows.Scan(
+			&h.ID,
+			&h.ContractID,
+			&h.OldAddress,
+			&h.NewAddress,
+			&h.ChangeReason,
+			&h.ChangedBy,
+			&h.ChangedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("scanning history row: %w", err)
+		}
+		result = append(result, h)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating history rows: %w", err)
+	}
+
+	return result, nil
+}
+
+// ============================================================================
+// Deployment Config Method
+// ============================================================================
+
+// GetDeploymentConfig returns all config needed for deployment scripts
+func (r *SQLiteContractRepo) GetDeploymentConfig(ctx context.Context, chainID int64) (*repository.DeploymentConfig, error) {
+	// 1. Get network config (includes default_deployer)
+	network, err := r.GetNetworkByChainID(ctx, chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Get all contract mappings (Solidity→DB name)
+	mappings, err := r.GetAllMappings(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. Get existing contracts for this chain
+	contracts, err := r.GetByChainID(ctx, chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &repository.DeploymentConfig{
+		Network:   network,
+		Mappings:  mappings,
+		Contracts: contracts,
+	}, nil
+}
+
+// ============================================================================
+// ABI Artifact Methods
+// ============================================================================
+
+// UpsertABI publishes an ABI for a contract mapping and version. An existing
+// version is returned unchanged when its ABI hash matches, and refused with
+// repository.ErrABIVersionConflict otherwise.
+func (r *SQLiteContractRepo) UpsertABI(ctx context.Context, artifact *repository.ABIArtifactUpsert) (*repository.ABIArtifact, error) {
+	mapping, err := r.GetMappingByDBName(ctx, artifact.DBName)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		INSERT INTO abi_artifacts (
+			contract_mapping_id, version, abi, abi_hash, compiler_version, uploaded_by
+		) VALUES (?1, ?2, ?3, ?4, ?5, ?6)
+		ON CONFLICT (contract_mapping_id, version) DO NOTHING
+		RETURNING id, created_at, updated_at
+	`
+
+	result := &repository.ABIArtifact{
+		ContractMappingID: mapping.ID,
+		DBName:            mapping.DBName,
+		SolidityName:      mapping.SolidityName,
+		Version:           artifact.Version,
+		ABI:               artifact.ABI,
+		ABIHash:           artifact.ABIHash,
+		CompilerVersion:   artifact.CompilerVersion,
+		UploadedBy:        artifact.UploadedBy,
+	}
+
+	err = r.db.QueryRowContext(ctx, query,
+		mapping.ID,
+		artifact.Version,
+		string(artifact.ABI),
+		artifact.ABIHash,
+		artifact.CompilerVersion,
+		artifact.UploadedBy,
+	).Scan(&result.ID, &result.CreatedAt, &result.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return r.publishedABI(ctx, result)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("upserting abi for %s@%s: %w", artifact.DBName, artifact.Version, err)
+	}
+
+	return result, nil
+}
+
+// publishedABI returns the stored artifact of upload's version if its ABI
+// hash matches upload's
+func (r *SQLiteContractRepo) publishedABI(ctx context.Context, upload *repository.ABIArtifact) (*repository.ABIArtifact, error) {
+	query := `
+		SELECT id, abi, abi_hash, compiler_version, uploaded_by, created_at, updated_at
+		FROM abi_artifacts
+		WHERE contract_mapping_id = ?1 AND version = ?2
+	`
+
+	stored := *upload
+	var abiJSON []byte
+	err := r.db.QueryRowContext(ctx, query, upload.ContractMappingID, upload.Version).Scan(
+		&stored.ID,
+		&abiJSON,
+		&stored.ABIHash,
+		&stored.CompilerVersion,
+		&stored.UploadedBy,
+		&stored.CreatedAt,
+		&stored.UpdatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("getting published abi for %s@%s: %w", upload.DBName, upload.Version, err)
+	}
+	if stored.ABIHash != upload.ABIHash {
+		return nil, repository.ErrABIVersionConflict
+	}
+
+	stored.ABI = abiJSON
+	return &stored, nil
+}
+
+// GetABI retrieves the ABI for a contract by db_name and version.
+// Passing repository.ABIVersionLatest returns the most recent upload.
+func (r *SQLiteContractRepo) GetABI(ctx context.Context, dbName, version string) (*repository.ABIArtifact, error) {
+	query := `
+		SELECT aa.id, aa.contract_mapping_id, cm.db_name, cm.solidity_name, aa.version,
+		       aa.abi, aa.abi_hash, aa.compiler_version, aa.uploaded_by, aa.created_at, aa.updated_at
+		FROM abi_artifacts aa
+		JOIN contract_mappings cm ON aa.contract_mapping_id = cm.id
+		WHERE cm.db_name = ?1
+	`
+	args := []interface{}{dbName}
+
+	if version == repository.ABIVersionLatest {
+		// rowid orders uploads within the timestamps' millisecond
+		query += " ORDER BY aa.created_at DESC, aa.rowid DESC LIMIT 1"
+	} else {
+		query += " AND aa.version = ?2"
+		args = append(args, version)
+	}
+
+	aa := &repository.ABIArtifact{}
+	var abiJSON []byte
+	err := r.db.QueryRowContext(ctx, query, args...).Scan(
+		&aa.ID,
+		&aa.ContractMappingID,
+		&aa.DBName,
+		&aa.SolidityName,
+		&aa.Version,
+		&abiJSON,
+		&aa.ABIHash,
+		&aa.CompilerVersion,
+		&aa.UploadedBy,
+		&aa.CreatedAt,
+		&aa.UpdatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrABIArtifactNotFound
+		}
+		return nil, fmt.Errorf("getting abi for %s@%s: %w", dbName, version, err)
+	}
+
+	aa.ABI = abiJSON
+	return aa, nil
+}
+
+// ListABIVersions lists the stored ABI versions for a contract (newest first).
+// The ABI body is not included; fetch a specific version with GetABI.
+func (r *SQLiteContractRepo) ListABIVersions(ctx context.Context, dbName string) ([]*repository.ABIArtifact, error) {
+	query := `
+		SELECT aa.id, aa.contract_mapping_id, cm.db_name, cm.solidity_name, aa.version,
+		       aa.abi_hash, aa.compiler_version, aa.uploaded_by, aa.created_at, aa.updated_at
+		FROM abi_artifacts aa
+		JOIN contract_mappings cm ON aa.contract_mapping_id = cm.id
+		WHERE cm.db_name = ?1
+		ORDER BY aa.created_at DESC, aa.rowid DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, dbName)
+	if err != nil {
+		return nil, fmt.Errorf("listing abi versions for %s: %w", dbName, err)
+	}
+	defer rows.Close()
+
+	var result []*repository.ABIArtifact
+	for rows.Next() {
+		aa := &repository.ABIArtifact{}
+		err := rows.Scan(
+			&aa.ID,
+			&aa.ContractMappingID,
+			&aa.DBName,
+			&aa.SolidityName,
+			&aa.Version,
+			&aa.ABIHash,
+			&aa.CompilerVersion,
+			&aa.UploadedBy,
+			&aa.CreatedAt,
+			&aa.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("scanning abi artifact row: %w", err)
+		}
+		result = append(result, aa)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating abi artifact rows: %w", err)
+	}
+
+	return result, nil
+}
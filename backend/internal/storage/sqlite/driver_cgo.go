@@ -0,0 +1,15 @@
+//go:build sqlite && cgo
+
+package sqlite
+
+import (
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// driverName is the database/sql driver registered by mattn/go-sqlite3
+const driverName = "sqlite3"
+
+// dsn builds the connection string for a database file
+func dsn(path string) string {
+	return "file:" + path + "?_foreign_keys=on&_busy_timeout=5000&_journal_mode=WAL&_txlock=immediate"
+}
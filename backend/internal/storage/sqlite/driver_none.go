@@ -0,0 +1,12 @@
+//go:build !sqlite
+
+package sqlite
+
+// driverName is empty when no SQLite driver is compiled in; Open returns
+// ErrDriverUnavailable
+const driverName = ""
+
+// dsn is unused without a driver
+func dsn(path string) string {
+	return path
+}
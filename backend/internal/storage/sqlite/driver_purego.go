@@ -0,0 +1,16 @@
+//go:build sqlite && !cgo
+
+package sqlite
+
+import (
+	_ "modernc.org/sqlite"
+)
+
+// driverName is the database/sql driver registered by modernc.org/sqlite
+// (pure Go, used when cgo is disabled)
+const driverName = "sqlite"
+
+// dsn builds the connection string for a database file
+func dsn(path string) string {
+	return "file:" + path + "?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)&_txlock=immediate"
+}
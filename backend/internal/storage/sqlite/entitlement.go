@@ -0,0 +1,129 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure SQLiteEntitlementRepo implements EntitlementRepository
+var _ repository.EntitlementRepository = (*SQLiteEntitlementRepo)(nil)
+
+// SQLiteEntitlementRepo implements EntitlementRepository using SQLite
+type SQLiteEntitlementRepo struct {
+	db *sql.DB
+}
+
+// NewSQLiteEntitlementRepo creates a new SQLite entitlement repository
+func NewSQLiteEntitlementRepo(db *sql.DB) *SQLiteEntitlementRepo {
+	return &SQLiteEntitlementRepo{db: db}
+}
+
+// entitlementColumns is the column list read by every entitlement query
+const entitlementColumns = `address, service_code, granted, balance, updated_at`
+
+// GrantCredits records the payment's grant and tops up the balance in one
+// transaction, so a replayed grant adds nothing
+func (r *SQLiteEntitlementRepo) GrantCredits(ctx context.Context, g *repository.EntitlementGrant) (bool, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("beginning credit grant: %w", err)
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO entitlement_grants (id, payment_id, address, service_code, quantity)
+		VALUES (?1, ?2, ?3, ?4, ?5)
+		ON CONFLICT (payment_id) DO NOTHING
+		RETURNING id, created_at
+	`, newID(), g.PaymentID, g.Address, g.ServiceCode, g.Quantity).Scan(&g.ID, &g.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("recording credit grant of payment %s: %w", g.PaymentID, err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO entitlements (address, service_code, granted, balance)
+		VALUES (?1, ?2, ?3, ?3)
+		ON CONFLICT (address, service_code) DO UPDATE
+		SET granted = entitlements.granted + EXCLUDED.granted,
+		    balance = entitlements.balance + EXCLUDED.balance,
+		    updated_at = `+sqlNow+`
+	`, g.Address, g.ServiceCode, g.Quantity)
+	if err != nil {
+		return false, fmt.Errorf("crediting %s with %s: %w", g.Address, g.ServiceCode, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("committing credit grant: %w", err)
+	}
+	return true, nil
+}
+
+// ConsumeCredits decrements the balance only where it covers quantity
+func (r *SQLiteEntitlementRepo) ConsumeCredits(ctx context.Context, address, serviceCode string, quantity int) (*repository.Entitlement, error) {
+	query := `
+		UPDATE entitlements
+		SET balance = balance - ?3, updated_at = ` + sqlNow + `
+		WHERE address = ?1 AND service_code = ?2 AND balance >= ?3
+		RETURNING ` + entitlementColumns
+
+	e, err := scanEntitlement(r.db.QueryRowContext(ctx, query, address, serviceCode, quantity))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrInsufficientCredits
+		}
+		return nil, fmt.Errorf("consuming %s credits of %s: %w", serviceCode, address, err)
+	}
+	return e, nil
+}
+
+// GetEntitlement retrieves an address's credits for a service
+func (r *SQLiteEntitlementRepo) GetEntitlement(ctx context.Context, address, serviceCode string) (*repository.Entitlement, error) {
+	query := `SELECT ` + entitlementColumns + ` FROM entitlements WHERE address = ?1 AND service_code = ?2`
+
+	e, err := scanEntitlement(r.db.QueryRowContext(ctx, query, address, serviceCode))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrEntitlementNotFound
+		}
+		return nil, fmt.Errorf("getting %s credits of %s: %w", serviceCode, address, err)
+	}
+	return e, nil
+}
+
+// ListEntitlements lists an address's credits by service code
+func (r *SQLiteEntitlementRepo) ListEntitlements(ctx context.Context, address string) ([]*repository.Entitlement, error) {
+	query := `SELECT ` + entitlementColumns + ` FROM entitlements WHERE address = ?1 ORDER BY service_code`
+
+	rows, err := r.db.QueryContext(ctx, query, address)
+	if err != nil {
+		return nil, fmt.Errorf("listing credits of %s: %w", address, err)
+	}
+	defer rows.Close()
+
+	var result []*repository.Entitlement
+	for rows.Next() {
+		e, err := scanEntitlement(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning entitlement row: %w", err)
+		}
+		result = append(result, e)
+	}
+
+	return result, rows.Err()
+}
+
+// scanEntitlement scans an entitlements row
+func scanEntitlement(row rowScanner) (*repository.Entitlement, error) {
+	e := &repository.Entitlement{}
+	if err := row.Scan(&e.Address, &e.ServiceCode, &e.Granted, &e.Balance, &e.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
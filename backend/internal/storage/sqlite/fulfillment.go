@@ -0,0 +1,147 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure SQLiteFulfillmentRepo implements FulfillmentRepository
+var _ repository.FulfillmentRepository = (*SQLiteFulfillmentRepo)(nil)
+
+// SQLiteFulfillmentRepo implements FulfillmentRepository using SQLite
+type SQLiteFulfillmentRepo struct {
+	db *sql.DB
+}
+
+// NewSQLiteFulfillmentRepo creates a new SQLite fulfillment repository
+func NewSQLiteFulfillmentRepo(db *sql.DB) *SQLiteFulfillmentRepo {
+	return &SQLiteFulfillmentRepo{db: db}
+}
+
+// fulfillmentColumns is the column list read by every fulfillment query
+const fulfillmentColumns = `id, payment_id, service_code, address, kind, quantity, valid_from, valid_until, reference, created_at`
+
+// RecordFulfillment stores a payment's fulfillment once
+func (r *SQLiteFulfillmentRepo) RecordFulfillment(ctx context.Context, f *repository.Fulfillment) (bool, error) {
+	query := `
+		INSERT INTO service_fulfillments (id, payment_id, service_code, address, kind, quantity, valid_from, valid_until, reference)
+		VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7, ?8, ?9)
+		ON CONFLICT (payment_id) DO NOTHING
+		RETURNING id, created_at
+	`
+
+	var validFrom, validUntil interface{}
+	if f.ValidFrom != nil {
+		validFrom = timeArg(*f.ValidFrom)
+	}
+	if f.ValidUntil != nil {
+		validUntil = timeArg(*f.ValidUntil)
+	}
+	err := r.db.QueryRowContext(ctx, query,
+		newID(), f.PaymentID, f.ServiceCode, f.Address, f.Kind, f.Quantity, validFrom, validUntil, f.Reference,
+	).Scan(&f.ID, &f.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("recording fulfillment of payment %s: %w", f.PaymentID, err)
+	}
+	return true, nil
+}
+
+// GetFulfillmentByPayment retrieves a payment's fulfillment
+func (r *SQLiteFulfillmentRepo) GetFulfillmentByPayment(ctx context.Context, paymentID string) (*repository.Fulfillment, error) {
+	query := `SELECT ` + fulfillmentColumns + ` FROM service_fulfillments WHERE payment_id = ?1`
+
+	f, err := scanFulfillment(r.db.QueryRowContext(ctx, query, paymentID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrFulfillmentNotFound
+		}
+		return nil, fmt.Errorf("getting fulfillment of payment %s: %w", paymentID, err)
+	}
+	return f, nil
+}
+
+// ListFulfillments lists fulfillments with filtering, newest first
+func (r *SQLiteFulfillmentRepo) ListFulfillments(ctx context.Context, filter repository.FulfillmentFilter, page repository.Pagination) ([]*repository.Fulfillment, int64, error) {
+	whereClause := "WHERE 1=1"
+	args := []interface{}{}
+	argNum := 1
+
+	if filter.Address != "" {
+		whereClause += fmt.Sprintf(" AND address = ?%d", argNum)
+		args = append(args, filter.Address)
+		argNum++
+	}
+	if filter.ServiceCode != "" {
+		whereClause += fmt.Sprintf(" AND service_code = ?%d", argNum)
+		args = append(args, filter.ServiceCode)
+		argNum++
+	}
+	if filter.Kind != "" {
+		whereClause += fmt.Sprintf(" AND kind = ?%d", argNum)
+		args = append(args, filter.Kind)
+		argNum++
+	}
+	if filter.ActiveAt != nil {
+		whereClause += fmt.Sprintf(" AND (valid_from IS NULL OR valid_from <= ?%d) AND (valid_until IS NULL OR valid_until > ?%d)", argNum, argNum)
+		args = append(args, timeArg(*filter.ActiveAt))
+		argNum++
+	}
+
+	var total int64
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM service_fulfillments "+whereClause, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("counting fulfillments: %w", err)
+	}
+
+	if page.PageSize <= 0 {
+		page.PageSize = 20
+	}
+	if page.Page <= 0 {
+		page.Page = 1
+	}
+	offset := (page.Page - 1) * page.PageSize
+
+	query := fmt.Sprintf(`
+		SELECT `+fulfillmentColumns+`
+		FROM service_fulfillments
+		%s
+		ORDER BY created_at DESC
+		LIMIT ?%d OFFSET ?%d
+	`, whereClause, argNum, argNum+1)
+
+	args = append(args, page.PageSize, offset)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("listing fulfillments: %w", err)
+	}
+	defer rows.Close()
+
+	var result []*repository.Fulfillment
+	for rows.Next() {
+		f, err := scanFulfillment(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("scanning fulfillment row: %w", err)
+		}
+		result = append(result, f)
+	}
+
+	return result, total, rows.Err()
+}
+
+// scanFulfillment scans a service_fulfillments row
+func scanFulfillment(row rowScanner) (*repository.Fulfillment, error) {
+	f := &repository.Fulfillment{}
+	err := row.Scan(&f.ID, &f.PaymentID, &f.ServiceCode, &f.Address, &f.Kind, &f.Quantity,
+		&f.ValidFrom, &f.ValidUntil, &f.Reference, &f.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return f, nil
+}
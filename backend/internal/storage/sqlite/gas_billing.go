@@ -0,0 +1,344 @@
+// Package sqlite implements repository interfaces using SQLite
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure SQLiteGasBillingRepo implements GasBillingRepository
+var _ repository.GasBillingRepository = (*SQLiteGasBillingRepo)(nil)
+
+// SQLiteGasBillingRepo implements GasBillingRepository using SQLite. Amounts
+// overflow SQLite's integers, so debts are summed in Go.
+type SQLiteGasBillingRepo struct {
+	db *sql.DB
+}
+
+// NewSQLiteGasBillingRepo creates a new SQLite gas billing repository
+func NewSQLiteGasBillingRepo(db *sql.DB) *SQLiteGasBillingRepo {
+	return &SQLiteGasBillingRepo{db: db}
+}
+
+// gasChargeColumns is the column list read by every gas charge query
+const gasChargeColumns = `id, meta_tx_id, user_address, gas_used, gas_price, cost_wei,
+		       nexus_per_eth, markup_percent, amount_nexus, created_at`
+
+// gasSettlementColumns is the column list read by every gas settlement query
+const gasSettlementColumns = `id, user_address, method, amount_nexus, status, tx_hash,
+		       token_address, treasury_address, invoice_ref, error_message, created_by,
+		       created_at, updated_at, settled_at`
+
+// CreateGasCharge records a meta-transaction's charge once
+func (r *SQLiteGasBillingRepo) CreateGasCharge(ctx context.Context, c *repository.GasCharge) (bool, error) {
+	query := `
+		INSERT INTO gas_charges (meta_tx_id, user_address, gas_used, gas_price, cost_wei,
+		                         nexus_per_eth, markup_percent, amount_nexus)
+		VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7, ?8)
+		ON CONFLICT (meta_tx_id) DO NOTHING
+		RETURNING id, created_at
+	`
+
+	err := r.db.QueryRowContext(ctx, query,
+		c.MetaTxID, c.UserAddress, c.GasUsed, c.GasPrice, c.CostWei,
+		c.NexusPerETH, c.MarkupPercent, c.AmountNexus,
+	).Scan(&c.ID, &c.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("creating gas charge: %w", err)
+	}
+	return true, nil
+}
+
+// ListGasCharges lists a user's charges, newest first
+func (r *SQLiteGasBillingRepo) ListGasCharges(ctx context.Context, userAddress string, page repository.Pagination) ([]*repository.GasCharge, int64, error) {
+	var total int64
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM gas_charges WHERE user_address = ?1", userAddress).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("counting gas charges: %w", err)
+	}
+
+	if page.PageSize <= 0 {
+		page.PageSize = 20
+	}
+	if page.Page <= 0 {
+		page.Page = 1
+	}
+	offset := (page.Page - 1) * page.PageSize
+
+	query := `
+		SELECT ` + gasChargeColumns + `
+		FROM gas_charges
+		WHERE user_address = ?1
+		ORDER BY created_at DESC
+		LIMIT ?2 OFFSET ?3
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userAddress, page.PageSize, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("listing gas charges: %w", err)
+	}
+	defer rows.Close()
+
+	var result []*repository.GasCharge
+	for rows.Next() {
+		c := &repository.GasCharge{}
+		if err := rows.Scan(
+			&c.ID, &c.MetaTxID, &c.UserAddress, &c.GasUsed, &c.GasPrice, &c.CostWei,
+			&c.NexusPerETH, &c.MarkupPercent, &c.AmountNexus, &c.CreatedAt,
+		); err != nil {
+			return nil, 0, fmt.Errorf("scanning gas charge row: %w", err)
+		}
+		result = append(result, c)
+	}
+
+	return result, total, rows.Err()
+}
+
+// CreateGasSettlement records a settlement, refusing a tx hash used before
+func (r *SQLiteGasBillingRepo) CreateGasSettlement(ctx context.Context, s *repository.GasSettlement) error {
+	query := `
+		INSERT INTO gas_settlements (user_address, method, amount_nexus, status, tx_hash,
+		                             token_address, treasury_address, invoice_ref, created_by)
+		VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7, ?8, ?9)
+		ON CONFLICT (tx_hash) DO NOTHING
+		RETURNING id, created_at, updated_at
+	`
+
+	err := r.db.QueryRowContext(ctx, query,
+		s.UserAddress, s.Method, s.AmountNexus, s.Status, s.TxHash,
+		s.TokenAddress, s.TreasuryAddress, s.InvoiceRef, s.CreatedBy,
+	).Scan(&s.ID, &s.CreatedAt, &s.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return repository.ErrGasSettlementExists
+	}
+	if err != nil {
+		return fmt.Errorf("creating gas settlement: %w", err)
+	}
+	return nil
+}
+
+// GetGasSettlement retrieves a settlement by ID
+func (r *SQLiteGasBillingRepo) GetGasSettlement(ctx context.Context, id string) (*repository.GasSettlement, error) {
+	query := `SELECT ` + gasSettlementColumns + ` FROM gas_settlements WHERE id = ?1`
+
+	s, err := scanGasSettlement(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrGasSettlementNotFound
+		}
+		return nil, fmt.Errorf("getting gas settlement %s: %w", id, err)
+	}
+
+	return s, nil
+}
+
+// ListGasSettlements lists settlements with filtering, newest first
+func (r *SQLiteGasBillingRepo) ListGasSettlements(ctx context.Context, filter repository.GasSettlementFilter, page repository.Pagination) ([]*repository.GasSettlement, int64, error) {
+	whereClause := "WHERE 1=1"
+	args := []interface{}{}
+	argNum := 1
+
+	if filter.UserAddress != "" {
+		whereClause += fmt.Sprintf(" AND user_address = ?%d", argNum)
+		args = append(args, filter.UserAddress)
+		argNum++
+	}
+	if filter.Method != "" {
+		whereClause += fmt.Sprintf(" AND method = ?%d", argNum)
+		args = append(args, filter.Method)
+		argNum++
+	}
+	if filter.Status != "" {
+		whereClause += fmt.Sprintf(" AND status = ?%d", argNum)
+		args = append(args, filter.Status)
+		argNum++
+	}
+
+	var total int64
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM gas_settlements "+whereClause, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("counting gas settlements: %w", err)
+	}
+
+	if page.PageSize <= 0 {
+		page.PageSize = 20
+	}
+	if page.Page <= 0 {
+		page.Page = 1
+	}
+	offset := (page.Page - 1) * page.PageSize
+
+	query := fmt.Sprintf(`
+		SELECT `+gasSettlementColumns+`
+		FROM gas_settlements
+		%s
+		ORDER BY created_at DESC
+		LIMIT ?%d OFFSET ?%d
+	`, whereClause, argNum, argNum+1)
+
+	args = append(args, page.PageSize, offset)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("listing gas settlements: %w", err)
+	}
+	defer rows.Close()
+
+	var result []*repository.GasSettlement
+	for rows.Next() {
+		s, err := scanGasSettlement(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("scanning gas settlement row: %w", err)
+		}
+		result = append(result, s)
+	}
+
+	return result, total, rows.Err()
+}
+
+// UpdateGasSettlementStatus applies an allowed status change
+func (r *SQLiteGasBillingRepo) UpdateGasSettlementStatus(ctx context.Context, id string, update *repository.GasSettlementUpdate) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginning gas settlement update: %w", err)
+	}
+	defer tx.Rollback()
+
+	current, err := scanGasSettlement(tx.QueryRowContext(ctx,
+		`SELECT `+gasSettlementColumns+` FROM gas_settlements WHERE id = ?1`, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return repository.ErrGasSettlementNotFound
+		}
+		return fmt.Errorf("getting gas settlement %s: %w", id, err)
+	}
+	if !current.CanMoveTo(update.Status) {
+		return repository.ErrGasSettlementClosed
+	}
+
+	query := `
+		UPDATE gas_settlements
+		SET status = ?2, error_message = ?3, updated_at = ` + sqlNow + `,
+		    settled_at = CASE WHEN ?2 = 'settled' THEN ` + sqlNow + ` END
+		WHERE id = ?1
+	`
+	if _, err := tx.ExecContext(ctx, query, id, update.Status, update.ErrorMessage); err != nil {
+		return fmt.Errorf("updating gas settlement %s: %w", id, err)
+	}
+
+	return tx.Commit()
+}
+
+// GetGasDebt totals a user's charges and settlements
+func (r *SQLiteGasBillingRepo) GetGasDebt(ctx context.Context, userAddress string) (*repository.GasDebt, error) {
+	tally, err := r.tally(ctx, "WHERE user_address = ?1", userAddress)
+	if err != nil {
+		return nil, err
+	}
+	return tally.Debt(userAddress), nil
+}
+
+// ListGasDebts lists users with outstanding debt, largest first
+func (r *SQLiteGasBillingRepo) ListGasDebts(ctx context.Context, page repository.Pagination) ([]*repository.GasDebt, int64, error) {
+	tally, err := r.tally(ctx, "")
+	if err != nil {
+		return nil, 0, err
+	}
+
+	debts := tally.Outstanding()
+	total := int64(len(debts))
+
+	if page.PageSize <= 0 {
+		page.PageSize = 50
+	}
+	if page.Page <= 0 {
+		page.Page = 1
+	}
+	offset := (page.Page - 1) * page.PageSize
+	if offset >= len(debts) {
+		return nil, total, nil
+	}
+	end := offset + page.PageSize
+	if end > len(debts) {
+		end = len(debts)
+	}
+	return debts[offset:end], total, nil
+}
+
+// tally loads the charges and settlements matching whereClause
+func (r *SQLiteGasBillingRepo) tally(ctx context.Context, whereClause string, args ...interface{}) (*repository.GasDebtTally, error) {
+	tally := repository.NewGasDebtTally()
+
+	rows, err := r.db.QueryContext(ctx, "SELECT user_address, amount_nexus, created_at FROM gas_charges "+whereClause, args...)
+	if err != nil {
+		return nil, fmt.Errorf("totalling gas charges: %w", err)
+	}
+	for rows.Next() {
+		var user, amount string
+		var createdAt time.Time
+		if err := rows.Scan(&user, &amount, &createdAt); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("scanning gas charge row: %w", err)
+		}
+		if err := tally.AddCharge(user, amount, createdAt); err != nil {
+			rows.Close()
+			return nil, err
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("totalling gas charges: %w", err)
+	}
+
+	rows, err = r.db.QueryContext(ctx, "SELECT user_address, amount_nexus, status FROM gas_settlements "+whereClause, args...)
+	if err != nil {
+		return nil, fmt.Errorf("totalling gas settlements: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var user, amount string
+		var status repository.GasSettlementStatus
+		if err := rows.Scan(&user, &amount, &status); err != nil {
+			return nil, fmt.Errorf("scanning gas settlement row: %w", err)
+		}
+		if err := tally.AddSettlement(user, amount, status); err != nil {
+			return nil, err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("totalling gas settlements: %w", err)
+	}
+
+	return tally, nil
+}
+
+// scanGasSettlement scans a gas_settlements row
+func scanGasSettlement(row rowScanner) (*repository.GasSettlement, error) {
+	s := &repository.GasSettlement{}
+	err := row.Scan(
+		&s.ID,
+		&s.UserAddress,
+		&s.Method,
+		&s.AmountNexus,
+		&s.Status,
+		&s.TxHash,
+		&s.TokenAddress,
+		&s.TreasuryAddress,
+		&s.InvoiceRef,
+		&s.ErrorMessage,
+		&s.CreatedBy,
+		&s.CreatedAt,
+		&s.UpdatedAt,
+		&s.SettledAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
@@ -0,0 +1,91 @@
+// Package sqlite implements repository interfaces using SQLite
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure SQLiteKPIRepo implements KPIRepository
+var _ repository.KPIRepository = (*SQLiteKPIRepo)(nil)
+
+// SQLiteKPIRepo implements KPIRepository using SQLite. The SQLite schema has
+// no NFT tables, so NFTMints is always nil.
+type SQLiteKPIRepo struct {
+	db *sql.DB
+}
+
+// NewSQLiteKPIRepo creates a new SQLite KPI repository
+func NewSQLiteKPIRepo(db *sql.DB) *SQLiteKPIRepo {
+	return &SQLiteKPIRepo{db: db}
+}
+
+// GetBusinessKPIs aggregates payments, KYC verifications and meta-transactions
+func (r *SQLiteKPIRepo) GetBusinessKPIs(ctx context.Context, since time.Time) (*repository.BusinessKPIs, error) {
+	kpis := &repository.BusinessKPIs{
+		PaymentsByStatus: make(map[repository.PaymentStatus]int64),
+		KYCByStatus:      make(map[repository.KYCVerificationStatus]int64),
+	}
+
+	rows, err := r.db.QueryContext(ctx, `SELECT status, COUNT(*) FROM payments GROUP BY status`)
+	if err != nil {
+		return nil, fmt.Errorf("counting payments by status: %w", err)
+	}
+	for rows.Next() {
+		var status repository.PaymentStatus
+		var count int64
+		if err := rows.Scan(&status, &count); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("scanning payment count: %w", err)
+		}
+		kpis.PaymentsByStatus[status] = count
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("counting payments by status: %w", err)
+	}
+
+	rows, err = r.db.QueryContext(ctx, `SELECT status, COUNT(*) FROM kyc_verifications GROUP BY status`)
+	if err != nil {
+		return nil, fmt.Errorf("counting kyc verifications by status: %w", err)
+	}
+	for rows.Next() {
+		var status repository.KYCVerificationStatus
+		var count int64
+		if err := rows.Scan(&status, &count); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("scanning kyc verification count: %w", err)
+		}
+		kpis.KYCByStatus[status] = count
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("counting kyc verifications by status: %w", err)
+	}
+
+	query := `
+		SELECT
+			(SELECT COUNT(*) FROM kyc_verifications WHERE verified_at >= ?1) AS kyc_approved,
+			(SELECT COUNT(*) FROM kyc_verifications WHERE rejected_at >= ?1) AS kyc_rejected,
+			COUNT(*) AS relay_confirmed,
+			COALESCE(SUM((julianday(confirmed_at) - julianday(created_at)) * 86400.0), 0.0) AS relay_seconds
+		FROM meta_transactions
+		WHERE status = 'confirmed' AND confirmed_at >= ?1
+	`
+
+	err = r.db.QueryRowContext(ctx, query, timeArg(since)).Scan(
+		&kpis.KYCApproved,
+		&kpis.KYCRejected,
+		&kpis.RelayConfirmed,
+		&kpis.RelayConfirmationSeconds,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("getting activity kpis: %w", err)
+	}
+
+	return kpis, nil
+}
@@ -0,0 +1,116 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure SQLiteKYCAuditRepo implements KYCAuditRepository
+var _ repository.KYCAuditRepository = (*SQLiteKYCAuditRepo)(nil)
+
+// SQLiteKYCAuditRepo implements KYCAuditRepository using SQLite. Pages are
+// keyset queries on (timestamp, id), served by idx_audit_timestamp or, for
+// one subject, idx_audit_subject.
+type SQLiteKYCAuditRepo struct {
+	db *sql.DB
+}
+
+// NewSQLiteKYCAuditRepo creates a new SQLite KYC audit repository
+func NewSQLiteKYCAuditRepo(db *sql.DB) *SQLiteKYCAuditRepo {
+	return &SQLiteKYCAuditRepo{db: db}
+}
+
+// auditColumns is the column list read by every audit query
+const auditColumns = `id, timestamp, action, actor, COALESCE(subject, ''), COALESCE(details, ''),
+	COALESCE(ip_address, ''), COALESCE(country, ''), COALESCE(previous_state, ''), COALESCE(new_state, '')`
+
+// AppendAuditEntry stores an audit entry
+func (r *SQLiteKYCAuditRepo) AppendAuditEntry(ctx context.Context, e *repository.KYCAuditEntry) error {
+	query := `
+		INSERT INTO audit_log (id, timestamp, action, actor, subject, details, ip_address, country, previous_state, new_state)
+		VALUES (?1, ?2, ?3, ?4, ?5, ?6, NULLIF(?7, ''), NULLIF(?8, ''), ?9, ?10)
+	`
+
+	id := newID()
+	ts := e.Timestamp
+	if ts.IsZero() {
+		ts = time.Now()
+	}
+	_, err := r.db.ExecContext(ctx, query,
+		id, timeArg(ts), e.Action, e.Actor, e.Subject, e.Details, e.IPAddress, e.Country, e.PreviousState, e.NewState,
+	)
+	if err != nil {
+		return fmt.Errorf("appending audit entry: %w", err)
+	}
+	e.ID = id
+	e.Timestamp = ts.UTC()
+	return nil
+}
+
+// ListAuditEntries returns up to limit entries after filter.After, newest first
+func (r *SQLiteKYCAuditRepo) ListAuditEntries(ctx context.Context, filter repository.KYCAuditFilter, limit int) ([]*repository.KYCAuditEntry, error) {
+	where, args, argNum := auditWhere(filter)
+	if filter.After != nil {
+		where = append(where, fmt.Sprintf("(timestamp, id) < (?%d, ?%d)", argNum, argNum+1))
+		args = append(args, timeArg(filter.After.Timestamp), filter.After.ID)
+		argNum += 2
+	}
+
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM audit_log
+		WHERE %s
+		ORDER BY timestamp DESC, id DESC
+		LIMIT ?%d
+	`, auditColumns, join(where, " AND "), argNum)
+	args = append(args, limit)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("listing audit entries: %w", err)
+	}
+	defer rows.Close()
+
+	var result []*repository.KYCAuditEntry
+	for rows.Next() {
+		e := &repository.KYCAuditEntry{}
+		if err := rows.Scan(&e.ID, &e.Timestamp, &e.Action, &e.Actor, &e.Subject, &e.Details,
+			&e.IPAddress, &e.Country, &e.PreviousState, &e.NewState); err != nil {
+			return nil, fmt.Errorf("scanning audit entry: %w", err)
+		}
+		result = append(result, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating audit entries: %w", err)
+	}
+	return result, nil
+}
+
+// CountAuditEntries counts the entries of filter.Subject (all without one)
+func (r *SQLiteKYCAuditRepo) CountAuditEntries(ctx context.Context, filter repository.KYCAuditFilter) (int64, error) {
+	where, args, _ := auditWhere(filter)
+
+	var total int64
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM audit_log WHERE "+join(where, " AND "), args...).Scan(&total); err != nil {
+		return 0, fmt.Errorf("counting audit entries: %w", err)
+	}
+	return total, nil
+}
+
+// auditWhere builds the subject condition shared by list and count
+func auditWhere(filter repository.KYCAuditFilter) ([]string, []interface{}, int) {
+	where := []string{"1=1"}
+	args := []interface{}{}
+	argNum := 1
+
+	if filter.Subject != "" {
+		where = append(where, fmt.Sprintf("subject = ?%d", argNum))
+		args = append(args, filter.Subject)
+		argNum++
+	}
+	return where, args, argNum
+}
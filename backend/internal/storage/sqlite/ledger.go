@@ -0,0 +1,252 @@
+// Package sqlite implements repository interfaces using SQLite
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure SQLiteLedgerRepo implements LedgerRepository
+var _ repository.LedgerRepository = (*SQLiteLedgerRepo)(nil)
+
+// SQLiteLedgerRepo implements LedgerRepository using SQLite. Amounts are
+// stored as text, so balances are totalled in Go.
+type SQLiteLedgerRepo struct {
+	db *sql.DB
+}
+
+// NewSQLiteLedgerRepo creates a new SQLite ledger repository
+func NewSQLiteLedgerRepo(db *sql.DB) *SQLiteLedgerRepo {
+	return &SQLiteLedgerRepo{db: db}
+}
+
+// ledgerEntryColumns is the column list read by every ledger entry query
+const ledgerEntryColumns = `e.id, e.transaction_id, e.account, e.direction, e.amount, e.currency,
+		       t.event_type, t.payment_id, e.created_at`
+
+// PostLedgerTransaction writes a balanced transaction and its entries once
+func (r *SQLiteLedgerRepo) PostLedgerTransaction(ctx context.Context, lt *repository.LedgerTransaction) (bool, error) {
+	if err := lt.Validate(); err != nil {
+		return false, err
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("beginning ledger transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO ledger_transactions (idempotency_key, event_type, payment_id, description)
+		VALUES (?1, ?2, ?3, ?4)
+		ON CONFLICT (idempotency_key) DO NOTHING
+		RETURNING id, created_at
+	`, lt.IdempotencyKey, lt.EventType, lt.PaymentID, lt.Description).Scan(&lt.ID, &lt.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("posting ledger transaction: %w", err)
+	}
+
+	for _, e := range lt.Entries {
+		err := tx.QueryRowContext(ctx, `
+			INSERT INTO ledger_entries (transaction_id, account, direction, amount, currency, created_at)
+			VALUES (?1, ?2, ?3, ?4, ?5, ?6)
+			RETURNING id
+		`, lt.ID, e.Account, e.Direction, e.Amount, e.Currency, timeArg(lt.CreatedAt)).Scan(&e.ID)
+		if err != nil {
+			return false, fmt.Errorf("posting ledger entry: %w", err)
+		}
+		e.TransactionID = lt.ID
+		e.EventType = lt.EventType
+		e.PaymentID = lt.PaymentID
+		e.CreatedAt = lt.CreatedAt
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("committing ledger transaction: %w", err)
+	}
+	return true, nil
+}
+
+// GetLedgerTransaction retrieves a transaction with its entries
+func (r *SQLiteLedgerRepo) GetLedgerTransaction(ctx context.Context, id string) (*repository.LedgerTransaction, error) {
+	lt := &repository.LedgerTransaction{}
+	err := r.db.QueryRowContext(ctx, `
+		SELECT id, idempotency_key, event_type, payment_id, description, created_at
+		FROM ledger_transactions WHERE id = ?1
+	`, id).Scan(&lt.ID, &lt.IdempotencyKey, &lt.EventType, &lt.PaymentID, &lt.Description, &lt.CreatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrLedgerTransactionNotFound
+		}
+		return nil, fmt.Errorf("getting ledger transaction %s: %w", id, err)
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+ledgerEntryColumns+`
+		FROM ledger_entries e JOIN ledger_transactions t ON t.id = e.transaction_id
+		WHERE e.transaction_id = ?1
+		ORDER BY e.direction DESC, e.account
+	`, id)
+	if err != nil {
+		return nil, fmt.Errorf("getting ledger entries of %s: %w", id, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		e, err := scanLedgerEntry(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning ledger entry row: %w", err)
+		}
+		lt.Entries = append(lt.Entries, e)
+	}
+
+	return lt, rows.Err()
+}
+
+// ListLedgerEntries lists entries with filtering, oldest first
+func (r *SQLiteLedgerRepo) ListLedgerEntries(ctx context.Context, filter repository.LedgerEntryFilter, page repository.Pagination) ([]*repository.LedgerEntry, int64, error) {
+	whereClause := "WHERE 1=1"
+	args := []interface{}{}
+	argNum := 1
+
+	if filter.Account != "" {
+		whereClause += fmt.Sprintf(" AND e.account = ?%d", argNum)
+		args = append(args, filter.Account)
+		argNum++
+	}
+	if filter.Currency != "" {
+		whereClause += fmt.Sprintf(" AND e.currency = ?%d", argNum)
+		args = append(args, filter.Currency)
+		argNum++
+	}
+	if filter.PaymentID != "" {
+		whereClause += fmt.Sprintf(" AND t.payment_id = ?%d", argNum)
+		args = append(args, filter.PaymentID)
+		argNum++
+	}
+	if filter.EventType != "" {
+		whereClause += fmt.Sprintf(" AND t.event_type = ?%d", argNum)
+		args = append(args, filter.EventType)
+		argNum++
+	}
+	if filter.From != nil {
+		whereClause += fmt.Sprintf(" AND e.created_at >= ?%d", argNum)
+		args = append(args, timeArg(*filter.From))
+		argNum++
+	}
+	if filter.To != nil {
+		whereClause += fmt.Sprintf(" AND e.created_at < ?%d", argNum)
+		args = append(args, timeArg(*filter.To))
+		argNum++
+	}
+
+	from := "FROM ledger_entries e JOIN ledger_transactions t ON t.id = e.transaction_id "
+
+	var total int64
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) "+from+whereClause, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("counting ledger entries: %w", err)
+	}
+
+	if page.PageSize <= 0 {
+		page.PageSize = 50
+	}
+	if page.Page <= 0 {
+		page.Page = 1
+	}
+	offset := (page.Page - 1) * page.PageSize
+
+	query := fmt.Sprintf(`
+		SELECT `+ledgerEntryColumns+`
+		`+from+`
+		%s
+		ORDER BY e.created_at, e.transaction_id, e.direction DESC, e.account
+		LIMIT ?%d OFFSET ?%d
+	`, whereClause, argNum, argNum+1)
+
+	args = append(args, page.PageSize, offset)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("listing ledger entries: %w", err)
+	}
+	defer rows.Close()
+
+	var result []*repository.LedgerEntry
+	for rows.Next() {
+		e, err := scanLedgerEntry(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("scanning ledger entry row: %w", err)
+		}
+		result = append(result, e)
+	}
+
+	return result, total, rows.Err()
+}
+
+// GetLedgerBalances totals entries per account and currency
+func (r *SQLiteLedgerRepo) GetLedgerBalances(ctx context.Context, filter repository.LedgerBalanceFilter) ([]*repository.LedgerBalance, error) {
+	whereClause := "WHERE 1=1"
+	args := []interface{}{}
+	argNum := 1
+
+	if filter.Currency != "" {
+		whereClause += fmt.Sprintf(" AND currency = ?%d", argNum)
+		args = append(args, filter.Currency)
+		argNum++
+	}
+	if filter.To != nil {
+		whereClause += fmt.Sprintf(" AND created_at < ?%d", argNum)
+		args = append(args, timeArg(*filter.To))
+	}
+
+	rows, err := r.db.QueryContext(ctx, "SELECT account, currency, direction, amount FROM ledger_entries "+whereClause, args...)
+	if err != nil {
+		return nil, fmt.Errorf("totalling ledger entries: %w", err)
+	}
+	defer rows.Close()
+
+	tally := repository.NewLedgerTally()
+	for rows.Next() {
+		var account repository.LedgerAccount
+		var direction repository.LedgerDirection
+		var currency, amount string
+		if err := rows.Scan(&account, &currency, &direction, &amount); err != nil {
+			return nil, fmt.Errorf("scanning ledger entry row: %w", err)
+		}
+		if err := tally.Add(account, currency, direction, amount); err != nil {
+			return nil, err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return tally.Balances(), nil
+}
+
+// scanLedgerEntry scans a ledger_entries row joined with its transaction
+func scanLedgerEntry(row rowScanner) (*repository.LedgerEntry, error) {
+	e := &repository.LedgerEntry{}
+	err := row.Scan(
+		&e.ID,
+		&e.TransactionID,
+		&e.Account,
+		&e.Direction,
+		&e.Amount,
+		&e.Currency,
+		&e.EventType,
+		&e.PaymentID,
+		&e.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return e, nil
+}
@@ -0,0 +1,630 @@
+-- Nexus Protocol SQLite schema
+-- Mirrors the tables in infrastructure/docker/init-db.sql that the repository
+-- layer reads and writes. Dialect differences:
+--   * UUIDs are generated as random v4 text
+--   * Timestamps are UTC text ('YYYY-MM-DD HH:MM:SS.SSS+00:00'), matching the
+--     layout the Go code binds so they compare correctly
+--   * Wei amounts are TEXT (NUMERIC affinity would lose precision)
+--   * JSONB and arrays are JSON text
+--   * Postgres trigger functions become AFTER UPDATE triggers
+
+-- ============================================
+-- Governance Configuration
+-- ============================================
+
+CREATE TABLE IF NOT EXISTS governance_config (
+    id TEXT PRIMARY KEY DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+    config_key VARCHAR(50) NOT NULL,
+    config_name VARCHAR(100) NOT NULL,
+    description TEXT,
+    value_wei TEXT,
+    value_number BIGINT,
+    value_percent REAL,
+    value_string VARCHAR(255),
+    value_type VARCHAR(20) NOT NULL,
+    unit_label VARCHAR(20),
+    chain_id BIGINT NOT NULL,
+    contract_synced BOOLEAN NOT NULL DEFAULT FALSE,
+    last_sync_tx VARCHAR(66),
+    last_sync_at TIMESTAMP,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    updated_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    updated_by VARCHAR(42),
+    CONSTRAINT governance_config_key_chain_unique UNIQUE (config_key, chain_id)
+);
+
+CREATE INDEX idx_governance_config_chain ON governance_config(chain_id);
+
+CREATE TABLE IF NOT EXISTS governance_config_history (
+    id TEXT PRIMARY KEY DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+    governance_config_id TEXT NOT NULL REFERENCES governance_config(id) ON DELETE CASCADE,
+    old_value_wei TEXT,
+    old_value_number BIGINT,
+    old_value_percent REAL,
+    old_value_string VARCHAR(255),
+    new_value_wei TEXT,
+    new_value_number BIGINT,
+    new_value_percent REAL,
+    new_value_string VARCHAR(255),
+    was_synced BOOLEAN,
+    sync_tx VARCHAR(66),
+    changed_by VARCHAR(42) NOT NULL,
+    changed_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    change_reason TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    updated_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now'))
+);
+
+CREATE INDEX idx_governance_config_history_config ON governance_config_history(governance_config_id);
+
+-- ============================================
+-- Application Configuration
+-- ============================================
+
+CREATE TABLE IF NOT EXISTS app_config (
+    id TEXT PRIMARY KEY DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+    namespace VARCHAR(50) NOT NULL,
+    config_key VARCHAR(100) NOT NULL,
+    value_type VARCHAR(20) NOT NULL,
+    value_string TEXT,
+    value_number BIGINT,
+    value_wei TEXT,
+    value_boolean BOOLEAN,
+    description TEXT,
+    is_secret BOOLEAN NOT NULL DEFAULT FALSE,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    chain_id BIGINT NOT NULL DEFAULT 0,
+    updated_by VARCHAR(42),
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    updated_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    CONSTRAINT app_config_namespace_key_chain_unique UNIQUE (namespace, config_key, chain_id)
+);
+
+CREATE INDEX idx_app_config_namespace ON app_config(namespace);
+CREATE INDEX idx_app_config_chain ON app_config(chain_id);
+
+CREATE TABLE IF NOT EXISTS app_config_history (
+    id TEXT PRIMARY KEY DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+    app_config_id TEXT NOT NULL REFERENCES app_config(id) ON DELETE CASCADE,
+    old_value_string TEXT,
+    old_value_number BIGINT,
+    old_value_wei TEXT,
+    old_value_boolean BOOLEAN,
+    new_value_string TEXT,
+    new_value_number BIGINT,
+    new_value_wei TEXT,
+    new_value_boolean BOOLEAN,
+    changed_by VARCHAR(42) NOT NULL,
+    changed_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    change_reason TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now'))
+);
+
+CREATE INDEX idx_app_config_history_config ON app_config_history(app_config_id);
+
+-- ============================================
+-- Pricing & Payments
+-- ============================================
+
+CREATE TABLE IF NOT EXISTS pricing (
+    id TEXT PRIMARY KEY DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+    service_code VARCHAR(50) NOT NULL UNIQUE,
+    service_name VARCHAR(100) NOT NULL,
+    description TEXT,
+    cost_usd REAL NOT NULL DEFAULT 0,
+    cost_provider VARCHAR(50),
+    price_usd REAL NOT NULL,
+    price_eth REAL,
+    price_nexus REAL,
+    markup_percent REAL NOT NULL DEFAULT 0,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    updated_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    updated_by VARCHAR(42)
+);
+
+CREATE TABLE IF NOT EXISTS pricing_history (
+    id TEXT PRIMARY KEY DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+    pricing_id TEXT NOT NULL REFERENCES pricing(id) ON DELETE CASCADE,
+    old_price_usd REAL,
+    old_price_eth REAL,
+    old_price_nexus REAL,
+    old_markup_percent REAL,
+    new_price_usd REAL,
+    new_price_eth REAL,
+    new_price_nexus REAL,
+    new_markup_percent REAL,
+    changed_by VARCHAR(42) NOT NULL,
+    changed_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    change_reason TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    updated_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now'))
+);
+
+CREATE INDEX idx_pricing_history_pricing_id ON pricing_history(pricing_id);
+
+CREATE TABLE IF NOT EXISTS payment_methods (
+    id TEXT PRIMARY KEY DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+    method_code VARCHAR(20) NOT NULL UNIQUE,
+    method_name VARCHAR(50) NOT NULL,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    processor_config TEXT,
+    min_amount_usd REAL DEFAULT 0,
+    max_amount_usd REAL,
+    fee_percent REAL DEFAULT 0,
+    display_order SMALLINT DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    updated_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now'))
+);
+
+CREATE TABLE IF NOT EXISTS payments (
+    id TEXT PRIMARY KEY DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+    service_code VARCHAR(50) NOT NULL,
+    pricing_id TEXT REFERENCES pricing(id),
+    payer_address VARCHAR(42) NOT NULL,
+    payment_method VARCHAR(20) NOT NULL,
+    amount_charged REAL NOT NULL,
+    currency VARCHAR(10) NOT NULL,
+    amount_usd REAL,
+    tx_hash VARCHAR(66),
+    stripe_payment_id VARCHAR(100),
+    stripe_session_id VARCHAR(100),
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    error_message TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    updated_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    completed_at TIMESTAMP,
+    CONSTRAINT valid_payment_status CHECK (status IN ('pending', 'processing', 'completed', 'failed', 'refunded', 'cancelled'))
+);
+
+CREATE INDEX idx_payments_payer ON payments(payer_address);
+CREATE INDEX idx_payments_status ON payments(status);
+CREATE INDEX idx_payments_created ON payments(created_at);
+CREATE INDEX idx_payments_stripe_session ON payments(stripe_session_id);
+
+CREATE TABLE IF NOT EXISTS kyc_verifications (
+    id TEXT PRIMARY KEY DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+    payment_id TEXT REFERENCES payments(id),
+    user_address VARCHAR(42) NOT NULL,
+    sumsub_applicant_id VARCHAR(100),
+    sumsub_inspection_id VARCHAR(100),
+    sumsub_review_status VARCHAR(50),
+    sumsub_review_result TEXT,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    whitelist_tx_hash VARCHAR(66),
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    updated_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    submitted_at TIMESTAMP,
+    verified_at TIMESTAMP,
+    rejected_at TIMESTAMP,
+    CONSTRAINT valid_kyc_status CHECK (status IN ('pending', 'payment_required', 'submitted', 'in_review', 'approved', 'rejected', 'expired'))
+);
+
+CREATE INDEX idx_kyc_verifications_user ON kyc_verifications(user_address);
+CREATE INDEX idx_kyc_verifications_sumsub ON kyc_verifications(sumsub_applicant_id);
+
+-- ============================================
+-- Meta-Transactions (ERC-2771 Relayer)
+-- ============================================
+
+CREATE TABLE IF NOT EXISTS meta_transactions (
+    id TEXT PRIMARY KEY DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+    from_address VARCHAR(42) NOT NULL,
+    to_address VARCHAR(42) NOT NULL,
+    function_name VARCHAR(100) NOT NULL,
+    calldata TEXT NOT NULL,
+    value TEXT NOT NULL DEFAULT '0',
+    gas_limit BIGINT NOT NULL,
+    nonce BIGINT NOT NULL,
+    deadline TIMESTAMP NOT NULL,
+    signature TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    tx_hash VARCHAR(66),
+    gas_used BIGINT,
+    gas_price TEXT,
+    relay_cost_eth TEXT,
+    error_message TEXT,
+    retry_count INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    updated_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    submitted_at TIMESTAMP,
+    confirmed_at TIMESTAMP,
+    CONSTRAINT valid_meta_tx_status CHECK (status IN ('pending', 'submitted', 'confirmed', 'failed', 'expired', 'cancelled'))
+);
+
+CREATE INDEX idx_meta_tx_from ON meta_transactions(from_address);
+CREATE INDEX idx_meta_tx_status ON meta_transactions(status);
+CREATE INDEX idx_meta_tx_tx_hash ON meta_transactions(tx_hash);
+
+-- ============================================
+-- Chain Tracking (Confirmations & Reorgs)
+-- ============================================
+
+CREATE TABLE IF NOT EXISTS chain_blocks (
+    chain_id BIGINT NOT NULL,
+    block_number BIGINT NOT NULL,
+    block_hash VARCHAR(66) NOT NULL,
+    parent_hash VARCHAR(66) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    PRIMARY KEY (chain_id, block_number)
+);
+
+CREATE TABLE IF NOT EXISTS chain_tx_tracking (
+    id TEXT PRIMARY KEY DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+    chain_id BIGINT NOT NULL,
+    tx_hash VARCHAR(66) NOT NULL,
+    record_type VARCHAR(20) NOT NULL,
+    record_id VARCHAR(100) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    block_number BIGINT,
+    block_hash VARCHAR(66),
+    confirmations INT NOT NULL DEFAULT 0,
+    reorg_count INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    updated_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    finalized_at TIMESTAMP,
+    CONSTRAINT chain_tx_tracking_record_unique UNIQUE (chain_id, tx_hash, record_type, record_id),
+    CONSTRAINT valid_tracked_tx_status CHECK (status IN ('pending', 'included', 'confirmed', 'failed'))
+);
+
+CREATE INDEX idx_chain_tx_tracking_status ON chain_tx_tracking(chain_id, status);
+CREATE INDEX idx_chain_tx_tracking_block ON chain_tx_tracking(chain_id, block_number);
+
+-- ============================================
+-- Request Log Archive
+-- ============================================
+
+CREATE TABLE IF NOT EXISTS request_logs (
+    id TEXT PRIMARY KEY DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+    request_id VARCHAR(64) NOT NULL,
+    method VARCHAR(10) NOT NULL,
+    path VARCHAR(500) NOT NULL,
+    route VARCHAR(200) NOT NULL DEFAULT '',
+    query TEXT NOT NULL DEFAULT '',
+    status_code INT NOT NULL,
+    latency_ms BIGINT NOT NULL DEFAULT 0,
+    client_ip VARCHAR(45) NOT NULL DEFAULT '',
+    user_agent TEXT NOT NULL DEFAULT '',
+    request_headers TEXT NOT NULL DEFAULT '{}',
+    request_body TEXT,
+    response_body TEXT,
+    error TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now'))
+);
+
+CREATE INDEX idx_request_logs_created_at ON request_logs(created_at);
+CREATE INDEX idx_request_logs_request_id ON request_logs(request_id);
+CREATE INDEX idx_request_logs_status ON request_logs(status_code, created_at);
+
+-- ============================================
+-- Contract Address Management
+-- ============================================
+
+CREATE TABLE IF NOT EXISTS network_config (
+    id TEXT PRIMARY KEY DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+    chain_id BIGINT NOT NULL UNIQUE,
+    network_name VARCHAR(50) NOT NULL UNIQUE,
+    display_name VARCHAR(100) NOT NULL,
+    rpc_url VARCHAR(255),
+    rpc_fallback_urls TEXT NOT NULL DEFAULT '[]',
+    ws_url VARCHAR(255),
+    explorer_url VARCHAR(255),
+    default_deployer VARCHAR(42),
+    is_testnet BOOLEAN NOT NULL DEFAULT TRUE,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    updated_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now'))
+);
+
+CREATE TABLE IF NOT EXISTS contract_mappings (
+    id TEXT PRIMARY KEY DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+    solidity_name VARCHAR(100) NOT NULL UNIQUE,
+    db_name VARCHAR(50) NOT NULL UNIQUE,
+    display_name VARCHAR(100) NOT NULL,
+    category VARCHAR(50) NOT NULL,
+    description TEXT,
+    is_required BOOLEAN NOT NULL DEFAULT TRUE,
+    sort_order INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now'))
+);
+
+CREATE TABLE IF NOT EXISTS contract_addresses (
+    id TEXT PRIMARY KEY DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+    chain_id BIGINT NOT NULL REFERENCES network_config(chain_id),
+    contract_mapping_id TEXT NOT NULL REFERENCES contract_mappings(id),
+    address VARCHAR(42) NOT NULL,
+    deployment_tx_hash VARCHAR(66),
+    deployment_block BIGINT,
+    abi_version VARCHAR(20) DEFAULT '1.0.0',
+    status VARCHAR(20) NOT NULL DEFAULT 'active',
+    is_primary BOOLEAN NOT NULL DEFAULT TRUE,
+    deployed_by VARCHAR(42),
+    notes TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    updated_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    CONSTRAINT contract_addresses_status_check CHECK (status IN ('active', 'deprecated', 'paused'))
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_contract_addresses_unique_active
+    ON contract_addresses(chain_id, contract_mapping_id)
+    WHERE is_primary = TRUE;
+
+CREATE INDEX idx_contract_addresses_chain ON contract_addresses(chain_id);
+
+CREATE TABLE IF NOT EXISTS contract_addresses_history (
+    id TEXT PRIMARY KEY DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+    contract_id TEXT NOT NULL REFERENCES contract_addresses(id) ON DELETE CASCADE,
+    old_address VARCHAR(42),
+    new_address VARCHAR(42) NOT NULL,
+    change_reason VARCHAR(255),
+    changed_by VARCHAR(42) NOT NULL,
+    changed_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now'))
+);
+
+CREATE INDEX idx_contract_history_contract ON contract_addresses_history(contract_id);
+
+CREATE TABLE IF NOT EXISTS abi_artifacts (
+    id TEXT PRIMARY KEY DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+    contract_mapping_id TEXT NOT NULL REFERENCES contract_mappings(id),
+    version VARCHAR(20) NOT NULL,
+    abi TEXT NOT NULL,
+    abi_hash VARCHAR(66) NOT NULL,
+    compiler_version VARCHAR(50),
+    uploaded_by VARCHAR(100),
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    updated_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    CONSTRAINT abi_artifacts_mapping_version_unique UNIQUE (contract_mapping_id, version),
+    CONSTRAINT abi_artifacts_abi_array_check CHECK (json_valid(abi) AND json_type(abi) = 'array')
+);
+
+CREATE INDEX idx_abi_artifacts_mapping ON abi_artifacts(contract_mapping_id);
+
+-- ============================================
+-- Triggers
+-- ============================================
+
+-- updated_at maintenance (skipped when the statement sets updated_at itself)
+CREATE TRIGGER update_governance_config_updated_at AFTER UPDATE ON governance_config
+    FOR EACH ROW WHEN NEW.updated_at IS OLD.updated_at
+BEGIN
+    UPDATE governance_config SET updated_at = strftime('%Y-%m-%d %H:%M:%f+00:00', 'now') WHERE id = NEW.id;
+END;
+
+CREATE TRIGGER update_app_config_updated_at AFTER UPDATE ON app_config
+    FOR EACH ROW WHEN NEW.updated_at IS OLD.updated_at
+BEGIN
+    UPDATE app_config SET updated_at = strftime('%Y-%m-%d %H:%M:%f+00:00', 'now') WHERE id = NEW.id;
+END;
+
+CREATE TRIGGER update_pricing_updated_at AFTER UPDATE ON pricing
+    FOR EACH ROW WHEN NEW.updated_at IS OLD.updated_at
+BEGIN
+    UPDATE pricing SET updated_at = strftime('%Y-%m-%d %H:%M:%f+00:00', 'now') WHERE id = NEW.id;
+END;
+
+CREATE TRIGGER update_payment_methods_updated_at AFTER UPDATE ON payment_methods
+    FOR EACH ROW WHEN NEW.updated_at IS OLD.updated_at
+BEGIN
+    UPDATE payment_methods SET updated_at = strftime('%Y-%m-%d %H:%M:%f+00:00', 'now') WHERE id = NEW.id;
+END;
+
+CREATE TRIGGER update_payments_updated_at AFTER UPDATE ON payments
+    FOR EACH ROW WHEN NEW.updated_at IS OLD.updated_at
+BEGIN
+    UPDATE payments SET updated_at = strftime('%Y-%m-%d %H:%M:%f+00:00', 'now') WHERE id = NEW.id;
+END;
+
+CREATE TRIGGER update_kyc_verifications_updated_at AFTER UPDATE ON kyc_verifications
+    FOR EACH ROW WHEN NEW.updated_at IS OLD.updated_at
+BEGIN
+    UPDATE kyc_verifications SET updated_at = strftime('%Y-%m-%d %H:%M:%f+00:00', 'now') WHERE id = NEW.id;
+END;
+
+CREATE TRIGGER update_meta_transactions_updated_at AFTER UPDATE ON meta_transactions
+    FOR EACH ROW WHEN NEW.updated_at IS OLD.updated_at
+BEGIN
+    UPDATE meta_transactions SET updated_at = strftime('%Y-%m-%d %H:%M:%f+00:00', 'now') WHERE id = NEW.id;
+END;
+
+CREATE TRIGGER update_chain_tx_tracking_updated_at AFTER UPDATE ON chain_tx_tracking
+    FOR EACH ROW WHEN NEW.updated_at IS OLD.updated_at
+BEGIN
+    UPDATE chain_tx_tracking SET updated_at = strftime('%Y-%m-%d %H:%M:%f+00:00', 'now') WHERE id = NEW.id;
+END;
+
+CREATE TRIGGER update_network_config_updated_at AFTER UPDATE ON network_config
+    FOR EACH ROW WHEN NEW.updated_at IS OLD.updated_at
+BEGIN
+    UPDATE network_config SET updated_at = strftime('%Y-%m-%d %H:%M:%f+00:00', 'now') WHERE id = NEW.id;
+END;
+
+CREATE TRIGGER update_contract_addresses_updated_at AFTER UPDATE ON contract_addresses
+    FOR EACH ROW WHEN NEW.updated_at IS OLD.updated_at
+BEGIN
+    UPDATE contract_addresses SET updated_at = strftime('%Y-%m-%d %H:%M:%f+00:00', 'now') WHERE id = NEW.id;
+END;
+
+CREATE TRIGGER update_abi_artifacts_updated_at AFTER UPDATE ON abi_artifacts
+    FOR EACH ROW WHEN NEW.updated_at IS OLD.updated_at
+BEGIN
+    UPDATE abi_artifacts SET updated_at = strftime('%Y-%m-%d %H:%M:%f+00:00', 'now') WHERE id = NEW.id;
+END;
+
+-- App config history auto-logging
+CREATE TRIGGER log_app_config_changes AFTER UPDATE ON app_config
+    FOR EACH ROW WHEN OLD.value_string IS NOT NEW.value_string
+        OR OLD.value_number IS NOT NEW.value_number
+        OR OLD.value_wei IS NOT NEW.value_wei
+        OR OLD.value_boolean IS NOT NEW.value_boolean
+BEGIN
+    INSERT INTO app_config_history (
+        app_config_id,
+        old_value_string, old_value_number, old_value_wei, old_value_boolean,
+        new_value_string, new_value_number, new_value_wei, new_value_boolean,
+        changed_by, change_reason
+    ) VALUES (
+        NEW.id,
+        OLD.value_string, OLD.value_number, OLD.value_wei, OLD.value_boolean,
+        NEW.value_string, NEW.value_number, NEW.value_wei, NEW.value_boolean,
+        COALESCE(NEW.updated_by, 'system'),
+        'Config update'
+    );
+END;
+
+-- Pricing history auto-logging
+CREATE TRIGGER log_pricing_changes AFTER UPDATE ON pricing
+    FOR EACH ROW WHEN OLD.price_usd IS NOT NEW.price_usd
+        OR OLD.price_eth IS NOT NEW.price_eth
+        OR OLD.price_nexus IS NOT NEW.price_nexus
+        OR OLD.markup_percent IS NOT NEW.markup_percent
+BEGIN
+    INSERT INTO pricing_history (
+        pricing_id,
+        old_price_usd, old_price_eth, old_price_nexus, old_markup_percent,
+        new_price_usd, new_price_eth, new_price_nexus, new_markup_percent,
+        changed_by, change_reason
+    ) VALUES (
+        NEW.id,
+        OLD.price_usd, OLD.price_eth, OLD.price_nexus, OLD.markup_percent,
+        NEW.price_usd, NEW.price_eth, NEW.price_nexus, NEW.markup_percent,
+        COALESCE(NEW.updated_by, 'system'),
+        'Price update'
+    );
+END;
+
+-- Governance config history auto-logging. SQLite triggers cannot modify NEW,
+-- so the sync reset the Postgres BEFORE trigger does is a follow-up UPDATE.
+CREATE TRIGGER log_governance_config_changes AFTER UPDATE ON governance_config
+    FOR EACH ROW WHEN OLD.value_wei IS NOT NEW.value_wei
+        OR OLD.value_number IS NOT NEW.value_number
+        OR OLD.value_percent IS NOT NEW.value_percent
+        OR OLD.value_string IS NOT NEW.value_string
+BEGIN
+    INSERT INTO governance_config_history (
+        governance_config_id,
+        old_value_wei, old_value_number, old_value_percent, old_value_string,
+        new_value_wei, new_value_number, new_value_percent, new_value_string,
+        was_synced, sync_tx,
+        changed_by, change_reason
+    ) VALUES (
+        NEW.id,
+        OLD.value_wei, OLD.value_number, OLD.value_percent, OLD.value_string,
+        NEW.value_wei, NEW.value_number, NEW.value_percent, NEW.value_string,
+        OLD.contract_synced, OLD.last_sync_tx,
+        COALESCE(NEW.updated_by, 'system'),
+        'Governance config update'
+    );
+
+    UPDATE governance_config SET contract_synced = FALSE WHERE id = NEW.id;
+END;
+
+-- Contract address history auto-logging
+CREATE TRIGGER log_contract_address_changes AFTER UPDATE ON contract_addresses
+    FOR EACH ROW WHEN OLD.address IS NOT NEW.address
+BEGIN
+    INSERT INTO contract_addresses_history (
+        contract_id, old_address, new_address, change_reason, changed_by
+    ) VALUES (
+        NEW.id, OLD.address, NEW.address, 'Address update', COALESCE(NEW.deployed_by, 'system')
+    );
+END;
+
+-- ============================================
+-- Seed Data (for demo/testing)
+-- ============================================
+
+INSERT OR IGNORE INTO payment_methods (method_code, method_name, is_active, fee_percent, display_order, processor_config) VALUES
+    ('nexus', 'NEXUS Token', TRUE, 0, 1, '{"contract": "NexusToken", "discount_percent": 10}'),
+    ('eth', 'Ethereum (ETH)', TRUE, 0, 2, '{"min_confirmations": 2}'),
+    ('stripe', 'Credit Card (Stripe)', TRUE, 2.9, 3, '{"currency": "usd", "payment_method_types": ["card"]}');
+
+INSERT OR IGNORE INTO pricing (service_code, service_name, description, cost_usd, cost_provider, price_usd, price_eth, price_nexus, markup_percent, is_active) VALUES
+    ('kyc_verification', 'KYC Identity Verification', 'Full identity verification with document check and AML screening via Sumsub', 5.00, 'sumsub', 15.00, 0.005, 150, 200.00, TRUE),
+    ('kyc_aml_recheck', 'AML Re-screening', 'Periodic AML/sanctions re-check for existing users', 1.00, 'sumsub', 3.00, 0.001, 30, 200.00, TRUE),
+    ('kyc_enhanced', 'Enhanced Due Diligence', 'Enhanced verification for high-value accounts', 15.00, 'sumsub', 45.00, 0.015, 450, 200.00, TRUE),
+    ('meta_tx_relay', 'Meta-Transaction Relay', 'Gasless transaction relay fee per meta-transaction', 0.10, 'gas', 0.50, 0.000167, 5, 400.00, TRUE),
+    ('nft_mint', 'NFT Minting Fee', 'Platform fee for minting new NFTs (includes gas subsidy)', 5.00, 'platform', 25.00, 0.00833, 250, 400.00, TRUE),
+    ('premium_monthly', 'Premium Features (Monthly)', 'Monthly subscription for premium platform features', 2.00, 'platform', 10.00, 0.00333, 100, 400.00, TRUE),
+    ('governance_proposal', 'Governance Proposal Fee', 'Fee for submitting governance proposals (refundable if passed)', 0, 'platform', 10.00, 0.00333, 100, 0, TRUE);
+
+INSERT OR IGNORE INTO governance_config (config_key, config_name, description, value_wei, value_number, value_percent, value_type, unit_label, chain_id, is_active) VALUES
+    ('proposal_threshold', 'Proposal Threshold', 'Minimum tokens required to create a governance proposal', '100000000000000000000', NULL, NULL, 'wei', 'NXS', 31337, TRUE),
+    ('voting_delay', 'Voting Delay', 'Number of blocks after proposal creation before voting starts', NULL, 1, NULL, 'blocks', 'blocks', 31337, TRUE),
+    ('voting_period', 'Voting Period', 'Number of blocks that voting remains open', NULL, 100, NULL, 'blocks', 'blocks', 31337, TRUE),
+    ('quorum_percent', 'Quorum Percentage', 'Minimum percentage of total supply that must vote for a proposal to be valid', NULL, NULL, 4.0, 'percent', '%', 31337, TRUE),
+    ('timelock_delay', 'Timelock Delay', 'Seconds to wait after proposal passes before it can be executed', NULL, 60, NULL, 'seconds', 'sec', 31337, TRUE),
+    ('proposal_threshold', 'Proposal Threshold', 'Minimum tokens required to create a governance proposal', '100000000000000000000', NULL, NULL, 'wei', 'NXS', 11155111, TRUE),
+    ('voting_delay', 'Voting Delay', 'Number of blocks after proposal creation before voting starts', NULL, 1, NULL, 'blocks', 'blocks', 11155111, TRUE),
+    ('voting_period', 'Voting Period', 'Number of blocks that voting remains open', NULL, 100, NULL, 'blocks', 'blocks', 11155111, TRUE),
+    ('quorum_percent', 'Quorum Percentage', 'Minimum percentage of total supply that must vote for a proposal to be valid', NULL, NULL, 4.0, 'percent', '%', 11155111, TRUE),
+    ('timelock_delay', 'Timelock Delay', 'Seconds to wait after proposal passes before it can be executed', NULL, 60, NULL, 'seconds', 'sec', 11155111, TRUE);
+
+INSERT OR IGNORE INTO app_config (namespace, config_key, value_type, value_wei, description, chain_id) VALUES
+    ('nft', 'mint_price', 'wei', '10000000000000000', 'NFT mint price in wei (0.01 ETH for testnet)', 11155111),
+    ('nft', 'mint_price', 'wei', '100000000000000000', 'NFT mint price in wei (0.1 ETH for mainnet)', 1),
+    ('token', 'total_supply', 'wei', '100000000000000000000000000', 'Total token supply in wei (100M tokens)', 0),
+    ('token', 'initial_liquidity', 'wei', '10000000000000000000000000', 'Initial liquidity pool allocation (10M tokens)', 0),
+    ('staking', 'min_stake_wei', 'wei', '100000000000000000000', 'Minimum stake in wei (100 tokens)', 0);
+
+INSERT OR IGNORE INTO app_config (namespace, config_key, value_type, value_number, description, chain_id) VALUES
+    ('nft', 'max_supply', 'number', 10000, 'Maximum NFT collection supply', 0),
+    ('nft', 'royalty_bps', 'number', 500, 'Royalty in basis points (500 = 5%)', 0),
+    ('nft', 'max_per_wallet', 'number', 5, 'Maximum NFTs per wallet during public sale', 0),
+    ('staking', 'unbonding_days', 'number', 7, 'Unbonding period in days', 0),
+    ('staking', 'min_stake_amount', 'number', 100, 'Minimum stake amount in tokens', 0),
+    ('staking', 'max_validators', 'number', 100, 'Maximum number of validators', 0),
+    ('relayer', 'gas_price_multiplier', 'number', 120, 'Gas price multiplier in percent (120 = 1.2x)', 0),
+    ('relayer', 'max_gas_price_gwei', 'number', 100, 'Maximum gas price in gwei', 0),
+    ('relayer', 'min_gas_price_gwei', 'number', 1, 'Minimum gas price in gwei', 0),
+    ('relayer', 'max_retries', 'number', 3, 'Maximum retry attempts for failed transactions', 0),
+    ('relayer', 'tx_timeout_seconds', 'number', 120, 'Transaction confirmation timeout in seconds', 0),
+    ('api', 'rate_limit_per_minute', 'number', 60, 'API rate limit per minute per IP', 0),
+    ('api', 'max_page_size', 'number', 100, 'Maximum page size for paginated endpoints', 0),
+    ('chain', 'confirmation_depth', 'number', 12, 'Blocks required before a tracked tx is treated as final', 0),
+    ('chain', 'confirmation_depth', 'number', 1, 'Blocks required before a tracked tx is treated as final (Anvil)', 31337),
+    ('chain', 'poll_interval_seconds', 'number', 15, 'Seconds between confirmation tracker polls', 0),
+    ('chain', 'reorg_window_blocks', 'number', 128, 'Recent block hashes retained for reorg detection', 0),
+    ('request_log', 'retention_days', 'number', 30, 'Days archived request logs are kept before deletion', 0),
+    ('request_log', 'max_body_bytes', 'number', 16384, 'Maximum request/response body bytes archived per request', 0),
+    ('kyc', 'verification_expiry_days', 'number', 365, 'Days until KYC verification expires', 0),
+    ('kyc', 'max_daily_verifications', 'number', 1000, 'Maximum KYC verifications per day', 0);
+
+INSERT OR IGNORE INTO app_config (namespace, config_key, value_type, value_string, description, chain_id) VALUES
+    ('nft', 'base_uri', 'string', 'https://nexus.dapp.academy/metadata/', 'NFT metadata base URI', 0),
+    ('nft', 'contract_uri', 'string', 'https://nexus.dapp.academy/contract-metadata.json', 'OpenSea collection metadata URI', 0),
+    ('nft', 'royalty_receiver', 'address', '0xFc9019b7e35A480445b6Ea50Ab9049dca20695Ab', 'Royalty payment receiver address', 0),
+    ('token', 'treasury_address', 'address', '0xFc9019b7e35A480445b6Ea50Ab9049dca20695Ab', 'Treasury wallet address', 0),
+    ('api', 'metadata_base_url', 'string', 'https://nexus.dapp.academy', 'Base URL for metadata and API', 0),
+    ('api', 'cors_origins', 'json', '["https://nexus.dapp.academy", "http://localhost:3000"]', 'Allowed CORS origins (JSON array)', 0),
+    ('contracts', 'call_allowlist', 'json', '{"nexusToken": ["name", "symbol", "decimals", "totalSupply", "balanceOf", "allowance", "getVotes", "delegates"], "nexusStaking": ["getVotingPower", "getPendingUnbonding", "getWithdrawableUnbonding", "canInitiateUnbonding", "getEpochInfo"], "nexusNFT": ["name", "symbol", "totalSupply", "balanceOf", "ownerOf", "tokenURI", "remainingSupply", "getMintInfo"], "nexusKYC": ["isCompliant", "getKYCLevel", "isWhitelisted", "isBlacklisted", "isKYCExpired"], "nexusGovernor": ["state", "proposalThreshold", "quorum", "votingDelay", "votingPeriod", "canPropose"], "nexusForwarder": ["getNonce", "isTargetAllowed", "paused"]}', 'View methods callable via POST /api/v1/contracts/:chainId/:name/call (JSON object of contract -> methods)', 0),
+    ('kyc', 'sumsub_base_url', 'string', 'https://api.sumsub.com', 'Sumsub API base URL', 0),
+    ('kyc', 'sumsub_level_name', 'string', 'basic-kyc-level', 'Sumsub KYC verification level name', 0);
+
+INSERT OR IGNORE INTO app_config (namespace, config_key, value_type, value_boolean, description, chain_id) VALUES
+    ('kyc', 'require_kyc_for_staking', 'boolean', FALSE, 'Require KYC to stake tokens', 0),
+    ('kyc', 'require_kyc_for_governance', 'boolean', FALSE, 'Require KYC to participate in governance', 0);
+
+INSERT OR IGNORE INTO network_config (chain_id, network_name, display_name, rpc_url, rpc_fallback_urls, ws_url, explorer_url, default_deployer, is_testnet, is_active) VALUES
+    (31337, 'localhost', 'Local Development (Anvil)', 'http://localhost:8545', '[]', 'ws://localhost:8545', NULL, '0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266', TRUE, TRUE),
+    (11155111, 'sepolia', 'Sepolia Testnet', NULL, '["https://ethereum-sepolia-rpc.publicnode.com", "https://sepolia.drpc.org"]', NULL, 'https://sepolia.etherscan.io', NULL, TRUE, TRUE),
+    (1, 'mainnet', 'Ethereum Mainnet', NULL, '["https://ethereum-rpc.publicnode.com", "https://eth.drpc.org"]', NULL, 'https://etherscan.io', NULL, FALSE, FALSE),
+    (137, 'polygon', 'Polygon Mainnet', 'https://polygon-rpc.com', '["https://polygon-bor-rpc.publicnode.com"]', NULL, 'https://polygonscan.com', NULL, FALSE, FALSE),
+    (80002, 'amoy', 'Polygon Amoy Testnet', 'https://rpc-amoy.polygon.technology', '[]', NULL, 'https://amoy.polygonscan.com', NULL, TRUE, FALSE),
+    (42161, 'arbitrum', 'Arbitrum One', 'https://arb1.arbitrum.io/rpc', '["https://arbitrum-one-rpc.publicnode.com"]', NULL, 'https://arbiscan.io', NULL, FALSE, FALSE),
+    (421614, 'arbitrum-sepolia', 'Arbitrum Sepolia', 'https://sepolia-rollup.arbitrum.io/rpc', '[]', NULL, 'https://sepolia.arbiscan.io', NULL, TRUE, FALSE),
+    (10, 'optimism', 'Optimism', 'https://mainnet.optimism.io', '["https://optimism-rpc.publicnode.com"]', NULL, 'https://optimistic.etherscan.io', NULL, FALSE, FALSE),
+    (11155420, 'optimism-sepolia', 'Optimism Sepolia', 'https://sepolia.optimism.io', '[]', NULL, 'https://sepolia-optimism.etherscan.io', NULL, TRUE, FALSE),
+    (8453, 'base', 'Base', 'https://mainnet.base.org', '["https://base-rpc.publicnode.com"]', NULL, 'https://basescan.org', NULL, FALSE, FALSE);
+
+INSERT OR IGNORE INTO contract_mappings (solidity_name, db_name, display_name, category, description, is_required, sort_order) VALUES
+    ('NexusToken', 'nexusToken', 'Nexus Token', 'core', 'ERC-20 governance token with snapshot, permit, votes', TRUE, 1),
+    ('NexusStaking', 'nexusStaking', 'Nexus Staking', 'defi', 'Token staking with rewards and delegation', TRUE, 2),
+    ('NexusNFT', 'nexusNFT', 'Nexus NFT', 'core', 'ERC-721A NFT collection with royalties', TRUE, 3),
+    ('NexusAccessControl', 'nexusAccessControl', 'Access Control', 'security', 'Role-based access control (ADMIN, OPERATOR, COMPLIANCE, PAUSER)', TRUE, 4),
+    ('NexusKYCRegistry', 'nexusKYC', 'KYC Registry', 'security', 'Whitelist/blacklist management for compliance', TRUE, 5),
+    ('NexusEmergency', 'nexusEmergency', 'Emergency', 'security', 'Circuit breakers and global pause functionality', TRUE, 6),
+    ('NexusTimelock', 'nexusTimelock', 'Timelock', 'governance', 'Governance execution delay (24h minimum)', TRUE, 7),
+    ('NexusGovernor', 'nexusGovernor', 'Governor', 'governance', 'DAO governance with proposal/vote system', TRUE, 8),
+    ('NexusForwarder', 'nexusForwarder', 'Forwarder', 'metatx', 'ERC-2771 meta-transactions for gasless UX', FALSE, 9),
+    ('RewardsDistributor', 'rewardsDistributor', 'Rewards Distributor', 'defi', 'Merkle-based reward distribution', FALSE, 10);
@@ -0,0 +1,60 @@
+-- Payment & meta-transaction archive
+-- Mirrors payments_archive / meta_transactions_archive in
+-- infrastructure/docker/init-db.sql. Rows are moved here by the archiver once
+-- they fall outside the retention window (app_config namespace 'retention').
+
+CREATE TABLE IF NOT EXISTS payments_archive (
+    id TEXT PRIMARY KEY,
+    service_code VARCHAR(50) NOT NULL,
+    pricing_id TEXT,
+    payer_address VARCHAR(42) NOT NULL,
+    payment_method VARCHAR(20) NOT NULL,
+    amount_charged REAL NOT NULL,
+    currency VARCHAR(10) NOT NULL,
+    amount_usd REAL,
+    tx_hash VARCHAR(66),
+    stripe_payment_id VARCHAR(100),
+    stripe_session_id VARCHAR(100),
+    status VARCHAR(20) NOT NULL,
+    error_message TEXT,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    completed_at TIMESTAMP,
+    archived_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now'))
+);
+
+CREATE INDEX idx_payments_archive_payer ON payments_archive(payer_address);
+CREATE INDEX idx_payments_archive_created ON payments_archive(created_at);
+
+CREATE TABLE IF NOT EXISTS meta_transactions_archive (
+    id TEXT PRIMARY KEY,
+    from_address VARCHAR(42) NOT NULL,
+    to_address VARCHAR(42) NOT NULL,
+    function_name VARCHAR(100) NOT NULL,
+    calldata TEXT NOT NULL,
+    value TEXT NOT NULL DEFAULT '0',
+    gas_limit BIGINT NOT NULL,
+    nonce BIGINT NOT NULL,
+    deadline TIMESTAMP NOT NULL,
+    signature TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    tx_hash VARCHAR(66),
+    gas_used BIGINT,
+    gas_price TEXT,
+    relay_cost_eth TEXT,
+    error_message TEXT,
+    retry_count INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    submitted_at TIMESTAMP,
+    confirmed_at TIMESTAMP,
+    archived_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now'))
+);
+
+CREATE INDEX idx_meta_tx_archive_from ON meta_transactions_archive(from_address);
+CREATE INDEX idx_meta_tx_archive_created ON meta_transactions_archive(created_at);
+
+INSERT OR IGNORE INTO app_config (namespace, config_key, value_type, value_number, description, chain_id) VALUES
+    ('retention', 'payments_months', 'number', 24, 'Months before settled payments move to payments_archive (0 disables)', 0),
+    ('retention', 'meta_tx_months', 'number', 6, 'Months before finished meta-transactions move to meta_transactions_archive (0 disables)', 0),
+    ('retention', 'batch_size', 'number', 1000, 'Rows moved per archive statement', 0);
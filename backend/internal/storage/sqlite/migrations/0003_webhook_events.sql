@@ -0,0 +1,22 @@
+-- Inbound webhook events
+-- Mirrors webhook_events in infrastructure/docker/init-db.sql. Each provider
+-- delivery is stored once per (provider, event_id) so redeliveries are
+-- recognised and can be replayed from the stored payload.
+
+CREATE TABLE IF NOT EXISTS webhook_events (
+    id TEXT PRIMARY KEY DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+    provider VARCHAR(20) NOT NULL,
+    event_id VARCHAR(255) NOT NULL,
+    event_type VARCHAR(100) NOT NULL,
+    payload TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'received',
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    updated_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    processed_at TIMESTAMP,
+    UNIQUE (provider, event_id),
+    CHECK (status IN ('received', 'processing', 'processed', 'failed'))
+);
+
+CREATE INDEX idx_webhook_events_status ON webhook_events(provider, status, created_at);
@@ -0,0 +1,40 @@
+-- Background webhook retries
+-- Adds next_attempt_at scheduling and the dead_letter status for events that
+-- exhausted their retries. SQLite cannot alter a CHECK constraint, so the
+-- table is rebuilt.
+
+CREATE TABLE webhook_events_new (
+    id TEXT PRIMARY KEY DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+    provider VARCHAR(20) NOT NULL,
+    event_id VARCHAR(255) NOT NULL,
+    event_type VARCHAR(100) NOT NULL,
+    payload TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'received',
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    updated_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    processed_at TIMESTAMP,
+    next_attempt_at TIMESTAMP DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    UNIQUE (provider, event_id),
+    CHECK (status IN ('received', 'processing', 'processed', 'failed', 'dead_letter'))
+);
+
+INSERT INTO webhook_events_new (
+    id, provider, event_id, event_type, payload, status, attempts, last_error,
+    created_at, updated_at, processed_at, next_attempt_at
+)
+SELECT id, provider, event_id, event_type, payload, status, attempts, last_error,
+       created_at, updated_at, processed_at, NULL
+FROM webhook_events;
+
+DROP TABLE webhook_events;
+ALTER TABLE webhook_events_new RENAME TO webhook_events;
+
+CREATE INDEX idx_webhook_events_status ON webhook_events(provider, status, created_at);
+CREATE INDEX idx_webhook_events_due ON webhook_events(provider, next_attempt_at);
+
+INSERT OR IGNORE INTO app_config (namespace, config_key, value_type, value_number, description, chain_id) VALUES
+    ('webhooks', 'max_attempts', 'number', 8, 'Processing attempts before a webhook event is dead-lettered', 0),
+    ('webhooks', 'retry_base_seconds', 'number', 30, 'Delay before the first retry; doubles on each further attempt', 0),
+    ('webhooks', 'retry_max_seconds', 'number', 3600, 'Upper bound on the delay between retries', 0);
@@ -0,0 +1,5 @@
+-- Maintenance mode switch (toggled via PUT /api/v1/admin/maintenance)
+
+INSERT OR IGNORE INTO app_config (namespace, config_key, value_type, value_boolean, value_string, description, chain_id) VALUES
+    ('maintenance', 'enabled', 'boolean', 0, NULL, 'Return 503 for non-admin API routes', 0),
+    ('maintenance', 'message', 'string', NULL, 'The service is down for scheduled maintenance. Please try again shortly.', 'Message returned while in maintenance mode', 0);
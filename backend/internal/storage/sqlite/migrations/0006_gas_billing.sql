@@ -0,0 +1,46 @@
+-- Sponsored-gas billing
+-- Mirrors gas_charges / gas_settlements in infrastructure/docker/init-db.sql.
+-- Amounts are NEXUS base units stored as decimal text (they exceed 64 bits).
+
+CREATE TABLE IF NOT EXISTS gas_charges (
+    id TEXT PRIMARY KEY DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+    meta_tx_id TEXT NOT NULL UNIQUE,
+    user_address VARCHAR(42) NOT NULL,
+    gas_used BIGINT NOT NULL,
+    gas_price TEXT NOT NULL,
+    cost_wei TEXT NOT NULL,
+    nexus_per_eth TEXT NOT NULL,
+    markup_percent INT NOT NULL DEFAULT 0,
+    amount_nexus TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now'))
+);
+
+CREATE INDEX idx_gas_charges_user ON gas_charges(user_address, created_at);
+
+CREATE TABLE IF NOT EXISTS gas_settlements (
+    id TEXT PRIMARY KEY DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+    user_address VARCHAR(42) NOT NULL,
+    method VARCHAR(20) NOT NULL,
+    amount_nexus TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    tx_hash VARCHAR(66) UNIQUE,
+    token_address VARCHAR(42),
+    treasury_address VARCHAR(42),
+    invoice_ref VARCHAR(100),
+    error_message TEXT,
+    created_by VARCHAR(100),
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    updated_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    settled_at TIMESTAMP,
+    CHECK (method IN ('onchain', 'invoice')),
+    CHECK (status IN ('pending', 'invoiced', 'settled', 'failed', 'void'))
+);
+
+CREATE INDEX idx_gas_settlements_user ON gas_settlements(user_address, created_at);
+
+INSERT OR IGNORE INTO app_config (namespace, config_key, value_type, value_boolean, value_number, value_wei, value_string, description, chain_id) VALUES
+    ('gas_billing', 'enabled', 'boolean', 0, NULL, NULL, NULL, 'Charge the gas of relayed meta-transactions to their signers in NEXUS', 0),
+    ('gas_billing', 'nexus_per_eth', 'wei', NULL, NULL, '0', NULL, 'Fixed-rate price oracle: NEXUS base units per 1 ETH (0 disables charging)', 0),
+    ('gas_billing', 'markup_percent', 'number', NULL, 0, NULL, NULL, 'Markup added to the converted gas cost, in percent', 0),
+    ('gas_billing', 'max_outstanding', 'wei', NULL, NULL, '0', NULL, 'Refuse to relay for users owing more than this many NEXUS base units (0 = no limit)', 0),
+    ('gas_billing', 'treasury_address', 'address', NULL, NULL, NULL, NULL, 'Recipient of on-chain gas debt settlements', 0);
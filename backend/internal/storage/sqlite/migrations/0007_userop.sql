@@ -0,0 +1,9 @@
+-- ERC-4337 UserOperation forwarding policy (the fee cap is relayer.max_gas_price_gwei)
+
+INSERT OR IGNORE INTO app_config (namespace, config_key, value_type, value_boolean, value_number, value_string, description, chain_id) VALUES
+    ('userop', 'enabled', 'boolean', 0, NULL, NULL, 'Accept UserOperations at POST /api/v1/userops and forward them to BUNDLER_URL', 0),
+    ('userop', 'entry_point', 'address', NULL, NULL, '0x5FF137D4b0FDCD49DcA30c7CF57E578a026d2789', 'EntryPoint v0.6 the bundler submits to', 0),
+    ('userop', 'paymaster_address', 'address', NULL, NULL, NULL, 'Paymaster that must sponsor forwarded operations', 0),
+    ('userop', 'max_call_gas_limit', 'number', NULL, 2000000, NULL, 'Maximum callGasLimit (0 = no limit)', 0),
+    ('userop', 'max_verification_gas_limit', 'number', NULL, 1000000, NULL, 'Maximum verificationGasLimit (0 = no limit)', 0),
+    ('userop', 'max_pre_verification_gas', 'number', NULL, 200000, NULL, 'Maximum preVerificationGas (0 = no limit)', 0);
@@ -0,0 +1,4 @@
+-- EIP-712 domain of contracts that verify typed signatures (the forwarder)
+
+ALTER TABLE contract_addresses ADD COLUMN eip712_name VARCHAR(100);
+ALTER TABLE contract_addresses ADD COLUMN eip712_version VARCHAR(20);
@@ -0,0 +1,5 @@
+-- Relay pause switch (toggled via POST /api/v1/admin/relay/pause and /resume)
+
+INSERT OR IGNORE INTO app_config (namespace, config_key, value_type, value_boolean, value_string, description, chain_id) VALUES
+    ('relay_pause', 'enabled', 'boolean', 0, NULL, 'Return 503 for new meta-transaction relays', 0),
+    ('relay_pause', 'message', 'string', NULL, 'Relaying is paused. Please try again later.', 'Reason returned while relaying is paused', 0);
@@ -0,0 +1,5 @@
+-- Relay queue priority classes and gas-spike hold
+
+INSERT OR IGNORE INTO app_config (namespace, config_key, value_type, value_number, value_string, description, chain_id) VALUES
+    ('relay_queue', 'contract_priorities', 'json', NULL, '{"nexusKYC": "high"}', 'Relay priority class by target contract (JSON object of contract -> high/normal/low; others are normal)', 0),
+    ('relay_queue', 'defer_low_priority_above_gwei', 'number', 0, NULL, 'Hold low priority relays while the gas price is above this (0 = never)', 0);
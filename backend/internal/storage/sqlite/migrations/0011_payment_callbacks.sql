@@ -0,0 +1,7 @@
+-- Payment status notifications to the originating dapp
+-- Mirrors payments.callback_url / callback_secret in
+-- infrastructure/docker/init-db.sql. Deliveries are queued as webhook events
+-- (provider 'payment_callback').
+
+ALTER TABLE payments ADD COLUMN callback_url TEXT;
+ALTER TABLE payments ADD COLUMN callback_secret TEXT;
@@ -0,0 +1,43 @@
+-- Deposit-address crypto payments
+-- Mirrors deposit_intents in infrastructure/docker/init-db.sql. SQLite has no
+-- sequences, so derivation indexes come from a one-row counter. Amounts are
+-- base units stored as decimal text (they exceed 64 bits).
+
+CREATE TABLE IF NOT EXISTS deposit_index_counter (
+    id INTEGER PRIMARY KEY CHECK (id = 1),
+    next_index INTEGER NOT NULL
+);
+
+INSERT OR IGNORE INTO deposit_index_counter (id, next_index) VALUES (1, 0);
+
+CREATE TABLE IF NOT EXISTS deposit_intents (
+    id TEXT PRIMARY KEY DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+    payment_id TEXT NOT NULL UNIQUE,
+    payer_address VARCHAR(42) NOT NULL,
+    currency VARCHAR(10) NOT NULL,
+    token_address VARCHAR(42),
+    deposit_address VARCHAR(42) NOT NULL UNIQUE,
+    derivation_index INTEGER NOT NULL UNIQUE,
+    expected_amount TEXT NOT NULL,
+    received_amount TEXT NOT NULL DEFAULT '0',
+    status VARCHAR(20) NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    updated_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    detected_at TIMESTAMP,
+    paid_at TIMESTAMP,
+    sweep_tx_hash VARCHAR(66),
+    sweep_attempted_at TIMESTAMP,
+    swept_at TIMESTAMP,
+    CHECK (currency IN ('ETH', 'NEXUS')),
+    CHECK (status IN ('awaiting', 'confirming', 'paid', 'underpaid', 'swept', 'expired'))
+);
+
+CREATE INDEX idx_deposit_intents_payer ON deposit_intents(payer_address, created_at);
+CREATE INDEX idx_deposit_intents_status ON deposit_intents(status, created_at);
+
+INSERT OR IGNORE INTO app_config (namespace, config_key, value_type, value_number, value_string, description, chain_id) VALUES
+    ('deposits', 'expiry_minutes', 'number', 60, NULL, 'Minutes a deposit address waits for its transfer before the payment expires', 0),
+    ('deposits', 'tolerance_percent', 'number', 1, NULL, 'Accept deposits short of the expected amount by up to this percent', 0),
+    ('deposits', 'sweep_retry_minutes', 'number', 10, NULL, 'Minutes to wait for a sweep transaction before sending another', 0),
+    ('deposits', 'treasury_address', 'address', NULL, NULL, 'Recipient of swept deposits (unset = funds stay on the deposit addresses)', 0);
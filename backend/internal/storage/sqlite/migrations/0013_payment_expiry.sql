@@ -0,0 +1,63 @@
+-- Payment expiry
+-- Mirrors the 'expired' payment status and app_config namespace
+-- 'payment_expiry' in infrastructure/docker/init-db.sql. SQLite cannot alter
+-- a CHECK constraint, so payments is rebuilt under its own name (a renamed
+-- copy would take kyc_verifications' foreign key with it); that foreign key
+-- is checked at commit, once the rows are back.
+
+PRAGMA defer_foreign_keys = ON;
+
+CREATE TEMP TABLE payments_backup AS SELECT * FROM payments;
+DROP TABLE payments;
+
+CREATE TABLE payments (
+    id TEXT PRIMARY KEY DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+    service_code VARCHAR(50) NOT NULL,
+    pricing_id TEXT REFERENCES pricing(id),
+    payer_address VARCHAR(42) NOT NULL,
+    payment_method VARCHAR(20) NOT NULL,
+    amount_charged REAL NOT NULL,
+    currency VARCHAR(10) NOT NULL,
+    amount_usd REAL,
+    tx_hash VARCHAR(66),
+    stripe_payment_id VARCHAR(100),
+    stripe_session_id VARCHAR(100),
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    error_message TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    updated_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    completed_at TIMESTAMP,
+    callback_url TEXT,
+    callback_secret TEXT,
+    CONSTRAINT valid_payment_status CHECK (status IN ('pending', 'processing', 'completed', 'failed', 'refunded', 'cancelled', 'expired'))
+);
+
+INSERT INTO payments (
+    id, service_code, pricing_id, payer_address, payment_method,
+    amount_charged, currency, amount_usd, tx_hash,
+    stripe_payment_id, stripe_session_id, status, error_message,
+    created_at, updated_at, completed_at, callback_url, callback_secret
+)
+SELECT id, service_code, pricing_id, payer_address, payment_method,
+       amount_charged, currency, amount_usd, tx_hash,
+       stripe_payment_id, stripe_session_id, status, error_message,
+       created_at, updated_at, completed_at, callback_url, callback_secret
+FROM payments_backup;
+
+DROP TABLE payments_backup;
+
+CREATE INDEX idx_payments_payer ON payments(payer_address);
+CREATE INDEX idx_payments_status ON payments(status);
+CREATE INDEX idx_payments_created ON payments(created_at);
+CREATE INDEX idx_payments_stripe_session ON payments(stripe_session_id);
+
+CREATE TRIGGER update_payments_updated_at AFTER UPDATE ON payments
+    FOR EACH ROW WHEN NEW.updated_at IS OLD.updated_at
+BEGIN
+    UPDATE payments SET updated_at = strftime('%Y-%m-%d %H:%M:%f+00:00', 'now') WHERE id = NEW.id;
+END;
+
+INSERT OR IGNORE INTO app_config (namespace, config_key, value_type, value_number, description, chain_id) VALUES
+    ('payment_expiry', 'stripe_minutes', 'number', 1440, 'Minutes a Stripe checkout may stay unpaid before its payment expires (0 disables)', 0),
+    ('payment_expiry', 'crypto_minutes', 'number', 1440, 'Minutes an ETH/NEXUS payment may stay pending before it expires; keep above deposits.expiry_minutes (0 disables)', 0),
+    ('payment_expiry', 'batch_size', 'number', 500, 'Payments expired per statement', 0);
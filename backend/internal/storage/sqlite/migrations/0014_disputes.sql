@@ -0,0 +1,111 @@
+-- Stripe disputes
+-- Mirrors the dispute columns, the 'disputed' payment status, the 'suspended'
+-- KYC status and app_config namespace 'disputes' in
+-- infrastructure/docker/init-db.sql. Both CHECK constraints change, so
+-- payments and kyc_verifications are rebuilt under their own names as in
+-- 0013_payment_expiry.sql.
+
+PRAGMA defer_foreign_keys = ON;
+
+CREATE TEMP TABLE kyc_verifications_backup AS SELECT * FROM kyc_verifications;
+CREATE TEMP TABLE payments_backup AS SELECT * FROM payments;
+DROP TABLE kyc_verifications;
+DROP TABLE payments;
+
+CREATE TABLE payments (
+    id TEXT PRIMARY KEY DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+    service_code VARCHAR(50) NOT NULL,
+    pricing_id TEXT REFERENCES pricing(id),
+    payer_address VARCHAR(42) NOT NULL,
+    payment_method VARCHAR(20) NOT NULL,
+    amount_charged REAL NOT NULL,
+    currency VARCHAR(10) NOT NULL,
+    amount_usd REAL,
+    tx_hash VARCHAR(66),
+    stripe_payment_id VARCHAR(100),
+    stripe_session_id VARCHAR(100),
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    error_message TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    updated_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    completed_at TIMESTAMP,
+    callback_url TEXT,
+    callback_secret TEXT,
+    dispute_id VARCHAR(100),
+    dispute_status VARCHAR(30),
+    dispute_reason VARCHAR(50),
+    disputed_at TIMESTAMP,
+    CONSTRAINT valid_payment_status CHECK (status IN ('pending', 'processing', 'completed', 'failed', 'refunded', 'cancelled', 'expired', 'disputed'))
+);
+
+INSERT INTO payments (
+    id, service_code, pricing_id, payer_address, payment_method,
+    amount_charged, currency, amount_usd, tx_hash,
+    stripe_payment_id, stripe_session_id, status, error_message,
+    created_at, updated_at, completed_at, callback_url, callback_secret
+)
+SELECT id, service_code, pricing_id, payer_address, payment_method,
+       amount_charged, currency, amount_usd, tx_hash,
+       stripe_payment_id, stripe_session_id, status, error_message,
+       created_at, updated_at, completed_at, callback_url, callback_secret
+FROM payments_backup;
+
+CREATE TABLE kyc_verifications (
+    id TEXT PRIMARY KEY DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+    payment_id TEXT REFERENCES payments(id),
+    user_address VARCHAR(42) NOT NULL,
+    sumsub_applicant_id VARCHAR(100),
+    sumsub_inspection_id VARCHAR(100),
+    sumsub_review_status VARCHAR(50),
+    sumsub_review_result TEXT,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    whitelist_tx_hash VARCHAR(66),
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    updated_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    submitted_at TIMESTAMP,
+    verified_at TIMESTAMP,
+    rejected_at TIMESTAMP,
+    CONSTRAINT valid_kyc_status CHECK (status IN ('pending', 'payment_required', 'submitted', 'in_review', 'approved', 'rejected', 'expired', 'suspended'))
+);
+
+INSERT INTO kyc_verifications (
+    id, payment_id, user_address, sumsub_applicant_id, sumsub_inspection_id,
+    sumsub_review_status, sumsub_review_result, status, whitelist_tx_hash,
+    created_at, updated_at, submitted_at, verified_at, rejected_at
+)
+SELECT id, payment_id, user_address, sumsub_applicant_id, sumsub_inspection_id,
+       sumsub_review_status, sumsub_review_result, status, whitelist_tx_hash,
+       created_at, updated_at, submitted_at, verified_at, rejected_at
+FROM kyc_verifications_backup;
+
+DROP TABLE kyc_verifications_backup;
+DROP TABLE payments_backup;
+
+CREATE INDEX idx_payments_payer ON payments(payer_address);
+CREATE INDEX idx_payments_status ON payments(status);
+CREATE INDEX idx_payments_created ON payments(created_at);
+CREATE INDEX idx_payments_stripe_session ON payments(stripe_session_id);
+CREATE INDEX idx_payments_stripe_payment ON payments(stripe_payment_id);
+
+CREATE INDEX idx_kyc_verifications_user ON kyc_verifications(user_address);
+CREATE INDEX idx_kyc_verifications_sumsub ON kyc_verifications(sumsub_applicant_id);
+
+CREATE TRIGGER update_payments_updated_at AFTER UPDATE ON payments
+    FOR EACH ROW WHEN NEW.updated_at IS OLD.updated_at
+BEGIN
+    UPDATE payments SET updated_at = strftime('%Y-%m-%d %H:%M:%f+00:00', 'now') WHERE id = NEW.id;
+END;
+
+CREATE TRIGGER update_kyc_verifications_updated_at AFTER UPDATE ON kyc_verifications
+    FOR EACH ROW WHEN NEW.updated_at IS OLD.updated_at
+BEGIN
+    UPDATE kyc_verifications SET updated_at = strftime('%Y-%m-%d %H:%M:%f+00:00', 'now') WHERE id = NEW.id;
+END;
+
+ALTER TABLE payments_archive ADD COLUMN dispute_id VARCHAR(100);
+ALTER TABLE payments_archive ADD COLUMN dispute_status VARCHAR(30);
+ALTER TABLE payments_archive ADD COLUMN dispute_reason VARCHAR(50);
+ALTER TABLE payments_archive ADD COLUMN disputed_at TIMESTAMP;
+
+INSERT OR IGNORE INTO app_config (namespace, config_key, value_type, value_string, description, chain_id) VALUES
+    ('disputes', 'compliance_webhook_url', 'string', NULL, 'Receives a signed alert when a payment is disputed or its dispute closes (unset = alerts are only logged)', 0);
@@ -0,0 +1,52 @@
+-- Double-entry ledger
+-- Mirrors ledger_transactions and ledger_entries in
+-- infrastructure/docker/init-db.sql, including the triggers refusing changes
+-- to posted rows. Amounts are base units stored as decimal text (they exceed
+-- 64 bits).
+
+CREATE TABLE IF NOT EXISTS ledger_transactions (
+    id TEXT PRIMARY KEY DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+    idempotency_key VARCHAR(200) NOT NULL UNIQUE,
+    event_type VARCHAR(50) NOT NULL,
+    payment_id TEXT,
+    description TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now'))
+);
+
+CREATE INDEX idx_ledger_transactions_payment ON ledger_transactions(payment_id);
+
+CREATE TABLE IF NOT EXISTS ledger_entries (
+    id TEXT PRIMARY KEY DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+    transaction_id TEXT NOT NULL REFERENCES ledger_transactions(id),
+    account VARCHAR(30) NOT NULL,
+    direction VARCHAR(6) NOT NULL,
+    amount TEXT NOT NULL,
+    currency VARCHAR(10) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    CHECK (account IN ('customer_funds', 'disputed_funds', 'revenue', 'fees', 'refunds', 'provider_costs', 'provider_payable')),
+    CHECK (direction IN ('debit', 'credit'))
+);
+
+CREATE INDEX idx_ledger_entries_transaction ON ledger_entries(transaction_id);
+CREATE INDEX idx_ledger_entries_account ON ledger_entries(account, currency, created_at);
+CREATE INDEX idx_ledger_entries_created ON ledger_entries(created_at);
+
+CREATE TRIGGER ledger_transactions_no_update BEFORE UPDATE ON ledger_transactions
+BEGIN
+    SELECT RAISE(ABORT, 'ledger rows are immutable: post a reversing transaction instead');
+END;
+
+CREATE TRIGGER ledger_transactions_no_delete BEFORE DELETE ON ledger_transactions
+BEGIN
+    SELECT RAISE(ABORT, 'ledger rows are immutable: post a reversing transaction instead');
+END;
+
+CREATE TRIGGER ledger_entries_no_update BEFORE UPDATE ON ledger_entries
+BEGIN
+    SELECT RAISE(ABORT, 'ledger rows are immutable: post a reversing transaction instead');
+END;
+
+CREATE TRIGGER ledger_entries_no_delete BEFORE DELETE ON ledger_entries
+BEGIN
+    SELECT RAISE(ABORT, 'ledger rows are immutable: post a reversing transaction instead');
+END;
@@ -0,0 +1,40 @@
+-- Payouts
+-- Mirrors payouts and app_config namespace 'payouts' in
+-- infrastructure/docker/init-db.sql. Amounts are base units stored as
+-- decimal text (they exceed 64 bits).
+
+CREATE TABLE IF NOT EXISTS payouts (
+    id TEXT PRIMARY KEY DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+    kind VARCHAR(20) NOT NULL,
+    recipient VARCHAR(42) NOT NULL,
+    currency VARCHAR(10) NOT NULL,
+    token_address VARCHAR(42),
+    amount TEXT NOT NULL,
+    reference VARCHAR(100),
+    description TEXT NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL,
+    scheduled_for TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    created_by VARCHAR(100) NOT NULL,
+    reviewed_by VARCHAR(100),
+    reviewed_at TIMESTAMP,
+    sender_address VARCHAR(42),
+    tx_hash VARCHAR(66) UNIQUE,
+    block_number INTEGER,
+    gas_used INTEGER,
+    fee_wei TEXT,
+    error_message TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    updated_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    submitted_at TIMESTAMP,
+    confirmed_at TIMESTAMP,
+    CHECK (kind IN ('royalty', 'grant')),
+    CHECK (currency IN ('ETH', 'NEXUS')),
+    CHECK (status IN ('pending_approval', 'approved', 'rejected', 'cancelled', 'sending', 'submitted', 'confirmed', 'failed'))
+);
+
+CREATE INDEX idx_payouts_status ON payouts(status, scheduled_for);
+CREATE INDEX idx_payouts_recipient ON payouts(recipient, created_at);
+
+INSERT OR IGNORE INTO app_config (namespace, config_key, value_type, value_boolean, value_string, description, chain_id) VALUES
+    ('payouts', 'enabled', 'boolean', 0, NULL, 'Send approved payouts from the relayer keys when they fall due (the keys must hold the funds)', 0),
+    ('payouts', 'royalty_receiver', 'address', NULL, NULL, 'Recipient of royalty payouts created without one: the NFT royaltyReceiver', 0);
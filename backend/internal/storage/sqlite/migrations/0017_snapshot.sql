@@ -0,0 +1,32 @@
+-- Snapshot proposals
+-- Mirrors snapshot_proposals and app_config namespace 'snapshot' in
+-- infrastructure/docker/init-db.sql. Choices and scores are JSON arrays.
+
+CREATE TABLE IF NOT EXISTS snapshot_proposals (
+    id VARCHAR(100) PRIMARY KEY,
+    space VARCHAR(100) NOT NULL,
+    title TEXT NOT NULL,
+    body TEXT NOT NULL DEFAULT '',
+    author VARCHAR(42) NOT NULL,
+    choices TEXT NOT NULL DEFAULT '[]',
+    scores TEXT NOT NULL DEFAULT '[]',
+    scores_total REAL NOT NULL DEFAULT 0,
+    votes INTEGER NOT NULL DEFAULT 0,
+    state VARCHAR(20) NOT NULL,
+    snapshot_block VARCHAR(30) NOT NULL DEFAULT '',
+    link TEXT NOT NULL DEFAULT '',
+    onchain_proposal_id VARCHAR(100),
+    start_time TIMESTAMP NOT NULL,
+    end_time TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    synced_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    CHECK (state IN ('pending', 'active', 'closed'))
+);
+
+CREATE INDEX idx_snapshot_proposals_space ON snapshot_proposals(space, created_at);
+CREATE INDEX idx_snapshot_proposals_onchain ON snapshot_proposals(onchain_proposal_id) WHERE onchain_proposal_id IS NOT NULL;
+
+INSERT OR IGNORE INTO app_config (namespace, config_key, value_type, value_boolean, value_string, description, chain_id) VALUES
+    ('snapshot', 'enabled', 'boolean', 0, NULL, 'Mirror the space''s Snapshot proposals and results in the background', 0),
+    ('snapshot', 'space', 'string', NULL, NULL, 'Snapshot space ID to mirror, e.g. nexusprotocol.eth', 0),
+    ('snapshot', 'hub_url', 'string', NULL, 'https://hub.snapshot.org/graphql', 'Snapshot hub GraphQL endpoint', 0);
@@ -0,0 +1,33 @@
+-- Governance notifications
+-- Mirrors governance_subscriptions, governance_events and app_config
+-- namespace 'governance_notifications' in infrastructure/docker/init-db.sql.
+-- Subscribed event types are a JSON array.
+
+CREATE TABLE IF NOT EXISTS governance_subscriptions (
+    address VARCHAR(42) PRIMARY KEY,
+    webhook_url TEXT NOT NULL,
+    secret TEXT NOT NULL,
+    events TEXT NOT NULL DEFAULT '[]',
+    digest VARCHAR(10) NOT NULL DEFAULT 'none',
+    last_digest_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    updated_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    CHECK (digest IN ('none', 'daily', 'weekly'))
+);
+
+CREATE TABLE IF NOT EXISTS governance_events (
+    id VARCHAR(36) PRIMARY KEY,
+    proposal_id VARCHAR(100) NOT NULL,
+    event_type VARCHAR(30) NOT NULL,
+    title TEXT NOT NULL DEFAULT '',
+    detail TEXT NOT NULL DEFAULT '',
+    occurred_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    UNIQUE (proposal_id, event_type),
+    CHECK (event_type IN ('proposal_opened', 'near_quorum', 'proposal_queued'))
+);
+
+CREATE INDEX idx_governance_events_occurred ON governance_events(occurred_at);
+
+INSERT OR IGNORE INTO app_config (namespace, config_key, value_type, value_boolean, value_number, description, chain_id) VALUES
+    ('governance_notifications', 'enabled', 'boolean', 0, NULL, 'Notify subscribed token holders of proposal lifecycle events', 0),
+    ('governance_notifications', 'near_quorum_percent', 'number', NULL, 80, 'Percent of quorum at which subscribers are told a proposal is near quorum', 0);
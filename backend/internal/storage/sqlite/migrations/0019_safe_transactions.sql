@@ -0,0 +1,47 @@
+-- Safe Transactions
+-- Mirrors safe_transactions / safe_confirmations in
+-- infrastructure/docker/init-db.sql. Values are wei stored as decimal text
+-- (they exceed 64 bits).
+
+CREATE TABLE IF NOT EXISTS safe_transactions (
+    id TEXT PRIMARY KEY DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+    chain_id INTEGER NOT NULL,
+    safe_address VARCHAR(42) NOT NULL,
+    to_address VARCHAR(42) NOT NULL,
+    value TEXT NOT NULL DEFAULT '0',
+    data TEXT NOT NULL DEFAULT '0x',
+    operation INTEGER NOT NULL DEFAULT 0,
+    safe_tx_gas TEXT NOT NULL DEFAULT '0',
+    base_gas TEXT NOT NULL DEFAULT '0',
+    gas_price TEXT NOT NULL DEFAULT '0',
+    gas_token VARCHAR(42) NOT NULL,
+    refund_receiver VARCHAR(42) NOT NULL,
+    nonce INTEGER NOT NULL,
+    safe_tx_hash VARCHAR(66) NOT NULL UNIQUE,
+    threshold INTEGER NOT NULL,
+    confirmations INTEGER NOT NULL DEFAULT 0,
+    status VARCHAR(30) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    proposed_by VARCHAR(100) NOT NULL,
+    executor_address VARCHAR(42),
+    tx_hash VARCHAR(66) UNIQUE,
+    block_number INTEGER,
+    error_message TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    updated_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    submitted_at TIMESTAMP,
+    executed_at TIMESTAMP,
+    CHECK (operation IN (0, 1)),
+    CHECK (status IN ('awaiting_confirmations', 'ready', 'cancelled', 'sending', 'submitted', 'executed', 'failed'))
+);
+
+CREATE INDEX idx_safe_transactions_safe ON safe_transactions(safe_address, nonce);
+CREATE INDEX idx_safe_transactions_status ON safe_transactions(status, created_at);
+
+CREATE TABLE IF NOT EXISTS safe_confirmations (
+    safe_tx_id TEXT NOT NULL REFERENCES safe_transactions(id) ON DELETE CASCADE,
+    owner VARCHAR(42) NOT NULL,
+    signature TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    PRIMARY KEY (safe_tx_id, owner)
+);
@@ -0,0 +1,45 @@
+-- Bridge Transfers
+-- Mirrors bridge_transfers / bridge_cursors in
+-- infrastructure/docker/init-db.sql. Amounts are base units stored as
+-- decimal text (they exceed 64 bits).
+
+CREATE TABLE IF NOT EXISTS bridge_transfers (
+    id TEXT PRIMARY KEY DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+    direction VARCHAR(20) NOT NULL DEFAULT '',
+    sender VARCHAR(42) NOT NULL DEFAULT '',
+    recipient VARCHAR(42) NOT NULL,
+    amount TEXT NOT NULL,
+    source_chain_id INTEGER NOT NULL,
+    dest_chain_id INTEGER NOT NULL,
+    nonce INTEGER,
+    source_status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    source_transfer_id VARCHAR(66),
+    source_tx_hash VARCHAR(66),
+    source_block INTEGER,
+    source_confirmed_at TIMESTAMP,
+    dest_status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    dest_transfer_id VARCHAR(66),
+    dest_tx_hash VARCHAR(66),
+    dest_block INTEGER,
+    dest_unlock_at TIMESTAMP,
+    dest_completed_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    updated_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    UNIQUE (source_chain_id, nonce),
+    CHECK (direction IN ('', 'lock_mint', 'burn_release')),
+    CHECK (source_status IN ('pending', 'confirmed')),
+    CHECK (dest_status IN ('pending', 'queued', 'completed', 'cancelled'))
+);
+
+CREATE INDEX idx_bridge_transfers_sender ON bridge_transfers(sender, created_at DESC);
+CREATE INDEX idx_bridge_transfers_recipient ON bridge_transfers(recipient, created_at DESC);
+CREATE INDEX idx_bridge_transfers_dest_id ON bridge_transfers(dest_transfer_id);
+
+CREATE TABLE IF NOT EXISTS bridge_cursors (
+    chain_id INTEGER PRIMARY KEY,
+    next_block INTEGER NOT NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now'))
+);
+
+INSERT OR IGNORE INTO contract_mappings (solidity_name, db_name, display_name, category, description, is_required, sort_order) VALUES
+    ('NexusBridge', 'nexusBridge', 'Bridge', 'bridge', 'Lock/mint and burn/release NEXUS bridge between chains', FALSE, 11);
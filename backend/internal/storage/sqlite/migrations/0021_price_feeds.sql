@@ -0,0 +1,11 @@
+-- Price Feeds
+-- Mirrors the Chainlink aggregator mappings and oracle settings in
+-- infrastructure/docker/init-db.sql
+
+INSERT OR IGNORE INTO contract_mappings (solidity_name, db_name, display_name, category, description, is_required, sort_order) VALUES
+    ('ChainlinkEthUsd', 'ethUsdPriceFeed', 'ETH/USD Price Feed', 'oracle', 'Chainlink ETH/USD aggregator', FALSE, 12),
+    ('ChainlinkNexusUsd', 'nexusUsdPriceFeed', 'NEXUS/USD Price Feed', 'oracle', 'Chainlink NEXUS/USD aggregator', FALSE, 13);
+
+INSERT OR IGNORE INTO app_config (namespace, config_key, value_type, value_number, description, chain_id) VALUES
+    ('oracle', 'cache_seconds', 'number', 30, 'Seconds a price feed round is served from cache before it is read again', 0),
+    ('oracle', 'max_age_seconds', 'number', 3600, 'Reject price feed rounds last updated longer ago than this', 0);
@@ -0,0 +1,7 @@
+-- ENS Resolution
+-- Mirrors the ens settings in infrastructure/docker/init-db.sql
+
+INSERT OR IGNORE INTO app_config (namespace, config_key, value_type, value_number, value_string, description, chain_id) VALUES
+    ('ens', 'chain_id', 'number', 1, NULL, 'Chain holding the ENS registry', 0),
+    ('ens', 'registry_address', 'address', NULL, '0x00000000000C2E074eC69A0dFb2997BA6C7d2e1e', 'ENS registry contract', 0),
+    ('ens', 'cache_seconds', 'number', 900, NULL, 'Seconds ENS lookups (misses included) are cached', 0);
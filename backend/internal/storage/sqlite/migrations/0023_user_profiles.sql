@@ -0,0 +1,19 @@
+-- User Profiles and Sign-In with Ethereum
+-- Mirrors user_profiles and the auth settings in infrastructure/docker/init-db.sql
+
+CREATE TABLE IF NOT EXISTS user_profiles (
+    address VARCHAR(42) PRIMARY KEY,
+    display_name VARCHAR(50),
+    avatar_chain_id INTEGER,
+    avatar_contract VARCHAR(42),
+    avatar_token_id VARCHAR(78),
+    email TEXT,
+    preferred_currency VARCHAR(10) NOT NULL DEFAULT 'USD',
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    updated_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    CHECK (preferred_currency IN ('USD', 'ETH', 'NEXUS'))
+);
+
+INSERT OR IGNORE INTO app_config (namespace, config_key, value_type, value_number, value_string, description, chain_id) VALUES
+    ('auth', 'nonce_seconds', 'number', 600, NULL, 'Seconds a sign-in nonce stays valid', 0),
+    ('auth', 'session_seconds', 'number', 86400, NULL, 'Seconds a wallet session lasts', 0);
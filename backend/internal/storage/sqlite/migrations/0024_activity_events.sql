@@ -0,0 +1,17 @@
+-- Activity Feed
+-- Mirrors activity_events in infrastructure/docker/init-db.sql; rowid orders
+-- events recorded at the same instant
+
+CREATE TABLE IF NOT EXISTS activity_events (
+    id VARCHAR(36) PRIMARY KEY,
+    address VARCHAR(42) NOT NULL,
+    kind VARCHAR(20) NOT NULL,
+    action VARCHAR(30) NOT NULL,
+    subject_id VARCHAR(100) NOT NULL DEFAULT '',
+    summary TEXT NOT NULL DEFAULT '',
+    tx_hash VARCHAR(66),
+    occurred_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    CHECK (kind IN ('payment', 'kyc', 'nft_transfer', 'vote', 'meta_tx'))
+);
+
+CREATE INDEX idx_activity_events_address ON activity_events(address, occurred_at);
@@ -0,0 +1,38 @@
+-- Scheduled Reports
+-- Mirrors report_schedules and report_runs in
+-- infrastructure/docker/init-db.sql. Recipients are a JSON array.
+
+CREATE TABLE IF NOT EXISTS report_schedules (
+    id VARCHAR(36) PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    kind VARCHAR(20) NOT NULL,
+    cron VARCHAR(100) NOT NULL,
+    recipients TEXT NOT NULL DEFAULT '[]',
+    enabled BOOLEAN NOT NULL DEFAULT 1,
+    created_by VARCHAR(100) NOT NULL,
+    next_run_at TIMESTAMP NOT NULL,
+    last_run_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    updated_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    CHECK (kind IN ('payments', 'kyc', 'relay'))
+);
+
+CREATE INDEX idx_report_schedules_due ON report_schedules(next_run_at) WHERE enabled;
+
+CREATE TABLE IF NOT EXISTS report_runs (
+    id VARCHAR(36) PRIMARY KEY,
+    schedule_id VARCHAR(36) NOT NULL,
+    kind VARCHAR(20) NOT NULL,
+    period_start TIMESTAMP NOT NULL,
+    period_end TIMESTAMP NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    object_key TEXT NOT NULL DEFAULT '',
+    row_count INTEGER NOT NULL DEFAULT 0,
+    manual BOOLEAN NOT NULL DEFAULT 0,
+    error_message TEXT,
+    started_at TIMESTAMP NOT NULL,
+    finished_at TIMESTAMP NOT NULL,
+    CHECK (status IN ('succeeded', 'failed'))
+);
+
+CREATE INDEX idx_report_runs_schedule ON report_runs(schedule_id, started_at);
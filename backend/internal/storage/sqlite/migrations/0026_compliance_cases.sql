@@ -0,0 +1,20 @@
+-- Compliance Cases
+-- Mirrors compliance_cases in infrastructure/docker/init-db.sql
+
+CREATE TABLE IF NOT EXISTS compliance_cases (
+    id VARCHAR(36) PRIMARY KEY,
+    rule VARCHAR(50) NOT NULL,
+    subject VARCHAR(100) NOT NULL,
+    summary TEXT NOT NULL,
+    observed REAL NOT NULL,
+    threshold REAL NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'open',
+    opened_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    closed_at TIMESTAMP,
+    closed_by VARCHAR(100),
+    resolution TEXT,
+    CHECK (status IN ('open', 'closed'))
+);
+
+CREATE UNIQUE INDEX idx_compliance_cases_open ON compliance_cases(rule, subject) WHERE status = 'open';
+CREATE INDEX idx_compliance_cases_opened ON compliance_cases(opened_at);
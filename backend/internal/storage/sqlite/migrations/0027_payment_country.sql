@@ -0,0 +1,6 @@
+-- GeoIP country of the payer's IP
+-- Mirrors payments.country in infrastructure/docker/init-db.sql; the archive
+-- keeps it as the payments columns move there.
+
+ALTER TABLE payments ADD COLUMN country CHAR(2);
+ALTER TABLE payments_archive ADD COLUMN country CHAR(2);
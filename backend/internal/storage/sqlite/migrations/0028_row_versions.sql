@@ -0,0 +1,6 @@
+-- Optimistic concurrency for admin updates
+-- Mirrors pricing.version and payment_methods.version in
+-- infrastructure/docker/init-db.sql.
+
+ALTER TABLE pricing ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE payment_methods ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
@@ -0,0 +1,14 @@
+-- Address Watchlists
+-- Mirrors watchlists in infrastructure/docker/init-db.sql. Addresses and
+-- events are JSON arrays.
+
+CREATE TABLE IF NOT EXISTS watchlists (
+    id VARCHAR(36) PRIMARY KEY,
+    name VARCHAR(100) NOT NULL DEFAULT '',
+    webhook_url TEXT NOT NULL,
+    secret TEXT NOT NULL,
+    addresses TEXT NOT NULL DEFAULT '[]',
+    events TEXT NOT NULL DEFAULT '[]',
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    updated_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now'))
+);
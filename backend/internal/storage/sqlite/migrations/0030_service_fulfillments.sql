@@ -0,0 +1,19 @@
+-- Service Fulfillments
+-- Mirrors service_fulfillments in infrastructure/docker/init-db.sql.
+
+CREATE TABLE IF NOT EXISTS service_fulfillments (
+    id VARCHAR(36) PRIMARY KEY,
+    payment_id VARCHAR(36) NOT NULL UNIQUE,
+    service_code VARCHAR(50) NOT NULL,
+    address VARCHAR(42) NOT NULL,
+    kind VARCHAR(20) NOT NULL,
+    quantity INTEGER NOT NULL DEFAULT 1,
+    valid_from TIMESTAMP,
+    valid_until TIMESTAMP,
+    reference VARCHAR(100),
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+
+    CONSTRAINT valid_fulfillment_kind CHECK (kind IN ('kyc', 'nft_mint_credit', 'api_subscription'))
+);
+
+CREATE INDEX idx_service_fulfillments_address ON service_fulfillments(address, created_at);
@@ -0,0 +1,55 @@
+-- Prepaid Credits
+-- Mirrors entitlements, entitlement_grants, the 'credits' fulfillment kind
+-- and the kyc_verification_10 pricing in infrastructure/docker/init-db.sql.
+-- The kind CHECK changes, so service_fulfillments is rebuilt under its own
+-- name as in 0014_disputes.sql.
+
+CREATE TEMP TABLE service_fulfillments_backup AS SELECT * FROM service_fulfillments;
+DROP TABLE service_fulfillments;
+
+CREATE TABLE service_fulfillments (
+    id VARCHAR(36) PRIMARY KEY,
+    payment_id VARCHAR(36) NOT NULL UNIQUE,
+    service_code VARCHAR(50) NOT NULL,
+    address VARCHAR(42) NOT NULL,
+    kind VARCHAR(20) NOT NULL,
+    quantity INTEGER NOT NULL DEFAULT 1,
+    valid_from TIMESTAMP,
+    valid_until TIMESTAMP,
+    reference VARCHAR(100),
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+
+    CONSTRAINT valid_fulfillment_kind CHECK (kind IN ('kyc', 'nft_mint_credit', 'api_subscription', 'credits'))
+);
+
+INSERT INTO service_fulfillments SELECT * FROM service_fulfillments_backup;
+DROP TABLE service_fulfillments_backup;
+
+CREATE INDEX idx_service_fulfillments_address ON service_fulfillments(address, created_at);
+
+CREATE TABLE IF NOT EXISTS entitlements (
+    address VARCHAR(42) NOT NULL,
+    service_code VARCHAR(50) NOT NULL,
+    granted INTEGER NOT NULL DEFAULT 0,
+    balance INTEGER NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+
+    PRIMARY KEY (address, service_code),
+    CONSTRAINT entitlement_balance_covered CHECK (balance >= 0 AND balance <= granted)
+);
+
+CREATE TABLE IF NOT EXISTS entitlement_grants (
+    id VARCHAR(36) PRIMARY KEY,
+    payment_id VARCHAR(36) NOT NULL UNIQUE,
+    address VARCHAR(42) NOT NULL,
+    service_code VARCHAR(50) NOT NULL,
+    quantity INTEGER NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+
+    CONSTRAINT positive_grant CHECK (quantity > 0)
+);
+
+CREATE INDEX idx_entitlement_grants_address ON entitlement_grants(address, created_at);
+
+INSERT OR IGNORE INTO pricing (service_code, service_name, description, cost_usd, cost_provider, price_usd, price_eth, price_nexus, markup_percent, is_active) VALUES
+    ('kyc_verification_10', 'KYC Verification Credits (10)', 'Ten prepaid KYC identity verifications, spent one per check', 50.00, 'sumsub', 135.00, 0.045, 1350, 170.00, TRUE);
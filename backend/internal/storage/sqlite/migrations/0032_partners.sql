@@ -0,0 +1,41 @@
+-- Partner Revenue Share
+-- Mirrors payments.referrer_code, partners, partner_commissions and app_config
+-- namespace 'partners' in infrastructure/docker/init-db.sql; the archive
+-- keeps referrer_code as the payments columns move there.
+
+ALTER TABLE payments ADD COLUMN referrer_code VARCHAR(32);
+ALTER TABLE payments_archive ADD COLUMN referrer_code VARCHAR(32);
+
+CREATE TABLE IF NOT EXISTS partners (
+    id VARCHAR(36) PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    referral_code VARCHAR(32) NOT NULL UNIQUE,
+    share_percent REAL NOT NULL,
+    api_key_hash VARCHAR(64) NOT NULL UNIQUE,
+    contact_email VARCHAR(255),
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    updated_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+
+    CONSTRAINT valid_share_percent CHECK (share_percent >= 0 AND share_percent <= 100)
+);
+
+CREATE TABLE IF NOT EXISTS partner_commissions (
+    id VARCHAR(36) PRIMARY KEY,
+    partner_id VARCHAR(36) NOT NULL REFERENCES partners(id),
+    payment_id VARCHAR(36) NOT NULL UNIQUE,
+    service_code VARCHAR(50) NOT NULL,
+    amount_usd REAL NOT NULL,
+    share_percent REAL NOT NULL,
+    commission_usd REAL NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'earned',
+    earned_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    reversed_at TIMESTAMP,
+
+    CONSTRAINT valid_commission_status CHECK (status IN ('earned', 'reversed'))
+);
+
+CREATE INDEX idx_partner_commissions_partner ON partner_commissions(partner_id, earned_at);
+
+INSERT OR IGNORE INTO app_config (namespace, config_key, value_type, value_number, description, chain_id) VALUES
+    ('partners', 'default_share_percent', 'number', 10, 'Revenue share (percent of the USD amount) of partners created without one', 0);
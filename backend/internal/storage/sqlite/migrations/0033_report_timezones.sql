@@ -0,0 +1,5 @@
+-- Report Time Zones
+-- Mirrors report_schedules.timezone in infrastructure/docker/init-db.sql.
+-- Existing schedules keep firing in UTC.
+
+ALTER TABLE report_schedules ADD COLUMN timezone VARCHAR(64) NOT NULL DEFAULT 'UTC';
@@ -0,0 +1,21 @@
+-- KYC Audit Log
+-- Mirrors audit_log in infrastructure/docker/init-db.sql, including the
+-- (timestamp, id) indexes its keyset pages read.
+
+CREATE TABLE IF NOT EXISTS audit_log (
+    id VARCHAR(36) PRIMARY KEY,
+    action VARCHAR(50) NOT NULL,
+    actor VARCHAR(42) NOT NULL,
+    subject VARCHAR(42),
+    details TEXT,
+    ip_address VARCHAR(45),
+    country VARCHAR(2),
+    previous_state TEXT,
+    new_state TEXT,
+    timestamp TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now'))
+);
+
+CREATE INDEX idx_audit_actor ON audit_log(actor);
+CREATE INDEX idx_audit_subject ON audit_log(subject, timestamp DESC, id DESC);
+CREATE INDEX idx_audit_action ON audit_log(action);
+CREATE INDEX idx_audit_timestamp ON audit_log(timestamp DESC, id DESC);
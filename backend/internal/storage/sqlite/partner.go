@@ -0,0 +1,308 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure SQLitePartnerRepo implements PartnerRepository
+var _ repository.PartnerRepository = (*SQLitePartnerRepo)(nil)
+
+// SQLitePartnerRepo implements PartnerRepository using SQLite
+type SQLitePartnerRepo struct {
+	db *sql.DB
+}
+
+// NewSQLitePartnerRepo creates a new SQLite partner repository
+func NewSQLitePartnerRepo(db *sql.DB) *SQLitePartnerRepo {
+	return &SQLitePartnerRepo{db: db}
+}
+
+// partnerColumns is the column list read by every partner query
+const partnerColumns = `id, name, referral_code, share_percent, api_key_hash, contact_email, is_active, created_at, updated_at`
+
+// commissionColumns is the column list read by every commission query
+const commissionColumns = `id, partner_id, payment_id, service_code, amount_usd, share_percent, commission_usd, status, earned_at, reversed_at`
+
+// CreatePartner stores a partner, refusing a referral code in use
+func (r *SQLitePartnerRepo) CreatePartner(ctx context.Context, p *repository.Partner) error {
+	query := `
+		INSERT INTO partners (id, name, referral_code, share_percent, api_key_hash, contact_email, is_active)
+		VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7)
+		ON CONFLICT (referral_code) DO NOTHING
+		RETURNING id, created_at, updated_at
+	`
+
+	err := r.db.QueryRowContext(ctx, query,
+		newID(), p.Name, p.ReferralCode, p.SharePercent, p.APIKeyHash, p.ContactEmail, p.IsActive,
+	).Scan(&p.ID, &p.CreatedAt, &p.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return repository.ErrPartnerExists
+	}
+	if err != nil {
+		return fmt.Errorf("creating partner: %w", err)
+	}
+	return nil
+}
+
+// GetPartner retrieves a partner by ID
+func (r *SQLitePartnerRepo) GetPartner(ctx context.Context, id string) (*repository.Partner, error) {
+	return r.getPartner(ctx, "id", id)
+}
+
+// GetPartnerByCode retrieves a partner by referral code
+func (r *SQLitePartnerRepo) GetPartnerByCode(ctx context.Context, referralCode string) (*repository.Partner, error) {
+	return r.getPartner(ctx, "referral_code", referralCode)
+}
+
+// GetPartnerByKeyHash retrieves the partner an API key was issued to
+func (r *SQLitePartnerRepo) GetPartnerByKeyHash(ctx context.Context, keyHash string) (*repository.Partner, error) {
+	return r.getPartner(ctx, "api_key_hash", keyHash)
+}
+
+// getPartner retrieves a partner by a unique column
+func (r *SQLitePartnerRepo) getPartner(ctx context.Context, column, value string) (*repository.Partner, error) {
+	query := `SELECT ` + partnerColumns + ` FROM partners WHERE ` + column + ` = ?1`
+
+	p, err := scanPartner(r.db.QueryRowContext(ctx, query, value))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrPartnerNotFound
+		}
+		return nil, fmt.Errorf("getting partner by %s: %w", column, err)
+	}
+	return p, nil
+}
+
+// ListPartners lists partners by name
+func (r *SQLitePartnerRepo) ListPartners(ctx context.Context, page repository.Pagination) ([]*repository.Partner, int64, error) {
+	var total int64
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM partners").Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("counting partners: %w", err)
+	}
+
+	if page.PageSize <= 0 {
+		page.PageSize = 20
+	}
+	if page.Page <= 0 {
+		page.Page = 1
+	}
+	offset := (page.Page - 1) * page.PageSize
+
+	query := `SELECT ` + partnerColumns + ` FROM partners ORDER BY name, created_at LIMIT ?1 OFFSET ?2`
+	rows, err := r.db.QueryContext(ctx, query, page.PageSize, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("listing partners: %w", err)
+	}
+	defer rows.Close()
+
+	var result []*repository.Partner
+	for rows.Next() {
+		p, err := scanPartner(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("scanning partner row: %w", err)
+		}
+		result = append(result, p)
+	}
+
+	return result, total, rows.Err()
+}
+
+// UpdatePartner applies the set fields of update
+func (r *SQLitePartnerRepo) UpdatePartner(ctx context.Context, id string, update *repository.PartnerUpdate) error {
+	query := "UPDATE partners SET updated_at = " + sqlNow
+	args := []interface{}{id}
+	argNum := 2
+
+	if update.Name != nil {
+		query += fmt.Sprintf(", name = ?%d", argNum)
+		args = append(args, *update.Name)
+		argNum++
+	}
+	if update.SharePercent != nil {
+		query += fmt.Sprintf(", share_percent = ?%d", argNum)
+		args = append(args, *update.SharePercent)
+		argNum++
+	}
+	if update.ContactEmail != nil {
+		query += fmt.Sprintf(", contact_email = ?%d", argNum)
+		args = append(args, *update.ContactEmail)
+		argNum++
+	}
+	if update.IsActive != nil {
+		query += fmt.Sprintf(", is_active = ?%d", argNum)
+		args = append(args, *update.IsActive)
+		argNum++
+	}
+	if update.APIKeyHash != nil {
+		query += fmt.Sprintf(", api_key_hash = ?%d", argNum)
+		args = append(args, *update.APIKeyHash)
+	}
+	query += " WHERE id = ?1"
+
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("updating partner %s: %w", id, err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("checking partner update: %w", err)
+	}
+	if rows == 0 {
+		return repository.ErrPartnerNotFound
+	}
+	return nil
+}
+
+// RecordCommission stores a payment's commission once
+func (r *SQLitePartnerRepo) RecordCommission(ctx context.Context, c *repository.PartnerCommission) (bool, error) {
+	query := `
+		INSERT INTO partner_commissions (id, partner_id, payment_id, service_code, amount_usd, share_percent, commission_usd)
+		VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7)
+		ON CONFLICT (payment_id) DO NOTHING
+		RETURNING id, status, earned_at
+	`
+
+	err := r.db.QueryRowContext(ctx, query,
+		newID(), c.PartnerID, c.PaymentID, c.ServiceCode, c.AmountUSD, c.SharePercent, c.CommissionUSD,
+	).Scan(&c.ID, &c.Status, &c.EarnedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("recording commission on payment %s: %w", c.PaymentID, err)
+	}
+	return true, nil
+}
+
+// ReverseCommission marks a payment's earned commission reversed
+func (r *SQLitePartnerRepo) ReverseCommission(ctx context.Context, paymentID string) (bool, error) {
+	query := `
+		UPDATE partner_commissions
+		SET status = 'reversed', reversed_at = ` + sqlNow + `
+		WHERE payment_id = ?1 AND status = 'earned'
+	`
+
+	result, err := r.db.ExecContext(ctx, query, paymentID)
+	if err != nil {
+		return false, fmt.Errorf("reversing commission on payment %s: %w", paymentID, err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("checking commission reversal: %w", err)
+	}
+	return rows > 0, nil
+}
+
+// commissionWhere builds the WHERE clause selecting filter's commissions
+func commissionWhere(filter repository.PartnerCommissionFilter) (string, []interface{}) {
+	whereClause := "WHERE 1=1"
+	args := []interface{}{}
+	argNum := 1
+
+	if filter.PartnerID != "" {
+		whereClause += fmt.Sprintf(" AND partner_id = ?%d", argNum)
+		args = append(args, filter.PartnerID)
+		argNum++
+	}
+	if filter.From != nil {
+		whereClause += fmt.Sprintf(" AND earned_at >= ?%d", argNum)
+		args = append(args, timeArg(*filter.From))
+		argNum++
+	}
+	if filter.To != nil {
+		whereClause += fmt.Sprintf(" AND earned_at < ?%d", argNum)
+		args = append(args, timeArg(*filter.To))
+	}
+	return whereClause, args
+}
+
+// ListCommissions lists commissions with filtering, newest first
+func (r *SQLitePartnerRepo) ListCommissions(ctx context.Context, filter repository.PartnerCommissionFilter, page repository.Pagination) ([]*repository.PartnerCommission, int64, error) {
+	whereClause, args := commissionWhere(filter)
+
+	var total int64
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM partner_commissions "+whereClause, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("counting commissions: %w", err)
+	}
+
+	if page.PageSize <= 0 {
+		page.PageSize = 20
+	}
+	if page.Page <= 0 {
+		page.Page = 1
+	}
+	offset := (page.Page - 1) * page.PageSize
+
+	query := fmt.Sprintf(`
+		SELECT `+commissionColumns+`
+		FROM partner_commissions
+		%s
+		ORDER BY earned_at DESC
+		LIMIT ?%d OFFSET ?%d
+	`, whereClause, len(args)+1, len(args)+2)
+
+	args = append(args, page.PageSize, offset)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("listing commissions: %w", err)
+	}
+	defer rows.Close()
+
+	var result []*repository.PartnerCommission
+	for rows.Next() {
+		c, err := scanCommission(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("scanning commission row: %w", err)
+		}
+		result = append(result, c)
+	}
+
+	return result, total, rows.Err()
+}
+
+// GetCommissionTotals totals the commissions matching filter
+func (r *SQLitePartnerRepo) GetCommissionTotals(ctx context.Context, filter repository.PartnerCommissionFilter) (*repository.PartnerCommissionTotals, error) {
+	whereClause, args := commissionWhere(filter)
+	query := `
+		SELECT COUNT(*),
+		       COALESCE(SUM(amount_usd), 0),
+		       COALESCE(SUM(commission_usd), 0),
+		       COALESCE(SUM(CASE WHEN status = 'reversed' THEN commission_usd ELSE 0 END), 0)
+		FROM partner_commissions
+	` + whereClause
+
+	t := &repository.PartnerCommissionTotals{}
+	if err := r.db.QueryRowContext(ctx, query, args...).Scan(&t.Payments, &t.AmountUSD, &t.EarnedUSD, &t.ReversedUSD); err != nil {
+		return nil, fmt.Errorf("totaling commissions: %w", err)
+	}
+	t.NetUSD = t.EarnedUSD - t.ReversedUSD
+	return t, nil
+}
+
+// scanPartner scans a partners row
+func scanPartner(row rowScanner) (*repository.Partner, error) {
+	p := &repository.Partner{}
+	err := row.Scan(&p.ID, &p.Name, &p.ReferralCode, &p.SharePercent, &p.APIKeyHash, &p.ContactEmail,
+		&p.IsActive, &p.CreatedAt, &p.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// scanCommission scans a partner_commissions row
+func scanCommission(row rowScanner) (*repository.PartnerCommission, error) {
+	c := &repository.PartnerCommission{}
+	err := row.Scan(&c.ID, &c.PartnerID, &c.PaymentID, &c.ServiceCode, &c.AmountUSD, &c.SharePercent,
+		&c.CommissionUSD, &c.Status, &c.EarnedAt, &c.ReversedAt)
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
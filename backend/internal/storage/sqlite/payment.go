@@ -0,0 +1,669 @@
+// Package sqlite implements repository interfaces using SQLite
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure SQLitePaymentRepo implements PaymentRepository
+var _ repository.PaymentRepository = (*SQLitePaymentRepo)(nil)
+
+// SQLitePaymentRepo implements PaymentRepository using SQLite
+type SQLitePaymentRepo struct {
+	db *sql.DB
+}
+
+// NewSQLitePaymentRepo creates a new SQLite payment repository
+func NewSQLitePaymentRepo(db *sql.DB) *SQLitePaymentRepo {
+	return &SQLitePaymentRepo{db: db}
+}
+
+// CreatePayment creates a new payment record
+func (r *SQLitePaymentRepo) CreatePayment(ctx context.Context, payment *repository.Payment) error {
+	query := `
+		INSERT INTO payments (
+			service_code, pricing_id, payer_address, payment_method,
+			amount_charged, currency, amount_usd, tx_hash,
+			stripe_payment_id, stripe_session_id, status,
+			callback_url, callback_secret, country, referrer_code
+		) VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7, ?8, ?9, ?10, ?11, ?12, ?13, ?14, ?15)
+		RETURNING id, created_at, updated_at
+	`
+
+	err := r.db.QueryRowContext(ctx, query,
+		payment.ServiceCode,
+		payment.PricingID,
+		payment.PayerAddress,
+		payment.PaymentMethod,
+		payment.AmountCharged,
+		payment.Currency,
+		payment.AmountUSD,
+		payment.TxHash,
+		payment.StripePaymentID,
+		payment.StripeSessionID,
+		payment.Status,
+		payment.CallbackURL,
+		payment.CallbackSecret,
+		payment.Country,
+		payment.ReferrerCode,
+	).Scan(&payment.ID, &payment.CreatedAt, &payment.UpdatedAt)
+
+	if err != nil {
+		return fmt.Errorf("creating payment: %w", err)
+	}
+
+	return nil
+}
+
+// GetPayment retrieves a payment by ID
+func (r *SQLitePaymentRepo) GetPayment(ctx context.Context, id string) (*repository.Payment, error) {
+	query := `
+		SELECT id, service_code, pricing_id, payer_address, payment_method,
+		       amount_charged, currency, amount_usd, tx_hash,
+		       stripe_payment_id, stripe_session_id, status, error_message,
+		       created_at, updated_at, completed_at, callback_url, callback_secret,
+		       dispute_id, dispute_status, dispute_reason, disputed_at, country, referrer_code
+		FROM payments
+		WHERE id = ?1
+	`
+
+	p := &repository.Payment{}
+	err := r.db.QueryRowContext(ctx, query, id).Scan(
+		&p.ID,
+		&p.ServiceCode,
+		&p.PricingID,
+		&p.PayerAddress,
+		&p.PaymentMethod,
+		&p.AmountCharged,
+		&p.Currency,
+		&p.AmountUSD,
+		&p.TxHash,
+		&p.StripePaymentID,
+		&p.StripeSessionID,
+		&p.Status,
+		&p.ErrorMessage,
+		&p.CreatedAt,
+		&p.UpdatedAt,
+		&p.CompletedAt,
+		&p.CallbackURL,
+		&p.CallbackSecret,
+		&p.DisputeID,
+		&p.DisputeStatus,
+		&p.DisputeReason,
+		&p.DisputedAt,
+		&p.Country,
+		&p.ReferrerCode,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrPaymentNotFound
+		}
+		return nil, fmt.Errorf("getting payment %s: %w", id, err)
+	}
+
+	return p, nil
+}
+
+// GetPaymentByStripeSession retrieves a payment by Stripe session ID
+func (r *SQLitePaymentRepo) GetPaymentByStripeSession(ctx context.Context, sessionID string) (*repository.Payment, error) {
+	query := `
+		SELECT id, service_code, pricing_id, payer_address, payment_method,
+		       amount_charged, currency, amount_usd, tx_hash,
+		       stripe_payment_id, stripe_session_id, status, error_message,
+		       created_at, updated_at, completed_at, callback_url, callback_secret,
+		       dispute_id, dispute_status, dispute_reason, disputed_at, country, referrer_code
+		FROM payments
+		WHERE stripe_session_id = ?1
+	`
+
+	p := &repository.Payment{}
+	err := r.db.QueryRowContext(ctx, query, sessionID).Scan(
+		&p.ID,
+		&p.ServiceCode,
+		&p.PricingID,
+		&p.PayerAddress,
+		&p.PaymentMethod,
+		&p.AmountCharged,
+		&p.Currency,
+		&p.AmountUSD,
+		&p.TxHash,
+		&p.StripePaymentID,
+		&p.StripeSessionID,
+		&p.Status,
+		&p.ErrorMessage,
+		&p.CreatedAt,
+		&p.UpdatedAt,
+		&p.CompletedAt,
+		&p.CallbackURL,
+		&p.CallbackSecret,
+		&p.DisputeID,
+		&p.DisputeStatus,
+		&p.DisputeReason,
+		&p.DisputedAt,
+		&p.Country,
+		&p.ReferrerCode,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrPaymentNotFound
+		}
+		return nil, fmt.Errorf("getting payment by session %s: %w", sessionID, err)
+	}
+
+	return p, nil
+}
+
+// GetPaymentByStripePaymentID retrieves a payment by Stripe PaymentIntent ID
+func (r *SQLitePaymentRepo) GetPaymentByStripePaymentID(ctx context.Context, paymentIntentID string) (*repository.Payment, error) {
+	query := `
+		SELECT id, service_code, pricing_id, payer_address, payment_method,
+		       amount_charged, currency, amount_usd, tx_hash,
+		       stripe_payment_id, stripe_session_id, status, error_message,
+		       created_at, updated_at, completed_at, callback_url, callback_secret,
+		       dispute_id, dispute_status, dispute_reason, disputed_at, country, referrer_code
+		FROM payments
+		WHERE stripe_payment_id = ?1
+	`
+
+	p := &repository.Payment{}
+	err := r.db.QueryRowContext(ctx, query, paymentIntentID).Scan(
+		&p.ID,
+		&p.ServiceCode,
+		&p.PricingID,
+		&p.PayerAddress,
+		&p.PaymentMethod,
+		&p.AmountCharged,
+		&p.Currency,
+		&p.AmountUSD,
+		&p.TxHash,
+		&p.StripePaymentID,
+		&p.StripeSessionID,
+		&p.Status,
+		&p.ErrorMessage,
+		&p.CreatedAt,
+		&p.UpdatedAt,
+		&p.CompletedAt,
+		&p.CallbackURL,
+		&p.CallbackSecret,
+		&p.DisputeID,
+		&p.DisputeStatus,
+		&p.DisputeReason,
+		&p.DisputedAt,
+		&p.Country,
+		&p.ReferrerCode,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrPaymentNotFound
+		}
+		return nil, fmt.Errorf("getting payment by payment intent %s: %w", paymentIntentID, err)
+	}
+
+	return p, nil
+}
+
+// UpdatePaymentStatus updates the status of a payment
+func (r *SQLitePaymentRepo) UpdatePaymentStatus(ctx context.Context, id string, status repository.PaymentStatus, details *repository.PaymentStatusUpdate) error {
+	query := "UPDATE payments SET status = ?2"
+	args := []interface{}{id, status}
+	argNum := 3
+
+	switch status {
+	case repository.PaymentStatusCompleted:
+		query += ", completed_at = " + sqlNow
+	case repository.PaymentStatusPending, repository.PaymentStatusProcessing:
+		// Rolled back (e.g. chain reorg) - no longer completed
+		query += ", completed_at = NULL"
+	}
+
+	if details != nil {
+		if details.TxHash != nil {
+			query += fmt.Sprintf(", tx_hash = ?%d", argNum)
+			args = append(args, *details.TxHash)
+			argNum++
+		}
+		if details.StripePaymentID != nil {
+			query += fmt.Sprintf(", stripe_payment_id = ?%d", argNum)
+			args = append(args, *details.StripePaymentID)
+			argNum++
+		}
+		if details.ErrorMessage != nil {
+			query += fmt.Sprintf(", error_message = ?%d", argNum)
+			args = append(args, *details.ErrorMessage)
+			argNum++
+		}
+		if details.DisputeID != nil {
+			query += fmt.Sprintf(", dispute_id = ?%d", argNum) + ", disputed_at = COALESCE(disputed_at, " + sqlNow + ")"
+			args = append(args, *details.DisputeID)
+			argNum++
+		}
+		if details.DisputeStatus != nil {
+			query += fmt.Sprintf(", dispute_status = ?%d", argNum)
+			args = append(args, *details.DisputeStatus)
+			argNum++
+		}
+		if details.DisputeReason != nil {
+			query += fmt.Sprintf(", dispute_reason = ?%d", argNum)
+			args = append(args, *details.DisputeReason)
+		}
+	}
+
+	query += " WHERE id = ?1"
+
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("updating payment status: %w", err)
+	}
+
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return repository.ErrPaymentNotFound
+	}
+
+	return nil
+}
+
+// ListPayments lists payments with filtering
+func (r *SQLitePaymentRepo) ListPayments(ctx context.Context, filter repository.PaymentFilter, page repository.Pagination) ([]*repository.Payment, int64, error) {
+	// Build where clause
+	where := []string{"1=1"}
+	args := []interface{}{}
+	argNum := 1
+
+	if filter.PayerAddress != "" {
+		where = append(where, fmt.Sprintf("payer_address = ?%d", argNum))
+		args = append(args, filter.PayerAddress)
+		argNum++
+	}
+	if filter.ServiceCode != "" {
+		where = append(where, fmt.Sprintf("service_code = ?%d", argNum))
+		args = append(args, filter.ServiceCode)
+		argNum++
+	}
+	if filter.PaymentMethod != "" {
+		where = append(where, fmt.Sprintf("payment_method = ?%d", argNum))
+		args = append(args, filter.PaymentMethod)
+		argNum++
+	}
+	if filter.Status != "" {
+		where = append(where, fmt.Sprintf("status = ?%d", argNum))
+		args = append(args, filter.Status)
+		argNum++
+	}
+	if filter.ExcludesExpired() {
+		where = append(where, "status <> 'expired'")
+	}
+	if filter.Disputed {
+		where = append(where, "dispute_id IS NOT NULL")
+	}
+
+	whereClause := "WHERE " + join(where, " AND ")
+
+	// Count total
+	countQuery := "SELECT COUNT(*) FROM payments " + whereClause
+	var total int64
+	if err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("counting payments: %w", err)
+	}
+
+	// Get page
+	if page.PageSize <= 0 {
+		page.PageSize = 20
+	}
+	if page.Page <= 0 {
+		page.Page = 1
+	}
+	offset := (page.Page - 1) * page.PageSize
+
+	query := fmt.Sprintf(`
+		SELECT id, service_code, pricing_id, payer_address, payment_method,
+		       amount_charged, currency, amount_usd, tx_hash,
+		       stripe_payment_id, stripe_session_id, status, error_message,
+		       created_at, updated_at, completed_at, callback_url, callback_secret,
+		       dispute_id, dispute_status, dispute_reason, disputed_at, country, referrer_code
+		FROM payments
+		%s
+		ORDER BY created_at DESC
+		LIMIT ?%d OFFSET ?%d
+	`, whereClause, argNum, argNum+1)
+
+	args = append(args, page.PageSize, offset)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("listing payments: %w", err)
+	}
+	defer rows.Close()
+
+	var result []*repository.Payment
+	for rows.Next() {
+		p := &repository.Payment{}
+		err := rows.Scan(
+			&p.ID,
+			&p.ServiceCode,
+			&p.PricingID,
+			&p.PayerAddress,
+			&p.PaymentMethod,
+			&p.AmountCharged,
+			&p.Currency,
+			&p.AmountUSD,
+			&p.TxHash,
+			&p.StripePaymentID,
+			&p.StripeSessionID,
+			&p.Status,
+			&p.ErrorMessage,
+			&p.CreatedAt,
+			&p.UpdatedAt,
+			&p.CompletedAt,
+			&p.CallbackURL,
+			&p.CallbackSecret,
+			&p.DisputeID,
+			&p.DisputeStatus,
+			&p.DisputeReason,
+			&p.DisputedAt,
+			&p.Country,
+			&p.ReferrerCode,
+		)
+		if err != nil {
+			return nil, 0, fmt.Errorf("scanning payment row: %w", err)
+		}
+		result = append(result, p)
+	}
+
+	return result, total, nil
+}
+
+// ExpirePayments moves up to limit pending payments of method created before
+// the cutoff to expired, oldest first, and returns their IDs. The status is
+// checked in the same statement, so a payment completed meanwhile is kept.
+func (r *SQLitePaymentRepo) ExpirePayments(ctx context.Context, method string, before time.Time, limit int) ([]string, error) {
+	query := `
+		UPDATE payments SET status = 'expired'
+		WHERE id IN (
+			SELECT id FROM payments
+			WHERE status = 'pending' AND payment_method = ?1 AND created_at < ?2
+			ORDER BY created_at
+			LIMIT ?3
+		)
+		AND status = 'pending'
+		RETURNING id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, method, timeArg(before), limit)
+	if err != nil {
+		return nil, fmt.Errorf("expiring %s payments: %w", method, err)
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("scanning expired payment: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("expiring %s payments: %w", method, err)
+	}
+
+	return ids, nil
+}
+
+// CreateKYCVerification creates a new KYC verification record
+func (r *SQLitePaymentRepo) CreateKYCVerification(ctx context.Context, v *repository.KYCVerification) error {
+	query := `
+		INSERT INTO kyc_verifications (
+			payment_id, user_address, sumsub_applicant_id, status
+		) VALUES (?1, ?2, ?3, ?4)
+		RETURNING id, created_at, updated_at
+	`
+
+	err := r.db.QueryRowContext(ctx, query,
+		v.PaymentID,
+		v.UserAddress,
+		v.SumsubApplicantID,
+		v.Status,
+	).Scan(&v.ID, &v.CreatedAt, &v.UpdatedAt)
+
+	if err != nil {
+		return fmt.Errorf("creating kyc verification: %w", err)
+	}
+
+	return nil
+}
+
+// GetKYCVerification retrieves a KYC verification by ID
+func (r *SQLitePaymentRepo) GetKYCVerification(ctx context.Context, id string) (*repository.KYCVerification, error) {
+	return r.getKYCVerificationBy(ctx, "id", id)
+}
+
+// GetKYCVerificationByAddress retrieves a KYC verification by user address
+func (r *SQLitePaymentRepo) GetKYCVerificationByAddress(ctx context.Context, address string) (*repository.KYCVerification, error) {
+	return r.getKYCVerificationBy(ctx, "user_address", address)
+}
+
+// GetKYCVerificationByApplicant retrieves a KYC verification by Sumsub applicant ID
+func (r *SQLitePaymentRepo) GetKYCVerificationByApplicant(ctx context.Context, applicantID string) (*repository.KYCVerification, error) {
+	return r.getKYCVerificationBy(ctx, "sumsub_applicant_id", applicantID)
+}
+
+func (r *SQLitePaymentRepo) getKYCVerificationBy(ctx context.Context, field, value string) (*repository.KYCVerification, error) {
+	query := fmt.Sprintf(`
+		SELECT id, payment_id, user_address, sumsub_applicant_id, sumsub_inspection_id,
+		       sumsub_review_status, sumsub_review_result, status, whitelist_tx_hash,
+		       created_at, updated_at, submitted_at, verified_at, rejected_at
+		FROM kyc_verifications
+		WHERE %s = ?1
+		ORDER BY created_at DESC
+		LIMIT 1
+	`, field)
+
+	v := &repository.KYCVerification{}
+	var reviewResultJSON []byte
+	err := r.db.QueryRowContext(ctx, query, value).Scan(
+		&v.ID,
+		&v.PaymentID,
+		&v.UserAddress,
+		&v.SumsubApplicantID,
+		&v.SumsubInspectionID,
+		&v.SumsubReviewStatus,
+		&reviewResultJSON,
+		&v.Status,
+		&v.WhitelistTxHash,
+		&v.CreatedAt,
+		&v.UpdatedAt,
+		&v.SubmittedAt,
+		&v.VerifiedAt,
+		&v.RejectedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrKYCNotFound
+		}
+		return nil, fmt.Errorf("getting kyc verification: %w", err)
+	}
+
+	if reviewResultJSON != nil {
+		if err := json.Unmarshal(reviewResultJSON, &v.SumsubReviewResult); err != nil {
+			return nil, fmt.Errorf("parsing review result: %w", err)
+		}
+	}
+
+	return v, nil
+}
+
+// UpdateKYCVerification updates a KYC verification record
+func (r *SQLitePaymentRepo) UpdateKYCVerification(ctx context.Context, id string, update *repository.KYCVerificationUpdate) error {
+	query := "UPDATE kyc_verifications SET updated_at = " + sqlNow
+	args := []interface{}{id}
+	argNum := 2
+
+	if update.SumsubApplicantID != nil {
+		query += fmt.Sprintf(", sumsub_applicant_id = ?%d", argNum)
+		args = append(args, *update.SumsubApplicantID)
+		argNum++
+	}
+	if update.SumsubInspectionID != nil {
+		query += fmt.Sprintf(", sumsub_inspection_id = ?%d", argNum)
+		args = append(args, *update.SumsubInspectionID)
+		argNum++
+	}
+	if update.SumsubReviewStatus != nil {
+		query += fmt.Sprintf(", sumsub_review_status = ?%d", argNum)
+		args = append(args, *update.SumsubReviewStatus)
+		argNum++
+	}
+	if update.SumsubReviewResult != nil {
+		resultJSON, err := json.Marshal(update.SumsubReviewResult)
+		if err != nil {
+			return fmt.Errorf("marshaling review result: %w", err)
+		}
+		query += fmt.Sprintf(", sumsub_review_result = ?%d", argNum)
+		args = append(args, string(resultJSON))
+		argNum++
+	}
+	if update.Status != nil {
+		query += fmt.Sprintf(", status = ?%d", argNum)
+		args = append(args, *update.Status)
+
+		// Update timestamp fields based on status
+		switch *update.Status {
+		case repository.KYCStatusSubmitted:
+			query += ", submitted_at = " + sqlNow
+		case repository.KYCStatusApproved:
+			query += ", verified_at = " + sqlNow
+		case repository.KYCStatusRejected:
+			query += ", rejected_at = " + sqlNow
+		}
+		argNum++
+	}
+	if update.WhitelistTxHash != nil {
+		query += fmt.Sprintf(", whitelist_tx_hash = ?%d", argNum)
+		args = append(args, *update.WhitelistTxHash)
+	}
+
+	query += " WHERE id = ?1"
+
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("updating kyc verification: %w", err)
+	}
+
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return repository.ErrKYCNotFound
+	}
+
+	return nil
+}
+
+// ListKYCVerifications lists KYC verifications with filtering
+func (r *SQLitePaymentRepo) ListKYCVerifications(ctx context.Context, filter repository.KYCVerificationFilter, page repository.Pagination) ([]*repository.KYCVerification, int64, error) {
+	where := []string{"1=1"}
+	args := []interface{}{}
+	argNum := 1
+
+	if filter.UserAddress != "" {
+		where = append(where, fmt.Sprintf("user_address = ?%d", argNum))
+		args = append(args, filter.UserAddress)
+		argNum++
+	}
+	if filter.Status != "" {
+		where = append(where, fmt.Sprintf("status = ?%d", argNum))
+		args = append(args, filter.Status)
+		argNum++
+	}
+
+	whereClause := "WHERE " + join(where, " AND ")
+
+	// Count total
+	countQuery := "SELECT COUNT(*) FROM kyc_verifications " + whereClause
+	var total int64
+	if err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("counting verifications: %w", err)
+	}
+
+	// Get page
+	if page.PageSize <= 0 {
+		page.PageSize = 20
+	}
+	if page.Page <= 0 {
+		page.Page = 1
+	}
+	offset := (page.Page - 1) * page.PageSize
+
+	query := fmt.Sprintf(`
+		SELECT id, payment_id, user_address, sumsub_applicant_id, sumsub_inspection_id,
+		       sumsub_review_status, sumsub_review_result, status, whitelist_tx_hash,
+		       created_at, updated_at, submitted_at, verified_at, rejected_at
+		FROM kyc_verifications
+		%s
+		ORDER BY created_at DESC
+		LIMIT ?%d OFFSET ?%d
+	`, whereClause, argNum, argNum+1)
+
+	args = append(args, page.PageSize, offset)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("listing verifications: %w", err)
+	}
+	defer rows.Close()
+
+	var result []*repository.KYCVerification
+	for rows.Next() {
+		v := &repository.KYCVerification{}
+		var reviewResultJSON []byte
+		err := rows.Scan(
+			&v.ID,
+			&v.PaymentID,
+			&v.UserAddress,
+			&v.SumsubApplicantID,
+			&v.SumsubInspectionID,
+			&v.SumsubReviewStatus,
+			&reviewResultJSON,
+			&v.Status,
+			&v.WhitelistTxHash,
+			&v.CreatedAt,
+			&v.UpdatedAt,
+			&v.SubmittedAt,
+			&v.VerifiedAt,
+			&v.RejectedAt,
+		)
+		if err != nil {
+			return nil, 0, fmt.Errorf("scanning verification row: %w", err)
+		}
+
+		if reviewResultJSON != nil {
+			json.Unmarshal(reviewResultJSON, &v.SumsubReviewResult)
+		}
+
+		result = append(result, v)
+	}
+
+	return result, total, nil
+}
+
+// join is a helper to join strings with a separator
+func join(strs []string, sep string) string {
+	if len(strs) == 0 {
+		return ""
+	}
+	result := strs[0]
+	for _, s := range strs[1:] {
+		result += sep + s
+	}
+	return result
+}
@@ -0,0 +1,241 @@
+// Package sqlite implements repository interfaces using SQLite
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure SQLitePayoutRepo implements PayoutRepository
+var _ repository.PayoutRepository = (*SQLitePayoutRepo)(nil)
+
+// SQLitePayoutRepo implements PayoutRepository using SQLite
+type SQLitePayoutRepo struct {
+	db *sql.DB
+}
+
+// NewSQLitePayoutRepo creates a new SQLite payout repository
+func NewSQLitePayoutRepo(db *sql.DB) *SQLitePayoutRepo {
+	return &SQLitePayoutRepo{db: db}
+}
+
+// payoutColumns is the column list read by every payout query
+const payoutColumns = `id, kind, recipient, currency, token_address, amount, reference, description,
+		       status, scheduled_for, created_by, reviewed_by, reviewed_at, sender_address,
+		       tx_hash, block_number, gas_used, fee_wei, error_message,
+		       created_at, updated_at, submitted_at, confirmed_at`
+
+// CreatePayout records a payout
+func (r *SQLitePayoutRepo) CreatePayout(ctx context.Context, p *repository.Payout) error {
+	if p.ScheduledFor.IsZero() {
+		p.ScheduledFor = time.Now().UTC()
+	}
+
+	query := `
+		INSERT INTO payouts (kind, recipient, currency, token_address, amount, reference,
+		                     description, status, scheduled_for, created_by)
+		VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7, ?8, ?9, ?10)
+		RETURNING id, created_at, updated_at
+	`
+
+	err := r.db.QueryRowContext(ctx, query,
+		p.Kind, p.Recipient, p.Currency, p.TokenAddress, p.Amount, p.Reference,
+		p.Description, p.Status, timeArg(p.ScheduledFor), p.CreatedBy,
+	).Scan(&p.ID, &p.CreatedAt, &p.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("creating payout: %w", err)
+	}
+	return nil
+}
+
+// GetPayout retrieves a payout by ID
+func (r *SQLitePayoutRepo) GetPayout(ctx context.Context, id string) (*repository.Payout, error) {
+	query := `SELECT ` + payoutColumns + ` FROM payouts WHERE id = ?1`
+
+	p, err := scanPayout(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrPayoutNotFound
+		}
+		return nil, fmt.Errorf("getting payout %s: %w", id, err)
+	}
+
+	return p, nil
+}
+
+// ListPayouts lists payouts with filtering, newest first
+func (r *SQLitePayoutRepo) ListPayouts(ctx context.Context, filter repository.PayoutFilter, page repository.Pagination) ([]*repository.Payout, int64, error) {
+	whereClause := "WHERE 1=1"
+	args := []interface{}{}
+	argNum := 1
+
+	if filter.Kind != "" {
+		whereClause += fmt.Sprintf(" AND kind = ?%d", argNum)
+		args = append(args, filter.Kind)
+		argNum++
+	}
+	if filter.Status != "" {
+		whereClause += fmt.Sprintf(" AND status = ?%d", argNum)
+		args = append(args, filter.Status)
+		argNum++
+	}
+	if filter.Recipient != "" {
+		whereClause += fmt.Sprintf(" AND recipient = ?%d", argNum)
+		args = append(args, filter.Recipient)
+		argNum++
+	}
+	if filter.Reference != "" {
+		whereClause += fmt.Sprintf(" AND reference = ?%d", argNum)
+		args = append(args, filter.Reference)
+		argNum++
+	}
+
+	var total int64
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM payouts "+whereClause, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("counting payouts: %w", err)
+	}
+
+	if page.PageSize <= 0 {
+		page.PageSize = 20
+	}
+	if page.Page <= 0 {
+		page.Page = 1
+	}
+	offset := (page.Page - 1) * page.PageSize
+
+	query := fmt.Sprintf(`
+		SELECT `+payoutColumns+`
+		FROM payouts
+		%s
+		ORDER BY created_at DESC
+		LIMIT ?%d OFFSET ?%d
+	`, whereClause, argNum, argNum+1)
+
+	args = append(args, page.PageSize, offset)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("listing payouts: %w", err)
+	}
+	defer rows.Close()
+
+	var result []*repository.Payout
+	for rows.Next() {
+		p, err := scanPayout(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("scanning payout row: %w", err)
+		}
+		result = append(result, p)
+	}
+
+	return result, total, rows.Err()
+}
+
+// ListDuePayouts lists approved payouts scheduled at or before now
+func (r *SQLitePayoutRepo) ListDuePayouts(ctx context.Context, now time.Time, limit int) ([]*repository.Payout, error) {
+	query := `
+		SELECT ` + payoutColumns + `
+		FROM payouts
+		WHERE status = 'approved' AND scheduled_for <= ?1
+		ORDER BY scheduled_for
+		LIMIT ?2
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, timeArg(now), limit)
+	if err != nil {
+		return nil, fmt.Errorf("listing due payouts: %w", err)
+	}
+	defer rows.Close()
+
+	var result []*repository.Payout
+	for rows.Next() {
+		p, err := scanPayout(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning payout row: %w", err)
+		}
+		result = append(result, p)
+	}
+
+	return result, rows.Err()
+}
+
+// UpdatePayoutStatus applies an allowed status change
+func (r *SQLitePayoutRepo) UpdatePayoutStatus(ctx context.Context, id string, update *repository.PayoutUpdate) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginning payout update: %w", err)
+	}
+	defer tx.Rollback()
+
+	current, err := scanPayout(tx.QueryRowContext(ctx,
+		`SELECT `+payoutColumns+` FROM payouts WHERE id = ?1`, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return repository.ErrPayoutNotFound
+		}
+		return fmt.Errorf("getting payout %s: %w", id, err)
+	}
+	if !current.CanMoveTo(update.Status) {
+		return repository.ErrPayoutClosed
+	}
+
+	query := `
+		UPDATE payouts
+		SET status = ?2,
+		    reviewed_by = COALESCE(?3, reviewed_by),
+		    reviewed_at = CASE WHEN ?3 IS NOT NULL THEN ` + sqlNow + ` ELSE reviewed_at END,
+		    sender_address = COALESCE(?4, sender_address),
+		    tx_hash = COALESCE(?5, tx_hash),
+		    submitted_at = CASE WHEN ?5 IS NOT NULL THEN ` + sqlNow + ` ELSE submitted_at END,
+		    block_number = ?6, gas_used = ?7, fee_wei = ?8, error_message = ?9,
+		    confirmed_at = CASE WHEN ?2 = 'confirmed' THEN ` + sqlNow + ` END,
+		    updated_at = ` + sqlNow + `
+		WHERE id = ?1
+	`
+	if _, err := tx.ExecContext(ctx, query, id, update.Status, update.ReviewedBy,
+		update.SenderAddress, update.TxHash, update.BlockNumber, update.GasUsed,
+		update.FeeWei, update.ErrorMessage); err != nil {
+		return fmt.Errorf("updating payout %s: %w", id, err)
+	}
+
+	return tx.Commit()
+}
+
+// scanPayout scans a payouts row
+func scanPayout(row rowScanner) (*repository.Payout, error) {
+	p := &repository.Payout{}
+	err := row.Scan(
+		&p.ID,
+		&p.Kind,
+		&p.Recipient,
+		&p.Currency,
+		&p.TokenAddress,
+		&p.Amount,
+		&p.Reference,
+		&p.Description,
+		&p.Status,
+		&p.ScheduledFor,
+		&p.CreatedBy,
+		&p.ReviewedBy,
+		&p.ReviewedAt,
+		&p.SenderAddress,
+		&p.TxHash,
+		&p.BlockNumber,
+		&p.GasUsed,
+		&p.FeeWei,
+		&p.ErrorMessage,
+		&p.CreatedAt,
+		&p.UpdatedAt,
+		&p.SubmittedAt,
+		&p.ConfirmedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
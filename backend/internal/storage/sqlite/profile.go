@@ -0,0 +1,121 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure SQLiteProfileRepo implements ProfileRepository
+var _ repository.ProfileRepository = (*SQLiteProfileRepo)(nil)
+
+// SQLiteProfileRepo implements ProfileRepository using SQLite
+type SQLiteProfileRepo struct {
+	db *sql.DB
+}
+
+// NewSQLiteProfileRepo creates a new SQLite profile repository
+func NewSQLiteProfileRepo(db *sql.DB) *SQLiteProfileRepo {
+	return &SQLiteProfileRepo{db: db}
+}
+
+// profileColumns is the column list read by every profile query
+const profileColumns = `address, display_name, avatar_chain_id, avatar_contract, avatar_token_id, email, preferred_currency, created_at, updated_at`
+
+// UpsertProfile creates or replaces a profile
+func (r *SQLiteProfileRepo) UpsertProfile(ctx context.Context, p *repository.Profile) error {
+	query := `
+		INSERT INTO user_profiles (address, display_name, avatar_chain_id, avatar_contract, avatar_token_id, email, preferred_currency)
+		VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7)
+		ON CONFLICT (address) DO UPDATE SET
+		    display_name = excluded.display_name, avatar_chain_id = excluded.avatar_chain_id,
+		    avatar_contract = excluded.avatar_contract, avatar_token_id = excluded.avatar_token_id,
+		    email = excluded.email, preferred_currency = excluded.preferred_currency, updated_at = ` + sqlNow + `
+		RETURNING created_at, updated_at
+	`
+
+	err := r.db.QueryRowContext(ctx, query,
+		p.Address, p.DisplayName, p.AvatarChainID, p.AvatarContract, p.AvatarTokenID, p.Email, p.PreferredCurrency,
+	).Scan(&p.CreatedAt, &p.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("upserting profile %s: %w", p.Address, err)
+	}
+	return nil
+}
+
+// GetProfile retrieves the profile of address
+func (r *SQLiteProfileRepo) GetProfile(ctx context.Context, address string) (*repository.Profile, error) {
+	query := `SELECT ` + profileColumns + ` FROM user_profiles WHERE address = ?1`
+
+	p, err := scanProfile(r.db.QueryRowContext(ctx, query, address))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrProfileNotFound
+		}
+		return nil, fmt.Errorf("getting profile %s: %w", address, err)
+	}
+	return p, nil
+}
+
+// DeleteProfile removes the profile of address
+func (r *SQLiteProfileRepo) DeleteProfile(ctx context.Context, address string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM user_profiles WHERE address = ?1`, address)
+	if err != nil {
+		return fmt.Errorf("deleting profile %s: %w", address, err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return repository.ErrProfileNotFound
+	}
+	return nil
+}
+
+// GetProfiles returns the profiles of those addresses that have one
+func (r *SQLiteProfileRepo) GetProfiles(ctx context.Context, addresses []string) (map[string]*repository.Profile, error) {
+	out := make(map[string]*repository.Profile)
+	if len(addresses) == 0 {
+		return out, nil
+	}
+	list, err := json.Marshal(addresses)
+	if err != nil {
+		return nil, fmt.Errorf("encoding profile addresses: %w", err)
+	}
+
+	query := `SELECT ` + profileColumns + ` FROM user_profiles WHERE address IN (SELECT value FROM json_each(?1))`
+	rows, err := r.db.QueryContext(ctx, query, string(list))
+	if err != nil {
+		return nil, fmt.Errorf("getting profiles: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		p, err := scanProfile(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning profile: %w", err)
+		}
+		out[p.Address] = p
+	}
+	return out, rows.Err()
+}
+
+func scanProfile(row rowScanner) (*repository.Profile, error) {
+	p := &repository.Profile{}
+	err := row.Scan(
+		&p.Address,
+		&p.DisplayName,
+		&p.AvatarChainID,
+		&p.AvatarContract,
+		&p.AvatarTokenID,
+		&p.Email,
+		&p.PreferredCurrency,
+		&p.CreatedAt,
+		&p.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
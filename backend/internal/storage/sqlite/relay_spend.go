@@ -0,0 +1,100 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// relaySpendKeys maps a grouping to its key expression over minedMetaTxs.
+// SQLite knows no time zones other than UTC, so days are keyed by the UTC
+// mined time and converted to the filter's day in Go.
+var relaySpendKeys = map[repository.RelaySpendGroup]string{
+	repository.RelaySpendByUser:     "from_address",
+	repository.RelaySpendByContract: "to_address",
+	repository.RelaySpendByDay:      "strftime('%Y-%m-%dT%H:%M:%SZ', mined_at)",
+}
+
+// minedMetaTxs selects live and archived meta-transactions that were mined
+// with a receipt. Reverted ones have no confirmed_at; their last update is
+// the failure.
+const minedMetaTxs = `
+	SELECT from_address, to_address, gas_used, gas_price, COALESCE(confirmed_at, updated_at) AS mined_at
+	FROM meta_transactions
+	WHERE status IN ('confirmed', 'failed') AND gas_used IS NOT NULL AND gas_price IS NOT NULL
+	UNION ALL
+	SELECT from_address, to_address, gas_used, gas_price, COALESCE(confirmed_at, updated_at) AS mined_at
+	FROM meta_transactions_archive
+	WHERE status IN ('confirmed', 'failed') AND gas_used IS NOT NULL AND gas_price IS NOT NULL
+`
+
+// GetRelaySpend aggregates gas used × gas price. Wei amounts overflow
+// SQLite's integers, so rows are summed in Go.
+func (r *SQLiteRelayerRepo) GetRelaySpend(ctx context.Context, filter repository.RelaySpendFilter) ([]*repository.RelaySpend, error) {
+	key, ok := relaySpendKeys[filter.GroupBy]
+	if !ok {
+		return nil, fmt.Errorf("unknown relay spend grouping %q", filter.GroupBy)
+	}
+
+	whereClause := "WHERE 1=1"
+	args := []interface{}{}
+	argNum := 1
+
+	if filter.FromAddress != "" {
+		whereClause += fmt.Sprintf(" AND from_address = ?%d", argNum)
+		args = append(args, filter.FromAddress)
+		argNum++
+	}
+	if filter.ToAddress != "" {
+		whereClause += fmt.Sprintf(" AND to_address = ?%d", argNum)
+		args = append(args, filter.ToAddress)
+		argNum++
+	}
+	if filter.From != nil {
+		whereClause += fmt.Sprintf(" AND mined_at >= ?%d", argNum)
+		args = append(args, timeArg(*filter.From))
+		argNum++
+	}
+	if filter.To != nil {
+		whereClause += fmt.Sprintf(" AND mined_at < ?%d", argNum)
+		args = append(args, timeArg(*filter.To))
+		argNum++
+	}
+
+	query := fmt.Sprintf(`SELECT %s, gas_used, gas_price FROM (%s) mined %s`, key, minedMetaTxs, whereClause)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("aggregating relay spend: %w", err)
+	}
+	defer rows.Close()
+
+	tally := repository.NewRelaySpendTally()
+	for rows.Next() {
+		var (
+			group    string
+			gasUsed  uint64
+			gasPrice string
+		)
+		if err := rows.Scan(&group, &gasUsed, &gasPrice); err != nil {
+			return nil, fmt.Errorf("scanning relay spend row: %w", err)
+		}
+		if filter.GroupBy == repository.RelaySpendByDay {
+			minedAt, err := time.Parse(time.RFC3339, group)
+			if err != nil {
+				return nil, fmt.Errorf("parsing relay mined time %q: %w", group, err)
+			}
+			group = filter.Day(minedAt)
+		}
+		if err := tally.Add(group, gasUsed, gasPrice); err != nil {
+			return nil, fmt.Errorf("aggregating relay spend: %w", err)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("aggregating relay spend: %w", err)
+	}
+
+	return tally.Result(filter.GroupBy), nil
+}
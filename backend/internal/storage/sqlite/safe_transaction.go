@@ -0,0 +1,280 @@
+// Package sqlite implements repository interfaces using SQLite
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure SQLiteSafeTransactionRepo implements SafeTransactionRepository
+var _ repository.SafeTransactionRepository = (*SQLiteSafeTransactionRepo)(nil)
+
+// SQLiteSafeTransactionRepo implements SafeTransactionRepository using SQLite
+type SQLiteSafeTransactionRepo struct {
+	db *sql.DB
+}
+
+// NewSQLiteSafeTransactionRepo creates a new SQLite Safe transaction repository
+func NewSQLiteSafeTransactionRepo(db *sql.DB) *SQLiteSafeTransactionRepo {
+	return &SQLiteSafeTransactionRepo{db: db}
+}
+
+// safeTxColumns is the column list read by every Safe transaction query
+const safeTxColumns = `id, chain_id, safe_address, to_address, value, data, operation,
+		       safe_tx_gas, base_gas, gas_price, gas_token, refund_receiver, nonce,
+		       safe_tx_hash, threshold, confirmations, status, description, proposed_by,
+		       executor_address, tx_hash, block_number, error_message,
+		       created_at, updated_at, submitted_at, executed_at`
+
+// CreateSafeTransaction records a proposed transaction, refusing a
+// safe_tx_hash proposed before
+func (r *SQLiteSafeTransactionRepo) CreateSafeTransaction(ctx context.Context, tx *repository.SafeTransaction) error {
+	query := `
+		INSERT INTO safe_transactions (chain_id, safe_address, to_address, value, data, operation,
+		                               safe_tx_gas, base_gas, gas_price, gas_token, refund_receiver,
+		                               nonce, safe_tx_hash, threshold, status, description, proposed_by)
+		VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7, ?8, ?9, ?10, ?11, ?12, ?13, ?14, ?15, ?16, ?17)
+		ON CONFLICT (safe_tx_hash) DO NOTHING
+		RETURNING id, confirmations, created_at, updated_at
+	`
+
+	err := r.db.QueryRowContext(ctx, query,
+		tx.ChainID, tx.SafeAddress, tx.To, tx.Value, tx.Data, tx.Operation,
+		tx.SafeTxGas, tx.BaseGas, tx.GasPrice, tx.GasToken, tx.RefundReceiver,
+		tx.Nonce, tx.SafeTxHash, tx.Threshold, tx.Status, tx.Description, tx.ProposedBy,
+	).Scan(&tx.ID, &tx.Confirmations, &tx.CreatedAt, &tx.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return repository.ErrSafeTransactionExists
+	}
+	if err != nil {
+		return fmt.Errorf("creating safe transaction: %w", err)
+	}
+	return nil
+}
+
+// GetSafeTransaction retrieves a Safe transaction by ID
+func (r *SQLiteSafeTransactionRepo) GetSafeTransaction(ctx context.Context, id string) (*repository.SafeTransaction, error) {
+	query := `SELECT ` + safeTxColumns + ` FROM safe_transactions WHERE id = ?1`
+
+	tx, err := scanSafeTransaction(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrSafeTransactionNotFound
+		}
+		return nil, fmt.Errorf("getting safe transaction %s: %w", id, err)
+	}
+
+	return tx, nil
+}
+
+// ListSafeTransactions lists Safe transactions with filtering, newest first
+func (r *SQLiteSafeTransactionRepo) ListSafeTransactions(ctx context.Context, filter repository.SafeTransactionFilter, page repository.Pagination) ([]*repository.SafeTransaction, int64, error) {
+	whereClause := "WHERE 1=1"
+	args := []interface{}{}
+	argNum := 1
+
+	if filter.SafeAddress != "" {
+		whereClause += fmt.Sprintf(" AND safe_address = ?%d", argNum)
+		args = append(args, filter.SafeAddress)
+		argNum++
+	}
+	if filter.Status != "" {
+		whereClause += fmt.Sprintf(" AND status = ?%d", argNum)
+		args = append(args, filter.Status)
+		argNum++
+	}
+
+	var total int64
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM safe_transactions "+whereClause, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("counting safe transactions: %w", err)
+	}
+
+	if page.PageSize <= 0 {
+		page.PageSize = 20
+	}
+	if page.Page <= 0 {
+		page.Page = 1
+	}
+	offset := (page.Page - 1) * page.PageSize
+
+	query := fmt.Sprintf(`
+		SELECT `+safeTxColumns+`
+		FROM safe_transactions
+		%s
+		ORDER BY created_at DESC
+		LIMIT ?%d OFFSET ?%d
+	`, whereClause, argNum, argNum+1)
+
+	args = append(args, page.PageSize, offset)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("listing safe transactions: %w", err)
+	}
+	defer rows.Close()
+
+	var result []*repository.SafeTransaction
+	for rows.Next() {
+		tx, err := scanSafeTransaction(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("scanning safe transaction row: %w", err)
+		}
+		result = append(result, tx)
+	}
+
+	return result, total, rows.Err()
+}
+
+// AddSafeConfirmation records an owner's signature, marking the transaction
+// ready once the threshold is reached
+func (r *SQLiteSafeTransactionRepo) AddSafeConfirmation(ctx context.Context, c *repository.SafeConfirmation) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginning safe confirmation: %w", err)
+	}
+	defer tx.Rollback()
+
+	var status repository.SafeTransactionStatus
+	if err := tx.QueryRowContext(ctx, `SELECT status FROM safe_transactions WHERE id = ?1`, c.SafeTxID).Scan(&status); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return repository.ErrSafeTransactionNotFound
+		}
+		return fmt.Errorf("getting safe transaction %s: %w", c.SafeTxID, err)
+	}
+	if !(&repository.SafeTransaction{Status: status}).TakesConfirmations() {
+		return repository.ErrSafeTransactionClosed
+	}
+
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO safe_confirmations (safe_tx_id, owner, signature)
+		VALUES (?1, ?2, ?3)
+		ON CONFLICT (safe_tx_id, owner) DO NOTHING
+		RETURNING created_at
+	`, c.SafeTxID, c.Owner, c.Signature).Scan(&c.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return repository.ErrSafeConfirmationExists
+	}
+	if err != nil {
+		return fmt.Errorf("recording safe confirmation: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE safe_transactions
+		SET confirmations = confirmations + 1,
+		    status = CASE WHEN status = 'awaiting_confirmations' AND confirmations + 1 >= threshold
+		                  THEN 'ready' ELSE status END,
+		    updated_at = `+sqlNow+`
+		WHERE id = ?1
+	`, c.SafeTxID); err != nil {
+		return fmt.Errorf("counting safe confirmation: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// ListSafeConfirmations lists a transaction's confirmations by owner
+func (r *SQLiteSafeTransactionRepo) ListSafeConfirmations(ctx context.Context, safeTxID string) ([]*repository.SafeConfirmation, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT safe_tx_id, owner, signature, created_at
+		FROM safe_confirmations
+		WHERE safe_tx_id = ?1
+		ORDER BY owner
+	`, safeTxID)
+	if err != nil {
+		return nil, fmt.Errorf("listing safe confirmations: %w", err)
+	}
+	defer rows.Close()
+
+	result := []*repository.SafeConfirmation{}
+	for rows.Next() {
+		c := &repository.SafeConfirmation{}
+		if err := rows.Scan(&c.SafeTxID, &c.Owner, &c.Signature, &c.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scanning safe confirmation row: %w", err)
+		}
+		result = append(result, c)
+	}
+
+	return result, rows.Err()
+}
+
+// UpdateSafeTransactionStatus applies an allowed status change
+func (r *SQLiteSafeTransactionRepo) UpdateSafeTransactionStatus(ctx context.Context, id string, update *repository.SafeTransactionUpdate) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginning safe transaction update: %w", err)
+	}
+	defer tx.Rollback()
+
+	current, err := scanSafeTransaction(tx.QueryRowContext(ctx,
+		`SELECT `+safeTxColumns+` FROM safe_transactions WHERE id = ?1`, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return repository.ErrSafeTransactionNotFound
+		}
+		return fmt.Errorf("getting safe transaction %s: %w", id, err)
+	}
+	if !current.CanMoveTo(update.Status) {
+		return repository.ErrSafeTransactionClosed
+	}
+
+	query := `
+		UPDATE safe_transactions
+		SET status = ?2,
+		    threshold = COALESCE(?3, threshold),
+		    executor_address = COALESCE(?4, executor_address),
+		    tx_hash = COALESCE(?5, tx_hash),
+		    submitted_at = CASE WHEN ?5 IS NOT NULL THEN ` + sqlNow + ` ELSE submitted_at END,
+		    block_number = ?6, error_message = ?7,
+		    executed_at = CASE WHEN ?2 = 'executed' THEN ` + sqlNow + ` END,
+		    updated_at = ` + sqlNow + `
+		WHERE id = ?1
+	`
+	if _, err := tx.ExecContext(ctx, query, id, update.Status, update.Threshold,
+		update.ExecutorAddress, update.TxHash, update.BlockNumber, update.ErrorMessage); err != nil {
+		return fmt.Errorf("updating safe transaction %s: %w", id, err)
+	}
+
+	return tx.Commit()
+}
+
+// scanSafeTransaction scans a safe_transactions row
+func scanSafeTransaction(row rowScanner) (*repository.SafeTransaction, error) {
+	tx := &repository.SafeTransaction{}
+	err := row.Scan(
+		&tx.ID,
+		&tx.ChainID,
+		&tx.SafeAddress,
+		&tx.To,
+		&tx.Value,
+		&tx.Data,
+		&tx.Operation,
+		&tx.SafeTxGas,
+		&tx.BaseGas,
+		&tx.GasPrice,
+		&tx.GasToken,
+		&tx.RefundReceiver,
+		&tx.Nonce,
+		&tx.SafeTxHash,
+		&tx.Threshold,
+		&tx.Confirmations,
+		&tx.Status,
+		&tx.Description,
+		&tx.ProposedBy,
+		&tx.ExecutorAddress,
+		&tx.TxHash,
+		&tx.BlockNumber,
+		&tx.ErrorMessage,
+		&tx.CreatedAt,
+		&tx.UpdatedAt,
+		&tx.SubmittedAt,
+		&tx.ExecutedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
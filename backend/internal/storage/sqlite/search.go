@@ -0,0 +1,295 @@
+// Package sqlite implements repository interfaces using SQLite
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure SQLiteSearchRepo implements SearchRepository
+var _ repository.SearchRepository = (*SQLiteSearchRepo)(nil)
+
+// defaultSearchLimit is the per-type result limit when the query sets none
+const defaultSearchLimit = 20
+
+// SQLiteSearchRepo implements SearchRepository using SQLite. There is no
+// trigram index, so matching is a LIKE scan and the score is the share of the
+// matched value covered by the term.
+type SQLiteSearchRepo struct {
+	db *sql.DB
+}
+
+// NewSQLiteSearchRepo creates a new SQLite search repository
+func NewSQLiteSearchRepo(db *sql.DB) *SQLiteSearchRepo {
+	return &SQLiteSearchRepo{db: db}
+}
+
+// Search finds payments, KYC verifications and meta-transactions whose
+// identifiers contain the term
+func (r *SQLiteSearchRepo) Search(ctx context.Context, query repository.SearchQuery) ([]*repository.SearchResult, error) {
+	limit := query.Limit
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+	term := strings.TrimSpace(query.Term)
+	pattern := "%" + escapeLike(term) + "%"
+
+	var results []*repository.SearchResult
+
+	if query.Includes(repository.SearchResultPayment) {
+		found, err := r.searchPayments(ctx, term, pattern, limit)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, found...)
+	}
+
+	if query.Includes(repository.SearchResultKYCVerification) {
+		found, err := r.searchKYCVerifications(ctx, term, pattern, limit)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, found...)
+	}
+
+	if query.Includes(repository.SearchResultMetaTx) {
+		found, err := r.searchMetaTxs(ctx, term, pattern, limit)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, found...)
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].CreatedAt.After(results[j].CreatedAt)
+	})
+
+	return results, nil
+}
+
+func (r *SQLiteSearchRepo) searchPayments(ctx context.Context, term, pattern string, limit int) ([]*repository.SearchResult, error) {
+	query := `
+		SELECT ` + paymentColumns + `, matched_field,
+		       CAST(length(?1) AS REAL) / length(matched_value) AS score
+		FROM (
+			SELECT *,
+			       CASE
+			           WHEN tx_hash LIKE ?2 ESCAPE '\' THEN 'tx_hash'
+			           WHEN stripe_session_id LIKE ?2 ESCAPE '\' THEN 'stripe_session_id'
+			           WHEN stripe_payment_id LIKE ?2 ESCAPE '\' THEN 'stripe_payment_id'
+			           ELSE 'payer_address'
+			       END AS matched_field,
+			       CASE
+			           WHEN tx_hash LIKE ?2 ESCAPE '\' THEN tx_hash
+			           WHEN stripe_session_id LIKE ?2 ESCAPE '\' THEN stripe_session_id
+			           WHEN stripe_payment_id LIKE ?2 ESCAPE '\' THEN stripe_payment_id
+			           ELSE payer_address
+			       END AS matched_value
+			FROM payments
+			WHERE payer_address LIKE ?2 ESCAPE '\'
+			   OR tx_hash LIKE ?2 ESCAPE '\'
+			   OR stripe_session_id LIKE ?2 ESCAPE '\'
+			   OR stripe_payment_id LIKE ?2 ESCAPE '\'
+		)
+		ORDER BY score DESC, created_at DESC
+		LIMIT ?3
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, term, pattern, limit)
+	if err != nil {
+		return nil, fmt.Errorf("searching payments: %w", err)
+	}
+	defer rows.Close()
+
+	var results []*repository.SearchResult
+	for rows.Next() {
+		p := &repository.Payment{}
+		res := &repository.SearchResult{Type: repository.SearchResultPayment, Payment: p}
+		err := rows.Scan(
+			&p.ID,
+			&p.ServiceCode,
+			&p.PricingID,
+			&p.PayerAddress,
+			&p.PaymentMethod,
+			&p.AmountCharged,
+			&p.Currency,
+			&p.AmountUSD,
+			&p.TxHash,
+			&p.StripePaymentID,
+			&p.StripeSessionID,
+			&p.Status,
+			&p.ErrorMessage,
+			&p.CreatedAt,
+			&p.UpdatedAt,
+			&p.CompletedAt,
+			&p.DisputeID,
+			&p.DisputeStatus,
+			&p.DisputeReason,
+			&p.DisputedAt,
+			&p.Country,
+			&p.ReferrerCode,
+			&res.MatchedField,
+			&res.Score,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("scanning payment search row: %w", err)
+		}
+		res.ID, res.CreatedAt = p.ID, p.CreatedAt
+		results = append(results, res)
+	}
+
+	return results, rows.Err()
+}
+
+func (r *SQLiteSearchRepo) searchKYCVerifications(ctx context.Context, term, pattern string, limit int) ([]*repository.SearchResult, error) {
+	query := `
+		SELECT id, payment_id, user_address, sumsub_applicant_id, sumsub_inspection_id,
+		       sumsub_review_status, sumsub_review_result, status, whitelist_tx_hash,
+		       created_at, updated_at, submitted_at, verified_at, rejected_at, matched_field,
+		       CAST(length(?1) AS REAL) / length(matched_value) AS score
+		FROM (
+			SELECT *,
+			       CASE
+			           WHEN user_address LIKE ?2 ESCAPE '\' THEN 'user_address'
+			           WHEN sumsub_applicant_id LIKE ?2 ESCAPE '\' THEN 'sumsub_applicant_id'
+			           WHEN whitelist_tx_hash LIKE ?2 ESCAPE '\' THEN 'whitelist_tx_hash'
+			           ELSE 'sumsub_review_result'
+			       END AS matched_field,
+			       CASE
+			           WHEN user_address LIKE ?2 ESCAPE '\' THEN user_address
+			           WHEN sumsub_applicant_id LIKE ?2 ESCAPE '\' THEN sumsub_applicant_id
+			           WHEN whitelist_tx_hash LIKE ?2 ESCAPE '\' THEN whitelist_tx_hash
+			           ELSE sumsub_review_result
+			       END AS matched_value
+			FROM kyc_verifications
+			WHERE user_address LIKE ?2 ESCAPE '\'
+			   OR sumsub_applicant_id LIKE ?2 ESCAPE '\'
+			   OR whitelist_tx_hash LIKE ?2 ESCAPE '\'
+			   OR sumsub_review_result LIKE ?2 ESCAPE '\'
+		)
+		ORDER BY score DESC, created_at DESC
+		LIMIT ?3
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, term, pattern, limit)
+	if err != nil {
+		return nil, fmt.Errorf("searching kyc verifications: %w", err)
+	}
+	defer rows.Close()
+
+	var results []*repository.SearchResult
+	for rows.Next() {
+		v := &repository.KYCVerification{}
+		res := &repository.SearchResult{Type: repository.SearchResultKYCVerification, KYCVerification: v}
+		var reviewResultJSON []byte
+		err := rows.Scan(
+			&v.ID,
+			&v.PaymentID,
+			&v.UserAddress,
+			&v.SumsubApplicantID,
+			&v.SumsubInspectionID,
+			&v.SumsubReviewStatus,
+			&reviewResultJSON,
+			&v.Status,
+			&v.WhitelistTxHash,
+			&v.CreatedAt,
+			&v.UpdatedAt,
+			&v.SubmittedAt,
+			&v.VerifiedAt,
+			&v.RejectedAt,
+			&res.MatchedField,
+			&res.Score,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("scanning kyc verification search row: %w", err)
+		}
+		if reviewResultJSON != nil {
+			if err := json.Unmarshal(reviewResultJSON, &v.SumsubReviewResult); err != nil {
+				return nil, fmt.Errorf("parsing review result: %w", err)
+			}
+		}
+		res.ID, res.CreatedAt = v.ID, v.CreatedAt
+		results = append(results, res)
+	}
+
+	return results, rows.Err()
+}
+
+func (r *SQLiteSearchRepo) searchMetaTxs(ctx context.Context, term, pattern string, limit int) ([]*repository.SearchResult, error) {
+	query := `
+		SELECT ` + metaTxColumns + `, matched_field,
+		       CAST(length(?1) AS REAL) / length(matched_value) AS score
+		FROM (
+			SELECT *,
+			       CASE
+			           WHEN tx_hash LIKE ?2 ESCAPE '\' THEN 'tx_hash'
+			           WHEN from_address LIKE ?2 ESCAPE '\' THEN 'from_address'
+			           ELSE 'to_address'
+			       END AS matched_field,
+			       CASE
+			           WHEN tx_hash LIKE ?2 ESCAPE '\' THEN tx_hash
+			           WHEN from_address LIKE ?2 ESCAPE '\' THEN from_address
+			           ELSE to_address
+			       END AS matched_value
+			FROM meta_transactions
+			WHERE from_address LIKE ?2 ESCAPE '\'
+			   OR to_address LIKE ?2 ESCAPE '\'
+			   OR tx_hash LIKE ?2 ESCAPE '\'
+		)
+		ORDER BY score DESC, created_at DESC
+		LIMIT ?3
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, term, pattern, limit)
+	if err != nil {
+		return nil, fmt.Errorf("searching meta-transactions: %w", err)
+	}
+	defer rows.Close()
+
+	var results []*repository.SearchResult
+	for rows.Next() {
+		tx := &repository.MetaTransaction{}
+		res := &repository.SearchResult{Type: repository.SearchResultMetaTx, MetaTransaction: tx}
+		err := rows.Scan(
+			&tx.ID,
+			&tx.FromAddress,
+			&tx.ToAddress,
+			&tx.FunctionName,
+			&tx.Calldata,
+			&tx.Value,
+			&tx.GasLimit,
+			&tx.Nonce,
+			&tx.Deadline,
+			&tx.Signature,
+			&tx.Status,
+			&tx.TxHash,
+			&tx.GasUsed,
+			&tx.GasPrice,
+			&tx.RelayCostETH,
+			&tx.ErrorMessage,
+			&tx.RetryCount,
+			&tx.CreatedAt,
+			&tx.UpdatedAt,
+			&tx.SubmittedAt,
+			&tx.ConfirmedAt,
+			&res.MatchedField,
+			&res.Score,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("scanning meta-transaction search row: %w", err)
+		}
+		res.ID, res.CreatedAt = tx.ID, tx.CreatedAt
+		results = append(results, res)
+	}
+
+	return results, rows.Err()
+}
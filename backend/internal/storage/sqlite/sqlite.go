@@ -0,0 +1,116 @@
+// Package sqlite implements repository interfaces using SQLite
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/storage/migrate"
+)
+
+// ErrDriverUnavailable is returned by Open when the binary was built without
+// the sqlite build tag
+var ErrDriverUnavailable = errors.New("sqlite driver not compiled in (build with -tags sqlite)")
+
+//go:embed migrations/*.sql
+var migrationFS embed.FS
+
+// sqlNow is the SQLite expression for the current UTC time. It uses the same
+// layout as timeArg so stored timestamps order correctly as text.
+const sqlNow = "strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')"
+
+// timeLayout is the text layout timestamps are stored in
+const timeLayout = "2006-01-02 15:04:05.999999999-07:00"
+
+// Available reports whether a SQLite driver was compiled into the binary
+func Available() bool {
+	return driverName != ""
+}
+
+// Open opens (creating if needed) the SQLite database at path and applies any
+// pending migrations. Use ":memory:" for a throwaway database.
+func Open(ctx context.Context, path string) (*sql.DB, error) {
+	if !Available() {
+		return nil, ErrDriverUnavailable
+	}
+
+	db, err := sql.Open(driverName, dsn(path))
+	if err != nil {
+		return nil, fmt.Errorf("opening sqlite database %s: %w", path, err)
+	}
+
+	// SQLite has a single writer, and each connection to ":memory:" is a
+	// separate database, so serialize everything through one connection
+	db.SetMaxOpenConns(1)
+	db.SetConnMaxLifetime(0)
+
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("connecting to sqlite database %s: %w", path, err)
+	}
+
+	if err := Migrate(ctx, db); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return db, nil
+}
+
+// Migrate applies the embedded SQLite migrations
+func Migrate(ctx context.Context, db *sql.DB) error {
+	migrations, err := migrate.Load(migrationFS, "migrations")
+	if err != nil {
+		return err
+	}
+
+	if _, err := migrate.Apply(ctx, db, migrate.SQLite, migrations); err != nil {
+		return fmt.Errorf("migrating sqlite database: %w", err)
+	}
+
+	return nil
+}
+
+// timeArg formats a time for binding. Drivers disagree on how they encode
+// time.Time, so timestamps are always bound as UTC text in timeLayout.
+func timeArg(t time.Time) string {
+	return t.UTC().Format(timeLayout)
+}
+
+// stringArrayScanner scans a JSON text array column (SQLite has no array type)
+type stringArrayScanner struct {
+	dst *[]string
+}
+
+// stringArray returns a scanner that decodes a JSON array column into dst
+func stringArray(dst *[]string) sql.Scanner {
+	return stringArrayScanner{dst: dst}
+}
+
+// Scan implements sql.Scanner
+func (s stringArrayScanner) Scan(src interface{}) error {
+	var raw []byte
+	switch v := src.(type) {
+	case nil:
+		*s.dst = nil
+		return nil
+	case string:
+		raw = []byte(v)
+	case []byte:
+		raw = v
+	default:
+		return fmt.Errorf("scanning string array: unsupported type %T", src)
+	}
+
+	var values []string
+	if err := json.Unmarshal(raw, &values); err != nil {
+		return fmt.Errorf("scanning string array: %w", err)
+	}
+	*s.dst = values
+	return nil
+}
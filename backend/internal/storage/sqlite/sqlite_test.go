@@ -0,0 +1,1758 @@
+//go:build sqlite
+
+package sqlite_test
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/storage/sqlite"
+)
+
+// openTestDB opens a migrated database in a temp dir
+func openTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sqlite.Open(context.Background(), filepath.Join(t.TempDir(), "nexus.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func TestOpen_MigrationsAreIdempotent(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "nexus.db")
+
+	db, err := sqlite.Open(ctx, path)
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+
+	// Reopening must not re-run 0001 (its seed inserts would be harmless, but
+	// CREATE INDEX without IF NOT EXISTS would fail)
+	db, err = sqlite.Open(ctx, path)
+	require.NoError(t, err)
+	defer db.Close()
+
+	var versions int
+	require.NoError(t, db.QueryRow("SELECT COUNT(*) FROM schema_migrations").Scan(&versions))
+	assert.Equal(t, 34, versions)
+}
+
+func TestPricingRepo_SeedAndHistoryTrigger(t *testing.T) {
+	ctx := context.Background()
+	repo := sqlite.NewSQLitePricingRepo(openTestDB(t))
+
+	pricing, err := repo.ListPricing(ctx, true)
+	require.NoError(t, err)
+	require.Len(t, pricing, 8)
+
+	_, err = repo.GetPricing(ctx, "unknown")
+	assert.ErrorIs(t, err, repository.ErrPricingNotFound)
+
+	before, err := repo.GetPricing(ctx, "kyc_verification")
+	require.NoError(t, err)
+	assert.Equal(t, 15.0, before.PriceUSD)
+
+	newPrice := 20.0
+	require.NoError(t, repo.UpdatePricing(ctx, "kyc_verification", &repository.PricingUpdate{
+		PriceUSD:  &newPrice,
+		UpdatedBy: "admin",
+	}))
+
+	after, err := repo.GetPricing(ctx, "kyc_verification")
+	require.NoError(t, err)
+	assert.Equal(t, 20.0, after.PriceUSD)
+	assert.False(t, after.UpdatedAt.Before(before.UpdatedAt))
+
+	history, err := repo.GetPricingHistory(ctx, "kyc_verification", 10)
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	assert.Equal(t, 15.0, *history[0].OldPriceUSD)
+	assert.Equal(t, 20.0, *history[0].NewPriceUSD)
+	assert.Equal(t, "admin", history[0].ChangedBy)
+
+	method, err := repo.GetPaymentMethod(ctx, "stripe")
+	require.NoError(t, err)
+	cfg, ok := method.ProcessorConfig.(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, "usd", cfg["currency"])
+}
+
+func TestPricingRepo_VersionConflicts(t *testing.T) {
+	ctx := context.Background()
+	repo := sqlite.NewSQLitePricingRepo(openTestDB(t))
+
+	pricing, err := repo.GetPricing(ctx, "kyc_verification")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), pricing.Version)
+
+	price, stale := 20.0, int64(1)
+	require.NoError(t, repo.UpdatePricing(ctx, "kyc_verification", &repository.PricingUpdate{
+		PriceUSD: &price, UpdatedBy: "admin", ExpectedVersion: &stale,
+	}))
+	err = repo.UpdatePricing(ctx, "kyc_verification", &repository.PricingUpdate{
+		PriceUSD: &price, UpdatedBy: "admin", ExpectedVersion: &stale,
+	})
+	assert.ErrorIs(t, err, repository.ErrVersionConflict)
+	err = repo.UpdatePricing(ctx, "unknown", &repository.PricingUpdate{UpdatedBy: "admin", ExpectedVersion: &stale})
+	assert.ErrorIs(t, err, repository.ErrPricingNotFound)
+
+	pricing, err = repo.GetPricing(ctx, "kyc_verification")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), pricing.Version)
+
+	fee := 3.0
+	require.NoError(t, repo.UpdatePaymentMethod(ctx, "stripe", &repository.PaymentMethodUpdate{FeePercent: &fee, ExpectedVersion: &stale}))
+	err = repo.UpdatePaymentMethod(ctx, "stripe", &repository.PaymentMethodUpdate{FeePercent: &fee, ExpectedVersion: &stale})
+	assert.ErrorIs(t, err, repository.ErrVersionConflict)
+	err = repo.UpdatePaymentMethod(ctx, "paypal", &repository.PaymentMethodUpdate{FeePercent: &fee, ExpectedVersion: &stale})
+	assert.ErrorIs(t, err, repository.ErrPaymentMethodNotFound)
+
+	method, err := repo.GetPaymentMethod(ctx, "stripe")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), method.Version)
+}
+
+func TestPaymentRepo_Lifecycle(t *testing.T) {
+	ctx := context.Background()
+	repo := sqlite.NewSQLitePaymentRepo(openTestDB(t))
+
+	session := "cs_test_123"
+	payment := &repository.Payment{
+		ServiceCode:     "kyc_verification",
+		PayerAddress:    "0xabc",
+		PaymentMethod:   "stripe",
+		AmountCharged:   15,
+		Currency:        "USD",
+		StripeSessionID: &session,
+		Status:          repository.PaymentStatusPending,
+	}
+	require.NoError(t, repo.CreatePayment(ctx, payment))
+	assert.Len(t, payment.ID, 36)
+	assert.False(t, payment.CreatedAt.IsZero())
+
+	require.NoError(t, repo.UpdatePaymentStatus(ctx, payment.ID, repository.PaymentStatusCompleted, nil))
+	got, err := repo.GetPaymentByStripeSession(ctx, session)
+	require.NoError(t, err)
+	assert.Equal(t, repository.PaymentStatusCompleted, got.Status)
+	require.NotNil(t, got.CompletedAt)
+
+	assert.ErrorIs(t, repo.UpdatePaymentStatus(ctx, "missing", repository.PaymentStatusCompleted, nil), repository.ErrPaymentNotFound)
+
+	list, total, err := repo.ListPayments(ctx, repository.PaymentFilter{PayerAddress: "0xabc"}, repository.Pagination{Page: 1, PageSize: 10})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Len(t, list, 1)
+
+	v := &repository.KYCVerification{UserAddress: "0xabc", PaymentID: &payment.ID, Status: repository.KYCStatusPending}
+	require.NoError(t, repo.CreateKYCVerification(ctx, v))
+
+	approved := repository.KYCStatusApproved
+	require.NoError(t, repo.UpdateKYCVerification(ctx, v.ID, &repository.KYCVerificationUpdate{
+		Status:             &approved,
+		SumsubReviewResult: map[string]interface{}{"reviewAnswer": "GREEN"},
+	}))
+
+	kyc, err := repo.GetKYCVerificationByAddress(ctx, "0xabc")
+	require.NoError(t, err)
+	assert.NotNil(t, kyc.VerifiedAt)
+	result, ok := kyc.SumsubReviewResult.(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, "GREEN", result["reviewAnswer"])
+}
+
+func TestPaymentRepo_ExpirePayments(t *testing.T) {
+	ctx := context.Background()
+	repo := sqlite.NewSQLitePaymentRepo(openTestDB(t))
+
+	newPayment := func(method string, status repository.PaymentStatus) *repository.Payment {
+		p := &repository.Payment{ServiceCode: "kyc_verification", PayerAddress: "0xabc", PaymentMethod: method,
+			AmountCharged: 15, Currency: "USD", Status: status}
+		require.NoError(t, repo.CreatePayment(ctx, p))
+		return p
+	}
+	stale := newPayment("stripe", repository.PaymentStatusPending)
+	paid := newPayment("stripe", repository.PaymentStatusCompleted)
+	crypto := newPayment("eth", repository.PaymentStatusPending)
+
+	// Linked payments are kept from the archive, not from expiry
+	v := &repository.KYCVerification{UserAddress: "0xabc", PaymentID: &stale.ID, Status: repository.KYCStatusPaymentRequired}
+	require.NoError(t, repo.CreateKYCVerification(ctx, v))
+
+	ids, err := repo.ExpirePayments(ctx, "stripe", time.Now().Add(-time.Hour), 10)
+	require.NoError(t, err)
+	assert.Empty(t, ids, "nothing is older than the cutoff")
+
+	ids, err = repo.ExpirePayments(ctx, "stripe", time.Now().Add(time.Hour), 10)
+	require.NoError(t, err)
+	assert.Equal(t, []string{stale.ID}, ids)
+
+	got, err := repo.GetPayment(ctx, stale.ID)
+	require.NoError(t, err)
+	assert.Equal(t, repository.PaymentStatusExpired, got.Status)
+	got, err = repo.GetPayment(ctx, crypto.ID)
+	require.NoError(t, err)
+	assert.Equal(t, repository.PaymentStatusPending, got.Status)
+
+	page := repository.Pagination{Page: 1, PageSize: 10}
+	list, total, err := repo.ListPayments(ctx, repository.PaymentFilter{}, page)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	for _, p := range list {
+		assert.NotEqual(t, stale.ID, p.ID)
+	}
+	_, total, err = repo.ListPayments(ctx, repository.PaymentFilter{IncludeExpired: true}, page)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), total)
+	list, _, err = repo.ListPayments(ctx, repository.PaymentFilter{Status: repository.PaymentStatusExpired}, page)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, stale.ID, list[0].ID)
+
+	moved, err := repo.ArchivePayments(ctx, time.Now().Add(time.Hour), 100)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), moved, "only the completed payment is archived; the expired one has a KYC verification")
+	_, err = repo.GetArchivedPayment(ctx, paid.ID)
+	require.NoError(t, err)
+}
+
+func TestPaymentRepo_Disputes(t *testing.T) {
+	ctx := context.Background()
+	repo := sqlite.NewSQLitePaymentRepo(openTestDB(t))
+
+	intentID := "pi_disputed"
+	disputed := &repository.Payment{ServiceCode: "kyc_verification", PayerAddress: "0xabc", PaymentMethod: "stripe",
+		AmountCharged: 15, Currency: "USD", Status: repository.PaymentStatusCompleted, StripePaymentID: &intentID}
+	require.NoError(t, repo.CreatePayment(ctx, disputed))
+	other := &repository.Payment{ServiceCode: "kyc_verification", PayerAddress: "0xabc", PaymentMethod: "stripe",
+		AmountCharged: 15, Currency: "USD", Status: repository.PaymentStatusCompleted}
+	require.NoError(t, repo.CreatePayment(ctx, other))
+
+	got, err := repo.GetPaymentByStripePaymentID(ctx, intentID)
+	require.NoError(t, err)
+	assert.Equal(t, disputed.ID, got.ID)
+	assert.Nil(t, got.DisputeID)
+	_, err = repo.GetPaymentByStripePaymentID(ctx, "pi_unknown")
+	assert.ErrorIs(t, err, repository.ErrPaymentNotFound)
+
+	disputeID, disputeStatus, reason := "dp_1", "needs_response", "fraudulent"
+	require.NoError(t, repo.UpdatePaymentStatus(ctx, disputed.ID, repository.PaymentStatusDisputed, &repository.PaymentStatusUpdate{
+		DisputeID: &disputeID, DisputeStatus: &disputeStatus, DisputeReason: &reason,
+	}))
+	got, err = repo.GetPayment(ctx, disputed.ID)
+	require.NoError(t, err)
+	assert.Equal(t, repository.PaymentStatusDisputed, got.Status)
+	assert.Equal(t, "dp_1", *got.DisputeID)
+	assert.Equal(t, "needs_response", *got.DisputeStatus)
+	assert.Equal(t, "fraudulent", *got.DisputeReason)
+	require.NotNil(t, got.DisputedAt)
+	disputedAt := *got.DisputedAt
+
+	// Closing the dispute keeps the time it was opened
+	disputeStatus = "won"
+	require.NoError(t, repo.UpdatePaymentStatus(ctx, disputed.ID, repository.PaymentStatusCompleted, &repository.PaymentStatusUpdate{
+		DisputeID: &disputeID, DisputeStatus: &disputeStatus,
+	}))
+	got, err = repo.GetPayment(ctx, disputed.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "won", *got.DisputeStatus)
+	assert.True(t, disputedAt.Equal(*got.DisputedAt))
+
+	page := repository.Pagination{Page: 1, PageSize: 10}
+	list, total, err := repo.ListPayments(ctx, repository.PaymentFilter{Disputed: true}, page)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	require.Len(t, list, 1)
+	assert.Equal(t, "dp_1", *list[0].DisputeID)
+
+	v := &repository.KYCVerification{UserAddress: "0xabc", PaymentID: &other.ID, Status: repository.KYCStatusApproved}
+	require.NoError(t, repo.CreateKYCVerification(ctx, v))
+	suspended := repository.KYCStatusSuspended
+	require.NoError(t, repo.UpdateKYCVerification(ctx, v.ID, &repository.KYCVerificationUpdate{Status: &suspended}))
+
+	// Dispute details survive archiving
+	moved, err := repo.ArchivePayments(ctx, time.Now().Add(time.Hour), 100)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), moved)
+	archived, err := repo.GetArchivedPayment(ctx, disputed.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "won", *archived.DisputeStatus)
+	list, _, err = repo.ListArchivedPayments(ctx, repository.PaymentFilter{Disputed: true}, page)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+}
+
+func TestRelayerRepo_DeadlinesCompareAsTime(t *testing.T) {
+	ctx := context.Background()
+	repo := sqlite.NewSQLiteRelayerRepo(openTestDB(t))
+
+	// A local-zone deadline must compare correctly with the UTC clock in SQL
+	loc := time.FixedZone("UTC-7", -7*3600)
+	live := &repository.MetaTransaction{FromAddress: "0xabc", ToAddress: "0xdef", FunctionName: "transfer", Calldata: "0x", Value: "0",
+		GasLimit: 100000, Nonce: 1, Deadline: time.Now().Add(time.Hour).In(loc), Signature: "0xsig", Status: repository.MetaTxStatusPending}
+	expired := &repository.MetaTransaction{FromAddress: "0xabc", ToAddress: "0xdef", FunctionName: "transfer", Calldata: "0x", Value: "0",
+		GasLimit: 100000, Nonce: 2, Deadline: time.Now().Add(-time.Minute).In(loc), Signature: "0xsig", Status: repository.MetaTxStatusPending}
+	require.NoError(t, repo.CreateMetaTx(ctx, live))
+	require.NoError(t, repo.CreateMetaTx(ctx, expired))
+
+	nonce, err := repo.GetNextNonce(ctx, "0xabc")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), nonce)
+
+	pending, err := repo.GetPendingMetaTxs(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, live.ID, pending[0].ID)
+	assert.WithinDuration(t, live.Deadline, pending[0].Deadline, time.Microsecond)
+
+	stale, err := repo.GetExpiredMetaTxs(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, stale, 1)
+	assert.Equal(t, expired.ID, stale[0].ID)
+}
+
+func TestRelayerRepo_CreateMetaTxBatch(t *testing.T) {
+	ctx := context.Background()
+	repo := sqlite.NewSQLiteRelayerRepo(openTestDB(t))
+
+	txs := make([]*repository.MetaTransaction, 3)
+	for i := range txs {
+		txs[i] = &repository.MetaTransaction{FromAddress: "0xabc", ToAddress: "0xdef", FunctionName: "transfer", Calldata: "0x", Value: "0",
+			GasLimit: 100000, Nonce: uint64(i + 1), Deadline: time.Now().Add(time.Hour), Signature: "0xsig", Status: repository.MetaTxStatusPending}
+	}
+	require.NoError(t, repo.CreateMetaTxBatch(ctx, txs))
+
+	for _, tx := range txs {
+		require.NotEmpty(t, tx.ID)
+		assert.False(t, tx.CreatedAt.IsZero())
+
+		got, err := repo.GetMetaTx(ctx, tx.ID)
+		require.NoError(t, err)
+		assert.Equal(t, tx.Nonce, got.Nonce)
+	}
+
+	// All or nothing: a duplicate ID rejects the whole batch
+	dup := *txs[0]
+	extra := &repository.MetaTransaction{FromAddress: "0xabc", ToAddress: "0xdef", FunctionName: "transfer", Calldata: "0x", Value: "0",
+		GasLimit: 100000, Nonce: 9, Deadline: time.Now().Add(time.Hour), Signature: "0xsig", Status: repository.MetaTxStatusPending}
+	require.Error(t, repo.CreateMetaTxBatch(ctx, []*repository.MetaTransaction{extra, &dup}))
+
+	nonce, err := repo.GetNextNonce(ctx, "0xabc")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(4), nonce)
+}
+
+func TestArchive_MovesOldRecords(t *testing.T) {
+	ctx := context.Background()
+	db := openTestDB(t)
+	payments := sqlite.NewSQLitePaymentRepo(db)
+	relayer := sqlite.NewSQLiteRelayerRepo(db)
+
+	country := "DE"
+	settled := &repository.Payment{ServiceCode: "kyc_verification", PayerAddress: "0xabc", PaymentMethod: "stripe",
+		AmountCharged: 15, Currency: "USD", Status: repository.PaymentStatusCompleted, Country: &country}
+	open := &repository.Payment{ServiceCode: "kyc_verification", PayerAddress: "0xabc", PaymentMethod: "stripe",
+		AmountCharged: 15, Currency: "USD", Status: repository.PaymentStatusPending}
+	require.NoError(t, payments.CreatePayment(ctx, settled))
+	require.NoError(t, payments.CreatePayment(ctx, open))
+
+	moved, err := payments.ArchivePayments(ctx, time.Now().Add(time.Hour), 100)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), moved)
+
+	_, err = payments.GetPayment(ctx, settled.ID)
+	assert.ErrorIs(t, err, repository.ErrPaymentNotFound)
+	archived, err := payments.GetArchivedPayment(ctx, settled.ID)
+	require.NoError(t, err)
+	assert.Equal(t, settled.CreatedAt.UTC(), archived.CreatedAt.UTC())
+	require.NotNil(t, archived.ArchivedAt)
+	require.NotNil(t, archived.Country, "the GeoIP country moves with the payment")
+	assert.Equal(t, "DE", *archived.Country)
+
+	list, total, err := payments.ListArchivedPayments(ctx, repository.PaymentFilter{PayerAddress: "0xabc"}, repository.Pagination{Page: 1, PageSize: 10})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Len(t, list, 1)
+
+	newMetaTx := func(nonce uint64, status repository.MetaTxStatus) *repository.MetaTransaction {
+		return &repository.MetaTransaction{FromAddress: "0xabc", ToAddress: "0xdef", FunctionName: "transfer", Calldata: "0x", Value: "0",
+			GasLimit: 100000, Nonce: nonce, Deadline: time.Now().Add(time.Hour), Signature: "0xsig", Status: status}
+	}
+	first, latest := newMetaTx(1, repository.MetaTxStatusConfirmed), newMetaTx(2, repository.MetaTxStatusConfirmed)
+	require.NoError(t, relayer.CreateMetaTx(ctx, first))
+	require.NoError(t, relayer.CreateMetaTx(ctx, latest))
+
+	moved, err = relayer.ArchiveMetaTxs(ctx, time.Now().Add(time.Hour), 100)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), moved, "the sender's highest nonce stays live")
+
+	_, err = relayer.GetArchivedMetaTx(ctx, first.ID)
+	require.NoError(t, err)
+	nonce, err := relayer.GetNextNonce(ctx, "0xabc")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), nonce)
+}
+
+func TestRelayerRepo_RelaySpend(t *testing.T) {
+	ctx := context.Background()
+	repo := sqlite.NewSQLiteRelayerRepo(openTestDB(t))
+
+	mined := func(from string, nonce uint64, status repository.MetaTxStatus, gasUsed uint64, gasPrice string) *repository.MetaTransaction {
+		tx := &repository.MetaTransaction{FromAddress: from, ToAddress: "0xdef", FunctionName: "transfer", Calldata: "0x", Value: "0",
+			GasLimit: 200000, Nonce: nonce, Deadline: time.Now().Add(time.Hour), Signature: "0xsig", Status: repository.MetaTxStatusSubmitted}
+		require.NoError(t, repo.CreateMetaTx(ctx, tx))
+		require.NoError(t, repo.UpdateMetaTxStatus(ctx, tx.ID, &repository.MetaTxStatusUpdate{Status: status, GasUsed: &gasUsed, GasPrice: &gasPrice}))
+		return tx
+	}
+	// 100000 gas at 0.05 ETH/gas overflows a 64-bit wei sum
+	mined("0xabc", 1, repository.MetaTxStatusConfirmed, 100000, "50000000000000000")
+	mined("0xabc", 2, repository.MetaTxStatusFailed, 50000, "1000000000")
+	mined("0x123", 1, repository.MetaTxStatusConfirmed, 21000, "1000000000")
+	pending := &repository.MetaTransaction{FromAddress: "0x123", ToAddress: "0xdef", FunctionName: "transfer", Calldata: "0x", Value: "0",
+		GasLimit: 100000, Nonce: 2, Deadline: time.Now().Add(time.Hour), Signature: "0xsig", Status: repository.MetaTxStatusPending}
+	require.NoError(t, repo.CreateMetaTx(ctx, pending))
+
+	// Archived meta-transactions still count
+	moved, err := repo.ArchiveMetaTxs(ctx, time.Now().Add(time.Hour), 100)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), moved)
+
+	byUser, err := repo.GetRelaySpend(ctx, repository.RelaySpendFilter{GroupBy: repository.RelaySpendByUser})
+	require.NoError(t, err)
+	require.Len(t, byUser, 2)
+	assert.Equal(t, &repository.RelaySpend{Key: "0xabc", TxCount: 2, GasUsed: 150000,
+		SpendWei: "5000000050000000000000", SpendETH: "5000.000050000000000000"}, byUser[0])
+	assert.Equal(t, "0x123", byUser[1].Key)
+	assert.Equal(t, "0.000021000000000000", byUser[1].SpendETH)
+
+	byDay, err := repo.GetRelaySpend(ctx, repository.RelaySpendFilter{GroupBy: repository.RelaySpendByDay, FromAddress: "0x123"})
+	require.NoError(t, err)
+	require.Len(t, byDay, 1)
+	assert.Equal(t, time.Now().UTC().Format("2006-01-02"), byDay[0].Key)
+	assert.Equal(t, int64(1), byDay[0].TxCount)
+
+	// Days can start at another zone's midnight
+	kiritimati, err := time.LoadLocation("Pacific/Kiritimati")
+	require.NoError(t, err)
+	byDay, err = repo.GetRelaySpend(ctx, repository.RelaySpendFilter{GroupBy: repository.RelaySpendByDay, FromAddress: "0x123", Location: kiritimati})
+	require.NoError(t, err)
+	require.Len(t, byDay, 1)
+	assert.Equal(t, time.Now().In(kiritimati).Format("2006-01-02"), byDay[0].Key)
+
+	before := time.Now().Add(-time.Hour)
+	none, err := repo.GetRelaySpend(ctx, repository.RelaySpendFilter{GroupBy: repository.RelaySpendByContract, To: &before})
+	require.NoError(t, err)
+	assert.Empty(t, none)
+}
+
+func TestGasBillingRepo_DebtAndSettlements(t *testing.T) {
+	ctx := context.Background()
+	repo := sqlite.NewSQLiteGasBillingRepo(openTestDB(t))
+
+	charge := func(metaTxID, user, amount string) bool {
+		created, err := repo.CreateGasCharge(ctx, &repository.GasCharge{MetaTxID: metaTxID, UserAddress: user, GasUsed: 21000,
+			GasPrice: "1000000000", CostWei: "21000000000000", NexusPerETH: "1000000000000000000000", AmountNexus: amount})
+		require.NoError(t, err)
+		return created
+	}
+	// Amounts beyond 64 bits are summed exactly
+	assert.True(t, charge("mtx-1", "0xabc", "30000000000000000000"))
+	assert.True(t, charge("mtx-2", "0xabc", "20000000000000000000"))
+	assert.False(t, charge("mtx-2", "0xabc", "1"), "a meta-tx is charged once")
+	assert.True(t, charge("mtx-3", "0x123", "5"))
+
+	charges, total, err := repo.ListGasCharges(ctx, "0xabc", repository.Pagination{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	require.Len(t, charges, 2)
+	assert.Equal(t, "20000000000000000000", charges[0].AmountNexus)
+
+	txHash := "0xfeed"
+	onchain := &repository.GasSettlement{UserAddress: "0xabc", Method: repository.GasSettlementOnchain,
+		AmountNexus: "10000000000000000000", Status: repository.GasSettlementPending, TxHash: &txHash}
+	require.NoError(t, repo.CreateGasSettlement(ctx, onchain))
+	assert.ErrorIs(t, repo.CreateGasSettlement(ctx, &repository.GasSettlement{UserAddress: "0xabc", Method: repository.GasSettlementOnchain,
+		AmountNexus: "1", Status: repository.GasSettlementPending, TxHash: &txHash}), repository.ErrGasSettlementExists)
+
+	debt, err := repo.GetGasDebt(ctx, "0xabc")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), debt.ChargeCount)
+	assert.Equal(t, "50000000000000000000", debt.Charged)
+	assert.Equal(t, "10000000000000000000", debt.Pending)
+	assert.Equal(t, "50000000000000000000", debt.Outstanding, "pending settlements are not deducted")
+	assert.NotNil(t, debt.LastChargedAt)
+
+	require.NoError(t, repo.UpdateGasSettlementStatus(ctx, onchain.ID, &repository.GasSettlementUpdate{Status: repository.GasSettlementSettled}))
+	got, err := repo.GetGasSettlement(ctx, onchain.ID)
+	require.NoError(t, err)
+	assert.Equal(t, repository.GasSettlementSettled, got.Status)
+	assert.NotNil(t, got.SettledAt)
+	assert.ErrorIs(t, repo.UpdateGasSettlementStatus(ctx, onchain.ID, &repository.GasSettlementUpdate{Status: repository.GasSettlementVoid}),
+		repository.ErrGasSettlementClosed)
+
+	// A reorg reopens the settlement
+	require.NoError(t, repo.UpdateGasSettlementStatus(ctx, onchain.ID, &repository.GasSettlementUpdate{Status: repository.GasSettlementPending}))
+	got, err = repo.GetGasSettlement(ctx, onchain.ID)
+	require.NoError(t, err)
+	assert.Nil(t, got.SettledAt)
+	require.NoError(t, repo.UpdateGasSettlementStatus(ctx, onchain.ID, &repository.GasSettlementUpdate{Status: repository.GasSettlementSettled}))
+
+	debt, err = repo.GetGasDebt(ctx, "0xabc")
+	require.NoError(t, err)
+	assert.Equal(t, "40000000000000000000", debt.Outstanding)
+	assert.Equal(t, "40.000000000000000000", debt.OutstandingNexus)
+
+	debts, total, err := repo.ListGasDebts(ctx, repository.Pagination{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	require.Len(t, debts, 2)
+	assert.Equal(t, "0xabc", debts[0].UserAddress)
+	assert.Equal(t, "0x123", debts[1].UserAddress)
+
+	settlements, total, err := repo.ListGasSettlements(ctx, repository.GasSettlementFilter{UserAddress: "0xabc", Status: repository.GasSettlementSettled}, repository.Pagination{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	require.Len(t, settlements, 1)
+
+	debt, err = repo.GetGasDebt(ctx, "0xnever")
+	require.NoError(t, err)
+	assert.Equal(t, "0", debt.Outstanding)
+
+	_, err = repo.GetGasSettlement(ctx, "00000000-0000-4000-8000-000000000000")
+	assert.ErrorIs(t, err, repository.ErrGasSettlementNotFound)
+}
+
+func TestDepositRepo_IndexesAndTransitions(t *testing.T) {
+	ctx := context.Background()
+	repo := sqlite.NewSQLiteDepositRepo(openTestDB(t))
+
+	first, err := repo.NextDepositIndex(ctx)
+	require.NoError(t, err)
+	second, err := repo.NextDepositIndex(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), first)
+	assert.Equal(t, int64(1), second)
+
+	d := &repository.DepositIntent{PaymentID: "payment-1", PayerAddress: "0xabc", Currency: "ETH", DepositAddress: "0xdep",
+		DerivationIndex: first, ExpectedAmount: "30000000000000000000", Status: repository.DepositAwaiting, ExpiresAt: time.Now().Add(time.Hour)}
+	require.NoError(t, repo.CreateDepositIntent(ctx, d))
+	assert.NotEmpty(t, d.ID)
+	assert.Equal(t, "0", d.ReceivedAmount)
+	assert.Error(t, repo.CreateDepositIntent(ctx, &repository.DepositIntent{PaymentID: "payment-2", PayerAddress: "0xabc", Currency: "ETH",
+		DepositAddress: "0xdep", DerivationIndex: second, ExpectedAmount: "1", Status: repository.DepositAwaiting, ExpiresAt: time.Now()}),
+		"a deposit address is used once")
+
+	received := "29800000000000000000"
+	require.NoError(t, repo.UpdateDepositIntent(ctx, d.ID, &repository.DepositIntentUpdate{Status: repository.DepositConfirming, ReceivedAmount: &received}))
+	require.NoError(t, repo.UpdateDepositIntent(ctx, d.ID, &repository.DepositIntentUpdate{Status: repository.DepositPaid}))
+	sweep := "0xsweep"
+	require.NoError(t, repo.UpdateDepositIntent(ctx, d.ID, &repository.DepositIntentUpdate{Status: repository.DepositPaid, SweepTxHash: &sweep}))
+
+	got, err := repo.GetDepositIntent(ctx, d.ID)
+	require.NoError(t, err)
+	assert.Equal(t, repository.DepositPaid, got.Status)
+	assert.Equal(t, received, got.ReceivedAmount, "kept when not updated")
+	assert.NotNil(t, got.DetectedAt)
+	assert.NotNil(t, got.PaidAt)
+	require.NotNil(t, got.SweepTxHash)
+	assert.Equal(t, sweep, *got.SweepTxHash)
+	assert.NotNil(t, got.SweepAttemptedAt)
+	assert.Nil(t, got.SweptAt)
+	assert.WithinDuration(t, d.ExpiresAt, got.ExpiresAt, time.Millisecond)
+
+	list, total, err := repo.ListDepositIntents(ctx, repository.DepositIntentFilter{Status: repository.DepositPaid}, repository.Pagination{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	require.Len(t, list, 1)
+	list, _, err = repo.ListDepositIntents(ctx, repository.DepositIntentFilter{Status: repository.DepositAwaiting}, repository.Pagination{})
+	require.NoError(t, err)
+	assert.Empty(t, list)
+
+	_, err = repo.GetDepositIntent(ctx, "missing")
+	assert.ErrorIs(t, err, repository.ErrDepositIntentNotFound)
+	assert.ErrorIs(t, repo.UpdateDepositIntent(ctx, "missing", &repository.DepositIntentUpdate{Status: repository.DepositSwept}), repository.ErrDepositIntentNotFound)
+}
+
+func TestLedgerRepo_PostAndBalances(t *testing.T) {
+	ctx := context.Background()
+	db := openTestDB(t)
+	repo := sqlite.NewSQLiteLedgerRepo(db)
+
+	paymentID := "payment-1"
+	sale := &repository.LedgerTransaction{IdempotencyKey: "payment-1:completed:1", EventType: repository.LedgerEventSale, PaymentID: &paymentID,
+		Entries: []*repository.LedgerEntry{
+			{Account: repository.LedgerCustomerFunds, Direction: repository.LedgerDebit, Amount: "1544", Currency: "USD"},
+			{Account: repository.LedgerRevenue, Direction: repository.LedgerCredit, Amount: "1500", Currency: "USD"},
+			{Account: repository.LedgerFees, Direction: repository.LedgerCredit, Amount: "44", Currency: "USD"},
+			{Account: repository.LedgerCustomerFunds, Direction: repository.LedgerDebit, Amount: "30000000000000000000", Currency: "NEXUS"},
+			{Account: repository.LedgerRevenue, Direction: repository.LedgerCredit, Amount: "30000000000000000000", Currency: "NEXUS"},
+		}}
+	posted, err := repo.PostLedgerTransaction(ctx, sale)
+	require.NoError(t, err)
+	assert.True(t, posted)
+	assert.NotEmpty(t, sale.ID)
+
+	again := &repository.LedgerTransaction{IdempotencyKey: sale.IdempotencyKey, EventType: repository.LedgerEventSale, Entries: sale.Entries}
+	posted, err = repo.PostLedgerTransaction(ctx, again)
+	require.NoError(t, err)
+	assert.False(t, posted, "an idempotency key posts once")
+
+	_, err = repo.PostLedgerTransaction(ctx, &repository.LedgerTransaction{IdempotencyKey: "unbalanced", EventType: repository.LedgerEventRefund,
+		Entries: []*repository.LedgerEntry{
+			{Account: repository.LedgerRefunds, Direction: repository.LedgerDebit, Amount: "1544", Currency: "USD"},
+			{Account: repository.LedgerCustomerFunds, Direction: repository.LedgerCredit, Amount: "1500", Currency: "USD"},
+		}})
+	assert.ErrorIs(t, err, repository.ErrLedgerUnbalanced)
+
+	refund := &repository.LedgerTransaction{IdempotencyKey: "payment-1:refunded:2", EventType: repository.LedgerEventRefund, PaymentID: &paymentID,
+		Entries: []*repository.LedgerEntry{
+			{Account: repository.LedgerRefunds, Direction: repository.LedgerDebit, Amount: "1544", Currency: "USD"},
+			{Account: repository.LedgerCustomerFunds, Direction: repository.LedgerCredit, Amount: "1544", Currency: "USD"},
+		}}
+	_, err = repo.PostLedgerTransaction(ctx, refund)
+	require.NoError(t, err)
+
+	got, err := repo.GetLedgerTransaction(ctx, sale.ID)
+	require.NoError(t, err)
+	require.Len(t, got.Entries, 5)
+	assert.Equal(t, repository.LedgerDebit, got.Entries[0].Direction, "debits first")
+	assert.Equal(t, paymentID, *got.Entries[0].PaymentID)
+	_, err = repo.GetLedgerTransaction(ctx, "missing")
+	assert.ErrorIs(t, err, repository.ErrLedgerTransactionNotFound)
+
+	balances, err := repo.GetLedgerBalances(ctx, repository.LedgerBalanceFilter{Currency: "USD"})
+	require.NoError(t, err)
+	byAccount := map[repository.LedgerAccount]*repository.LedgerBalance{}
+	for _, b := range balances {
+		byAccount[b.Account] = b
+	}
+	require.Len(t, byAccount, 4)
+	assert.Equal(t, "0", byAccount[repository.LedgerCustomerFunds].Balance)
+	assert.Equal(t, int64(2), byAccount[repository.LedgerCustomerFunds].EntryCount)
+	assert.Equal(t, "1500", byAccount[repository.LedgerRevenue].Balance, "credit-normal")
+	assert.Equal(t, "1544", byAccount[repository.LedgerRefunds].Balance, "debit-normal")
+
+	balances, err = repo.GetLedgerBalances(ctx, repository.LedgerBalanceFilter{Currency: "NEXUS"})
+	require.NoError(t, err)
+	require.Len(t, balances, 2)
+	assert.Equal(t, "30000000000000000000", balances[0].Balance)
+
+	entries, total, err := repo.ListLedgerEntries(ctx, repository.LedgerEntryFilter{PaymentID: paymentID, EventType: repository.LedgerEventRefund}, repository.Pagination{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	require.Len(t, entries, 2)
+	assert.Equal(t, refund.ID, entries[0].TransactionID)
+	_, total, err = repo.ListLedgerEntries(ctx, repository.LedgerEntryFilter{Account: repository.LedgerFees}, repository.Pagination{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+
+	// Posted rows are immutable
+	_, err = db.Exec("UPDATE ledger_entries SET amount = '1' WHERE transaction_id = ?1", sale.ID)
+	assert.Error(t, err)
+	_, err = db.Exec("DELETE FROM ledger_transactions WHERE id = ?1", sale.ID)
+	assert.Error(t, err)
+}
+
+func TestPayoutRepo_WorkflowAndDue(t *testing.T) {
+	ctx := context.Background()
+	repo := sqlite.NewSQLitePayoutRepo(openTestDB(t))
+
+	token, ref := "0xtoken", "42"
+	p := &repository.Payout{Kind: repository.PayoutGrant, Recipient: "0xabc", Currency: "NEXUS", TokenAddress: &token,
+		Amount: "5000000000000000000000", Reference: &ref, Status: repository.PayoutPendingApproval, CreatedBy: "alice"}
+	require.NoError(t, repo.CreatePayout(ctx, p))
+	assert.NotEmpty(t, p.ID)
+	later := &repository.Payout{Kind: repository.PayoutRoyalty, Recipient: "0xdef", Currency: "ETH", Amount: "1",
+		Status: repository.PayoutApproved, ScheduledFor: time.Now().Add(time.Hour), CreatedBy: "alice"}
+	require.NoError(t, repo.CreatePayout(ctx, later))
+
+	due, err := repo.ListDuePayouts(ctx, time.Now(), 10)
+	require.NoError(t, err)
+	assert.Empty(t, due, "pending approval and future payouts are not due")
+
+	assert.ErrorIs(t, repo.UpdatePayoutStatus(ctx, p.ID, &repository.PayoutUpdate{Status: repository.PayoutSending}), repository.ErrPayoutClosed)
+	bob := "bob"
+	require.NoError(t, repo.UpdatePayoutStatus(ctx, p.ID, &repository.PayoutUpdate{Status: repository.PayoutApproved, ReviewedBy: &bob}))
+
+	due, err = repo.ListDuePayouts(ctx, time.Now(), 10)
+	require.NoError(t, err)
+	require.Len(t, due, 1)
+	assert.Equal(t, p.ID, due[0].ID)
+
+	sender, hash := "0xrelayer", "0xtx"
+	require.NoError(t, repo.UpdatePayoutStatus(ctx, p.ID, &repository.PayoutUpdate{Status: repository.PayoutSending}))
+	require.NoError(t, repo.UpdatePayoutStatus(ctx, p.ID, &repository.PayoutUpdate{Status: repository.PayoutSubmitted, SenderAddress: &sender, TxHash: &hash}))
+	block, gas, fee := uint64(12), uint64(52000), "1040000000000000"
+	require.NoError(t, repo.UpdatePayoutStatus(ctx, p.ID, &repository.PayoutUpdate{Status: repository.PayoutConfirmed, BlockNumber: &block, GasUsed: &gas, FeeWei: &fee}))
+
+	got, err := repo.GetPayout(ctx, p.ID)
+	require.NoError(t, err)
+	assert.Equal(t, repository.PayoutConfirmed, got.Status)
+	assert.Equal(t, p.Amount, got.Amount)
+	assert.Equal(t, bob, *got.ReviewedBy, "kept when not updated")
+	assert.NotNil(t, got.ReviewedAt)
+	assert.Equal(t, sender, *got.SenderAddress)
+	assert.Equal(t, hash, *got.TxHash)
+	assert.NotNil(t, got.SubmittedAt)
+	assert.NotNil(t, got.ConfirmedAt)
+	assert.Equal(t, block, *got.BlockNumber)
+	assert.Equal(t, fee, *got.FeeWei)
+
+	// A reorg sends it back to submitted
+	require.NoError(t, repo.UpdatePayoutStatus(ctx, p.ID, &repository.PayoutUpdate{Status: repository.PayoutSubmitted}))
+	got, err = repo.GetPayout(ctx, p.ID)
+	require.NoError(t, err)
+	assert.Nil(t, got.ConfirmedAt)
+	assert.Nil(t, got.BlockNumber)
+
+	list, total, err := repo.ListPayouts(ctx, repository.PayoutFilter{Reference: ref}, repository.Pagination{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	require.Len(t, list, 1)
+	_, total, err = repo.ListPayouts(ctx, repository.PayoutFilter{Kind: repository.PayoutRoyalty}, repository.Pagination{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+
+	_, err = repo.GetPayout(ctx, "missing")
+	assert.ErrorIs(t, err, repository.ErrPayoutNotFound)
+}
+
+func TestSnapshotRepo_UpsertKeepsLink(t *testing.T) {
+	ctx := context.Background()
+	repo := sqlite.NewSQLiteSnapshotRepo(openTestDB(t))
+
+	start := time.Now().Add(-time.Hour)
+	p := &repository.SnapshotProposal{ID: "0xabc", Space: "nexusprotocol.eth", Title: "Temp check", Author: "0xaa",
+		Choices: []string{"For", "Against"}, Scores: []float64{1200.5, 300}, ScoresTotal: 1500.5, Votes: 12,
+		State: repository.SnapshotProposalActive, StartTime: start, EndTime: start.Add(72 * time.Hour), CreatedAt: start}
+	require.NoError(t, repo.UpsertSnapshotProposal(ctx, p))
+	assert.Nil(t, p.OnchainProposalID)
+
+	onchain := "onchain-1"
+	require.NoError(t, repo.LinkSnapshotProposal(ctx, p.ID, &onchain))
+
+	p.State, p.Votes, p.Scores = repository.SnapshotProposalClosed, 20, []float64{2000, 300}
+	require.NoError(t, repo.UpsertSnapshotProposal(ctx, p))
+	require.NotNil(t, p.OnchainProposalID, "a sync keeps the link")
+
+	got, err := repo.GetSnapshotProposal(ctx, p.ID)
+	require.NoError(t, err)
+	assert.Equal(t, repository.SnapshotProposalClosed, got.State)
+	assert.Equal(t, 20, got.Votes)
+	assert.Equal(t, []string{"For", "Against"}, got.Choices)
+	assert.Equal(t, []float64{2000, 300}, got.Scores)
+	assert.Equal(t, onchain, *got.OnchainProposalID)
+	assert.WithinDuration(t, start, got.StartTime, time.Millisecond)
+
+	_, total, err := repo.ListSnapshotProposals(ctx, repository.SnapshotProposalFilter{Unlinked: true}, repository.Pagination{})
+	require.NoError(t, err)
+	assert.Zero(t, total)
+	list, total, err := repo.ListSnapshotProposals(ctx, repository.SnapshotProposalFilter{OnchainProposalID: onchain}, repository.Pagination{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	require.Len(t, list, 1)
+
+	require.NoError(t, repo.LinkSnapshotProposal(ctx, p.ID, nil))
+	got, err = repo.GetSnapshotProposal(ctx, p.ID)
+	require.NoError(t, err)
+	assert.Nil(t, got.OnchainProposalID)
+
+	_, err = repo.GetSnapshotProposal(ctx, "0xmissing")
+	assert.ErrorIs(t, err, repository.ErrSnapshotProposalNotFound)
+	assert.ErrorIs(t, repo.LinkSnapshotProposal(ctx, "0xmissing", nil), repository.ErrSnapshotProposalNotFound)
+}
+
+func TestSearchRepo_Search(t *testing.T) {
+	ctx := context.Background()
+	db := openTestDB(t)
+	payments := sqlite.NewSQLitePaymentRepo(db)
+	search := sqlite.NewSQLiteSearchRepo(db)
+
+	session := "cs_test_a1b2c3d4"
+	payment := &repository.Payment{ServiceCode: "kyc_verification", PayerAddress: "0xabc", PaymentMethod: "stripe",
+		AmountCharged: 15, Currency: "USD", Status: repository.PaymentStatusPending, StripeSessionID: &session}
+	require.NoError(t, payments.CreatePayment(ctx, payment))
+
+	kyc := &repository.KYCVerification{UserAddress: "0xdef", Status: repository.KYCStatusPending}
+	require.NoError(t, payments.CreateKYCVerification(ctx, kyc))
+	require.NoError(t, payments.UpdateKYCVerification(ctx, kyc.ID, &repository.KYCVerificationUpdate{
+		SumsubReviewResult: map[string]any{"email": "alice@example.com"},
+	}))
+
+	results, err := search.Search(ctx, repository.SearchQuery{Term: "A1B2C3"})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, repository.SearchResultPayment, results[0].Type)
+	assert.Equal(t, "stripe_session_id", results[0].MatchedField)
+	assert.Equal(t, payment.ID, results[0].Payment.ID)
+	assert.InDelta(t, 6.0/16.0, results[0].Score, 0.001)
+
+	results, err = search.Search(ctx, repository.SearchQuery{Term: "alice@", Types: []repository.SearchResultType{repository.SearchResultKYCVerification}})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "sumsub_review_result", results[0].MatchedField)
+	assert.Equal(t, kyc.ID, results[0].KYCVerification.ID)
+
+	// LIKE wildcards in the term match literally
+	results, err = search.Search(ctx, repository.SearchQuery{Term: "%_%"})
+	require.NoError(t, err)
+	assert.Empty(t, results)
+}
+
+func TestKPIRepo_GetBusinessKPIs(t *testing.T) {
+	ctx := context.Background()
+	db := openTestDB(t)
+	payments := sqlite.NewSQLitePaymentRepo(db)
+	relayer := sqlite.NewSQLiteRelayerRepo(db)
+
+	require.NoError(t, payments.CreatePayment(ctx, &repository.Payment{ServiceCode: "kyc_verification", PayerAddress: "0xabc",
+		PaymentMethod: "stripe", AmountCharged: 15, Currency: "USD", Status: repository.PaymentStatusCompleted}))
+	kyc := &repository.KYCVerification{UserAddress: "0xabc", Status: repository.KYCStatusPending}
+	require.NoError(t, payments.CreateKYCVerification(ctx, kyc))
+	approved := repository.KYCStatusApproved
+	require.NoError(t, payments.UpdateKYCVerification(ctx, kyc.ID, &repository.KYCVerificationUpdate{Status: &approved}))
+
+	tx := &repository.MetaTransaction{FromAddress: "0xabc", ToAddress: "0xdef", FunctionName: "transfer", Calldata: "0x", Value: "0",
+		GasLimit: 100000, Nonce: 1, Deadline: time.Now().Add(time.Hour), Signature: "0xsig", Status: repository.MetaTxStatusSubmitted}
+	require.NoError(t, relayer.CreateMetaTx(ctx, tx))
+	require.NoError(t, relayer.UpdateMetaTxStatus(ctx, tx.ID, &repository.MetaTxStatusUpdate{Status: repository.MetaTxStatusConfirmed}))
+
+	kpis, err := sqlite.NewSQLiteKPIRepo(db).GetBusinessKPIs(ctx, time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), kpis.PaymentsByStatus[repository.PaymentStatusCompleted])
+	assert.Equal(t, int64(1), kpis.KYCByStatus[repository.KYCStatusApproved])
+	assert.Equal(t, int64(1), kpis.KYCApproved)
+	assert.Equal(t, 1.0, kpis.KYCApprovalRate())
+	assert.Equal(t, int64(1), kpis.RelayConfirmed)
+	assert.GreaterOrEqual(t, kpis.RelayConfirmationSeconds, 0.0)
+	assert.Less(t, kpis.RelayConfirmationSeconds, 60.0)
+	assert.Nil(t, kpis.NFTMints)
+}
+
+func TestWebhookEventRepo_DedupAndClaim(t *testing.T) {
+	ctx := context.Background()
+	repo := sqlite.NewSQLiteWebhookEventRepo(openTestDB(t))
+
+	e := &repository.WebhookEvent{Provider: repository.WebhookProviderStripe, EventID: "evt_1",
+		EventType: "checkout.session.completed", Payload: []byte(`{"id":"evt_1"}`)}
+	created, err := repo.CreateWebhookEvent(ctx, e)
+	require.NoError(t, err)
+	assert.True(t, created)
+	assert.Equal(t, repository.WebhookEventReceived, e.Status)
+
+	claimed, err := repo.ClaimWebhookEvent(ctx, e.ID, time.Hour)
+	require.NoError(t, err)
+	assert.True(t, claimed)
+
+	// A concurrent delivery neither duplicates the row nor steals the claim
+	dup := &repository.WebhookEvent{Provider: repository.WebhookProviderStripe, EventID: "evt_1", EventType: "checkout.session.completed"}
+	created, err = repo.CreateWebhookEvent(ctx, dup)
+	require.NoError(t, err)
+	assert.False(t, created)
+	assert.Equal(t, e.ID, dup.ID)
+	assert.Equal(t, repository.WebhookEventProcessing, dup.Status)
+	assert.Equal(t, `{"id":"evt_1"}`, string(dup.Payload))
+
+	claimed, err = repo.ClaimWebhookEvent(ctx, e.ID, time.Hour)
+	require.NoError(t, err)
+	assert.False(t, claimed)
+
+	// A stale claim can be taken over
+	claimed, err = repo.ClaimWebhookEvent(ctx, e.ID, -time.Second)
+	require.NoError(t, err)
+	assert.True(t, claimed)
+
+	require.NoError(t, repo.MarkWebhookEventFailed(ctx, e.ID, "boom", nil))
+	claimed, err = repo.ClaimWebhookEvent(ctx, e.ID, time.Hour)
+	require.NoError(t, err)
+	assert.True(t, claimed)
+	require.NoError(t, repo.MarkWebhookEventProcessed(ctx, e.ID))
+
+	got, err := repo.GetWebhookEventByEventID(ctx, repository.WebhookProviderStripe, "evt_1")
+	require.NoError(t, err)
+	assert.Equal(t, repository.WebhookEventProcessed, got.Status)
+	assert.Equal(t, 3, got.Attempts)
+	assert.Nil(t, got.LastError)
+	assert.NotNil(t, got.ProcessedAt)
+
+	claimed, err = repo.ClaimWebhookEvent(ctx, e.ID, -time.Second)
+	require.NoError(t, err)
+	assert.False(t, claimed)
+
+	events, total, err := repo.ListWebhookEvents(ctx, repository.WebhookEventFilter{Status: repository.WebhookEventProcessed}, repository.Pagination{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	require.Len(t, events, 1)
+
+	_, err = repo.GetWebhookEvent(ctx, "00000000-0000-4000-8000-000000000000")
+	assert.ErrorIs(t, err, repository.ErrWebhookEventNotFound)
+}
+
+func TestWebhookEventRepo_RetriesAndDeadLetter(t *testing.T) {
+	ctx := context.Background()
+	repo := sqlite.NewSQLiteWebhookEventRepo(openTestDB(t))
+
+	e := &repository.WebhookEvent{Provider: repository.WebhookProviderSumsub, EventID: "digest-1",
+		EventType: "applicantReviewed", Payload: []byte(`{}`)}
+	_, err := repo.CreateWebhookEvent(ctx, e)
+	require.NoError(t, err)
+
+	due, err := repo.ListDueWebhookEvents(ctx, repository.WebhookProviderSumsub, time.Hour, 10)
+	require.NoError(t, err)
+	require.Len(t, due, 1)
+
+	claimed, err := repo.ClaimWebhookEvent(ctx, e.ID, time.Hour)
+	require.NoError(t, err)
+	require.True(t, claimed)
+	retryAt := time.Now().Add(time.Minute)
+	require.NoError(t, repo.MarkWebhookEventFailed(ctx, e.ID, "boom", &retryAt))
+
+	due, err = repo.ListDueWebhookEvents(ctx, repository.WebhookProviderSumsub, time.Hour, 10)
+	require.NoError(t, err)
+	assert.Empty(t, due)
+
+	require.NoError(t, repo.MarkWebhookEventDeadLetter(ctx, e.ID, "boom"))
+	got, err := repo.GetWebhookEvent(ctx, e.ID)
+	require.NoError(t, err)
+	assert.Equal(t, repository.WebhookEventDeadLetter, got.Status)
+	assert.Nil(t, got.NextAttemptAt)
+
+	require.NoError(t, repo.RequeueWebhookEvent(ctx, e.ID))
+	due, err = repo.ListDueWebhookEvents(ctx, repository.WebhookProviderSumsub, time.Hour, 10)
+	require.NoError(t, err)
+	require.Len(t, due, 1)
+	assert.Equal(t, repository.WebhookEventReceived, due[0].Status)
+
+	assert.ErrorIs(t, repo.RequeueWebhookEvent(ctx, e.ID), repository.ErrWebhookEventNotRetryable)
+	assert.ErrorIs(t, repo.RequeueWebhookEvent(ctx, "00000000-0000-4000-8000-000000000000"), repository.ErrWebhookEventNotFound)
+}
+
+func TestContractRepo_UpsertAndABI(t *testing.T) {
+	ctx := context.Background()
+	repo := sqlite.NewSQLiteContractRepo(openTestDB(t))
+
+	network, err := repo.GetNetworkByChainID(ctx, 11155111)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"https://ethereum-sepolia-rpc.publicnode.com", "https://sepolia.drpc.org"}, network.RPCFallbackUrls)
+
+	mapping, err := repo.GetMappingByDBName(ctx, "nexusToken")
+	require.NoError(t, err)
+
+	first, err := repo.Upsert(ctx, &repository.ContractAddressUpsert{ChainID: 31337, ContractMappingID: mapping.ID, Address: "0x1"})
+	require.NoError(t, err)
+	name, version := "NexusForwarder", "2"
+	second, err := repo.Upsert(ctx, &repository.ContractAddressUpsert{ChainID: 31337, ContractMappingID: mapping.ID, Address: "0x2",
+		EIP712Name: &name, EIP712Version: &version})
+	require.NoError(t, err)
+	assert.Equal(t, first.ID, second.ID)
+	assert.Equal(t, "0x2", second.Address)
+	assert.Nil(t, first.EIP712Name)
+
+	stored, err := repo.GetByChainAndDBName(ctx, 31337, "nexusToken")
+	require.NoError(t, err)
+	require.NotNil(t, stored.EIP712Name)
+	assert.Equal(t, "NexusForwarder", *stored.EIP712Name)
+	assert.Equal(t, "2", *stored.EIP712Version)
+
+	history, err := repo.GetHistory(ctx, first.ID, 10)
+	require.NoError(t, err)
+	assert.NotEmpty(t, history)
+
+	_, err = repo.Upsert(ctx, &repository.ContractAddressUpsert{ChainID: 999, ContractMappingID: mapping.ID, Address: "0x3"})
+	assert.ErrorIs(t, err, repository.ErrNetworkNotFound)
+
+	abi := json.RawMessage(`[{"type":"function","name":"name"}]`)
+	_, err = repo.UpsertABI(ctx, &repository.ABIArtifactUpsert{DBName: "nexusToken", Version: "1.0.0", ABI: abi, ABIHash: "0xhash"})
+	require.NoError(t, err)
+
+	got, err := repo.GetABI(ctx, "nexusToken", repository.ABIVersionLatest)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(abi), string(got.ABI))
+
+	_, err = repo.UpsertABI(ctx, &repository.ABIArtifactUpsert{DBName: "nexusToken", Version: "2.0.0", ABI: json.RawMessage(`{}`), ABIHash: "0xhash"})
+	assert.Error(t, err, "non-array ABI violates the check constraint")
+}
+
+func TestContractRepo_ABIVersionsAreImmutable(t *testing.T) {
+	ctx := context.Background()
+	repo := sqlite.NewSQLiteContractRepo(openTestDB(t))
+
+	v1 := json.RawMessage(`[{"type":"function","name":"name"}]`)
+	first, err := repo.UpsertABI(ctx, &repository.ABIArtifactUpsert{DBName: "nexusToken", Version: "1.0.0", ABI: v1, ABIHash: "0xv1"})
+	require.NoError(t, err)
+
+	again, err := repo.UpsertABI(ctx, &repository.ABIArtifactUpsert{DBName: "nexusToken", Version: "1.0.0", ABI: v1, ABIHash: "0xv1"})
+	require.NoError(t, err)
+	assert.Equal(t, first.ID, again.ID)
+	assert.Equal(t, first.CreatedAt, again.CreatedAt)
+
+	v2 := json.RawMessage(`[{"type":"function","name":"symbol"}]`)
+	_, err = repo.UpsertABI(ctx, &repository.ABIArtifactUpsert{DBName: "nexusToken", Version: "1.0.0", ABI: v2, ABIHash: "0xv2"})
+	assert.ErrorIs(t, err, repository.ErrABIVersionConflict)
+	got, err := repo.GetABI(ctx, "nexusToken", "1.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, "0xv1", got.ABIHash)
+
+	// latest is the newest version, even when published within the same second
+	_, err = repo.UpsertABI(ctx, &repository.ABIArtifactUpsert{DBName: "nexusToken", Version: "2.0.0", ABI: v2, ABIHash: "0xv2"})
+	require.NoError(t, err)
+	got, err = repo.GetABI(ctx, "nexusToken", repository.ABIVersionLatest)
+	require.NoError(t, err)
+	assert.Equal(t, "2.0.0", got.Version)
+
+	versions, err := repo.ListABIVersions(ctx, "nexusToken")
+	require.NoError(t, err)
+	require.Len(t, versions, 2)
+	assert.Equal(t, "2.0.0", versions[0].Version)
+	assert.Equal(t, "1.0.0", versions[1].Version)
+}
+
+func TestGovernanceConfigRepo_ValueChangeResetsSync(t *testing.T) {
+	ctx := context.Background()
+	repo := sqlite.NewSQLiteGovernanceConfigRepo(openTestDB(t))
+
+	require.NoError(t, repo.MarkSynced(ctx, "proposal_threshold", 31337, "0xsync"))
+	c, err := repo.GetConfig(ctx, "proposal_threshold", 31337)
+	require.NoError(t, err)
+	assert.True(t, c.ContractSynced)
+	assert.Equal(t, "100000000000000000000", c.ValueWei.String(), "wei survives without float rounding")
+
+	threshold, _ := new(big.Int).SetString("250000000000000000000", 10)
+	require.NoError(t, repo.UpdateConfig(ctx, "proposal_threshold", 31337, &repository.GovernanceConfigUpdate{ValueWei: threshold, UpdatedBy: "dao"}))
+
+	c, err = repo.GetConfig(ctx, "proposal_threshold", 31337)
+	require.NoError(t, err)
+	assert.Equal(t, threshold.String(), c.ValueWei.String())
+	assert.False(t, c.ContractSynced)
+
+	history, err := repo.GetConfigHistory(ctx, "proposal_threshold", 31337, 10)
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	assert.True(t, *history[0].WasSynced)
+	assert.Equal(t, "dao", history[0].ChangedBy)
+}
+
+func TestAppConfigRepo_FallbackAndHistory(t *testing.T) {
+	ctx := context.Background()
+	repo := sqlite.NewSQLiteAppConfigRepo(openTestDB(t))
+
+	depth, err := repo.GetNumber(ctx, "chain", "confirmation_depth", 31337)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), depth)
+
+	depth, err = repo.GetNumber(ctx, "chain", "confirmation_depth", 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(12), depth)
+
+	updated := int64(6)
+	require.NoError(t, repo.Update(ctx, "chain", "confirmation_depth", 0, &repository.AppConfigUpdate{ValueNumber: &updated, UpdatedBy: "admin"}))
+
+	history, err := repo.GetHistory(ctx, "chain", "confirmation_depth", 0, 10)
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	assert.Equal(t, int64(12), *history[0].OldValueNumber)
+}
+
+func TestChainRepo_TrackAndRollback(t *testing.T) {
+	ctx := context.Background()
+	repo := sqlite.NewSQLiteChainRepo(openTestDB(t))
+
+	tx := &repository.TrackedTx{ChainID: 31337, TxHash: "0xtx", RecordType: repository.TrackedRecordPayment, RecordID: "p1"}
+	require.NoError(t, repo.TrackTx(ctx, tx))
+	assert.Equal(t, repository.TrackedTxStatusPending, tx.Status)
+
+	block, hash, confs := uint64(10), "0xblock", 3
+	require.NoError(t, repo.UpdateTrackedTx(ctx, tx.ID, &repository.TrackedTxUpdate{
+		Status:        repository.TrackedTxStatusIncluded,
+		BlockNumber:   &block,
+		BlockHash:     &hash,
+		Confirmations: &confs,
+	}))
+
+	rolledBack, err := repo.RollbackTxsAbove(ctx, 31337, 9)
+	require.NoError(t, err)
+	require.Len(t, rolledBack, 1)
+	assert.Nil(t, rolledBack[0].BlockNumber)
+	assert.Equal(t, 1, rolledBack[0].ReorgCount)
+
+	for n := uint64(1); n <= 5; n++ {
+		require.NoError(t, repo.SaveBlock(ctx, &repository.BlockRecord{ChainID: 31337, Number: n, Hash: "0xh", ParentHash: "0xp"}))
+	}
+	deleted, err := repo.DeleteBlocksAbove(ctx, 31337, 3)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), deleted)
+
+	latest, err := repo.GetLatestBlock(ctx, 31337)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), latest.Number)
+}
+
+func TestRequestLogRepo_FilterAndPrune(t *testing.T) {
+	ctx := context.Background()
+	repo := sqlite.NewSQLiteRequestLogRepo(openTestDB(t))
+
+	old := time.Now().Add(-48 * time.Hour)
+	require.NoError(t, repo.InsertRequestLogs(ctx, []*repository.RequestLogEntry{
+		{RequestID: "r1", Method: "GET", Path: "/api/v1/pricing", StatusCode: 200, RequestHeaders: map[string]string{"Accept": "*/*"}},
+		{RequestID: "r2", Method: "POST", Path: "/api/v1/payments_x", StatusCode: 500},
+		{RequestID: "r3", Method: "GET", Path: "/api/v1/pricing", StatusCode: 200, CreatedAt: old},
+	}))
+
+	// '_' in the filter must match literally
+	logs, total, err := repo.ListRequestLogs(ctx, repository.RequestLogFilter{Path: "/api/v1/payments_"}, repository.Pagination{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	require.Len(t, logs, 1)
+
+	_, total, err = repo.ListRequestLogs(ctx, repository.RequestLogFilter{Path: "/api/v1/p"}, repository.Pagination{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), total)
+
+	deleted, err := repo.DeleteRequestLogsBefore(ctx, time.Now().Add(-24*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+
+	got, err := repo.GetRequestLog(ctx, logs[0].ID)
+	require.NoError(t, err)
+	assert.Equal(t, "r2", got.RequestID)
+}
+
+func TestGovernanceNotificationRepo_SubscriptionsAndEvents(t *testing.T) {
+	ctx := context.Background()
+	repo := sqlite.NewSQLiteGovernanceNotificationRepo(openTestDB(t))
+
+	addr := "0x00000000000000000000000000000000000000aa"
+	s := &repository.GovernanceSubscription{Address: addr, WebhookURL: "https://example.com/hook", Secret: "0123456789abcdef",
+		Events: []string{"proposal_opened", "near_quorum"}, Digest: repository.GovernanceDigestDaily}
+	require.NoError(t, repo.UpsertGovernanceSubscription(ctx, s))
+	assert.False(t, s.CreatedAt.IsZero())
+
+	sent := time.Now().Add(-time.Hour)
+	require.NoError(t, repo.MarkGovernanceDigestSent(ctx, addr, sent))
+	s.Events = []string{"proposal_queued"}
+	require.NoError(t, repo.UpsertGovernanceSubscription(ctx, s))
+	require.NotNil(t, s.LastDigestAt, "replacing a subscription keeps its digest schedule")
+	assert.WithinDuration(t, sent, *s.LastDigestAt, time.Millisecond)
+
+	got, err := repo.GetGovernanceSubscription(ctx, addr)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"proposal_queued"}, got.Events)
+	assert.Equal(t, "0123456789abcdef", got.Secret)
+
+	_, total, err := repo.ListGovernanceSubscriptions(ctx, repository.GovernanceSubscriptionFilter{Event: repository.GovernanceEventProposalQueued}, repository.Pagination{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	_, total, err = repo.ListGovernanceSubscriptions(ctx, repository.GovernanceSubscriptionFilter{Event: repository.GovernanceEventNearQuorum}, repository.Pagination{})
+	require.NoError(t, err)
+	assert.Zero(t, total)
+
+	before := time.Now().Add(-time.Minute)
+	e := &repository.GovernanceEvent{ProposalID: "p1", EventType: repository.GovernanceEventProposalOpened, Title: "Fund grants"}
+	recorded, err := repo.RecordGovernanceEvent(ctx, e)
+	require.NoError(t, err)
+	assert.True(t, recorded)
+	assert.NotEmpty(t, e.ID)
+	recorded, err = repo.RecordGovernanceEvent(ctx, &repository.GovernanceEvent{ProposalID: "p1", EventType: repository.GovernanceEventProposalOpened})
+	require.NoError(t, err)
+	assert.False(t, recorded, "an event is recorded once per proposal")
+
+	events, err := repo.ListGovernanceEvents(ctx, before, 10)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	events, err = repo.ListGovernanceEvents(ctx, events[0].OccurredAt, 10)
+	require.NoError(t, err)
+	assert.Empty(t, events, "events are listed strictly after since")
+
+	require.NoError(t, repo.DeleteGovernanceSubscription(ctx, addr))
+	assert.ErrorIs(t, repo.DeleteGovernanceSubscription(ctx, addr), repository.ErrGovernanceSubscriptionNotFound)
+}
+
+func TestWatchlistRepo_CRUDAndMatching(t *testing.T) {
+	ctx := context.Background()
+	repo := sqlite.NewSQLiteWatchlistRepo(openTestDB(t))
+
+	watched := "0x00000000000000000000000000000000000000aa"
+	w := &repository.Watchlist{Name: "sanctions", WebhookURL: "https://example.com/hook", Secret: "0123456789abcdef",
+		Addresses: []string{watched}, Events: []string{"payment", "meta_tx"}}
+	require.NoError(t, repo.CreateWatchlist(ctx, w))
+	require.NotEmpty(t, w.ID)
+	assert.False(t, w.CreatedAt.IsZero())
+
+	_, total, err := repo.ListWatchlists(ctx, repository.WatchlistFilter{Address: watched, Event: repository.ActivityMetaTx}, repository.Pagination{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	_, total, err = repo.ListWatchlists(ctx, repository.WatchlistFilter{Address: watched, Event: repository.ActivityNFTTransfer}, repository.Pagination{})
+	require.NoError(t, err)
+	assert.Zero(t, total)
+
+	w.Addresses = []string{"0x00000000000000000000000000000000000000bb"}
+	require.NoError(t, repo.UpdateWatchlist(ctx, w))
+	got, err := repo.GetWatchlist(ctx, w.ID)
+	require.NoError(t, err)
+	assert.Equal(t, w.Addresses, got.Addresses)
+	assert.Equal(t, []string{"payment", "meta_tx"}, got.Events)
+	assert.Equal(t, "0123456789abcdef", got.Secret)
+	_, total, err = repo.ListWatchlists(ctx, repository.WatchlistFilter{Address: watched}, repository.Pagination{})
+	require.NoError(t, err)
+	assert.Zero(t, total)
+
+	require.NoError(t, repo.DeleteWatchlist(ctx, w.ID))
+	_, err = repo.GetWatchlist(ctx, w.ID)
+	assert.ErrorIs(t, err, repository.ErrWatchlistNotFound)
+	assert.ErrorIs(t, repo.UpdateWatchlist(ctx, w), repository.ErrWatchlistNotFound)
+}
+
+func TestFulfillmentRepo_OncePerPaymentAndActive(t *testing.T) {
+	ctx := context.Background()
+	repo := sqlite.NewSQLiteFulfillmentRepo(openTestDB(t))
+
+	payer := "0x00000000000000000000000000000000000000aa"
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	until := from.AddDate(0, 1, 0)
+	sub := &repository.Fulfillment{PaymentID: "11111111-1111-4111-8111-111111111111", ServiceCode: "premium_monthly", Address: payer,
+		Kind: repository.FulfillmentAPISubscription, Quantity: 1, ValidFrom: &from, ValidUntil: &until}
+	created, err := repo.RecordFulfillment(ctx, sub)
+	require.NoError(t, err)
+	assert.True(t, created)
+	require.NotEmpty(t, sub.ID)
+
+	created, err = repo.RecordFulfillment(ctx, &repository.Fulfillment{PaymentID: sub.PaymentID, ServiceCode: "premium_monthly", Address: payer,
+		Kind: repository.FulfillmentAPISubscription, Quantity: 1})
+	require.NoError(t, err)
+	assert.False(t, created)
+
+	_, err = repo.RecordFulfillment(ctx, &repository.Fulfillment{PaymentID: "22222222-2222-4222-8222-222222222222", ServiceCode: "nft_mint", Address: payer,
+		Kind: repository.FulfillmentNFTMintCredit, Quantity: 1})
+	require.NoError(t, err)
+
+	got, err := repo.GetFulfillmentByPayment(ctx, sub.PaymentID)
+	require.NoError(t, err)
+	assert.True(t, got.ValidUntil.Equal(until))
+	_, err = repo.GetFulfillmentByPayment(ctx, "33333333-3333-4333-8333-333333333333")
+	assert.ErrorIs(t, err, repository.ErrFulfillmentNotFound)
+
+	// Credits have no period, so they stay active
+	during := from.AddDate(0, 0, 10)
+	_, total, err := repo.ListFulfillments(ctx, repository.FulfillmentFilter{Address: payer, ActiveAt: &during}, repository.Pagination{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	after := until.Add(time.Second)
+	list, total, err := repo.ListFulfillments(ctx, repository.FulfillmentFilter{Address: payer, ActiveAt: &after}, repository.Pagination{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Equal(t, repository.FulfillmentNFTMintCredit, list[0].Kind)
+}
+
+func TestEntitlementRepo_GrantOnceAndConsume(t *testing.T) {
+	ctx := context.Background()
+	db := openTestDB(t)
+	repo := sqlite.NewSQLiteEntitlementRepo(db)
+
+	payer := "0x00000000000000000000000000000000000000aa"
+	pack := &repository.EntitlementGrant{PaymentID: "11111111-1111-4111-8111-111111111111", Address: payer, ServiceCode: "kyc_verification", Quantity: 10}
+	created, err := repo.GrantCredits(ctx, pack)
+	require.NoError(t, err)
+	assert.True(t, created)
+	require.NotEmpty(t, pack.ID)
+	created, err = repo.GrantCredits(ctx, &repository.EntitlementGrant{PaymentID: pack.PaymentID, Address: payer, ServiceCode: "kyc_verification", Quantity: 10})
+	require.NoError(t, err)
+	assert.False(t, created, "a payment grants once")
+	_, err = repo.GrantCredits(ctx, &repository.EntitlementGrant{PaymentID: "22222222-2222-4222-8222-222222222222", Address: payer, ServiceCode: "kyc_verification", Quantity: 2})
+	require.NoError(t, err)
+
+	e, err := repo.ConsumeCredits(ctx, payer, "kyc_verification", 3)
+	require.NoError(t, err)
+	assert.Equal(t, 12, e.Granted)
+	assert.Equal(t, 9, e.Balance)
+	_, err = repo.ConsumeCredits(ctx, payer, "kyc_verification", 10)
+	assert.ErrorIs(t, err, repository.ErrInsufficientCredits)
+	_, err = repo.ConsumeCredits(ctx, payer, "nft_mint", 1)
+	assert.ErrorIs(t, err, repository.ErrInsufficientCredits)
+
+	e, err = repo.GetEntitlement(ctx, payer, "kyc_verification")
+	require.NoError(t, err)
+	assert.Equal(t, 9, e.Balance, "a short consumption takes nothing")
+	assert.Equal(t, 3, e.Consumed())
+	_, err = repo.GetEntitlement(ctx, payer, "nft_mint")
+	assert.ErrorIs(t, err, repository.ErrEntitlementNotFound)
+	list, err := repo.ListEntitlements(ctx, payer)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+
+	// The rebuilt service_fulfillments accepts the credits kind
+	created, err = sqlite.NewSQLiteFulfillmentRepo(db).RecordFulfillment(ctx, &repository.Fulfillment{PaymentID: pack.PaymentID,
+		ServiceCode: "kyc_verification_10", Address: payer, Kind: repository.FulfillmentCredits, Quantity: 10})
+	require.NoError(t, err)
+	assert.True(t, created)
+}
+
+func TestPartnerRepo_CommissionsAndTotals(t *testing.T) {
+	ctx := context.Background()
+	db := openTestDB(t)
+	repo := sqlite.NewSQLitePartnerRepo(db)
+
+	partner := &repository.Partner{Name: "Acme", ReferralCode: "acme", SharePercent: 12.5, APIKeyHash: "hash-1", IsActive: true}
+	require.NoError(t, repo.CreatePartner(ctx, partner))
+	require.NotEmpty(t, partner.ID)
+	assert.ErrorIs(t, repo.CreatePartner(ctx, &repository.Partner{Name: "Other", ReferralCode: "acme", SharePercent: 5, APIKeyHash: "hash-2"}),
+		repository.ErrPartnerExists)
+	got, err := repo.GetPartnerByKeyHash(ctx, "hash-1")
+	require.NoError(t, err)
+	assert.Equal(t, "acme", got.ReferralCode)
+	share := 20.0
+	require.NoError(t, repo.UpdatePartner(ctx, partner.ID, &repository.PartnerUpdate{SharePercent: &share}))
+	got, err = repo.GetPartnerByCode(ctx, "acme")
+	require.NoError(t, err)
+	assert.Equal(t, 20.0, got.SharePercent)
+	_, err = repo.GetPartnerByCode(ctx, "nobody")
+	assert.ErrorIs(t, err, repository.ErrPartnerNotFound)
+
+	// Payments keep the referrer code given at checkout
+	code := "acme"
+	payment := &repository.Payment{ServiceCode: "kyc_verification", PayerAddress: "0x00000000000000000000000000000000000000aa",
+		PaymentMethod: "stripe", AmountCharged: 15, Currency: "USD", Status: repository.PaymentStatusCompleted, ReferrerCode: &code}
+	payments := sqlite.NewSQLitePaymentRepo(db)
+	require.NoError(t, payments.CreatePayment(ctx, payment))
+	stored, err := payments.GetPayment(ctx, payment.ID)
+	require.NoError(t, err)
+	require.NotNil(t, stored.ReferrerCode)
+	assert.Equal(t, "acme", *stored.ReferrerCode)
+
+	for _, c := range []*repository.PartnerCommission{
+		{PartnerID: partner.ID, PaymentID: payment.ID, ServiceCode: "kyc_verification", AmountUSD: 15, SharePercent: 20, CommissionUSD: 3},
+		{PartnerID: partner.ID, PaymentID: "22222222-2222-4222-8222-222222222222", ServiceCode: "nft_mint", AmountUSD: 10, SharePercent: 20, CommissionUSD: 2},
+	} {
+		created, err := repo.RecordCommission(ctx, c)
+		require.NoError(t, err)
+		assert.True(t, created)
+	}
+	created, err := repo.RecordCommission(ctx, &repository.PartnerCommission{PartnerID: partner.ID, PaymentID: payment.ID,
+		ServiceCode: "kyc_verification", AmountUSD: 15, SharePercent: 20, CommissionUSD: 3})
+	require.NoError(t, err)
+	assert.False(t, created, "a payment earns once")
+
+	reversed, err := repo.ReverseCommission(ctx, payment.ID)
+	require.NoError(t, err)
+	assert.True(t, reversed)
+	reversed, err = repo.ReverseCommission(ctx, payment.ID)
+	require.NoError(t, err)
+	assert.False(t, reversed)
+
+	filter := repository.PartnerCommissionFilter{PartnerID: partner.ID}
+	totals, err := repo.GetCommissionTotals(ctx, filter)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, totals.Payments)
+	assert.Equal(t, 25.0, totals.AmountUSD)
+	assert.Equal(t, 5.0, totals.EarnedUSD)
+	assert.Equal(t, 3.0, totals.ReversedUSD)
+	assert.Equal(t, 2.0, totals.NetUSD)
+
+	list, total, err := repo.ListCommissions(ctx, filter, repository.Pagination{Page: 1, PageSize: 20})
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, total)
+	require.Len(t, list, 2)
+	past := time.Now().Add(-time.Hour)
+	filter.To = &past
+	_, total, err = repo.ListCommissions(ctx, filter, repository.Pagination{Page: 1, PageSize: 20})
+	require.NoError(t, err)
+	assert.Zero(t, total)
+}
+
+func TestSafeTransactionRepo_ConfirmAndExecute(t *testing.T) {
+	ctx := context.Background()
+	repo := sqlite.NewSQLiteSafeTransactionRepo(openTestDB(t))
+
+	tx := &repository.SafeTransaction{ChainID: 1, SafeAddress: "0x00000000000000000000000000000000000005af", To: "0x00000000000000000000000000000000000000aa",
+		Value: "1000000000000000000000", Data: "0x", SafeTxGas: "0", BaseGas: "0", GasPrice: "0",
+		GasToken: "0x0000000000000000000000000000000000000000", RefundReceiver: "0x0000000000000000000000000000000000000000",
+		Nonce: 4, SafeTxHash: "0xa1", Threshold: 2, Status: repository.SafeTxAwaitingConfirmations, ProposedBy: "ops"}
+	require.NoError(t, repo.CreateSafeTransaction(ctx, tx))
+	assert.NotEmpty(t, tx.ID)
+	dup := *tx
+	assert.ErrorIs(t, repo.CreateSafeTransaction(ctx, &dup), repository.ErrSafeTransactionExists)
+
+	require.NoError(t, repo.AddSafeConfirmation(ctx, &repository.SafeConfirmation{SafeTxID: tx.ID, Owner: "0x00000000000000000000000000000000000000b2", Signature: "0x02"}))
+	assert.ErrorIs(t, repo.AddSafeConfirmation(ctx, &repository.SafeConfirmation{SafeTxID: tx.ID, Owner: "0x00000000000000000000000000000000000000b2", Signature: "0x02"}),
+		repository.ErrSafeConfirmationExists)
+	got, err := repo.GetSafeTransaction(ctx, tx.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, got.Confirmations)
+	assert.Equal(t, repository.SafeTxAwaitingConfirmations, got.Status)
+
+	require.NoError(t, repo.AddSafeConfirmation(ctx, &repository.SafeConfirmation{SafeTxID: tx.ID, Owner: "0x00000000000000000000000000000000000000b1", Signature: "0x01"}))
+	got, err = repo.GetSafeTransaction(ctx, tx.ID)
+	require.NoError(t, err)
+	assert.Equal(t, repository.SafeTxReady, got.Status, "ready once the threshold is met")
+	assert.Equal(t, "1000000000000000000000", got.Value)
+
+	confirmations, err := repo.ListSafeConfirmations(ctx, tx.ID)
+	require.NoError(t, err)
+	require.Len(t, confirmations, 2)
+	assert.Equal(t, "0x00000000000000000000000000000000000000b1", confirmations[0].Owner, "by owner ascending")
+
+	assert.ErrorIs(t, repo.UpdateSafeTransactionStatus(ctx, tx.ID, &repository.SafeTransactionUpdate{Status: repository.SafeTxExecuted}),
+		repository.ErrSafeTransactionClosed)
+	require.NoError(t, repo.UpdateSafeTransactionStatus(ctx, tx.ID, &repository.SafeTransactionUpdate{Status: repository.SafeTxSending}))
+	assert.ErrorIs(t, repo.AddSafeConfirmation(ctx, &repository.SafeConfirmation{SafeTxID: tx.ID, Owner: "0x00000000000000000000000000000000000000b3", Signature: "0x03"}),
+		repository.ErrSafeTransactionClosed)
+	txHash := "0xfeed"
+	require.NoError(t, repo.UpdateSafeTransactionStatus(ctx, tx.ID, &repository.SafeTransactionUpdate{Status: repository.SafeTxSubmitted, TxHash: &txHash}))
+	block := uint64(42)
+	require.NoError(t, repo.UpdateSafeTransactionStatus(ctx, tx.ID, &repository.SafeTransactionUpdate{Status: repository.SafeTxExecuted, BlockNumber: &block}))
+
+	got, err = repo.GetSafeTransaction(ctx, tx.ID)
+	require.NoError(t, err)
+	assert.Equal(t, repository.SafeTxExecuted, got.Status)
+	require.NotNil(t, got.TxHash)
+	assert.Equal(t, txHash, *got.TxHash)
+	assert.NotNil(t, got.SubmittedAt)
+	assert.NotNil(t, got.ExecutedAt)
+
+	list, total, err := repo.ListSafeTransactions(ctx, repository.SafeTransactionFilter{Status: repository.SafeTxExecuted}, repository.Pagination{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	require.Len(t, list, 1)
+	_, err = repo.GetSafeTransaction(ctx, "00000000-0000-0000-0000-000000000000")
+	assert.ErrorIs(t, err, repository.ErrSafeTransactionNotFound)
+}
+
+func TestBridgeRepo_IntentMatchAndLegs(t *testing.T) {
+	ctx := context.Background()
+	repo := sqlite.NewSQLiteBridgeRepo(openTestDB(t))
+
+	intent := &repository.BridgeTransfer{Sender: "0x00000000000000000000000000000000000000a1", Recipient: "0x00000000000000000000000000000000000000a2",
+		Amount: "200000000000000000000000", SourceChainID: 1, DestChainID: 137,
+		SourceStatus: repository.BridgeLegPending, DestStatus: repository.BridgeLegPending}
+	require.NoError(t, repo.CreateBridgeTransfer(ctx, intent))
+	assert.NotEmpty(t, intent.ID)
+	assert.Nil(t, intent.SourceConfirmedAt)
+
+	match := repository.BridgeIntentMatch{Sender: intent.Sender, Recipient: intent.Recipient, Amount: intent.Amount,
+		SourceChainID: 1, DestChainID: 137, TxHash: "0xlock"}
+	found, err := repo.MatchBridgeIntent(ctx, match)
+	require.NoError(t, err)
+	assert.Equal(t, intent.ID, found.ID)
+	other := match
+	other.Amount = "1"
+	_, err = repo.MatchBridgeIntent(ctx, other)
+	assert.ErrorIs(t, err, repository.ErrBridgeTransferNotFound)
+
+	nonce, block := uint64(9), uint64(500)
+	confirmed, queued := repository.BridgeLegConfirmed, repository.BridgeLegQueued
+	direction := repository.BridgeLockMint
+	destID, txHash := "0xdest", "0xlock"
+	require.NoError(t, repo.UpdateBridgeTransfer(ctx, intent.ID, &repository.BridgeTransferUpdate{
+		Direction: &direction, Nonce: &nonce, SourceStatus: &confirmed, SourceTxHash: &txHash, SourceBlock: &block, DestTransferID: &destID}))
+	_, err = repo.MatchBridgeIntent(ctx, match)
+	assert.ErrorIs(t, err, repository.ErrBridgeTransferNotFound, "matched intents are not matched again")
+
+	dup := &repository.BridgeTransfer{Recipient: intent.Recipient, Amount: "1", SourceChainID: 1, DestChainID: 137, Nonce: &nonce,
+		SourceStatus: repository.BridgeLegPending, DestStatus: repository.BridgeLegCompleted}
+	assert.ErrorIs(t, repo.CreateBridgeTransfer(ctx, dup), repository.ErrBridgeTransferExists)
+
+	unlockAt := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
+	require.NoError(t, repo.UpdateBridgeTransfer(ctx, intent.ID, &repository.BridgeTransferUpdate{DestStatus: &queued, DestUnlockAt: &unlockAt}))
+
+	got, err := repo.GetBridgeTransferByNonce(ctx, 1, 9)
+	require.NoError(t, err)
+	assert.Equal(t, repository.BridgeLockMint, got.Direction)
+	assert.Equal(t, "200000000000000000000000", got.Amount)
+	assert.Equal(t, repository.BridgeLegConfirmed, got.SourceStatus)
+	assert.NotNil(t, got.SourceConfirmedAt)
+	assert.Equal(t, repository.BridgeLegQueued, got.DestStatus)
+	require.NotNil(t, got.DestUnlockAt)
+	assert.True(t, unlockAt.Equal(*got.DestUnlockAt))
+	assert.Nil(t, got.DestCompletedAt)
+
+	byDest, err := repo.GetBridgeTransferByDestID(ctx, "0xdest")
+	require.NoError(t, err)
+	assert.Equal(t, intent.ID, byDest.ID)
+
+	list, total, err := repo.ListBridgeTransfers(ctx, intent.Recipient, repository.Pagination{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	require.Len(t, list, 1)
+
+	_, err = repo.GetBridgeCursor(ctx, 1)
+	assert.ErrorIs(t, err, repository.ErrBridgeCursorNotFound)
+	require.NoError(t, repo.SetBridgeCursor(ctx, 1, 100))
+	require.NoError(t, repo.SetBridgeCursor(ctx, 1, 200))
+	next, err := repo.GetBridgeCursor(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(200), next)
+}
+
+func TestProfileRepo_UpsertAndBatch(t *testing.T) {
+	ctx := context.Background()
+	repo := sqlite.NewSQLiteProfileRepo(openTestDB(t))
+
+	name, email := "Alice", "alice@example.com"
+	chainID, contract, tokenID := int64(1), "0x00000000000000000000000000000000000000c1", "42"
+	alice := &repository.Profile{Address: "0x00000000000000000000000000000000000000a1", DisplayName: &name, Email: &email,
+		AvatarChainID: &chainID, AvatarContract: &contract, AvatarTokenID: &tokenID, PreferredCurrency: "ETH"}
+	require.NoError(t, repo.UpsertProfile(ctx, alice))
+	created := alice.CreatedAt
+
+	got, err := repo.GetProfile(ctx, alice.Address)
+	require.NoError(t, err)
+	assert.Equal(t, "Alice", *got.DisplayName)
+	assert.Equal(t, "alice@example.com", *got.Email)
+	assert.Equal(t, "42", *got.AvatarTokenID)
+	assert.Equal(t, "ETH", got.PreferredCurrency)
+
+	// Replacing clears omitted fields and keeps the creation time
+	require.NoError(t, repo.UpsertProfile(ctx, &repository.Profile{Address: alice.Address, PreferredCurrency: "USD"}))
+	got, err = repo.GetProfile(ctx, alice.Address)
+	require.NoError(t, err)
+	assert.Nil(t, got.DisplayName)
+	assert.Nil(t, got.Email)
+	assert.True(t, created.Equal(got.CreatedAt))
+
+	bob := &repository.Profile{Address: "0x00000000000000000000000000000000000000b2", PreferredCurrency: "NEXUS"}
+	require.NoError(t, repo.UpsertProfile(ctx, bob))
+	profiles, err := repo.GetProfiles(ctx, []string{alice.Address, bob.Address, "0x00000000000000000000000000000000000000c3"})
+	require.NoError(t, err)
+	assert.Len(t, profiles, 2)
+	assert.Equal(t, "NEXUS", profiles[bob.Address].PreferredCurrency)
+
+	require.NoError(t, repo.DeleteProfile(ctx, bob.Address))
+	_, err = repo.GetProfile(ctx, bob.Address)
+	assert.ErrorIs(t, err, repository.ErrProfileNotFound)
+	assert.ErrorIs(t, repo.DeleteProfile(ctx, bob.Address), repository.ErrProfileNotFound)
+}
+
+func TestActivityRepo_MergedTimeline(t *testing.T) {
+	ctx := context.Background()
+	repo := sqlite.NewSQLiteActivityRepo(openTestDB(t))
+
+	const alice = "0x00000000000000000000000000000000000000a1"
+	base := time.Now().Add(-time.Hour).UTC()
+	for i, e := range []*repository.ActivityEvent{
+		{Address: alice, Kind: repository.ActivityPayment, Action: "completed", SubjectID: "p1", OccurredAt: base},
+		{Address: alice, Kind: repository.ActivityVote, Action: "for", SubjectID: "prop-1", OccurredAt: base.Add(2 * time.Minute)},
+		{Address: alice, Kind: repository.ActivityKYC, Action: "approved", SubjectID: "k1", OccurredAt: base.Add(time.Minute)},
+		{Address: "0x00000000000000000000000000000000000000b2", Kind: repository.ActivityPayment, Action: "pending", SubjectID: "p2"},
+	} {
+		require.NoError(t, repo.RecordActivity(ctx, e), i)
+		assert.NotEmpty(t, e.ID)
+	}
+
+	// Events recorded without a time are stamped now
+	hash := "0xabc"
+	now := &repository.ActivityEvent{Address: alice, Kind: repository.ActivityMetaTx, Action: "confirmed", SubjectID: "m1", TxHash: &hash}
+	require.NoError(t, repo.RecordActivity(ctx, now))
+	assert.False(t, now.OccurredAt.IsZero())
+
+	events, total, err := repo.ListActivity(ctx, repository.ActivityFilter{Address: alice}, repository.Pagination{Page: 1, PageSize: 3})
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), total)
+	require.Len(t, events, 3)
+	assert.Equal(t, []string{"m1", "prop-1", "k1"}, []string{events[0].SubjectID, events[1].SubjectID, events[2].SubjectID})
+	assert.Equal(t, "0xabc", *events[0].TxHash)
+
+	events, total, err = repo.ListActivity(ctx, repository.ActivityFilter{Address: alice, Kinds: []repository.ActivityKind{repository.ActivityPayment, repository.ActivityKYC}}, repository.Pagination{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	require.Len(t, events, 2)
+	assert.Equal(t, "k1", events[0].SubjectID)
+	assert.Equal(t, "p1", events[1].SubjectID)
+}
+
+func TestReportRepo_ClaimOnce(t *testing.T) {
+	ctx := context.Background()
+	repo := sqlite.NewSQLiteReportRepo(openTestDB(t))
+
+	due := time.Date(2026, 3, 2, 8, 0, 0, 0, time.UTC)
+	s := &repository.ReportSchedule{
+		Name: "Weekly payments", Kind: repository.ReportPayments, Cron: "0 8 * * 1", Timezone: "Europe/Berlin",
+		Recipients: []string{"finance@example.com"}, Enabled: true, CreatedBy: "ops", NextRunAt: due,
+	}
+	require.NoError(t, repo.CreateReportSchedule(ctx, s))
+	require.NotEmpty(t, s.ID)
+
+	list, err := repo.ListDueReportSchedules(ctx, due.Add(-time.Second), 10)
+	require.NoError(t, err)
+	assert.Empty(t, list)
+	list, err = repo.ListDueReportSchedules(ctx, due, 10)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, []string{"finance@example.com"}, list[0].Recipients)
+	assert.Equal(t, "Europe/Berlin", list[0].Timezone)
+
+	// Only one instance claims a run
+	next := due.Add(7 * 24 * time.Hour)
+	claimed, err := repo.ClaimReportSchedule(ctx, s.ID, list[0].NextRunAt, next)
+	require.NoError(t, err)
+	assert.True(t, claimed)
+	claimed, err = repo.ClaimReportSchedule(ctx, s.ID, list[0].NextRunAt, next)
+	require.NoError(t, err)
+	assert.False(t, claimed)
+
+	got, err := repo.GetReportSchedule(ctx, s.ID)
+	require.NoError(t, err)
+	assert.True(t, got.NextRunAt.Equal(next))
+	require.NotNil(t, got.LastRunAt)
+	assert.True(t, got.LastRunAt.Equal(due))
+
+	got.Enabled = false
+	require.NoError(t, repo.UpdateReportSchedule(ctx, got))
+	assert.Equal(t, "ops", got.CreatedBy)
+	list, err = repo.ListDueReportSchedules(ctx, next, 10)
+	require.NoError(t, err)
+	assert.Empty(t, list, "disabled schedules are not due")
+
+	run := &repository.ReportRun{
+		ScheduleID: s.ID, Kind: s.Kind, PeriodStart: due.Add(-7 * 24 * time.Hour), PeriodEnd: due,
+		Status: repository.ReportRunSucceeded, ObjectKey: "exports/reports/x.csv", Rows: 3, StartedAt: due, FinishedAt: due.Add(time.Second),
+	}
+	require.NoError(t, repo.CreateReportRun(ctx, run))
+	require.NoError(t, repo.DeleteReportSchedule(ctx, s.ID))
+	assert.ErrorIs(t, repo.DeleteReportSchedule(ctx, s.ID), repository.ErrReportScheduleNotFound)
+
+	runs, total, err := repo.ListReportRuns(ctx, s.ID, repository.Pagination{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total, "runs outlive their schedule")
+	require.Len(t, runs, 1)
+	assert.Equal(t, int64(3), runs[0].Rows)
+	assert.True(t, runs[0].PeriodEnd.Equal(due))
+}
+
+func TestAnomalyRepo_CountsAndCases(t *testing.T) {
+	ctx := context.Background()
+	db := openTestDB(t)
+	payments := sqlite.NewSQLitePaymentRepo(db)
+	relayer := sqlite.NewSQLiteRelayerRepo(db)
+	repo := sqlite.NewSQLiteAnomalyRepo(db)
+
+	for _, payer := range []string{"0xabc", "0xabc", "0xabc", "0xdef"} {
+		require.NoError(t, payments.CreatePayment(ctx, &repository.Payment{ServiceCode: "kyc_verification", PayerAddress: payer,
+			PaymentMethod: "stripe", AmountCharged: 15, Currency: "USD", Status: repository.PaymentStatusPending}))
+	}
+	for i, status := range []repository.MetaTxStatus{repository.MetaTxStatusFailed, repository.MetaTxStatusFailed, repository.MetaTxStatusConfirmed} {
+		require.NoError(t, relayer.CreateMetaTx(ctx, &repository.MetaTransaction{FromAddress: "0xbad", ToAddress: "0xdef", FunctionName: "transfer",
+			Calldata: "0x", Value: "0", GasLimit: 100000, Nonce: uint64(i + 1), Deadline: time.Now().Add(time.Hour), Signature: "0xsig", Status: status}))
+	}
+
+	since := time.Now().Add(-time.Hour)
+	payers, err := repo.CountPaymentsByPayer(ctx, since, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []repository.SubjectCount{{Subject: "0xabc", Count: 3}}, payers)
+	payers, err = repo.CountPaymentsByPayer(ctx, time.Now().Add(time.Minute), 1)
+	require.NoError(t, err)
+	assert.Empty(t, payers)
+
+	senders, err := repo.CountFailedRelaysBySender(ctx, since, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []repository.SubjectCount{{Subject: "0xbad", Count: 2}}, senders)
+
+	hours, err := repo.HourlyActivity(ctx, since)
+	require.NoError(t, err)
+	var totalPayments, totalRelays int64
+	for _, h := range hours {
+		assert.Equal(t, h.Hour, h.Hour.Truncate(time.Hour))
+		totalPayments += h.Payments
+		totalRelays += h.Relays
+	}
+	assert.Equal(t, int64(4), totalPayments)
+	assert.Equal(t, int64(3), totalRelays)
+
+	// One open case per rule and subject
+	c := &repository.ComplianceCase{Rule: "rapid_payments", Subject: "0xabc", Summary: "3 payments in 10 minutes", Observed: 3, Threshold: 2}
+	opened, err := repo.OpenComplianceCase(ctx, c)
+	require.NoError(t, err)
+	assert.True(t, opened)
+	assert.Equal(t, repository.ComplianceCaseOpen, c.Status)
+	opened, err = repo.OpenComplianceCase(ctx, &repository.ComplianceCase{Rule: "rapid_payments", Subject: "0xabc", Summary: "again"})
+	require.NoError(t, err)
+	assert.False(t, opened)
+
+	closed, err := repo.CloseComplianceCase(ctx, c.ID, "compliance@nexus", "known market maker")
+	require.NoError(t, err)
+	assert.Equal(t, repository.ComplianceCaseClosed, closed.Status)
+	require.NotNil(t, closed.ClosedBy)
+	assert.Equal(t, "compliance@nexus", *closed.ClosedBy)
+	_, err = repo.CloseComplianceCase(ctx, c.ID, "compliance@nexus", "")
+	assert.ErrorIs(t, err, repository.ErrComplianceCaseClosed)
+	_, err = repo.CloseComplianceCase(ctx, "00000000-0000-4000-8000-000000000000", "compliance@nexus", "")
+	assert.ErrorIs(t, err, repository.ErrComplianceCaseNotFound)
+
+	opened, err = repo.OpenComplianceCase(ctx, &repository.ComplianceCase{Rule: "rapid_payments", Subject: "0xabc", Summary: "again"})
+	require.NoError(t, err)
+	assert.True(t, opened, "closing a case lets the rule flag the subject again")
+
+	list, total, err := repo.ListComplianceCases(ctx, repository.ComplianceCaseFilter{Status: repository.ComplianceCaseOpen}, repository.Pagination{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	require.Len(t, list, 1)
+	assert.Equal(t, "again", list[0].Summary)
+}
+
+func TestKYCAuditRepo_KeysetPages(t *testing.T) {
+	ctx := context.Background()
+	db := openTestDB(t)
+	repo := sqlite.NewSQLiteKYCAuditRepo(db)
+
+	// Two entries share a timestamp; the ID breaks the tie
+	base := time.Date(2026, 10, 1, 9, 0, 0, 123456789, time.UTC)
+	for i, at := range []time.Time{base, base.Add(time.Second), base.Add(time.Second), base.Add(2 * time.Second)} {
+		subject := "0xaaa"
+		if i%2 == 1 {
+			subject = "0xbbb"
+		}
+		require.NoError(t, repo.AppendAuditEntry(ctx, &repository.KYCAuditEntry{Timestamp: at, Action: "KYC_UPDATE", Actor: "0xofficer", Subject: subject}))
+	}
+	e := &repository.KYCAuditEntry{Action: "KYC_REGISTER", Actor: "0xccc", Subject: "0xccc", IPAddress: "203.0.113.9", Country: "GB"}
+	require.NoError(t, repo.AppendAuditEntry(ctx, e))
+	assert.NotEmpty(t, e.ID)
+
+	var seen []*repository.KYCAuditEntry
+	filter := repository.KYCAuditFilter{}
+	for {
+		page, err := repo.ListAuditEntries(ctx, filter, 2)
+		require.NoError(t, err)
+		if len(page) == 0 {
+			break
+		}
+		seen = append(seen, page...)
+		after := page[len(page)-1].Cursor()
+		filter.After = &after
+	}
+	require.Len(t, seen, 5)
+	assert.Equal(t, e.ID, seen[0].ID)
+	assert.Equal(t, "203.0.113.9", seen[0].IPAddress)
+	assert.Equal(t, "GB", seen[0].Country)
+	for i := 1; i < len(seen); i++ {
+		assert.True(t, seen[i-1].Cursor().Precedes(seen[i]), "entry %d out of order", i)
+	}
+	assert.True(t, seen[4].Timestamp.Equal(base), "timestamps keep their precision")
+
+	page, err := repo.ListAuditEntries(ctx, repository.KYCAuditFilter{Subject: "0xbbb"}, 10)
+	require.NoError(t, err)
+	require.Len(t, page, 2)
+	assert.True(t, page[0].Timestamp.After(page[1].Timestamp))
+	total, err := repo.CountAuditEntries(ctx, repository.KYCAuditFilter{Subject: "0xaaa"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+
+	var plan string
+	rows, err := db.QueryContext(ctx, `EXPLAIN QUERY PLAN SELECT id FROM audit_log WHERE subject = ?1 AND (timestamp, id) < (?2, ?3) ORDER BY timestamp DESC, id DESC LIMIT 10`,
+		"0xaaa", "2026-10-01 09:00:01+00:00", "x")
+	require.NoError(t, err)
+	defer rows.Close()
+	for rows.Next() {
+		var id, parent, notUsed int
+		var detail string
+		require.NoError(t, rows.Scan(&id, &parent, &notUsed, &detail))
+		plan += detail + "\n"
+	}
+	assert.Contains(t, plan, "idx_audit_subject")
+	assert.NotContains(t, plan, "TEMP B-TREE", "the index serves the order")
+}
@@ -0,0 +1,190 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure SQLiteWatchlistRepo implements WatchlistRepository
+var _ repository.WatchlistRepository = (*SQLiteWatchlistRepo)(nil)
+
+// SQLiteWatchlistRepo implements WatchlistRepository using SQLite
+type SQLiteWatchlistRepo struct {
+	db *sql.DB
+}
+
+// NewSQLiteWatchlistRepo creates a new SQLite watchlist repository
+func NewSQLiteWatchlistRepo(db *sql.DB) *SQLiteWatchlistRepo {
+	return &SQLiteWatchlistRepo{db: db}
+}
+
+// watchlistColumns is the column list read by every watchlist query
+const watchlistColumns = `id, name, webhook_url, secret, addresses, events, created_at, updated_at`
+
+// CreateWatchlist stores a watchlist
+func (r *SQLiteWatchlistRepo) CreateWatchlist(ctx context.Context, w *repository.Watchlist) error {
+	addresses, events, err := encodeWatchlistArrays(w)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO watchlists (id, name, webhook_url, secret, addresses, events)
+		VALUES (?1, ?2, ?3, ?4, ?5, ?6)
+		RETURNING id, created_at, updated_at
+	`
+
+	err = r.db.QueryRowContext(ctx, query, newID(), w.Name, w.WebhookURL, w.Secret, addresses, events).
+		Scan(&w.ID, &w.CreatedAt, &w.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("creating watchlist: %w", err)
+	}
+	return nil
+}
+
+// GetWatchlist retrieves a watchlist by ID
+func (r *SQLiteWatchlistRepo) GetWatchlist(ctx context.Context, id string) (*repository.Watchlist, error) {
+	query := `SELECT ` + watchlistColumns + ` FROM watchlists WHERE id = ?1`
+
+	w, err := scanWatchlist(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrWatchlistNotFound
+		}
+		return nil, fmt.Errorf("getting watchlist %s: %w", id, err)
+	}
+
+	return w, nil
+}
+
+// UpdateWatchlist replaces a watchlist's settings
+func (r *SQLiteWatchlistRepo) UpdateWatchlist(ctx context.Context, w *repository.Watchlist) error {
+	addresses, events, err := encodeWatchlistArrays(w)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE watchlists
+		SET name = ?2, webhook_url = ?3, secret = ?4, addresses = ?5, events = ?6, updated_at = ` + sqlNow + `
+		WHERE id = ?1
+		RETURNING created_at, updated_at
+	`
+
+	err = r.db.QueryRowContext(ctx, query, w.ID, w.Name, w.WebhookURL, w.Secret, addresses, events).
+		Scan(&w.CreatedAt, &w.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return repository.ErrWatchlistNotFound
+		}
+		return fmt.Errorf("updating watchlist %s: %w", w.ID, err)
+	}
+	return nil
+}
+
+// DeleteWatchlist removes a watchlist
+func (r *SQLiteWatchlistRepo) DeleteWatchlist(ctx context.Context, id string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM watchlists WHERE id = ?1`, id)
+	if err != nil {
+		return fmt.Errorf("deleting watchlist %s: %w", id, err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return repository.ErrWatchlistNotFound
+	}
+	return nil
+}
+
+// ListWatchlists lists watchlists with filtering, newest first
+func (r *SQLiteWatchlistRepo) ListWatchlists(ctx context.Context, filter repository.WatchlistFilter, page repository.Pagination) ([]*repository.Watchlist, int64, error) {
+	whereClause := "WHERE 1=1"
+	args := []interface{}{}
+	argNum := 1
+
+	if filter.Address != "" {
+		whereClause += fmt.Sprintf(" AND EXISTS (SELECT 1 FROM json_each(addresses) WHERE value = ?%d)", argNum)
+		args = append(args, filter.Address)
+		argNum++
+	}
+	if filter.Event != "" {
+		whereClause += fmt.Sprintf(" AND EXISTS (SELECT 1 FROM json_each(events) WHERE value = ?%d)", argNum)
+		args = append(args, filter.Event)
+		argNum++
+	}
+
+	var total int64
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM watchlists "+whereClause, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("counting watchlists: %w", err)
+	}
+
+	if page.PageSize <= 0 {
+		page.PageSize = 20
+	}
+	if page.Page <= 0 {
+		page.Page = 1
+	}
+	offset := (page.Page - 1) * page.PageSize
+
+	query := fmt.Sprintf(`
+		SELECT `+watchlistColumns+`
+		FROM watchlists
+		%s
+		ORDER BY created_at DESC
+		LIMIT ?%d OFFSET ?%d
+	`, whereClause, argNum, argNum+1)
+
+	args = append(args, page.PageSize, offset)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("listing watchlists: %w", err)
+	}
+	defer rows.Close()
+
+	var result []*repository.Watchlist
+	for rows.Next() {
+		w, err := scanWatchlist(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("scanning watchlist row: %w", err)
+		}
+		result = append(result, w)
+	}
+
+	return result, total, rows.Err()
+}
+
+// encodeWatchlistArrays encodes w's addresses and events as JSON arrays
+func encodeWatchlistArrays(w *repository.Watchlist) (string, string, error) {
+	addresses, err := json.Marshal(append([]string{}, w.Addresses...))
+	if err != nil {
+		return "", "", fmt.Errorf("encoding watchlist addresses: %w", err)
+	}
+	events, err := json.Marshal(append([]string{}, w.Events...))
+	if err != nil {
+		return "", "", fmt.Errorf("encoding watchlist events: %w", err)
+	}
+	return string(addresses), string(events), nil
+}
+
+// scanWatchlist scans a watchlists row
+func scanWatchlist(row rowScanner) (*repository.Watchlist, error) {
+	w := &repository.Watchlist{}
+	err := row.Scan(
+		&w.ID,
+		&w.Name,
+		&w.WebhookURL,
+		&w.Secret,
+		stringArray(&w.Addresses),
+		stringArray(&w.Events),
+		&w.CreatedAt,
+		&w.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return w, nil
+}
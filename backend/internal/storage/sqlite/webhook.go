@@ -0,0 +1,321 @@
+// Package sqlite implements repository interfaces using SQLite
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Ensure SQLiteWebhookEventRepo implements WebhookEventRepository
+var _ repository.WebhookEventRepository = (*SQLiteWebhookEventRepo)(nil)
+
+// SQLiteWebhookEventRepo implements WebhookEventRepository using SQLite
+type SQLiteWebhookEventRepo struct {
+	db *sql.DB
+}
+
+// NewSQLiteWebhookEventRepo creates a new SQLite webhook event repository
+func NewSQLiteWebhookEventRepo(db *sql.DB) *SQLiteWebhookEventRepo {
+	return &SQLiteWebhookEventRepo{db: db}
+}
+
+// webhookEventColumns is the column list read by every webhook event query
+const webhookEventColumns = `id, provider, event_id, event_type, payload, status,
+		       attempts, last_error, created_at, updated_at, processed_at, next_attempt_at`
+
+// CreateWebhookEvent stores a new event, or loads the stored copy if the
+// provider already delivered it
+func (r *SQLiteWebhookEventRepo) CreateWebhookEvent(ctx context.Context, e *repository.WebhookEvent) (bool, error) {
+	query := `
+		INSERT INTO webhook_events (provider, event_id, event_type, payload)
+		VALUES (?1, ?2, ?3, ?4)
+		ON CONFLICT (provider, event_id) DO NOTHING
+		RETURNING id, status, attempts, created_at, updated_at, next_attempt_at
+	`
+
+	err := r.db.QueryRowContext(ctx, query, e.Provider, e.EventID, e.EventType, string(e.Payload)).Scan(
+		&e.ID, &e.Status, &e.Attempts, &e.CreatedAt, &e.UpdatedAt, &e.NextAttemptAt,
+	)
+	if err == nil {
+		return true, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return false, fmt.Errorf("creating webhook event: %w", err)
+	}
+
+	existing, err := r.GetWebhookEventByEventID(ctx, e.Provider, e.EventID)
+	if err != nil {
+		return false, err
+	}
+	*e = *existing
+	return false, nil
+}
+
+// GetWebhookEvent retrieves a webhook event by ID
+func (r *SQLiteWebhookEventRepo) GetWebhookEvent(ctx context.Context, id string) (*repository.WebhookEvent, error) {
+	query := `SELECT ` + webhookEventColumns + ` FROM webhook_events WHERE id = ?1`
+
+	e, err := scanWebhookEvent(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrWebhookEventNotFound
+		}
+		return nil, fmt.Errorf("getting webhook event %s: %w", id, err)
+	}
+
+	return e, nil
+}
+
+// GetWebhookEventByEventID retrieves a webhook event by the provider's event ID
+func (r *SQLiteWebhookEventRepo) GetWebhookEventByEventID(ctx context.Context, provider, eventID string) (*repository.WebhookEvent, error) {
+	query := `SELECT ` + webhookEventColumns + ` FROM webhook_events WHERE provider = ?1 AND event_id = ?2`
+
+	e, err := scanWebhookEvent(r.db.QueryRowContext(ctx, query, provider, eventID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrWebhookEventNotFound
+		}
+		return nil, fmt.Errorf("getting webhook event %s/%s: %w", provider, eventID, err)
+	}
+
+	return e, nil
+}
+
+// ListWebhookEvents lists webhook events with filtering, newest first
+func (r *SQLiteWebhookEventRepo) ListWebhookEvents(ctx context.Context, filter repository.WebhookEventFilter, page repository.Pagination) ([]*repository.WebhookEvent, int64, error) {
+	where := []string{"1=1"}
+	args := []interface{}{}
+	argNum := 1
+
+	if filter.Provider != "" {
+		where = append(where, fmt.Sprintf("provider = ?%d", argNum))
+		args = append(args, filter.Provider)
+		argNum++
+	}
+	if filter.EventType != "" {
+		where = append(where, fmt.Sprintf("event_type = ?%d", argNum))
+		args = append(args, filter.EventType)
+		argNum++
+	}
+	if filter.Status != "" {
+		where = append(where, fmt.Sprintf("status = ?%d", argNum))
+		args = append(args, filter.Status)
+		argNum++
+	}
+
+	whereClause := "WHERE " + join(where, " AND ")
+
+	var total int64
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM webhook_events "+whereClause, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("counting webhook events: %w", err)
+	}
+
+	if page.PageSize <= 0 {
+		page.PageSize = 50
+	}
+	if page.Page <= 0 {
+		page.Page = 1
+	}
+	offset := (page.Page - 1) * page.PageSize
+
+	query := fmt.Sprintf(`
+		SELECT `+webhookEventColumns+`
+		FROM webhook_events
+		%s
+		ORDER BY created_at DESC
+		LIMIT ?%d OFFSET ?%d
+	`, whereClause, argNum, argNum+1)
+
+	args = append(args, page.PageSize, offset)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("listing webhook events: %w", err)
+	}
+	defer rows.Close()
+
+	var result []*repository.WebhookEvent
+	for rows.Next() {
+		e, err := scanWebhookEvent(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("scanning webhook event row: %w", err)
+		}
+		result = append(result, e)
+	}
+
+	return result, total, rows.Err()
+}
+
+// ListDueWebhookEvents returns events ready for background processing
+func (r *SQLiteWebhookEventRepo) ListDueWebhookEvents(ctx context.Context, provider string, staleAfter time.Duration, limit int) ([]*repository.WebhookEvent, error) {
+	query := `
+		SELECT ` + webhookEventColumns + `
+		FROM webhook_events
+		WHERE provider = ?1
+		  AND ((status IN ('received', 'failed') AND next_attempt_at <= ?2)
+		       OR (status = 'processing' AND updated_at < ?3))
+		ORDER BY created_at
+		LIMIT ?4
+	`
+
+	now := time.Now()
+	rows, err := r.db.QueryContext(ctx, query, provider, timeArg(now), timeArg(now.Add(-staleAfter)), limit)
+	if err != nil {
+		return nil, fmt.Errorf("listing due webhook events: %w", err)
+	}
+	defer rows.Close()
+
+	var result []*repository.WebhookEvent
+	for rows.Next() {
+		e, err := scanWebhookEvent(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning webhook event row: %w", err)
+		}
+		result = append(result, e)
+	}
+
+	return result, rows.Err()
+}
+
+// ClaimWebhookEvent moves a claimable event to processing
+func (r *SQLiteWebhookEventRepo) ClaimWebhookEvent(ctx context.Context, id string, staleAfter time.Duration) (bool, error) {
+	query := `
+		UPDATE webhook_events
+		SET status = 'processing', attempts = attempts + 1, updated_at = ` + sqlNow + `
+		WHERE id = ?1
+		  AND (status IN ('received', 'failed')
+		       OR (status = 'processing' AND updated_at < ?2))
+	`
+
+	result, err := r.db.ExecContext(ctx, query, id, timeArg(time.Now().Add(-staleAfter)))
+	if err != nil {
+		return false, fmt.Errorf("claiming webhook event: %w", err)
+	}
+
+	rows, _ := result.RowsAffected()
+	return rows == 1, nil
+}
+
+// MarkWebhookEventProcessed records successful processing
+func (r *SQLiteWebhookEventRepo) MarkWebhookEventProcessed(ctx context.Context, id string) error {
+	query := `
+		UPDATE webhook_events
+		SET status = 'processed', last_error = NULL, next_attempt_at = NULL,
+		    processed_at = ` + sqlNow + `, updated_at = ` + sqlNow + `
+		WHERE id = ?1
+	`
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("marking webhook event processed: %w", err)
+	}
+
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return repository.ErrWebhookEventNotFound
+	}
+
+	return nil
+}
+
+// MarkWebhookEventFailed records a processing failure and schedules the
+// next background attempt, if any
+func (r *SQLiteWebhookEventRepo) MarkWebhookEventFailed(ctx context.Context, id string, errMsg string, retryAt *time.Time) error {
+	query := `
+		UPDATE webhook_events
+		SET status = 'failed', last_error = ?2, next_attempt_at = ?3, updated_at = ` + sqlNow + `
+		WHERE id = ?1
+	`
+
+	var next interface{}
+	if retryAt != nil {
+		next = timeArg(*retryAt)
+	}
+
+	result, err := r.db.ExecContext(ctx, query, id, errMsg, next)
+	if err != nil {
+		return fmt.Errorf("marking webhook event failed: %w", err)
+	}
+
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return repository.ErrWebhookEventNotFound
+	}
+
+	return nil
+}
+
+// MarkWebhookEventDeadLetter parks an event that exhausted its retries
+func (r *SQLiteWebhookEventRepo) MarkWebhookEventDeadLetter(ctx context.Context, id string, errMsg string) error {
+	query := `
+		UPDATE webhook_events
+		SET status = 'dead_letter', last_error = ?2, next_attempt_at = NULL, updated_at = ` + sqlNow + `
+		WHERE id = ?1
+	`
+
+	result, err := r.db.ExecContext(ctx, query, id, errMsg)
+	if err != nil {
+		return fmt.Errorf("marking webhook event dead-lettered: %w", err)
+	}
+
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return repository.ErrWebhookEventNotFound
+	}
+
+	return nil
+}
+
+// RequeueWebhookEvent makes a failed or dead-lettered event due now
+func (r *SQLiteWebhookEventRepo) RequeueWebhookEvent(ctx context.Context, id string) error {
+	query := `
+		UPDATE webhook_events
+		SET status = 'received', next_attempt_at = ` + sqlNow + `, updated_at = ` + sqlNow + `
+		WHERE id = ?1 AND status IN ('failed', 'dead_letter')
+	`
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("requeueing webhook event: %w", err)
+	}
+
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		if _, err := r.GetWebhookEvent(ctx, id); err != nil {
+			return err
+		}
+		return repository.ErrWebhookEventNotRetryable
+	}
+
+	return nil
+}
+
+// scanWebhookEvent scans a webhook_events row
+func scanWebhookEvent(row rowScanner) (*repository.WebhookEvent, error) {
+	e := &repository.WebhookEvent{}
+	var payload string
+	err := row.Scan(
+		&e.ID,
+		&e.Provider,
+		&e.EventID,
+		&e.EventType,
+		&payload,
+		&e.Status,
+		&e.Attempts,
+		&e.LastError,
+		&e.CreatedAt,
+		&e.UpdatedAt,
+		&e.ProcessedAt,
+		&e.NextAttemptAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	e.Payload = []byte(payload)
+	return e, nil
+}
@@ -0,0 +1,97 @@
+// Package timing adds up where a request's time goes. A Breakdown carried
+// on the request context collects the time spent in the database and in
+// each external provider, recorded by the layers that make those calls
+// (storage guard, outbound transports, RPC client), so a slow request can
+// be logged with what dominated it.
+package timing
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Components recorded by this repository's clients. Outbound transports
+// record under their provider name, e.g. "stripe" or "sumsub".
+const (
+	DB  = "db"
+	RPC = "rpc"
+)
+
+// Breakdown is the time a request spent per component. It is safe for
+// concurrent use, as handlers may call several backends at once.
+type Breakdown struct {
+	mu    sync.Mutex
+	spent map[string]*Span
+}
+
+// Span is the time spent in one component and how many calls made it up
+type Span struct {
+	Component string
+	Duration  time.Duration
+	Calls     int
+}
+
+type contextKey struct{}
+
+// WithBreakdown returns a context collecting a new Breakdown
+func WithBreakdown(ctx context.Context) (context.Context, *Breakdown) {
+	b := &Breakdown{spent: make(map[string]*Span)}
+	return context.WithValue(ctx, contextKey{}, b), b
+}
+
+// FromContext returns the Breakdown of ctx, or nil
+func FromContext(ctx context.Context) *Breakdown {
+	b, _ := ctx.Value(contextKey{}).(*Breakdown)
+	return b
+}
+
+// Record adds one call of d to component on ctx's Breakdown, if any
+func Record(ctx context.Context, component string, d time.Duration) {
+	if b := FromContext(ctx); b != nil {
+		b.add(component, d)
+	}
+}
+
+// Track starts timing a call to component, recorded when the returned
+// function is called:
+//
+//	defer timing.Track(ctx, timing.DB)()
+func Track(ctx context.Context, component string) func() {
+	b := FromContext(ctx)
+	if b == nil {
+		return func() {}
+	}
+	start := time.Now()
+	return func() { b.add(component, time.Since(start)) }
+}
+
+func (b *Breakdown) add(component string, d time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	s, ok := b.spent[component]
+	if !ok {
+		s = &Span{Component: component}
+		b.spent[component] = s
+	}
+	s.Duration += d
+	s.Calls++
+}
+
+// Spans returns the components recorded so far, slowest first
+func (b *Breakdown) Spans() []Span {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	spans := make([]Span, 0, len(b.spent))
+	for _, s := range b.spent {
+		spans = append(spans, *s)
+	}
+	sort.Slice(spans, func(i, j int) bool {
+		if spans[i].Duration != spans[j].Duration {
+			return spans[i].Duration > spans[j].Duration
+		}
+		return spans[i].Component < spans[j].Component
+	})
+	return spans
+}
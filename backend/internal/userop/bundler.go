@@ -0,0 +1,80 @@
+package userop
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Bundler is a JSON-RPC client for an ERC-4337 bundler
+type Bundler struct {
+	client *rpc.Client
+}
+
+// NewBundler connects to the bundler at url
+func NewBundler(ctx context.Context, url string) (*Bundler, error) {
+	client, err := rpc.DialContext(ctx, url)
+	if err != nil {
+		return nil, fmt.Errorf("connecting to bundler: %w", err)
+	}
+	return &Bundler{client: client}, nil
+}
+
+// Close closes the connection
+func (b *Bundler) Close() {
+	b.client.Close()
+}
+
+// SendUserOperation submits op through entryPoint and returns its
+// userOpHash. Rejections by the bundler are returned as *RejectedError.
+func (b *Bundler) SendUserOperation(ctx context.Context, op *UserOperation, entryPoint common.Address) (common.Hash, error) {
+	var hash common.Hash
+	if err := b.client.CallContext(ctx, &hash, "eth_sendUserOperation", op, entryPoint); err != nil {
+		return common.Hash{}, wrapRPCError(err)
+	}
+	return hash, nil
+}
+
+// GetUserOperationReceipt returns the bundler's receipt for a userOpHash, or
+// nil while the operation is not yet included
+func (b *Bundler) GetUserOperationReceipt(ctx context.Context, hash common.Hash) (json.RawMessage, error) {
+	var receipt json.RawMessage
+	if err := b.client.CallContext(ctx, &receipt, "eth_getUserOperationReceipt", hash); err != nil {
+		return nil, wrapRPCError(err)
+	}
+	if string(receipt) == "null" {
+		return nil, nil
+	}
+	return receipt, nil
+}
+
+// RejectedError is a JSON-RPC error returned by the bundler, e.g. a failed
+// simulation (AA2x/AA3x codes)
+type RejectedError struct {
+	Code    int
+	Message string
+}
+
+func (e *RejectedError) Error() string {
+	return fmt.Sprintf("bundler rejected user operation (%d): %s", e.Code, e.Message)
+}
+
+// IsRejected reports whether err is a bundler rejection rather than a
+// transport failure
+func IsRejected(err error) bool {
+	var rejected *RejectedError
+	return errors.As(err, &rejected)
+}
+
+// wrapRPCError turns JSON-RPC error responses into *RejectedError
+func wrapRPCError(err error) error {
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) {
+		return &RejectedError{Code: rpcErr.ErrorCode(), Message: rpcErr.Error()}
+	}
+	return err
+}
@@ -0,0 +1,143 @@
+package userop
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// namespace is the app_config namespace holding the paymaster policy
+const namespace = "userop"
+
+// ErrDisabled is returned while UserOperation forwarding is switched off or
+// has no entry point and paymaster configured
+var ErrDisabled = errors.New("user operation forwarding is disabled")
+
+// PolicyError is a UserOperation rejected by the paymaster policy
+type PolicyError struct {
+	Reason string
+}
+
+func (e *PolicyError) Error() string {
+	return "user operation rejected: " + e.Reason
+}
+
+// Policy is the paymaster policy UserOperations must satisfy. Gas caps of 0
+// are not enforced.
+type Policy struct {
+	Enabled               bool           `json:"enabled"`
+	EntryPoint            common.Address `json:"entry_point"`
+	Paymaster             common.Address `json:"paymaster"`
+	MaxCallGasLimit       int64          `json:"max_call_gas_limit"`
+	MaxVerificationGas    int64          `json:"max_verification_gas_limit"`
+	MaxPreVerificationGas int64          `json:"max_pre_verification_gas"`
+	MaxFeePerGas          *big.Int       `json:"max_fee_per_gas"` // relayer.max_gas_price_gwei, in wei
+}
+
+// LoadPolicy reads the policy from app_config. The fee cap is shared with the
+// meta-transaction relayer.
+func LoadPolicy(ctx context.Context, configRepo repository.AppConfigRepository, chainID int64) (*Policy, error) {
+	p := &Policy{}
+
+	enabled, err := configRepo.GetBool(ctx, namespace, "enabled", chainID)
+	if err != nil && !errors.Is(err, repository.ErrAppConfigNotFound) {
+		return nil, fmt.Errorf("loading userop policy: %w", err)
+	}
+	p.Enabled = enabled
+
+	for key, dest := range map[string]*common.Address{"entry_point": &p.EntryPoint, "paymaster_address": &p.Paymaster} {
+		value, err := configRepo.GetString(ctx, namespace, key, chainID)
+		if errors.Is(err, repository.ErrAppConfigNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("loading userop policy: %w", err)
+		}
+		if common.IsHexAddress(value) {
+			*dest = common.HexToAddress(value)
+		}
+	}
+
+	for key, dest := range map[string]*int64{
+		"max_call_gas_limit":         &p.MaxCallGasLimit,
+		"max_verification_gas_limit": &p.MaxVerificationGas,
+		"max_pre_verification_gas":   &p.MaxPreVerificationGas,
+	} {
+		value, err := configRepo.GetNumber(ctx, namespace, key, chainID)
+		if errors.Is(err, repository.ErrAppConfigNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("loading userop policy: %w", err)
+		}
+		*dest = value
+	}
+
+	gwei, err := configRepo.GetNumber(ctx, "relayer", "max_gas_price_gwei", chainID)
+	if err != nil && !errors.Is(err, repository.ErrAppConfigNotFound) {
+		return nil, fmt.Errorf("loading userop policy: %w", err)
+	}
+	if gwei > 0 {
+		p.MaxFeePerGas = new(big.Int).Mul(big.NewInt(gwei), big.NewInt(1e9))
+	}
+
+	return p, nil
+}
+
+// Check returns ErrDisabled or a *PolicyError if the operation may not be
+// forwarded
+func (p *Policy) Check(op *UserOperation) error {
+	if !p.Enabled || p.EntryPoint == (common.Address{}) || p.Paymaster == (common.Address{}) {
+		return ErrDisabled
+	}
+
+	if op.Sender == (common.Address{}) {
+		return &PolicyError{Reason: "missing sender"}
+	}
+	if op.Nonce == nil || op.CallGasLimit == nil || op.VerificationGasLimit == nil ||
+		op.PreVerificationGas == nil || op.MaxFeePerGas == nil || op.MaxPriorityFeePerGas == nil {
+		return &PolicyError{Reason: "missing gas or nonce fields"}
+	}
+	if len(op.Signature) == 0 {
+		return &PolicyError{Reason: "missing signature"}
+	}
+	if len(op.InitCode) > 0 && len(op.InitCode) < common.AddressLength {
+		return &PolicyError{Reason: "initCode must start with the factory address"}
+	}
+
+	paymaster, ok := op.Paymaster()
+	if !ok || paymaster != p.Paymaster {
+		return &PolicyError{Reason: "operation must be sponsored by paymaster " + p.Paymaster.Hex()}
+	}
+
+	if err := checkCap("callGasLimit", op.CallGasLimit.ToInt(), p.MaxCallGasLimit); err != nil {
+		return err
+	}
+	if err := checkCap("verificationGasLimit", op.VerificationGasLimit.ToInt(), p.MaxVerificationGas); err != nil {
+		return err
+	}
+	if err := checkCap("preVerificationGas", op.PreVerificationGas.ToInt(), p.MaxPreVerificationGas); err != nil {
+		return err
+	}
+	if p.MaxFeePerGas != nil && op.MaxFeePerGas.ToInt().Cmp(p.MaxFeePerGas) > 0 {
+		return &PolicyError{Reason: fmt.Sprintf("maxFeePerGas exceeds %s wei", p.MaxFeePerGas)}
+	}
+	if op.MaxPriorityFeePerGas.ToInt().Cmp(op.MaxFeePerGas.ToInt()) > 0 {
+		return &PolicyError{Reason: "maxPriorityFeePerGas exceeds maxFeePerGas"}
+	}
+
+	return nil
+}
+
+// checkCap rejects a gas field above limit (0 = no limit)
+func checkCap(field string, value *big.Int, limit int64) error {
+	if limit > 0 && value.Cmp(big.NewInt(limit)) > 0 {
+		return &PolicyError{Reason: fmt.Sprintf("%s exceeds %d", field, limit)}
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+// Package userop forwards ERC-4337 UserOperations to a bundler for wallets
+// that use account abstraction instead of ERC-2771 meta-transactions.
+// Operations are checked against the paymaster policy (app_config namespace
+// "userop") before they are sent.
+package userop
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// UserOperation is an EntryPoint v0.6 user operation in the JSON form of the
+// eth_sendUserOperation RPC
+type UserOperation struct {
+	Sender               common.Address `json:"sender"`
+	Nonce                *hexutil.Big   `json:"nonce"`
+	InitCode             hexutil.Bytes  `json:"initCode"`
+	CallData             hexutil.Bytes  `json:"callData"`
+	CallGasLimit         *hexutil.Big   `json:"callGasLimit"`
+	VerificationGasLimit *hexutil.Big   `json:"verificationGasLimit"`
+	PreVerificationGas   *hexutil.Big   `json:"preVerificationGas"`
+	MaxFeePerGas         *hexutil.Big   `json:"maxFeePerGas"`
+	MaxPriorityFeePerGas *hexutil.Big   `json:"maxPriorityFeePerGas"`
+	PaymasterAndData     hexutil.Bytes  `json:"paymasterAndData"`
+	Signature            hexutil.Bytes  `json:"signature"`
+}
+
+// Paymaster returns the paymaster sponsoring the operation, if any
+func (op *UserOperation) Paymaster() (common.Address, bool) {
+	if len(op.PaymasterAndData) < common.AddressLength {
+		return common.Address{}, false
+	}
+	return common.BytesToAddress(op.PaymasterAndData[:common.AddressLength]), true
+}
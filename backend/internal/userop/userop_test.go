@@ -0,0 +1,162 @@
+package userop_test
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/storage/memory"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/userop"
+)
+
+var (
+	entryPoint = common.HexToAddress("0x5FF137D4b0FDCD49DcA30c7CF57E578a026d2789")
+	paymaster  = common.HexToAddress("0x9fE46736679d2D9a65F0992F2272dE9f3c7fa6e0")
+)
+
+func validOp() *userop.UserOperation {
+	n := func(v int64) *hexutil.Big { return (*hexutil.Big)(big.NewInt(v)) }
+	return &userop.UserOperation{
+		Sender:               common.HexToAddress("0x3C44CdDdB6a900fa2b585dd299e03d12FA4293BC"),
+		Nonce:                n(0),
+		CallData:             hexutil.Bytes{0xb6, 0x1d, 0x27, 0xf6},
+		CallGasLimit:         n(100000),
+		VerificationGasLimit: n(150000),
+		PreVerificationGas:   n(50000),
+		MaxFeePerGas:         n(2e9),
+		MaxPriorityFeePerGas: n(1e9),
+		PaymasterAndData:     append(paymaster.Bytes(), 0x01, 0x02),
+		Signature:            hexutil.Bytes{0x01},
+	}
+}
+
+func create(t *testing.T, repo repository.AppConfigRepository, cfg *repository.AppConfigCreate) {
+	t.Helper()
+	cfg.UpdatedBy = "test"
+	require.NoError(t, repo.Create(context.Background(), cfg))
+}
+
+func TestLoadPolicy(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryAppConfigRepo()
+
+	// Nothing configured: disabled
+	policy, err := userop.LoadPolicy(ctx, repo, 31337)
+	require.NoError(t, err)
+	assert.ErrorIs(t, policy.Check(validOp()), userop.ErrDisabled)
+
+	enabled := true
+	ep, pm := entryPoint.Hex(), paymaster.Hex()
+	callGas, gwei := int64(200000), int64(5)
+	create(t, repo, &repository.AppConfigCreate{Namespace: "userop", ConfigKey: "enabled", ValueType: "boolean", ValueBoolean: &enabled})
+	create(t, repo, &repository.AppConfigCreate{Namespace: "userop", ConfigKey: "entry_point", ValueType: "address", ValueString: &ep})
+	create(t, repo, &repository.AppConfigCreate{Namespace: "userop", ConfigKey: "paymaster_address", ValueType: "address", ValueString: &pm})
+	create(t, repo, &repository.AppConfigCreate{Namespace: "userop", ConfigKey: "max_call_gas_limit", ValueType: "number", ValueNumber: &callGas})
+	create(t, repo, &repository.AppConfigCreate{Namespace: "relayer", ConfigKey: "max_gas_price_gwei", ValueType: "number", ValueNumber: &gwei})
+
+	policy, err = userop.LoadPolicy(ctx, repo, 31337)
+	require.NoError(t, err)
+	assert.True(t, policy.Enabled)
+	assert.Equal(t, entryPoint, policy.EntryPoint)
+	assert.Equal(t, paymaster, policy.Paymaster)
+	assert.Equal(t, int64(200000), policy.MaxCallGasLimit)
+	assert.Equal(t, "5000000000", policy.MaxFeePerGas.String())
+	assert.NoError(t, policy.Check(validOp()))
+}
+
+func TestPolicy_Check(t *testing.T) {
+	policy := &userop.Policy{Enabled: true, EntryPoint: entryPoint, Paymaster: paymaster,
+		MaxCallGasLimit: 200000, MaxVerificationGas: 200000, MaxFeePerGas: big.NewInt(5e9)}
+
+	tests := []struct {
+		name   string
+		modify func(op *userop.UserOperation)
+		reason string
+	}{
+		{"unsponsored", func(op *userop.UserOperation) { op.PaymasterAndData = nil }, "sponsored by paymaster"},
+		{"other paymaster", func(op *userop.UserOperation) { op.PaymasterAndData = common.HexToAddress("0x01").Bytes() }, "sponsored by paymaster"},
+		{"call gas", func(op *userop.UserOperation) { op.CallGasLimit = (*hexutil.Big)(big.NewInt(200001)) }, "callGasLimit exceeds 200000"},
+		{"verification gas", func(op *userop.UserOperation) { op.VerificationGasLimit = (*hexutil.Big)(big.NewInt(300000)) }, "verificationGasLimit exceeds"},
+		{"fee cap", func(op *userop.UserOperation) { op.MaxFeePerGas = (*hexutil.Big)(big.NewInt(6e9)) }, "maxFeePerGas exceeds 5000000000"},
+		{"priority fee", func(op *userop.UserOperation) { op.MaxPriorityFeePerGas = (*hexutil.Big)(big.NewInt(3e9)) }, "maxPriorityFeePerGas"},
+		{"signature", func(op *userop.UserOperation) { op.Signature = nil }, "missing signature"},
+		{"init code", func(op *userop.UserOperation) { op.InitCode = hexutil.Bytes{0x01} }, "factory address"},
+		{"missing gas", func(op *userop.UserOperation) { op.PreVerificationGas = nil }, "missing gas"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			op := validOp()
+			tt.modify(op)
+			var rejected *userop.PolicyError
+			require.ErrorAs(t, policy.Check(op), &rejected)
+			assert.Contains(t, rejected.Reason, tt.reason)
+		})
+	}
+
+	// preVerificationGas has no cap configured
+	op := validOp()
+	op.PreVerificationGas = (*hexutil.Big)(big.NewInt(1e7))
+	assert.NoError(t, policy.Check(op))
+}
+
+func TestBundler_SendUserOperation(t *testing.T) {
+	var params []json.RawMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     json.RawMessage   `json:"id"`
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		w.Header().Set("Content-Type", "application/json")
+
+		switch req.Method {
+		case "eth_sendUserOperation":
+			params = req.Params
+			var op userop.UserOperation
+			require.NoError(t, json.Unmarshal(req.Params[0], &op))
+			if len(op.Signature) == 0 {
+				json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": req.ID,
+					"error": map[string]any{"code": -32507, "message": "AA24 signature error"}})
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": req.ID, "result": common.HexToHash("0xabc").Hex()})
+		case "eth_getUserOperationReceipt":
+			json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": req.ID, "result": nil})
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	bundler, err := userop.NewBundler(ctx, server.URL)
+	require.NoError(t, err)
+	defer bundler.Close()
+
+	hash, err := bundler.SendUserOperation(ctx, validOp(), entryPoint)
+	require.NoError(t, err)
+	assert.Equal(t, common.HexToHash("0xabc"), hash)
+	require.Len(t, params, 2)
+	var gotEntryPoint common.Address
+	require.NoError(t, json.Unmarshal(params[1], &gotEntryPoint))
+	assert.Equal(t, entryPoint, gotEntryPoint)
+	assert.Contains(t, string(params[0]), `"callGasLimit":"0x186a0"`)
+
+	op := validOp()
+	op.Signature = nil
+	_, err = bundler.SendUserOperation(ctx, op, entryPoint)
+	assert.True(t, userop.IsRejected(err))
+	assert.Contains(t, err.Error(), "AA24")
+
+	receipt, err := bundler.GetUserOperationReceipt(ctx, hash)
+	require.NoError(t, err)
+	assert.Nil(t, receipt)
+}
@@ -0,0 +1,101 @@
+package watchlist
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/callbacks"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// deliveryTimeout bounds one POST to a watchlist's webhook
+const deliveryTimeout = 10 * time.Second
+
+// Sender delivers queued watchlist alerts (registered with the webhook
+// processor for WebhookProviderWatchlistAlert). Each alert goes to the
+// watchlist's current webhook URL, signed with its secret.
+type Sender struct {
+	repo   repository.WatchlistRepository
+	client *http.Client
+	logger *zap.Logger
+	now    func() time.Time
+}
+
+// NewSender creates a watchlist alert sender. Watchlist owners choose their
+// own URLs, so like payment callbacks non-public addresses are refused
+// unless allowInsecure is set (local development).
+func NewSender(repo repository.WatchlistRepository, allowInsecure bool, logger *zap.Logger) *Sender {
+	return &Sender{
+		repo:   repo,
+		client: callbacks.NewClient(allowInsecure),
+		logger: logger,
+		now:    time.Now,
+	}
+}
+
+// ProcessWebhookEvent POSTs a queued alert to the watchlist's webhook
+// (called by the background webhook processor). Alerts are dropped if the
+// watchlist was deleted or no longer watches the address or activity kind.
+// A non-2xx response is retried with backoff until the event is
+// dead-lettered.
+func (s *Sender) ProcessWebhookEvent(ctx context.Context, e *repository.WebhookEvent) error {
+	var a Alert
+	if err := json.Unmarshal(e.Payload, &a); err != nil {
+		return fmt.Errorf("parsing stored watchlist alert: %w", err)
+	}
+
+	l, err := s.repo.GetWatchlist(ctx, a.WatchlistID)
+	if err != nil {
+		if errors.Is(err, repository.ErrWatchlistNotFound) {
+			s.logger.Info("dropping watchlist alert for a deleted watchlist",
+				zap.String("alert_id", a.ID),
+				zap.String("watchlist_id", a.WatchlistID),
+			)
+			return nil
+		}
+		return fmt.Errorf("getting watchlist %s: %w", a.WatchlistID, err)
+	}
+	if a.Activity == nil || !l.Watches(a.Address, a.Activity.Kind) {
+		s.logger.Info("dropping watchlist alert no longer watched",
+			zap.String("alert_id", a.ID),
+			zap.String("watchlist_id", a.WatchlistID),
+		)
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deliveryTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, l.WebhookURL, bytes.NewReader(e.Payload))
+	if err != nil {
+		return fmt.Errorf("building watchlist alert request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "nexus-protocol-watchlist/1")
+	req.Header.Set(callbacks.EventIDHeader, a.ID)
+	req.Header.Set(callbacks.SignatureHeader, callbacks.Sign(l.Secret, s.now(), e.Payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("posting watchlist alert: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("watchlist webhook returned %d", resp.StatusCode)
+	}
+	s.logger.Info("watchlist alert delivered",
+		zap.String("alert_id", a.ID),
+		zap.String("watchlist_id", a.WatchlistID),
+		zap.String("address", a.Address),
+	)
+	return nil
+}
@@ -0,0 +1,113 @@
+// Package watchlist alerts compliance and users when watched addresses
+// transact. A watchlist is a set of addresses with a webhook URL, a signing
+// secret and the kinds of activity it wants: payments, NFT transfers and
+// relayed meta-transactions. The Watcher listens to the activity recorder;
+// each recorded event of a watched address is queued as a webhook event per
+// matching watchlist and POSTed in the background by the Sender, signed
+// like payment callbacks.
+package watchlist
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/callbacks"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// watchlistPageSize bounds one page of matching watchlists
+const watchlistPageSize = 100
+
+// Alert is the body POSTed to a watchlist's webhook URL. Type is
+// "watchlist." followed by the activity kind.
+type Alert struct {
+	ID          string                    `json:"id"` // stable across retries, so redeliveries can be deduplicated
+	Type        string                    `json:"type"`
+	WatchlistID string                    `json:"watchlist_id"`
+	Watchlist   string                    `json:"watchlist,omitempty"` // its name
+	Address     string                    `json:"address"`
+	Activity    *repository.ActivityEvent `json:"activity"`
+	OccurredAt  time.Time                 `json:"occurred_at"`
+}
+
+// Watcher queues an alert for every watchlist watching a recorded event's
+// address and kind (subscribed to activity.Recorder)
+type Watcher struct {
+	repo   repository.WatchlistRepository
+	queue  callbacks.Queue
+	logger *zap.Logger
+}
+
+// NewWatcher creates a watcher queueing alerts on q for the Sender
+func NewWatcher(repo repository.WatchlistRepository, q callbacks.Queue, logger *zap.Logger) *Watcher {
+	return &Watcher{repo: repo, queue: q, logger: logger}
+}
+
+// ActivityRecorded implements activity.Listener. The event is already
+// recorded, so failing to find or alert its watchlists is logged rather
+// than returned.
+func (w *Watcher) ActivityRecorded(ctx context.Context, e *repository.ActivityEvent) {
+	if !e.Kind.Watchable() {
+		return
+	}
+	filter := repository.WatchlistFilter{Address: e.Address, Event: e.Kind}
+	err := w.eachWatchlist(ctx, filter, func(l *repository.Watchlist) {
+		w.enqueue(ctx, &Alert{
+			ID:          e.ID + ":" + l.ID,
+			Type:        "watchlist." + string(e.Kind),
+			WatchlistID: l.ID,
+			Watchlist:   l.Name,
+			Address:     e.Address,
+			Activity:    e,
+			OccurredAt:  e.OccurredAt,
+		})
+	})
+	if err != nil {
+		w.logger.Error("failed to alert watchlists",
+			zap.String("address", e.Address),
+			zap.String("kind", string(e.Kind)),
+			zap.String("activity_id", e.ID),
+			zap.Error(err),
+		)
+	}
+}
+
+// eachWatchlist calls fn for every watchlist matching filter
+func (w *Watcher) eachWatchlist(ctx context.Context, filter repository.WatchlistFilter, fn func(*repository.Watchlist)) error {
+	for page := 1; ; page++ {
+		watchlists, total, err := w.repo.ListWatchlists(ctx, filter, repository.Pagination{Page: page, PageSize: watchlistPageSize})
+		if err != nil {
+			return fmt.Errorf("listing watchlists: %w", err)
+		}
+		for _, l := range watchlists {
+			fn(l)
+		}
+		if int64(page*watchlistPageSize) >= total || len(watchlists) == 0 {
+			return nil
+		}
+	}
+}
+
+// enqueue queues an alert, logging a failure
+func (w *Watcher) enqueue(ctx context.Context, a *Alert) {
+	payload, err := json.Marshal(a)
+	if err == nil {
+		_, err = w.queue.Enqueue(ctx, &repository.WebhookEvent{
+			Provider:  repository.WebhookProviderWatchlistAlert,
+			EventID:   a.ID,
+			EventType: a.Type,
+			Payload:   payload,
+		})
+	}
+	if err != nil {
+		w.logger.Error("failed to queue watchlist alert",
+			zap.String("alert_id", a.ID),
+			zap.String("watchlist_id", a.WatchlistID),
+			zap.Error(err),
+		)
+	}
+}
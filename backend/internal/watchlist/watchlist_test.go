@@ -0,0 +1,131 @@
+package watchlist_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/activity"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/callbacks"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/storage/memory"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/watchlist"
+)
+
+const (
+	watched = "0x00000000000000000000000000000000000000aa"
+	other   = "0x00000000000000000000000000000000000000bb"
+)
+
+// fakeQueue records enqueued alerts by event ID
+type fakeQueue struct {
+	events map[string]*repository.WebhookEvent
+}
+
+func (q *fakeQueue) Enqueue(_ context.Context, e *repository.WebhookEvent) (bool, error) {
+	if _, ok := q.events[e.EventID]; ok {
+		return false, nil
+	}
+	q.events[e.EventID] = e
+	return true, nil
+}
+
+// alerts decodes the queued alerts of a watchlist
+func (q *fakeQueue) alerts(t *testing.T, watchlistID string) []watchlist.Alert {
+	t.Helper()
+	var out []watchlist.Alert
+	for _, e := range q.events {
+		assert.Equal(t, repository.WebhookProviderWatchlistAlert, e.Provider)
+		var a watchlist.Alert
+		require.NoError(t, json.Unmarshal(e.Payload, &a))
+		if a.WatchlistID == watchlistID {
+			out = append(out, a)
+		}
+	}
+	return out
+}
+
+func TestWatcher_AlertsMatchingWatchlists(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	queue := &fakeQueue{events: make(map[string]*repository.WebhookEvent)}
+	recorder := activity.NewRecorder(store.Activity, zap.NewNop())
+	recorder.Subscribe(watchlist.NewWatcher(store.Watchlists, queue, zap.NewNop()))
+
+	relays := &repository.Watchlist{Name: "relays", WebhookURL: "https://example.com/a", Secret: "0123456789abcdef",
+		Addresses: []string{watched}, Events: []string{"meta_tx"}}
+	everything := &repository.Watchlist{Name: "everything", WebhookURL: "https://example.com/b", Secret: "0123456789abcdef",
+		Addresses: []string{watched, other}, Events: []string{"payment", "nft_transfer", "meta_tx"}}
+	require.NoError(t, store.Watchlists.CreateWatchlist(ctx, relays))
+	require.NoError(t, store.Watchlists.CreateWatchlist(ctx, everything))
+
+	relayer := activity.NewRecordingRelayerRepo(store.Relayer, recorder)
+	tx := &repository.MetaTransaction{FromAddress: "0x00000000000000000000000000000000000000AA", ToAddress: "0x00000000000000000000000000000000000000c2",
+		FunctionName: "transfer", Nonce: 1, Deadline: time.Now().Add(time.Hour), Status: repository.MetaTxStatusPending}
+	require.NoError(t, relayer.CreateMetaTx(ctx, tx))
+
+	payments := activity.NewRecordingPaymentRepo(store.Payments, recorder)
+	require.NoError(t, payments.CreatePayment(ctx, &repository.Payment{ServiceCode: "kyc_verification", PayerAddress: other,
+		PaymentMethod: "stripe", Currency: "USD", Status: repository.PaymentStatusPending}))
+
+	// Votes are not watchable
+	recorder.Record(ctx, &repository.ActivityEvent{Address: watched, Kind: repository.ActivityVote, Action: "for", SubjectID: "p1"})
+
+	got := queue.alerts(t, relays.ID)
+	require.Len(t, got, 1)
+	assert.Equal(t, "watchlist.meta_tx", got[0].Type)
+	assert.Equal(t, "relays", got[0].Watchlist)
+	assert.Equal(t, watched, got[0].Address)
+	assert.Equal(t, tx.ID, got[0].Activity.SubjectID)
+
+	got = queue.alerts(t, everything.ID)
+	require.Len(t, got, 2)
+	assert.Len(t, queue.events, 3)
+}
+
+func TestSender_SignsAndDropsUnwatched(t *testing.T) {
+	ctx := context.Background()
+	var received *http.Request
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		body, _ = io.ReadAll(r.Body)
+	}))
+	t.Cleanup(server.Close)
+
+	store := memory.NewStore()
+	l := &repository.Watchlist{WebhookURL: server.URL, Secret: "0123456789abcdef", Addresses: []string{watched}, Events: []string{"payment"}}
+	require.NoError(t, store.Watchlists.CreateWatchlist(ctx, l))
+	sender := watchlist.NewSender(store.Watchlists, true, zap.NewNop())
+
+	payload, err := json.Marshal(watchlist.Alert{ID: "a1:" + l.ID, Type: "watchlist.payment", WatchlistID: l.ID, Address: watched,
+		Activity: &repository.ActivityEvent{ID: "a1", Address: watched, Kind: repository.ActivityPayment, Action: "completed"}})
+	require.NoError(t, err)
+	event := &repository.WebhookEvent{Provider: repository.WebhookProviderWatchlistAlert, EventID: "a1:" + l.ID, Payload: payload}
+	require.NoError(t, sender.ProcessWebhookEvent(ctx, event))
+	require.NotNil(t, received)
+	assert.Equal(t, payload, body)
+	assert.Equal(t, "a1:"+l.ID, received.Header.Get(callbacks.EventIDHeader))
+	assert.True(t, strings.HasPrefix(received.Header.Get(callbacks.SignatureHeader), "t="))
+
+	// Alerts for an address removed since are dropped
+	received = nil
+	l.Addresses = []string{other}
+	require.NoError(t, store.Watchlists.UpdateWatchlist(ctx, l))
+	require.NoError(t, sender.ProcessWebhookEvent(ctx, event))
+	assert.Nil(t, received)
+
+	// As are alerts for a deleted watchlist
+	require.NoError(t, store.Watchlists.DeleteWatchlist(ctx, l.ID))
+	require.NoError(t, sender.ProcessWebhookEvent(ctx, event))
+	assert.Nil(t, received)
+}
@@ -0,0 +1,267 @@
+// Package webhooks processes stored inbound webhook events in the background.
+// Handlers persist the raw payload and acknowledge the provider; the
+// processor applies events with retries and backoff so a transient failure
+// delays an update instead of dropping it, and parks events that keep
+// failing in a dead-letter state for manual reprocessing.
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+)
+
+// Processing defaults (overridable via app_config namespace "webhooks")
+const (
+	defaultMaxAttempts      = 8
+	defaultRetryBaseSeconds = 30
+	defaultRetryMaxSeconds  = 3600
+
+	// DefaultInterval is how often Run looks for due events when no interval
+	// is given
+	DefaultInterval = 10 * time.Second
+
+	// StaleAfter is how long an event may stay processing before another
+	// pass takes it over from a worker presumed dead
+	StaleAfter = 5 * time.Minute
+
+	// batchSize caps how many events of one provider are processed per pass
+	batchSize = 50
+)
+
+// ErrNoHandler is returned when reprocessing an event of a provider that is
+// not processed in the background
+var ErrNoHandler = errors.New("no webhook handler registered for provider")
+
+// Handler applies a stored webhook event. Implementations must be idempotent:
+// an event is retried after any error and may be applied again after a crash.
+type Handler interface {
+	ProcessWebhookEvent(ctx context.Context, e *repository.WebhookEvent) error
+}
+
+// Processor applies stored webhook events for registered providers
+type Processor struct {
+	repo       repository.WebhookEventRepository
+	configRepo repository.AppConfigRepository
+	logger     *zap.Logger
+
+	mu       sync.RWMutex
+	handlers map[string]Handler
+
+	wake chan struct{}
+}
+
+// NewProcessor creates a new webhook event processor
+func NewProcessor(repo repository.WebhookEventRepository, configRepo repository.AppConfigRepository, logger *zap.Logger) *Processor {
+	return &Processor{
+		repo:       repo,
+		configRepo: configRepo,
+		logger:     logger,
+		handlers:   make(map[string]Handler),
+		wake:       make(chan struct{}, 1),
+	}
+}
+
+// RegisterHandler registers the handler for a webhook provider
+func (p *Processor) RegisterHandler(provider string, h Handler) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.handlers[provider] = h
+}
+
+// Enqueue stores an event for background processing and wakes Run. It
+// reports false when the provider already delivered the event.
+func (p *Processor) Enqueue(ctx context.Context, e *repository.WebhookEvent) (bool, error) {
+	created, err := p.repo.CreateWebhookEvent(ctx, e)
+	if err != nil {
+		return false, err
+	}
+	if created {
+		p.notify()
+	}
+	return created, nil
+}
+
+// notify wakes Run without blocking
+func (p *Processor) notify() {
+	select {
+	case p.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run processes due events every interval, and whenever an event is
+// enqueued, until ctx is cancelled
+func (p *Processor) Run(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		p.RunOnce(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-p.wake:
+		}
+	}
+}
+
+// RunOnce processes the due events of every registered provider and returns
+// how many were attempted
+func (p *Processor) RunOnce(ctx context.Context) int {
+	p.mu.RLock()
+	providers := make([]string, 0, len(p.handlers))
+	for provider := range p.handlers {
+		providers = append(providers, provider)
+	}
+	p.mu.RUnlock()
+
+	attempted := 0
+	for _, provider := range providers {
+		due, err := p.repo.ListDueWebhookEvents(ctx, provider, StaleAfter, batchSize)
+		if err != nil {
+			if ctx.Err() == nil {
+				p.logger.Warn("failed to list due webhook events", zap.String("provider", provider), zap.Error(err))
+			}
+			continue
+		}
+
+		for _, e := range due {
+			if ctx.Err() != nil {
+				return attempted
+			}
+			if p.process(ctx, e) {
+				attempted++
+			}
+		}
+	}
+	return attempted
+}
+
+// Reprocess requeues a failed or dead-lettered event and processes it
+// immediately. Processing errors are recorded on the returned event; the
+// error is only set when the event could not be attempted.
+func (p *Processor) Reprocess(ctx context.Context, id string) (*repository.WebhookEvent, error) {
+	e, err := p.repo.GetWebhookEvent(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if p.handler(e.Provider) == nil {
+		return nil, fmt.Errorf("%w: %s", ErrNoHandler, e.Provider)
+	}
+
+	if err := p.repo.RequeueWebhookEvent(ctx, id); err != nil {
+		return nil, err
+	}
+
+	p.logger.Info("reprocessing webhook event",
+		zap.String("id", id),
+		zap.String("provider", e.Provider),
+		zap.String("type", e.EventType),
+		zap.Int("previous_attempts", e.Attempts),
+	)
+	p.process(ctx, e)
+
+	return p.repo.GetWebhookEvent(ctx, id)
+}
+
+// handler returns the handler registered for provider, or nil
+func (p *Processor) handler(provider string) Handler {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.handlers[provider]
+}
+
+// process claims e, applies it and records the outcome. It reports false if
+// another worker holds the event.
+func (p *Processor) process(ctx context.Context, e *repository.WebhookEvent) bool {
+	h := p.handler(e.Provider)
+	if h == nil {
+		return false
+	}
+
+	claimed, err := p.repo.ClaimWebhookEvent(ctx, e.ID, StaleAfter)
+	if err != nil {
+		p.logger.Warn("failed to claim webhook event", zap.String("id", e.ID), zap.Error(err))
+		return false
+	}
+	if !claimed {
+		return false
+	}
+	attempt := e.Attempts + 1
+
+	procErr := h.ProcessWebhookEvent(ctx, e)
+	if procErr == nil {
+		if err := p.repo.MarkWebhookEventProcessed(ctx, e.ID); err != nil {
+			// The update was applied; the stale claim is retried and the
+			// handler re-applies the same state
+			p.logger.Error("failed to mark webhook event processed", zap.String("id", e.ID), zap.Error(err))
+		}
+		return true
+	}
+
+	fields := []zap.Field{
+		zap.String("id", e.ID),
+		zap.String("provider", e.Provider),
+		zap.String("type", e.EventType),
+		zap.Int("attempt", attempt),
+		zap.Error(procErr),
+	}
+
+	if attempt >= int(p.getConfigNumber(ctx, "max_attempts", defaultMaxAttempts)) {
+		p.logger.Error("webhook event dead-lettered", fields...)
+		if err := p.repo.MarkWebhookEventDeadLetter(ctx, e.ID, procErr.Error()); err != nil {
+			p.logger.Error("failed to dead-letter webhook event", zap.String("id", e.ID), zap.Error(err))
+		}
+		return true
+	}
+
+	retryAt := time.Now().Add(p.backoff(ctx, attempt))
+	p.logger.Warn("webhook event processing failed, will retry", append(fields, zap.Time("retry_at", retryAt))...)
+	if err := p.repo.MarkWebhookEventFailed(ctx, e.ID, procErr.Error(), &retryAt); err != nil {
+		p.logger.Error("failed to mark webhook event failed", zap.String("id", e.ID), zap.Error(err))
+	}
+	return true
+}
+
+// backoff returns the delay before the retry following attempt: the base
+// delay doubled per attempt, capped at the maximum
+func (p *Processor) backoff(ctx context.Context, attempt int) time.Duration {
+	base := time.Duration(p.getConfigNumber(ctx, "retry_base_seconds", defaultRetryBaseSeconds)) * time.Second
+	max := time.Duration(p.getConfigNumber(ctx, "retry_max_seconds", defaultRetryMaxSeconds)) * time.Second
+	if base <= 0 {
+		base = defaultRetryBaseSeconds * time.Second
+	}
+
+	delay := base
+	for i := 1; i < attempt && delay < max; i++ {
+		delay *= 2
+	}
+	if max > 0 && delay > max {
+		delay = max
+	}
+	return delay
+}
+
+// getConfigNumber reads a webhooks config value with a code fallback
+func (p *Processor) getConfigNumber(ctx context.Context, key string, fallback int64) int64 {
+	if p.configRepo == nil {
+		return fallback
+	}
+	v, err := p.configRepo.GetNumber(ctx, "webhooks", key, 0)
+	if err != nil {
+		return fallback
+	}
+	return v
+}
@@ -0,0 +1,150 @@
+package webhooks_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/repository"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/storage/memory"
+	"github.com/colemanwhaylon/nexus-protocol/backend/internal/webhooks"
+)
+
+// stubHandler fails the first failures calls and records every event applied
+type stubHandler struct {
+	failures int
+	applied  []string
+}
+
+func (s *stubHandler) ProcessWebhookEvent(ctx context.Context, e *repository.WebhookEvent) error {
+	if s.failures > 0 {
+		s.failures--
+		return errors.New("database unavailable")
+	}
+	s.applied = append(s.applied, e.EventID)
+	return nil
+}
+
+func setNumber(t *testing.T, cfg *memory.MemoryAppConfigRepo, key string, v int64) {
+	t.Helper()
+	require.NoError(t, cfg.Create(context.Background(), &repository.AppConfigCreate{
+		Namespace:   "webhooks",
+		ConfigKey:   key,
+		ValueType:   "number",
+		ValueNumber: &v,
+	}))
+}
+
+func enqueue(t *testing.T, p *webhooks.Processor, eventID string) *repository.WebhookEvent {
+	t.Helper()
+	e := &repository.WebhookEvent{
+		Provider:  repository.WebhookProviderSumsub,
+		EventID:   eventID,
+		EventType: "applicantReviewed",
+		Payload:   []byte(`{}`),
+	}
+	created, err := p.Enqueue(context.Background(), e)
+	require.NoError(t, err)
+	require.True(t, created)
+	return e
+}
+
+func TestProcessor_ProcessesQueuedEventsOnce(t *testing.T) {
+	ctx := context.Background()
+	events := memory.NewMemoryWebhookEventRepo()
+	handler := &stubHandler{}
+	p := webhooks.NewProcessor(events, nil, zap.NewNop())
+	p.RegisterHandler(repository.WebhookProviderSumsub, handler)
+
+	e := enqueue(t, p, "evt-1")
+
+	// Redelivery is recognised and not queued again
+	created, err := p.Enqueue(ctx, &repository.WebhookEvent{Provider: repository.WebhookProviderSumsub, EventID: "evt-1"})
+	require.NoError(t, err)
+	assert.False(t, created)
+
+	assert.Equal(t, 1, p.RunOnce(ctx))
+	assert.Equal(t, 0, p.RunOnce(ctx))
+	assert.Equal(t, []string{"evt-1"}, handler.applied)
+
+	got, err := events.GetWebhookEvent(ctx, e.ID)
+	require.NoError(t, err)
+	assert.Equal(t, repository.WebhookEventProcessed, got.Status)
+	assert.Nil(t, got.NextAttemptAt)
+}
+
+func TestProcessor_RetriesWithBackoff(t *testing.T) {
+	ctx := context.Background()
+	events := memory.NewMemoryWebhookEventRepo()
+	handler := &stubHandler{failures: 1}
+	p := webhooks.NewProcessor(events, nil, zap.NewNop())
+	p.RegisterHandler(repository.WebhookProviderSumsub, handler)
+
+	e := enqueue(t, p, "evt-1")
+	before := time.Now()
+	assert.Equal(t, 1, p.RunOnce(ctx))
+
+	got, err := events.GetWebhookEvent(ctx, e.ID)
+	require.NoError(t, err)
+	assert.Equal(t, repository.WebhookEventFailed, got.Status)
+	require.NotNil(t, got.LastError)
+	assert.Contains(t, *got.LastError, "database unavailable")
+	require.NotNil(t, got.NextAttemptAt)
+	assert.WithinDuration(t, before.Add(30*time.Second), *got.NextAttemptAt, 5*time.Second)
+
+	// Not due yet
+	assert.Equal(t, 0, p.RunOnce(ctx))
+	assert.Empty(t, handler.applied)
+}
+
+func TestProcessor_DeadLettersAndReprocesses(t *testing.T) {
+	ctx := context.Background()
+	events := memory.NewMemoryWebhookEventRepo()
+	cfg := memory.NewMemoryAppConfigRepo()
+	setNumber(t, cfg, "max_attempts", 2)
+	handler := &stubHandler{failures: 2}
+	p := webhooks.NewProcessor(events, cfg, zap.NewNop())
+	p.RegisterHandler(repository.WebhookProviderSumsub, handler)
+
+	e := enqueue(t, p, "evt-1")
+	p.RunOnce(ctx)
+
+	got, err := p.Reprocess(ctx, e.ID)
+	require.NoError(t, err)
+	assert.Equal(t, repository.WebhookEventDeadLetter, got.Status)
+	assert.Equal(t, 2, got.Attempts)
+	assert.Nil(t, got.NextAttemptAt)
+
+	// Dead letters are not picked up in the background
+	assert.Equal(t, 0, p.RunOnce(ctx))
+
+	got, err = p.Reprocess(ctx, e.ID)
+	require.NoError(t, err)
+	assert.Equal(t, repository.WebhookEventProcessed, got.Status)
+	assert.Equal(t, 3, got.Attempts)
+	assert.Equal(t, []string{"evt-1"}, handler.applied)
+
+	_, err = p.Reprocess(ctx, e.ID)
+	assert.ErrorIs(t, err, repository.ErrWebhookEventNotRetryable)
+}
+
+func TestProcessor_ReprocessRequiresHandler(t *testing.T) {
+	ctx := context.Background()
+	events := memory.NewMemoryWebhookEventRepo()
+	p := webhooks.NewProcessor(events, nil, zap.NewNop())
+
+	e := &repository.WebhookEvent{Provider: repository.WebhookProviderStripe, EventID: "evt_1", Payload: []byte(`{}`)}
+	_, err := events.CreateWebhookEvent(ctx, e)
+	require.NoError(t, err)
+
+	_, err = p.Reprocess(ctx, e.ID)
+	assert.ErrorIs(t, err, webhooks.ErrNoHandler)
+
+	_, err = p.Reprocess(ctx, "00000000-0000-4000-8000-000000000000")
+	assert.ErrorIs(t, err, repository.ErrWebhookEventNotFound)
+}
@@ -4,6 +4,7 @@
 -- Enable required extensions
 CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
 CREATE EXTENSION IF NOT EXISTS "pgcrypto";
+CREATE EXTENSION IF NOT EXISTS "pg_trgm";
 
 -- ============================================
 -- Users and Permissions
@@ -202,7 +203,7 @@ CREATE INDEX idx_governance_config_history_changed_at ON governance_config_histo
 -- Uses namespace + key pattern for flexible configuration
 CREATE TABLE IF NOT EXISTS app_config (
     id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
-    namespace VARCHAR(50) NOT NULL,              -- 'nft', 'token', 'staking', 'kyc', 'relayer', 'api'
+    namespace VARCHAR(50) NOT NULL,              -- 'nft', 'token', 'staking', 'kyc', 'relayer', 'api', 'contracts', 'chain', 'request_log', 'retention', 'webhooks', 'maintenance', 'relay_pause', 'logging', 'relay_queue', 'gas_billing', 'userop', 'deposits', 'payment_expiry', 'disputes', 'anomaly', 'geo', 'payouts', 'snapshot', 'governance_notifications', 'oracle', 'ens', 'auth', 'partners'
     config_key VARCHAR(100) NOT NULL,            -- 'mint_price', 'max_supply', etc.
     value_type VARCHAR(20) NOT NULL,             -- 'string', 'number', 'wei', 'address', 'boolean', 'json'
 
@@ -284,6 +285,7 @@ CREATE TABLE IF NOT EXISTS nft_tokens (
 
 CREATE INDEX idx_nft_owner ON nft_tokens(owner);
 CREATE INDEX idx_nft_soulbound ON nft_tokens(soulbound);
+CREATE INDEX idx_nft_minted ON nft_tokens(minted_at);
 
 -- NFT approvals
 CREATE TABLE IF NOT EXISTS nft_approvals (
@@ -356,6 +358,7 @@ CREATE TABLE IF NOT EXISTS compliance_officers (
 );
 
 -- Audit log
+-- Read newest first by keyset on (timestamp, id), optionally for one subject
 CREATE TABLE IF NOT EXISTS audit_log (
     id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
     action VARCHAR(50) NOT NULL,
@@ -363,15 +366,16 @@ CREATE TABLE IF NOT EXISTS audit_log (
     subject VARCHAR(42),
     details TEXT,
     ip_address INET,
+    country VARCHAR(2),                           -- of ip_address, when GeoIP is configured
     previous_state TEXT,
     new_state TEXT,
     timestamp TIMESTAMPTZ NOT NULL DEFAULT NOW()
 );
 
 CREATE INDEX idx_audit_actor ON audit_log(actor);
-CREATE INDEX idx_audit_subject ON audit_log(subject);
+CREATE INDEX idx_audit_subject ON audit_log(subject, timestamp DESC, id DESC);
 CREATE INDEX idx_audit_action ON audit_log(action);
-CREATE INDEX idx_audit_timestamp ON audit_log(timestamp);
+CREATE INDEX idx_audit_timestamp ON audit_log(timestamp DESC, id DESC);
 
 -- ============================================
 -- Jurisdictions (reference data)
@@ -433,7 +437,8 @@ CREATE TABLE IF NOT EXISTS pricing (
     -- Audit
     created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
     updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
-    updated_by VARCHAR(42)  -- Admin address who made change
+    updated_by VARCHAR(42),  -- Admin address who made change
+    version BIGINT NOT NULL DEFAULT 1  -- optimistic concurrency, incremented by every update
 );
 
 CREATE INDEX idx_pricing_service_code ON pricing(service_code);
@@ -481,7 +486,8 @@ CREATE TABLE IF NOT EXISTS payment_methods (
     fee_percent DECIMAL(5,2) DEFAULT 0,  -- Payment processor fee
     display_order SMALLINT DEFAULT 0,
     created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
-    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    version BIGINT NOT NULL DEFAULT 1  -- optimistic concurrency, incremented by every update
 );
 
 CREATE INDEX idx_payment_methods_code ON payment_methods(method_code);
@@ -520,8 +526,25 @@ CREATE TABLE IF NOT EXISTS payments (
     updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
     completed_at TIMESTAMPTZ,
 
+    -- Status notifications to the originating dapp (secret signs them;
+    -- encrypted when FIELD_ENCRYPTION_KEYS is set)
+    callback_url TEXT,
+    callback_secret TEXT,
+
+    -- Stripe dispute (chargeback) on the charge, if any
+    dispute_id VARCHAR(100),
+    dispute_status VARCHAR(30),  -- Stripe's: 'needs_response', 'under_review', 'won', 'lost', ...
+    dispute_reason VARCHAR(50),
+    disputed_at TIMESTAMPTZ,
+
+    -- ISO 3166-1 alpha-2 country of the payer's IP (GeoIP), if known
+    country CHAR(2),
+
+    -- Partner referral code given at checkout (see partners)
+    referrer_code VARCHAR(32),
+
     -- Constraints
-    CONSTRAINT valid_payment_status CHECK (status IN ('pending', 'processing', 'completed', 'failed', 'refunded', 'cancelled'))
+    CONSTRAINT valid_payment_status CHECK (status IN ('pending', 'processing', 'completed', 'failed', 'refunded', 'cancelled', 'expired', 'disputed'))
 );
 
 CREATE INDEX idx_payments_payer ON payments(payer_address);
@@ -529,6 +552,7 @@ CREATE INDEX idx_payments_status ON payments(status);
 CREATE INDEX idx_payments_service ON payments(service_code);
 CREATE INDEX idx_payments_created ON payments(created_at);
 CREATE INDEX idx_payments_stripe_session ON payments(stripe_session_id);
+CREATE INDEX idx_payments_stripe_payment ON payments(stripe_payment_id);
 
 -- KYC verification requests (links payment to Sumsub verification)
 CREATE TABLE IF NOT EXISTS kyc_verifications (
@@ -540,9 +564,11 @@ CREATE TABLE IF NOT EXISTS kyc_verifications (
     -- User info
     user_address VARCHAR(42) NOT NULL,
 
-    -- Sumsub data
-    sumsub_applicant_id VARCHAR(100),
-    sumsub_inspection_id VARCHAR(100),
+    -- Sumsub data. The IDs and review result are encrypted by the backend
+    -- when FIELD_ENCRYPTION_KEYS is set ("enc:v1:<key id>:..." values; the
+    -- review result is then a JSON string)
+    sumsub_applicant_id TEXT,
+    sumsub_inspection_id TEXT,
     sumsub_review_status VARCHAR(50),  -- 'init', 'pending', 'completed', etc.
     sumsub_review_result JSONB,  -- Full Sumsub response
 
@@ -560,7 +586,7 @@ CREATE TABLE IF NOT EXISTS kyc_verifications (
     rejected_at TIMESTAMPTZ,   -- When rejected
 
     -- Constraints
-    CONSTRAINT valid_kyc_status CHECK (status IN ('pending', 'payment_required', 'submitted', 'in_review', 'approved', 'rejected', 'expired'))
+    CONSTRAINT valid_kyc_status CHECK (status IN ('pending', 'payment_required', 'submitted', 'in_review', 'approved', 'rejected', 'expired', 'suspended'))
 );
 
 CREATE INDEX idx_kyc_verifications_user ON kyc_verifications(user_address);
@@ -800,7 +826,9 @@ INSERT INTO pricing (service_code, service_name, description, cost_usd, cost_pro
     -- Premium features: $10/month
     ('premium_monthly', 'Premium Features (Monthly)', 'Monthly subscription for premium platform features', 2.00, 'platform', 10.00, 0.00333, 100, 400.00, true),
     -- Governance proposal fee (existing)
-    ('governance_proposal', 'Governance Proposal Fee', 'Fee for submitting governance proposals (refundable if passed)', 0, 'platform', 10.00, 0.00333, 100, 0, true)
+    ('governance_proposal', 'Governance Proposal Fee', 'Fee for submitting governance proposals (refundable if passed)', 0, 'platform', 10.00, 0.00333, 100, 0, true),
+    -- Prepaid credits: 10 KYC verifications for $135 (10% off)
+    ('kyc_verification_10', 'KYC Verification Credits (10)', 'Ten prepaid KYC identity verifications, spent one per check', 50.00, 'sumsub', 135.00, 0.045, 1350, 170.00, true)
 ON CONFLICT (service_code) DO NOTHING;
 
 -- Insert governance config seed data for localhost (31337)
@@ -882,6 +910,10 @@ INSERT INTO app_config (namespace, config_key, value_type, value_number, descrip
     ('relayer', 'tx_timeout_seconds', 'number', 120, 'Transaction confirmation timeout in seconds', 0)
 ON CONFLICT ON CONSTRAINT app_config_namespace_key_chain_unique DO NOTHING;
 
+INSERT INTO app_config (namespace, config_key, value_type, value_string, description, chain_id) VALUES
+    ('relayer', 'kyc_requirements', 'json', '{}', 'Minimum KYC level of the signer by relay target (JSON object of contract db_name or address -> level 1-3)', 0)
+ON CONFLICT ON CONSTRAINT app_config_namespace_key_chain_unique DO NOTHING;
+
 -- API Configuration
 INSERT INTO app_config (namespace, config_key, value_type, value_string, description, chain_id) VALUES
     ('api', 'metadata_base_url', 'string', 'https://nexus.dapp.academy', 'Base URL for metadata and API', 0),
@@ -893,10 +925,158 @@ INSERT INTO app_config (namespace, config_key, value_type, value_number, descrip
     ('api', 'max_page_size', 'number', 100, 'Maximum page size for paginated endpoints', 0)
 ON CONFLICT ON CONSTRAINT app_config_namespace_key_chain_unique DO NOTHING;
 
+-- Contract Call Proxy Configuration
+INSERT INTO app_config (namespace, config_key, value_type, value_string, description, chain_id) VALUES
+    ('contracts', 'call_allowlist', 'json', '{"nexusToken": ["name", "symbol", "decimals", "totalSupply", "balanceOf", "allowance", "getVotes", "delegates"], "nexusStaking": ["getVotingPower", "getPendingUnbonding", "getWithdrawableUnbonding", "canInitiateUnbonding", "getEpochInfo"], "nexusNFT": ["name", "symbol", "totalSupply", "balanceOf", "ownerOf", "tokenURI", "remainingSupply", "getMintInfo"], "nexusKYC": ["isCompliant", "getKYCLevel", "isWhitelisted", "isBlacklisted", "isKYCExpired"], "nexusGovernor": ["state", "proposalThreshold", "quorum", "votingDelay", "votingPeriod", "canPropose"], "nexusForwarder": ["getNonce", "isTargetAllowed", "paused"]}', 'View methods callable via POST /api/v1/contracts/:chainId/:name/call (JSON object of contract -> methods)', 0)
+ON CONFLICT ON CONSTRAINT app_config_namespace_key_chain_unique DO NOTHING;
+
+-- Chain Confirmation Configuration
+INSERT INTO app_config (namespace, config_key, value_type, value_number, description, chain_id) VALUES
+    ('chain', 'confirmation_depth', 'number', 12, 'Blocks required before a tracked tx is treated as final', 0),
+    ('chain', 'confirmation_depth', 'number', 1, 'Blocks required before a tracked tx is treated as final (Anvil)', 31337),
+    ('chain', 'poll_interval_seconds', 'number', 15, 'Seconds between confirmation tracker polls', 0),
+    ('chain', 'reorg_window_blocks', 'number', 128, 'Recent block hashes retained for reorg detection', 0)
+ON CONFLICT ON CONSTRAINT app_config_namespace_key_chain_unique DO NOTHING;
+
+-- Request Log Archive Configuration
+INSERT INTO app_config (namespace, config_key, value_type, value_number, description, chain_id) VALUES
+    ('request_log', 'retention_days', 'number', 30, 'Days archived request logs are kept before deletion', 0),
+    ('request_log', 'max_body_bytes', 'number', 16384, 'Maximum request/response body bytes archived per request', 0)
+ON CONFLICT ON CONSTRAINT app_config_namespace_key_chain_unique DO NOTHING;
+
+-- Payment & Meta-Transaction Retention Configuration
+INSERT INTO app_config (namespace, config_key, value_type, value_number, description, chain_id) VALUES
+    ('retention', 'payments_months', 'number', 24, 'Months before settled payments move to payments_archive (0 disables)', 0),
+    ('retention', 'meta_tx_months', 'number', 6, 'Months before finished meta-transactions move to meta_transactions_archive (0 disables)', 0),
+    ('retention', 'batch_size', 'number', 1000, 'Rows moved per archive statement', 0)
+ON CONFLICT ON CONSTRAINT app_config_namespace_key_chain_unique DO NOTHING;
+
+-- Background Webhook Processing Configuration
+INSERT INTO app_config (namespace, config_key, value_type, value_number, description, chain_id) VALUES
+    ('webhooks', 'max_attempts', 'number', 8, 'Processing attempts before a webhook event is dead-lettered', 0),
+    ('webhooks', 'retry_base_seconds', 'number', 30, 'Delay before the first retry; doubles on each further attempt', 0),
+    ('webhooks', 'retry_max_seconds', 'number', 3600, 'Upper bound on the delay between retries', 0)
+ON CONFLICT ON CONSTRAINT app_config_namespace_key_chain_unique DO NOTHING;
+
+-- Maintenance Mode (toggled via PUT /api/v1/admin/maintenance)
+INSERT INTO app_config (namespace, config_key, value_type, value_boolean, value_string, description, chain_id) VALUES
+    ('maintenance', 'enabled', 'boolean', FALSE, NULL, 'Return 503 for non-admin API routes', 0),
+    ('maintenance', 'message', 'string', NULL, 'The service is down for scheduled maintenance. Please try again shortly.', 'Message returned while in maintenance mode', 0)
+ON CONFLICT ON CONSTRAINT app_config_namespace_key_chain_unique DO NOTHING;
+
+-- Relay Pause (toggled via POST /api/v1/admin/relay/pause and /resume)
+INSERT INTO app_config (namespace, config_key, value_type, value_boolean, value_string, description, chain_id) VALUES
+    ('relay_pause', 'enabled', 'boolean', FALSE, NULL, 'Return 503 for new meta-transaction relays', 0),
+    ('relay_pause', 'message', 'string', NULL, 'Relaying is paused. Please try again later.', 'Reason returned while relaying is paused', 0)
+ON CONFLICT ON CONSTRAINT app_config_namespace_key_chain_unique DO NOTHING;
+
+-- Logging (changed via PUT /api/v1/admin/logging; no level row means LOG_LEVEL)
+INSERT INTO app_config (namespace, config_key, value_type, value_boolean, value_string, description, chain_id) VALUES
+    ('logging', 'sampling_enabled', 'boolean', FALSE, NULL, 'Sample repeated log entries', 0),
+    ('logging', 'module_levels', 'string', NULL, '', 'Per-module log levels (module=level, comma separated)', 0)
+ON CONFLICT ON CONSTRAINT app_config_namespace_key_chain_unique DO NOTHING;
+
+-- Relay Queue (priority classes; low priority relays held during gas spikes)
+INSERT INTO app_config (namespace, config_key, value_type, value_number, value_string, description, chain_id) VALUES
+    ('relay_queue', 'contract_priorities', 'json', NULL, '{"nexusKYC": "high"}', 'Relay priority class by target contract (JSON object of contract -> high/normal/low; others are normal)', 0),
+    ('relay_queue', 'defer_low_priority_above_gwei', 'number', 0, NULL, 'Hold low priority relays while the gas price is above this (0 = never)', 0)
+ON CONFLICT ON CONSTRAINT app_config_namespace_key_chain_unique DO NOTHING;
+
+-- Sponsored-Gas Billing (relayed gas charged back to users in NEXUS)
+INSERT INTO app_config (namespace, config_key, value_type, value_boolean, value_number, value_wei, value_string, description, chain_id) VALUES
+    ('gas_billing', 'enabled', 'boolean', FALSE, NULL, NULL, NULL, 'Charge the gas of relayed meta-transactions to their signers in NEXUS', 0),
+    ('gas_billing', 'nexus_per_eth', 'wei', NULL, NULL, 0, NULL, 'Fixed-rate price oracle: NEXUS base units per 1 ETH (0 disables charging)', 0),
+    ('gas_billing', 'markup_percent', 'number', NULL, 0, NULL, NULL, 'Markup added to the converted gas cost, in percent', 0),
+    ('gas_billing', 'max_outstanding', 'wei', NULL, NULL, 0, NULL, 'Refuse to relay for users owing more than this many NEXUS base units (0 = no limit)', 0),
+    ('gas_billing', 'treasury_address', 'address', NULL, NULL, NULL, NULL, 'Recipient of on-chain gas debt settlements', 0)
+ON CONFLICT ON CONSTRAINT app_config_namespace_key_chain_unique DO NOTHING;
+
+-- Deposit-Address Crypto Payments (enabled by the DEPOSIT_WALLET_SEED secret)
+INSERT INTO app_config (namespace, config_key, value_type, value_number, value_string, description, chain_id) VALUES
+    ('deposits', 'expiry_minutes', 'number', 60, NULL, 'Minutes a deposit address waits for its transfer before the payment expires', 0),
+    ('deposits', 'tolerance_percent', 'number', 1, NULL, 'Accept deposits short of the expected amount by up to this percent', 0),
+    ('deposits', 'sweep_retry_minutes', 'number', 10, NULL, 'Minutes to wait for a sweep transaction before sending another', 0),
+    ('deposits', 'treasury_address', 'address', NULL, NULL, 'Recipient of swept deposits (unset = funds stay on the deposit addresses)', 0)
+ON CONFLICT ON CONSTRAINT app_config_namespace_key_chain_unique DO NOTHING;
+
+-- Payment Expiry (pending payments left unpaid past their window become 'expired')
+INSERT INTO app_config (namespace, config_key, value_type, value_number, description, chain_id) VALUES
+    ('payment_expiry', 'stripe_minutes', 'number', 1440, 'Minutes a Stripe checkout may stay unpaid before its payment expires (0 disables)', 0),
+    ('payment_expiry', 'crypto_minutes', 'number', 1440, 'Minutes an ETH/NEXUS payment may stay pending before it expires; keep above deposits.expiry_minutes (0 disables)', 0),
+    ('payment_expiry', 'batch_size', 'number', 500, 'Payments expired per statement', 0)
+ON CONFLICT ON CONSTRAINT app_config_namespace_key_chain_unique DO NOTHING;
+
+-- Stripe Disputes (alerts are signed with the COMPLIANCE_WEBHOOK_SECRET secret)
+INSERT INTO app_config (namespace, config_key, value_type, value_string, description, chain_id) VALUES
+    ('disputes', 'compliance_webhook_url', 'string', NULL, 'Receives a signed alert when a payment is disputed or its dispute closes (unset = alerts are only logged)', 0)
+ON CONFLICT ON CONSTRAINT app_config_namespace_key_chain_unique DO NOTHING;
+
+-- Geo-blocking (with GEOIP_DB set: KYC and payment requests from these countries get 451)
+INSERT INTO app_config (namespace, config_key, value_type, value_string, value_boolean, description, chain_id) VALUES
+    ('geo', 'block_enabled', 'boolean', NULL, FALSE, 'Reject KYC and payment requests from the blocked countries', 0),
+    ('geo', 'blocked_countries', 'string', 'CU,IR,KP,SY', NULL, 'Comma-separated ISO 3166-1 alpha-2 codes of restricted jurisdictions', 0),
+    ('geo', 'block_unknown', 'boolean', NULL, FALSE, 'Also reject requests whose IP has no country in the GeoIP database', 0)
+ON CONFLICT ON CONSTRAINT app_config_namespace_key_chain_unique DO NOTHING;
+
+-- Anomaly Detection (new compliance cases alert disputes.compliance_webhook_url)
+INSERT INTO app_config (namespace, config_key, value_type, value_number, value_boolean, description, chain_id) VALUES
+    ('anomaly', 'enabled', 'boolean', NULL, TRUE, 'Open compliance cases for unusual payment and relay activity', 0),
+    ('anomaly', 'relay_failures', 'number', 10, NULL, 'Failed relays from one sender within relay_failure_minutes that open a case', 0),
+    ('anomaly', 'relay_failure_minutes', 'number', 60, NULL, 'Window for the failed relay rule', 0),
+    ('anomaly', 'rapid_payments', 'number', 20, NULL, 'Payments from one payer within rapid_payment_minutes that open a case', 0),
+    ('anomaly', 'rapid_payment_minutes', 'number', 10, NULL, 'Window for the rapid payment rule', 0),
+    ('anomaly', 'baseline_hours', 'number', 168, NULL, 'Hours of history the hourly payment and relay volume is compared with', 0),
+    ('anomaly', 'spike_sigmas', 'number', 4, NULL, 'Standard deviations above the baseline mean that make an hour a volume spike', 0),
+    ('anomaly', 'spike_minimum', 'number', 50, NULL, 'Hourly payments or relays below which no volume spike is flagged', 0)
+ON CONFLICT ON CONSTRAINT app_config_namespace_key_chain_unique DO NOTHING;
+
+-- Payouts (royalties and governance grants sent from the relayer keys)
+INSERT INTO app_config (namespace, config_key, value_type, value_boolean, value_string, description, chain_id) VALUES
+    ('payouts', 'enabled', 'boolean', FALSE, NULL, 'Send approved payouts from the relayer keys when they fall due (the keys must hold the funds)', 0),
+    ('payouts', 'royalty_receiver', 'address', NULL, NULL, 'Recipient of royalty payouts created without one: the NFT royaltyReceiver', 0)
+ON CONFLICT ON CONSTRAINT app_config_namespace_key_chain_unique DO NOTHING;
+
+-- Snapshot (off-chain votes mirrored from the Snapshot hub GraphQL API)
+INSERT INTO app_config (namespace, config_key, value_type, value_boolean, value_string, description, chain_id) VALUES
+    ('snapshot', 'enabled', 'boolean', FALSE, NULL, 'Mirror the space''s Snapshot proposals and results in the background', 0),
+    ('snapshot', 'space', 'string', NULL, NULL, 'Snapshot space ID to mirror, e.g. nexusprotocol.eth', 0),
+    ('snapshot', 'hub_url', 'string', NULL, 'https://hub.snapshot.org/graphql', 'Snapshot hub GraphQL endpoint', 0)
+ON CONFLICT ON CONSTRAINT app_config_namespace_key_chain_unique DO NOTHING;
+
+-- Governance Notifications (signed with each subscriber's own secret)
+INSERT INTO app_config (namespace, config_key, value_type, value_boolean, value_number, description, chain_id) VALUES
+    ('governance_notifications', 'enabled', 'boolean', FALSE, NULL, 'Notify subscribed token holders of proposal lifecycle events', 0),
+    ('governance_notifications', 'near_quorum_percent', 'number', NULL, 80, 'Percent of quorum at which subscribers are told a proposal is near quorum', 0)
+ON CONFLICT ON CONSTRAINT app_config_namespace_key_chain_unique DO NOTHING;
+
+-- Chainlink Price Feeds (aggregators registered as ethUsdPriceFeed and nexusUsdPriceFeed)
+INSERT INTO app_config (namespace, config_key, value_type, value_number, description, chain_id) VALUES
+    ('oracle', 'cache_seconds', 'number', 30, 'Seconds a price feed round is served from cache before it is read again', 0),
+    ('oracle', 'max_age_seconds', 'number', 3600, 'Reject price feed rounds last updated longer ago than this', 0)
+ON CONFLICT ON CONSTRAINT app_config_namespace_key_chain_unique DO NOTHING;
+
+-- ENS Resolution (GET /api/v1/resolve/:name and ?names=true on responses)
+INSERT INTO app_config (namespace, config_key, value_type, value_number, value_string, description, chain_id) VALUES
+    ('ens', 'chain_id', 'number', 1, NULL, 'Chain holding the ENS registry', 0),
+    ('ens', 'registry_address', 'address', NULL, '0x00000000000C2E074eC69A0dFb2997BA6C7d2e1e', 'ENS registry contract', 0),
+    ('ens', 'cache_seconds', 'number', 900, NULL, 'Seconds ENS lookups (misses included) are cached', 0)
+ON CONFLICT ON CONSTRAINT app_config_namespace_key_chain_unique DO NOTHING;
+
+-- ERC-4337 UserOperation Forwarding (paymaster policy; the fee cap is relayer.max_gas_price_gwei)
+INSERT INTO app_config (namespace, config_key, value_type, value_boolean, value_number, value_string, description, chain_id) VALUES
+    ('userop', 'enabled', 'boolean', FALSE, NULL, NULL, 'Accept UserOperations at POST /api/v1/userops and forward them to BUNDLER_URL', 0),
+    ('userop', 'entry_point', 'address', NULL, NULL, '0x5FF137D4b0FDCD49DcA30c7CF57E578a026d2789', 'EntryPoint v0.6 the bundler submits to', 0),
+    ('userop', 'paymaster_address', 'address', NULL, NULL, NULL, 'Paymaster that must sponsor forwarded operations', 0),
+    ('userop', 'max_call_gas_limit', 'number', NULL, 2000000, NULL, 'Maximum callGasLimit (0 = no limit)', 0),
+    ('userop', 'max_verification_gas_limit', 'number', NULL, 1000000, NULL, 'Maximum verificationGasLimit (0 = no limit)', 0),
+    ('userop', 'max_pre_verification_gas', 'number', NULL, 200000, NULL, 'Maximum preVerificationGas (0 = no limit)', 0)
+ON CONFLICT ON CONSTRAINT app_config_namespace_key_chain_unique DO NOTHING;
+
 -- KYC Configuration
 INSERT INTO app_config (namespace, config_key, value_type, value_number, description, chain_id) VALUES
     ('kyc', 'verification_expiry_days', 'number', 365, 'Days until KYC verification expires', 0),
-    ('kyc', 'max_daily_verifications', 'number', 1000, 'Maximum KYC verifications per day', 0)
+    ('kyc', 'max_daily_verifications', 'number', 1000, 'Maximum KYC verifications per day', 0),
+    ('kyc', 'approved_level', 'number', 2, 'KYC level of an approved Sumsub verification, checked against relayer.kyc_requirements', 0)
 ON CONFLICT ON CONSTRAINT app_config_namespace_key_chain_unique DO NOTHING;
 
 INSERT INTO app_config (namespace, config_key, value_type, value_boolean, description, chain_id) VALUES
@@ -935,7 +1115,7 @@ CREATE TABLE IF NOT EXISTS meta_transactions (
     tx_hash VARCHAR(66),                     -- Relayed transaction hash
     gas_used BIGINT,                         -- Actual gas used
     gas_price NUMERIC(78, 0),                -- Gas price paid
-    relay_cost_eth NUMERIC(18, 18),          -- Cost to relayer in ETH
+    relay_cost_eth NUMERIC(38, 18),          -- Cost to relayer in ETH
 
     -- Error tracking
     error_message TEXT,
@@ -955,6 +1135,7 @@ CREATE INDEX idx_meta_tx_from ON meta_transactions(from_address);
 CREATE INDEX idx_meta_tx_status ON meta_transactions(status);
 CREATE INDEX idx_meta_tx_tx_hash ON meta_transactions(tx_hash);
 CREATE INDEX idx_meta_tx_created ON meta_transactions(created_at);
+CREATE INDEX idx_meta_tx_confirmed ON meta_transactions(confirmed_at);
 
 -- Add trigger for updated_at
 CREATE TRIGGER update_meta_transactions_updated_at
@@ -962,6 +1143,767 @@ CREATE TRIGGER update_meta_transactions_updated_at
     FOR EACH ROW
     EXECUTE FUNCTION update_updated_at_column();
 
+-- ============================================
+-- Chain Tracking (Confirmations & Reorgs)
+-- ============================================
+
+-- Recent canonical block hashes per chain, used to detect reorgs
+CREATE TABLE IF NOT EXISTS chain_blocks (
+    chain_id BIGINT NOT NULL,
+    block_number BIGINT NOT NULL,
+    block_hash VARCHAR(66) NOT NULL,
+    parent_hash VARCHAR(66) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (chain_id, block_number)
+);
+
+-- Transactions backing application records (payments, meta-txs) awaiting
+-- confirmation depth. Rolled back to pending when their block is reorged out.
+CREATE TABLE IF NOT EXISTS chain_tx_tracking (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    chain_id BIGINT NOT NULL,
+    tx_hash VARCHAR(66) NOT NULL,
+    record_type VARCHAR(20) NOT NULL,          -- 'payment', 'meta_tx', 'gas_settlement', 'payout', 'safe_tx'
+    record_id VARCHAR(100) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    block_number BIGINT,
+    block_hash VARCHAR(66),
+    confirmations INT NOT NULL DEFAULT 0,
+    reorg_count INT NOT NULL DEFAULT 0,
+
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    finalized_at TIMESTAMPTZ,
+
+    CONSTRAINT chain_tx_tracking_record_unique UNIQUE (chain_id, tx_hash, record_type, record_id),
+    CONSTRAINT valid_tracked_tx_status CHECK (status IN ('pending', 'included', 'confirmed', 'failed'))
+);
+
+CREATE INDEX idx_chain_tx_tracking_status ON chain_tx_tracking(chain_id, status);
+CREATE INDEX idx_chain_tx_tracking_block ON chain_tx_tracking(chain_id, block_number);
+CREATE INDEX idx_chain_tx_tracking_record ON chain_tx_tracking(record_type, record_id);
+
+CREATE TRIGGER update_chain_tx_tracking_updated_at
+    BEFORE UPDATE ON chain_tx_tracking
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- ============================================
+-- Request Log Archive
+-- ============================================
+
+-- Scrubbed request/response pairs for support investigations. Signatures,
+-- tokens, document hashes and credentials are redacted before insert.
+CREATE TABLE IF NOT EXISTS request_logs (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    request_id VARCHAR(64) NOT NULL,
+    method VARCHAR(10) NOT NULL,
+    path VARCHAR(500) NOT NULL,
+    route VARCHAR(200) NOT NULL DEFAULT '',    -- Gin route pattern
+    query TEXT NOT NULL DEFAULT '',
+    status_code INT NOT NULL,
+    latency_ms BIGINT NOT NULL DEFAULT 0,
+    client_ip VARCHAR(45) NOT NULL DEFAULT '',
+    user_agent TEXT NOT NULL DEFAULT '',
+    request_headers JSONB NOT NULL DEFAULT '{}',
+    request_body TEXT,
+    response_body TEXT,
+    error TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_request_logs_created_at ON request_logs(created_at);
+CREATE INDEX idx_request_logs_request_id ON request_logs(request_id);
+CREATE INDEX idx_request_logs_path ON request_logs(path varchar_pattern_ops);
+CREATE INDEX idx_request_logs_status ON request_logs(status_code, created_at);
+
+-- ============================================
+-- Payment & Meta-Transaction Archive
+-- ============================================
+
+-- Settled payments and finished meta-transactions older than the retention
+-- window (app_config namespace 'retention') are moved here by the archiver.
+-- No foreign keys: the live rows they referenced may since have been removed.
+CREATE TABLE IF NOT EXISTS payments_archive (
+    LIKE payments INCLUDING DEFAULTS,
+    archived_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (id)
+);
+
+CREATE INDEX idx_payments_archive_payer ON payments_archive(payer_address);
+CREATE INDEX idx_payments_archive_created ON payments_archive(created_at);
+
+CREATE TABLE IF NOT EXISTS meta_transactions_archive (
+    LIKE meta_transactions INCLUDING DEFAULTS,
+    archived_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (id)
+);
+
+CREATE INDEX idx_meta_tx_archive_from ON meta_transactions_archive(from_address);
+CREATE INDEX idx_meta_tx_archive_created ON meta_transactions_archive(created_at);
+
+-- ============================================
+-- Admin Search
+-- ============================================
+
+-- Trigram indexes serve the partial-match (ILIKE '%term%') lookups behind
+-- GET /api/v1/admin/search; the full-text index covers the Sumsub review
+-- payload, which is where applicant emails and names live.
+CREATE INDEX idx_payments_payer_trgm ON payments USING GIN (payer_address gin_trgm_ops);
+CREATE INDEX idx_payments_tx_hash_trgm ON payments USING GIN (tx_hash gin_trgm_ops);
+CREATE INDEX idx_payments_stripe_session_trgm ON payments USING GIN (stripe_session_id gin_trgm_ops);
+CREATE INDEX idx_payments_stripe_payment_trgm ON payments USING GIN (stripe_payment_id gin_trgm_ops);
+
+CREATE INDEX idx_kyc_verifications_user_trgm ON kyc_verifications USING GIN (user_address gin_trgm_ops);
+CREATE INDEX idx_kyc_verifications_applicant_trgm ON kyc_verifications USING GIN (sumsub_applicant_id gin_trgm_ops);
+CREATE INDEX idx_kyc_verifications_whitelist_tx_trgm ON kyc_verifications USING GIN (whitelist_tx_hash gin_trgm_ops);
+CREATE INDEX idx_kyc_verifications_review_fts ON kyc_verifications
+    USING GIN (to_tsvector('simple', COALESCE(sumsub_review_result, '{}'::jsonb)));
+
+CREATE INDEX idx_meta_tx_from_trgm ON meta_transactions USING GIN (from_address gin_trgm_ops);
+CREATE INDEX idx_meta_tx_to_trgm ON meta_transactions USING GIN (to_address gin_trgm_ops);
+CREATE INDEX idx_meta_tx_tx_hash_trgm ON meta_transactions USING GIN (tx_hash gin_trgm_ops);
+
+-- ============================================
+-- Inbound Webhook Events
+-- ============================================
+
+-- Verified provider deliveries, stored before processing. The unique
+-- (provider, event_id) key makes redelivered events no-ops once processed;
+-- failed events keep their payload so an admin can replay them. Events
+-- processed in the background (sumsub) are retried with backoff via
+-- next_attempt_at and parked as dead_letter once retries are exhausted.
+CREATE TABLE IF NOT EXISTS webhook_events (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    provider VARCHAR(20) NOT NULL,             -- stripe, sumsub; payment_callback, compliance_alert, governance_alert, contract_change, watchlist_alert (outbound); fulfillment (internal)
+    event_id VARCHAR(255) NOT NULL,            -- Provider event ID (evt_...)
+    event_type VARCHAR(100) NOT NULL,
+    payload TEXT NOT NULL,                     -- Raw body as received
+    status VARCHAR(20) NOT NULL DEFAULT 'received',
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    processed_at TIMESTAMPTZ,
+    next_attempt_at TIMESTAMPTZ DEFAULT NOW(),  -- NULL when no background attempt is scheduled
+
+    CONSTRAINT webhook_events_provider_event_unique UNIQUE (provider, event_id),
+    CONSTRAINT valid_webhook_event_status CHECK (status IN ('received', 'processing', 'processed', 'failed', 'dead_letter'))
+);
+
+CREATE INDEX idx_webhook_events_status ON webhook_events(provider, status, created_at);
+CREATE INDEX idx_webhook_events_due ON webhook_events(provider, next_attempt_at)
+    WHERE status IN ('received', 'failed');
+
+-- ============================================
+-- Sponsored-Gas Billing
+-- ============================================
+
+-- With app_config gas_billing.enabled, the gas of every mined meta-transaction
+-- is converted to NEXUS at the price oracle's rate and charged to the signer.
+-- Amounts are NEXUS base units (18 decimals).
+CREATE TABLE IF NOT EXISTS gas_charges (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    meta_tx_id UUID NOT NULL,                  -- No FK: meta-transactions are archived
+    user_address VARCHAR(42) NOT NULL,         -- Signer of the meta-transaction
+    gas_used BIGINT NOT NULL,
+    gas_price NUMERIC(78, 0) NOT NULL,         -- Effective gas price in wei
+    cost_wei NUMERIC(78, 0) NOT NULL,          -- gas_used * gas_price
+    nexus_per_eth NUMERIC(78, 0) NOT NULL,     -- Oracle rate applied, NEXUS base units per ETH
+    markup_percent INT NOT NULL DEFAULT 0,
+    amount_nexus NUMERIC(78, 0) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT gas_charges_meta_tx_unique UNIQUE (meta_tx_id)
+);
+
+CREATE INDEX idx_gas_charges_user ON gas_charges(user_address, created_at);
+
+-- Payments against gas debt: NEXUS transfers to the treasury (verified when
+-- the tx is confirmed) or invoices settled off-chain
+CREATE TABLE IF NOT EXISTS gas_settlements (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_address VARCHAR(42) NOT NULL,
+    method VARCHAR(20) NOT NULL,               -- onchain, invoice
+    amount_nexus NUMERIC(78, 0) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    tx_hash VARCHAR(66),                       -- onchain: the NEXUS transfer
+    token_address VARCHAR(42),                 -- onchain: token the transfer must use
+    treasury_address VARCHAR(42),              -- onchain: recipient the transfer must pay
+    invoice_ref VARCHAR(100),                  -- invoice: external invoice number
+    error_message TEXT,
+    created_by VARCHAR(100),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    settled_at TIMESTAMPTZ,
+
+    CONSTRAINT gas_settlements_tx_hash_unique UNIQUE (tx_hash),
+    CONSTRAINT valid_gas_settlement_method CHECK (method IN ('onchain', 'invoice')),
+    CONSTRAINT valid_gas_settlement_status CHECK (status IN ('pending', 'invoiced', 'settled', 'failed', 'void')),
+    CONSTRAINT positive_gas_settlement_amount CHECK (amount_nexus > 0)
+);
+
+CREATE INDEX idx_gas_settlements_user ON gas_settlements(user_address, created_at);
+
+-- ============================================
+-- Deposit-Address Crypto Payments
+-- ============================================
+
+-- Each intent is paid by a plain transfer to its own address, derived from
+-- the deposit HD wallet (DEPOSIT_WALLET_SEED) at m/44'/60'/1'/0/<index>.
+-- The watcher confirms the payment once the balance reaches the expected
+-- amount at confirmation depth; the sweeper then moves it to the treasury.
+-- Amounts are base units (wei, or NEXUS with 18 decimals).
+CREATE SEQUENCE IF NOT EXISTS deposit_derivation_index MINVALUE 0 START WITH 0;
+
+CREATE TABLE IF NOT EXISTS deposit_intents (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    payment_id UUID NOT NULL,                  -- No FK: payments are archived
+    payer_address VARCHAR(42) NOT NULL,
+    currency VARCHAR(10) NOT NULL,             -- ETH, NEXUS
+    token_address VARCHAR(42),                 -- NEXUS: token the transfer must use
+    deposit_address VARCHAR(42) NOT NULL,
+    derivation_index BIGINT NOT NULL,
+    expected_amount NUMERIC(78, 0) NOT NULL,
+    received_amount NUMERIC(78, 0) NOT NULL DEFAULT 0,  -- Last balance observed
+    status VARCHAR(20) NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    detected_at TIMESTAMPTZ,                   -- First seen holding enough
+    paid_at TIMESTAMPTZ,
+    sweep_tx_hash VARCHAR(66),                 -- Last sweep (or gas top-up) transaction
+    sweep_attempted_at TIMESTAMPTZ,
+    swept_at TIMESTAMPTZ,
+
+    CONSTRAINT deposit_intents_payment_unique UNIQUE (payment_id),
+    CONSTRAINT deposit_intents_address_unique UNIQUE (deposit_address),
+    CONSTRAINT deposit_intents_index_unique UNIQUE (derivation_index),
+    CONSTRAINT valid_deposit_currency CHECK (currency IN ('ETH', 'NEXUS')),
+    CONSTRAINT valid_deposit_status CHECK (status IN ('awaiting', 'confirming', 'paid', 'underpaid', 'swept', 'expired')),
+    CONSTRAINT positive_deposit_amount CHECK (expected_amount > 0)
+);
+
+CREATE INDEX idx_deposit_intents_payer ON deposit_intents(payer_address, created_at);
+CREATE INDEX idx_deposit_intents_status ON deposit_intents(status, created_at);
+
+-- ============================================
+-- Double-Entry Ledger
+-- ============================================
+
+-- Every payment lifecycle event (sale, refund, dispute, reversal) posts a
+-- transaction whose debit and credit entries balance in each currency.
+-- Posted rows are immutable: corrections are new, reversing transactions.
+-- Amounts are base units (cents for USD, 18 decimals for ETH and NEXUS).
+CREATE TABLE IF NOT EXISTS ledger_transactions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    idempotency_key VARCHAR(200) NOT NULL,     -- One posting per payment status change
+    event_type VARCHAR(50) NOT NULL,           -- payment.sale, payment.refund, ...
+    payment_id UUID,                           -- No FK: payments are archived
+    description TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT ledger_transactions_idempotency_unique UNIQUE (idempotency_key)
+);
+
+CREATE INDEX idx_ledger_transactions_payment ON ledger_transactions(payment_id);
+
+CREATE TABLE IF NOT EXISTS ledger_entries (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    transaction_id UUID NOT NULL REFERENCES ledger_transactions(id),
+    account VARCHAR(30) NOT NULL,
+    direction VARCHAR(6) NOT NULL,
+    amount NUMERIC(78, 0) NOT NULL,
+    currency VARCHAR(10) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT valid_ledger_account CHECK (account IN ('customer_funds', 'disputed_funds', 'revenue', 'fees', 'refunds', 'provider_costs', 'provider_payable')),
+    CONSTRAINT valid_ledger_direction CHECK (direction IN ('debit', 'credit')),
+    CONSTRAINT positive_ledger_amount CHECK (amount > 0)
+);
+
+CREATE INDEX idx_ledger_entries_transaction ON ledger_entries(transaction_id);
+CREATE INDEX idx_ledger_entries_account ON ledger_entries(account, currency, created_at);
+CREATE INDEX idx_ledger_entries_created ON ledger_entries(created_at);
+
+-- Refuse changes to posted ledger rows
+CREATE OR REPLACE FUNCTION reject_ledger_change()
+RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'ledger rows are immutable: post a reversing transaction instead';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER ledger_transactions_immutable
+    BEFORE UPDATE OR DELETE ON ledger_transactions
+    FOR EACH ROW EXECUTE FUNCTION reject_ledger_change();
+
+CREATE TRIGGER ledger_entries_immutable
+    BEFORE UPDATE OR DELETE ON ledger_entries
+    FOR EACH ROW EXECUTE FUNCTION reject_ledger_change();
+
+-- ============================================
+-- Payouts
+-- ============================================
+
+-- Outbound transfers: NFT royalties to the royalty receiver and grants
+-- approved by governance. A payout is approved by someone other than its
+-- creator, sent from a relayer key once due, and reconciled against its
+-- receipt at confirmation depth. Amounts are base units (18 decimals).
+CREATE TABLE IF NOT EXISTS payouts (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    kind VARCHAR(20) NOT NULL,                 -- royalty, grant
+    recipient VARCHAR(42) NOT NULL,
+    currency VARCHAR(10) NOT NULL,             -- ETH, NEXUS
+    token_address VARCHAR(42),                 -- NEXUS: token the transfer must use
+    amount NUMERIC(78, 0) NOT NULL,
+    reference VARCHAR(100),                    -- grant: governance proposal ID
+    description TEXT NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL,
+    scheduled_for TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    created_by VARCHAR(100) NOT NULL,
+    reviewed_by VARCHAR(100),                  -- Approver or rejecter
+    reviewed_at TIMESTAMPTZ,
+    sender_address VARCHAR(42),                -- Relayer key that sent it
+    tx_hash VARCHAR(66),
+    block_number BIGINT,
+    gas_used BIGINT,
+    fee_wei NUMERIC(78, 0),
+    error_message TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    submitted_at TIMESTAMPTZ,
+    confirmed_at TIMESTAMPTZ,
+
+    CONSTRAINT payouts_tx_hash_unique UNIQUE (tx_hash),
+    CONSTRAINT valid_payout_kind CHECK (kind IN ('royalty', 'grant')),
+    CONSTRAINT valid_payout_currency CHECK (currency IN ('ETH', 'NEXUS')),
+    CONSTRAINT valid_payout_status CHECK (status IN ('pending_approval', 'approved', 'rejected', 'cancelled', 'sending', 'submitted', 'confirmed', 'failed')),
+    CONSTRAINT positive_payout_amount CHECK (amount > 0)
+);
+
+CREATE INDEX idx_payouts_status ON payouts(status, scheduled_for);
+CREATE INDEX idx_payouts_recipient ON payouts(recipient, created_at);
+
+-- ============================================
+-- Snapshot Proposals
+-- ============================================
+
+-- Off-chain proposals and results mirrored from Snapshot, keyed by their
+-- Snapshot ID. onchain_proposal_id links one to the on-chain proposal it
+-- went on to; it is kept when the proposal is synced again.
+CREATE TABLE IF NOT EXISTS snapshot_proposals (
+    id VARCHAR(100) PRIMARY KEY,               -- Snapshot proposal ID
+    space VARCHAR(100) NOT NULL,
+    title TEXT NOT NULL,
+    body TEXT NOT NULL DEFAULT '',
+    author VARCHAR(42) NOT NULL,
+    choices TEXT[] NOT NULL DEFAULT '{}',
+    scores DOUBLE PRECISION[] NOT NULL DEFAULT '{}', -- Voting power per choice
+    scores_total DOUBLE PRECISION NOT NULL DEFAULT 0,
+    votes INTEGER NOT NULL DEFAULT 0,
+    state VARCHAR(20) NOT NULL,                -- pending, active, closed
+    snapshot_block VARCHAR(30) NOT NULL DEFAULT '', -- Block voting power is read at
+    link TEXT NOT NULL DEFAULT '',
+    onchain_proposal_id VARCHAR(100),
+    start_time TIMESTAMPTZ NOT NULL,
+    end_time TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,           -- Created on Snapshot
+    synced_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT valid_snapshot_proposal_state CHECK (state IN ('pending', 'active', 'closed'))
+);
+
+CREATE INDEX idx_snapshot_proposals_space ON snapshot_proposals(space, created_at);
+CREATE INDEX idx_snapshot_proposals_onchain ON snapshot_proposals(onchain_proposal_id) WHERE onchain_proposal_id IS NOT NULL;
+
+-- ============================================
+-- Governance Notifications
+-- ============================================
+
+-- Token holders' notification preferences, one row per address. Alerts are
+-- POSTed to webhook_url signed with secret (encrypted at rest when field
+-- encryption is configured).
+CREATE TABLE IF NOT EXISTS governance_subscriptions (
+    address VARCHAR(42) PRIMARY KEY,
+    webhook_url TEXT NOT NULL,
+    secret TEXT NOT NULL,
+    events TEXT[] NOT NULL DEFAULT '{}',       -- proposal_opened, near_quorum, proposal_queued
+    digest VARCHAR(10) NOT NULL DEFAULT 'none', -- none, daily, weekly
+    last_digest_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT valid_governance_digest CHECK (digest IN ('none', 'daily', 'weekly'))
+);
+
+-- Proposal lifecycle events, recorded once per proposal and type
+CREATE TABLE IF NOT EXISTS governance_events (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    proposal_id VARCHAR(100) NOT NULL,
+    event_type VARCHAR(30) NOT NULL,
+    title TEXT NOT NULL DEFAULT '',
+    detail TEXT NOT NULL DEFAULT '',
+    occurred_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT governance_events_proposal_type_unique UNIQUE (proposal_id, event_type),
+    CONSTRAINT valid_governance_event_type CHECK (event_type IN ('proposal_opened', 'near_quorum', 'proposal_queued'))
+);
+
+CREATE INDEX idx_governance_events_occurred ON governance_events(occurred_at);
+
+-- ============================================
+-- Safe Transactions
+-- ============================================
+
+-- Safe (Gnosis Safe) multisig transactions of the treasury and admin
+-- operations, coordinated off-chain. Owners sign safe_tx_hash (the EIP-712
+-- SafeTx hash); once the threshold of owners has, execTransaction is sent
+-- from a relayer key and reconciled against its receipt.
+CREATE TABLE IF NOT EXISTS safe_transactions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    chain_id BIGINT NOT NULL,
+    safe_address VARCHAR(42) NOT NULL,
+    to_address VARCHAR(42) NOT NULL,
+    value NUMERIC(78, 0) NOT NULL DEFAULT 0,
+    data TEXT NOT NULL DEFAULT '0x',
+    operation SMALLINT NOT NULL DEFAULT 0,        -- 0 call, 1 delegatecall
+    safe_tx_gas NUMERIC(78, 0) NOT NULL DEFAULT 0,
+    base_gas NUMERIC(78, 0) NOT NULL DEFAULT 0,
+    gas_price NUMERIC(78, 0) NOT NULL DEFAULT 0,
+    gas_token VARCHAR(42) NOT NULL,
+    refund_receiver VARCHAR(42) NOT NULL,
+    nonce BIGINT NOT NULL,
+    safe_tx_hash VARCHAR(66) NOT NULL,
+    threshold INT NOT NULL,                       -- The Safe's threshold when last checked
+    confirmations INT NOT NULL DEFAULT 0,
+    status VARCHAR(30) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    proposed_by VARCHAR(100) NOT NULL,
+    executor_address VARCHAR(42),                 -- Relayer key that sent execTransaction
+    tx_hash VARCHAR(66),
+    block_number BIGINT,
+    error_message TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    submitted_at TIMESTAMPTZ,
+    executed_at TIMESTAMPTZ,
+
+    CONSTRAINT safe_transactions_safe_tx_hash_unique UNIQUE (safe_tx_hash),
+    CONSTRAINT safe_transactions_tx_hash_unique UNIQUE (tx_hash),
+    CONSTRAINT valid_safe_operation CHECK (operation IN (0, 1)),
+    CONSTRAINT valid_safe_transaction_status CHECK (status IN ('awaiting_confirmations', 'ready', 'cancelled', 'sending', 'submitted', 'executed', 'failed'))
+);
+
+CREATE INDEX idx_safe_transactions_safe ON safe_transactions(safe_address, nonce);
+CREATE INDEX idx_safe_transactions_status ON safe_transactions(status, created_at);
+
+-- Owners' signatures of a Safe transaction, in the form execTransaction
+-- takes them
+CREATE TABLE IF NOT EXISTS safe_confirmations (
+    safe_tx_id UUID NOT NULL REFERENCES safe_transactions(id) ON DELETE CASCADE,
+    owner VARCHAR(42) NOT NULL,
+    signature TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (safe_tx_id, owner)
+);
+
+-- ============================================
+-- Bridge Transfers
+-- ============================================
+
+-- NEXUS transfers across NexusBridge: the lock or burn on the source chain
+-- and the mint or unlock on the destination, paired by the source bridge's
+-- outbound nonce. Rows start as a user's intent (nonce NULL) or when the
+-- watcher first sees either leg.
+CREATE TABLE IF NOT EXISTS bridge_transfers (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    direction VARCHAR(20) NOT NULL DEFAULT '',    -- 'lock_mint', 'burn_release'; '' until a leg is seen
+    sender VARCHAR(42) NOT NULL DEFAULT '',
+    recipient VARCHAR(42) NOT NULL,
+    amount NUMERIC(78, 0) NOT NULL,
+    source_chain_id BIGINT NOT NULL,
+    dest_chain_id BIGINT NOT NULL,
+    nonce BIGINT,
+    source_status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    source_transfer_id VARCHAR(66),
+    source_tx_hash VARCHAR(66),
+    source_block BIGINT,
+    source_confirmed_at TIMESTAMPTZ,
+    dest_status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    dest_transfer_id VARCHAR(66),                 -- keccak256(recipient, amount, sourceChain, destChain, nonce)
+    dest_tx_hash VARCHAR(66),
+    dest_block BIGINT,
+    dest_unlock_at TIMESTAMPTZ,                   -- End of a queued large transfer's timelock
+    dest_completed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT bridge_transfers_nonce_unique UNIQUE (source_chain_id, nonce),
+    CONSTRAINT valid_bridge_direction CHECK (direction IN ('', 'lock_mint', 'burn_release')),
+    CONSTRAINT valid_bridge_source_status CHECK (source_status IN ('pending', 'confirmed')),
+    CONSTRAINT valid_bridge_dest_status CHECK (dest_status IN ('pending', 'queued', 'completed', 'cancelled'))
+);
+
+CREATE INDEX idx_bridge_transfers_sender ON bridge_transfers(sender, created_at DESC);
+CREATE INDEX idx_bridge_transfers_recipient ON bridge_transfers(recipient, created_at DESC);
+CREATE INDEX idx_bridge_transfers_dest_id ON bridge_transfers(dest_transfer_id);
+
+-- First block of each chain the bridge watcher has not scanned
+CREATE TABLE IF NOT EXISTS bridge_cursors (
+    chain_id BIGINT PRIMARY KEY,
+    next_block BIGINT NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- ============================================
+-- User Profiles
+-- ============================================
+
+-- Self-managed profiles keyed by wallet address, edited after signing in
+-- with Ethereum. email is encrypted at rest when field encryption is
+-- configured and is never shown publicly.
+CREATE TABLE IF NOT EXISTS user_profiles (
+    address VARCHAR(42) PRIMARY KEY,
+    display_name VARCHAR(50),
+    avatar_chain_id BIGINT,                       -- Avatar NFT: chain, contract and token ID
+    avatar_contract VARCHAR(42),
+    avatar_token_id VARCHAR(78),
+    email TEXT,
+    preferred_currency VARCHAR(10) NOT NULL DEFAULT 'USD',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT valid_preferred_currency CHECK (preferred_currency IN ('USD', 'ETH', 'NEXUS'))
+);
+
+-- Sign-In with Ethereum; auth.siwe_domain (a string) is the domain sign-in
+-- messages must be for. Sign-in is refused until it is set.
+INSERT INTO app_config (namespace, config_key, value_type, value_number, value_string, description, chain_id) VALUES
+    ('auth', 'nonce_seconds', 'number', 600, NULL, 'Seconds a sign-in nonce stays valid', 0),
+    ('auth', 'session_seconds', 'number', 86400, NULL, 'Seconds a wallet session lasts', 0)
+ON CONFLICT ON CONSTRAINT app_config_namespace_key_chain_unique DO NOTHING;
+
+-- ============================================
+-- Activity Feed
+-- ============================================
+
+-- Domain-event store behind GET /api/v1/users/:address/activity: payments,
+-- KYC, NFT transfers, votes and relayed transactions, appended as they
+-- happen. seq orders events recorded at the same instant.
+CREATE TABLE IF NOT EXISTS activity_events (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    seq BIGSERIAL NOT NULL,
+    address VARCHAR(42) NOT NULL,
+    kind VARCHAR(20) NOT NULL,                    -- 'payment', 'kyc', 'nft_transfer', 'vote', 'meta_tx'
+    action VARCHAR(30) NOT NULL,                  -- e.g. the payment's new status
+    subject_id VARCHAR(100) NOT NULL DEFAULT '',  -- payment, verification, token, proposal or meta-tx ID
+    summary TEXT NOT NULL DEFAULT '',
+    tx_hash VARCHAR(66),
+    occurred_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT valid_activity_kind CHECK (kind IN ('payment', 'kyc', 'nft_transfer', 'vote', 'meta_tx'))
+);
+
+CREATE INDEX idx_activity_events_address ON activity_events(address, occurred_at DESC);
+
+-- ============================================
+-- Scheduled Reports
+-- ============================================
+
+-- Payment, KYC and relay reports generated when cron (UTC) fires, uploaded
+-- to object storage under exports/reports/ and emailed to recipients as a
+-- download link. next_run_at = the claimed run's time guards against two
+-- instances running it.
+CREATE TABLE IF NOT EXISTS report_schedules (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(100) NOT NULL,
+    kind VARCHAR(20) NOT NULL,                  -- 'payments', 'kyc', 'relay'
+    cron VARCHAR(100) NOT NULL,
+    timezone VARCHAR(64) NOT NULL DEFAULT 'UTC', -- IANA name the cron expression is evaluated in
+    recipients TEXT[] NOT NULL DEFAULT '{}',    -- email addresses
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    created_by VARCHAR(100) NOT NULL,
+    next_run_at TIMESTAMPTZ NOT NULL,
+    last_run_at TIMESTAMPTZ,                    -- scheduled time of the last claimed run
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT valid_report_kind CHECK (kind IN ('payments', 'kyc', 'relay'))
+);
+
+CREATE INDEX idx_report_schedules_due ON report_schedules(next_run_at) WHERE enabled;
+
+-- One row per generated report; kept when the schedule is deleted
+CREATE TABLE IF NOT EXISTS report_runs (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    schedule_id UUID NOT NULL,
+    kind VARCHAR(20) NOT NULL,
+    period_start TIMESTAMPTZ NOT NULL,
+    period_end TIMESTAMPTZ NOT NULL,
+    status VARCHAR(20) NOT NULL,                -- 'succeeded', 'failed'
+    object_key TEXT NOT NULL DEFAULT '',
+    row_count BIGINT NOT NULL DEFAULT 0,
+    manual BOOLEAN NOT NULL DEFAULT FALSE,      -- started from the admin API
+    error_message TEXT,
+    started_at TIMESTAMPTZ NOT NULL,
+    finished_at TIMESTAMPTZ NOT NULL,
+
+    CONSTRAINT valid_report_run_status CHECK (status IN ('succeeded', 'failed'))
+);
+
+CREATE INDEX idx_report_runs_schedule ON report_runs(schedule_id, started_at DESC);
+
+-- ============================================
+-- Compliance Cases
+-- ============================================
+
+-- Unusual payment and relay activity flagged by the anomaly detector
+-- (failed-relay bursts, rapid-fire payments, volume spikes). At most one
+-- case per rule and subject is open at a time; closing it lets the rule
+-- flag the subject again.
+CREATE TABLE IF NOT EXISTS compliance_cases (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    rule VARCHAR(50) NOT NULL,                  -- 'relay_failures', 'rapid_payments', 'payment_volume_spike', 'relay_volume_spike'
+    subject VARCHAR(100) NOT NULL,              -- address, or the hour for volume rules
+    summary TEXT NOT NULL,
+    observed DOUBLE PRECISION NOT NULL,
+    threshold DOUBLE PRECISION NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'open', -- 'open', 'closed'
+    opened_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    closed_at TIMESTAMPTZ,
+    closed_by VARCHAR(100),
+    resolution TEXT,
+
+    CONSTRAINT valid_compliance_case_status CHECK (status IN ('open', 'closed'))
+);
+
+CREATE UNIQUE INDEX idx_compliance_cases_open ON compliance_cases(rule, subject) WHERE status = 'open';
+CREATE INDEX idx_compliance_cases_opened ON compliance_cases(opened_at DESC);
+
+-- ============================================
+-- Address Watchlists
+-- ============================================
+
+-- Sets of addresses whose payments, NFT transfers and relayed
+-- meta-transactions are POSTed to webhook_url signed with secret (encrypted
+-- at rest when field encryption is configured). Addresses are lowercased.
+CREATE TABLE IF NOT EXISTS watchlists (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(100) NOT NULL DEFAULT '',
+    webhook_url TEXT NOT NULL,
+    secret TEXT NOT NULL,
+    addresses TEXT[] NOT NULL DEFAULT '{}',
+    events TEXT[] NOT NULL DEFAULT '{}',       -- payment, nft_transfer, meta_tx
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_watchlists_addresses ON watchlists USING GIN (addresses);
+
+-- ============================================
+-- Service Fulfillments
+-- ============================================
+
+-- What each completed payment delivered, recorded once per payment by the
+-- fulfiller registered for its service: a KYC verification to start, NFT
+-- mint credits, an API subscription valid from valid_from to valid_until, or
+-- prepaid credits added to entitlements.
+CREATE TABLE IF NOT EXISTS service_fulfillments (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    payment_id UUID NOT NULL UNIQUE,              -- outlives the payment's archival
+    service_code VARCHAR(50) NOT NULL,
+    address VARCHAR(42) NOT NULL,
+    kind VARCHAR(20) NOT NULL,                    -- 'kyc', 'nft_mint_credit', 'api_subscription', 'credits'
+    quantity INTEGER NOT NULL DEFAULT 1,
+    valid_from TIMESTAMPTZ,
+    valid_until TIMESTAMPTZ,
+    reference VARCHAR(100),                       -- e.g. the KYC verification ID
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT valid_fulfillment_kind CHECK (kind IN ('kyc', 'nft_mint_credit', 'api_subscription', 'credits'))
+);
+
+CREATE INDEX idx_service_fulfillments_address ON service_fulfillments(address, created_at DESC);
+
+-- ============================================
+-- Prepaid Credits
+-- ============================================
+
+-- Each address's credits per service: credit packs (e.g. kyc_verification_10)
+-- add to granted and balance; spending takes from balance with a single
+-- conditional UPDATE, so concurrent spends cannot overdraw it.
+CREATE TABLE IF NOT EXISTS entitlements (
+    address VARCHAR(42) NOT NULL,
+    service_code VARCHAR(50) NOT NULL,            -- the service credited, e.g. 'kyc_verification'
+    granted INTEGER NOT NULL DEFAULT 0,
+    balance INTEGER NOT NULL DEFAULT 0,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (address, service_code),
+    CONSTRAINT entitlement_balance_covered CHECK (balance >= 0 AND balance <= granted)
+);
+
+-- One row per paying payment, so a redelivered fulfillment credits once
+CREATE TABLE IF NOT EXISTS entitlement_grants (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    payment_id UUID NOT NULL UNIQUE,              -- outlives the payment's archival
+    address VARCHAR(42) NOT NULL,
+    service_code VARCHAR(50) NOT NULL,
+    quantity INTEGER NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT positive_grant CHECK (quantity > 0)
+);
+
+CREATE INDEX idx_entitlement_grants_address ON entitlement_grants(address, created_at DESC);
+
+-- ============================================
+-- Partner Revenue Share
+-- ============================================
+
+-- Referral partners. Payments whose referrer_code matches an active partner
+-- earn it share_percent of their USD amount once completed; the partner
+-- reads its statement with an API key stored here as a SHA-256 hash.
+CREATE TABLE IF NOT EXISTS partners (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(100) NOT NULL,
+    referral_code VARCHAR(32) NOT NULL UNIQUE,    -- lowercased
+    share_percent DECIMAL(5, 2) NOT NULL,
+    api_key_hash VARCHAR(64) NOT NULL UNIQUE,
+    contact_email VARCHAR(255),
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT valid_share_percent CHECK (share_percent >= 0 AND share_percent <= 100)
+);
+
+-- One commission per referred payment, at the partner's share when it
+-- completed; refunds and lost disputes reverse it
+CREATE TABLE IF NOT EXISTS partner_commissions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    partner_id UUID NOT NULL REFERENCES partners(id),
+    payment_id UUID NOT NULL UNIQUE,              -- outlives the payment's archival
+    service_code VARCHAR(50) NOT NULL,
+    amount_usd DECIMAL(18, 2) NOT NULL,
+    share_percent DECIMAL(5, 2) NOT NULL,
+    commission_usd DECIMAL(18, 2) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'earned', -- 'earned', 'reversed'
+    earned_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    reversed_at TIMESTAMPTZ,
+
+    CONSTRAINT valid_commission_status CHECK (status IN ('earned', 'reversed'))
+);
+
+CREATE INDEX idx_partner_commissions_partner ON partner_commissions(partner_id, earned_at DESC);
+
+INSERT INTO app_config (namespace, config_key, value_type, value_number, description, chain_id) VALUES
+    ('partners', 'default_share_percent', 'number', 10, 'Revenue share (percent of the USD amount) of partners created without one', 0)
+ON CONFLICT ON CONSTRAINT app_config_namespace_key_chain_unique DO NOTHING;
+
 -- Grant read access to analytics user
 GRANT SELECT ON ALL TABLES IN SCHEMA public TO nexus_readonly;
 
@@ -977,6 +1919,8 @@ CREATE TABLE IF NOT EXISTS network_config (
     network_name VARCHAR(50) NOT NULL UNIQUE,     -- 'localhost', 'sepolia', 'mainnet'
     display_name VARCHAR(100) NOT NULL,           -- 'Local Development', 'Sepolia Testnet'
     rpc_url VARCHAR(255),
+    rpc_fallback_urls TEXT[] NOT NULL DEFAULT '{}',  -- Tried in order when rpc_url is unhealthy
+    ws_url VARCHAR(255),                          -- WebSocket endpoint for eth_subscribe (NULL = HTTP polling)
     explorer_url VARCHAR(255),
     default_deployer VARCHAR(42),                 -- Default deployer address for this network
     is_testnet BOOLEAN NOT NULL DEFAULT TRUE,
@@ -997,7 +1941,7 @@ CREATE TABLE IF NOT EXISTS contract_mappings (
     solidity_name VARCHAR(100) NOT NULL UNIQUE,   -- 'NexusToken', 'NexusStaking'
     db_name VARCHAR(50) NOT NULL UNIQUE,          -- 'nexusToken', 'nexusStaking'
     display_name VARCHAR(100) NOT NULL,           -- 'Nexus Token', 'Nexus Staking'
-    category VARCHAR(50) NOT NULL,                -- 'core', 'defi', 'governance', 'security', 'metatx'
+    category VARCHAR(50) NOT NULL,                -- 'core', 'defi', 'governance', 'security', 'metatx', 'bridge', 'oracle'
     description TEXT,
     is_required BOOLEAN NOT NULL DEFAULT TRUE,    -- Must be deployed for app to work
     sort_order INT NOT NULL DEFAULT 0,
@@ -1022,6 +1966,8 @@ CREATE TABLE IF NOT EXISTS contract_addresses (
     is_primary BOOLEAN NOT NULL DEFAULT TRUE,
     deployed_by VARCHAR(42),
     notes TEXT,
+    eip712_name VARCHAR(100),                     -- EIP-712 domain of contracts verifying typed signatures (e.g. the forwarder)
+    eip712_version VARCHAR(20),
     created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
     updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
     CONSTRAINT contract_addresses_status_check CHECK (status IN ('active', 'deprecated', 'paused'))
@@ -1052,6 +1998,25 @@ CREATE TABLE IF NOT EXISTS contract_addresses_history (
 CREATE INDEX idx_contract_history_contract ON contract_addresses_history(contract_id);
 CREATE INDEX idx_contract_history_changed_at ON contract_addresses_history(changed_at);
 
+-- ABI artifacts
+-- Versioned contract ABIs so clients can resolve them dynamically
+CREATE TABLE IF NOT EXISTS abi_artifacts (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    contract_mapping_id UUID NOT NULL REFERENCES contract_mappings(id),
+    version VARCHAR(20) NOT NULL,                 -- Matches contract_addresses.abi_version
+    abi JSONB NOT NULL,
+    abi_hash VARCHAR(66) NOT NULL,                -- keccak256 of the compact ABI JSON
+    compiler_version VARCHAR(50),
+    uploaded_by VARCHAR(100),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT abi_artifacts_mapping_version_unique UNIQUE (contract_mapping_id, version),
+    CONSTRAINT abi_artifacts_abi_array_check CHECK (jsonb_typeof(abi) = 'array')
+);
+
+CREATE INDEX idx_abi_artifacts_mapping ON abi_artifacts(contract_mapping_id);
+CREATE INDEX idx_abi_artifacts_created_at ON abi_artifacts(created_at);
+
 -- Triggers for contract management tables
 CREATE TRIGGER update_network_config_updated_at
     BEFORE UPDATE ON network_config
@@ -1061,6 +2026,10 @@ CREATE TRIGGER update_contract_addresses_updated_at
     BEFORE UPDATE ON contract_addresses
     FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
 
+CREATE TRIGGER update_abi_artifacts_updated_at
+    BEFORE UPDATE ON abi_artifacts
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
 -- Function to log contract address changes
 CREATE OR REPLACE FUNCTION log_contract_address_change()
 RETURNS TRIGGER AS $$
@@ -1109,6 +2078,23 @@ VALUES
     (8453, 'base', 'Base', 'https://mainnet.base.org', 'https://basescan.org', NULL, FALSE, FALSE)
 ON CONFLICT (chain_id) DO NOTHING;
 
+-- Seed data: WebSocket endpoints (Anvil serves ws on the HTTP port)
+UPDATE network_config SET ws_url = 'ws://localhost:8545' WHERE chain_id = 31337 AND ws_url IS NULL;
+
+-- Seed data: Public fallback RPC endpoints (used when rpc_url fails health checks)
+UPDATE network_config SET rpc_fallback_urls = ARRAY['https://ethereum-sepolia-rpc.publicnode.com', 'https://sepolia.drpc.org']
+WHERE chain_id = 11155111 AND rpc_fallback_urls = '{}';
+UPDATE network_config SET rpc_fallback_urls = ARRAY['https://ethereum-rpc.publicnode.com', 'https://eth.drpc.org']
+WHERE chain_id = 1 AND rpc_fallback_urls = '{}';
+UPDATE network_config SET rpc_fallback_urls = ARRAY['https://polygon-bor-rpc.publicnode.com']
+WHERE chain_id = 137 AND rpc_fallback_urls = '{}';
+UPDATE network_config SET rpc_fallback_urls = ARRAY['https://arbitrum-one-rpc.publicnode.com']
+WHERE chain_id = 42161 AND rpc_fallback_urls = '{}';
+UPDATE network_config SET rpc_fallback_urls = ARRAY['https://optimism-rpc.publicnode.com']
+WHERE chain_id = 10 AND rpc_fallback_urls = '{}';
+UPDATE network_config SET rpc_fallback_urls = ARRAY['https://base-rpc.publicnode.com']
+WHERE chain_id = 8453 AND rpc_fallback_urls = '{}';
+
 -- Seed data: Contract mappings
 INSERT INTO contract_mappings (solidity_name, db_name, display_name, category, description, is_required, sort_order)
 VALUES
@@ -1121,7 +2107,10 @@ VALUES
     ('NexusTimelock', 'nexusTimelock', 'Timelock', 'governance', 'Governance execution delay (24h minimum)', TRUE, 7),
     ('NexusGovernor', 'nexusGovernor', 'Governor', 'governance', 'DAO governance with proposal/vote system', TRUE, 8),
     ('NexusForwarder', 'nexusForwarder', 'Forwarder', 'metatx', 'ERC-2771 meta-transactions for gasless UX', FALSE, 9),
-    ('RewardsDistributor', 'rewardsDistributor', 'Rewards Distributor', 'defi', 'Merkle-based reward distribution', FALSE, 10)
+    ('RewardsDistributor', 'rewardsDistributor', 'Rewards Distributor', 'defi', 'Merkle-based reward distribution', FALSE, 10),
+    ('NexusBridge', 'nexusBridge', 'Bridge', 'bridge', 'Lock/mint and burn/release NEXUS bridge between chains', FALSE, 11),
+    ('ChainlinkEthUsd', 'ethUsdPriceFeed', 'ETH/USD Price Feed', 'oracle', 'Chainlink ETH/USD aggregator', FALSE, 12),
+    ('ChainlinkNexusUsd', 'nexusUsdPriceFeed', 'NEXUS/USD Price Feed', 'oracle', 'Chainlink NEXUS/USD aggregator', FALSE, 13)
 ON CONFLICT (solidity_name) DO NOTHING;
 
 -- ============================================